@@ -0,0 +1,91 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package algo
+
+import (
+	"math"
+	"math/bits"
+)
+
+const (
+	// hllPrecision is the number of bits of a hash picking its register. The 4096 registers of a
+	// sketch give a standard error of about 1.6%.
+	hllPrecision = 12
+	hllRegisters = 1 << hllPrecision
+	// hllSparseMax is the number of hashes kept by a sketch before it switches to its registers.
+	hllSparseMax = hllRegisters / 8
+)
+
+// HLL is a HyperLogLog sketch estimating the number of distinct hashes added to it in a fixed
+// amount of memory. It keeps the hashes, and counts them exactly, until it holds hllSparseMax of
+// them, so that the many small sketches of an aggregation stay small. The zero value is an empty
+// sketch.
+type HLL struct {
+	sparse    map[uint64]struct{}
+	registers []uint8
+}
+
+// Add adds the hash of a value to the sketch. The hashes must be uniformly distributed.
+func (h *HLL) Add(hash uint64) {
+	if h.registers != nil {
+		h.addToRegisters(hash)
+		return
+	}
+	if h.sparse == nil {
+		h.sparse = make(map[uint64]struct{})
+	}
+	h.sparse[hash] = struct{}{}
+	if len(h.sparse) <= hllSparseMax {
+		return
+	}
+	h.registers = make([]uint8, hllRegisters)
+	for hash := range h.sparse {
+		h.addToRegisters(hash)
+	}
+	h.sparse = nil
+}
+
+func (h *HLL) addToRegisters(hash uint64) {
+	idx := hash >> (64 - hllPrecision)
+	// The rank is the position of the first set bit in the rest of the hash.
+	rank := uint8(bits.LeadingZeros64(hash<<hllPrecision|1<<(hllPrecision-1))) + 1
+	if rank > h.registers[idx] {
+		h.registers[idx] = rank
+	}
+}
+
+// Count returns the estimated number of distinct hashes added to the sketch.
+func (h *HLL) Count() uint64 {
+	if h.registers == nil {
+		return uint64(len(h.sparse))
+	}
+	m := float64(hllRegisters)
+	var sum float64
+	var zeros int
+	for _, r := range h.registers {
+		sum += 1 / float64(uint64(1)<<r)
+		if r == 0 {
+			zeros++
+		}
+	}
+	estimate := 0.7213 / (1 + 1.079/m) * m * m / sum
+	if estimate <= 2.5*m && zeros > 0 {
+		// Linear counting is more accurate for the small cardinalities.
+		estimate = m * math.Log(m/float64(zeros))
+	}
+	return uint64(estimate + 0.5)
+}
@@ -0,0 +1,56 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package algo
+
+import (
+	"strconv"
+	"testing"
+
+	farm "github.com/dgryski/go-farm"
+	"github.com/stretchr/testify/require"
+)
+
+func hllOf(n, repeat int) *HLL {
+	h := &HLL{}
+	for r := 0; r < repeat; r++ {
+		for i := 0; i < n; i++ {
+			h.Add(farm.Fingerprint64([]byte(strconv.Itoa(i))))
+		}
+	}
+	return h
+}
+
+func TestHLLEmpty(t *testing.T) {
+	var h HLL
+	require.Zero(t, h.Count())
+}
+
+func TestHLLSparse(t *testing.T) {
+	// The sketches holding few hashes count them exactly.
+	require.Equal(t, uint64(100), hllOf(100, 3).Count())
+	require.Equal(t, uint64(hllSparseMax), hllOf(hllSparseMax, 2).Count())
+}
+
+func TestHLLEstimate(t *testing.T) {
+	for _, n := range []int{hllSparseMax + 1, 5000, 100000, 1000000} {
+		h := hllOf(n, 1)
+		require.NotNil(t, h.registers)
+		require.InEpsilon(t, n, h.Count(), 0.08, "n: %d", n)
+	}
+	// Adding the same values again doesn't change the estimate.
+	require.Equal(t, hllOf(100000, 1).Count(), hllOf(100000, 2).Count())
+}
@@ -26,7 +26,6 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
-	"unicode"
 
 	"github.com/dgraph-io/dgraph/ee/enc"
 	"github.com/dgraph-io/dgraph/lex"
@@ -70,6 +69,17 @@ const (
 	RdfFormat
 	// JsonFormat is a constant to denote the input to the live/bulk loader is in the JSON format.
 	JsonFormat
+	// CsvFormat is a constant to denote the input to the live/bulk loader is in the CSV format.
+	CsvFormat
+	// Neo4jFormat is a constant to denote the input to the live/bulk loader is in the CSV layout
+	// of neo4j-admin import and export.
+	Neo4jFormat
+	// GraphMLFormat is a constant to denote the input to the live/bulk loader is in the GraphML
+	// format.
+	GraphMLFormat
+	// GraphSONFormat is a constant to denote the input to the live/bulk loader is in the GraphSON
+	// adjacency list format of TinkerPop.
+	GraphSONFormat
 )
 
 // NewChunker returns a new chunker for the specified format.
@@ -84,12 +94,35 @@ func NewChunker(inputFormat InputFormat, batchSize int) Chunker {
 		return &jsonChunker{
 			nqs: NewNQuadBuffer(batchSize),
 		}
+	case CsvFormat:
+		return NewCSVChunker(&CSVMapping{}, batchSize)
+	case Neo4jFormat:
+		return &neo4jChunker{csvChunker: &csvChunker{
+			nqs:     NewNQuadBuffer(batchSize),
+			mapping: &CSVMapping{},
+		}}
+	case GraphMLFormat:
+		return &graphmlChunker{
+			nqs: NewNQuadBuffer(batchSize),
+		}
+	case GraphSONFormat:
+		return &graphsonChunker{
+			nqs: NewNQuadBuffer(batchSize),
+		}
 	default:
 		x.Panic(errors.New("unknown input format"))
 		return nil
 	}
 }
 
+// NewCSVChunker returns a new chunker for CSV files, with the given mapping of their columns.
+func NewCSVChunker(mapping *CSVMapping, batchSize int) Chunker {
+	return &csvChunker{
+		nqs:     NewNQuadBuffer(batchSize),
+		mapping: mapping,
+	}
+}
+
 // Chunk reads the input line by line until one of the following 3 conditions happens
 // 1) the EOF is reached
 // 2) 1e5 lines have been read
@@ -162,9 +195,13 @@ func (rc *rdfChunker) Parse(chunkBuf *bytes.Buffer) error {
 }
 
 // Chunk tries to consume multiple top-level maps from the reader until a size threshold is
-// reached, or the end of file is reached.
+// reached, or the end of file is reached. Only the maps of the chunk are held in memory, so a
+// file holding a single top-level list of any size is read in bounded memory.
+//
+// The reader is scanned byte by byte rather than rune by rune. That's safe for UTF-8 input, as
+// the bytes of multi-byte runes never match the ASCII characters the JSON structure is made of.
 func (jc *jsonChunker) Chunk(r *bufio.Reader) (*bytes.Buffer, error) {
-	ch, err := jc.nextRune(r)
+	ch, err := nextByte(r)
 	if err != nil {
 		return nil, err
 	}
@@ -175,7 +212,7 @@ func (jc *jsonChunker) Chunk(r *bufio.Reader) (*bytes.Buffer, error) {
 		jc.inList = true
 	case ch == '{':
 		// put the rune back for it to be consumed in the consumeMap function
-		if err := r.UnreadRune(); err != nil {
+		if err := r.UnreadByte(); err != nil {
 			return nil, err
 		}
 	default:
@@ -183,32 +220,27 @@ func (jc *jsonChunker) Chunk(r *bufio.Reader) (*bytes.Buffer, error) {
 	}
 
 	out := new(bytes.Buffer)
-	if _, err := out.WriteRune('['); err != nil {
-		return nil, err
-	}
+	out.Grow(1e5 + 4<<10)
+	x.Check(out.WriteByte('['))
 	hasMapsBefore := false
 	for out.Len() < 1e5 {
 		if hasMapsBefore {
-			if _, err := out.WriteRune(','); err != nil {
-				return nil, err
-			}
+			x.Check(out.WriteByte(','))
 		}
-		if err := jc.consumeMap(r, out); err != nil {
+		if err := consumeMap(r, out); err != nil {
 			return nil, err
 		}
 		hasMapsBefore = true
 
 		// handle the legal termination cases, by checking the next rune after the map
-		ch, err := jc.nextRune(r)
+		ch, err := nextByte(r)
 		if err == io.EOF {
 			// handles the EOF case, return the buffer which represents the top level map
 			if jc.inList {
 				return nil, errors.Errorf("JSON file ends abruptly, expecting ]")
 			}
 
-			if _, err := out.WriteRune(']'); err != nil {
-				return nil, err
-			}
+			x.Check(out.WriteByte(']'))
 			return out, io.EOF
 		} else if err != nil {
 			return nil, err
@@ -224,9 +256,7 @@ func (jc *jsonChunker) Chunk(r *bufio.Reader) (*bytes.Buffer, error) {
 				return nil, errors.New("Not all of JSON file consumed")
 			}
 
-			if _, err := out.WriteRune(']'); err != nil {
-				return nil, err
-			}
+			x.Check(out.WriteByte(']'))
 			return out, io.EOF
 		}
 
@@ -239,36 +269,29 @@ func (jc *jsonChunker) Chunk(r *bufio.Reader) (*bytes.Buffer, error) {
 			return nil, errors.Errorf("JSON map is followed by illegal rune \"%c\"", ch)
 		}
 	}
-	if _, err := out.WriteRune(']'); err != nil {
-		return nil, err
-	}
+	x.Check(out.WriteByte(']'))
 	return out, nil
 }
 
 // consumeMap consumes the next map from the reader, and stores the result into the buffer out.
 // After ignoring spaces, if the reader does not begin with {, no rune will be consumed
 // from the reader.
-func (jc *jsonChunker) consumeMap(r *bufio.Reader, out *bytes.Buffer) error {
+func consumeMap(r *bufio.Reader, out *bytes.Buffer) error {
 	// Just find the matching closing brace. Let the JSON-to-nquad parser in the mapper worry
 	// about whether everything in between is valid JSON or not.
 	depth := 0
 	for {
-		ch, err := jc.nextRune(r)
+		ch, err := nextByte(r)
 		if err != nil {
 			return errors.New("Malformed JSON")
 		}
 		if depth == 0 && ch != '{' {
 			// We encountered a beginning rune that's not {,
 			// unread the char and return without consuming anything.
-			if err := r.UnreadRune(); err != nil {
-				return err
-			}
-			return nil
+			return r.UnreadByte()
 		}
 
-		if _, err := out.WriteRune(ch); err != nil {
-			return err
-		}
+		x.Check(out.WriteByte(ch))
 		switch ch {
 		case '{':
 			depth++
@@ -282,22 +305,22 @@ func (jc *jsonChunker) consumeMap(r *bufio.Reader, out *bytes.Buffer) error {
 			// We just write the rune to out, and let the Go JSON parser do its job.
 		}
 		if depth <= 0 {
-			break
+			return nil
 		}
 	}
-	return nil
 }
 
-// nextRune ignores any number of spaces that may precede a rune
-func (*jsonChunker) nextRune(r *bufio.Reader) (rune, error) {
-	if err := slurpSpace(r); err != nil {
-		return ' ', err
-	}
-	ch, _, err := r.ReadRune()
-	if err != nil {
-		return ' ', err
+// nextByte ignores any number of spaces that may precede a byte
+func nextByte(r *bufio.Reader) (byte, error) {
+	for {
+		ch, err := r.ReadByte()
+		if err != nil {
+			return ' ', err
+		}
+		if !isSpace(ch) {
+			return ch, nil
+		}
 	}
-	return ch, nil
 }
 
 func (jc *jsonChunker) Parse(chunkBuf *bytes.Buffer) error {
@@ -308,41 +331,41 @@ func (jc *jsonChunker) Parse(chunkBuf *bytes.Buffer) error {
 	return jc.nqs.ParseJSON(chunkBuf.Bytes(), SetNquads)
 }
 
+func isSpace(ch byte) bool {
+	switch ch {
+	case ' ', '\t', '\n', '\r', '\v', '\f':
+		return true
+	}
+	return false
+}
+
 func slurpSpace(r *bufio.Reader) error {
-	for {
-		ch, _, err := r.ReadRune()
-		if err != nil {
-			return err
-		}
-		if !unicode.IsSpace(ch) {
-			x.Check(r.UnreadRune())
-			return nil
-		}
+	if _, err := nextByte(r); err != nil {
+		return err
 	}
+	return r.UnreadByte()
 }
 
+// slurpQuoted copies the rest of a quoted string, up to the closing quote, from the reader to
+// out.
 func slurpQuoted(r *bufio.Reader, out *bytes.Buffer) error {
 	for {
-		ch, _, err := r.ReadRune()
-		if err != nil {
-			return err
+		slc, err := r.ReadSlice('"')
+		out.Write(slc)
+		if err == bufio.ErrBufferFull {
+			continue
 		}
-		if _, err := out.WriteRune(ch); err != nil {
+		if err != nil {
 			return err
 		}
-
-		if ch == '\\' {
-			// Pick one more rune.
-			esc, _, err := r.ReadRune()
-			if err != nil {
-				return err
-			}
-			if _, err := out.WriteRune(esc); err != nil {
-				return err
-			}
-			continue
+		// The quote is escaped if it follows an odd number of backslashes. The opening quote is
+		// already in out, so the count stops there.
+		b := out.Bytes()
+		backslashes := 0
+		for i := len(b) - 2; b[i] == '\\'; i-- {
+			backslashes++
 		}
-		if ch == '"' {
+		if backslashes%2 == 0 {
 			return nil
 		}
 	}
@@ -408,16 +431,26 @@ func IsJSONData(r *bufio.Reader) (bool, error) {
 	return err == nil, nil
 }
 
-// DataFormat returns a file's data format (RDF, JSON, or unknown) based on the filename
-// or the user-provided format option. The file extension has precedence.
+// DataFormat returns a file's data format (RDF, JSON, CSV, Neo4j, GraphML, GraphSON or unknown)
+// based on the filename or the user-provided format option. The file extension has precedence,
+// except for Neo4j files which are CSV files.
 func DataFormat(filename string, format string) InputFormat {
 	format = strings.ToLower(format)
 	filename = strings.TrimSuffix(strings.ToLower(filename), ".gz")
 	switch {
+	case format == "neo4j" && !strings.HasSuffix(filename, ".rdf") &&
+		!strings.HasSuffix(filename, ".json"):
+		return Neo4jFormat
 	case strings.HasSuffix(filename, ".rdf") || format == "rdf":
 		return RdfFormat
 	case strings.HasSuffix(filename, ".json") || format == "json":
 		return JsonFormat
+	case strings.HasSuffix(filename, ".csv") || format == "csv":
+		return CsvFormat
+	case strings.HasSuffix(filename, ".graphml") || format == "graphml":
+		return GraphMLFormat
+	case strings.HasSuffix(filename, ".graphson") || format == "graphson":
+		return GraphSONFormat
 	default:
 		return UnknownFormat
 	}
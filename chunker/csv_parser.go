@@ -0,0 +1,297 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chunker
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/dgraph-io/dgo/v210/protos/api"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/types"
+	"github.com/pkg/errors"
+)
+
+// CSVMapping maps the columns of CSV files to predicates. The first row of a CSV file holds the
+// names of its columns, and each of the other rows is a node.
+type CSVMapping struct {
+	// Subject is the column holding the xids of the nodes. Defaults to the first column.
+	Subject string `json:"subject"`
+	// Prefix is prepended to the xids of the nodes, to tell apart the nodes of different files
+	// using the same ids.
+	Prefix string `json:"prefix"`
+	// Type is set as the dgraph.type of the nodes, if not empty.
+	Type string `json:"type"`
+	// Delimiter is the field delimiter. Defaults to a comma.
+	Delimiter string `json:"delimiter"`
+	// Columns maps the columns to predicates. If empty, all the columns but the subject are
+	// loaded into the predicates named after them.
+	Columns map[string]*CSVColumn `json:"columns"`
+}
+
+// CSVColumn is the mapping of a column of CSV files.
+type CSVColumn struct {
+	// Predicate is the predicate of the values. Defaults to the name of the column.
+	Predicate string `json:"predicate"`
+	// Type is the scalar type of the values, e.g. int, float, bool, datetime or geo. The values
+	// are converted to the type of the predicate in the schema if not set.
+	Type string `json:"type"`
+	// Lang is the language tag of the values.
+	Lang string `json:"lang"`
+	// Ref is set if the values are the xids of other nodes, in which case RefPrefix is prepended
+	// to them.
+	Ref       bool   `json:"ref"`
+	RefPrefix string `json:"refPrefix"`
+	// Separator splits the values in a cell, if not empty.
+	Separator string `json:"separator"`
+
+	typ types.TypeID
+}
+
+// ReadCSVMapping reads the mapping of the columns of CSV files from a JSON file.
+func ReadCSVMapping(file string) (*CSVMapping, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while reading CSV mapping %s", file)
+	}
+	var m CSVMapping
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, errors.Wrapf(err, "while parsing CSV mapping %s", file)
+	}
+	if err := m.validate(); err != nil {
+		return nil, errors.Wrapf(err, "invalid CSV mapping %s", file)
+	}
+	return &m, nil
+}
+
+func (m *CSVMapping) validate() error {
+	if utf8.RuneCountInString(m.Delimiter) > 1 {
+		return errors.Errorf("delimiter %q must be a single character", m.Delimiter)
+	}
+	for name, col := range m.Columns {
+		if col == nil {
+			return errors.Errorf("column %q has no mapping", name)
+		}
+		if col.Predicate == "" {
+			col.Predicate = name
+		}
+		if col.Type == "" {
+			continue
+		}
+		if col.Ref {
+			return errors.Errorf("column %q can't have both a type and ref set", name)
+		}
+		typ, ok := types.TypeForName(col.Type)
+		if !ok {
+			return errors.Errorf("column %q has an unknown type %q", name, col.Type)
+		}
+		col.typ = typ
+	}
+	return nil
+}
+
+func (m *CSVMapping) comma() rune {
+	if m.Delimiter == "" {
+		return ','
+	}
+	r, _ := utf8.DecodeRuneInString(m.Delimiter)
+	return r
+}
+
+// columns returns the mapping of each column in the header, nil for the columns not loaded, and
+// the index of the subject column.
+func (m *CSVMapping) columns(header []string) ([]*CSVColumn, int, error) {
+	subject := -1
+	cols := make([]*CSVColumn, len(header))
+	for i, name := range header {
+		switch {
+		case name == m.Subject || (m.Subject == "" && i == 0):
+			subject = i
+		case len(m.Columns) == 0:
+			cols[i] = &CSVColumn{Predicate: name}
+		default:
+			cols[i] = m.Columns[name]
+		}
+	}
+	if subject < 0 {
+		return nil, 0, errors.Errorf("subject column %q not found in the CSV header", m.Subject)
+	}
+	for name := range m.Columns {
+		if !contains(header, name) {
+			return nil, 0, errors.Errorf("column %q not found in the CSV header", name)
+		}
+	}
+	return cols, subject, nil
+}
+
+func contains(list []string, s string) bool {
+	for _, e := range list {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
+
+type csvChunker struct {
+	nqs     *NQuadBuffer
+	mapping *CSVMapping
+	// header is the first row of the file, which starts every chunk.
+	header []string
+}
+
+func (cc *csvChunker) NQuads() *NQuadBuffer {
+	return cc.nqs
+}
+
+// Chunk reads up to 1e4 rows from the reader. The header of the file is written at the start of
+// each chunk, so that the chunks can be parsed independently.
+func (cc *csvChunker) Chunk(r *bufio.Reader) (*bytes.Buffer, error) {
+	// The CSV reader reads from r directly, as r is already buffered. So, it doesn't read past
+	// the rows it returns.
+	cr := csv.NewReader(r)
+	cr.Comma = cc.mapping.comma()
+	if cc.header == nil {
+		header, err := cr.Read()
+		if err != nil {
+			return nil, err
+		}
+		cc.header = append([]string{}, header...)
+	}
+	cr.FieldsPerRecord = len(cc.header)
+	cr.ReuseRecord = true
+
+	out := new(bytes.Buffer)
+	w := csv.NewWriter(out)
+	w.Comma = cr.Comma
+	if err := w.Write(cc.header); err != nil {
+		return nil, err
+	}
+	for rows := 0; rows < 1e4; rows++ {
+		row, err := cr.Read()
+		if err == io.EOF {
+			w.Flush()
+			return out, io.EOF
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err := w.Write(row); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	return out, w.Error()
+}
+
+// Parse converts each row of the chunk into the N-Quads of a node, one per value.
+func (cc *csvChunker) Parse(chunkBuf *bytes.Buffer) error {
+	if chunkBuf == nil || chunkBuf.Len() == 0 {
+		return nil
+	}
+
+	cr := csv.NewReader(chunkBuf)
+	cr.Comma = cc.mapping.comma()
+	header, err := cr.Read()
+	if err != nil {
+		return errors.Wrapf(err, "while reading the CSV header")
+	}
+	cr.FieldsPerRecord = len(header)
+	cols, subject, err := cc.mapping.columns(header)
+	if err != nil {
+		return err
+	}
+	for _, col := range cols {
+		if col != nil && col.Separator != "" {
+			cc.nqs.PushPredHint(col.Predicate, pb.Metadata_LIST)
+		}
+	}
+
+	for {
+		row, err := cr.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := cc.parseRow(row, cols, subject); err != nil {
+			return errors.Wrapf(err, "while parsing row %q", row)
+		}
+	}
+}
+
+func (cc *csvChunker) parseRow(row []string, cols []*CSVColumn, subject int) error {
+	if row[subject] == "" {
+		return errors.New("subject is empty")
+	}
+	sub := "_:" + cc.mapping.Prefix + row[subject]
+	var nqs []*api.NQuad
+	if cc.mapping.Type != "" {
+		nqs = append(nqs, &api.NQuad{
+			Subject:     sub,
+			Predicate:   "dgraph.type",
+			ObjectValue: &api.Value{Val: &api.Value_DefaultVal{DefaultVal: cc.mapping.Type}},
+		})
+	}
+	for i, col := range cols {
+		if col == nil || row[i] == "" {
+			continue
+		}
+		vals := []string{row[i]}
+		if col.Separator != "" {
+			vals = strings.Split(row[i], col.Separator)
+		}
+		for _, val := range vals {
+			nq := &api.NQuad{Subject: sub, Predicate: col.Predicate, Lang: col.Lang}
+			if col.Ref {
+				nq.ObjectId = "_:" + col.RefPrefix + val
+			} else {
+				ov, err := col.value(val)
+				if err != nil {
+					return errors.Wrapf(err, "in column %q", col.Predicate)
+				}
+				nq.ObjectValue = ov
+			}
+			nqs = append(nqs, nq)
+		}
+	}
+	cc.nqs.Push(nqs...)
+	return nil
+}
+
+func (col *CSVColumn) value(val string) (*api.Value, error) {
+	if col.Type == "" {
+		return &api.Value{Val: &api.Value_DefaultVal{DefaultVal: val}}, nil
+	}
+	src := types.ValueForType(types.StringID)
+	src.Value = []byte(val)
+	if col.typ == types.PasswordID {
+		src.Tid = col.typ
+	}
+	dst, err := types.Convert(src, col.typ)
+	if err != nil {
+		return nil, err
+	}
+	return types.ObjectValue(col.typ, dst.Value)
+}
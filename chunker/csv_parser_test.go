@@ -0,0 +1,95 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chunker
+
+import (
+	"io"
+	"testing"
+
+	"github.com/dgraph-io/dgo/v210/protos/api"
+	"github.com/stretchr/testify/require"
+)
+
+func parseCSV(t *testing.T, m *CSVMapping, data string) []*api.NQuad {
+	ck := NewCSVChunker(m, 1000)
+	r := bufioReader(data)
+	go func() {
+		for {
+			buf, err := ck.Chunk(r)
+			if buf != nil {
+				require.NoError(t, ck.Parse(buf))
+			}
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+		}
+		ck.NQuads().Flush()
+	}()
+
+	var nqs []*api.NQuad
+	for batch := range ck.NQuads().Ch() {
+		nqs = append(nqs, batch...)
+	}
+	return nqs
+}
+
+func TestCSVDefaultMapping(t *testing.T) {
+	nqs := parseCSV(t, &CSVMapping{}, "id,name,age\n1,alice,26\n2,\"bob, jr\",\n")
+	require.Equal(t, []*api.NQuad{
+		{Subject: "_:1", Predicate: "name",
+			ObjectValue: &api.Value{Val: &api.Value_DefaultVal{DefaultVal: "alice"}}},
+		{Subject: "_:1", Predicate: "age",
+			ObjectValue: &api.Value{Val: &api.Value_DefaultVal{DefaultVal: "26"}}},
+		{Subject: "_:2", Predicate: "name",
+			ObjectValue: &api.Value{Val: &api.Value_DefaultVal{DefaultVal: "bob, jr"}}},
+	}, nqs)
+}
+
+func TestCSVMapping(t *testing.T) {
+	m := &CSVMapping{
+		Subject:   "id",
+		Prefix:    "person.",
+		Type:      "Person",
+		Delimiter: ";",
+		Columns: map[string]*CSVColumn{
+			"age":     {Type: "int"},
+			"friends": {Predicate: "friend", Ref: true, RefPrefix: "person.", Separator: "|"},
+		},
+	}
+	require.NoError(t, m.validate())
+	nqs := parseCSV(t, m, "name;id;age;friends\nalice;1;26;2|3\n")
+	require.Equal(t, []*api.NQuad{
+		{Subject: "_:person.1", Predicate: "dgraph.type",
+			ObjectValue: &api.Value{Val: &api.Value_DefaultVal{DefaultVal: "Person"}}},
+		{Subject: "_:person.1", Predicate: "age",
+			ObjectValue: &api.Value{Val: &api.Value_IntVal{IntVal: 26}}},
+		{Subject: "_:person.1", Predicate: "friend", ObjectId: "_:person.2"},
+		{Subject: "_:person.1", Predicate: "friend", ObjectId: "_:person.3"},
+	}, nqs)
+}
+
+func TestCSVMappingErrors(t *testing.T) {
+	m := &CSVMapping{Columns: map[string]*CSVColumn{"age": {Type: "integer"}}}
+	require.Error(t, m.validate())
+
+	m = &CSVMapping{Subject: "id"}
+	ck := NewCSVChunker(m, 1000)
+	buf, err := ck.Chunk(bufioReader("name,age\nalice,26\n"))
+	require.Equal(t, io.EOF, err)
+	require.Error(t, ck.Parse(buf))
+}
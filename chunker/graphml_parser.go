@@ -0,0 +1,301 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chunker
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/dgraph-io/dgo/v210/protos/api"
+	"github.com/dgraph-io/dgraph/types"
+	"github.com/dgraph-io/dgraph/types/facets"
+	"github.com/pkg/errors"
+)
+
+// GraphMLEdgeLabel is the name of the GraphML edge attribute holding the predicate of the edges.
+// Edges without it are loaded into the predicate named GraphMLDefaultEdge.
+const (
+	GraphMLEdgeLabel   = "label"
+	GraphMLDefaultEdge = "edge"
+)
+
+type graphmlDoc struct {
+	XMLName xml.Name      `xml:"graphml"`
+	Keys    []*graphmlKey `xml:"key"`
+	Graph   graphmlGraph  `xml:"graph"`
+}
+
+type graphmlKey struct {
+	ID   string `xml:"id,attr"`
+	For  string `xml:"for,attr,omitempty"`
+	Name string `xml:"attr.name,attr,omitempty"`
+	Type string `xml:"attr.type,attr,omitempty"`
+}
+
+type graphmlGraph struct {
+	EdgeDefault string         `xml:"edgedefault,attr,omitempty"`
+	Nodes       []*graphmlNode `xml:"node"`
+	Edges       []*graphmlEdge `xml:"edge"`
+}
+
+type graphmlNode struct {
+	ID   string         `xml:"id,attr"`
+	Data []*graphmlData `xml:"data"`
+}
+
+type graphmlEdge struct {
+	Source   string         `xml:"source,attr"`
+	Target   string         `xml:"target,attr"`
+	Directed string         `xml:"directed,attr,omitempty"`
+	Data     []*graphmlData `xml:"data"`
+}
+
+type graphmlData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// graphmlTypes maps the attr.type of GraphML keys to Dgraph types.
+var graphmlTypes = map[string]types.TypeID{
+	"boolean": types.BoolID,
+	"int":     types.IntID,
+	"long":    types.IntID,
+	"float":   types.FloatID,
+	"double":  types.FloatID,
+	"string":  types.DefaultID,
+}
+
+// graphmlChunker parses GraphML files. Each node is loaded with its data as predicates, and each
+// edge is loaded into the predicate in its label data, with its other data as facets. The keys
+// of the file are written at the start of each chunk, so that the chunks can be parsed
+// independently. Nested graphs, hyperedges and the default values of keys are not supported.
+type graphmlChunker struct {
+	nqs *NQuadBuffer
+	// dec decodes the file being chunked, across the calls to Chunk.
+	dec *xml.Decoder
+	// header holds the keys and the edge default of the file, which start every chunk.
+	header *graphmlDoc
+}
+
+func (gc *graphmlChunker) NQuads() *NQuadBuffer {
+	return gc.nqs
+}
+
+// Chunk reads up to 1e4 nodes and edges from the reader.
+func (gc *graphmlChunker) Chunk(r *bufio.Reader) (*bytes.Buffer, error) {
+	if gc.dec == nil {
+		// The decoder reads r byte by byte, as r is an io.ByteReader. So, it doesn't read past
+		// the elements it returns.
+		gc.dec = xml.NewDecoder(r)
+		if err := gc.readHeader(); err != nil {
+			return nil, err
+		}
+	}
+
+	doc := &graphmlDoc{Keys: gc.header.Keys}
+	doc.Graph.EdgeDefault = gc.header.Graph.EdgeDefault
+	var err error
+	for n := 0; n < 1e4 && err == nil; {
+		var tok xml.Token
+		if tok, err = gc.dec.Token(); err != nil {
+			break
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "node":
+				node := &graphmlNode{}
+				err = gc.dec.DecodeElement(node, &t)
+				doc.Graph.Nodes = append(doc.Graph.Nodes, node)
+			case "edge":
+				edge := &graphmlEdge{}
+				err = gc.dec.DecodeElement(edge, &t)
+				doc.Graph.Edges = append(doc.Graph.Edges, edge)
+			default:
+				err = gc.dec.Skip()
+			}
+			n++
+		case xml.EndElement:
+			if t.Name.Local == "graph" {
+				err = io.EOF
+			}
+		}
+	}
+	if err != nil && err != io.EOF {
+		return nil, errors.Wrapf(err, "while reading GraphML")
+	}
+	out := new(bytes.Buffer)
+	if len(doc.Graph.Nodes) > 0 || len(doc.Graph.Edges) > 0 {
+		if err := xml.NewEncoder(out).Encode(doc); err != nil {
+			return nil, err
+		}
+	}
+	return out, err
+}
+
+// readHeader reads the keys of the file, up to the start of its graph.
+func (gc *graphmlChunker) readHeader() error {
+	gc.header = &graphmlDoc{}
+	for {
+		tok, err := gc.dec.Token()
+		if err == io.EOF {
+			return errors.New("GraphML file has no graph")
+		}
+		if err != nil {
+			return errors.Wrapf(err, "while reading GraphML")
+		}
+		t, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch t.Name.Local {
+		case "key":
+			key := &graphmlKey{}
+			if err := gc.dec.DecodeElement(key, &t); err != nil {
+				return errors.Wrapf(err, "while reading GraphML key")
+			}
+			gc.header.Keys = append(gc.header.Keys, key)
+		case "graph":
+			for _, attr := range t.Attr {
+				if attr.Name.Local == "edgedefault" {
+					gc.header.Graph.EdgeDefault = attr.Value
+				}
+			}
+			return nil
+		}
+	}
+}
+
+// Parse converts the nodes and edges of the chunk into N-Quads.
+func (gc *graphmlChunker) Parse(chunkBuf *bytes.Buffer) error {
+	if chunkBuf == nil || chunkBuf.Len() == 0 {
+		return nil
+	}
+
+	var doc graphmlDoc
+	if err := xml.NewDecoder(chunkBuf).Decode(&doc); err != nil {
+		return errors.Wrapf(err, "while parsing GraphML")
+	}
+	keys := make(map[string]*graphmlKey, len(doc.Keys))
+	for _, key := range doc.Keys {
+		if key.Name == "" {
+			key.Name = key.ID
+		}
+		keys[key.ID] = key
+	}
+
+	for _, node := range doc.Graph.Nodes {
+		if err := gc.parseNode(node, keys); err != nil {
+			return errors.Wrapf(err, "while parsing node %q", node.ID)
+		}
+	}
+	undirected := doc.Graph.EdgeDefault == "undirected"
+	for _, edge := range doc.Graph.Edges {
+		if err := gc.parseEdge(edge, keys, undirected); err != nil {
+			return errors.Wrapf(err, "while parsing edge from %q to %q", edge.Source, edge.Target)
+		}
+	}
+	return nil
+}
+
+func (gc *graphmlChunker) parseNode(node *graphmlNode, keys map[string]*graphmlKey) error {
+	if node.ID == "" {
+		return errors.New("node has no id")
+	}
+	var nqs []*api.NQuad
+	for _, data := range node.Data {
+		key, ok := keys[data.Key]
+		if !ok {
+			return errors.Errorf("key %q is not declared", data.Key)
+		}
+		typ, ok := graphmlTypes[key.Type]
+		if !ok {
+			typ = types.DefaultID
+		}
+		ov, err := graphmlValue(typ, data.Value)
+		if err != nil {
+			return errors.Wrapf(err, "in data %q", key.Name)
+		}
+		nq := &api.NQuad{Subject: "_:" + node.ID, Predicate: key.Name, ObjectValue: ov}
+		// Values with language tags are exported with their language in the attribute name.
+		if idx := strings.LastIndex(key.Name, "@"); idx > 0 {
+			nq.Predicate, nq.Lang = key.Name[:idx], key.Name[idx+1:]
+		}
+		nqs = append(nqs, nq)
+	}
+	gc.nqs.Push(nqs...)
+	return nil
+}
+
+func (gc *graphmlChunker) parseEdge(edge *graphmlEdge, keys map[string]*graphmlKey,
+	undirected bool) error {
+	if edge.Source == "" || edge.Target == "" {
+		return errors.New("edge has no source or target")
+	}
+	nq := &api.NQuad{
+		Subject:   "_:" + edge.Source,
+		Predicate: GraphMLDefaultEdge,
+		ObjectId:  "_:" + edge.Target,
+	}
+	for _, data := range edge.Data {
+		key, ok := keys[data.Key]
+		if !ok {
+			return errors.Errorf("key %q is not declared", data.Key)
+		}
+		if key.Name == GraphMLEdgeLabel {
+			nq.Predicate = data.Value
+			continue
+		}
+		val := data.Value
+		if typ, ok := graphmlTypes[key.Type]; !ok || typ == types.DefaultID {
+			val = strconv.Quote(val)
+		}
+		f, err := facets.FacetFor(key.Name, val)
+		if err != nil {
+			return errors.Wrapf(err, "in data %q", key.Name)
+		}
+		nq.Facets = append(nq.Facets, f)
+	}
+	if err := facets.SortAndValidate(nq.Facets); err != nil {
+		return err
+	}
+	nqs := []*api.NQuad{nq}
+	if edge.Directed == "false" || (undirected && edge.Directed != "true") {
+		rev := *nq
+		rev.Subject, rev.ObjectId = nq.ObjectId, nq.Subject
+		nqs = append(nqs, &rev)
+	}
+	gc.nqs.Push(nqs...)
+	return nil
+}
+
+func graphmlValue(typ types.TypeID, val string) (*api.Value, error) {
+	if typ == types.DefaultID {
+		return &api.Value{Val: &api.Value_DefaultVal{DefaultVal: val}}, nil
+	}
+	src := types.ValueForType(types.StringID)
+	src.Value = []byte(strings.TrimSpace(val))
+	dst, err := types.Convert(src, typ)
+	if err != nil {
+		return nil, err
+	}
+	return types.ObjectValue(typ, dst.Value)
+}
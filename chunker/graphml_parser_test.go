@@ -0,0 +1,118 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chunker
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/dgraph-io/dgo/v210/protos/api"
+	"github.com/stretchr/testify/require"
+)
+
+// chunkAndParse chunks and parses the data with a chunker of the given format, and returns the
+// N-Quads along with the number of chunks.
+func chunkAndParse(t *testing.T, format InputFormat, data string) ([]*api.NQuad, int) {
+	ck := NewChunker(format, -1)
+	r := bufioReader(data)
+	var chunks int
+	for {
+		buf, err := ck.Chunk(r)
+		if buf != nil && buf.Len() > 0 {
+			require.NoError(t, ck.Parse(buf))
+			chunks++
+		}
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+	}
+	return ck.NQuads().nquads, chunks
+}
+
+const graphmlSample = `<?xml version="1.0" encoding="UTF-8"?>
+<graphml xmlns="http://graphml.graphdrawing.org/xmlns">
+  <key id="d0" for="node" attr.name="name" attr.type="string"/>
+  <key id="d1" for="node" attr.name="age" attr.type="int"/>
+  <key id="d2" for="edge" attr.name="label" attr.type="string"/>
+  <key id="d3" for="edge" attr.name="weight" attr.type="double"/>
+  <key id="d4" for="node" attr.name="name@fr" attr.type="string"/>
+  <graph id="G" edgedefault="directed">
+    <node id="n0">
+      <data key="d0">alice &amp; co</data>
+      <data key="d1">26</data>
+      <data key="d4">alice</data>
+    </node>
+    <node id="n1"/>
+    <edge source="n0" target="n1">
+      <data key="d2">knows</data>
+      <data key="d3">0.5</data>
+    </edge>
+    <edge source="n1" target="n0" directed="false"/>
+  </graph>
+</graphml>
+`
+
+func TestGraphML(t *testing.T) {
+	nqs, _ := chunkAndParse(t, GraphMLFormat, graphmlSample)
+	require.Len(t, nqs, 6)
+	require.Equal(t, &api.NQuad{Subject: "_:n0", Predicate: "name",
+		ObjectValue: &api.Value{Val: &api.Value_DefaultVal{DefaultVal: "alice & co"}}}, nqs[0])
+	require.Equal(t, &api.NQuad{Subject: "_:n0", Predicate: "age",
+		ObjectValue: &api.Value{Val: &api.Value_IntVal{IntVal: 26}}}, nqs[1])
+	require.Equal(t, &api.NQuad{Subject: "_:n0", Predicate: "name", Lang: "fr",
+		ObjectValue: &api.Value{Val: &api.Value_DefaultVal{DefaultVal: "alice"}}}, nqs[2])
+
+	require.Equal(t, "knows", nqs[3].Predicate)
+	require.Equal(t, "_:n1", nqs[3].ObjectId)
+	require.Len(t, nqs[3].Facets, 1)
+	require.Equal(t, "weight", nqs[3].Facets[0].Key)
+	require.Equal(t, api.Facet_FLOAT, nqs[3].Facets[0].ValType)
+
+	// Undirected edges are loaded in both directions.
+	require.Equal(t, &api.NQuad{Subject: "_:n1", Predicate: GraphMLDefaultEdge,
+		ObjectId: "_:n0"}, nqs[4])
+	require.Equal(t, &api.NQuad{Subject: "_:n0", Predicate: GraphMLDefaultEdge,
+		ObjectId: "_:n1"}, nqs[5])
+}
+
+func TestGraphMLChunks(t *testing.T) {
+	var sb strings.Builder
+	sb.WriteString(`<graphml><key id="k" for="node" attr.name="name"/><graph>`)
+	for i := 0; i < 25000; i++ {
+		fmt.Fprintf(&sb, `<node id="n%d"><data key="k">node %d</data></node>`, i, i)
+	}
+	sb.WriteString(`</graph></graphml>`)
+	nqs, chunks := chunkAndParse(t, GraphMLFormat, sb.String())
+	require.Equal(t, 3, chunks)
+	require.Len(t, nqs, 25000)
+	require.Equal(t, "_:n24999", nqs[24999].Subject)
+}
+
+func TestGraphMLErrors(t *testing.T) {
+	ck := NewChunker(GraphMLFormat, -1)
+	_, err := ck.Chunk(bufioReader(`<graphml></graphml>`))
+	require.Error(t, err)
+
+	ck = NewChunker(GraphMLFormat, -1)
+	buf, err := ck.Chunk(bufioReader(`<graphml><graph><node id="a"><data key="x">1</data></node>` +
+		`</graph></graphml>`))
+	require.Equal(t, io.EOF, err)
+	require.Error(t, ck.Parse(buf))
+}
@@ -0,0 +1,219 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chunker
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/dgraph-io/dgo/v210/protos/api"
+	"github.com/dgraph-io/dgraph/types/facets"
+	"github.com/pkg/errors"
+)
+
+// GraphSONDefaultLabel is the label of the vertices of TinkerPop without any label, which isn't
+// loaded as a dgraph.type.
+const GraphSONDefaultLabel = "vertex"
+
+// graphsonVertex is a vertex of the GraphSON adjacency list format of TinkerPop, in which each line
+// holds a vertex along with its properties and edges. The in edges are not loaded, as they are
+// the out edges of other vertices.
+type graphsonVertex struct {
+	ID         interface{}                `json:"id"`
+	Label      string                     `json:"label"`
+	Properties map[string][]interface{}   `json:"properties"`
+	OutE       map[string][]*graphsonEdge `json:"outE"`
+}
+
+type graphsonEdge struct {
+	InV        interface{}            `json:"inV"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+type graphsonChunker struct {
+	nqs *NQuadBuffer
+}
+
+func (gc *graphsonChunker) NQuads() *NQuadBuffer {
+	return gc.nqs
+}
+
+// Chunk reads up to 1e3 lines from the reader, each holding a vertex.
+func (*graphsonChunker) Chunk(r *bufio.Reader) (*bytes.Buffer, error) {
+	batch := new(bytes.Buffer)
+	for lines := 0; lines < 1e3; lines++ {
+		line, err := r.ReadBytes('\n')
+		batch.Write(line)
+		if err != nil {
+			return batch, err
+		}
+	}
+	return batch, nil
+}
+
+// Parse converts each vertex of the chunk into N-Quads. The labels of the vertices are set as
+// their dgraph.type, and the properties of the edges as their facets. Both the typed and the
+// untyped flavours of GraphSON are supported.
+func (gc *graphsonChunker) Parse(chunkBuf *bytes.Buffer) error {
+	if chunkBuf == nil || chunkBuf.Len() == 0 {
+		return nil
+	}
+
+	for chunkBuf.Len() > 0 {
+		line, err := chunkBuf.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return err
+		}
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		if err := gc.parseVertex(line); err != nil {
+			return errors.Wrapf(err, "while parsing vertex %q", line)
+		}
+	}
+	return nil
+}
+
+func (gc *graphsonChunker) parseVertex(line []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(line))
+	dec.UseNumber()
+	var v graphsonVertex
+	if err := dec.Decode(&v); err != nil {
+		return err
+	}
+	if v.ID == nil {
+		return errors.New("vertex has no id")
+	}
+	sub := "_:" + graphsonString(v.ID)
+
+	var nqs []*api.NQuad
+	if v.Label != "" && v.Label != GraphSONDefaultLabel {
+		nqs = append(nqs, &api.NQuad{
+			Subject:     sub,
+			Predicate:   "dgraph.type",
+			ObjectValue: &api.Value{Val: &api.Value_DefaultVal{DefaultVal: v.Label}},
+		})
+	}
+	for name, props := range v.Properties {
+		for _, prop := range props {
+			// The vertex properties are maps holding their value, along with their id.
+			m, ok := graphsonUnwrap(prop).(map[string]interface{})
+			if !ok {
+				return errors.Errorf("property %q is not a map", name)
+			}
+			nq := &api.NQuad{Subject: sub, Predicate: name, ObjectValue: graphsonValue(m["value"])}
+			// Values with language tags are exported with their language in the property name.
+			if idx := strings.LastIndex(name, "@"); idx > 0 {
+				nq.Predicate, nq.Lang = name[:idx], name[idx+1:]
+			}
+			nqs = append(nqs, nq)
+		}
+	}
+	for label, edges := range v.OutE {
+		for _, edge := range edges {
+			if edge == nil || edge.InV == nil {
+				return errors.Errorf("%q edge has no inV", label)
+			}
+			nq := &api.NQuad{
+				Subject:   sub,
+				Predicate: label,
+				ObjectId:  "_:" + graphsonString(edge.InV),
+			}
+			for key, val := range edge.Properties {
+				f, err := graphsonFacet(key, val)
+				if err != nil {
+					return errors.Wrapf(err, "in %q edge", label)
+				}
+				nq.Facets = append(nq.Facets, f)
+			}
+			if err := facets.SortAndValidate(nq.Facets); err != nil {
+				return err
+			}
+			nqs = append(nqs, nq)
+		}
+	}
+	gc.nqs.Push(nqs...)
+	return nil
+}
+
+// graphsonUnwrap returns the value of the typed GraphSON values, like
+// {"@type": "g:Int64", "@value": 1}, and the value itself otherwise.
+func graphsonUnwrap(v interface{}) interface{} {
+	for {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return v
+		}
+		val, ok := m["@value"]
+		if !ok {
+			return v
+		}
+		v = val
+	}
+}
+
+func graphsonString(v interface{}) string {
+	switch v := graphsonUnwrap(v).(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func graphsonValue(v interface{}) *api.Value {
+	switch v := graphsonUnwrap(v).(type) {
+	case bool:
+		return &api.Value{Val: &api.Value_BoolVal{BoolVal: v}}
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return &api.Value{Val: &api.Value_IntVal{IntVal: i}}
+		}
+		if f, err := v.Float64(); err == nil {
+			return &api.Value{Val: &api.Value_DoubleVal{DoubleVal: f}}
+		}
+		return &api.Value{Val: &api.Value_DefaultVal{DefaultVal: v.String()}}
+	case string:
+		return &api.Value{Val: &api.Value_DefaultVal{DefaultVal: v}}
+	default:
+		// Lists, maps and nulls are kept as their JSON.
+		b, _ := json.Marshal(v)
+		return &api.Value{Val: &api.Value_DefaultVal{DefaultVal: string(b)}}
+	}
+}
+
+func graphsonFacet(key string, v interface{}) (*api.Facet, error) {
+	switch v := graphsonUnwrap(v).(type) {
+	case bool:
+		return facets.FacetFor(key, strconv.FormatBool(v))
+	case json.Number:
+		return facets.FacetFor(key, v.String())
+	case string:
+		return facets.FacetFor(key, strconv.Quote(v))
+	default:
+		b, _ := json.Marshal(v)
+		return facets.FacetFor(key, strconv.Quote(string(b)))
+	}
+}
@@ -0,0 +1,72 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chunker
+
+import (
+	"bytes"
+	"sort"
+	"testing"
+
+	"github.com/dgraph-io/dgo/v210/protos/api"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraphSON(t *testing.T) {
+	data := `{"id":1,"label":"person","properties":{"name":[{"id":0,"value":"marko"}],` +
+		`"age":[{"id":1,"value":29}]},"outE":{"knows":[{"id":7,"inV":2,` +
+		`"properties":{"weight":0.5,"since":"2010"}}]}}
+
+{"id":{"@type":"g:Int32","@value":2},"label":"vertex","properties":{"name@it":[` +
+		`{"@type":"g:VertexProperty","@value":{"id":{"@type":"g:Int64","@value":2},` +
+		`"value":"vadas"}}],"active":[{"id":3,"value":true}]}}
+`
+	nqs, _ := chunkAndParse(t, GraphSONFormat, data)
+	// The properties and edges are maps, so their order isn't fixed.
+	sort.Slice(nqs, func(i, j int) bool {
+		if nqs[i].Subject != nqs[j].Subject {
+			return nqs[i].Subject < nqs[j].Subject
+		}
+		return nqs[i].Predicate < nqs[j].Predicate
+	})
+	require.Len(t, nqs, 6)
+	require.Equal(t, &api.NQuad{Subject: "_:1", Predicate: "age",
+		ObjectValue: &api.Value{Val: &api.Value_IntVal{IntVal: 29}}}, nqs[0])
+	require.Equal(t, &api.NQuad{Subject: "_:1", Predicate: "dgraph.type",
+		ObjectValue: &api.Value{Val: &api.Value_DefaultVal{DefaultVal: "person"}}}, nqs[1])
+
+	require.Equal(t, "knows", nqs[2].Predicate)
+	require.Equal(t, "_:2", nqs[2].ObjectId)
+	require.Len(t, nqs[2].Facets, 2)
+	require.Equal(t, "since", nqs[2].Facets[0].Key)
+	require.Equal(t, api.Facet_STRING, nqs[2].Facets[0].ValType)
+	require.Equal(t, "weight", nqs[2].Facets[1].Key)
+	require.Equal(t, api.Facet_FLOAT, nqs[2].Facets[1].ValType)
+
+	require.Equal(t, &api.NQuad{Subject: "_:1", Predicate: "name",
+		ObjectValue: &api.Value{Val: &api.Value_DefaultVal{DefaultVal: "marko"}}}, nqs[3])
+	require.Equal(t, &api.NQuad{Subject: "_:2", Predicate: "active",
+		ObjectValue: &api.Value{Val: &api.Value_BoolVal{BoolVal: true}}}, nqs[4])
+	require.Equal(t, &api.NQuad{Subject: "_:2", Predicate: "name", Lang: "it",
+		ObjectValue: &api.Value{Val: &api.Value_DefaultVal{DefaultVal: "vadas"}}}, nqs[5])
+}
+
+func TestGraphSONErrors(t *testing.T) {
+	ck := NewChunker(GraphSONFormat, -1)
+	require.Error(t, ck.Parse(bytes.NewBufferString(`{"label":"person"}`+"\n")))
+	require.Error(t, ck.Parse(bytes.NewBufferString(`{"id":1,"outE":{"knows":[{"id":2}]}}`)))
+	require.Error(t, ck.Parse(bytes.NewBufferString(`{"id":1,"properties":{"name":["a"]}}`)))
+}
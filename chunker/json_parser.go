@@ -317,6 +317,7 @@ type NQuadBuffer struct {
 	nquads    []*api.NQuad
 	nqCh      chan []*api.NQuad
 	predHints map[string]pb.Metadata_HintType
+	pushed    uint64
 }
 
 // NewNQuadBuffer returns a new NQuadBuffer instance with the specified batch size.
@@ -339,6 +340,7 @@ func (buf *NQuadBuffer) Ch() <-chan []*api.NQuad {
 
 // Push can be passed one or more NQuad pointers, which get pushed to the buffer.
 func (buf *NQuadBuffer) Push(nqs ...*api.NQuad) {
+	buf.pushed += uint64(len(nqs))
 	for _, nq := range nqs {
 		buf.nquads = append(buf.nquads, nq)
 		if buf.batchSize > 0 && len(buf.nquads) >= buf.batchSize {
@@ -348,6 +350,12 @@ func (buf *NQuadBuffer) Push(nqs ...*api.NQuad) {
 	}
 }
 
+// Pushed returns the number of NQuads pushed to the buffer so far. It must be called from the
+// goroutine which pushes the NQuads.
+func (buf *NQuadBuffer) Pushed() uint64 {
+	return buf.pushed
+}
+
 // Metadata returns the parse metadata that has been aggregated so far..
 func (buf *NQuadBuffer) Metadata() *pb.Metadata {
 	return &pb.Metadata{
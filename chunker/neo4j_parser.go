@@ -0,0 +1,262 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chunker
+
+import (
+	"bytes"
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/dgraph-io/dgo/v210/protos/api"
+	"github.com/dgraph-io/dgraph/types"
+	"github.com/dgraph-io/dgraph/types/facets"
+	"github.com/pkg/errors"
+)
+
+// neo4jArrayDelimiter is the default delimiter of the values of arrays and labels in the CSV
+// files of Neo4j.
+const neo4jArrayDelimiter = ";"
+
+type neo4jField int
+
+const (
+	neo4jProperty neo4jField = iota
+	neo4jID
+	neo4jLabel
+	neo4jStartID
+	neo4jEndID
+	neo4jType
+	neo4jIgnore
+)
+
+// neo4jColumn is a column of a CSV file in the layout of neo4j-admin import and export, whose
+// header fields look like name:type, :ID(group), :LABEL, :START_ID(group), :END_ID(group) and
+// :TYPE. The array types, e.g. string[], hold values separated by semicolons.
+type neo4jColumn struct {
+	field neo4jField
+	// name is the name of the property, if any.
+	name string
+	// group is the ID space of the ID columns.
+	group string
+	typ   types.TypeID
+	array bool
+}
+
+var neo4jTypes = map[string]types.TypeID{
+	"string":        types.StringID,
+	"char":          types.StringID,
+	"int":           types.IntID,
+	"long":          types.IntID,
+	"short":         types.IntID,
+	"byte":          types.IntID,
+	"float":         types.FloatID,
+	"double":        types.FloatID,
+	"boolean":       types.BoolID,
+	"date":          types.DateTimeID,
+	"datetime":      types.DateTimeID,
+	"localdatetime": types.DateTimeID,
+}
+
+func parseNeo4jHeader(header []string) []*neo4jColumn {
+	cols := make([]*neo4jColumn, len(header))
+	for i, h := range header {
+		col := &neo4jColumn{name: h, typ: types.StringID}
+		cols[i] = col
+		idx := strings.Index(h, ":")
+		if idx < 0 {
+			continue
+		}
+		col.name = h[:idx]
+		spec := h[idx+1:]
+		if open := strings.Index(spec, "("); open >= 0 && strings.HasSuffix(spec, ")") {
+			col.group = spec[open+1 : len(spec)-1]
+			spec = spec[:open]
+		}
+		switch spec {
+		case "ID":
+			col.field = neo4jID
+		case "LABEL":
+			col.field = neo4jLabel
+		case "START_ID":
+			col.field = neo4jStartID
+		case "END_ID":
+			col.field = neo4jEndID
+		case "TYPE":
+			col.field = neo4jType
+		case "IGNORE":
+			col.field = neo4jIgnore
+		default:
+			col.array = strings.HasSuffix(spec, "[]")
+			spec = strings.ToLower(strings.TrimSuffix(spec, "[]"))
+			typ, ok := neo4jTypes[spec]
+			if !ok {
+				// Types without a Dgraph equivalent, like point or duration, are kept as strings.
+				typ = types.StringID
+			}
+			col.typ = typ
+		}
+	}
+	return cols
+}
+
+// neo4jXid returns the blank node of the Neo4j node with the given ID in the ID space group.
+func neo4jXid(group, id string) string {
+	return "_:neo4j." + group + "." + id
+}
+
+// neo4jChunker parses the CSV files of neo4j-admin import and export. Each row of a node file is
+// a node, whose labels are set as its dgraph.type. Each row of a relationship file is an edge
+// named after its type, whose properties are set as facets.
+type neo4jChunker struct {
+	*csvChunker
+}
+
+// Parse converts each row of the chunk into the N-Quads of a node or of a relationship.
+func (nc *neo4jChunker) Parse(chunkBuf *bytes.Buffer) error {
+	if chunkBuf == nil || chunkBuf.Len() == 0 {
+		return nil
+	}
+
+	cr := csv.NewReader(chunkBuf)
+	header, err := cr.Read()
+	if err != nil {
+		return errors.Wrapf(err, "while reading the CSV header")
+	}
+	cr.FieldsPerRecord = len(header)
+	cols := parseNeo4jHeader(header)
+	var id, start, end, typ = -1, -1, -1, -1
+	for i, col := range cols {
+		switch col.field {
+		case neo4jID:
+			id = i
+		case neo4jStartID:
+			start = i
+		case neo4jEndID:
+			end = i
+		case neo4jType:
+			typ = i
+		}
+	}
+	isNode := id >= 0
+	if !isNode && (start < 0 || end < 0 || typ < 0) {
+		return errors.Errorf("CSV header %q has neither an :ID column, nor :START_ID, :END_ID "+
+			"and :TYPE columns", header)
+	}
+
+	for {
+		row, err := cr.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if isNode {
+			err = nc.parseNode(row, cols, id)
+		} else {
+			err = nc.parseRelationship(row, cols, start, end, typ)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "while parsing row %q", row)
+		}
+	}
+}
+
+func (nc *neo4jChunker) parseNode(row []string, cols []*neo4jColumn, id int) error {
+	if row[id] == "" {
+		return errors.New(":ID is empty")
+	}
+	sub := neo4jXid(cols[id].group, row[id])
+	var nqs []*api.NQuad
+	for i, col := range cols {
+		if row[i] == "" {
+			continue
+		}
+		switch col.field {
+		case neo4jLabel:
+			for _, label := range strings.Split(row[i], neo4jArrayDelimiter) {
+				nqs = append(nqs, &api.NQuad{
+					Subject:     sub,
+					Predicate:   "dgraph.type",
+					ObjectValue: &api.Value{Val: &api.Value_DefaultVal{DefaultVal: label}},
+				})
+			}
+		case neo4jID, neo4jProperty:
+			// The ID is only kept if it's named.
+			if col.name == "" {
+				continue
+			}
+			vals := []string{row[i]}
+			if col.array {
+				vals = strings.Split(row[i], neo4jArrayDelimiter)
+			}
+			for _, val := range vals {
+				ov, err := neo4jValue(col.typ, val)
+				if err != nil {
+					return errors.Wrapf(err, "in column %q", col.name)
+				}
+				nqs = append(nqs, &api.NQuad{Subject: sub, Predicate: col.name, ObjectValue: ov})
+			}
+		}
+	}
+	nc.nqs.Push(nqs...)
+	return nil
+}
+
+func (nc *neo4jChunker) parseRelationship(row []string, cols []*neo4jColumn,
+	start, end, typ int) error {
+	if row[start] == "" || row[end] == "" || row[typ] == "" {
+		return errors.New(":START_ID, :END_ID or :TYPE is empty")
+	}
+	nq := &api.NQuad{
+		Subject:   neo4jXid(cols[start].group, row[start]),
+		Predicate: row[typ],
+		ObjectId:  neo4jXid(cols[end].group, row[end]),
+	}
+	for i, col := range cols {
+		if col.field != neo4jProperty || col.name == "" || row[i] == "" {
+			continue
+		}
+		val := row[i]
+		if col.typ == types.StringID || col.array {
+			// Facets don't have lists, so arrays are kept as strings.
+			val = strconv.Quote(val)
+		}
+		f, err := facets.FacetFor(col.name, val)
+		if err != nil {
+			return errors.Wrapf(err, "in column %q", col.name)
+		}
+		nq.Facets = append(nq.Facets, f)
+	}
+	if err := facets.SortAndValidate(nq.Facets); err != nil {
+		return err
+	}
+	nc.nqs.Push(nq)
+	return nil
+}
+
+func neo4jValue(typ types.TypeID, val string) (*api.Value, error) {
+	src := types.ValueForType(types.StringID)
+	src.Value = []byte(val)
+	dst, err := types.Convert(src, typ)
+	if err != nil {
+		return nil, err
+	}
+	return types.ObjectValue(typ, dst.Value)
+}
@@ -0,0 +1,91 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chunker
+
+import (
+	"io"
+	"testing"
+
+	"github.com/dgraph-io/dgo/v210/protos/api"
+	"github.com/stretchr/testify/require"
+)
+
+func parseNeo4j(t *testing.T, data string) ([]*api.NQuad, error) {
+	ck := NewChunker(Neo4jFormat, 1000)
+	buf, err := ck.Chunk(bufioReader(data))
+	require.Equal(t, io.EOF, err)
+	if err := ck.Parse(buf); err != nil {
+		return nil, err
+	}
+	return ck.NQuads().nquads, nil
+}
+
+func TestNeo4jNodes(t *testing.T) {
+	nqs, err := parseNeo4j(t, "personId:ID(Person),name,age:int,tags:string[],:LABEL\n"+
+		"1,alice,26,a;b,Person;Admin\n2,bob,,,Person\n")
+	require.NoError(t, err)
+	str := func(s string) *api.Value {
+		return &api.Value{Val: &api.Value_StrVal{StrVal: s}}
+	}
+	require.Equal(t, []*api.NQuad{
+		{Subject: "_:neo4j.Person.1", Predicate: "personId", ObjectValue: str("1")},
+		{Subject: "_:neo4j.Person.1", Predicate: "name", ObjectValue: str("alice")},
+		{Subject: "_:neo4j.Person.1", Predicate: "age",
+			ObjectValue: &api.Value{Val: &api.Value_IntVal{IntVal: 26}}},
+		{Subject: "_:neo4j.Person.1", Predicate: "tags", ObjectValue: str("a")},
+		{Subject: "_:neo4j.Person.1", Predicate: "tags", ObjectValue: str("b")},
+		{Subject: "_:neo4j.Person.1", Predicate: "dgraph.type",
+			ObjectValue: &api.Value{Val: &api.Value_DefaultVal{DefaultVal: "Person"}}},
+		{Subject: "_:neo4j.Person.1", Predicate: "dgraph.type",
+			ObjectValue: &api.Value{Val: &api.Value_DefaultVal{DefaultVal: "Admin"}}},
+		{Subject: "_:neo4j.Person.2", Predicate: "personId", ObjectValue: str("2")},
+		{Subject: "_:neo4j.Person.2", Predicate: "name", ObjectValue: str("bob")},
+		{Subject: "_:neo4j.Person.2", Predicate: "dgraph.type",
+			ObjectValue: &api.Value{Val: &api.Value_DefaultVal{DefaultVal: "Person"}}},
+	}, nqs)
+}
+
+func TestNeo4jRelationships(t *testing.T) {
+	nqs, err := parseNeo4j(t, ":START_ID(Person),since:int,role,:END_ID(Person),:TYPE,x:IGNORE\n"+
+		"1,2010,friend,2,KNOWS,foo\n")
+	require.NoError(t, err)
+	require.Len(t, nqs, 1)
+	nq := nqs[0]
+	require.Equal(t, "_:neo4j.Person.1", nq.Subject)
+	require.Equal(t, "KNOWS", nq.Predicate)
+	require.Equal(t, "_:neo4j.Person.2", nq.ObjectId)
+	require.Len(t, nq.Facets, 2)
+	require.Equal(t, "role", nq.Facets[0].Key)
+	require.Equal(t, api.Facet_STRING, nq.Facets[0].ValType)
+	require.Equal(t, "since", nq.Facets[1].Key)
+	require.Equal(t, api.Facet_INT, nq.Facets[1].ValType)
+}
+
+func TestNeo4jErrors(t *testing.T) {
+	_, err := parseNeo4j(t, "name,age\nalice,26\n")
+	require.Error(t, err)
+	_, err = parseNeo4j(t, ":ID,age:int\n1,old\n")
+	require.Error(t, err)
+	_, err = parseNeo4j(t, ":START_ID,:END_ID,:TYPE\n1,,KNOWS\n")
+	require.Error(t, err)
+}
+
+func TestNeo4jDataFormat(t *testing.T) {
+	require.Equal(t, Neo4jFormat, DataFormat("nodes.csv.gz", "neo4j"))
+	require.Equal(t, CsvFormat, DataFormat("nodes.csv", ""))
+	require.Equal(t, RdfFormat, DataFormat("data.rdf", "neo4j"))
+}
@@ -0,0 +1,138 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chunker
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/dgraph-io/dgo/v210/protos/api"
+	"github.com/dgraph-io/dgraph/protos/pb"
+)
+
+// ParallelParser parses chunks with several workers. The N-Quads of the chunks are pushed to the
+// output buffer in the order of the chunks, so they keep the order they have in the input.
+type ParallelParser struct {
+	out   *NQuadBuffer
+	jobs  chan *parseJob
+	order chan *parseJob
+	wg    sync.WaitGroup
+	done  chan struct{}
+
+	mu  sync.Mutex
+	err error
+}
+
+type parseJob struct {
+	buf      *bytes.Buffer
+	onPushed func()
+
+	parsed chan struct{}
+	nqs    []*api.NQuad
+	hints  map[string]pb.Metadata_HintType
+	err    error
+}
+
+// NewParallelParser returns a parser with the given number of workers, which push the N-Quads to
+// out. newChunker returns the chunker of a worker, for the given batch size.
+func NewParallelParser(newChunker func(batchSize int) Chunker, out *NQuadBuffer,
+	workers int) *ParallelParser {
+	if workers < 1 {
+		workers = 1
+	}
+	p := &ParallelParser{
+		out:   out,
+		jobs:  make(chan *parseJob, workers),
+		order: make(chan *parseJob, 2*workers),
+		done:  make(chan struct{}),
+	}
+	p.wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		// The workers don't send batches. They hand all the N-Quads of a chunk to the pusher.
+		go p.parse(newChunker(-1))
+	}
+	go p.push()
+	return p
+}
+
+func (p *ParallelParser) parse(ck Chunker) {
+	defer p.wg.Done()
+	nqs := ck.NQuads()
+	for job := range p.jobs {
+		job.err = ck.Parse(job.buf)
+		job.nqs, nqs.nquads = nqs.nquads, nil
+		if len(nqs.predHints) > 0 {
+			job.hints, nqs.predHints = nqs.predHints, make(map[string]pb.Metadata_HintType)
+		}
+		close(job.parsed)
+	}
+}
+
+func (p *ParallelParser) push() {
+	defer close(p.done)
+	for job := range p.order {
+		<-job.parsed
+		if job.err != nil {
+			p.mu.Lock()
+			if p.err == nil {
+				p.err = job.err
+			}
+			p.mu.Unlock()
+		}
+		if p.Err() != nil {
+			// Keep draining the jobs, so that Parse doesn't block.
+			continue
+		}
+		for pred, hint := range job.hints {
+			p.out.PushPredHint(pred, hint)
+		}
+		p.out.Push(job.nqs...)
+		if job.onPushed != nil {
+			job.onPushed()
+		}
+	}
+}
+
+// Parse queues the chunk to be parsed. onPushed, if set, is called once the N-Quads of the chunk
+// are pushed, from the goroutine which pushes them. It returns the error of any chunk which
+// failed to parse so far, after which the following chunks are not pushed.
+func (p *ParallelParser) Parse(buf *bytes.Buffer, onPushed func()) error {
+	if err := p.Err(); err != nil {
+		return err
+	}
+	job := &parseJob{buf: buf, onPushed: onPushed, parsed: make(chan struct{})}
+	p.order <- job
+	p.jobs <- job
+	return nil
+}
+
+// Err returns the error of the first chunk which failed to parse, if any.
+func (p *ParallelParser) Err() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.err
+}
+
+// Close waits for the queued chunks to be parsed and pushed, and returns the error of the first
+// chunk which failed to parse. It doesn't flush the output buffer.
+func (p *ParallelParser) Close() error {
+	close(p.jobs)
+	close(p.order)
+	p.wg.Wait()
+	<-p.done
+	return p.Err()
+}
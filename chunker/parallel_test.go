@@ -0,0 +1,134 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chunker
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/dgraph-io/dgo/v210/protos/api"
+	"github.com/stretchr/testify/require"
+)
+
+// jsonListReader generates a JSON file with a single top-level list of n objects.
+type jsonListReader struct {
+	n, next int
+	buf     bytes.Buffer
+	size    int64
+}
+
+func (r *jsonListReader) Read(p []byte) (int, error) {
+	for r.buf.Len() < len(p) && r.next <= r.n {
+		switch {
+		case r.next == 0:
+			r.buf.WriteString("[\n")
+		case r.next < r.n:
+			if r.next > 1 {
+				r.buf.WriteString(",\n")
+			}
+			fmt.Fprintf(&r.buf, `{"uid": "_:n%d", "name": "node {%d}", "bio": "say \"hi\\", `+
+				`"friend": {"uid": "_:n%d"}}`, r.next, r.next, r.next+1)
+		default:
+			r.buf.WriteString("\n]\n")
+		}
+		r.next++
+	}
+	n, err := r.buf.Read(p)
+	r.size += int64(n)
+	return n, err
+}
+
+func TestJSONChunkLargeList(t *testing.T) {
+	// Around 100 bytes per object, so about 2GB unless in short mode.
+	n := 20 << 20
+	if testing.Short() {
+		n = 200 << 10
+	}
+	gen := &jsonListReader{n: n}
+	r := bufio.NewReader(gen)
+	ck := NewChunker(JsonFormat, 1000)
+	var objects int
+	for {
+		buf, err := ck.Chunk(r)
+		if buf != nil {
+			// Chunks stay bounded, whatever the size of the list.
+			require.Less(t, buf.Len(), 100000+1<<10)
+			require.True(t, json.Valid(buf.Bytes()))
+			objects += bytes.Count(buf.Bytes(), []byte(`"friend"`))
+		}
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+	}
+	require.Equal(t, n-1, objects)
+	t.Logf("Chunked %d objects, %d bytes", objects, gen.size)
+}
+
+func TestParallelParserOrder(t *testing.T) {
+	nqbuf := NewNQuadBuffer(100)
+	parser := NewParallelParser(func(batchSize int) Chunker {
+		return NewChunker(RdfFormat, batchSize)
+	}, nqbuf, 4)
+
+	var pushed []uint64
+	go func() {
+		for i := 0; i < 50000; i += 100 {
+			var chunk bytes.Buffer
+			for j := i; j < i+100; j++ {
+				fmt.Fprintf(&chunk, "<_:n%d> <name> \"node %d\" .\n", j, j)
+			}
+			require.NoError(t, parser.Parse(&chunk, func() {
+				pushed = append(pushed, nqbuf.Pushed())
+			}))
+		}
+		require.NoError(t, parser.Close())
+		nqbuf.Flush()
+	}()
+
+	var nqs []*api.NQuad
+	for batch := range nqbuf.Ch() {
+		nqs = append(nqs, batch...)
+	}
+	require.Len(t, nqs, 50000)
+	for i, nq := range nqs {
+		require.Equal(t, fmt.Sprintf("_:n%d", i), nq.Subject)
+	}
+	require.Len(t, pushed, 500)
+	for i, p := range pushed {
+		require.Equal(t, uint64(100*(i+1)), p)
+	}
+}
+
+func TestParallelParserError(t *testing.T) {
+	nqbuf := NewNQuadBuffer(100)
+	parser := NewParallelParser(func(batchSize int) Chunker {
+		return NewChunker(RdfFormat, batchSize)
+	}, nqbuf, 2)
+	go func() {
+		for range nqbuf.Ch() {
+		}
+	}()
+	require.NoError(t, parser.Parse(bytes.NewBufferString("<_:a> <name> \"a\" .\n"), nil))
+	require.NoError(t, parser.Parse(bytes.NewBufferString("<_:a> <name> .\n"), nil))
+	require.Error(t, parser.Close())
+	nqbuf.Flush()
+}
@@ -363,7 +363,10 @@ var typeMap = map[string]types.TypeID{
 	"xs:boolean":         types.BoolID,
 	"xs:double":          types.FloatID,
 	"xs:float":           types.FloatID,
-	"xs:base64Binary":    types.BinaryID,
+	"xs:decimal":         types.DecimalID,
+	"xs:bigint":          types.BigIntID,
+	"xs:duration":        types.DurationID,
+	"xs:base64Binary":    types.BytesID,
 	"geo:geojson":        types.GeoID,
 	"http://www.w3.org/2001/XMLSchema#string":          types.StringID,
 	"http://www.w3.org/2001/XMLSchema#dateTime":        types.DateTimeID,
@@ -374,6 +377,9 @@ var typeMap = map[string]types.TypeID{
 	"http://www.w3.org/2001/XMLSchema#boolean":         types.BoolID,
 	"http://www.w3.org/2001/XMLSchema#double":          types.FloatID,
 	"http://www.w3.org/2001/XMLSchema#float":           types.FloatID,
+	"http://www.w3.org/2001/XMLSchema#decimal":         types.DecimalID,
+	"http://www.w3.org/2001/XMLSchema#duration":        types.DurationID,
+	"http://www.w3.org/2001/XMLSchema#base64Binary":    types.BytesID,
 	"http://www.w3.org/2001/XMLSchema#gYear":           types.DateTimeID,
 	"http://www.w3.org/2001/XMLSchema#gYearMonth":      types.DateTimeID,
 }
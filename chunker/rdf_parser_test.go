@@ -279,6 +279,19 @@ var testNQuads = []struct {
 		input:       `_:alice <knows> "stuff"^^xs:string .`,
 		expectedErr: true,
 	},
+	{
+		input: `_:alice <thumbnail> "aGVsbG8="^^<xs:base64Binary> .`,
+		nq: api.NQuad{
+			Subject:     "_:alice",
+			Predicate:   "thumbnail",
+			ObjectId:    "",
+			ObjectValue: &api.Value{Val: &api.Value_BytesVal{BytesVal: []byte("hello")}},
+		},
+	},
+	{
+		input:       `_:alice <thumbnail> "hello"^^<xs:base64Binary> .`,
+		expectedErr: true,
+	},
 	{
 		input:       `_:alice <age> "thirteen"^^<xs:int> .`,
 		expectedErr: true,
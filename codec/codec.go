@@ -18,6 +18,8 @@ package codec
 
 import (
 	"encoding/binary"
+	"math/rand"
+	"sort"
 
 	"github.com/dgraph-io/dgraph/protos/pb"
 	"github.com/dgraph-io/dgraph/x"
@@ -132,6 +134,76 @@ func Merge(matrix []*pb.List) *sroar.Bitmap {
 	return sroar.FastOr(bms...)
 }
 
+// Difference returns the uids of the list which aren't in the bitmap, computed with a bitmap AndNot.
+func Difference(l *pb.List, bm *sroar.Bitmap) *pb.List {
+	out := FromList(l)
+	out.AndNot(bm)
+	return ToList(out)
+}
+
+// Sample returns n uids of the bitmap, picked at evenly spaced ranks so that they are spread
+// uniformly across the bitmap. The bitmap itself is returned if it has no more than n uids.
+func Sample(bm *sroar.Bitmap, n uint64) (*sroar.Bitmap, error) {
+	card := uint64(bm.GetCardinality())
+	if card <= n {
+		return bm, nil
+	}
+	out := sroar.NewBitmap()
+	for i := uint64(0); i < n; i++ {
+		uid, err := bm.Select(i * card / n)
+		if err != nil {
+			return nil, err
+		}
+		out.Set(uid)
+	}
+	return out, nil
+}
+
+// RandomRanks returns n distinct ranks in [0, card) picked uniformly at random with Floyd's
+// algorithm, in increasing order. All the ranks are returned if card is no more than n.
+func RandomRanks(card, n uint64) []uint64 {
+	if card <= n {
+		ranks := make([]uint64, card)
+		for i := range ranks {
+			ranks[i] = uint64(i)
+		}
+		return ranks
+	}
+	picked := make(map[uint64]struct{}, n)
+	for j := card - n; j < card; j++ {
+		r := uint64(rand.Int63n(int64(j + 1)))
+		if _, ok := picked[r]; ok {
+			r = j
+		}
+		picked[r] = struct{}{}
+	}
+	ranks := make([]uint64, 0, n)
+	for r := range picked {
+		ranks = append(ranks, r)
+	}
+	sort.Slice(ranks, func(i, j int) bool { return ranks[i] < ranks[j] })
+	return ranks
+}
+
+// RandomSample returns n uids of the bitmap picked uniformly at random. The uids are read by rank
+// with Select, so the bitmap isn't turned into a list. The bitmap itself is returned if it has no
+// more than n uids.
+func RandomSample(bm *sroar.Bitmap, n uint64) (*sroar.Bitmap, error) {
+	card := uint64(bm.GetCardinality())
+	if card <= n {
+		return bm, nil
+	}
+	out := sroar.NewBitmap()
+	for _, r := range RandomRanks(card, n) {
+		uid, err := bm.Select(r)
+		if err != nil {
+			return nil, err
+		}
+		out.Set(uid)
+	}
+	return out, nil
+}
+
 func FromList(l *pb.List) *sroar.Bitmap {
 	if l == nil {
 		return sroar.NewBitmap()
@@ -0,0 +1,62 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package conn
+
+import (
+	"context"
+	"net"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// localBufSize is the size of the in-memory buffer of each local connection.
+const localBufSize = 1 << 20
+
+var localListeners struct {
+	sync.RWMutex
+	all map[string]*bufconn.Listener
+}
+
+// ListenLocal returns an in-memory listener for the gRPC server of a node running in this
+// process, like the Zero embedded by dgraph standalone. The pools connecting to addr reach the
+// server through this listener instead of the network.
+func ListenLocal(addr string) net.Listener {
+	l := bufconn.Listen(localBufSize)
+	localListeners.Lock()
+	defer localListeners.Unlock()
+	if localListeners.all == nil {
+		localListeners.all = make(map[string]*bufconn.Listener)
+	}
+	localListeners.all[addr] = l
+	return l
+}
+
+// localDialOption returns the dial option connecting to addr in memory, if a node of this process
+// listens locally on addr.
+func localDialOption(addr string) (grpc.DialOption, bool) {
+	localListeners.RLock()
+	defer localListeners.RUnlock()
+	l, ok := localListeners.all[addr]
+	if !ok {
+		return nil, false
+	}
+	return grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return l.DialContext(ctx)
+	}), true
+}
@@ -0,0 +1,55 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package conn
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+type echoRaft struct {
+	pb.UnimplementedRaftServer
+}
+
+func (echoRaft) IsPeer(ctx context.Context, rc *pb.RaftContext) (*pb.PeerResponse, error) {
+	return &pb.PeerResponse{Status: rc.Id == 7}, nil
+}
+
+func TestListenLocal(t *testing.T) {
+	const addr = "localhost:15080"
+	_, ok := localDialOption(addr)
+	require.False(t, ok)
+
+	l := ListenLocal(addr)
+	s := grpc.NewServer()
+	pb.RegisterRaftServer(s, echoRaft{})
+	go func() { _ = s.Serve(l) }()
+	defer s.Stop()
+
+	// Nothing listens on addr, so the call only succeeds in memory.
+	pool, err := newPool(addr, nil)
+	require.NoError(t, err)
+	defer pool.shutdown()
+	resp, err := pb.NewRaftClient(pool.Get()).IsPeer(context.Background(),
+		&pb.RaftContext{Id: 7})
+	require.NoError(t, err)
+	require.True(t, resp.Status)
+}
@@ -646,8 +646,8 @@ type linReadReq struct {
 	indexCh chan<- uint64
 }
 
-var errReadIndex = errors.Errorf(
-	"Cannot get linearized read (time expired or no configured leader)")
+var errReadIndex = x.WithErrorCode(errors.Errorf(
+	"Cannot get linearized read (time expired or no configured leader)"), x.ErrorCodeRetryable)
 
 var readIndexOk, readIndexTotal uint64
 
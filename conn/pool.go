@@ -37,10 +37,11 @@ import (
 
 var (
 	// ErrNoConnection indicates no connection exists to a node.
-	ErrNoConnection = errors.New("No connection exists")
+	ErrNoConnection = x.WithErrorCode(errors.New("No connection exists"), x.ErrorCodeRetryable)
 	// ErrUnhealthyConnection indicates the connection to a node is unhealthy.
-	ErrUnhealthyConnection = errors.New("Unhealthy connection")
-	echoDuration           = 500 * time.Millisecond
+	ErrUnhealthyConnection = x.WithErrorCode(
+		errors.New("Unhealthy connection"), x.ErrorCodeRetryable)
+	echoDuration = 500 * time.Millisecond
 )
 
 // Pool is used to manage the grpc client connection(s) for communicating with other
@@ -182,6 +183,9 @@ func newPool(addr string, tlsClientConf *tls.Config) (*Pool, error) {
 	} else {
 		conOpts = append(conOpts, grpc.WithInsecure())
 	}
+	if opt, ok := localDialOption(addr); ok {
+		conOpts = append(conOpts, opt)
+	}
 
 	conn, err := grpc.Dial(addr, conOpts...)
 	if err != nil {
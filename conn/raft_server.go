@@ -106,6 +106,13 @@ func (p *proposals) Delete(key uint64) {
 	delete(p.all, key)
 }
 
+// Len returns the number of proposals waiting to be applied.
+func (p *proposals) Len() int {
+	p.RLock()
+	defer p.RUnlock()
+	return len(p.all)
+}
+
 func (p *proposals) Done(key uint64, err error) {
 	if key == 0 {
 		return
@@ -0,0 +1,706 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package cypher translates read-only openCypher queries into DQL. Only a subset of openCypher
+// is supported: a single MATCH of a path pattern, along with WHERE, RETURN, ORDER BY, SKIP and
+// LIMIT clauses.
+package cypher
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+)
+
+type tokenType int
+
+const (
+	tokenEOF tokenType = iota
+	tokenIdent
+	tokenString
+	tokenNumber
+	tokenParam
+	tokenPunct
+)
+
+type token struct {
+	typ tokenType
+	val string
+	pos int
+}
+
+// is returns true if the token is the given punctuation, or the given keyword.
+func (t token) is(val string) bool {
+	switch t.typ {
+	case tokenPunct:
+		return t.val == val
+	case tokenIdent:
+		return strings.EqualFold(t.val, val)
+	default:
+		return false
+	}
+}
+
+var puncts = map[string]bool{
+	"(": true, ")": true, "[": true, "]": true, "{": true, "}": true, ",": true, ":": true,
+	".": true, "-": true, "<": true, ">": true, "=": true, "*": true, ";": true, "|": true,
+	"<>": true, "<=": true, ">=": true, "!=": true, "..": true,
+}
+
+func lex(query string) ([]token, error) {
+	var tokens []token
+	rs := []rune(query)
+	for i := 0; i < len(rs); {
+		r := rs[i]
+		start := i
+		switch {
+		case unicode.IsSpace(r):
+			i++
+			continue
+		case r == '/' && i+1 < len(rs) && rs[i+1] == '/':
+			for i < len(rs) && rs[i] != '\n' {
+				i++
+			}
+			continue
+		case unicode.IsLetter(r) || r == '_':
+			for i < len(rs) && (unicode.IsLetter(rs[i]) || unicode.IsDigit(rs[i]) || rs[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, token{typ: tokenIdent, val: string(rs[start:i]), pos: start})
+		case r == '`':
+			i++
+			for i < len(rs) && rs[i] != '`' {
+				i++
+			}
+			if i == len(rs) {
+				return nil, errors.Errorf("unterminated identifier at %d", start)
+			}
+			i++
+			tokens = append(tokens, token{typ: tokenIdent, val: string(rs[start+1 : i-1]),
+				pos: start})
+		case r == '$':
+			i++
+			for i < len(rs) && (unicode.IsLetter(rs[i]) || unicode.IsDigit(rs[i]) || rs[i] == '_') {
+				i++
+			}
+			if i == start+1 {
+				return nil, errors.Errorf("expected a parameter name at %d", start)
+			}
+			tokens = append(tokens, token{typ: tokenParam, val: string(rs[start+1 : i]), pos: start})
+		case unicode.IsDigit(r):
+			for i < len(rs) && (unicode.IsDigit(rs[i]) || rs[i] == '.' || rs[i] == 'e' ||
+				rs[i] == 'E') {
+				i++
+			}
+			tokens = append(tokens, token{typ: tokenNumber, val: string(rs[start:i]), pos: start})
+		case r == '\'' || r == '"':
+			var sb strings.Builder
+			for i++; i < len(rs) && rs[i] != r; i++ {
+				if rs[i] == '\\' && i+1 < len(rs) {
+					i++
+					switch rs[i] {
+					case 'n':
+						sb.WriteRune('\n')
+					case 't':
+						sb.WriteRune('\t')
+					case 'r':
+						sb.WriteRune('\r')
+					default:
+						sb.WriteRune(rs[i])
+					}
+					continue
+				}
+				sb.WriteRune(rs[i])
+			}
+			if i == len(rs) {
+				return nil, errors.Errorf("unterminated string at %d", start)
+			}
+			i++
+			tokens = append(tokens, token{typ: tokenString, val: sb.String(), pos: start})
+		default:
+			val := string(r)
+			if i+1 < len(rs) {
+				switch two := string(rs[i : i+2]); two {
+				case "<>", "<=", ">=", "!=", "..":
+					val = two
+				}
+			}
+			if !puncts[val] {
+				return nil, errors.Errorf("unexpected character %q at %d", r, start)
+			}
+			i += len([]rune(val))
+			tokens = append(tokens, token{typ: tokenPunct, val: val, pos: start})
+		}
+	}
+	return append(tokens, token{typ: tokenEOF, pos: len(rs)}), nil
+}
+
+// Query is a parsed openCypher read query.
+type Query struct {
+	Path    *Path
+	Where   Expr
+	Return  []*ReturnItem
+	OrderBy []*SortItem
+	Skip    int
+	Limit   int
+}
+
+// Path is a path pattern, made of nodes joined by relationships. Rels[i] joins Nodes[i] and
+// Nodes[i+1].
+type Path struct {
+	Nodes []*NodePattern
+	Rels  []*RelPattern
+}
+
+// NodePattern is a node pattern like (a:Person {name: 'Alice'}).
+type NodePattern struct {
+	Var    string
+	Labels []string
+	Props  []*Property
+}
+
+// RelPattern is a relationship pattern like -[r:KNOWS {since: 2010}]->.
+type RelPattern struct {
+	Var   string
+	Type  string
+	Props []*Property
+	// Reverse is set for the relationships pointing to the left, like <-[:KNOWS]-.
+	Reverse bool
+}
+
+// Property is a property of a pattern, along with its value.
+type Property struct {
+	Name  string
+	Value *Value
+}
+
+// ValueKind is the kind of a literal value.
+type ValueKind int
+
+const (
+	StringValue ValueKind = iota
+	NumberValue
+	BoolValue
+	ParamValue
+	ListValue
+)
+
+// Value is a literal value, a parameter or a list of them.
+type Value struct {
+	Kind ValueKind
+	Val  string
+	List []*Value
+}
+
+// Expr is an expression of the WHERE clause. It's either a *BoolExpr, a *NotExpr or a
+// *Comparison.
+type Expr interface{}
+
+// BoolExpr is the AND or the OR of two expressions.
+type BoolExpr struct {
+	Op          string
+	Left, Right Expr
+}
+
+// NotExpr is the negation of an expression.
+type NotExpr struct {
+	Expr Expr
+}
+
+// Comparison compares a property of a variable to a value. Op is one of =, <>, <, <=, >, >=,
+// IN, STARTS WITH, CONTAINS, IS NULL and IS NOT NULL.
+type Comparison struct {
+	Var   string
+	Prop  string
+	Op    string
+	Value *Value
+}
+
+// ReturnItem is a variable, or a property of a variable, to return.
+type ReturnItem struct {
+	Var   string
+	Prop  string
+	Alias string
+}
+
+// SortItem is a property to order the results by.
+type SortItem struct {
+	Var  string
+	Prop string
+	Desc bool
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.typ != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+// accept consumes the next token if it is the given punctuation or keyword.
+func (p *parser) accept(val string) bool {
+	if p.peek().is(val) {
+		p.next()
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(val string) error {
+	if t := p.next(); !t.is(val) {
+		return errors.Errorf("expected %q at %d, got %q", val, t.pos, t.val)
+	}
+	return nil
+}
+
+func (p *parser) ident() (string, error) {
+	t := p.next()
+	if t.typ != tokenIdent {
+		return "", errors.Errorf("expected a name at %d, got %q", t.pos, t.val)
+	}
+	return t.val, nil
+}
+
+// writeClauses are the clauses of openCypher which update the graph.
+var writeClauses = []string{"CREATE", "MERGE", "SET", "DELETE", "DETACH", "REMOVE", "FOREACH"}
+
+// unsupportedClauses are the read clauses of openCypher which aren't supported.
+var unsupportedClauses = []string{"OPTIONAL", "WITH", "UNWIND", "UNION", "CALL", "LOAD"}
+
+// checkClause returns an error if the next token starts a clause which isn't supported.
+func (p *parser) checkClause() error {
+	t := p.peek()
+	for _, clause := range writeClauses {
+		if t.is(clause) {
+			return errors.Errorf("%s isn't supported, only read queries are", clause)
+		}
+	}
+	for _, clause := range unsupportedClauses {
+		if t.is(clause) {
+			return errors.Errorf("%s isn't supported", clause)
+		}
+	}
+	return nil
+}
+
+// Parse parses a read-only openCypher query.
+func Parse(query string) (*Query, error) {
+	tokens, err := lex(query)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	q := &Query{}
+	if err := p.checkClause(); err != nil {
+		return nil, err
+	}
+	if err := p.expect("MATCH"); err != nil {
+		return nil, err
+	}
+	if q.Path, err = p.parsePath(); err != nil {
+		return nil, err
+	}
+	if p.peek().is(",") || p.peek().is("MATCH") {
+		return nil, errors.New("only a single path pattern is supported")
+	}
+	if err := p.checkClause(); err != nil {
+		return nil, err
+	}
+	if p.accept("WHERE") {
+		if q.Where, err = p.parseOr(); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.checkClause(); err != nil {
+		return nil, err
+	}
+	if err := p.expect("RETURN"); err != nil {
+		return nil, err
+	}
+	if q.Return, err = p.parseReturn(); err != nil {
+		return nil, err
+	}
+	if p.accept("ORDER") {
+		if err := p.expect("BY"); err != nil {
+			return nil, err
+		}
+		if q.OrderBy, err = p.parseOrderBy(); err != nil {
+			return nil, err
+		}
+	}
+	if p.accept("SKIP") {
+		if q.Skip, err = p.parseInt(); err != nil {
+			return nil, err
+		}
+	}
+	if p.accept("LIMIT") {
+		if q.Limit, err = p.parseInt(); err != nil {
+			return nil, err
+		}
+	}
+	p.accept(";")
+	if t := p.peek(); t.typ != tokenEOF {
+		return nil, errors.Errorf("unexpected %q at %d", t.val, t.pos)
+	}
+	return q, nil
+}
+
+func (p *parser) parsePath() (*Path, error) {
+	path := &Path{}
+	for {
+		node, err := p.parseNode()
+		if err != nil {
+			return nil, err
+		}
+		path.Nodes = append(path.Nodes, node)
+		if !p.peek().is("-") && !p.peek().is("<") {
+			return path, nil
+		}
+		rel, err := p.parseRel()
+		if err != nil {
+			return nil, err
+		}
+		path.Rels = append(path.Rels, rel)
+	}
+}
+
+func (p *parser) parseNode() (*NodePattern, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	node := &NodePattern{}
+	if p.peek().typ == tokenIdent {
+		node.Var = p.next().val
+	}
+	for p.accept(":") {
+		label, err := p.ident()
+		if err != nil {
+			return nil, err
+		}
+		node.Labels = append(node.Labels, label)
+	}
+	if p.peek().is("{") {
+		props, err := p.parseProps()
+		if err != nil {
+			return nil, err
+		}
+		node.Props = props
+	}
+	return node, p.expect(")")
+}
+
+func (p *parser) parseRel() (*RelPattern, error) {
+	rel := &RelPattern{}
+	if p.accept("<") {
+		rel.Reverse = true
+	}
+	if err := p.expect("-"); err != nil {
+		return nil, err
+	}
+	if !p.accept("[") {
+		return nil, errors.Errorf("relationships without a type aren't supported, at %d",
+			p.peek().pos)
+	}
+	if p.peek().typ == tokenIdent {
+		rel.Var = p.next().val
+	}
+	if p.accept(":") {
+		typ, err := p.ident()
+		if err != nil {
+			return nil, err
+		}
+		rel.Type = typ
+	}
+	if p.peek().is("|") {
+		return nil, errors.New("relationships with several types aren't supported")
+	}
+	if p.peek().is("*") {
+		return nil, errors.New("variable length relationships aren't supported")
+	}
+	if rel.Type == "" {
+		return nil, errors.Errorf("relationships without a type aren't supported, at %d",
+			p.peek().pos)
+	}
+	if p.peek().is("{") {
+		props, err := p.parseProps()
+		if err != nil {
+			return nil, err
+		}
+		rel.Props = props
+	}
+	if err := p.expect("]"); err != nil {
+		return nil, err
+	}
+	if err := p.expect("-"); err != nil {
+		return nil, err
+	}
+	right := p.accept(">")
+	if right == rel.Reverse {
+		return nil, errors.New("relationships must have a single direction")
+	}
+	return rel, nil
+}
+
+func (p *parser) parseProps() ([]*Property, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	var props []*Property
+	for !p.accept("}") {
+		if len(props) > 0 {
+			if err := p.expect(","); err != nil {
+				return nil, err
+			}
+		}
+		name, err := p.ident()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		val, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		props = append(props, &Property{Name: name, Value: val})
+	}
+	return props, nil
+}
+
+func (p *parser) parseValue() (*Value, error) {
+	t := p.next()
+	switch {
+	case t.typ == tokenString:
+		return &Value{Kind: StringValue, Val: t.val}, nil
+	case t.typ == tokenNumber:
+		if _, err := strconv.ParseFloat(t.val, 64); err != nil {
+			return nil, errors.Errorf("invalid number %q at %d", t.val, t.pos)
+		}
+		return &Value{Kind: NumberValue, Val: t.val}, nil
+	case t.is("-"):
+		val, err := p.parseValue()
+		if err != nil || val.Kind != NumberValue {
+			return nil, errors.Errorf("expected a number at %d", t.pos)
+		}
+		val.Val = "-" + val.Val
+		return val, nil
+	case t.is("true") || t.is("false"):
+		return &Value{Kind: BoolValue, Val: strings.ToLower(t.val)}, nil
+	case t.typ == tokenParam:
+		return &Value{Kind: ParamValue, Val: t.val}, nil
+	case t.is("["):
+		list := &Value{Kind: ListValue}
+		for !p.accept("]") {
+			if len(list.List) > 0 {
+				if err := p.expect(","); err != nil {
+					return nil, err
+				}
+			}
+			val, err := p.parseValue()
+			if err != nil {
+				return nil, err
+			}
+			if val.Kind == ListValue {
+				return nil, errors.Errorf("nested lists aren't supported, at %d", t.pos)
+			}
+			list.List = append(list.List, val)
+		}
+		return list, nil
+	default:
+		return nil, errors.Errorf("expected a value at %d, got %q", t.pos, t.val)
+	}
+}
+
+func (p *parser) parseOr() (Expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("OR") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &BoolExpr{Op: "OR", Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Expr, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("AND") {
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &BoolExpr{Op: "AND", Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseNot() (Expr, error) {
+	if p.accept("NOT") {
+		expr, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &NotExpr{Expr: expr}, nil
+	}
+	if p.accept("(") {
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		return expr, p.expect(")")
+	}
+	return p.parseComparison()
+}
+
+func (p *parser) parseProp() (string, string, error) {
+	v, err := p.ident()
+	if err != nil {
+		return "", "", err
+	}
+	if err := p.expect("."); err != nil {
+		return "", "", err
+	}
+	prop, err := p.ident()
+	return v, prop, err
+}
+
+func (p *parser) parseComparison() (Expr, error) {
+	v, prop, err := p.parseProp()
+	if err != nil {
+		return nil, err
+	}
+	c := &Comparison{Var: v, Prop: prop}
+	t := p.next()
+	switch {
+	case t.is("=") || t.is("<>") || t.is("!=") || t.is("<") || t.is("<=") || t.is(">") ||
+		t.is(">="):
+		c.Op = t.val
+		if c.Op == "!=" {
+			c.Op = "<>"
+		}
+	case t.is("IN"):
+		c.Op = "IN"
+	case t.is("CONTAINS"):
+		c.Op = "CONTAINS"
+	case t.is("STARTS"):
+		if err := p.expect("WITH"); err != nil {
+			return nil, err
+		}
+		c.Op = "STARTS WITH"
+	case t.is("IS"):
+		c.Op = "IS NULL"
+		if p.accept("NOT") {
+			c.Op = "IS NOT NULL"
+		}
+		return c, p.expect("NULL")
+	default:
+		return nil, errors.Errorf("unsupported operator %q at %d", t.val, t.pos)
+	}
+	if c.Value, err = p.parseValue(); err != nil {
+		return nil, err
+	}
+	if (c.Value.Kind == ListValue) != (c.Op == "IN") {
+		return nil, errors.Errorf("IN must be followed by a list, at %d", t.pos)
+	}
+	return c, nil
+}
+
+func (p *parser) parseReturn() ([]*ReturnItem, error) {
+	if p.peek().is("DISTINCT") {
+		return nil, errors.New("RETURN DISTINCT isn't supported")
+	}
+	var items []*ReturnItem
+	for {
+		item := &ReturnItem{}
+		switch {
+		case p.accept("*"):
+			item.Var = "*"
+		default:
+			v, err := p.ident()
+			if err != nil {
+				return nil, err
+			}
+			if p.peek().is("(") {
+				return nil, errors.Errorf("functions like %s() aren't supported", v)
+			}
+			item.Var = v
+			if p.accept(".") {
+				if item.Prop, err = p.ident(); err != nil {
+					return nil, err
+				}
+			}
+			if p.accept("AS") {
+				if item.Alias, err = p.ident(); err != nil {
+					return nil, err
+				}
+			}
+		}
+		items = append(items, item)
+		if !p.accept(",") {
+			return items, nil
+		}
+	}
+}
+
+func (p *parser) parseOrderBy() ([]*SortItem, error) {
+	var items []*SortItem
+	for {
+		v, prop, err := p.parseProp()
+		if err != nil {
+			return nil, err
+		}
+		item := &SortItem{Var: v, Prop: prop}
+		switch {
+		case p.accept("DESC") || p.accept("DESCENDING"):
+			item.Desc = true
+		case p.accept("ASC") || p.accept("ASCENDING"):
+		}
+		items = append(items, item)
+		if !p.accept(",") {
+			return items, nil
+		}
+	}
+}
+
+func (p *parser) parseInt() (int, error) {
+	t := p.next()
+	n, err := strconv.Atoi(t.val)
+	if t.typ != tokenNumber || err != nil || n < 0 {
+		return 0, errors.Errorf("expected a positive integer at %d, got %q", t.pos, t.val)
+	}
+	return n, nil
+}
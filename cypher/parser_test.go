@@ -0,0 +1,107 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cypher
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	q, err := Parse(`MATCH (a:Person {name: 'Alice'})-[r:KNOWS]->(b)<-[:OWNS]-(c:Pet)
+		WHERE a.age >= 30 AND (b.name STARTS WITH "B" OR NOT c.name IS NULL)
+		RETURN a.name AS name, r.since, b, c.name
+		ORDER BY a.name DESC SKIP 5 LIMIT 10;`)
+	require.NoError(t, err)
+
+	require.Len(t, q.Path.Nodes, 3)
+	require.Equal(t, &NodePattern{
+		Var:    "a",
+		Labels: []string{"Person"},
+		Props:  []*Property{{Name: "name", Value: &Value{Kind: StringValue, Val: "Alice"}}},
+	}, q.Path.Nodes[0])
+	require.Equal(t, &NodePattern{Var: "b"}, q.Path.Nodes[1])
+	require.Equal(t, []*RelPattern{
+		{Var: "r", Type: "KNOWS"},
+		{Type: "OWNS", Reverse: true},
+	}, q.Path.Rels)
+
+	require.Equal(t, &BoolExpr{
+		Op: "AND",
+		Left: &Comparison{Var: "a", Prop: "age", Op: ">=",
+			Value: &Value{Kind: NumberValue, Val: "30"}},
+		Right: &BoolExpr{
+			Op: "OR",
+			Left: &Comparison{Var: "b", Prop: "name", Op: "STARTS WITH",
+				Value: &Value{Kind: StringValue, Val: "B"}},
+			Right: &NotExpr{Expr: &Comparison{Var: "c", Prop: "name", Op: "IS NULL"}},
+		},
+	}, q.Where)
+
+	require.Equal(t, []*ReturnItem{
+		{Var: "a", Prop: "name", Alias: "name"},
+		{Var: "r", Prop: "since"},
+		{Var: "b"},
+		{Var: "c", Prop: "name"},
+	}, q.Return)
+	require.Equal(t, []*SortItem{{Var: "a", Prop: "name", Desc: true}}, q.OrderBy)
+	require.Equal(t, 5, q.Skip)
+	require.Equal(t, 10, q.Limit)
+}
+
+func TestParseValues(t *testing.T) {
+	q, err := Parse(`MATCH (n) WHERE n.x IN [1, -2.5, true, $p, 'it\'s'] RETURN *`)
+	require.NoError(t, err)
+	require.Equal(t, &Comparison{Var: "n", Prop: "x", Op: "IN", Value: &Value{
+		Kind: ListValue,
+		List: []*Value{
+			{Kind: NumberValue, Val: "1"},
+			{Kind: NumberValue, Val: "-2.5"},
+			{Kind: BoolValue, Val: "true"},
+			{Kind: ParamValue, Val: "p"},
+			{Kind: StringValue, Val: "it's"},
+		},
+	}}, q.Where)
+	require.Equal(t, []*ReturnItem{{Var: "*"}}, q.Return)
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []string{
+		`CREATE (n:Person) RETURN n`,
+		`MATCH (n) SET n.name = 'a' RETURN n`,
+		`MATCH (n) DETACH DELETE n`,
+		`OPTIONAL MATCH (n) RETURN n`,
+		`MATCH (n) WITH n RETURN n`,
+		`MATCH (a), (b) RETURN a`,
+		`MATCH (a)-->(b) RETURN a`,
+		`MATCH (a)-[:A|B]->(b) RETURN a`,
+		`MATCH (a)-[:A*1..3]->(b) RETURN a`,
+		`MATCH (a)-[:A]-(b) RETURN a`,
+		`MATCH (a) RETURN DISTINCT a`,
+		`MATCH (a) RETURN count(a)`,
+		`MATCH (a) RETURN a LIMIT -1`,
+		`MATCH (a RETURN a`,
+		`MATCH (a) WHERE a.name = RETURN a`,
+		`MATCH (a) RETURN a.name = 'x'`,
+		`MATCH (a) WHERE a.name = 'x RETURN a`,
+	}
+	for _, query := range tests {
+		_, err := Parse(query)
+		require.Error(t, err, query)
+	}
+}
@@ -0,0 +1,482 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cypher
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ToDQL translates a read-only openCypher query into a DQL query.
+//
+// The first node of the MATCH pattern is the root of the DQL query, and each relationship is a
+// nested block, named after the variable of the node it leads to. Labels are matched against
+// dgraph.type, and relationships pointing to the left are followed through reverse edges. So the
+// results are nested along the pattern rather than returned as rows. The parameters of the query
+// become DQL variables of type string, to be passed along with the DQL query.
+func ToDQL(query string) (string, error) {
+	q, err := Parse(query)
+	if err != nil {
+		return "", err
+	}
+	return Translate(q)
+}
+
+// block is a block of the DQL query, for a node of the pattern.
+type block struct {
+	node *NodePattern
+	// rel is the relationship leading to the node, nil for the root.
+	rel *RelPattern
+
+	filters      []string
+	facetFilters []string
+	fields       []string
+	facets       []string
+	expand       bool
+}
+
+type translator struct {
+	q      *Query
+	blocks []*block
+	// nodes and rels map the variables to the blocks of their nodes, and of the nodes their
+	// relationships lead to.
+	nodes  map[string]*block
+	rels   map[string]*block
+	params map[string]bool
+}
+
+// Translate translates a parsed openCypher query into a DQL query. See ToDQL.
+func Translate(q *Query) (string, error) {
+	t := &translator{
+		q:      q,
+		nodes:  make(map[string]*block),
+		rels:   make(map[string]*block),
+		params: make(map[string]bool),
+	}
+	if err := t.collect(); err != nil {
+		return "", err
+	}
+	return t.write()
+}
+
+func (t *translator) collect() error {
+	path := t.q.Path
+	for i, node := range path.Nodes {
+		b := &block{node: node}
+		if i > 0 {
+			b.rel = path.Rels[i-1]
+		}
+		t.blocks = append(t.blocks, b)
+		if err := t.addVar(node.Var, b, t.nodes); err != nil {
+			return err
+		}
+		if b.rel != nil {
+			if err := t.addVar(b.rel.Var, b, t.rels); err != nil {
+				return err
+			}
+		}
+
+		// The first label of the root is matched by the root function.
+		for j, label := range node.Labels {
+			if i > 0 || j > 0 {
+				b.filters = append(b.filters, "type("+dqlName(label)+")")
+			}
+		}
+		for _, prop := range node.Props {
+			f, err := t.filter(&Comparison{Prop: prop.Name, Op: "=", Value: prop.Value}, false)
+			if err != nil {
+				return err
+			}
+			b.filters = append(b.filters, f)
+		}
+		if b.rel != nil {
+			for _, prop := range b.rel.Props {
+				f, err := t.filter(&Comparison{Prop: prop.Name, Op: "=", Value: prop.Value}, true)
+				if err != nil {
+					return err
+				}
+				b.facetFilters = append(b.facetFilters, f)
+			}
+		}
+	}
+
+	if t.q.Where != nil {
+		for _, cond := range conjuncts(t.q.Where) {
+			vars := make(map[string]bool)
+			exprVars(cond, vars)
+			if len(vars) != 1 {
+				return errors.New("the conditions of WHERE must each refer to a single variable")
+			}
+			var v string
+			for v = range vars {
+			}
+			if b, ok := t.nodes[v]; ok {
+				f, err := t.filter(cond, false)
+				if err != nil {
+					return err
+				}
+				b.filters = append(b.filters, f)
+			} else if b, ok := t.rels[v]; ok {
+				f, err := t.filter(cond, true)
+				if err != nil {
+					return err
+				}
+				b.facetFilters = append(b.facetFilters, f)
+			} else {
+				return errors.Errorf("variable %s is not defined", v)
+			}
+		}
+	}
+
+	for _, item := range t.q.Return {
+		if err := t.addReturn(item); err != nil {
+			return err
+		}
+	}
+	for _, item := range t.q.OrderBy {
+		if b, ok := t.nodes[item.Var]; !ok || b != t.blocks[0] {
+			return errors.Errorf("ORDER BY only supports the properties of the first node "+
+				"of the pattern, got %s.%s", item.Var, item.Prop)
+		}
+	}
+	return nil
+}
+
+func (t *translator) addVar(v string, b *block, vars map[string]*block) error {
+	if v == "" {
+		return nil
+	}
+	if !aliasName.MatchString(v) {
+		return errors.Errorf("variable %s can't be used as a DQL alias", v)
+	}
+	if _, ok := t.nodes[v]; ok {
+		return errors.Errorf("variable %s is defined more than once", v)
+	}
+	if _, ok := t.rels[v]; ok {
+		return errors.Errorf("variable %s is defined more than once", v)
+	}
+	vars[v] = b
+	return nil
+}
+
+func (t *translator) addReturn(item *ReturnItem) error {
+	if item.Var == "*" {
+		for _, b := range t.blocks {
+			if b.node.Var != "" {
+				b.expand = true
+			}
+			if b.rel != nil && b.rel.Var != "" && len(b.facets) == 0 {
+				b.facets = append(b.facets, "")
+			}
+		}
+		return nil
+	}
+	if b, ok := t.nodes[item.Var]; ok {
+		if item.Prop == "" {
+			b.expand = true
+			return nil
+		}
+		field := dqlName(item.Prop)
+		if item.Alias != "" {
+			field = dqlName(item.Alias) + " : " + field
+		}
+		b.fields = append(b.fields, field)
+		return nil
+	}
+	if b, ok := t.rels[item.Var]; ok {
+		// An empty facet stands for all the facets.
+		field := ""
+		if item.Prop != "" {
+			field = dqlName(item.Prop)
+			if item.Alias != "" {
+				field = dqlName(item.Alias) + ": " + field
+			}
+		}
+		b.facets = append(b.facets, field)
+		return nil
+	}
+	return errors.Errorf("variable %s is not defined", item.Var)
+}
+
+// conjuncts splits the expression into the expressions joined by AND.
+func conjuncts(expr Expr) []Expr {
+	if b, ok := expr.(*BoolExpr); ok && b.Op == "AND" {
+		return append(conjuncts(b.Left), conjuncts(b.Right)...)
+	}
+	return []Expr{expr}
+}
+
+func exprVars(expr Expr, vars map[string]bool) {
+	switch e := expr.(type) {
+	case *BoolExpr:
+		exprVars(e.Left, vars)
+		exprVars(e.Right, vars)
+	case *NotExpr:
+		exprVars(e.Expr, vars)
+	case *Comparison:
+		vars[e.Var] = true
+	}
+}
+
+var dqlOps = map[string]string{"=": "eq", "<": "lt", "<=": "le", ">": "gt", ">=": "ge"}
+
+// filter returns the DQL filter of the expression, for the facets of an edge if facets is set.
+func (t *translator) filter(expr Expr, facets bool) (string, error) {
+	switch e := expr.(type) {
+	case *BoolExpr:
+		left, err := t.filter(e.Left, facets)
+		if err != nil {
+			return "", err
+		}
+		right, err := t.filter(e.Right, facets)
+		if err != nil {
+			return "", err
+		}
+		return "(" + left + " " + e.Op + " " + right + ")", nil
+	case *NotExpr:
+		f, err := t.filter(e.Expr, facets)
+		if err != nil {
+			return "", err
+		}
+		return "NOT (" + f + ")", nil
+	case *Comparison:
+		return t.comparison(e, facets)
+	default:
+		return "", errors.Errorf("unexpected expression %T", expr)
+	}
+}
+
+func (t *translator) comparison(c *Comparison, facets bool) (string, error) {
+	pred := dqlName(c.Prop)
+	switch c.Op {
+	case "=", "<", "<=", ">", ">=":
+		val, err := t.value(c.Value)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s(%s, %s)", dqlOps[c.Op], pred, val), nil
+	case "<>":
+		val, err := t.value(c.Value)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("NOT eq(%s, %s)", pred, val), nil
+	case "IN":
+		if facets {
+			// The facets can't be compared to lists.
+			var fs []string
+			for _, v := range c.Value.List {
+				val, err := t.value(v)
+				if err != nil {
+					return "", err
+				}
+				fs = append(fs, fmt.Sprintf("eq(%s, %s)", pred, val))
+			}
+			if len(fs) == 0 {
+				return "", errors.Errorf("IN needs a list which isn't empty for %s", c.Prop)
+			}
+			return "(" + strings.Join(fs, " OR ") + ")", nil
+		}
+		val, err := t.value(c.Value)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("eq(%s, %s)", pred, val), nil
+	}
+
+	if facets {
+		return "", errors.Errorf("%s isn't supported for the properties of relationships", c.Op)
+	}
+	switch c.Op {
+	case "IS NULL":
+		return fmt.Sprintf("NOT has(%s)", pred), nil
+	case "IS NOT NULL":
+		return fmt.Sprintf("has(%s)", pred), nil
+	case "STARTS WITH", "CONTAINS":
+		if c.Value.Kind != StringValue {
+			return "", errors.Errorf("%s needs a string for %s", c.Op, c.Prop)
+		}
+		// Regular expressions need a trigram index on the predicate.
+		re := regexp.QuoteMeta(c.Value.Val)
+		if c.Op == "STARTS WITH" {
+			re = "^" + re
+		}
+		return fmt.Sprintf("regexp(%s, /%s/)", pred, strings.ReplaceAll(re, "/", `\/`)), nil
+	}
+	return "", errors.Errorf("unsupported operator %s", c.Op)
+}
+
+func (t *translator) value(v *Value) (string, error) {
+	switch v.Kind {
+	case StringValue:
+		return strconv.Quote(v.Val), nil
+	case NumberValue, BoolValue:
+		return v.Val, nil
+	case ParamValue:
+		t.params[v.Val] = true
+		return "$" + v.Val, nil
+	case ListValue:
+		vals := make([]string, 0, len(v.List))
+		for _, e := range v.List {
+			val, err := t.value(e)
+			if err != nil {
+				return "", err
+			}
+			vals = append(vals, val)
+		}
+		return "[" + strings.Join(vals, ", ") + "]", nil
+	default:
+		return "", errors.Errorf("unexpected value %v", v.Val)
+	}
+}
+
+var (
+	simpleName = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_.]*$`)
+	aliasName  = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+)
+
+// dqlName returns the name as a DQL predicate or type.
+func dqlName(name string) string {
+	if simpleName.MatchString(name) {
+		return name
+	}
+	return "<" + name + ">"
+}
+
+// rootFunc returns the function of the root block. It matches the first label of the root, or
+// the first property it's compared to if it has no label.
+func (t *translator) rootFunc() (string, error) {
+	root := t.blocks[0]
+	if len(root.node.Labels) > 0 {
+		return "type(" + dqlName(root.node.Labels[0]) + ")", nil
+	}
+	for i, f := range root.filters {
+		if strings.HasPrefix(f, "eq(") {
+			root.filters = append(root.filters[:i:i], root.filters[i+1:]...)
+			return f, nil
+		}
+	}
+	return "", errors.New("the first node of the pattern needs a label, or a property " +
+		"compared with =")
+}
+
+func (t *translator) write() (string, error) {
+	fn, err := t.rootFunc()
+	if err != nil {
+		return "", err
+	}
+	args := []string{"func: " + fn}
+	for _, item := range t.q.OrderBy {
+		order := "orderasc"
+		if item.Desc {
+			order = "orderdesc"
+		}
+		args = append(args, order+": "+dqlName(item.Prop))
+	}
+	if t.q.Limit > 0 {
+		args = append(args, "first: "+strconv.Itoa(t.q.Limit))
+	}
+	if t.q.Skip > 0 {
+		args = append(args, "offset: "+strconv.Itoa(t.q.Skip))
+	}
+
+	var sb strings.Builder
+	sb.WriteString("query cypher")
+	if len(t.params) > 0 {
+		params := make([]string, 0, len(t.params))
+		for p := range t.params {
+			params = append(params, "$"+p+": string")
+		}
+		sort.Strings(params)
+		sb.WriteString("(" + strings.Join(params, ", ") + ")")
+	}
+	sb.WriteString(" {\n")
+	name := t.blocks[0].node.Var
+	if name == "" {
+		name = "q"
+	}
+	fmt.Fprintf(&sb, "  %s(%s)", name, strings.Join(args, ", "))
+	t.writeBlock(&sb, 0, 1)
+	sb.WriteString("}\n")
+	return sb.String(), nil
+}
+
+func (t *translator) writeBlock(sb *strings.Builder, i, depth int) {
+	b := t.blocks[i]
+	if len(b.filters) > 0 {
+		sb.WriteString(" @filter(" + strings.Join(b.filters, " AND ") + ")")
+	}
+	if len(b.facetFilters) > 0 {
+		sb.WriteString(" @facets(" + strings.Join(b.facetFilters, " AND ") + ")")
+	}
+	if len(b.facets) > 0 {
+		var facets []string
+		for _, f := range b.facets {
+			if f == "" {
+				// All the facets are returned.
+				facets = nil
+				break
+			}
+			facets = append(facets, f)
+		}
+		if len(facets) == 0 {
+			sb.WriteString(" @facets")
+		} else {
+			sb.WriteString(" @facets(" + strings.Join(facets, ", ") + ")")
+		}
+	}
+	// The nodes without the rest of the pattern are removed. The edge to the next node is
+	// required at each level, which overrides the edge required by the level above.
+	var next *block
+	if i+1 < len(t.blocks) {
+		next = t.blocks[i+1]
+		sb.WriteString(" @cascade(" + edgeName(next.rel) + ")")
+	} else if i > 0 {
+		sb.WriteString(" @cascade(uid)")
+	}
+	sb.WriteString(" {\n")
+
+	indent := strings.Repeat("  ", depth+1)
+	sb.WriteString(indent + "uid\n")
+	if b.expand {
+		sb.WriteString(indent + "expand(_all_)\n")
+	}
+	for _, f := range b.fields {
+		sb.WriteString(indent + f + "\n")
+	}
+	if next != nil {
+		sb.WriteString(indent)
+		if next.node.Var != "" {
+			sb.WriteString(next.node.Var + " : ")
+		}
+		sb.WriteString(edgeName(next.rel))
+		t.writeBlock(sb, i+1, depth+1)
+	}
+	sb.WriteString(strings.Repeat("  ", depth) + "}\n")
+}
+
+func edgeName(rel *RelPattern) string {
+	if rel.Reverse {
+		return "~" + dqlName(rel.Type)
+	}
+	return dqlName(rel.Type)
+}
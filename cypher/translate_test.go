@@ -0,0 +1,117 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cypher
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/dgraph/gql"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToDQL(t *testing.T) {
+	tests := []struct {
+		cypher string
+		dql    string
+	}{
+		{
+			cypher: `MATCH (p:Person) RETURN p.name ORDER BY p.name LIMIT 10`,
+			dql: `query cypher {
+  p(func: type(Person), orderasc: name, first: 10) {
+    uid
+    name
+  }
+}
+`,
+		},
+		{
+			cypher: `MATCH (p {name: $name}) WHERE p.age > 30 OR p.age IS NULL RETURN p`,
+			dql: `query cypher($name: string) {
+  p(func: eq(name, $name)) @filter((gt(age, 30) OR NOT has(age))) {
+    uid
+    expand(_all_)
+  }
+}
+`,
+		},
+		{
+			cypher: `MATCH (a:Person:Employee)-[r:KNOWS {close: true}]->(b:Person)<-[:OWNS]-(c)
+				WHERE r.since < 2010 AND b.name STARTS WITH 'B/' AND c.kind IN ['cat', 'dog']
+				RETURN a.name AS person, r.since, b, c.kind
+				ORDER BY a.age DESC SKIP 20 LIMIT 10`,
+			dql: `query cypher {
+  a(func: type(Person), orderdesc: age, first: 10, offset: 20) @filter(type(Employee)) @cascade(KNOWS) {
+    uid
+    person : name
+    b : KNOWS @filter(type(Person) AND regexp(name, /^B\//)) @facets(eq(close, true) AND lt(since, 2010)) @facets(since) @cascade(~OWNS) {
+      uid
+      expand(_all_)
+      c : ~OWNS @filter(eq(kind, ["cat", "dog"])) @cascade(uid) {
+        uid
+        kind
+      }
+    }
+  }
+}
+`,
+		},
+		{
+			cypher: "MATCH (:`Some Type`)-[:`has part`]->() RETURN *",
+			dql: `query cypher {
+  q(func: type(<Some Type>)) @cascade(<has part>) {
+    uid
+    <has part> @cascade(uid) {
+      uid
+    }
+  }
+}
+`,
+		},
+	}
+	for _, tc := range tests {
+		dql, err := ToDQL(tc.cypher)
+		require.NoError(t, err, tc.cypher)
+		require.Equal(t, tc.dql, dql, tc.cypher)
+	}
+}
+
+func TestToDQLParses(t *testing.T) {
+	dql, err := ToDQL(`MATCH (a:Person)-[r:follows]->(b) WHERE a.age >= $age AND r.weight <> 1
+		AND NOT (b.name CONTAINS 'x' OR b.age < 5) RETURN a, r, b.name AS name`)
+	require.NoError(t, err)
+	_, err = gql.Parse(gql.Request{Str: dql, Variables: map[string]string{"$age": "18"}})
+	require.NoError(t, err, dql)
+}
+
+func TestToDQLErrors(t *testing.T) {
+	tests := []string{
+		`MATCH (n) RETURN n`,
+		`MATCH (n:A)-[:B]->(m) WHERE n.x = m.x RETURN n`,
+		`MATCH (n:A)-[:B]->(m) WHERE n.x = 1 OR m.x = 1 RETURN n`,
+		`MATCH (n:A)-[:B]->(n) RETURN n`,
+		`MATCH (n:A) RETURN m`,
+		"MATCH (`n m`:A) RETURN `n m`",
+		`MATCH (n:A) WHERE m.x = 1 RETURN n`,
+		`MATCH (n:A)-[:B]->(m) RETURN n ORDER BY m.name`,
+		`MATCH (n:A)-[r:B]->(m) WHERE r.x IS NULL RETURN n`,
+		`MATCH (n:A) WHERE n.name STARTS WITH $p RETURN n`,
+	}
+	for _, query := range tests {
+		_, err := ToDQL(query)
+		require.Error(t, err, query)
+	}
+}
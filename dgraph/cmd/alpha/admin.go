@@ -17,18 +17,22 @@
 package alpha
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/dgraph-io/dgraph/edgraph"
 	"github.com/dgraph-io/dgraph/graphql/admin"
 
 	"github.com/dgraph-io/dgraph/graphql/schema"
 	"github.com/dgraph-io/dgraph/worker"
 	"github.com/dgraph-io/dgraph/x"
+	"google.golang.org/grpc/metadata"
 )
 
 type allowedMethods map[string]bool
@@ -77,6 +81,9 @@ func getAdminMux() *http.ServeMux {
 	adminMux := http.NewServeMux()
 	adminMux.Handle("/admin/schema", adminAuthHandler(http.HandlerFunc(adminSchemaHandler)))
 	adminMux.Handle("/admin/schema/validate", schemaValidateHandler())
+	adminMux.Handle("/admin/schema/explore", allowedMethodsHandler(allowedMethods{
+		http.MethodGet: true,
+	}, adminAuthHandler(http.HandlerFunc(schemaExploreHandler))))
 	adminMux.Handle("/admin/shutdown", allowedMethodsHandler(allowedMethods{http.MethodGet: true},
 		adminAuthHandler(http.HandlerFunc(shutDownHandler))))
 	adminMux.Handle("/admin/draining", allowedMethodsHandler(allowedMethods{
@@ -87,6 +94,8 @@ func getAdminMux() *http.ServeMux {
 		http.MethodGet: true,
 		http.MethodPut: true,
 	}, adminAuthHandler(http.HandlerFunc(memoryLimitHandler))))
+	adminMux.Handle("/admin/bundle", allowedMethodsHandler(allowedMethods{http.MethodGet: true},
+		adminAuthHandler(http.HandlerFunc(supportBundleHandler))))
 	return adminMux
 }
 
@@ -224,3 +233,107 @@ func memoryLimitGetHandler(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 	}
 }
+
+// supportBundleHandler collects a support bundle of this Alpha, see worker.WriteSupportBundle.
+// The cpu parameter is the duration of the CPU profile, e.g. 10s. If the destination parameter
+// is set, e.g. to s3:///bucket/path, the bundle is written there instead of being returned.
+func supportBundleHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := metadata.NewIncomingContext(r.Context(), metadata.New(nil))
+	ctx = x.AttachAccessJwt(ctx, r)
+	ctx = x.AttachJWTNamespace(ctx)
+	if err := edgraph.AuthGuardianOfTheGalaxy(ctx); err != nil {
+		x.SetStatus(w, x.ErrorUnauthorized, err.Error())
+		return
+	}
+
+	var opts worker.SupportBundleOptions
+	if cpu := r.URL.Query().Get("cpu"); cpu != "" {
+		d, err := time.ParseDuration(cpu)
+		if err != nil {
+			x.SetStatus(w, x.ErrorInvalidRequest, "Invalid cpu duration: "+err.Error())
+			return
+		}
+		opts.CPUProfile = d
+	}
+	slow, err := json.MarshalIndent(edgraph.RecentSlowQueries(), "", "  ")
+	if err != nil {
+		x.SetStatus(w, x.Error, err.Error())
+		return
+	}
+	opts.Extra = map[string][]byte{"slow_queries.json": slow}
+
+	var buf bytes.Buffer
+	if err := worker.WriteSupportBundle(ctx, &buf, opts); err != nil {
+		x.SetStatus(w, x.Error, err.Error())
+		return
+	}
+	name := worker.SupportBundleName(time.Now())
+
+	if dest := r.URL.Query().Get("destination"); dest != "" {
+		path, err := worker.UploadSupportBundle(dest, name, buf.Bytes())
+		if err != nil {
+			x.SetStatus(w, x.Error, "Unable to upload the support bundle: "+err.Error())
+			return
+		}
+		x.SetStatus(w, x.Success, "Support bundle written to "+path)
+		return
+	}
+	w.Header().Set("Content-Type", "application/gzip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name))
+	x.Check2(w.Write(buf.Bytes()))
+}
+
+// schemaExploreHandler returns the schema of the namespace along with the stats of its predicates
+// and types, see worker.GetSchemaStats. The predicates parameter is a comma separated list of the
+// predicates to return, the scan parameter bounds the number of nodes read per predicate to
+// estimate its cardinality, and the samples parameter is the number of sample values returned per
+// predicate.
+func schemaExploreHandler(w http.ResponseWriter, r *http.Request) {
+	x.AddCorsHeaders(w)
+	ctx := metadata.NewIncomingContext(r.Context(), metadata.New(nil))
+	ctx = x.AttachAccessJwt(ctx, r)
+	ctx = x.AttachJWTNamespace(ctx)
+	// The samples are read from the data of all the predicates.
+	if err := edgraph.AuthorizeGuardians(ctx); err != nil {
+		x.SetStatus(w, x.ErrorUnauthorized, err.Error())
+		return
+	}
+
+	opts := worker.SchemaStatsOptions{
+		ScanLimit:  worker.DefaultStatsScanLimit,
+		SampleSize: worker.DefaultStatsSampleSize,
+	}
+	params := r.URL.Query()
+	if preds := params.Get("predicates"); preds != "" {
+		for _, pred := range strings.Split(preds, ",") {
+			if pred = strings.TrimSpace(pred); pred != "" {
+				opts.Predicates = append(opts.Predicates, pred)
+			}
+		}
+	}
+	for name, val := range map[string]*int{"scan": &opts.ScanLimit, "samples": &opts.SampleSize} {
+		s := params.Get(name)
+		if s == "" {
+			continue
+		}
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 0 {
+			x.SetStatus(w, x.ErrorInvalidRequest, fmt.Sprintf("Invalid value for %s: %q", name, s))
+			return
+		}
+		*val = n
+	}
+
+	stats, err := worker.GetSchemaStats(ctx, opts)
+	if err != nil {
+		x.SetStatus(w, x.Error, err.Error())
+		return
+	}
+	b, err := json.Marshal(stats)
+	if err != nil {
+		x.SetStatus(w, x.Error, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	x.Check2(w.Write(b))
+}
@@ -0,0 +1,91 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package alpha
+
+import (
+	"context"
+	"time"
+
+	"github.com/dgraph-io/dgraph/edgraph"
+	"github.com/dgraph-io/dgraph/posting"
+	"github.com/dgraph-io/dgraph/schema"
+	"github.com/dgraph-io/dgraph/worker"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/dgraph-io/ristretto/z"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+)
+
+// StartEmbedded starts an Alpha in this process, configured by Alpha.Conf, for a program
+// embedding Dgraph. Unlike dgraph alpha, it serves neither HTTP nor gRPC: the program calls
+// edgraph.Server directly, and the other nodes reach the Alpha in memory. It returns once the
+// Alpha serves requests, and the returned function stops it.
+func StartEmbedded(ctx context.Context) (func(), error) {
+	telemetry := z.NewSuperFlag(Alpha.Conf.GetString("telemetry")).MergeAndCheckDefault(
+		x.TelemetryDefaults)
+	postingListCacheSize, err := setupConfig(telemetry)
+	if err != nil {
+		return nil, err
+	}
+
+	worker.InitServerState()
+	worker.InitTasks()
+	schema.Init(worker.State.Pstore)
+	posting.Init(worker.State.Pstore, postingListCacheSize)
+	worker.Init(worker.State.Pstore)
+
+	go worker.RunLocalServer()
+	updaters := z.NewCloser(4)
+	started := make(chan struct{})
+	go func() {
+		worker.StartRaftNodes(worker.State.WALstore, false)
+		close(started)
+
+		go edgraph.RefreshRuntimeConfig(updaters)
+		go edgraph.RefreshColocations(updaters)
+		edgraph.ResetAcl(updaters)
+		edgraph.RefreshAcls(updaters)
+	}()
+
+	stop := func() {
+		updaters.Signal()
+		worker.BlockingStop()
+		glog.Infoln("worker stopped.")
+		worker.State.Dispose()
+		posting.Cleanup()
+		updaters.Wait()
+		glog.Infoln("Embedded Alpha stopped.")
+	}
+
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-started:
+			if x.HealthCheck() == nil {
+				return stop, nil
+			}
+		default:
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			// The Raft nodes can't be stopped before they have started.
+			return nil, errors.Wrapf(ctx.Err(), "while waiting for the Alpha to start")
+		}
+	}
+}
@@ -25,7 +25,9 @@ import (
 	"io"
 	"io/ioutil"
 	"mime"
+	"net"
 	"net/http"
+	"net/url"
 	"sort"
 	"strconv"
 	"strings"
@@ -35,10 +37,12 @@ import (
 	"github.com/dgraph-io/dgraph/graphql/admin"
 
 	"github.com/dgraph-io/dgo/v210/protos/api"
+	"github.com/dgraph-io/dgraph/cypher"
 	"github.com/dgraph-io/dgraph/edgraph"
 	"github.com/dgraph-io/dgraph/gql"
 	"github.com/dgraph-io/dgraph/graphql/schema"
 	"github.com/dgraph-io/dgraph/query"
+	"github.com/dgraph-io/dgraph/worker"
 	"github.com/dgraph-io/dgraph/x"
 	"github.com/gogo/protobuf/jsonpb"
 	"github.com/golang/glog"
@@ -162,6 +166,16 @@ func queryHandler(w http.ResponseWriter, r *http.Request) {
 		x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
 		return
 	}
+	isLinearizable, err := parseBool(r, "linearizable")
+	if err != nil {
+		x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
+		return
+	}
+	expandSample, err := parseUint64(r, "expandSample")
+	if err != nil {
+		x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
+		return
+	}
 	startTs, err := parseUint64(r, "startTs")
 	hash := r.URL.Query().Get("hash")
 	if err != nil {
@@ -177,6 +191,9 @@ func queryHandler(w http.ResponseWriter, r *http.Request) {
 	var params struct {
 		Query     string            `json:"query"`
 		Variables map[string]string `json:"variables"`
+		// Cypher holds a read-only openCypher query, which is run instead of the DQL query.
+		// This is experimental.
+		Cypher string `json:"cypher"`
 	}
 
 	contentType := r.Header.Get("Content-Type")
@@ -199,15 +216,48 @@ func queryHandler(w http.ResponseWriter, r *http.Request) {
 		}
 	case "application/graphql+-", "application/dql":
 		params.Query = string(body)
+	case "application/cypher":
+		params.Cypher = string(body)
 	default:
 		x.SetStatus(w, x.ErrorInvalidRequest, "Unsupported Content-Type. "+
-			"Supported content types are application/json, application/graphql+-,application/dql")
+			"Supported content types are application/json, application/graphql+-,application/dql,"+
+			"application/cypher")
 		return
 	}
 
+	isCypher := params.Cypher != ""
+	if isCypher {
+		dql, err := cypher.ToDQL(params.Cypher)
+		if err != nil {
+			x.SetStatus(w, x.ErrorInvalidRequest, errors.Wrapf(err,
+				"while translating openCypher query").Error())
+			return
+		}
+		params.Query = dql
+		// The parameters of the openCypher query are the variables of the DQL query.
+		vars := make(map[string]string, len(params.Variables))
+		for k, v := range params.Variables {
+			if !strings.HasPrefix(k, "$") {
+				k = "$" + k
+			}
+			vars[k] = v
+		}
+		params.Variables = vars
+	}
+
 	ctx := context.WithValue(r.Context(), query.DebugKey, isDebugMode)
 	ctx = x.AttachAccessJwt(ctx, r)
 	ctx = x.AttachRemoteIP(ctx, r)
+	ctx = x.AttachAsOf(ctx, r)
+	ctx = x.AttachPreparedQuery(ctx, r)
+	ctx = x.AttachSavepoint(ctx, r)
+	ctx = x.AttachConsistencyToken(ctx, r)
+	if isLinearizable {
+		ctx = x.AttachLinearizable(ctx)
+	}
+	if expandSample > 0 {
+		ctx = x.AttachExpandSample(ctx, expandSample)
+	}
 
 	if queryTimeout != 0 {
 		var cancel context.CancelFunc
@@ -220,6 +270,8 @@ func queryHandler(w http.ResponseWriter, r *http.Request) {
 		Query:   params.Query,
 		StartTs: startTs,
 		Hash:    hash,
+		// The openCypher queries are translated into read-only queries.
+		ReadOnly: isCypher,
 	}
 
 	if req.StartTs == 0 {
@@ -254,27 +306,71 @@ func queryHandler(w http.ResponseWriter, r *http.Request) {
 	if rdfResponse {
 		req.RespFormat = api.Request_RDF
 	}
+	// If graph is set true, the response lists the nodes and the edges of the result.
+	graphResponse, err := parseBool(r, "graph")
+	if err != nil {
+		x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
+		return
+	}
+	if graphResponse {
+		if rdfResponse {
+			x.SetStatus(w, x.ErrorInvalidRequest, "rdf and graph can't be set together")
+			return
+		}
+		ctx = context.WithValue(ctx, query.GraphKey, true)
+	}
+	// If trace is set true, the server_latency breaks down the time spent in each group,
+	// predicate and phase of the query.
+	traceLatency, err := parseBool(r, "trace")
+	if err != nil {
+		x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
+		return
+	}
+	var trace *query.LatencyTrace
+	if traceLatency {
+		trace = query.NewLatencyTrace()
+		ctx = context.WithValue(ctx, query.LatencyTraceKey, trace)
+	}
+	// The clients accepting MessagePack get the response in MessagePack, unless they asked for
+	// RDF.
+	msgpackResponse := !rdfResponse && acceptsMsgpack(r)
+	if msgpackResponse {
+		ctx = context.WithValue(ctx, query.MsgpackKey, true)
+	}
 
 	// Core processing happens here.
 	resp, err := (&edgraph.Server{}).Query(ctx, &req)
 	if err != nil {
-		x.SetStatusWithData(w, x.ErrorInvalidRequest, err.Error())
+		x.SetErrorStatusWithData(w, x.ErrorInvalidRequest, err)
 		return
 	}
 	// Add cost to the header.
 	w.Header().Set(x.DgraphCostHeader, fmt.Sprint(resp.Metrics.NumUids["_total"]))
+	w.Header().Set(x.DgraphConsistencyTokenHeader, fmt.Sprint(edgraph.ConsistencyToken(resp.Txn)))
+	if warning := edgraph.TxnAgeWarning(resp.Txn.GetStartTs()); warning != "" {
+		w.Header().Set(x.DgraphTxnAgeWarningHeader, warning)
+	}
 
+	trace.AddPhase(query.PhaseJSONEncoding, time.Duration(resp.Latency.GetEncodingNs()))
 	e := query.Extensions{
 		Txn:     resp.Txn,
-		Latency: resp.Latency,
+		Latency: &query.ServerLatency{Latency: resp.Latency, LatencyTrace: trace},
 		Metrics: resp.Metrics,
 	}
+	if warning := edgraph.SampleWarning(ctx, resp); warning != "" {
+		e.Warnings = append(e.Warnings, warning)
+	}
 	js, err := json.Marshal(e)
 	if err != nil {
 		x.SetStatusWithData(w, x.Error, err.Error())
 		return
 	}
 
+	if msgpackResponse {
+		writeMsgpackResponse(w, r, resp.Json, js)
+		return
+	}
+
 	var out bytes.Buffer
 	writeEntry := func(key string, js []byte) {
 		x.Check2(out.WriteRune('"'))
@@ -300,6 +396,112 @@ func queryHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// msgpackMediaTypes are the media types under which clients accept a response in MessagePack.
+var msgpackMediaTypes = map[string]bool{
+	"application/msgpack":     true,
+	"application/x-msgpack":   true,
+	"application/vnd.msgpack": true,
+}
+
+// acceptsMsgpack tells whether the Accept header of the request lists a MessagePack media type.
+func acceptsMsgpack(r *http.Request) bool {
+	for _, accept := range r.Header.Values("Accept") {
+		for _, mt := range strings.Split(accept, ",") {
+			mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(mt))
+			if err == nil && msgpackMediaTypes[mediaType] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// writeMsgpackResponse writes a MessagePack map with the data, already encoded in MessagePack, and
+// the extensions, given in JSON.
+func writeMsgpackResponse(w http.ResponseWriter, r *http.Request, data, extensions []byte) {
+	ext, err := query.JSONToMsgpack(extensions)
+	if err != nil {
+		x.SetStatusWithData(w, x.Error, err.Error())
+		return
+	}
+
+	var out bytes.Buffer
+	// A fixmap with 2 keys, followed by the keys as fixstr.
+	x.Check(out.WriteByte(0x82))
+	x.Check(out.WriteByte(0xa0 | byte(len("data"))))
+	x.Check2(out.WriteString("data"))
+	x.Check2(out.Write(data))
+	x.Check(out.WriteByte(0xa0 | byte(len("extensions"))))
+	x.Check2(out.WriteString("extensions"))
+	x.Check2(out.Write(ext))
+
+	w.Header().Set("Content-Type", "application/msgpack")
+	if _, err := x.WriteResponse(w, r, out.Bytes()); err != nil {
+		glog.Errorln("Unable to write response: ", err)
+	}
+}
+
+// sparqlHandler runs the SPARQL queries of the SPARQL 1.1 protocol. The query is either in the
+// query URL parameter of GET requests, or in the body of POST requests, as is or URL-encoded.
+func sparqlHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		x.AddCorsHeaders(w)
+		w.Header().Set("Content-Type", "application/json")
+	} else if commonHandler(w, r) {
+		return
+	}
+
+	q := r.URL.Query().Get("query")
+	if r.Method != http.MethodGet {
+		body := readRequest(w, r)
+		if body == nil {
+			return
+		}
+		mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if err != nil {
+			x.SetStatus(w, x.ErrorInvalidRequest, "Invalid Content-Type")
+			return
+		}
+		switch mediaType {
+		case "application/sparql-query":
+			q = string(body)
+		case "application/x-www-form-urlencoded":
+			form, err := url.ParseQuery(string(body))
+			if err != nil {
+				x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
+				return
+			}
+			q = form.Get("query")
+		default:
+			x.SetStatus(w, x.ErrorInvalidRequest, "Unsupported Content-Type. "+
+				"Supported content types are application/sparql-query, "+
+				"application/x-www-form-urlencoded")
+			return
+		}
+	}
+	if strings.TrimSpace(q) == "" {
+		x.SetStatus(w, x.ErrorInvalidRequest, "empty SPARQL query")
+		return
+	}
+
+	ctx := x.AttachAccessJwt(r.Context(), r)
+	ctx = x.AttachRemoteIP(ctx, r)
+	res, err := (&edgraph.Server{}).Sparql(ctx, q)
+	if err != nil {
+		x.SetErrorStatusWithData(w, x.ErrorInvalidRequest, err)
+		return
+	}
+	js, err := json.Marshal(res)
+	if err != nil {
+		x.SetStatusWithData(w, x.Error, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/sparql-results+json")
+	if _, err := x.WriteResponse(w, r, js); err != nil {
+		glog.Errorln("Unable to write response: ", err)
+	}
+}
+
 func mutationHandler(w http.ResponseWriter, r *http.Request) {
 	if commonHandler(w, r) {
 		return
@@ -316,6 +518,11 @@ func mutationHandler(w http.ResponseWriter, r *http.Request) {
 		x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
 		return
 	}
+	upsertByXid, err := parseBool(r, "upsertByXid")
+	if err != nil {
+		x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
+		return
+	}
 	body := readRequest(w, r)
 	if body == nil {
 		return
@@ -426,18 +633,28 @@ func mutationHandler(w http.ResponseWriter, r *http.Request) {
 	req.CommitNow = commitNow
 
 	ctx := x.AttachAccessJwt(context.Background(), r)
+	ctx = x.AttachSavepoint(ctx, r)
+	ctx = x.AttachIdempotencyKey(ctx, r)
+	ctx = x.AttachPreconditions(ctx, r)
+	if upsertByXid {
+		ctx = x.AttachUpsertByXid(ctx)
+	}
 	resp, err := (&edgraph.Server{}).Query(ctx, req)
 	if err != nil {
-		x.SetStatusWithData(w, x.ErrorInvalidRequest, err.Error())
+		x.SetErrorStatusWithData(w, x.ErrorInvalidRequest, err)
 		return
 	}
 	// Add cost to the header.
 	w.Header().Set(x.DgraphCostHeader, fmt.Sprint(resp.Metrics.NumUids["_total"]))
+	w.Header().Set(x.DgraphConsistencyTokenHeader, fmt.Sprint(edgraph.ConsistencyToken(resp.Txn)))
+	if warning := edgraph.TxnAgeWarning(resp.Txn.GetStartTs()); warning != "" {
+		w.Header().Set(x.DgraphTxnAgeWarningHeader, warning)
+	}
 
 	resp.Latency.ParsingNs = uint64(parseEnd.Sub(parseStart).Nanoseconds())
 	e := query.Extensions{
 		Txn:     resp.Txn,
-		Latency: resp.Latency,
+		Latency: &query.ServerLatency{Latency: resp.Latency},
 	}
 	sort.Strings(e.Txn.Keys)
 	sort.Strings(e.Txn.Preds)
@@ -502,7 +719,7 @@ func commitHandler(w http.ResponseWriter, r *http.Request) {
 		response, err = handleCommit(ctx, startTs, hash, reqText)
 	}
 	if err != nil {
-		x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
+		x.SetErrorStatus(w, x.ErrorInvalidRequest, err)
 		return
 	}
 
@@ -515,6 +732,189 @@ func commitHandler(w http.ResponseWriter, r *http.Request) {
 	_, _ = x.WriteResponse(w, r, js)
 }
 
+// prepareHandler handles /query/prepare?name=..., which prepares the DQL query given in the body
+// under the given name. The query is then run by sending the variables to
+// /query?prepared=name&preparedHash=hash, without the query.
+func prepareHandler(w http.ResponseWriter, r *http.Request) {
+	if commonHandler(w, r) {
+		return
+	}
+
+	name := r.URL.Query().Get("name")
+	if name == "" {
+		x.SetStatus(w, x.ErrorInvalidRequest, "name parameter is mandatory to prepare a query")
+		return
+	}
+	body := readRequest(w, r)
+	if body == nil {
+		return
+	}
+
+	ctx := x.AttachAccessJwt(context.Background(), r)
+	hash, err := (&edgraph.Server{}).PrepareQuery(ctx, name, string(body))
+	if err != nil {
+		x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
+		return
+	}
+
+	js, err := json.Marshal(map[string]interface{}{
+		"data": map[string]interface{}{
+			"code":    x.Success,
+			"message": "Done",
+			"name":    name,
+			"hash":    hash,
+		},
+	})
+	if err != nil {
+		x.SetStatusWithData(w, x.Error, err.Error())
+		return
+	}
+	_, _ = x.WriteResponse(w, r, js)
+}
+
+// queryJobsHandler handles /query/jobs. A POST submits the DQL query given in the body as a query
+// job, and returns the id of the job. A GET of /query/jobs?id=... returns the status of the job.
+func queryJobsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		x.AddCorsHeaders(w)
+		w.Header().Set("Content-Type", "application/json")
+		id := r.URL.Query().Get("id")
+		if id == "" {
+			x.SetStatus(w, x.ErrorInvalidRequest, "id parameter is mandatory to get a query job")
+			return
+		}
+		ctx := x.AttachAccessJwt(context.Background(), r)
+		st, err := (&edgraph.Server{}).QueryJob(ctx, id)
+		if err != nil {
+			x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
+			return
+		}
+		writeQueryJobStatus(w, r, st)
+		return
+	}
+	if commonHandler(w, r) {
+		return
+	}
+
+	body := readRequest(w, r)
+	if body == nil {
+		return
+	}
+	var params struct {
+		Query     string            `json:"query"`
+		Variables map[string]string `json:"variables"`
+	}
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		x.SetStatus(w, x.ErrorInvalidRequest, "Invalid Content-Type")
+		return
+	}
+	switch mediaType {
+	case "application/json":
+		if err := json.Unmarshal(body, &params); err != nil {
+			x.SetStatus(w, x.ErrorInvalidRequest, convertJSONError(string(body), err).Error())
+			return
+		}
+	case "application/graphql+-", "application/dql":
+		params.Query = string(body)
+	default:
+		x.SetStatus(w, x.ErrorInvalidRequest, "Unsupported Content-Type. "+
+			"Supported content types are application/json, application/graphql+-,application/dql")
+		return
+	}
+
+	// The job outlives the request, so its context isn't derived from the one of the request.
+	ctx := x.AttachAccessJwt(context.Background(), r)
+	ctx = x.AttachRemoteIP(ctx, r)
+	ctx = x.AttachAsOf(ctx, r)
+	req := &api.Request{Vars: params.Variables, Query: params.Query}
+	st, err := (&edgraph.Server{}).SubmitQueryJob(ctx, req)
+	if err != nil {
+		x.SetErrorStatusWithData(w, x.ErrorInvalidRequest, err)
+		return
+	}
+	writeQueryJobStatus(w, r, st)
+}
+
+// queryJobResultsHandler handles /query/jobs/results?id=...&offset=...&first=..., which returns
+// the page of the results of a done query job. The offset and first apply to each block of the
+// query, and all the results are returned if first isn't given.
+func queryJobResultsHandler(w http.ResponseWriter, r *http.Request) {
+	x.AddCorsHeaders(w)
+	w.Header().Set("Content-Type", "application/json")
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusBadRequest)
+		x.SetStatus(w, x.ErrorInvalidMethod, "Invalid method")
+		return
+	}
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		x.SetStatus(w, x.ErrorInvalidRequest, "id parameter is mandatory to get query job results")
+		return
+	}
+	offset, err := parseUint64(r, "offset")
+	if err != nil {
+		x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
+		return
+	}
+	first, err := parseUint64(r, "first")
+	if err != nil {
+		x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
+		return
+	}
+
+	ctx := x.AttachAccessJwt(context.Background(), r)
+	page, st, err := (&edgraph.Server{}).QueryJobResults(ctx, id, int(offset), int(first))
+	if err != nil {
+		x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
+		return
+	}
+	js, err := json.Marshal(map[string]interface{}{
+		"data":       page,
+		"extensions": map[string]interface{}{"job": st},
+	})
+	if err != nil {
+		x.SetStatusWithData(w, x.Error, err.Error())
+		return
+	}
+	_, _ = x.WriteResponse(w, r, js)
+}
+
+// cancelQueryJobHandler handles /query/jobs/cancel?id=..., which cancels a running query job, or
+// discards the results of a finished one.
+func cancelQueryJobHandler(w http.ResponseWriter, r *http.Request) {
+	if commonHandler(w, r) {
+		return
+	}
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		x.SetStatus(w, x.ErrorInvalidRequest, "id parameter is mandatory to cancel a query job")
+		return
+	}
+	ctx := x.AttachAccessJwt(context.Background(), r)
+	st, err := (&edgraph.Server{}).CancelQueryJob(ctx, id)
+	if err != nil {
+		x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
+		return
+	}
+	writeQueryJobStatus(w, r, st)
+}
+
+func writeQueryJobStatus(w http.ResponseWriter, r *http.Request, st *edgraph.QueryJobStatus) {
+	js, err := json.Marshal(map[string]interface{}{
+		"data": map[string]interface{}{
+			"code":    x.Success,
+			"message": "Done",
+			"job":     st,
+		},
+	})
+	if err != nil {
+		x.SetStatusWithData(w, x.Error, err.Error())
+		return
+	}
+	_, _ = x.WriteResponse(w, r, js)
+}
+
 func handleAbort(ctx context.Context, startTs uint64, hash string) (map[string]interface{}, error) {
 	tc := &api.TxnContext{
 		StartTs: startTs,
@@ -615,7 +1015,7 @@ func alterHandler(w http.ResponseWriter, r *http.Request) {
 	ctx = x.AttachAccessJwt(ctx, r)
 	ctx = x.AttachRemoteIP(ctx, r)
 	if _, err := (&edgraph.Server{}).Alter(ctx, op); err != nil {
-		x.SetStatus(w, x.Error, err.Error())
+		x.SetErrorStatus(w, x.Error, err)
 		return
 	}
 
@@ -647,6 +1047,22 @@ func adminSchemaHandler(w http.ResponseWriter, r *http.Request) {
 		}`,
 		Variables: map[string]interface{}{"sch": string(b)},
 	}
+	// With ?document=name, the body only replaces the document with that name.
+	if name := r.URL.Query().Get("document"); name != "" {
+		gqlReq.Query = `
+		mutation updateGqlSchema($name: String!, $sch: String!) {
+			updateGQLSchema(input: {
+				set: {
+					documents: [{name: $name, schema: $sch}]
+				}
+			}) {
+				gqlSchema {
+					id
+				}
+			}
+		}`
+		gqlReq.Variables["name"] = name
+	}
 
 	response := resolveWithAdminServer(gqlReq, r, adminServer)
 	if len(response.Errors) > 0 {
@@ -657,6 +1073,47 @@ func adminSchemaHandler(w http.ResponseWriter, r *http.Request) {
 	writeSuccessResponse(w, r)
 }
 
+// graphqlNamespacePath is the path of the GraphQL API of a given namespace, /graphql/ns/<ns>.
+const graphqlNamespacePath = "/graphql/ns/"
+
+// graphqlNamespace returns the namespace whose GraphQL API the request is addressed to, so that a
+// gateway can route the tenants by URL. The namespace is given by the /graphql/ns/<ns> path, or by
+// the hostname of the request if the namespaceHosts runtime option maps it to a namespace, and
+// otherwise by the access JWT. The access JWT of a request addressed by URL, if any, must belong to
+// the namespace of the URL.
+func graphqlNamespace(r *http.Request) (uint64, error) {
+	var ns uint64
+	if strings.HasPrefix(r.URL.Path, graphqlNamespacePath) {
+		path := strings.TrimPrefix(r.URL.Path, graphqlNamespacePath)
+		var err error
+		if ns, err = strconv.ParseUint(path, 0, 64); err != nil {
+			return 0, errors.Errorf("invalid namespace %q in the URL", path)
+		}
+	} else {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		var ok bool
+		if ns, ok = worker.NamespaceOfHost(host); !ok {
+			return x.ExtractNamespaceHTTP(r), nil
+		}
+	}
+
+	if !x.WorkerConfig.AclEnabled {
+		if ns != x.GalaxyNamespace {
+			return 0, errors.Errorf("namespace %#x doesn't exist, ACL is disabled", ns)
+		}
+		return ns, nil
+	}
+	ctx := x.AttachAccessJwt(context.Background(), r)
+	if jwtNs, err := x.ExtractJWTNamespace(ctx); err == nil && jwtNs != ns {
+		return 0, errors.Errorf("the access JWT belongs to namespace %#x, not to namespace %#x "+
+			"of the URL", jwtNs, ns)
+	}
+	return ns, nil
+}
+
 func graphqlProbeHandler(gqlHealthStore *admin.GraphQLHealthStore, globalEpoch map[uint64]*uint64) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		x.AddCorsHeaders(w)
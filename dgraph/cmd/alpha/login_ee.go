@@ -33,13 +33,23 @@ func loginHandler(w http.ResponseWriter, r *http.Request) {
 	ctx = x.AttachAuthToken(ctx, r)
 
 	body := readRequest(w, r)
-	loginReq := api.LoginRequest{}
+	loginReq := struct {
+		api.LoginRequest
+		// IdToken is the ID token issued by the OIDC provider configured via --oidc.
+		IdToken string `json:"id_token,omitempty"`
+	}{}
 	if err := json.Unmarshal(body, &loginReq); err != nil {
 		x.SetStatusWithData(w, x.Error, err.Error())
 		return
 	}
 
-	resp, err := (&edgraph.Server{}).Login(ctx, &loginReq)
+	var resp *api.Response
+	var err error
+	if loginReq.IdToken != "" {
+		resp, err = (&edgraph.Server{}).OidcLogin(ctx, loginReq.IdToken, loginReq.Namespace)
+	} else {
+		resp, err = (&edgraph.Server{}).Login(ctx, &loginReq.LoginRequest)
+	}
 	if err != nil {
 		x.SetStatusWithData(w, x.ErrorInvalidRequest, err.Error())
 		return
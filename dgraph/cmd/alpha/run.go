@@ -21,6 +21,7 @@ import (
 	"context"
 	"crypto/tls"
 	"embed"
+	"encoding/json"
 	"fmt"
 	"log"
 	"math"
@@ -46,10 +47,14 @@ import (
 	"github.com/dgraph-io/dgo/v210/protos/api"
 	"github.com/dgraph-io/dgraph/edgraph"
 	"github.com/dgraph-io/dgraph/ee/enc"
+	"github.com/dgraph-io/dgraph/flight"
 	"github.com/dgraph-io/dgraph/graphql/admin"
 	"github.com/dgraph-io/dgraph/posting"
+	"github.com/dgraph-io/dgraph/protos/pb"
 	"github.com/dgraph-io/dgraph/schema"
 	"github.com/dgraph-io/dgraph/tok"
+	"github.com/dgraph-io/dgraph/types"
+	"github.com/dgraph-io/dgraph/validate"
 	"github.com/dgraph-io/dgraph/worker"
 	"github.com/dgraph-io/dgraph/x"
 	"github.com/dgraph-io/ristretto/z"
@@ -132,6 +137,8 @@ they form a Raft group and provide synchronous replication.
 	// Custom plugins.
 	flag.String("custom_tokenizers", "",
 		"Comma separated list of tokenizer plugins for custom indices.")
+	flag.String("custom_validators", "",
+		"Comma separated list of validator plugins for the @validate directive.")
 
 	// By default Go GRPC traces all requests.
 	grpc.EnableTracing = false
@@ -165,6 +172,10 @@ they form a Raft group and provide synchronous replication.
 		Flag("learner",
 			`Make this Alpha a "learner" node. In learner mode, this Alpha will not participate `+
 				"in Raft elections. This can be used to achieve a read-only replica.").
+		Flag("witness",
+			`Make this Alpha a "witness" node of the group given by group. A witness votes in `+
+				"Raft elections but holds no data, serves no queries and hands the leadership "+
+				"over to a data replica, so that two replicas and a witness keep a quorum.").
 		Flag("snapshot-after-entries",
 			"Create a new Raft snapshot after N number of Raft entries. The lower this number, "+
 				"the more frequent snapshot creation will be. Snapshots are created only if both "+
@@ -201,6 +212,13 @@ they form a Raft group and provide synchronous replication.
 			"[allow, disallow, strict] The mutations mode to use.").
 		Flag("mutations-nquad",
 			"The maximum number of nquads that can be inserted in a mutation request.").
+		Flag("txn-postings",
+			"The maximum number of postings a transaction can write in a group. The mutation "+
+				"going past it is rejected. If set to 0, there's no limit.").
+		Flag("txn-node-edges",
+			"The maximum number of edges a transaction can add to a single node, counting the "+
+				"reverse edges. It keeps a transaction from accidentally creating a supernode. "+
+				"If set to 0, there's no limit.").
 		Flag("disallow-drop",
 			"Set disallow-drop to true to block drop-all and drop-data operation. It still"+
 				" allows dropping attributes and types.").
@@ -209,15 +227,56 @@ they form a Raft group and provide synchronous replication.
 				" 0, the timeout is infinite.").
 		Flag("max-pending-queries",
 			"Number of maximum pending queries before we reject them as too many requests.").
+		Flag("max-predicate-tasks",
+			"Number of query tasks reading a predicate which the alpha runs concurrently. The "+
+				"tasks beyond the limit wait for one of them to finish, so that a flood of "+
+				"expensive queries against a predicate can't starve the other predicates of the "+
+				"group. If set to 0, there's no limit.").
+		Flag("max-prepared-queries",
+			"Number of prepared queries kept by the alpha. When the limit is reached, preparing "+
+				"a new query evicts another one, which is prepared again when it is next run.").
+		Flag("max-query-jobs",
+			"Number of query jobs kept by the alpha, see /query/jobs. When the limit is reached, "+
+				"submitting a job evicts the results of the job finished first, and fails if all "+
+				"the jobs are running. If set to 0, query jobs are disabled.").
+		Flag("query-job-ttl",
+			"The duration for which the results of a finished query job are kept.").
+		Flag("query-job-timeout",
+			"Maximum time after which a query job fails. It replaces query-timeout for the query "+
+				"jobs. If set to 0, the query jobs are bounded by query-timeout.").
 		Flag("max-retries",
 			"Commits to disk will give up after these number of retries to prevent locking the "+
 				"worker in a failed state. Use -1 to retry infinitely.").
 		Flag("txn-abort-after", "Abort any pending transactions older than this duration."+
 			" The liveness of a transaction is determined by its last mutation.").
+		Flag("txn-max-age",
+			"Abort any pending transactions which were started longer than this duration ago, "+
+				"even if they're still active. Old transactions keep the earlier versions of the "+
+				"data from being discarded. If set to 0, there's no limit.").
+		Flag("txn-warn-age",
+			"The responses to the requests of transactions older than this duration carry the "+
+				"Dgraph-Txn-Age-Warning header. If set to 0, no warning is sent.").
+		Flag("txn-ts-batch",
+			"The minimum number of start timestamps leased from Zero in one request. The ones "+
+				"left over are handed out to the next transactions, which saves the round trips "+
+				"to Zero under high commit rates. If set to 1, no timestamp is leased ahead.").
+		Flag("txn-ts-max-age",
+			"The duration for which the start timestamps leased ahead are handed out. A "+
+				"transaction may not see the commits made through other Alphas during this "+
+				"duration before it starts. The commits made through this Alpha are always seen. If "+
+				"set to 0, there's no limit.").
 		Flag("shared-instance", "When set to true, it disables ACLs for non-galaxy users. "+
 			"It expects the access JWT to be constructed outside dgraph for those users as even "+
 			"login is denied to them. Additionally, this disables access to environment variables"+
 			"for minio, aws, etc.").
+		Flag("history",
+			"The duration for which the earlier versions of the data are retained for time-travel "+
+				"queries, which are run with the asOf parameter. If set to 0, time-travel queries "+
+				"are disabled.").
+		Flag("idempotency-ttl",
+			"The duration for which the mutations applied with an idempotency key are recorded. "+
+				"A mutation retried with the same key within this duration isn't applied again, and "+
+				"gets the response of the first one. If set to 0, idempotency keys are rejected.").
 		String())
 
 	flag.String("graphql", worker.GraphQLDefaults, z.NewSuperFlagHelp(worker.GraphQLDefaults).
@@ -231,6 +290,15 @@ they form a Raft group and provide synchronous replication.
 			"Enables extensions in GraphQL response body.").
 		Flag("poll-interval",
 			"The polling interval for GraphQL subscription.").
+		Flag("breaker-failures",
+			"The number of consecutive failed requests to a host after which the requests of "+
+				"@custom fields to that host fail fast. Zero disables the circuit breaker.").
+		Flag("breaker-cooldown",
+			"How long the requests to a host fail fast, once the circuit breaker opened, before "+
+				"a request is let through to probe the host again.").
+		Flag("schema-history",
+			"The number of previous GraphQL schemas kept for each namespace, which can be listed "+
+				"with getGQLSchemaHistory and rolled back to with rollbackGQLSchema.").
 		String())
 
 	flag.String("lambda", worker.LambdaDefaults, z.NewSuperFlagHelp(worker.LambdaDefaults).
@@ -246,6 +314,34 @@ they form a Raft group and provide synchronous replication.
 			"The starting port at which the lambda server listens.").
 		Flag("restart-after",
 			"Restarts the lambda server after given duration of unresponsiveness").
+		Flag("batch-size",
+			"The max number of parents sent in a single request to resolve a lambda field. "+
+				"The parents beyond are sent in further requests. 0 means no limit.").
+		Flag("batch-concurrency",
+			"The max number of requests made at once to resolve a lambda field.").
+		String())
+
+	flag.String("metrics", worker.MetricsDefaults, z.NewSuperFlagHelp(worker.MetricsDefaults).
+		Head("Metrics options").
+		Flag("predicates",
+			"Records the queries, mutated edges, posting list sizes and tablet size of every "+
+				"predicate, tagged by namespace and predicate. This adds a time series per "+
+				"predicate, so only enable it if the number of predicates is bounded.").
+		String())
+
+	flag.String("integrity", worker.IntegrityDefaults, z.NewSuperFlagHelp(worker.IntegrityDefaults).
+		Head("Integrity options").
+		Flag("checksums",
+			"Stores a CRC32C checksum along with the posting lists written, and verifies it when "+
+				"they are read. The keys found corrupted are quarantined, see the corruptedKeys "+
+				"admin query. The posting lists written without checksum are still read.").
+		Flag("count-index",
+			"The consistency of the @count indexes under concurrent mutations: eventual or "+
+				"strict. The count indexes are updated by the mutations from the number of edges "+
+				"they read, which concurrent transactions can make stale. With eventual, the "+
+				"entries found wrong are fixed when the posting lists are rolled up. With strict, "+
+				"the entries are also recomputed when the transactions commit, which reads the "+
+				"posting lists changed on commit.").
 		String())
 
 	flag.String("cdc", worker.CDCDefaults, z.NewSuperFlagHelp(worker.CDCDefaults).
@@ -260,12 +356,34 @@ they form a Raft group and provide synchronous replication.
 			"The SASL password for Kafka.").
 		Flag("sasl-mechanism",
 			"The SASL mechanism for Kafka (PLAIN, SCRAM-SHA-256 or SCRAM-SHA-512)").
+		Flag("dgraph",
+			"A comma separated list of the Alphas of a standby cluster to replicate the changes "+
+				"to.").
+		Flag("dgraph-zero",
+			"The Zero of the standby cluster, used to lease the uids of the replicated nodes.").
+		Flag("dgraph-user",
+			"The user to login into the standby cluster as, if it has ACL enabled.").
+		Flag("dgraph-password",
+			"The password of dgraph-user.").
 		Flag("ca-cert",
 			"The path to CA cert file for TLS encryption.").
 		Flag("client-cert",
 			"The path to client cert file for TLS encryption.").
 		Flag("client-key",
 			"The path to client key file for TLS encryption.").
+		Flag("views",
+			"Maintain the view predicates, declared with @view in the schema, from the "+
+				"change events. No sink is needed if the events are only used for the views.").
+		Flag("triggers",
+			"Call the targets of the @trigger directives in the schema with the committed "+
+				"changes. No sink is needed if the events are only used for the triggers.").
+		Flag("trigger-retries",
+			"The number of times a failed trigger call is retried, with a backoff.").
+		Flag("trigger-timeout",
+			"The timeout of a trigger call.").
+		Flag("trigger-dlq",
+			"The directory of the dead-letter queue, where the trigger calls which failed "+
+				"after the retries are written.").
 		String())
 
 	flag.String("audit", worker.AuditDefaults, z.NewSuperFlagHelp(worker.AuditDefaults).
@@ -283,6 +401,8 @@ they form a Raft group and provide synchronous replication.
 		Flag("size",
 			"The audit log max size in MB after which it will be rolled over.").
 		String())
+
+	initStandalone()
 }
 
 func setupCustomTokenizers() {
@@ -295,6 +415,16 @@ func setupCustomTokenizers() {
 	}
 }
 
+func setupCustomValidators() {
+	customValidators := Alpha.Conf.GetString("custom_validators")
+	if customValidators == "" {
+		return
+	}
+	for _, soFile := range strings.Split(customValidators, ",") {
+		validate.LoadCustomValidator(soFile)
+	}
+}
+
 // Parses a comma-delimited list of IP addresses, IP ranges, CIDR blocks, or hostnames
 // and returns a slice of []IPRange.
 //
@@ -400,7 +530,12 @@ func healthCheck(w http.ResponseWriter, r *http.Request) {
 	if !ok {
 		if err := x.HealthCheck(); err != nil {
 			w.WriteHeader(http.StatusServiceUnavailable)
-			_, err = w.Write([]byte(err.Error()))
+			msg := err.Error()
+			if p := worker.GetStartupProgress(); p.Phase != worker.StartupReady {
+				// Tell how far the startup is, as it can take a while for a large Alpha.
+				msg = fmt.Sprintf("%s. Startup phase: %s.", msg, p)
+			}
+			_, err = w.Write([]byte(msg))
 			if err != nil {
 				glog.V(2).Infof("Error while writing health check response: %v", err)
 			}
@@ -422,6 +557,41 @@ func healthCheck(w http.ResponseWriter, r *http.Request) {
 	_, _ = w.Write(resp.Json)
 }
 
+// readinessCheck reports the detailed health of this Alpha. It responds with 503 if the Alpha
+// isn't ready to serve, so it can be used as a readiness probe. The max-apply-lag and max-ts-lag
+// query params set the lag beyond which the Alpha isn't ready.
+func readinessCheck(w http.ResponseWriter, r *http.Request) {
+	x.AddCorsHeaders(w)
+
+	var opts worker.ReadinessOptions
+	for param, val := range map[string]*uint64{
+		"max-apply-lag": &opts.MaxApplyLag,
+		"max-ts-lag":    &opts.MaxTsLag,
+	} {
+		v := r.URL.Query().Get(param)
+		if v == "" {
+			continue
+		}
+		var err error
+		if *val, err = strconv.ParseUint(v, 0, 64); err != nil {
+			x.SetStatus(w, x.ErrorInvalidRequest, fmt.Sprintf("Invalid %s: %v", param, err))
+			return
+		}
+	}
+
+	readiness := worker.GetReadiness(r.Context(), opts)
+	data, err := json.Marshal(readiness)
+	if err != nil {
+		x.SetStatus(w, x.Error, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if !readiness.Ready {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	_, _ = w.Write(data)
+}
+
 func stateHandler(w http.ResponseWriter, r *http.Request) {
 	var err error
 	x.AddCorsHeaders(w)
@@ -591,6 +761,7 @@ func serveGRPC(l net.Listener, tlsCfg *tls.Config, closer *z.Closer) {
 		grpc.MaxConcurrentStreams(1000),
 		grpc.StatsHandler(&ocgrpc.ServerHandler{}),
 		grpc.UnaryInterceptor(audit.AuditRequestGRPC),
+		grpc.ChainUnaryInterceptor(x.ErrorCodeInterceptor),
 	}
 	if tlsCfg != nil {
 		opt = append(opt, grpc.Creds(credentials.NewTLS(tlsCfg)))
@@ -598,6 +769,8 @@ func serveGRPC(l net.Listener, tlsCfg *tls.Config, closer *z.Closer) {
 
 	s := grpc.NewServer(opt...)
 	api.RegisterDgraphServer(s, &edgraph.Server{})
+	pb.RegisterAlphaServer(s, &edgraph.Server{})
+	flight.Register(s)
 	hapi.RegisterHealthServer(s, health.NewServer())
 	worker.RegisterZeroProxyServer(s)
 
@@ -634,11 +807,17 @@ func setupServer(closer *z.Closer) {
 
 	baseMux.HandleFunc("/query", queryHandler)
 	baseMux.HandleFunc("/query/", queryHandler)
+	baseMux.HandleFunc("/query/prepare", prepareHandler)
+	baseMux.HandleFunc("/query/jobs", queryJobsHandler)
+	baseMux.HandleFunc("/query/jobs/results", queryJobResultsHandler)
+	baseMux.HandleFunc("/query/jobs/cancel", cancelQueryJobHandler)
+	baseMux.HandleFunc("/sparql", sparqlHandler)
 	baseMux.HandleFunc("/mutate", mutationHandler)
 	baseMux.HandleFunc("/mutate/", mutationHandler)
 	baseMux.HandleFunc("/commit", commitHandler)
 	baseMux.HandleFunc("/alter", alterHandler)
 	baseMux.HandleFunc("/health", healthCheck)
+	baseMux.HandleFunc("/ready", readinessCheck)
 	baseMux.HandleFunc("/state", stateHandler)
 	baseMux.HandleFunc("/debug/jemalloc", x.JemallocHandler)
 	zpages.Handle(baseMux, "/debug/z")
@@ -671,15 +850,21 @@ func setupServer(closer *z.Closer) {
 	// Do not use := notation here because adminServer is a global variable.
 	mainServer, adminServer, gqlHealthStore = admin.NewServers(introspection,
 		globalEpoch, closer)
-	baseMux.HandleFunc("/graphql", func(w http.ResponseWriter, r *http.Request) {
-		namespace := x.ExtractNamespaceHTTP(r)
+	serveGraphQL := func(w http.ResponseWriter, r *http.Request) {
+		namespace, err := graphqlNamespace(r)
+		if err != nil {
+			admin.WriteErrorResponse(w, r, err)
+			return
+		}
 		r.Header.Set("resolver", strconv.FormatUint(namespace, 10))
 		if err := admin.LazyLoadSchema(namespace); err != nil {
 			admin.WriteErrorResponse(w, r, err)
 			return
 		}
 		mainServer.HTTPHandler().ServeHTTP(w, r)
-	})
+	}
+	baseMux.HandleFunc("/graphql", serveGraphQL)
+	baseMux.HandleFunc(graphqlNamespacePath, serveGraphQL)
 
 	baseMux.Handle("/probe/graphql", graphqlProbeHandler(gqlHealthStore, globalEpoch))
 
@@ -746,19 +931,9 @@ func setupServer(closer *z.Closer) {
 	x.ServerCloser.Wait()
 }
 
-func run() {
-	var err error
-
-	telemetry := z.NewSuperFlag(Alpha.Conf.GetString("telemetry")).MergeAndCheckDefault(
-		x.TelemetryDefaults)
-	if telemetry.GetBool("sentry") {
-		x.InitSentry(enc.EeBuild)
-		defer x.FlushSentry()
-		x.ConfigureSentryScope("alpha")
-		x.WrapPanics()
-		x.SentryOptOutNote()
-	}
-
+// setupConfig sets the options of the Alpha from Alpha.Conf. It returns the size of the posting
+// list cache.
+func setupConfig(telemetry *z.SuperFlag) (int64, error) {
 	bindall = Alpha.Conf.GetBool("bindall")
 	cache := z.NewSuperFlag(Alpha.Conf.GetString("cache")).MergeAndCheckDefault(
 		worker.CacheDefaults)
@@ -800,6 +975,14 @@ func run() {
 		opts.RefreshJwtTtl = keys.AclRefreshTtl
 		glog.Info("ACL secret key loaded successfully.")
 	}
+	opts.Oidc = z.NewSuperFlag(Alpha.Conf.GetString("oidc")).MergeAndCheckDefault(
+		ee.OidcDefaults)
+	opts.PasswordPolicy = z.NewSuperFlag(Alpha.Conf.GetString("password-policy")).
+		MergeAndCheckDefault(ee.PasswordPolicyDefaults)
+	if opts.PasswordPolicy.GetInt64("min-length") < 6 {
+		glog.Fatalf("password-policy min-length can't be less than 6")
+	}
+	x.Check(types.SetPasswordHashScheme(opts.PasswordPolicy.GetString("hash")))
 
 	x.Config.Limit = z.NewSuperFlag(Alpha.Conf.GetString("limit")).MergeAndCheckDefault(
 		worker.LimitDefaults)
@@ -827,6 +1010,8 @@ func run() {
 	x.Check(err)
 
 	raft := z.NewSuperFlag(Alpha.Conf.GetString("raft")).MergeAndCheckDefault(worker.RaftDefaults)
+	x.AssertTruef(!raft.GetBool("witness") || !raft.GetBool("learner"),
+		"An Alpha can't be both a witness and a learner.")
 	x.WorkerConfig = x.WorkerOptions{
 		TmpDir:              Alpha.Conf.GetString("tmp"),
 		ExportPath:          Alpha.Conf.GetString("export"),
@@ -836,6 +1021,11 @@ func run() {
 		StrictMutations:     opts.MutationsMode == worker.StrictMutations,
 		AclEnabled:          keys.AclKey != nil,
 		AbortOlderThan:      abortDur,
+		TxnMaxAge:           x.Config.Limit.GetDuration("txn-max-age"),
+		TxnWarnAge:          x.Config.Limit.GetDuration("txn-warn-age"),
+		TxnTsBatch:          x.Config.Limit.GetUint64("txn-ts-batch"),
+		TxnTsMaxAge:         x.Config.Limit.GetDuration("txn-ts-max-age"),
+		MaxPredicateTasks:   int(x.Config.Limit.GetInt64("max-predicate-tasks")),
 		StartTime:           startTime,
 		Security:            security,
 		TLSClientConfig:     tlsClientConf,
@@ -856,15 +1046,34 @@ func run() {
 	x.WorkerConfig.EncryptionKey = keys.EncKey
 
 	setupCustomTokenizers()
+	setupCustomValidators()
 	x.Init()
 	x.Config.PortOffset = Alpha.Conf.GetInt("port_offset")
 	x.Config.LimitMutationsNquad = int(x.Config.Limit.GetInt64("mutations-nquad"))
+	x.Config.LimitTxnPostings = int(x.Config.Limit.GetInt64("txn-postings"))
+	x.Config.LimitTxnNodeEdges = int(x.Config.Limit.GetInt64("txn-node-edges"))
 	x.Config.LimitQueryEdge = x.Config.Limit.GetUint64("query-edge")
 	x.Config.BlockClusterWideDrop = x.Config.Limit.GetBool("disallow-drop")
 	x.Config.LimitNormalizeNode = int(x.Config.Limit.GetInt64("normalize-node"))
 	x.Config.QueryTimeout = x.Config.Limit.GetDuration("query-timeout")
 	x.Config.MaxRetries = x.Config.Limit.GetInt64("max-retries")
 	x.Config.SharedInstance = x.Config.Limit.GetBool("shared-instance")
+	x.Config.HistoryRetention = x.Config.Limit.GetDuration("history")
+	x.Config.IdempotencyTTL = x.Config.Limit.GetDuration("idempotency-ttl")
+	x.Config.PredicateMetrics = z.NewSuperFlag(Alpha.Conf.GetString("metrics")).
+		MergeAndCheckDefault(worker.MetricsDefaults).GetBool("predicates")
+	integrity := z.NewSuperFlag(Alpha.Conf.GetString("integrity")).MergeAndCheckDefault(
+		worker.IntegrityDefaults)
+	x.Config.PostingChecksums = integrity.GetBool("checksums")
+	switch strings.ToLower(integrity.GetString("count-index")) {
+	case "eventual":
+		x.Config.StrictCountIndex = false
+	case "strict":
+		x.Config.StrictCountIndex = true
+	default:
+		glog.Error(`--integrity "count-index=<mode>;" must be one of eventual or strict`)
+		os.Exit(1)
+	}
 
 	graphql := z.NewSuperFlag(Alpha.Conf.GetString("graphql")).MergeAndCheckDefault(
 		worker.GraphQLDefaults)
@@ -873,28 +1082,51 @@ func run() {
 		Debug:         graphql.GetBool("debug"),
 		Extensions:    graphql.GetBool("extensions"),
 		PollInterval:  graphql.GetDuration("poll-interval"),
+
+		BreakerFailures: uint32(graphql.GetUint64("breaker-failures")),
+		BreakerCooldown: graphql.GetDuration("breaker-cooldown"),
+		SchemaHistory:   uint32(graphql.GetUint64("schema-history")),
 	}
 	lambda := z.NewSuperFlag(Alpha.Conf.GetString("lambda")).MergeAndCheckDefault(
 		worker.LambdaDefaults)
 	x.Config.Lambda = x.LambdaOptions{
-		Url:          lambda.GetString("url"),
-		Num:          lambda.GetUint32("num"),
-		Port:         lambda.GetUint32("port"),
-		RestartAfter: lambda.GetDuration("restart-after"),
+		Url:              lambda.GetString("url"),
+		Num:              lambda.GetUint32("num"),
+		Port:             lambda.GetUint32("port"),
+		RestartAfter:     lambda.GetDuration("restart-after"),
+		BatchSize:        lambda.GetUint32("batch-size"),
+		BatchConcurrency: lambda.GetUint32("batch-concurrency"),
 	}
 	if x.Config.Lambda.Url != "" {
 		graphqlLambdaUrl, err := url.Parse(x.Config.Lambda.Url)
 		if err != nil {
-			glog.Errorf("unable to parse --lambda url: %v", err)
-			return
+			return 0, errors.Errorf("unable to parse --lambda url: %v", err)
 		}
 		if !graphqlLambdaUrl.IsAbs() {
-			glog.Errorf("expecting --lambda url to be an absolute URL, got: %s",
+			return 0, errors.Errorf("expecting --lambda url to be an absolute URL, got: %s",
 				graphqlLambdaUrl.String())
-			return
 		}
 	}
 	edgraph.Init()
+	return postingListCacheSize, nil
+}
+
+func run() {
+	telemetry := z.NewSuperFlag(Alpha.Conf.GetString("telemetry")).MergeAndCheckDefault(
+		x.TelemetryDefaults)
+	if telemetry.GetBool("sentry") {
+		x.InitSentry(enc.EeBuild)
+		defer x.FlushSentry()
+		x.ConfigureSentryScope("alpha")
+		x.WrapPanics()
+		x.SentryOptOutNote()
+	}
+
+	postingListCacheSize, err := setupConfig(telemetry)
+	if err != nil {
+		glog.Error(err)
+		return
+	}
 
 	x.PrintVersion()
 	glog.Infof("x.Config: %+v", x.Config)
@@ -955,11 +1187,14 @@ func run() {
 		}
 	}()
 
-	updaters := z.NewCloser(2)
+	updaters := z.NewCloser(4)
 	go func() {
 		worker.StartRaftNodes(worker.State.WALstore, bindall)
 		atomic.AddUint32(&initDone, 1)
 
+		go edgraph.RefreshRuntimeConfig(updaters)
+		go edgraph.RefreshColocations(updaters)
+
 		// initialization of the admin account can only be done after raft nodes are running
 		// and health check passes
 		edgraph.ResetAcl(updaters)
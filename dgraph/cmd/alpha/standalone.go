@@ -0,0 +1,69 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package alpha
+
+import (
+	"github.com/dgraph-io/dgraph/dgraph/cmd/zero"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/spf13/cobra"
+)
+
+// Standalone is the sub-command running a Zero and an Alpha in a single process.
+var Standalone x.SubCommand
+
+// initStandalone sets up the standalone sub-command. It takes all the flags of Alpha, so it must
+// be called once they are defined.
+func initStandalone() {
+	Standalone.Cmd = &cobra.Command{
+		Use:   "standalone",
+		Short: "Run Dgraph Zero and Alpha in a single process",
+		Long: `
+A Dgraph standalone instance runs a Zero and an Alpha in a single process, for
+development setups and single node deployments. The Alpha reaches the Zero in
+memory instead of over the network. It accepts all the flags of Alpha.
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			defer x.StartProfile(Standalone.Conf).Stop()
+			runStandalone()
+		},
+		Annotations: map[string]string{"group": "core"},
+	}
+	Standalone.EnvPrefix = "DGRAPH_STANDALONE"
+	Standalone.Cmd.SetHelpTemplate(x.NonRootTemplate)
+
+	flag := Standalone.Cmd.Flags()
+	flag.AddFlagSet(Alpha.Cmd.Flags())
+	flag.String("zero_wal", "zw", "Directory storing the write-ahead log of the Zero.")
+	flag.Bool("lite", false,
+		"Only serve the Zero in memory to the Alpha. Otherwise, the Zero also listens on its "+
+			"gRPC port (5080 + port_offset), so that the live and bulk loaders can reach it.")
+}
+
+func runStandalone() {
+	zero.Zero.Conf.Set("wal", Standalone.Conf.GetString("zero_wal"))
+	zero.Zero.Conf.Set("port_offset", Standalone.Conf.GetInt("port_offset"))
+	zero.Zero.Conf.Set("bindall", Standalone.Conf.GetBool("bindall"))
+	zero.Zero.Conf.Set("tls", Standalone.Conf.GetString("tls"))
+	embedded, err := zero.StartEmbedded(Standalone.Conf.GetBool("lite"))
+	x.Check(err)
+	defer embedded.Stop()
+
+	// The Alpha reads its options from Alpha.Conf.
+	Alpha.Conf = Standalone.Conf
+	Alpha.Conf.Set("zero", embedded.Addr)
+	run()
+}
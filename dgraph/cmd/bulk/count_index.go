@@ -71,7 +71,7 @@ type current struct {
 
 type countIndexer struct {
 	*reducer
-	writer      *badger.StreamWriter
+	writer      kvWriter
 	splitWriter *badger.WriteBatch
 	splitCh     chan *badger.KVList
 	tmpDb       *badger.DB
@@ -0,0 +1,264 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bulk
+
+// In incremental mode, the bulk loader loads data into the p directories of an existing cluster,
+// while its Alphas are stopped. The p directories must be given as <out>/<i>/p, where i+1 is the
+// group of the Alpha the directory belongs to.
+//
+// The predicates already in the cluster are reduced into the directory of the group serving them,
+// and keep the schema they have in the cluster. Instead of complete posting lists, the reducers
+// write deltas at a timestamp leased from Zero, which the Alphas merge with the existing posting
+// lists when they read them. The uids are leased from Zero as usual.
+//
+// The count indexes can't be updated this way, so the predicates already in the cluster with a
+// @count index can't be loaded. Overwriting the value of a non-list predicate which is already
+// indexed leaves its old index entries around.
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/badger/v3"
+	bpb "github.com/dgraph-io/badger/v3/pb"
+	"github.com/dgraph-io/dgraph/posting"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/dgraph-io/ristretto/z"
+	"github.com/dgraph-io/sroar"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+)
+
+// existingShardDirs returns the p directories of the groups of the cluster in outDir.
+func existingShardDirs(outDir string) ([]string, error) {
+	var dirs []string
+	for i := 0; ; i++ {
+		dir := filepath.Join(outDir, strconv.Itoa(i), "p")
+		if _, err := os.Stat(dir); os.IsNotExist(err) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		dirs = append(dirs, dir)
+	}
+	if len(dirs) == 0 {
+		return nil, fmt.Errorf("no p directory found in %s. Incremental mode expects the p "+
+			"directory of group i+1 at %s", outDir, filepath.Join(outDir, "<i>", "p"))
+	}
+	return dirs, nil
+}
+
+// existingSchema is the schema of the predicates already in the cluster.
+type existingSchema struct {
+	preds  map[string]*pb.SchemaUpdate
+	shards map[string]int
+}
+
+// readExistingSchema reads the schema of the predicates in the p directories of the cluster, and
+// checks with Zero that they're served by the group of their directory.
+func readExistingSchema(opt *options, zero *grpc.ClientConn) *existingSchema {
+	es := &existingSchema{
+		preds:  make(map[string]*pb.SchemaUpdate),
+		shards: make(map[string]int),
+	}
+	key := opt.EncryptionKey
+	if !opt.EncryptedOut {
+		key = nil
+	}
+	for i, dir := range opt.shardOutputDirs {
+		db, err := badger.OpenManaged(opt.Badger.WithDir(dir).WithValueDir(dir).
+			WithReadOnly(true).WithEncryptionKey(key))
+		x.Checkf(err, "Unable to open %s. Make sure that the Alphas are stopped", dir)
+
+		txn := db.NewTransactionAt(math.MaxUint64, false)
+		iopt := badger.DefaultIteratorOptions
+		iopt.Prefix = x.SchemaPrefix()
+		itr := txn.NewIterator(iopt)
+		for itr.Rewind(); itr.Valid(); itr.Next() {
+			item := itr.Item()
+			if item.IsDeletedOrExpired() {
+				continue
+			}
+			pk, err := x.Parse(item.Key())
+			x.Check(err)
+			var su pb.SchemaUpdate
+			x.Check(item.Value(func(val []byte) error {
+				return su.Unmarshal(val)
+			}))
+			if shard, ok := es.shards[pk.Attr]; ok {
+				log.Fatalf("Predicate %s is in both %s and %s", x.ParseAttr(pk.Attr),
+					opt.shardOutputDirs[shard], dir)
+			}
+			es.preds[pk.Attr] = &su
+			es.shards[pk.Attr] = i
+		}
+		itr.Close()
+		txn.Discard()
+		x.Check(db.Close())
+	}
+
+	client := pb.NewZeroClient(zero)
+	for pred, shard := range es.shards {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		tab, err := client.ShouldServe(ctx, &pb.Tablet{Predicate: pred, ReadOnly: true})
+		cancel()
+		x.Checkf(err, "Unable to get the group serving %s from Zero", x.ParseAttr(pred))
+		if tab.GetGroupId() != 0 && tab.GetGroupId() != uint32(shard+1) {
+			log.Fatalf("Predicate %s is in %s but Zero says it's served by group %d. "+
+				"The p directory of group i+1 must be at %s", x.ParseAttr(pred),
+				opt.shardOutputDirs[shard], tab.GetGroupId(),
+				filepath.Join(opt.OutDir, "<i>", "p"))
+		}
+	}
+	fmt.Printf("Found %d predicates in %d groups\n", len(es.preds), len(opt.shardOutputDirs))
+	return es
+}
+
+// hasNamespace returns whether the cluster has any predicate in the given namespace.
+func (es *existingSchema) hasNamespace(ns uint64) bool {
+	for pred := range es.preds {
+		if x.ParseNamespace(pred) == ns {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeExistingSchema replaces the schema of the predicates already in the cluster with their
+// existing schema, so that the new data is indexed the way the existing data is. The schema
+// file can't change the type of these predicates.
+func (s *schemaStore) mergeExistingSchema(es *existingSchema) {
+	s.Lock()
+	defer s.Unlock()
+	for pred, sch := range es.preds {
+		if cur, ok := s.schemaMap[pred]; ok && !x.IsReservedPredicate(pred) {
+			if cur.ValueType != sch.ValueType || cur.List != sch.List {
+				log.Fatalf("Schema of %s doesn't match the existing schema in the cluster: %+v",
+					x.ParseAttr(pred), sch)
+			}
+			if !proto.Equal(cur, sch) {
+				fmt.Printf("Keeping the existing schema of %s, use alter to change it: %+v\n",
+					x.ParseAttr(pred), sch)
+			}
+		}
+		s.schemaMap[pred] = sch
+	}
+}
+
+// mergeShardsByIndex moves each map shard into the reduce shard with the same index, so that the
+// predicates already in the cluster are reduced into the p directory of their group.
+func mergeShardsByIndex(opt *options) {
+	shardDirs := readShardDirs(filepath.Join(opt.TmpDir, mapShardDir))
+	if len(shardDirs) == 0 {
+		fmt.Printf(
+			"No map shards found. Possibly caused by empty data files passed to the bulk loader.\n")
+		os.Exit(1)
+	}
+	for i := 0; i < opt.ReduceShards; i++ {
+		shardDir := filepath.Join(opt.TmpDir, reduceShardDir, fmt.Sprintf("shard_%d", i))
+		x.Check(os.MkdirAll(shardDir, 0750))
+	}
+	for _, shard := range shardDirs {
+		idx, err := strconv.Atoi(filepath.Base(shard))
+		x.Check(err)
+		reduceShard := filepath.Join(opt.TmpDir, reduceShardDir, fmt.Sprintf("shard_%d", idx),
+			filepath.Base(shard))
+		fmt.Printf("Shard %s -> Reduce %s\n", shard, reduceShard)
+		x.Check(os.Rename(shard, reduceShard))
+	}
+}
+
+// kvWriter writes the KVs in a buffer to the output DB.
+type kvWriter interface {
+	Write(buf *z.Buffer) error
+	Flush() error
+}
+
+// batchWriter is the kvWriter used in incremental mode. Unlike the StreamWriter, it can write to
+// a DB which already has data.
+type batchWriter struct {
+	sync.Mutex
+	db *badger.DB
+	wb *badger.WriteBatch
+	sz int
+}
+
+func newBatchWriter(db *badger.DB) *batchWriter {
+	return &batchWriter{db: db, wb: db.NewManagedWriteBatch()}
+}
+
+func (w *batchWriter) Write(buf *z.Buffer) error {
+	kvs, err := badger.BufferToKVList(buf)
+	if err != nil {
+		return err
+	}
+	list := &bpb.KVList{}
+	for _, kv := range kvs.Kv {
+		if kv.StreamDone {
+			continue
+		}
+		list.Kv = append(list.Kv, kv)
+	}
+
+	w.Lock()
+	defer w.Unlock()
+	if err := w.wb.WriteList(list); err != nil {
+		return err
+	}
+	// Flush periodically to keep the value log from growing over the allowed limit.
+	if w.sz += len(list.Kv); w.sz >= maxSplitBatchLen {
+		if err := w.wb.Flush(); err != nil {
+			return err
+		}
+		w.wb = w.db.NewManagedWriteBatch()
+		w.sz = 0
+	}
+	return nil
+}
+
+func (w *batchWriter) Flush() error {
+	w.Lock()
+	defer w.Unlock()
+	return w.wb.Flush()
+}
+
+// toDelta converts the posting list of the uids in bm into a delta. The postings of pl are those
+// of the uids in bm which have a value, sorted by uid.
+func toDelta(pl *pb.PostingList, bm *sroar.Bitmap) *pb.PostingList {
+	delta := &pb.PostingList{Postings: make([]*pb.Posting, 0, bm.GetCardinality())}
+	var idx int
+	for _, uid := range bm.ToArray() {
+		var p *pb.Posting
+		if idx < len(pl.Postings) && pl.Postings[idx].Uid == uid {
+			p = pl.Postings[idx]
+			idx++
+		} else {
+			p = &pb.Posting{Uid: uid}
+		}
+		p.Op = posting.Set
+		delta.Postings = append(delta.Postings, p)
+	}
+	return delta
+}
@@ -0,0 +1,74 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bulk
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dgraph-io/sroar"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/posting"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+func TestExistingShardDirs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bulk")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	_, err = existingShardDirs(dir)
+	require.Error(t, err)
+
+	// The directories are read in the order of the groups, up to the first one missing.
+	for _, i := range []string{"0", "1", "3"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, i, "p"), 0750))
+	}
+	dirs, err := existingShardDirs(dir)
+	require.NoError(t, err)
+	require.Equal(t, []string{filepath.Join(dir, "0", "p"), filepath.Join(dir, "1", "p")}, dirs)
+}
+
+func TestExistingSchemaHasNamespace(t *testing.T) {
+	es := &existingSchema{preds: map[string]*pb.SchemaUpdate{
+		x.NamespaceAttr(x.GalaxyNamespace, "name"): {},
+		x.NamespaceAttr(2, "name"):                 {},
+	}}
+	require.True(t, es.hasNamespace(x.GalaxyNamespace))
+	require.True(t, es.hasNamespace(2))
+	require.False(t, es.hasNamespace(1))
+}
+
+func TestToDelta(t *testing.T) {
+	bm := sroar.NewBitmap()
+	bm.SetMany([]uint64{1, 2, 3})
+	pl := &pb.PostingList{Postings: []*pb.Posting{{Uid: 2, Value: []byte("two")}}}
+
+	// Every uid of the list is set, with its value if it has one.
+	delta := toDelta(pl, bm)
+	require.Len(t, delta.Postings, 3)
+	for i, p := range delta.Postings {
+		require.Equal(t, uint64(i+1), p.Uid)
+		require.Equal(t, uint32(posting.Set), p.Op)
+	}
+	require.Equal(t, []byte("two"), delta.Postings[1].Value)
+	require.Nil(t, delta.Postings[0].Value)
+}
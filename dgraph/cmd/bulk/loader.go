@@ -52,6 +52,7 @@ import (
 type options struct {
 	DataFiles        string
 	DataFormat       string
+	CsvMapping       string
 	SchemaFile       string
 	GqlSchemaFile    string
 	OutDir           string
@@ -65,14 +66,17 @@ type options struct {
 	NumReducers      int
 	Version          bool
 	StoreXids        bool
+	Xids             bool
 	ZeroAddr         string
 	HttpAddr         string
 	IgnoreErrors     bool
 	CustomTokenizers string
+	CustomValidators string
 	NewUids          bool
 	ClientDir        string
 	Encrypted        bool
 	EncryptedOut     bool
+	Incremental      bool
 
 	MapShards    int
 	ReduceShards int
@@ -100,6 +104,9 @@ type state struct {
 	tmpDbs        []*badger.DB // Temporary DB to write the split lists to avoid ordering issues.
 	writeTs       uint64       // All badger writes use this timestamp
 	namespaces    *sync.Map    // To store the encountered namespaces.
+	// Schema of the cluster loaded into in incremental mode.
+	existing   *existingSchema
+	csvMapping *chunker.CSVMapping
 }
 
 type loader struct {
@@ -139,6 +146,18 @@ func newLoader(opt *options) *loader {
 		namespaces:    &sync.Map{},
 	}
 	st.schema = newSchemaStore(readSchema(opt), opt, st)
+	st.csvMapping = &chunker.CSVMapping{}
+	if opt.CsvMapping != "" {
+		st.csvMapping, err = chunker.ReadCSVMapping(opt.CsvMapping)
+		x.Check(err)
+	}
+	if opt.Incremental {
+		st.existing = readExistingSchema(opt, zero)
+		st.schema.mergeExistingSchema(st.existing)
+		for pred, shard := range st.existing.shards {
+			st.shards.predToShard[pred] = shard
+		}
+	}
 	ld := &loader{
 		state:   st,
 		mappers: make([]*mapper, opt.NumGoroutines),
@@ -151,6 +170,14 @@ func newLoader(opt *options) *loader {
 	return ld
 }
 
+// newChunker returns a chunker for the given format, using the CSV mapping for CSV files.
+func (st *state) newChunker(format chunker.InputFormat, batchSize int) chunker.Chunker {
+	if format == chunker.CsvFormat {
+		return chunker.NewCSVChunker(st.csvMapping, batchSize)
+	}
+	return chunker.NewChunker(format, batchSize)
+}
+
 func getWriteTimestamp(zero *grpc.ClientConn) uint64 {
 	client := pb.NewZeroClient(zero)
 	for {
@@ -237,19 +264,21 @@ func (ld *loader) mapStage() {
 
 	fs := filestore.NewFileStore(ld.opt.DataFiles)
 
-	files := fs.FindDataFiles(ld.opt.DataFiles, []string{".rdf", ".rdf.gz", ".json", ".json.gz"})
+	files := fs.FindDataFiles(ld.opt.DataFiles, []string{".rdf", ".rdf.gz", ".json", ".json.gz",
+		".csv", ".csv.gz", ".graphml", ".graphml.gz", ".graphson", ".graphson.gz"})
 	if len(files) == 0 {
 		fmt.Printf("No data files found in %s.\n", ld.opt.DataFiles)
 		os.Exit(1)
 	}
 
 	// Because mappers must handle chunks that may be from different input files, they must all
-	// assume the same data format, either RDF or JSON. Use the one specified by the user or by
-	// the first load file.
+	// assume the same data format, either RDF, JSON, CSV, Neo4j, GraphML or GraphSON. Use the one
+	// specified by the user or by the first load file.
 	loadType := chunker.DataFormat(files[0], ld.opt.DataFormat)
 	if loadType == chunker.UnknownFormat {
 		// Dont't try to detect JSON input in bulk loader.
-		fmt.Printf("Need --format=rdf or --format=json to load %s", files[0])
+		fmt.Printf("Need --format=rdf, --format=json, --format=csv, --format=neo4j, "+
+			"--format=graphml or --format=graphson to load %s", files[0])
 		os.Exit(1)
 	}
 
@@ -278,7 +307,7 @@ func (ld *loader) mapStage() {
 			r, cleanup := fs.ChunkReader(file, key)
 			defer cleanup()
 
-			chunk := chunker.NewChunker(loadType, 1000)
+			chunk := ld.newChunker(loadType, 1000)
 			for {
 				chunkBuf, err := chunk.Chunk(r)
 				if chunkBuf != nil && chunkBuf.Len() > 0 {
@@ -338,6 +367,11 @@ func (ld *loader) processGqlSchema(loadType chunker.InputFormat) {
 	if ld.opt.GqlSchemaFile == "" {
 		return
 	}
+	if loadType != chunker.RdfFormat && loadType != chunker.JsonFormat {
+		fmt.Println("The GraphQL schema can only be loaded along with RDF or JSON files. " +
+			"Update it once the Alphas are up.")
+		return
+	}
 
 	f, err := filestore.Open(ld.opt.GqlSchemaFile)
 	x.Check(err)
@@ -219,7 +219,7 @@ func (m *mapper) writeMapEntriesToFile(cbuf *z.Buffer, shardIdx int) {
 var once sync.Once
 
 func (m *mapper) run(inputFormat chunker.InputFormat) {
-	chunk := chunker.NewChunker(inputFormat, 1000)
+	chunk := m.newChunker(inputFormat, 1000)
 	nquads := chunk.NQuads()
 	go func() {
 		for chunkBuf := range m.readerChunkCh {
@@ -231,7 +231,8 @@ func (m *mapper) run(inputFormat chunker.InputFormat) {
 			}
 		}
 		once.Do(func() {
-			if m.opt.Namespace != math.MaxUint64 && m.opt.Namespace != x.GalaxyNamespace {
+			if m.opt.Namespace != math.MaxUint64 && m.opt.Namespace != x.GalaxyNamespace &&
+				(m.existing == nil || !m.existing.hasNamespace(m.opt.Namespace)) {
 				// Insert ACL related RDFs force uploading the data into non-galaxy namespace.
 				aclNquads := make([]*api.NQuad, 0)
 				aclNquads = append(aclNquads, acl.CreateGroupNQuads(x.GuardiansId)...)
@@ -342,7 +343,7 @@ func (m *mapper) processNQuad(nq gql.NQuad) {
 }
 
 func (m *mapper) uid(xid string, ns uint64) uint64 {
-	if !m.opt.NewUids {
+	if !m.opt.NewUids && !m.opt.Xids {
 		if uid, err := strconv.ParseUint(xid, 0, 64); err == nil {
 			m.xids.BumpTo(uid)
 			return uid
@@ -369,6 +370,17 @@ func (m *mapper) lookupUid(xid string, ns uint64) uint64 {
 
 	// There might be a case where Nquad from different namespace have the same xid.
 	uid, isNew := m.xids.AssignUid(x.NamespaceAttr(ns, xid))
+	if m.opt.Xids && isNew && !strings.HasPrefix(xid, "_:dg.") {
+		// The blank nodes generated for the JSON objects without a uid have no external ID.
+		m.processNQuad(gql.NQuad{NQuad: &api.NQuad{
+			Subject:   xid,
+			Predicate: x.ExternalIdPred,
+			ObjectValue: &api.Value{
+				Val: &api.Value_StrVal{StrVal: strings.TrimPrefix(xid, "_:")},
+			},
+			Namespace: ns,
+		}})
+	}
 	if !m.opt.StoreXids || !isNew {
 		return uid
 	}
@@ -452,6 +464,15 @@ func (m *mapper) addIndexMapEntries(nq gql.NQuad, de *pb.DirectedEdge) {
 		// Extract tokens.
 		toks, err := tok.BuildTokens(schemaVal.Value, tok.GetTokenizerForLang(toker, nq.Lang))
 		x.Check(err)
+		if name, ok := schemaVal.Value.(string); ok && nq.Predicate == "dgraph.type" {
+			// The type index also holds the types extended by the type of the node.
+			for _, typ := range m.schema.getSupertypes(x.NamespaceAttr(nq.Namespace, name)) {
+				typToks, err := tok.BuildTokens(x.ParseAttr(typ),
+					tok.GetTokenizerForLang(toker, nq.Lang))
+				x.Check(err)
+				toks = append(toks, typToks...)
+			}
+		}
 
 		attr := x.NamespaceAttr(nq.Namespace, nq.Predicate)
 		// Store index posting.
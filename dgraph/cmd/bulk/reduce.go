@@ -83,8 +83,14 @@ func (r *reducer) run() error {
 				mapItrs = append(mapItrs, itr)
 			}
 
-			writer := db.NewStreamWriter()
-			x.Check(writer.Prepare())
+			var writer kvWriter
+			if r.opt.Incremental {
+				writer = newBatchWriter(db)
+			} else {
+				sw := db.NewStreamWriter()
+				x.Check(sw.Prepare())
+				writer = sw
+			}
 			// Split lists are written to a separate DB first to avoid ordering issues.
 			splitWriter := tmpDb.NewManagedWriteBatch()
 
@@ -381,7 +387,7 @@ func (r *reducer) startWriting(ci *countIndexer, writerCh chan *encodeRequest, c
 	tmpWg.Wait()
 }
 
-func (r *reducer) writeSplitLists(db, tmpDb *badger.DB, writer *badger.StreamWriter) {
+func (r *reducer) writeSplitLists(db, tmpDb *badger.DB, writer kvWriter) {
 	// baseStreamId is the max ID seen while writing non-split lists.
 	baseStreamId := atomic.AddUint32(&r.streamId, 1)
 	stream := tmpDb.NewStreamAt(math.MaxUint64)
@@ -646,7 +652,20 @@ func (r *reducer) toList(req *encodeRequest) {
 			}
 		}
 
-		if posting.ShouldSplit(pl) {
+		if r.opt.Incremental {
+			// The delta is merged with the existing posting list when read, and rolled up and
+			// split by the Alpha if needed.
+			val, err := toDelta(pl, bm).Marshal()
+			x.Check(err)
+			kv := &bpb.KV{
+				Key:      y.Copy(currentKey),
+				Value:    val,
+				UserMeta: []byte{posting.BitDeltaPosting},
+				Version:  writeVersionTs,
+				StreamId: r.streamIdFor(pk.Attr),
+			}
+			badger.KVToBuffer(kv, kvBuf)
+		} else if posting.ShouldSplit(pl) {
 			// Give ownership of pl.Pack away to list. Rollup would deallocate the Pack.
 			l := posting.NewList(y.Copy(currentKey), pl, writeVersionTs)
 			kvs, err := l.Rollup(nil)
@@ -38,6 +38,7 @@ import (
 	"github.com/dgraph-io/ristretto/z"
 
 	"github.com/dgraph-io/dgraph/tok"
+	"github.com/dgraph-io/dgraph/validate"
 	"github.com/dgraph-io/dgraph/x"
 	"github.com/spf13/cobra"
 )
@@ -64,12 +65,17 @@ func init() {
 
 	flag := Bulk.Cmd.Flags()
 	flag.StringP("files", "f", "",
-		"Location of *.rdf(.gz) or *.json(.gz) file(s) to load.")
+		"Location of *.rdf(.gz), *.json(.gz), *.csv(.gz), *.graphml(.gz) or *.graphson(.gz) "+
+			"file(s) to load.")
 	flag.StringP("schema", "s", "",
 		"Location of schema file.")
 	flag.StringP("graphql_schema", "g", "", "Location of the GraphQL schema file.")
 	flag.String("format", "",
-		"Specify file format (rdf or json) instead of getting it from filename.")
+		"Specify file format (rdf, json, csv, neo4j, graphml or graphson) instead of getting it "+
+			"from filename. The neo4j format loads the CSV files of neo4j-admin import and export.")
+	flag.String("csv_mapping", "", "Location of the JSON file mapping the columns of the CSV "+
+		"files to predicates. By default, the first column holds the xids of the nodes, and "+
+		"each of the other columns is loaded into the predicate with the same name.")
 	flag.Bool("encrypted", false,
 		"Flag to indicate whether schema and data files are encrypted. "+
 			"Must be specified with --encryption or vault option(s).")
@@ -80,6 +86,11 @@ func init() {
 		"Location to write the final dgraph data directories.")
 	flag.Bool("replace_out", false,
 		"Replace out directory and its contents if it exists.")
+	flag.Bool("incremental", false,
+		"Load the data into the p directories of an existing cluster in --out, where the p "+
+			"directory of group i+1 is <out>/<i>/p. The Alphas must be stopped. The predicates "+
+			"already in the cluster keep their schema, and those with a @count index can't be "+
+			"loaded. --map_shards and --reduce_shards are set to the number of groups.")
 	flag.String("tmp", "tmp",
 		"Temp directory used to use for on-disk scratch space. Requires free space proportional"+
 			" to the size of the RDF file and the amount of indexing used.")
@@ -99,6 +110,9 @@ func init() {
 			"must be less than or equal to the number of reduce shards.")
 	flag.Bool("version", false, "Prints the version of Dgraph Bulk Loader.")
 	flag.Bool("store_xids", false, "Generate an xid edge for each node.")
+	flag.Bool("xids", false, "Set the blank nodes as the external IDs of their nodes, so that "+
+		"the live loader and the upserts by external ID find them. The UIDs in the files are "+
+		"treated as external IDs too.")
 	flag.StringP("zero", "z", "localhost:5080", "gRPC address for Dgraph zero")
 	flag.String("xidmap", "", "Directory to store xid to uid mapping")
 	// TODO: Potentially move http server to main.
@@ -115,6 +129,8 @@ func init() {
 			"more parallelism, but increases memory usage.")
 	flag.String("custom_tokenizers", "",
 		"Comma separated list of tokenizer plugins")
+	flag.String("custom_validators", "",
+		"Comma separated list of validator plugins")
 	flag.Bool("new_uids", false,
 		"Ignore UIDs in load files and assign new ones.")
 	flag.Uint64("force-namespace", math.MaxUint64,
@@ -150,6 +166,7 @@ func run() {
 	opt := options{
 		DataFiles:        Bulk.Conf.GetString("files"),
 		DataFormat:       Bulk.Conf.GetString("format"),
+		CsvMapping:       Bulk.Conf.GetString("csv_mapping"),
 		EncryptionKey:    keys.EncKey,
 		SchemaFile:       Bulk.Conf.GetString("schema"),
 		GqlSchemaFile:    Bulk.Conf.GetString("graphql_schema"),
@@ -157,6 +174,7 @@ func run() {
 		EncryptedOut:     Bulk.Conf.GetBool("encrypted_out"),
 		OutDir:           Bulk.Conf.GetString("out"),
 		ReplaceOutDir:    Bulk.Conf.GetBool("replace_out"),
+		Incremental:      Bulk.Conf.GetBool("incremental"),
 		TmpDir:           Bulk.Conf.GetString("tmp"),
 		NumGoroutines:    Bulk.Conf.GetInt("num_go_routines"),
 		MapBufSize:       uint64(Bulk.Conf.GetInt("mapoutput_mb")),
@@ -166,12 +184,14 @@ func run() {
 		NumReducers:      Bulk.Conf.GetInt("reducers"),
 		Version:          Bulk.Conf.GetBool("version"),
 		StoreXids:        Bulk.Conf.GetBool("store_xids"),
+		Xids:             Bulk.Conf.GetBool("xids"),
 		ZeroAddr:         Bulk.Conf.GetString("zero"),
 		HttpAddr:         Bulk.Conf.GetString("http"),
 		IgnoreErrors:     Bulk.Conf.GetBool("ignore_errors"),
 		MapShards:        Bulk.Conf.GetInt("map_shards"),
 		ReduceShards:     Bulk.Conf.GetInt("reduce_shards"),
 		CustomTokenizers: Bulk.Conf.GetString("custom_tokenizers"),
+		CustomValidators: Bulk.Conf.GetString("custom_validators"),
 		NewUids:          Bulk.Conf.GetBool("new_uids"),
 		ClientDir:        Bulk.Conf.GetString("xidmap"),
 		Namespace:        Bulk.Conf.GetUint64("force-namespace"),
@@ -237,6 +257,25 @@ func run() {
 		}
 	}
 
+	if opt.Incremental {
+		if opt.ReplaceOutDir {
+			fmt.Fprint(os.Stderr, "Invalid flags: --incremental can't be used with --replace_out\n")
+			os.Exit(1)
+		}
+		if opt.GqlSchemaFile != "" {
+			fmt.Fprint(os.Stderr, "Invalid flags: --incremental can't be used with "+
+				"--graphql_schema. Update the GraphQL schema once the Alphas are up.\n")
+			os.Exit(1)
+		}
+		dirs, err := existingShardDirs(opt.OutDir)
+		x.CheckfNoTrace(err)
+		opt.shardOutputDirs = dirs
+		opt.MapShards, opt.ReduceShards = len(dirs), len(dirs)
+		if opt.NumReducers > opt.ReduceShards {
+			opt.NumReducers = opt.ReduceShards
+		}
+	}
+
 	if opt.ReduceShards > opt.MapShards {
 		fmt.Fprintf(os.Stderr, "Invalid flags: reduce_shards(%d) should be <= map_shards(%d)\n",
 			opt.ReduceShards, opt.MapShards)
@@ -252,6 +291,11 @@ func run() {
 			tok.LoadCustomTokenizer(soFile)
 		}
 	}
+	if opt.CustomValidators != "" {
+		for _, soFile := range strings.Split(opt.CustomValidators, ",") {
+			validate.LoadCustomValidator(soFile)
+		}
+	}
 	if opt.MapBufSize <= 0 || opt.PartitionBufSize <= 0 {
 		fmt.Fprintf(os.Stderr, "mapoutput_mb: %d and partition_mb: %d must be greater than zero\n",
 			opt.MapBufSize, opt.PartitionBufSize)
@@ -274,7 +318,7 @@ func run() {
 
 	// Make sure it's OK to create or replace the directory specified with the --out option.
 	// It is always OK to create or replace the default output directory.
-	if opt.OutDir != defaultOutDir && !opt.ReplaceOutDir {
+	if opt.OutDir != defaultOutDir && !opt.ReplaceOutDir && !opt.Incremental {
 		err := x.IsMissingOrEmptyDir(opt.OutDir)
 		if err == nil {
 			fmt.Fprintf(os.Stderr, "Output directory exists and is not empty."+
@@ -285,14 +329,17 @@ func run() {
 		}
 	}
 
-	// Delete and recreate the output dirs to ensure they are empty.
-	x.Check(os.RemoveAll(opt.OutDir))
-	for i := 0; i < opt.ReduceShards; i++ {
-		dir := filepath.Join(opt.OutDir, strconv.Itoa(i), "p")
-		x.Check(os.MkdirAll(dir, 0700))
-		opt.shardOutputDirs = append(opt.shardOutputDirs, dir)
+	// Delete and recreate the output dirs to ensure they are empty. In incremental mode, they
+	// hold the data of the cluster.
+	if !opt.Incremental {
+		x.Check(os.RemoveAll(opt.OutDir))
+		for i := 0; i < opt.ReduceShards; i++ {
+			dir := filepath.Join(opt.OutDir, strconv.Itoa(i), "p")
+			x.Check(os.MkdirAll(dir, 0700))
+			opt.shardOutputDirs = append(opt.shardOutputDirs, dir)
 
-		x.Check(x.WriteGroupIdFile(dir, uint32(i+1)))
+			x.Check(x.WriteGroupIdFile(dir, uint32(i+1)))
+		}
 	}
 
 	// Create a directory just for bulk loader's usage.
@@ -333,7 +380,11 @@ func run() {
 		loader.schema.types = bulkMeta.Types
 	} else {
 		loader.mapStage()
-		mergeMapShardsIntoReduceShards(&opt)
+		if opt.Incremental {
+			mergeShardsByIndex(&opt)
+		} else {
+			mergeMapShardsIntoReduceShards(&opt)
+		}
 		loader.leaseNamespaces()
 
 		bulkMeta := pb.BulkMeta{
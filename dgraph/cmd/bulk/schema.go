@@ -73,6 +73,20 @@ func (s *schemaStore) getSchema(pred string) *pb.SchemaUpdate {
 	return s.schemaMap[pred]
 }
 
+// getSupertypes returns the types extended by the given type, directly or through other types.
+func (s *schemaStore) getSupertypes(typeName string) []string {
+	s.RLock()
+	defer s.RUnlock()
+	return schema.Supertypes(typeName, func(name string) []string {
+		for _, typ := range s.types {
+			if typ.TypeName == name {
+				return typ.Extends
+			}
+		}
+		return nil
+	})
+}
+
 func (s *schemaStore) setSchemaAsList(pred string) {
 	s.Lock()
 	defer s.Unlock()
@@ -134,6 +148,13 @@ func (s *schemaStore) validateType(de *pb.DirectedEdge, objectIsUID bool) {
 		s.Unlock()
 	}
 
+	if s.existing != nil && sch.GetCount() {
+		if _, ok := s.existing.preds[de.Attr]; ok {
+			log.Fatalf("Predicate %s has a @count index, which can't be updated in "+
+				"incremental mode", x.ParseAttr(de.Attr))
+		}
+	}
+
 	err := wk.ValidateAndConvert(de, sch)
 	if err != nil {
 		log.Fatalf("RDF doesn't match schema: %v", err)
@@ -167,6 +188,13 @@ func (s *schemaStore) getPredicates(db *badger.DB) []string {
 }
 
 func (s *schemaStore) write(db *badger.DB, preds []string) {
+	// Write schema and types always at timestamp 1, s.state.writeTs may not be equal to 1
+	// if bulk loader was restarted or other similar scenarios. In incremental mode, they're
+	// written at s.state.writeTs to replace those already in the cluster.
+	ts := uint64(1)
+	if s.opt.Incremental {
+		ts = s.state.writeTs
+	}
 	w := posting.NewTxnWriter(db)
 	for _, pred := range preds {
 		sch, ok := s.schemaMap[pred]
@@ -176,9 +204,7 @@ func (s *schemaStore) write(db *badger.DB, preds []string) {
 		k := x.SchemaKey(pred)
 		v, err := sch.Marshal()
 		x.Check(err)
-		// Write schema and types always at timestamp 1, s.state.writeTs may not be equal to 1
-		// if bulk loader was restarted or other similar scenarios.
-		x.Check(w.SetAt(k, v, posting.BitSchemaPosting, 1))
+		x.Check(w.SetAt(k, v, posting.BitSchemaPosting, ts))
 	}
 
 	// Write all the types as all groups should have access to all the types.
@@ -186,7 +212,7 @@ func (s *schemaStore) write(db *badger.DB, preds []string) {
 		k := x.TypeKey(typ.TypeName)
 		v, err := typ.Marshal()
 		x.Check(err)
-		x.Check(w.SetAt(k, v, posting.BitSchemaPosting, 1))
+		x.Check(w.SetAt(k, v, posting.BitSchemaPosting, ts))
 	}
 
 	x.Check(w.Flush())
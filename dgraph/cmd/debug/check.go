@@ -0,0 +1,351 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package debug
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/dgraph-io/badger/v3"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/dgraph-io/dgraph/codec"
+	"github.com/dgraph-io/dgraph/ee"
+	"github.com/dgraph-io/dgraph/posting"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// The invariants checked by "dgraph debug check". They are the ones the uid-set and bank Jepsen
+// workloads rely on, checked on the data rather than through queries.
+const (
+	// invariantSplits checks that the parts of the multi-part lists exist, and hold each uid once
+	// and within the range of the part.
+	invariantSplits = "splits"
+	// invariantCommitTs checks that no version of a key holds postings committed after it, and
+	// that no part of a multi-part list is newer than its main list.
+	invariantCommitTs = "commit-ts"
+	// invariantDeltas checks that the deltas are only written on the keys read with their deltas.
+	// The deltas of the other keys, like the parts of the multi-part lists, are never read.
+	invariantDeltas = "deltas"
+
+	// unreadable counts the values which can't be decoded, whatever the invariants checked.
+	unreadable = "unreadable"
+)
+
+var allInvariants = []string{invariantSplits, invariantCommitTs, invariantDeltas}
+
+func checkCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "check [" + strings.Join(allInvariants, "|") + "]...",
+		Short: "Check the invariants of the posting lists of a p directory",
+		Long: "Check offline the invariants of the posting lists of a p directory, e.g. after a " +
+			"crash. All the invariants are checked if none is given:\n" +
+			"  splits:    the parts of the multi-part lists exist, and hold each uid once and " +
+			"within their range.\n" +
+			"  commit-ts: no version of a key holds postings committed after it, and no part " +
+			"of a multi-part list is newer than its main list.\n" +
+			"  deltas:    the deltas aren't written on the keys never read with their deltas, " +
+			"like the parts of the multi-part lists.\n" +
+			"The values which can't be decoded are reported too. The command exits with " +
+			"status 1 if a violation is found.",
+		ValidArgs: allInvariants,
+		Args:      cobra.OnlyValidArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			n, err := runCheck(args)
+			if err != nil {
+				fmt.Printf("Error while checking the invariants: %v\n", err)
+				os.Exit(1)
+			}
+			if n > 0 {
+				os.Exit(1)
+			}
+		},
+	}
+	// The flags are shared with the debug command, so that they're read from the same config.
+	for _, name := range []string{"postings", "at", "pred", "ns", "readonly", "encryption",
+		"vault"} {
+		if f := Debug.Cmd.Flag(name); f != nil {
+			cmd.Flags().AddFlag(f)
+		}
+	}
+	return cmd
+}
+
+// runCheck checks the invariants of the p directory, and returns the number of violations found.
+func runCheck(invariants []string) (int, error) {
+	if len(opt.pdir) == 0 {
+		return 0, errors.New("the p directory must be set with --postings")
+	}
+	if len(invariants) == 0 {
+		invariants = allInvariants
+	}
+	keys, err := ee.GetKeys(Debug.Conf)
+	if err != nil {
+		return 0, err
+	}
+	bopts := badger.DefaultOptions(opt.pdir).
+		WithReadOnly(opt.readOnly).
+		WithEncryptionKey(keys.EncKey).
+		WithNamespaceOffset(x.NamespaceOffset)
+	fmt.Printf("Opening DB: %s\n", bopts.Dir)
+	db, err := badger.OpenManaged(bopts)
+	if err != nil {
+		return 0, err
+	}
+	defer db.Close()
+
+	c := newInvariantChecker(db, opt.readTs, invariants)
+	defer c.txn.Discard()
+	if len(opt.predicate) > 0 {
+		c.attr = x.NamespaceAttr(opt.namespace, opt.predicate)
+	}
+	if err := c.run(); err != nil {
+		return 0, err
+	}
+
+	fmt.Printf("\nChecked %d keys at read ts %d.\n", c.keys, c.readTs)
+	var total int
+	for _, inv := range append(invariants, unreadable) {
+		if inv == unreadable && c.violations[inv] == 0 {
+			continue
+		}
+		fmt.Printf("  %-11s %d violations\n", inv+":", c.violations[inv])
+		total += c.violations[inv]
+	}
+	return total, nil
+}
+
+type invariantChecker struct {
+	txn    *badger.Txn
+	readTs uint64
+	// attr restricts the check to a predicate, if set.
+	attr    string
+	enabled map[string]bool
+
+	keys       uint64
+	violations map[string]int
+}
+
+func newInvariantChecker(db *badger.DB, readTs uint64, invariants []string) *invariantChecker {
+	c := &invariantChecker{
+		txn:        db.NewTransactionAt(readTs, false),
+		readTs:     readTs,
+		enabled:    make(map[string]bool),
+		violations: make(map[string]int),
+	}
+	for _, inv := range invariants {
+		c.enabled[inv] = true
+	}
+	return c
+}
+
+func (c *invariantChecker) report(inv string, key []byte, version uint64, format string,
+	args ...interface{}) {
+	c.violations[inv]++
+	var desc string
+	if pk, err := x.Parse(key); err == nil {
+		desc = fmt.Sprintf(" attr: %s uid: %#x", x.ParseAttr(pk.Attr), pk.Uid)
+		if pk.HasStartUid {
+			desc += fmt.Sprintf(" startUid: %#x", pk.StartUid)
+		}
+	}
+	fmt.Printf("[%s] key: %x%s ts: %d: %s\n", inv, key, desc, version,
+		fmt.Sprintf(format, args...))
+}
+
+// keyVersion is a version of a key which is read along with the latest version, i.e. a version
+// above the first complete list, deletion or discard marker.
+type keyVersion struct {
+	version uint64
+	meta    byte
+	plist   *pb.PostingList
+}
+
+func (c *invariantChecker) run() error {
+	iopt := badger.DefaultIteratorOptions
+	iopt.AllVersions = true
+	itr := c.txn.NewIterator(iopt)
+	defer itr.Close()
+
+	for itr.Rewind(); itr.Valid(); {
+		key := itr.Item().KeyCopy(nil)
+		pk, err := x.Parse(key)
+		// The keys which don't belong to a predicate, like the index state keys, hold no postings.
+		if err != nil || pk.Attr == "" || (len(c.attr) > 0 && pk.Attr != c.attr) {
+			for ; itr.Valid() && bytes.Equal(itr.Item().Key(), key); itr.Next() {
+			}
+			continue
+		}
+		c.keys++
+
+		// The versions are iterated from the latest. The older versions than the first complete
+		// list, deletion or discard marker are never read, and wait for the GC.
+		var versions []keyVersion
+		done := false
+		for ; itr.Valid() && bytes.Equal(itr.Item().Key(), key); itr.Next() {
+			item := itr.Item()
+			if done || item.IsDeletedOrExpired() {
+				done = true
+				continue
+			}
+			kv := keyVersion{version: item.Version(), meta: posting.PostingMeta(item.UserMeta())}
+			if kv.meta != posting.BitSchemaPosting {
+				kv.plist, err = readPlist(item)
+				if err != nil {
+					c.report(unreadable, key, kv.version, "%v", err)
+				}
+			}
+			versions = append(versions, kv)
+			done = kv.meta != posting.BitDeltaPosting || item.DiscardEarlierVersions()
+		}
+		if err := c.checkKey(key, pk, versions); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readPlist(item *badger.Item) (*pb.PostingList, error) {
+	val, err := item.ValueCopy(nil)
+	if err != nil {
+		return nil, err
+	}
+	if val, err = posting.VerifyValue(item, val); err != nil {
+		return nil, err
+	}
+	plist := &pb.PostingList{}
+	if err := plist.Unmarshal(val); err != nil {
+		return nil, err
+	}
+	return plist, nil
+}
+
+func (c *invariantChecker) checkKey(key []byte, pk x.ParsedKey, versions []keyVersion) error {
+	for _, kv := range versions {
+		if c.enabled[invariantDeltas] && kv.meta == posting.BitDeltaPosting &&
+			(pk.HasStartUid || pk.IsSchema() || pk.IsType()) {
+			c.report(invariantDeltas, key, kv.version,
+				"delta written on a key which is never read with its deltas")
+		}
+		if c.enabled[invariantCommitTs] && kv.plist != nil {
+			c.checkCommitTs(key, kv)
+		}
+	}
+	if !c.enabled[invariantSplits] && !c.enabled[invariantCommitTs] {
+		return nil
+	}
+	if pk.HasStartUid || len(versions) == 0 {
+		return nil
+	}
+	base := versions[len(versions)-1]
+	if base.meta != posting.BitCompletePosting || base.plist == nil ||
+		len(base.plist.Splits) == 0 {
+		return nil
+	}
+	return c.checkSplits(key, base)
+}
+
+// checkCommitTs checks that the postings of a version of a key were committed at or before it.
+// The rollups write the complete lists after the last commit they include.
+func (c *invariantChecker) checkCommitTs(key []byte, kv keyVersion) {
+	if kv.meta == posting.BitCompletePosting && kv.plist.CommitTs > kv.version {
+		c.report(invariantCommitTs, key, kv.version,
+			"complete list includes the commits until ts %d", kv.plist.CommitTs)
+	}
+	for _, p := range kv.plist.Postings {
+		switch {
+		case p.StartTs != 0 && p.StartTs >= kv.version:
+			c.report(invariantCommitTs, key, kv.version,
+				"posting for uid %#x was started at ts %d", p.Uid, p.StartTs)
+		case p.CommitTs > kv.version:
+			c.report(invariantCommitTs, key, kv.version,
+				"posting for uid %#x was committed at ts %d", p.Uid, p.CommitTs)
+		}
+	}
+}
+
+// checkSplits checks the parts of the multi-part list whose latest complete list is base.
+func (c *invariantChecker) checkSplits(key []byte, base keyVersion) error {
+	splits := base.plist.Splits
+	if c.enabled[invariantSplits] && splits[0] != 1 {
+		c.report(invariantSplits, key, base.version, "first part starts at uid %#x", splits[0])
+	}
+	// The uids of the parts, mapped to the start uid of the part holding them.
+	seen := make(map[uint64]uint64)
+	for i, startUid := range splits {
+		endUid := uint64(0)
+		if i+1 < len(splits) {
+			endUid = splits[i+1]
+			if c.enabled[invariantSplits] && endUid <= startUid {
+				c.report(invariantSplits, key, base.version,
+					"splits aren't sorted: %#x is followed by %#x", startUid, endUid)
+			}
+		}
+		partKey, err := x.SplitKey(key, startUid)
+		if err != nil {
+			return err
+		}
+		item, err := c.txn.Get(partKey)
+		switch {
+		case err == badger.ErrKeyNotFound:
+			if c.enabled[invariantSplits] {
+				c.report(invariantSplits, key, base.version,
+					"part starting at uid %#x isn't found", startUid)
+			}
+			continue
+		case err != nil:
+			return err
+		}
+
+		if c.enabled[invariantCommitTs] && item.Version() > base.version {
+			c.report(invariantCommitTs, partKey, item.Version(),
+				"part is newer than its main list, written at ts %d", base.version)
+		}
+		if !c.enabled[invariantSplits] ||
+			posting.PostingMeta(item.UserMeta()) != posting.BitCompletePosting {
+			// The deltas of the parts are reported by the deltas invariant.
+			continue
+		}
+		part, err := readPlist(item)
+		if err != nil {
+			c.report(unreadable, partKey, item.Version(), "%v", err)
+			continue
+		}
+		check := func(uid uint64) {
+			if prev, ok := seen[uid]; ok && prev != startUid {
+				c.report(invariantSplits, partKey, item.Version(),
+					"uid %#x is also in the part starting at uid %#x", uid, prev)
+			}
+			seen[uid] = startUid
+			if uid < startUid || (endUid > 0 && uid >= endUid) {
+				c.report(invariantSplits, partKey, item.Version(),
+					"uid %#x is out of the range of the part", uid)
+			}
+		}
+		for _, uid := range codec.ToUids(part, 0) {
+			check(uid)
+		}
+		for _, p := range part.Postings {
+			check(p.Uid)
+		}
+	}
+	return nil
+}
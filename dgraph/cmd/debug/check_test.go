@@ -0,0 +1,94 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package debug
+
+import (
+	"math"
+	"testing"
+
+	"github.com/dgraph-io/badger/v3"
+	"github.com/dgraph-io/sroar"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/posting"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+func writePlist(t *testing.T, db *badger.DB, key []byte, version uint64, meta byte,
+	plist *pb.PostingList) {
+	val, err := plist.Marshal()
+	require.NoError(t, err)
+	txn := db.NewTransactionAt(version, true)
+	defer txn.Discard()
+	require.NoError(t, txn.SetEntry(badger.NewEntry(key, val).WithMeta(meta)))
+	require.NoError(t, txn.CommitAt(version, nil))
+}
+
+func splitKey(t *testing.T, key []byte, startUid uint64) []byte {
+	k, err := x.SplitKey(key, startUid)
+	require.NoError(t, err)
+	return k
+}
+
+func TestInvariantChecker(t *testing.T) {
+	db, err := badger.OpenManaged(badger.DefaultOptions("").WithInMemory(true).
+		WithNamespaceOffset(x.NamespaceOffset))
+	require.NoError(t, err)
+	defer db.Close()
+	attr := x.GalaxyAttr("friend")
+
+	// A multi-part list whose second part is missing, and whose first part has a uid beyond
+	// its range.
+	multi := x.DataKey(attr, 1)
+	writePlist(t, db, multi, 10, posting.BitCompletePosting,
+		&pb.PostingList{Splits: []uint64{1, 100}, CommitTs: 10})
+	bm := sroar.NewBitmap()
+	bm.Set(5)
+	bm.Set(150)
+	writePlist(t, db, splitKey(t, multi, 1), 10, posting.BitCompletePosting,
+		&pb.PostingList{Bitmap: bm.ToBuffer(), CommitTs: 10})
+
+	// A delta holding a posting committed after it.
+	writePlist(t, db, x.DataKey(attr, 2), 20, posting.BitDeltaPosting,
+		&pb.PostingList{Postings: []*pb.Posting{{Uid: 7, StartTs: 15, CommitTs: 25}}})
+
+	// A delta written on the part of a multi-part list, newer than its main list.
+	parted := x.DataKey(attr, 3)
+	writePlist(t, db, parted, 30, posting.BitCompletePosting,
+		&pb.PostingList{Splits: []uint64{1}, CommitTs: 30})
+	writePlist(t, db, splitKey(t, parted, 1), 30, posting.BitCompletePosting,
+		&pb.PostingList{CommitTs: 30})
+	writePlist(t, db, splitKey(t, parted, 1), 31, posting.BitDeltaPosting,
+		&pb.PostingList{Postings: []*pb.Posting{{Uid: 8, StartTs: 29, CommitTs: 31}}})
+
+	c := newInvariantChecker(db, math.MaxUint64, allInvariants)
+	defer c.txn.Discard()
+	require.NoError(t, c.run())
+	require.Equal(t, uint64(5), c.keys)
+	require.Equal(t, map[string]int{
+		invariantSplits:   2,
+		invariantCommitTs: 2,
+		invariantDeltas:   1,
+	}, c.violations)
+
+	// Only the given invariants are checked.
+	deltas := newInvariantChecker(db, math.MaxUint64, []string{invariantDeltas})
+	defer deltas.txn.Discard()
+	require.NoError(t, deltas.run())
+	require.Equal(t, map[string]int{invariantDeltas: 1}, deltas.violations)
+}
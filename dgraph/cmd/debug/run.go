@@ -117,6 +117,8 @@ func init() {
 		"Set snapshot term,index,readts to this. Value must be comma-separated list containing"+
 			" the value for these vars in that order.")
 	ee.RegisterEncFlag(flag)
+
+	Debug.Cmd.AddCommand(checkCmd())
 }
 
 func toInt(o *pb.Posting) int {
@@ -300,6 +302,8 @@ func showAllPostingsAt(db *badger.DB, readTs uint64) {
 		fmt.Fprintf(&buf, "  key: %+v hex: %x\n", pk, item.Key())
 		val, err := item.ValueCopy(nil)
 		x.Check(err)
+		val, err = posting.VerifyValue(item, val)
+		x.Check(err)
 		var plist pb.PostingList
 		x.Check(plist.Unmarshal(val))
 
@@ -394,6 +398,11 @@ func history(lookup []byte, itr *badger.Iterator) {
 		}
 		val, err := item.ValueCopy(nil)
 		x.Check(err)
+		val, err = posting.VerifyValue(item, val)
+		if err != nil {
+			x.Check2(buf.WriteString("{corrupted}\n"))
+			continue
+		}
 
 		meta := item.UserMeta()
 		if meta&posting.BitCompletePosting > 0 {
@@ -619,7 +628,7 @@ func printKeys(db *badger.DB) {
 				x.Check2(buf.WriteString(" {v.del}"))
 				break
 			}
-			switch item.UserMeta() {
+			switch posting.PostingMeta(item.UserMeta()) {
 			// This is rather a default case as one of the 4 bit must be set.
 			case posting.BitCompletePosting, posting.BitEmptyPosting, posting.BitSchemaPosting:
 				sz += item.EstimatedSize()
@@ -89,6 +89,8 @@ type loader struct {
 	zeroconn   *grpc.ClientConn
 	schema     *schema
 	namespaces map[uint64]struct{}
+	// checkpoint records the progress of the load, if --checkpoint is set.
+	checkpoint *checkpoint
 
 	upsertLock sync.RWMutex
 }
@@ -150,6 +152,9 @@ func (l *loader) infinitelyRetry(req *request) {
 			}
 			atomic.AddUint64(&l.nquads, uint64(len(req.Set)))
 			atomic.AddUint64(&l.txns, 1)
+			if req.done != nil {
+				req.done()
+			}
 			return
 		}
 		nretries++
@@ -182,6 +187,9 @@ func (l *loader) request(req *request) {
 		atomic.AddUint64(&l.nquads, uint64(len(req.Set)))
 		atomic.AddUint64(&l.txns, 1)
 		l.deregister(req)
+		if req.done != nil {
+			req.done()
+		}
 		return
 	}
 	handleError(err, false)
@@ -0,0 +1,250 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package live
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/badger/v3"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/dgraph-io/dgraph/xidmap"
+	"github.com/dgraph-io/ristretto/z"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+)
+
+// fileDone is the number of chunks recorded for a file which was completely loaded.
+const fileDone = math.MaxUint64
+
+// checkpoint records the progress of the live loader in a Badger DB, so that a run with --resume
+// skips the chunks of the data files which were committed by the previous runs. The xid to uid
+// mappings are kept in the xidmap DB, which is synced before a progress is recorded. Otherwise, a
+// resumed run could assign new uids to the blank nodes of the committed chunks.
+type checkpoint struct {
+	db    *badger.DB
+	alloc *xidmap.XidMap
+
+	sync.Mutex
+	files map[string]*fileProgress
+}
+
+// openCheckpoint opens the checkpoint DB in dir. It fails if the DB has the progress of a
+// previous run, unless resume is set.
+func openCheckpoint(dir string, resume bool) (*checkpoint, error) {
+	x.Check(os.MkdirAll(dir, 0700))
+	db, err := badger.Open(badger.DefaultOptions(filepath.Join(dir, "chunks")).
+		WithLogger(nil))
+	if err != nil {
+		return nil, errors.Wrapf(err, "while opening the checkpoint in %s", dir)
+	}
+	cp := &checkpoint{db: db, files: make(map[string]*fileProgress)}
+	if resume {
+		return cp, nil
+	}
+
+	var empty bool
+	err = db.View(func(txn *badger.Txn) error {
+		itr := txn.NewIterator(badger.IteratorOptions{})
+		defer itr.Close()
+		itr.Rewind()
+		empty = !itr.Valid()
+		return nil
+	})
+	if err == nil && !empty {
+		err = errors.Errorf("Checkpoint %s has the progress of a previous run. Use --resume to "+
+			"continue it, or remove the directory to load from scratch.", dir)
+	}
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return cp, nil
+}
+
+// progress returns the progress of the given file, loading the number of chunks committed by
+// the previous runs.
+func (cp *checkpoint) progress(file string) (*fileProgress, error) {
+	fp := &fileProgress{}
+	err := cp.db.View(func(txn *badger.Txn) error {
+		item, err := txn.Get([]byte(file))
+		if err == badger.ErrKeyNotFound {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		return item.Value(func(val []byte) error {
+			fp.skip = binary.BigEndian.Uint64(val)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "while reading the checkpoint of %s", file)
+	}
+	fp.saved = fp.skip
+
+	cp.Lock()
+	cp.files[file] = fp
+	cp.Unlock()
+	return fp, nil
+}
+
+// save records the number of committed chunks of each file. If done is set, all the files are
+// recorded as completely loaded.
+func (cp *checkpoint) save(done bool) error {
+	cp.Lock()
+	defer cp.Unlock()
+
+	updates := make(map[string]uint64)
+	for file, fp := range cp.files {
+		chunks := fileDone
+		if !done {
+			chunks = fp.committedChunks()
+		}
+		if chunks != fp.saved {
+			updates[file] = chunks
+		}
+	}
+	if len(updates) == 0 {
+		return nil
+	}
+	// The committed chunks may have assigned new uids. Persist them first.
+	if err := cp.alloc.Sync(); err != nil {
+		return errors.Wrapf(err, "while syncing the xidmap")
+	}
+	err := cp.db.Update(func(txn *badger.Txn) error {
+		for file, chunks := range updates {
+			var buf [8]byte
+			binary.BigEndian.PutUint64(buf[:], chunks)
+			if err := txn.Set([]byte(file), buf[:]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.Wrapf(err, "while saving the checkpoint")
+	}
+	for file, chunks := range updates {
+		cp.files[file].saved = chunks
+	}
+	return cp.db.Sync()
+}
+
+// run saves the checkpoint periodically, until the closer is signalled.
+func (cp *checkpoint) run(closer *z.Closer) {
+	defer closer.Done()
+	ticker := time.NewTicker(5 * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-closer.HasBeenClosed():
+			return
+		case <-ticker.C:
+			if err := cp.save(false); err != nil {
+				glog.Errorf("Unable to save the checkpoint: %v", err)
+				fmt.Printf("Unable to save the checkpoint: %v\n", err)
+			}
+		}
+	}
+}
+
+func (cp *checkpoint) close() error {
+	return cp.db.Close()
+}
+
+// fileProgress tracks the chunks of a data file whose N-Quads have all been committed.
+//
+// The N-Quads are numbered in the order they're parsed. The chunker records the number of N-Quads
+// parsed at the end of each chunk. The N-Quads are then sent in segments, each being a
+// contiguous range of N-Quads split into requests. Once all the requests of a segment and of the
+// segments before it are committed, all the chunks ending before the end of the segment are
+// committed.
+type fileProgress struct {
+	// skip is the number of chunks committed by the previous runs.
+	skip uint64
+	// saved is the number of committed chunks last saved in the checkpoint.
+	saved uint64
+
+	sync.Mutex
+	// chunkEnds are the number of N-Quads parsed at the end of the chunks, starting from the
+	// chunk skip+chunksDone, which aren't committed yet.
+	chunkEnds  []uint64
+	chunksDone uint64
+	segments   []*segment
+	// committed is the number of N-Quads committed, counting from the first parsed one.
+	committed uint64
+}
+
+type segment struct {
+	end     uint64
+	pending int
+}
+
+// chunkParsed records that the chunk ending with the given number of N-Quads was parsed.
+func (fp *fileProgress) chunkParsed(end uint64) {
+	fp.Lock()
+	defer fp.Unlock()
+	fp.chunkEnds = append(fp.chunkEnds, end)
+	fp.advance()
+}
+
+// newSegment returns a segment ending with the given number of N-Quads, to be sent in the given
+// number of requests.
+func (fp *fileProgress) newSegment(end uint64, requests int) *segment {
+	fp.Lock()
+	defer fp.Unlock()
+	seg := &segment{end: end, pending: requests}
+	fp.segments = append(fp.segments, seg)
+	return seg
+}
+
+// requestDone records that one of the requests of the segment was committed.
+func (fp *fileProgress) requestDone(seg *segment) {
+	fp.Lock()
+	defer fp.Unlock()
+	seg.pending--
+	for len(fp.segments) > 0 && fp.segments[0].pending == 0 {
+		fp.committed = fp.segments[0].end
+		fp.segments = fp.segments[1:]
+	}
+	fp.advance()
+}
+
+func (fp *fileProgress) advance() {
+	for len(fp.chunkEnds) > 0 && fp.chunkEnds[0] <= fp.committed {
+		fp.chunkEnds = fp.chunkEnds[1:]
+		fp.chunksDone++
+	}
+}
+
+// committedChunks returns the number of chunks of the file committed, including those committed
+// by the previous runs.
+func (fp *fileProgress) committedChunks() uint64 {
+	if fp.skip == fileDone {
+		return fileDone
+	}
+	fp.Lock()
+	defer fp.Unlock()
+	return fp.skip + fp.chunksDone
+}
@@ -0,0 +1,46 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package live
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileProgress(t *testing.T) {
+	fp := &fileProgress{skip: 3}
+	// Chunks 4 to 6 end at 10, 10 and 25 N-Quads.
+	fp.chunkParsed(10)
+	fp.chunkParsed(10)
+	seg1 := fp.newSegment(20, 2)
+	fp.chunkParsed(25)
+	seg2 := fp.newSegment(25, 1)
+	require.Equal(t, uint64(3), fp.committedChunks())
+
+	// The second segment can't be committed before the first one.
+	fp.requestDone(seg2)
+	fp.requestDone(seg1)
+	require.Equal(t, uint64(3), fp.committedChunks())
+
+	fp.requestDone(seg1)
+	require.Equal(t, uint64(6), fp.committedChunks())
+
+	// A chunk without N-Quads is committed right away.
+	fp.chunkParsed(25)
+	require.Equal(t, uint64(7), fp.committedChunks())
+}
@@ -30,6 +30,8 @@ import (
 	"net/http"
 	_ "net/http/pprof" // http profiler
 	"os"
+	"path/filepath"
+	"runtime"
 	"sort"
 	"strconv"
 	"strings"
@@ -67,6 +69,7 @@ type options struct {
 	zero            string
 	concurrent      int
 	batchSize       int
+	parsers         int
 	clientDir       string
 	authToken       string
 	useCompression  bool
@@ -75,10 +78,14 @@ type options struct {
 	httpAddr        string
 	bufferSize      int
 	upsertPredicate string
+	xids            bool
 	tmpDir          string
 	key             x.Sensitive
 	namespaceToLoad uint64
 	preserveNs      bool
+	checkpointDir   string
+	resume          bool
+	csvMapping      *chunker.CSVMapping
 }
 
 type predicate struct {
@@ -103,6 +110,8 @@ type schema struct {
 type request struct {
 	*api.Mutation
 	conflicts []uint64
+	// done is called once the mutation is committed.
+	done func()
 }
 
 func (l *schema) init(ns uint64, galaxyOperation bool) {
@@ -146,10 +155,16 @@ func init() {
 	// --tls SuperFlag
 	x.RegisterClientTLSFlags(flag)
 
-	flag.StringP("files", "f", "", "Location of *.rdf(.gz) or *.json(.gz) file(s) to load")
+	flag.StringP("files", "f", "",
+		"Location of *.rdf(.gz), *.json(.gz), *.csv(.gz), *.graphml(.gz) or *.graphson(.gz) "+
+			"file(s) to load")
 	flag.StringP("schema", "s", "", "Location of schema file")
-	flag.String("format", "", "Specify file format (rdf or json) instead of getting it "+
-		"from filename")
+	flag.String("format", "", "Specify file format (rdf, json, csv, neo4j, graphml or graphson) "+
+		"instead of getting it from filename. The neo4j format loads the CSV files of "+
+		"neo4j-admin import and export.")
+	flag.String("csv_mapping", "", "Location of the JSON file mapping the columns of the CSV "+
+		"files to predicates. By default, the first column holds the xids of the nodes, and "+
+		"each of the other columns is loaded into the predicate with the same name.")
 	flag.StringP("alpha", "a", "127.0.0.1:9080",
 		"Comma-separated list of Dgraph alpha gRPC server addresses")
 	flag.StringP("zero", "z", "127.0.0.1:5080", "Dgraph zero gRPC server address")
@@ -157,7 +172,14 @@ func init() {
 		"Number of concurrent requests to make to Dgraph")
 	flag.IntP("batch", "b", 1000,
 		"Number of N-Quads to send as part of a mutation.")
+	flag.Int("parsers", int(math.Ceil(float64(runtime.NumCPU())/4.0)),
+		"Number of goroutines parsing the chunks of a data file concurrently.")
 	flag.StringP("xidmap", "x", "", "Directory to store xid to uid mapping")
+	flag.String("checkpoint", "", "Directory to record the progress of the load in, so that "+
+		"it can be resumed with --resume if it fails. The xid to uid mapping is also stored in "+
+		"this directory, unless --xidmap is set.")
+	flag.Bool("resume", false, "Resume the load recorded in the --checkpoint directory, "+
+		"skipping the data already committed by the previous runs.")
 	flag.StringP("auth_token", "t", "",
 		"The auth token passed to the server for Alter operation of the schema file. "+
 			"If used with --slash_grpc_endpoint, then this should be set to the API token issued"+
@@ -181,7 +203,13 @@ func init() {
 
 	flag.StringP("bufferSize", "m", "100", "Buffer for each thread")
 	flag.StringP("upsertPredicate", "U", "", "run in upsertPredicate mode. the value would "+
-		"be used to store blank nodes as an xid")
+		"be used to store blank nodes as an xid. The nodes which already have the xid as the "+
+		"value of the predicate are reused, so that reloading the same files doesn't duplicate "+
+		"the nodes. The UIDs in the files are treated as xids too. The predicate is created "+
+		"with a hash index if it doesn't exist.")
+	flag.Bool("xids", false, "Map the blank nodes to the nodes having them as their external "+
+		"ID, creating the missing nodes, so that reloading the same files doesn't duplicate the "+
+		"nodes. The UIDs in the files are treated as external IDs too.")
 	flag.String("tmp", "t", "Directory to store temporary buffers.")
 	flag.Int64("force-namespace", 0, "Namespace onto which to load the data."+
 		"Only guardian of galaxy should use this for loading data into multiple namespaces or some"+
@@ -271,7 +299,10 @@ func (l *loader) uid(val string, ns uint64) string {
 	// to be an existing node in the graph. There is limited protection against
 	// a user selecting an unassigned UID in this way - it may be assigned
 	// later to another node. It is up to the user to avoid this.
-	if !opt.newUids {
+	// In the upsert mode, the UIDs are xids too, as in the files exported from another cluster.
+	// They have been mapped to the nodes having them as the upsert predicate by upsertUids, or
+	// as their external ID by resolveXids.
+	if !opt.newUids && len(opt.upsertPredicate) == 0 && !opt.xids {
 		if uid, err := strconv.ParseUint(val, 0, 64); err == nil {
 			return fmt.Sprintf("%#x", uid)
 		}
@@ -286,6 +317,46 @@ func (l *loader) uid(val string, ns uint64) string {
 	return fmt.Sprintf("%#x", uint64(uid))
 }
 
+// checkUpsertPredicate verifies that the upsert predicate is a string predicate indexed for the eq
+// function. If it doesn't exist, it is created with a hash index and the @upsert directive.
+func (l *loader) checkUpsertPredicate(ctx context.Context, dc *dgo.Dgraph) error {
+	pred, ok := l.schema.preds[x.NamespaceAttr(opt.namespaceToLoad, opt.upsertPredicate)]
+	if !ok {
+		fmt.Printf("Creating the upsert predicate %q\n", opt.upsertPredicate)
+		if len(opt.authToken) > 0 {
+			md := metadata.New(nil)
+			md.Append("auth-token", opt.authToken)
+			ctx = metadata.NewOutgoingContext(ctx, md)
+		}
+		op := &api.Operation{
+			Schema: fmt.Sprintf("<%s>: string @index(hash) @upsert .", opt.upsertPredicate),
+		}
+		if err := dc.Alter(ctx, op); err != nil {
+			return errors.Wrapf(err, "while creating the upsert predicate %q",
+				opt.upsertPredicate)
+		}
+		var err error
+		l.schema, err = getSchema(ctx, dc, false)
+		return err
+	}
+
+	if pred.ValueType != types.StringID {
+		return errors.Errorf("Upsert predicate %q must be of type string, found %s",
+			opt.upsertPredicate, pred.Type)
+	}
+	for _, tok := range pred.Tokenizer {
+		if tok == "hash" || tok == "exact" {
+			if !pred.Upsert {
+				fmt.Printf("Warning: the upsert predicate %q doesn't have the @upsert directive."+
+					" Concurrent loads can create duplicate nodes.\n", opt.upsertPredicate)
+			}
+			return nil
+		}
+	}
+	return errors.Errorf("Upsert predicate %q must have a hash or exact index",
+		opt.upsertPredicate)
+}
+
 func generateBlankNode(val string) string {
 	// generates "u_hash(val)"
 
@@ -428,6 +499,63 @@ func (l *loader) upsertUids(nqs []*api.NQuad) error {
 	return nil
 }
 
+// resolveXids maps the xids of the given NQuads to the nodes having them as their external ID,
+// through an upsert by external ID which creates the missing nodes.
+func (l *loader) resolveXids(nqs []*api.NQuad) error {
+	l.upsertLock.Lock()
+	defer l.upsertLock.Unlock()
+
+	ids := make(map[string]string)
+	add := func(val string, ns uint64) {
+		// The blank nodes generated for the JSON objects without a uid have no external ID.
+		if len(val) == 0 || strings.HasPrefix(val, "_:dg.") {
+			return
+		}
+		id := x.NamespaceAttr(ns, val)
+		if _, ok := ids[id]; ok || l.alloc.CheckUid(id) {
+			return
+		}
+		ids[id] = strings.TrimPrefix(val, "_:")
+	}
+	for _, nq := range nqs {
+		add(nq.Subject, nq.Namespace)
+		add(nq.ObjectId, nq.Namespace)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	mutations := make([]*api.NQuad, 0, len(ids))
+	for _, xid := range ids {
+		mutations = append(mutations, &api.NQuad{
+			Subject:     "_:" + xid,
+			Predicate:   x.ExternalIdPred,
+			ObjectValue: &api.Value{Val: &api.Value_StrVal{StrVal: xid}},
+		})
+	}
+	ctx := metadata.AppendToOutgoingContext(l.opts.Ctx, "upsert-by-xid", "true")
+	resp, err := l.dc.NewTxn().Do(ctx, &api.Request{
+		CommitNow: true,
+		Mutations: []*api.Mutation{{Set: mutations}},
+	})
+	if err != nil {
+		return err
+	}
+
+	for id, xid := range ids {
+		val, ok := resp.GetUids()[xid]
+		if !ok {
+			return errors.Errorf("No uid was returned for the external ID %q", xid)
+		}
+		uid, err := strconv.ParseUint(val, 0, 64)
+		if err != nil {
+			return err
+		}
+		l.alloc.SetUid(id, uid)
+	}
+	return nil
+}
+
 // allocateUids looks for the maximum uid value in the given NQuads and bumps the
 // maximum seen uid to that value.
 func (l *loader) allocateUids(nqs []*api.NQuad) {
@@ -460,6 +588,21 @@ func (l *loader) allocateUids(nqs []*api.NQuad) {
 func (l *loader) processFile(ctx context.Context, fs filestore.FileStore, filename string,
 	key x.Sensitive) error {
 
+	var fp *fileProgress
+	if l.checkpoint != nil {
+		var err error
+		if fp, err = l.checkpoint.progress(filename); err != nil {
+			return err
+		}
+		if fp.skip == fileDone {
+			fmt.Printf("Skipping data file %q, loaded by a previous run\n", filename)
+			return nil
+		}
+		if fp.skip > 0 {
+			fmt.Printf("Resuming data file %q after %d chunks\n", filename, fp.skip)
+		}
+	}
+
 	fmt.Printf("Processing data file %q\n", filename)
 
 	rd, cleanup := fs.ChunkReader(filename, key)
@@ -471,15 +614,27 @@ func (l *loader) processFile(ctx context.Context, fs filestore.FileStore, filena
 			if isJson {
 				loadType = chunker.JsonFormat
 			} else {
-				return errors.Errorf("need --format=rdf or --format=json to load %s", filename)
+				return errors.Errorf("need --format=rdf, --format=json, --format=csv, "+
+					"--format=neo4j, --format=graphml or --format=graphson to load %s", filename)
 			}
 		}
 	}
 
-	return l.processLoadFile(ctx, rd, chunker.NewChunker(loadType, opt.batchSize))
+	newChunker := func(batchSize int) chunker.Chunker {
+		if loadType == chunker.CsvFormat {
+			return chunker.NewCSVChunker(opt.csvMapping, batchSize)
+		}
+		return chunker.NewChunker(loadType, batchSize)
+	}
+	return l.processLoadFile(ctx, rd, newChunker, fp)
 }
 
-func (l *loader) processLoadFile(ctx context.Context, rd *bufio.Reader, ck chunker.Chunker) error {
+// processLoadFile loads the data from rd, reading it in chunks with a chunker returned by
+// newChunker and parsing the chunks concurrently. If fp is set, the chunks already committed are
+// skipped, and the progress is recorded in it.
+func (l *loader) processLoadFile(ctx context.Context, rd *bufio.Reader,
+	newChunker func(batchSize int) chunker.Chunker, fp *fileProgress) error {
+	ck := newChunker(opt.batchSize)
 	nqbuf := ck.NQuads()
 	errCh := make(chan error, 1)
 	// Spin a goroutine to push NQuads to mutation channel.
@@ -489,6 +644,8 @@ func (l *loader) processLoadFile(ctx context.Context, rd *bufio.Reader, ck chunk
 			errCh <- err
 		}()
 		buffer := make([]*api.NQuad, 0, opt.bufferSize*opt.batchSize)
+		// received is the number of N-Quads received from the chunker.
+		var received uint64
 
 		drain := func() {
 			// We collect opt.bufferSize requests and preprocess them. For the requests
@@ -512,12 +669,19 @@ func (l *loader) processLoadFile(ctx context.Context, rd *bufio.Reader, ck chunk
 				}
 				return buffer[i].Predicate < buffer[j].Predicate
 			})
+			var seg *segment
+			if fp != nil && len(buffer) > 0 {
+				seg = fp.newSegment(received, (len(buffer)+opt.batchSize-1)/opt.batchSize)
+			}
 			for len(buffer) > 0 {
 				sz := opt.batchSize
 				if len(buffer) < opt.batchSize {
 					sz = len(buffer)
 				}
 				mu := &request{Mutation: &api.Mutation{Set: buffer[:sz]}}
+				if seg != nil {
+					mu.done = func() { fp.requestDone(seg) }
+				}
 				l.reqs <- mu
 				buffer = buffer[sz:]
 			}
@@ -527,6 +691,7 @@ func (l *loader) processLoadFile(ctx context.Context, rd *bufio.Reader, ck chunk
 			if len(nqs) == 0 {
 				continue
 			}
+			received += uint64(len(nqs))
 
 			for _, nq := range nqs {
 				if !opt.preserveNs {
@@ -540,7 +705,11 @@ func (l *loader) processLoadFile(ctx context.Context, rd *bufio.Reader, ck chunk
 				}
 			}
 
-			if opt.upsertPredicate == "" {
+			if opt.xids {
+				if err = l.resolveXids(nqs); err != nil {
+					return
+				}
+			} else if opt.upsertPredicate == "" {
 				l.allocateUids(nqs)
 			} else {
 				// TODO(Naman): Handle this. Upserts UIDs send a single upsert block for multiple
@@ -570,6 +739,12 @@ func (l *loader) processLoadFile(ctx context.Context, rd *bufio.Reader, ck chunk
 		drain()
 	}()
 
+	parser := chunker.NewParallelParser(newChunker, nqbuf, opt.parsers)
+	var onPushed func()
+	if fp != nil {
+		onPushed = func() { fp.chunkParsed(nqbuf.Pushed()) }
+	}
+	var chunks uint64
 	for {
 		select {
 		case <-ctx.Done():
@@ -580,11 +755,15 @@ func (l *loader) processLoadFile(ctx context.Context, rd *bufio.Reader, ck chunk
 		}
 
 		chunkBuf, err := ck.Chunk(rd)
-		// Parses the rdf entries from the chunk, groups them into batches (each one
-		// containing opt.batchSize entries) and sends the batches to the loader.reqs channel (see
-		// above).
-		if oerr := ck.Parse(chunkBuf); oerr != nil {
-			return errors.Wrap(oerr, "During parsing chunk in processLoadFile")
+		chunks++
+		// The chunks committed by a previous run are read, but not parsed.
+		if fp == nil || chunks > fp.skip {
+			// Parses the rdf entries from the chunk, groups them into batches (each one
+			// containing opt.batchSize entries) and sends the batches to the loader.reqs channel
+			// (see above).
+			if oerr := parser.Parse(chunkBuf, onPushed); oerr != nil {
+				return errors.Wrap(oerr, "During parsing chunk in processLoadFile")
+			}
 		}
 		if err == io.EOF {
 			break
@@ -592,6 +771,9 @@ func (l *loader) processLoadFile(ctx context.Context, rd *bufio.Reader, ck chunk
 			x.Check(err)
 		}
 	}
+	if oerr := parser.Close(); oerr != nil {
+		return errors.Wrap(oerr, "During parsing chunk in processLoadFile")
+	}
 	nqbuf.Flush()
 	return <-errCh
 }
@@ -711,6 +893,7 @@ func run() error {
 		zero:            zero,
 		concurrent:      Live.Conf.GetInt("conc"),
 		batchSize:       Live.Conf.GetInt("batch"),
+		parsers:         Live.Conf.GetInt("parsers"),
 		clientDir:       Live.Conf.GetString("xidmap"),
 		authToken:       Live.Conf.GetString("auth_token"),
 		useCompression:  Live.Conf.GetBool("use_compression"),
@@ -719,8 +902,26 @@ func run() error {
 		httpAddr:        Live.Conf.GetString("http"),
 		bufferSize:      Live.Conf.GetInt("bufferSize"),
 		upsertPredicate: Live.Conf.GetString("upsertPredicate"),
+		xids:            Live.Conf.GetBool("xids"),
 		tmpDir:          Live.Conf.GetString("tmp"),
 		key:             keys.EncKey,
+		checkpointDir:   Live.Conf.GetString("checkpoint"),
+		resume:          Live.Conf.GetBool("resume"),
+	}
+	if opt.xids && (opt.newUids || len(opt.upsertPredicate) > 0) {
+		return errors.Errorf("--xids can't be used with --new_uids or --upsertPredicate")
+	}
+	if opt.resume && opt.checkpointDir == "" {
+		return errors.Errorf("--resume requires --checkpoint")
+	}
+	if opt.checkpointDir != "" && opt.clientDir == "" {
+		opt.clientDir = filepath.Join(opt.checkpointDir, "xidmap")
+	}
+	opt.csvMapping = &chunker.CSVMapping{}
+	if f := Live.Conf.GetString("csv_mapping"); f != "" {
+		if opt.csvMapping, err = chunker.ReadCSVMapping(f); err != nil {
+			return err
+		}
 	}
 
 	forceNs := Live.Conf.GetInt64("force-namespace")
@@ -769,6 +970,9 @@ func run() error {
 			return errors.Errorf("Upsert Predicate feature is not supported for loading" +
 				"into multiple namespaces.")
 		}
+		if opt.xids {
+			return errors.Errorf("--xids is not supported for loading into multiple namespaces.")
+		}
 	}
 
 	bmOpts := batchMutationOptions{
@@ -786,9 +990,37 @@ func run() error {
 	dg, closeFunc := x.GetDgraphClient(Live.Conf, true)
 	defer closeFunc()
 
+	var cp *checkpoint
+	if opt.checkpointDir != "" {
+		if cp, err = openCheckpoint(opt.checkpointDir, opt.resume); err != nil {
+			return err
+		}
+		defer cp.close()
+	}
+
 	l := setup(bmOpts, dg, Live.Conf)
 	defer l.zeroconn.Close()
 
+	// cpDone is set once all the data is recorded as loaded in the checkpoint.
+	var cpDone bool
+	var cpCloser *z.Closer
+	if cp != nil {
+		cp.alloc = l.alloc
+		l.checkpoint = cp
+		cpCloser = z.NewCloser(1)
+		go cp.run(cpCloser)
+		defer func() {
+			if cpDone {
+				return
+			}
+			cpCloser.SignalAndWait()
+			// Record the data committed so far, to resume from it.
+			if err := cp.save(false); err != nil {
+				fmt.Printf("Unable to save the checkpoint: %v\n", err)
+			}
+		}()
+	}
+
 	if err := l.populateNamespaces(ctx, dg, singleNsOp); err != nil {
 		fmt.Printf("Error while populating namespaces %s\n", err)
 		return err
@@ -818,6 +1050,11 @@ func run() error {
 		fmt.Printf("Error while loading schema from alpha %s\n", err)
 		return err
 	}
+	if len(opt.upsertPredicate) > 0 {
+		if err := l.checkUpsertPredicate(ctx, dg); err != nil {
+			return err
+		}
+	}
 
 	if opt.dataFiles == "" {
 		return errors.New("RDF or JSON file(s) location must be specified")
@@ -825,7 +1062,8 @@ func run() error {
 
 	fs := filestore.NewFileStore(opt.dataFiles)
 
-	filesList := fs.FindDataFiles(opt.dataFiles, []string{".rdf", ".rdf.gz", ".json", ".json.gz"})
+	filesList := fs.FindDataFiles(opt.dataFiles, []string{".rdf", ".rdf.gz", ".json", ".json.gz",
+		".csv", ".csv.gz", ".graphml", ".graphml.gz", ".graphson", ".graphson.gz"})
 	totalFiles := len(filesList)
 	if totalFiles == 0 {
 		return errors.Errorf("No data files found in %s", opt.dataFiles)
@@ -873,6 +1111,13 @@ func run() error {
 	fmt.Printf("Time spent                   : %v\n", c.Elapsed)
 	fmt.Printf("N-Quads processed per second : %d\n", rate)
 
+	if cp != nil {
+		cpCloser.SignalAndWait()
+		if err := cp.save(true); err != nil {
+			return err
+		}
+		cpDone = true
+	}
 	if err := l.alloc.Flush(); err != nil {
 		return err
 	}
@@ -0,0 +1,48 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package live
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckUpsertPredicate(t *testing.T) {
+	defer func(prev options) { opt = prev }(opt)
+	opt.namespaceToLoad = 0
+
+	s := &schema{Predicates: []*predicate{
+		{Predicate: "hashed", Type: "string", Tokenizer: []string{"hash"}, Upsert: true},
+		{Predicate: "exact", Type: "string", Tokenizer: []string{"exact"}},
+		{Predicate: "term", Type: "string", Tokenizer: []string{"term"}},
+		{Predicate: "number", Type: "int", Tokenizer: []string{"int"}},
+	}}
+	s.init(0, false)
+	l := &loader{schema: s}
+
+	check := func(pred string) error {
+		opt.upsertPredicate = pred
+		// The existing predicates are only checked, without a client.
+		return l.checkUpsertPredicate(context.Background(), nil)
+	}
+	require.NoError(t, check("hashed"))
+	require.NoError(t, check("exact"))
+	require.Error(t, check("term"))
+	require.Error(t, check("number"))
+}
@@ -0,0 +1,87 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package migrate
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// The phases of the dump, in the order they run.
+const (
+	phaseRows = iota
+	phaseConstraints
+	phaseDone
+)
+
+// checkpoint records how far the dump went, so that an interrupted dump can be resumed without
+// reading the tables again from the start.
+type checkpoint struct {
+	// Tables are the names of the tables being dumped, in the order they are dumped.
+	Tables []string `json:"tables"`
+	// Phase is either phaseRows or phaseConstraints, or phaseDone once the dump is over.
+	Phase int `json:"phase"`
+	// Table is the index in Tables of the table being dumped.
+	Table int `json:"table"`
+	// LastKey holds the SQL literals of the primary key of the last row dumped from the table.
+	LastKey []string `json:"last_key,omitempty"`
+	// Offset is the size of the data file once the rows up to LastKey are written.
+	Offset int64 `json:"offset"`
+}
+
+func readCheckpoint(file string) (*checkpoint, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while reading checkpoint file %s", file)
+	}
+	cp := &checkpoint{}
+	if err := json.Unmarshal(data, cp); err != nil {
+		return nil, errors.Wrapf(err, "while parsing checkpoint file %s", file)
+	}
+	if cp.Phase < phaseRows || cp.Phase > phaseDone || cp.Table < 0 ||
+		(cp.Phase != phaseDone && cp.Table >= len(cp.Tables)) {
+		return nil, errors.Errorf("invalid checkpoint file %s", file)
+	}
+	return cp, nil
+}
+
+// save writes the checkpoint to a temporary file, which then replaces the file, so that the
+// file always holds a complete checkpoint.
+func (cp *checkpoint) save(file string) error {
+	data, err := json.Marshal(cp)
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(file), filepath.Base(file)+".tmp")
+	if err != nil {
+		return errors.Wrapf(err, "while saving checkpoint")
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return errors.Wrapf(err, "while saving checkpoint")
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return errors.Wrapf(err, "while saving checkpoint")
+	}
+	return os.Rename(tmp.Name(), file)
+}
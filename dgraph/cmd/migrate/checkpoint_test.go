@@ -0,0 +1,77 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package migrate
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckpoint(t *testing.T) {
+	dir, err := ioutil.TempDir("", "migrate")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "checkpoint.json")
+	_, err = readCheckpoint(file)
+	require.Error(t, err)
+
+	cp := &checkpoint{Tables: []string{"a", "b"}, Phase: phaseRows, Table: 1,
+		LastKey: []string{"'x'", "3"}, Offset: 42}
+	require.NoError(t, cp.save(file))
+	read, err := readCheckpoint(file)
+	require.NoError(t, err)
+	require.Equal(t, cp, read)
+
+	// The checkpoint replaces the previous one, without leaving temporary files around.
+	cp.Phase, cp.Table, cp.LastKey = phaseDone, 2, nil
+	require.NoError(t, cp.save(file))
+	read, err = readCheckpoint(file)
+	require.NoError(t, err)
+	require.Equal(t, cp, read)
+	files, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	// A checkpoint pointing past the tables to dump is rejected.
+	require.NoError(t, ioutil.WriteFile(file, []byte(`{"tables":["a"],"phase":0,"table":1}`),
+		0600))
+	_, err = readCheckpoint(file)
+	require.Error(t, err)
+}
+
+func TestOpenResumedFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "migrate")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "sql.rdf")
+	require.NoError(t, ioutil.WriteFile(file, []byte("first\nsecond, partly"), 0600))
+	f, err := openResumedFile(file, int64(len("first\n")))
+	require.NoError(t, err)
+	_, err = f.WriteString("second\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	data, err := ioutil.ReadFile(file)
+	require.NoError(t, err)
+	require.Equal(t, "first\nsecond\n", string(data))
+}
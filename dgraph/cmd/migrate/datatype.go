@@ -23,6 +23,7 @@ const (
 	floatType
 	doubleType
 	datetimeType
+	boolType
 	uidType // foreign key reference, which would corrspond to uid type in Dgraph
 )
 
@@ -40,12 +41,17 @@ func initDataTypes() {
 	typeToString[floatType] = "float"
 	typeToString[doubleType] = "double"
 	typeToString[datetimeType] = "datetime"
+	typeToString[boolType] = "bool"
 	typeToString[uidType] = "uid"
 
 	sqlTypeToInternal = make(map[string]dataType)
 	sqlTypeToInternal["int"] = intType
 	sqlTypeToInternal["tinyint"] = intType
+	sqlTypeToInternal["smallint"] = intType
+	sqlTypeToInternal["mediumint"] = intType
+	sqlTypeToInternal["bigint"] = intType
 	sqlTypeToInternal["varchar"] = stringType
+	sqlTypeToInternal["char"] = stringType
 	sqlTypeToInternal["text"] = stringType
 	sqlTypeToInternal["date"] = datetimeType
 	sqlTypeToInternal["time"] = datetimeType
@@ -53,6 +59,15 @@ func initDataTypes() {
 	sqlTypeToInternal["float"] = floatType
 	sqlTypeToInternal["double"] = doubleType
 	sqlTypeToInternal["decimal"] = floatType
+
+	// The data types of PostgreSQL, which are longer than the MySQL ones they start with.
+	sqlTypeToInternal["integer"] = intType
+	sqlTypeToInternal["character"] = stringType
+	sqlTypeToInternal["uuid"] = stringType
+	sqlTypeToInternal["timestamp"] = datetimeType
+	sqlTypeToInternal["numeric"] = floatType
+	sqlTypeToInternal["real"] = floatType
+	sqlTypeToInternal["boolean"] = boolType
 }
 
 func (t dataType) String() string {
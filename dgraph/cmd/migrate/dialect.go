@@ -0,0 +1,186 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package migrate
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// sqlDialect holds what differs between the databases the tool migrates from. The names and
+// values are quoted in the queries, as the PostgreSQL connections don't take query arguments.
+type sqlDialect interface {
+	open(host, port, user, password, db string) (*sql.DB, error)
+	// tablesQuery lists the names of the tables of the database.
+	tablesQuery(db string) string
+	// columnsQuery lists the name and the data type of the columns of a table.
+	columnsQuery(table, db string) string
+	// indicesQuery lists the index name and the column name of the indexed columns of a table.
+	// The index of the primary key is named PRIMARY.
+	indicesQuery(table, db string) string
+	// foreignKeysQuery lists the column name, the constraint name, the referenced table and the
+	// referenced column of the foreign keys of a table.
+	foreignKeysQuery(table, db string) string
+	quoteIdent(name string) string
+	quoteTable(table string) string
+	quoteString(s string) string
+	quoteTime(t time.Time) string
+}
+
+// dialect is the dialect of the database being migrated.
+var dialect sqlDialect = mysqlDialect{}
+
+func getDialect(name, pgSchema, sslmode string) (sqlDialect, error) {
+	switch name {
+	case "mysql":
+		return mysqlDialect{}, nil
+	case "postgres", "postgresql":
+		switch sslmode {
+		case "disable", "require", "verify-full":
+		default:
+			return nil, errors.Errorf("invalid sslmode %q, it should be one of disable, require "+
+				"and verify-full", sslmode)
+		}
+		return postgresDialect{schema: pgSchema, sslmode: sslmode}, nil
+	default:
+		return nil, errors.Errorf("unsupported database %q, it should be either mysql or postgres",
+			name)
+	}
+}
+
+type mysqlDialect struct{}
+
+func (mysqlDialect) open(host, port, user, password, db string) (*sql.DB, error) {
+	return sql.Open("mysql",
+		fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?parseTime=true", user, password, host, port, db))
+}
+
+func (mysqlDialect) tablesQuery(string) string {
+	return "show tables"
+}
+
+func (d mysqlDialect) columnsQuery(table, db string) string {
+	return fmt.Sprintf(`select COLUMN_NAME,DATA_TYPE from INFORMATION_SCHEMA.
+COLUMNS where TABLE_NAME = %s AND TABLE_SCHEMA=%s ORDER BY COLUMN_NAME`,
+		d.quoteString(table), d.quoteString(db))
+}
+
+func (d mysqlDialect) indicesQuery(table, db string) string {
+	return fmt.Sprintf(`select INDEX_NAME,COLUMN_NAME from INFORMATION_SCHEMA.`+
+		`STATISTICS where TABLE_NAME = %s AND index_schema=%s`,
+		d.quoteString(table), d.quoteString(db))
+}
+
+func (d mysqlDialect) foreignKeysQuery(table, db string) string {
+	return fmt.Sprintf(`select COLUMN_NAME,CONSTRAINT_NAME,REFERENCED_TABLE_NAME,
+		REFERENCED_COLUMN_NAME from INFORMATION_SCHEMA.KEY_COLUMN_USAGE where TABLE_NAME = %s
+        AND CONSTRAINT_SCHEMA=%s AND REFERENCED_TABLE_NAME IS NOT NULL`,
+		d.quoteString(table), d.quoteString(db))
+}
+
+func (mysqlDialect) quoteIdent(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+func (d mysqlDialect) quoteTable(table string) string {
+	return d.quoteIdent(table)
+}
+
+func (mysqlDialect) quoteString(s string) string {
+	// Backslashes are escape characters in MySQL strings, unless NO_BACKSLASH_ESCAPES is set.
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+func (d mysqlDialect) quoteTime(t time.Time) string {
+	return d.quoteString(t.Format("2006-01-02 15:04:05.999999"))
+}
+
+// postgresDialect reads the tables of one schema of the database.
+type postgresDialect struct {
+	schema  string
+	sslmode string
+}
+
+func (d postgresDialect) open(host, port, user, password, db string) (*sql.DB, error) {
+	return sql.OpenDB(&pgConnector{
+		host:     host,
+		port:     port,
+		user:     user,
+		password: password,
+		db:       db,
+		sslmode:  d.sslmode,
+	}), nil
+}
+
+func (d postgresDialect) tablesQuery(string) string {
+	return fmt.Sprintf(`SELECT table_name FROM information_schema.tables
+		WHERE table_schema = %s AND table_type = 'BASE TABLE' ORDER BY table_name`,
+		d.quoteString(d.schema))
+}
+
+func (d postgresDialect) columnsQuery(table, _ string) string {
+	return fmt.Sprintf(`SELECT column_name, data_type FROM information_schema.columns
+		WHERE table_name = %s AND table_schema = %s ORDER BY column_name`,
+		d.quoteString(table), d.quoteString(d.schema))
+}
+
+func (d postgresDialect) indicesQuery(table, _ string) string {
+	return fmt.Sprintf(`SELECT CASE WHEN c.contype = 'p' THEN 'PRIMARY' ELSE c.conname END,
+			a.attname
+		FROM pg_constraint c
+		JOIN pg_class cl ON cl.oid = c.conrelid
+		JOIN pg_namespace n ON n.oid = cl.relnamespace
+		CROSS JOIN LATERAL unnest(c.conkey) AS k(attnum)
+		JOIN pg_attribute a ON a.attrelid = c.conrelid AND a.attnum = k.attnum
+		WHERE c.contype IN ('p', 'u') AND cl.relname = %s AND n.nspname = %s`,
+		d.quoteString(table), d.quoteString(d.schema))
+}
+
+func (d postgresDialect) foreignKeysQuery(table, _ string) string {
+	// The columns of multi-column foreign keys are paired by their position in the key.
+	return fmt.Sprintf(`SELECT a.attname, c.conname, cf.relname, af.attname
+		FROM pg_constraint c
+		JOIN pg_class cl ON cl.oid = c.conrelid
+		JOIN pg_namespace n ON n.oid = cl.relnamespace
+		JOIN pg_class cf ON cf.oid = c.confrelid
+		CROSS JOIN LATERAL unnest(c.conkey, c.confkey) AS k(attnum, fattnum)
+		JOIN pg_attribute a ON a.attrelid = c.conrelid AND a.attnum = k.attnum
+		JOIN pg_attribute af ON af.attrelid = c.confrelid AND af.attnum = k.fattnum
+		WHERE c.contype = 'f' AND cl.relname = %s AND n.nspname = %s`,
+		d.quoteString(table), d.quoteString(d.schema))
+}
+
+func (postgresDialect) quoteIdent(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func (d postgresDialect) quoteTable(table string) string {
+	return d.quoteIdent(d.schema) + "." + d.quoteIdent(table)
+}
+
+func (postgresDialect) quoteString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+func (d postgresDialect) quoteTime(t time.Time) string {
+	return d.quoteString(t.Format("2006-01-02 15:04:05.999999Z07:00"))
+}
@@ -0,0 +1,109 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package migrate
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetDialect(t *testing.T) {
+	d, err := getDialect("mysql", "", "")
+	require.NoError(t, err)
+	require.Equal(t, mysqlDialect{}, d)
+
+	d, err = getDialect("postgresql", "public", "require")
+	require.NoError(t, err)
+	require.Equal(t, postgresDialect{schema: "public", sslmode: "require"}, d)
+
+	_, err = getDialect("postgres", "public", "prefer")
+	require.Error(t, err)
+	_, err = getDialect("oracle", "", "")
+	require.Error(t, err)
+}
+
+func TestDialectQuoting(t *testing.T) {
+	my := mysqlDialect{}
+	require.Equal(t, "`we``ird`", my.quoteTable("we`ird"))
+	require.Equal(t, `'it''s a \\ path'`, my.quoteString(`it's a \ path`))
+
+	pg := postgresDialect{schema: "sales"}
+	require.Equal(t, `"sales"."we""ird"`, pg.quoteTable(`we"ird`))
+	require.Equal(t, `'it''s a \ path'`, pg.quoteString(`it's a \ path`))
+
+	ts := time.Date(2021, 3, 4, 5, 6, 7, 500000000, time.UTC)
+	require.Equal(t, "'2021-03-04 05:06:07.5'", my.quoteTime(ts))
+	require.Equal(t, "'2021-03-04 05:06:07.5Z'", pg.quoteTime(ts))
+}
+
+func TestSqlLiteral(t *testing.T) {
+	defer func(d sqlDialect) { dialect = d }(dialect)
+	dialect = postgresDialect{schema: "public"}
+
+	lit, err := sqlLiteral(stringType, []byte("o'neil"))
+	require.NoError(t, err)
+	require.Equal(t, "'o''neil'", lit)
+	lit, err = sqlLiteral(intType, sql.NullInt64{Int64: -7, Valid: true})
+	require.NoError(t, err)
+	require.Equal(t, "-7", lit)
+	lit, err = sqlLiteral(doubleType, sql.NullFloat64{Float64: 0.25, Valid: true})
+	require.NoError(t, err)
+	require.Equal(t, "0.25", lit)
+	lit, err = sqlLiteral(datetimeType, mysql.NullTime{
+		Time: time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC), Valid: true})
+	require.NoError(t, err)
+	require.Equal(t, "'2021-01-02 03:04:05Z'", lit)
+
+	// The rows can't be resumed after a null key.
+	_, err = sqlLiteral(intType, sql.NullInt64{})
+	require.Error(t, err)
+	_, err = sqlLiteral(stringType, []byte(nil))
+	require.Error(t, err)
+}
+
+func TestPgValue(t *testing.T) {
+	require.Equal(t, true, pgValue(pgBoolOID, "t"))
+	require.Equal(t, int64(12), pgValue(pgInt4OID, "12"))
+	require.Equal(t, 1.5, pgValue(pgFloat8OID, "1.5"))
+	require.Equal(t, time.Date(2021, 5, 6, 0, 0, 0, 0, time.UTC), pgValue(pgDateOID, "2021-05-06"))
+
+	tz, ok := pgValue(pgTimestamptzOID, "2021-05-06 07:08:09.5+02").(time.Time)
+	require.True(t, ok)
+	require.True(t, tz.Equal(time.Date(2021, 5, 6, 5, 8, 9, 500000000, time.UTC)))
+
+	// The values of the other types, or which don't parse, are kept as bytes.
+	require.Equal(t, []byte("abc"), pgValue(pgInt4OID, "abc"))
+	require.Equal(t, []byte("{1,2}"), pgValue(1007, "{1,2}"))
+}
+
+func TestPgScramNonce(t *testing.T) {
+	s, err := newPgScram("pencil")
+	require.NoError(t, err)
+	require.Equal(t, "n,,n=,r="+s.clientNonce, s.clientFirst())
+
+	// The server nonce must extend the client nonce.
+	_, err = s.clientFinal("r=other,s=W22ZaJ0SNY7soEsUEjb6gQ==,i=4096")
+	require.Error(t, err)
+	final, err := s.clientFinal("r=" + s.clientNonce + "srv,s=W22ZaJ0SNY7soEsUEjb6gQ==,i=4096")
+	require.NoError(t, err)
+	require.Contains(t, final, "c=biws,r="+s.clientNonce+"srv,p=")
+	require.Error(t, s.verify("v=bm90IHRoZSBzaWduYXR1cmU="))
+}
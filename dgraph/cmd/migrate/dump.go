@@ -20,6 +20,9 @@ import (
 	"bufio"
 	"database/sql"
 	"fmt"
+	"io"
+	"os"
+	"sort"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -38,6 +41,14 @@ type dumpMeta struct {
 	schemaWriter *bufio.Writer
 	sqlPool      *sql.DB
 
+	// dataFile is the file behind dataWriter, whose size is recorded in the checkpoint.
+	dataFile *os.File
+	// batchSize is the number of rows read at once from the tables with a primary key.
+	batchSize int
+	// checkpoint is the progress of the dump, saved to checkpointFile if it's set.
+	checkpoint     *checkpoint
+	checkpointFile string
+
 	buf strings.Builder // reusable buf for building strings, call buf.Reset before use
 }
 
@@ -66,38 +77,192 @@ func (m *dumpMeta) dumpSchema() error {
 
 // dumpTables goes through all the tables twice. In the first time it generates RDF entries for the
 // column values. In the second time, it follows the foreign key constraints in SQL tables, and
-// generate the corresponding Dgraph edges.
+// generate the corresponding Dgraph edges. The tables are dumped in the order of their names, so
+// that the progress recorded in the checkpoint can be resumed.
 func (m *dumpMeta) dumpTables() error {
+	tables := make([]string, 0, len(m.tableInfos))
 	for table := range m.tableInfos {
-		fmt.Printf("Dumping table %s\n", table)
-		if err := m.dumpTable(table); err != nil {
-			return errors.Wrapf(err, "while dumping table %s", table)
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+
+	cp := m.checkpoint
+	if cp == nil {
+		cp = &checkpoint{Tables: tables}
+		m.checkpoint = cp
+	} else {
+		if strings.Join(cp.Tables, ",") != strings.Join(tables, ",") {
+			return errors.Errorf("the tables %v differ from the tables %v of the checkpoint",
+				tables, cp.Tables)
+		}
+		if err := m.recordTables(tables); err != nil {
+			return err
 		}
 	}
 
-	for table := range m.tableInfos {
-		fmt.Printf("Dumping table constraints %s\n", table)
-		if err := m.dumpTableConstraints(table); err != nil {
-			return errors.Wrapf(err, "while dumping table %s", table)
+	for phase := cp.Phase; phase < phaseDone; phase++ {
+		first := 0
+		if phase == cp.Phase {
+			first = cp.Table
+		}
+		for i := first; i < len(tables); i++ {
+			var after []string
+			if phase == cp.Phase && i == cp.Table {
+				after = cp.LastKey
+			}
+			if err := m.saveCheckpoint(phase, i, after); err != nil {
+				return err
+			}
+
+			table := tables[i]
+			var err error
+			if phase == phaseRows {
+				fmt.Printf("Dumping table %s\n", table)
+				err = m.dumpTable(table, phase, i, after)
+			} else {
+				fmt.Printf("Dumping table constraints %s\n", table)
+				err = m.dumpTableConstraints(table, phase, i, after)
+			}
+			if err != nil {
+				return errors.Wrapf(err, "while dumping table %s", table)
+			}
 		}
 	}
+	return m.saveCheckpoint(phaseDone, 0, nil)
+}
 
-	return m.dataWriter.Flush()
+// saveCheckpoint records that the data file holds the rows of the table up to the given key.
+func (m *dumpMeta) saveCheckpoint(phase, table int, lastKey []string) error {
+	if err := m.dataWriter.Flush(); err != nil {
+		return err
+	}
+	if m.checkpointFile == "" {
+		return nil
+	}
+	offset, err := m.dataFile.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+	m.checkpoint.Phase, m.checkpoint.Table = phase, table
+	m.checkpoint.LastKey, m.checkpoint.Offset = lastKey, offset
+	return m.checkpoint.save(m.checkpointFile)
 }
 
-// dumpTable converts the cells in a SQL table into RDF entries,
-// and sends entries to the m.dataWriter
-func (m *dumpMeta) dumpTable(table string) error {
-	tableGuide := m.tableGuides[table]
-	tableInfo := m.tableInfos[table]
+// recordTables records the blank nodes of the rows referenced by foreign keys in the tables
+// dumped before the checkpoint, as the resumed dump needs them to output the edges.
+func (m *dumpMeta) recordTables(tables []string) error {
+	cp := m.checkpoint
+	for i, table := range tables {
+		tableInfo := m.tableInfos[table]
+		if len(tableInfo.cstSources) == 0 || (cp.Phase == phaseRows && i > cp.Table) {
+			continue
+		}
+		generator := getBlankNodeGen(tableInfo)
+		if _, ok := generator.(*usingCounter); ok && !quiet {
+			logger.Printf("The rows of table %s are numbered as it has no primary key, the "+
+				"edges to them are only right if they are read in the same order again.\n",
+				table)
+		}
+		fmt.Printf("Reading the referenced rows of table %s\n", table)
+		recorder := m.tableGuides[table].valuesRecorder
+		err := m.scanRows(tableInfo, nil, func(values []interface{}) error {
+			recorder.record(tableInfo, values, generator.generate(tableInfo, values))
+			return nil
+		}, nil)
+		if err != nil {
+			return errors.Wrapf(err, "while reading table %s", table)
+		}
+	}
+	return nil
+}
+
+// scanRows calls fn with the values of the rows of the table. The rows are read in batches
+// ordered by the primary key, starting after the given key, and saved is called with the key of
+// the last row of each batch. The tables without primary key are read in one go.
+func (m *dumpMeta) scanRows(tableInfo *sqlTable, after []string,
+	fn func(values []interface{}) error, saved func(lastKey []string) error) error {
+	columns := make([]string, 0, len(tableInfo.columnNames))
+	for _, column := range tableInfo.columnNames {
+		columns = append(columns, dialect.quoteIdent(column))
+	}
+	query := fmt.Sprintf(`select %s from %s`, strings.Join(columns, ","),
+		dialect.quoteTable(tableInfo.tableName))
 
-	query := fmt.Sprintf(`select %s from %s`, strings.Join(tableInfo.columnNames, ","), table)
+	pkIndices := getColumnIndices(tableInfo, func(info *sqlTable, column string) bool {
+		return info.columns[column].keyType == primary
+	})
+	if m.batchSize <= 0 || len(pkIndices) == 0 {
+		_, err := m.scanBatch(query, tableInfo, fn)
+		return err
+	}
+
+	pkColumns := make([]string, 0, len(pkIndices))
+	for _, idx := range pkIndices {
+		pkColumns = append(pkColumns, dialect.quoteIdent(idx.name))
+	}
+	pk := strings.Join(pkColumns, ",")
+	for {
+		batchQuery := query
+		if len(after) > 0 {
+			batchQuery += fmt.Sprintf(" where (%s) > (%s)", pk, strings.Join(after, ","))
+		}
+		batchQuery += fmt.Sprintf(" order by %s limit %d", pk, m.batchSize)
+
+		var last []interface{}
+		n, err := m.scanBatch(batchQuery, tableInfo, func(values []interface{}) error {
+			last = values
+			return fn(values)
+		})
+		if err != nil || n == 0 {
+			return err
+		}
+		after = after[:0:0]
+		for _, idx := range pkIndices {
+			lit, err := sqlLiteral(tableInfo.columnDataTypes[idx.index], last[idx.index])
+			if err != nil {
+				return errors.Wrapf(err, "in primary key column %s", idx.name)
+			}
+			after = append(after, lit)
+		}
+		if saved != nil {
+			if err := saved(after); err != nil {
+				return err
+			}
+		}
+		if n < m.batchSize {
+			return nil
+		}
+	}
+}
+
+func (m *dumpMeta) scanBatch(query string, tableInfo *sqlTable,
+	fn func(values []interface{}) error) (int, error) {
 	rows, err := m.sqlPool.Query(query)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	defer rows.Close()
 
+	n := 0
+	for rows.Next() {
+		colValues, err := getColumnValues(tableInfo.columnNames, tableInfo.columnDataTypes, rows)
+		if err != nil {
+			return n, err
+		}
+		if err := fn(colValues); err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, rows.Err()
+}
+
+// dumpTable converts the cells in a SQL table into RDF entries,
+// and sends entries to the m.dataWriter
+func (m *dumpMeta) dumpTable(table string, phase, index int, after []string) error {
+	tableGuide := m.tableGuides[table]
+	tableInfo := m.tableInfos[table]
+
 	// populate the predNames
 	for _, column := range tableInfo.columnNames {
 		tableInfo.predNames = append(tableInfo.predNames,
@@ -108,12 +273,8 @@ func (m *dumpMeta) dumpTable(table string) error {
 		tableInfo: tableInfo,
 	}
 
-	for rows.Next() {
+	return m.scanRows(tableInfo, after, func(colValues []interface{}) error {
 		// step 1: read the row's column values
-		colValues, err := getColumnValues(tableInfo.columnNames, tableInfo.columnDataTypes, rows)
-		if err != nil {
-			return err
-		}
 		row.values = colValues
 
 		// step 2: output the column values in RDF format
@@ -123,43 +284,34 @@ func (m *dumpMeta) dumpTable(table string) error {
 		// step 3: record mappings to the blankNodeLabel so that future tables can look up the
 		// blankNodeLabel
 		tableGuide.valuesRecorder.record(tableInfo, colValues, row.blankNodeLabel)
-	}
-
-	return nil
+		return nil
+	}, func(lastKey []string) error {
+		return m.saveCheckpoint(phase, index, lastKey)
+	})
 }
 
 // dumpTableConstraints reads data from a table, and then generate RDF entries
 // from a row to another row in a foreign table by following columns with foreign key constraints.
 // It then sends the generated RDF entries to the m.dataWriter
-func (m *dumpMeta) dumpTableConstraints(table string) error {
+func (m *dumpMeta) dumpTableConstraints(table string, phase, index int, after []string) error {
 	tableGuide := m.tableGuides[table]
 	tableInfo := m.tableInfos[table]
 
-	query := fmt.Sprintf(`select %s from %s`, strings.Join(tableInfo.columnNames, ","), table)
-	rows, err := m.sqlPool.Query(query)
-	if err != nil {
-		return err
-	}
-	defer rows.Close()
-
 	row := &sqlRow{
 		tableInfo: tableInfo,
 	}
-	for rows.Next() {
+	return m.scanRows(tableInfo, after, func(colValues []interface{}) error {
 		// step 1: read the row's column values
-		colValues, err := getColumnValues(tableInfo.columnNames, tableInfo.columnDataTypes, rows)
-		if err != nil {
-			return err
-		}
 		row.values = colValues
 
 		// step 2: output the constraints in RDF format
 		row.blankNodeLabel = tableGuide.blankNode.generate(tableInfo, colValues)
 
 		m.outputConstraints(row, tableInfo)
-	}
-
-	return nil
+		return nil
+	}, func(lastKey []string) error {
+		return m.saveCheckpoint(phase, index, lastKey)
+	})
 }
 
 // outputRow takes a row with its metadata as well as the table metadata, and
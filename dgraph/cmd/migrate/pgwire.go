@@ -0,0 +1,541 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package migrate
+
+import (
+	"bufio"
+	"context"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// pgConnector connects to PostgreSQL servers. It implements just what the migration needs of
+// the version 3 of the PostgreSQL protocol: the simple query protocol, the trust, password, MD5
+// and SCRAM-SHA-256 authentications, and TLS.
+type pgConnector struct {
+	host, port, user, password, db string
+	// sslmode is either disable, require or verify-full, as for libpq.
+	sslmode string
+}
+
+func (c *pgConnector) Driver() driver.Driver {
+	return pgDriver{}
+}
+
+// pgDriver only exists to satisfy driver.Connector, the connections are opened by pgConnector.
+type pgDriver struct{}
+
+func (pgDriver) Open(string) (driver.Conn, error) {
+	return nil, errors.New("the PostgreSQL connections are opened by their connector")
+}
+
+const (
+	pgProtocolVersion = 196608
+	pgSSLRequestCode  = 80877103
+)
+
+func (c *pgConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	var d net.Dialer
+	nc, err := d.DialContext(ctx, "tcp", net.JoinHostPort(c.host, c.port))
+	if err != nil {
+		return nil, err
+	}
+	if nc, err = c.startTLS(nc); err != nil {
+		return nil, err
+	}
+
+	cn := &pgConn{conn: nc, r: bufio.NewReader(nc)}
+	startup := &pgMessage{}
+	startup.int32(pgProtocolVersion)
+	for _, kv := range [][2]string{{"user", c.user}, {"database", c.db},
+		{"client_encoding", "UTF8"}, {"datestyle", "ISO, MDY"}} {
+		startup.cstring(kv[0])
+		startup.cstring(kv[1])
+	}
+	startup.buf = append(startup.buf, 0)
+	if err := cn.send(0, startup); err != nil {
+		cn.conn.Close()
+		return nil, err
+	}
+	if err := cn.authenticate(c.user, c.password); err != nil {
+		cn.conn.Close()
+		return nil, err
+	}
+	return cn, nil
+}
+
+func (c *pgConnector) startTLS(nc net.Conn) (net.Conn, error) {
+	if c.sslmode == "" || c.sslmode == "disable" {
+		return nc, nil
+	}
+	req := &pgMessage{}
+	req.int32(pgSSLRequestCode)
+	if err := (&pgConn{conn: nc}).send(0, req); err != nil {
+		nc.Close()
+		return nil, err
+	}
+	resp := make([]byte, 1)
+	if _, err := io.ReadFull(nc, resp); err != nil {
+		nc.Close()
+		return nil, err
+	}
+	if resp[0] != 'S' {
+		nc.Close()
+		return nil, errors.New("the PostgreSQL server doesn't support TLS")
+	}
+	conf := &tls.Config{ServerName: c.host}
+	if c.sslmode == "require" {
+		// As for libpq, require only encrypts the connection.
+		conf.InsecureSkipVerify = true
+	}
+	return tls.Client(nc, conf), nil
+}
+
+// pgMessage builds the payload of a message.
+type pgMessage struct {
+	buf []byte
+}
+
+func (m *pgMessage) int32(v int) {
+	m.buf = append(m.buf, 0, 0, 0, 0)
+	binary.BigEndian.PutUint32(m.buf[len(m.buf)-4:], uint32(v))
+}
+
+func (m *pgMessage) cstring(s string) {
+	m.buf = append(m.buf, s...)
+	m.buf = append(m.buf, 0)
+}
+
+type pgConn struct {
+	conn net.Conn
+	r    *bufio.Reader
+	// rows are the rows being read, which must be drained before the next query.
+	rows *pgRows
+}
+
+// send sends a message of the given type. The startup messages have no type.
+func (cn *pgConn) send(typ byte, m *pgMessage) error {
+	out := make([]byte, 0, len(m.buf)+5)
+	if typ != 0 {
+		out = append(out, typ)
+	}
+	out = append(out, 0, 0, 0, 0)
+	binary.BigEndian.PutUint32(out[len(out)-4:], uint32(len(m.buf)+4))
+	out = append(out, m.buf...)
+	_, err := cn.conn.Write(out)
+	return err
+}
+
+// receive returns the type and the payload of the next message.
+func (cn *pgConn) receive() (byte, []byte, error) {
+	var header [5]byte
+	if _, err := io.ReadFull(cn.r, header[:]); err != nil {
+		return 0, nil, err
+	}
+	size := int(binary.BigEndian.Uint32(header[1:])) - 4
+	if size < 0 {
+		return 0, nil, errors.New("invalid PostgreSQL message length")
+	}
+	payload := make([]byte, size)
+	if _, err := io.ReadFull(cn.r, payload); err != nil {
+		return 0, nil, err
+	}
+	return header[0], payload, nil
+}
+
+// pgError returns the error of an ErrorResponse message.
+func pgError(payload []byte) error {
+	fields := make(map[byte]string)
+	for len(payload) > 1 {
+		code := payload[0]
+		end := strings.IndexByte(string(payload[1:]), 0)
+		if end < 0 {
+			break
+		}
+		fields[code] = string(payload[1 : end+1])
+		payload = payload[end+2:]
+	}
+	return errors.Errorf("PostgreSQL %s: %s (SQLSTATE %s)", fields['S'], fields['M'], fields['C'])
+}
+
+func (cn *pgConn) authenticate(user, password string) error {
+	var scram *pgScram
+	for {
+		typ, payload, err := cn.receive()
+		if err != nil {
+			return err
+		}
+		switch typ {
+		case 'E':
+			return pgError(payload)
+		case 'Z':
+			return nil
+		case 'R':
+		default:
+			// ParameterStatus, BackendKeyData and notices.
+			continue
+		}
+		if len(payload) < 4 {
+			return errors.New("invalid PostgreSQL authentication message")
+		}
+		code, data := binary.BigEndian.Uint32(payload), payload[4:]
+		resp := &pgMessage{}
+		switch code {
+		case 0:
+			continue
+		case 3:
+			resp.cstring(password)
+		case 5:
+			if len(data) < 4 {
+				return errors.New("invalid PostgreSQL MD5 salt")
+			}
+			inner := fmt.Sprintf("%x", md5.Sum([]byte(password+user)))
+			resp.cstring(fmt.Sprintf("md5%x", md5.Sum(append([]byte(inner), data[:4]...))))
+		case 10:
+			if !strings.Contains(string(data), "SCRAM-SHA-256\x00") {
+				return errors.New("the PostgreSQL server needs an unsupported SASL mechanism")
+			}
+			if scram, err = newPgScram(password); err != nil {
+				return err
+			}
+			first := scram.clientFirst()
+			resp.cstring("SCRAM-SHA-256")
+			resp.int32(len(first))
+			resp.buf = append(resp.buf, first...)
+		case 11:
+			if scram == nil {
+				return errors.New("unexpected SASL message")
+			}
+			final, err := scram.clientFinal(string(data))
+			if err != nil {
+				return err
+			}
+			resp.buf = append(resp.buf, final...)
+		case 12:
+			if scram == nil {
+				return errors.New("unexpected SASL message")
+			}
+			if err := scram.verify(string(data)); err != nil {
+				return err
+			}
+			continue
+		default:
+			return errors.Errorf("unsupported PostgreSQL authentication method %d", code)
+		}
+		if err := cn.send('p', resp); err != nil {
+			return err
+		}
+	}
+}
+
+// pgScram runs the SCRAM-SHA-256 authentication of RFC 7677, without channel binding.
+type pgScram struct {
+	password    string
+	clientNonce string
+	authMessage string
+	saltedPass  []byte
+}
+
+func newPgScram(password string) (*pgScram, error) {
+	nonce := make([]byte, 18)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return &pgScram{password: password, clientNonce: base64.StdEncoding.EncodeToString(nonce)}, nil
+}
+
+func (s *pgScram) clientFirstBare() string {
+	// The user name is sent in the startup message, so PostgreSQL ignores this one.
+	return "n=,r=" + s.clientNonce
+}
+
+func (s *pgScram) clientFirst() string {
+	return "n,," + s.clientFirstBare()
+}
+
+func scramHMAC(key []byte, msg string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(msg))
+	return h.Sum(nil)
+}
+
+func (s *pgScram) clientFinal(serverFirst string) (string, error) {
+	var nonce, salt string
+	iters := 0
+	for _, attr := range strings.Split(serverFirst, ",") {
+		switch {
+		case strings.HasPrefix(attr, "r="):
+			nonce = attr[2:]
+		case strings.HasPrefix(attr, "s="):
+			salt = attr[2:]
+		case strings.HasPrefix(attr, "i="):
+			iters, _ = strconv.Atoi(attr[2:])
+		}
+	}
+	if !strings.HasPrefix(nonce, s.clientNonce) || iters <= 0 {
+		return "", errors.New("invalid SCRAM message from the PostgreSQL server")
+	}
+	saltBytes, err := base64.StdEncoding.DecodeString(salt)
+	if err != nil {
+		return "", errors.Wrapf(err, "while decoding the SCRAM salt")
+	}
+
+	s.saltedPass = pbkdf2.Key([]byte(s.password), saltBytes, iters, sha256.Size, sha256.New)
+	clientKey := scramHMAC(s.saltedPass, "Client Key")
+	storedKey := sha256.Sum256(clientKey)
+	withoutProof := "c=biws,r=" + nonce
+	s.authMessage = s.clientFirstBare() + "," + serverFirst + "," + withoutProof
+	proof := scramHMAC(storedKey[:], s.authMessage)
+	for i := range proof {
+		proof[i] ^= clientKey[i]
+	}
+	return withoutProof + ",p=" + base64.StdEncoding.EncodeToString(proof), nil
+}
+
+func (s *pgScram) verify(serverFinal string) error {
+	serverKey := scramHMAC(s.saltedPass, "Server Key")
+	sig := base64.StdEncoding.EncodeToString(scramHMAC(serverKey, s.authMessage))
+	if !hmac.Equal([]byte(strings.TrimPrefix(serverFinal, "v=")), []byte(sig)) {
+		return errors.New("invalid SCRAM signature from the PostgreSQL server")
+	}
+	return nil
+}
+
+func (cn *pgConn) Prepare(query string) (driver.Stmt, error) {
+	return &pgStmt{cn: cn, query: query}, nil
+}
+
+func (cn *pgConn) Close() error {
+	if cn.rows != nil {
+		_ = cn.rows.Close()
+	}
+	_ = cn.send('X', &pgMessage{})
+	return cn.conn.Close()
+}
+
+func (cn *pgConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("transactions are not supported")
+}
+
+// Query runs the query through the simple query protocol, which doesn't take arguments.
+func (cn *pgConn) Query(query string, args []driver.Value) (driver.Rows, error) {
+	if len(args) > 0 {
+		return nil, errors.New("query arguments are not supported")
+	}
+	if cn.rows != nil {
+		if err := cn.rows.Close(); err != nil {
+			return nil, err
+		}
+	}
+	m := &pgMessage{}
+	m.cstring(query)
+	if err := cn.send('Q', m); err != nil {
+		return nil, driver.ErrBadConn
+	}
+
+	rows := &pgRows{cn: cn}
+	for {
+		typ, payload, err := cn.receive()
+		if err != nil {
+			return nil, err
+		}
+		switch typ {
+		case 'T':
+			rows.parseDescription(payload)
+			cn.rows = rows
+			return rows, nil
+		case 'E':
+			rows.err = pgError(payload)
+		case 'Z':
+			// The query returned no rows, like the statements other than SELECT.
+			rows.done = true
+			if rows.err != nil {
+				return nil, rows.err
+			}
+			return rows, nil
+		}
+	}
+}
+
+type pgStmt struct {
+	cn    *pgConn
+	query string
+}
+
+func (s *pgStmt) Close() error {
+	return nil
+}
+
+func (s *pgStmt) NumInput() int {
+	return -1
+}
+
+func (s *pgStmt) Exec(args []driver.Value) (driver.Result, error) {
+	rows, err := s.cn.Query(s.query, args)
+	if err != nil {
+		return nil, err
+	}
+	return driver.RowsAffected(0), rows.Close()
+}
+
+func (s *pgStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return s.cn.Query(s.query, args)
+}
+
+// The OIDs of the PostgreSQL types converted to Go types.
+const (
+	pgBoolOID        = 16
+	pgInt8OID        = 20
+	pgInt2OID        = 21
+	pgInt4OID        = 23
+	pgFloat4OID      = 700
+	pgFloat8OID      = 701
+	pgDateOID        = 1082
+	pgTimestampOID   = 1114
+	pgTimestamptzOID = 1184
+)
+
+// pgRows streams the rows of a query from the connection.
+type pgRows struct {
+	cn      *pgConn
+	columns []string
+	oids    []uint32
+	done    bool
+	err     error
+}
+
+func (r *pgRows) parseDescription(payload []byte) {
+	n := int(binary.BigEndian.Uint16(payload))
+	payload = payload[2:]
+	for i := 0; i < n; i++ {
+		end := strings.IndexByte(string(payload), 0)
+		r.columns = append(r.columns, string(payload[:end]))
+		// The name is followed by the table OID, the column number and then the type OID.
+		r.oids = append(r.oids, binary.BigEndian.Uint32(payload[end+7:]))
+		payload = payload[end+19:]
+	}
+}
+
+func (r *pgRows) Columns() []string {
+	return r.columns
+}
+
+func (r *pgRows) Close() error {
+	for !r.done {
+		if err := r.Next(nil); err != nil && err != io.EOF {
+			return err
+		}
+	}
+	if r.cn.rows == r {
+		r.cn.rows = nil
+	}
+	return nil
+}
+
+func (r *pgRows) Next(dest []driver.Value) error {
+	for !r.done {
+		typ, payload, err := r.cn.receive()
+		if err != nil {
+			r.done = true
+			return err
+		}
+		switch typ {
+		case 'D':
+			if dest == nil {
+				continue
+			}
+			return r.parseRow(payload, dest)
+		case 'E':
+			r.err = pgError(payload)
+		case 'Z':
+			r.done = true
+		}
+	}
+	if r.err != nil {
+		return r.err
+	}
+	return io.EOF
+}
+
+func (r *pgRows) parseRow(payload []byte, dest []driver.Value) error {
+	n := int(binary.BigEndian.Uint16(payload))
+	payload = payload[2:]
+	for i := 0; i < n && i < len(dest); i++ {
+		size := int32(binary.BigEndian.Uint32(payload))
+		payload = payload[4:]
+		if size < 0 {
+			dest[i] = nil
+			continue
+		}
+		dest[i] = pgValue(r.oids[i], string(payload[:size]))
+		payload = payload[size:]
+	}
+	return nil
+}
+
+var pgTimeLayouts = []string{
+	"2006-01-02 15:04:05.999999999Z07",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999Z07:00:00",
+}
+
+// pgValue converts the text value of a column to the Go type of the column, or to bytes for
+// the types which aren't converted.
+func pgValue(oid uint32, val string) driver.Value {
+	switch oid {
+	case pgBoolOID:
+		return val == "t"
+	case pgInt2OID, pgInt4OID, pgInt8OID:
+		if i, err := strconv.ParseInt(val, 10, 64); err == nil {
+			return i
+		}
+	case pgFloat4OID, pgFloat8OID:
+		if f, err := strconv.ParseFloat(val, 64); err == nil {
+			return f
+		}
+	case pgDateOID:
+		if t, err := time.Parse("2006-01-02", val); err == nil {
+			return t
+		}
+	case pgTimestampOID:
+		if t, err := time.Parse("2006-01-02 15:04:05.999999999", val); err == nil {
+			return t
+		}
+	case pgTimestamptzOID:
+		for _, layout := range pgTimeLayouts {
+			if t, err := time.Parse(layout, val); err == nil {
+				return t
+			}
+		}
+	}
+	return []byte(val)
+}
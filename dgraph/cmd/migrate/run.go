@@ -39,7 +39,7 @@ var (
 func init() {
 	Migrate.Cmd = &cobra.Command{
 		Use:   "migrate",
-		Short: "Run the Dgraph migration tool from a MySQL database to Dgraph",
+		Short: "Run the Dgraph migration tool from a MySQL or PostgreSQL database to Dgraph",
 		Run: func(cmd *cobra.Command, args []string) {
 			if err := run(Migrate.Conf); err != nil {
 				logger.Fatalf("%v\n", err)
@@ -61,7 +61,20 @@ func init() {
 	flag.StringP("separator", "p", ".", "The separator for constructing predicate names")
 	flag.BoolP("quiet", "q", false, "Enable quiet mode to suppress the warning logs")
 	flag.StringP("host", "", "localhost", "The hostname or IP address of the database server.")
-	flag.StringP("port", "", "3306", "The port of the database server.")
+	flag.StringP("port", "", "", "The port of the database server, 3306 for MySQL and 5432 "+
+		"for PostgreSQL by default.")
+	flag.String("db_type", "mysql", "The type of the database, either mysql or postgres.")
+	flag.String("pg_schema", "public", "The PostgreSQL schema holding the tables to import.")
+	flag.String("sslmode", "disable", "The SSL mode of the PostgreSQL connection, one of "+
+		"disable, require and verify-full.")
+	flag.Bool("infer_fks", false, "Turn the columns named <table>_id, that aren't foreign keys, "+
+		"into edges to the rows of <table> having the same primary key")
+	flag.Int("batch", 10000, "The number of rows read at once from the tables having a primary "+
+		"key, 0 reads each table in one go")
+	flag.String("checkpoint", "", "The file recording the progress of the export, which "+
+		"--resume continues from")
+	flag.Bool("resume", false, "Resume the export recorded in the --checkpoint file, appending "+
+		"to the data file")
 }
 
 func run(conf *viper.Viper) error {
@@ -75,6 +88,21 @@ func run(conf *viper.Viper) error {
 	port := conf.GetString("port")
 	quiet = conf.GetBool("quiet")
 	separator = conf.GetString("separator")
+	checkpointFile := conf.GetString("checkpoint")
+	resume := conf.GetBool("resume")
+
+	var err error
+	dbType := conf.GetString("db_type")
+	if dialect, err = getDialect(dbType, conf.GetString("pg_schema"),
+		conf.GetString("sslmode")); err != nil {
+		return err
+	}
+	if len(port) == 0 {
+		port = "3306"
+		if _, ok := dialect.(postgresDialect); ok {
+			port = "5432"
+		}
+	}
 
 	switch {
 	case len(user) == 0:
@@ -88,13 +116,26 @@ func run(conf *viper.Viper) error {
 			"provide the schema output file.")
 	case len(dataOutput) == 0:
 		logger.Fatalf("Please use the --output_data option to provide the data output file.")
+	case resume && len(checkpointFile) == 0:
+		logger.Fatalf("Please use the --checkpoint option to provide the checkpoint to resume.")
 	}
 
-	if err := checkFile(schemaOutput); err != nil {
-		return err
-	}
-	if err := checkFile(dataOutput); err != nil {
-		return err
+	var cp *checkpoint
+	if resume {
+		if cp, err = readCheckpoint(checkpointFile); err != nil {
+			return err
+		}
+		if cp.Phase == phaseDone {
+			fmt.Printf("The export recorded in %s is already complete\n", checkpointFile)
+			return nil
+		}
+	} else {
+		if err := checkFile(schemaOutput); err != nil {
+			return err
+		}
+		if err := checkFile(dataOutput); err != nil {
+			return err
+		}
 	}
 
 	initDataTypes()
@@ -105,7 +146,7 @@ func run(conf *viper.Viper) error {
 	}
 	defer pool.Close()
 
-	tablesToRead, err := showTables(pool, tables)
+	tablesToRead, err := showTables(pool, tables, db)
 	if err != nil {
 		return err
 	}
@@ -118,14 +159,20 @@ func run(conf *viper.Viper) error {
 		}
 		tableInfos[tableInfo.tableName] = tableInfo
 	}
+	if conf.GetBool("infer_fks") {
+		inferForeignKeys(tableInfos)
+	}
 	populateReferencedByColumns(tableInfos)
 
 	tableGuides := getTableGuides(tableInfos)
 
 	return generateSchemaAndData(&dumpMeta{
-		tableInfos:  tableInfos,
-		tableGuides: tableGuides,
-		sqlPool:     pool,
+		tableInfos:     tableInfos,
+		tableGuides:    tableGuides,
+		sqlPool:        pool,
+		batchSize:      conf.GetInt("batch"),
+		checkpoint:     cp,
+		checkpointFile: checkpointFile,
 	}, schemaOutput, dataOutput)
 }
 
@@ -159,20 +206,28 @@ func checkFile(file string) error {
 
 // generateSchemaAndData opens the two files schemaOutput and dataOutput,
 // then it dumps schema to the writer backed by schemaOutput, and data in RDF format
-// to the writer backed by dataOutput
+// to the writer backed by dataOutput. When resuming from a checkpoint, the data is appended to
+// the data recorded in the checkpoint.
 func generateSchemaAndData(dumpMeta *dumpMeta, schemaOutput string, dataOutput string) error {
 	schemaWriter, schemaCancelFunc, err := getFileWriter(schemaOutput)
 	if err != nil {
 		return err
 	}
 	defer schemaCancelFunc()
-	dataWriter, dataCancelFunc, err := getFileWriter(dataOutput)
+
+	var dataFile *os.File
+	if dumpMeta.checkpoint != nil {
+		dataFile, err = openResumedFile(dataOutput, dumpMeta.checkpoint.Offset)
+	} else {
+		dataFile, err = os.OpenFile(dataOutput, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	}
 	if err != nil {
 		return err
 	}
-	defer dataCancelFunc()
+	defer func() { _ = dataFile.Close() }()
 
-	dumpMeta.dataWriter = dataWriter
+	dumpMeta.dataFile = dataFile
+	dumpMeta.dataWriter = bufio.NewWriter(dataFile)
 	dumpMeta.schemaWriter = schemaWriter
 
 	if err := dumpMeta.dumpSchema(); err != nil {
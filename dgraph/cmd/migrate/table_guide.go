@@ -179,12 +179,17 @@ func getValue(dataType dataType, value interface{}) (string, error) {
 		}
 		dateVal, _ := value.(mysql.NullTime).Value()
 		return fmt.Sprintf("%v", dateVal), nil
-	case floatType:
+	case floatType, doubleType:
 		if !value.(sql.NullFloat64).Valid {
 			return "", errors.Errorf("found invalid nullfloat")
 		}
 		floatVal, _ := value.(sql.NullFloat64).Value()
 		return fmt.Sprintf("%v", floatVal), nil
+	case boolType:
+		if !value.(sql.NullBool).Valid {
+			return "", errors.Errorf("found invalid nullbool")
+		}
+		return fmt.Sprintf("%v", value.(sql.NullBool).Bool), nil
 	default:
 		return fmt.Sprintf("%v", value), nil
 	}
@@ -232,7 +237,8 @@ func createDgraphSchema(info *sqlTable) []string {
 
 	for _, cst := range info.foreignKeyConstraints {
 		pred := getPredFromConstraint(info.tableName, separator, cst)
-		dgraphIndices = append(dgraphIndices, fmt.Sprintf("%s: [%s] .\n",
+		// The reverse edges lead from the referenced rows to the rows referencing them.
+		dgraphIndices = append(dgraphIndices, fmt.Sprintf("%s: [%s] @reverse .\n",
 			pred, uidType))
 	}
 	return dgraphIndices
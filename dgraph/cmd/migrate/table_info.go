@@ -18,7 +18,6 @@ package migrate
 
 import (
 	"database/sql"
-	"fmt"
 	"strings"
 
 	"github.com/dgraph-io/dgraph/x"
@@ -98,8 +97,7 @@ func getColumnInfo(fieldName string, dbType string) *columnInfo {
 }
 
 func parseTables(pool *sql.DB, tableName string, database string) (*sqlTable, error) {
-	query := fmt.Sprintf(`select COLUMN_NAME,DATA_TYPE from INFORMATION_SCHEMA.
-COLUMNS where TABLE_NAME = "%s" AND TABLE_SCHEMA="%s" ORDER BY COLUMN_NAME`, tableName, database)
+	query := dialect.columnsQuery(tableName, database)
 	columns, err := pool.Query(query)
 	if err != nil {
 		return nil, err
@@ -144,8 +142,7 @@ COLUMNS where TABLE_NAME = "%s" AND TABLE_SCHEMA="%s" ORDER BY COLUMN_NAME`, tab
 	}
 
 	// query indices
-	indexQuery := fmt.Sprintf(`select INDEX_NAME,COLUMN_NAME from INFORMATION_SCHEMA.`+
-		`STATISTICS where TABLE_NAME = "%s" AND index_schema="%s"`, tableName, database)
+	indexQuery := dialect.indicesQuery(tableName, database)
 	indices, err := pool.Query(indexQuery)
 	if err != nil {
 		return nil, err
@@ -166,9 +163,7 @@ COLUMNS where TABLE_NAME = "%s" AND TABLE_SCHEMA="%s" ORDER BY COLUMN_NAME`, tab
 
 	}
 
-	foreignKeysQuery := fmt.Sprintf(`select COLUMN_NAME,CONSTRAINT_NAME,REFERENCED_TABLE_NAME,
-		REFERENCED_COLUMN_NAME from INFORMATION_SCHEMA.KEY_COLUMN_USAGE where TABLE_NAME = "%s"
-        AND CONSTRAINT_SCHEMA="%s" AND REFERENCED_TABLE_NAME IS NOT NULL`, tableName, database)
+	foreignKeysQuery := dialect.foreignKeysQuery(tableName, database)
 	fkeys, err := pool.Query(foreignKeysQuery)
 	if err != nil {
 		return nil, err
@@ -252,3 +247,67 @@ func populateReferencedByColumns(tables map[string]*sqlTable) {
 		}
 	}
 }
+
+// inferForeignKeys adds the foreign key constraints which aren't declared, for the columns named
+// after a table with a single column primary key, like person_id or personid for the table
+// person or persons. The columns which are already part of a foreign key are left as they are.
+func inferForeignKeys(tables map[string]*sqlTable) {
+	// The tables by the lowercase names their key columns can refer to them with.
+	targets := make(map[string]*sqlTable)
+	for name, table := range tables {
+		pkIndices := getColumnIndices(table, func(info *sqlTable, column string) bool {
+			return info.columns[column].keyType == primary
+		})
+		if len(pkIndices) != 1 {
+			continue
+		}
+		lower := strings.ToLower(name)
+		targets[lower] = table
+		if singular := strings.TrimSuffix(lower, "s"); singular != lower {
+			if _, ok := targets[singular]; !ok {
+				targets[singular] = table
+			}
+		}
+	}
+
+	for _, table := range tables {
+		for _, column := range table.columnNames {
+			if table.isForeignKey[column] || table.columns[column].keyType == primary {
+				continue
+			}
+			lower := strings.ToLower(column)
+			var target *sqlTable
+			for _, suffix := range []string{"_id", "id"} {
+				if strings.HasSuffix(lower, suffix) {
+					if target = targets[strings.TrimSuffix(lower, suffix)]; target != nil {
+						break
+					}
+				}
+			}
+			if target == nil || target == table {
+				continue
+			}
+			pk := getColumnIndices(target, func(info *sqlTable, column string) bool {
+				return info.columns[column].keyType == primary
+			})[0].name
+			if table.columns[column].dataType != target.columns[pk].dataType {
+				continue
+			}
+
+			if !quiet {
+				logger.Printf("inferring that %s.%s references %s.%s\n", table.tableName, column,
+					target.tableName, pk)
+			}
+			table.dstTables[target.tableName] = struct{}{}
+			table.foreignKeyConstraints["inferred_"+column] = &fkConstraint{
+				parts: []*constraintPart{{
+					tableName:        table.tableName,
+					columnName:       column,
+					remoteTableName:  target.tableName,
+					remoteColumnName: pk,
+				}},
+			}
+			table.isForeignKey[column] = true
+		}
+	}
+}
@@ -19,9 +19,10 @@ package migrate
 import (
 	"bufio"
 	"database/sql"
-	"fmt"
+	"io"
 	"os"
 	"reflect"
+	"strconv"
 	"strings"
 
 	"github.com/dgraph-io/dgraph/x"
@@ -31,8 +32,7 @@ import (
 
 func getPool(host, port, user, password, db string) (*sql.DB,
 	error) {
-	return sql.Open("mysql",
-		fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?parseTime=true", user, password, host, port, db))
+	return dialect.open(host, port, user, password, db)
 }
 
 // showTables will return a slice of table names using one of the following logic
@@ -40,11 +40,11 @@ func getPool(host, port, user, password, db string) (*sql.DB,
 // by splitting the parameter with the separate comma
 // 2) if the parameter is empty, this function will read all the tables under the given
 // database and then return the result
-func showTables(pool *sql.DB, tableNames string) ([]string, error) {
+func showTables(pool *sql.DB, tableNames string, db string) ([]string, error) {
 	if len(tableNames) > 0 {
 		return strings.Split(tableNames, ","), nil
 	}
-	query := "show tables"
+	query := dialect.tablesQuery(db)
 	rows, err := pool.Query(query)
 	if err != nil {
 		return nil, err
@@ -94,6 +94,24 @@ func getFileWriter(filename string) (*bufio.Writer, func(), error) {
 	return bufio.NewWriter(output), func() { _ = output.Close() }, nil
 }
 
+// openResumedFile opens an existing file for writing after its first size bytes, dropping the
+// rest of the file.
+func openResumedFile(filename string, size int64) (*os.File, error) {
+	output, err := os.OpenFile(filename, os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, err
+	}
+	if err := output.Truncate(size); err != nil {
+		_ = output.Close()
+		return nil, err
+	}
+	if _, err := output.Seek(size, io.SeekStart); err != nil {
+		_ = output.Close()
+		return nil, err
+	}
+	return output, nil
+}
+
 func getColumnValues(columns []string, dataTypes []dataType,
 	rows *sql.Rows) ([]interface{}, error) {
 	// ptrToValues takes a slice of pointers, deference them, and return the values referenced
@@ -115,8 +133,10 @@ func getColumnValues(columns []string, dataTypes []dataType,
 			valuePtrs = append(valuePtrs, new([]byte)) // the value can be nil
 		case intType:
 			valuePtrs = append(valuePtrs, new(sql.NullInt64))
-		case floatType:
+		case floatType, doubleType:
 			valuePtrs = append(valuePtrs, new(sql.NullFloat64))
+		case boolType:
+			valuePtrs = append(valuePtrs, new(sql.NullBool))
 		case datetimeType:
 			valuePtrs = append(valuePtrs, new(mysql.NullTime))
 		default:
@@ -130,3 +150,37 @@ func getColumnValues(columns []string, dataTypes []dataType,
 	colValues := ptrToValues(valuePtrs)
 	return colValues, nil
 }
+
+// sqlLiteral returns the SQL literal of a value read by getColumnValues, which is used to resume
+// reading a table after a row.
+func sqlLiteral(dataType dataType, value interface{}) (string, error) {
+	switch dataType {
+	case stringType:
+		if value.([]byte) == nil {
+			return "", errors.Errorf("found null string")
+		}
+		return dialect.quoteString(string(value.([]byte))), nil
+	case intType:
+		if !value.(sql.NullInt64).Valid {
+			return "", errors.Errorf("found invalid nullint")
+		}
+		return strconv.FormatInt(value.(sql.NullInt64).Int64, 10), nil
+	case floatType, doubleType:
+		if !value.(sql.NullFloat64).Valid {
+			return "", errors.Errorf("found invalid nullfloat")
+		}
+		return strconv.FormatFloat(value.(sql.NullFloat64).Float64, 'g', -1, 64), nil
+	case boolType:
+		if !value.(sql.NullBool).Valid {
+			return "", errors.Errorf("found invalid nullbool")
+		}
+		return strconv.FormatBool(value.(sql.NullBool).Bool), nil
+	case datetimeType:
+		if !value.(mysql.NullTime).Valid {
+			return "", errors.Errorf("found invalid nulltime")
+		}
+		return dialect.quoteTime(value.(mysql.NullTime).Time), nil
+	default:
+		return "", errors.Errorf("unsupported type %s", dataType)
+	}
+}
@@ -18,6 +18,7 @@ package zero
 
 import (
 	"context"
+	"math"
 	"math/rand"
 	"time"
 
@@ -42,10 +43,12 @@ func (s *Server) updateLeases() {
 	s.nextUint[pb.Num_UID] = s.state.MaxUID + 1
 	s.nextUint[pb.Num_TXN_TS] = s.state.MaxTxnTs + 1
 	s.nextUint[pb.Num_NS_ID] = s.state.MaxNsID + 1
+	s.nextUint[pb.Num_SEQ] = s.state.MaxSeq + 1
 
 	startTs = s.nextUint[pb.Num_TXN_TS]
-	glog.Infof("Updated UID: %d. Txn Ts: %d. NsID: %d.",
-		s.nextUint[pb.Num_UID], s.nextUint[pb.Num_TXN_TS], s.nextUint[pb.Num_NS_ID])
+	glog.Infof("Updated UID: %d. Txn Ts: %d. NsID: %d. Seq: %d.",
+		s.nextUint[pb.Num_UID], s.nextUint[pb.Num_TXN_TS], s.nextUint[pb.Num_NS_ID],
+		s.nextUint[pb.Num_SEQ])
 	s.Unlock()
 	s.orc.updateStartTxnTs(startTs)
 }
@@ -65,6 +68,8 @@ func (s *Server) maxLease(typ pb.NumLeaseType) uint64 {
 		maxlease = s.state.MaxTxnTs
 	case pb.Num_NS_ID:
 		maxlease = s.state.MaxNsID
+	case pb.Num_SEQ:
+		maxlease = s.state.MaxSeq
 	}
 	return maxlease
 }
@@ -94,7 +99,12 @@ func (s *Server) lease(ctx context.Context, num *pb.Num) (*pb.AssignedIds, error
 
 	s.leaseLock.Lock()
 	defer s.leaseLock.Unlock()
+	return s.leaseLocked(ctx, num)
+}
 
+// leaseLocked is lease, for callers already holding the leaseLock.
+func (s *Server) leaseLocked(ctx context.Context, num *pb.Num) (*pb.AssignedIds, error) {
+	typ := num.GetType()
 	if typ == pb.Num_TXN_TS {
 		if num.Val == 0 && num.ReadOnly {
 			// If we're only asking for a readonly timestamp, we can potentially
@@ -134,6 +144,16 @@ func (s *Server) lease(ctx context.Context, num *pb.Num) (*pb.AssignedIds, error
 			return &emptyAssignedIds, errors.Errorf("Cannot lease %s as the limit has reached."+
 				" currMax:%d", typ, s.nextUint[typ]-1)
 		}
+		if typ == pb.Num_SEQ && maxLease+howMany > math.MaxInt64 {
+			// The values of the sequence predicates are int64, so the lease stops at the
+			// largest one.
+			howMany = math.MaxInt64 - maxLease
+			if available+howMany < num.Val {
+				return &emptyAssignedIds, errors.Errorf("Cannot lease %d sequence values as the"+
+					" limit %d has been reached. currMax:%d", num.Val, int64(math.MaxInt64),
+					s.nextUint[typ]-1)
+			}
+		}
 
 		var proposal pb.ZeroProposal
 		switch typ {
@@ -143,10 +163,15 @@ func (s *Server) lease(ctx context.Context, num *pb.Num) (*pb.AssignedIds, error
 			proposal.MaxUID = maxLease + howMany
 		case pb.Num_NS_ID:
 			proposal.MaxNsID = maxLease + howMany
+		case pb.Num_SEQ:
+			proposal.MaxSeq = maxLease + howMany
 		}
-		// Blocking propose to get more ids or timestamps.
-		if err := s.Node.proposeAndWait(ctx, &proposal); err != nil {
-			return nil, err
+		// Blocking propose to get more ids or timestamps. The lease of the sequence values may
+		// have reached its limit, but still cover the request.
+		if howMany > 0 {
+			if err := s.Node.proposeAndWait(ctx, &proposal); err != nil {
+				return nil, err
+			}
 		}
 	}
 
@@ -172,6 +197,10 @@ func (s *Server) lease(ctx context.Context, num *pb.Num) (*pb.AssignedIds, error
 		out.EndId = out.StartId + num.Val - 1
 		s.nextUint[pb.Num_NS_ID] = out.EndId + 1
 
+	} else if typ == pb.Num_SEQ {
+		out.StartId = s.nextUint[pb.Num_SEQ]
+		out.EndId = out.StartId + num.Val - 1
+		s.nextUint[pb.Num_SEQ] = out.EndId + 1
 	} else {
 		return out, errors.Errorf("Unknown lease type: %v\n", typ)
 	}
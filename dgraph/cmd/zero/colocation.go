@@ -0,0 +1,134 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zero
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/worker"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/pkg/errors"
+)
+
+// colocations reads the co-location hints, given via the @shard directive, from group 1 which
+// serves all the reserved predicates. It returns a map from the predicate to the predicate it
+// must be co-located with.
+func (s *Server) colocations(ctx context.Context) (map[string]string, error) {
+	attr := x.GalaxyAttr(worker.ColocationPred)
+	if s.ServingTablet(attr) == nil {
+		// No hints have been stored yet.
+		return nil, nil
+	}
+	pl := s.Leader(1)
+	if pl == nil {
+		return nil, errors.Errorf("No healthy connection found to leader of group 1")
+	}
+	ids, err := s.Timestamps(ctx, &pb.Num{ReadOnly: true})
+	if err != nil {
+		return nil, errors.Wrapf(err, "while getting read timestamp")
+	}
+	wc := pb.NewWorkerClient(pl.Get())
+
+	res, err := wc.ServeTask(ctx, &pb.Query{
+		Attr:    attr,
+		ReadTs:  ids.ReadOnly,
+		SrcFunc: &pb.SrcFunction{Name: "has"},
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "while looking up co-location hints")
+	}
+	if len(res.GetUidMatrix()) == 0 || len(res.UidMatrix[0].GetUids()) == 0 {
+		return nil, nil
+	}
+	uid := res.UidMatrix[0].Uids[0]
+
+	res, err = wc.ServeTask(ctx, &pb.Query{
+		Attr:    attr,
+		ReadTs:  ids.ReadOnly,
+		UidList: &pb.List{Uids: []uint64{uid}},
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "while reading co-location hints")
+	}
+	if len(res.GetValueMatrix()) == 0 || len(res.ValueMatrix[0].GetValues()) == 0 {
+		return nil, nil
+	}
+	return worker.ParseColocations(res.ValueMatrix[0].Values[0].Val)
+}
+
+// colocationRoot returns the predicate at the end of the chain of co-location hints starting at
+// pred. All the predicates with the same root must be served by the same group.
+func colocationRoot(hints map[string]string, pred string) string {
+	seen := map[string]bool{pred: true}
+	for {
+		next, ok := hints[pred]
+		if !ok || seen[next] {
+			return pred
+		}
+		seen[next] = true
+		pred = next
+	}
+}
+
+// chooseColocationRepair finds a predicate which isn't served by the same group as the predicate
+// it must be co-located with. That happens when the hint is given after both the predicates were
+// assigned to groups.
+func (s *Server) chooseColocationRepair(
+	hints map[string]string) (predicate string, srcGroup uint32, dstGroup uint32) {
+	s.RLock()
+	defer s.RUnlock()
+	if s.state == nil || !s.Node.AmLeader() {
+		return
+	}
+
+	// Go through the hints in order, so that the same repair is chosen every time.
+	preds := make([]string, 0, len(hints))
+	for pred := range hints {
+		preds = append(preds, pred)
+	}
+	sort.Strings(preds)
+	for _, pred := range preds {
+		tab := s.servingTablet(pred)
+		other := s.servingTablet(hints[pred])
+		if tab == nil || other == nil || tab.GroupId == other.GroupId ||
+			x.IsReservedPredicate(pred) || !s.hasLeader(other.GroupId) {
+			continue
+		}
+		return pred, tab.GroupId, other.GroupId
+	}
+	return
+}
+
+// repairColocations moves a predicate to the group of the predicate it must be co-located with,
+// if needed. It returns true if a move was attempted.
+func (s *Server) repairColocations(hints map[string]string) (bool, error) {
+	predicate, srcGroup, dstGroup := s.chooseColocationRepair(hints)
+	if len(predicate) == 0 {
+		return false, nil
+	}
+	return true, s.movePredicate(predicate, srcGroup, dstGroup)
+}
+
+// fetchColocations reads the co-location hints with a timeout.
+func (s *Server) fetchColocations() (map[string]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	return s.colocations(ctx)
+}
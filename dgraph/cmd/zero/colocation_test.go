@@ -0,0 +1,39 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zero
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestColocationRoot(t *testing.T) {
+	hints := map[string]string{
+		"name": "friend",
+		"age":  "name",
+		"a":    "b",
+		"b":    "a",
+	}
+	require.Equal(t, "friend", colocationRoot(hints, "age"))
+	require.Equal(t, "friend", colocationRoot(hints, "name"))
+	require.Equal(t, "friend", colocationRoot(hints, "friend"))
+	require.Equal(t, "other", colocationRoot(hints, "other"))
+	// A cycle in the hints must not loop forever.
+	require.Equal(t, "b", colocationRoot(hints, "a"))
+	require.Equal(t, "a", colocationRoot(hints, "b"))
+}
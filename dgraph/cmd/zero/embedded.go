@@ -0,0 +1,106 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zero
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/dgraph-io/dgraph/conn"
+	"github.com/dgraph-io/dgraph/raftwal"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/dgraph-io/ristretto/z"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+)
+
+// Embedded is a Zero running in the process of an Alpha, see StartEmbedded.
+type Embedded struct {
+	// Addr is the address the Alpha uses to reach the Zero.
+	Addr  string
+	st    *state
+	store *raftwal.DiskStorage
+}
+
+// StartEmbedded starts a single Zero in this process, configured by Zero.Conf. The Zero is served
+// in memory to the Alpha of the process, through conn.ListenLocal. If lite is false, it also
+// listens on its gRPC port, so that the loaders and other Alphas can reach it. It never serves
+// the HTTP endpoints of Zero, the Alpha serves its own.
+//
+// It must be called before the Alpha sets x.WorkerConfig, which the Zero only reads while
+// starting.
+func StartEmbedded(lite bool) (*Embedded, error) {
+	parseOptions(z.NewSuperFlag(Zero.Conf.GetString("telemetry")).MergeAndCheckDefault(
+		x.TelemetryDefaults))
+	if opts.numReplicas != 1 {
+		return nil, errors.Errorf("An embedded Zero only supports a single replica, got %d",
+			opts.numReplicas)
+	}
+	if opts.peer != "" {
+		return nil, errors.Errorf("An embedded Zero can't join other Zeros")
+	}
+
+	addr := fmt.Sprintf("localhost:%d", x.PortZeroGrpc+opts.portOffset)
+	x.WorkerConfig.MyAddr = addr
+	var extra []net.Listener
+	if !lite {
+		laddr := "localhost"
+		if opts.bindall {
+			laddr = "0.0.0.0"
+		}
+		l, err := setupListener(laddr, x.PortZeroGrpc+opts.portOffset, "grpc")
+		if err != nil {
+			return nil, err
+		}
+		extra = append(extra, l)
+	}
+	local := conn.ListenLocal(addr)
+
+	nodeId := opts.raft.GetUint64("idx")
+	if nodeId == 0 {
+		return nil, errors.Errorf("raft.idx flag cannot be 0")
+	}
+	e := &Embedded{Addr: addr, store: openWAL(nodeId)}
+	e.st = &state{}
+	e.st.serveGRPC(local, e.store, extra...)
+	// The closer of the Zero counts the HTTP server, which isn't started.
+	e.st.zero.closer.Done()
+	if err := e.st.node.initAndStartNode(); err != nil {
+		return nil, err
+	}
+
+	e.st.zero.closer.AddRunning(1)
+	go func() {
+		defer e.st.zero.closer.Done()
+		<-e.st.zero.closer.HasBeenClosed()
+		// Stop Raft, then the gRPC server.
+		e.st.node.closer.SignalAndWait()
+		_ = local.Close()
+	}()
+	glog.Infof("Running embedded Dgraph Zero at %s (lite: %v)", addr, lite)
+	return e, nil
+}
+
+// Stop stops the Zero, and closes its write-ahead log.
+func (e *Embedded) Stop() {
+	e.st.zero.closer.SignalAndWait()
+	if err := e.store.Close(); err != nil {
+		glog.Errorf("While closing the Raft WAL of the embedded Zero: %v", err)
+	}
+	e.st.zero.orc.close()
+	glog.Infoln("Embedded Zero stopped.")
+}
@@ -18,7 +18,9 @@ package zero
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"strconv"
 	"strings"
@@ -85,9 +87,12 @@ func (st *state) assign(w http.ResponseWriter, r *http.Request) {
 	case "nsids":
 		num.Type = pb.Num_NS_ID
 		ids, err = st.zero.AssignIds(ctx, num)
+	case "sequence":
+		num.Type = pb.Num_SEQ
+		ids, err = st.zero.AssignIds(ctx, num)
 	default:
-		x.SetStatus(w, x.Error,
-			fmt.Sprintf("Invalid what: [%s]. Must be one of: [uids, timestamps, nsids]", what))
+		x.SetStatus(w, x.Error, fmt.Sprintf("Invalid what: [%s]. Must be one of: "+
+			"[uids, timestamps, nsids, sequence]", what))
 		return
 	}
 	if err != nil {
@@ -102,6 +107,60 @@ func (st *state) assign(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// uids manages the UID lease. /uids returns its usage, /uids/reserve?name=&num=[&start=]
+// reserves num UIDs under the name, /uids/release?name= releases them, and /uids/shrink gives
+// back the UIDs leased but not handed out.
+func (st *state) uids(w http.ResponseWriter, r *http.Request) {
+	x.AddCorsHeaders(w)
+	w.Header().Set("Content-Type", "application/json")
+	if r.Method == "OPTIONS" {
+		return
+	}
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusBadRequest)
+		x.SetStatus(w, x.ErrorInvalidMethod, "Invalid method")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	var res interface{}
+	var err error
+	name := r.URL.Query().Get("name")
+	switch r.URL.Path {
+	case "/uids":
+		res, err = st.zero.uidLease()
+	case "/uids/reserve":
+		num, ok := intFromQueryParam(w, r, "num")
+		if !ok {
+			return
+		}
+		var start uint64
+		if len(r.URL.Query().Get("start")) > 0 {
+			if start, ok = intFromQueryParam(w, r, "start"); !ok {
+				return
+			}
+		}
+		res, err = st.zero.reserveUids(ctx, name, num, start)
+	case "/uids/release":
+		err = st.zero.releaseUids(ctx, name)
+		res = map[string]string{"code": "Success", "message": "UIDs released."}
+	case "/uids/shrink":
+		var freed uint64
+		freed, err = st.zero.shrinkUidLease(ctx)
+		res = map[string]uint64{"freed": freed}
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
+		return
+	}
+	if err := json.NewEncoder(w).Encode(res); err != nil {
+		glog.Errorf("Unable to send http response. Err: %v\n", err)
+	}
+}
+
 // removeNode can be used to remove a node from the cluster. It takes in the RAFT id of the node
 // and the group it belongs to. It can be used to remove Dgraph alpha and Zero nodes(group=0).
 func (st *state) removeNode(w http.ResponseWriter, r *http.Request) {
@@ -206,6 +265,76 @@ func (st *state) moveTablet(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// moveProgress returns the progress of the ongoing predicate move as JSON, or null if there's none.
+func (st *state) moveProgress(w http.ResponseWriter, r *http.Request) {
+	x.AddCorsHeaders(w)
+	w.Header().Set("Content-Type", "application/json")
+	if r.Method == "OPTIONS" {
+		return
+	}
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusBadRequest)
+		x.SetStatus(w, x.ErrorInvalidMethod, "Invalid method")
+		return
+	}
+	if !st.node.AmLeader() {
+		w.WriteHeader(http.StatusBadRequest)
+		x.SetStatus(w, x.ErrorInvalidRequest,
+			"This Zero server is not the leader. Re-run command on leader.")
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(st.zero.moves.current()); err != nil {
+		glog.Warningf("Error while writing response: %+v", err)
+	}
+}
+
+// controlMove pauses, resumes or cancels the ongoing predicate move, depending on the path.
+func (st *state) controlMove(w http.ResponseWriter, r *http.Request) {
+	x.AddCorsHeaders(w)
+	if r.Method == "OPTIONS" {
+		return
+	}
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusBadRequest)
+		x.SetStatus(w, x.ErrorInvalidMethod, "Invalid method")
+		return
+	}
+	if !st.node.AmLeader() {
+		w.WriteHeader(http.StatusBadRequest)
+		x.SetStatus(w, x.ErrorInvalidRequest,
+			"This Zero server is not the leader. Re-run command on leader.")
+		return
+	}
+
+	var err error
+	var msg string
+	switch r.URL.Path {
+	case "/moveTablet/pause":
+		err = st.zero.moves.pause()
+		msg = "Predicate move paused"
+	case "/moveTablet/resume":
+		err = st.zero.moves.resume()
+		msg = "Predicate move resumed"
+	case "/moveTablet/cancel":
+		err = st.zero.moves.cancelMove()
+		msg = "Predicate move cancelled"
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		x.SetStatus(w, x.ErrorInvalidRequest, "Unknown command")
+		return
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
+		return
+	}
+	glog.Info(msg)
+	if _, err := fmt.Fprint(w, msg); err != nil {
+		glog.Warningf("Error while writing response: %+v", err)
+	}
+}
+
 func (st *state) getState(w http.ResponseWriter, r *http.Request) {
 	x.AddCorsHeaders(w)
 	w.Header().Set("Content-Type", "application/json")
@@ -230,6 +359,93 @@ func (st *state) getState(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// exportState returns the state of Zero as a document which can be imported by importState,
+// or as the marshaled MembershipState proto if the format query param is proto.
+func (st *state) exportState(w http.ResponseWriter, r *http.Request) {
+	x.AddCorsHeaders(w)
+	if r.Method == "OPTIONS" {
+		return
+	}
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusBadRequest)
+		x.SetStatus(w, x.ErrorInvalidMethod, "Invalid method")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := st.node.WaitLinearizableRead(ctx); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		x.SetStatus(w, x.Error, err.Error())
+		return
+	}
+
+	var data []byte
+	var err error
+	mstate := st.zero.membershipState()
+	if r.URL.Query().Get("format") == "proto" {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		data, err = mstate.Marshal()
+	} else {
+		w.Header().Set("Content-Type", "application/json")
+		data, err = marshalState(mstate)
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		x.SetStatus(w, x.Error, err.Error())
+		return
+	}
+	if _, err := w.Write(data); err != nil {
+		glog.Errorf("Unable to send http response. Err: %v\n", err)
+	}
+}
+
+// importState merges the state posted as a document written by exportState, or as a marshaled
+// MembershipState proto if the Content-Type is application/octet-stream, into the state of Zero.
+func (st *state) importState(w http.ResponseWriter, r *http.Request) {
+	x.AddCorsHeaders(w)
+	if r.Method == "OPTIONS" {
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusBadRequest)
+		x.SetStatus(w, x.ErrorInvalidMethod, "Invalid method")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	b, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
+		return
+	}
+	var mstate *pb.MembershipState
+	if r.Header.Get("Content-Type") == "application/octet-stream" {
+		mstate = &pb.MembershipState{}
+		err = mstate.Unmarshal(b)
+	} else {
+		mstate, err = unmarshalState(b)
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	res, err := st.zero.importState(ctx, mstate)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		x.SetStatus(w, x.Error, err.Error())
+		return
+	}
+	if err := json.NewEncoder(w).Encode(res); err != nil {
+		glog.Errorf("Unable to send http response. Err: %v\n", err)
+	}
+}
+
 func (st *state) pingResponse(w http.ResponseWriter, r *http.Request) {
 	x.AddCorsHeaders(w)
 
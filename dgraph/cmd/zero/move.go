@@ -0,0 +1,312 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zero
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	moveDefaults = "rate=0; window=;"
+
+	movePhaseOne = "phase I"
+	movePhaseTwo = "phase II"
+)
+
+var (
+	errNoMoveOngoing = errors.New("No predicate move is going on")
+	errMoveCancelled = errors.New("Predicate move was cancelled")
+)
+
+// maintenanceWindow is the time of the day during which Zero is allowed to start rebalancing
+// tablets. The window wraps around midnight if end is before start, e.g. 22:00-06:00.
+type maintenanceWindow struct {
+	start, end time.Duration // Since midnight.
+}
+
+// parseMaintenanceWindow parses a window of the form "HH:MM-HH:MM". An empty string means that
+// there is no window, and it returns nil.
+func parseMaintenanceWindow(s string) (*maintenanceWindow, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+	parts := strings.Split(s, "-")
+	if len(parts) != 2 {
+		return nil, errors.Errorf("invalid maintenance window %q, expected HH:MM-HH:MM", s)
+	}
+	var w maintenanceWindow
+	for i, d := range []*time.Duration{&w.start, &w.end} {
+		t, err := time.Parse("15:04", strings.TrimSpace(parts[i]))
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid maintenance window %q", s)
+		}
+		*d = time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	}
+	if w.start == w.end {
+		return nil, errors.Errorf("maintenance window %q is empty", s)
+	}
+	return &w, nil
+}
+
+// contains returns true if t falls within the window. A nil window contains all times.
+func (w *maintenanceWindow) contains(t time.Time) bool {
+	if w == nil {
+		return true
+	}
+	tod := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute +
+		time.Duration(t.Second())*time.Second
+	if w.start < w.end {
+		return tod >= w.start && tod < w.end
+	}
+	return tod >= w.start || tod < w.end
+}
+
+// MoveProgress is the progress of the ongoing predicate move, as returned by the
+// /moveTablet/progress endpoint.
+type MoveProgress struct {
+	Predicate string    `json:"predicate"`
+	SrcGroup  uint32    `json:"srcGroup"`
+	DstGroup  uint32    `json:"dstGroup"`
+	Phase     string    `json:"phase"`
+	Paused    bool      `json:"paused"`
+	StartedAt time.Time `json:"startedAt"`
+	// RateLimit is the bandwidth limit of the move in bytes per second, 0 if unlimited.
+	RateLimit int64 `json:"rateLimit"`
+	// TotalBytes is the uncompressed size of the tablet, which is an estimate of the bytes that
+	// need to be moved.
+	TotalBytes int64 `json:"totalBytes"`
+	// BytesMoved is the number of bytes the source group has confirmed sending.
+	BytesMoved int64 `json:"bytesMoved"`
+	// EtaSeconds is the estimated time left for the move, or -1 if it isn't known yet.
+	EtaSeconds int64 `json:"etaSeconds"`
+}
+
+// moveTracker keeps track of the ongoing predicate move, and lets it be paused, resumed and
+// cancelled. There's at most one move going on at any time.
+type moveTracker struct {
+	sync.Mutex
+	progress *MoveProgress
+	// stepStart is the start time of the ongoing call to the source group, zero if none.
+	stepStart time.Time
+	// movingTime is the time spent in the calls to the source group that completed.
+	movingTime time.Duration
+
+	cancelled bool
+	// stepInterrupted is set when pausing the move interrupts the call to the source group.
+	stepInterrupted bool
+	cancel          context.CancelFunc // Cancels the whole move.
+	stepCancel      context.CancelFunc // Cancels the ongoing call to the source group.
+	resumeCh        chan struct{}      // Closed when a paused move is resumed or cancelled.
+}
+
+// start registers a new move and returns a context which is cancelled if the move is cancelled.
+func (t *moveTracker) start(ctx context.Context, p *MoveProgress) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(ctx)
+	t.Lock()
+	defer t.Unlock()
+	p.Phase = movePhaseOne
+	p.StartedAt = time.Now()
+	t.progress = p
+	t.stepStart = time.Time{}
+	t.movingTime = 0
+	t.cancelled = false
+	t.stepInterrupted = false
+	t.cancel = cancel
+	t.stepCancel = nil
+	t.resumeCh = nil
+	return ctx, func() {
+		cancel()
+		t.Lock()
+		defer t.Unlock()
+		t.progress = nil
+		t.cancel = nil
+		t.stepCancel = nil
+	}
+}
+
+// waitIfPaused blocks while the move is paused. It returns errMoveCancelled if the move gets
+// cancelled.
+func (t *moveTracker) waitIfPaused(ctx context.Context) error {
+	t.Lock()
+	ch, cancelled := t.resumeCh, t.cancelled
+	t.Unlock()
+	if cancelled {
+		return errMoveCancelled
+	}
+	if ch == nil {
+		return nil
+	}
+	select {
+	case <-ch:
+	case <-ctx.Done():
+	}
+	t.Lock()
+	defer t.Unlock()
+	if t.cancelled {
+		return errMoveCancelled
+	}
+	return ctx.Err()
+}
+
+// startStep returns the context for a call to the source group. The call is interrupted if the
+// move gets paused or cancelled.
+func (t *moveTracker) startStep(ctx context.Context) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(ctx)
+	t.Lock()
+	defer t.Unlock()
+	t.stepCancel = cancel
+	t.stepStart = time.Now()
+	return ctx, func() {
+		cancel()
+		t.Lock()
+		defer t.Unlock()
+		t.stepCancel = nil
+		t.stepStart = time.Time{}
+	}
+}
+
+// stepDone records the bytes sent by a call to the source group that completed successfully.
+func (t *moveTracker) stepDone(sent int64) {
+	t.Lock()
+	defer t.Unlock()
+	t.progress.BytesMoved += sent
+	t.movingTime += time.Since(t.stepStart)
+}
+
+// interrupted returns true if the last call to the source group failed because the move was
+// paused, in which case the call should be redone once the move is resumed.
+func (t *moveTracker) interrupted() bool {
+	t.Lock()
+	defer t.Unlock()
+	interrupted := t.stepInterrupted && !t.cancelled
+	t.stepInterrupted = false
+	return interrupted
+}
+
+func (t *moveTracker) isCancelled() bool {
+	t.Lock()
+	defer t.Unlock()
+	return t.cancelled
+}
+
+// enterPhaseTwo waits for a paused move to be resumed, and then marks the move as being in
+// phase II, from which point it can no longer be paused or cancelled.
+func (t *moveTracker) enterPhaseTwo(ctx context.Context) error {
+	for {
+		if err := t.waitIfPaused(ctx); err != nil {
+			return err
+		}
+		t.Lock()
+		// The move could have been paused again since we checked.
+		if t.resumeCh == nil && !t.cancelled {
+			t.progress.Phase = movePhaseTwo
+			t.Unlock()
+			return nil
+		}
+		t.Unlock()
+	}
+}
+
+func (t *moveTracker) pause() error {
+	t.Lock()
+	defer t.Unlock()
+	switch {
+	case t.progress == nil:
+		return errNoMoveOngoing
+	case t.progress.Phase != movePhaseOne:
+		return errors.Errorf("Predicate move is in %s and can't be paused", t.progress.Phase)
+	case t.resumeCh != nil:
+		return errors.New("Predicate move is already paused")
+	}
+	t.resumeCh = make(chan struct{})
+	t.progress.Paused = true
+	if t.stepCancel != nil {
+		t.stepCancel()
+		t.stepInterrupted = true
+	}
+	return nil
+}
+
+func (t *moveTracker) resume() error {
+	t.Lock()
+	defer t.Unlock()
+	switch {
+	case t.progress == nil:
+		return errNoMoveOngoing
+	case t.resumeCh == nil:
+		return errors.New("Predicate move is not paused")
+	}
+	close(t.resumeCh)
+	t.resumeCh = nil
+	t.progress.Paused = false
+	return nil
+}
+
+func (t *moveTracker) cancelMove() error {
+	t.Lock()
+	defer t.Unlock()
+	switch {
+	case t.progress == nil:
+		return errNoMoveOngoing
+	case t.progress.Phase != movePhaseOne:
+		return errors.Errorf("Predicate move is in %s and can't be cancelled", t.progress.Phase)
+	}
+	t.cancelled = true
+	if t.resumeCh != nil {
+		close(t.resumeCh)
+		t.resumeCh = nil
+		t.progress.Paused = false
+	}
+	t.cancel()
+	return nil
+}
+
+// current returns the progress of the ongoing move, or nil if there's none.
+func (t *moveTracker) current() *MoveProgress {
+	t.Lock()
+	defer t.Unlock()
+	if t.progress == nil {
+		return nil
+	}
+	p := *t.progress
+	p.EtaSeconds = -1
+
+	// Prefer the throughput observed so far, and fall back to the rate limit.
+	var rate float64
+	if p.BytesMoved > 0 && t.movingTime > 0 {
+		rate = float64(p.BytesMoved) / t.movingTime.Seconds()
+	} else if p.RateLimit > 0 {
+		rate = float64(p.RateLimit)
+	}
+	if rate > 0 {
+		remaining := float64(p.TotalBytes - p.BytesMoved)
+		if !t.stepStart.IsZero() {
+			remaining -= rate * time.Since(t.stepStart).Seconds()
+		}
+		if remaining < 0 {
+			remaining = 0
+		}
+		p.EtaSeconds = int64(remaining / rate)
+	}
+	return &p
+}
@@ -0,0 +1,119 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zero
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaintenanceWindow(t *testing.T) {
+	at := func(hour, min int) time.Time {
+		return time.Date(2021, 6, 1, hour, min, 0, 0, time.Local)
+	}
+
+	w, err := parseMaintenanceWindow("")
+	require.NoError(t, err)
+	require.Nil(t, w)
+	require.True(t, w.contains(at(12, 0)))
+
+	w, err = parseMaintenanceWindow("01:30-05:00")
+	require.NoError(t, err)
+	require.False(t, w.contains(at(1, 29)))
+	require.True(t, w.contains(at(1, 30)))
+	require.True(t, w.contains(at(4, 59)))
+	require.False(t, w.contains(at(5, 0)))
+
+	// The window wraps around midnight.
+	w, err = parseMaintenanceWindow(" 22:00 - 06:00 ")
+	require.NoError(t, err)
+	require.True(t, w.contains(at(23, 0)))
+	require.True(t, w.contains(at(0, 0)))
+	require.True(t, w.contains(at(5, 59)))
+	require.False(t, w.contains(at(6, 0)))
+	require.False(t, w.contains(at(12, 0)))
+
+	for _, s := range []string{"22:00", "25:00-06:00", "10:00-10:00", "a-b"} {
+		_, err := parseMaintenanceWindow(s)
+		require.Error(t, err, s)
+	}
+}
+
+func TestMoveTracker(t *testing.T) {
+	tr := &moveTracker{}
+	require.Nil(t, tr.current())
+	require.Equal(t, errNoMoveOngoing, tr.pause())
+	require.Equal(t, errNoMoveOngoing, tr.cancelMove())
+
+	ctx, done := tr.start(context.Background(), &MoveProgress{
+		Predicate:  "name",
+		TotalBytes: 1000,
+		RateLimit:  100,
+	})
+	p := tr.current()
+	require.Equal(t, movePhaseOne, p.Phase)
+	require.Equal(t, int64(10), p.EtaSeconds)
+
+	// Pausing interrupts the ongoing step.
+	stepCtx, stepDone := tr.startStep(ctx)
+	require.NoError(t, tr.pause())
+	require.Error(t, tr.pause())
+	require.Error(t, stepCtx.Err())
+	stepDone()
+	require.True(t, tr.interrupted())
+	require.False(t, tr.interrupted())
+	require.True(t, tr.current().Paused)
+
+	resumed := make(chan error, 1)
+	go func() {
+		resumed <- tr.waitIfPaused(ctx)
+	}()
+	select {
+	case <-resumed:
+		t.Fatal("waitIfPaused returned while the move is paused")
+	case <-time.After(50 * time.Millisecond):
+	}
+	require.NoError(t, tr.resume())
+	require.NoError(t, <-resumed)
+	require.Error(t, tr.resume())
+
+	_, stepDone = tr.startStep(ctx)
+	tr.stepDone(400)
+	stepDone()
+	require.Equal(t, int64(400), tr.current().BytesMoved)
+
+	// The move can't be paused or cancelled once in phase II.
+	require.NoError(t, tr.enterPhaseTwo(ctx))
+	require.Error(t, tr.pause())
+	require.Error(t, tr.cancelMove())
+	done()
+	require.Nil(t, tr.current())
+
+	// Cancelling a paused move unblocks it.
+	ctx, done = tr.start(context.Background(), &MoveProgress{Predicate: "name"})
+	defer done()
+	require.Equal(t, int64(-1), tr.current().EtaSeconds)
+	require.NoError(t, tr.pause())
+	require.NoError(t, tr.cancelMove())
+	require.Equal(t, errMoveCancelled, tr.waitIfPaused(ctx))
+	require.False(t, tr.interrupted())
+	require.True(t, tr.isCancelled())
+	require.Error(t, ctx.Err())
+}
@@ -84,6 +84,46 @@ func (n *node) AmLeader() bool {
 	return time.Since(n.lastQuorum) <= 5*time.Second
 }
 
+// transferLeadership hands the leadership over to the most up-to-date Zero peer, if this node
+// is the leader, and waits for the transfer for at most the given timeout. The new leader renews
+// the leases once elected, so that the Alphas don't have to wait for an election timeout before
+// they can get timestamps again.
+func (n *node) transferLeadership(timeout time.Duration) {
+	if !n.amLeader() {
+		return
+	}
+	st := n.Raft().Status()
+	var target, match uint64
+	for id, pr := range st.Progress {
+		if id == n.Id || pr.IsLearner {
+			continue
+		}
+		if target == 0 || pr.Match > match {
+			target, match = id, pr.Match
+		}
+	}
+	if target == 0 {
+		return
+	}
+
+	glog.Infof("Transferring the leadership of Zero to %#x", target)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	n.Raft().TransferLeadership(ctx, n.Id, target)
+
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for n.amLeader() {
+		select {
+		case <-ctx.Done():
+			glog.Warningf("Zero is still the leader after %s", timeout)
+			return
+		case <-ticker.C:
+		}
+	}
+	glog.Infof("Zero isn't the leader anymore")
+}
+
 // {2 bytes Node ID} {4 bytes for random} {2 bytes zero}
 func (n *node) initProposalKey(id uint64) error {
 	x.AssertTrue(id != 0)
@@ -238,12 +278,12 @@ func (n *node) handleMemberProposal(member *pb.Member) error {
 	}
 	var numReplicas int
 	for _, gm := range group.Members {
-		if !gm.Learner {
+		if !gm.Learner && !gm.Witness {
 			numReplicas++
 		}
 	}
 	switch {
-	case has || member.GetLearner():
+	case has || member.GetLearner() || member.GetWitness():
 		// pass
 	case numReplicas >= n.server.NumReplicas:
 		// We shouldn't allow more members than the number of replicas.
@@ -463,6 +503,20 @@ func (n *node) applyProposal(e raftpb.Entry) (uint64, error) {
 		}
 	}
 
+	for name, ids := range p.ReservedUids {
+		if ids.GetEndId() == 0 {
+			delete(state.ReservedUids, name)
+			continue
+		}
+		if state.ReservedUids == nil {
+			state.ReservedUids = make(map[string]*pb.AssignedIds)
+		}
+		state.ReservedUids[name] = ids
+	}
+	if p.ShrinkMaxUID > 0 && p.ShrinkMaxUID < state.MaxUID {
+		state.MaxUID = p.ShrinkMaxUID
+	}
+
 	switch {
 	case p.MaxUID > state.MaxUID:
 		state.MaxUID = p.MaxUID
@@ -470,12 +524,15 @@ func (n *node) applyProposal(e raftpb.Entry) (uint64, error) {
 		state.MaxTxnTs = p.MaxTxnTs
 	case p.MaxNsID > state.MaxNsID:
 		state.MaxNsID = p.MaxNsID
-	case p.MaxUID != 0 || p.MaxTxnTs != 0 || p.MaxNsID != 0:
+	case p.MaxSeq > state.MaxSeq:
+		state.MaxSeq = p.MaxSeq
+	case p.MaxUID != 0 || p.MaxTxnTs != 0 || p.MaxNsID != 0 || p.MaxSeq != 0:
 		// Could happen after restart when some entries were there in WAL and did not get
 		// snapshotted.
 		glog.Infof("Could not apply proposal, ignoring: p.MaxUID=%v, p.MaxTxnTs=%v"+
-			"p.MaxNsID=%v, maxUID=%d maxTxnTs=%d maxNsID=%d\n",
-			p.MaxUID, p.MaxTxnTs, p.MaxNsID, state.MaxUID, state.MaxTxnTs, state.MaxNsID)
+			"p.MaxNsID=%v p.MaxSeq=%v, maxUID=%d maxTxnTs=%d maxNsID=%d maxSeq=%d\n",
+			p.MaxUID, p.MaxTxnTs, p.MaxNsID, p.MaxSeq, state.MaxUID, state.MaxTxnTs,
+			state.MaxNsID, state.MaxSeq)
 	}
 	if p.Txn != nil {
 		n.server.orc.updateCommitStatus(e.Index, p.Txn)
@@ -0,0 +1,172 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zero
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/dgraph-io/dgraph/conn"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/golang/glog"
+)
+
+const replicaDefaults = "remove-dead-after=0s; provision-learners=false;"
+
+/*
+QUORUM REPAIR
+
+An Alpha which stays unreachable from the Zero leader for longer than remove-dead-after is
+removed from its group, just like /removeNode would do. That frees up its place in the group for a
+replacement Alpha. A dead Alpha is only removed if the rest of the group still has a quorum, as the
+removal itself has to go through the Raft of the group. The leader of a group is never removed;
+once the group elects a new leader, the old one can be removed.
+
+With provision-learners set, an Alpha joining a group which already serves tablets joins it as a
+learner. It retrieves the snapshot and catches up with the group without counting towards the
+quorum, and then promotes itself to a voter. This needs an enterprise license, like any learner;
+without one, Alphas join as voters right away.
+
+A witness is an Alpha which votes in the elections of its group, but holds no data. It lets two
+replicas and a witness keep a quorum when one of the replicas is down. A witness joins the group it
+asks for, which must already exist, and doesn't count towards the replicas of the group.
+*/
+
+// numReplicas returns the number of members of the group holding its data, the witnesses aside.
+func numReplicas(group *pb.Group) int {
+	var n int
+	for _, m := range group.GetMembers() {
+		if !m.Witness {
+			n++
+		}
+	}
+	return n
+}
+
+// joinAsLearner returns true if a new Alpha assigned to the given group should join it as a
+// learner, to be promoted to a voter once it has caught up.
+func (s *Server) joinAsLearner(group *pb.Group) bool {
+	return opts.provisionLearners && s.state.GetLicense().GetEnabled() &&
+		len(group.GetMembers()) > 0 && len(group.GetTablets()) > 0
+}
+
+// isReachable returns true if Zero has a healthy connection to the given address.
+func isReachable(addr string) bool {
+	pl, err := conn.GetPools().Get(addr)
+	return err == nil && pl.IsHealthy()
+}
+
+// chooseDeadReplica picks a member of the group which has been unreachable since before the given
+// deadline, and can be removed without the group losing its quorum. It returns zero if there's
+// none.
+func chooseDeadReplica(group *pb.Group, unreachableSince map[uint64]time.Time,
+	deadline time.Time) uint64 {
+	if len(group.GetMembers()) < 2 {
+		return 0
+	}
+	var voters, reachableVoters int
+	for id, m := range group.Members {
+		if m.Learner {
+			continue
+		}
+		voters++
+		if _, ok := unreachableSince[id]; !ok {
+			reachableVoters++
+		}
+	}
+
+	ids := make([]uint64, 0, len(group.Members))
+	for id := range group.Members {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	for _, id := range ids {
+		m := group.Members[id]
+		since, ok := unreachableSince[id]
+		if !ok || since.After(deadline) || m.Leader {
+			continue
+		}
+		// Removing a learner doesn't affect the quorum. Removing a voter needs a quorum of the
+		// current voters to be reachable.
+		if m.Learner || reachableVoters > voters/2 {
+			return id
+		}
+	}
+	return 0
+}
+
+// removeDeadReplicas periodically checks the health of the Alphas, and removes the ones which
+// have been unreachable for longer than remove-dead-after.
+func (s *Server) removeDeadReplicas() {
+	if opts.removeDeadAfter <= 0 {
+		return
+	}
+	ticker := time.NewTicker(10 * time.Second)
+	defer ticker.Stop()
+
+	unreachableSince := make(map[uint64]time.Time)
+	for {
+		select {
+		case <-s.closer.HasBeenClosed():
+			return
+		case <-ticker.C:
+		}
+		if !s.Node.AmLeader() {
+			// Another Zero might have been tracking the Alphas. Start afresh if we become the
+			// leader again.
+			unreachableSince = make(map[uint64]time.Time)
+			continue
+		}
+
+		now := time.Now()
+		state := s.membershipState()
+		seen := make(map[uint64]bool)
+		for _, group := range state.GetGroups() {
+			for id, m := range group.GetMembers() {
+				seen[id] = true
+				if isReachable(m.Addr) {
+					delete(unreachableSince, id)
+				} else if _, ok := unreachableSince[id]; !ok {
+					glog.Warningf("Alpha %#x at %s of group %d is unreachable.",
+						id, m.Addr, m.GroupId)
+					unreachableSince[id] = now
+				}
+			}
+		}
+		for id := range unreachableSince {
+			if !seen[id] {
+				delete(unreachableSince, id)
+			}
+		}
+
+		for gid, group := range state.GetGroups() {
+			id := chooseDeadReplica(group, unreachableSince, now.Add(-opts.removeDeadAfter))
+			if id == 0 {
+				continue
+			}
+			glog.Infof("Removing Alpha %#x from group %d after being unreachable since %s.",
+				id, gid, unreachableSince[id].Format(time.RFC3339))
+			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			_, err := s.RemoveNode(ctx, &pb.RemoveNodeRequest{NodeId: id, GroupId: gid})
+			cancel()
+			if err != nil {
+				glog.Errorf("While removing dead Alpha %#x from group %d: %v", id, gid, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,67 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zero
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChooseDeadReplica(t *testing.T) {
+	now := time.Now()
+	deadline := now.Add(-time.Minute)
+	long, short := now.Add(-time.Hour), now.Add(-time.Second)
+
+	group := &pb.Group{Members: map[uint64]*pb.Member{
+		1: {Id: 1, Leader: true},
+		2: {Id: 2},
+		3: {Id: 3},
+		4: {Id: 4, Learner: true},
+	}}
+	require.Zero(t, chooseDeadReplica(group, nil, deadline))
+
+	// Not unreachable for long enough.
+	require.Zero(t, chooseDeadReplica(group, map[uint64]time.Time{3: short}, deadline))
+	require.Equal(t, uint64(3), chooseDeadReplica(group, map[uint64]time.Time{3: long}, deadline))
+
+	// The leader is never removed.
+	require.Zero(t, chooseDeadReplica(group, map[uint64]time.Time{1: long}, deadline))
+
+	// Two of the three voters are unreachable, so the group has no quorum to remove either. The
+	// learner can still be removed.
+	require.Zero(t, chooseDeadReplica(group, map[uint64]time.Time{2: long, 3: long}, deadline))
+	require.Equal(t, uint64(4),
+		chooseDeadReplica(group, map[uint64]time.Time{2: long, 3: long, 4: long}, deadline))
+
+	// The last member is never removed.
+	single := &pb.Group{Members: map[uint64]*pb.Member{1: {Id: 1}}}
+	require.Zero(t, chooseDeadReplica(single, map[uint64]time.Time{1: long}, deadline))
+}
+
+func TestNumReplicas(t *testing.T) {
+	group := &pb.Group{Members: map[uint64]*pb.Member{
+		1: {Id: 1, Leader: true},
+		2: {Id: 2},
+		3: {Id: 3, Witness: true},
+	}}
+	// The witness doesn't count towards the replicas of the group.
+	require.Equal(t, 2, numReplicas(group))
+	require.Zero(t, numReplicas(&pb.Group{}))
+}
@@ -45,6 +45,7 @@ import (
 	"github.com/dgraph-io/dgraph/raftwal"
 	"github.com/dgraph-io/dgraph/x"
 	"github.com/dgraph-io/ristretto/z"
+	humanize "github.com/dustin/go-humanize"
 	"github.com/golang/glog"
 	"github.com/spf13/cobra"
 )
@@ -59,6 +60,10 @@ type options struct {
 	peer              string
 	w                 string
 	rebalanceInterval time.Duration
+	moveRate          int64
+	moveWindow        *maintenanceWindow
+	removeDeadAfter   time.Duration
+	provisionLearners bool
 	tlsClientConfig   *tls.Config
 	audit             *x.LoggerConf
 	limiterConfig     *x.LimiterConf
@@ -113,6 +118,28 @@ instances to achieve high-availability.
 			"Turn on/off the administrative endpoints exposed over Zero's HTTP port.").
 		String())
 
+	flag.String("move", moveDefaults, z.NewSuperFlagHelp(moveDefaults).
+		Head("Predicate move options").
+		Flag("rate",
+			`The maximum rate at which a predicate is sent during a move, per second, e.g. "50MiB".
+			Set it to 0 to remove limiting.`).
+		Flag("window",
+			`The time of the day, in local time, during which Zero can start moving predicates to
+			rebalance the groups, e.g. "22:00-06:00". Moves started via /moveTablet aren't
+			restricted. Leave it empty to rebalance at any time.`).
+		String())
+
+	flag.String("replica", replicaDefaults, z.NewSuperFlagHelp(replicaDefaults).
+		Head("Replica repair options").
+		Flag("remove-dead-after",
+			`The duration after which an Alpha that is unreachable from Zero is removed from its
+			group, so that another Alpha can take its place. Set it to 0 to only remove Alphas
+			via /removeNode.`).
+		Flag("provision-learners",
+			`Make Alphas joining a group that already serves data join as learners, and promote
+			them to voters once they have caught up. Needs an enterprise license.`).
+		String())
+
 	flag.String("raft", raftDefaults, z.NewSuperFlagHelp(raftDefaults).
 		Head("Raft options").
 		Flag("idx",
@@ -151,7 +178,9 @@ type state struct {
 	zero *Server
 }
 
-func (st *state) serveGRPC(l net.Listener, store *raftwal.DiskStorage) {
+// serveGRPC serves the Zero and Raft services on l, and on the extra listeners if any. Closing l
+// stops the server.
+func (st *state) serveGRPC(l net.Listener, store *raftwal.DiskStorage, extra ...net.Listener) {
 	x.RegisterExporters(Zero.Conf, "dgraph.zero")
 	grpcOpts := []grpc.ServerOption{
 		grpc.MaxRecvMsgSize(x.GrpcMaxSize),
@@ -190,6 +219,12 @@ func (st *state) serveGRPC(l net.Listener, store *raftwal.DiskStorage) {
 	pb.RegisterZeroServer(s, st.zero)
 	pb.RegisterRaftServer(s, st.rs)
 
+	for _, el := range extra {
+		go func(el net.Listener) {
+			err := s.Serve(el)
+			glog.Infof("gRPC server stopped serving %s: %v", el.Addr(), err)
+		}(el)
+	}
 	go func() {
 		defer st.zero.closer.Done()
 		err := s.Serve(l)
@@ -225,68 +260,7 @@ func run() {
 	}
 
 	x.PrintVersion()
-	tlsConf, err := x.LoadClientTLSConfigForInternalPort(Zero.Conf)
-	x.Check(err)
-
-	raft := z.NewSuperFlag(Zero.Conf.GetString("raft")).MergeAndCheckDefault(
-		raftDefaults)
-	auditConf := audit.GetAuditConf(Zero.Conf.GetString("audit"))
-	limit := z.NewSuperFlag(Zero.Conf.GetString("limit")).MergeAndCheckDefault(
-		worker.ZeroLimitsDefaults)
-	limitConf := &x.LimiterConf{
-		UidLeaseLimit: limit.GetUint64("uid-lease"),
-		RefillAfter:   limit.GetDuration("refill-interval"),
-	}
-	opts = options{
-		telemetry:         telemetry,
-		raft:              raft,
-		limit:             limit,
-		bindall:           Zero.Conf.GetBool("bindall"),
-		portOffset:        Zero.Conf.GetInt("port_offset"),
-		numReplicas:       Zero.Conf.GetInt("replicas"),
-		peer:              Zero.Conf.GetString("peer"),
-		w:                 Zero.Conf.GetString("wal"),
-		rebalanceInterval: Zero.Conf.GetDuration("rebalance_interval"),
-		tlsClientConfig:   tlsConf,
-		audit:             auditConf,
-		limiterConfig:     limitConf,
-	}
-	glog.Infof("Setting Config to: %+v", opts)
-	x.WorkerConfig.Parse(Zero.Conf)
-
-	if !enc.EeBuild && Zero.Conf.GetString("enterprise_license") != "" {
-		log.Fatalf("ERROR: enterprise_license option cannot be applied to OSS builds. ")
-	}
-
-	if opts.numReplicas < 0 || opts.numReplicas%2 == 0 {
-		log.Fatalf("ERROR: Number of replicas must be odd for consensus. Found: %d",
-			opts.numReplicas)
-	}
-
-	if Zero.Conf.GetBool("expose_trace") {
-		// TODO: Remove this once we get rid of event logs.
-		trace.AuthRequest = func(req *http.Request) (any, sensitive bool) {
-			return true, true
-		}
-	}
-
-	if opts.audit != nil {
-		wd, err := filepath.Abs(opts.w)
-		x.Check(err)
-		ad, err := filepath.Abs(opts.audit.Output)
-		x.Check(err)
-		x.AssertTruef(ad != wd,
-			"WAL directory and Audit output cannot be the same ('%s').", opts.audit.Output)
-	}
-
-	if opts.rebalanceInterval <= 0 {
-		log.Fatalf("ERROR: Rebalance interval must be greater than zero. Found: %d",
-			opts.rebalanceInterval)
-	}
-
-	grpc.EnableTracing = false
-	otrace.ApplyConfig(otrace.Config{
-		DefaultSampler: otrace.ProbabilitySampler(Zero.Conf.GetFloat64("trace"))})
+	parseOptions(telemetry)
 
 	addr := "localhost"
 	if opts.bindall {
@@ -305,11 +279,7 @@ func run() {
 	httpListener, err := setupListener(addr, x.PortZeroHTTP+opts.portOffset, "http")
 	x.Check(err)
 
-	// Create and initialize write-ahead log.
-	x.Checkf(os.MkdirAll(opts.w, 0700), "Error while creating WAL dir.")
-	store := raftwal.Init(opts.w)
-	store.SetUint(raftwal.RaftId, nodeId)
-	store.SetUint(raftwal.GroupId, 0) // All zeros have group zero.
+	store := openWAL(nodeId)
 
 	// Initialize the servers.
 	var st state
@@ -324,11 +294,21 @@ func run() {
 
 	baseMux.HandleFunc("/health", st.pingResponse)
 	// the following endpoints are disabled only if the flag is explicitly set to true
-	if !limit.GetBool("disable-admin-http") {
+	if !opts.limit.GetBool("disable-admin-http") {
 		baseMux.HandleFunc("/state", st.getState)
+		baseMux.HandleFunc("/state/export", st.exportState)
+		baseMux.HandleFunc("/state/import", st.importState)
 		baseMux.HandleFunc("/removeNode", st.removeNode)
 		baseMux.HandleFunc("/moveTablet", st.moveTablet)
+		baseMux.HandleFunc("/moveTablet/progress", st.moveProgress)
+		baseMux.HandleFunc("/moveTablet/pause", st.controlMove)
+		baseMux.HandleFunc("/moveTablet/resume", st.controlMove)
+		baseMux.HandleFunc("/moveTablet/cancel", st.controlMove)
 		baseMux.HandleFunc("/assign", st.assign)
+		baseMux.HandleFunc("/uids", st.uids)
+		baseMux.HandleFunc("/uids/reserve", st.uids)
+		baseMux.HandleFunc("/uids/release", st.uids)
+		baseMux.HandleFunc("/uids/shrink", st.uids)
 		baseMux.HandleFunc("/enterpriseLicense", st.applyEnterpriseLicense)
 	}
 	baseMux.HandleFunc("/debug/jemalloc", x.JemallocHandler)
@@ -373,6 +353,8 @@ func run() {
 
 		// Stop all HTTP requests.
 		_ = httpListener.Close()
+		// Let another Zero take over the leases before Raft is stopped.
+		st.node.transferLeadership(10 * time.Second)
 		// Stop Raft.
 		st.node.closer.SignalAndWait()
 		// Stop all internal requests.
@@ -397,3 +379,93 @@ func run() {
 	st.zero.orc.close()
 	glog.Infoln("All done. Goodbye!")
 }
+
+// parseOptions sets opts from the flags of Zero.
+func parseOptions(telemetry *z.SuperFlag) {
+	tlsConf, err := x.LoadClientTLSConfigForInternalPort(Zero.Conf)
+	x.Check(err)
+
+	raft := z.NewSuperFlag(Zero.Conf.GetString("raft")).MergeAndCheckDefault(
+		raftDefaults)
+	auditConf := audit.GetAuditConf(Zero.Conf.GetString("audit"))
+	limit := z.NewSuperFlag(Zero.Conf.GetString("limit")).MergeAndCheckDefault(
+		worker.ZeroLimitsDefaults)
+	move := z.NewSuperFlag(Zero.Conf.GetString("move")).MergeAndCheckDefault(moveDefaults)
+	moveRate, err := humanize.ParseBytes(move.GetString("rate"))
+	if err != nil {
+		log.Fatalf("ERROR: Invalid move rate %q: %v", move.GetString("rate"), err)
+	}
+	moveWindow, err := parseMaintenanceWindow(move.GetString("window"))
+	if err != nil {
+		log.Fatalf("ERROR: %v", err)
+	}
+	replica := z.NewSuperFlag(Zero.Conf.GetString("replica")).MergeAndCheckDefault(
+		replicaDefaults)
+	limitConf := &x.LimiterConf{
+		UidLeaseLimit: limit.GetUint64("uid-lease"),
+		RefillAfter:   limit.GetDuration("refill-interval"),
+	}
+	opts = options{
+		telemetry:         telemetry,
+		raft:              raft,
+		limit:             limit,
+		bindall:           Zero.Conf.GetBool("bindall"),
+		portOffset:        Zero.Conf.GetInt("port_offset"),
+		numReplicas:       Zero.Conf.GetInt("replicas"),
+		peer:              Zero.Conf.GetString("peer"),
+		w:                 Zero.Conf.GetString("wal"),
+		rebalanceInterval: Zero.Conf.GetDuration("rebalance_interval"),
+		moveRate:          int64(moveRate),
+		moveWindow:        moveWindow,
+		removeDeadAfter:   replica.GetDuration("remove-dead-after"),
+		provisionLearners: replica.GetBool("provision-learners"),
+		tlsClientConfig:   tlsConf,
+		audit:             auditConf,
+		limiterConfig:     limitConf,
+	}
+	glog.Infof("Setting Config to: %+v", opts)
+	x.WorkerConfig.Parse(Zero.Conf)
+
+	if !enc.EeBuild && Zero.Conf.GetString("enterprise_license") != "" {
+		log.Fatalf("ERROR: enterprise_license option cannot be applied to OSS builds. ")
+	}
+
+	if opts.numReplicas < 0 || opts.numReplicas%2 == 0 {
+		log.Fatalf("ERROR: Number of replicas must be odd for consensus. Found: %d",
+			opts.numReplicas)
+	}
+
+	if Zero.Conf.GetBool("expose_trace") {
+		// TODO: Remove this once we get rid of event logs.
+		trace.AuthRequest = func(req *http.Request) (any, sensitive bool) {
+			return true, true
+		}
+	}
+
+	if opts.audit != nil {
+		wd, err := filepath.Abs(opts.w)
+		x.Check(err)
+		ad, err := filepath.Abs(opts.audit.Output)
+		x.Check(err)
+		x.AssertTruef(ad != wd,
+			"WAL directory and Audit output cannot be the same ('%s').", opts.audit.Output)
+	}
+
+	if opts.rebalanceInterval <= 0 {
+		log.Fatalf("ERROR: Rebalance interval must be greater than zero. Found: %d",
+			opts.rebalanceInterval)
+	}
+
+	grpc.EnableTracing = false
+	otrace.ApplyConfig(otrace.Config{
+		DefaultSampler: otrace.ProbabilitySampler(Zero.Conf.GetFloat64("trace"))})
+}
+
+// openWAL creates and initializes the write-ahead log of the Zero with the given Raft ID.
+func openWAL(nodeId uint64) *raftwal.DiskStorage {
+	x.Checkf(os.MkdirAll(opts.w, 0700), "Error while creating WAL dir.")
+	store := raftwal.Init(opts.w)
+	store.SetUint(raftwal.RaftId, nodeId)
+	store.SetUint(raftwal.GroupId, 0) // All zeros have group zero.
+	return store
+}
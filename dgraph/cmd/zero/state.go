@@ -0,0 +1,192 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zero
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+)
+
+// stateVersion is the version of the state documents written by exportState.
+const stateVersion = 1
+
+// stateDocument is the portable copy of the state of Zero used for disaster recovery. The
+// state is kept in its protobuf JSON form, so that it can be read by the other tools.
+type stateDocument struct {
+	Version    int             `json:"version"`
+	ExportedAt time.Time       `json:"exportedAt"`
+	State      json.RawMessage `json:"state"`
+}
+
+// marshalState returns the state document of the given state.
+func marshalState(state *pb.MembershipState) ([]byte, error) {
+	var buf bytes.Buffer
+	m := jsonpb.Marshaler{EmitDefaults: true}
+	if err := m.Marshal(&buf, state); err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(stateDocument{
+		Version:    stateVersion,
+		ExportedAt: time.Now().UTC(),
+		State:      buf.Bytes(),
+	}, "", "  ")
+}
+
+// unmarshalState returns the state of the given state document.
+func unmarshalState(data []byte) (*pb.MembershipState, error) {
+	var doc stateDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, errors.Wrap(err, "while reading the state document")
+	}
+	if doc.Version != stateVersion {
+		return nil, errors.Errorf("unsupported version %d of the state document", doc.Version)
+	}
+	if len(doc.State) == 0 {
+		return nil, errors.New("the state document has no state")
+	}
+	var state pb.MembershipState
+	if err := jsonpb.Unmarshal(bytes.NewReader(doc.State), &state); err != nil {
+		return nil, errors.Wrap(err, "while reading the state")
+	}
+	return &state, nil
+}
+
+// ImportResult is the outcome of importing a state into Zero.
+type ImportResult struct {
+	MaxUID       uint64   `json:"maxUID"`
+	MaxTxnTs     uint64   `json:"maxTxnTs"`
+	MaxNsID      uint64   `json:"maxNsID"`
+	MaxSeq       uint64   `json:"maxSeq"`
+	MaxRaftId    uint64   `json:"maxRaftId"`
+	Tablets      int      `json:"tablets"`
+	ReservedUids int      `json:"reservedUids"`
+	License      bool     `json:"license"`
+	Conflicts    []string `json:"conflicts"`
+}
+
+// importState merges the given state, usually exported from another cluster, into the state of
+// Zero. The leases only move forward, so that no UID or timestamp is handed out twice, and the
+// tablets which aren't served yet are assigned to the groups of the state. The members aren't
+// imported: the Alphas join by themselves, and the Zeros join via Raft. Anything which can't be
+// imported is reported in the conflicts of the result.
+func (s *Server) importState(ctx context.Context, in *pb.MembershipState) (*ImportResult, error) {
+	if !s.Node.AmLeader() {
+		return nil, errors.New("The state can only be imported on the Zero leader")
+	}
+	res := &ImportResult{}
+	cur := s.membershipState()
+	if len(in.Cid) > 0 && len(cur.Cid) > 0 && in.Cid != cur.Cid {
+		res.Conflicts = append(res.Conflicts,
+			fmt.Sprintf("cid %s differs from the cid %s of the cluster", in.Cid, cur.Cid))
+	}
+
+	// The leases are applied one per proposal.
+	leases := []*pb.ZeroProposal{
+		{MaxUID: in.MaxUID}, {MaxTxnTs: in.MaxTxnTs}, {MaxNsID: in.MaxNsID}, {MaxSeq: in.MaxSeq},
+	}
+	var proposed bool
+	s.leaseLock.Lock()
+	for _, p := range leases {
+		if p.MaxUID <= cur.MaxUID && p.MaxTxnTs <= cur.MaxTxnTs && p.MaxNsID <= cur.MaxNsID &&
+			p.MaxSeq <= cur.MaxSeq {
+			continue
+		}
+		if err := s.Node.proposeAndWait(ctx, p); err != nil {
+			s.leaseLock.Unlock()
+			return nil, errors.Wrapf(err, "while proposing the lease %+v", p)
+		}
+		proposed = true
+	}
+	if proposed {
+		// Hand out the IDs from the new leases.
+		s.updateLeases()
+	}
+	s.leaseLock.Unlock()
+
+	if in.MaxRaftId > cur.MaxRaftId {
+		if err := s.Node.proposeAndWait(ctx, &pb.ZeroProposal{MaxRaftId: in.MaxRaftId}); err != nil {
+			return nil, errors.Wrap(err, "while proposing the max raft id")
+		}
+	}
+
+	gids := make([]uint32, 0, len(in.Groups))
+	for gid := range in.Groups {
+		gids = append(gids, gid)
+	}
+	sort.Slice(gids, func(i, j int) bool { return gids[i] < gids[j] })
+	for _, gid := range gids {
+		for pred, tablet := range in.Groups[gid].GetTablets() {
+			if prev := s.ServingTablet(pred); prev != nil {
+				if prev.GroupId != gid {
+					res.Conflicts = append(res.Conflicts, fmt.Sprintf(
+						"tablet %s is served by group %d instead of %d", pred, prev.GroupId, gid))
+				}
+				continue
+			}
+			t := &pb.Tablet{GroupId: gid, Predicate: pred, ReadOnly: tablet.ReadOnly}
+			if err := s.Node.proposeAndWait(ctx, &pb.ZeroProposal{Tablet: t}); err != nil {
+				res.Conflicts = append(res.Conflicts,
+					fmt.Sprintf("tablet %s of group %d: %v", pred, gid, err))
+				continue
+			}
+			res.Tablets++
+		}
+	}
+
+	reserved := make(map[string]*pb.AssignedIds)
+	for name, ids := range in.ReservedUids {
+		if _, ok := cur.ReservedUids[name]; ok {
+			res.Conflicts = append(res.Conflicts, fmt.Sprintf("UIDs are already reserved under %q", name))
+			continue
+		}
+		reserved[name] = ids
+	}
+	if len(reserved) > 0 {
+		if err := s.Node.proposeAndWait(ctx, &pb.ZeroProposal{ReservedUids: reserved}); err != nil {
+			res.Conflicts = append(res.Conflicts, fmt.Sprintf("reserved UIDs: %v", err))
+		} else {
+			res.ReservedUids = len(reserved)
+		}
+	}
+
+	if in.License != nil {
+		if cur.License != nil {
+			res.Conflicts = append(res.Conflicts, "the cluster already has a license")
+		} else if err := s.Node.proposeAndWait(ctx,
+			&pb.ZeroProposal{License: in.License}); err != nil {
+			res.Conflicts = append(res.Conflicts, fmt.Sprintf("license: %v", err))
+		} else {
+			res.License = true
+		}
+	}
+
+	state := s.membershipState()
+	res.MaxUID, res.MaxTxnTs = state.MaxUID, state.MaxTxnTs
+	res.MaxNsID, res.MaxRaftId = state.MaxNsID, state.MaxRaftId
+	res.MaxSeq = state.MaxSeq
+	glog.Infof("Imported the state: %+v", res)
+	return res, nil
+}
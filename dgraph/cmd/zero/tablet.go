@@ -20,14 +20,17 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strconv"
 	"time"
 
 	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/worker"
 	"github.com/dgraph-io/dgraph/x"
 	humanize "github.com/dustin/go-humanize"
 	"github.com/golang/glog"
 	"github.com/pkg/errors"
 	otrace "go.opencensus.io/trace"
+	"google.golang.org/grpc/metadata"
 )
 
 const (
@@ -61,18 +64,42 @@ Phase II:
 • G1 gets this, G2 gets this.
 • Both propagate this to their followers.
 
+THROTTLING:
+• Zero passes the bandwidth limit set via --move to G1, which keeps its sending rate under it.
+• Zero starts rebalancing moves only within the maintenance window set via --move.
+• A move can be paused, resumed or cancelled while in Phase I. Pausing interrupts the ongoing run
+  of Phase I, which is redone once the move is resumed. Cancelling asks G2 to delete the data it
+  received so far.
+
 */
 
-//  TODO: Have a event log for everything.
+// TODO: Have a event log for everything.
 func (s *Server) rebalanceTablets() {
 	ticker := time.NewTicker(opts.rebalanceInterval)
 	for range ticker.C {
-		predicate, srcGroup, dstGroup := s.chooseTablet()
-		if len(predicate) == 0 {
+		if !opts.moveWindow.contains(time.Now()) || !s.Node.AmLeader() {
+			continue
+		}
+		hints, err := s.fetchColocations()
+		if err != nil {
+			// Don't rebalance without the hints, as that could break the co-location.
+			glog.Errorf("Skipping rebalancing. Unable to read co-location hints: %v", err)
 			continue
 		}
-		if err := s.movePredicate(predicate, srcGroup, dstGroup); err != nil {
-			glog.Errorln(err)
+		// Restore the co-location of the predicates before balancing the groups.
+		if moved, err := s.repairColocations(hints); moved {
+			if err != nil {
+				glog.Errorln(err)
+			}
+			continue
+		}
+
+		predicates, srcGroup, dstGroup := s.chooseTablet(hints)
+		for _, predicate := range predicates {
+			if err := s.movePredicate(predicate, srcGroup, dstGroup); err != nil {
+				glog.Errorln(err)
+				break
+			}
 		}
 	}
 }
@@ -173,10 +200,25 @@ func (s *Server) movePredicate(predicate string, srcGroup, dstGroup uint32) erro
 		SourceGid: srcGroup,
 		DestGid:   dstGroup,
 	}
+	if opts.moveRate > 0 {
+		ctx = metadata.AppendToOutgoingContext(ctx, worker.MoveRateLimitKey,
+			strconv.FormatInt(opts.moveRate, 10))
+	}
+	ctx, done := s.moves.start(ctx, &MoveProgress{
+		Predicate:  predicate,
+		SrcGroup:   srcGroup,
+		DstGroup:   dstGroup,
+		RateLimit:  opts.moveRate,
+		TotalBytes: tab.UncompressedBytes,
+	})
+	defer done()
 
 	var sinceTs uint64
 	counter := 1
 	nonBlockingMove := func() error {
+		if err := s.moves.waitIfPaused(ctx); err != nil {
+			return err
+		}
 		// Get a new timestamp. Source Alpha leader must reach this timestamp before streaming data.
 		ids, err := s.Timestamps(ctx, &pb.Num{Val: 1})
 		if err != nil || ids.StartId == 0 {
@@ -186,17 +228,34 @@ func (s *Server) movePredicate(predicate string, srcGroup, dstGroup uint32) erro
 		// Move the predicate. Commits on this predicate are not blocked yet. Any data after ReadTs
 		// will be moved in the phase II below.
 		in.ReadTs = ids.StartId
-		in.SinceTs, sinceTs = sinceTs, in.ReadTs
+		in.SinceTs = sinceTs
 		span.Annotatef(nil, "Starting move [1.%d]: %+v", counter, in)
 		glog.Infof("Starting move [1.%d]: %+v", counter, in)
-		_, err = wc.MovePredicate(ctx, in)
-		return err
+		stepCtx, stepDone := s.moves.startStep(ctx)
+		defer stepDone()
+		resp, err := wc.MovePredicate(stepCtx, in)
+		if err != nil {
+			return err
+		}
+		sent, _ := strconv.ParseInt(string(resp.GetData()), 10, 64)
+		s.moves.stepDone(sent)
+		sinceTs = in.ReadTs
+		return nil
 	}
 
 	var start time.Time
 	for {
 		start = time.Now()
 		if err := nonBlockingMove(); err != nil {
+			if s.moves.interrupted() {
+				// The move was paused. This run is redone once it's resumed.
+				glog.Infof("Predicate move paused at [1.%d]", counter)
+				continue
+			}
+			if s.moves.isCancelled() {
+				s.cleanupCancelledMove(predicate, dstGroup)
+				return errMoveCancelled
+			}
 			return errors.Wrapf(err, "while moving the majority of predicate")
 		}
 		took := time.Since(start)
@@ -214,6 +273,14 @@ func (s *Server) movePredicate(predicate string, srcGroup, dstGroup uint32) erro
 		counter++
 	}
 
+	// The move can't be paused or cancelled from here on, as commits on the predicate are blocked.
+	if err := s.moves.enterPhaseTwo(ctx); err != nil {
+		if err == errMoveCancelled {
+			s.cleanupCancelledMove(predicate, dstGroup)
+		}
+		return err
+	}
+
 	// PHASE II:
 	// Block all commits on this predicate. Keep them blocked until we return from this function.
 	unblock := s.blockTablet(predicate)
@@ -276,7 +343,35 @@ func (s *Server) movePredicate(predicate string, srcGroup, dstGroup uint32) erro
 	return nil
 }
 
-func (s *Server) chooseTablet() (predicate string, srcGroup uint32, dstGroup uint32) {
+// cleanupCancelledMove deletes the data of the predicate received by the destination group before
+// the move was cancelled. The destination group doesn't serve the predicate, so it's safe to
+// delete it there.
+func (s *Server) cleanupCancelledMove(predicate string, dstGroup uint32) {
+	glog.Infof("Predicate move of %s to group %d cancelled. Cleaning up.", predicate, dstGroup)
+	pl := s.Leader(dstGroup)
+	if pl == nil {
+		glog.Warningf("Unable to reach leader of group %d to clean up predicate %s",
+			dstGroup, predicate)
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	in := &pb.MovePredicatePayload{
+		Predicate:        predicate,
+		SourceGid:        dstGroup,
+		DestGid:          0,
+		ExpectedChecksum: s.groupChecksums()[dstGroup],
+	}
+	if _, err := pb.NewWorkerClient(pl.Get()).MovePredicate(ctx, in); err != nil {
+		glog.Warningf("While deleting predicate %s in group %d. Error: %v",
+			predicate, dstGroup, err)
+	}
+}
+
+// chooseTablet finds the tablets to move to balance the sizes of the groups. The predicates which
+// must be co-located are moved together.
+func (s *Server) chooseTablet(
+	hints map[string]string) (predicates []string, srcGroup uint32, dstGroup uint32) {
 	s.RLock()
 	defer s.RUnlock()
 	if s.state == nil {
@@ -321,23 +416,52 @@ func (s *Server) chooseTablet() (predicate string, srcGroup uint32, dstGroup uin
 			continue
 		}
 
-		// Try to find a predicate which we can move.
+		// Group the tablets which must be co-located, as they can only be moved together.
+		units := make(map[string][]*pb.Tablet)
+		for _, tab := range s.state.Groups[srcGroup].Tablets {
+			root := colocationRoot(hints, tab.Predicate)
+			units[root] = append(units[root], tab)
+		}
+		// The tablets which must be co-located with a tablet served by another group can't be
+		// moved here. Those are taken care of by repairColocations.
+		for pred := range hints {
+			tab := s.servingTablet(pred)
+			if tab != nil && tab.GroupId != srcGroup {
+				delete(units, colocationRoot(hints, pred))
+			}
+		}
+		for root := range units {
+			if tab := s.servingTablet(root); tab != nil && tab.GroupId != srcGroup {
+				delete(units, root)
+			}
+		}
+
+		// Try to find the tablets which we can move.
 		size := int64(0)
-		group := s.state.Groups[srcGroup]
-		for _, tab := range group.Tablets {
-			// Reserved predicates should always be in group 1 so do not re-balance them.
-			if x.IsReservedPredicate(tab.Predicate) {
+		for _, tabs := range units {
+			unitSize := int64(0)
+			reserved := false
+			for _, tab := range tabs {
+				// Reserved predicates should always be in group 1 so do not re-balance them.
+				reserved = reserved || x.IsReservedPredicate(tab.Predicate)
+				unitSize += tab.OnDiskBytes
+			}
+			if reserved {
 				continue
 			}
 
-			// Finds a tablet as big a possible such that on moving it dstGroup's size is
+			// Finds the tablets as big a possible such that on moving them dstGroup's size is
 			// less than or equal to srcGroup.
-			if tab.OnDiskBytes <= sizeDiff/2 && tab.OnDiskBytes > size {
-				predicate = tab.Predicate
-				size = tab.OnDiskBytes
+			if unitSize <= sizeDiff/2 && unitSize > size {
+				predicates = predicates[:0]
+				for _, tab := range tabs {
+					predicates = append(predicates, tab.Predicate)
+				}
+				size = unitSize
 			}
 		}
-		if len(predicate) > 0 {
+		if len(predicates) > 0 {
+			sort.Strings(predicates)
 			return
 		}
 	}
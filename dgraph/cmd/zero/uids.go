@@ -0,0 +1,146 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zero
+
+import (
+	"context"
+	"sort"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+)
+
+// UidRange is a range of UIDs reserved under a name.
+type UidRange struct {
+	Name    string `json:"name"`
+	StartId uint64 `json:"startId"`
+	EndId   uint64 `json:"endId"`
+}
+
+// UidLease is the usage of the UID lease of Zero.
+type UidLease struct {
+	// MaxLeased is the highest UID leased by Zero.
+	MaxLeased uint64 `json:"maxLeased"`
+	// Next is the next UID handed out.
+	Next uint64 `json:"next"`
+	// Available is the number of UIDs leased, but not handed out yet.
+	Available uint64     `json:"available"`
+	Reserved  []UidRange `json:"reserved"`
+}
+
+var errNotLeader = errors.New("The UID lease can only be managed on the Zero leader")
+
+// uidLease returns the usage of the UID lease. The next UID is only known by the leader.
+func (s *Server) uidLease() (*UidLease, error) {
+	if !s.Node.AmLeader() {
+		return nil, errNotLeader
+	}
+	s.leaseLock.Lock()
+	next := s.nextUint[pb.Num_UID]
+	s.leaseLock.Unlock()
+
+	state := s.membershipState()
+	lease := &UidLease{MaxLeased: state.MaxUID, Next: next, Reserved: []UidRange{}}
+	if state.MaxUID >= next {
+		lease.Available = state.MaxUID - next + 1
+	}
+	for name, ids := range state.ReservedUids {
+		lease.Reserved = append(lease.Reserved,
+			UidRange{Name: name, StartId: ids.StartId, EndId: ids.EndId})
+	}
+	sort.Slice(lease.Reserved, func(i, j int) bool {
+		return lease.Reserved[i].StartId < lease.Reserved[j].StartId
+	})
+	return lease, nil
+}
+
+// reserveUids hands out num UIDs and records them under the given name, so that an external ID
+// system can assign them. If start isn't zero, the range begins at start, and the UIDs below it
+// which weren't handed out yet are skipped. The start must not have been handed out already.
+func (s *Server) reserveUids(ctx context.Context, name string, num, start uint64) (
+	*UidRange, error) {
+	if !s.Node.AmLeader() {
+		return nil, errNotLeader
+	}
+	if len(name) == 0 {
+		return nil, errors.New("The reserved UIDs need a name")
+	}
+	if num == 0 {
+		return nil, errors.New("Nothing to be reserved")
+	}
+
+	s.leaseLock.Lock()
+	defer s.leaseLock.Unlock()
+	if _, ok := s.membershipState().ReservedUids[name]; ok {
+		return nil, errors.Errorf("UIDs are already reserved under the name %q", name)
+	}
+	val := num
+	if start > 0 {
+		next := s.nextUint[pb.Num_UID]
+		if start < next {
+			return nil, errors.Errorf("UID %#x has already been handed out, the next UID is %#x",
+				start, next)
+		}
+		val = start + num - next
+	}
+	ids, err := s.leaseLocked(ctx, &pb.Num{Val: val, Type: pb.Num_UID})
+	if err != nil {
+		return nil, err
+	}
+	reserved := &pb.AssignedIds{StartId: ids.EndId - num + 1, EndId: ids.EndId}
+	if err := s.Node.proposeAndWait(ctx, &pb.ZeroProposal{
+		ReservedUids: map[string]*pb.AssignedIds{name: reserved}}); err != nil {
+		return nil, errors.Wrapf(err, "while recording the UIDs [%#x, %#x] reserved under %q",
+			reserved.StartId, reserved.EndId, name)
+	}
+	glog.Infof("Reserved the UIDs [%#x, %#x] under %q", reserved.StartId, reserved.EndId, name)
+	return &UidRange{Name: name, StartId: reserved.StartId, EndId: reserved.EndId}, nil
+}
+
+// releaseUids forgets the UIDs reserved under the given name. The UIDs aren't handed out again.
+func (s *Server) releaseUids(ctx context.Context, name string) error {
+	if !s.Node.AmLeader() {
+		return errNotLeader
+	}
+	if _, ok := s.membershipState().ReservedUids[name]; !ok {
+		return errors.Errorf("No UIDs are reserved under the name %q", name)
+	}
+	return s.Node.proposeAndWait(ctx, &pb.ZeroProposal{
+		ReservedUids: map[string]*pb.AssignedIds{name: {}}})
+}
+
+// shrinkUidLease gives back the UIDs leased but not handed out yet, so that the max UID of the
+// state is the last UID handed out. It returns the number of UIDs given back.
+func (s *Server) shrinkUidLease(ctx context.Context) (uint64, error) {
+	if !s.Node.AmLeader() {
+		return 0, errNotLeader
+	}
+	s.leaseLock.Lock()
+	defer s.leaseLock.Unlock()
+
+	last := s.nextUint[pb.Num_UID] - 1
+	max := s.maxLease(pb.Num_UID)
+	if last == 0 || last >= max {
+		return 0, nil
+	}
+	if err := s.Node.proposeAndWait(ctx, &pb.ZeroProposal{ShrinkMaxUID: last}); err != nil {
+		return 0, err
+	}
+	glog.Infof("Shrank the UID lease from %#x to %#x", max, last)
+	return max - last, nil
+}
@@ -76,6 +76,7 @@ type Server struct {
 	tlsClientConfig *tls.Config
 
 	moveOngoing    chan struct{}
+	moves          *moveTracker // Tracks the ongoing predicate move.
 	blockCommitsOn *sync.Map
 
 	checkpointPerGroup map[uint32]uint64
@@ -97,11 +98,13 @@ func (s *Server) Init() {
 	s.nextUint[pb.Num_UID] = 1
 	s.nextUint[pb.Num_TXN_TS] = 1
 	s.nextUint[pb.Num_NS_ID] = 1
+	s.nextUint[pb.Num_SEQ] = 1
 	s.nextGroup = 1
 	s.leaderChangeCh = make(chan struct{}, 1)
 	s.closer = z.NewCloser(2) // grpc and http
 	s.blockCommitsOn = new(sync.Map)
 	s.moveOngoing = make(chan struct{}, 1)
+	s.moves = &moveTracker{}
 	s.checkpointPerGroup = make(map[uint32]uint64)
 	if opts.limiterConfig.UidLeaseLimit > 0 {
 		// rate limiting is not enabled when lease limit is set to zero.
@@ -110,6 +113,7 @@ func (s *Server) Init() {
 	}
 
 	go s.rebalanceTablets()
+	go s.removeDeadReplicas()
 }
 
 func (s *Server) periodicallyPostTelemetry() {
@@ -369,7 +373,9 @@ func (s *Server) createProposals(dst *pb.Group) ([]*pb.ZeroProposal, error) {
 			return res, errors.Errorf("Unknown member: %+v", dstMember)
 		}
 		if srcMember.Addr != dstMember.Addr ||
-			srcMember.Leader != dstMember.Leader {
+			srcMember.Leader != dstMember.Leader ||
+			srcMember.Learner != dstMember.Learner ||
+			srcMember.Witness != dstMember.Witness {
 
 			proposal := &pb.ZeroProposal{
 				Member: dstMember,
@@ -462,6 +468,11 @@ func (s *Server) Connect(ctx context.Context,
 			"Cannot add Learner Node.")
 	}
 
+	if _, has := ms.GetGroups()[m.GroupId]; m.Witness && (m.GroupId == 0 || !has) {
+		return nil, errors.Errorf("A witness must join an existing group, got group %d: %+v",
+			m.GroupId, m)
+	}
+
 	if m.ClusterInfoOnly {
 		// This request only wants to access the membership state, and nothing else. Most likely
 		// from our clients.
@@ -553,15 +564,18 @@ func (s *Server) Connect(ctx context.Context,
 				return proposal
 			}
 
-			if m.Learner {
+			if m.Learner || m.Witness {
 				// Give it the group it wants.
 				proposal.Member = m
 				return proposal
 			}
 
 			// We don't have this server in the list.
-			if len(group.Members) < s.NumReplicas {
+			if numReplicas(group) < s.NumReplicas {
 				// We need more servers here, so let's add it.
+				if s.joinAsLearner(group) {
+					m.Learner = true
+				}
 				proposal.Member = m
 				return proposal
 			} else if m.ForceGroupId {
@@ -575,8 +589,11 @@ func (s *Server) Connect(ctx context.Context,
 		}
 		// Let's assign this server to a new group.
 		for gid, group := range s.state.Groups {
-			if len(group.Members) < s.NumReplicas {
+			if numReplicas(group) < s.NumReplicas {
 				m.GroupId = gid
+				if s.joinAsLearner(group) {
+					m.Learner = true
+				}
 				proposal.Member = m
 				return proposal
 			}
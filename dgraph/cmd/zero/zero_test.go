@@ -83,3 +83,55 @@ func TestIdBump(t *testing.T) {
 	res, err = zc.AssignIds(ctx, &pb.Num{Val: 10, Type: pb.Num_UID, Bump: true})
 	require.Contains(t, err.Error(), "Nothing to be leased")
 }
+
+func TestStateDocument(t *testing.T) {
+	state := &pb.MembershipState{
+		Cid:       "cid",
+		MaxUID:    100,
+		MaxTxnTs:  200,
+		MaxNsID:   3,
+		MaxRaftId: 4,
+		Groups: map[uint32]*pb.Group{
+			1: {Tablets: map[string]*pb.Tablet{"name": {GroupId: 1, Predicate: "name"}}},
+		},
+	}
+	data, err := marshalState(state)
+	require.NoError(t, err)
+	got, err := unmarshalState(data)
+	require.NoError(t, err)
+	require.Equal(t, state.MaxUID, got.MaxUID)
+	require.Equal(t, state.MaxTxnTs, got.MaxTxnTs)
+	require.Equal(t, state.MaxNsID, got.MaxNsID)
+	require.Equal(t, state.MaxRaftId, got.MaxRaftId)
+	require.Equal(t, "name", got.Groups[1].Tablets["name"].Predicate)
+
+	_, err = unmarshalState([]byte(`{"version": 2, "state": {}}`))
+	require.Error(t, err)
+	_, err = unmarshalState([]byte(`{"version": 1}`))
+	require.Error(t, err)
+}
+
+func TestReservedUidsProto(t *testing.T) {
+	p := &pb.ZeroProposal{
+		ReservedUids: map[string]*pb.AssignedIds{
+			"users": {StartId: 100, EndId: 199},
+			"old":   {},
+		},
+		ShrinkMaxUID: 1000,
+	}
+	data, err := p.Marshal()
+	require.NoError(t, err)
+	var got pb.ZeroProposal
+	require.NoError(t, got.Unmarshal(data))
+	require.Equal(t, p, &got)
+
+	state := &pb.MembershipState{
+		MaxUID:       1000,
+		ReservedUids: map[string]*pb.AssignedIds{"users": {StartId: 100, EndId: 199}},
+	}
+	data, err = state.Marshal()
+	require.NoError(t, err)
+	var gotState pb.MembershipState
+	require.NoError(t, gotState.Unmarshal(data))
+	require.Equal(t, state, &gotState)
+}
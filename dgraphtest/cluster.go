@@ -0,0 +1,431 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package dgraphtest runs Dgraph clusters in the integration tests of the applications built on
+// Dgraph. The nodes of a cluster are dgraph processes listening on localhost, so the tests need
+// a dgraph binary but no container:
+//
+//	c, err := dgraphtest.NewCluster(dgraphtest.ClusterConfig{Groups: 2, Replicas: 3})
+//	require.NoError(t, err)
+//	defer c.Cleanup()
+//	require.NoError(t, c.Start())
+//
+// Faults are injected by killing, restarting or pausing the nodes. Snapshot and Restore save and
+// bring back the data of the whole cluster, so that each test can start from the same state.
+package dgraphtest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go/build"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/dgo/v210"
+	"github.com/dgraph-io/dgo/v210/protos/api"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+const (
+	// DefaultPortOffset is the default port offset of the first node of a cluster.
+	DefaultPortOffset = 100
+	// DefaultStartTimeout is the default time given to a cluster to start.
+	DefaultStartTimeout = time.Minute
+)
+
+// ClusterConfig is the config of a cluster. The zero values are replaced by the defaults.
+type ClusterConfig struct {
+	// Binary is the path of the dgraph binary. It's $DGRAPH_BINARY by default, or dgraph in
+	// $GOPATH/bin.
+	Binary string
+	// Dir is the directory storing the data and the logs of the nodes. A temporary directory,
+	// removed by Cleanup, is used by default.
+	Dir string
+	// Zeros is the number of Zeros, 1 by default.
+	Zeros int
+	// Groups is the number of groups of Alphas, 1 by default.
+	Groups int
+	// Replicas is the number of Alphas of each group, 1 by default.
+	Replicas int
+	// PortOffset is the port offset from which the nodes look for free ports.
+	PortOffset int
+	// ZeroFlags and AlphaFlags are the flags added to the command line of the nodes, e.g.
+	// {"limit": "mutations=strict;"}. They override the flags set by the cluster.
+	ZeroFlags  map[string]string
+	AlphaFlags map[string]string
+	// StartTimeout is the time given to the cluster to start, and to each node to stop.
+	StartTimeout time.Duration
+}
+
+func (conf *ClusterConfig) setDefaults() {
+	if conf.Binary == "" {
+		conf.Binary = os.Getenv("DGRAPH_BINARY")
+	}
+	if conf.Binary == "" {
+		gopath := os.Getenv("GOPATH")
+		if gopath == "" {
+			gopath = build.Default.GOPATH
+		}
+		conf.Binary = filepath.Join(gopath, "bin", "dgraph")
+	}
+	if conf.Zeros <= 0 {
+		conf.Zeros = 1
+	}
+	if conf.Groups <= 0 {
+		conf.Groups = 1
+	}
+	if conf.Replicas <= 0 {
+		conf.Replicas = 1
+	}
+	if conf.PortOffset <= 0 {
+		conf.PortOffset = DefaultPortOffset
+	}
+	if conf.StartTimeout <= 0 {
+		conf.StartTimeout = DefaultStartTimeout
+	}
+}
+
+// Cluster is a Dgraph cluster run by a test.
+type Cluster struct {
+	Zeros  []*Node
+	Alphas []*Node
+
+	conf   ClusterConfig
+	tmpDir bool
+}
+
+// NewCluster creates the nodes of a cluster, without starting them.
+func NewCluster(conf ClusterConfig) (*Cluster, error) {
+	conf.setDefaults()
+	if _, err := os.Stat(conf.Binary); err != nil {
+		return nil, errors.Wrapf(err, "the dgraph binary isn't found")
+	}
+	c := &Cluster{conf: conf}
+	if c.conf.Dir == "" {
+		dir, err := ioutil.TempDir("", "dgraphtest")
+		if err != nil {
+			return nil, err
+		}
+		c.conf.Dir, c.tmpDir = dir, true
+	}
+
+	used := make(map[int]bool)
+	offset := conf.PortOffset
+	newNode := func(name string, group uint32) *Node {
+		n := &Node{
+			Name:    name,
+			Group:   group,
+			binary:  conf.Binary,
+			dir:     filepath.Join(c.conf.Dir, name),
+			timeout: conf.StartTimeout,
+		}
+		for ; ; offset++ {
+			n.Offset = offset
+			if portsFree(n.ports(), used) {
+				break
+			}
+		}
+		for _, port := range n.ports() {
+			used[port] = true
+		}
+		offset++
+		return n
+	}
+
+	for i := 1; i <= conf.Zeros; i++ {
+		n := newNode(fmt.Sprintf("zero%d", i), 0)
+		flags := map[string]string{
+			"port_offset": strconv.Itoa(n.Offset),
+			"my":          n.raftAddr(),
+			"raft":        fmt.Sprintf("idx=%d;", i),
+			"replicas":    strconv.Itoa(conf.Replicas),
+			"wal":         "zw",
+		}
+		if i > 1 {
+			flags["peer"] = c.Zeros[0].raftAddr()
+		}
+		n.args = commandLine("zero", flags, conf.ZeroFlags)
+		c.Zeros = append(c.Zeros, n)
+	}
+	var zeros []string
+	for _, z := range c.Zeros {
+		zeros = append(zeros, z.GrpcAddr())
+	}
+
+	for g := 1; g <= conf.Groups; g++ {
+		for r := 1; r <= conf.Replicas; r++ {
+			idx := len(c.Alphas) + 1
+			n := newNode(fmt.Sprintf("alpha%d", idx), uint32(g))
+			flags := map[string]string{
+				"port_offset": strconv.Itoa(n.Offset),
+				"my":          n.raftAddr(),
+				"zero":        strings.Join(zeros, ","),
+				"raft":        fmt.Sprintf("idx=%d; group=%d;", idx, g),
+				"postings":    "p",
+				"wal":         "w",
+				"tmp":         "t",
+				"export":      "export",
+			}
+			n.args = commandLine("alpha", flags, conf.AlphaFlags)
+			c.Alphas = append(c.Alphas, n)
+		}
+	}
+	return c, nil
+}
+
+// portsFree returns whether the ports aren't used by the cluster nor by another process.
+func portsFree(ports []int, used map[int]bool) bool {
+	for _, port := range ports {
+		if used[port] {
+			return false
+		}
+		l, err := net.Listen("tcp", fmt.Sprintf("localhost:%d", port))
+		if err != nil {
+			return false
+		}
+		l.Close()
+	}
+	return true
+}
+
+// commandLine returns the arguments of the dgraph command, with the flags sorted so that the
+// command lines of the nodes are the same from one run to the next.
+func commandLine(cmd string, flags, extra map[string]string) []string {
+	all := make(map[string]string, len(flags)+len(extra))
+	for k, v := range flags {
+		all[k] = v
+	}
+	for k, v := range extra {
+		all[k] = v
+	}
+	names := make([]string, 0, len(all))
+	for k := range all {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	args := []string{cmd}
+	for _, k := range names {
+		args = append(args, "--"+k+"="+all[k])
+	}
+	return args
+}
+
+// Dir returns the directory storing the data and the logs of the nodes.
+func (c *Cluster) Dir() string {
+	return c.conf.Dir
+}
+
+// Nodes returns the Zeros followed by the Alphas.
+func (c *Cluster) Nodes() []*Node {
+	return append(append([]*Node{}, c.Zeros...), c.Alphas...)
+}
+
+// Group returns the Alphas of the group.
+func (c *Cluster) Group(gid uint32) []*Node {
+	var res []*Node
+	for _, n := range c.Alphas {
+		if n.Group == gid {
+			res = append(res, n)
+		}
+	}
+	return res
+}
+
+// Start starts the nodes which aren't running, and waits until the cluster is ready: the nodes
+// are healthy, and the Alphas have joined their group.
+func (c *Cluster) Start() error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.conf.StartTimeout)
+	defer cancel()
+	// The first Zero must be ready for the other nodes to join the cluster.
+	for i, n := range c.Nodes() {
+		if !n.Running() {
+			if err := n.Start(); err != nil {
+				return err
+			}
+		}
+		if i == 0 {
+			if err := n.WaitHealthy(ctx); err != nil {
+				return err
+			}
+		}
+	}
+	return c.WaitHealthy(ctx)
+}
+
+// WaitHealthy waits until the nodes are healthy, and the Alphas have joined their group.
+func (c *Cluster) WaitHealthy(ctx context.Context) error {
+	for _, n := range c.Nodes() {
+		if err := n.WaitHealthy(ctx); err != nil {
+			return err
+		}
+	}
+	for {
+		joined, err := c.alphasJoined(ctx)
+		if err != nil {
+			return err
+		}
+		if joined {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return errors.Wrapf(ctx.Err(), "while waiting for the Alphas to join their group")
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
+// alphasJoined returns whether the state of the cluster, as known by the Zero leader, lists all
+// the Alphas in their group.
+func (c *Cluster) alphasJoined(ctx context.Context) (bool, error) {
+	var zero *Node
+	for _, n := range c.Zeros {
+		if n.Running() {
+			zero = n
+			break
+		}
+	}
+	if zero == nil {
+		return false, errors.New("no Zero is running")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		"http://"+zero.HttpAddr()+"/state", nil)
+	if err != nil {
+		return false, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return false, nil
+	}
+	defer resp.Body.Close()
+	var state struct {
+		Groups map[string]struct {
+			Members map[string]struct {
+				Addr string `json:"addr"`
+			} `json:"members"`
+		} `json:"groups"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&state); err != nil {
+		return false, nil
+	}
+	for _, n := range c.Alphas {
+		found := false
+		for _, m := range state.Groups[strconv.Itoa(int(n.Group))].Members {
+			if m.Addr == n.raftAddr() {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// Stop stops all the nodes gracefully, the Alphas first.
+func (c *Cluster) Stop() error {
+	nodes := c.Nodes()
+	for i := len(nodes) - 1; i >= 0; i-- {
+		if err := nodes[i].Stop(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Cleanup kills all the nodes, and removes the directory of the cluster if it's temporary. The
+// logs of the nodes are kept in a directory given by ClusterConfig.Dir.
+func (c *Cluster) Cleanup() {
+	for _, n := range c.Nodes() {
+		_ = n.Kill()
+	}
+	if c.tmpDir {
+		_ = os.RemoveAll(c.conf.Dir)
+	}
+}
+
+// PartitionGroup pauses all the Alphas of the group, which the rest of the cluster and the
+// clients can't reach anymore, until HealGroup is called.
+func (c *Cluster) PartitionGroup(gid uint32) error {
+	for _, n := range c.Group(gid) {
+		if err := n.Pause(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// HealGroup resumes the Alphas of the group paused by PartitionGroup.
+func (c *Cluster) HealGroup(gid uint32) error {
+	for _, n := range c.Group(gid) {
+		if err := n.Resume(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Client returns a client connected to the running Alphas, and a function closing it.
+func (c *Cluster) Client() (*dgo.Dgraph, func(), error) {
+	var conns []*grpc.ClientConn
+	closeAll := func() {
+		for _, conn := range conns {
+			conn.Close()
+		}
+	}
+	var clients []api.DgraphClient
+	for _, n := range c.Alphas {
+		if !n.Running() {
+			continue
+		}
+		conn, err := grpc.Dial(n.GrpcAddr(), grpc.WithInsecure())
+		if err != nil {
+			closeAll()
+			return nil, nil, err
+		}
+		conns = append(conns, conn)
+		clients = append(clients, api.NewDgraphClient(conn))
+	}
+	if len(clients) == 0 {
+		return nil, nil, errors.New("no Alpha is running")
+	}
+	return dgo.NewDgraphClient(clients...), closeAll, nil
+}
+
+// AssertQuery runs the query with a best effort read-only transaction, and checks that its
+// result is the JSON want.
+func (c *Cluster) AssertQuery(t *testing.T, query, want string) {
+	dg, closeFn, err := c.Client()
+	require.NoError(t, err)
+	defer closeFn()
+	ctx, cancel := context.WithTimeout(context.Background(), c.conf.StartTimeout)
+	defer cancel()
+	resp, err := dg.NewReadOnlyTxn().BestEffort().Query(ctx, query)
+	require.NoError(t, err)
+	require.JSONEq(t, want, string(resp.Json))
+}
@@ -0,0 +1,64 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dgraphtest
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCluster(t *testing.T) {
+	// The nodes aren't started, so any existing file will do as the binary.
+	c, err := NewCluster(ClusterConfig{
+		Binary:     os.Args[0],
+		Zeros:      3,
+		Groups:     2,
+		Replicas:   3,
+		AlphaFlags: map[string]string{"limit": "mutations=strict;"},
+	})
+	require.NoError(t, err)
+	defer c.Cleanup()
+
+	require.Len(t, c.Zeros, 3)
+	require.Len(t, c.Alphas, 6)
+	require.Len(t, c.Group(1), 3)
+	require.Len(t, c.Group(2), 3)
+
+	ports := make(map[int]string)
+	for _, n := range c.Nodes() {
+		for _, port := range n.ports() {
+			require.NotContains(t, ports, port, "port of %s is used by %s", n.Name, ports[port])
+			ports[port] = n.Name
+		}
+	}
+
+	require.Contains(t, c.Zeros[1].args, "--peer="+c.Zeros[0].GrpcAddr())
+	alpha := c.Group(2)[0]
+	require.Equal(t, "alpha4", alpha.Name)
+	require.Equal(t, "alpha", alpha.args[0])
+	require.Contains(t, alpha.args, "--raft=idx=4; group=2;")
+	require.Contains(t, alpha.args, "--limit=mutations=strict;")
+}
+
+func TestCommandLine(t *testing.T) {
+	args := commandLine("zero",
+		map[string]string{"wal": "zw", "replicas": "1"},
+		map[string]string{"replicas": "3"})
+	require.Equal(t, []string{"zero", "--replicas=3", "--wal=zw"}, args)
+}
@@ -0,0 +1,248 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dgraphtest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Node is a Zero or an Alpha of a cluster, run as a dgraph process.
+type Node struct {
+	// Name is the name of the node, e.g. zero1 or alpha2. Its data is stored in the directory of
+	// the cluster having this name, and its output is written to the file Name.log in it.
+	Name string
+	// Group is the group of an Alpha, and 0 for a Zero.
+	Group uint32
+	// Offset is the port offset of the node.
+	Offset int
+
+	binary  string
+	dir     string
+	args    []string
+	timeout time.Duration
+
+	mu     sync.Mutex
+	cmd    *exec.Cmd
+	done   chan struct{}
+	paused bool
+}
+
+// IsZero returns whether the node is a Zero.
+func (n *Node) IsZero() bool {
+	return n.Group == 0
+}
+
+// GrpcAddr returns the address of the public gRPC port of the node.
+func (n *Node) GrpcAddr() string {
+	if n.IsZero() {
+		return fmt.Sprintf("localhost:%d", 5080+n.Offset)
+	}
+	return fmt.Sprintf("localhost:%d", 9080+n.Offset)
+}
+
+// HttpAddr returns the address of the HTTP port of the node.
+func (n *Node) HttpAddr() string {
+	if n.IsZero() {
+		return fmt.Sprintf("localhost:%d", 6080+n.Offset)
+	}
+	return fmt.Sprintf("localhost:%d", 8080+n.Offset)
+}
+
+// raftAddr returns the address the node uses to talk to the other nodes.
+func (n *Node) raftAddr() string {
+	if n.IsZero() {
+		return n.GrpcAddr()
+	}
+	return fmt.Sprintf("localhost:%d", 7080+n.Offset)
+}
+
+// ports returns the ports the node listens on.
+func (n *Node) ports() []int {
+	if n.IsZero() {
+		return []int{5080 + n.Offset, 6080 + n.Offset}
+	}
+	return []int{7080 + n.Offset, 8080 + n.Offset, 9080 + n.Offset}
+}
+
+// Running returns whether the process of the node is running, even if paused.
+func (n *Node) Running() bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.runningLocked()
+}
+
+func (n *Node) runningLocked() bool {
+	if n.cmd == nil {
+		return false
+	}
+	select {
+	case <-n.done:
+		return false
+	default:
+		return true
+	}
+}
+
+// Start starts the process of the node, with the data it had when it was stopped. It doesn't
+// wait for the node to be ready, see WaitHealthy.
+func (n *Node) Start() error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.runningLocked() {
+		return errors.Errorf("%s is already running", n.Name)
+	}
+	if err := os.MkdirAll(n.dir, 0700); err != nil {
+		return err
+	}
+	log, err := os.OpenFile(filepath.Join(filepath.Dir(n.dir), n.Name+".log"),
+		os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command(n.binary, n.args...)
+	cmd.Dir = n.dir
+	cmd.Stdout = log
+	cmd.Stderr = log
+	if err := cmd.Start(); err != nil {
+		log.Close()
+		return errors.Wrapf(err, "while starting %s", n.Name)
+	}
+	done := make(chan struct{})
+	go func() {
+		_ = cmd.Wait()
+		log.Close()
+		close(done)
+	}()
+	n.cmd, n.done, n.paused = cmd, done, false
+	return nil
+}
+
+// Kill kills the process of the node, like a crash would.
+func (n *Node) Kill() error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if !n.runningLocked() {
+		return nil
+	}
+	if err := n.cmd.Process.Kill(); err != nil {
+		return errors.Wrapf(err, "while killing %s", n.Name)
+	}
+	<-n.done
+	return nil
+}
+
+// Stop stops the process of the node gracefully. The node is killed if it doesn't stop in time.
+func (n *Node) Stop() error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if !n.runningLocked() {
+		return nil
+	}
+	if n.paused {
+		if err := resumeProcess(n.cmd.Process); err != nil {
+			return err
+		}
+		n.paused = false
+	}
+	if err := interruptProcess(n.cmd.Process); err != nil {
+		return errors.Wrapf(err, "while stopping %s", n.Name)
+	}
+	select {
+	case <-n.done:
+		return nil
+	case <-time.After(n.timeout):
+	}
+	if err := n.cmd.Process.Kill(); err != nil {
+		return errors.Wrapf(err, "while killing %s", n.Name)
+	}
+	<-n.done
+	return nil
+}
+
+// Restart stops the node gracefully and starts it again.
+func (n *Node) Restart() error {
+	if err := n.Stop(); err != nil {
+		return err
+	}
+	return n.Start()
+}
+
+// Pause freezes the process of the node, which stops answering the other nodes and the clients
+// as if it was partitioned from the network, until Resume is called.
+func (n *Node) Pause() error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if !n.runningLocked() {
+		return errors.Errorf("%s isn't running", n.Name)
+	}
+	if n.paused {
+		return nil
+	}
+	if err := pauseProcess(n.cmd.Process); err != nil {
+		return errors.Wrapf(err, "while pausing %s", n.Name)
+	}
+	n.paused = true
+	return nil
+}
+
+// Resume resumes the process of the node paused by Pause.
+func (n *Node) Resume() error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if !n.runningLocked() || !n.paused {
+		return nil
+	}
+	if err := resumeProcess(n.cmd.Process); err != nil {
+		return errors.Wrapf(err, "while resuming %s", n.Name)
+	}
+	n.paused = false
+	return nil
+}
+
+// WaitHealthy waits until the node answers its health check.
+func (n *Node) WaitHealthy(ctx context.Context) error {
+	url := "http://" + n.HttpAddr() + "/health"
+	for {
+		if !n.Running() {
+			return errors.Errorf("%s isn't running, see %s.log", n.Name, n.Name)
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+		if resp, err := http.DefaultClient.Do(req); err == nil {
+			resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				return nil
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return errors.Wrapf(ctx.Err(), "while waiting for %s to be healthy", n.Name)
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
@@ -0,0 +1,141 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dgraphtest
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// snapshotDir returns the directory of the snapshot name of the cluster.
+func (c *Cluster) snapshotDir(name string) string {
+	return filepath.Join(c.conf.Dir, "snapshots", name)
+}
+
+// Snapshot saves the data of all the nodes under the given name, see Restore. The nodes are
+// stopped gracefully while their data is copied, so that the snapshot doesn't depend on the
+// writes in flight, and the running nodes are started again.
+func (c *Cluster) Snapshot(name string) error {
+	dir := c.snapshotDir(name)
+	if _, err := os.Stat(dir); err == nil {
+		return errors.Errorf("snapshot %s already exists", name)
+	}
+	running, err := c.stopRunning()
+	if err != nil {
+		return err
+	}
+	for _, n := range c.Nodes() {
+		if err := copyDir(n.dir, filepath.Join(dir, n.Name)); err != nil {
+			return errors.Wrapf(err, "while saving the data of %s", n.Name)
+		}
+	}
+	return c.startNodes(running)
+}
+
+// Restore replaces the data of all the nodes with the data saved by Snapshot, and starts the
+// nodes which were running.
+func (c *Cluster) Restore(name string) error {
+	dir := c.snapshotDir(name)
+	if _, err := os.Stat(dir); err != nil {
+		return errors.Wrapf(err, "snapshot %s isn't found", name)
+	}
+	running, err := c.stopRunning()
+	if err != nil {
+		return err
+	}
+	for _, n := range c.Nodes() {
+		if err := os.RemoveAll(n.dir); err != nil {
+			return err
+		}
+		if err := copyDir(filepath.Join(dir, n.Name), n.dir); err != nil {
+			return errors.Wrapf(err, "while restoring the data of %s", n.Name)
+		}
+	}
+	return c.startNodes(running)
+}
+
+// stopRunning stops the running nodes gracefully, and returns them.
+func (c *Cluster) stopRunning() ([]*Node, error) {
+	var running []*Node
+	for _, n := range c.Nodes() {
+		if n.Running() {
+			running = append(running, n)
+		}
+	}
+	return running, c.Stop()
+}
+
+func (c *Cluster) startNodes(nodes []*Node) error {
+	if len(nodes) == 0 {
+		return nil
+	}
+	for _, n := range nodes {
+		if err := n.Start(); err != nil {
+			return err
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), c.conf.StartTimeout)
+	defer cancel()
+	for _, n := range nodes {
+		if err := n.WaitHealthy(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyDir copies the directory src, which may not exist, to dst.
+func copyDir(src, dst string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if os.IsNotExist(err) && path == src {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if info.IsDir() {
+			return os.MkdirAll(target, 0700)
+		}
+		return copyFile(path, target)
+	})
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
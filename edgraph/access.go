@@ -20,6 +20,7 @@ package edgraph
 
 import (
 	"context"
+	"time"
 
 	"github.com/dgraph-io/dgo/v210/protos/api"
 	"github.com/dgraph-io/dgraph/gql"
@@ -41,6 +42,36 @@ func (s *Server) Login(ctx context.Context,
 	return &api.Response{}, x.ErrNotSupported
 }
 
+// OidcLogin handles OIDC login requests from clients. This version rejects all requests
+// since ACL is only supported in the enterprise version.
+func (s *Server) OidcLogin(ctx context.Context, idToken string,
+	namespace uint64) (*api.Response, error) {
+	if err := x.HealthCheck(); err != nil {
+		return nil, err
+	}
+
+	glog.Warningf("OIDC login failed: %s", x.ErrNotSupported)
+	return &api.Response{}, x.ErrNotSupported
+}
+
+// Session is a login session of an ACL user.
+type Session struct {
+	SessionId string
+	UserId    string
+	Expiry    time.Time
+	Revoked   bool
+}
+
+// ListSessions returns an error since ACL is only supported in the enterprise version.
+func ListSessions(ctx context.Context, userId string) ([]Session, error) {
+	return nil, x.ErrNotSupported
+}
+
+// RevokeSessions returns an error since ACL is only supported in the enterprise version.
+func RevokeSessions(ctx context.Context, sessionId, userId string) (int, error) {
+	return 0, x.ErrNotSupported
+}
+
 // ResetAcl is an empty method since ACL is only supported in the enterprise version.
 func ResetAcl(closer *z.Closer) {
 	// do nothing
@@ -65,6 +96,10 @@ func authorizeMutation(ctx context.Context, gmu *gql.Mutation) error {
 	return nil
 }
 
+func applyPasswordPolicy(qc *queryContext, enforce bool) error {
+	return nil
+}
+
 func authorizeQuery(ctx context.Context, parsedReq *gql.Result, graphql bool) error {
 	// always allow access
 	return nil
@@ -36,6 +36,7 @@ import (
 	"github.com/dgraph-io/dgraph/x"
 	jwt "github.com/dgrijalva/jwt-go"
 	"github.com/golang/glog"
+	"github.com/google/uuid"
 	otrace "go.opencensus.io/trace"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -83,9 +84,76 @@ func (s *Server) Login(ctx context.Context,
 		return nil, x.ErrorInvalidLogin
 	}
 	glog.Infof("%s logged in successfully in namespace %#x", user.UserID, user.Namespace)
+	return loginResponse(ctx, user, addr)
+}
+
+// OidcLogin handles login requests that carry an ID token issued by the OpenID Connect provider
+// configured through the --oidc flag. The groups of the user are taken from the ID token and
+// mapped to Dgraph groups, so the user doesn't need to exist in Dgraph.
+func (s *Server) OidcLogin(ctx context.Context, idToken string,
+	namespace uint64) (*api.Response, error) {
+
+	if !shouldAllowAcls(namespace) {
+		return nil, errors.New("operation is not allowed in cloud mode")
+	}
+
+	if err := x.HealthCheck(); err != nil {
+		return nil, err
+	}
+
+	if !worker.EnterpriseEnabled() {
+		return nil, errors.New("Enterprise features are disabled. You can enable them by " +
+			"supplying the appropriate license file to Dgraph Zero using the HTTP endpoint.")
+	}
+
+	verifier := getOidcVerifier()
+	if verifier == nil {
+		return nil, errors.New("OIDC login is not configured. It can be enabled by setting " +
+			"the issuer in the --oidc flag.")
+	}
+
+	ctx, span := otrace.StartSpan(ctx, "server.OidcLogin")
+	defer span.End()
+
+	var addr string
+	if ipAddr, err := hasAdminAuth(ctx, "Login"); err != nil {
+		return nil, err
+	} else {
+		addr = ipAddr.String()
+		span.Annotate([]otrace.Attribute{
+			otrace.StringAttribute("client_ip", addr),
+		}, "client ip for login")
+	}
+
+	user, err := verifier.verify(idToken)
+	if err != nil {
+		glog.Errorf("OIDC authentication from address %s failed: %v", addr, err)
+		return nil, x.ErrorInvalidLogin
+	}
+	user.Namespace = namespace
+	user.Oidc = true
+	glog.Infof("%s logged in successfully through OIDC in namespace %#x", user.UserID,
+		user.Namespace)
+	return loginResponse(ctx, user, addr)
+}
+
+// loginResponse creates the access and refresh JWTs for an authenticated user. A new session is
+// recorded unless the user was authenticated through a refresh token of an existing session.
+func loginResponse(ctx context.Context, user *acl.User, addr string) (*api.Response, error) {
+	if user.SessionId == "" {
+		user.SessionId = uuid.New().String()
+		sctx := x.AttachNamespace(ctx, user.Namespace)
+		expiry := refreshJwtExpiry(user)
+		if err := recordSession(sctx, user.SessionId, user.UserID, expiry); err != nil {
+			errMsg := fmt.Sprintf("unable to record session (userid=%s,addr=%s):%v",
+				user.UserID, addr, err)
+			glog.Errorf(errMsg)
+			return nil, errors.Errorf(errMsg)
+		}
+	}
 
 	resp := &api.Response{}
-	accessJwt, err := getAccessJwt(user.UserID, user.Groups, user.Namespace)
+	accessJwt, err := getAccessJwt(user.UserID, user.Groups, user.Namespace, user.SessionId)
 	if err != nil {
 		errMsg := fmt.Sprintf("unable to get access jwt (userid=%s,addr=%s):%v",
 			user.UserID, addr, err)
@@ -93,7 +161,13 @@ func (s *Server) Login(ctx context.Context,
 		return nil, errors.Errorf(errMsg)
 	}
 
-	refreshJwt, err := getRefreshJwt(user.UserID, user.Namespace)
+	var refreshJwt string
+	if user.Oidc {
+		refreshJwt, err = getOidcRefreshJwt(user.UserID, user.Groups, user.Namespace,
+			user.SessionId, refreshJwtExpiry(user))
+	} else {
+		refreshJwt, err = getRefreshJwt(user.UserID, user.Namespace, user.SessionId)
+	}
 	if err != nil {
 		errMsg := fmt.Sprintf("unable to get refresh jwt (userid=%s,addr=%s):%v",
 			user.UserID, addr, err)
@@ -135,6 +209,20 @@ func (s *Server) authenticateLogin(ctx context.Context, request *api.LoginReques
 		}
 
 		userId := userData.userId
+		if userData.oidc {
+			// OIDC users don't exist in Dgraph, their groups were carried over from the ID token
+			// into the refresh token.
+			groups := make([]acl.Group, 0, len(userData.groupIds))
+			for _, g := range userData.groupIds {
+				groups = append(groups, acl.Group{GroupID: g})
+			}
+			glog.Infof("Authenticated OIDC user %s through refresh token", userId)
+			// The refreshed token expires along with this one, so that the groups aren't kept
+			// past the expiry of the ID token they were taken from.
+			return &acl.User{UserID: userId, Groups: groups, Namespace: userData.namespace,
+				Oidc: true, OidcExpiry: userData.expiry, SessionId: userData.sessionId}, nil
+		}
+
 		ctx = x.AttachNamespace(ctx, userData.namespace)
 		user, err = authorizeUser(ctx, userId, "")
 		if err != nil {
@@ -146,6 +234,7 @@ func (s *Server) authenticateLogin(ctx context.Context, request *api.LoginReques
 		}
 
 		user.Namespace = userData.namespace
+		user.SessionId = userData.sessionId
 		glog.Infof("Authenticated user %s through refresh token", userId)
 		return user, nil
 	}
@@ -168,6 +257,11 @@ func (s *Server) authenticateLogin(ctx context.Context, request *api.LoginReques
 	if !user.PasswordMatch {
 		return nil, x.ErrorInvalidLogin
 	}
+	if getPasswordPolicy().isExpired(user) {
+		return nil, errors.Errorf("the password of user %s has expired, it must be reset by a "+
+			"guardian", request.Userid)
+	}
+	upgradePasswordHash(ctx, user, request.Password)
 	user.Namespace = request.Namespace
 	return user, nil
 }
@@ -176,6 +270,13 @@ type userData struct {
 	namespace uint64
 	userId    string
 	groupIds  []string
+	// oidc is set if the user was authenticated through the OIDC provider.
+	oidc bool
+	// sessionId is the login session the token belongs to. It is empty for tokens issued before
+	// sessions were introduced.
+	sessionId string
+	// expiry is the expiry of the token.
+	expiry time.Time
 }
 
 // validateToken verifies the signature and expiration of the jwt, and if validation passes,
@@ -217,7 +318,14 @@ func validateToken(jwtStr string) (*userData, error) {
 			groupIds = append(groupIds, groupId)
 		}
 	}
-	return &userData{namespace: uint64(namespace), userId: userId, groupIds: groupIds}, nil
+	oidc, _ := claims["oidc"].(bool)
+	sessionId, _ := claims["sid"].(string)
+	if sessionId != "" && revokedSessions.isRevoked(sessionId) {
+		return nil, errors.Errorf("Token has been revoked")
+	}
+	exp, _ := claims["exp"].(float64)
+	return &userData{namespace: uint64(namespace), userId: userId, groupIds: groupIds,
+		oidc: oidc, sessionId: sessionId, expiry: time.Unix(int64(exp), 0)}, nil
 }
 
 // validateLoginRequest validates that the login request has either the refresh token or the
@@ -241,13 +349,15 @@ func validateLoginRequest(request *api.LoginRequest) error {
 	return nil
 }
 
-// getAccessJwt constructs an access jwt with the given user id, groupIds, namespace
+// getAccessJwt constructs an access jwt with the given user id, groupIds, namespace, session id
 // and expiration TTL specified by worker.Config.AccessJwtTtl
-func getAccessJwt(userId string, groups []acl.Group, namespace uint64) (string, error) {
+func getAccessJwt(userId string, groups []acl.Group, namespace uint64,
+	sessionId string) (string, error) {
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
 		"userid":    userId,
 		"groups":    acl.GetGroupIDs(groups),
 		"namespace": namespace,
+		"sid":       sessionId,
 		// set the jwt exp according to the ttl
 		"exp": time.Now().Add(worker.Config.AccessJwtTtl).Unix(),
 	})
@@ -259,12 +369,13 @@ func getAccessJwt(userId string, groups []acl.Group, namespace uint64) (string,
 	return jwtString, nil
 }
 
-// getRefreshJwt constructs a refresh jwt with the given user id, namespace and expiration ttl
-// specified by worker.Config.RefreshJwtTtl
-func getRefreshJwt(userId string, namespace uint64) (string, error) {
+// getRefreshJwt constructs a refresh jwt with the given user id, namespace, session id and
+// expiration ttl specified by worker.Config.RefreshJwtTtl
+func getRefreshJwt(userId string, namespace uint64, sessionId string) (string, error) {
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
 		"userid":    userId,
 		"namespace": namespace,
+		"sid":       sessionId,
 		"exp":       time.Now().Add(worker.Config.RefreshJwtTtl).Unix(),
 	})
 
@@ -275,12 +386,46 @@ func getRefreshJwt(userId string, namespace uint64) (string, error) {
 	return jwtString, nil
 }
 
+// refreshJwtExpiry returns the expiry of the refresh jwt of the user. The refresh jwt of a user
+// authenticated through OIDC expires with the ID token, as the groups taken from it can't be
+// checked again without a new one.
+func refreshJwtExpiry(user *acl.User) time.Time {
+	expiry := time.Now().Add(worker.Config.RefreshJwtTtl)
+	if user.Oidc && user.OidcExpiry.Before(expiry) {
+		return user.OidcExpiry
+	}
+	return expiry
+}
+
+// getOidcRefreshJwt constructs a refresh jwt for a user authenticated through OIDC. As such users
+// are not stored in Dgraph, the groups mapped from the ID token are kept in the refresh jwt, until
+// the given expiry.
+func getOidcRefreshJwt(userId string, groups []acl.Group, namespace uint64, sessionId string,
+	expiry time.Time) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"userid":    userId,
+		"groups":    acl.GetGroupIDs(groups),
+		"namespace": namespace,
+		"sid":       sessionId,
+		"oidc":      true,
+		"exp":       expiry.Unix(),
+	})
+
+	jwtString, err := token.SignedString([]byte(worker.Config.HmacSecret))
+	if err != nil {
+		return "", errors.Errorf("unable to encode jwt to string: %v", err)
+	}
+	return jwtString, nil
+}
+
 const queryUser = `
     query search($userid: string, $password: string){
       user(func: eq(dgraph.xid, $userid)) @filter(type(dgraph.type.User)) {
 	    uid
         dgraph.xid
         password_match: checkpwd(dgraph.password, $password)
+        dgraph.user.password_updated
+        dgraph.user.password_scheme
         dgraph.user.group {
           uid
           dgraph.xid
@@ -361,6 +506,14 @@ func RefreshAcls(closer *z.Closer) {
 		return nil
 	}
 
+	// Load the sessions revoked before this alpha started, later revocations arrive through the
+	// subscription below.
+	for ns := range schema.State().Namespaces() {
+		if err := retrieveRevokedSessions(closer.Ctx(), ns, 0); err != nil {
+			glog.Errorf("Error while retrieving revoked sessions: %v", err)
+		}
+	}
+
 	closer.AddRunning(1)
 	go worker.SubscribeForUpdates(aclPrefixes, x.IgnoreBytes, func(kvs *bpb.KVList) {
 		if kvs == nil || len(kvs.Kv) == 0 {
@@ -373,7 +526,13 @@ func RefreshAcls(closer *z.Closer) {
 		}
 		glog.V(3).Infof("Got ACL update via subscription for attr: %s", pk.Attr)
 
-		ns, _ := x.ParseNamespaceAttr(pk.Attr)
+		ns, attr := x.ParseNamespaceAttr(pk.Attr)
+		if attr == "dgraph.session.revoked" {
+			if err := retrieveRevokedSessions(closer.Ctx(), ns, kv.GetVersion()); err != nil {
+				glog.Errorf("Error while retrieving revoked sessions: %v", err)
+			}
+			return
+		}
 		if err := retrieveAcls(ns, kv.GetVersion()); err != nil {
 			glog.Errorf("Error while retrieving acls: %v", err)
 		}
@@ -404,6 +563,7 @@ var aclPrefixes = [][]byte{
 	x.PredicatePrefix(x.GalaxyAttr("dgraph.user.group")),
 	x.PredicatePrefix(x.GalaxyAttr("dgraph.type.Group")),
 	x.PredicatePrefix(x.GalaxyAttr("dgraph.xid")),
+	x.PredicatePrefix(x.GalaxyAttr("dgraph.session.revoked")),
 }
 
 // clears the aclCachePtr and upserts the Groot account.
@@ -537,6 +697,8 @@ func upsertGroot(ctx context.Context, passwd string) error {
 		Predicate: "dgraph.user.group",
 		ObjectId:  "uid(guid)",
 	})
+	// The default password of groot must be usable whatever the password policy is.
+	ctx = context.WithValue(ctx, skipPasswordPolicy, true)
 	req := &Request{
 		req: &api.Request{
 			CommitNow: true,
@@ -639,10 +801,16 @@ func authorizePreds(ctx context.Context, userData *userData, preds []string,
 	}
 
 	aclCachePtr.RLock()
-	allowedPreds := make([]string, 0, len(aclCachePtr.userPredPerms[userId]))
+	userPerms := aclCachePtr.userPredPerms[userId]
+	if userData.oidc {
+		// OIDC users are not members of any group stored in Dgraph, so derive their
+		// permissions from the groups in the JWT.
+		userPerms = aclCachePtr.groupPredPerms(groupIds)
+	}
+	allowedPreds := make([]string, 0, len(userPerms))
 	// User can have multiple permission for same predicate, add predicate
 	// only if the acl.Op is covered in the set of permissions for the user
-	for predicate, perm := range aclCachePtr.userPredPerms[userId] {
+	for predicate, perm := range userPerms {
 		if (perm & aclOp.Code) > 0 {
 			allowedPreds = append(allowedPreds, predicate)
 		}
@@ -862,9 +1030,16 @@ func parsePredsFromQuery(gqls []*gql.GraphQuery) predsAndvars {
 		if len(gq.Var) > 0 {
 			varsMap[gq.Var] = gq.Attr
 		}
-		if len(gq.Attr) > 0 && gq.Attr != "uid" && gq.Attr != "expand" && gq.Attr != "val" {
+		switch {
+		case gq.Join != nil:
+			// The predicates compared by join() are read instead of its attribute.
+			for _, attr := range []string{gq.Join.Attr, gq.Join.VarAttr} {
+				if attr != "uid" {
+					predsMap[attr] = struct{}{}
+				}
+			}
+		case len(gq.Attr) > 0 && gq.Attr != "uid" && gq.Attr != "expand" && gq.Attr != "val":
 			predsMap[gq.Attr] = struct{}{}
-
 		}
 		for _, ord := range gq.Order {
 			predsMap[ord.Attr] = struct{}{}
@@ -1320,7 +1495,13 @@ L:
 				continue
 			}
 		}
-		if len(gq.Attr) > 0 {
+		if gq.Join != nil {
+			_, attrBlocked := blockedPreds[gq.Join.Attr]
+			_, varAttrBlocked := blockedPreds[gq.Join.VarAttr]
+			if attrBlocked || varAttrBlocked {
+				continue
+			}
+		} else if len(gq.Attr) > 0 {
 			if _, ok := blockedPreds[gq.Attr]; ok {
 				continue
 			}
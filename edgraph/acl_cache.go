@@ -107,6 +107,20 @@ func (cache *aclCache) update(ns uint64, groups []acl.Group) {
 	aclCachePtr.userPredPerms = userPredPerms
 }
 
+// groupPredPerms returns the permissions on each predicate that the given groups have. A predicate
+// is mapped to the OR of the permissions of all the groups. The caller must hold the read lock.
+func (cache *aclCache) groupPredPerms(groups []string) map[string]int32 {
+	perms := make(map[string]int32)
+	for pred, groupPerms := range cache.predPerms {
+		for _, group := range groups {
+			if perm, found := groupPerms[group]; found {
+				perms[pred] |= perm
+			}
+		}
+	}
+	return perms
+}
+
 func (cache *aclCache) authorizePredicate(groups []string, predicate string,
 	operation *acl.Operation) error {
 	ns, attr := x.ParseNamespaceAttr(predicate)
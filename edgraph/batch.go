@@ -0,0 +1,102 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package edgraph
+
+import (
+	"context"
+	"sync"
+
+	"github.com/dgraph-io/dgo/v210/protos/api"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/pkg/errors"
+)
+
+const (
+	// defaultBatchConcurrency is the number of requests of a batch applied concurrently, when the
+	// batch doesn't set it.
+	defaultBatchConcurrency = 16
+	// maxBatchConcurrency is the maximum number of requests of a batch applied concurrently.
+	maxBatchConcurrency = 256
+)
+
+// batchConcurrency returns the number of requests of a batch applied concurrently.
+func batchConcurrency(req *pb.BatchMutationRequest) int {
+	switch c := int(req.GetConcurrency()); {
+	case c == 0:
+		return defaultBatchConcurrency
+	case c > maxBatchConcurrency:
+		return maxBatchConcurrency
+	default:
+		return c
+	}
+}
+
+// validateBatchRequest checks that a request of a batch is a mutation which can be applied in a
+// transaction of its own.
+func validateBatchRequest(req *api.Request) error {
+	switch {
+	case req == nil || len(req.GetMutations()) == 0:
+		return errors.New("The request of a batch must have mutations")
+	case req.GetStartTs() != 0:
+		return errors.New("The request of a batch can't be part of a transaction")
+	}
+	return nil
+}
+
+// BatchMutate applies each request of the batch in a transaction of its own, which is committed
+// right away. The requests are independent: the failure of a request is reported in its result
+// and doesn't affect the others. The results are in the order of the requests.
+func (s *Server) BatchMutate(ctx context.Context,
+	req *pb.BatchMutationRequest) (*pb.BatchMutationResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	results := make([]*pb.BatchMutationResult, len(req.GetRequests()))
+	apply := func(i int, r *api.Request) {
+		res := &pb.BatchMutationResult{}
+		results[i] = res
+		if err := validateBatchRequest(r); err != nil {
+			res.Error = err.Error()
+			return
+		}
+		r.CommitNow = true
+		resp, err := s.Query(ctx, r)
+		if err != nil {
+			res.Error = err.Error()
+			return
+		}
+		res.Success = true
+		res.Response = resp
+	}
+
+	var wg sync.WaitGroup
+	limiter := make(chan struct{}, batchConcurrency(req))
+	for i, r := range req.GetRequests() {
+		limiter <- struct{}{}
+		wg.Add(1)
+		go func(i int, r *api.Request) {
+			defer func() {
+				<-limiter
+				wg.Done()
+			}()
+			apply(i, r)
+		}(i, r)
+	}
+	wg.Wait()
+	return &pb.BatchMutationResponse{Results: results}, nil
+}
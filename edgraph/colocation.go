@@ -0,0 +1,203 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package edgraph
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"time"
+
+	bpb "github.com/dgraph-io/badger/v3/pb"
+	"github.com/dgraph-io/dgo/v210/protos/api"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/schema"
+	"github.com/dgraph-io/dgraph/worker"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/dgraph-io/ristretto/z"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+)
+
+const queryColocations = `
+	{
+		hints(func: has(dgraph.colocation)) {
+			uid
+			dgraph.colocation
+		}
+	}`
+
+// getColocations reads the co-location hints stored in the galaxy namespace. It returns nil if
+// none have been stored yet.
+func getColocations(ctx context.Context) (map[string]string, error) {
+	req := &Request{
+		req: &api.Request{
+			Query:    queryColocations,
+			ReadOnly: true,
+		},
+		doAuth: NoAuthorize,
+	}
+	resp, err := (&Server{}).doQuery(x.AttachNamespace(ctx, x.GalaxyNamespace), req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while reading the co-location hints")
+	}
+	var result struct {
+		Hints []struct {
+			Uid   string `json:"uid"`
+			Hints string `json:"dgraph.colocation"`
+		} `json:"hints"`
+	}
+	if err := json.Unmarshal(resp.GetJson(), &result); err != nil {
+		return nil, errors.Wrapf(err, "while unmarshalling the co-location hints")
+	}
+
+	switch len(result.Hints) {
+	case 0:
+		return nil, nil
+	case 1:
+		return worker.ParseColocations([]byte(result.Hints[0].Hints))
+	}
+	return nil, errors.Errorf("found multiple nodes for the co-location hints")
+}
+
+// updateColocations applies the given change to the stored co-location hints, and stores them if
+// they changed.
+func updateColocations(ctx context.Context, update func(hints map[string]string)) error {
+	hints, err := getColocations(ctx)
+	if err != nil {
+		return err
+	}
+	if hints == nil {
+		hints = make(map[string]string)
+	}
+	old := make(map[string]string, len(hints))
+	for pred, sameAs := range hints {
+		old[pred] = sameAs
+	}
+	update(hints)
+	if reflect.DeepEqual(old, hints) {
+		return nil
+	}
+	return storeColocations(ctx, hints)
+}
+
+// storeColocations replaces the stored co-location hints. The new hints are used by this Alpha
+// right away, and reach the other Alphas through their subscription.
+func storeColocations(ctx context.Context, hints map[string]string) error {
+	data, err := worker.MarshalColocations(hints)
+	if err != nil {
+		return errors.Wrapf(err, "while marshalling the co-location hints")
+	}
+
+	// An empty variable in uid() creates a new node, so this works whether the hints were stored
+	// before or not.
+	req := &Request{
+		req: &api.Request{
+			Query: `{
+				c as var(func: has(dgraph.colocation))
+			}`,
+			Mutations: []*api.Mutation{{
+				Set: []*api.NQuad{{
+					Subject:     "uid(c)",
+					Predicate:   worker.ColocationPred,
+					ObjectValue: &api.Value{Val: &api.Value_StrVal{StrVal: string(data)}},
+				}},
+			}},
+			CommitNow: true,
+		},
+		doAuth: NoAuthorize,
+	}
+	// The co-location predicate is reserved, so it can only be mutated internally.
+	ctx = context.WithValue(ctx, IsGraphql, true)
+	if _, err := (&Server{}).doQuery(x.AttachNamespace(ctx, x.GalaxyNamespace), req); err != nil {
+		return errors.Wrapf(err, "while storing the co-location hints")
+	}
+	worker.SetColocations(hints)
+	return nil
+}
+
+// updateColocationsForSchema sets the co-location hints of the predicates in the schema update.
+// The hint of a predicate that is updated without a @shard directive is removed.
+func updateColocationsForSchema(ctx context.Context, result *schema.ParsedSchema) error {
+	return updateColocations(ctx, func(hints map[string]string) {
+		for _, su := range result.Preds {
+			if sameAs, ok := result.Colocations[su.Predicate]; ok {
+				hints[su.Predicate] = sameAs
+			} else {
+				delete(hints, su.Predicate)
+			}
+		}
+	})
+}
+
+// RefreshColocations loads the stored co-location hints on this Alpha, and keeps them up to date
+// whenever they are updated by any of the Alphas.
+func RefreshColocations(closer *z.Closer) {
+	defer func() {
+		glog.Infoln("RefreshColocations closed")
+		closer.Done()
+	}()
+
+	for closer.Ctx().Err() == nil {
+		ctx, cancel := context.WithTimeout(closer.Ctx(), time.Minute)
+		hints, err := getColocations(ctx)
+		cancel()
+		if err != nil {
+			glog.Infof("Unable to read the co-location hints. Error: %v", err)
+			time.Sleep(time.Second)
+			continue
+		}
+		if hints == nil {
+			hints = make(map[string]string)
+		}
+		worker.SetColocations(hints)
+		break
+	}
+
+	prefix := x.PredicatePrefix(x.GalaxyAttr(worker.ColocationPred))
+	closer.AddRunning(1)
+	go worker.SubscribeForUpdates([][]byte{prefix}, x.IgnoreBytes, func(kvs *bpb.KVList) {
+		if kvs == nil || len(kvs.Kv) == 0 {
+			return
+		}
+		kv := x.KvWithMaxVersion(kvs, [][]byte{prefix})
+		pk, err := x.Parse(kv.GetKey())
+		if err != nil || !pk.IsData() {
+			return
+		}
+		pl := &pb.PostingList{}
+		if err := pl.Unmarshal(kv.GetValue()); err != nil {
+			glog.Errorf("Unable to unmarshal the posting list for co-location update: %v", err)
+			return
+		}
+		if len(pl.Postings) != 1 {
+			glog.Errorf("Only one posting is expected in the co-location posting list but "+
+				"got %d", len(pl.Postings))
+			return
+		}
+
+		hints, err := worker.ParseColocations(pl.Postings[0].Value)
+		if err != nil {
+			glog.Errorf("Unable to parse the co-location hints: %v", err)
+			return
+		}
+		glog.Infof("Updating co-location hints from subscription.")
+		worker.SetColocations(hints)
+	}, 1, closer)
+
+	<-closer.HasBeenClosed()
+}
@@ -0,0 +1,328 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package edgraph
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/dgo/v210/protos/api"
+	"github.com/dgraph-io/dgraph/gql"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/query"
+	"github.com/dgraph-io/dgraph/worker"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+)
+
+const (
+	// maxIdempotencyKeyLen is the maximum length of an idempotency key.
+	maxIdempotencyKeyLen = 256
+	// idempotencyPurgeInterval is how often the expired idempotency records of a namespace are
+	// removed by an alpha.
+	idempotencyPurgeInterval = time.Minute
+	// idempotencyPurgeBatch is the maximum number of expired records removed at once.
+	idempotencyPurgeBatch = 1000
+)
+
+// idempotencyLocks serializes the requests having the same idempotency key on this alpha, so
+// that a request retried while the first one is still being applied waits for its outcome instead
+// of being aborted. The requests sent to different alphas are serialized by the transactions, see
+// doIdempotentQuery.
+var idempotencyLocks struct {
+	sync.Mutex
+	keys map[string]*idempotencyLock
+}
+
+type idempotencyLock struct {
+	sync.Mutex
+	refs int
+}
+
+// idempotencyPurges holds the last time the expired records of each namespace were removed.
+var idempotencyPurges struct {
+	sync.Mutex
+	purgedAt map[uint64]time.Time
+}
+
+// idempotencyEntry is the idempotency key of a request, whose record is written by the
+// transaction applying the mutations of the request, see idempotencyEdges.
+type idempotencyEntry struct {
+	key         string
+	fingerprint []byte
+}
+
+// lockIdempotencyKey locks the given idempotency key of the namespace, and returns the function
+// unlocking it.
+func lockIdempotencyKey(ns uint64, key string) func() {
+	name := x.NamespaceAttr(ns, key)
+	idempotencyLocks.Lock()
+	if idempotencyLocks.keys == nil {
+		idempotencyLocks.keys = make(map[string]*idempotencyLock)
+	}
+	l, ok := idempotencyLocks.keys[name]
+	if !ok {
+		l = &idempotencyLock{}
+		idempotencyLocks.keys[name] = l
+	}
+	l.refs++
+	idempotencyLocks.Unlock()
+
+	l.Lock()
+	return func() {
+		l.Unlock()
+		idempotencyLocks.Lock()
+		if l.refs--; l.refs == 0 {
+			delete(idempotencyLocks.keys, name)
+		}
+		idempotencyLocks.Unlock()
+	}
+}
+
+// requestFingerprint returns the hash of the query and the mutations of the request, which tells
+// whether a request retried with an idempotency key is the same as the first one.
+func requestFingerprint(req *api.Request) ([]byte, error) {
+	h := sha256.New()
+	h.Write([]byte(req.GetQuery()))
+	names := make([]string, 0, len(req.GetVars()))
+	for name := range req.GetVars() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		h.Write([]byte(name))
+		h.Write([]byte(req.Vars[name]))
+	}
+	for _, mu := range req.GetMutations() {
+		data, err := mu.Marshal()
+		if err != nil {
+			return nil, err
+		}
+		h.Write(data)
+	}
+	return h.Sum(nil), nil
+}
+
+// idempotencyRecord returns the record of the request applied with an idempotency key: the
+// fingerprint of the request followed by its response, without the keys of the transaction.
+func idempotencyRecord(fingerprint []byte, resp *api.Response) ([]byte, error) {
+	data, err := (&api.Response{
+		Json: resp.GetJson(),
+		Txn: &api.TxnContext{
+			StartTs:  resp.GetTxn().GetStartTs(),
+			CommitTs: resp.GetTxn().GetCommitTs(),
+		},
+		Uids: resp.GetUids(),
+	}).Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return append(append([]byte{}, fingerprint...), data...), nil
+}
+
+// recordedResponse returns the response recorded for the request having the given fingerprint.
+func recordedResponse(fingerprint, record []byte) (*api.Response, error) {
+	if len(record) < sha256.Size || !bytes.Equal(record[:sha256.Size], fingerprint) {
+		return nil, errors.New("The idempotency key was already used by another request")
+	}
+	resp := &api.Response{}
+	if err := resp.Unmarshal(record[sha256.Size:]); err != nil {
+		return nil, errors.Wrapf(err, "while reading the record of the idempotency key")
+	}
+	resp.Latency = &api.Latency{}
+	resp.Metrics = &api.Metrics{NumUids: map[string]uint64{}}
+	return resp, nil
+}
+
+// idempotencyEdges returns the edges storing the record of the idempotency key of the request.
+// They're applied by the transaction of the mutations, so the record is committed if and only if
+// the mutations are. The commit ts isn't known yet, so the record only holds the start ts.
+func idempotencyEdges(ctx context.Context, qc *queryContext,
+	resp *api.Response) ([]*pb.DirectedEdge, error) {
+	record, err := idempotencyRecord(qc.idempotency.fingerprint, &api.Response{
+		Json: resp.GetJson(),
+		Txn:  &api.TxnContext{StartTs: qc.req.StartTs},
+		Uids: resp.GetUids(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	nquad := func(pred, val string) *api.NQuad {
+		return &api.NQuad{
+			Subject:     "_:idempotency",
+			Predicate:   pred,
+			ObjectValue: &api.Value{Val: &api.Value_StrVal{StrVal: val}},
+		}
+	}
+	gmuList := []*gql.Mutation{{
+		Set: []*api.NQuad{
+			nquad(x.IdempotencyKeyPred, qc.idempotency.key),
+			nquad(x.IdempotencyRecordPred, base64.StdEncoding.EncodeToString(record)),
+			nquad(x.IdempotencyExpiryPred,
+				time.Now().Add(x.Config.IdempotencyTTL).UTC().Format(time.RFC3339)),
+		},
+	}}
+	newUids, err := query.AssignUids(ctx, gmuList)
+	if err != nil {
+		return nil, err
+	}
+	return query.ToDirectedEdges(gmuList, newUids)
+}
+
+// getIdempotencyRecord returns the unexpired record of the idempotency key as of the given ts,
+// or nil if there's none. The context of the request isn't used as it carries the options of the
+// request, like its preconditions.
+func (s *Server) getIdempotencyRecord(ns uint64, key string, readTs uint64) ([]byte, error) {
+	req := &Request{
+		req: &api.Request{
+			Query: `
+				query q($key: string, $now: string) {
+					records(func: eq(dgraph.idempotency.key, $key))
+						@filter(gt(dgraph.idempotency.expiry, $now)) {
+						dgraph.idempotency.record
+					}
+				}`,
+			Vars: map[string]string{
+				"$key": key,
+				"$now": time.Now().UTC().Format(time.RFC3339),
+			},
+			StartTs:  readTs,
+			ReadOnly: true,
+		},
+		doAuth: NoAuthorize,
+	}
+	resp, err := s.doQuery(x.AttachNamespace(context.Background(), ns), req)
+	if err != nil {
+		return nil, err
+	}
+	var result struct {
+		Records []struct {
+			Record []byte `json:"dgraph.idempotency.record"`
+		} `json:"records"`
+	}
+	if err := json.Unmarshal(resp.GetJson(), &result); err != nil {
+		return nil, err
+	}
+	if len(result.Records) == 0 {
+		return nil, nil
+	}
+	return result.Records[0].Record, nil
+}
+
+// purgeIdempotencyRecords removes the expired idempotency records of the namespace, at most once
+// every idempotencyPurgeInterval.
+func (s *Server) purgeIdempotencyRecords(ns uint64) {
+	idempotencyPurges.Lock()
+	if idempotencyPurges.purgedAt == nil {
+		idempotencyPurges.purgedAt = make(map[uint64]time.Time)
+	}
+	if time.Since(idempotencyPurges.purgedAt[ns]) < idempotencyPurgeInterval {
+		idempotencyPurges.Unlock()
+		return
+	}
+	idempotencyPurges.purgedAt[ns] = time.Now()
+	idempotencyPurges.Unlock()
+
+	del := func(pred string) *api.NQuad {
+		return &api.NQuad{
+			Subject:     "uid(expired)",
+			Predicate:   pred,
+			ObjectValue: &api.Value{Val: &api.Value_DefaultVal{DefaultVal: x.Star}},
+		}
+	}
+	req := &Request{
+		req: &api.Request{
+			Query: `
+				query q($now: string) {
+					expired as var(func: le(dgraph.idempotency.expiry, $now), first: ` +
+				fmt.Sprint(idempotencyPurgeBatch) + `)
+				}`,
+			Vars:      map[string]string{"$now": time.Now().UTC().Format(time.RFC3339)},
+			CommitNow: true,
+			Mutations: []*api.Mutation{{
+				Cond: "@if(gt(len(expired), 0))",
+				Del: []*api.NQuad{
+					del(x.IdempotencyKeyPred),
+					del(x.IdempotencyRecordPred),
+					del(x.IdempotencyExpiryPred),
+				},
+			}},
+		},
+		doAuth: NoAuthorize,
+	}
+	if _, err := s.doQuery(x.AttachNamespace(context.Background(), ns), req); err != nil {
+		glog.Warningf("Unable to remove the expired idempotency records of namespace %#x: %v",
+			ns, err)
+	}
+}
+
+// doIdempotentQuery applies the mutations of the request at most once per idempotency key. The
+// response of the first request committed with the key is recorded for x.Config.IdempotencyTTL,
+// and is returned to the requests retried with the same key instead of applying them again.
+//
+// The record is looked up at the start ts of the transaction of the mutations, and is written by
+// that transaction. As the key has an @upsert index, the transaction conflicts with any other one
+// writing a record for the key after that ts, on whichever alpha, so only one of them commits.
+func (s *Server) doIdempotentQuery(ctx context.Context, r *Request,
+	key string) (*api.Response, error) {
+	switch {
+	case x.Config.IdempotencyTTL == 0:
+		return nil, errors.New("Idempotency keys are disabled on this alpha")
+	case len(key) > maxIdempotencyKeyLen:
+		return nil, errors.Errorf("The idempotency key is longer than %d bytes",
+			maxIdempotencyKeyLen)
+	case len(r.req.GetMutations()) == 0 || !r.req.GetCommitNow():
+		return nil, errors.New("An idempotency key can only be given to mutations which are " +
+			"committed immediately")
+	}
+	ns, err := x.ExtractNamespace(ctx)
+	if err != nil {
+		return nil, err
+	}
+	fingerprint, err := requestFingerprint(r.req)
+	if err != nil {
+		return nil, err
+	}
+
+	unlock := lockIdempotencyKey(ns, key)
+	defer unlock()
+	if r.req.StartTs == 0 {
+		r.req.StartTs = worker.State.GetTimestamp(false)
+	}
+	record, err := s.getIdempotencyRecord(ns, key, r.req.StartTs)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while reading the record of the idempotency key")
+	}
+	if record != nil {
+		return recordedResponse(fingerprint, record)
+	}
+
+	r.idempotency = &idempotencyEntry{key: key, fingerprint: fingerprint}
+	resp, err := s.doQuery(ctx, r)
+	if err == nil {
+		go s.purgeIdempotencyRecords(ns)
+	}
+	return resp, err
+}
@@ -0,0 +1,375 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package edgraph
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/dgo/v210/protos/api"
+	"github.com/dgraph-io/dgraph/worker"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+)
+
+// The query jobs are long-running read-only queries run in the background by each alpha. A client
+// submits the query to /query/jobs, and gets the id of the job, with which it polls the status of
+// the job and then fetches its results page by page. The results are kept in memory by the alpha
+// for query-job-ttl once the job is finished, so that a client whose connection timed out doesn't
+// have to run the query again. The ids are random, and a job is only visible in the namespace
+// which submitted it. The jobs are also reported as tasks of the alpha by the admin task API,
+// which can cancel them.
+
+// The statuses of a query job.
+const (
+	QueryJobRunning   = "running"
+	QueryJobDone      = "done"
+	QueryJobFailed    = "failed"
+	QueryJobCancelled = "cancelled"
+)
+
+// ErrQueryJobNotFound is returned for a job the alpha doesn't know of, or whose results expired.
+var ErrQueryJobNotFound = errors.New("query job not found, it may have expired")
+
+var queryJobs = &queryJobStore{jobs: make(map[queryJobKey]*queryJob)}
+
+type queryJobKey struct {
+	ns uint64
+	id string
+}
+
+type queryJob struct {
+	cancel    context.CancelFunc
+	submitted time.Time
+	task      *worker.Task
+
+	// The fields below are guarded by the mutex of the store.
+	status   string
+	err      error
+	finished time.Time
+	// lists holds the results of the blocks of the query, and values the blocks whose results
+	// aren't lists, which are returned along with every page.
+	lists  map[string][]json.RawMessage
+	values map[string]json.RawMessage
+}
+
+type queryJobStore struct {
+	sync.Mutex
+	max     int
+	ttl     time.Duration
+	timeout time.Duration
+	jobs    map[queryJobKey]*queryJob
+}
+
+// QueryJobStatus is the status of a query job, as returned to the clients.
+type QueryJobStatus struct {
+	ID        string `json:"id"`
+	Status    string `json:"status"`
+	Error     string `json:"error,omitempty"`
+	Submitted string `json:"submitted"`
+	Finished  string `json:"finished,omitempty"`
+	// Counts holds the number of results of each block of the query once the job is done.
+	Counts map[string]int `json:"counts,omitempty"`
+	// TaskID is the id of the job in the admin task API.
+	TaskID string `json:"taskId,omitempty"`
+}
+
+func (j *queryJob) statusOf(id string) *QueryJobStatus {
+	st := &QueryJobStatus{
+		ID:        id,
+		Status:    j.status,
+		Submitted: j.submitted.Format(time.RFC3339Nano),
+	}
+	if j.err != nil {
+		st.Error = j.err.Error()
+	}
+	if !j.finished.IsZero() {
+		st.Finished = j.finished.Format(time.RFC3339Nano)
+	}
+	if j.status == QueryJobDone {
+		st.Counts = make(map[string]int, len(j.lists))
+		for name, list := range j.lists {
+			st.Counts[name] = len(list)
+		}
+	}
+	if id := j.task.ID(); id != 0 {
+		st.TaskID = fmt.Sprintf("%#x", id)
+	}
+	return st
+}
+
+// stop cancels the job if it's running. It must be called with the lock of the store held.
+func (j *queryJob) stop() {
+	if j.status == QueryJobRunning {
+		j.cancel()
+		j.status, j.finished = QueryJobCancelled, time.Now()
+	}
+}
+
+// expire drops the finished jobs whose results were kept for longer than the ttl. It must be
+// called with the lock held.
+func (c *queryJobStore) expire(now time.Time) {
+	for key, j := range c.jobs {
+		if j.status != QueryJobRunning && now.Sub(j.finished) > c.ttl {
+			delete(c.jobs, key)
+		}
+	}
+}
+
+// add adds the job to the store. If the store is full, the job finished first is evicted, and
+// the job is rejected if all the jobs are still running.
+func (c *queryJobStore) add(key queryJobKey, j *queryJob) error {
+	c.Lock()
+	defer c.Unlock()
+	c.expire(j.submitted)
+	if len(c.jobs) >= c.max {
+		var oldest queryJobKey
+		var found bool
+		for k, other := range c.jobs {
+			if other.status == QueryJobRunning {
+				continue
+			}
+			if !found || other.finished.Before(c.jobs[oldest].finished) {
+				oldest, found = k, true
+			}
+		}
+		if !found {
+			return x.WithErrorCode(errors.Errorf("%d query jobs are already running, see "+
+				"--limit max-query-jobs", len(c.jobs)), x.ErrorCodeResourceExhausted)
+		}
+		delete(c.jobs, oldest)
+	}
+	c.jobs[key] = j
+	return nil
+}
+
+func (c *queryJobStore) get(key queryJobKey) *queryJob {
+	c.Lock()
+	defer c.Unlock()
+	c.expire(time.Now())
+	return c.jobs[key]
+}
+
+// finish records the outcome of the job, unless it was cancelled, and returns the error the job
+// finished with.
+func (c *queryJobStore) finish(j *queryJob, resp *api.Response, err error) error {
+	var lists map[string][]json.RawMessage
+	var values map[string]json.RawMessage
+	if err == nil {
+		lists, values, err = splitJobResults(resp.GetJson())
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	if j.status != QueryJobRunning {
+		return worker.ErrTaskCancelled
+	}
+	j.finished = time.Now()
+	if err != nil {
+		j.status, j.err = QueryJobFailed, err
+		return err
+	}
+	j.status, j.lists, j.values = QueryJobDone, lists, values
+	return nil
+}
+
+// splitJobResults splits the JSON results of a query into the lists of results of its blocks, so
+// that they can be paginated.
+func splitJobResults(js []byte) (map[string][]json.RawMessage, map[string]json.RawMessage,
+	error) {
+	var blocks map[string]json.RawMessage
+	if len(js) > 0 {
+		if err := json.Unmarshal(js, &blocks); err != nil {
+			return nil, nil, errors.Wrapf(err, "while reading the results of the query job")
+		}
+	}
+	lists := make(map[string][]json.RawMessage)
+	values := make(map[string]json.RawMessage)
+	for name, block := range blocks {
+		var list []json.RawMessage
+		if err := json.Unmarshal(block, &list); err != nil {
+			values[name] = block
+			continue
+		}
+		lists[name] = list
+	}
+	return lists, values, nil
+}
+
+// pageJobResults returns the page of the results of a job starting at offset, with at most first
+// results per block. All the results are returned if first is 0.
+func pageJobResults(lists map[string][]json.RawMessage, values map[string]json.RawMessage,
+	offset, first int) map[string]json.RawMessage {
+	page := make(map[string]json.RawMessage, len(lists)+len(values))
+	for name, value := range values {
+		page[name] = value
+	}
+	for name, list := range lists {
+		start, end := offset, len(list)
+		if start > end {
+			start = end
+		}
+		if first > 0 && start+first < end {
+			end = start + first
+		}
+		// The results are valid JSON, so the page can be marshalled.
+		js, _ := json.Marshal(list[start:end])
+		page[name] = js
+	}
+	return page
+}
+
+func newQueryJobID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b[:]), nil
+}
+
+// jobKey returns the key of the job with the given id in the namespace of the request.
+func jobKey(ctx context.Context, id string) (queryJobKey, error) {
+	ctx = x.AttachJWTNamespace(ctx)
+	ns, err := x.ExtractNamespace(ctx)
+	if err != nil {
+		return queryJobKey{}, err
+	}
+	return queryJobKey{ns: ns, id: id}, nil
+}
+
+// SubmitQueryJob runs the read-only query of the request in the background, and returns the
+// status of its job. The context must not be cancelled when the request submitting the job
+// returns, the job is instead cancelled by CancelQueryJob or when it runs for longer than
+// query-job-timeout.
+func (s *Server) SubmitQueryJob(ctx context.Context, req *api.Request) (*QueryJobStatus, error) {
+	switch {
+	case queryJobs.max <= 0:
+		return nil, errors.New("query jobs are disabled, see --limit max-query-jobs")
+	case len(req.GetMutations()) > 0 || req.GetStartTs() != 0:
+		return nil, errors.New("a query job can't have mutations or be part of a transaction")
+	}
+	id, err := newQueryJobID()
+	if err != nil {
+		return nil, err
+	}
+	key, err := jobKey(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// The interactive query timeout doesn't apply to the jobs, as they have a deadline.
+	var cancel context.CancelFunc
+	if queryJobs.timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, queryJobs.timeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	j := &queryJob{cancel: cancel, submitted: time.Now(), status: QueryJobRunning}
+	j.task = worker.Tasks.Start(worker.TaskKindQueryJob, func() {
+		queryJobs.Lock()
+		defer queryJobs.Unlock()
+		j.stop()
+	})
+	if err := queryJobs.add(key, j); err != nil {
+		cancel()
+		j.task.Finish(err)
+		return nil, err
+	}
+	j.task.Logf("Running query job %s", id)
+	queryJobs.Lock()
+	st := j.statusOf(id)
+	queryJobs.Unlock()
+
+	req.ReadOnly = true
+	go func() {
+		defer cancel()
+		resp, err := s.Query(ctx, req)
+		if err != nil {
+			glog.V(2).Infof("Query job %s failed: %v", id, err)
+		}
+		j.task.Finish(queryJobs.finish(j, resp, err))
+	}()
+	return st, nil
+}
+
+// QueryJob returns the status of the job with the given id.
+func (s *Server) QueryJob(ctx context.Context, id string) (*QueryJobStatus, error) {
+	key, err := jobKey(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	j := queryJobs.get(key)
+	if j == nil {
+		return nil, errors.Wrapf(ErrQueryJobNotFound, "%q", id)
+	}
+	queryJobs.Lock()
+	defer queryJobs.Unlock()
+	return j.statusOf(id), nil
+}
+
+// QueryJobResults returns the page of the results of the done job with the given id, starting at
+// offset, with at most first results per block of the query. All the results are returned if
+// first is 0.
+func (s *Server) QueryJobResults(ctx context.Context, id string,
+	offset, first int) (map[string]json.RawMessage, *QueryJobStatus, error) {
+	if offset < 0 || first < 0 {
+		return nil, nil, errors.New("offset and first can't be negative")
+	}
+	key, err := jobKey(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	j := queryJobs.get(key)
+	if j == nil {
+		return nil, nil, errors.Wrapf(ErrQueryJobNotFound, "%q", id)
+	}
+	queryJobs.Lock()
+	defer queryJobs.Unlock()
+	st := j.statusOf(id)
+	if j.status != QueryJobDone {
+		return nil, st, errors.Errorf("query job %q is %s, it has no results", id, j.status)
+	}
+	return pageJobResults(j.lists, j.values, offset, first), st, nil
+}
+
+// CancelQueryJob cancels the job with the given id if it's running. The results of a finished job
+// are discarded.
+func (s *Server) CancelQueryJob(ctx context.Context, id string) (*QueryJobStatus, error) {
+	key, err := jobKey(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	j := queryJobs.get(key)
+	if j == nil {
+		return nil, errors.Wrapf(ErrQueryJobNotFound, "%q", id)
+	}
+	queryJobs.Lock()
+	defer queryJobs.Unlock()
+	if j.status == QueryJobRunning {
+		j.stop()
+	} else {
+		delete(queryJobs.jobs, key)
+		j.lists, j.values = nil, nil
+	}
+	return j.statusOf(id), nil
+}
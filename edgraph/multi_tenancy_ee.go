@@ -35,6 +35,9 @@ type ResetPasswordInput struct {
 }
 
 func (s *Server) ResetPassword(ctx context.Context, inp *ResetPasswordInput) error {
+	if err := validatePassword(inp.Password); err != nil {
+		return err
+	}
 	query := fmt.Sprintf(`{
 			x as updateUser(func: eq(dgraph.xid, "%s")) @filter(type(dgraph.type.User)) {
 				uid
@@ -90,6 +93,9 @@ func (s *Server) ResetPassword(ctx context.Context, inp *ResetPasswordInput) err
 // Authorization is handled by middlewares.
 func (s *Server) CreateNamespace(ctx context.Context, passwd string) (uint64, error) {
 	glog.V(2).Info("Got create namespace request.")
+	if err := validatePassword(passwd); err != nil {
+		return 0, err
+	}
 
 	num := &pb.Num{Val: 1, Type: pb.Num_NS_ID}
 	ids, err := worker.AssignNsIdsOverNetwork(ctx, num)
@@ -0,0 +1,278 @@
+// +build !oss
+
+/*
+ * Copyright 2021 Dgraph Labs, Inc. All rights reserved.
+ *
+ * Licensed under the Dgraph Community License (the "License"); you
+ * may not use this file except in compliance with the License. You
+ * may obtain a copy of the License at
+ *
+ *     https://github.com/dgraph-io/dgraph/blob/master/licenses/DCL.txt
+ */
+
+package edgraph
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/dgraph/ee/acl"
+	"github.com/dgraph-io/dgraph/worker"
+	"github.com/dgraph-io/ristretto/z"
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/pkg/errors"
+	"gopkg.in/square/go-jose.v2"
+)
+
+// jwksRefreshInterval is the minimum time between two fetches of the JWKS. A token signed by an
+// unknown key triggers a refetch, so this protects the IdP against a flood of bogus tokens.
+const jwksRefreshInterval = time.Minute
+
+// oidcVerifier verifies ID tokens issued by an OpenID Connect provider and maps the identity in
+// them to a Dgraph ACL user.
+type oidcVerifier struct {
+	issuer     string
+	jwksUrl    string
+	audience   string
+	userClaim  string
+	groupClaim string
+	// groupMap maps the IdP group names to Dgraph group IDs.
+	groupMap map[string]string
+	// passThrough is set if the IdP groups which aren't in the group map are used as Dgraph group
+	// IDs as is. Otherwise they are ignored.
+	passThrough bool
+	httpClient  *http.Client
+
+	sync.RWMutex
+	keys *jose.JSONWebKeySet
+	// fetchedAt is the time of the last attempt to fetch the JWKS, successful or not, so that an
+	// unavailable provider isn't queried for every token either.
+	fetchedAt time.Time
+}
+
+var (
+	oidcOnce sync.Once
+	oidc     *oidcVerifier
+)
+
+// getOidcVerifier returns the verifier configured through the --oidc flag, or nil if OIDC login
+// has not been configured.
+func getOidcVerifier() *oidcVerifier {
+	oidcOnce.Do(func() {
+		if worker.Config.Oidc == nil || worker.Config.Oidc.GetString("issuer") == "" {
+			return
+		}
+		oidc = newOidcVerifier(worker.Config.Oidc)
+	})
+	return oidc
+}
+
+func newOidcVerifier(sf *z.SuperFlag) *oidcVerifier {
+	return &oidcVerifier{
+		issuer:      strings.TrimSuffix(sf.GetString("issuer"), "/"),
+		jwksUrl:     sf.GetString("jwks-url"),
+		audience:    sf.GetString("audience"),
+		userClaim:   sf.GetString("user-claim"),
+		groupClaim:  sf.GetString("group-claim"),
+		groupMap:    parseGroupMap(sf.GetString("group-map")),
+		passThrough: sf.GetBool("pass-through-groups"),
+		httpClient:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// parseGroupMap parses a list of the form "idp-group1:dgraph-group1,idp-group2:dgraph-group2".
+func parseGroupMap(s string) map[string]string {
+	groupMap := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		// IdP group names may themselves contain colons (e.g. URNs), so split on the last one.
+		idx := strings.LastIndex(pair, ":")
+		if idx <= 0 || idx == len(pair)-1 {
+			continue
+		}
+		groupMap[pair[:idx]] = pair[idx+1:]
+	}
+	return groupMap
+}
+
+// mapGroups converts the IdP groups into Dgraph groups. Groups that are not present in the group
+// map are ignored, unless passThrough is set, in which case they are used as Dgraph group IDs as
+// is. Passing them through by default would let anyone able to name a group in the IdP, e.g.
+// guardians, get the permissions of the Dgraph group of the same name.
+func (v *oidcVerifier) mapGroups(idpGroups []string) []acl.Group {
+	seen := make(map[string]struct{})
+	groups := make([]acl.Group, 0, len(idpGroups))
+	for _, g := range idpGroups {
+		if mapped, ok := v.groupMap[g]; ok {
+			g = mapped
+		} else if !v.passThrough {
+			continue
+		}
+		if _, ok := seen[g]; ok {
+			continue
+		}
+		seen[g] = struct{}{}
+		groups = append(groups, acl.Group{GroupID: g})
+	}
+	return groups
+}
+
+// verify validates the signature, issuer, audience and expiry of the ID token and returns the
+// user identified by it.
+func (v *oidcVerifier) verify(idToken string) (*acl.User, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(idToken, claims, func(token *jwt.Token) (interface{}, error) {
+		switch token.Method.(type) {
+		case *jwt.SigningMethodRSA, *jwt.SigningMethodECDSA, *jwt.SigningMethodRSAPSS:
+		default:
+			return nil, errors.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		kid, _ := token.Header["kid"].(string)
+		return v.signingKey(kid)
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to parse ID token")
+	}
+
+	// MapClaims.Valid doesn't fail on a missing exp, but an ID token must always have one.
+	if !claims.VerifyExpiresAt(time.Now().Unix(), true) {
+		return nil, errors.Errorf("ID token is expired")
+	}
+	if !claims.VerifyIssuer(v.issuer, true) &&
+		!claims.VerifyIssuer(v.issuer+"/", true) {
+		return nil, errors.Errorf("ID token has an unexpected issuer: %v", claims["iss"])
+	}
+	if v.audience != "" && !verifyAudience(claims["aud"], v.audience) {
+		return nil, errors.Errorf("ID token has an unexpected audience: %v", claims["aud"])
+	}
+
+	userId, ok := claims[v.userClaim].(string)
+	if !ok || userId == "" {
+		return nil, errors.Errorf("claim %q in ID token is not a valid user id", v.userClaim)
+	}
+	var idpGroups []string
+	switch groups := claims[v.groupClaim].(type) {
+	case nil:
+	case string:
+		idpGroups = []string{groups}
+	case []interface{}:
+		for _, g := range groups {
+			group, ok := g.(string)
+			if !ok {
+				return nil, errors.Errorf("unable to convert group to string: %v", g)
+			}
+			idpGroups = append(idpGroups, group)
+		}
+	default:
+		return nil, errors.Errorf("claim %q in ID token is not a list of groups", v.groupClaim)
+	}
+
+	exp, _ := claims["exp"].(float64)
+	return &acl.User{
+		UserID:     userId,
+		Groups:     v.mapGroups(idpGroups),
+		OidcExpiry: time.Unix(int64(exp), 0),
+	}, nil
+}
+
+// verifyAudience checks the aud claim, which can either be a string or a list of strings.
+func verifyAudience(aud interface{}, expected string) bool {
+	switch aud := aud.(type) {
+	case string:
+		return aud == expected
+	case []interface{}:
+		for _, a := range aud {
+			if s, ok := a.(string); ok && s == expected {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// signingKey returns the key with the given kid from the provider's JWKS, fetching the JWKS again
+// if the key is not found, which happens when the provider rotates its keys.
+func (v *oidcVerifier) signingKey(kid string) (interface{}, error) {
+	if kid == "" {
+		return nil, errors.Errorf("kid not present in ID token")
+	}
+	v.RLock()
+	keys, fetchedAt := v.keys, v.fetchedAt
+	v.RUnlock()
+
+	if keys != nil {
+		if found := keys.Key(kid); len(found) > 0 {
+			return found[0].Key, nil
+		}
+	}
+	if time.Since(fetchedAt) < jwksRefreshInterval {
+		return nil, errors.Errorf("invalid kid: %s", kid)
+	}
+	if err := v.fetchKeys(); err != nil {
+		return nil, errors.Wrapf(err, "while fetching the JWKS from the OIDC provider")
+	}
+
+	v.RLock()
+	defer v.RUnlock()
+	// The keys are still nil if the JWKS was never fetched successfully.
+	if v.keys != nil {
+		if found := v.keys.Key(kid); len(found) > 0 {
+			return found[0].Key, nil
+		}
+	}
+	return nil, errors.Errorf("invalid kid: %s", kid)
+}
+
+func (v *oidcVerifier) fetchKeys() error {
+	v.Lock()
+	defer v.Unlock()
+	// Another goroutine might have fetched the keys while we were waiting for the lock.
+	if time.Since(v.fetchedAt) < jwksRefreshInterval {
+		return nil
+	}
+	v.fetchedAt = time.Now()
+
+	if v.jwksUrl == "" {
+		var discovery struct {
+			JwksUri string `json:"jwks_uri"`
+		}
+		if err := v.getJSON(v.issuer+"/.well-known/openid-configuration", &discovery); err != nil {
+			return err
+		}
+		if discovery.JwksUri == "" {
+			return errors.Errorf("jwks_uri not found in the OIDC discovery document")
+		}
+		v.jwksUrl = discovery.JwksUri
+	}
+
+	keys := &jose.JSONWebKeySet{}
+	if err := v.getJSON(v.jwksUrl, keys); err != nil {
+		return err
+	}
+	v.keys = keys
+	return nil
+}
+
+func (v *oidcVerifier) getJSON(url string, out interface{}) error {
+	resp, err := v.httpClient.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("unexpected status %d from %s: %s", resp.StatusCode, url, data)
+	}
+	return json.Unmarshal(data, out)
+}
@@ -0,0 +1,183 @@
+// +build !oss
+
+/*
+ * Copyright 2021 Dgraph Labs, Inc. All rights reserved.
+ *
+ * Licensed under the Dgraph Community License (the "License"); you
+ * may not use this file except in compliance with the License. You
+ * may obtain a copy of the License at
+ *
+ *     https://github.com/dgraph-io/dgraph/blob/master/licenses/DCL.txt
+ */
+
+package edgraph
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/dgraph/ee"
+	"github.com/dgraph-io/dgraph/ee/acl"
+	"github.com/dgraph-io/dgraph/worker"
+	"github.com/dgraph-io/ristretto/z"
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/square/go-jose.v2"
+)
+
+func TestParseGroupMap(t *testing.T) {
+	m := parseGroupMap("okta-admins:guardians, eng:dev,urn:grp:ops:sre,broken,:x,y:")
+	require.Equal(t, map[string]string{
+		"okta-admins": "guardians",
+		"eng":         "dev",
+		"urn:grp:ops": "sre",
+	}, m)
+	require.Empty(t, parseGroupMap(""))
+}
+
+func TestOidcVerify(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	var issuer string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter,
+		r *http.Request) {
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]string{
+			"issuer":   issuer,
+			"jwks_uri": issuer + "/keys",
+		}))
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewEncoder(w).Encode(jose.JSONWebKeySet{
+			Keys: []jose.JSONWebKey{{Key: &key.PublicKey, KeyID: "key1", Algorithm: "RS256",
+				Use: "sig"}},
+		}))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	issuer = server.URL
+
+	sf := z.NewSuperFlag("issuer=" + issuer + "; audience=dgraph; group-map=okta-admins:guardians").
+		MergeAndCheckDefault(ee.OidcDefaults)
+	v := newOidcVerifier(sf)
+
+	sign := func(kid string, claims jwt.MapClaims) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		token.Header["kid"] = kid
+		s, err := token.SignedString(key)
+		require.NoError(t, err)
+		return s
+	}
+	validClaims := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"iss":    issuer,
+			"aud":    []string{"dgraph", "other"},
+			"exp":    time.Now().Add(time.Hour).Unix(),
+			"email":  "alice@example.com",
+			"groups": []string{"okta-admins", "dev", "dev"},
+		}
+	}
+
+	claims := validClaims()
+	user, err := v.verify(sign("key1", claims))
+	require.NoError(t, err)
+	require.Equal(t, "alice@example.com", user.UserID)
+	// The groups which aren't in the group map are ignored.
+	require.Equal(t, []string{"guardians"}, acl.GetGroupIDs(user.Groups))
+	require.Equal(t, claims["exp"], user.OidcExpiry.Unix())
+
+	claims = validClaims()
+	claims["groups"] = []string{"guardians", "dev"}
+	user, err = v.verify(sign("key1", claims))
+	require.NoError(t, err)
+	require.Empty(t, user.Groups)
+
+	v.passThrough = true
+	user, err = v.verify(sign("key1", validClaims()))
+	require.NoError(t, err)
+	require.Equal(t, []string{"guardians", "dev"}, acl.GetGroupIDs(user.Groups))
+	v.passThrough = false
+
+	claims = validClaims()
+	claims["aud"] = "someone-else"
+	_, err = v.verify(sign("key1", claims))
+	require.Error(t, err)
+
+	claims = validClaims()
+	claims["iss"] = "https://evil.example.com"
+	_, err = v.verify(sign("key1", claims))
+	require.Error(t, err)
+
+	claims = validClaims()
+	delete(claims, "exp")
+	_, err = v.verify(sign("key1", claims))
+	require.Error(t, err)
+
+	claims = validClaims()
+	delete(claims, "email")
+	_, err = v.verify(sign("key1", claims))
+	require.Error(t, err)
+
+	_, err = v.verify(sign("unknown", validClaims()))
+	require.Error(t, err)
+
+	// A token signed with the HMAC secret must never be accepted as an ID token.
+	hmacToken := jwt.NewWithClaims(jwt.SigningMethodHS256, validClaims())
+	hmacToken.Header["kid"] = "key1"
+	s, err := hmacToken.SignedString([]byte("a-secret-that-is-long-enough-for-hs256"))
+	require.NoError(t, err)
+	_, err = v.verify(s)
+	require.Error(t, err)
+}
+
+func TestOidcJwksUnavailable(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	sf := z.NewSuperFlag("issuer=" + server.URL + "; jwks-url=" + server.URL + "/keys;").
+		MergeAndCheckDefault(ee.OidcDefaults)
+	v := newOidcVerifier(sf)
+
+	_, err := v.signingKey("key1")
+	require.Error(t, err)
+	require.Equal(t, int32(1), atomic.LoadInt32(&hits))
+	// The failed fetch isn't retried before the refresh interval, whatever the kid.
+	for _, kid := range []string{"key1", "key2", "key3"} {
+		_, err = v.signingKey(kid)
+		require.Error(t, err)
+	}
+	require.Equal(t, int32(1), atomic.LoadInt32(&hits))
+
+	v.fetchedAt = time.Now().Add(-jwksRefreshInterval)
+	_, err = v.signingKey("key1")
+	require.Error(t, err)
+	require.Equal(t, int32(2), atomic.LoadInt32(&hits))
+}
+
+func TestOidcRefreshJwtExpiry(t *testing.T) {
+	ttl := worker.Config.RefreshJwtTtl
+	worker.Config.RefreshJwtTtl = 24 * time.Hour
+	defer func() { worker.Config.RefreshJwtTtl = ttl }()
+
+	// The refresh JWT of an OIDC user expires with its ID token.
+	idTokenExpiry := time.Now().Add(time.Hour)
+	user := &acl.User{UserID: "alice", Oidc: true, OidcExpiry: idTokenExpiry}
+	require.Equal(t, idTokenExpiry, refreshJwtExpiry(user))
+
+	user.OidcExpiry = time.Now().Add(48 * time.Hour)
+	require.WithinDuration(t, time.Now().Add(24*time.Hour), refreshJwtExpiry(user), time.Minute)
+
+	user = &acl.User{UserID: "bob"}
+	require.WithinDuration(t, time.Now().Add(24*time.Hour), refreshJwtExpiry(user), time.Minute)
+}
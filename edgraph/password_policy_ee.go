@@ -0,0 +1,265 @@
+// +build !oss
+
+/*
+ * Copyright 2021 Dgraph Labs, Inc. All rights reserved.
+ *
+ * Licensed under the Dgraph Community License (the "License"); you
+ * may not use this file except in compliance with the License. You
+ * may obtain a copy of the License at
+ *
+ *     https://github.com/dgraph-io/dgraph/blob/master/licenses/DCL.txt
+ */
+
+package edgraph
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/dgraph-io/dgo/v210/protos/api"
+	"github.com/dgraph-io/dgraph/ee/acl"
+	"github.com/dgraph-io/dgraph/types"
+	"github.com/dgraph-io/dgraph/worker"
+	"github.com/dgraph-io/ristretto/z"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+)
+
+const (
+	passwordPred        = "dgraph.password"
+	passwordUpdatedPred = "dgraph.user.password_updated"
+	passwordSchemePred  = "dgraph.user.password_scheme"
+)
+
+// passwordPolicy holds the complexity and rotation rules for the passwords of ACL users.
+type passwordPolicy struct {
+	minLength      int
+	requireUpper   bool
+	requireLower   bool
+	requireDigit   bool
+	requireSpecial bool
+	// maxAge is the duration after which a password expires. It is 0 if passwords never expire.
+	maxAge time.Duration
+}
+
+var (
+	passwordPolicyOnce sync.Once
+	pwdPolicy          *passwordPolicy
+)
+
+// getPasswordPolicy returns the policy configured through the --password-policy flag.
+func getPasswordPolicy() *passwordPolicy {
+	passwordPolicyOnce.Do(func() {
+		if worker.Config.PasswordPolicy == nil {
+			pwdPolicy = &passwordPolicy{minLength: 6}
+			return
+		}
+		pwdPolicy = newPasswordPolicy(worker.Config.PasswordPolicy)
+	})
+	return pwdPolicy
+}
+
+func newPasswordPolicy(sf *z.SuperFlag) *passwordPolicy {
+	return &passwordPolicy{
+		minLength:      int(sf.GetInt64("min-length")),
+		requireUpper:   sf.GetBool("require-upper"),
+		requireLower:   sf.GetBool("require-lower"),
+		requireDigit:   sf.GetBool("require-digit"),
+		requireSpecial: sf.GetBool("require-special"),
+		maxAge:         sf.GetDuration("max-age"),
+	}
+}
+
+// validate checks that the password satisfies the complexity rules of the policy.
+func (p *passwordPolicy) validate(password string) error {
+	var hasUpper, hasLower, hasDigit, hasSpecial bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case !unicode.IsLetter(r):
+			hasSpecial = true
+		}
+	}
+
+	var missing []string
+	if n := len([]rune(password)); n < p.minLength {
+		missing = append(missing, fmt.Sprintf("at least %d characters", p.minLength))
+	}
+	if p.requireUpper && !hasUpper {
+		missing = append(missing, "an upper case letter")
+	}
+	if p.requireLower && !hasLower {
+		missing = append(missing, "a lower case letter")
+	}
+	if p.requireDigit && !hasDigit {
+		missing = append(missing, "a digit")
+	}
+	if p.requireSpecial && !hasSpecial {
+		missing = append(missing, "a special character")
+	}
+	if len(missing) > 0 {
+		return errors.Errorf("password does not satisfy the password policy, it must have %s",
+			strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// isExpired returns true if the password of the user is older than the maximum age allowed by
+// the policy. Passwords set before the update time was recorded never expire.
+func (p *passwordPolicy) isExpired(user *acl.User) bool {
+	if p.maxAge == 0 || user.PasswordUpdated.IsZero() {
+		return false
+	}
+	return time.Since(user.PasswordUpdated) > p.maxAge
+}
+
+// validatePassword checks the password against the configured password policy.
+func validatePassword(password string) error {
+	return getPasswordPolicy().validate(password)
+}
+
+// nquadStrVal returns the string value of the N-Quad, and false if it has no literal value, e.g.
+// if the value comes from a value variable.
+func nquadStrVal(nq *api.NQuad) (string, bool) {
+	switch val := nq.GetObjectValue().GetVal().(type) {
+	case *api.Value_StrVal:
+		return val.StrVal, true
+	case *api.Value_DefaultVal:
+		return val.DefaultVal, true
+	case *api.Value_PasswordVal:
+		return val.PasswordVal, true
+	}
+	return "", false
+}
+
+// applyPasswordPolicy validates the passwords set by the mutations of the request if enforce is
+// true, and records when and with which scheme each of them was hashed. The update time and the
+// scheme are only written by the server: the mutations of the users can't set or delete them, as
+// setting the update time would dodge the expiry of the password. Internal requests, for which
+// enforce is false, keep the update time they set explicitly.
+func applyPasswordPolicy(qc *queryContext, enforce bool) error {
+	if len(worker.Config.HmacSecret) == 0 {
+		return nil
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	for _, gmu := range qc.gmuList {
+		if enforce {
+			for _, nquads := range [][]*api.NQuad{gmu.Set, gmu.Del} {
+				for _, nq := range nquads {
+					if nq.Predicate == passwordUpdatedPred || nq.Predicate == passwordSchemePred {
+						return errors.Errorf("Cannot mutate the predicate %s, it is set by the "+
+							"server when the password changes", nq.Predicate)
+					}
+				}
+			}
+		}
+
+		updated := make(map[string]struct{})
+		for _, nq := range gmu.Set {
+			if nq.Predicate == passwordUpdatedPred {
+				updated[nq.Subject] = struct{}{}
+			}
+		}
+
+		var extra []*api.NQuad
+		for _, nq := range gmu.Set {
+			if nq.Predicate != passwordPred {
+				continue
+			}
+			if enforce {
+				if password, ok := nquadStrVal(nq); ok {
+					if err := validatePassword(password); err != nil {
+						return err
+					}
+				}
+			}
+			if _, ok := updated[nq.Subject]; !ok {
+				updated[nq.Subject] = struct{}{}
+				extra = append(extra, &api.NQuad{
+					Subject:     nq.Subject,
+					Predicate:   passwordUpdatedPred,
+					ObjectValue: &api.Value{Val: &api.Value_StrVal{StrVal: now}},
+				})
+			}
+			extra = append(extra, &api.NQuad{
+				Subject:   nq.Subject,
+				Predicate: passwordSchemePred,
+				ObjectValue: &api.Value{Val: &api.Value_StrVal{
+					StrVal: types.PasswordHashScheme()}},
+			})
+		}
+		gmu.Set = append(gmu.Set, extra...)
+	}
+	return nil
+}
+
+// upgradePasswordHash re-hashes the password of the user with the configured scheme if it was
+// hashed with another one. It is called after a successful password login, as that is the only
+// time the plain-text password is available. The update time of the password is kept, so that
+// the re-hashing doesn't postpone the expiry of the password. The upgrade is skipped if the
+// password was changed in the meantime.
+func upgradePasswordHash(ctx context.Context, user *acl.User, password string) {
+	scheme := user.PasswordScheme
+	if scheme == "" {
+		// Passwords stored before the scheme was recorded were hashed with bcrypt.
+		scheme = types.BcryptScheme
+	}
+	if scheme == types.PasswordHashScheme() {
+		return
+	}
+
+	filter := fmt.Sprintf("NOT has(%s)", passwordUpdatedPred)
+	vars := map[string]string{"$uid": user.Uid, "$updated": ""}
+	nquads := []*api.NQuad{
+		{
+			Subject:     "uid(u)",
+			Predicate:   passwordPred,
+			ObjectValue: &api.Value{Val: &api.Value_StrVal{StrVal: password}},
+		},
+	}
+	if !user.PasswordUpdated.IsZero() {
+		updated := user.PasswordUpdated.UTC().Format(time.RFC3339)
+		filter = fmt.Sprintf("eq(%s, $updated)", passwordUpdatedPred)
+		vars["$updated"] = updated
+		nquads = append(nquads, &api.NQuad{
+			Subject:     "uid(u)",
+			Predicate:   passwordUpdatedPred,
+			ObjectValue: &api.Value{Val: &api.Value_StrVal{StrVal: updated}},
+		})
+	}
+	query := fmt.Sprintf(`
+		query user($uid: string, $updated: string) {
+			u as var(func: uid($uid)) @filter(%s AND NOT eq(%s, %q))
+		}`, filter, passwordSchemePred, types.PasswordHashScheme())
+
+	req := &Request{
+		req: &api.Request{
+			CommitNow: true,
+			Query:     query,
+			Vars:      vars,
+			Mutations: []*api.Mutation{{
+				Set:  nquads,
+				Cond: "@if(gt(len(u), 0))",
+			}},
+		},
+		doAuth: NoAuthorize,
+	}
+	ctx = context.WithValue(ctx, skipPasswordPolicy, true)
+	if _, err := (&Server{}).doQuery(ctx, req); err != nil {
+		glog.Errorf("Unable to upgrade the password hash of user %s to %s: %v", user.UserID,
+			types.PasswordHashScheme(), err)
+		return
+	}
+	glog.Infof("Upgraded the password hash of user %s from %s to %s", user.UserID, scheme,
+		types.PasswordHashScheme())
+}
@@ -0,0 +1,108 @@
+// +build !oss
+
+/*
+ * Copyright 2021 Dgraph Labs, Inc. All rights reserved.
+ *
+ * Licensed under the Dgraph Community License (the "License"); you
+ * may not use this file except in compliance with the License. You
+ * may obtain a copy of the License at
+ *
+ *     https://github.com/dgraph-io/dgraph/blob/master/licenses/DCL.txt
+ */
+
+package edgraph
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/dgo/v210/protos/api"
+	"github.com/dgraph-io/dgraph/ee"
+	"github.com/dgraph-io/dgraph/ee/acl"
+	"github.com/dgraph-io/dgraph/gql"
+	"github.com/dgraph-io/dgraph/worker"
+	"github.com/dgraph-io/ristretto/z"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPasswordPolicyValidate(t *testing.T) {
+	p := newPasswordPolicy(z.NewSuperFlag("min-length=8; require-upper=true; " +
+		"require-lower=true; require-digit=true; require-special=true").
+		MergeAndCheckDefault(ee.PasswordPolicyDefaults))
+
+	require.NoError(t, p.validate("Passw0rd!"))
+	require.Error(t, p.validate("Pa0rd!"))
+	require.Error(t, p.validate("password0!"))
+	require.Error(t, p.validate("PASSWORD0!"))
+	require.Error(t, p.validate("Password!!"))
+	require.Error(t, p.validate("Password00"))
+
+	p = newPasswordPolicy(z.NewSuperFlag("").MergeAndCheckDefault(ee.PasswordPolicyDefaults))
+	require.NoError(t, p.validate("simple"))
+	require.Error(t, p.validate("short"))
+}
+
+func TestPasswordPolicyExpiry(t *testing.T) {
+	p := &passwordPolicy{minLength: 6, maxAge: 24 * time.Hour}
+	require.False(t, p.isExpired(&acl.User{}))
+	require.False(t, p.isExpired(&acl.User{PasswordUpdated: time.Now().Add(-time.Hour)}))
+	require.True(t, p.isExpired(&acl.User{PasswordUpdated: time.Now().Add(-48 * time.Hour)}))
+
+	p.maxAge = 0
+	require.False(t, p.isExpired(&acl.User{PasswordUpdated: time.Now().Add(-48 * time.Hour)}))
+}
+
+func TestApplyPasswordPolicy(t *testing.T) {
+	secret := worker.Config.HmacSecret
+	worker.Config.HmacSecret = []byte("0123456789abcdef0123456789abcdef")
+	defer func() { worker.Config.HmacSecret = secret }()
+
+	strVal := func(s string) *api.Value {
+		return &api.Value{Val: &api.Value_StrVal{StrVal: s}}
+	}
+	newQc := func() *queryContext {
+		return &queryContext{gmuList: []*gql.Mutation{{Set: []*api.NQuad{
+			{Subject: "_:alice", Predicate: "dgraph.xid", ObjectValue: strVal("alice")},
+			{Subject: "_:alice", Predicate: "dgraph.password", ObjectValue: strVal("simple")},
+		}}}}
+	}
+
+	qc := newQc()
+	require.NoError(t, applyPasswordPolicy(qc, true))
+	preds := make(map[string]string)
+	for _, nq := range qc.gmuList[0].Set {
+		require.Equal(t, "_:alice", nq.Subject)
+		preds[nq.Predicate] = nq.ObjectValue.GetStrVal()
+	}
+	require.Equal(t, "bcrypt", preds["dgraph.user.password_scheme"])
+	_, err := time.Parse(time.RFC3339, preds["dgraph.user.password_updated"])
+	require.NoError(t, err)
+
+	// The users can't set the update time to dodge the expiry of the password, nor delete it.
+	qc = newQc()
+	qc.gmuList[0].Set = append(qc.gmuList[0].Set, &api.NQuad{Subject: "_:alice",
+		Predicate: "dgraph.user.password_updated", ObjectValue: strVal("2021-01-01T00:00:00Z")})
+	require.Error(t, applyPasswordPolicy(qc, true))
+	qc = newQc()
+	qc.gmuList[0].Del = []*api.NQuad{{Subject: "0x1", Predicate: "dgraph.user.password_updated",
+		ObjectValue: strVal("_STAR_ALL")}}
+	require.Error(t, applyPasswordPolicy(qc, true))
+
+	// The internal requests keep the update time they set explicitly.
+	qc = newQc()
+	qc.gmuList[0].Set = append(qc.gmuList[0].Set, &api.NQuad{Subject: "_:alice",
+		Predicate: "dgraph.user.password_updated", ObjectValue: strVal("2021-01-01T00:00:00Z")})
+	require.NoError(t, applyPasswordPolicy(qc, false))
+	var updated []string
+	for _, nq := range qc.gmuList[0].Set {
+		if nq.Predicate == "dgraph.user.password_updated" {
+			updated = append(updated, nq.ObjectValue.GetStrVal())
+		}
+	}
+	require.Equal(t, []string{"2021-01-01T00:00:00Z"}, updated)
+
+	qc = newQc()
+	qc.gmuList[0].Set[1].ObjectValue = strVal("short")
+	require.Error(t, applyPasswordPolicy(qc, true))
+	require.NoError(t, applyPasswordPolicy(newQc(), false))
+}
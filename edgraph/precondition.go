@@ -0,0 +1,190 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package edgraph
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/dgraph-io/dgo/v210/protos/api"
+	"github.com/dgraph-io/dgraph/chunker"
+	"github.com/dgraph-io/dgraph/gql"
+	"github.com/dgraph-io/dgraph/posting"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/schema"
+	"github.com/dgraph-io/dgraph/types"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/pkg/errors"
+)
+
+// precondition is a compare-and-set condition of a mutation request: the mutations are only
+// applied if the predicate of the node currently has the given value, see x.ExtractPreconditions.
+type precondition struct {
+	// nquad is the precondition as given in the request.
+	nquad *api.NQuad
+	// uid is the node of the precondition.
+	uid uint64
+	// attr is the predicate of the precondition, with its namespace.
+	attr string
+	// filter is the filter of the node matching the precondition.
+	filter string
+	// varName is the uid variable holding the node if it matches the precondition.
+	varName string
+}
+
+// preconditionValue returns the value of a precondition on a value predicate, as a string.
+func preconditionValue(val *api.Value) (string, error) {
+	switch v := val.GetVal().(type) {
+	case *api.Value_DefaultVal:
+		return v.DefaultVal, nil
+	case *api.Value_StrVal:
+		return v.StrVal, nil
+	case *api.Value_IntVal:
+		return strconv.FormatInt(v.IntVal, 10), nil
+	case *api.Value_DoubleVal:
+		return strconv.FormatFloat(v.DoubleVal, 'g', -1, 64), nil
+	case *api.Value_BoolVal:
+		return strconv.FormatBool(v.BoolVal), nil
+	}
+	return "", errors.Errorf("Unsupported type of value %v in a precondition", val)
+}
+
+// newPrecondition returns the precondition of the given N-Quad, on a predicate of the namespace.
+// The predicate must be a scalar predicate whose writes conflict, so that the precondition can
+// be checked at commit time. The writes of counters and @noconflict predicates don't.
+func newPrecondition(ns uint64, nq *api.NQuad, i int) (*precondition, error) {
+	uid, err := gql.ParseUid(nq.Subject)
+	if err != nil || uid == 0 {
+		return nil, errors.Errorf("The subject of the precondition %v must be a uid", nq)
+	}
+	if nq.Lang != "" || len(nq.Facets) > 0 {
+		return nil, errors.Errorf("The precondition %v can't have a language or facets", nq)
+	}
+	attr := x.NamespaceAttr(ns, nq.Predicate)
+	typ, err := schema.State().TypeOf(attr)
+	switch {
+	case err != nil:
+		return nil, errors.Errorf("The predicate %s of a precondition has no schema",
+			nq.Predicate)
+	case schema.State().IsList(attr):
+		return nil, errors.Errorf("The predicate %s of a precondition can't be a list",
+			nq.Predicate)
+	case schema.State().HasNoConflict(attr):
+		return nil, errors.Errorf("The predicate %s of a precondition can't have @noconflict",
+			nq.Predicate)
+	case schema.State().IsCounter(attr):
+		// The increments of a counter don't conflict with each other.
+		return nil, errors.Errorf("The predicate %s of a precondition can't be a counter",
+			nq.Predicate)
+	}
+
+	p := &precondition{
+		nquad:   nq,
+		uid:     uid,
+		attr:    attr,
+		varName: "__precondition__" + strconv.Itoa(i),
+	}
+	if typ == types.UidID {
+		objectId, err := gql.ParseUid(nq.ObjectId)
+		if err != nil || objectId == 0 {
+			return nil, errors.Errorf("The object of the precondition %v must be a uid", nq)
+		}
+		p.filter = fmt.Sprintf("uid_in(<%s>, %#x)", nq.Predicate, objectId)
+		return p, nil
+	}
+	if nq.ObjectValue == nil {
+		return nil, errors.Errorf("The object of the precondition %v must be a value", nq)
+	}
+	val, err := preconditionValue(nq.ObjectValue)
+	if err != nil {
+		return nil, err
+	}
+	p.filter = fmt.Sprintf("eq(<%s>, %s)", nq.Predicate, strconv.Quote(val))
+	return p, nil
+}
+
+// extractPreconditions returns the preconditions of the mutations in the context.
+func extractPreconditions(ctx context.Context) ([]*precondition, error) {
+	texts := x.ExtractPreconditions(ctx)
+	if len(texts) == 0 {
+		return nil, nil
+	}
+	ns, err := x.ExtractNamespace(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var res []*precondition
+	for _, text := range texts {
+		nqs, _, err := chunker.ParseRDFs([]byte(text))
+		if err != nil {
+			return nil, errors.Wrapf(err, "while parsing the precondition %q", text)
+		}
+		for _, nq := range nqs {
+			p, err := newPrecondition(ns, nq, len(res))
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, p)
+		}
+	}
+	return res, nil
+}
+
+// addPreconditionVars returns the upsert query extended with the queries of the nodes matching the
+// preconditions. They are read at the start ts of the transaction.
+func addPreconditionVars(qc *queryContext, upsertQuery string) string {
+	blocks := make([]string, 0, len(qc.preconditions))
+	for _, p := range qc.preconditions {
+		qc.uidRes[p.varName] = nil
+		blocks = append(blocks, fmt.Sprintf("%s as var(func: uid(%#x)) @filter(%s)", p.varName,
+			p.uid, p.filter))
+	}
+	return addQueryBlocks(upsertQuery, blocks)
+}
+
+// checkPreconditions returns an error with ErrorCodePreconditionFailed if a precondition doesn't
+// hold at the start ts of the transaction.
+func checkPreconditions(qc *queryContext) error {
+	for _, p := range qc.preconditions {
+		if len(qc.uidRes[p.varName]) == 0 {
+			return x.WithErrorCode(errors.Errorf("Precondition failed: %v", p.nquad),
+				x.ErrorCodePreconditionFailed)
+		}
+	}
+	return nil
+}
+
+// preconditionKeys returns the conflict keys of the predicates of the preconditions, which make
+// the transaction conflict with the ones writing them after its start ts. The preconditions which
+// hold at the start ts thus still hold when the transaction commits.
+func preconditionKeys(qc *queryContext) []string {
+	keys := make([]string, 0, len(qc.preconditions))
+	for _, p := range qc.preconditions {
+		key := x.DataKey(p.attr, p.uid)
+		pk, err := x.Parse(key)
+		if err != nil {
+			continue
+		}
+		conflictKey := posting.GetConflictKey(pk, key, &pb.DirectedEdge{Attr: p.attr})
+		if conflictKey == 0 {
+			continue
+		}
+		keys = append(keys, strconv.FormatUint(conflictKey, 36))
+	}
+	return keys
+}
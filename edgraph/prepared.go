@@ -0,0 +1,155 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package edgraph
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+
+	"github.com/dgraph-io/dgo/v210/protos/api"
+	"github.com/dgraph-io/dgraph/gql"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/pkg/errors"
+)
+
+// The prepared queries are kept by each alpha, in memory. A client runs a prepared query by giving
+// its name and hash, either with the prepared-query and prepared-hash gRPC metadata or with the
+// prepared and preparedHash parameters of /query. If the query was never prepared on the alpha,
+// or was evicted, the client gets an error and has to send the query again. A request giving the
+// query along with its name prepares it if needed, so that clients can always send the query the
+// first time they run it on an alpha.
+
+// ErrPreparedQueryNotFound is returned when running a prepared query the alpha doesn't know of.
+var ErrPreparedQueryNotFound = errors.New("prepared query not found, it must be prepared again")
+
+var preparedQueries = &preparedQueryCache{queries: make(map[preparedKey]*preparedQuery)}
+
+type preparedKey struct {
+	ns   uint64
+	name string
+}
+
+type preparedQuery struct {
+	hash  string
+	query *gql.PreparedQuery
+}
+
+type preparedQueryCache struct {
+	sync.RWMutex
+	max     int
+	queries map[preparedKey]*preparedQuery
+}
+
+func (c *preparedQueryCache) get(key preparedKey) *preparedQuery {
+	c.RLock()
+	defer c.RUnlock()
+	return c.queries[key]
+}
+
+func (c *preparedQueryCache) set(key preparedKey, pq *preparedQuery) {
+	c.Lock()
+	defer c.Unlock()
+	if _, ok := c.queries[key]; !ok && len(c.queries) >= c.max {
+		// Evict any query, it is prepared again when a client sends it.
+		for k := range c.queries {
+			delete(c.queries, k)
+			break
+		}
+	}
+	c.queries[key] = pq
+}
+
+// PreparedQueryHash returns the hash of the query, which is given along with its name to run a
+// prepared query.
+func PreparedQueryHash(query string) string {
+	h := sha256.Sum256([]byte(query))
+	return hex.EncodeToString(h[:])
+}
+
+// PrepareQuery lexes the query and keeps it under the given name in the namespace of the request,
+// replacing the query previously prepared under that name. It returns the hash of the query.
+func (s *Server) PrepareQuery(ctx context.Context, name, query string) (string, error) {
+	if name == "" {
+		return "", errors.New("the name of a prepared query can't be empty")
+	}
+	ctx = x.AttachJWTNamespace(ctx)
+	ns, err := x.ExtractNamespace(ctx)
+	if err != nil {
+		return "", err
+	}
+	pq, err := prepareQuery(preparedKey{ns: ns, name: name}, query)
+	if err != nil {
+		return "", err
+	}
+	return pq.hash, nil
+}
+
+func prepareQuery(key preparedKey, query string) (*preparedQuery, error) {
+	if preparedQueries.max <= 0 {
+		return nil, errors.New("prepared queries are disabled, see --limit max-prepared-queries")
+	}
+	q, err := gql.Prepare(query)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while preparing query %q", key.name)
+	}
+	pq := &preparedQuery{hash: PreparedQueryHash(query), query: q}
+	preparedQueries.set(key, pq)
+	return pq, nil
+}
+
+// getPreparedQuery returns the prepared query with the given name and hash to run for the request.
+// If the request gives the query, it is prepared if it wasn't already. Otherwise, the query of
+// the request is set to the prepared one, so that it shows in the logs.
+func getPreparedQuery(ctx context.Context, req *api.Request, name,
+	hash string) (*gql.PreparedQuery, error) {
+	if len(req.GetMutations()) > 0 {
+		return nil, errors.New("prepared queries can't be run along with mutations")
+	}
+	ns, err := x.ExtractNamespace(ctx)
+	if err != nil {
+		return nil, err
+	}
+	key := preparedKey{ns: ns, name: name}
+
+	if req.Query != "" {
+		queryHash := PreparedQueryHash(req.Query)
+		if hash != "" && hash != queryHash {
+			return nil, errors.Errorf("the hash of prepared query %q doesn't match the query",
+				name)
+		}
+		if pq := preparedQueries.get(key); pq != nil && pq.hash == queryHash {
+			return pq.query, nil
+		}
+		pq, err := prepareQuery(key, req.Query)
+		if err != nil {
+			return nil, err
+		}
+		return pq.query, nil
+	}
+
+	pq := preparedQueries.get(key)
+	if pq == nil {
+		return nil, errors.Wrapf(ErrPreparedQueryNotFound, "%q", name)
+	}
+	if hash != "" && hash != pq.hash {
+		return nil, errors.Errorf("prepared query %q has changed, its hash is %s", name, pq.hash)
+	}
+	req.Query = pq.query.Query()
+	return pq.query, nil
+}
@@ -0,0 +1,157 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package edgraph
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/dgraph-io/dgo/v210/protos/api"
+	"github.com/dgraph-io/dgraph/schema"
+	"github.com/dgraph-io/dgraph/types"
+	"github.com/dgraph-io/dgraph/worker"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/metadata"
+)
+
+// checksumPageSize is the number of nodes read at a time while computing a checksum.
+const checksumPageSize = 1000
+
+var errStandby = errors.New("The cluster is a standby, it only accepts the writes replicated " +
+	"from its primary cluster. Run the failover mutation to accept writes.")
+
+// isReplicated returns true if the request comes from the dgraph CDC sink of the primary cluster.
+func isReplicated(ctx context.Context) bool {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false
+	}
+	vals := md.Get(worker.ReplicationHeader)
+	return len(vals) > 0 && vals[0] == "true"
+}
+
+// checkStandby returns an error if this cluster is a standby, and the write with the given
+// context isn't replicated from the primary.
+func checkStandby(ctx context.Context) error {
+	if worker.InStandby() && !isReplicated(ctx) {
+		return errStandby
+	}
+	return nil
+}
+
+// Failover takes the cluster out of standby mode, so that it accepts writes.
+func Failover(ctx context.Context) (*worker.RuntimeConfig, error) {
+	standby := false
+	return UpdateRuntimeConfig(ctx, &worker.RuntimeConfig{Standby: &standby})
+}
+
+// PredicateChecksum is a digest of the data of a predicate in a namespace. Comparing the checksums
+// of the primary and the standby cluster verifies that the standby has replicated the predicate.
+type PredicateChecksum struct {
+	Predicate string
+	// Count is the number of nodes that have the predicate.
+	Count int
+	// Checksum is the hex encoded SHA-256 of the values of the nodes, in the order of their uids.
+	Checksum string
+	// ReadTs is the timestamp at which the data was read.
+	ReadTs uint64
+}
+
+// checksumQuery returns the query for a page of the nodes having the predicate, after the given
+// uid.
+func checksumQuery(ns uint64, pred string, after uint64) string {
+	attr := x.NamespaceAttr(ns, pred)
+	sel := fmt.Sprintf("<%s>", pred)
+	if typ, err := schema.State().TypeOf(attr); err == nil && typ == types.UidID {
+		sel += " { uid }"
+	} else if schema.State().HasLang(attr) {
+		sel += "@*"
+	}
+	return fmt.Sprintf(`{
+		q(func: has(<%s>), first: %d, after: %#x) {
+			uid
+			%s
+		}
+	}`, pred, checksumPageSize, after, sel)
+}
+
+// ChecksumPredicates computes the checksums of the given predicates in the given namespace. All the
+// predicates are read at the same timestamp.
+func ChecksumPredicates(ctx context.Context, ns uint64,
+	preds []string) ([]*PredicateChecksum, error) {
+	ctx = x.AttachNamespace(ctx, ns)
+	var readTs uint64
+	var res []*PredicateChecksum
+	for _, pred := range preds {
+		if x.IsReservedPredicate(pred) {
+			return nil, errors.Errorf("Checksum of the reserved predicate %s is not supported",
+				pred)
+		}
+		h := sha256.New()
+		sum := &PredicateChecksum{Predicate: pred}
+		var after uint64
+		for {
+			req := &Request{
+				req: &api.Request{
+					Query:    checksumQuery(ns, pred, after),
+					StartTs:  readTs,
+					ReadOnly: true,
+				},
+				doAuth: NoAuthorize,
+			}
+			resp, err := (&Server{}).doQuery(ctx, req)
+			if err != nil {
+				return nil, errors.Wrapf(err, "while reading predicate %s", pred)
+			}
+			readTs = resp.GetTxn().GetStartTs()
+
+			var page struct {
+				Q []json.RawMessage `json:"q"`
+			}
+			if err := json.Unmarshal(resp.GetJson(), &page); err != nil {
+				return nil, errors.Wrapf(err, "while unmarshalling predicate %s", pred)
+			}
+			for _, node := range page.Q {
+				var n struct {
+					Uid string `json:"uid"`
+				}
+				if err := json.Unmarshal(node, &n); err != nil {
+					return nil, errors.Wrapf(err, "while unmarshalling predicate %s", pred)
+				}
+				if after, err = strconv.ParseUint(n.Uid, 0, 64); err != nil {
+					return nil, errors.Wrapf(err, "while parsing uid %s", n.Uid)
+				}
+				_, _ = h.Write(node)
+			}
+			sum.Count += len(page.Q)
+			if len(page.Q) < checksumPageSize {
+				break
+			}
+		}
+		sum.Checksum = hex.EncodeToString(h.Sum(nil))
+		res = append(res, sum)
+	}
+	for _, sum := range res {
+		sum.ReadTs = readTs
+	}
+	return res, nil
+}
@@ -0,0 +1,186 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package edgraph
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	bpb "github.com/dgraph-io/badger/v3/pb"
+	"github.com/dgraph-io/dgo/v210/protos/api"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/worker"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/dgraph-io/ristretto/z"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+)
+
+const queryRuntimeConfig = `
+	{
+		config(func: has(dgraph.runtime_config)) {
+			uid
+			dgraph.runtime_config
+		}
+	}`
+
+type runtimeConfigNode struct {
+	Uid    string `json:"uid"`
+	Config string `json:"dgraph.runtime_config"`
+}
+
+// getRuntimeConfig reads the runtime config stored in the galaxy namespace. It returns an empty
+// config if none has been stored yet.
+func getRuntimeConfig(ctx context.Context) (*worker.RuntimeConfig, error) {
+	req := &Request{
+		req: &api.Request{
+			Query:    queryRuntimeConfig,
+			ReadOnly: true,
+		},
+		doAuth: NoAuthorize,
+	}
+	resp, err := (&Server{}).doQuery(x.AttachNamespace(ctx, x.GalaxyNamespace), req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while reading the runtime config")
+	}
+	var result struct {
+		Config []runtimeConfigNode `json:"config"`
+	}
+	if err := json.Unmarshal(resp.GetJson(), &result); err != nil {
+		return nil, errors.Wrapf(err, "while unmarshalling the runtime config")
+	}
+
+	config := &worker.RuntimeConfig{}
+	switch len(result.Config) {
+	case 0:
+		return config, nil
+	case 1:
+		if err := json.Unmarshal([]byte(result.Config[0].Config), config); err != nil {
+			return nil, errors.Wrapf(err, "while unmarshalling the runtime config")
+		}
+		return config, nil
+	}
+	return nil, errors.Errorf("found multiple nodes for the runtime config")
+}
+
+// UpdateRuntimeConfig merges the update into the runtime config stored in the galaxy namespace.
+// The new config is applied to this Alpha right away, and reaches the other Alphas through their
+// subscription to the runtime config. It returns the stored config.
+func UpdateRuntimeConfig(ctx context.Context,
+	update *worker.RuntimeConfig) (*worker.RuntimeConfig, error) {
+	if err := update.Validate(); err != nil {
+		return nil, err
+	}
+	config, err := getRuntimeConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	config.Merge(update)
+	data, err := json.Marshal(config)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while marshalling the runtime config")
+	}
+
+	// An empty variable in uid() creates a new node, so this works whether the config was stored
+	// before or not.
+	req := &Request{
+		req: &api.Request{
+			Query: `{
+				c as var(func: has(dgraph.runtime_config))
+			}`,
+			Mutations: []*api.Mutation{{
+				Set: []*api.NQuad{{
+					Subject:     "uid(c)",
+					Predicate:   worker.RuntimeConfigPred,
+					ObjectValue: &api.Value{Val: &api.Value_StrVal{StrVal: string(data)}},
+				}},
+			}},
+			CommitNow: true,
+		},
+		doAuth: NoAuthorize,
+	}
+	// The runtime config predicate is reserved, so it can only be mutated internally.
+	ctx = context.WithValue(ctx, IsGraphql, true)
+	if _, err := (&Server{}).doQuery(x.AttachNamespace(ctx, x.GalaxyNamespace), req); err != nil {
+		return nil, errors.Wrapf(err, "while storing the runtime config")
+	}
+
+	if err := worker.ApplyRuntimeConfig(config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// RefreshRuntimeConfig applies the stored runtime config to this Alpha, and keeps applying it
+// whenever it is updated by any of the Alphas.
+func RefreshRuntimeConfig(closer *z.Closer) {
+	defer func() {
+		glog.Infoln("RefreshRuntimeConfig closed")
+		closer.Done()
+	}()
+
+	for closer.Ctx().Err() == nil {
+		ctx, cancel := context.WithTimeout(closer.Ctx(), time.Minute)
+		config, err := getRuntimeConfig(ctx)
+		cancel()
+		if err != nil {
+			glog.Infof("Unable to read the runtime config. Error: %v", err)
+			time.Sleep(time.Second)
+			continue
+		}
+		if err := worker.ApplyRuntimeConfig(config); err != nil {
+			glog.Errorf("Unable to apply the runtime config: %v", err)
+		}
+		break
+	}
+
+	prefix := x.PredicatePrefix(x.GalaxyAttr(worker.RuntimeConfigPred))
+	closer.AddRunning(1)
+	go worker.SubscribeForUpdates([][]byte{prefix}, x.IgnoreBytes, func(kvs *bpb.KVList) {
+		if kvs == nil || len(kvs.Kv) == 0 {
+			return
+		}
+		kv := x.KvWithMaxVersion(kvs, [][]byte{prefix})
+		pk, err := x.Parse(kv.GetKey())
+		if err != nil || !pk.IsData() {
+			return
+		}
+		pl := &pb.PostingList{}
+		if err := pl.Unmarshal(kv.GetValue()); err != nil {
+			glog.Errorf("Unable to unmarshal the posting list for runtime config update: %v", err)
+			return
+		}
+		if len(pl.Postings) != 1 {
+			glog.Errorf("Only one posting is expected in the runtime config posting list but "+
+				"got %d", len(pl.Postings))
+			return
+		}
+
+		config := &worker.RuntimeConfig{}
+		if err := json.Unmarshal(pl.Postings[0].Value, config); err != nil {
+			glog.Errorf("Unable to unmarshal the runtime config: %v", err)
+			return
+		}
+		glog.Infof("Updating runtime config from subscription.")
+		if err := worker.ApplyRuntimeConfig(config); err != nil {
+			glog.Errorf("Unable to apply the runtime config: %v", err)
+		}
+	}, 1, closer)
+
+	<-closer.HasBeenClosed()
+}
@@ -0,0 +1,78 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package edgraph
+
+import (
+	"context"
+
+	"github.com/dgraph-io/dgo/v210/protos/api"
+	"github.com/dgraph-io/dgraph/gql"
+	"github.com/dgraph-io/dgraph/schema"
+	"github.com/dgraph-io/dgraph/worker"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/pkg/errors"
+)
+
+// sequenceNQuads returns the positions of the set nquads of the mutations on predicates having
+// the @sequence directive, for each mutation.
+func sequenceNQuads(ctx context.Context, gmuList []*gql.Mutation) ([][]int, int, error) {
+	ns, err := x.ExtractNamespace(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	isGalaxyQuery := x.IsGalaxyOperation(ctx)
+	positions := make([][]int, len(gmuList))
+	var total int
+	for i, gmu := range gmuList {
+		for j, nq := range gmu.Set {
+			if isGalaxyQuery {
+				ns = nq.Namespace
+			}
+			if schema.State().IsSequence(x.NamespaceAttr(ns, nq.Predicate)) {
+				positions[i] = append(positions[i], j)
+				total++
+			}
+		}
+	}
+	return positions, total, nil
+}
+
+// assignSequences sets the values of the set nquads on predicates having the @sequence directive
+// to the next values of the sequence, leased from Zero. The values given by the mutations are
+// replaced, and the values are handed out in the order of the nquads.
+func assignSequences(ctx context.Context, gmuList []*gql.Mutation) error {
+	positions, total, err := sequenceNQuads(ctx, gmuList)
+	if err != nil || total == 0 {
+		return err
+	}
+	ids, err := worker.AssignSequenceOverNetwork(ctx, uint64(total))
+	if err != nil {
+		return errors.Wrapf(err, "while assigning the values of the sequence predicates")
+	}
+
+	next := ids.StartId
+	for i, gmu := range gmuList {
+		for _, j := range positions[i] {
+			// The nquads are copied, as they may belong to the request.
+			nq := *gmu.Set[j]
+			nq.ObjectValue = &api.Value{Val: &api.Value_IntVal{IntVal: int64(next)}}
+			gmu.Set[j] = &nq
+			next++
+		}
+	}
+	return nil
+}
@@ -74,6 +74,9 @@ const (
 	IsGraphql GraphqlContextKey = iota
 	// Authorize is used to set if the request requires validation.
 	Authorize
+	// skipPasswordPolicy is set for internal requests that store passwords which are not subject
+	// to the password policy, like the default password of groot.
+	skipPasswordPolicy
 )
 
 type AuthMode int
@@ -92,7 +95,8 @@ var (
 )
 
 var (
-	errIndexingInProgress = errors.New("errIndexingInProgress. Please retry")
+	errIndexingInProgress = x.WithErrorCode(
+		errors.New("errIndexingInProgress. Please retry"), x.ErrorCodeRetryable)
 )
 
 // Server implements protos.DgraphServer
@@ -156,6 +160,13 @@ func GetGQLSchema(namespace uint64) (uid, graphQLSchema string, err error) {
 	return uid, gql.Schema, nil
 }
 
+// GetGQLSchemaHistory returns the current GraphQL schema of the namespace along with its version
+// and the previous versions of the schema.
+func GetGQLSchemaHistory(namespace uint64) (*x.GQL, error) {
+	_, gql, err := getGQLSchema(namespace)
+	return gql, err
+}
+
 // getGQLSchema queries for the GraphQL schema node, and returns the uid and the GraphQL schema and
 // lambda script.
 // If multiple schema nodes were found, it returns an error.
@@ -188,8 +199,7 @@ func getGQLSchema(namespace uint64) (string, *x.GQL, error) {
 	} else if len(res) == 1 {
 		// we found an existing GraphQL schema
 		gqlSchemaNode := res[0]
-		data.Schema, data.Script = worker.ParseAsSchemaAndScript([]byte(gqlSchemaNode.Schema))
-		return gqlSchemaNode.Uid, data, nil
+		return gqlSchemaNode.Uid, worker.ParseGQL([]byte(gqlSchemaNode.Schema)), nil
 	}
 
 	// found multiple GraphQL schema nodes, this should never happen
@@ -207,8 +217,7 @@ func getGQLSchema(namespace uint64) (string, *x.GQL, error) {
 	})
 	glog.Errorf("namespace: %d. Multiple schema nodes found, using the last one", namespace)
 	resLast := res[len(res)-1]
-	data.Schema, data.Script = worker.ParseAsSchemaAndScript([]byte(resLast.Schema))
-	return resLast.Uid, data, nil
+	return resLast.Uid, worker.ParseGQL([]byte(resLast.Schema)), nil
 }
 
 // UpdateGQLSchema updates the GraphQL and Dgraph schemas using the given inputs.
@@ -217,6 +226,14 @@ func getGQLSchema(namespace uint64) (string, *x.GQL, error) {
 // Then it sends an update request to the worker, which is executed only on Group-1 leader.
 func UpdateGQLSchema(ctx context.Context, gqlSchema,
 	dgraphSchema string) (*pb.UpdateGraphQLSchemaResponse, error) {
+	return UpdateGQLSchemaAtVersion(ctx, gqlSchema, dgraphSchema, 0)
+}
+
+// UpdateGQLSchemaAtVersion is like UpdateGQLSchema, but if version is non-zero, the schemas are
+// only updated if the current GraphQL schema still has that version. This allows to roll back to
+// a previous version of the schema without overwriting a concurrent update.
+func UpdateGQLSchemaAtVersion(ctx context.Context, gqlSchema, dgraphSchema string,
+	version uint64) (*pb.UpdateGraphQLSchemaResponse, error) {
 	var err error
 	parsedDgraphSchema := &schema.ParsedSchema{}
 
@@ -235,11 +252,12 @@ func UpdateGQLSchema(ctx context.Context, gqlSchema,
 	}
 
 	return worker.UpdateGQLSchemaOverNetwork(ctx, &pb.UpdateGraphQLSchemaRequest{
-		StartTs:       worker.State.GetTimestamp(false),
-		GraphqlSchema: gqlSchema,
-		DgraphPreds:   parsedDgraphSchema.Preds,
-		DgraphTypes:   parsedDgraphSchema.Types,
-		Op:            pb.UpdateGraphQLSchemaRequest_SCHEMA,
+		StartTs:         worker.State.GetTimestamp(false),
+		GraphqlSchema:   gqlSchema,
+		DgraphPreds:     parsedDgraphSchema.Preds,
+		DgraphTypes:     parsedDgraphSchema.Types,
+		Op:              pb.UpdateGraphQLSchemaRequest_SCHEMA,
+		ExpectedVersion: version,
 	})
 }
 
@@ -277,6 +295,11 @@ func validateAlterOperation(ctx context.Context, op *api.Operation) error {
 	if !isMutationAllowed(ctx) {
 		return errors.Errorf("No mutations allowed by server.")
 	}
+	if isDropAll(op) || op.DropAttr != "" || op.DropOp != api.Operation_NONE {
+		if err := checkStandby(ctx); err != nil {
+			return err
+		}
+	}
 	if _, err := hasAdminAuth(ctx, "Alter"); err != nil {
 		glog.Warningf("Alter denied with error: %v\n", err)
 		return err
@@ -321,7 +344,7 @@ func parseSchemaFromAlterOperation(ctx context.Context, op *api.Operation) (*sch
 
 	result, err := schema.ParseWithNamespace(op.Schema, namespace)
 	if err != nil {
-		return nil, err
+		return nil, x.WithErrorCode(err, x.ErrorCodeSchema)
 	}
 
 	preds := make(map[string]struct{})
@@ -457,6 +480,11 @@ func (s *Server) Alter(ctx context.Context, op *api.Operation) (*api.Payload, er
 			return empty, err
 		}
 
+		// store empty co-location hints, so all alphas get notified to reset theirs
+		if err := storeColocations(ctx, map[string]string{}); err != nil {
+			return empty, err
+		}
+
 		// insert empty GraphQL schema, so all alphas get notified to
 		// reset their in-memory GraphQL schema
 		// NOTE: As lambda script and graphql schema are stored in same predicate, there is no need
@@ -544,6 +572,13 @@ func (s *Server) Alter(ctx context.Context, op *api.Operation) (*api.Payload, er
 
 		// insert a helper record for backup & restore, indicating that drop_attr was done
 		err = InsertDropRecord(ctx, "DROP_ATTR;"+attr)
+		if err != nil {
+			return empty, err
+		}
+
+		err = updateColocations(ctx, func(hints map[string]string) {
+			delete(hints, attr)
+		})
 		return empty, err
 	}
 
@@ -577,8 +612,18 @@ func (s *Server) Alter(ctx context.Context, op *api.Operation) (*api.Payload, er
 	if err = validateDQLSchemaForGraphQL(ctx, result, namespace); err != nil {
 		return nil, err
 	}
+	if err = validateViews(result); err != nil {
+		return nil, err
+	}
+	if err = schema.ValidateTypeHierarchy(result.Types); err != nil {
+		return nil, err
+	}
 
 	glog.Infof("Got schema: %+v\n", result)
+	// The hints must be in place before the tablets of the new predicates are assigned.
+	if err := updateColocationsForSchema(ctx, result); err != nil {
+		return empty, err
+	}
 	// TODO: Maybe add some checks about the schema.
 	m.Schema = result.Preds
 	m.Types = result.Types
@@ -593,6 +638,7 @@ func (s *Server) Alter(ctx context.Context, op *api.Operation) (*api.Payload, er
 	if err != nil {
 		return empty, errors.Wrapf(err, "During ApplyMutations")
 	}
+	views.invalidate()
 
 	// wait for indexing to complete or context to be canceled.
 	if err = worker.WaitForIndexing(ctx, !op.RunInBackground); err != nil {
@@ -766,10 +812,20 @@ func (s *Server) doMutate(ctx context.Context, qc *queryContext, resp *api.Respo
 		return errors.Errorf("no mutations allowed")
 	}
 
+	if err := checkPreconditions(qc); err != nil {
+		return err
+	}
+	if err := setXids(qc); err != nil {
+		return err
+	}
 	// update mutations from the query results before assigning UIDs
 	if err := updateMutations(qc); err != nil {
 		return err
 	}
+	// The values of the sequence predicates are leased from Zero.
+	if err := assignSequences(ctx, qc.gmuList); err != nil {
+		return err
+	}
 
 	newUids, err := query.AssignUids(ctx, qc.gmuList)
 	if err != nil {
@@ -781,10 +837,27 @@ func (s *Server) doMutate(ctx context.Context, qc *queryContext, resp *api.Respo
 	// 2. For a uid variable that is part of an upsert query,
 	//    like uid(foo), the key would be uid(foo).
 	resp.Uids = query.UidsToHex(query.StripBlankNode(newUids))
+	if qc.xids != nil {
+		resp.Uids = xidUids(qc, resp.Uids)
+	}
 	edges, err := query.ToDirectedEdges(qc.gmuList, newUids)
 	if err != nil {
 		return err
 	}
+	// The nodes detached from their owners by the mutation are deleted before the mutation is
+	// applied.
+	ownedEdges, err := query.OwnedDeletes(ctx, edges, qc.req.StartTs)
+	if err != nil {
+		return err
+	}
+	edges = append(ownedEdges, edges...)
+	if qc.idempotency != nil {
+		recordEdges, err := idempotencyEdges(ctx, qc, resp)
+		if err != nil {
+			return errors.Wrapf(err, "while recording the idempotency key")
+		}
+		edges = append(edges, recordEdges...)
+	}
 	ns, err := x.ExtractNamespace(ctx)
 	if err != nil {
 		return errors.Wrapf(err, "While doing mutations:")
@@ -810,6 +883,11 @@ func (s *Server) doMutate(ctx context.Context, qc *queryContext, resp *api.Respo
 	qc.span.Annotatef(nil, "Applying mutations: %+v", m)
 	resp.Txn, err = query.ApplyMutations(ctx, m)
 	qc.span.Annotatef(nil, "Txn Context: %+v. Err=%v", resp.Txn, err)
+	if err == nil && len(qc.preconditions) > 0 {
+		// The transaction conflicts with the ones writing the predicates of the preconditions
+		// after its start ts, so that the preconditions still hold when it commits.
+		resp.Txn.Keys = append(resp.Txn.Keys, preconditionKeys(qc)...)
+	}
 
 	// calculateMutationMetrics calculate cost for the mutation.
 	calculateMutationMetrics := func() {
@@ -820,7 +898,8 @@ func (s *Server) doMutate(ctx context.Context, qc *queryContext, resp *api.Respo
 	if !qc.req.CommitNow {
 		calculateMutationMetrics()
 		if err == x.ErrConflict {
-			err = status.Error(codes.FailedPrecondition, err.Error())
+			err = x.WithErrorCode(status.Error(codes.FailedPrecondition, err.Error()),
+				x.ErrorCodeConflict)
 		}
 
 		return err
@@ -1061,16 +1140,16 @@ func updateValInNQuads(nquads []*api.NQuad, qc *queryContext, isSet bool) []*api
 func updateValInMutations(gmu *gql.Mutation, qc *queryContext) error {
 	gmu.Del = updateValInNQuads(gmu.Del, qc, false)
 	gmu.Set = updateValInNQuads(gmu.Set, qc, true)
-	if qc.nquadsCount > x.Config.LimitMutationsNquad {
-		return errors.Errorf("NQuad count in the request: %d, is more that threshold: %d",
-			qc.nquadsCount, int(x.Config.LimitMutationsNquad))
+	if limit := x.LimitMutationsNquad(); qc.nquadsCount > limit {
+		return errors.Errorf("NQuad count in the request: %d, is more than the "+
+			"mutations-nquad limit: %d", qc.nquadsCount, limit)
 	}
 	return nil
 }
 
 // updateUIDInMutations does following transformations:
-//   * uid(v) -> 0x123     -- If v is defined in query block
-//   * uid(v) -> _:uid(v)  -- Otherwise
+//   - uid(v) -> 0x123     -- If v is defined in query block
+//   - uid(v) -> _:uid(v)  -- Otherwise
 func updateUIDInMutations(gmu *gql.Mutation, qc *queryContext) error {
 	// usedMutationVars keeps track of variables that are used in mutations.
 	getNewVals := func(s string) []string {
@@ -1115,9 +1194,9 @@ func updateUIDInMutations(gmu *gql.Mutation, qc *queryContext) error {
 				gmuDel = append(gmuDel, getNewNQuad(nq, s, o))
 				qc.nquadsCount++
 			}
-			if qc.nquadsCount > int(x.Config.LimitMutationsNquad) {
-				return errors.Errorf("NQuad count in the request: %d, is more that threshold: %d",
-					qc.nquadsCount, int(x.Config.LimitMutationsNquad))
+			if limit := x.LimitMutationsNquad(); qc.nquadsCount > limit {
+				return errors.Errorf("NQuad count in the request: %d, is more than the "+
+					"mutations-nquad limit: %d", qc.nquadsCount, limit)
 			}
 		}
 	}
@@ -1131,9 +1210,9 @@ func updateUIDInMutations(gmu *gql.Mutation, qc *queryContext) error {
 		newObs := getNewVals(nq.ObjectId)
 
 		qc.nquadsCount += len(newSubs) * len(newObs)
-		if qc.nquadsCount > int(x.Config.LimitQueryEdge) {
-			return errors.Errorf("NQuad count in the request: %d, is more that threshold: %d",
-				qc.nquadsCount, int(x.Config.LimitQueryEdge))
+		if limit := int(x.LimitQueryEdge()); qc.nquadsCount > limit {
+			return errors.Errorf("NQuad count in the request: %d, is more than the "+
+				"query-edge limit: %d", qc.nquadsCount, limit)
 		}
 
 		for _, s := range newSubs {
@@ -1184,6 +1263,18 @@ type queryContext struct {
 	// 1B) and resulting in OOM. We are limiting number of nquads which can be inserted in
 	// a single request.
 	nquadsCount int
+	// prepared is the prepared query to run instead of parsing req.Query, if any.
+	prepared *gql.PreparedQuery
+	// byXid indicates whether the mutations are upserts by external ID, see x.AttachUpsertByXid.
+	byXid bool
+	// xids maps the external IDs of an upsertByXid request to the nodes they name.
+	xids map[string]xidNode
+	// preconditions are the compare-and-set conditions of the mutations, see
+	// x.ExtractPreconditions.
+	preconditions []*precondition
+	// idempotency is the idempotency key whose record is written along with the mutations, if
+	// any.
+	idempotency *idempotencyEntry
 }
 
 // Request represents a query request sent to the doQuery() method on the Server.
@@ -1195,6 +1286,10 @@ type Request struct {
 	gqlField gqlSchema.Field
 	// doAuth tells whether this request needs ACL authorization or not
 	doAuth AuthMode
+	// prepared is the prepared query to run instead of parsing req.Query, if any.
+	prepared *gql.PreparedQuery
+	// idempotency is the idempotency key of the mutations, if any, see doIdempotentQuery.
+	idempotency *idempotencyEntry
 }
 
 // Health handles /health and /health?all requests.
@@ -1218,6 +1313,7 @@ func (s *Server) Health(ctx context.Context, all bool) (*api.Response, error) {
 	}
 
 	// Append self.
+	startup := worker.GetStartupProgress()
 	healthAll = append(healthAll, pb.HealthInfo{
 		Instance:    "alpha",
 		Address:     x.WorkerConfig.MyAddr,
@@ -1230,6 +1326,10 @@ func (s *Server) Health(ctx context.Context, all bool) (*api.Response, error) {
 		Indexing:    schema.GetIndexingPredicates(),
 		EeFeatures:  worker.GetEEFeaturesList(),
 		MaxAssigned: posting.Oracle().MaxAssigned(),
+
+		StartupPhase:   startup.Phase,
+		StartupApplied: startup.Replayed,
+		StartupTotal:   startup.ToReplay,
 	})
 
 	var err error
@@ -1307,10 +1407,10 @@ func (s *Server) QueryGraphQL(ctx context.Context, req *api.Request,
 	// Add a timeout for queries which don't have a deadline set. We don't want to
 	// apply a timeout if it's a mutation, that's currently handled by flag
 	// "txn-abort-after".
-	if req.GetMutations() == nil && x.Config.QueryTimeout != 0 {
+	if timeout := x.QueryTimeout(); req.GetMutations() == nil && timeout != 0 {
 		if d, _ := ctx.Deadline(); d.IsZero() {
 			var cancel context.CancelFunc
-			ctx, cancel = context.WithTimeout(ctx, x.Config.QueryTimeout)
+			ctx, cancel = context.WithTimeout(ctx, timeout)
 			defer cancel()
 		}
 	}
@@ -1331,25 +1431,170 @@ func (s *Server) Query(ctx context.Context, req *api.Request) (*api.Response, er
 			return nil, x.ErrHashMismatch
 		}
 	}
+	if asOf := x.ExtractAsOf(ctx); asOf != "" {
+		if err := setAsOf(req, asOf); err != nil {
+			return nil, err
+		}
+	}
+	if savepoint, rollbackTo := x.ExtractSavepoint(ctx); savepoint != "" || rollbackTo != "" {
+		resp, err := applySavepoint(ctx, req, savepoint, rollbackTo)
+		if err != nil || resp != nil {
+			return resp, err
+		}
+	}
+	var prepared *gql.PreparedQuery
+	if name, hash := x.ExtractPreparedQuery(ctx); name != "" {
+		var err error
+		if prepared, err = getPreparedQuery(ctx, req, name, hash); err != nil {
+			return nil, err
+		}
+	}
 	// Add a timeout for queries which don't have a deadline set. We don't want to
 	// apply a timeout if it's a mutation, that's currently handled by flag
 	// "txn-abort-after".
-	if req.GetMutations() == nil && x.Config.QueryTimeout != 0 {
+	if timeout := x.QueryTimeout(); req.GetMutations() == nil && timeout != 0 {
 		if d, _ := ctx.Deadline(); d.IsZero() {
 			var cancel context.CancelFunc
-			ctx, cancel = context.WithTimeout(ctx, x.Config.QueryTimeout)
+			ctx, cancel = context.WithTimeout(ctx, timeout)
 			defer cancel()
 		}
 	}
-	return s.doQuery(ctx, &Request{req: req, doAuth: getAuthMode(ctx)})
+	r := &Request{req: req, doAuth: getAuthMode(ctx), prepared: prepared}
+	if key := x.ExtractIdempotencyKey(ctx); key != "" {
+		return s.doIdempotentQuery(ctx, r, key)
+	}
+	return s.doQuery(ctx, r)
+}
+
+// setAsOf makes req a time-travel query, which reads the data as it was at asOf. asOf is either a
+// commit ts or a time in RFC3339 format, and must lie within the retained history.
+func setAsOf(req *api.Request, asOf string) error {
+	if req.GetStartTs() != 0 || len(req.GetMutations()) > 0 {
+		return errors.Errorf("asOf can only be set for queries outside of a transaction")
+	}
+	readTs, err := posting.Oracle().ResolveAsOf(asOf)
+	if err != nil {
+		return err
+	}
+	req.StartTs = readTs
+	req.ReadOnly = true
+	return nil
+}
+
+// applySavepoint rolls the transaction of req back to the savepoint named rollbackTo, and then
+// sets the savepoint named savepoint, each of them being optional. The savepoints are kept along
+// with the deltas of the transaction in every group, so the request must be part of a pending
+// transaction. If req has neither a query nor mutations, the response to the request is returned.
+func applySavepoint(ctx context.Context, req *api.Request, savepoint,
+	rollbackTo string) (*api.Response, error) {
+	if req.GetStartTs() == 0 {
+		return nil, errors.Errorf("savepoints can only be used within a transaction")
+	}
+	if req.GetReadOnly() || req.GetBestEffort() {
+		return nil, errors.Errorf("savepoints can't be used in read-only transactions")
+	}
+	if _, err := worker.MutateOverNetwork(ctx, &pb.Mutations{
+		StartTs:    req.StartTs,
+		Savepoint:  savepoint,
+		RollbackTo: rollbackTo,
+	}); err != nil {
+		return nil, errors.Wrapf(err, "while applying savepoint")
+	}
+	if strings.TrimSpace(req.Query) != "" || len(req.Mutations) > 0 {
+		return nil, nil
+	}
+
+	resp := &api.Response{
+		Txn:     &api.TxnContext{StartTs: req.StartTs},
+		Metrics: &api.Metrics{NumUids: map[string]uint64{"_total": 0}},
+		Latency: &api.Latency{},
+	}
+	if x.WorkerConfig.AclEnabled {
+		ns, err := x.ExtractNamespace(ctx)
+		if err != nil {
+			return nil, err
+		}
+		resp.Txn.Hash = getHash(ns, req.StartTs)
+	}
+	return resp, nil
+}
+
+// maxConsistencyWait is the longest a best-effort query waits for the commits up to its
+// consistency token to be applied.
+const maxConsistencyWait = 30 * time.Second
+
+// ConsistencyToken returns the consistency token of the response to a request of the given txn:
+// its commit ts if it was committed, its start ts otherwise. A best-effort query given the token
+// reads the data at least as recent as the request, so a client can read its own writes.
+func ConsistencyToken(txn *api.TxnContext) uint64 {
+	if txn.GetCommitTs() > txn.GetStartTs() {
+		return txn.GetCommitTs()
+	}
+	return txn.GetStartTs()
+}
+
+// waitForConsistencyToken waits for this Alpha to have applied the commits up to the consistency
+// token given along with the best-effort query, if any.
+func waitForConsistencyToken(ctx context.Context) error {
+	token, err := x.ExtractConsistencyToken(ctx)
+	if err != nil || token == 0 {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, maxConsistencyWait)
+	defer cancel()
+	if err := posting.Oracle().WaitForTs(ctx, token); err != nil {
+		return errors.Wrapf(err, "while waiting for the consistency token %d to be applied", token)
+	}
+	return nil
+}
+
+// TxnAgeWarning returns the warning to send along with the response to a request of the txn
+// having the given start ts, if the txn was started longer than --limit "txn-warn-age" ago.
+// Otherwise, it returns an empty string. The age of the txn is the one seen by this Alpha, so only
+// the txns which have written to its group are known.
+func TxnAgeWarning(startTs uint64) string {
+	warnAge := x.WorkerConfig.TxnWarnAge
+	if startTs == 0 || warnAge <= 0 {
+		return ""
+	}
+	startedAt, ok := posting.Oracle().PendingTxnStartedAt(startTs)
+	if !ok {
+		return ""
+	}
+	age := time.Since(startedAt)
+	if age < warnAge {
+		return ""
+	}
+	warning := fmt.Sprintf("transaction %d has been open for %s", startTs, age.Round(time.Second))
+	if maxAge := x.WorkerConfig.TxnMaxAge; maxAge > 0 {
+		warning += fmt.Sprintf(", it is aborted once open for %s", maxAge)
+	}
+	return warning
+}
+
+// SampleWarning returns the warning to send along with the response of a query whose edges were
+// sampled down to its expand sample, or an empty string if no edge was sampled.
+func SampleWarning(ctx context.Context, resp *api.Response) string {
+	num := resp.GetMetrics().GetNumUids()["_sampled"]
+	if num == 0 {
+		return ""
+	}
+	return fmt.Sprintf("the edges of %d nodes were sampled down to %d uids, the results are "+
+		"truncated", num, x.ExtractExpandSample(ctx))
 }
 
 var pendingQueries int64
 var maxPendingQueries int64
-var serverOverloadErr = errors.New("429 Too Many Requests. Please throttle your requests")
+var serverOverloadErr = x.WithErrorCode(
+	errors.New("429 Too Many Requests. Please throttle your requests"),
+	x.ErrorCodeResourceExhausted)
 
 func Init() {
 	maxPendingQueries = x.Config.Limit.GetInt64("max-pending-queries")
+	preparedQueries.max = int(x.Config.Limit.GetInt64("max-prepared-queries"))
+	queryJobs.max = int(x.Config.Limit.GetInt64("max-query-jobs"))
+	queryJobs.ttl = x.Config.Limit.GetDuration("query-job-ttl")
+	queryJobs.timeout = x.Config.Limit.GetDuration("query-job-timeout")
 }
 
 func (s *Server) doQuery(ctx context.Context, req *Request) (resp *api.Response, rerr error) {
@@ -1398,6 +1643,21 @@ func (s *Server) doQuery(ctx context.Context, req *Request) (resp *api.Response,
 		timeSpentMs := x.SinceMs(l.Start)
 		measurements = append(measurements, x.LatencyMs.M(timeSpentMs))
 		ostats.Record(ctx, measurements...)
+
+		if took := time.Since(l.Start); took >= slowQueryThreshold {
+			sq := SlowQuery{
+				Time:     l.Start,
+				Duration: took,
+				StartTs:  req.req.StartTs,
+				Mutation: isMutation,
+				Query:    req.req.Query,
+			}
+			sq.Namespace, _ = x.ExtractNamespace(ctx)
+			if rerr != nil {
+				sq.Error = rerr.Error()
+			}
+			slowQueries.add(sq)
+		}
 	}()
 
 	if rerr = x.HealthCheck(); rerr != nil {
@@ -1432,14 +1692,36 @@ func (s *Server) doQuery(ctx context.Context, req *Request) (resp *api.Response,
 		}
 	}
 
+	if isMutation && req.doAuth == NeedAuthorize {
+		if err := checkStandby(ctx); err != nil {
+			return nil, err
+		}
+	}
+
 	qc := &queryContext{
-		req:      req.req,
-		latency:  l,
-		span:     span,
-		graphql:  isGraphQL,
-		gqlField: req.gqlField,
+		req:         req.req,
+		latency:     l,
+		span:        span,
+		graphql:     isGraphQL,
+		gqlField:    req.gqlField,
+		prepared:    req.prepared,
+		byXid:       x.IsUpsertByXid(ctx),
+		idempotency: req.idempotency,
+	}
+	if qc.preconditions, rerr = extractPreconditions(ctx); rerr != nil {
+		return
 	}
-	if rerr = parseRequest(qc); rerr != nil {
+	if len(qc.preconditions) > 0 && !isMutation {
+		return nil, errors.New("Preconditions can only be given to mutations")
+	}
+	_, parseSpan := otrace.StartSpan(ctx, "Server.parseRequest")
+	rerr = parseRequest(qc)
+	parseSpan.End()
+	if rerr != nil {
+		return
+	}
+	skipPolicy, _ := ctx.Value(skipPasswordPolicy).(bool)
+	if rerr = applyPasswordPolicy(qc, !skipPolicy); rerr != nil {
 		return
 	}
 
@@ -1504,13 +1786,22 @@ func (s *Server) doQuery(ctx context.Context, req *Request) (resp *api.Response,
 		TotalNs:           uint64((time.Since(l.Start)).Nanoseconds()),
 	}
 	md := metadata.Pairs(x.DgraphCostHeader, fmt.Sprint(resp.Metrics.NumUids["_total"]))
+	md.Append(x.DgraphConsistencyTokenHeader, fmt.Sprint(ConsistencyToken(resp.Txn)))
+	if warning := TxnAgeWarning(resp.Txn.GetStartTs()); warning != "" {
+		md.Append(x.DgraphTxnAgeWarningHeader, warning)
+	}
+	if warning := SampleWarning(ctx, resp); warning != "" {
+		md.Append(x.DgraphSampleWarningHeader, warning)
+	}
 	grpc.SendHeader(ctx, md)
 	return resp, gqlErrs
 }
 
 func processQuery(ctx context.Context, qc *queryContext) (*api.Response, error) {
 	resp := &api.Response{}
-	if qc.req.Query == "" {
+	// The query blocks added by the request options, such as the preconditions, are evaluated
+	// even if the request has no query of its own.
+	if qc.req.Query == "" && len(qc.gqlRes.Query) == 0 {
 		// No query, so make the query cost 0.
 		resp.Metrics = &api.Metrics{
 			NumUids: map[string]uint64{"_total": 0},
@@ -1542,7 +1833,13 @@ func processQuery(ctx context.Context, qc *queryContext) (*api.Response, error)
 		if !qc.req.ReadOnly {
 			return resp, errors.Errorf("A best effort query must be read-only.")
 		}
+		if x.IsLinearizable(ctx) {
+			return resp, errors.Errorf("A best effort query can't be linearizable.")
+		}
 		if qc.req.StartTs == 0 {
+			if err := waitForConsistencyToken(ctx); err != nil {
+				return resp, err
+			}
 			qc.req.StartTs = posting.Oracle().MaxAssigned()
 		}
 		qr.Cache = worker.NoCache
@@ -1564,7 +1861,9 @@ func processQuery(ctx context.Context, qc *queryContext) (*api.Response, error)
 		return resp, errors.Wrap(err, "")
 	}
 
-	if len(er.SchemaNode) > 0 || len(er.Types) > 0 {
+	if vars, ok := ctx.Value(query.ValueVarsKey).(*query.ValueVars); ok {
+		*vars = qr.ValueVars()
+	} else if len(er.SchemaNode) > 0 || len(er.Types) > 0 {
 		if err = authorizeSchemaQuery(ctx, &er); err != nil {
 			return resp, err
 		}
@@ -1583,8 +1882,18 @@ func processQuery(ctx context.Context, qc *queryContext) (*api.Response, error)
 			respMap["types"] = formatTypes(er.Types)
 		}
 		resp.Json, err = json.Marshal(respMap)
+		if msgpack, _ := ctx.Value(query.MsgpackKey).(bool); msgpack && err == nil {
+			resp.Json, err = query.JSONToMsgpack(resp.Json)
+		}
 	} else if qc.req.RespFormat == api.Request_RDF {
 		resp.Rdf, err = query.ToRDF(qc.latency, er.Subgraphs)
+	} else if graph, _ := ctx.Value(query.GraphKey).(bool); graph && qc.gqlField == nil {
+		resp.Json, err = query.ToGraph(qc.latency, er.Subgraphs)
+		if msgpack, _ := ctx.Value(query.MsgpackKey).(bool); msgpack && err == nil {
+			resp.Json, err = query.JSONToMsgpack(resp.Json)
+		}
+	} else if msgpack, _ := ctx.Value(query.MsgpackKey).(bool); msgpack && qc.gqlField == nil {
+		resp.Json, err = query.ToMsgpack(qc.latency, er.Subgraphs)
 	} else {
 		resp.Json, err = query.ToJson(ctx, qc.latency, er.Subgraphs, qc.gqlField)
 	}
@@ -1648,6 +1957,10 @@ func processQuery(ctx context.Context, qc *queryContext) (*api.Response, error)
 		total += num
 	}
 	resp.Metrics.NumUids["_total"] = total
+	if er.NumSampled > 0 {
+		// It is added after the total, as it isn't a number of uids read.
+		resp.Metrics.NumUids["_sampled"] = er.NumSampled
+	}
 
 	return resp, err
 }
@@ -1676,6 +1989,18 @@ func parseRequest(qc *queryContext) error {
 		qc.uidRes = make(map[string][]string)
 		qc.valRes = make(map[string]map[uint64]types.Val)
 		upsertQuery = buildUpsertQuery(qc)
+		if qc.byXid {
+			if qc.prepared != nil {
+				return errors.New("Upserts by external ID can't use a prepared query")
+			}
+			upsertQuery = addXidVars(qc, upsertQuery)
+		}
+		if len(qc.preconditions) > 0 {
+			if qc.prepared != nil {
+				return errors.New("Preconditions can't be used with a prepared query")
+			}
+			upsertQuery = addPreconditionVars(qc, upsertQuery)
+		}
 		needVars = findMutationVars(qc)
 		if upsertQuery == "" {
 			if len(needVars) > 0 {
@@ -1688,6 +2013,12 @@ func parseRequest(qc *queryContext) error {
 
 	// parsing the updated query
 	var err error
+	if qc.prepared != nil {
+		if qc.gqlRes, err = qc.prepared.Parse(qc.req.Vars, needVars); err != nil {
+			return err
+		}
+		return validateQuery(qc.gqlRes.Query)
+	}
 	qc.gqlRes, err = gql.ParseWithNeedVars(gql.Request{
 		Str:       upsertQuery,
 		Variables: qc.req.Vars,
@@ -1785,9 +2116,9 @@ func (s *Server) CheckVersion(ctx context.Context, c *api.Check) (v *api.Version
 	return v, nil
 }
 
-//-------------------------------------------------------------------------------------------------
+// -------------------------------------------------------------------------------------------------
 // HELPER FUNCTIONS
-//-------------------------------------------------------------------------------------------------
+// -------------------------------------------------------------------------------------------------
 func isMutationAllowed(ctx context.Context) bool {
 	if worker.Config.MutationsMode != worker.DisallowMutations {
 		return true
@@ -1799,7 +2130,8 @@ func isMutationAllowed(ctx context.Context) bool {
 	return true
 }
 
-var errNoAuth = errors.Errorf("No Auth Token found. Token needed for Admin operations.")
+var errNoAuth = x.WithErrorCode(
+	errors.Errorf("No Auth Token found. Token needed for Admin operations."), x.ErrorCodeAuth)
 
 func hasAdminAuth(ctx context.Context, tag string) (net.Addr, error) {
 	ipAddr, err := x.HasWhitelistedIP(ctx)
@@ -1932,7 +2264,11 @@ func validateNQuads(set, del []*api.NQuad, qc *queryContext) error {
 		if err := validateKeys(nq); err != nil {
 			return errors.Wrapf(err, "key error: %+v", nq)
 		}
-		if err := validateForGraphql(nq, qc.graphql); err != nil {
+		if qc.byXid && nq.Predicate == x.ExternalIdPred {
+			if err := validateXidNQuad(nq); err != nil {
+				return err
+			}
+		} else if err := validateForGraphql(nq, qc.graphql); err != nil {
 			return err
 		}
 	}
@@ -2023,6 +2359,9 @@ func formatTypes(typeList []*pb.TypeUpdate) []map[string]interface{} {
 			fields[i] = m
 		}
 		typeMap["fields"] = fields
+		if len(typ.Extends) > 0 {
+			typeMap["extends"] = typ.Extends
+		}
 
 		res = append(res, typeMap)
 	}
@@ -17,10 +17,16 @@
 package edgraph
 
 import (
+	"context"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/dgraph-io/dgo/v210/protos/api"
 	"github.com/dgraph-io/dgraph/chunker"
+	"github.com/dgraph-io/dgraph/gql"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/schema"
 	"github.com/dgraph-io/dgraph/x"
 	"github.com/stretchr/testify/require"
 )
@@ -119,3 +125,199 @@ func TestValidateKeys(t *testing.T) {
 		})
 	}
 }
+
+func TestSlowQueryLog(t *testing.T) {
+	l := &slowQueryLog{}
+	for i := 0; i < maxSlowQueries+10; i++ {
+		l.add(SlowQuery{StartTs: uint64(i)})
+	}
+	list := l.list()
+	require.Len(t, list, maxSlowQueries)
+	require.Equal(t, uint64(10), list[0].StartTs)
+	require.Equal(t, uint64(maxSlowQueries+9), list[maxSlowQueries-1].StartTs)
+
+	l.add(SlowQuery{Query: strings.Repeat("a", maxSlowQueryLen+1)})
+	list = l.list()
+	require.Len(t, list[maxSlowQueries-1].Query, maxSlowQueryLen+3)
+}
+
+func TestUpsertByXid(t *testing.T) {
+	str := func(s string) *api.Value { return &api.Value{Val: &api.Value_StrVal{StrVal: s}} }
+	qc := &queryContext{
+		byXid:  true,
+		uidRes: make(map[string][]string),
+		gmuList: []*gql.Mutation{{
+			Set: []*api.NQuad{
+				makeNquad("_:alice", "name", str("Alice")),
+				makeNquad("_:alice", x.ExternalIdPred, str("alice")),
+				makeNquadEdge("_:alice", "friend", "_:bob"),
+				makeNquad("_:dg.1.2", "name", str("Eve")),
+			},
+		}},
+	}
+	require.NoError(t, validateXidNQuad(qc.gmuList[0].Set[1]))
+	require.Error(t, validateXidNQuad(makeNquad("_:alice", x.ExternalIdPred, str("bob"))))
+
+	query := addXidVars(qc, "")
+	require.Equal(t, "{\n\t__xid__0 as var(func: eq(dgraph.external_id, \"alice\"))"+
+		"\n\t__xid__1 as var(func: eq(dgraph.external_id, \"bob\"))\n}", query)
+	require.Equal(t, []*api.NQuad{
+		makeNquad("uid(__xid__0)", "name", str("Alice")),
+		makeNquadEdge("uid(__xid__0)", "friend", "uid(__xid__1)"),
+		makeNquad("_:dg.1.2", "name", str("Eve")),
+	}, qc.gmuList[0].Set)
+	require.Len(t, qc.condVars, 1)
+
+	// alice exists, bob is created.
+	qc.uidRes["__xid__0"] = []string{"10"}
+	require.NoError(t, setXids(qc))
+	require.Equal(t, makeNquad("uid(__xid__1)", x.ExternalIdPred, str("bob")),
+		qc.gmuList[0].Set[3])
+	uids := xidUids(qc, map[string]string{"uid(__xid__1)": "0xb", "dg.1.2": "0xc"})
+	require.Equal(t, map[string]string{"alice": "0xa", "bob": "0xb", "dg.1.2": "0xc"}, uids)
+
+	qc.uidRes["__xid__0"] = []string{"10", "12"}
+	require.Error(t, setXids(qc))
+}
+
+func TestBatchMutate(t *testing.T) {
+	require.Equal(t, defaultBatchConcurrency, batchConcurrency(&pb.BatchMutationRequest{}))
+	require.Equal(t, 4, batchConcurrency(&pb.BatchMutationRequest{Concurrency: 4}))
+	require.Equal(t, maxBatchConcurrency,
+		batchConcurrency(&pb.BatchMutationRequest{Concurrency: 100000}))
+
+	// The invalid requests fail on their own, without being applied.
+	mu := []*api.Mutation{{SetNquads: []byte(`_:a <name> "a" .`)}}
+	req := &pb.BatchMutationRequest{Requests: []*api.Request{
+		{Query: "{ q(func: uid(1)) { uid } }"},
+		{StartTs: 10, Mutations: mu},
+		nil,
+	}}
+	resp, err := (&Server{}).BatchMutate(context.Background(), req)
+	require.NoError(t, err)
+	require.Len(t, resp.Results, 3)
+	for _, res := range resp.Results {
+		require.False(t, res.Success)
+		require.NotEmpty(t, res.Error)
+	}
+	require.Contains(t, resp.Results[1].Error, "transaction")
+}
+
+func TestIdempotencyRecord(t *testing.T) {
+	req := &api.Request{
+		Mutations: []*api.Mutation{{SetNquads: []byte(`_:a <name> "a" .`)}},
+		CommitNow: true,
+	}
+	fingerprint, err := requestFingerprint(req)
+	require.NoError(t, err)
+	resp := &api.Response{
+		Txn:  &api.TxnContext{StartTs: 10, CommitTs: 11, Keys: []string{"k"}},
+		Uids: map[string]string{"a": "0x1"},
+	}
+	record, err := idempotencyRecord(fingerprint, resp)
+	require.NoError(t, err)
+
+	got, err := recordedResponse(fingerprint, record)
+	require.NoError(t, err)
+	require.Equal(t, resp.Uids, got.Uids)
+	require.Equal(t, uint64(11), got.Txn.CommitTs)
+	require.Empty(t, got.Txn.Keys)
+	require.NotNil(t, got.Metrics)
+
+	// The key can't be reused by another request.
+	req.Mutations[0].SetNquads = []byte(`_:a <name> "b" .`)
+	other, err := requestFingerprint(req)
+	require.NoError(t, err)
+	_, err = recordedResponse(other, record)
+	require.Error(t, err)
+}
+
+func TestPreconditions(t *testing.T) {
+	val, err := preconditionValue(&api.Value{Val: &api.Value_IntVal{IntVal: 42}})
+	require.NoError(t, err)
+	require.Equal(t, "42", val)
+	_, err = preconditionValue(&api.Value{Val: &api.Value_GeoVal{}})
+	require.Error(t, err)
+
+	qc := &queryContext{
+		uidRes: make(map[string][]string),
+		preconditions: []*precondition{{
+			nquad:   makeNquad("0xa", "balance", &api.Value{Val: &api.Value_IntVal{IntVal: 42}}),
+			uid:     10,
+			filter:  `eq(<balance>, "42")`,
+			varName: "__precondition__0",
+		}},
+	}
+	query := addPreconditionVars(qc, "{ q(func: uid(1)) { uid } }")
+	require.Equal(t, "{ q(func: uid(1)) { uid } \n\t__precondition__0 as var(func: uid(0xa))"+
+		" @filter(eq(<balance>, \"42\"))\n}", query)
+
+	err = checkPreconditions(qc)
+	require.Error(t, err)
+	require.Equal(t, x.ErrorCodePreconditionFailed, x.ErrorCodeOf(err))
+	qc.uidRes["__precondition__0"] = []string{"10"}
+	require.NoError(t, checkPreconditions(qc))
+}
+
+func TestPreconditionPredicates(t *testing.T) {
+	require.NoError(t, schema.ParseBytes([]byte(`
+		balance: int .
+		likes: counter .
+		seen: int @noconflict .
+	`), 1))
+	value := &api.Value{Val: &api.Value_IntVal{IntVal: 42}}
+	p, err := newPrecondition(x.GalaxyNamespace, makeNquad("0xa", "balance", value), 0)
+	require.NoError(t, err)
+	require.Equal(t, `eq(<balance>, "42")`, p.filter)
+	// The writes of these predicates don't conflict, so the preconditions couldn't be checked.
+	for _, pred := range []string{"likes", "seen"} {
+		_, err = newPrecondition(x.GalaxyNamespace, makeNquad("0xa", pred, value), 0)
+		require.Error(t, err, pred)
+	}
+}
+
+func TestQueryJobResults(t *testing.T) {
+	lists, values, err := splitJobResults([]byte(`{"q":[{"a":1},{"a":2},{"a":3}],"r":[],"v":{}}`))
+	require.NoError(t, err)
+	require.Len(t, lists["q"], 3)
+	require.Empty(t, lists["r"])
+	require.Contains(t, values, "v")
+
+	page := pageJobResults(lists, values, 1, 1)
+	require.JSONEq(t, `[{"a":2}]`, string(page["q"]))
+	require.JSONEq(t, `[]`, string(page["r"]))
+	require.JSONEq(t, `{}`, string(page["v"]))
+	page = pageJobResults(lists, values, 1, 0)
+	require.JSONEq(t, `[{"a":2},{"a":3}]`, string(page["q"]))
+	page = pageJobResults(lists, values, 5, 2)
+	require.JSONEq(t, `[]`, string(page["q"]))
+}
+
+func TestQueryJobStore(t *testing.T) {
+	store := &queryJobStore{max: 2, ttl: time.Hour, jobs: make(map[queryJobKey]*queryJob)}
+	now := time.Now()
+	running := func() *queryJob {
+		return &queryJob{cancel: func() {}, submitted: now, status: QueryJobRunning}
+	}
+	a, b := running(), running()
+	require.NoError(t, store.add(queryJobKey{id: "a"}, a))
+	require.NoError(t, store.add(queryJobKey{id: "b"}, b))
+	// All the jobs are running, so no job can be evicted.
+	err := store.add(queryJobKey{id: "c"}, running())
+	require.Error(t, err)
+	require.Equal(t, x.ErrorCodeResourceExhausted, x.ErrorCodeOf(err))
+
+	store.finish(b, &api.Response{Json: []byte(`{"q":[]}`)}, nil)
+	require.Equal(t, QueryJobDone, b.status)
+	require.NoError(t, store.add(queryJobKey{id: "c"}, running()))
+	require.Nil(t, store.get(queryJobKey{id: "b"}))
+	require.NotNil(t, store.get(queryJobKey{id: "a"}))
+	// The jobs are only visible in their namespace.
+	require.Nil(t, store.get(queryJobKey{ns: 1, id: "a"}))
+
+	// The results of the finished jobs expire.
+	store.finish(a, nil, context.Canceled)
+	require.Equal(t, QueryJobFailed, a.status)
+	a.finished = now.Add(-2 * time.Hour)
+	require.Nil(t, store.get(queryJobKey{id: "a"}))
+}
@@ -0,0 +1,282 @@
+// +build !oss
+
+/*
+ * Copyright 2021 Dgraph Labs, Inc. All rights reserved.
+ *
+ * Licensed under the Dgraph Community License (the "License"); you
+ * may not use this file except in compliance with the License. You
+ * may obtain a copy of the License at
+ *
+ *     https://github.com/dgraph-io/dgraph/blob/master/licenses/DCL.txt
+ */
+
+package edgraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/dgo/v210/protos/api"
+	"github.com/dgraph-io/dgraph/worker"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/pkg/errors"
+)
+
+// Session is a login session of an ACL user. All the access and refresh JWTs created from the
+// same login, including the ones created by refreshing, share the session.
+type Session struct {
+	SessionId string    `json:"dgraph.session.id"`
+	UserId    string    `json:"dgraph.session.user"`
+	Expiry    time.Time `json:"dgraph.session.expiry"`
+	Revoked   bool      `json:"dgraph.session.revoked"`
+}
+
+// revocationCache holds the sessions that have been revoked and have not expired yet. It is
+// checked every time a JWT is validated, so it is kept in memory and refreshed via subscription.
+type revocationCache struct {
+	sync.RWMutex
+	// revoked maps the namespace to the revoked session ids and their expiry.
+	revoked map[uint64]map[string]time.Time
+}
+
+var revokedSessions = &revocationCache{revoked: make(map[uint64]map[string]time.Time)}
+
+func (c *revocationCache) isRevoked(sessionId string) bool {
+	c.RLock()
+	defer c.RUnlock()
+	for _, sessions := range c.revoked {
+		if _, ok := sessions[sessionId]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *revocationCache) update(ns uint64, sessions []Session) {
+	now := time.Now()
+	revoked := make(map[string]time.Time, len(sessions))
+	for _, s := range sessions {
+		// Expired sessions are rejected by the JWT validation anyway.
+		if s.Revoked && s.Expiry.After(now) {
+			revoked[s.SessionId] = s.Expiry
+		}
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	c.revoked[ns] = revoked
+}
+
+// recordSession stores a new session for the user. The expired sessions of the user are removed
+// at the same time, so the number of stored sessions stays bounded by the active ones. It must
+// be called after setting the namespace in the context.
+func recordSession(ctx context.Context, sessionId, userId string, expiry time.Time) error {
+	query := `
+		query search($userid: string, $now: string) {
+			expired as var(func: eq(dgraph.session.user, $userid))
+				@filter(type(dgraph.type.Session) AND lt(dgraph.session.expiry, $now))
+		}`
+	now := time.Now().UTC().Format(time.RFC3339)
+	nquads := []*api.NQuad{
+		{
+			Subject:     "_:session",
+			Predicate:   "dgraph.session.id",
+			ObjectValue: &api.Value{Val: &api.Value_StrVal{StrVal: sessionId}},
+		},
+		{
+			Subject:     "_:session",
+			Predicate:   "dgraph.session.user",
+			ObjectValue: &api.Value{Val: &api.Value_StrVal{StrVal: userId}},
+		},
+		{
+			Subject:   "_:session",
+			Predicate: "dgraph.session.expiry",
+			ObjectValue: &api.Value{Val: &api.Value_StrVal{
+				StrVal: expiry.UTC().Format(time.RFC3339)}},
+		},
+		{
+			Subject:     "_:session",
+			Predicate:   "dgraph.type",
+			ObjectValue: &api.Value{Val: &api.Value_StrVal{StrVal: "dgraph.type.Session"}},
+		},
+	}
+	req := &Request{
+		req: &api.Request{
+			CommitNow: true,
+			Query:     query,
+			Vars:      map[string]string{"$userid": userId, "$now": now},
+			Mutations: []*api.Mutation{
+				{
+					Set: nquads,
+					Del: []*api.NQuad{{
+						Subject:     "uid(expired)",
+						Predicate:   x.Star,
+						ObjectValue: &api.Value{Val: &api.Value_DefaultVal{DefaultVal: x.Star}},
+					}},
+				},
+			},
+		},
+		doAuth: NoAuthorize,
+	}
+	_, err := (&Server{}).doQuery(ctx, req)
+	return errors.Wrapf(err, "while recording session for user %s", userId)
+}
+
+const queryRevokedSessions = `
+	{
+		sessions(func: has(dgraph.session.revoked)) @filter(type(dgraph.type.Session)) {
+			dgraph.session.id
+			dgraph.session.expiry
+			dgraph.session.revoked
+		}
+	}`
+
+func unmarshalSessions(resp *api.Response) ([]Session, error) {
+	var result struct {
+		Sessions []Session `json:"sessions"`
+	}
+	if err := json.Unmarshal(resp.GetJson(), &result); err != nil {
+		return nil, errors.Wrapf(err, "while unmarshalling sessions")
+	}
+	return result.Sessions, nil
+}
+
+// retrieveRevokedSessions reads the revoked sessions of the namespace in the context and updates
+// the revocation cache.
+func retrieveRevokedSessions(ctx context.Context, ns uint64, readTs uint64) error {
+	req := &Request{
+		req: &api.Request{
+			Query:    queryRevokedSessions,
+			ReadOnly: true,
+			StartTs:  readTs,
+		},
+		doAuth: NoAuthorize,
+	}
+	resp, err := (&Server{}).doQuery(x.AttachNamespace(ctx, ns), req)
+	if err != nil {
+		return errors.Wrapf(err, "unable to retrieve revoked sessions")
+	}
+	sessions, err := unmarshalSessions(resp)
+	if err != nil {
+		return err
+	}
+	revokedSessions.update(ns, sessions)
+	return nil
+}
+
+// sessionContext authorizes the caller as a guardian and attaches the namespace of the caller to
+// the context.
+func sessionContext(ctx context.Context) (context.Context, error) {
+	if len(worker.Config.HmacSecret) == 0 {
+		return nil, errors.New("sessions are only available when ACL is enabled")
+	}
+	if err := AuthorizeGuardians(ctx); err != nil {
+		return nil, err
+	}
+	ns, err := x.ExtractJWTNamespace(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return x.AttachNamespace(ctx, ns), nil
+}
+
+// ListSessions returns the unexpired sessions of the given user, or of all the users if userId is
+// empty, in the namespace of the caller. Only guardians are allowed to list sessions.
+func ListSessions(ctx context.Context, userId string) ([]Session, error) {
+	ctx, err := sessionContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	filter := "gt(dgraph.session.expiry, $now)"
+	vars := map[string]string{"$now": time.Now().UTC().Format(time.RFC3339)}
+	if userId != "" {
+		filter += " AND eq(dgraph.session.user, $userid)"
+		vars["$userid"] = userId
+	}
+	query := fmt.Sprintf(`
+		query sessions($now: string, $userid: string) {
+			sessions(func: type(dgraph.type.Session)) @filter(%s) {
+				dgraph.session.id
+				dgraph.session.user
+				dgraph.session.expiry
+				dgraph.session.revoked
+			}
+		}`, filter)
+	req := &Request{
+		req: &api.Request{
+			Query:    query,
+			Vars:     vars,
+			ReadOnly: true,
+		},
+		doAuth: NoAuthorize,
+	}
+	resp, err := (&Server{}).doQuery(ctx, req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while listing sessions")
+	}
+	return unmarshalSessions(resp)
+}
+
+// RevokeSessions revokes the session with the given id, or all the sessions of the given user if
+// sessionId is empty, in the namespace of the caller. All the access and refresh JWTs of a revoked
+// session are rejected by every Alpha once the revocation reaches it via subscription. It returns
+// the number of sessions revoked. Only guardians are allowed to revoke sessions.
+func RevokeSessions(ctx context.Context, sessionId, userId string) (int, error) {
+	ctx, err := sessionContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if sessionId == "" && userId == "" {
+		return 0, errors.New("either the session id or the user id must be provided")
+	}
+
+	var filters []string
+	vars := make(map[string]string)
+	if sessionId != "" {
+		filters = append(filters, "eq(dgraph.session.id, $sid)")
+		vars["$sid"] = sessionId
+	}
+	if userId != "" {
+		filters = append(filters, "eq(dgraph.session.user, $userid)")
+		vars["$userid"] = userId
+	}
+	query := fmt.Sprintf(`
+		query sessions($sid: string, $userid: string) {
+			s as sessions(func: type(dgraph.type.Session)) @filter(%s) {
+				uid
+			}
+		}`, strings.Join(filters, " AND "))
+	req := &Request{
+		req: &api.Request{
+			CommitNow: true,
+			Query:     query,
+			Vars:      vars,
+			Mutations: []*api.Mutation{{
+				Set: []*api.NQuad{{
+					Subject:     "uid(s)",
+					Predicate:   "dgraph.session.revoked",
+					ObjectValue: &api.Value{Val: &api.Value_BoolVal{BoolVal: true}},
+				}},
+				Cond: "@if(gt(len(s), 0))",
+			}},
+		},
+		doAuth: NoAuthorize,
+	}
+	resp, err := (&Server{}).doQuery(ctx, req)
+	if err != nil {
+		return 0, errors.Wrapf(err, "while revoking sessions")
+	}
+	var result struct {
+		Sessions []struct {
+			Uid string `json:"uid"`
+		} `json:"sessions"`
+	}
+	if err := json.Unmarshal(resp.GetJson(), &result); err != nil {
+		return 0, errors.Wrapf(err, "while unmarshalling revoked sessions")
+	}
+	return len(result.Sessions), nil
+}
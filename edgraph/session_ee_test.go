@@ -0,0 +1,42 @@
+// +build !oss
+
+/*
+ * Copyright 2021 Dgraph Labs, Inc. All rights reserved.
+ *
+ * Licensed under the Dgraph Community License (the "License"); you
+ * may not use this file except in compliance with the License. You
+ * may obtain a copy of the License at
+ *
+ *     https://github.com/dgraph-io/dgraph/blob/master/licenses/DCL.txt
+ */
+
+package edgraph
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRevocationCache(t *testing.T) {
+	c := &revocationCache{revoked: make(map[uint64]map[string]time.Time)}
+	future := time.Now().Add(time.Hour)
+	past := time.Now().Add(-time.Hour)
+
+	c.update(0, []Session{
+		{SessionId: "s1", Expiry: future, Revoked: true},
+		{SessionId: "s2", Expiry: future, Revoked: false},
+		{SessionId: "s3", Expiry: past, Revoked: true},
+	})
+	c.update(1, []Session{{SessionId: "s4", Expiry: future, Revoked: true}})
+	require.True(t, c.isRevoked("s1"))
+	require.False(t, c.isRevoked("s2"))
+	require.False(t, c.isRevoked("s3"))
+	require.True(t, c.isRevoked("s4"))
+
+	// An update replaces the revocations of the namespace.
+	c.update(0, nil)
+	require.False(t, c.isRevoked("s1"))
+	require.True(t, c.isRevoked("s4"))
+}
@@ -0,0 +1,81 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package edgraph
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// slowQueryThreshold is the latency beyond which a request is kept in the slow query log.
+	slowQueryThreshold = time.Second
+	// maxSlowQueries is the number of most recent slow requests kept.
+	maxSlowQueries = 100
+	// maxSlowQueryLen is the max length of the query text kept for a slow request.
+	maxSlowQueryLen = 4 << 10
+)
+
+// SlowQuery is a request which took longer than slowQueryThreshold. The variables aren't kept,
+// as they can hold sensitive values.
+type SlowQuery struct {
+	Time      time.Time     `json:"time"`
+	Duration  time.Duration `json:"duration"`
+	Namespace uint64        `json:"namespace"`
+	StartTs   uint64        `json:"startTs,omitempty"`
+	Mutation  bool          `json:"mutation,omitempty"`
+	Query     string        `json:"query,omitempty"`
+	Error     string        `json:"error,omitempty"`
+}
+
+// slowQueryLog is a ring buffer of the most recent slow requests.
+type slowQueryLog struct {
+	sync.Mutex
+	queries []SlowQuery
+	next    int
+}
+
+var slowQueries = &slowQueryLog{}
+
+func (l *slowQueryLog) add(q SlowQuery) {
+	if len(q.Query) > maxSlowQueryLen {
+		q.Query = q.Query[:maxSlowQueryLen] + "..."
+	}
+	l.Lock()
+	defer l.Unlock()
+	if len(l.queries) < maxSlowQueries {
+		l.queries = append(l.queries, q)
+		return
+	}
+	l.queries[l.next] = q
+	l.next = (l.next + 1) % maxSlowQueries
+}
+
+// list returns the slow requests, from the oldest to the most recent.
+func (l *slowQueryLog) list() []SlowQuery {
+	l.Lock()
+	defer l.Unlock()
+	res := make([]SlowQuery, 0, len(l.queries))
+	res = append(res, l.queries[l.next:]...)
+	return append(res, l.queries[:l.next]...)
+}
+
+// RecentSlowQueries returns the most recent requests served by this Alpha which took longer than
+// a second, from the oldest to the most recent.
+func RecentSlowQueries() []SlowQuery {
+	return slowQueries.list()
+}
@@ -0,0 +1,108 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package edgraph
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync/atomic"
+
+	"github.com/dgraph-io/dgraph/gql"
+	"github.com/dgraph-io/dgraph/query"
+	"github.com/dgraph-io/dgraph/sparql"
+	"github.com/dgraph-io/dgraph/worker"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/golang/glog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Sparql runs a SPARQL SELECT query, at a fresh read-only timestamp.
+func (s *Server) Sparql(ctx context.Context, q string) (*sparql.Results, error) {
+	ctx = x.AttachJWTNamespace(ctx)
+	if timeout := x.QueryTimeout(); timeout != 0 {
+		if d, _ := ctx.Deadline(); d.IsZero() {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+	}
+	defer atomic.AddInt64(&pendingQueries, -1)
+	if val := atomic.AddInt64(&pendingQueries, 1); val > maxPendingQueries {
+		return nil, serverOverloadErr
+	}
+	if bool(glog.V(3)) || worker.LogRequestEnabled() {
+		glog.Infof("Got a SPARQL query: %s", q)
+	}
+	if err := x.HealthCheck(); err != nil {
+		return nil, err
+	}
+
+	parsed, err := sparql.Parse(q)
+	if err != nil {
+		return nil, err
+	}
+	if getAuthMode(ctx) == NeedAuthorize {
+		if err := authorizeSparql(ctx, parsed); err != nil {
+			return nil, err
+		}
+	}
+	return query.ProcessSparql(ctx, parsed, worker.State.GetTimestamp(true))
+}
+
+// authorizeSparql checks that the user can read all the predicates of the query. Unlike DQL
+// queries, the patterns with blocked predicates fail the query instead of being dropped.
+func authorizeSparql(ctx context.Context, q *sparql.Query) error {
+	preds := make(map[string]struct{})
+	sparqlPreds(q.Where, preds)
+	// The predicates are authorized as the children of a DQL block, which are dropped if they
+	// are blocked.
+	root := &gql.GraphQuery{}
+	for pred := range preds {
+		root.Children = append(root.Children, &gql.GraphQuery{Attr: pred})
+	}
+	if err := authorizeQuery(ctx, &gql.Result{Query: []*gql.GraphQuery{root}}, false); err != nil {
+		return err
+	}
+	for _, child := range root.Children {
+		delete(preds, child.Attr)
+	}
+	if len(preds) == 0 {
+		return nil
+	}
+	blocked := make([]string, 0, len(preds))
+	for pred := range preds {
+		blocked = append(blocked, pred)
+	}
+	sort.Strings(blocked)
+	return status.Errorf(codes.PermissionDenied,
+		"unauthorized to query following predicates: %s", strings.Join(blocked, " "))
+}
+
+func sparqlPreds(g *sparql.Group, preds map[string]struct{}) {
+	for _, t := range g.Triples {
+		pred := t.Predicate.Value
+		if pred == sparql.RDFType {
+			pred = "dgraph.type"
+		}
+		preds[pred] = struct{}{}
+	}
+	for _, opt := range g.Optionals {
+		sparqlPreds(opt, preds)
+	}
+}
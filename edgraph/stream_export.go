@@ -0,0 +1,82 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package edgraph
+
+import (
+	"context"
+
+	"github.com/dgraph-io/dgraph/ee/enc"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/worker"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/pkg/errors"
+)
+
+// validateStreamExport checks the export request of a streamed export, and restricts it to the
+// namespace of the caller unless it's a guardian of the galaxy.
+func validateStreamExport(ctx context.Context, req *pb.ExportRequest) error {
+	if err := AuthorizeGuardians(ctx); err != nil {
+		return err
+	}
+	ns, err := x.ExtractNamespace(x.AttachJWTNamespace(ctx))
+	if err != nil {
+		return err
+	}
+	if ns != x.GalaxyNamespace {
+		if req.Namespace != 0 && req.Namespace != ns {
+			return errors.Errorf("not allowed to export namespace %#x", req.Namespace)
+		}
+		req.Namespace = ns
+	}
+
+	req.Format = worker.NormalizeExportFormat(req.Format)
+	if req.Format == "" {
+		req.Format = worker.DefaultExportFormat
+	}
+	if req.Recipient != "" {
+		if _, err := enc.ParseAgeRecipient(req.Recipient); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// StreamExport exports or backs up the data of the cluster, streaming the files to the caller
+// instead of writing them at a destination the Alphas must reach. A streamed backup is always a
+// full backup, and its manifest is streamed along with its files. Backups are restricted to the
+// guardians of the galaxy, and exports to the guardians.
+func (s *Server) StreamExport(req *pb.StreamExportRequest,
+	stream pb.Alpha_StreamExportServer) error {
+	ctx := stream.Context()
+	if _, err := hasAdminAuth(ctx, "StreamExport"); err != nil {
+		return err
+	}
+
+	switch {
+	case req.GetExport() != nil && req.GetBackup() == nil:
+		if err := validateStreamExport(ctx, req.Export); err != nil {
+			return err
+		}
+		return worker.StreamExportOverNetwork(ctx, req.Export, stream.Send)
+	case req.GetBackup() != nil && req.GetExport() == nil:
+		if err := AuthGuardianOfTheGalaxy(ctx); err != nil {
+			return err
+		}
+		return worker.StreamBackupOverNetwork(ctx, req.Backup, stream.Send)
+	}
+	return errors.New("Exactly one of export and backup must be set")
+}
@@ -0,0 +1,308 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package edgraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/dgo/v210/protos/api"
+	"github.com/dgraph-io/dgraph/gql"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/schema"
+	"github.com/dgraph-io/dgraph/worker"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+)
+
+// A view is a predicate whose values are maintained by a DQL query, given with the @view
+// directive in the schema. Once a transaction is committed, the query is run with the $changed
+// variable set to the comma separated uids touched by the transaction on the predicates read by
+// the query. It must have a block named view, returning the uid of each node to refresh along
+// with its new value, e.g.
+//
+//	query v($changed: string) {
+//		var(func: uid($changed)) { c as ~orders }
+//		view(func: uid(c)) {
+//			uid
+//			value: sum(val(amount))
+//		}
+//	}
+//
+// The nodes returned without a value have the value of the view deleted. The views are refreshed
+// from the CDC events, so --cdc "views=true;" must be set for them to be maintained. A view can't
+// read its own predicate, and the changes to view predicates don't refresh other views, to avoid
+// refreshing the views in loops.
+const (
+	viewBlock      = "view"
+	viewValue      = "value"
+	viewChangedVar = "$changed"
+
+	// viewCacheTTL is how long the views are cached before being fetched again. The views changed
+	// by an Alter on another Alpha are picked up after at most this long.
+	viewCacheTTL = 10 * time.Second
+	// viewRefreshTimeout bounds the time to run the query and the mutation of a view.
+	viewRefreshTimeout = time.Minute
+)
+
+func init() {
+	worker.SetViewRefresher(refreshViews)
+}
+
+type view struct {
+	pred  string
+	query string
+	// reads are the predicates read by the query.
+	reads map[string]struct{}
+}
+
+// newView parses and validates the query of the view on the given predicate.
+func newView(pred, query string) (*view, error) {
+	res, err := gql.Parse(gql.Request{
+		Str:       query,
+		Variables: map[string]string{viewChangedVar: "0x1"},
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "while parsing the query of view %s", pred)
+	}
+	if res.Schema != nil {
+		return nil, errors.Errorf("The query of view %s can't be a schema query", pred)
+	}
+
+	v := &view{pred: pred, query: query, reads: make(map[string]struct{})}
+	hasBlock := false
+	for _, gq := range res.Query {
+		if gq.Alias == viewBlock {
+			hasBlock = true
+		}
+		if err := v.addReads(gq); err != nil {
+			return nil, err
+		}
+	}
+	if !hasBlock {
+		return nil, errors.Errorf("The query of view %s must have a block named %s", pred,
+			viewBlock)
+	}
+	if _, ok := v.reads[pred]; ok {
+		return nil, errors.Errorf("The query of view %s can't read its own predicate", pred)
+	}
+	return v, nil
+}
+
+func (v *view) addReads(gq *gql.GraphQuery) error {
+	if gq == nil {
+		return nil
+	}
+	if gq.IsExpand() {
+		return errors.Errorf("The query of view %s can't use expand", v.pred)
+	}
+	v.addRead(gq.Attr)
+	if gq.Func != nil {
+		v.addRead(gq.Func.Attr)
+	}
+	v.addFilterReads(gq.Filter)
+	for _, order := range gq.Order {
+		v.addRead(order.Attr)
+	}
+	for _, child := range gq.Children {
+		if err := v.addReads(child); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v *view) addFilterReads(ft *gql.FilterTree) {
+	if ft == nil {
+		return
+	}
+	if ft.Func != nil {
+		v.addRead(ft.Func.Attr)
+	}
+	for _, child := range ft.Child {
+		v.addFilterReads(child)
+	}
+}
+
+func (v *view) addRead(attr string) {
+	if attr = strings.TrimPrefix(attr, "~"); attr != "" {
+		v.reads[attr] = struct{}{}
+	}
+}
+
+// refresh runs the query of the view for the given changed uids, and writes the values it returns.
+func (v *view) refresh(ctx context.Context, changed []uint64) error {
+	uids := make([]string, 0, len(changed))
+	for _, uid := range changed {
+		uids = append(uids, fmt.Sprintf("%#x", uid))
+	}
+	resp, err := (&Server{}).doQuery(ctx, &Request{
+		req: &api.Request{
+			Query:    v.query,
+			Vars:     map[string]string{viewChangedVar: strings.Join(uids, ", ")},
+			ReadOnly: true,
+		},
+		doAuth: NoAuthorize,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "while running the query of view %s", v.pred)
+	}
+	var result map[string][]map[string]json.RawMessage
+	if err := json.Unmarshal(resp.GetJson(), &result); err != nil {
+		return errors.Wrapf(err, "while reading the result of view %s", v.pred)
+	}
+
+	var set, del []map[string]interface{}
+	for _, node := range result[viewBlock] {
+		uid, ok := node["uid"]
+		if !ok {
+			return errors.Errorf("The block %s of view %s must return the uid", viewBlock, v.pred)
+		}
+		val, ok := node[viewValue]
+		if !ok || string(val) == "null" {
+			del = append(del, map[string]interface{}{"uid": uid, v.pred: nil})
+			continue
+		}
+		set = append(set, map[string]interface{}{"uid": uid, v.pred: val})
+	}
+	if len(set) == 0 && len(del) == 0 {
+		return nil
+	}
+
+	mu := &api.Mutation{}
+	if len(set) > 0 {
+		if mu.SetJson, err = json.Marshal(set); err != nil {
+			return err
+		}
+	}
+	if len(del) > 0 {
+		if mu.DeleteJson, err = json.Marshal(del); err != nil {
+			return err
+		}
+	}
+	_, err = (&Server{}).doQuery(ctx, &Request{
+		req: &api.Request{
+			Mutations: []*api.Mutation{mu},
+			CommitNow: true,
+		},
+		doAuth: NoAuthorize,
+	})
+	return errors.Wrapf(err, "while writing the values of view %s", v.pred)
+}
+
+// viewCache caches the views of all the namespaces. The views are stored in the schema of the
+// predicates, which can be served by any group, so they are fetched over the network.
+type viewCache struct {
+	sync.Mutex
+	views     map[uint64][]*view
+	fetchedAt time.Time
+}
+
+var views viewCache
+
+func (c *viewCache) get(ctx context.Context, ns uint64) ([]*view, error) {
+	c.Lock()
+	defer c.Unlock()
+	if c.views != nil && time.Since(c.fetchedAt) < viewCacheTTL {
+		return c.views[ns], nil
+	}
+
+	nodes, err := worker.GetSchemaOverNetwork(ctx, &pb.SchemaRequest{
+		Fields: []string{"view"},
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "while fetching the views")
+	}
+	c.views = make(map[uint64][]*view)
+	for _, node := range nodes {
+		if node.GetView() == "" {
+			continue
+		}
+		vns, pred := x.ParseNamespaceAttr(node.GetPredicate())
+		v, err := newView(pred, node.GetView())
+		if err != nil {
+			// The view was validated when the schema was altered.
+			glog.Errorf("Skipping view %s in namespace %#x: %v", pred, vns, err)
+			continue
+		}
+		c.views[vns] = append(c.views[vns], v)
+	}
+	c.fetchedAt = time.Now()
+	return c.views[ns], nil
+}
+
+// invalidate drops the cached views, so that they are fetched again on the next refresh.
+func (c *viewCache) invalidate() {
+	c.Lock()
+	defer c.Unlock()
+	c.views = nil
+}
+
+// refreshViews refreshes the views of the namespace which read the predicates changed by a
+// transaction. The errors are logged, as there's no client to return them to.
+func refreshViews(ns, commitTs uint64, changed map[string][]uint64) {
+	ctx, cancel := context.WithTimeout(context.Background(), viewRefreshTimeout)
+	defer cancel()
+	ctx = x.AttachNamespace(ctx, ns)
+
+	vs, err := views.get(ctx, ns)
+	if err != nil {
+		glog.Errorf("Unable to refresh the views for commit ts %d: %v", commitTs, err)
+		return
+	}
+	for _, v := range vs {
+		// The writes of the views don't refresh other views.
+		delete(changed, v.pred)
+	}
+	for _, v := range vs {
+		seen := make(map[uint64]struct{})
+		var uids []uint64
+		for pred := range v.reads {
+			for _, uid := range changed[pred] {
+				if _, ok := seen[uid]; !ok {
+					seen[uid] = struct{}{}
+					uids = append(uids, uid)
+				}
+			}
+		}
+		if len(uids) == 0 {
+			continue
+		}
+		if err := v.refresh(ctx, uids); err != nil {
+			glog.Errorf("Unable to refresh view %s in namespace %#x for commit ts %d: %v",
+				v.pred, ns, commitTs, err)
+		}
+	}
+}
+
+// validateViews validates the queries of the views declared in the schema being altered.
+func validateViews(result *schema.ParsedSchema) error {
+	for _, update := range result.Preds {
+		if update.GetView() == "" {
+			continue
+		}
+		if _, err := newView(x.ParseAttr(update.Predicate), update.GetView()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
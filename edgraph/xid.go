@@ -0,0 +1,177 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package edgraph
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dgraph-io/dgo/v210/protos/api"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/pkg/errors"
+)
+
+// xidNode is a node named by an external ID in an upsertByXid request.
+type xidNode struct {
+	// varName is the uid variable holding the node having the external ID.
+	varName string
+	// mu is the index of the first mutation of the request naming the node.
+	mu int
+}
+
+// externalId returns the external ID naming the given node in an upsertByXid request, if any. The
+// blank nodes generated for the JSON objects without a uid aren't external IDs.
+func externalId(node string) (string, bool) {
+	if !strings.HasPrefix(node, "_:") || strings.HasPrefix(node, "_:dg.") {
+		return "", false
+	}
+	return node[2:], true
+}
+
+// validateXidNQuad checks that a set nquad of an upsertByXid request on ExternalIdPred sets the
+// external ID naming its subject, which is the only value allowed.
+func validateXidNQuad(nq *api.NQuad) error {
+	var val string
+	switch v := nq.ObjectValue.GetVal().(type) {
+	case *api.Value_StrVal:
+		val = v.StrVal
+	case *api.Value_DefaultVal:
+		val = v.DefaultVal
+	}
+	xid, ok := externalId(nq.Subject)
+	if !ok || val != xid || nq.Lang != "" || len(nq.Facets) > 0 {
+		return errors.Errorf("%s can only be set to the external ID of the node: %+v",
+			x.ExternalIdPred, nq)
+	}
+	return nil
+}
+
+// addXidVars replaces the external IDs in the mutations of an upsertByXid request with uid
+// variables, and returns the upsert query extended with the queries of the variables. The nquads
+// setting the external IDs are dropped, as setXids sets them on the new nodes.
+func addXidVars(qc *queryContext, upsertQuery string) string {
+	qc.xids = make(map[string]xidNode)
+	var xids []string
+	rename := func(node string, mu int) string {
+		xid, ok := externalId(node)
+		if !ok {
+			return node
+		}
+		n, ok := qc.xids[xid]
+		if !ok {
+			n = xidNode{varName: "__xid__" + strconv.Itoa(len(xids)), mu: mu}
+			qc.xids[xid] = n
+			xids = append(xids, xid)
+		}
+		return "uid(" + n.varName + ")"
+	}
+	renameAll := func(nquads []*api.NQuad, mu int, isSet bool) []*api.NQuad {
+		res := make([]*api.NQuad, 0, len(nquads))
+		for _, nq := range nquads {
+			// The nquads are copied, as they may belong to the request.
+			n := *nq
+			n.Subject = rename(nq.Subject, mu)
+			n.ObjectId = rename(nq.ObjectId, mu)
+			if isSet && n.Predicate == x.ExternalIdPred {
+				continue
+			}
+			res = append(res, &n)
+		}
+		return res
+	}
+	for i, gmu := range qc.gmuList {
+		gmu.Set = renameAll(gmu.Set, i, true)
+		gmu.Del = renameAll(gmu.Del, i, false)
+	}
+	if len(xids) == 0 {
+		return upsertQuery
+	}
+
+	// updateMutations only updates the mutations having an entry in condVars.
+	if qc.condVars == nil {
+		qc.condVars = make([]string, len(qc.gmuList))
+	}
+	blocks := make([]string, 0, len(xids))
+	for _, xid := range xids {
+		n := qc.xids[xid]
+		qc.uidRes[n.varName] = nil
+		blocks = append(blocks, fmt.Sprintf("%s as var(func: eq(%s, %s))", n.varName,
+			x.ExternalIdPred, strconv.Quote(xid)))
+	}
+	return addQueryBlocks(upsertQuery, blocks)
+}
+
+// addQueryBlocks returns the upsert query extended with the given query blocks.
+func addQueryBlocks(upsertQuery string, blocks []string) string {
+	var sb strings.Builder
+	if upsertQuery == "" {
+		sb.WriteString("{")
+	} else {
+		sb.WriteString(strings.TrimSuffix(strings.TrimSpace(upsertQuery), "}"))
+	}
+	for _, block := range blocks {
+		sb.WriteString("\n\t")
+		sb.WriteString(block)
+	}
+	sb.WriteString("\n}")
+	return sb.String()
+}
+
+// setXids checks that each external ID of an upsertByXid request names at most one node, and sets
+// the external IDs of the nodes to be created.
+func setXids(qc *queryContext) error {
+	for xid, n := range qc.xids {
+		switch uids := qc.uidRes[n.varName]; len(uids) {
+		case 0:
+			gmu := qc.gmuList[n.mu]
+			gmu.Set = append(gmu.Set, &api.NQuad{
+				Subject:     "uid(" + n.varName + ")",
+				Predicate:   x.ExternalIdPred,
+				ObjectValue: &api.Value{Val: &api.Value_StrVal{StrVal: xid}},
+			})
+		case 1:
+		default:
+			return errors.Errorf("External ID %q is set on %d nodes", xid, len(uids))
+		}
+	}
+	return nil
+}
+
+// xidUids returns the uids of the response of an upsertByXid request, where the uids of the
+// nodes named by external IDs, new or existing, are keyed by their external IDs.
+func xidUids(qc *queryContext, uids map[string]string) map[string]string {
+	res := make(map[string]string, len(uids)+len(qc.xids))
+	for name, uid := range uids {
+		res[name] = uid
+	}
+	for xid, n := range qc.xids {
+		name := "uid(" + n.varName + ")"
+		if uid, ok := uids[name]; ok {
+			delete(res, name)
+			res[xid] = uid
+			continue
+		}
+		if existing := qc.uidRes[n.varName]; len(existing) == 1 {
+			// The uids of the query results are in base 10.
+			if uid, err := strconv.ParseUint(existing[0], 10, 64); err == nil {
+				res[xid] = fmt.Sprintf("%#x", uid)
+			}
+		}
+	}
+	return res
+}
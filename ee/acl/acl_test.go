@@ -2326,6 +2326,34 @@ func TestSchemaQueryWithACL(t *testing.T) {
       "type": "uid",
       "list": true
 	},
+	{
+		"predicate":"dgraph.colocation",
+		"type":"string"
+	},
+	{
+		"predicate":"dgraph.external_id",
+		"type":"string",
+		"index":true,
+		"tokenizer":["exact"],
+		"upsert":true
+	},
+	{
+		"predicate":"dgraph.idempotency.key",
+		"type":"string",
+		"index":true,
+		"tokenizer":["exact"],
+		"upsert":true
+	},
+	{
+		"predicate":"dgraph.idempotency.record",
+		"type":"string"
+	},
+	{
+		"predicate":"dgraph.idempotency.expiry",
+		"type":"datetime",
+		"index":true,
+		"tokenizer":["hour"]
+	},
 	{
 		"predicate":"dgraph.drop.op",
 		"type":"string"
@@ -2366,6 +2394,34 @@ func TestSchemaQueryWithACL(t *testing.T) {
       ],
       "upsert": true
     },
+    {
+      "predicate": "dgraph.runtime_config",
+      "type": "string"
+    },
+    {
+      "predicate": "dgraph.session.expiry",
+      "type": "datetime"
+    },
+    {
+      "predicate": "dgraph.session.id",
+      "type": "string",
+      "index": true,
+      "tokenizer": [
+        "exact"
+      ]
+    },
+    {
+      "predicate": "dgraph.session.revoked",
+      "type": "bool"
+    },
+    {
+      "predicate": "dgraph.session.user",
+      "type": "string",
+      "index": true,
+      "tokenizer": [
+        "exact"
+      ]
+    },
     {
       "predicate": "dgraph.type",
       "type": "string",
@@ -2381,6 +2437,14 @@ func TestSchemaQueryWithACL(t *testing.T) {
       "reverse": true,
       "list": true
     },
+    {
+      "predicate": "dgraph.user.password_scheme",
+      "type": "string"
+    },
+    {
+      "predicate": "dgraph.user.password_updated",
+      "type": "datetime"
+    },
     {
       "predicate": "dgraph.xid",
       "type": "string",
@@ -2433,6 +2497,23 @@ func TestSchemaQueryWithACL(t *testing.T) {
       ],
       "name": "dgraph.type.Rule"
     },
+    {
+      "fields": [
+        {
+          "name": "dgraph.session.id"
+        },
+        {
+          "name": "dgraph.session.user"
+        },
+        {
+          "name": "dgraph.session.expiry"
+        },
+        {
+          "name": "dgraph.session.revoked"
+        }
+      ],
+      "name": "dgraph.type.Session"
+    },
     {
       "fields": [
         {
@@ -2443,6 +2524,12 @@ func TestSchemaQueryWithACL(t *testing.T) {
         },
         {
           "name": "dgraph.user.group"
+        },
+        {
+          "name": "dgraph.user.password_updated"
+        },
+        {
+          "name": "dgraph.user.password_scheme"
         }
       ],
       "name": "dgraph.type.User"
@@ -2477,6 +2564,10 @@ func TestSchemaQueryWithACL(t *testing.T) {
       "fields": [],
       "name": "dgraph.type.Rule"
     },
+    {
+      "fields": [],
+      "name": "dgraph.type.Session"
+    },
     {
       "fields": [],
       "name": "dgraph.type.User"
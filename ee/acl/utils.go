@@ -14,6 +14,7 @@ package acl
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/dgraph-io/dgo/v210"
 	"github.com/dgraph-io/dgo/v210/protos/api"
@@ -76,6 +77,17 @@ type User struct {
 	Namespace     uint64  `json:"namespace"`
 	PasswordMatch bool    `json:"password_match"`
 	Groups        []Group `json:"dgraph.user.group"`
+	// PasswordUpdated is the time at which the password was last set.
+	PasswordUpdated time.Time `json:"dgraph.user.password_updated"`
+	// PasswordScheme is the scheme the stored password was hashed with.
+	PasswordScheme string `json:"dgraph.user.password_scheme"`
+	// Oidc is set for users authenticated through an OIDC provider instead of a password.
+	Oidc bool `json:"-"`
+	// OidcExpiry is the expiry of the ID token an OIDC user logged in with. The groups taken from
+	// the ID token aren't trusted after it, so the refresh JWT expires with it.
+	OidcExpiry time.Time `json:"-"`
+	// SessionId identifies the login session, it is carried over when the JWTs are refreshed.
+	SessionId string `json:"-"`
 }
 
 // GetUid returns the UID of the user.
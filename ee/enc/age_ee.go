@@ -0,0 +1,260 @@
+// +build !oss
+
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Dgraph Community License (the "License"); you
+ * may not use this file except in compliance with the License. You
+ * may obtain a copy of the License at
+ *
+ *     https://github.com/dgraph-io/dgraph/blob/master/licenses/DCL.txt
+ */
+
+package enc
+
+import (
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/curve25519"
+	"golang.org/x/crypto/hkdf"
+)
+
+// The age writer encrypts the data for an X25519 recipient in the age v1 format, see
+// https://age-encryption.org/v1, so that it can be decrypted with the age tools by the holder of
+// the identity of the recipient. Only the public key of the recipient is known to the Alpha.
+
+const (
+	ageIntro     = "age-encryption.org/v1\n"
+	ageX25519    = "age-encryption.org/v1/X25519"
+	ageChunkSize = 64 << 10
+	ageBech32HRP = "age"
+)
+
+var b64 = base64.RawStdEncoding
+
+// ParseAgeRecipient parses an age X25519 recipient, the Bech32 encoding of its public key starting
+// with "age1".
+func ParseAgeRecipient(s string) ([]byte, error) {
+	hrp, key, err := bech32Decode(s)
+	switch {
+	case err != nil:
+		return nil, errors.Wrapf(err, "invalid age recipient %q", s)
+	case hrp != ageBech32HRP:
+		return nil, errors.Errorf("invalid age recipient %q: it must start with age1", s)
+	case len(key) != curve25519.PointSize:
+		return nil, errors.Errorf("invalid age recipient %q: wrong key length", s)
+	}
+	return key, nil
+}
+
+type ageWriter struct {
+	w     io.Writer
+	aead  cipher.AEAD
+	nonce [chacha20poly1305.NonceSize]byte
+	buf   []byte
+	err   error
+}
+
+// NewAgeWriter returns a writer encrypting the data written to it for the given age recipient,
+// see ParseAgeRecipient. The data is only complete once the writer is closed, which doesn't close
+// the underlying writer.
+func NewAgeWriter(recipient string, w io.Writer) (io.WriteCloser, error) {
+	key, err := ParseAgeRecipient(recipient)
+	if err != nil {
+		return nil, err
+	}
+	return newAgeWriter(key, w)
+}
+
+func newAgeWriter(key []byte, w io.Writer) (io.WriteCloser, error) {
+	fileKey := make([]byte, 16)
+	if _, err := rand.Read(fileKey); err != nil {
+		return nil, err
+	}
+
+	// The file key is wrapped with a key agreed with the recipient by an ephemeral X25519 key.
+	ephemeral := make([]byte, curve25519.ScalarSize)
+	if _, err := rand.Read(ephemeral); err != nil {
+		return nil, err
+	}
+	share, err := curve25519.X25519(ephemeral, curve25519.Basepoint)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := curve25519.X25519(ephemeral, key)
+	if err != nil {
+		return nil, err
+	}
+	wrapKey, err := hkdfKey(secret, append(share, key...), ageX25519)
+	if err != nil {
+		return nil, err
+	}
+	wrap, err := chacha20poly1305.New(wrapKey)
+	if err != nil {
+		return nil, err
+	}
+	body := wrap.Seal(nil, make([]byte, chacha20poly1305.NonceSize), fileKey, nil)
+
+	// The header is authenticated by an HMAC keyed by the file key, up to the "---".
+	header := fmt.Sprintf("%s-> X25519 %s\n%s\n---", ageIntro, b64.EncodeToString(share),
+		b64.EncodeToString(body))
+	macKey, err := hkdfKey(fileKey, nil, "header")
+	if err != nil {
+		return nil, err
+	}
+	mac := hmac.New(sha256.New, macKey)
+	mac.Write([]byte(header))
+	header += " " + b64.EncodeToString(mac.Sum(nil)) + "\n"
+
+	payloadNonce := make([]byte, 16)
+	if _, err := rand.Read(payloadNonce); err != nil {
+		return nil, err
+	}
+	payloadKey, err := hkdfKey(fileKey, payloadNonce, "payload")
+	if err != nil {
+		return nil, err
+	}
+	aead, err := chacha20poly1305.New(payloadKey)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.WriteString(w, header); err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(payloadNonce); err != nil {
+		return nil, err
+	}
+	return &ageWriter{w: w, aead: aead, buf: make([]byte, 0, ageChunkSize)}, nil
+}
+
+func hkdfKey(secret, salt []byte, info string) ([]byte, error) {
+	key := make([]byte, chacha20poly1305.KeySize)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, secret, salt, []byte(info)), key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// Write encrypts the data by chunks of 64KiB. A full chunk is only written once more data
+// follows, as the last chunk is flagged when the writer is closed.
+func (aw *ageWriter) Write(p []byte) (int, error) {
+	if aw.err != nil {
+		return 0, aw.err
+	}
+	n := len(p)
+	for len(p) > 0 {
+		if len(aw.buf) == ageChunkSize {
+			if aw.err = aw.flush(false); aw.err != nil {
+				return n - len(p), aw.err
+			}
+		}
+		k := copy(aw.buf[len(aw.buf):ageChunkSize], p)
+		aw.buf = aw.buf[:len(aw.buf)+k]
+		p = p[k:]
+	}
+	return n, nil
+}
+
+func (aw *ageWriter) flush(last bool) error {
+	if last {
+		aw.nonce[len(aw.nonce)-1] = 1
+	}
+	if _, err := aw.w.Write(aw.aead.Seal(nil, aw.nonce[:], aw.buf, nil)); err != nil {
+		return err
+	}
+	aw.buf = aw.buf[:0]
+	// The nonce is the 11 bytes big-endian counter of the chunk, followed by the last flag.
+	counter := binary.BigEndian.Uint64(aw.nonce[3:11]) + 1
+	binary.BigEndian.PutUint64(aw.nonce[3:11], counter)
+	return nil
+}
+
+// Close writes the last chunk.
+func (aw *ageWriter) Close() error {
+	if aw.err != nil {
+		return aw.err
+	}
+	if err := aw.flush(true); err != nil {
+		aw.err = err
+		return err
+	}
+	aw.err = errors.New("age writer is closed")
+	return nil
+}
+
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+func bech32Polymod(values []byte) uint32 {
+	gen := [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	chk := uint32(1)
+	for _, v := range values {
+		b := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := 0; i < 5; i++ {
+			if (b>>uint(i))&1 == 1 {
+				chk ^= gen[i]
+			}
+		}
+	}
+	return chk
+}
+
+// bech32Decode decodes a Bech32 string into its human-readable part and its data, see BIP 173.
+// Unlike BIP 173, the length of the string isn't limited.
+func bech32Decode(s string) (string, []byte, error) {
+	if strings.ToLower(s) != s && strings.ToUpper(s) != s {
+		return "", nil, errors.New("mixed case")
+	}
+	s = strings.ToLower(s)
+	pos := strings.LastIndexByte(s, '1')
+	if pos < 1 || pos+7 > len(s) {
+		return "", nil, errors.New("separator '1' at invalid position")
+	}
+	hrp := s[:pos]
+	values := make([]byte, 0, 2*len(hrp)+1+len(s)-pos-1)
+	for i := 0; i < len(hrp); i++ {
+		values = append(values, hrp[i]>>5)
+	}
+	values = append(values, 0)
+	for i := 0; i < len(hrp); i++ {
+		values = append(values, hrp[i]&31)
+	}
+	for i := pos + 1; i < len(s); i++ {
+		d := strings.IndexByte(bech32Charset, s[i])
+		if d < 0 {
+			return "", nil, errors.Errorf("invalid character %q", s[i])
+		}
+		values = append(values, byte(d))
+	}
+	if bech32Polymod(values) != 1 {
+		return "", nil, errors.New("invalid checksum")
+	}
+
+	// Regroup the 5-bit values, without the checksum, into bytes.
+	data := values[2*len(hrp)+1 : len(values)-6]
+	var out []byte
+	var acc uint32
+	var bits uint
+	for _, v := range data {
+		acc = acc<<5 | uint32(v)
+		bits += 5
+		if bits >= 8 {
+			bits -= 8
+			out = append(out, byte(acc>>bits))
+		}
+	}
+	if bits >= 5 || acc&(1<<bits-1) != 0 {
+		return "", nil, errors.New("invalid padding")
+	}
+	return hrp, out, nil
+}
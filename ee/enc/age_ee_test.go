@@ -0,0 +1,141 @@
+// +build !oss
+
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Dgraph Community License (the "License"); you
+ * may not use this file except in compliance with the License. You
+ * may obtain a copy of the License at
+ *
+ *     https://github.com/dgraph-io/dgraph/blob/master/licenses/DCL.txt
+ */
+
+package enc
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/curve25519"
+)
+
+// ageDecrypt decrypts the age file with the X25519 identity, following the age v1 spec.
+func ageDecrypt(t *testing.T, identity, file []byte) []byte {
+	r := bufio.NewReader(bytes.NewReader(file))
+	readLine := func() string {
+		line, err := r.ReadString('\n')
+		require.NoError(t, err)
+		return strings.TrimSuffix(line, "\n")
+	}
+	require.Equal(t, strings.TrimSuffix(ageIntro, "\n"), readLine())
+	stanza := strings.Split(readLine(), " ")
+	require.Equal(t, []string{"->", "X25519"}, stanza[:2])
+	share, err := b64.DecodeString(stanza[2])
+	require.NoError(t, err)
+	body, err := b64.DecodeString(readLine())
+	require.NoError(t, err)
+	macLine := readLine()
+	require.True(t, strings.HasPrefix(macLine, "--- "))
+
+	recipient, err := curve25519.X25519(identity, curve25519.Basepoint)
+	require.NoError(t, err)
+	secret, err := curve25519.X25519(identity, share)
+	require.NoError(t, err)
+	wrapKey, err := hkdfKey(secret, append(share, recipient...), ageX25519)
+	require.NoError(t, err)
+	wrap, err := chacha20poly1305.New(wrapKey)
+	require.NoError(t, err)
+	fileKey, err := wrap.Open(nil, make([]byte, chacha20poly1305.NonceSize), body, nil)
+	require.NoError(t, err)
+
+	headerLen := bytes.Index(file, []byte("\n--- ")) + len("\n---")
+	macKey, err := hkdfKey(fileKey, nil, "header")
+	require.NoError(t, err)
+	mac := hmac.New(sha256.New, macKey)
+	mac.Write(file[:headerLen])
+	sum, err := b64.DecodeString(strings.TrimPrefix(macLine, "--- "))
+	require.NoError(t, err)
+	require.True(t, hmac.Equal(mac.Sum(nil), sum), "invalid header MAC")
+
+	payload, err := ioutil.ReadAll(r)
+	require.NoError(t, err)
+	payloadKey, err := hkdfKey(fileKey, payload[:16], "payload")
+	require.NoError(t, err)
+	aead, err := chacha20poly1305.New(payloadKey)
+	require.NoError(t, err)
+	payload = payload[16:]
+
+	var out []byte
+	var nonce [chacha20poly1305.NonceSize]byte
+	for counter := uint64(0); ; counter++ {
+		binary.BigEndian.PutUint64(nonce[3:11], counter)
+		chunk := payload
+		if len(chunk) > ageChunkSize+aead.Overhead() {
+			chunk = chunk[:ageChunkSize+aead.Overhead()]
+		}
+		payload = payload[len(chunk):]
+		if len(payload) == 0 {
+			nonce[len(nonce)-1] = 1
+		}
+		plain, err := aead.Open(nil, nonce[:], chunk, nil)
+		require.NoError(t, err, "chunk %d", counter)
+		out = append(out, plain...)
+		if len(payload) == 0 {
+			return out
+		}
+	}
+}
+
+func TestParseAgeRecipient(t *testing.T) {
+	key, err := ParseAgeRecipient("age1ql3z7hjy54pw3hyww5ayyfg7zqgvc7w3j2elw8zmrj2kg5sfn9aqmcac8p")
+	require.NoError(t, err)
+	require.Len(t, key, 32)
+
+	for _, s := range []string{
+		"",
+		"age1ql3z7hjy54pw3hyww5ayyfg7zqgvc7w3j2elw8zmrj2kg5sfn9aqmcac8q",
+		"age1ql3z7hjy54pw3hyww5ayyfg7zqgvc7w3j2ELW8zmrj2kg5sfn9aqmcac8p",
+	} {
+		_, err := ParseAgeRecipient(s)
+		require.Error(t, err, "recipient: %q", s)
+	}
+}
+
+func TestAgeWriter(t *testing.T) {
+	identity := make([]byte, curve25519.ScalarSize)
+	_, err := rand.Read(identity)
+	require.NoError(t, err)
+	recipient, err := curve25519.X25519(identity, curve25519.Basepoint)
+	require.NoError(t, err)
+
+	for _, size := range []int{0, 10, ageChunkSize, ageChunkSize + 1, 3*ageChunkSize + 100} {
+		data := make([]byte, size)
+		_, err := rand.Read(data)
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		w, err := newAgeWriter(recipient, &buf)
+		require.NoError(t, err)
+		// Write in pieces not aligned with the chunks.
+		for p := data; len(p) > 0; {
+			n := 1000
+			if n > len(p) {
+				n = len(p)
+			}
+			_, err := w.Write(p[:n])
+			require.NoError(t, err)
+			p = p[n:]
+		}
+		require.NoError(t, w.Close())
+		require.Equal(t, data, ageDecrypt(t, identity, buf.Bytes()), "size: %d", size)
+	}
+}
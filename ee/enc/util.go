@@ -20,8 +20,12 @@ package enc
 
 import (
 	"io"
+
+	"github.com/pkg/errors"
 )
 
+var errAgeOSS = errors.New("encrypting exports for a recipient is an enterprise feature")
+
 // Eebuild indicates if this is a Enterprise build.
 var EeBuild = false
 
@@ -34,3 +38,13 @@ func GetWriter(_ []byte, w io.Writer) (io.Writer, error) {
 func GetReader(_ []byte, r io.Reader) (io.Reader, error) {
 	return r, nil
 }
+
+// ParseAgeRecipient returns an error for OSS Builds.
+func ParseAgeRecipient(_ string) ([]byte, error) {
+	return nil, errAgeOSS
+}
+
+// NewAgeWriter returns an error for OSS Builds.
+func NewAgeWriter(_ string, _ io.Writer) (io.WriteCloser, error) {
+	return nil, errAgeOSS
+}
@@ -40,6 +40,24 @@ const (
 	flagAclRefreshTtl = "refresh-ttl"
 	flagAclSecretFile = "secret-file"
 
+	flagOidc           = "oidc"
+	flagOidcIssuer     = "issuer"
+	flagOidcJwksUrl    = "jwks-url"
+	flagOidcAudience   = "audience"
+	flagOidcUserClaim  = "user-claim"
+	flagOidcGroupClaim = "group-claim"
+	flagOidcGroupMap   = "group-map"
+	flagOidcPassGroups = "pass-through-groups"
+
+	flagPasswordPolicy         = "password-policy"
+	flagPasswordMinLength      = "min-length"
+	flagPasswordRequireUpper   = "require-upper"
+	flagPasswordRequireLower   = "require-lower"
+	flagPasswordRequireDigit   = "require-digit"
+	flagPasswordRequireSpecial = "require-special"
+	flagPasswordMaxAge         = "max-age"
+	flagPasswordHash           = "hash"
+
 	flagEnc        = "encryption"
 	flagEncKeyFile = "key-file"
 
@@ -56,6 +74,8 @@ const (
 
 func RegisterAclAndEncFlags(flag *pflag.FlagSet) {
 	registerAclFlag(flag)
+	registerOidcFlag(flag)
+	registerPasswordPolicyFlag(flag)
 	registerEncFlag(flag)
 	registerVaultFlag(flag, true, true)
 }
@@ -70,6 +90,22 @@ var (
 		flagAclAccessTtl, "6h",
 		flagAclRefreshTtl, "30d",
 		flagAclSecretFile, "")
+	OidcDefaults = fmt.Sprintf("%s=%s; %s=%s; %s=%s; %s=%s; %s=%s; %s=%s; %s=%s",
+		flagOidcUserClaim, "email",
+		flagOidcGroupClaim, "groups",
+		flagOidcIssuer, "",
+		flagOidcJwksUrl, "",
+		flagOidcAudience, "",
+		flagOidcGroupMap, "",
+		flagOidcPassGroups, "false")
+	PasswordPolicyDefaults = fmt.Sprintf("%s=%s; %s=%s; %s=%s; %s=%s; %s=%s; %s=%s; %s=%s",
+		flagPasswordMinLength, "6",
+		flagPasswordRequireUpper, "false",
+		flagPasswordRequireLower, "false",
+		flagPasswordRequireDigit, "false",
+		flagPasswordRequireSpecial, "false",
+		flagPasswordMaxAge, "0s",
+		flagPasswordHash, "bcrypt")
 	EncDefaults = fmt.Sprintf("%s=%s", flagEncKeyFile, "")
 )
 
@@ -135,6 +171,58 @@ func registerAclFlag(flag *pflag.FlagSet) {
 	flag.String(flagAcl, AclDefaults, helpText)
 }
 
+func registerOidcFlag(flag *pflag.FlagSet) {
+	helpText := z.NewSuperFlagHelp(OidcDefaults).
+		Head("[Enterprise Feature] OIDC login options for ACL users").
+		Flag("issuer",
+			"The issuer URL of the OpenID Connect provider (Okta, Auth0, Keycloak, etc.). "+
+				"Setting it allows ACL users to login with an ID token issued by the provider.").
+		Flag("jwks-url",
+			"The URL of the JSON Web Key Set of the provider. If empty, it is discovered from "+
+				"<issuer>/.well-known/openid-configuration.").
+		Flag("audience",
+			"The expected audience (client ID) of the ID token.").
+		Flag("user-claim",
+			"The claim of the ID token that is used as the Dgraph user ID.").
+		Flag("group-claim",
+			"The claim of the ID token that holds the list of IdP groups of the user.").
+		Flag("group-map",
+			"A comma separated list of idp-group:dgraph-group pairs. IdP groups not present in "+
+				"the map are ignored, unless pass-through-groups is set. e.g. "+
+				`"group-map=okta-admins:guardians,engineering:dev"`).
+		Flag("pass-through-groups",
+			"If true, the IdP groups not present in the group map are used as Dgraph group IDs "+
+				"as is. Anyone able to name a group in the IdP then gets the permissions of the "+
+				"Dgraph group of the same name, including guardians.").
+		String()
+	flag.String(flagOidc, OidcDefaults, helpText)
+}
+
+func registerPasswordPolicyFlag(flag *pflag.FlagSet) {
+	helpText := z.NewSuperFlagHelp(PasswordPolicyDefaults).
+		Head("[Enterprise Feature] Password policy for ACL users").
+		Flag("min-length",
+			"The minimum length of the password of an ACL user. It can't be less than 6.").
+		Flag("require-upper",
+			"If true, passwords must contain at least one upper case letter.").
+		Flag("require-lower",
+			"If true, passwords must contain at least one lower case letter.").
+		Flag("require-digit",
+			"If true, passwords must contain at least one digit.").
+		Flag("require-special",
+			"If true, passwords must contain at least one character that is neither a letter "+
+				"nor a digit.").
+		Flag("max-age",
+			"The duration after which a password expires and must be reset before the user can "+
+				"login again. 0 disables password expiry.").
+		Flag("hash",
+			"The scheme used to hash new passwords, can be 'bcrypt' or 'argon2id'. Passwords "+
+				"stored with another scheme are re-hashed when the user next logs in. It "+
+				"should be the same on all the Alphas.").
+		String()
+	flag.String(flagPasswordPolicy, PasswordPolicyDefaults, helpText)
+}
+
 func registerEncFlag(flag *pflag.FlagSet) {
 	helpText := z.NewSuperFlagHelp(EncDefaults).
 		Head("[Enterprise Feature] Encryption At Rest options").
@@ -0,0 +1,214 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package embedded runs Dgraph inside a Go program, for desktop and single node applications:
+//
+//	db, err := embedded.Open("data")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer db.Close()
+//	resp, err := db.Query(ctx, `{ q(func: has(name)) { name } }`, nil)
+//
+// A Zero and an Alpha run in the process, and talk to each other in memory. No port is opened.
+// Dgraph keeps its state in global variables, so a process can only open one DB, once.
+package embedded
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dgraph-io/dgo/v210/protos/api"
+	"github.com/dgraph-io/dgraph/dgraph/cmd/alpha"
+	"github.com/dgraph-io/dgraph/dgraph/cmd/zero"
+	"github.com/dgraph-io/dgraph/edgraph"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc/peer"
+)
+
+// DefaultStartTimeout is the default time given to Dgraph to start.
+const DefaultStartTimeout = time.Minute
+
+var (
+	opened int32
+
+	errClosed = errors.New("The DB is closed")
+	// localPeer is the peer of the requests, which are always trusted as local.
+	localPeer = &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1)}}
+)
+
+// Options are the options of a DB.
+type Options struct {
+	// Dir is the directory of the DB. The postings, the write-ahead logs of the Alpha and of the
+	// Zero, and the temporary files are stored in its p, w, zw and t subdirectories.
+	Dir string
+	// StartTimeout bounds the time taken by Open.
+	StartTimeout time.Duration
+	// Flags sets flags of dgraph alpha by name, e.g. "cache": "size-mb=512;" or
+	// "badger": "compression=zstd:1;". The flags setting the directories and Zero are ignored.
+	Flags map[string]string
+}
+
+// DefaultOptions returns the default options for a DB in the given directory.
+func DefaultOptions(dir string) Options {
+	return Options{
+		Dir:          dir,
+		StartTimeout: DefaultStartTimeout,
+	}
+}
+
+// DB is a Dgraph database running in this process.
+type DB struct {
+	sync.RWMutex
+	server    *edgraph.Server
+	zero      *zero.Embedded
+	stopAlpha func()
+	closed    bool
+}
+
+// Open opens the DB in the given directory with the default options, creating it if needed.
+func Open(dir string) (*DB, error) {
+	return OpenWithOptions(DefaultOptions(dir))
+}
+
+// OpenWithOptions opens the DB with the given options, creating it if needed.
+func OpenWithOptions(opts Options) (_ *DB, rerr error) {
+	if opts.Dir == "" {
+		return nil, errors.New("The directory of the DB must be set")
+	}
+	if opts.StartTimeout <= 0 {
+		opts.StartTimeout = DefaultStartTimeout
+	}
+	if !atomic.CompareAndSwapInt32(&opened, 0, 1) {
+		return nil, errors.New("A DB was already opened in this process")
+	}
+	// A DB can be opened again if this one failed to open.
+	defer func() {
+		if rerr != nil {
+			atomic.StoreInt32(&opened, 0)
+		}
+	}()
+	dirs := map[string]string{}
+	for _, sub := range []string{"p", "w", "zw", "t", "export"} {
+		dirs[sub] = filepath.Join(opts.Dir, sub)
+		if err := os.MkdirAll(dirs[sub], 0700); err != nil {
+			return nil, errors.Wrapf(err, "while creating directory %s", dirs[sub])
+		}
+	}
+
+	zconf := viper.New()
+	if err := zconf.BindPFlags(zero.Zero.Cmd.Flags()); err != nil {
+		return nil, err
+	}
+	zconf.Set("wal", dirs["zw"])
+	zconf.Set("telemetry", "reports=false; sentry=false;")
+	zero.Zero.Conf = zconf
+	ez, err := zero.StartEmbedded(true)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while starting Zero")
+	}
+
+	aconf := viper.New()
+	if err := aconf.BindPFlags(alpha.Alpha.Cmd.Flags()); err != nil {
+		ez.Stop()
+		return nil, err
+	}
+	aconf.Set("telemetry", "reports=false; sentry=false;")
+	for name, val := range opts.Flags {
+		aconf.Set(name, val)
+	}
+	aconf.Set("postings", dirs["p"])
+	aconf.Set("wal", dirs["w"])
+	aconf.Set("tmp", dirs["t"])
+	aconf.Set("export", dirs["export"])
+	aconf.Set("zero", ez.Addr)
+	alpha.Alpha.Conf = aconf
+
+	ctx, cancel := context.WithTimeout(context.Background(), opts.StartTimeout)
+	defer cancel()
+	stop, err := alpha.StartEmbedded(ctx)
+	if err != nil {
+		ez.Stop()
+		return nil, errors.Wrapf(err, "while starting Alpha")
+	}
+	return &DB{server: &edgraph.Server{}, zero: ez, stopAlpha: stop}, nil
+}
+
+func (db *DB) context(ctx context.Context) context.Context {
+	return peer.NewContext(ctx, localPeer)
+}
+
+// Query runs a DQL query in a read-only transaction, with the given variables.
+func (db *DB) Query(ctx context.Context, query string,
+	vars map[string]string) (*api.Response, error) {
+	return db.Do(ctx, &api.Request{Query: query, Vars: vars, ReadOnly: true})
+}
+
+// Mutate runs the mutation, and commits it.
+func (db *DB) Mutate(ctx context.Context, mu *api.Mutation) (*api.Response, error) {
+	return db.Do(ctx, &api.Request{Mutations: []*api.Mutation{mu}, CommitNow: true})
+}
+
+// Do runs the request, like dgo's Txn.Do. Requests with a StartTs and without CommitNow are part
+// of a transaction, which must be committed or aborted with CommitOrAbort.
+func (db *DB) Do(ctx context.Context, req *api.Request) (*api.Response, error) {
+	db.RLock()
+	defer db.RUnlock()
+	if db.closed {
+		return nil, errClosed
+	}
+	return db.server.Query(db.context(ctx), req)
+}
+
+// CommitOrAbort commits or aborts the transaction, like dgo's Txn.Commit and Txn.Discard.
+func (db *DB) CommitOrAbort(ctx context.Context, tc *api.TxnContext) (*api.TxnContext, error) {
+	db.RLock()
+	defer db.RUnlock()
+	if db.closed {
+		return nil, errClosed
+	}
+	return db.server.CommitOrAbort(db.context(ctx), tc)
+}
+
+// Alter changes the schema, or drops data.
+func (db *DB) Alter(ctx context.Context, op *api.Operation) error {
+	db.RLock()
+	defer db.RUnlock()
+	if db.closed {
+		return errClosed
+	}
+	_, err := db.server.Alter(db.context(ctx), op)
+	return err
+}
+
+// Close stops Dgraph, once the requests in progress are done.
+func (db *DB) Close() error {
+	db.Lock()
+	defer db.Unlock()
+	if db.closed {
+		return nil
+	}
+	db.closed = true
+	db.stopAlpha()
+	db.zero.Stop()
+	return nil
+}
@@ -0,0 +1,66 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package embedded
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dgraph-io/dgo/v210/protos/api"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmbedded(t *testing.T) {
+	_, err := OpenWithOptions(Options{})
+	require.Error(t, err)
+
+	dir, err := ioutil.TempDir("", "embedded")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// The directories of the DB can't be created under a file, but opening it again succeeds
+	// after the failure.
+	file := filepath.Join(dir, "file")
+	require.NoError(t, ioutil.WriteFile(file, nil, 0600))
+	_, err = Open(file)
+	require.Error(t, err)
+
+	db, err := Open(dir)
+	require.NoError(t, err)
+	defer db.Close()
+	// Dgraph keeps its state in global variables, so a second DB can't be opened.
+	_, err = Open(dir)
+	require.Error(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, db.Alter(ctx, &api.Operation{Schema: `name: string @index(exact) .`}))
+	_, err = db.Mutate(ctx, &api.Mutation{SetNquads: []byte(`_:a <name> "Alice" .`)})
+	require.NoError(t, err)
+	resp, err := db.Query(ctx, `query q($name: string) { q(func: eq(name, $name)) { name } }`,
+		map[string]string{"$name": "Alice"})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"q":[{"name":"Alice"}]}`, string(resp.Json))
+
+	require.NoError(t, db.Close())
+	_, err = db.Query(ctx, `{ q(func: has(name)) { name } }`, nil)
+	require.Equal(t, errClosed, err)
+	// Closing the DB again does nothing.
+	require.NoError(t, db.Close())
+}
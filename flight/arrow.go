@@ -0,0 +1,378 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flight
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/dgraph-io/dgraph/query"
+	"github.com/dgraph-io/dgraph/types"
+	flatbuffers "github.com/google/flatbuffers/go"
+	"github.com/pkg/errors"
+)
+
+// The values below come from Schema.fbs and Message.fbs of the Arrow format, which describe the
+// flatbuffers sent as the header of the Arrow IPC messages.
+const (
+	metadataV5 = 4
+
+	headerSchema      = 1
+	headerRecordBatch = 3
+
+	typeInt           = 2
+	typeFloatingPoint = 3
+	typeUtf8          = 5
+	typeBool          = 6
+	typeTimestamp     = 10
+
+	precisionDouble  = 2
+	timeUnitMicrosec = 2
+)
+
+// maxBatchRows is the maximum number of rows sent in a record batch.
+const maxBatchRows = 64 << 10
+
+type columnType int
+
+const (
+	colUid columnType = iota
+	colInt64
+	colFloat64
+	colBool
+	colTimestamp
+	colUtf8
+)
+
+// column is a column of a table, holding the values of a value variable.
+type column struct {
+	name string
+	typ  columnType
+	vals map[uint64]types.Val
+}
+
+// table holds the value variables of a query in columns. There is a row for each uid having a
+// value in at least one of the variables, and a row for the aggregations at the root of the query
+// blocks, whose uid is null.
+type table struct {
+	columns []*column
+	uids    []uint64
+}
+
+// newTable returns the table of the given value variables. If no variable is given, the table has
+// all the value variables of the query, in the order of their names.
+func newTable(vars query.ValueVars, names []string) (*table, error) {
+	if len(names) == 0 {
+		for name := range vars {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+	}
+
+	t := &table{columns: []*column{{name: "uid", typ: colUid}}}
+	seen := make(map[uint64]bool)
+	for _, name := range names {
+		vals, ok := vars[name]
+		if !ok {
+			return nil, errors.Errorf("value variable %s is not defined by the query", name)
+		}
+		t.columns = append(t.columns, &column{name: name, typ: columnTypeOf(vals), vals: vals})
+		for uid := range vals {
+			if !seen[uid] {
+				seen[uid] = true
+				t.uids = append(t.uids, uid)
+			}
+		}
+	}
+	// The row of the aggregations, mapped from math.MaxUint64, comes last.
+	sort.Slice(t.uids, func(i, j int) bool { return t.uids[i] < t.uids[j] })
+	return t, nil
+}
+
+// columnTypeOf returns the type of the column holding the given values. The numbers are stored
+// as floats if any of them is a float, and the values of different types are stored as strings.
+func columnTypeOf(vals map[uint64]types.Val) columnType {
+	var typ columnType
+	for _, v := range vals {
+		var cur columnType
+		switch v.Value.(type) {
+		case int64:
+			cur = colInt64
+		case float64:
+			cur = colFloat64
+		case bool:
+			cur = colBool
+		case time.Time:
+			cur = colTimestamp
+		default:
+			return colUtf8
+		}
+		switch {
+		case typ == colUid || typ == cur:
+			typ = cur
+		case (typ == colInt64 && cur == colFloat64) || (typ == colFloat64 && cur == colInt64):
+			typ = colFloat64
+		default:
+			return colUtf8
+		}
+	}
+	if typ == colUid {
+		// There's no value to tell the type.
+		return colUtf8
+	}
+	return typ
+}
+
+// schemaMessage returns the header of the IPC message describing the schema of the table.
+func (t *table) schemaMessage() []byte {
+	b := flatbuffers.NewBuilder(1024)
+	fields := make([]flatbuffers.UOffsetT, len(t.columns))
+	for i, c := range t.columns {
+		fields[i] = c.field(b)
+	}
+	b.StartVector(4, len(fields), 4)
+	for i := len(fields) - 1; i >= 0; i-- {
+		b.PrependUOffsetT(fields[i])
+	}
+	fieldsVec := b.EndVector(len(fields))
+
+	// The endianness is left to its default, little endian.
+	b.StartObject(4)
+	b.PrependUOffsetTSlot(1, fieldsVec, 0)
+	return finishMessage(b, headerSchema, b.EndObject(), 0)
+}
+
+// field builds the Field table describing the column.
+func (c *column) field(b *flatbuffers.Builder) flatbuffers.UOffsetT {
+	name := b.CreateString(c.name)
+	var typeType byte
+	var typ flatbuffers.UOffsetT
+	switch c.typ {
+	case colUid, colInt64:
+		typeType = typeInt
+		b.StartObject(2)
+		b.PrependInt32Slot(0, 64, 0)
+		b.PrependBoolSlot(1, c.typ == colInt64, false)
+		typ = b.EndObject()
+	case colFloat64:
+		typeType = typeFloatingPoint
+		b.StartObject(1)
+		b.PrependInt16Slot(0, precisionDouble, 0)
+		typ = b.EndObject()
+	case colBool:
+		typeType = typeBool
+		b.StartObject(0)
+		typ = b.EndObject()
+	case colTimestamp:
+		typeType = typeTimestamp
+		tz := b.CreateString("UTC")
+		b.StartObject(2)
+		b.PrependInt16Slot(0, timeUnitMicrosec, 0)
+		b.PrependUOffsetTSlot(1, tz, 0)
+		typ = b.EndObject()
+	default:
+		typeType = typeUtf8
+		b.StartObject(0)
+		typ = b.EndObject()
+	}
+	// The readers expect the children, even if there are none.
+	b.StartVector(4, 0, 4)
+	children := b.EndVector(0)
+
+	b.StartObject(7)
+	b.PrependUOffsetTSlot(0, name, 0)
+	b.PrependBoolSlot(1, true, false)
+	b.PrependByteSlot(2, typeType, 0)
+	b.PrependUOffsetTSlot(3, typ, 0)
+	b.PrependUOffsetTSlot(5, children, 0)
+	return b.EndObject()
+}
+
+// fieldNode and buffer are the FieldNode and Buffer structs of a RecordBatch.
+type fieldNode struct {
+	length    int64
+	nullCount int64
+}
+
+type buffer struct {
+	offset int64
+	length int64
+}
+
+// recordBatch holds the body of a RecordBatch message, and the buffers it is made of.
+type recordBatch struct {
+	nodes   []fieldNode
+	buffers []buffer
+	body    []byte
+}
+
+// addBuffer appends the buffer to the body. The buffers are aligned on 8 bytes.
+func (rb *recordBatch) addBuffer(data []byte) {
+	rb.buffers = append(rb.buffers, buffer{offset: int64(len(rb.body)), length: int64(len(data))})
+	rb.body = append(rb.body, data...)
+	for len(rb.body)%8 != 0 {
+		rb.body = append(rb.body, 0)
+	}
+}
+
+// recordBatch returns the header and the body of the IPC message holding the rows of the table
+// from lo to hi.
+func (t *table) recordBatch(lo, hi int) ([]byte, []byte, error) {
+	uids := t.uids[lo:hi]
+	rb := &recordBatch{}
+	for _, c := range t.columns {
+		if err := c.encode(uids, rb); err != nil {
+			return nil, nil, errors.Wrapf(err, "while encoding column %s", c.name)
+		}
+	}
+
+	b := flatbuffers.NewBuilder(1024)
+	b.StartVector(16, len(rb.nodes), 8)
+	for i := len(rb.nodes) - 1; i >= 0; i-- {
+		b.Prep(8, 16)
+		b.PrependInt64(rb.nodes[i].nullCount)
+		b.PrependInt64(rb.nodes[i].length)
+	}
+	nodes := b.EndVector(len(rb.nodes))
+	b.StartVector(16, len(rb.buffers), 8)
+	for i := len(rb.buffers) - 1; i >= 0; i-- {
+		b.Prep(8, 16)
+		b.PrependInt64(rb.buffers[i].length)
+		b.PrependInt64(rb.buffers[i].offset)
+	}
+	buffers := b.EndVector(len(rb.buffers))
+
+	b.StartObject(4)
+	b.PrependInt64Slot(0, int64(len(uids)), 0)
+	b.PrependUOffsetTSlot(1, nodes, 0)
+	b.PrependUOffsetTSlot(2, buffers, 0)
+	header := finishMessage(b, headerRecordBatch, b.EndObject(), int64(len(rb.body)))
+	return header, rb.body, nil
+}
+
+func finishMessage(b *flatbuffers.Builder, headerType byte, header flatbuffers.UOffsetT,
+	bodyLength int64) []byte {
+	b.StartObject(5)
+	b.PrependInt64Slot(3, bodyLength, 0)
+	b.PrependUOffsetTSlot(2, header, 0)
+	b.PrependByteSlot(1, headerType, 0)
+	b.PrependInt16Slot(0, metadataV5, 0)
+	b.Finish(b.EndObject())
+	return b.FinishedBytes()
+}
+
+// encode appends the buffers holding the values of the column for the given uids to the record
+// batch: the validity bitmap, followed by the values for the fixed-width types, or by the offsets
+// and the data for strings.
+func (c *column) encode(uids []uint64, rb *recordBatch) error {
+	n := len(uids)
+	validity := make([]byte, (n+7)/8)
+	var nulls int64
+	var values, data []byte
+	switch c.typ {
+	case colBool:
+		values = make([]byte, (n+7)/8)
+	case colUtf8:
+		values = make([]byte, 4*(n+1))
+	default:
+		values = make([]byte, 8*n)
+	}
+
+	for i, uid := range uids {
+		if c.typ == colUid {
+			if uid == math.MaxUint64 {
+				nulls++
+				continue
+			}
+			validity[i/8] |= 1 << (i % 8)
+			binary.LittleEndian.PutUint64(values[8*i:], uid)
+			continue
+		}
+
+		v, ok := c.vals[uid]
+		if c.typ == colUtf8 {
+			if ok {
+				s, err := valueString(v)
+				if err != nil {
+					return err
+				}
+				data = append(data, s...)
+			}
+			if len(data) > math.MaxInt32 {
+				return errors.Errorf("more than %d bytes of strings in a record batch",
+					math.MaxInt32)
+			}
+			binary.LittleEndian.PutUint32(values[4*(i+1):], uint32(len(data)))
+		}
+		if !ok {
+			nulls++
+			continue
+		}
+		validity[i/8] |= 1 << (i % 8)
+
+		switch c.typ {
+		case colInt64:
+			binary.LittleEndian.PutUint64(values[8*i:], uint64(v.Value.(int64)))
+		case colFloat64:
+			f, ok := v.Value.(float64)
+			if !ok {
+				f = float64(v.Value.(int64))
+			}
+			binary.LittleEndian.PutUint64(values[8*i:], math.Float64bits(f))
+		case colBool:
+			if v.Value.(bool) {
+				values[i/8] |= 1 << (i % 8)
+			}
+		case colTimestamp:
+			t := v.Value.(time.Time)
+			us := t.Unix()*1e6 + int64(t.Nanosecond()/1e3)
+			binary.LittleEndian.PutUint64(values[8*i:], uint64(us))
+		}
+	}
+
+	rb.nodes = append(rb.nodes, fieldNode{length: int64(n), nullCount: nulls})
+	if nulls == 0 {
+		// The validity bitmap can be omitted when there's no null.
+		validity = nil
+	}
+	rb.addBuffer(validity)
+	rb.addBuffer(values)
+	if c.typ == colUtf8 {
+		rb.addBuffer(data)
+	}
+	return nil
+}
+
+func valueString(v types.Val) (string, error) {
+	if s, ok := v.Value.(string); ok {
+		return s, nil
+	}
+	out := types.Val{Tid: types.StringID}
+	if err := types.Marshal(v, &out); err != nil {
+		// Some types, like geo, can't be converted to a string.
+		return fmt.Sprintf("%v", v.Value), nil
+	}
+	s, ok := out.Value.(string)
+	if !ok {
+		return "", errors.Errorf("unexpected %T converting %s to a string", out.Value,
+			v.Tid.Name())
+	}
+	return s, nil
+}
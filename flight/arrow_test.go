@@ -0,0 +1,121 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flight
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/dgraph-io/dgraph/query"
+	"github.com/dgraph-io/dgraph/types"
+	flatbuffers "github.com/google/flatbuffers/go"
+	"github.com/stretchr/testify/require"
+)
+
+func intVal(i int64) types.Val {
+	return types.Val{Tid: types.IntID, Value: i}
+}
+
+func TestColumnTypeOf(t *testing.T) {
+	require.Equal(t, colInt64, columnTypeOf(map[uint64]types.Val{1: intVal(1), 2: intVal(2)}))
+	require.Equal(t, colFloat64, columnTypeOf(map[uint64]types.Val{
+		1: intVal(1),
+		2: {Tid: types.FloatID, Value: 2.5},
+	}))
+	require.Equal(t, colUtf8, columnTypeOf(map[uint64]types.Val{
+		1: intVal(1),
+		2: {Tid: types.StringID, Value: "a"},
+	}))
+	require.Equal(t, colUtf8, columnTypeOf(nil))
+}
+
+func TestTableRecordBatch(t *testing.T) {
+	vars := query.ValueVars{
+		"age":  {0x1: intVal(30), 0x3: intVal(40)},
+		"name": {0x1: {Tid: types.StringID, Value: "ab"}, 0x2: {Tid: types.StringID, Value: "c"}},
+		"avg":  {math.MaxUint64: {Tid: types.FloatID, Value: 35.0}},
+	}
+	tbl, err := newTable(vars, nil)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{0x1, 0x2, 0x3, math.MaxUint64}, tbl.uids)
+	var names []string
+	for _, c := range tbl.columns {
+		names = append(names, c.name)
+	}
+	require.Equal(t, []string{"uid", "age", "avg", "name"}, names)
+
+	_, err = newTable(vars, []string{"missing"})
+	require.Error(t, err)
+
+	rb := &recordBatch{}
+	require.NoError(t, tbl.columns[1].encode(tbl.uids, rb))
+	require.Equal(t, []fieldNode{{length: 4, nullCount: 2}}, rb.nodes)
+	require.Len(t, rb.buffers, 2)
+	// Rows 0 and 2 are valid.
+	require.Equal(t, byte(0x5), rb.body[rb.buffers[0].offset])
+	values := rb.body[rb.buffers[1].offset:]
+	require.Equal(t, uint64(30), binary.LittleEndian.Uint64(values[0:]))
+	require.Equal(t, uint64(40), binary.LittleEndian.Uint64(values[16:]))
+
+	rb = &recordBatch{}
+	require.NoError(t, tbl.columns[3].encode(tbl.uids, rb))
+	require.Len(t, rb.buffers, 3)
+	offsets := rb.body[rb.buffers[1].offset:]
+	for i, expected := range []uint32{0, 2, 3, 3, 3} {
+		require.Equal(t, expected, binary.LittleEndian.Uint32(offsets[4*i:]))
+	}
+	require.Equal(t, "abc", string(rb.body[rb.buffers[2].offset:rb.buffers[2].offset+3]))
+	for _, b := range rb.buffers {
+		require.Zero(t, b.offset%8)
+	}
+
+	header, body, err := tbl.recordBatch(0, len(tbl.uids))
+	require.NoError(t, err)
+	require.Zero(t, len(body)%8)
+	requireMessage(t, header, headerRecordBatch, int64(len(body)))
+	requireMessage(t, tbl.schemaMessage(), headerSchema, 0)
+}
+
+// requireMessage checks the fields of the Message table read from the flatbuffer.
+func requireMessage(t *testing.T, b []byte, headerType byte, bodyLength int64) {
+	msg := &flatbuffers.Table{Bytes: b, Pos: flatbuffers.GetUOffsetT(b)}
+	// The field i of a table is at offset 4+2*i of its vtable.
+	require.Equal(t, int16(metadataV5), msg.GetInt16(msg.Pos+flatbuffers.UOffsetT(msg.Offset(4))))
+	require.Equal(t, headerType, msg.GetByte(msg.Pos+flatbuffers.UOffsetT(msg.Offset(6))))
+	if bodyLength > 0 {
+		require.Equal(t, bodyLength, msg.GetInt64(msg.Pos+flatbuffers.UOffsetT(msg.Offset(10))))
+	}
+}
+
+func TestFlightDataWireFormat(t *testing.T) {
+	in := &FlightData{DataHeader: []byte("header"), DataBody: []byte("body")}
+	b, err := in.Marshal()
+	require.NoError(t, err)
+	// data_body is the field 1000, whose key is a 2 bytes varint.
+	require.Equal(t, append([]byte{0x12, 6}, append([]byte("header"),
+		append([]byte{0xc2, 0x3e, 4}, []byte("body")...)...)...), b)
+
+	out := &FlightData{}
+	require.NoError(t, out.Unmarshal(b))
+	require.Equal(t, in, out)
+
+	tk := &Ticket{}
+	// The unknown field 2 is skipped.
+	require.NoError(t, tk.Unmarshal([]byte{0x10, 0x01, 0x0a, 2, '{', '}'}))
+	require.Equal(t, []byte("{}"), tk.Ticket)
+}
@@ -0,0 +1,260 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package flight serves the value variables of DQL queries as Apache Arrow record batches over
+// Arrow Flight, so that large results can be loaded into data frames without going through JSON.
+//
+// Only the DoGet method of the Flight service is implemented. The ticket is a JSON object like
+//
+//	{"query": "...", "variables": {"$a": "..."}, "vars": ["age", "score"]}
+//
+// The response has a uid column, followed by a column for each of the value variables listed in
+// vars, or for all of them if none is listed.
+package flight
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+
+	"github.com/dgraph-io/dgo/v210/protos/api"
+	"github.com/dgraph-io/dgraph/edgraph"
+	"github.com/dgraph-io/dgraph/query"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Register registers the Flight service on the gRPC server, next to the Dgraph service.
+func Register(s *grpc.Server) {
+	s.RegisterService(&serviceDesc, &Server{})
+}
+
+// FlightServer is the part of the Flight service implemented by Dgraph.
+type FlightServer interface {
+	DoGet(*Ticket, grpc.ServerStream) error
+}
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: "arrow.flight.protocol.FlightService",
+	HandlerType: (*FlightServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "DoGet",
+			Handler:       doGetHandler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "Flight.proto",
+}
+
+func doGetHandler(srv interface{}, stream grpc.ServerStream) error {
+	t := &Ticket{}
+	if err := stream.RecvMsg(t); err != nil {
+		return err
+	}
+	return srv.(FlightServer).DoGet(t, stream)
+}
+
+// request is the content of a ticket.
+type request struct {
+	Query     string            `json:"query"`
+	Variables map[string]string `json:"variables"`
+	Vars      []string          `json:"vars"`
+}
+
+// Server implements the Flight service.
+type Server struct{}
+
+// DoGet runs the read-only query of the ticket, and streams the schema of the table made of its
+// value variables, followed by its rows in record batches. The query is run like a query of the
+// Dgraph service, with the metadata of the call, e.g. the accessJwt.
+func (s *Server) DoGet(t *Ticket, stream grpc.ServerStream) error {
+	var req request
+	if err := json.Unmarshal(t.Ticket, &req); err != nil {
+		return status.Errorf(codes.InvalidArgument, "while reading the ticket: %v", err)
+	}
+	if req.Query == "" {
+		return status.Error(codes.InvalidArgument, "the ticket has no query")
+	}
+
+	vars := &query.ValueVars{}
+	ctx := context.WithValue(stream.Context(), query.ValueVarsKey, vars)
+	if _, err := (&edgraph.Server{}).Query(ctx, &api.Request{
+		Query:    req.Query,
+		Vars:     req.Variables,
+		ReadOnly: true,
+	}); err != nil {
+		return err
+	}
+
+	tbl, err := newTable(*vars, req.Vars)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := stream.SendMsg(&FlightData{DataHeader: tbl.schemaMessage()}); err != nil {
+		return err
+	}
+	for lo := 0; lo < len(tbl.uids); lo += maxBatchRows {
+		hi := lo + maxBatchRows
+		if hi > len(tbl.uids) {
+			hi = len(tbl.uids)
+		}
+		header, body, err := tbl.recordBatch(lo, hi)
+		if err != nil {
+			return err
+		}
+		if err := stream.SendMsg(&FlightData{DataHeader: header, DataBody: body}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Ticket is the Ticket message of Flight.proto.
+type Ticket struct {
+	Ticket []byte `protobuf:"bytes,1,opt,name=ticket,proto3" json:"ticket,omitempty"`
+}
+
+func (m *Ticket) Reset()         { *m = Ticket{} }
+func (m *Ticket) String() string { return fmt.Sprintf("%q", m.Ticket) }
+func (*Ticket) ProtoMessage()    {}
+
+// Marshal encodes the ticket in the protobuf wire format.
+func (m *Ticket) Marshal() ([]byte, error) {
+	return appendBytesField(nil, 1, m.Ticket), nil
+}
+
+// Unmarshal decodes the ticket from the protobuf wire format, skipping the unknown fields.
+func (m *Ticket) Unmarshal(b []byte) error {
+	m.Reset()
+	for len(b) > 0 {
+		key, n := binary.Uvarint(b)
+		if n <= 0 {
+			return errors.New("invalid field key in Ticket")
+		}
+		b = b[n:]
+		num, wireType := key>>3, key&7
+		val, rest, err := readField(b, wireType)
+		if err != nil {
+			return err
+		}
+		if num == 1 && wireType == 2 {
+			m.Ticket = append([]byte{}, val...)
+		}
+		b = rest
+	}
+	return nil
+}
+
+// FlightData is the FlightData message of Flight.proto, without the flight descriptor which is
+// only sent by the clients calling DoPut.
+type FlightData struct {
+	DataHeader  []byte `protobuf:"bytes,2,opt,name=data_header,json=dataHeader,proto3"`
+	AppMetadata []byte `protobuf:"bytes,3,opt,name=app_metadata,json=appMetadata,proto3"`
+	DataBody    []byte `protobuf:"bytes,1000,opt,name=data_body,json=dataBody,proto3"`
+}
+
+func (m *FlightData) Reset()         { *m = FlightData{} }
+func (m *FlightData) String() string { return fmt.Sprintf("FlightData(%d bytes)", len(m.DataBody)) }
+func (*FlightData) ProtoMessage()    {}
+
+// Marshal encodes the message in the protobuf wire format.
+func (m *FlightData) Marshal() ([]byte, error) {
+	b := make([]byte, 0, len(m.DataHeader)+len(m.AppMetadata)+len(m.DataBody)+32)
+	b = appendBytesField(b, 2, m.DataHeader)
+	b = appendBytesField(b, 3, m.AppMetadata)
+	b = appendBytesField(b, 1000, m.DataBody)
+	return b, nil
+}
+
+// Unmarshal decodes the message from the protobuf wire format, skipping the unknown fields.
+func (m *FlightData) Unmarshal(b []byte) error {
+	m.Reset()
+	for len(b) > 0 {
+		key, n := binary.Uvarint(b)
+		if n <= 0 {
+			return errors.New("invalid field key in FlightData")
+		}
+		b = b[n:]
+		num, wireType := key>>3, key&7
+		val, rest, err := readField(b, wireType)
+		if err != nil {
+			return err
+		}
+		if wireType == 2 {
+			switch num {
+			case 2:
+				m.DataHeader = append([]byte{}, val...)
+			case 3:
+				m.AppMetadata = append([]byte{}, val...)
+			case 1000:
+				m.DataBody = append([]byte{}, val...)
+			}
+		}
+		b = rest
+	}
+	return nil
+}
+
+func appendBytesField(b []byte, num uint64, val []byte) []byte {
+	if len(val) == 0 {
+		return b
+	}
+	b = appendUvarint(b, num<<3|2)
+	b = appendUvarint(b, uint64(len(val)))
+	return append(b, val...)
+}
+
+func appendUvarint(b []byte, v uint64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], v)
+	return append(b, buf[:n]...)
+}
+
+// readField reads the value of a field of the given wire type, and returns it along with the
+// rest of the message.
+func readField(b []byte, wireType uint64) ([]byte, []byte, error) {
+	switch wireType {
+	case 0:
+		_, n := binary.Uvarint(b)
+		if n <= 0 {
+			return nil, nil, errors.New("invalid varint")
+		}
+		return b[:n], b[n:], nil
+	case 1:
+		if len(b) < 8 {
+			return nil, nil, errors.New("truncated fixed64")
+		}
+		return b[:8], b[8:], nil
+	case 2:
+		l, n := binary.Uvarint(b)
+		if n <= 0 || uint64(len(b)-n) < l {
+			return nil, nil, errors.New("truncated length-delimited field")
+		}
+		return b[n : n+int(l)], b[n+int(l):], nil
+	case 5:
+		if len(b) < 4 {
+			return nil, nil, errors.New("truncated fixed32")
+		}
+		return b[:4], b[4:], nil
+	default:
+		return nil, nil, errors.Errorf("unsupported wire type %d", wireType)
+	}
+}
@@ -451,6 +451,52 @@ func TestLangsFilter(t *testing.T) {
 	require.Equal(t, "en", res.Query[0].Children[0].Filter.Func.Lang)
 }
 
+func TestLangsFilterFallback(t *testing.T) {
+	query := `
+	query {
+		me(func: eq(name@en:fr:., "Paris")) @filter(anyofterms(descr@fr:en, "ville")) {
+			count(name@en)
+		}
+	}
+`
+	res, err := Parse(Request{Str: query})
+	require.NoError(t, err)
+	require.Equal(t, "name", res.Query[0].Func.Attr)
+	require.Equal(t, "en:fr:.", res.Query[0].Func.Lang)
+	require.Equal(t, []Arg{{Value: "Paris"}}, res.Query[0].Func.Args)
+	require.Equal(t, "descr", res.Query[0].Filter.Func.Attr)
+	require.Equal(t, "fr:en", res.Query[0].Filter.Func.Lang)
+	require.True(t, res.Query[0].Children[0].IsCount)
+	require.Equal(t, []string{"en"}, res.Query[0].Children[0].Langs)
+}
+
+func TestFilterCoercion(t *testing.T) {
+	query := `
+	query {
+		me(func: uid(0x0a)) @filter(gt(toInt(age), 18) and
+			between(toDateTime(dob@en), "2000", "2010")) {
+			a as age
+			total : math(toFloat(a) + 1)
+		}
+	}
+`
+	res, err := Parse(Request{Str: query})
+	require.NoError(t, err)
+	require.Equal(t, `(AND (gt toint(age) "18") (between todatetime(dob)@en "2000" "2010"))`,
+		res.Query[0].Filter.debugString())
+	require.Equal(t, "int", res.Query[0].Filter.Child[0].Func.Coerce)
+	require.Equal(t, "(+ (tofloat a) 1)", res.Query[0].Children[1].MathExp.debugString())
+
+	for _, query := range []string{
+		`{ me(func: uid(0x0a)) @filter(anyofterms(toString(name), "a")) { name } }`,
+		`{ me(func: uid(0x0a)) @filter(gt(age, toInt(a))) { name } }`,
+		`{ me(func: uid(0x0a)) @filter(eq(toInt(age, 2), 2)) { name } }`,
+	} {
+		_, err := Parse(Request{Str: query})
+		require.Error(t, err, query)
+	}
+}
+
 func TestLangsFilter_error1(t *testing.T) {
 	// this query should fail, because '@lang' is used twice (and only one appearance is allowed)
 	query := `
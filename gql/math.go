@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/dgraph-io/dgraph/lex"
 	"github.com/dgraph-io/dgraph/types"
@@ -65,7 +66,7 @@ type MathTree struct {
 
 func isUnary(f string) bool {
 	return f == "exp" || f == "ln" || f == "u-" || f == "sqrt" ||
-		f == "floor" || f == "ceil" || f == "since"
+		f == "floor" || f == "ceil" || f == "since" || isCoercionFunc(f)
 }
 
 func isBinaryMath(f string) bool {
@@ -163,7 +164,7 @@ func isMathFunc(f string) bool {
 		f == "==" || f == "!=" ||
 		f == "min" || f == "max" || f == "sqrt" ||
 		f == "pow" || f == "logbase" || f == "floor" || f == "ceil" ||
-		f == "since"
+		f == "since" || isCoercionFunc(f)
 }
 
 func parseMathFunc(it *lex.ItemIterator, again bool) (*MathTree, bool, error) {
@@ -250,13 +251,18 @@ loop:
 				}
 				continue
 			}
-			// We will try to parse the constant as an Int first, if that fails we move to float
+			// We will try to parse the constant as an Int first, if that fails we move to float,
+			// and then to an ISO-8601 duration like PT1H.
 			child := &MathTree{}
 			i, err := strconv.ParseInt(item.Val, 10, 64)
 			if err != nil {
 				v, err := strconv.ParseFloat(item.Val, 64)
 				if err != nil {
-					child.Var = item.Val
+					if d, err := types.ParseDuration(item.Val); err == nil {
+						child.Const = types.Val{Tid: types.DurationID, Value: d}
+					} else {
+						child.Var = item.Val
+					}
 				} else {
 					child.Const = types.Val{
 						Tid:   types.FloatID,
@@ -373,6 +379,8 @@ func (t *MathTree) stringHelper(buf *bytes.Buffer) {
 				t.Const.Value.(float64), 'E', -1, 64))
 		case types.IntID:
 			leafStr, err = buf.WriteString(strconv.FormatInt(t.Const.Value.(int64), 10))
+		case types.DurationID:
+			leafStr, err = buf.WriteString(types.FormatDuration(t.Const.Value.(time.Duration)))
 		}
 		x.Check2(leafStr, err)
 		return
@@ -382,7 +390,7 @@ func (t *MathTree) stringHelper(buf *bytes.Buffer) {
 	switch t.Fn {
 	case "+", "-", "/", "*", "%", "exp", "ln", "cond", "min",
 		"sqrt", "max", "<", ">", "<=", ">=", "==", "!=", "u-",
-		"logbase", "pow":
+		"logbase", "pow", "toint", "tofloat", "todatetime", "tostring":
 		x.Check2(buf.WriteString(t.Fn))
 	default:
 		x.Fatalf("Unknown operator: %q", t.Fn)
@@ -19,6 +19,7 @@ package gql
 import (
 	"bytes"
 	"fmt"
+	"path"
 	"sort"
 	"strconv"
 	"strings"
@@ -31,18 +32,24 @@ import (
 )
 
 const (
-	uidFunc   = "uid"
-	valueFunc = "val"
-	typFunc   = "type"
-	lenFunc   = "len"
-	countFunc = "count"
-	uidInFunc = "uid_in"
+	uidFunc      = "uid"
+	valueFunc    = "val"
+	typFunc      = "type"
+	lenFunc      = "len"
+	countFunc    = "count"
+	uidInFunc    = "uid_in"
+	notUidInFunc = "not_uid_in"
+	joinFunc     = "join"
+	betweenFunc  = "between"
 )
 
 var (
 	errExpandType = "expand is only compatible with type filters"
 )
 
+// maxExpandDepth bounds the depth argument of expand().
+const maxExpandDepth = 10
+
 // GraphQuery stores the parsed Query in a tree format. This gets converted to
 // pb.y used query.SubGraph before processing the query.
 type GraphQuery struct {
@@ -57,6 +64,10 @@ type GraphQuery struct {
 	NeedsVar   []VarContext
 	Func       *Function
 	Expand     string // Which variable to expand with.
+	// ExpandPatterns holds the glob patterns of the predicates to expand, like "person.*".
+	ExpandPatterns []string
+	// ExpandDepth is the number of levels of uid predicates to expand, if set.
+	ExpandDepth int
 
 	Args map[string]string
 	// Query can have multiple sort parameters.
@@ -68,6 +79,7 @@ type GraphQuery struct {
 	Recurse          bool
 	RecurseArgs      RecurseArgs
 	ShortestPathArgs ShortestPathArgs
+	Join             *JoinArgs
 	Cascade          []string
 	IgnoreReflex     bool
 	Facets           *pb.FacetParams
@@ -75,6 +87,9 @@ type GraphQuery struct {
 	GroupbyAttrs     []GroupByAttr
 	FacetVar         map[string]string
 	FacetsOrder      []*FacetOrder
+	// ValidAt is the time given by the @validAt directive. Only the edges valid at that time are
+	// read, in this block and the blocks nested in it.
+	ValidAt string
 
 	// Used for ACL enabled queries to curtail results to only accessible params
 	AllowedPreds []string
@@ -106,6 +121,19 @@ type ShortestPathArgs struct {
 	To   *Function
 }
 
+// JoinArgs stores the arguments of join(), which traverses a virtual edge from the nodes of a
+// block to the nodes of a uid variable. The objects of the edge are the nodes of the variable
+// whose value of VarAttr equals the value of Attr of the subject, like in
+// writer: join(authors, author_email, email).
+type JoinArgs struct {
+	// Var is the uid variable holding the nodes to join.
+	Var string
+	// Attr is the predicate of the subjects compared, or uid to compare the subjects themselves.
+	Attr string
+	// VarAttr is the predicate of the nodes of the variable compared, or uid.
+	VarAttr string
+}
+
 // GroupByAttr stores the arguments needed to process the @groupby directive.
 type GroupByAttr struct {
 	Attr  string
@@ -177,7 +205,7 @@ type Arg struct {
 // Function holds the information about gql functions.
 type Function struct {
 	Attr       string
-	Lang       string // language of the attribute value
+	Lang       string // languages of the attribute value in order of preference, e.g. en:fr:.
 	Name       string // Specifies the name of the function.
 	Args       []Arg  // Contains the arguments of the function.
 	UID        []uint64
@@ -185,6 +213,7 @@ type Function struct {
 	IsCount    bool         // gt(count(friends),0)
 	IsValueVar bool         // eq(val(s), 5)
 	IsLenVar   bool         // eq(len(s), 5)
+	Coerce     string       // gt(toInt(age), 18) has the type int.
 }
 
 // filterOpPrecedence is a map from filterOp (a string) to its precedence.
@@ -194,18 +223,22 @@ var filterOpPrecedence = map[string]int{
 	"or":  1,
 }
 var mathOpPrecedence = map[string]int{
-	"u-":      500,
-	"floor":   105,
-	"ceil":    104,
-	"since":   103,
-	"exp":     100,
-	"ln":      99,
-	"sqrt":    98,
-	"cond":    90,
-	"pow":     89,
-	"logbase": 88,
-	"max":     85,
-	"min":     84,
+	"u-":         500,
+	"toint":      109,
+	"tofloat":    108,
+	"todatetime": 107,
+	"tostring":   106,
+	"floor":      105,
+	"ceil":       104,
+	"since":      103,
+	"exp":        100,
+	"ln":         99,
+	"sqrt":       98,
+	"cond":       90,
+	"pow":        89,
+	"logbase":    88,
+	"max":        85,
+	"min":        84,
 
 	"/": 50,
 	"*": 49,
@@ -221,6 +254,20 @@ var mathOpPrecedence = map[string]int{
 	"!=": 5,
 }
 
+// coercionTypes maps the coercion functions, which convert values at query time, to the names of
+// the types they convert to.
+var coercionTypes = map[string]string{
+	"toint":      "int",
+	"tofloat":    "float",
+	"todatetime": "datetime",
+	"tostring":   "string",
+}
+
+func isCoercionFunc(f string) bool {
+	_, ok := coercionTypes[f]
+	return ok
+}
+
 // IsAggregator returns true if the function name is an aggregation function.
 func (f *Function) IsAggregator() bool {
 	return isAggregator(f.Name)
@@ -574,16 +621,48 @@ func LexQuery(req string) []lex.Item {
 // The variable name v needs to be passed through the needVars parameter. Otherwise, an error
 // is reported complaining that the variable v is defined but not used in the query block.
 func ParseWithNeedVars(r Request, needVars []string) (res Result, rerr error) {
-	query := r.Str
-	vmap := convertToVarMap(r.Variables)
-
 	var lexer lex.Lexer
-	lexer.Reset(query)
+	lexer.Reset(r.Str)
 	lexer.Run(lexTopLevel)
 	if err := lexer.ValidateResult(); err != nil {
 		return res, err
 	}
+	return parseLexed(&lexer, convertToVarMap(r.Variables), needVars)
+}
+
+// PreparedQuery is a query lexed once, which can then be parsed many times with different
+// variables. The variables are substituted while building the GraphQuery tree, so the tree itself
+// can't be shared by the requests running the query.
+// A PreparedQuery is safe for concurrent use.
+type PreparedQuery struct {
+	lexer lex.Lexer
+}
+
+// Prepare lexes the query and returns a PreparedQuery for it.
+func Prepare(query string) (*PreparedQuery, error) {
+	p := &PreparedQuery{}
+	p.lexer.Reset(query)
+	p.lexer.Run(lexTopLevel)
+	if err := p.lexer.ValidateResult(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Query returns the query string the PreparedQuery was made from.
+func (p *PreparedQuery) Query() string {
+	return p.lexer.Input
+}
+
+// Parse constructs the GraphQuery subgraph from the prepared query and the given variables, like
+// ParseWithNeedVars does from the query string.
+func (p *PreparedQuery) Parse(vars map[string]string, needVars []string) (Result, error) {
+	return parseLexed(&p.lexer, convertToVarMap(vars), needVars)
+}
 
+// parseLexed constructs the GraphQuery subgraph from the items emitted by the lexer. The lexer is
+// only read, so that the items of a PreparedQuery can be parsed concurrently.
+func parseLexed(lexer *lex.Lexer, vmap varMap, needVars []string) (res Result, rerr error) {
 	var qu *GraphQuery
 	it := lexer.NewIterator()
 	fmap := make(fragmentMap)
@@ -986,6 +1065,10 @@ L:
 				}
 			case "ignorereflex":
 				gq.IgnoreReflex = true
+			case "validat":
+				if err := parseValidAt(it, gq); err != nil {
+					return nil, err
+				}
 			case "recurse":
 				gq.Recurse = true
 				if err := parseRecurseArgs(it, gq); err != nil {
@@ -1294,7 +1377,7 @@ func unquoteIfQuoted(str string) (string, error) {
 // parseArguments parses the arguments part of the GraphQL query root.
 func parseArguments(it *lex.ItemIterator, gq *GraphQuery) (result []pair, rerr error) {
 	expectArg := true
-	orderCount := 0
+	varOrdered := false
 loop:
 	for it.Next() {
 		var p pair
@@ -1306,9 +1389,6 @@ loop:
 				return result, item.Errorf("Expecting a comma. But got: %v", item.Val)
 			}
 			p.Key = collectName(it, item.Val)
-			if isSortkey(p.Key) {
-				orderCount++
-			}
 			expectArg = false
 		case itemRightRound:
 			if expectArg {
@@ -1346,9 +1426,13 @@ loop:
 			gq.NeedsVar[len(gq.NeedsVar)-1].Typ = ValueVar
 			p.Val = gq.NeedsVar[len(gq.NeedsVar)-1].Name
 			result = append(result, p)
-			if isSortkey(p.Key) && orderCount > 1 {
-				return result, item.Errorf("Multiple sorting only allowed by predicates. Got: %+v",
-					p.Val)
+			if isSortkey(p.Key) {
+				// A value variable can be one of multiple sort keys, but only one of them.
+				if varOrdered {
+					return result, item.Errorf("Sorting by more than one value variable isn't "+
+						"supported. Got: %+v", p.Val)
+				}
+				varOrdered = true
 			}
 			continue
 		}
@@ -1418,9 +1502,11 @@ func (f *FilterTree) stringHelper(buf *bytes.Buffer) {
 				x.Check2(buf.WriteString("val("))
 			case f.Func.IsLenVar:
 				x.Check2(buf.WriteString("len("))
+			case len(f.Func.Coerce) > 0:
+				x.Check2(buf.WriteString("to" + f.Func.Coerce + "("))
 			}
 			x.Check2(buf.WriteString(f.Func.Attr))
-			if f.Func.IsCount || f.Func.IsValueVar || f.Func.IsLenVar {
+			if f.Func.IsCount || f.Func.IsValueVar || f.Func.IsLenVar || len(f.Func.Coerce) > 0 {
 				x.Check2(buf.WriteRune(')'))
 			}
 			if len(f.Func.Lang) > 0 {
@@ -1636,7 +1722,7 @@ func validFuncName(name string) bool {
 
 	switch name {
 	case "regexp", "anyofterms", "allofterms", "alloftext", "anyoftext",
-		"has", "uid", "uid_in", "anyof", "allof", "type", "match":
+		"has", "uid", "uid_in", "not_uid_in", "anyof", "allof", "type", "match":
 		return true
 	}
 	return false
@@ -1663,7 +1749,9 @@ func parseRegexArgs(val string) (regexArgs, error) {
 
 func parseFunction(it *lex.ItemIterator, gq *GraphQuery) (*Function, error) {
 	function := &Function{}
-	var expectArg, seenFuncArg, expectLang, isDollar bool
+	var expectArg, seenFuncArg, isDollar bool
+	// openBounds are the indexes of the args of between() given as *.
+	var openBounds []int
 L:
 	for it.Next() {
 		item := it.Item()
@@ -1742,6 +1830,20 @@ L:
 				case countFunc:
 					function.Attr = nestedFunc.Attr
 					function.IsCount = true
+				case "toint", "tofloat", "todatetime", "tostring":
+					// The values of the attribute are converted, eq(toInt(age), 18)
+					if !IsInequalityFn(function.Name) || len(function.Attr) > 0 {
+						return nil, itemInFunc.Errorf("%s function only allowed as the "+
+							"attribute of an inequality function", nestedFunc.Name)
+					}
+					if len(nestedFunc.Attr) == 0 || len(nestedFunc.Args) > 0 ||
+						len(nestedFunc.NeedsVar) > 0 {
+						return nil, itemInFunc.Errorf("%s function expects a single attribute",
+							nestedFunc.Name)
+					}
+					function.Attr = nestedFunc.Attr
+					function.Lang = nestedFunc.Lang
+					function.Coerce = coercionTypes[nestedFunc.Name]
 				case uidFunc:
 					// TODO (Anurag): See if is is possible to support uid(1,2,3) when
 					// uid is nested inside a function like @filter(uid_in(predicate, uid()))
@@ -1757,7 +1859,8 @@ L:
 					function.NeedsVar[0].Typ = UidVar
 					function.Args = append(function.Args, Arg{Value: nestedFunc.NeedsVar[0].Name})
 				default:
-					return nil, itemInFunc.Errorf("Only val/count/len/uid allowed as function "+
+					return nil, itemInFunc.Errorf("Only val/count/len/uid/toInt/toFloat/"+
+						"toDateTime/toString allowed as function "+
 						"within another. Got: %s", nestedFunc.Name)
 				}
 				expectArg = false
@@ -1767,9 +1870,30 @@ L:
 					return nil, itemInFunc.Errorf("Invalid usage of '@' in function " +
 						"argument, must only appear immediately after attr.")
 				}
-				expectLang = true
+				it.Next()
+				langs, err := parseLanguageList(it)
+				if err != nil {
+					return nil, err
+				}
+				if len(langs) == 0 {
+					return nil, itemInFunc.Errorf("Expected a language after @ in function [%s]",
+						function.Name)
+				}
+				if langs[0] == string(star) {
+					return nil, errors.Errorf(
+						"The * symbol cannot be used as a valid language inside functions")
+				}
+				function.Lang = strings.Join(langs, ":")
 				continue
 			case itemMathOp:
+				if function.Name == betweenFunc && itemInFunc.Val == "*" && expectArg &&
+					len(function.Attr) > 0 {
+					// An open bound, e.g. between(age, 18, *).
+					openBounds = append(openBounds, len(function.Args))
+					function.Args = append(function.Args, Arg{})
+					expectArg = false
+					continue
+				}
 				val = itemInFunc.Val
 				it.Next()
 				itemInFunc = it.Item()
@@ -1797,7 +1921,7 @@ L:
 				case IsInequalityFn(function.Name):
 					err = parseFuncArgs(it, function)
 
-				case function.Name == "uid_in":
+				case function.Name == uidInFunc || function.Name == notUidInFunc:
 					err = parseFuncArgs(it, function)
 
 				default:
@@ -1831,7 +1955,7 @@ L:
 				continue
 			}
 
-			if !expectArg && !expectLang {
+			if !expectArg {
 				return nil, itemInFunc.Errorf("Expected comma or language but got: %s",
 					itemInFunc.Val)
 			}
@@ -1871,18 +1995,13 @@ L:
 					return nil, itemInFunc.Errorf("Attribute in function"+
 						" must not be quoted with \": %s", itemInFunc.Val)
 				}
-				if function.Name == uidInFunc && item.Typ == itemRightRound {
-					return nil, itemInFunc.Errorf("uid_in function expects an argument, got none")
+				if (function.Name == uidInFunc || function.Name == notUidInFunc) &&
+					item.Typ == itemRightRound {
+					return nil, itemInFunc.Errorf("%s function expects an argument, got none",
+						function.Name)
 				}
 				function.Attr = val
 				attrItemsAgo = 0
-			case expectLang:
-				if val == "*" {
-					return nil, errors.Errorf(
-						"The * symbol cannot be used as a valid language inside functions")
-				}
-				function.Lang = val
-				expectLang = false
 			case function.Name != uidFunc:
 				// For UID function. we set g.UID
 				function.Args = append(function.Args, Arg{Value: val})
@@ -1943,9 +2062,33 @@ L:
 		return nil, it.Errorf("type function only supports one argument. Got: %v", function.Args)
 	}
 
+	if len(openBounds) > 0 {
+		if err := openBetween(it, function, openBounds); err != nil {
+			return nil, err
+		}
+	}
+
 	return function, nil
 }
 
+// openBetween rewrites a between() function having an open bound into the inequality of its
+// other bound: between(age, 18, *) is ge(age, 18) and between(age, *, 30) is le(age, 30).
+func openBetween(it *lex.ItemIterator, function *Function, openBounds []int) error {
+	switch {
+	case len(function.Args) != 2:
+		return it.Errorf("between function expects 2 bounds, got %d", len(function.Args))
+	case len(openBounds) > 1:
+		return it.Errorf("between function can't have both of its bounds open")
+	case openBounds[0] == 1:
+		function.Name = "ge"
+		function.Args = function.Args[:1]
+	default:
+		function.Name = "le"
+		function.Args = function.Args[1:]
+	}
+	return nil
+}
+
 type facetRes struct {
 	f           *pb.FacetParams
 	ft          *FilterTree
@@ -2191,6 +2334,32 @@ loop:
 	return nil
 }
 
+// parseValidAt parses the time of the @validAt directive, eg. @validAt("2023-01-01").
+func parseValidAt(it *lex.ItemIterator, gq *GraphQuery) error {
+	item := it.Item()
+	if gq.ValidAt != "" {
+		return item.Errorf("Only one validAt directive allowed.")
+	}
+	if !it.Next() || it.Item().Typ != itemLeftRound {
+		return item.Errorf("Expected a left round after validAt")
+	}
+	if !it.Next() || it.Item().Typ != itemName {
+		return item.Errorf("Expected a time in validAt")
+	}
+	val, err := unquoteIfQuoted(it.Item().Val)
+	if err != nil {
+		return err
+	}
+	if !it.Next() || it.Item().Typ != itemRightRound {
+		return item.Errorf("Expected a right round after the time in validAt")
+	}
+	if val == "" {
+		return item.Errorf("Expected a time in validAt")
+	}
+	gq.ValidAt = val
+	return nil
+}
+
 // parseGroupby parses the groupby directive.
 func parseGroupby(it *lex.ItemIterator, gq *GraphQuery) error {
 	count := 0
@@ -2440,38 +2609,139 @@ loop:
 	return count, nil
 }
 
-func parseTypeList(it *lex.ItemIterator, gq *GraphQuery) error {
-	typeList := it.Item().Val
-	expectArg := false
+// parseExpandArgs parses the arguments of expand() other than a value variable: _all_, _owned_ or
+// a list of types, along with the quoted glob patterns of the predicates to expand, like
+// expand(Person, "address.*"). The last argument can be the number of levels of uid predicates to
+// expand, like expand(_all_, depth: 2).
+func parseExpandArgs(it *lex.ItemIterator, gq *GraphQuery) error {
+	var names []string
+	expectArg := true
 loop:
-	for it.Next() {
-		item := it.Item()
+	for item := it.Item(); ; item = it.Item() {
 		switch item.Typ {
 		case itemRightRound:
 			it.Prev()
 			break loop
 		case itemComma:
 			if expectArg {
-				return item.Errorf("Expected a variable but got comma")
+				return item.Errorf("Expected an argument of expand() but got comma")
 			}
 			expectArg = true
 		case itemName:
 			if !expectArg {
-				return item.Errorf("Expected a variable but got %s", item.Val)
+				return item.Errorf("Expected a comma in expand() but got %s", item.Val)
+			}
+			if gq.ExpandDepth > 0 {
+				return item.Errorf("The depth must be the last argument of expand()")
 			}
-			typeList = fmt.Sprintf("%s,%s", typeList, item.Val)
 			expectArg = false
+			next, ok := it.PeekOne()
+			switch {
+			case item.Val == "depth" && ok && next.Typ == itemColon:
+				it.Next() // Consume the ':'
+				if !it.Next() || it.Item().Typ != itemName {
+					return item.Errorf("Expected the depth of expand()")
+				}
+				depth, err := strconv.Atoi(it.Item().Val)
+				if err != nil || depth < 1 || depth > maxExpandDepth {
+					return item.Errorf("The depth of expand() must be between 1 and %d, got %s",
+						maxExpandDepth, it.Item().Val)
+				}
+				gq.ExpandDepth = depth
+			case item.Val[0] == quote:
+				pattern, err := unquoteIfQuoted(item.Val)
+				if err != nil {
+					return item.Errorf("Invalid pattern %s in expand(): %v", item.Val, err)
+				}
+				if _, err := path.Match(pattern, ""); err != nil {
+					return item.Errorf("Invalid pattern %s in expand(): %v", item.Val, err)
+				}
+				gq.ExpandPatterns = append(gq.ExpandPatterns, pattern)
+			default:
+				names = append(names, item.Val)
+			}
 		default:
-			return item.Errorf("Unexpected token %s when reading a type list", item.Val)
+			return item.Errorf("Unexpected token %s in expand()", item.Val)
+		}
+		if !it.Next() {
+			return item.Errorf("Expected ) at the end of expand()")
 		}
 	}
 	if expectArg {
-		return it.Item().Errorf("Unnecessary comma in val()")
+		return it.Item().Errorf("Unnecessary comma in expand()")
+	}
+
+	for _, name := range names {
+		if name != "_all_" && name != "_owned_" {
+			continue
+		}
+		if len(names) > 1 || len(gq.ExpandPatterns) > 0 {
+			return it.Item().Errorf("expand(%s) can't have other types or patterns", name)
+		}
+		if name == "_owned_" && gq.ExpandDepth > 0 {
+			return it.Item().Errorf("expand(_owned_) can't have a depth, the owned predicates " +
+				"are expanded at every level")
+		}
 	}
-	gq.Expand = typeList
+	if len(names) == 0 && len(gq.ExpandPatterns) == 0 {
+		return it.Item().Errorf("expand() needs a type or a pattern of predicates")
+	}
+	gq.Expand = strings.Join(names, ",")
 	return nil
 }
 
+// isJoinFunc returns true if the current item starts a join(), rather than a predicate named join
+// with arguments like join(first: 10).
+func isJoinFunc(it *lex.ItemIterator) bool {
+	items, err := it.Peek(3)
+	return err == nil && items[0].Typ == itemLeftRound && items[1].Typ == itemName &&
+		items[2].Typ != itemColon
+}
+
+// parseJoinArgs parses the arguments of join(): a uid variable, the predicate of the subjects, and
+// the predicate of the nodes of the variable if it's not the same, like join(authors, email).
+func parseJoinArgs(it *lex.ItemIterator, gq *GraphQuery) error {
+	it.Next() // Consume the '('
+	var args []string
+	expectArg := true
+loop:
+	for it.Next() {
+		item := it.Item()
+		switch item.Typ {
+		case itemRightRound:
+			break loop
+		case itemComma:
+			if expectArg {
+				return item.Errorf("Expected an argument of join() but got comma")
+			}
+			expectArg = true
+		case itemName:
+			if !expectArg {
+				return item.Errorf("Expected a comma in join() but got %s", item.Val)
+			}
+			args = append(args, collectName(it, item.Val))
+			expectArg = false
+		default:
+			return item.Errorf("Unexpected token %s in join()", item.Val)
+		}
+	}
+	if it.Item().Typ != itemRightRound {
+		return it.Errorf("Expected ) at the end of join()")
+	}
+	if len(args) != 2 && len(args) != 3 {
+		return it.Errorf("join() expects a uid variable and one or two predicates, got %d "+
+			"arguments", len(args))
+	}
+	gq.Join = &JoinArgs{Var: args[0], Attr: args[1], VarAttr: args[len(args)-1]}
+	gq.NeedsVar = append(gq.NeedsVar, VarContext{Name: args[0], Typ: UidVar})
+	return nil
+}
+
+// IsExpand returns true if the GraphQuery is an expand() of predicates.
+func (gq *GraphQuery) IsExpand() bool {
+	return gq.Expand != "" || len(gq.ExpandPatterns) > 0
+}
+
 func parseDirective(it *lex.ItemIterator, curp *GraphQuery) error {
 	valid := true
 	it.Prev()
@@ -2483,7 +2753,7 @@ func parseDirective(it *lex.ItemIterator, curp *GraphQuery) error {
 	it.Next()
 
 	isExpand := false
-	if curp != nil && len(curp.Expand) > 0 {
+	if curp != nil && curp.IsExpand() {
 		isExpand = true
 	}
 	// No directive is allowed on pb.subgraph like expand all (except type filters),
@@ -2559,6 +2829,10 @@ func parseDirective(it *lex.ItemIterator, curp *GraphQuery) error {
 			if err := parseGroupby(it, curp); err != nil {
 				return err
 			}
+		case "validAt":
+			if err := parseValidAt(it, curp); err != nil {
+				return err
+			}
 		default:
 			return item.Errorf("Unknown directive [%s]", item.Val)
 		}
@@ -2612,7 +2886,7 @@ func parseLanguageList(it *lex.ItemIterator) ([]string, error) {
 
 func validKeyAtRoot(k string) bool {
 	switch k {
-	case "func", "orderasc", "orderdesc", "first", "offset", "after", "random":
+	case "func", "orderasc", "orderdesc", "first", "offset", "after", "random", "sample":
 		return true
 	case "from", "to", "numpaths", "minweight", "maxweight":
 		// Specific to shortest path
@@ -2626,7 +2900,7 @@ func validKeyAtRoot(k string) bool {
 // Check for validity of key at non-root nodes.
 func validKey(k string) bool {
 	switch k {
-	case "orderasc", "orderdesc", "first", "offset", "after", "random":
+	case "orderasc", "orderdesc", "first", "offset", "after", "random", "sample":
 		return true
 	}
 	return false
@@ -2682,6 +2956,7 @@ func getRoot(it *lex.ItemIterator) (gq *GraphQuery, rerr error) {
 
 	expectArg := true
 	order := make(map[string]bool)
+	varOrdered := false
 	// Parse in KV fashion. Depending on the value of key, decide the path.
 loop:
 	for it.Next() {
@@ -2854,9 +3129,12 @@ loop:
 				}
 				val = gq.NeedsVar[len(gq.NeedsVar)-1].Name
 				// Right now we only allow one sort by a variable
-				if len(gq.Order) > 0 && isSortkey(key) {
-					return nil, it.Errorf("Multiple sorting only allowed by predicates. "+
-						"Got: %+v", val)
+				if isSortkey(key) {
+					if varOrdered {
+						return nil, it.Errorf("Sorting by more than one value variable isn't "+
+							"supported. Got: %+v", val)
+					}
+					varOrdered = true
 				}
 			}
 			if isSortkey(key) {
@@ -3087,6 +3365,24 @@ func godeep(it *lex.ItemIterator, gq *GraphQuery) error {
 				gq.Children = append(gq.Children, child)
 				curp = nil
 				continue
+			case valLower == joinFunc && isJoinFunc(it):
+				if alias == "" {
+					return it.Errorf("join() must have an alias")
+				}
+				child := &GraphQuery{
+					Attr:  val,
+					Alias: alias,
+					Var:   varName,
+					Args:  make(map[string]string),
+				}
+				varName, alias = "", ""
+				if err := parseJoinArgs(it, child); err != nil {
+					return err
+				}
+				gq.Children = append(gq.Children, child)
+				// Like a uid predicate, join() can have children and filters.
+				curp = child
+				continue
 			case isExpandFunc(valLower):
 				if varName != "" {
 					return it.Errorf("expand() cannot be used with a variable: %s", val)
@@ -3116,14 +3412,12 @@ func godeep(it *lex.ItemIterator, gq *GraphQuery) error {
 					}
 					child.NeedsVar[len(child.NeedsVar)-1].Typ = ListVar
 					child.Expand = child.NeedsVar[len(child.NeedsVar)-1].Name
-				case "_all_":
-					child.Expand = "_all_"
 				case "_forward_":
 					return item.Errorf("Argument _forward_ has been deprecated")
 				case "_reverse_":
 					return item.Errorf("Argument _reverse_ has been deprecated")
 				default:
-					if err := parseTypeList(it, child); err != nil {
+					if err := parseExpandArgs(it, child); err != nil {
 						return err
 					}
 				}
@@ -3315,7 +3609,8 @@ func godeep(it *lex.ItemIterator, gq *GraphQuery) error {
 }
 
 func isAggregator(fname string) bool {
-	return fname == "min" || fname == "max" || fname == "sum" || fname == "avg"
+	return fname == "min" || fname == "max" || fname == "sum" || fname == "avg" ||
+		fname == "countdistinct"
 }
 
 func isExpandFunc(name string) bool {
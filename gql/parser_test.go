@@ -1071,6 +1071,63 @@ func TestUidInWithNoParseErrors(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestNotUidIn(t *testing.T) {
+	query := `{
+		productVar as q(func: uid(5000))
+		me(func: type(Customer)) @filter(not_uid_in(bought, uid(productVar))) {
+			name
+			friend @filter(not_uid_in(school, [5000, 5001])) {
+				name
+			}
+		}
+	}`
+	res, err := Parse(Request{Str: query})
+	require.NoError(t, err)
+	filter := res.Query[1].Filter
+	require.Equal(t, "not_uid_in", filter.Func.Name)
+	require.Equal(t, "bought", filter.Func.Attr)
+	require.Equal(t, []VarContext{{Name: "productVar", Typ: UidVar}}, filter.Func.NeedsVar)
+	friend := res.Query[1].Children[1]
+	require.Equal(t, "not_uid_in", friend.Filter.Func.Name)
+	require.Equal(t, []Arg{{Value: "5000"}, {Value: "5001"}}, friend.Filter.Func.Args)
+
+	_, err = Parse(Request{Str: `{ me(func: uid(1)) @filter(not_uid_in(school)) { name } }`})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "not_uid_in function expects an argument, got none")
+}
+
+func TestParseBetweenOpenBound(t *testing.T) {
+	query := `{
+		me(func: between(age, 18, *)) @filter(between(created_at, *, "2021-01-01")) {
+			name
+		}
+	}`
+	res, err := Parse(Request{Str: query})
+	require.NoError(t, err)
+	fn := res.Query[0].Func
+	require.Equal(t, "ge", fn.Name)
+	require.Equal(t, "age", fn.Attr)
+	require.Equal(t, []Arg{{Value: "18"}}, fn.Args)
+	filter := res.Query[0].Filter.Func
+	require.Equal(t, "le", filter.Name)
+	require.Equal(t, "created_at", filter.Attr)
+	require.Equal(t, []Arg{{Value: "2021-01-01"}}, filter.Args)
+
+	tcases := []struct {
+		fn  string
+		err string
+	}{
+		{`between(age, *, *)`, "between function can't have both of its bounds open"},
+		{`between(age, *)`, "between function expects 2 bounds, got 1"},
+		{`between(age, 18, 30, *)`, "between function expects 2 bounds, got 3"},
+	}
+	for _, tc := range tcases {
+		_, err := Parse(Request{Str: `{ me(func: ` + tc.fn + `) { name } }`})
+		require.Error(t, err, tc.fn)
+		require.Contains(t, err.Error(), tc.err, tc.fn)
+	}
+}
+
 func TestUidInWithParseErrors(t *testing.T) {
 	tcases := []struct {
 		description string
@@ -1219,6 +1276,123 @@ func TestParseExpandType(t *testing.T) {
 	require.Equal(t, "uid", gq.Query[0].Children[0].Children[0].Attr)
 }
 
+func TestParseExpandOwned(t *testing.T) {
+	query := `
+	{
+		q(func: uid(0x1)) {
+			expand(_owned_) {
+				uid
+			}
+		}
+	}
+`
+	gq, err := Parse(Request{Str: query})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(gq.Query[0].Children))
+	require.Equal(t, "expand", gq.Query[0].Children[0].Attr)
+	require.Equal(t, "_owned_", gq.Query[0].Children[0].Expand)
+	require.Equal(t, 1, len(gq.Query[0].Children[0].Children))
+}
+
+func TestParseExpandPatterns(t *testing.T) {
+	query := `
+	{
+		q(func: uid(0x1)) {
+			expand(Person, "address.*", "phone_?", depth: 2) {
+				uid
+			}
+		}
+	}
+`
+	gq, err := Parse(Request{Str: query})
+	require.NoError(t, err)
+	child := gq.Query[0].Children[0]
+	require.Equal(t, "expand", child.Attr)
+	require.Equal(t, "Person", child.Expand)
+	require.Equal(t, []string{"address.*", "phone_?"}, child.ExpandPatterns)
+	require.Equal(t, 2, child.ExpandDepth)
+	require.True(t, child.IsExpand())
+	require.Equal(t, 1, len(child.Children))
+
+	query = `
+	{
+		q(func: uid(0x1)) {
+			expand("person.*") @filter(type(Person))
+		}
+	}
+`
+	gq, err = Parse(Request{Str: query})
+	require.NoError(t, err)
+	child = gq.Query[0].Children[0]
+	require.Equal(t, "", child.Expand)
+	require.Equal(t, []string{"person.*"}, child.ExpandPatterns)
+	require.True(t, child.IsExpand())
+
+	gq, err = Parse(Request{Str: `{ q(func: uid(0x1)) { expand(_all_, depth: 3) } }`})
+	require.NoError(t, err)
+	require.Equal(t, "_all_", gq.Query[0].Children[0].Expand)
+	require.Equal(t, 3, gq.Query[0].Children[0].ExpandDepth)
+}
+
+func TestParseJoin(t *testing.T) {
+	query := `
+	{
+		authors as var(func: type(Author))
+		q(func: type(Book)) {
+			title
+			writer: join(authors, author_email, email) @filter(has(name)) {
+				name
+			}
+			same: join(authors, uid)
+		}
+	}
+`
+	gq, err := Parse(Request{Str: query})
+	require.NoError(t, err)
+	q := gq.Query[1]
+	require.Equal(t, 3, len(q.Children))
+	writer := q.Children[1]
+	require.Equal(t, "writer", writer.Alias)
+	require.Equal(t, &JoinArgs{Var: "authors", Attr: "author_email", VarAttr: "email"},
+		writer.Join)
+	require.Equal(t, []VarContext{{Name: "authors", Typ: UidVar}}, writer.NeedsVar)
+	require.NotNil(t, writer.Filter)
+	require.Equal(t, 1, len(writer.Children))
+	require.Equal(t, &JoinArgs{Var: "authors", Attr: "uid", VarAttr: "uid"}, q.Children[2].Join)
+
+	_, err = Parse(Request{Str: `{ a as var(func: has(name)) q(func: has(name)) { join(a, name) } }`})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "join() must have an alias")
+
+	_, err = Parse(Request{Str: `{ a as var(func: has(name))
+		q(func: has(name)) { j: join(a) } }`})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "join() expects a uid variable and one or two predicates")
+
+	_, err = Parse(Request{Str: `{ q(func: has(name)) { j: join(a, name) } }`})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Some variables are used but not defined")
+}
+
+func TestParseExpandPatternsErrors(t *testing.T) {
+	tests := []struct {
+		expand string
+		err    string
+	}{
+		{`expand(_all_, "person.*")`, "expand(_all_) can't have other types or patterns"},
+		{`expand(_owned_, depth: 2)`, "expand(_owned_) can't have a depth"},
+		{`expand(Person, depth: 0)`, "The depth of expand() must be between 1 and 10"},
+		{`expand(Person, depth: 11)`, "The depth of expand() must be between 1 and 10"},
+		{`expand(depth: 2, Person)`, "The depth must be the last argument of expand()"},
+		{`expand("person.[")`, "Invalid pattern"},
+	}
+	for _, tc := range tests {
+		_, err := Parse(Request{Str: "{ q(func: uid(0x1)) { " + tc.expand + " } }"})
+		require.Error(t, err, tc.expand)
+		require.Contains(t, err.Error(), tc.err, tc.expand)
+	}
+}
+
 func TestRecurseWithArgs(t *testing.T) {
 	query := `
 	{
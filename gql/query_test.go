@@ -1227,3 +1227,37 @@ func TestParseMutationTooManyBlocks(t *testing.T) {
 		}
 	}
 }
+
+func TestParseQueryValidAt(t *testing.T) {
+	query := `
+{
+  q(func: has(name)) @validAt("2023-01-01") {
+    name
+    friend @validAt("2020-06-01T10:00:00") @facets(since) {
+      name
+    }
+  }
+}
+`
+	res, err := Parse(Request{Str: query})
+	require.NoError(t, err)
+	require.Equal(t, "2023-01-01", res.Query[0].ValidAt)
+	require.Equal(t, "", res.Query[0].Children[0].ValidAt)
+	require.Equal(t, "2020-06-01T10:00:00", res.Query[0].Children[1].ValidAt)
+	require.NotNil(t, res.Query[0].Children[1].Facets)
+}
+
+func TestParseQueryValidAtTwice(t *testing.T) {
+	query := `
+{
+  q(func: has(name)) {
+    friend @validAt("2023-01-01") @validAt("2022-01-01") {
+      name
+    }
+  }
+}
+`
+	_, err := Parse(Request{Str: query})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Only one validAt directive allowed")
+}
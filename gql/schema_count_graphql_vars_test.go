@@ -920,9 +920,12 @@ func TestOrderByVarAndPred(t *testing.T) {
 		}
 
 	}`
-	_, err := Parse(Request{Str: query})
-	require.Error(t, err)
-	require.Contains(t, err.Error(), "Multiple sorting only allowed by predicates.")
+	res, err := Parse(Request{Str: query})
+	require.NoError(t, err)
+	require.Len(t, res.Query[0].Order, 2)
+	require.Equal(t, "name", res.Query[0].Order[0].Attr)
+	require.Equal(t, "n", res.Query[0].Order[1].Attr)
+	require.True(t, res.Query[0].Order[1].Desc)
 
 	query = `{
 		q(func: uid(1)) {
@@ -934,10 +937,41 @@ func TestOrderByVarAndPred(t *testing.T) {
 			}
 		}
 
+	}`
+	_, err = Parse(Request{Str: query})
+	require.NoError(t, err)
+
+	query = `{
+		q(func: uid(1), orderasc: val(m), orderdesc: val(n)) {
+		}
+
+		var(func: uid(0x0a)) {
+			friends {
+				n AS name
+				m AS age
+			}
+		}
+
 	}`
 	_, err = Parse(Request{Str: query})
 	require.Error(t, err)
-	require.Contains(t, err.Error(), "Multiple sorting only allowed by predicates.")
+	require.Contains(t, err.Error(), "Sorting by more than one value variable isn't supported")
+
+	query = `{
+		q(func: uid(1)) {
+		}
+
+		var(func: uid(0x0a)) {
+			friends (orderasc: val(m), orderdesc: val(n)) {
+				n AS name
+				m AS age
+			}
+		}
+
+	}`
+	_, err = Parse(Request{Str: query})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Sorting by more than one value variable isn't supported")
 
 	query = `{
 		q(func: uid(1)) {
@@ -1172,3 +1206,20 @@ func TestParseVarAfterCountQry(t *testing.T) {
 	_, err := Parse(Request{Str: query})
 	require.NoError(t, err)
 }
+
+func TestParsePreparedQuery(t *testing.T) {
+	pq, err := Prepare("query test($a: int = 2){ q(func: uid(0x1), first: $a) { name }}")
+	require.NoError(t, err)
+
+	for _, a := range []string{"3", "5"} {
+		gq, err := pq.Parse(map[string]string{"$a": a}, nil)
+		require.NoError(t, err)
+		require.Equal(t, a, gq.Query[0].Args["first"])
+	}
+	gq, err := pq.Parse(nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, "2", gq.Query[0].Args["first"])
+
+	_, err = Prepare("{ q(func: uid(0x1)) { name }")
+	require.Error(t, err)
+}
@@ -288,6 +288,8 @@ func lexFuncOrArg(l *lex.Lexer) lex.StateFn {
 			}
 			fallthrough
 		case isMathOp(r):
+			// A math op can be an argument on its own, e.g. the open bound of between(age, 18, *).
+			empty = false
 			l.Emit(itemMathOp)
 		case isInequalityOp(r):
 			if r == equal {
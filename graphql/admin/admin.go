@@ -81,6 +81,20 @@ const (
 		This is the schema that is being served by Dgraph at /graphql.
 		"""
 		generatedSchema: String!
+
+		"""
+		The documents the input schema was merged from, if it was uploaded as several documents.
+		A schema uploaded as one string is a single document with an empty name.
+		"""
+		documents: [GQLSchemaDocument!]!
+	}
+
+	"""
+	A named part of the GraphQL schema.
+	"""
+	type GQLSchemaDocument {
+		name: String!
+		schema: String!
 	}
 
 	"""
@@ -218,9 +232,51 @@ const (
 	}
 
 	input GQLSchemaPatch {
+		"""
+		The whole schema, replacing the current schema and all its documents.
+		"""
+		schema: String
+
+		"""
+		Documents replacing the documents of the current schema with the same name, the other
+		documents are kept. A document with an empty schema is removed. The documents are merged
+		into the schema, and can't define the same types or give the same Dgraph.Authorization
+		or Dgraph.Secret. Only one of schema or documents can be given.
+		"""
+		documents: [GQLSchemaDocumentInput!]
+	}
+
+	input GQLSchemaDocumentInput {
+		name: String!
 		schema: String!
 	}
 
+	"""
+	A version of the GraphQL schema. Each update of the schema creates a new version.
+	"""
+	type GQLSchemaVersion {
+		version: UInt64!
+		schema: String!
+
+		"""
+		When the version was applied. It is not known for a schema applied before the versions
+		were kept.
+		"""
+		appliedAt: DateTime
+
+		"""
+		Whether this is the version currently served.
+		"""
+		current: Boolean!
+	}
+
+	input RollbackGQLSchemaInput {
+		"""
+		The version to roll back to, as listed by getGQLSchemaHistory.
+		"""
+		version: UInt64!
+	}
+
 	type UpdateLambdaScriptPayload {
 		lambdaScript: LambdaScript
 	}
@@ -235,7 +291,7 @@ const (
 
 	input ExportInput {
 		"""
-		Data format for the export, e.g. "rdf" or "json" (default: "rdf")
+		Data format for the export, e.g. "rdf", "json", "graphml" or "graphson" (default: "rdf")
 		"""
 		format: String
 
@@ -269,6 +325,13 @@ const (
 		Set to true to allow backing up to S3 or Minio bucket that requires no credentials.
 		"""
 		anonymous: Boolean
+
+		"""
+		The age X25519 public key, e.g. "age1...", to encrypt the export files for, instead of
+		the encryption key of the cluster. The files get the .age suffix, and are decrypted with
+		the matching age identity.
+		"""
+		recipient: String
 	}
 
 	input TaskInput {
@@ -289,14 +352,444 @@ const (
 		response: Response
 	}
 
+	input DrainInput {
+		"""
+		Maximum time in seconds to wait for the drain to complete. Defaults to 60.
+		"""
+		timeoutSeconds: Int
+	}
+
+	type DrainPayload {
+		response: Response
+
+		"""
+		Whether the Alpha is drained, and can be stopped safely.
+		"""
+		readyToStop: Boolean
+
+		"""
+		The step of the drain that didn't complete in time, if the Alpha isn't ready to stop.
+		"""
+		step: String
+		pendingTxns: Int
+		pendingProposals: Int
+		isLeader: Boolean
+	}
+
 	type ShutdownPayload {
 		response: Response
 	}
 
+	type CompactRaftLogPayload {
+		response: Response
+
+		"""
+		Raft index of the snapshot up to which the Raft log was truncated.
+		"""
+		index: UInt64
+
+		"""
+		Timestamp at which the snapshot was taken.
+		"""
+		readTs: UInt64
+	}
+
+	type ReplicationStatus {
+		"""
+		The group of this node. Only the leader of a group sends its changes to the sink.
+		"""
+		groupId: Int
+		isLeader: Boolean
+
+		"""
+		Commit timestamp of the last transaction sent to the sink.
+		"""
+		sentTs: UInt64
+
+		"""
+		Raft index up to which the changes have been read for sending.
+		"""
+		seenIndex: UInt64
+		appliedIndex: UInt64
+
+		"""
+		Number of Raft entries applied by this node whose changes are yet to be read for sending.
+		"""
+		lagEntries: UInt64
+
+		"""
+		Number of transactions read but not yet committed, or not yet sent.
+		"""
+		pendingTxns: Int
+		lastSentAt: DateTime
+
+		"""
+		Error of the last attempt to send to the sink, null if it succeeded.
+		"""
+		lastError: String
+	}
+
+	type PendingTransaction {
+		startTs: UInt64
+
+		"""
+		Time of the first write of the transaction seen by this node.
+		"""
+		startedAt: DateTime
+
+		"""
+		Time of the last write of the transaction seen by this node.
+		"""
+		lastUpdate: DateTime
+
+		"""
+		Time elapsed since startedAt, e.g. 1m30s.
+		"""
+		age: String
+
+		"""
+		Number of keys written by the transaction in the group of this node.
+		"""
+		numKeys: Int
+	}
+
+	input PredicateChecksumInput {
+		"""
+		The namespace of the predicates, the galaxy namespace by default.
+		"""
+		namespace: Int
+
+		predicates: [String!]!
+	}
+
+	type PredicateChecksum {
+		predicate: String
+
+		"""
+		Number of nodes that have the predicate.
+		"""
+		count: Int
+
+		"""
+		Hex encoded SHA-256 of the values of the predicate, in the order of the uids.
+		"""
+		checksum: String
+
+		"""
+		Timestamp at which the predicate was read.
+		"""
+		readTs: UInt64
+	}
+
+	type PredicateDiskStats {
+		predicate: String
+		namespace: UInt64
+
+		"""
+		Number of data keys of the predicate, one per node having the predicate.
+		"""
+		keyCount: UInt64
+
+		"""
+		Estimated size in bytes of all the versions of the keys of the predicate, including its
+		indexes.
+		"""
+		totalBytes: Int64
+
+		"""
+		Number of posting lists of the predicate split in several parts.
+		"""
+		splitLists: UInt64
+
+		"""
+		Average number of deltas above the latest complete posting list of the data keys.
+		"""
+		avgDeltaChain: Float
+
+		"""
+		Estimated size in bytes of the term indexes of the predicate.
+		"""
+		indexBytes: Int64
+
+		"""
+		Estimated size in bytes of the reverse edges of the predicate.
+		"""
+		reverseBytes: Int64
+
+		"""
+		Estimated size in bytes of the count indexes of the predicate.
+		"""
+		countBytes: Int64
+	}
+
+	type PredicateStatsReport {
+		groupId: UInt64
+
+		"""
+		Timestamp at which the data was read.
+		"""
+		readTs: UInt64
+
+		computedAt: DateTime
+
+		"""
+		Time taken to compute the stats, e.g. 1m30s.
+		"""
+		duration: String
+
+		predicates: [PredicateDiskStats]
+	}
+
+	input ValueLogGCInput {
+		"""
+		Fraction of discardable data a value log file must have to be rewritten, 0.5 by default.
+		"""
+		discardRatio: Float
+	}
+
+	input FlattenStorageInput {
+		"""
+		Number of compaction workers, 1 by default.
+		"""
+		workers: Int
+	}
+
+	input StorageCacheInput {
+		"""
+		Size of the block cache of the postings store, in MB. Unchanged if not set.
+		"""
+		blockCacheMb: Int64
+
+		"""
+		Size of the index cache of the postings store, in MB. Unchanged if not set.
+		"""
+		indexCacheMb: Int64
+	}
+
+	type StoragePayload {
+		response: Response
+	}
+
+	type StorageLevel {
+		level: Int
+		numTables: Int
+		size: Int64
+		targetSize: Int64
+
+		"""
+		The level is due for a compaction when its score is at least 1.
+		"""
+		score: Float
+	}
+
+	type StorageOp {
+		"""
+		The operation, valueLogGC or flatten.
+		"""
+		op: String
+		startedAt: DateTime
+
+		"""
+		Time taken by the operation, e.g. 1m30s. Not set while it runs.
+		"""
+		duration: String
+		error: String
+
+		"""
+		Number of value log files rewritten by a value log GC.
+		"""
+		rewritten: Int
+	}
+
+	type StorageStatus {
+		lsmBytes: Int64
+		vlogBytes: Int64
+		levels: [StorageLevel]
+		blockCacheBytes: Int64
+		indexCacheBytes: Int64
+
+		"""
+		The storage operation currently running, if any.
+		"""
+		running: StorageOp
+		lastValueLogGC: StorageOp
+		lastFlatten: StorageOp
+	}
+
+	input FsckInput {
+		"""
+		Predicates to check. All the predicates of the group are checked if not set.
+		"""
+		predicates: [String!]
+
+		"""
+		Rebuild the indexes, reverse edges and count indexes found inconsistent once the check
+		is done.
+		"""
+		repair: Boolean
+
+		"""
+		Max number of issues reported, 1000 by default.
+		"""
+		maxIssues: Int
+	}
+
+	type FsckPayload {
+		response: Response
+	}
+
+	type FsckIssue {
+		"""
+		The kind of inconsistency: missingIndex, staleIndex, missingReverse, staleReverse,
+		missingCount, staleCount, missingPart, orphanPart or unreadable.
+		"""
+		kind: String
+		predicate: String
+		namespace: UInt64
+
+		"""
+		The hex encoded key holding, or which should hold, the inconsistent entry.
+		"""
+		key: String
+		uid: UInt64
+		detail: String
+	}
+
+	type FsckReport {
+		startedAt: DateTime
+
+		"""
+		Time taken by the check, e.g. 1m30s. Not set while it runs.
+		"""
+		duration: String
+		readTs: UInt64
+		predicates: Int
+		keys: UInt64
+		issues: [FsckIssue]
+
+		"""
+		Whether more issues were found than reported.
+		"""
+		truncated: Boolean
+
+		"""
+		The predicates whose indexes were rebuilt.
+		"""
+		repaired: [String]
+		error: String
+	}
+
+	type FsckStatus {
+		running: FsckReport
+		last: FsckReport
+	}
+
+	input RebuildCountIndexInput {
+		"""
+		Predicates whose count index is rebuilt. All the predicates of the group having a count
+		index are rebuilt if not set.
+		"""
+		predicates: [String!]
+	}
+
+	type RebuildCountIndexPayload {
+		response: Response
+
+		"""
+		The predicates whose count index is being rebuilt.
+		"""
+		predicates: [String]
+	}
+
+	type CorruptedKey {
+		"""
+		The hex encoded key.
+		"""
+		key: String
+		predicate: String
+		namespace: UInt64
+
+		"""
+		The latest version of the key found corrupted.
+		"""
+		version: UInt64
+		detectedAt: DateTime
+
+		"""
+		Number of reads which failed on the key since it was quarantined.
+		"""
+		reads: UInt64
+	}
+
+	type ReleaseCorruptedKeyPayload {
+		response: Response
+	}
+
+	type HotKey {
+		"""
+		The hex encoded key.
+		"""
+		key: String
+		predicate: String
+		namespace: UInt64
+
+		"""
+		The kind of posting list of the key: data, index, reverse, count or other.
+		"""
+		kind: String
+
+		"""
+		The node of a data or reverse key.
+		"""
+		uid: UInt64
+
+		"""
+		Number of mutations which waited for the lock of the key for at least a millisecond.
+		"""
+		waits: UInt64
+
+		"""
+		Total and longest time spent by the mutations waiting for the lock, e.g. 1.5s.
+		"""
+		totalWait: String
+		maxWait: String
+		lastWait: DateTime
+	}
+
+	type HotKeys {
+		"""
+		Time since which the waits are recorded.
+		"""
+		since: DateTime
+		keys: [HotKey]
+	}
+
+	type ResetHotKeysPayload {
+		response: Response
+	}
+
+	type FailoverPayload {
+		response: Response
+	}
+
 	type TaskPayload {
+		id: String
 		kind: TaskKind
 		status: TaskStatus
 		lastUpdated: DateTime
+
+		"""
+		The progress of the task, e.g. the number of posting lists processed by an index rebuild
+		or the bytes sent by a tablet move, out of total if the total is known.
+		"""
+		progress: UInt64
+		total: UInt64
+
+		"""
+		The last log lines of the task. They are kept in memory by the node running the task.
+		"""
+		logs: [String]
+		error: String
 	}
 
 	enum TaskStatus {
@@ -304,12 +797,16 @@ const (
 		Running
 		Failed
 		Success
+		Cancelled
 		Unknown
 	}
 
 	enum TaskKind {
 		Backup
 		Export
+		IndexRebuild
+		MoveTablet
+		QueryJob
 		Unknown
 	}
 
@@ -336,6 +833,86 @@ const (
 		cacheMb: Float
 	}
 
+	input RuntimeConfigInput {
+		"""
+		Estimated memory the caches can take, in MB.
+		"""
+		cacheMb: Int64
+
+		"""
+		True value of logRequest enables logging of all the requests coming to alphas.
+		"""
+		logRequest: Boolean
+
+		"""
+		The glog verbosity level, same as the --v flag.
+		"""
+		logVerbosity: Int
+
+		"""
+		Maximum number of edges that can be returned in a query.
+		"""
+		queryEdgeLimit: UInt64
+
+		"""
+		Maximum number of nodes that can be returned in a query that uses @normalize.
+		"""
+		normalizeNodeLimit: Int
+
+		"""
+		Maximum number of nquads that can be inserted in a mutation request.
+		"""
+		mutationsNquadLimit: Int
+
+		"""
+		Maximum time after which a query execution fails, e.g. "30s". "0s" disables it.
+		"""
+		queryTimeout: String
+
+		"""
+		True makes the cluster a standby, which only accepts the writes replicated from its
+		primary cluster.
+		"""
+		standby: Boolean
+
+		"""
+		Hostnames serving the GraphQL API of a namespace at /graphql. They replace all the
+		hostnames set before, an empty list removes them.
+		"""
+		namespaceHosts: [NamespaceHostInput!]
+	}
+
+	input NamespaceHostInput {
+		host: String!
+		namespace: UInt64!
+	}
+
+	type NamespaceHost {
+		host: String
+		namespace: UInt64
+	}
+
+	type RuntimeConfig {
+		cacheMb: Int64
+		logRequest: Boolean
+		logVerbosity: Int
+		queryEdgeLimit: UInt64
+		normalizeNodeLimit: Int
+		mutationsNquadLimit: Int
+		queryTimeout: String
+		standby: Boolean
+		namespaceHosts: [NamespaceHost]
+	}
+
+	type RuntimeConfigPayload {
+		response: Response
+
+		"""
+		The runtime options stored for the cluster. Options that were never updated are not set.
+		"""
+		config: RuntimeConfig
+	}
+
 	input RemoveNodeInput {
 		"""
 		ID of the node to be removed.
@@ -416,11 +993,78 @@ const (
 
 	type Query {
 		getGQLSchema: GQLSchema
+
+		"""
+		The current version of the GraphQL schema followed by the previous versions kept, the
+		latest first.
+		"""
+		getGQLSchemaHistory: [GQLSchemaVersion!]!
+
 		getLambdaScript: LambdaScript
 		health: [NodeState]
 		state: MembershipState
 		config: Config
+
+		"""
+		The values of the runtime options currently in use by this node.
+		"""
+		runtimeConfig: RuntimeConfig
+
+		"""
+		The progress of replicating the changes of this node's group through CDC.
+		"""
+		replicationStatus: ReplicationStatus
+
+		"""
+		The transactions which have written to the group of this node, but are yet to be committed
+		or aborted, the oldest first. They keep the earlier versions of the data from being
+		discarded.
+		"""
+		pendingTransactions: [PendingTransaction]
+
+		"""
+		Checksums of the data of the given predicates. Matching checksums on the primary and the
+		standby cluster verify that the standby has caught up with the primary.
+		"""
+		predicateChecksums(input: PredicateChecksumInput!): [PredicateChecksum]
+
+		"""
+		The storage stats of the predicates served by the group of this node, computed
+		periodically in the background. Empty until the first computation is done.
+		"""
+		predicateStats: PredicateStatsReport
+
+		"""
+		The state of the postings store of this node, and of the storage operations run on it.
+		"""
+		storageStatus: StorageStatus
+
+		"""
+		The progress of the running consistency check of this node if any, and the report of the
+		last one.
+		"""
+		fsckStatus: FsckStatus
+
+		"""
+		The keys of this node whose posting lists failed their checksum when read, since the node
+		started. The reads of these keys fail until their data is restored. Checksums are enabled
+		by the --integrity flag.
+		"""
+		corruptedKeys: [CorruptedKey]
+
+		"""
+		The posting list keys of this node whose lock is the most contended by mutations, the
+		longest total wait first, e.g. supernodes and counters updated concurrently. Only the
+		first keys are returned, 20 by default.
+		"""
+		hotKeys(first: Int): HotKeys
 		task(input: TaskInput!): TaskPayload
+
+		"""
+		The tasks of this node updated in the last week: the exports, backups, index rebuilds,
+		tablet moves and query jobs, the most recently updated first.
+		"""
+		tasks: [TaskPayload]
 		` + adminQueries + `
 	}
 
@@ -432,6 +1076,13 @@ const (
 		"""
 		updateGQLSchema(input: UpdateGQLSchemaInput!) : UpdateGQLSchemaPayload
 
+		"""
+		Roll the GraphQL schema back to a previous version. This applies the schema of that
+		version as a new version, along with the corresponding changes to the Dgraph schema.
+		It fails if the schema was updated concurrently.
+		"""
+		rollbackGQLSchema(input: RollbackGQLSchemaInput!) : UpdateGQLSchemaPayload
+
 		"""
 		Update the lambda script used by lambda resolvers.
 		"""
@@ -439,7 +1090,7 @@ const (
 
 		"""
 		Starts an export of all data in the cluster.  Export format should be 'rdf' (the default
-		if no format is given), or 'json'.
+		if no format is given), 'json', 'graphml' or 'graphson'.
 		See : https://dgraph.io/docs/deploy/#export-database
 		"""
 		export(input: ExportInput!): ExportPayload
@@ -449,16 +1100,96 @@ const (
 		"""
 		draining(enable: Boolean): DrainingPayload
 
+		"""
+		Drain this node before stopping it, e.g. for a rolling upgrade. This enables the draining
+		mode, waits for the pending transactions and Raft proposals to finish, transfers the
+		leadership of the group to another replica, and writes the lists rolled up in memory to
+		disk. The node is ready to stop once readyToStop is true. If the drain doesn't complete in
+		time, it can be run again. Use draining(enable: false) to serve requests again.
+		"""
+		drain(input: DrainInput): DrainPayload
+
 		"""
 		Shutdown this node.
 		"""
 		shutdown: ShutdownPayload
 
+		"""
+		Take a snapshot of the group this node belongs to. Applying the snapshot truncates the
+		Raft log of every replica of the group, which reclaims space in the w directory.
+		"""
+		compactRaftLog: CompactRaftLogPayload
+
+		"""
+		Start a value log GC of the postings store of this node, which reclaims the space of the
+		values that were deleted or overwritten. Only one storage operation runs at a time, see
+		storageStatus for its progress.
+		"""
+		runValueLogGC(input: ValueLogGCInput): StoragePayload
+
+		"""
+		Start compacting all the levels of the postings store of this node, L0 first, into a
+		single level. Only one storage operation runs at a time, see storageStatus for its
+		progress.
+		"""
+		flattenStorage(input: FlattenStorageInput): StoragePayload
+
+		"""
+		Resize the caches of the postings store of this node. The compression of the store is
+		set by the --badger flag, and can't be changed at runtime.
+		"""
+		updateStorageCache(input: StorageCacheInput!): StoragePayload
+
+		"""
+		Start checking in the background that the posting lists of the group of this node are
+		consistent: the indexes, reverse edges and count indexes must match the data, and the parts
+		of the multi-part lists must be the ones listed by their main key. See fsckStatus for its
+		progress and report. With repair, the inconsistent indexes are rebuilt on all the replicas
+		of the group, and can't be queried until they are built.
+		"""
+		fsck(input: FsckInput): FsckPayload
+
+		"""
+		Rebuild the count indexes of the group of this node from the posting lists, on all the
+		replicas of the group, e.g. if they drifted under concurrent mutations. The indexes can't
+		be queried until they are built.
+		"""
+		rebuildCountIndex(input: RebuildCountIndexInput): RebuildCountIndexPayload
+
+		"""
+		Remove a key, hex encoded, from the corrupted keys of this node, e.g. once its data was
+		restored. The key is listed again if its checksum still fails.
+		"""
+		releaseCorruptedKey(key: String!): ReleaseCorruptedKeyPayload
+
+		"""
+		Forget the lock waits recorded so far by the hotKeys query of this node.
+		"""
+		resetHotKeys: ResetHotKeysPayload
+
+		"""
+		Cancel a queued or running task, on the node running it. The index rebuilds can't be
+		cancelled.
+		"""
+		cancelTask(input: TaskInput!): TaskPayload
+
 		"""
 		Alter the node's config.
 		"""
 		config(input: ConfigInput!): ConfigPayload
 
+		"""
+		Update the runtime options of all the nodes in the cluster. The options are stored in the
+		cluster, so they are kept across restarts and override the values given through flags.
+		"""
+		updateRuntimeConfig(input: RuntimeConfigInput!): RuntimeConfigPayload
+
+		"""
+		Take the cluster out of standby mode, so that it accepts writes. Make sure the old
+		primary no longer replicates to it first.
+		"""
+		failover: FailoverPayload
+
 		"""
 		Remove a node from the cluster.
 		"""
@@ -529,12 +1260,23 @@ var (
 		resolve.LoggingMWMutation,
 	}
 	adminQueryMWConfig = map[string]resolve.QueryMiddlewares{
-		"health":          minimalAdminQryMWs, // dgraph checks Guardian auth for health
-		"state":           minimalAdminQryMWs, // dgraph checks Guardian auth for state
-		"config":          stdAdminQryMWs,
-		"listBackups":     gogQryMWs,
-		"getGQLSchema":    stdAdminQryMWs,
-		"getLambdaScript": stdAdminQryMWs,
+		"health":              minimalAdminQryMWs, // dgraph checks Guardian auth for health
+		"state":               minimalAdminQryMWs, // dgraph checks Guardian auth for state
+		"config":              stdAdminQryMWs,
+		"runtimeConfig":       stdAdminQryMWs,
+		"replicationStatus":   gogQryMWs,
+		"pendingTransactions": gogQryMWs,
+		"predicateChecksums":  gogQryMWs,
+		"predicateStats":      gogQryMWs,
+		"storageStatus":       gogQryMWs,
+		"fsckStatus":          gogQryMWs,
+		"corruptedKeys":       gogQryMWs,
+		"hotKeys":             gogQryMWs,
+		"listBackups":         gogQryMWs,
+		"tasks":               gogQryMWs,
+		"getGQLSchema":        stdAdminQryMWs,
+		"getGQLSchemaHistory": stdAdminQryMWs,
+		"getLambdaScript":     stdAdminQryMWs,
 		// for queries and mutations related to User/Group, dgraph handles Guardian auth,
 		// so no need to apply GuardianAuth Middleware
 		"queryUser":      minimalAdminQryMWs,
@@ -542,24 +1284,38 @@ var (
 		"getUser":        minimalAdminQryMWs,
 		"getCurrentUser": minimalAdminQryMWs,
 		"getGroup":       minimalAdminQryMWs,
+		"listSessions":   minimalAdminQryMWs,
 	}
 	adminMutationMWConfig = map[string]resolve.MutationMiddlewares{
-		"backup":             gogMutMWs,
-		"config":             gogMutMWs,
-		"draining":           gogMutMWs,
-		"export":             stdAdminMutMWs, // dgraph handles the export by GoG internally
-		"login":              minimalAdminMutMWs,
-		"restore":            gogMutMWs,
-		"shutdown":           gogMutMWs,
-		"removeNode":         gogMutMWs,
-		"moveTablet":         gogMutMWs,
-		"assign":             gogMutMWs,
-		"enterpriseLicense":  gogMutMWs,
-		"updateGQLSchema":    stdAdminMutMWs,
-		"updateLambdaScript": stdAdminMutMWs,
-		"addNamespace":       gogAclMutMWs,
-		"deleteNamespace":    gogAclMutMWs,
-		"resetPassword":      gogAclMutMWs,
+		"backup":              gogMutMWs,
+		"config":              gogMutMWs,
+		"updateRuntimeConfig": gogMutMWs,
+		"failover":            gogMutMWs,
+		"draining":            gogMutMWs,
+		"drain":               gogMutMWs,
+		"export":              stdAdminMutMWs, // dgraph handles the export by GoG internally
+		"login":               minimalAdminMutMWs,
+		"restore":             gogMutMWs,
+		"shutdown":            gogMutMWs,
+		"compactRaftLog":      gogMutMWs,
+		"runValueLogGC":       gogMutMWs,
+		"fsck":                gogMutMWs,
+		"rebuildCountIndex":   gogMutMWs,
+		"releaseCorruptedKey": gogMutMWs,
+		"resetHotKeys":        gogMutMWs,
+		"cancelTask":          gogMutMWs,
+		"flattenStorage":      gogMutMWs,
+		"updateStorageCache":  gogMutMWs,
+		"removeNode":          gogMutMWs,
+		"moveTablet":          gogMutMWs,
+		"assign":              gogMutMWs,
+		"enterpriseLicense":   gogMutMWs,
+		"updateGQLSchema":     stdAdminMutMWs,
+		"rollbackGQLSchema":   stdAdminMutMWs,
+		"updateLambdaScript":  stdAdminMutMWs,
+		"addNamespace":        gogAclMutMWs,
+		"deleteNamespace":     gogAclMutMWs,
+		"resetPassword":       gogAclMutMWs,
 		// for queries and mutations related to User/Group, dgraph handles Guardian auth,
 		// so no need to apply GuardianAuth Middleware
 		"addUser":     minimalAdminMutMWs,
@@ -568,6 +1324,8 @@ var (
 		"updateGroup": minimalAdminMutMWs,
 		"deleteUser":  minimalAdminMutMWs,
 		"deleteGroup": minimalAdminMutMWs,
+		// dgraph checks Guardian auth for sessions in the namespace of the user
+		"revokeSession": minimalAdminMutMWs,
 	}
 	// mainHealthStore stores the health of the main GraphQL server.
 	mainHealthStore = &GraphQLHealthStore{}
@@ -793,17 +1551,30 @@ func newAdminResolver(
 
 func newAdminResolverFactory() resolve.ResolverFactory {
 	adminMutationResolvers := map[string]resolve.MutationResolverFunc{
-		"addNamespace":       resolveAddNamespace,
-		"backup":             resolveBackup,
-		"config":             resolveUpdateConfig,
-		"deleteNamespace":    resolveDeleteNamespace,
-		"draining":           resolveDraining,
-		"export":             resolveExport,
-		"login":              resolveLogin,
-		"resetPassword":      resolveResetPassword,
-		"restore":            resolveRestore,
-		"shutdown":           resolveShutdown,
-		"updateLambdaScript": resolveUpdateLambda,
+		"addNamespace":        resolveAddNamespace,
+		"backup":              resolveBackup,
+		"cancelTask":          resolveCancelTask,
+		"compactRaftLog":      resolveCompactRaftLog,
+		"config":              resolveUpdateConfig,
+		"deleteNamespace":     resolveDeleteNamespace,
+		"draining":            resolveDraining,
+		"drain":               resolveDrain,
+		"export":              resolveExport,
+		"failover":            resolveFailover,
+		"flattenStorage":      resolveFlattenStorage,
+		"fsck":                resolveFsck,
+		"rebuildCountIndex":   resolveRebuildCountIndex,
+		"login":               resolveLogin,
+		"releaseCorruptedKey": resolveReleaseCorruptedKey,
+		"resetHotKeys":        resolveResetHotKeys,
+		"resetPassword":       resolveResetPassword,
+		"restore":             resolveRestore,
+		"revokeSession":       resolveRevokeSession,
+		"runValueLogGC":       resolveValueLogGC,
+		"shutdown":            resolveShutdown,
+		"updateLambdaScript":  resolveUpdateLambda,
+		"updateRuntimeConfig": resolveUpdateRuntimeConfig,
+		"updateStorageCache":  resolveUpdateStorageCache,
 
 		"removeNode":        resolveRemoveNode,
 		"moveTablet":        resolveMoveTablet,
@@ -823,12 +1594,45 @@ func newAdminResolverFactory() resolve.ResolverFactory {
 		WithQueryResolver("config", func(q schema.Query) resolve.QueryResolver {
 			return resolve.QueryResolverFunc(resolveGetConfig)
 		}).
+		WithQueryResolver("runtimeConfig", func(q schema.Query) resolve.QueryResolver {
+			return resolve.QueryResolverFunc(resolveGetRuntimeConfig)
+		}).
+		WithQueryResolver("replicationStatus", func(q schema.Query) resolve.QueryResolver {
+			return resolve.QueryResolverFunc(resolveReplicationStatus)
+		}).
+		WithQueryResolver("pendingTransactions", func(q schema.Query) resolve.QueryResolver {
+			return resolve.QueryResolverFunc(resolvePendingTransactions)
+		}).
+		WithQueryResolver("predicateChecksums", func(q schema.Query) resolve.QueryResolver {
+			return resolve.QueryResolverFunc(resolvePredicateChecksums)
+		}).
+		WithQueryResolver("predicateStats", func(q schema.Query) resolve.QueryResolver {
+			return resolve.QueryResolverFunc(resolvePredicateStats)
+		}).
+		WithQueryResolver("storageStatus", func(q schema.Query) resolve.QueryResolver {
+			return resolve.QueryResolverFunc(resolveStorageStatus)
+		}).
+		WithQueryResolver("fsckStatus", func(q schema.Query) resolve.QueryResolver {
+			return resolve.QueryResolverFunc(resolveFsckStatus)
+		}).
+		WithQueryResolver("corruptedKeys", func(q schema.Query) resolve.QueryResolver {
+			return resolve.QueryResolverFunc(resolveCorruptedKeys)
+		}).
+		WithQueryResolver("hotKeys", func(q schema.Query) resolve.QueryResolver {
+			return resolve.QueryResolverFunc(resolveHotKeys)
+		}).
 		WithQueryResolver("listBackups", func(q schema.Query) resolve.QueryResolver {
 			return resolve.QueryResolverFunc(resolveListBackups)
 		}).
 		WithQueryResolver("task", func(q schema.Query) resolve.QueryResolver {
 			return resolve.QueryResolverFunc(resolveTask)
 		}).
+		WithQueryResolver("tasks", func(q schema.Query) resolve.QueryResolver {
+			return resolve.QueryResolverFunc(resolveTasks)
+		}).
+		WithQueryResolver("listSessions", func(q schema.Query) resolve.QueryResolver {
+			return resolve.QueryResolverFunc(resolveListSessions)
+		}).
 		WithQueryResolver("getLambdaScript", func(q schema.Query) resolve.QueryResolver {
 			return resolve.QueryResolverFunc(resolveGetLambda)
 		}).
@@ -838,12 +1642,25 @@ func newAdminResolverFactory() resolve.ResolverFactory {
 					return &resolve.Resolved{Err: errors.Errorf(errMsgServerNotReady), Field: q}
 				})
 		}).
+		WithQueryResolver("getGQLSchemaHistory", func(q schema.Query) resolve.QueryResolver {
+			return resolve.QueryResolverFunc(
+				func(ctx context.Context, query schema.Query) *resolve.Resolved {
+					return &resolve.Resolved{Err: errors.Errorf(errMsgServerNotReady), Field: q}
+				})
+		}).
 		WithMutationResolver("updateGQLSchema", func(m schema.Mutation) resolve.MutationResolver {
 			return resolve.MutationResolverFunc(
 				func(ctx context.Context, m schema.Mutation) (*resolve.Resolved, bool) {
 					return &resolve.Resolved{Err: errors.Errorf(errMsgServerNotReady), Field: m},
 						false
 				})
+		}).
+		WithMutationResolver("rollbackGQLSchema", func(m schema.Mutation) resolve.MutationResolver {
+			return resolve.MutationResolverFunc(
+				func(ctx context.Context, m schema.Mutation) (*resolve.Resolved, bool) {
+					return &resolve.Resolved{Err: errors.Errorf(errMsgServerNotReady), Field: m},
+						false
+				})
 		})
 	for gqlMut, resolver := range adminMutationResolvers {
 		// gotta force go to evaluate the right function at each loop iteration
@@ -948,6 +1765,14 @@ func (as *adminServer) addConnectedAdminResolvers() {
 			func(q schema.Query) resolve.QueryResolver {
 				return &getSchemaResolver{admin: as}
 			}).
+		WithQueryResolver("getGQLSchemaHistory",
+			func(q schema.Query) resolve.QueryResolver {
+				return resolve.QueryResolverFunc(resolveGetSchemaHistory)
+			}).
+		WithMutationResolver("rollbackGQLSchema",
+			func(m schema.Mutation) resolve.MutationResolver {
+				return &rollbackSchemaResolver{admin: as}
+			}).
 		WithQueryResolver("queryGroup",
 			func(q schema.Query) resolve.QueryResolver {
 				return resolve.NewQueryResolver(qryRw, dgEx)
@@ -1070,6 +1895,9 @@ func (as *adminServer) resetSchema(ns uint64, gqlSchema schema.Schema) {
 
 	resolvers := resolve.New(gqlSchema, resolverFactory)
 	as.gqlServer.Set(ns, as.getGlobalEpoch(ns), resolvers)
+	// The connections of the @custom(grpc: {...}) fields of the previous schema aren't needed
+	// anymore.
+	schema.CloseGRPCConns(ns)
 
 	// reset status to up, as now we are serving the new schema
 	mainHealthStore.up()
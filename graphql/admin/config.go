@@ -19,12 +19,15 @@ package admin
 import (
 	"context"
 	"encoding/json"
+	"sort"
 	"strconv"
 
+	"github.com/dgraph-io/dgraph/edgraph"
 	"github.com/dgraph-io/dgraph/graphql/resolve"
 	"github.com/dgraph-io/dgraph/graphql/schema"
 	"github.com/dgraph-io/dgraph/worker"
 	"github.com/golang/glog"
+	"github.com/pkg/errors"
 )
 
 type configInput struct {
@@ -75,6 +78,123 @@ func resolveGetConfig(ctx context.Context, q schema.Query) *resolve.Resolved {
 
 }
 
+func resolveUpdateRuntimeConfig(ctx context.Context, m schema.Mutation) (*resolve.Resolved,
+	bool) {
+	glog.Info("Got runtime config update through GraphQL admin API")
+
+	input, err := getRuntimeConfigInput(m)
+	if err != nil {
+		return resolve.EmptyResult(m, err), false
+	}
+
+	config, err := edgraph.UpdateRuntimeConfig(ctx, input)
+	if err != nil {
+		return resolve.EmptyResult(m, err), false
+	}
+
+	data := response("Success", "Runtime config updated successfully")
+	data["config"] = runtimeConfigToMap(config)
+	return resolve.DataResult(
+		m,
+		map[string]interface{}{m.Name(): data},
+		nil,
+	), true
+}
+
+func resolveGetRuntimeConfig(ctx context.Context, q schema.Query) *resolve.Resolved {
+	glog.Info("Got runtime config query through GraphQL admin API")
+
+	return resolve.DataResult(
+		q,
+		map[string]interface{}{q.Name(): runtimeConfigToMap(worker.CurrentRuntimeConfig())},
+		nil,
+	)
+}
+
+// runtimeConfigToMap converts the config into the GraphQL response, the options that are not set
+// are returned as null.
+func runtimeConfigToMap(c *worker.RuntimeConfig) map[string]interface{} {
+	res := make(map[string]interface{})
+	if c.CacheMb != nil {
+		res["cacheMb"] = json.Number(strconv.FormatInt(*c.CacheMb, 10))
+	}
+	if c.LogRequest != nil {
+		res["logRequest"] = *c.LogRequest
+	}
+	if c.LogVerbosity != nil {
+		res["logVerbosity"] = json.Number(strconv.Itoa(*c.LogVerbosity))
+	}
+	if c.QueryEdgeLimit != nil {
+		res["queryEdgeLimit"] = json.Number(strconv.FormatUint(*c.QueryEdgeLimit, 10))
+	}
+	if c.NormalizeNodeLimit != nil {
+		res["normalizeNodeLimit"] = json.Number(strconv.Itoa(*c.NormalizeNodeLimit))
+	}
+	if c.MutationsNquadLimit != nil {
+		res["mutationsNquadLimit"] = json.Number(strconv.Itoa(*c.MutationsNquadLimit))
+	}
+	if c.QueryTimeout != nil {
+		res["queryTimeout"] = *c.QueryTimeout
+	}
+	if c.Standby != nil {
+		res["standby"] = *c.Standby
+	}
+	if c.NamespaceHosts != nil {
+		names := make([]string, 0, len(c.NamespaceHosts))
+		for host := range c.NamespaceHosts {
+			names = append(names, host)
+		}
+		sort.Strings(names)
+		hosts := make([]interface{}, 0, len(names))
+		for _, host := range names {
+			hosts = append(hosts, map[string]interface{}{
+				"host":      host,
+				"namespace": json.Number(strconv.FormatUint(c.NamespaceHosts[host], 10)),
+			})
+		}
+		res["namespaceHosts"] = hosts
+	}
+	return res
+}
+
+func getRuntimeConfigInput(m schema.Mutation) (*worker.RuntimeConfig, error) {
+	inputArg, ok := m.ArgValue(schema.InputArgName).(map[string]interface{})
+	if !ok {
+		return nil, inputArgError(errors.Errorf("can't convert input to map"))
+	}
+	// Int64 and UInt64 values can be given as strings.
+	for _, key := range []string{"cacheMb", "queryEdgeLimit"} {
+		if v, ok := inputArg[key].(string); ok {
+			inputArg[key] = json.Number(v)
+		}
+	}
+
+	// The hostnames are given as a list, and stored as a map.
+	if list, ok := inputArg["namespaceHosts"].([]interface{}); ok {
+		hosts := make(map[string]interface{}, len(list))
+		for _, item := range list {
+			host, _ := item.(map[string]interface{})
+			name, _ := host["host"].(string)
+			ns := host["namespace"]
+			if v, ok := ns.(string); ok {
+				ns = json.Number(v)
+			}
+			hosts[name] = ns
+		}
+		inputArg["namespaceHosts"] = hosts
+	}
+
+	inputByts, err := json.Marshal(inputArg)
+	if err != nil {
+		return nil, inputArgError(err)
+	}
+	var input worker.RuntimeConfig
+	if err := json.Unmarshal(inputByts, &input); err != nil {
+		return nil, inputArgError(err)
+	}
+	return &input, nil
+}
+
 func getConfigInput(m schema.Mutation) (*configInput, error) {
 	inputArg := m.ArgValue(schema.InputArgName)
 	inputByts, err := json.Marshal(inputArg)
@@ -0,0 +1,70 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package admin
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/dgraph-io/dgraph/graphql/resolve"
+	"github.com/dgraph-io/dgraph/graphql/schema"
+	"github.com/dgraph-io/dgraph/posting"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+)
+
+func resolveCorruptedKeys(ctx context.Context, q schema.Query) *resolve.Resolved {
+	keys := posting.CorruptedKeys()
+	res := make([]interface{}, 0, len(keys))
+	for _, ck := range keys {
+		key := map[string]interface{}{
+			"key":        hex.EncodeToString(ck.Key),
+			"version":    json.Number(strconv.FormatUint(ck.Version, 10)),
+			"detectedAt": ck.DetectedAt.Format(time.RFC3339),
+			"reads":      json.Number(strconv.FormatUint(ck.Reads, 10)),
+		}
+		if pk, err := x.Parse(ck.Key); err == nil {
+			key["predicate"] = x.ParseAttr(pk.Attr)
+			key["namespace"] = json.Number(strconv.FormatUint(x.ParseNamespace(pk.Attr), 10))
+		}
+		res = append(res, key)
+	}
+	return resolve.DataResult(q, map[string]interface{}{q.Name(): res}, nil)
+}
+
+func resolveReleaseCorruptedKey(ctx context.Context, m schema.Mutation) (*resolve.Resolved, bool) {
+	glog.Info("Got releaseCorruptedKey request through GraphQL admin API")
+
+	arg, _ := m.ArgValue("key").(string)
+	key, err := hex.DecodeString(arg)
+	if err != nil {
+		return resolve.EmptyResult(m, errors.Wrapf(err, "invalid key %q", arg)), false
+	}
+	if !posting.ReleaseCorruptedKey(key) {
+		return resolve.EmptyResult(m, errors.Errorf("key %s isn't corrupted", arg)), false
+	}
+	return resolve.DataResult(
+		m,
+		map[string]interface{}{m.Name(): response("Success", fmt.Sprintf("Released key %s", arg))},
+		nil,
+	), true
+}
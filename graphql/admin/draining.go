@@ -18,12 +18,17 @@ package admin
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/dgraph-io/dgraph/graphql/resolve"
 	"github.com/dgraph-io/dgraph/graphql/schema"
+	"github.com/dgraph-io/dgraph/worker"
 	"github.com/dgraph-io/dgraph/x"
 	"github.com/golang/glog"
+	"github.com/pkg/errors"
 )
 
 func resolveDraining(ctx context.Context, m schema.Mutation) (*resolve.Resolved, bool) {
@@ -45,3 +50,45 @@ func getDrainingInput(m schema.Mutation) bool {
 	enable, _ := m.ArgValue("enable").(bool)
 	return enable
 }
+
+type drainInput struct {
+	TimeoutSeconds *int
+}
+
+func resolveDrain(ctx context.Context, m schema.Mutation) (*resolve.Resolved, bool) {
+	glog.Info("Got drain request through GraphQL admin API")
+
+	var input drainInput
+	if err := getStorageInput(m, &input); err != nil {
+		return resolve.EmptyResult(m, err), false
+	}
+	timeout := time.Minute
+	if input.TimeoutSeconds != nil {
+		if *input.TimeoutSeconds <= 0 {
+			return resolve.EmptyResult(m, errors.New("timeoutSeconds must be positive")), false
+		}
+		timeout = time.Duration(*input.TimeoutSeconds) * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	st, err := worker.Drain(ctx)
+	if err != nil {
+		return resolve.EmptyResult(m, err), false
+	}
+
+	msg := "The node is drained and ready to stop"
+	if !st.ReadyToStop {
+		msg = fmt.Sprintf("The drain didn't complete in %s, waiting for %s", timeout, st.Step)
+	}
+	data := response("Success", msg)
+	data["readyToStop"] = st.ReadyToStop
+	data["step"] = st.Step
+	data["pendingTxns"] = json.Number(strconv.Itoa(st.PendingTxns))
+	data["pendingProposals"] = json.Number(strconv.Itoa(st.PendingProposals))
+	data["isLeader"] = st.IsLeader
+	return resolve.DataResult(
+		m,
+		map[string]interface{}{m.Name(): data},
+		nil,
+	), true
+}
@@ -446,6 +446,31 @@ const adminTypes = `
 		namespace: UInt64
 	}
 
+	type Session {
+		sessionId: String!
+		userId: String!
+
+		"""
+		Time after which the refresh JWT of the session expires.
+		"""
+		expiry: DateTime
+		revoked: Boolean
+	}
+
+	input RevokeSessionInput {
+
+		"""
+		ID of the session to revoke.  If not given, all the sessions of the user are revoked.
+		"""
+		sessionId: String
+		userId: String
+	}
+
+	type RevokeSessionPayload {
+		message: String
+		numSessions: Int
+	}
+
 	input EnterpriseLicenseInput {
 		"""
 		The contents of license file as a String.
@@ -473,9 +498,11 @@ const adminMutations = `
 
 	"""
 	Login to Dgraph.  Successful login results in a JWT that can be used in future requests.
-	If login is not successful an error is returned.
+	If login is not successful an error is returned.  Instead of a userId and password, an
+	idToken issued by the OIDC provider configured through the --oidc flag can be used.
 	"""
-	login(userId: String, password: String, namespace: Int, refreshToken: String): LoginPayload
+	login(userId: String, password: String, namespace: Int, refreshToken: String,
+		idToken: String): LoginPayload
 
 	"""
 	Add a user.  When linking to groups: if the group doesn't exist it is created; if the group
@@ -528,6 +555,12 @@ const adminMutations = `
 	Apply enterprise license.
 	"""
 	enterpriseLicense(input: EnterpriseLicenseInput!): EnterpriseLicensePayload
+
+	"""
+	Revoke a login session, or all the sessions of a user, in the namespace of the guardian.
+	All the access and refresh JWTs of a revoked session are rejected, even before they expire.
+	"""
+	revokeSession(input: RevokeSessionInput!): RevokeSessionPayload
 	`
 
 const adminQueries = `
@@ -542,6 +575,12 @@ const adminQueries = `
 	queryUser(filter: UserFilter, order: UserOrder, first: Int, offset: Int): [User]
 	queryGroup(filter: GroupFilter, order: GroupOrder, first: Int, offset: Int): [Group]
 
+	"""
+	List the unexpired login sessions, of all users or of the given user, in the namespace of
+	the guardian.
+	"""
+	listSessions(userId: String): [Session]
+
 	"""
 	Get the information about the backups at a given location.
 	"""
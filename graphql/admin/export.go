@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"math"
 
+	"github.com/dgraph-io/dgraph/ee/enc"
 	"github.com/dgraph-io/dgraph/graphql/resolve"
 	"github.com/dgraph-io/dgraph/graphql/schema"
 	"github.com/dgraph-io/dgraph/protos/pb"
@@ -36,6 +37,7 @@ const notSet = math.MaxInt64
 type exportInput struct {
 	Format    string
 	Namespace int64
+	Recipient string
 	DestinationFields
 }
 
@@ -82,6 +84,11 @@ func resolveExport(ctx context.Context, m schema.Mutation) (*resolve.Resolved, b
 	if exportNs, err = validateAndGetNs(input.Namespace); err != nil {
 		return resolve.EmptyResult(m, err), false
 	}
+	if input.Recipient != "" {
+		if _, err := enc.ParseAgeRecipient(input.Recipient); err != nil {
+			return resolve.EmptyResult(m, err), false
+		}
+	}
 
 	req := &pb.ExportRequest{
 		Format:       format,
@@ -91,6 +98,7 @@ func resolveExport(ctx context.Context, m schema.Mutation) (*resolve.Resolved, b
 		SecretKey:    input.SecretKey,
 		SessionToken: input.SessionToken,
 		Anonymous:    input.Anonymous,
+		Recipient:    input.Recipient,
 	}
 	taskId, err := worker.Tasks.Enqueue(req)
 	if err != nil {
@@ -0,0 +1,128 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/dgraph-io/dgraph/graphql/resolve"
+	"github.com/dgraph-io/dgraph/graphql/schema"
+	"github.com/dgraph-io/dgraph/worker"
+	"github.com/golang/glog"
+)
+
+type fsckInput struct {
+	Predicates []string
+	Repair     bool
+	MaxIssues  int
+}
+
+func resolveFsck(ctx context.Context, m schema.Mutation) (*resolve.Resolved, bool) {
+	glog.Info("Got fsck request through GraphQL admin API")
+
+	var input fsckInput
+	if err := getStorageInput(m, &input); err != nil {
+		return resolve.EmptyResult(m, err), false
+	}
+	if err := worker.StartFsck(worker.FsckOptions{
+		Predicates: input.Predicates,
+		Repair:     input.Repair,
+		MaxIssues:  input.MaxIssues,
+	}); err != nil {
+		return resolve.EmptyResult(m, err), false
+	}
+	return resolve.DataResult(
+		m,
+		map[string]interface{}{m.Name(): response("Success", "Consistency check started")},
+		nil,
+	), true
+}
+
+type rebuildCountIndexInput struct {
+	Predicates []string
+}
+
+func resolveRebuildCountIndex(ctx context.Context, m schema.Mutation) (*resolve.Resolved, bool) {
+	glog.Info("Got rebuildCountIndex request through GraphQL admin API")
+
+	var input rebuildCountIndexInput
+	if err := getStorageInput(m, &input); err != nil {
+		return resolve.EmptyResult(m, err), false
+	}
+	preds, err := worker.RebuildCountIndex(ctx, input.Predicates)
+	if err != nil {
+		return resolve.EmptyResult(m, err), false
+	}
+	rebuilt := make([]interface{}, 0, len(preds))
+	for _, pred := range preds {
+		rebuilt = append(rebuilt, pred)
+	}
+	res := response("Success", fmt.Sprintf("Rebuilding the count index of %d predicates",
+		len(preds)))
+	res["predicates"] = rebuilt
+	return resolve.DataResult(m, map[string]interface{}{m.Name(): res}, nil), true
+}
+
+func fsckReportResult(r *worker.FsckReport) interface{} {
+	if r == nil {
+		return nil
+	}
+	issues := make([]interface{}, 0, len(r.Issues))
+	for _, issue := range r.Issues {
+		issues = append(issues, map[string]interface{}{
+			"kind":      issue.Kind,
+			"predicate": issue.Predicate,
+			"namespace": json.Number(strconv.FormatUint(issue.Namespace, 10)),
+			"key":       issue.Key,
+			"uid":       json.Number(strconv.FormatUint(issue.Uid, 10)),
+			"detail":    issue.Detail,
+		})
+	}
+	repaired := make([]interface{}, 0, len(r.Repaired))
+	for _, pred := range r.Repaired {
+		repaired = append(repaired, pred)
+	}
+	res := map[string]interface{}{
+		"startedAt":  r.StartedAt.Format(time.RFC3339),
+		"readTs":     json.Number(strconv.FormatUint(r.ReadTs, 10)),
+		"predicates": json.Number(strconv.Itoa(r.Predicates)),
+		"keys":       json.Number(strconv.FormatUint(r.Keys, 10)),
+		"issues":     issues,
+		"truncated":  r.Truncated,
+		"repaired":   repaired,
+	}
+	if r.Duration != "" {
+		res["duration"] = r.Duration
+	}
+	if r.Error != "" {
+		res["error"] = r.Error
+	}
+	return res
+}
+
+func resolveFsckStatus(ctx context.Context, q schema.Query) *resolve.Resolved {
+	running, last := worker.GetFsckStatus()
+	res := map[string]interface{}{
+		"running": fsckReportResult(running),
+		"last":    fsckReportResult(last),
+	}
+	return resolve.DataResult(q, map[string]interface{}{q.Name(): res}, nil)
+}
@@ -0,0 +1,97 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package admin
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/dgraph-io/dgraph/graphql/resolve"
+	"github.com/dgraph-io/dgraph/graphql/schema"
+	"github.com/dgraph-io/dgraph/posting"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+)
+
+const defaultHotKeys = 20
+
+// hotKeyKind returns the kind of the posting list of the key.
+func hotKeyKind(pk x.ParsedKey) string {
+	switch {
+	case pk.IsData():
+		return "data"
+	case pk.IsIndex():
+		return "index"
+	case pk.IsReverse():
+		return "reverse"
+	case pk.IsCountOrCountRev():
+		return "count"
+	}
+	return "other"
+}
+
+func resolveHotKeys(ctx context.Context, q schema.Query) *resolve.Resolved {
+	first := uint32(defaultHotKeys)
+	if arg := q.ArgValue("first"); arg != nil {
+		var err error
+		if first, err = parseAsUint32(arg); err != nil {
+			return resolve.EmptyResult(q, errors.Wrapf(err, "invalid first"))
+		}
+	}
+
+	hotKeys, since := posting.HotKeys(int(first))
+	keys := make([]interface{}, 0, len(hotKeys))
+	for _, hk := range hotKeys {
+		key := map[string]interface{}{
+			"key":       hex.EncodeToString(hk.Key),
+			"waits":     json.Number(strconv.FormatUint(hk.Waits, 10)),
+			"totalWait": hk.TotalWait.String(),
+			"maxWait":   hk.MaxWait.String(),
+			"lastWait":  hk.LastWait.Format(time.RFC3339),
+		}
+		if pk, err := x.Parse(hk.Key); err == nil {
+			key["predicate"] = x.ParseAttr(pk.Attr)
+			key["namespace"] = json.Number(strconv.FormatUint(x.ParseNamespace(pk.Attr), 10))
+			key["kind"] = hotKeyKind(pk)
+			// The index and count keys are shared by the nodes with the same token or count.
+			if pk.IsData() || pk.IsReverse() {
+				key["uid"] = json.Number(strconv.FormatUint(pk.Uid, 10))
+			}
+		}
+		keys = append(keys, key)
+	}
+	res := map[string]interface{}{"keys": keys}
+	if !since.IsZero() {
+		res["since"] = since.Format(time.RFC3339)
+	}
+	return resolve.DataResult(q, map[string]interface{}{q.Name(): res}, nil)
+}
+
+func resolveResetHotKeys(ctx context.Context, m schema.Mutation) (*resolve.Resolved, bool) {
+	glog.Info("Got resetHotKeys request through GraphQL admin API")
+
+	posting.ResetHotKeys()
+	return resolve.DataResult(
+		m,
+		map[string]interface{}{m.Name(): response("Success", "Reset the hot keys")},
+		nil,
+	), true
+}
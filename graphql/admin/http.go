@@ -195,6 +195,13 @@ func (gs *graphqlSubscription) Subscribe(
 		Header:        reqHeader,
 	}
 	namespace := x.ExtractNamespaceHTTP(&http.Request{Header: reqHeader})
+	// The namespace of a subscription addressed by URL is the one the alpha resolved for the
+	// websocket request, which can't be overridden by the payload.
+	if resolver := httpHeaders.Get("resolver"); resolver != "" {
+		if ns, err := strconv.ParseUint(resolver, 10, 64); err == nil {
+			namespace = ns
+		}
+	}
 	glog.Infof("namespace: %d. Got GraphQL request over websocket.", namespace)
 	// first load the schema, then do anything else
 	if err = LazyLoadSchema(namespace); err != nil {
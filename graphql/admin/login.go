@@ -32,18 +32,25 @@ type loginInput struct {
 	Password     string
 	Namespace    uint64
 	RefreshToken string
+	IdToken      string
 }
 
 func resolveLogin(ctx context.Context, m schema.Mutation) (*resolve.Resolved, bool) {
 	glog.Info("Got login request")
 
 	input := getLoginInput(m)
-	resp, err := (&edgraph.Server{}).Login(ctx, &dgoapi.LoginRequest{
-		Userid:       input.UserId,
-		Password:     input.Password,
-		Namespace:    input.Namespace,
-		RefreshToken: input.RefreshToken,
-	})
+	var resp *dgoapi.Response
+	var err error
+	if input.IdToken != "" {
+		resp, err = (&edgraph.Server{}).OidcLogin(ctx, input.IdToken, input.Namespace)
+	} else {
+		resp, err = (&edgraph.Server{}).Login(ctx, &dgoapi.LoginRequest{
+			Userid:       input.UserId,
+			Password:     input.Password,
+			Namespace:    input.Namespace,
+			RefreshToken: input.RefreshToken,
+		})
+	}
 	if err != nil {
 		return resolve.EmptyResult(m, err), false
 	}
@@ -74,6 +81,7 @@ func getLoginInput(m schema.Mutation) *loginInput {
 	input.UserId, _ = m.ArgValue("userId").(string)
 	input.Password, _ = m.ArgValue("password").(string)
 	input.RefreshToken, _ = m.ArgValue("refreshToken").(string)
+	input.IdToken, _ = m.ArgValue("idToken").(string)
 
 	b, err := json.Marshal(m.ArgValue("namespace"))
 	if err != nil {
@@ -0,0 +1,58 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/dgraph-io/dgraph/graphql/resolve"
+	"github.com/dgraph-io/dgraph/graphql/schema"
+	"github.com/dgraph-io/dgraph/worker"
+)
+
+func resolvePredicateStats(ctx context.Context, q schema.Query) *resolve.Resolved {
+	report := worker.GetPredicateStats()
+	if report == nil {
+		return resolve.DataResult(q, map[string]interface{}{q.Name(): nil}, nil)
+	}
+
+	preds := make([]interface{}, 0, len(report.Predicates))
+	for _, st := range report.Predicates {
+		preds = append(preds, map[string]interface{}{
+			"predicate":     st.Predicate,
+			"namespace":     json.Number(strconv.FormatUint(st.Namespace, 10)),
+			"keyCount":      json.Number(strconv.FormatUint(st.KeyCount, 10)),
+			"totalBytes":    json.Number(strconv.FormatInt(st.TotalBytes, 10)),
+			"splitLists":    json.Number(strconv.FormatUint(st.SplitLists, 10)),
+			"avgDeltaChain": st.AvgDeltaChain,
+			"indexBytes":    json.Number(strconv.FormatInt(st.IndexBytes, 10)),
+			"reverseBytes":  json.Number(strconv.FormatInt(st.ReverseBytes, 10)),
+			"countBytes":    json.Number(strconv.FormatInt(st.CountBytes, 10)),
+		})
+	}
+	res := map[string]interface{}{
+		"groupId":    json.Number(strconv.FormatUint(uint64(report.GroupId), 10)),
+		"readTs":     json.Number(strconv.FormatUint(report.ReadTs, 10)),
+		"computedAt": report.ComputedAt.Format(time.RFC3339),
+		"duration":   report.Duration,
+		"predicates": preds,
+	}
+	return resolve.DataResult(q, map[string]interface{}{q.Name(): res}, nil)
+}
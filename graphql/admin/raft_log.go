@@ -0,0 +1,53 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package admin
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dgraph-io/dgraph/graphql/resolve"
+	"github.com/dgraph-io/dgraph/graphql/schema"
+	"github.com/dgraph-io/dgraph/worker"
+	"github.com/golang/glog"
+)
+
+func resolveCompactRaftLog(ctx context.Context, m schema.Mutation) (*resolve.Resolved, bool) {
+	glog.Info("Got Raft log compaction request through GraphQL admin API")
+
+	snap, err := worker.CompactRaftLog(ctx)
+	if err != nil {
+		return resolve.EmptyResult(m, err), false
+	}
+	if snap == nil {
+		return resolve.DataResult(
+			m,
+			map[string]interface{}{m.Name(): response("Success", "Nothing to compact")},
+			nil,
+		), true
+	}
+
+	data := response("Success",
+		fmt.Sprintf("Raft log truncated up to the snapshot at index %d", snap.Index))
+	data["index"] = snap.Index
+	data["readTs"] = snap.ReadTs
+	return resolve.DataResult(
+		m,
+		map[string]interface{}{m.Name(): data},
+		nil,
+	), true
+}
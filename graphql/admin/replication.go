@@ -0,0 +1,122 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/dgraph-io/dgraph/edgraph"
+	"github.com/dgraph-io/dgraph/graphql/resolve"
+	"github.com/dgraph-io/dgraph/graphql/schema"
+	"github.com/dgraph-io/dgraph/posting"
+	"github.com/dgraph-io/dgraph/worker"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+)
+
+type predicateChecksumInput struct {
+	Namespace  uint64
+	Predicates []string
+}
+
+func resolveReplicationStatus(ctx context.Context, q schema.Query) *resolve.Resolved {
+	st, err := worker.GetCDCStatus()
+	if err != nil {
+		return resolve.EmptyResult(q, err)
+	}
+
+	res := map[string]interface{}{
+		"groupId":      json.Number(strconv.FormatUint(uint64(st.GroupId), 10)),
+		"isLeader":     st.IsLeader,
+		"sentTs":       json.Number(strconv.FormatUint(st.SentTs, 10)),
+		"seenIndex":    json.Number(strconv.FormatUint(st.SeenIndex, 10)),
+		"appliedIndex": json.Number(strconv.FormatUint(st.AppliedIndex, 10)),
+		"lagEntries":   json.Number(strconv.FormatUint(st.LagEntries(), 10)),
+		"pendingTxns":  json.Number(strconv.Itoa(st.PendingTxns)),
+	}
+	if !st.LastSentAt.IsZero() {
+		res["lastSentAt"] = st.LastSentAt.Format(time.RFC3339)
+	}
+	if st.LastError != "" {
+		res["lastError"] = st.LastError
+	}
+	return resolve.DataResult(q, map[string]interface{}{q.Name(): res}, nil)
+}
+
+func resolvePendingTransactions(ctx context.Context, q schema.Query) *resolve.Resolved {
+	now := time.Now()
+	txns := posting.Oracle().PendingTxns()
+	res := make([]interface{}, 0, len(txns))
+	for _, txn := range txns {
+		res = append(res, map[string]interface{}{
+			"startTs":    json.Number(strconv.FormatUint(txn.StartTs, 10)),
+			"startedAt":  txn.StartedAt.Format(time.RFC3339),
+			"lastUpdate": txn.LastUpdate.Format(time.RFC3339),
+			"age":        txn.Age(now).Round(time.Second).String(),
+			"numKeys":    json.Number(strconv.Itoa(txn.NumKeys)),
+		})
+	}
+	return resolve.DataResult(q, map[string]interface{}{q.Name(): res}, nil)
+}
+
+func resolvePredicateChecksums(ctx context.Context, q schema.Query) *resolve.Resolved {
+	glog.Info("Got predicate checksums request through GraphQL admin API")
+
+	inputBytes, err := json.Marshal(q.ArgValue(schema.InputArgName))
+	if err != nil {
+		return resolve.EmptyResult(q, schema.GQLWrapf(err, "couldn't get input argument"))
+	}
+	var input predicateChecksumInput
+	if err := json.Unmarshal(inputBytes, &input); err != nil {
+		return resolve.EmptyResult(q, schema.GQLWrapf(err, "couldn't get input argument"))
+	}
+	if len(input.Predicates) == 0 {
+		return resolve.EmptyResult(q, errors.Errorf("no predicates given"))
+	}
+
+	sums, err := edgraph.ChecksumPredicates(ctx, input.Namespace, input.Predicates)
+	if err != nil {
+		return resolve.EmptyResult(q, err)
+	}
+	res := make([]interface{}, 0, len(sums))
+	for _, sum := range sums {
+		res = append(res, map[string]interface{}{
+			"predicate": sum.Predicate,
+			"count":     json.Number(strconv.Itoa(sum.Count)),
+			"checksum":  sum.Checksum,
+			"readTs":    json.Number(strconv.FormatUint(sum.ReadTs, 10)),
+		})
+	}
+	return resolve.DataResult(q, map[string]interface{}{q.Name(): res}, nil)
+}
+
+func resolveFailover(ctx context.Context, m schema.Mutation) (*resolve.Resolved, bool) {
+	glog.Info("Got failover request through GraphQL admin API")
+
+	if _, err := edgraph.Failover(ctx); err != nil {
+		return resolve.EmptyResult(m, err), false
+	}
+	return resolve.DataResult(
+		m,
+		map[string]interface{}{m.Name(): response("Success",
+			"The cluster is no longer a standby and accepts writes")},
+		nil,
+	), true
+}
@@ -19,14 +19,17 @@ package admin
 import (
 	"context"
 	"encoding/json"
-	"github.com/dgraph-io/dgraph/worker"
+	"strconv"
+	"time"
 
 	"github.com/dgraph-io/dgraph/edgraph"
 	"github.com/dgraph-io/dgraph/graphql/resolve"
 	"github.com/dgraph-io/dgraph/graphql/schema"
 	"github.com/dgraph-io/dgraph/query"
+	"github.com/dgraph-io/dgraph/worker"
 	"github.com/dgraph-io/dgraph/x"
 	"github.com/golang/glog"
+	"github.com/pkg/errors"
 )
 
 type getSchemaResolver struct {
@@ -34,7 +37,12 @@ type getSchemaResolver struct {
 }
 
 type updateGQLSchemaInput struct {
-	Set worker.GqlSchema `json:"set,omitempty"`
+	Set gqlSchemaPatch `json:"set,omitempty"`
+}
+
+type gqlSchemaPatch struct {
+	Schema    string             `json:"schema,omitempty"`
+	Documents []*schema.Document `json:"documents,omitempty"`
 }
 
 type updateSchemaResolver struct {
@@ -48,10 +56,61 @@ func (usr *updateSchemaResolver) Resolve(ctx context.Context, m schema.Mutation)
 	if err != nil {
 		return resolve.EmptyResult(m, err), false
 	}
+	sch, err := input.Set.merge(ctx)
+	if err != nil {
+		return resolve.EmptyResult(m, err), false
+	}
+	return applyGQLSchema(ctx, m, sch, 0)
+}
+
+type rollbackSchemaResolver struct {
+	admin *adminServer
+}
+
+func (rsr *rollbackSchemaResolver) Resolve(ctx context.Context,
+	m schema.Mutation) (*resolve.Resolved, bool) {
+	inputArg, ok := m.ArgValue(schema.InputArgName).(map[string]interface{})
+	if !ok {
+		return resolve.EmptyResult(m, inputArgError(errors.Errorf(
+			"can't convert input to map"))), false
+	}
+	version, err := parseAsUint64(inputArg["version"])
+	if err != nil {
+		return resolve.EmptyResult(m, inputArgError(schema.GQLWrapf(err,
+			"can't convert input.version to uint64"))), false
+	}
+	glog.Infof("Got rollbackGQLSchema request to version %d", version)
+
+	ns, err := x.ExtractNamespace(ctx)
+	if err != nil {
+		return resolve.EmptyResult(m, err), false
+	}
+	gql, err := edgraph.GetGQLSchemaHistory(ns)
+	if err != nil {
+		return resolve.EmptyResult(m, err), false
+	}
+	current := worker.GQLSchemaVersion(gql)
+	if version == current {
+		return resolve.EmptyResult(m, errors.Errorf(
+			"version %d is the current version of the GraphQL schema", version)), false
+	}
+	for _, v := range gql.History {
+		if v.Version == version {
+			// The rollback only succeeds if no other update happened since the history was read.
+			return applyGQLSchema(ctx, m, v.Schema, current)
+		}
+	}
+	return resolve.EmptyResult(m, errors.Errorf(
+		"version %d of the GraphQL schema was not found in the history", version)), false
+}
 
+// applyGQLSchema validates the GraphQL schema and updates the GraphQL and Dgraph schemas with it.
+// If version is non-zero, the update only succeeds if the current GraphQL schema has that version.
+func applyGQLSchema(ctx context.Context, m schema.Mutation, sch string,
+	version uint64) (*resolve.Resolved, bool) {
 	// We just need to validate the schema. Schema is later set in `resetSchema()` when the schema
 	// is returned from badger.
-	schHandler, err := schema.NewHandler(input.Set.Schema, false)
+	schHandler, err := schema.NewHandler(sch, false)
 	if err != nil {
 		return resolve.EmptyResult(m, err), false
 	}
@@ -61,7 +120,7 @@ func (usr *updateSchemaResolver) Resolve(ctx context.Context, m schema.Mutation)
 		return resolve.EmptyResult(m, err), false
 	}
 
-	resp, err := edgraph.UpdateGQLSchema(ctx, input.Set.Schema, schHandler.DGSchema())
+	resp, err := edgraph.UpdateGQLSchemaAtVersion(ctx, sch, schHandler.DGSchema(), version)
 	if err != nil {
 		return resolve.EmptyResult(m, err), false
 	}
@@ -72,12 +131,45 @@ func (usr *updateSchemaResolver) Resolve(ctx context.Context, m schema.Mutation)
 			m.Name(): map[string]interface{}{
 				"gqlSchema": map[string]interface{}{
 					"id":              query.UidToHex(resp.Uid),
-					"schema":          input.Set.Schema,
+					"schema":          sch,
 					"generatedSchema": schHandler.GQLSchema(),
+					"documents":       documentsData(sch),
 				}}},
 		nil), true
 }
 
+func resolveGetSchemaHistory(ctx context.Context, q schema.Query) *resolve.Resolved {
+	ns, err := x.ExtractNamespace(ctx)
+	if err != nil {
+		return resolve.EmptyResult(q, err)
+	}
+	gql, err := edgraph.GetGQLSchemaHistory(ns)
+	if err != nil {
+		return resolve.EmptyResult(q, err)
+	}
+
+	versionData := func(version uint64, sch string, appliedAt time.Time, current bool) interface{} {
+		data := map[string]interface{}{
+			"version": json.Number(strconv.FormatUint(version, 10)),
+			"schema":  sch,
+			"current": current,
+		}
+		if !appliedAt.IsZero() {
+			data["appliedAt"] = appliedAt.Format(time.RFC3339)
+		}
+		return data
+	}
+	versions := make([]interface{}, 0, len(gql.History)+1)
+	if gql.Schema != "" {
+		versions = append(versions,
+			versionData(worker.GQLSchemaVersion(gql), gql.Schema, gql.AppliedAt, true))
+	}
+	for _, v := range gql.History {
+		versions = append(versions, versionData(v.Version, v.Schema, v.AppliedAt, false))
+	}
+	return resolve.DataResult(q, map[string]interface{}{q.Name(): versions}, nil)
+}
+
 func (gsr *getSchemaResolver) Resolve(ctx context.Context, q schema.Query) *resolve.Resolved {
 	var data map[string]interface{}
 
@@ -98,6 +190,7 @@ func (gsr *getSchemaResolver) Resolve(ctx context.Context, q schema.Query) *reso
 				"id":              cs.ID,
 				"schema":          cs.Schema,
 				"generatedSchema": cs.GeneratedSchema,
+				"documents":       documentsData(cs.Schema),
 			}}
 	}
 
@@ -115,3 +208,41 @@ func getSchemaInput(m schema.Mutation) (*updateGQLSchemaInput, error) {
 	err = json.Unmarshal(inputByts, &input)
 	return &input, schema.GQLWrapf(err, "couldn't get input argument")
 }
+
+// merge returns the schema to store. If the patch has documents, they are applied to the documents
+// of the current schema of the namespace, which are merged back into a schema. Concurrent updates
+// of different documents are applied one after the other, so the last one may drop the changes of
+// the others, like concurrent updates of the whole schema.
+func (p *gqlSchemaPatch) merge(ctx context.Context) (string, error) {
+	if len(p.Documents) == 0 {
+		// A whole schema may be made of documents, like one read from getGQLSchema, which must
+		// not conflict either.
+		if docs := schema.SplitDocuments(p.Schema); len(docs) > 1 {
+			return schema.MergeDocuments(docs)
+		}
+		return p.Schema, nil
+	}
+	if p.Schema != "" {
+		return "", errors.Errorf("only one of schema or documents can be given")
+	}
+
+	ns, err := x.ExtractNamespace(ctx)
+	if err != nil {
+		return "", err
+	}
+	cs, err := getCurrentGraphQLSchema(ns)
+	if err != nil {
+		return "", err
+	}
+	return schema.MergeDocuments(schema.PatchDocuments(schema.SplitDocuments(cs.Schema),
+		p.Documents))
+}
+
+func documentsData(sch string) []interface{} {
+	docs := schema.SplitDocuments(sch)
+	data := make([]interface{}, 0, len(docs))
+	for _, doc := range docs {
+		data = append(data, map[string]interface{}{"name": doc.Name, "schema": doc.Schema})
+	}
+	return data
+}
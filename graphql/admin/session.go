@@ -0,0 +1,93 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/dgraph-io/dgraph/edgraph"
+	"github.com/dgraph-io/dgraph/graphql/resolve"
+	"github.com/dgraph-io/dgraph/graphql/schema"
+	"github.com/golang/glog"
+)
+
+type revokeSessionInput struct {
+	SessionId string
+	UserId    string
+}
+
+func resolveListSessions(ctx context.Context, q schema.Query) *resolve.Resolved {
+	userId, _ := q.ArgValue("userId").(string)
+	sessions, err := edgraph.ListSessions(ctx, userId)
+	if err != nil {
+		return resolve.EmptyResult(q, err)
+	}
+
+	results := make([]map[string]interface{}, 0, len(sessions))
+	for _, s := range sessions {
+		results = append(results, map[string]interface{}{
+			"sessionId": s.SessionId,
+			"userId":    s.UserId,
+			"expiry":    s.Expiry.UTC().Format(time.RFC3339),
+			"revoked":   s.Revoked,
+		})
+	}
+	return resolve.DataResult(
+		q,
+		map[string]interface{}{q.Name(): results},
+		nil,
+	)
+}
+
+func resolveRevokeSession(ctx context.Context, m schema.Mutation) (*resolve.Resolved, bool) {
+	glog.Info("Got revoke session request through GraphQL admin API")
+
+	input, err := getRevokeSessionInput(m)
+	if err != nil {
+		return resolve.EmptyResult(m, err), false
+	}
+	num, err := edgraph.RevokeSessions(ctx, input.SessionId, input.UserId)
+	if err != nil {
+		return resolve.EmptyResult(m, err), false
+	}
+
+	return resolve.DataResult(
+		m,
+		map[string]interface{}{
+			m.Name(): map[string]interface{}{
+				"message":     "Revoked sessions successfully",
+				"numSessions": json.Number(strconv.Itoa(num)),
+			},
+		},
+		nil,
+	), true
+}
+
+func getRevokeSessionInput(m schema.Mutation) (*revokeSessionInput, error) {
+	inputArg := m.ArgValue(schema.InputArgName)
+	inputByts, err := json.Marshal(inputArg)
+	if err != nil {
+		return nil, schema.GQLWrapf(err, "couldn't get input argument")
+	}
+
+	var input revokeSessionInput
+	err = json.Unmarshal(inputByts, &input)
+	return &input, schema.GQLWrapf(err, "couldn't get input argument")
+}
@@ -0,0 +1,182 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/dgraph-io/dgraph/graphql/resolve"
+	"github.com/dgraph-io/dgraph/graphql/schema"
+	"github.com/dgraph-io/dgraph/worker"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+)
+
+type valueLogGCInput struct {
+	DiscardRatio *float64
+}
+
+type flattenStorageInput struct {
+	Workers *int
+}
+
+type storageCacheInput struct {
+	BlockCacheMb *int64
+	IndexCacheMb *int64
+}
+
+// getStorageInput decodes the input argument of the mutation into input. The argument is
+// optional for some of the mutations, in which case input is left as is.
+func getStorageInput(m schema.Mutation, input interface{}) error {
+	arg := m.ArgValue(schema.InputArgName)
+	if arg == nil {
+		return nil
+	}
+	inputBytes, err := json.Marshal(arg)
+	if err != nil {
+		return schema.GQLWrapf(err, "couldn't get input argument")
+	}
+	if err := json.Unmarshal(inputBytes, input); err != nil {
+		return schema.GQLWrapf(err, "couldn't get input argument")
+	}
+	return nil
+}
+
+func resolveValueLogGC(ctx context.Context, m schema.Mutation) (*resolve.Resolved, bool) {
+	glog.Info("Got value log GC request through GraphQL admin API")
+
+	var input valueLogGCInput
+	if err := getStorageInput(m, &input); err != nil {
+		return resolve.EmptyResult(m, err), false
+	}
+	ratio := worker.DefaultDiscardRatio
+	if input.DiscardRatio != nil {
+		ratio = *input.DiscardRatio
+	}
+	if err := worker.StartValueLogGC(ratio); err != nil {
+		return resolve.EmptyResult(m, err), false
+	}
+	return resolve.DataResult(
+		m,
+		map[string]interface{}{m.Name(): response("Success",
+			fmt.Sprintf("Value log GC started with discard ratio %v", ratio))},
+		nil,
+	), true
+}
+
+func resolveFlattenStorage(ctx context.Context, m schema.Mutation) (*resolve.Resolved, bool) {
+	glog.Info("Got flatten storage request through GraphQL admin API")
+
+	var input flattenStorageInput
+	if err := getStorageInput(m, &input); err != nil {
+		return resolve.EmptyResult(m, err), false
+	}
+	workers := 1
+	if input.Workers != nil {
+		workers = *input.Workers
+	}
+	if err := worker.StartFlatten(workers); err != nil {
+		return resolve.EmptyResult(m, err), false
+	}
+	return resolve.DataResult(
+		m,
+		map[string]interface{}{m.Name(): response("Success",
+			fmt.Sprintf("Compaction started with %d workers", workers))},
+		nil,
+	), true
+}
+
+func resolveUpdateStorageCache(ctx context.Context, m schema.Mutation) (*resolve.Resolved, bool) {
+	glog.Info("Got update storage cache request through GraphQL admin API")
+
+	var input storageCacheInput
+	if err := getStorageInput(m, &input); err != nil {
+		return resolve.EmptyResult(m, err), false
+	}
+	block, index := int64(-1), int64(-1)
+	for _, c := range []struct {
+		mb   *int64
+		size *int64
+	}{{input.BlockCacheMb, &block}, {input.IndexCacheMb, &index}} {
+		if c.mb == nil {
+			continue
+		}
+		if *c.mb < 0 {
+			return resolve.EmptyResult(m, errors.New("cache sizes must be non-negative")), false
+		}
+		*c.size = *c.mb << 20
+	}
+	if err := worker.UpdateStorageCache(block, index); err != nil {
+		return resolve.EmptyResult(m, err), false
+	}
+	return resolve.DataResult(
+		m,
+		map[string]interface{}{m.Name(): response("Success", "Storage caches updated")},
+		nil,
+	), true
+}
+
+func storageOpResult(st *worker.StorageOpStatus) interface{} {
+	if st == nil {
+		return nil
+	}
+	res := map[string]interface{}{
+		"op":        st.Op,
+		"startedAt": st.StartedAt.Format(time.RFC3339),
+		"rewritten": json.Number(strconv.Itoa(st.Rewritten)),
+	}
+	if st.Duration != "" {
+		res["duration"] = st.Duration
+	}
+	if st.Error != "" {
+		res["error"] = st.Error
+	}
+	return res
+}
+
+func resolveStorageStatus(ctx context.Context, q schema.Query) *resolve.Resolved {
+	st, err := worker.GetStorageStatus()
+	if err != nil {
+		return resolve.EmptyResult(q, err)
+	}
+
+	levels := make([]interface{}, 0, len(st.Levels))
+	for _, l := range st.Levels {
+		levels = append(levels, map[string]interface{}{
+			"level":      json.Number(strconv.Itoa(l.Level)),
+			"numTables":  json.Number(strconv.Itoa(l.NumTables)),
+			"size":       json.Number(strconv.FormatInt(l.Size, 10)),
+			"targetSize": json.Number(strconv.FormatInt(l.TargetSize, 10)),
+			"score":      l.Score,
+		})
+	}
+	res := map[string]interface{}{
+		"lsmBytes":        json.Number(strconv.FormatInt(st.LsmBytes, 10)),
+		"vlogBytes":       json.Number(strconv.FormatInt(st.VlogBytes, 10)),
+		"levels":          levels,
+		"blockCacheBytes": json.Number(strconv.FormatInt(st.BlockCacheBytes, 10)),
+		"indexCacheBytes": json.Number(strconv.FormatInt(st.IndexCacheBytes, 10)),
+		"running":         storageOpResult(st.Running),
+		"lastValueLogGC":  storageOpResult(st.Last[worker.StorageOpValueLogGC]),
+		"lastFlatten":     storageOpResult(st.Last[worker.StorageOpFlatten]),
+	}
+	return resolve.DataResult(q, map[string]interface{}{q.Name(): res}, nil)
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/dgraph-io/dgraph/graphql/schema"
 	"github.com/dgraph-io/dgraph/protos/pb"
 	"github.com/dgraph-io/dgraph/worker"
+	"github.com/golang/glog"
 	"github.com/pkg/errors"
 )
 
@@ -35,40 +36,91 @@ type taskInput struct {
 }
 
 func resolveTask(ctx context.Context, q schema.Query) *resolve.Resolved {
-	// Get Task ID.
-	input, err := getTaskInput(q)
+	taskId, err := getTaskId(q)
 	if err != nil {
 		return resolve.EmptyResult(q, err)
 	}
-	if input.Id == "" {
-		return resolve.EmptyResult(q, fmt.Errorf("task ID is missing"))
+
+	// Get TaskMeta from network.
+	req := &pb.TaskStatusRequest{TaskId: taskId}
+	resp, err := worker.TaskStatusOverNetwork(context.Background(), req)
+	if err != nil {
+		return resolve.EmptyResult(q, err)
 	}
-	taskId, err := strconv.ParseUint(input.Id, 0, 64)
+	return resolve.DataResult(q, map[string]interface{}{q.Name(): taskToMap(resp)}, nil)
+}
+
+func resolveTasks(ctx context.Context, q schema.Query) *resolve.Resolved {
+	list, err := worker.Tasks.List()
 	if err != nil {
-		err = errors.Wrapf(err, "invalid task ID: %s", input.Id)
 		return resolve.EmptyResult(q, err)
 	}
+	res := make([]interface{}, 0, len(list))
+	for _, resp := range list {
+		res = append(res, taskToMap(resp))
+	}
+	return resolve.DataResult(q, map[string]interface{}{q.Name(): res}, nil)
+}
 
-	// Get TaskMeta from network.
-	req := &pb.TaskStatusRequest{TaskId: taskId}
+func resolveCancelTask(ctx context.Context, m schema.Mutation) (*resolve.Resolved, bool) {
+	glog.Info("Got cancelTask request through GraphQL admin API")
+
+	taskId, err := getTaskId(m)
+	if err != nil {
+		return resolve.EmptyResult(m, err), false
+	}
+	req := &pb.TaskStatusRequest{TaskId: taskId, Cancel: true}
 	resp, err := worker.TaskStatusOverNetwork(context.Background(), req)
 	if err != nil {
-		return resolve.EmptyResult(q, err)
+		return resolve.EmptyResult(m, err), false
 	}
+	return resolve.DataResult(m, map[string]interface{}{m.Name(): taskToMap(resp)}, nil), true
+}
+
+// taskToMap converts the status of a task into the GraphQL response.
+func taskToMap(resp *pb.TaskStatusResponse) map[string]interface{} {
 	meta := worker.TaskMeta(resp.GetTaskMeta())
-	return resolve.DataResult(
-		q,
-		map[string]interface{}{q.Name(): map[string]interface{}{
-			"kind":        meta.Kind().String(),
-			"status":      meta.Status().String(),
-			"lastUpdated": meta.Timestamp().Format(time.RFC3339),
-		}},
-		nil,
-	)
+	logs := make([]interface{}, 0, len(resp.GetLogs()))
+	for _, line := range resp.GetLogs() {
+		logs = append(logs, line)
+	}
+	res := map[string]interface{}{
+		"kind":        meta.Kind().String(),
+		"status":      meta.Status().String(),
+		"lastUpdated": meta.Timestamp().Format(time.RFC3339),
+		"progress":    json.Number(strconv.FormatUint(resp.GetProgress(), 10)),
+		"logs":        logs,
+	}
+	if resp.GetTaskId() != 0 {
+		res["id"] = fmt.Sprintf("%#x", resp.GetTaskId())
+	}
+	if resp.GetTotal() != 0 {
+		res["total"] = json.Number(strconv.FormatUint(resp.GetTotal(), 10))
+	}
+	if resp.GetError() != "" {
+		res["error"] = resp.GetError()
+	}
+	return res
+}
+
+// getTaskId returns the ID of the task given as input to the query or the mutation.
+func getTaskId(f schema.Field) (uint64, error) {
+	input, err := getTaskInput(f)
+	if err != nil {
+		return 0, err
+	}
+	if input.Id == "" {
+		return 0, fmt.Errorf("task ID is missing")
+	}
+	taskId, err := strconv.ParseUint(input.Id, 0, 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid task ID: %s", input.Id)
+	}
+	return taskId, nil
 }
 
-func getTaskInput(q schema.Query) (*taskInput, error) {
-	inputArg := q.ArgValue(schema.InputArgName)
+func getTaskInput(f schema.Field) (*taskInput, error) {
+	inputArg := f.ArgValue(schema.InputArgName)
 	inputBytes, err := json.Marshal(inputArg)
 	if err != nil {
 		return nil, schema.GQLWrapf(err, "couldn't get input argument")
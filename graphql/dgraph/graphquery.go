@@ -22,6 +22,7 @@ import (
 
 	"github.com/dgraph-io/dgraph/gql"
 	"github.com/dgraph-io/dgraph/graphql/schema"
+	"github.com/dgraph-io/dgraph/protos/pb"
 	"github.com/dgraph-io/dgraph/x"
 )
 
@@ -92,6 +93,12 @@ func writeQuery(b *strings.Builder, query *gql.GraphQuery, prefix string) {
 		x.Check2(b.WriteRune(')'))
 	}
 
+	if query.Facets != nil {
+		x.Check2(b.WriteString(" @facets("))
+		writeFacets(b, query.Facets)
+		x.Check2(b.WriteRune(')'))
+	}
+
 	if query.Func == nil && hasOrderOrPage(query) {
 		x.Check2(b.WriteString(" ("))
 		writeOrderAndPage(b, query, false)
@@ -133,6 +140,21 @@ func writeQuery(b *strings.Builder, query *gql.GraphQuery, prefix string) {
 	}
 }
 
+// writeFacets writes the facet keys of the query, along with their aliases. For eg :-
+// `Edge.since: since, Edge.close: close`
+func writeFacets(b *strings.Builder, facets *pb.FacetParams) {
+	for i, f := range facets.Param {
+		if i != 0 {
+			x.Check2(b.WriteString(", "))
+		}
+		if f.Alias != "" {
+			x.Check2(b.WriteString(f.Alias))
+			x.Check2(b.WriteString(": "))
+		}
+		x.Check2(b.WriteString(f.Key))
+	}
+}
+
 // writeNeedVar writes the NeedsVar of the query. For eg :-
 // `userFollowerCount as sum(val(followers))` has `followers`
 // as NeedsVar.
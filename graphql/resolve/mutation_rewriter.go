@@ -1702,6 +1702,13 @@ func rewriteObject(
 						"type":        fieldDef.Type().Name(),
 						"coordinates": rewriteGeoObject(val, fieldDef.Type()),
 					}
+			} else if fieldDef.Type().IsEdge() {
+				fieldMutationFragment, _, err := rewriteEdgeField(ctx, fieldDef, fieldName, myUID, varGen, val, xidMetadata, idExistence, mutationType)
+				if fieldMutationFragment != nil {
+					newObj[fieldName] = fieldMutationFragment.fragment
+					updateFromChildren(frag, fieldMutationFragment)
+				}
+				retErrors = append(retErrors, err...)
 			} else {
 				fieldMutationFragment, _, err := rewriteObject(ctx, fieldDef.Type(), fieldDef, myUID, varGen, val, xidMetadata, idExistence, mutationType)
 				if fieldMutationFragment != nil {
@@ -1726,6 +1733,8 @@ func rewriteObject(
 								"coordinates": rewriteGeoObject(object, fieldDef.Type()),
 							},
 						)
+					} else if fieldDef.Type().IsEdge() {
+						fieldMutationFragment, _, err = rewriteEdgeField(ctx, fieldDef, fieldName, myUID, varGen, object, xidMetadata, idExistence, mutationType)
 					} else {
 						fieldMutationFragment, _, err = rewriteObject(ctx, fieldDef.Type(), fieldDef, myUID, varGen, object, xidMetadata, idExistence, mutationType)
 					}
@@ -1946,6 +1955,12 @@ func existenceQueries(
 				retErrors = append(retErrors, err...)
 				ret = append(ret, fieldQueries...)
 				retTypes = append(retTypes, fieldTypes...)
+			} else if fieldDef.Type().IsEdge() {
+				fieldQueries, fieldTypes, err := existenceQueriesEdge(
+					ctx, typ, fieldDef, varGen, val, xidMetadata, -1)
+				retErrors = append(retErrors, err...)
+				ret = append(ret, fieldQueries...)
+				retTypes = append(retTypes, fieldTypes...)
 			} else {
 				fieldQueries, fieldTypes, err := existenceQueries(ctx,
 					fieldDef.Type(), fieldDef, varGen, val, xidMetadata)
@@ -1963,6 +1978,9 @@ func existenceQueries(
 					if fieldDef.Type().IsUnion() {
 						fieldQueries, fieldTypes, err = existenceQueriesUnion(
 							ctx, typ, fieldDef, varGen, object, xidMetadata, i)
+					} else if fieldDef.Type().IsEdge() {
+						fieldQueries, fieldTypes, err = existenceQueriesEdge(
+							ctx, typ, fieldDef, varGen, object, xidMetadata, i)
 					} else {
 						fieldQueries, fieldTypes, err = existenceQueries(
 							ctx, fieldDef.Type(), fieldDef, varGen, object, xidMetadata)
@@ -2028,6 +2046,73 @@ func existenceQueriesUnion(
 	return existenceQueries(ctx, newtyp, srcField, varGen, obj, xidMetadata)
 }
 
+// existenceQueriesEdge creates the existence queries for the node of obj, which is an edge of
+// the edge field srcField. The node is checked like an object of the field whose edges are
+// exposed by srcField.
+func existenceQueriesEdge(
+	ctx context.Context,
+	parentTyp schema.Type,
+	srcField schema.FieldDefinition,
+	varGen *VariableGenerator,
+	obj map[string]interface{},
+	xidMetadata *xidMetadata,
+	listIndex int) ([]*gql.GraphQuery, []string, []error) {
+
+	facetedField := srcField.FacetedField()
+	node, _ := obj[schema.EdgeNode].(map[string]interface{})
+	if facetedField.Type().IsUnion() {
+		return existenceQueriesUnion(ctx, parentTyp, facetedField, varGen, node, xidMetadata,
+			listIndex)
+	}
+	return existenceQueries(ctx, facetedField.Type(), facetedField, varGen, node, xidMetadata)
+}
+
+// rewriteEdgeField rewrites obj, which is an edge of the edge field srcField. The node of the
+// edge is rewritten like an object of the field whose edges are exposed by srcField, and the
+// facets of the edge are added to it as predicate|facet, like facets are given in JSON
+// mutations. Eg:
+// { "node": { "id": "0x123" }, "since": "2021-01-01" }
+// is rewritten as
+// { "uid": "0x123", "Person.friends|since": "2021-01-01" }
+func rewriteEdgeField(
+	ctx context.Context,
+	srcField schema.FieldDefinition,
+	predicate string,
+	srcUID string,
+	varGen *VariableGenerator,
+	obj map[string]interface{},
+	xidMetadata *xidMetadata,
+	existenceQueriesResult map[string]string,
+	mutationType MutationType) (*mutationFragment, string, []error) {
+
+	facetedField := srcField.FacetedField()
+	node, _ := obj[schema.EdgeNode].(map[string]interface{})
+	var frag *mutationFragment
+	var upsertVar string
+	var errs []error
+	if facetedField.Type().IsUnion() {
+		frag, upsertVar, errs = rewriteUnionField(ctx, facetedField, srcUID, varGen, node,
+			xidMetadata, existenceQueriesResult, mutationType)
+	} else {
+		frag, upsertVar, errs = rewriteObject(ctx, facetedField.Type(), facetedField, srcUID,
+			varGen, node, xidMetadata, existenceQueriesResult, mutationType)
+	}
+
+	// Removing an edge removes its facets along with it.
+	if frag == nil || mutationType == UpdateWithRemove {
+		return frag, upsertVar, errs
+	}
+	if fragment, ok := frag.fragment.(map[string]interface{}); ok {
+		for key, val := range obj {
+			if key == schema.EdgeNode || val == nil {
+				continue
+			}
+			fragment[predicate+x.FacetDelimeter+key] = val
+		}
+	}
+	return frag, upsertVar, errs
+}
+
 // if this is a union field, then obj should have only one key which will be a ref
 // to one of the member types. Eg:
 // { "dogRef" : { ... } }
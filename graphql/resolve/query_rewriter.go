@@ -1590,21 +1590,24 @@ func addSelectionSetFrom(
 			child.Attr = f.DgraphPredicate()
 		}
 
+		// For edge fields, this is the type of the nodes of the edges.
+		fieldType := f.ConstructedFor()
+
 		filter, _ := f.ArgValue("filter").(map[string]interface{})
 		// if this field has been filtered out by the filter, then don't add it in DQL query
-		if includeField := addFilter(child, f.Type(), filter); !includeField {
+		if includeField := addFilter(child, fieldType, filter); !includeField {
 			continue
 		}
 
 		// Add type filter in case the Dgraph predicate is a reverse edge
 		if strings.HasPrefix(f.DgraphPredicate(), "~") {
-			addTypeFilter(child, f.Type())
+			addTypeFilter(child, fieldType)
 		}
 
 		addOrder(child, f)
 		addPagination(child, f)
 		addCascadeDirective(child, f)
-		rbac := auth.evaluateStaticRules(f.Type())
+		rbac := auth.evaluateStaticRules(fieldType)
 
 		// Since the recursion processes the query in bottom up way, we store the state of the so
 		// that we can restore it later.
@@ -1612,12 +1615,14 @@ func addSelectionSetFrom(
 		if len(f.SelectionSet()) > 0 && !auth.isWritingAuth && auth.hasAuthRules {
 			parentVarName = auth.parentVarName
 			parentQryName = auth.varName
-			auth.parentVarName = auth.varGen.Next(f.Type(), "", "", auth.isWritingAuth)
-			auth.varName = auth.varGen.Next(f.Type(), "", "", auth.isWritingAuth)
+			auth.parentVarName = auth.varGen.Next(fieldType, "", "", auth.isWritingAuth)
+			auth.varName = auth.varGen.Next(fieldType, "", "", auth.isWritingAuth)
 		}
 
 		var selectionAuth []*gql.GraphQuery
-		if !f.Type().IsGeo() {
+		if f.Type().IsEdge() {
+			selectionAuth = addEdgeSelectionSetFrom(child, f, auth)
+		} else if !f.Type().IsGeo() {
 			selectionAuth = addSelectionSetFrom(child, f, auth)
 		}
 
@@ -1642,7 +1647,7 @@ func addSelectionSetFrom(
 			// to write the query and add a dummy filter that doesn't return anything.
 			// Example: AdminTask5 as var(func: uid())
 			q.Children = append(q.Children, child)
-			varName := auth.varGen.Next(f.Type(), "", "", auth.isWritingAuth)
+			varName := auth.varGen.Next(fieldType, "", "", auth.isWritingAuth)
 			fieldAuth = append(fieldAuth, &gql.GraphQuery{
 				Var:  varName,
 				Attr: "var",
@@ -1666,7 +1671,7 @@ func addSelectionSetFrom(
 
 		// If RBAC rules are evaluated to `Uncertain` then we add the Auth rules.
 		if rbac == schema.Uncertain {
-			fieldAuth, authFilter = auth.rewriteAuthQueries(f.Type())
+			fieldAuth, authFilter = auth.rewriteAuthQueries(fieldType)
 		}
 
 		if len(f.SelectionSet()) > 0 && !auth.isWritingAuth && auth.hasAuthRules {
@@ -1717,6 +1722,54 @@ func addSelectionSetFrom(
 	return authQueries
 }
 
+// addEdgeSelectionSetFrom adds the selections of the node of the edge field into q, along with
+// the facets of the edges requested in field, and returns a list of extra queries needed to
+// satisfy auth requirements. The facets are fetched with the alias TypeEdge.facetKey, under
+// which they are found at the level of the node in the result.
+func addEdgeSelectionSetFrom(
+	q *gql.GraphQuery,
+	field schema.Field,
+	auth *authRewriter) []*gql.GraphQuery {
+
+	var authQueries []*gql.GraphQuery
+	var facets []*pb.FacetParam
+	nodeAdded := false
+	facetAdded := make(map[string]bool)
+	for _, f := range field.SelectionSet() {
+		if f.Skip() || !f.Include() || f.Name() == schema.Typename {
+			continue
+		}
+		if f.Name() == schema.EdgeNode {
+			// The node is the object at the end of the edge, so its fields are fetched only once.
+			if !nodeAdded {
+				authQueries = addSelectionSetFrom(q, f, auth)
+				nodeAdded = true
+			}
+			continue
+		}
+		if !facetAdded[f.Name()] {
+			facets = append(facets, &pb.FacetParam{
+				Key:   f.Name(),
+				Alias: f.GetObjectName() + "." + f.Name(),
+			})
+			facetAdded[f.Name()] = true
+		}
+	}
+
+	if len(facets) > 0 {
+		q.Facets = &pb.FacetParams{Param: facets}
+	}
+	// The uid makes sure that the edges are part of the result even if the node has none of the
+	// fields requested.
+	if n := len(q.Children); n == 0 || q.Children[n-1].Alias != "dgraph.uid" {
+		q.Children = append(q.Children, &gql.GraphQuery{
+			Attr:  "uid",
+			Alias: "dgraph.uid",
+		})
+	}
+	return authQueries
+}
+
 // dqlHasAuthRules is similar to `hasAuthRules`, except it is for DQL queries.
 // If the predicate Attribute of children is not of the type `Type.Field` then
 // the corresponding child is ignored during calculation. for eg: predicates like
@@ -1888,6 +1941,11 @@ func addAuthQueriesOnSelectionSet(
 func addOrder(q *gql.GraphQuery, field schema.Field) {
 	orderArg := field.ArgValue("order")
 	order, ok := orderArg.(map[string]interface{})
+	// Edge fields are ordered by the fields of the nodes of the edges.
+	typ := field.Type()
+	if typ.IsEdge() {
+		typ = field.ConstructedFor()
+	}
 	for ok {
 		ascArg := order["asc"]
 		descArg := order["desc"]
@@ -1895,10 +1953,10 @@ func addOrder(q *gql.GraphQuery, field schema.Field) {
 
 		if asc, ok := ascArg.(string); ok {
 			q.Order = append(q.Order,
-				&pb.Order{Attr: field.Type().DgraphPredicate(asc)})
+				&pb.Order{Attr: typ.DgraphPredicate(asc)})
 		} else if desc, ok := descArg.(string); ok {
 			q.Order = append(q.Order,
-				&pb.Order{Attr: field.Type().DgraphPredicate(desc), Desc: true})
+				&pb.Order{Attr: typ.DgraphPredicate(desc), Desc: true})
 		}
 
 		order, ok = thenArg.(map[string]interface{})
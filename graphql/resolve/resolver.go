@@ -670,7 +670,8 @@ func addResult(resp *schema.Response, res *Resolved) {
 	resp.MergeExtensions(res.Extensions)
 }
 
-// a httpResolver can resolve a single GraphQL field from an HTTP endpoint
+// a httpResolver can resolve a single GraphQL field from an HTTP endpoint, or from a gRPC
+// service for the fields with @custom(grpc: {...})
 type httpResolver struct {
 	*http.Client
 }
@@ -710,8 +711,14 @@ func (hr *httpResolver) rewriteAndExecute(ctx context.Context, field schema.Fiel
 		hrc.Template = schema.GetBodyForLambda(ctx, field, nil, hrc.Template)
 	}
 
-	fieldData, errs, hardErrs := hrc.MakeAndDecodeHTTPRequest(hr.Client, hrc.URL, hrc.Template,
-		field)
+	var fieldData interface{}
+	var errs, hardErrs x.GqlErrorList
+	if hrc.GRPC != nil {
+		fieldData, errs, hardErrs = hrc.MakeAndDecodeGRPCRequest(ctx, field)
+	} else {
+		fieldData, errs, hardErrs = hrc.MakeAndDecodeHTTPRequest(ctx, hr.Client, hrc.URL,
+			hrc.Template, field)
+	}
 	if hardErrs != nil {
 		// Not using EmptyResult() here as we don't want to wrap the errors returned from remote
 		// endpoints
@@ -0,0 +1,388 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package schema
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"sync"
+
+	"github.com/dgraph-io/dgraph/graphql/authorization"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	rpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// GRPCConfig contains the config needed to resolve a field by calling a method of a gRPC service.
+type GRPCConfig struct {
+	// Target is the address of the server, as accepted by grpc.Dial.
+	Target string
+	// Service is the fully qualified name of the service, e.g. package.Service.
+	Service string
+	Method  string
+	// Descriptor is the base64 encoded FileDescriptorSet describing the service, as written by
+	// protoc --include_imports --descriptor_set_out. If empty, the service is described by the
+	// server using gRPC server reflection.
+	Descriptor string
+	// Secure tells whether the connection uses TLS.
+	Secure bool
+}
+
+var (
+	grpcConns   = &grpcConnCache{conns: make(map[uint64]map[string]*grpcConn)}
+	grpcMethods = &grpcMethodCache{
+		methods: make(map[uint64]map[string]protoreflect.MethodDescriptor)}
+)
+
+// CloseGRPCConns closes the connections to the servers called by the @custom(grpc: {...}) fields
+// of the namespace, and forgets the descriptors of their methods. It is called when the GraphQL
+// schema of the namespace changes, as the servers it calls change with it. The calls in flight
+// complete before their connection is closed.
+func CloseGRPCConns(ns uint64) {
+	grpcConns.evict(ns)
+	grpcMethods.evict(ns)
+}
+
+// parseGRPCMethod splits a method given as package.Service/Method into the service and the method.
+func parseGRPCMethod(raw string) (string, string, error) {
+	parts := strings.Split(strings.TrimPrefix(raw, "/"), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", errors.Errorf("should be of the form 'package.Service/Method', found: `%s`",
+			raw)
+	}
+	return parts[0], parts[1], nil
+}
+
+// methodFromDescriptor finds the given method in the base64 encoded FileDescriptorSet.
+func methodFromDescriptor(descriptor, service, method string) (protoreflect.MethodDescriptor,
+	error) {
+	b, err := base64.StdEncoding.DecodeString(descriptor)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while decoding the descriptor")
+	}
+	var set descriptorpb.FileDescriptorSet
+	if err := proto.Unmarshal(b, &set); err != nil {
+		return nil, errors.Wrapf(err, "while unmarshalling the descriptor")
+	}
+	return findGRPCMethod(set.File, service, method)
+}
+
+// findGRPCMethod finds the given method in the given files. The files imported by them which
+// aren't given, like the well-known types, are looked up in the files linked into Dgraph.
+func findGRPCMethod(files []*descriptorpb.FileDescriptorProto, service,
+	method string) (protoreflect.MethodDescriptor, error) {
+	given := make(map[string]bool)
+	for _, fd := range files {
+		given[fd.GetName()] = true
+	}
+	for i := 0; i < len(files); i++ {
+		for _, dep := range files[i].GetDependency() {
+			if given[dep] {
+				continue
+			}
+			fd, err := protoregistry.GlobalFiles.FindFileByPath(dep)
+			if err != nil {
+				return nil, errors.Errorf("the descriptor of %s imported by %s is missing", dep,
+					files[i].GetName())
+			}
+			given[dep] = true
+			files = append(files, protodesc.ToFileDescriptorProto(fd))
+		}
+	}
+
+	reg, err := protodesc.NewFiles(&descriptorpb.FileDescriptorSet{File: files})
+	if err != nil {
+		return nil, errors.Wrapf(err, "while building the descriptors")
+	}
+	d, err := reg.FindDescriptorByName(protoreflect.FullName(service))
+	if err != nil {
+		return nil, errors.Errorf("service %s not found", service)
+	}
+	sd, ok := d.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, errors.Errorf("%s is not a service", service)
+	}
+	md := sd.Methods().ByName(protoreflect.Name(method))
+	if md == nil {
+		return nil, errors.Errorf("method %s not found in service %s", method, service)
+	}
+	if md.IsStreamingClient() || md.IsStreamingServer() {
+		return nil, errors.Errorf("method %s of service %s is a streaming method", method,
+			service)
+	}
+	return md, nil
+}
+
+// MakeAndDecodeGRPCRequest calls the gRPC method of the field with the request message given by
+// the template of the config, and returns the response message decoded from its JSON form. The
+// deadline of the context is propagated to the server, and the forwarded headers are sent as
+// metadata along with the auth JWT of the request, if any.
+// Like for MakeAndDecodeHTTPRequest, the errors returned are hard errors, and the soft errors are
+// always nil as gRPC has no notion of a partial response.
+func (fconf *FieldHTTPConfig) MakeAndDecodeGRPCRequest(ctx context.Context,
+	field Field) (interface{}, x.GqlErrorList, x.GqlErrorList) {
+	gconf := fconf.GRPC
+	ns, _ := x.ExtractNamespace(ctx)
+	conn, err := grpcConns.get(ns, gconf.Target, gconf.Secure)
+	if err != nil {
+		return nil, nil, x.GqlErrorList{externalRequestError(err, field)}
+	}
+	defer grpcConns.release(conn)
+	md, err := grpcMethods.get(ctx, ns, conn.ClientConn, gconf)
+	if err != nil {
+		return nil, nil, x.GqlErrorList{externalRequestError(err, field)}
+	}
+
+	b, err := json.Marshal(fconf.Template)
+	if err != nil {
+		return nil, nil, x.GqlErrorList{jsonMarshalError(err, field, fconf.Template)}
+	}
+	in := dynamicpb.NewMessage(md.Input())
+	if err := protojson.Unmarshal(b, in); err != nil {
+		return nil, nil, x.GqlErrorList{externalRequestError(
+			errors.Wrapf(err, "while building the request message"), field)}
+	}
+
+	if fconf.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, fconf.Timeout)
+		defer cancel()
+	}
+	pairs := make([]string, 0, 2*len(fconf.ForwardHeaders)+2)
+	for key, vals := range fconf.ForwardHeaders {
+		for _, val := range vals {
+			pairs = append(pairs, strings.ToLower(key), val)
+		}
+	}
+	if authHeader := field.GetAuthMeta().GetHeader(); authHeader != "" {
+		if jwt := authorization.GetJwtToken(ctx); jwt != "" {
+			pairs = append(pairs, strings.ToLower(authHeader), jwt)
+		}
+	}
+	ctx = metadata.NewOutgoingContext(ctx, metadata.Pairs(pairs...))
+
+	out := dynamicpb.NewMessage(md.Output())
+	fullMethod := "/" + gconf.Service + "/" + gconf.Method
+	if err := conn.Invoke(ctx, fullMethod, in, out); err != nil {
+		return nil, nil, x.GqlErrorList{externalRequestError(err, field)}
+	}
+
+	b, err = protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(out)
+	if err != nil {
+		return nil, nil, x.GqlErrorList{externalRequestError(
+			errors.Wrapf(err, "while reading the response message"), field)}
+	}
+	var response interface{}
+	if err := Unmarshal(b, &response); err != nil {
+		return nil, nil, x.GqlErrorList{jsonUnmarshalError(err, field)}
+	}
+	return response, nil, nil
+}
+
+// grpcConn is a connection of the cache, along with the number of calls using it.
+type grpcConn struct {
+	*grpc.ClientConn
+	users   int
+	evicted bool
+}
+
+// grpcConnCache holds a connection for each server called by the @custom fields of each
+// namespace. The connections are shared by all the fields of the namespace calling the same
+// server, until the schema of the namespace changes, see CloseGRPCConns.
+type grpcConnCache struct {
+	sync.Mutex
+	conns map[uint64]map[string]*grpcConn
+}
+
+// get returns the connection to the target, which must be released once the call is done.
+func (c *grpcConnCache) get(ns uint64, target string, secure bool) (*grpcConn, error) {
+	key := target
+	if secure {
+		key = "tls://" + target
+	}
+	c.Lock()
+	defer c.Unlock()
+	if conn, ok := c.conns[ns][key]; ok {
+		conn.users++
+		return conn, nil
+	}
+
+	creds := grpc.WithInsecure()
+	if secure {
+		creds = grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{}))
+	}
+	// Dial doesn't block, the connection is established by the first call.
+	cc, err := grpc.Dial(target, creds)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while dialing %s", target)
+	}
+	if c.conns[ns] == nil {
+		c.conns[ns] = make(map[string]*grpcConn)
+	}
+	conn := &grpcConn{ClientConn: cc, users: 1}
+	c.conns[ns][key] = conn
+	return conn, nil
+}
+
+func (c *grpcConnCache) release(conn *grpcConn) {
+	c.Lock()
+	defer c.Unlock()
+	conn.users--
+	if conn.evicted && conn.users == 0 {
+		closeGRPCConn(conn)
+	}
+}
+
+// evict removes the connections of the namespace from the cache, and closes those which aren't
+// used. The others are closed once released.
+func (c *grpcConnCache) evict(ns uint64) {
+	c.Lock()
+	defer c.Unlock()
+	for _, conn := range c.conns[ns] {
+		conn.evicted = true
+		if conn.users == 0 {
+			closeGRPCConn(conn)
+		}
+	}
+	delete(c.conns, ns)
+}
+
+func closeGRPCConn(conn *grpcConn) {
+	if err := conn.Close(); err != nil {
+		glog.Warningf("Error while closing the connection to %s: %v", conn.Target(), err)
+	}
+}
+
+// grpcMethodCache caches the descriptors of the methods called by the @custom fields, so that the
+// descriptor of the schema is parsed, or the server is asked for it, only once.
+type grpcMethodCache struct {
+	sync.RWMutex
+	methods map[uint64]map[string]protoreflect.MethodDescriptor
+}
+
+func (c *grpcMethodCache) get(ctx context.Context, ns uint64, conn *grpc.ClientConn,
+	gconf *GRPCConfig) (protoreflect.MethodDescriptor, error) {
+	key := gconf.Target + "/" + gconf.Service + "/" + gconf.Method + "/" + gconf.Descriptor
+	c.RLock()
+	md, ok := c.methods[ns][key]
+	c.RUnlock()
+	if ok {
+		return md, nil
+	}
+
+	var err error
+	if gconf.Descriptor != "" {
+		md, err = methodFromDescriptor(gconf.Descriptor, gconf.Service, gconf.Method)
+	} else {
+		md, err = methodFromReflection(ctx, conn, gconf.Service, gconf.Method)
+	}
+	if err != nil {
+		return nil, err
+	}
+	c.Lock()
+	if c.methods[ns] == nil {
+		c.methods[ns] = make(map[string]protoreflect.MethodDescriptor)
+	}
+	c.methods[ns][key] = md
+	c.Unlock()
+	return md, nil
+}
+
+func (c *grpcMethodCache) evict(ns uint64) {
+	c.Lock()
+	defer c.Unlock()
+	delete(c.methods, ns)
+}
+
+// methodFromReflection asks the server for the descriptors of the service and of the files it
+// imports, using gRPC server reflection.
+func methodFromReflection(ctx context.Context, conn *grpc.ClientConn, service,
+	method string) (protoreflect.MethodDescriptor, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := rpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while calling the reflection service")
+	}
+	defer stream.CloseSend()
+
+	var files []*descriptorpb.FileDescriptorProto
+	seen := make(map[string]bool)
+	request := func(req *rpb.ServerReflectionRequest) error {
+		if err := stream.Send(req); err != nil {
+			return err
+		}
+		resp, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		if errResp := resp.GetErrorResponse(); errResp != nil {
+			return errors.New(errResp.GetErrorMessage())
+		}
+		// The server may send the files imported by the requested one along with it.
+		for _, b := range resp.GetFileDescriptorResponse().GetFileDescriptorProto() {
+			fd := &descriptorpb.FileDescriptorProto{}
+			if err := proto.Unmarshal(b, fd); err != nil {
+				return err
+			}
+			if !seen[fd.GetName()] {
+				seen[fd.GetName()] = true
+				files = append(files, fd)
+			}
+		}
+		return nil
+	}
+
+	if err := request(&rpb.ServerReflectionRequest{
+		MessageRequest: &rpb.ServerReflectionRequest_FileContainingSymbol{
+			FileContainingSymbol: service,
+		},
+	}); err != nil {
+		return nil, errors.Wrapf(err, "while asking the server for service %s", service)
+	}
+	// Ask for the imported files the server didn't send, unless they are linked into Dgraph.
+	for i := 0; i < len(files); i++ {
+		for _, dep := range files[i].GetDependency() {
+			if seen[dep] {
+				continue
+			}
+			if _, err := protoregistry.GlobalFiles.FindFileByPath(dep); err == nil {
+				continue
+			}
+			if err := request(&rpb.ServerReflectionRequest{
+				MessageRequest: &rpb.ServerReflectionRequest_FileByFilename{FileByFilename: dep},
+			}); err != nil {
+				return nil, errors.Wrapf(err, "while asking the server for file %s", dep)
+			}
+		}
+	}
+	return findGRPCMethod(files, service, method)
+}
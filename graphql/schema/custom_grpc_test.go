@@ -0,0 +1,82 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package schema
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+func TestGRPCConnCache(t *testing.T) {
+	lis, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	srv := grpc.NewServer()
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+	reflection.Register(srv)
+	go srv.Serve(lis)
+	defer srv.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	gconf := &GRPCConfig{Target: lis.Addr().String(), Service: "grpc.health.v1.Health",
+		Method: "Check"}
+	const ns = 5
+
+	conn, err := grpcConns.get(ns, gconf.Target, false)
+	require.NoError(t, err)
+	md, err := grpcMethods.get(ctx, ns, conn.ClientConn, gconf)
+	require.NoError(t, err)
+	out := dynamicpb.NewMessage(md.Output())
+	require.NoError(t, conn.Invoke(ctx, "/grpc.health.v1.Health/Check",
+		dynamicpb.NewMessage(md.Input()), out))
+	require.Equal(t, protoreflect.EnumNumber(healthpb.HealthCheckResponse_SERVING),
+		out.Get(md.Output().Fields().ByName("status")).Enum())
+
+	// The connection is shared by the calls of the namespace.
+	same, err := grpcConns.get(ns, gconf.Target, false)
+	require.NoError(t, err)
+	require.Same(t, conn, same)
+	grpcConns.release(same)
+
+	// The connection in use is only closed once released.
+	CloseGRPCConns(ns)
+	require.NotEqual(t, connectivity.Shutdown, conn.GetState())
+	grpcConns.release(conn)
+	require.Equal(t, connectivity.Shutdown, conn.GetState())
+	grpcMethods.RLock()
+	require.Empty(t, grpcMethods.methods[ns])
+	grpcMethods.RUnlock()
+
+	// A new connection is dialed after the schema update.
+	fresh, err := grpcConns.get(ns, gconf.Target, false)
+	require.NoError(t, err)
+	require.NotSame(t, conn, fresh)
+	grpcConns.release(fresh)
+	CloseGRPCConns(ns)
+	require.Equal(t, connectivity.Shutdown, fresh.GetState())
+}
@@ -19,17 +19,24 @@ package schema
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/dgraph-io/dgraph/graphql/authorization"
 	"github.com/dgraph-io/dgraph/worker"
 
 	"github.com/dgraph-io/dgraph/x"
+	"github.com/pkg/errors"
 )
 
 var (
@@ -47,6 +54,11 @@ type graphqlResp struct {
 // If no client is provided, it uses the defaultHttpClient which has a timeout of 1 minute.
 func MakeHttpRequest(client *http.Client, method, url string, header http.Header,
 	body []byte) (*http.Response, error) {
+	return makeHttpRequest(context.Background(), client, method, url, header, body)
+}
+
+func makeHttpRequest(ctx context.Context, client *http.Client, method, url string,
+	header http.Header, body []byte) (*http.Response, error) {
 	var reqBody io.Reader
 	if len(body) == 0 {
 		reqBody = http.NoBody
@@ -54,7 +66,7 @@ func MakeHttpRequest(client *http.Client, method, url string, header http.Header
 		reqBody = bytes.NewReader(body)
 	}
 
-	req, err := http.NewRequest(method, url, reqBody)
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
 	if err != nil {
 		return nil, err
 	}
@@ -74,8 +86,8 @@ func MakeHttpRequest(client *http.Client, method, url string, header http.Header
 // For GraphQL requests, the GraphQL errors returned from the remote endpoint are considered soft
 // errors. Any other kind of error is a hard error.
 // For REST requests, any error is a hard error, including those returned from the remote endpoint.
-func (fconf *FieldHTTPConfig) MakeAndDecodeHTTPRequest(client *http.Client, url string,
-	body interface{}, field Field) (interface{}, x.GqlErrorList, x.GqlErrorList) {
+func (fconf *FieldHTTPConfig) MakeAndDecodeHTTPRequest(ctx context.Context, client *http.Client,
+	url string, body interface{}, field Field) (interface{}, x.GqlErrorList, x.GqlErrorList) {
 	var b []byte
 	var err error
 	// need this check to make sure that we don't send body as []byte(`null`)
@@ -87,13 +99,8 @@ func (fconf *FieldHTTPConfig) MakeAndDecodeHTTPRequest(client *http.Client, url
 	}
 
 	// Make the request to external HTTP endpoint using the URL and body
-	resp, err := MakeHttpRequest(client, fconf.Method, url, fconf.ForwardHeaders, b)
-	if err != nil {
-		return nil, nil, x.GqlErrorList{externalRequestError(err, field)}
-	}
-
-	defer resp.Body.Close()
-	b, err = ioutil.ReadAll(resp.Body)
+	mutation := field.GetObjectName() == "Mutation"
+	statusCode, b, err := fconf.makeRequest(ctx, client, url, b, mutation)
 	if err != nil {
 		return nil, nil, x.GqlErrorList{externalRequestError(err, field)}
 	}
@@ -117,7 +124,7 @@ func (fconf *FieldHTTPConfig) MakeAndDecodeHTTPRequest(client *http.Client, url
 		}
 	} else {
 		// this was a REST request
-		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		if statusCode >= 200 && statusCode < 300 {
 			// if this was a successful request, lets try to unmarshal the response
 			if err = Unmarshal(b, &response); err != nil {
 				return nil, nil, x.GqlErrorList{jsonUnmarshalError(err, field)}
@@ -127,7 +134,7 @@ func (fconf *FieldHTTPConfig) MakeAndDecodeHTTPRequest(client *http.Client, url
 			// if we get unsuccessful response from the REST api, lets try to see if
 			// it sent any errors in the form expected for GraphQL errors.
 			if err = Unmarshal(b, &graphqlResp); err != nil {
-				err = fmt.Errorf("unexpected error with: %v", resp.StatusCode)
+				err = fmt.Errorf("unexpected error with: %v", statusCode)
 				return nil, nil, x.GqlErrorList{externalRequestError(err, field)}
 			} else {
 				return nil, nil, graphqlResp.Errors
@@ -138,6 +145,92 @@ func (fconf *FieldHTTPConfig) MakeAndDecodeHTTPRequest(client *http.Client, url
 	return response, softErrs, nil
 }
 
+// makeRequest sends the request of a @custom field and returns the status and the body of the
+// response. The response is served from the cache if the field has a cacheTTL, and the request is
+// retried with a backoff on the transport errors and on the 429 and 5xx statuses, as many times as
+// the retries of the field allow, up to maxHttpRetries. The request of a mutation is only retried
+// if its method is idempotent, as a failed request may still have been applied. The requests to a
+// host which keeps failing are failed fast by the circuit breaker of the host.
+func (fconf *FieldHTTPConfig) makeRequest(ctx context.Context, client *http.Client, url string,
+	body []byte, mutation bool) (int, []byte, error) {
+	var key string
+	if fconf.CacheTTL > 0 {
+		key = responseCacheKey(fconf.Method, url, fconf.ForwardHeaders, body)
+		if resp, ok := responses.get(key); ok {
+			return resp.statusCode, resp.body, nil
+		}
+	}
+	if fconf.Timeout > 0 {
+		if client == nil {
+			client = defaultHttpClient
+		}
+		c := *client
+		c.Timeout = fconf.Timeout
+		client = &c
+	}
+
+	retries := fconf.Retries
+	if retries > maxHttpRetries {
+		retries = maxHttpRetries
+	}
+	if mutation && !idempotentMethods[fconf.Method] {
+		retries = 0
+	}
+	cb := breakers.get(url)
+	for attempt := 0; ; attempt++ {
+		if err := cb.allow(); err != nil {
+			return 0, nil, err
+		}
+		statusCode, b, err := doHttpRequest(ctx, client, fconf.Method, url, fconf.ForwardHeaders,
+			body)
+		retryable := err != nil || statusCode == http.StatusTooManyRequests ||
+			statusCode >= http.StatusInternalServerError
+		cb.done(!retryable)
+		if !retryable || attempt >= retries || ctx.Err() != nil {
+			if err == nil && key != "" && statusCode >= 200 && statusCode < 300 {
+				responses.set(key, &cachedResponse{
+					statusCode: statusCode,
+					body:       b,
+					expiresAt:  time.Now().Add(fconf.CacheTTL),
+				})
+			}
+			return statusCode, b, err
+		}
+		select {
+		case <-time.After(retryBackoff(attempt)):
+		case <-ctx.Done():
+			return 0, nil, ctx.Err()
+		}
+	}
+}
+
+// retryBackoff returns how long to wait before retrying a request after the given attempt, which
+// doubles with each attempt up to maxRetryBackoff.
+func retryBackoff(attempt int) time.Duration {
+	backoff := minRetryBackoff
+	for i := 0; i < attempt && backoff < maxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxRetryBackoff {
+		backoff = maxRetryBackoff
+	}
+	return backoff
+}
+
+func doHttpRequest(ctx context.Context, client *http.Client, method, url string,
+	header http.Header, body []byte) (int, []byte, error) {
+	resp, err := makeHttpRequest(ctx, client, method, url, header, body)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, err
+	}
+	return resp.StatusCode, b, nil
+}
+
 func keyNotFoundError(f Field, key string) *x.GqlError {
 	return f.GqlErrorf(nil, "Evaluation of custom field failed because key: %s "+
 		"could not be found in the JSON response returned by external request "+
@@ -183,3 +276,162 @@ func GetBodyForLambda(ctx context.Context, field Field, parents,
 	}
 	return body
 }
+
+const (
+	// maxCachedResponses bounds the number of responses of the @custom fields held in the cache.
+	maxCachedResponses = 10000
+	// maxHttpRetries bounds the retries of the requests of the @custom fields, whatever the
+	// retries of the field are.
+	maxHttpRetries = 10
+	// minRetryBackoff and maxRetryBackoff bound the wait before retrying a request.
+	minRetryBackoff = 100 * time.Millisecond
+	maxRetryBackoff = 10 * time.Second
+)
+
+// idempotentMethods are the HTTP methods whose requests can be retried for a mutation, as sending
+// them again has the same effect as sending them once.
+var idempotentMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodOptions: true,
+	http.MethodPut:     true,
+	http.MethodDelete:  true,
+}
+
+var (
+	responses = &responseCache{entries: make(map[string]*cachedResponse)}
+	breakers  = &circuitBreakers{hosts: make(map[string]*circuitBreaker)}
+)
+
+type cachedResponse struct {
+	statusCode int
+	body       []byte
+	expiresAt  time.Time
+}
+
+// responseCache caches the successful responses of the @custom fields having a cacheTTL.
+type responseCache struct {
+	sync.RWMutex
+	entries map[string]*cachedResponse
+}
+
+func responseCacheKey(method, url string, header http.Header, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method + " " + url + "\n"))
+	keys := make([]string, 0, len(header))
+	for k := range header {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		h.Write([]byte(k + ": " + strings.Join(header[k], ",") + "\n"))
+	}
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (c *responseCache) get(key string) (*cachedResponse, bool) {
+	c.RLock()
+	defer c.RUnlock()
+	resp, ok := c.entries[key]
+	if !ok || time.Now().After(resp.expiresAt) {
+		return nil, false
+	}
+	return resp, true
+}
+
+func (c *responseCache) set(key string, resp *cachedResponse) {
+	c.Lock()
+	defer c.Unlock()
+	if len(c.entries) >= maxCachedResponses {
+		now := time.Now()
+		for k, e := range c.entries {
+			if now.After(e.expiresAt) {
+				delete(c.entries, k)
+			}
+		}
+		if len(c.entries) >= maxCachedResponses {
+			// The cache is full of live responses, this one is just not cached.
+			return
+		}
+	}
+	c.entries[key] = resp
+}
+
+// circuitBreakers holds a circuit breaker for each host called by the @custom fields.
+type circuitBreakers struct {
+	sync.Mutex
+	hosts map[string]*circuitBreaker
+}
+
+// get returns the circuit breaker of the host of the given url, or nil if circuit breaking is
+// disabled.
+func (cbs *circuitBreakers) get(rawURL string) *circuitBreaker {
+	if x.Config.GraphQL.BreakerFailures == 0 {
+		return nil
+	}
+	host := rawURL
+	if u, err := url.Parse(rawURL); err == nil {
+		host = u.Host
+	}
+	cbs.Lock()
+	defer cbs.Unlock()
+	cb, ok := cbs.hosts[host]
+	if !ok {
+		cb = &circuitBreaker{
+			host:      host,
+			threshold: int(x.Config.GraphQL.BreakerFailures),
+			cooldown:  x.Config.GraphQL.BreakerCooldown,
+		}
+		cbs.hosts[host] = cb
+	}
+	return cb
+}
+
+// circuitBreaker opens once threshold consecutive requests to its host have failed. While it is
+// open, the requests fail fast. After the cooldown, a single request is let through to probe the
+// host, which closes the circuit if it succeeds, or opens it again otherwise.
+type circuitBreaker struct {
+	sync.Mutex
+	host      string
+	threshold int
+	cooldown  time.Duration
+
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+func (cb *circuitBreaker) allow() error {
+	if cb == nil {
+		return nil
+	}
+	cb.Lock()
+	defer cb.Unlock()
+	if cb.failures < cb.threshold {
+		return nil
+	}
+	if cb.probing || time.Since(cb.openedAt) < cb.cooldown {
+		return errors.Errorf("circuit breaker is open for host %s after %d failed requests",
+			cb.host, cb.failures)
+	}
+	cb.probing = true
+	return nil
+}
+
+func (cb *circuitBreaker) done(success bool) {
+	if cb == nil {
+		return
+	}
+	cb.Lock()
+	defer cb.Unlock()
+	cb.probing = false
+	if success {
+		cb.failures = 0
+		return
+	}
+	cb.failures++
+	if cb.failures >= cb.threshold {
+		cb.openedAt = time.Now()
+	}
+}
@@ -0,0 +1,123 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package schema
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	cb := &circuitBreaker{host: "example.com", threshold: 2, cooldown: 50 * time.Millisecond}
+	require.NoError(t, cb.allow())
+	cb.done(false)
+	require.NoError(t, cb.allow())
+	cb.done(false)
+	// The circuit opens after threshold consecutive failures.
+	require.Error(t, cb.allow())
+
+	// After the cooldown, a single request probes the host.
+	time.Sleep(60 * time.Millisecond)
+	require.NoError(t, cb.allow())
+	require.Error(t, cb.allow())
+	cb.done(false)
+	require.Error(t, cb.allow())
+
+	// A successful probe closes the circuit.
+	time.Sleep(60 * time.Millisecond)
+	require.NoError(t, cb.allow())
+	cb.done(true)
+	require.NoError(t, cb.allow())
+	require.NoError(t, cb.allow())
+
+	// A nil circuit breaker, when circuit breaking is disabled, lets everything through.
+	var disabled *circuitBreaker
+	require.NoError(t, disabled.allow())
+	disabled.done(false)
+}
+
+func TestResponseCache(t *testing.T) {
+	header := http.Header{"X-A": {"1"}, "X-B": {"2"}}
+	key := responseCacheKey(http.MethodGet, "http://example.com/a", header, nil)
+	require.Equal(t, key, responseCacheKey(http.MethodGet, "http://example.com/a",
+		http.Header{"X-B": {"2"}, "X-A": {"1"}}, nil))
+	require.NotEqual(t, key, responseCacheKey(http.MethodPost, "http://example.com/a", header,
+		nil))
+	require.NotEqual(t, key, responseCacheKey(http.MethodGet, "http://example.com/b", header,
+		nil))
+	require.NotEqual(t, key, responseCacheKey(http.MethodGet, "http://example.com/a",
+		http.Header{"X-A": {"2"}, "X-B": {"2"}}, nil))
+	require.NotEqual(t, key, responseCacheKey(http.MethodGet, "http://example.com/a", header,
+		[]byte("{}")))
+
+	c := &responseCache{entries: make(map[string]*cachedResponse)}
+	c.set(key, &cachedResponse{statusCode: 200, body: []byte("a"),
+		expiresAt: time.Now().Add(time.Minute)})
+	resp, ok := c.get(key)
+	require.True(t, ok)
+	require.Equal(t, "a", string(resp.body))
+
+	// An expired response isn't served.
+	c.set(key, &cachedResponse{statusCode: 200, expiresAt: time.Now().Add(-time.Second)})
+	_, ok = c.get(key)
+	require.False(t, ok)
+}
+
+func TestMakeRequestRetries(t *testing.T) {
+	var requests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	request := func(ctx context.Context, method string, retries int, mutation bool) int32 {
+		atomic.StoreInt32(&requests, 0)
+		fconf := &FieldHTTPConfig{Method: method, Retries: retries}
+		status, _, _ := fconf.makeRequest(ctx, nil, ts.URL, nil, mutation)
+		if ctx.Err() == nil {
+			require.Equal(t, http.StatusServiceUnavailable, status)
+		}
+		return atomic.LoadInt32(&requests)
+	}
+
+	ctx := context.Background()
+	require.Equal(t, int32(3), request(ctx, http.MethodPost, 2, false))
+	// The request of a mutation is only retried if its method is idempotent.
+	require.Equal(t, int32(1), request(ctx, http.MethodPost, 2, true))
+	require.Equal(t, int32(3), request(ctx, http.MethodPut, 2, true))
+
+	// The retries stop once the context is done.
+	ctx, cancel := context.WithTimeout(ctx, 150*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	require.Less(t, request(ctx, http.MethodGet, maxHttpRetries, false), int32(maxHttpRetries))
+	require.Less(t, int64(time.Since(start)), int64(time.Second))
+}
+
+func TestRetryBackoff(t *testing.T) {
+	require.Equal(t, minRetryBackoff, retryBackoff(0))
+	require.Equal(t, 4*minRetryBackoff, retryBackoff(2))
+	require.Equal(t, maxRetryBackoff, retryBackoff(20))
+	require.Equal(t, maxRetryBackoff, retryBackoff(1000))
+}
@@ -0,0 +1,194 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package schema
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dgraph-io/gqlparser/v2/ast"
+	"github.com/dgraph-io/gqlparser/v2/gqlerror"
+	"github.com/dgraph-io/gqlparser/v2/parser"
+	"github.com/pkg/errors"
+)
+
+// DocumentHeader starts the comment marking the beginning of a named document in a GraphQL schema
+// uploaded as several documents, e.g.
+//
+//	# Dgraph.Document "orders"
+//
+// The documents are merged into a single schema, which is stored and served like a schema
+// uploaded as one string. The comments only allow to split it back into its documents.
+const DocumentHeader = "Dgraph.Document"
+
+// Document is a named part of a GraphQL schema.
+type Document struct {
+	Name   string `json:"name"`
+	Schema string `json:"schema"`
+}
+
+// SplitDocuments splits a schema into the documents it was merged from. The part of the schema
+// before the first document, like a schema uploaded as one string, is returned as a document with
+// an empty name, unless it is blank. That document is kept first when the documents are merged.
+func SplitDocuments(sch string) []*Document {
+	var docs []*Document
+	cur := &Document{}
+	var buf strings.Builder
+	flush := func() {
+		cur.Schema = strings.TrimSpace(buf.String())
+		if cur.Name != "" || cur.Schema != "" {
+			docs = append(docs, cur)
+		}
+		buf.Reset()
+	}
+
+	for _, line := range strings.Split(sch, "\n") {
+		if name, ok := documentName(line); ok {
+			flush()
+			cur = &Document{Name: name}
+			continue
+		}
+		buf.WriteString(line)
+		buf.WriteByte('\n')
+	}
+	flush()
+	return docs
+}
+
+func documentName(line string) (string, bool) {
+	text := strings.TrimSpace(line)
+	if !strings.HasPrefix(text, "#") {
+		return "", false
+	}
+	header := strings.TrimSpace(text[1:])
+	if !strings.HasPrefix(header, DocumentHeader) {
+		return "", false
+	}
+	name, err := strconv.Unquote(strings.TrimSpace(strings.TrimPrefix(header, DocumentHeader)))
+	if err != nil || name == "" {
+		return "", false
+	}
+	return name, true
+}
+
+// PatchDocuments returns the documents with the patch applied. A document of the patch replaces
+// the document with the same name, or is added if there's none. A document of the patch with an
+// empty schema removes the document with its name.
+func PatchDocuments(docs, patch []*Document) []*Document {
+	byName := make(map[string]*Document)
+	for _, doc := range docs {
+		byName[doc.Name] = doc
+	}
+	for _, doc := range patch {
+		if strings.TrimSpace(doc.Schema) == "" {
+			delete(byName, doc.Name)
+			continue
+		}
+		byName[doc.Name] = doc
+	}
+
+	res := make([]*Document, 0, len(byName))
+	for _, doc := range byName {
+		res = append(res, doc)
+	}
+	return res
+}
+
+// MergeDocuments merges the documents into a single schema. The documents are merged in the order
+// of their names, so that the same documents always give the same schema. It returns an error if
+// the documents don't parse, or if they conflict: two documents can't define the same type, give
+// the Dgraph.Authorization, or define the same secret. The types can be extended across the
+// documents.
+func MergeDocuments(docs []*Document) (string, error) {
+	docs = append([]*Document{}, docs...)
+	sort.Slice(docs, func(i, j int) bool { return docs[i].Name < docs[j].Name })
+
+	var errs gqlerror.List
+	definedIn := make(map[string]string)
+	metaIn := make(map[string]string)
+	seen := make(map[string]bool)
+	var sb strings.Builder
+	for _, doc := range docs {
+		if seen[doc.Name] {
+			return "", errors.Errorf("the document %s is given more than once",
+				strconv.Quote(doc.Name))
+		}
+		seen[doc.Name] = true
+		for _, line := range strings.Split(doc.Schema, "\n") {
+			if _, ok := documentName(line); ok {
+				return "", errors.Errorf("the document %s can't start another document: %s",
+					strconv.Quote(doc.Name), strings.TrimSpace(line))
+			}
+		}
+
+		sd, gqlErr := parser.ParseSchema(&ast.Source{Input: doc.Schema})
+		if gqlErr != nil {
+			errs = append(errs, gqlerror.Errorf("in document %s: %s", strconv.Quote(doc.Name),
+				gqlErr.Message))
+			continue
+		}
+		for _, def := range sd.Definitions {
+			if other, ok := definedIn[def.Name]; ok {
+				errs = append(errs, gqlerror.Errorf("%s is defined in both documents %s and %s",
+					def.Name, strconv.Quote(other), strconv.Quote(doc.Name)))
+				continue
+			}
+			definedIn[def.Name] = doc.Name
+		}
+		for _, key := range documentMetaKeys(doc.Schema) {
+			if other, ok := metaIn[key]; ok {
+				errs = append(errs, gqlerror.Errorf("%s is given in both documents %s and %s",
+					key, strconv.Quote(other), strconv.Quote(doc.Name)))
+				continue
+			}
+			metaIn[key] = doc.Name
+		}
+
+		if doc.Name != "" {
+			sb.WriteString("# " + DocumentHeader + " " + strconv.Quote(doc.Name) + "\n")
+		}
+		sb.WriteString(strings.TrimSpace(doc.Schema))
+		sb.WriteString("\n\n")
+	}
+	if len(errs) > 0 {
+		return "", errs
+	}
+	return strings.TrimSpace(sb.String()), nil
+}
+
+// documentMetaKeys returns the Dgraph.Authorization and the Dgraph.Secret keys given by the meta
+// comments of a document.
+func documentMetaKeys(sch string) []string {
+	var keys []string
+	for _, line := range strings.Split(sch, "\n") {
+		text := strings.TrimSpace(line)
+		if !strings.HasPrefix(text, "#") {
+			continue
+		}
+		header := strings.TrimSpace(text[1:])
+		switch {
+		case strings.HasPrefix(header, "Dgraph.Authorization"):
+			keys = append(keys, "Dgraph.Authorization")
+		case strings.HasPrefix(header, "Dgraph.Secret"):
+			if parts := strings.Fields(header); len(parts) > 1 {
+				keys = append(keys, "Dgraph.Secret "+strings.Trim(parts[1], `"`))
+			}
+		}
+	}
+	return keys
+}
@@ -0,0 +1,119 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeAndSplitDocuments(t *testing.T) {
+	docs := []*Document{
+		{Name: "users", Schema: "type User {\n\tname: String! @id\n}"},
+		{Name: "orders", Schema: "type Order {\n\tid: ID!\n\tuser: User\n}"},
+	}
+	sch, err := MergeDocuments(docs)
+	require.NoError(t, err)
+	require.Equal(t, `# Dgraph.Document "orders"
+type Order {
+	id: ID!
+	user: User
+}
+
+# Dgraph.Document "users"
+type User {
+	name: String! @id
+}`, sch)
+
+	_, err = NewHandler(sch, false)
+	require.NoError(t, err)
+	require.Equal(t, []*Document{docs[1], docs[0]}, SplitDocuments(sch))
+}
+
+func TestSplitDocumentsWithoutHeader(t *testing.T) {
+	sch := "type User {\n\tname: String!\n}"
+	require.Equal(t, []*Document{{Schema: sch}}, SplitDocuments(sch))
+	require.Empty(t, SplitDocuments(""))
+}
+
+func TestPatchDocuments(t *testing.T) {
+	docs := SplitDocuments("type A {\n\tf: String\n}\n" +
+		"# Dgraph.Document \"b\"\ntype B {\n\tf: String\n}")
+	patched := PatchDocuments(docs, []*Document{
+		{Name: "b", Schema: ""},
+		{Name: "c", Schema: "type C {\n\tf: String\n}"},
+	})
+	sch, err := MergeDocuments(patched)
+	require.NoError(t, err)
+	require.Equal(t, "type A {\n\tf: String\n}\n\n"+
+		"# Dgraph.Document \"c\"\ntype C {\n\tf: String\n}", sch)
+}
+
+func TestMergeDocumentsConflicts(t *testing.T) {
+	tests := []struct {
+		name string
+		docs []*Document
+		err  string
+	}{
+		{
+			name: "same type",
+			docs: []*Document{
+				{Name: "a", Schema: "type User {\n\tname: String\n}"},
+				{Name: "b", Schema: "type User {\n\tage: Int\n}"},
+			},
+			err: `User is defined in both documents "a" and "b"`,
+		},
+		{
+			name: "same secret",
+			docs: []*Document{
+				{Name: "a", Schema: "type A {\n\tf: String\n}\n# Dgraph.Secret KEY \"a\""},
+				{Name: "b", Schema: "type B {\n\tf: String\n}\n# Dgraph.Secret KEY \"b\""},
+			},
+			err: `Dgraph.Secret KEY is given in both documents "a" and "b"`,
+		},
+		{
+			name: "same name",
+			docs: []*Document{
+				{Name: "a", Schema: "type A {\n\tf: String\n}"},
+				{Name: "a", Schema: "type B {\n\tf: String\n}"},
+			},
+			err: `the document "a" is given more than once`,
+		},
+		{
+			name: "syntax error",
+			docs: []*Document{{Name: "a", Schema: "type A {"}},
+			err:  `in document "a"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := MergeDocuments(test.docs)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), test.err)
+		})
+	}
+}
+
+func TestMergeDocumentsWithExtensions(t *testing.T) {
+	_, err := MergeDocuments([]*Document{
+		{Name: "a", Schema: "type A @key(fields: \"id\") {\n\tid: ID!\n}"},
+		{Name: "b", Schema: "extend type A @key(fields: \"id\") {\n\tid: ID! @external\n}"},
+	})
+	require.NoError(t, err)
+}
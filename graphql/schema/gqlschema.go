@@ -37,6 +37,7 @@ const (
 	dgraphDirective = "dgraph"
 	dgraphTypeArg   = "type"
 	dgraphPredArg   = "pred"
+	dgraphFacetsArg = "facets"
 
 	idDirective             = "id"
 	idDirectiveInterfaceArg = "interface"
@@ -85,6 +86,17 @@ const (
 	mode        = "mode"
 	BATCH       = "BATCH"
 	SINGLE      = "SINGLE"
+	httpRetries = "retries"
+	httpTimeout = "timeout"
+	httpCache   = "cacheTTL"
+
+	// custom directive grpc fields, the body, forwardHeaders, secretHeaders and timeout fields
+	// are shared with http
+	grpcArg        = "grpc"
+	grpcTarget     = "target"
+	grpcMethod     = "method"
+	grpcDescriptor = "descriptor"
+	grpcSecure     = "secure"
 
 	// geo type names and fields
 	Point        = "Point"
@@ -102,6 +114,10 @@ const (
 
 	Typename = "__typename"
 
+	// EdgeNode is the field of the edge types generated for the facets of a field, which holds
+	// the object at the end of the edge.
+	EdgeNode = "node"
+
 	// schemaExtras is everything that gets added to an input schema to make it
 	// GraphQL valid and for the completion algorithm to use to build in search
 	// capability into the schema.
@@ -193,6 +209,20 @@ input CustomHTTP {
 	secretHeaders: [String!]
 	introspectionHeaders: [String!]
 	skipIntrospection: Boolean
+	retries: Int
+	timeout: String
+	cacheTTL: String
+}
+
+input CustomGRPC {
+	target: String!
+	method: String!
+	descriptor: String
+	body: String
+	forwardHeaders: [String!]
+	secretHeaders: [String!]
+	secure: Boolean
+	timeout: String
 }
 
 type Point {
@@ -276,7 +306,7 @@ input GenerateMutationParams {
 	directiveDefs = `
 directive @hasInverse(field: String!) on FIELD_DEFINITION
 directive @search(by: [DgraphIndex!]) on FIELD_DEFINITION
-directive @dgraph(type: String, pred: String) on OBJECT | INTERFACE | FIELD_DEFINITION
+directive @dgraph(type: String, pred: String, facets: [String!]) on OBJECT | INTERFACE | FIELD_DEFINITION
 directive @id(interface: Boolean) on FIELD_DEFINITION
 directive @withSubscription on OBJECT | INTERFACE | FIELD_DEFINITION
 directive @secret(field: String!, pred: String) on OBJECT | INTERFACE
@@ -286,7 +316,7 @@ directive @auth(
 	add: AuthRule,
 	update: AuthRule,
 	delete: AuthRule) on OBJECT | INTERFACE
-directive @custom(http: CustomHTTP, dql: String) on FIELD_DEFINITION
+directive @custom(http: CustomHTTP, grpc: CustomGRPC, dql: String) on FIELD_DEFINITION
 directive @remote on OBJECT | INTERFACE | UNION | INPUT_OBJECT | ENUM
 directive @remoteResponse(name: String) on FIELD_DEFINITION
 directive @cascade(fields: [String]) on FIELD
@@ -307,7 +337,7 @@ directive @generate(
 	apolloSupportedDirectiveDefs = `
 directive @hasInverse(field: String!) on FIELD_DEFINITION
 directive @search(by: [DgraphIndex!]) on FIELD_DEFINITION
-directive @dgraph(type: String, pred: String) on OBJECT | INTERFACE | FIELD_DEFINITION
+directive @dgraph(type: String, pred: String, facets: [String!]) on OBJECT | INTERFACE | FIELD_DEFINITION
 directive @id(interface: Boolean) on FIELD_DEFINITION
 directive @withSubscription on OBJECT | INTERFACE | FIELD_DEFINITION
 directive @secret(field: String!, pred: String) on OBJECT | INTERFACE
@@ -534,6 +564,17 @@ var builtInFilters = map[string]string{
 	"multiPolygon": "PolygonGeoFilter",
 }
 
+// facetTypeToDgraph maps the GraphQL scalars that the facets of a predicate can be declared with,
+// in the @dgraph directive, to the Dgraph facet types.
+var facetTypeToDgraph = map[string]string{
+	"Boolean":  "bool",
+	"Int":      "int",
+	"Int64":    "int",
+	"Float":    "float",
+	"String":   "string",
+	"DateTime": "datetime",
+}
+
 // GraphQL in-built type -> Dgraph scalar
 var inbuiltTypeToDgraph = map[string]string{
 	"ID":           "uid",
@@ -1037,6 +1078,16 @@ func completeSchema(sch *ast.Schema, definitions []string, providesFieldsMap map
 		// should not be part of HasFilter or UpdatePayloadType etc.
 		addAggregateFields(sch, defn, apolloServiceQuery)
 	}
+
+	// Edge fields are added once all the types have been completed, as their mutation inputs
+	// need the reference types of the objects of the edges.
+	for _, key := range definitions {
+		defn := sch.Types[key]
+		if isQueryOrMutation(key) || (defn.Kind != ast.Interface && defn.Kind != ast.Object) {
+			continue
+		}
+		addEdgeFields(sch, defn, apolloServiceQuery)
+	}
 }
 
 func cleanupInput(sch *ast.Schema, def *ast.Definition, seen map[string]bool) {
@@ -1378,6 +1429,138 @@ func addAggregateFields(schema *ast.Schema, defn *ast.Definition, apolloServiceQ
 	}
 }
 
+// addEdgeFields adds edge fields for the fields of object types which declare facets in their
+// @dgraph directive. eg. If defn is like
+// type T { fieldA : [A] @dgraph(pred: "a", facets: ["since: DateTime"]) }
+// The following types are added to the schema
+// type TFieldAEdge { node: A, since: DateTime }
+// input TFieldAEdgeRef { node: ARef!, since: DateTime }
+// and the field fieldAEdges(filter: AFilter, ...) : [TFieldAEdge] is added to type T, along
+// with fieldAEdges : [TFieldAEdgeRef] in the mutation inputs of T.
+// These fields are added to support querying and setting the facets of the edges.
+func addEdgeFields(schema *ast.Schema, defn *ast.Definition, apolloServiceQuery bool) {
+	for _, fld := range defn.Fields {
+		if len(edgeFacets(fld)) == 0 {
+			continue
+		}
+		nodeDefn := schema.Types[fld.Type.Name()]
+		if nodeDefn.Kind != ast.Object && nodeDefn.Kind != ast.Interface &&
+			nodeDefn.Kind != ast.Union {
+			continue
+		}
+		if apolloServiceQuery && hasExtends(nodeDefn) {
+			continue
+		}
+
+		edgeName := edgeTypeName(schema, defn, fld)
+		nodeRefName := nodeDefn.Name + "Ref"
+		_, hasNodeRef := schema.Types[nodeRefName]
+		if _, ok := schema.Types[edgeName]; !ok {
+			schema.Types[edgeName] = &ast.Definition{
+				Kind: ast.Object,
+				Name: edgeName,
+				Fields: append(ast.FieldList{{
+					Name: EdgeNode,
+					Type: &ast.Type{NamedType: nodeDefn.Name},
+				}}, edgeFacets(fld)...),
+			}
+			if hasNodeRef {
+				schema.Types[edgeName+"Ref"] = &ast.Definition{
+					Kind: ast.InputObject,
+					Name: edgeName + "Ref",
+					Fields: append(ast.FieldList{{
+						Name: EdgeNode,
+						Type: &ast.Type{NamedType: nodeRefName, NonNull: true},
+					}}, edgeFacets(fld)...),
+				}
+			}
+		}
+
+		edgesField := &ast.FieldDefinition{
+			Name:      edgesFieldName(fld),
+			Arguments: append(ast.ArgumentDefinitionList{}, fld.Arguments...),
+			Type:      edgesType(fld, edgeName),
+			Directives: ast.DirectiveList{{
+				Name: dgraphDirective,
+				Arguments: ast.ArgumentList{{
+					Name: dgraphPredArg,
+					Value: &ast.Value{
+						Raw:  getDgraphDirPredArg(fld).Value.Raw,
+						Kind: ast.StringValue,
+					},
+				}},
+			}},
+			Position: fld.Position,
+		}
+		defn.Fields = append(defn.Fields, edgesField)
+
+		if !hasNodeRef {
+			continue
+		}
+		for _, inputName := range []string{"Add" + defn.Name + "Input", defn.Name + "Patch",
+			defn.Name + "Ref"} {
+			input := schema.Types[inputName]
+			if input == nil || input.Fields.ForName(fld.Name) == nil {
+				continue
+			}
+			input.Fields = append(input.Fields, &ast.FieldDefinition{
+				Name: edgesField.Name,
+				Type: edgesType(fld, edgeName+"Ref"),
+			})
+		}
+	}
+}
+
+// edgeFacets returns the fields of the edge type of fld for the facets declared in its @dgraph
+// directive.
+func edgeFacets(fld *ast.FieldDefinition) ast.FieldList {
+	dir := fld.Directives.ForName(dgraphDirective)
+	if dir == nil {
+		return nil
+	}
+	arg := dir.Arguments.ForName(dgraphFacetsArg)
+	if arg == nil {
+		return nil
+	}
+	var facets ast.FieldList
+	for _, child := range arg.Value.Children {
+		key, typ, err := parseDgraphFacet(child.Value.Raw)
+		if err != nil {
+			// The facets have already been validated.
+			continue
+		}
+		facets = append(facets, &ast.FieldDefinition{
+			Name: key,
+			Type: &ast.Type{NamedType: typ},
+		})
+	}
+	return facets
+}
+
+// edgeTypeName returns the name of the edge type of fld. Fields inherited from an interface
+// share the edge type of the interface field.
+func edgeTypeName(schema *ast.Schema, defn *ast.Definition, fld *ast.FieldDefinition) string {
+	if parentInt := parentInterface(schema, defn, fld.Name); parentInt != nil {
+		defn = parentInt
+	}
+	return defn.Name + strings.ToUpper(fld.Name[:1]) + fld.Name[1:] + "Edge"
+}
+
+// edgesFieldName returns the name of the field exposing the edges of fld with their facets.
+func edgesFieldName(fld *ast.FieldDefinition) string {
+	if isTypeList(fld) {
+		return fld.Name + "Edges"
+	}
+	return fld.Name + "Edge"
+}
+
+func edgesType(fld *ast.FieldDefinition, typeName string) *ast.Type {
+	if isTypeList(fld) {
+		return &ast.Type{Elem: &ast.Type{NamedType: typeName, NonNull: fld.Type.Elem.NonNull}}
+	}
+	return &ast.Type{NamedType: typeName}
+}
+
 func addFilterArgument(schema *ast.Schema, fld *ast.FieldDefinition) {
 	addFilterArgumentForField(schema, fld, fld.Type.Name())
 }
@@ -23,6 +23,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/dgraph-io/dgraph/x"
 	"github.com/dgraph-io/gqlparser/v2/ast"
@@ -271,6 +272,7 @@ func typeNameValidation(schema *ast.SchemaDocument) gqlerror.List {
 		"HTTPMethod":           true,
 		"Mode":                 true,
 		"CustomHTTP":           true,
+		"CustomGRPC":           true,
 		"IntFilter":            true,
 		"Int64Filter":          true,
 		"FloatFilter":          true,
@@ -1089,6 +1091,64 @@ func searchValidation(
 	return errs
 }
 
+// dgraphFacetsValidation validates the facets argument of the @dgraph directive, which declares
+// the types of the facets of the predicate as a list of "key: Type".
+func dgraphFacetsValidation(sch *ast.Schema, typ *ast.Definition, field *ast.FieldDefinition,
+	predArg, facetsArg *ast.Argument) gqlerror.List {
+	if strings.HasPrefix(predArg.Value.Raw, "~") || strings.HasPrefix(predArg.Value.Raw, "<~") {
+		return []*gqlerror.Error{gqlerror.ErrorPosf(facetsArg.Position,
+			"Type %s; Field %s: facets argument for @dgraph directive is not allowed on "+
+				"reverse predicates.", typ.Name, field.Name)}
+	}
+	if facetsArg.Value.Kind != ast.ListValue {
+		return []*gqlerror.Error{gqlerror.ErrorPosf(facetsArg.Position,
+			"Type %s; Field %s: facets argument for @dgraph directive should be a list of "+
+				"strings.", typ.Name, field.Name)}
+	}
+
+	// The facets of edges to objects are fields of the generated edge type, along with the node.
+	var hasEdgeType bool
+	if fieldType := sch.Types[field.Type.Name()]; fieldType != nil {
+		hasEdgeType = fieldType.Kind == ast.Object || fieldType.Kind == ast.Interface ||
+			fieldType.Kind == ast.Union
+	}
+
+	var errs []*gqlerror.Error
+	seen := make(map[string]bool)
+	for _, child := range facetsArg.Value.Children {
+		key, _, err := parseDgraphFacet(child.Value.Raw)
+		switch {
+		case err != nil:
+			errs = append(errs, gqlerror.ErrorPosf(child.Value.Position,
+				"Type %s; Field %s: facets argument for @dgraph directive has an invalid "+
+					"facet: %s.", typ.Name, field.Name, err))
+		case hasEdgeType && (key == EdgeNode || !isGraphqlName(key)):
+			errs = append(errs, gqlerror.ErrorPosf(child.Value.Position,
+				"Type %s; Field %s: facets argument for @dgraph directive has a facet %s "+
+					"which can't be a field of the edge type.", typ.Name, field.Name, key))
+		case seen[key]:
+			errs = append(errs, gqlerror.ErrorPosf(child.Value.Position,
+				"Type %s; Field %s: facets argument for @dgraph directive has a duplicate "+
+					"facet %s.", typ.Name, field.Name, key))
+		}
+		seen[key] = true
+	}
+	return errs
+}
+
+// isGraphqlName tells whether name is a valid GraphQL name.
+func isGraphqlName(name string) bool {
+	for i, r := range name {
+		switch {
+		case r == '_', 'a' <= r && r <= 'z', 'A' <= r && r <= 'Z':
+		case i > 0 && '0' <= r && r <= '9':
+		default:
+			return false
+		}
+	}
+	return name != ""
+}
+
 func dgraphDirectiveValidation(sch *ast.Schema, typ *ast.Definition, field *ast.FieldDefinition,
 	dir *ast.Directive, secrets map[string]x.Sensitive) gqlerror.List {
 	var errs []*gqlerror.Error
@@ -1126,6 +1186,12 @@ func dgraphDirectiveValidation(sch *ast.Schema, typ *ast.Definition, field *ast.
 		return errs
 	}
 
+	if facetsArg := dir.Arguments.ForName(dgraphFacetsArg); facetsArg != nil {
+		if errs = dgraphFacetsValidation(sch, typ, field, predArg, facetsArg); errs != nil {
+			return errs
+		}
+	}
+
 	if strings.HasPrefix(predArg.Value.Raw, "~") || strings.HasPrefix(predArg.Value.Raw, "<~") {
 		if sch.Types[typ.Name].Kind == ast.Interface {
 			// We don't want to consider the field of an interface but only the fields with
@@ -1472,18 +1538,19 @@ func customDirectiveValidation(sch *ast.Schema,
 		errs = append(errs, gqlerror.ErrorPosf(
 			dir.Position,
 			"Type %s; Field %s: has %d arguments for @custom directive, "+
-				"it should contain exactly one of `http` or `dql` arguments.",
+				"it should contain exactly one of `http`, `grpc` or `dql` arguments.",
 			typ.Name, field.Name, l))
 	}
 
 	httpArg := dir.Arguments.ForName(httpArg)
+	grpcArg := dir.Arguments.ForName(grpcArg)
 	dqlArg := dir.Arguments.ForName(dqlArg)
 
-	if httpArg == nil && dqlArg == nil {
+	if httpArg == nil && grpcArg == nil && dqlArg == nil {
 		errs = append(errs, gqlerror.ErrorPosf(
 			dir.Position,
-			"Type %s; Field %s: one of `http` or `dql` arguments must be present for @custom"+
-				" directive.",
+			"Type %s; Field %s: one of `http`, `grpc` or `dql` arguments must be present for"+
+				" @custom directive.",
 			typ.Name, field.Name))
 		return errs
 	}
@@ -1530,7 +1597,12 @@ func customDirectiveValidation(sch *ast.Schema,
 		return errs
 	}
 
-	// 3.2 Validating http argument
+	// 3.2 Validating grpc argument
+	if grpcArg != nil {
+		return append(errs, grpcArgValidation(typ, field, grpcArg)...)
+	}
+
+	// 3.3 Validating http argument
 	// if we reach here, it means that httpArg != nil
 	if httpArg.Value.String() == "" {
 		errs = append(errs, gqlerror.ErrorPosf(
@@ -1956,6 +2028,31 @@ func customDirectiveValidation(sch *ast.Schema,
 		}
 	}
 
+	// 11. Validating retries, timeout and cacheTTL
+	if retries := httpArg.Value.Children.ForName(httpRetries); retries != nil {
+		if n, err := strconv.Atoi(retries.Raw); err != nil || n < 0 {
+			errs = append(errs, gqlerror.ErrorPosf(retries.Position,
+				"Type %s; Field %s; retries field inside @custom directive must be a "+
+					"non-negative integer, found `%s`.", typ.Name, field.Name, retries.Raw))
+		}
+	}
+	for _, arg := range []string{httpTimeout, httpCache} {
+		val := httpArg.Value.Children.ForName(arg)
+		if val == nil {
+			continue
+		}
+		if d, err := time.ParseDuration(val.Raw); err != nil || d <= 0 {
+			errs = append(errs, gqlerror.ErrorPosf(val.Position,
+				"Type %s; Field %s; %s field inside @custom directive must be a positive "+
+					"duration like 500ms or 10s, found `%s`.", typ.Name, field.Name, arg, val.Raw))
+		}
+		if arg == httpCache && typ.Name == "Mutation" {
+			errs = append(errs, gqlerror.ErrorPosf(val.Position,
+				"Type %s; Field %s; %s field inside @custom directive can't be present on "+
+					"Mutation.", typ.Name, field.Name, arg))
+		}
+	}
+
 	// 12. Finally validate the given graphql operation on remote server, when all locally doable
 	// validations have finished
 	var skip bool
@@ -2072,6 +2169,98 @@ func customDirectiveValidation(sch *ast.Schema,
 	return errs
 }
 
+func grpcArgValidation(typ *ast.Definition, field *ast.FieldDefinition,
+	grpcArg *ast.Argument) gqlerror.List {
+	var errs []*gqlerror.Error
+	if grpcArg.Value.Kind != ast.ObjectValue {
+		return append(errs, gqlerror.ErrorPosf(
+			grpcArg.Position,
+			"Type %s; Field %s: grpc argument for @custom directive should be of type Object.",
+			typ.Name, field.Name))
+	}
+	if !isQueryOrMutationType(typ) {
+		errs = append(errs, gqlerror.ErrorPosf(
+			grpcArg.Position,
+			"Type %s; Field %s: @custom directive with `grpc` can be used only on queries and "+
+				"mutations.", typ.Name, field.Name))
+	}
+
+	target := grpcArg.Value.Children.ForName(grpcTarget)
+	if target == nil || strings.TrimSpace(target.Raw) == "" {
+		errs = append(errs, gqlerror.ErrorPosf(
+			grpcArg.Position,
+			"Type %s; Field %s; target field inside @custom directive with `grpc` is mandatory.",
+			typ.Name, field.Name))
+	}
+
+	method := grpcArg.Value.Children.ForName(grpcMethod)
+	if method == nil {
+		errs = append(errs, gqlerror.ErrorPosf(
+			grpcArg.Position,
+			"Type %s; Field %s; method field inside @custom directive with `grpc` is mandatory.",
+			typ.Name, field.Name))
+	} else if service, name, err := parseGRPCMethod(method.Raw); err != nil {
+		errs = append(errs, gqlerror.ErrorPosf(method.Position,
+			"Type %s; Field %s; method field inside @custom directive with `grpc` %s.",
+			typ.Name, field.Name, err.Error()))
+	} else if descriptor := grpcArg.Value.Children.ForName(grpcDescriptor); descriptor != nil {
+		// Without a descriptor, the method is looked up on the server using reflection when the
+		// field is resolved.
+		if _, err := methodFromDescriptor(descriptor.Raw, service, name); err != nil {
+			errs = append(errs, gqlerror.ErrorPosf(descriptor.Position,
+				"Type %s; Field %s; descriptor field inside @custom directive with `grpc` is "+
+					"invalid: %s.", typ.Name, field.Name, err.Error()))
+		}
+	}
+
+	if secure := grpcArg.Value.Children.ForName(grpcSecure); secure != nil {
+		if _, err := strconv.ParseBool(secure.Raw); err != nil {
+			errs = append(errs, gqlerror.ErrorPosf(secure.Position,
+				"Type %s; Field %s; secure field inside @custom directive can only be "+
+					"true/false, found: `%s`.", typ.Name, field.Name, secure.Raw))
+		}
+	}
+	if timeout := grpcArg.Value.Children.ForName(httpTimeout); timeout != nil {
+		if d, err := time.ParseDuration(timeout.Raw); err != nil || d <= 0 {
+			errs = append(errs, gqlerror.ErrorPosf(timeout.Position,
+				"Type %s; Field %s; timeout field inside @custom directive must be a positive "+
+					"duration like 500ms or 10s, found `%s`.", typ.Name, field.Name, timeout.Raw))
+		}
+	}
+
+	if body := grpcArg.Value.Children.ForName(httpBody); body != nil {
+		_, requiredFields, err := parseBodyTemplate(body.Raw, true)
+		if err != nil {
+			errs = append(errs, gqlerror.ErrorPosf(body.Position,
+				"Type %s; Field %s; body template inside @custom directive could not be parsed: %s",
+				typ.Name, field.Name, err.Error()))
+		}
+		for fname := range requiredFields {
+			if field.Arguments.ForName(fname) == nil {
+				errs = append(errs, gqlerror.ErrorPosf(body.Position,
+					"Type %s; Field %s; body template inside @custom directive uses an"+
+						" argument %s that is not defined.", typ.Name, field.Name, fname))
+			}
+		}
+	}
+
+	for _, headersArg := range []string{"forwardHeaders", "secretHeaders"} {
+		headers := grpcArg.Value.Children.ForName(headersArg)
+		if headers == nil {
+			continue
+		}
+		for _, h := range headers.Children {
+			if len(strings.Split(h.Value.Raw, ":")) > 2 {
+				errs = append(errs, gqlerror.ErrorPosf(headers.Position,
+					"Type %s; Field %s; %s in @custom directive should be of the form "+
+						"'remote_headername:local_headername' or just 'headername', found: `%s`.",
+					typ.Name, field.Name, headersArg, h.Value.Raw))
+			}
+		}
+	}
+	return errs
+}
+
 func idValidation(sch *ast.Schema,
 	typ *ast.Definition,
 	field *ast.FieldDefinition,
@@ -434,6 +434,9 @@ func getAllowedHeaders(sch *ast.Schema, definitions []string, authHeader string)
 		}
 
 		httpArg := dir.Arguments.ForName("http")
+		if httpArg == nil {
+			httpArg = dir.Arguments.ForName("grpc")
+		}
 		if httpArg == nil {
 			return
 		}
@@ -513,6 +516,44 @@ func getDgraphDirPredArg(def *ast.FieldDefinition) *ast.Argument {
 	return predArg
 }
 
+// parseDgraphFacet parses the declaration of a facet in the @dgraph directive, like
+// "since: DateTime", and returns the facet key and its GraphQL type.
+func parseDgraphFacet(decl string) (string, string, error) {
+	parts := strings.SplitN(decl, ":", 2)
+	if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+		return "", "", errors.Errorf("%q should be of the form \"key: Type\"", decl)
+	}
+	key, typ := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+	if _, ok := facetTypeToDgraph[typ]; !ok {
+		return "", "", errors.Errorf("type %s of facet %s isn't supported", typ, key)
+	}
+	return key, typ, nil
+}
+
+// dgraphFacets returns the @facets directive of the Dgraph predicate of the field, as declared by
+// the facets argument of its @dgraph directive.
+func dgraphFacets(f *ast.FieldDefinition) string {
+	dir := f.Directives.ForName(dgraphDirective)
+	if dir == nil {
+		return ""
+	}
+	arg := dir.Arguments.ForName(dgraphFacetsArg)
+	if arg == nil || len(arg.Value.Children) == 0 {
+		return ""
+	}
+	fcs := make([]string, 0, len(arg.Value.Children))
+	for _, child := range arg.Value.Children {
+		key, typ, err := parseDgraphFacet(child.Value.Raw)
+		if err != nil {
+			// The facets have already been validated.
+			continue
+		}
+		fcs = append(fcs, fmt.Sprintf("%s: %s", key, facetTypeToDgraph[typ]))
+	}
+	sort.Strings(fcs)
+	return fmt.Sprintf(" @facets(%s)", strings.Join(fcs, ", "))
+}
+
 // genDgSchema generates Dgraph schema from a valid graphql schema.
 func genDgSchema(gqlSch *ast.Schema, definitions []string,
 	providesFieldsMap map[string]map[string]bool) string {
@@ -524,6 +565,7 @@ func genDgSchema(gqlSch *ast.Schema, definitions []string,
 		upsert  string
 		reverse string
 		lang    bool
+		facets  string
 	}
 
 	type field struct {
@@ -694,6 +736,13 @@ func genDgSchema(gqlSch *ast.Schema, definitions []string,
 					}
 					typ.fields = append(typ.fields, field{fname, parentInt != nil})
 				}
+
+				if facets := dgraphFacets(f); facets != "" && parentInt == nil {
+					if pred, ok := dgPreds[fname]; ok {
+						pred.facets = facets
+						dgPreds[fname] = pred
+					}
+				}
 			}
 			if pwdField != nil {
 				parentInt := parentInterfaceForPwdField(gqlSch, def, pwdField.Name)
@@ -739,8 +788,8 @@ func genDgSchema(gqlSch *ast.Schema, definitions []string,
 				if f.lang {
 					langStr = " @lang"
 				}
-				fmt.Fprintf(&preds, "%s: %s%s%s %s%s.\n", fld.name, f.typ, indexStr, langStr, f.upsert,
-					f.reverse)
+				fmt.Fprintf(&preds, "%s: %s%s%s%s %s%s.\n", fld.name, f.typ, indexStr, langStr,
+					f.facets, f.upsert, f.reverse)
 				predWritten[fld.name] = true
 			}
 		}
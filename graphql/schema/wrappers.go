@@ -25,6 +25,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/dgraph-io/dgraph/graphql/authorization"
 	"github.com/dgraph-io/gqlparser/v2/parser"
@@ -71,6 +72,17 @@ type FieldHTTPConfig struct {
 	// the GraphqlBatchModeArgument would be sinput, we use it to know the GraphQL variable that
 	// we should send the data in.
 	GraphqlBatchModeArgument string
+
+	// Retries is the number of times a failed request is retried, with a backoff.
+	Retries int
+	// Timeout overrides the default timeout of the requests, if non-zero.
+	Timeout time.Duration
+	// CacheTTL is how long the successful responses are cached, if non-zero.
+	CacheTTL time.Duration
+
+	// GRPC is set instead of the URL and Method if the field is resolved by calling a gRPC
+	// service, with @custom(grpc: {...}).
+	GRPC *GRPCConfig
 }
 
 // EntityRepresentations is the parsed form of the `representations` argument in `_entities` query
@@ -255,6 +267,8 @@ type Type interface {
 	AuthRules() *TypeAuth
 	IsGeo() bool
 	IsAggregateResult() bool
+	// IsEdge tells whether this is an edge type generated for the facets of a field.
+	IsEdge() bool
 	IsInbuiltOrEnumType() bool
 	fmt.Stringer
 }
@@ -273,6 +287,9 @@ type FieldDefinition interface {
 	HasIDDirective() bool
 	HasInterfaceArg() bool
 	Inverse() FieldDefinition
+	// FacetedField returns the field whose edges are exposed, with their facets, by this field,
+	// if this is an edge field. Otherwise, it returns nil.
+	FacetedField() FieldDefinition
 	WithMemberType(string) FieldDefinition
 	// TODO - It might be possible to get rid of ForwardEdge and just use Inverse() always.
 	ForwardEdge() FieldDefinition
@@ -319,6 +336,12 @@ type schema struct {
 	// remoteResponse stores the mapping of typeName->fieldName->responseName which will be used in result
 	// completion step.
 	remoteResponse map[string]map[string]string
+	// edgeFields stores the mapping of typeName->edgesFieldName->fieldName, for the fields
+	// generated to expose the edges of the fields declaring facets. It is read-only.
+	edgeFields map[string]map[string]string
+	// edgeTypes stores the names of the edge types generated for the fields declaring facets.
+	// It is read-only.
+	edgeTypes map[string]bool
 	// Map from typename to auth rules
 	authRules map[string]*TypeAuth
 	// meta is the meta information extracted from input schema
@@ -793,6 +816,33 @@ func requiresMappings(s *ast.Schema) map[string]map[string][]string {
 	return requiresDirectives
 }
 
+// edgeMappings returns the mapping of typeName->edgesFieldName->fieldName for the edge fields
+// generated for the fields declaring facets, along with the names of their edge types.
+func edgeMappings(s *ast.Schema) (map[string]map[string]string, map[string]bool) {
+	edgeFields := make(map[string]map[string]string)
+	edgeTypes := make(map[string]bool)
+	for _, typ := range s.Types {
+		if typ.Kind != ast.Object && typ.Kind != ast.Interface {
+			continue
+		}
+		for _, fld := range typ.Fields {
+			if len(edgeFacets(fld)) == 0 {
+				continue
+			}
+			edgesField := typ.Fields.ForName(edgesFieldName(fld))
+			if edgesField == nil || s.Types[edgesField.Type.Name()] == nil {
+				continue
+			}
+			if edgeFields[typ.Name] == nil {
+				edgeFields[typ.Name] = make(map[string]string)
+			}
+			edgeFields[typ.Name][edgesField.Name] = fld.Name
+			edgeTypes[edgesField.Type.Name()] = true
+		}
+	}
+	return edgeFields, edgeTypes
+}
+
 func remoteResponseMapping(s *ast.Schema) map[string]map[string]string {
 	remoteResponse := make(map[string]map[string]string)
 	for _, typ := range s.Types {
@@ -999,6 +1049,7 @@ func AsSchema(s *ast.Schema, ns uint64) (Schema, error) {
 		meta:               &metaInfo{}, // initialize with an empty metaInfo
 	}
 	sch.mutatedType = mutatedTypeMapping(sch, dgraphPredicate)
+	sch.edgeFields, sch.edgeTypes = edgeMappings(s)
 	// Auth rules can't be effectively validated as part of the normal rules -
 	// because they need the fully generated schema to be checked against.
 	var err error
@@ -1331,7 +1382,7 @@ func (f *field) IsCustomHTTP() bool {
 		return false
 	}
 
-	return custom.Arguments.ForName(httpArg) != nil
+	return custom.Arguments.ForName(httpArg) != nil || custom.Arguments.ForName(grpcArg) != nil
 }
 
 func (f *field) HasCustomHTTPChild() bool {
@@ -1489,6 +1540,9 @@ func (t *astType) IsInbuiltOrEnumType() bool {
 
 func getCustomHTTPConfig(f *field, isQueryOrMutation bool, ns uint64) (*FieldHTTPConfig, error) {
 	custom := f.op.inSchema.customDirectives[f.GetObjectName()][f.Name()]
+	if grpcArg := custom.Arguments.ForName(grpcArg); grpcArg != nil {
+		return getCustomGRPCConfig(f, grpcArg)
+	}
 	httpArg := custom.Arguments.ForName(httpArg)
 	fconf := &FieldHTTPConfig{
 		URL:    httpArg.Value.Children.ForName(httpUrl).Raw,
@@ -1501,6 +1555,17 @@ func getCustomHTTPConfig(f *field, isQueryOrMutation bool, ns uint64) (*FieldHTT
 		fconf.Mode = op.Raw
 	}
 
+	// retries, timeout and cacheTTL have been validated along with the schema.
+	if retries := httpArg.Value.Children.ForName(httpRetries); retries != nil {
+		fconf.Retries, _ = strconv.Atoi(retries.Raw)
+	}
+	if timeout := httpArg.Value.Children.ForName(httpTimeout); timeout != nil {
+		fconf.Timeout, _ = time.ParseDuration(timeout.Raw)
+	}
+	if cacheTTL := httpArg.Value.Children.ForName(httpCache); cacheTTL != nil {
+		fconf.CacheTTL, _ = time.ParseDuration(cacheTTL.Raw)
+	}
+
 	// both body and graphql can't be present together
 	bodyArg := httpArg.Value.Children.ForName(httpBody)
 	graphqlArg := httpArg.Value.Children.ForName(httpGraphql)
@@ -1522,29 +1587,7 @@ func getCustomHTTPConfig(f *field, isQueryOrMutation bool, ns uint64) (*FieldHTT
 	fconf.ForwardHeaders = http.Header{}
 	// set application/json as the default Content-Type
 	fconf.ForwardHeaders.Set("Content-Type", "application/json")
-	secretHeaders := httpArg.Value.Children.ForName("secretHeaders")
-	if secretHeaders != nil {
-		for _, h := range secretHeaders.Children {
-			key := strings.Split(h.Value.Raw, ":")
-			if len(key) == 1 {
-				key = []string{h.Value.Raw, h.Value.Raw}
-			}
-			val := string(f.op.inSchema.meta.secrets[key[1]])
-			fconf.ForwardHeaders.Set(key[0], val)
-		}
-	}
-
-	forwardHeaders := httpArg.Value.Children.ForName("forwardHeaders")
-	if forwardHeaders != nil {
-		for _, h := range forwardHeaders.Children {
-			key := strings.Split(h.Value.Raw, ":")
-			if len(key) == 1 {
-				key = []string{h.Value.Raw, h.Value.Raw}
-			}
-			reqHeaderVal := f.op.header.Get(key[1])
-			fconf.ForwardHeaders.Set(key[0], reqHeaderVal)
-		}
-	}
+	f.setCustomHeaders(httpArg, fconf.ForwardHeaders)
 
 	if graphqlArg != nil {
 		queryDoc, gqlErr := parser.ParseQuery(&ast.Source{Input: graphqlArg.Raw})
@@ -1595,6 +1638,71 @@ func getCustomHTTPConfig(f *field, isQueryOrMutation bool, ns uint64) (*FieldHTT
 	return fconf, nil
 }
 
+// getCustomGRPCConfig returns the config of a query or a mutation resolved by calling a gRPC
+// service. The request message is given by the body template, or by the arguments of the field if
+// there's no body.
+func getCustomGRPCConfig(f *field, grpcArg *ast.Argument) (*FieldHTTPConfig, error) {
+	gconf := &GRPCConfig{
+		Target: grpcArg.Value.Children.ForName(grpcTarget).Raw,
+	}
+	// method, secure and timeout have been validated along with the schema.
+	gconf.Service, gconf.Method, _ = parseGRPCMethod(
+		grpcArg.Value.Children.ForName(grpcMethod).Raw)
+	if descriptor := grpcArg.Value.Children.ForName(grpcDescriptor); descriptor != nil {
+		gconf.Descriptor = descriptor.Raw
+	}
+	if secure := grpcArg.Value.Children.ForName(grpcSecure); secure != nil {
+		gconf.Secure, _ = strconv.ParseBool(secure.Raw)
+	}
+
+	fconf := &FieldHTTPConfig{Mode: SINGLE, GRPC: gconf}
+	if timeout := grpcArg.Value.Children.ForName(httpTimeout); timeout != nil {
+		fconf.Timeout, _ = time.ParseDuration(timeout.Raw)
+	}
+	fconf.ForwardHeaders = http.Header{}
+	f.setCustomHeaders(grpcArg, fconf.ForwardHeaders)
+
+	argMap := f.field.ArgumentMap(f.op.vars)
+	if body := grpcArg.Value.Children.ForName(httpBody); body != nil {
+		bt, _, err := parseBodyTemplate(body.Raw, true)
+		if err != nil {
+			return nil, err
+		}
+		fconf.Template = SubstituteVarsInBody(bt, argMap)
+	} else {
+		fconf.Template = argMap
+	}
+	return fconf, nil
+}
+
+// setCustomHeaders sets the secretHeaders and the forwardHeaders of the http or grpc argument of a
+// @custom directive in the given header.
+func (f *field) setCustomHeaders(arg *ast.Argument, header http.Header) {
+	secretHeaders := arg.Value.Children.ForName("secretHeaders")
+	if secretHeaders != nil {
+		for _, h := range secretHeaders.Children {
+			key := strings.Split(h.Value.Raw, ":")
+			if len(key) == 1 {
+				key = []string{h.Value.Raw, h.Value.Raw}
+			}
+			val := string(f.op.inSchema.meta.secrets[key[1]])
+			header.Set(key[0], val)
+		}
+	}
+
+	forwardHeaders := arg.Value.Children.ForName("forwardHeaders")
+	if forwardHeaders != nil {
+		for _, h := range forwardHeaders.Children {
+			key := strings.Split(h.Value.Raw, ":")
+			if len(key) == 1 {
+				key = []string{h.Value.Raw, h.Value.Raw}
+			}
+			reqHeaderVal := f.op.header.Get(key[1])
+			header.Set(key[0], reqHeaderVal)
+		}
+	}
+}
+
 func (f *field) CustomHTTPConfig(ns uint64) (*FieldHTTPConfig, error) {
 	return getCustomHTTPConfig(f, false, ns)
 }
@@ -1921,8 +2029,12 @@ func (m *mutation) ConstructedFor() Type {
 }
 
 // In case the field f is of type <Type>Aggregate, the Type is retunred.
+// In case the field f is of an edge type, the type of the node of the edge is returned.
 // In all other case the function returns the type of field f.
 func (f *field) ConstructedFor() Type {
+	if f.Type().IsEdge() {
+		return f.Type().Field(EdgeNode).Type()
+	}
 	if !f.IsAggregateField() {
 		return f.Type()
 	}
@@ -2303,6 +2415,10 @@ func (t *astType) IsAggregateResult() bool {
 	return strings.HasSuffix(t.Name(), "AggregateResult")
 }
 
+func (t *astType) IsEdge() bool {
+	return t.inSchema.edgeTypes[t.Name()]
+}
+
 func (t *astType) Field(name string) FieldDefinition {
 	return &fieldDefinition{
 		// this ForName lookup is a loop in the underlying schema :-(
@@ -2427,6 +2543,14 @@ func (fd *fieldDefinition) Inverse() FieldDefinition {
 	}
 }
 
+func (fd *fieldDefinition) FacetedField() FieldDefinition {
+	fldName := fd.inSchema.edgeFields[fd.parentType.Name()][fd.Name()]
+	if fldName == "" {
+		return nil
+	}
+	return fd.parentType.Field(fldName)
+}
+
 func (fd *fieldDefinition) WithMemberType(memberType string) FieldDefinition {
 	// just need to return a copy of this fieldDefinition with type set to memberType
 	return &fieldDefinition{
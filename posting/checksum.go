@@ -0,0 +1,155 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package posting
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"hash/crc32"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/badger/v3"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	ostats "go.opencensus.io/stats"
+)
+
+// checksumLen is the length of the CRC32C checksum at the end of the values having BitChecksum.
+const checksumLen = 4
+
+var (
+	castagnoli = crc32.MakeTable(crc32.Castagnoli)
+
+	// ErrCorruptedPosting is returned when reading a posting list whose checksum doesn't match.
+	ErrCorruptedPosting = errors.New("the posting list is corrupted")
+)
+
+// PostingMeta returns the kind of posting list stored in a value, given the user meta of the
+// value. The BitChecksum bit is cleared, so the result is one of the other bits.
+func PostingMeta(meta byte) byte {
+	return meta &^ BitChecksum
+}
+
+// putChecksum writes the checksum of val[:len(val)-checksumLen] in the last bytes of val.
+func putChecksum(val []byte) {
+	data := val[:len(val)-checksumLen]
+	binary.BigEndian.PutUint32(val[len(data):], crc32.Checksum(data, castagnoli))
+}
+
+// withChecksum returns the value and the user meta to write for the posting list val of kind
+// meta, with a checksum if they are enabled.
+func withChecksum(val []byte, meta byte) ([]byte, byte) {
+	if !x.Config.PostingChecksums || len(val) == 0 {
+		return val, meta
+	}
+	res := make([]byte, len(val)+checksumLen)
+	copy(res, val)
+	putChecksum(res)
+	return res, meta | BitChecksum
+}
+
+// VerifyValue returns the posting list stored in val, the value of the item, without its checksum
+// if it has one. If the checksum doesn't match, the key is quarantined and ErrCorruptedPosting is
+// returned.
+func VerifyValue(item *badger.Item, val []byte) ([]byte, error) {
+	if item.UserMeta()&BitChecksum == 0 {
+		return val, nil
+	}
+	if len(val) >= checksumLen {
+		data := val[:len(val)-checksumLen]
+		if crc32.Checksum(data, castagnoli) == binary.BigEndian.Uint32(val[len(data):]) {
+			return data, nil
+		}
+	}
+	return nil, quarantineKey(item.Key(), item.Version())
+}
+
+// CorruptedKey is a key found holding a corrupted posting list.
+type CorruptedKey struct {
+	Key []byte
+	// Version is the latest version of the key found corrupted.
+	Version    uint64
+	DetectedAt time.Time
+	// Reads is the number of reads which failed on the corrupted versions of the key.
+	Reads uint64
+}
+
+// quarantine keeps the keys found corrupted since the start, until they are released.
+var quarantine struct {
+	sync.Mutex
+	keys map[string]*CorruptedKey
+}
+
+func quarantineKey(key []byte, version uint64) error {
+	quarantine.Lock()
+	if quarantine.keys == nil {
+		quarantine.keys = make(map[string]*CorruptedKey)
+	}
+	ck, ok := quarantine.keys[string(key)]
+	if !ok {
+		ck = &CorruptedKey{Key: append([]byte{}, key...), DetectedAt: time.Now()}
+		quarantine.keys[string(key)] = ck
+	}
+	ck.Reads++
+	if version > ck.Version {
+		ck.Version = version
+	}
+	numKeys := len(quarantine.keys)
+	quarantine.Unlock()
+
+	ostats.Record(context.Background(), x.CorruptedPostings.M(1),
+		x.QuarantinedKeys.M(int64(numKeys)))
+	var pred string
+	if pk, err := x.Parse(key); err == nil {
+		pred = x.ParseAttr(pk.Attr)
+	}
+	if !ok {
+		glog.Errorf("Checksum mismatch for key %x of predicate %q at version %d. The key is "+
+			"quarantined.", key, pred, version)
+	}
+	return errors.Wrapf(ErrCorruptedPosting, "checksum mismatch for key %x of predicate %q at "+
+		"version %d, see the corruptedKeys admin query", key, pred, version)
+}
+
+// CorruptedKeys returns the keys in quarantine, sorted.
+func CorruptedKeys() []CorruptedKey {
+	quarantine.Lock()
+	defer quarantine.Unlock()
+	res := make([]CorruptedKey, 0, len(quarantine.keys))
+	for _, ck := range quarantine.keys {
+		res = append(res, *ck)
+	}
+	sort.Slice(res, func(i, j int) bool { return bytes.Compare(res[i].Key, res[j].Key) < 0 })
+	return res
+}
+
+// ReleaseCorruptedKey removes the key from the quarantine, e.g. once its data was restored, and
+// returns whether it was there. The key is quarantined again if it's still found corrupted.
+func ReleaseCorruptedKey(key []byte) bool {
+	quarantine.Lock()
+	_, ok := quarantine.keys[string(key)]
+	delete(quarantine.keys, string(key))
+	numKeys := len(quarantine.keys)
+	quarantine.Unlock()
+
+	ostats.Record(context.Background(), x.QuarantinedKeys.M(int64(numKeys)))
+	return ok
+}
@@ -0,0 +1,78 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package posting
+
+import (
+	"math"
+	"testing"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/dgraph-io/sroar"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostingChecksum(t *testing.T) {
+	x.Config.PostingChecksums = true
+	defer func() { x.Config.PostingChecksums = false }()
+
+	writeList := func(key []byte, uids []uint64, ts uint64, corrupt bool) {
+		plist := &pb.PostingList{Bitmap: sroar.FromSortedList(uids).ToBuffer()}
+		kv := MarshalPostingList(plist, nil)
+		require.Equal(t, BitCompletePosting|BitChecksum, kv.UserMeta[0])
+		if corrupt {
+			kv.Value[0] ^= 0xff
+		}
+		w := NewTxnWriter(ps)
+		require.NoError(t, w.SetAt(key, kv.Value, kv.UserMeta[0], ts))
+		require.NoError(t, w.Flush())
+	}
+
+	attr := x.GalaxyAttr("checksum")
+	key := x.DataKey(attr, 1)
+	writeList(key, []uint64{2, 3, 4}, 1, false)
+	l, err := readPostingListFromDisk(key, ps, math.MaxUint64)
+	require.NoError(t, err)
+	bm, err := l.Bitmap(ListOptions{ReadTs: math.MaxUint64})
+	require.NoError(t, err)
+	require.Equal(t, []uint64{2, 3, 4}, bm.ToArray())
+
+	// The deltas are checksummed too.
+	addEdgeToUID(t, attr, 1, 5, 2, 3)
+	l, err = readPostingListFromDisk(key, ps, math.MaxUint64)
+	require.NoError(t, err)
+	bm, err = l.Bitmap(ListOptions{ReadTs: math.MaxUint64})
+	require.NoError(t, err)
+	require.Equal(t, []uint64{2, 3, 4, 5}, bm.ToArray())
+
+	corrupted := x.DataKey(attr, 2)
+	writeList(corrupted, []uint64{2, 3, 4}, 1, true)
+	for i := 0; i < 2; i++ {
+		_, err = readPostingListFromDisk(corrupted, ps, math.MaxUint64)
+		require.True(t, errors.Is(err, ErrCorruptedPosting), "got error %v", err)
+	}
+	keys := CorruptedKeys()
+	require.Len(t, keys, 1)
+	require.Equal(t, corrupted, keys[0].Key)
+	require.Equal(t, uint64(1), keys[0].Version)
+	require.Equal(t, uint64(2), keys[0].Reads)
+
+	require.True(t, ReleaseCorruptedKey(corrupted))
+	require.False(t, ReleaseCorruptedKey(corrupted))
+	require.Empty(t, CorruptedKeys())
+}
@@ -0,0 +1,502 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package posting
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/dgraph-io/badger/v3"
+	"github.com/dgraph-io/sroar"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/schema"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// The mutations move the node whose list they change from the count key of the length of the list
+// they read to the count key of its new length. Concurrent transactions changing the same list
+// read the same length, so the count index can drift from the actual lengths of the lists. The
+// drift is fixed from the bitmaps of the lists:
+//   - when the lists are rolled up: the rollups queue the checks of the count index, which run
+//     in batches, see checkIndexes, and queue the count keys found wrong, whose rollup fixes them,
+//   - when the transactions commit, with x.Config.StrictCountIndex, see Recounter.
+
+// countRepairs are the nodes found missing from, or wrongly in, a count key or a presence list by
+// the rollups of their lists, by key of the list to repair.
+var countRepairs struct {
+	sync.Mutex
+	uids map[string][]uint64
+}
+
+func addCountRepairs(key []byte, uids ...uint64) {
+	if len(uids) == 0 {
+		return
+	}
+	countRepairs.Lock()
+	if countRepairs.uids == nil {
+		countRepairs.uids = make(map[string][]uint64)
+	}
+	countRepairs.uids[string(key)] = append(countRepairs.uids[string(key)], uids...)
+	countRepairs.Unlock()
+}
+
+func takeCountRepairs(key []byte) []uint64 {
+	countRepairs.Lock()
+	defer countRepairs.Unlock()
+	uids := countRepairs.uids[string(key)]
+	delete(countRepairs.uids, string(key))
+	return uids
+}
+
+// hasCountIndex tells whether the key is the key of a list counted by a count index.
+func hasCountIndex(pk x.ParsedKey) bool {
+	return (pk.IsData() || pk.IsReverse()) && !pk.HasStartUid &&
+		schema.State().HasCount(context.Background(), pk.Attr)
+}
+
+// lengthAt returns the number of uids of the list at readTs, -1 if the list was rolled up after it.
+func (l *List) lengthAt(readTs uint64) int {
+	l.AssertRLock()
+	if l.minTs > readTs {
+		return -1
+	}
+	bm, err := l.bitmap(ListOptions{ReadTs: readTs})
+	if err != nil {
+		return -1
+	}
+	return int(bm.GetCardinality())
+}
+
+// indexCheck is a list whose entries in the count index and the presence list are to be checked.
+type indexCheck struct {
+	key []byte
+	pk  x.ParsedKey
+	// n is the length of the list, -1 if it's to be read once the list has the versions up to ts.
+	n  int
+	ts uint64
+}
+
+// indexChecks are the checks queued by the rollups since the last run of checkIndexes, and the
+// nodes to look up in all the count keys of a count index by findStaleCounts, by count prefix.
+var indexChecks struct {
+	sync.Mutex
+	pending []indexCheck
+	stale   map[string][]uint64
+}
+
+func queueIndexCheck(c indexCheck) {
+	indexChecks.Lock()
+	indexChecks.pending = append(indexChecks.pending, c)
+	indexChecks.Unlock()
+}
+
+func addStaleCount(prefix []byte, uid uint64) {
+	indexChecks.Lock()
+	if indexChecks.stale == nil {
+		indexChecks.stale = make(map[string][]uint64)
+	}
+	indexChecks.stale[string(prefix)] = append(indexChecks.stale[string(prefix)], uid)
+	indexChecks.Unlock()
+}
+
+// checkIndexes runs the checks queued since its last run, once per batch of rollups. The lists of
+// the count keys and of the presence lists are read once per run, and only their part which can
+// have the node is decoded. The nodes missing from the count key of their length, and those whose
+// list is empty, are left to findStaleCounts.
+func (ir *incrRollupi) checkIndexes() {
+	indexChecks.Lock()
+	checks := indexChecks.pending
+	indexChecks.pending = nil
+	indexChecks.Unlock()
+
+	lists := make(map[string]*List)
+	contains := func(key []byte, uid uint64) (bool, error) {
+		l, ok := lists[string(key)]
+		if !ok {
+			var err error
+			if l, err = GetNoStore(key, math.MaxUint64); err != nil {
+				return false, err
+			}
+			lists[string(key)] = l
+		}
+		return l.contains(math.MaxUint64, uid)
+	}
+	for _, c := range checks {
+		if err := ir.checkIndex(c, contains); err != nil {
+			glog.Warningf("Error %v checking the count index and presence list of key %v",
+				err, c.key)
+		}
+	}
+}
+
+// checkIndex checks that the node of the list is in the count key of its length, and in the
+// presence list if and only if the list isn't empty. The keys found wrong are queued for repair,
+// which is done by their rollup.
+func (ir *incrRollupi) checkIndex(c indexCheck,
+	contains func(key []byte, uid uint64) (bool, error)) error {
+	pk, n := c.pk, c.n
+	if n < 0 {
+		l, err := GetNoStore(c.key, math.MaxUint64)
+		if err != nil {
+			return err
+		}
+		l.RLock()
+		if l.maxTs < c.ts {
+			// The versions of the commit aren't written yet.
+			l.RUnlock()
+			queueIndexCheck(c)
+			return nil
+		}
+		n = l.lengthAt(math.MaxUint64)
+		l.RUnlock()
+		if n < 0 {
+			return nil
+		}
+	}
+
+	if hasCountIndex(pk) {
+		reverse := pk.IsReverse()
+		var found bool
+		if n > 0 {
+			key := x.CountKey(pk.Attr, uint32(n), reverse)
+			var err error
+			if found, err = contains(key, pk.Uid); err != nil {
+				return err
+			}
+			if !found {
+				addCountRepairs(key, pk.Uid)
+				ir.addKeyToBatch(key, 0)
+			}
+		}
+		if !found {
+			// The node may be left in the count key of a former length.
+			addStaleCount(pk.CountPrefix(reverse), pk.Uid)
+		}
+	}
+	if hasPresence(pk) {
+		key := PresenceKey(pk.Attr)
+		found, err := contains(key, pk.Uid)
+		if err != nil {
+			return err
+		}
+		if found != (n > 0) {
+			addCountRepairs(key, pk.Uid)
+			ir.addKeyToBatch(key, 0)
+		}
+	}
+	return nil
+}
+
+// findStaleCounts looks up the nodes left by checkIndexes in all the count keys of their count
+// index, and queues the count keys which have them for repair, which drops the entries that turn
+// out to be right. It reads whole count indexes, so it runs much less often than checkIndexes.
+func (ir *incrRollupi) findStaleCounts() {
+	indexChecks.Lock()
+	stale := indexChecks.stale
+	indexChecks.stale = nil
+	indexChecks.Unlock()
+
+	for prefix, uids := range stale {
+		if err := ir.findStaleCount([]byte(prefix), uids); err != nil {
+			glog.Warningf("Error %v looking up %d nodes in the count index with prefix %x",
+				err, len(uids), prefix)
+		}
+	}
+}
+
+func (ir *incrRollupi) findStaleCount(prefix []byte, uids []uint64) error {
+	nodes := sroar.NewBitmap()
+	for _, uid := range uids {
+		nodes.Set(uid)
+	}
+
+	txn := pstore.NewTransactionAt(math.MaxUint64, false)
+	defer txn.Discard()
+	iopt := badger.DefaultIteratorOptions
+	iopt.PrefetchValues = false
+	iopt.Prefix = prefix
+	itr := txn.NewIterator(iopt)
+	defer itr.Close()
+	for itr.Rewind(); itr.Valid(); itr.Next() {
+		if pk, err := x.Parse(itr.Item().Key()); err != nil || pk.HasStartUid {
+			continue
+		}
+		key := itr.Item().KeyCopy(nil)
+		l, err := GetNoStore(key, math.MaxUint64)
+		if err != nil {
+			return err
+		}
+		bm, err := l.Bitmap(ListOptions{ReadTs: math.MaxUint64})
+		if err != nil {
+			return err
+		}
+		bm.And(nodes)
+		if found := bm.ToArray(); len(found) > 0 {
+			addCountRepairs(key, found...)
+			ir.addKeyToBatch(key, 0)
+		}
+	}
+	return nil
+}
+
+// repairCountKey fixes the entries of the count key queued by checkIndex, in the list read
+// to roll it up, see repairEntries.
+func repairCountKey(l *List, pk x.ParsedKey) error {
+	return repairEntries(l, pk.Attr, fmt.Sprintf("count %d", pk.Count), func(uid uint64) []byte {
+		if pk.IsCountRev() {
+			return x.ReverseKey(pk.Attr, uid)
+		}
+		return x.DataKey(pk.Attr, uid)
+	}, func(n int) bool {
+		return n > 0 && uint32(n) == pk.Count
+	})
+}
+
+// repairEntries fixes the entries queued for repair of the list l, which has the nodes whose list,
+// of key listKey, has a length n for which want(n) is true. The lengths of the lists of the nodes
+// are read at the max assigned ts minus one, and the fixes are added at that ts, so that the list
+// is rolled up at the max assigned ts, which can't be the commit ts of a later transaction. The
+// nodes whose list changed since are checked again by the next rollup.
+func repairEntries(l *List, attr, what string, listKey func(uid uint64) []byte,
+	want func(n int) bool) error {
+	uids := takeCountRepairs(l.key)
+	if len(uids) == 0 {
+		return nil
+	}
+	maxAssigned := Oracle().MaxAssigned()
+	readTs := maxAssigned - 1
+
+	l.Lock()
+	defer l.Unlock()
+	if maxAssigned == 0 || l.maxTs >= readTs {
+		addCountRepairs(l.key, uids...)
+		return nil
+	}
+	bm, err := l.bitmap(ListOptions{ReadTs: readTs})
+	if err != nil {
+		return err
+	}
+
+	sort.Slice(uids, func(i, j int) bool { return uids[i] < uids[j] })
+	var fixes []*pb.Posting
+	var retry []uint64
+	for i, uid := range uids {
+		if i > 0 && uid == uids[i-1] {
+			continue
+		}
+		dl, err := GetNoStore(listKey(uid), math.MaxUint64)
+		if err != nil {
+			return err
+		}
+		dl.RLock()
+		n := dl.lengthAt(readTs)
+		dl.RUnlock()
+		if n < 0 {
+			retry = append(retry, uid)
+			continue
+		}
+		set := want(n)
+		if set == bm.Contains(uid) {
+			continue
+		}
+		op := Del
+		if set {
+			op = Set
+		}
+		fixes = append(fixes, &pb.Posting{
+			Uid:         uid,
+			Op:          op,
+			PostingType: pb.Posting_REF,
+			StartTs:     readTs,
+			CommitTs:    readTs,
+		})
+	}
+	addCountRepairs(l.key, retry...)
+	if len(fixes) == 0 {
+		return nil
+	}
+
+	glog.V(2).Infof("Fixing %d entries of %s of predicate %s", len(fixes), what,
+		x.ParseAttr(attr))
+	if l.mutationMap == nil {
+		l.mutationMap = make(map[uint64]*pb.PostingList)
+	}
+	l.mutationMap[readTs] = &pb.PostingList{Postings: fixes, CommitTs: readTs}
+	l.maxTs = readTs
+	return nil
+}
+
+// Recounter recomputes the entries of the count indexes written by transactions when they commit,
+// with x.Config.StrictCountIndex. The transactions committed together must be recounted by the
+// same Recounter, in the order of their commit, before they're written.
+type Recounter struct {
+	// lists are the counted lists read, with the deltas of the transactions recounted so far.
+	lists map[string]*List
+}
+
+// NewRecounter returns a Recounter for a batch of commits.
+func NewRecounter() *Recounter {
+	return &Recounter{lists: make(map[string]*List)}
+}
+
+// Recount rewrites the deltas of the count indexes of the transaction committed at commitTs, so
+// that they move the node of each counted list the transaction changed from the count key of the
+// length of the list before the commit to the count key of its length after it, instead of the
+// lengths the transaction read.
+func (r *Recounter) Recount(txn *Txn, commitTs uint64) error {
+	// The skiplist is built from the deltas, wait for it before changing them.
+	txn.Skiplist()
+
+	cache := txn.cache
+	cache.Lock()
+	var checks []indexCheck
+	for key := range cache.deltas {
+		if pk, err := x.Parse([]byte(key)); err == nil && hasCountIndex(pk) {
+			checks = append(checks, indexCheck{key: []byte(key), pk: pk, n: -1, ts: commitTs})
+		}
+	}
+	sort.Slice(checks, func(i, j int) bool {
+		return bytes.Compare(checks[i].key, checks[j].key) < 0
+	})
+	// The lists which can't be recounted have their count index checked once the transaction is
+	// written, and fixed by the rollups.
+	fail := func(err error) error {
+		for _, c := range checks {
+			queueIndexCheck(c)
+		}
+		return err
+	}
+
+	var recounted bool
+	for _, c := range checks {
+		key := string(c.key)
+		before, after, err := r.lengths(key, cache.deltas[key], commitTs)
+		if err != nil {
+			cache.Unlock()
+			return fail(errors.Wrapf(err, "while recounting key %x", key))
+		}
+		if before < 0 {
+			queueIndexCheck(c)
+			continue
+		}
+		if err := cache.moveCount(c.pk, before, after, txn.StartTs); err != nil {
+			cache.Unlock()
+			return fail(errors.Wrapf(err, "while recounting key %x", key))
+		}
+		recounted = true
+	}
+	cache.Unlock()
+
+	if !recounted {
+		return nil
+	}
+	if err := txn.ToSkiplist(); err != nil {
+		return fail(errors.Wrapf(err, "while writing the recounted deltas"))
+	}
+	return nil
+}
+
+// lengths returns the lengths of the list before and after the delta committed at commitTs.
+func (r *Recounter) lengths(key string, delta []byte, commitTs uint64) (int, int, error) {
+	l, ok := r.lists[key]
+	if !ok {
+		var err error
+		if l, err = GetNoStore([]byte(key), math.MaxUint64); err != nil {
+			return 0, 0, err
+		}
+		r.lists[key] = l
+	}
+	pl := &pb.PostingList{}
+	if err := pl.Unmarshal(delta); err != nil {
+		return 0, 0, err
+	}
+	pl.CommitTs = commitTs
+	for _, p := range pl.Postings {
+		p.CommitTs = commitTs
+	}
+
+	l.Lock()
+	defer l.Unlock()
+	before := l.lengthAt(commitTs)
+	if l.mutationMap == nil {
+		l.mutationMap = make(map[uint64]*pb.PostingList)
+	}
+	l.mutationMap[commitTs] = pl
+	l.maxTs = x.Max(l.maxTs, commitTs)
+	return before, l.lengthAt(commitTs), nil
+}
+
+// moveCount replaces the moves of the node of the list with key pk in the deltas of the count
+// index with its move from the count key of before to the count key of after.
+func (lc *LocalCache) moveCount(pk x.ParsedKey, before, after int, startTs uint64) error {
+	reverse := pk.IsReverse()
+	ops := make(map[string]uint32)
+	if before != after {
+		if before > 0 {
+			ops[string(x.CountKey(pk.Attr, uint32(before), reverse))] = Del
+		}
+		if after > 0 {
+			ops[string(x.CountKey(pk.Attr, uint32(after), reverse))] = Set
+		}
+	}
+	keys := make(map[string]struct{})
+	prefix := string(pk.CountPrefix(reverse))
+	for key := range lc.deltas {
+		if strings.HasPrefix(key, prefix) {
+			keys[key] = struct{}{}
+		}
+	}
+	for key := range ops {
+		keys[key] = struct{}{}
+	}
+
+	for key := range keys {
+		pl := &pb.PostingList{}
+		if err := pl.Unmarshal(lc.deltas[key]); err != nil {
+			return err
+		}
+		postings := pl.Postings[:0]
+		for _, p := range pl.Postings {
+			if p.Uid != pk.Uid {
+				postings = append(postings, p)
+			}
+		}
+		if op, ok := ops[key]; ok {
+			postings = append(postings, &pb.Posting{
+				Uid:         pk.Uid,
+				Op:          op,
+				PostingType: pb.Posting_REF,
+				StartTs:     startTs,
+			})
+		}
+		pl.Postings = postings
+		data, err := pl.Marshal()
+		if err != nil {
+			return err
+		}
+		lc.deltas[key] = data
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package posting
+
+import (
+	"context"
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/dgraph-io/badger/v3/skl"
+	"github.com/dgraph-io/badger/v3/y"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/schema"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+const countSchemaVal = `
+friendc: [uid] @count .
+friends: [uid] @count .
+`
+
+func countUids(t *testing.T, attr string, count uint32) []uint64 {
+	l, err := GetNoStore(x.CountKey(attr, count, false), math.MaxUint64)
+	require.NoError(t, err)
+	return uids(l, math.MaxUint64)
+}
+
+func handover(sl *skl.Skiplist, commitTs uint64) {
+	if commitTs > 0 {
+		itr := sl.NewUniIterator(false)
+		for itr.Rewind(); itr.Valid(); itr.Next() {
+			y.SetKeyTs(itr.Key(), commitTs)
+		}
+		itr.Close()
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	x.Check(pstore.HandoverSkiplist(sl, wg.Done))
+	wg.Wait()
+}
+
+func TestCountIndexRollupRepair(t *testing.T) {
+	require.NoError(t, schema.ParseBytes([]byte(countSchemaVal), 1))
+	attr := x.GalaxyAttr("friendc")
+	addEdgeToUID(t, attr, 1, 2, 1, 2)
+	addEdgeToUID(t, attr, 1, 3, 3, 4)
+	// The count index has node 1 with one edge, as if two txns had added the edges concurrently.
+	l, err := GetNoStore(x.CountKey(attr, 1, false), 5)
+	require.NoError(t, err)
+	addMutation(t, l, &pb.DirectedEdge{ValueId: 1, Attr: attr}, Set, 5, 6, false)
+	require.Equal(t, []uint64{1}, countUids(t, attr, 1))
+	require.Empty(t, countUids(t, attr, 2))
+
+	Oracle().SetMaxAssigned(10)
+	sl := skl.NewGrowingSkiplist(1 << 20)
+	require.NoError(t, IncrRollup.rollupKey(sl, x.DataKey(attr, 1)))
+	IncrRollup.checkIndexes()
+	IncrRollup.findStaleCounts()
+	require.NoError(t, IncrRollup.rollupKey(sl, x.CountKey(attr, 1, false)))
+	require.NoError(t, IncrRollup.rollupKey(sl, x.CountKey(attr, 2, false)))
+	handover(sl, 0)
+
+	require.Empty(t, countUids(t, attr, 1))
+	require.Equal(t, []uint64{1}, countUids(t, attr, 2))
+	// The fixed count keys are rolled up at the max assigned ts.
+	l, err = GetNoStore(x.CountKey(attr, 2, false), math.MaxUint64)
+	require.NoError(t, err)
+	require.Equal(t, Oracle().MaxAssigned(), l.maxVersion())
+}
+
+func TestCountIndexRollupRepairEmpty(t *testing.T) {
+	require.NoError(t, schema.ParseBytes([]byte(countSchemaVal), 1))
+	attr := x.GalaxyAttr("friendc")
+	addEdgeToUID(t, attr, 4, 5, 31, 32)
+	l, err := GetNoStore(x.CountKey(attr, 1, false), 33)
+	require.NoError(t, err)
+	addMutation(t, l, &pb.DirectedEdge{ValueId: 4, Attr: attr}, Set, 33, 34, false)
+	// The edge is deleted, but the node is left in the count index.
+	l, err = GetNoStore(x.DataKey(attr, 4), 35)
+	require.NoError(t, err)
+	addMutation(t, l, &pb.DirectedEdge{Entity: 4, ValueId: 5, Attr: attr}, Del, 35, 36, false)
+	require.Contains(t, countUids(t, attr, 1), uint64(4))
+
+	Oracle().SetMaxAssigned(40)
+	sl := skl.NewGrowingSkiplist(1 << 20)
+	require.NoError(t, IncrRollup.rollupKey(sl, x.DataKey(attr, 4)))
+	IncrRollup.checkIndexes()
+	IncrRollup.findStaleCounts()
+	require.NoError(t, IncrRollup.rollupKey(sl, x.CountKey(attr, 1, false)))
+	handover(sl, 0)
+
+	require.NotContains(t, countUids(t, attr, 1), uint64(4))
+}
+
+func TestRecountStrict(t *testing.T) {
+	require.NoError(t, schema.ParseBytes([]byte(countSchemaVal), 1))
+	attr := x.GalaxyAttr("friends")
+	ctx := context.Background()
+
+	// Two concurrent txns add an edge to node 1, both read that it has none.
+	mutate := func(startTs, uid uint64) *Txn {
+		txn, _ := Oracle().RegisterStartTs(startTs)
+		l, err := txn.Get(x.DataKey(attr, 1))
+		require.NoError(t, err)
+		edge := &pb.DirectedEdge{Entity: 1, ValueId: uid, Attr: attr, Op: pb.DirectedEdge_SET}
+		require.NoError(t, l.AddMutationWithIndex(ctx, edge, txn))
+		txn.Update(ctx)
+		return txn
+	}
+	txn1, txn2 := mutate(21, 2), mutate(22, 3)
+
+	r := NewRecounter()
+	require.NoError(t, r.Recount(txn1, 23))
+	require.NoError(t, r.Recount(txn2, 24))
+	handover(txn1.Skiplist(), 23)
+	handover(txn2.Skiplist(), 24)
+
+	require.Empty(t, countUids(t, attr, 1))
+	require.Equal(t, []uint64{1}, countUids(t, attr, 2))
+}
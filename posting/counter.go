@@ -0,0 +1,93 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package posting
+
+import (
+	"encoding/binary"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/golang/protobuf/proto"
+)
+
+// The values set on a counter predicate are increments, kept in the mutation layers as postings
+// with the Inc op. They don't conflict with each other, as they don't depend on the value they
+// are added to. The increments are summed with the posting below them when the postings are
+// picked, so the reads and the rollups see the value of the counter. A deleted value resets the
+// counter.
+
+// counterValue returns the value of a posting of a counter, which is 0 if it isn't an int.
+func counterValue(p *pb.Posting) int64 {
+	if p.ValType != pb.Posting_INT || len(p.Value) != 8 {
+		return 0
+	}
+	return int64(binary.LittleEndian.Uint64(p.Value))
+}
+
+// increment returns the posting of a counter once the increment is added to the posting below
+// it, which is nil if there is none. The result is an increment if the posting below is one, and
+// otherwise holds the value of the counter, a deleted value counting as 0.
+func increment(inc, below *pb.Posting) *pb.Posting {
+	out := proto.Clone(inc).(*pb.Posting)
+	switch {
+	case below == nil || below.Op == Del:
+		out.Op = Set
+		return out
+	case below.Op != Inc:
+		out.Op = Set
+	}
+	out.ValType = pb.Posting_INT
+	out.Value = make([]byte, 8)
+	binary.LittleEndian.PutUint64(out.Value, uint64(counterValue(below)+counterValue(inc)))
+	return out
+}
+
+// foldIncrements replaces the latest postings of each uid which are increments with their sum,
+// added to the posting below them if any. The postings must be sorted by uid, and then from the
+// latest to the oldest. The sum is still an increment if there is no posting below it in the
+// mutation layers, as it's then added to the immutable layer by iterate.
+func foldIncrements(posts []*pb.Posting) []*pb.Posting {
+	res := posts[:0]
+	for i := 0; i < len(posts); {
+		j := i + 1
+		for j < len(posts) && posts[j].Uid == posts[i].Uid {
+			j++
+		}
+		if posts[i].Op != Inc {
+			// Only the latest posting of the uid is read.
+			res = append(res, posts[i])
+			i = j
+			continue
+		}
+
+		m := i + 1
+		for m < j && posts[m].Op == Inc {
+			m++
+		}
+		var sum *pb.Posting
+		if m < j {
+			sum = increment(posts[m-1], posts[m])
+		} else {
+			sum = posts[m-1]
+		}
+		for k := m - 2; k >= i; k-- {
+			sum = increment(posts[k], sum)
+		}
+		res = append(res, sum)
+		i = j
+	}
+	return res
+}
@@ -0,0 +1,143 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package posting
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/pkg/errors"
+)
+
+// tsSample records the max assigned timestamp seen at a point in time.
+type tsSample struct {
+	at time.Time
+	ts uint64
+}
+
+// history keeps track of the timestamps which can still be read by time-travel queries. Zero
+// timestamps carry no wall clock, so the max assigned timestamp is sampled as the Oracle deltas
+// are applied, with a granularity of a second. The samples are kept in memory and only cover the
+// retention window, which is given by --limit "history=<duration>;".
+type history struct {
+	sync.Mutex
+	samples []tsSample
+}
+
+// record samples the max assigned ts at the given time, and drops the samples which are no longer
+// needed to resolve the reads within the retention window.
+func (h *history) record(now time.Time, ts uint64, retention time.Duration) {
+	h.Lock()
+	defer h.Unlock()
+
+	if n := len(h.samples); n > 0 && now.Sub(h.samples[n-1].at) < time.Second {
+		return
+	}
+	h.samples = append(h.samples, tsSample{at: now, ts: ts})
+
+	// Keep the last sample taken before the retention window, as it gives the ts of the start
+	// of the window.
+	cutoff := now.Add(-retention)
+	i := 0
+	for i+1 < len(h.samples) && !h.samples[i+1].at.After(cutoff) {
+		i++
+	}
+	h.samples = h.samples[i:]
+}
+
+// floor returns the oldest ts which can still be read, or false if there are no samples yet.
+func (h *history) floor() (uint64, bool) {
+	h.Lock()
+	defer h.Unlock()
+	if len(h.samples) == 0 {
+		return 0, false
+	}
+	return h.samples[0].ts, true
+}
+
+// tsAt returns the max assigned ts at the given time, or false if the time is older than the
+// samples.
+func (h *history) tsAt(t time.Time) (uint64, bool) {
+	h.Lock()
+	defer h.Unlock()
+	for i := len(h.samples) - 1; i >= 0; i-- {
+		if !h.samples[i].at.After(t) {
+			return h.samples[i].ts, true
+		}
+	}
+	return 0, false
+}
+
+// HistoryFloor returns the oldest read ts which is retained for time-travel queries. It returns
+// MaxAssigned if time-travel queries are disabled, or no history has been sampled yet.
+func (o *oracle) HistoryFloor() uint64 {
+	if x.Config.HistoryRetention == 0 {
+		return o.MaxAssigned()
+	}
+	if ts, ok := o.history.floor(); ok {
+		return ts
+	}
+	return o.MaxAssigned()
+}
+
+// DiscardTs returns the ts below which the earlier versions of keys can be discarded, given the
+// read ts of a snapshot. Rollups write complete posting lists with the discard bit set, which
+// Badger only honours for versions at or below the discard ts. So, capping the discard ts at the
+// history floor keeps the versions needed by time-travel queries.
+func (o *oracle) DiscardTs(readTs uint64) uint64 {
+	if x.Config.HistoryRetention == 0 {
+		return readTs
+	}
+	return x.Min(readTs, o.HistoryFloor())
+}
+
+// ResolveAsOf returns the read ts of a time-travel query, given its asOf value. The value is
+// either a commit ts, or a time in RFC3339 format.
+func (o *oracle) ResolveAsOf(asOf string) (uint64, error) {
+	if x.Config.HistoryRetention == 0 {
+		return 0, errors.Errorf("Time-travel queries are disabled. " +
+			`Set --limit "history=<duration>;" to enable them.`)
+	}
+
+	maxAssigned := o.MaxAssigned()
+	ts, err := strconv.ParseUint(asOf, 0, 64)
+	if err != nil {
+		t, terr := time.Parse(time.RFC3339Nano, asOf)
+		if terr != nil {
+			return 0, errors.Errorf("Invalid asOf value: %q. It must be a timestamp "+
+				"or a time in RFC3339 format.", asOf)
+		}
+		if t.After(time.Now()) {
+			return 0, errors.Errorf("asOf: %s is in the future", asOf)
+		}
+		var ok bool
+		if ts, ok = o.history.tsAt(t); !ok {
+			return 0, errors.Errorf("asOf: %s is older than the retained history", asOf)
+		}
+	}
+
+	if ts > maxAssigned {
+		return 0, errors.Errorf("asOf: %d is ahead of the max assigned ts: %d", ts, maxAssigned)
+	}
+	if floor := o.HistoryFloor(); ts < floor {
+		return 0, errors.Errorf("asOf: %s is older than the retained history, "+
+			"which starts at ts: %d", asOf, floor)
+	}
+	return ts, nil
+}
@@ -0,0 +1,51 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package posting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistorySamples(t *testing.T) {
+	var h history
+	_, ok := h.floor()
+	require.False(t, ok)
+
+	start := time.Now()
+	retention := 10 * time.Second
+	for i := 0; i < 30; i++ {
+		h.record(start.Add(time.Duration(i)*time.Second), uint64(100+i), retention)
+		// Samples within a second of the last one are skipped.
+		h.record(start.Add(time.Duration(i)*time.Second+time.Millisecond), uint64(1000+i),
+			retention)
+	}
+
+	// The last sample before the retention window is kept as the floor.
+	floor, ok := h.floor()
+	require.True(t, ok)
+	require.Equal(t, uint64(119), floor)
+
+	ts, ok := h.tsAt(start.Add(25*time.Second + 500*time.Millisecond))
+	require.True(t, ok)
+	require.Equal(t, uint64(125), ts)
+
+	_, ok = h.tsAt(start.Add(5 * time.Second))
+	require.False(t, ok)
+}
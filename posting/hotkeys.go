@@ -0,0 +1,117 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package posting
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	ostats "go.opencensus.io/stats"
+
+	"github.com/dgraph-io/dgraph/x"
+)
+
+const (
+	// hotKeyMinWait is the wait for the lock of a posting list from which the wait is recorded.
+	// Shorter waits are common under load and don't point at a contended key.
+	hotKeyMinWait = time.Millisecond
+	// maxHotKeys bounds the number of keys tracked.
+	maxHotKeys = 1000
+)
+
+// HotKey holds the waits for the lock of a posting list when mutating it. The keys with the
+// longest waits are the supernodes and the counters contended by concurrent mutations.
+type HotKey struct {
+	Key []byte
+	// Waits is the number of mutations which waited for the lock for at least a millisecond.
+	Waits     uint64
+	TotalWait time.Duration
+	MaxWait   time.Duration
+	LastWait  time.Time
+}
+
+// hotKeys keeps the waits of the keys since the start or since they were reset. When all the
+// entries are taken, the key with the shortest total wait is evicted to make room for a new one.
+var hotKeys struct {
+	sync.Mutex
+	keys  map[string]*HotKey
+	since time.Time
+}
+
+// recordLockWait records the wait for the lock of the list, if it's long enough.
+func recordLockWait(key []byte, wait time.Duration) {
+	if wait < hotKeyMinWait {
+		return
+	}
+	ostats.Record(context.Background(), x.ContendedPostingLocks.M(1))
+
+	now := time.Now()
+	hotKeys.Lock()
+	defer hotKeys.Unlock()
+	if hotKeys.keys == nil {
+		hotKeys.keys = make(map[string]*HotKey)
+		hotKeys.since = now
+	}
+	hk, ok := hotKeys.keys[string(key)]
+	if !ok {
+		if len(hotKeys.keys) >= maxHotKeys {
+			var coldest string
+			var min time.Duration
+			for k, other := range hotKeys.keys {
+				if min == 0 || other.TotalWait < min {
+					coldest, min = k, other.TotalWait
+				}
+			}
+			delete(hotKeys.keys, coldest)
+		}
+		hk = &HotKey{Key: append([]byte{}, key...)}
+		hotKeys.keys[string(key)] = hk
+	}
+	hk.Waits++
+	hk.TotalWait += wait
+	if wait > hk.MaxWait {
+		hk.MaxWait = wait
+	}
+	hk.LastWait = now
+}
+
+// HotKeys returns the n keys with the longest total wait for their lock, the longest first, and
+// the time since which the waits were recorded. All the keys are returned if n is 0.
+func HotKeys(n int) ([]HotKey, time.Time) {
+	hotKeys.Lock()
+	defer hotKeys.Unlock()
+	res := make([]HotKey, 0, len(hotKeys.keys))
+	for _, hk := range hotKeys.keys {
+		res = append(res, *hk)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].TotalWait > res[j].TotalWait })
+	if n > 0 && n < len(res) {
+		res = res[:n]
+	}
+	return res, hotKeys.since
+}
+
+// ResetHotKeys forgets the waits recorded so far, e.g. to observe the effect of a change to the
+// data model.
+func ResetHotKeys() {
+	hotKeys.Lock()
+	defer hotKeys.Unlock()
+	hotKeys.keys = nil
+	hotKeys.since = time.Now()
+}
@@ -0,0 +1,70 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package posting
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/x"
+)
+
+func TestHotKeys(t *testing.T) {
+	ResetHotKeys()
+	defer ResetHotKeys()
+
+	hot := x.DataKey(x.GalaxyAttr("counter"), 1)
+	warm := x.DataKey(x.GalaxyAttr("friend"), 2)
+	recordLockWait(hot, 10*time.Millisecond)
+	recordLockWait(hot, 30*time.Millisecond)
+	recordLockWait(warm, 5*time.Millisecond)
+	// Short waits aren't recorded.
+	recordLockWait(warm, time.Microsecond)
+	recordLockWait(x.DataKey(x.GalaxyAttr("name"), 3), time.Microsecond)
+
+	keys, since := HotKeys(0)
+	require.False(t, since.IsZero())
+	require.Len(t, keys, 2)
+	require.Equal(t, hot, keys[0].Key)
+	require.Equal(t, uint64(2), keys[0].Waits)
+	require.Equal(t, 40*time.Millisecond, keys[0].TotalWait)
+	require.Equal(t, 30*time.Millisecond, keys[0].MaxWait)
+	require.Equal(t, warm, keys[1].Key)
+	require.Equal(t, uint64(1), keys[1].Waits)
+
+	keys, _ = HotKeys(1)
+	require.Len(t, keys, 1)
+	require.Equal(t, hot, keys[0].Key)
+
+	// Once full, the coldest key is evicted for a new one.
+	for i := 0; i < maxHotKeys; i++ {
+		recordLockWait(x.DataKey(x.GalaxyAttr(fmt.Sprintf("pred%d", i)), 1), 6*time.Millisecond)
+	}
+	keys, _ = HotKeys(0)
+	require.Len(t, keys, maxHotKeys)
+	require.Equal(t, hot, keys[0].Key)
+	for _, hk := range keys {
+		require.NotEqual(t, warm, hk.Key)
+	}
+
+	ResetHotKeys()
+	keys, _ = HotKeys(0)
+	require.Empty(t, keys)
+}
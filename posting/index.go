@@ -29,16 +29,19 @@ import (
 	"time"
 
 	"github.com/golang/glog"
+	"github.com/golang/protobuf/proto"
 	"github.com/pkg/errors"
 	otrace "go.opencensus.io/trace"
 
 	"github.com/dgraph-io/badger/v3"
 	"github.com/dgraph-io/badger/v3/options"
 	bpb "github.com/dgraph-io/badger/v3/pb"
+	"github.com/dgraph-io/dgo/v210/protos/api"
 	"github.com/dgraph-io/dgraph/protos/pb"
 	"github.com/dgraph-io/dgraph/schema"
 	"github.com/dgraph-io/dgraph/tok"
 	"github.com/dgraph-io/dgraph/types"
+	"github.com/dgraph-io/dgraph/types/facets"
 	"github.com/dgraph-io/dgraph/x"
 	"github.com/dgraph-io/ristretto/z"
 )
@@ -50,6 +53,9 @@ type indexMutationInfo struct {
 	edge       *pb.DirectedEdge // Represents the original uid -> value edge.
 	val        types.Val
 	op         pb.DirectedEdge_Op
+	// keepTypes are the types still implied by the other types of the node, when a value of
+	// dgraph.type is deleted. Their index entries are kept.
+	keepTypes map[string]bool
 }
 
 // indexTokens return tokens, without the predicate prefix and
@@ -82,6 +88,76 @@ func indexTokens(ctx context.Context, info *indexMutationInfo) ([]string, error)
 	return tokens, nil
 }
 
+// isTypePredicate returns whether the predicate is the dgraph.type predicate of a namespace.
+func isTypePredicate(attr string) bool {
+	return x.ParseAttr(attr) == "dgraph.type"
+}
+
+// typeTokens returns the index tokens of a value of dgraph.type, for its type and the types it
+// extends. The type index thus makes type() match the nodes of the subtypes of a type. The types
+// in info.keepTypes are left out.
+func typeTokens(ctx context.Context, info *indexMutationInfo) ([]string, error) {
+	sv, err := types.Convert(info.val, types.StringID)
+	if err != nil {
+		return nil, err
+	}
+	name := sv.Value.(string)
+	ns := x.ParseNamespace(info.edge.Attr)
+	names := []string{name}
+	for _, typ := range schema.State().Supertypes(x.NamespaceAttr(ns, name)) {
+		names = append(names, x.ParseAttr(typ))
+	}
+
+	var tokens []string
+	for _, name := range names {
+		if info.keepTypes[name] {
+			continue
+		}
+		toks, err := indexTokens(ctx, &indexMutationInfo{
+			tokenizers: info.tokenizers,
+			edge:       info.edge,
+			val:        types.Val{Tid: types.StringID, Value: name},
+		})
+		if err != nil {
+			return tokens, err
+		}
+		tokens = append(tokens, toks...)
+	}
+	return tokens, nil
+}
+
+// impliedTypes returns the types of the node of the dgraph.type list, and the types they extend.
+func (l *List) impliedTypes(attr string, readTs uint64) (map[string]bool, error) {
+	vals, err := l.AllUntaggedValues(readTs)
+	if err != nil {
+		return nil, err
+	}
+	ns := x.ParseNamespace(attr)
+	res := make(map[string]bool)
+	for _, val := range vals {
+		sv, err := types.Convert(val, types.StringID)
+		if err != nil {
+			continue
+		}
+		name := sv.Value.(string)
+		res[name] = true
+		for _, typ := range schema.State().Supertypes(x.NamespaceAttr(ns, name)) {
+			res[x.ParseAttr(typ)] = true
+		}
+	}
+	return res, nil
+}
+
+// IndexTokens returns the index tokens of the value of the predicate in the language lang, for
+// the tokenizers of the predicate, the same way as the mutations index them.
+func IndexTokens(ctx context.Context, attr, lang string, val types.Val) ([]string, error) {
+	return indexTokens(ctx, &indexMutationInfo{
+		tokenizers: schema.State().Tokenizer(ctx, attr),
+		edge:       &pb.DirectedEdge{Attr: attr, Lang: lang},
+		val:        val,
+	})
+}
+
 // addIndexMutations adds mutation(s) for a single term, to maintain the index,
 // but only for the given tokenizers.
 // TODO - See if we need to pass op as argument as t should already have Op.
@@ -95,7 +171,13 @@ func (txn *Txn) addIndexMutations(ctx context.Context, info *indexMutationInfo)
 	if uid == 0 {
 		return errors.New("invalid UID with value 0")
 	}
-	tokens, err := indexTokens(ctx, info)
+	var tokens []string
+	var err error
+	if isTypePredicate(attr) {
+		tokens, err = typeTokens(ctx, info)
+	} else {
+		tokens, err = indexTokens(ctx, info)
+	}
 	if err != nil {
 		// This data is not indexable
 		return err
@@ -127,6 +209,75 @@ func (txn *Txn) addIndexMutation(ctx context.Context, edge *pb.DirectedEdge, tok
 	return plist.addMutation(ctx, txn, edge)
 }
 
+// validSinceForever is the validFrom facet indexed for the edges without one.
+var validSinceForever = func() *api.Facet {
+	f, err := facets.ToBinary(facets.ValidFrom, time.Time{}, api.Facet_DATETIME)
+	x.Check(err)
+	return f
+}()
+
+// addFacetIndexMutations adds or deletes the facet index entries of the posting p of the subject,
+// for the indexed facet keys.
+func (txn *Txn) addFacetIndexMutations(ctx context.Context, attr string, subject uint64,
+	p *pb.Posting, keys []string, op pb.DirectedEdge_Op) error {
+	if p == nil || p.Uid == 0 {
+		return nil
+	}
+	pfacets := facets.PostingFacets(p)
+	if p.ValidFrom == 0 && x.HasString(keys, facets.ValidFrom) {
+		// The edges valid since forever are indexed at the zero time, so that the edges valid at
+		// a time are all found by scanning the validFrom index up to that time.
+		pfacets = append(pfacets, validSinceForever)
+	}
+	if len(pfacets) == 0 {
+		return nil
+	}
+	edge := &pb.DirectedEdge{
+		ValueId: p.Uid,
+		Attr:    attr,
+		Op:      op,
+	}
+	for _, f := range pfacets {
+		if !x.HasString(keys, f.Key) {
+			continue
+		}
+		val, err := facets.ValFor(f)
+		if err != nil {
+			return err
+		}
+		token, err := facets.IndexToken(f.Key, subject, val)
+		if err != nil {
+			return err
+		}
+		if err := txn.addIndexMutation(ctx, edge, token); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// facetIndexedPostings returns the postings whose facet index entries are replaced by the edge.
+func (l *List) facetIndexedPostings(readTs uint64, edge *pb.DirectedEdge) ([]*pb.Posting,
+	error) {
+	l.RLock()
+	defer l.RUnlock()
+
+	var postings []*pb.Posting
+	if edge.Op == pb.DirectedEdge_SET && !schema.State().IsList(edge.Attr) {
+		// Setting a single uid replaces the current one.
+		err := l.iterate(readTs, 0, func(p *pb.Posting) error {
+			postings = append(postings, proto.Clone(p).(*pb.Posting))
+			return nil
+		})
+		return postings, err
+	}
+	found, p, err := l.findPosting(readTs, edge.ValueId)
+	if err != nil || !found {
+		return nil, err
+	}
+	return append(postings, proto.Clone(p).(*pb.Posting)), nil
+}
+
 // countParams is sent to updateCount function. It is used to update the count index.
 // It deletes the uid from the key corresponding to <attr, countBefore> and adds it
 // to <attr, countAfter>.
@@ -143,8 +294,10 @@ func (txn *Txn) addReverseMutationHelper(ctx context.Context, plist *List,
 	countBefore, countAfter := 0, 0
 	found := false
 
+	t1 := time.Now()
 	plist.Lock()
 	defer plist.Unlock()
+	recordLockWait(plist.key, time.Since(t1))
 	if hasCountIndex {
 		countBefore, found, _ = plist.getPostingAndLength(txn.StartTs, 0, edge.ValueId)
 		if countBefore == -1 {
@@ -296,6 +449,16 @@ func (l *List) handleDeleteAll(ctx context.Context, edge *pb.DirectedEdge, txn *
 	if err != nil {
 		return err
 	}
+	if keys := schema.State().FacetIndexes(ctx, edge.Attr); len(keys) > 0 {
+		// Delete the facet index entries of each posting.
+		err := l.Iterate(txn.StartTs, 0, func(p *pb.Posting) error {
+			return txn.addFacetIndexMutations(ctx, edge.Attr, edge.Entity, p, keys,
+				pb.DirectedEdge_DEL)
+		})
+		if err != nil {
+			return err
+		}
+	}
 	if hasCount {
 		// Delete uid from count index. Deletion of reverses is taken care by addReverseMutation
 		// above.
@@ -308,6 +471,12 @@ func (l *List) handleDeleteAll(ctx context.Context, edge *pb.DirectedEdge, txn *
 			return err
 		}
 	}
+	if pstore != nil && schema.State().HasPresence(ctx, edge.Attr) {
+		if err := txn.addPresenceMutation(ctx, edge.Attr, edge.Entity,
+			pb.DirectedEdge_DEL); err != nil {
+			return err
+		}
+	}
 
 	return l.addMutation(ctx, txn, edge)
 }
@@ -371,6 +540,7 @@ func (txn *Txn) addMutationHelper(ctx context.Context, l *List, doUpdateIndex bo
 		span := otrace.FromContext(ctx)
 		span.Annotatef([]otrace.Attribute{otrace.BoolAttribute("slow-lock", true)},
 			"Acquired lock %v %v %v", dur, t.Attr, t.Entity)
+		recordLockWait(l.key, dur)
 	}
 
 	getUID := func(t *pb.DirectedEdge) uint64 {
@@ -456,6 +626,7 @@ func (l *List) AddMutationWithIndex(ctx context.Context, edge *pb.DirectedEdge,
 
 	doUpdateIndex := pstore != nil && schema.State().IsIndexed(ctx, edge.Attr)
 	hasCountIndex := schema.State().HasCount(ctx, edge.Attr)
+	hasPresence := pstore != nil && schema.State().HasPresence(ctx, edge.Attr)
 
 	// Add reverse mutation irrespective of hasMutated, server crash can happen after
 	// mutation is synced and before reverse edge is synced
@@ -465,24 +636,66 @@ func (l *List) AddMutationWithIndex(ctx context.Context, edge *pb.DirectedEdge,
 		}
 	}
 
-	val, found, cp, err := txn.addMutationHelper(ctx, l, doUpdateIndex, hasCountIndex, edge)
+	// The facet index entries of the postings replaced by the edge are deleted, and the entries
+	// of its facets are added.
+	var facetKeys []string
+	var facetPostings []*pb.Posting
+	if pstore != nil && edge.ValueId != 0 {
+		facetKeys = schema.State().FacetIndexes(ctx, edge.Attr)
+	}
+	if len(facetKeys) > 0 {
+		var err error
+		if facetPostings, err = l.facetIndexedPostings(txn.StartTs, edge); err != nil {
+			return err
+		}
+	}
+
+	// The presence list needs the lengths of the list before and after the mutation, as the count
+	// index does.
+	val, found, cp, err := txn.addMutationHelper(ctx, l, doUpdateIndex,
+		hasCountIndex || hasPresence, edge)
 	if err != nil {
 		return err
 	}
+	for _, p := range facetPostings {
+		if err := txn.addFacetIndexMutations(ctx, edge.Attr, edge.Entity, p, facetKeys,
+			pb.DirectedEdge_DEL); err != nil {
+			return err
+		}
+	}
+	if len(facetKeys) > 0 && edge.Op == pb.DirectedEdge_SET {
+		p := NewPosting(edge)
+		if err := txn.addFacetIndexMutations(ctx, edge.Attr, edge.Entity, p, facetKeys,
+			pb.DirectedEdge_SET); err != nil {
+			return err
+		}
+	}
 	if hasCountIndex && cp.countAfter != cp.countBefore {
 		if err := txn.updateCount(ctx, cp); err != nil {
 			return err
 		}
 	}
+	if hasPresence {
+		if err := txn.updatePresence(ctx, cp); err != nil {
+			return err
+		}
+	}
 	if doUpdateIndex {
 		// Exact matches.
 		if found && val.Value != nil {
-			if err := txn.addIndexMutations(ctx, &indexMutationInfo{
+			info := &indexMutationInfo{
 				tokenizers: schema.State().Tokenizer(ctx, edge.Attr),
 				edge:       edge,
 				val:        val,
 				op:         pb.DirectedEdge_DEL,
-			}); err != nil {
+			}
+			if isTypePredicate(edge.Attr) {
+				// The types implied by the remaining types of the node stay in the index.
+				if info.keepTypes, err = l.impliedTypes(edge.Attr, txn.StartTs); err != nil {
+					return err
+				}
+			}
+			if err := txn.addIndexMutations(ctx, info); err != nil {
 				return err
 			}
 		}
@@ -583,6 +796,7 @@ func (r *rebuilder) Run(ctx context.Context) error {
 	// Counter is used here to ensure that all keys are committed at different timestamp.
 	// We set it to 1 in case there are no keys found and NewStreamAt is called with ts=0.
 	var counter uint64 = 1
+	build := runningIndexBuild(r.attr)
 
 	tmpWriter := tmpDB.NewManagedWriteBatch()
 	stream := pstore.NewStreamAt(r.startTs)
@@ -613,6 +827,9 @@ func (r *rebuilder) Run(ctx context.Context) error {
 		if err := r.fn(pk.Uid, l, txn); err != nil {
 			return nil, err
 		}
+		if build != nil {
+			atomic.AddUint64(&build.progress, 1)
+		}
 
 		// Convert data into deltas.
 		txn.Update(ctx)
@@ -691,7 +908,7 @@ func (r *rebuilder) Run(ctx context.Context) error {
 			e := &badger.Entry{
 				Key:      kv.Key,
 				Value:    kv.Value,
-				UserMeta: BitCompletePosting,
+				UserMeta: kv.UserMeta[0],
 			}
 			if err := writer.SetEntryAt(e.WithDiscard(), r.startTs); err != nil {
 				return errors.Wrap(err, "error in writing index to pstore")
@@ -749,9 +966,22 @@ func (rb *IndexRebuild) GetQuerySchema() *pb.SchemaUpdate {
 	if rb.needsCountIndexRebuild() == indexRebuild {
 		querySchema.Count = false
 	}
+	if rb.needsPresenceRebuild() == indexRebuild {
+		querySchema.Presence = false
+	}
 	if rb.needsReverseEdgesRebuild() == indexRebuild {
 		querySchema.Directive = pb.SchemaUpdate_NONE
 	}
+	if info := rb.needsFacetIndexRebuild(); info.op == indexRebuild {
+		// Only the facet keys whose index is kept as is can be served.
+		var keys []string
+		for _, key := range querySchema.FacetIndex {
+			if !x.HasString(info.tokenizersToRebuild, key) {
+				keys = append(keys, key)
+			}
+		}
+		querySchema.FacetIndex = keys
+	}
 	return &querySchema
 }
 
@@ -763,6 +993,8 @@ func (rb *IndexRebuild) DropIndexes(ctx context.Context) error {
 	}
 	prefixes = append(prefixes, prefixesToDropReverseEdges(ctx, rb)...)
 	prefixes = append(prefixes, prefixesToDropCountIndex(ctx, rb)...)
+	prefixes = append(prefixes, prefixesToDropFacetIndex(ctx, rb)...)
+	prefixes = append(prefixes, prefixesToDropPresence(ctx, rb)...)
 	glog.Infof("Deleting indexes for %s", rb.Attr)
 	return pstore.DropPrefix(prefixes...)
 }
@@ -777,17 +1009,33 @@ func (rb *IndexRebuild) BuildData(ctx context.Context) error {
 func (rb *IndexRebuild) NeedIndexRebuild() bool {
 	return rb.needsTokIndexRebuild().op == indexRebuild ||
 		rb.needsReverseEdgesRebuild() == indexRebuild ||
-		rb.needsCountIndexRebuild() == indexRebuild
+		rb.needsCountIndexRebuild() == indexRebuild ||
+		rb.needsFacetIndexRebuild().op == indexRebuild ||
+		rb.needsPresenceRebuild() == indexRebuild
 }
 
-// BuildIndexes builds indexes.
+// BuildIndexes builds indexes. The rebuild is recorded under the index state key of the predicate
+// until it succeeds, see IndexStateOf.
 func (rb *IndexRebuild) BuildIndexes(ctx context.Context) error {
+	if err := startIndexBuild(rb.Attr, rb.StartTs); err != nil {
+		return errors.Wrapf(err, "while recording the index state of %s", rb.Attr)
+	}
+	return finishIndexBuild(rb.Attr, rb.StartTs, rb.buildIndexes(ctx))
+}
+
+func (rb *IndexRebuild) buildIndexes(ctx context.Context) error {
 	if err := rebuildTokIndex(ctx, rb); err != nil {
 		return err
 	}
 	if err := rebuildReverseEdges(ctx, rb); err != nil {
 		return err
 	}
+	if err := rebuildFacetIndex(ctx, rb); err != nil {
+		return err
+	}
+	if err := rebuildPresence(ctx, rb); err != nil {
+		return err
+	}
 	return rebuildCountIndex(ctx, rb)
 }
 
@@ -1143,6 +1391,158 @@ func rebuildReverseEdges(ctx context.Context, rb *IndexRebuild) error {
 	return builder.Run(ctx)
 }
 
+// needsFacetIndexRebuild returns the facet keys whose index needs to be deleted or rebuilt. The
+// tokenizer fields of the returned info hold the facet keys.
+func (rb *IndexRebuild) needsFacetIndexRebuild() indexRebuildInfo {
+	x.AssertTruef(rb.CurrentSchema != nil, "Current schema cannot be nil.")
+
+	// If old schema is nil, treat it as an empty schema. Copy it to avoid
+	// overwriting it in rb.
+	old := rb.OldSchema
+	if old == nil {
+		old = &pb.SchemaUpdate{}
+	}
+
+	prevKeys := make(map[string]struct{})
+	for _, key := range old.FacetIndex {
+		prevKeys[key] = struct{}{}
+	}
+	currKeys := make(map[string]struct{})
+	for _, key := range rb.CurrentSchema.FacetIndex {
+		currKeys[key] = struct{}{}
+	}
+	newKeys, deletedKeys := x.Diff(currKeys, prevKeys)
+	switch {
+	case len(newKeys) > 0:
+		return indexRebuildInfo{
+			op:                  indexRebuild,
+			tokenizersToDelete:  deletedKeys,
+			tokenizersToRebuild: newKeys,
+		}
+	case len(deletedKeys) > 0:
+		return indexRebuildInfo{
+			op:                 indexDelete,
+			tokenizersToDelete: deletedKeys,
+		}
+	default:
+		return indexRebuildInfo{
+			op: indexNoop,
+		}
+	}
+}
+
+func prefixesToDropFacetIndex(ctx context.Context, rb *IndexRebuild) [][]byte {
+	info := rb.needsFacetIndexRebuild()
+	if info.op == indexNoop {
+		return nil
+	}
+
+	var prefixes [][]byte
+	pk := x.ParsedKey{Attr: rb.Attr}
+	keys := append(append([]string{}, info.tokenizersToDelete...), info.tokenizersToRebuild...)
+	for _, key := range keys {
+		prefix := append(pk.IndexPrefix(), facets.KeyPrefix(key)...)
+		prefixes = append(prefixes, prefix)
+
+		// All the parts of any list that has been split into multiple parts.
+		// Such keys have a different prefix (the last byte is set to 1).
+		prefix = append(pk.IndexPrefix(), facets.KeyPrefix(key)...)
+		prefix[0] = x.ByteSplit
+		prefixes = append(prefixes, prefix)
+	}
+	return prefixes
+}
+
+// rebuildFacetIndex rebuilds the index of the new facet keys of a given attribute.
+func rebuildFacetIndex(ctx context.Context, rb *IndexRebuild) error {
+	info := rb.needsFacetIndexRebuild()
+	if info.op != indexRebuild {
+		return nil
+	}
+
+	glog.Infof("Rebuilding facet index for attr %s and facet keys %s", rb.Attr,
+		info.tokenizersToRebuild)
+	pk := x.ParsedKey{Attr: rb.Attr}
+	builder := rebuilder{attr: rb.Attr, prefix: pk.DataPrefix(), startTs: rb.StartTs}
+	builder.fn = func(uid uint64, pl *List, txn *Txn) error {
+		return pl.Iterate(txn.StartTs, 0, func(p *pb.Posting) error {
+			for {
+				err := txn.addFacetIndexMutations(ctx, rb.Attr, uid, p,
+					info.tokenizersToRebuild, pb.DirectedEdge_SET)
+				switch err {
+				case ErrRetry:
+					time.Sleep(10 * time.Millisecond)
+				default:
+					return err
+				}
+			}
+		})
+	}
+	return builder.Run(ctx)
+}
+
+func (rb *IndexRebuild) needsPresenceRebuild() indexOp {
+	x.AssertTruef(rb.CurrentSchema != nil, "Current schema cannot be nil.")
+
+	// If old schema is nil, treat it as an empty schema. Copy it to avoid
+	// overwriting it in rb.
+	old := rb.OldSchema
+	if old == nil {
+		old = &pb.SchemaUpdate{}
+	}
+
+	switch {
+	case rb.CurrentSchema.Presence == old.Presence:
+		return indexNoop
+	case rb.CurrentSchema.Presence:
+		return indexRebuild
+	default:
+		return indexDelete
+	}
+}
+
+func prefixesToDropPresence(ctx context.Context, rb *IndexRebuild) [][]byte {
+	if rb.needsPresenceRebuild() == indexNoop {
+		return nil
+	}
+
+	prefixes := append([][]byte{}, PresenceKey(rb.Attr))
+
+	// All the parts of the presence list if it has been split into multiple parts.
+	// Such keys have a different prefix (the last byte is set to 1).
+	prefix := PresenceKey(rb.Attr)
+	prefix[0] = x.ByteSplit
+	prefixes = append(prefixes, prefix)
+
+	return prefixes
+}
+
+// rebuildPresence rebuilds the presence list of a given attribute.
+func rebuildPresence(ctx context.Context, rb *IndexRebuild) error {
+	if rb.needsPresenceRebuild() != indexRebuild {
+		return nil
+	}
+
+	glog.Infof("Rebuilding presence list for %s", rb.Attr)
+	pk := x.ParsedKey{Attr: rb.Attr}
+	builder := rebuilder{attr: rb.Attr, prefix: pk.DataPrefix(), startTs: rb.StartTs}
+	builder.fn = func(uid uint64, pl *List, txn *Txn) error {
+		if sz := pl.Length(txn.StartTs, 0); sz <= 0 {
+			return nil
+		}
+		for {
+			err := txn.addPresenceMutation(ctx, rb.Attr, uid, pb.DirectedEdge_SET)
+			switch err {
+			case ErrRetry:
+				time.Sleep(10 * time.Millisecond)
+			default:
+				return err
+			}
+		}
+	}
+	return builder.Run(ctx)
+}
+
 // needsListTypeRebuild returns true if the schema changed from a scalar to a
 // list. It returns true if the index can be left as is.
 func (rb *IndexRebuild) needsListTypeRebuild() (bool, error) {
@@ -1216,6 +1616,7 @@ func rebuildListType(ctx context.Context, rb *IndexRebuild) error {
 // DeleteAll deletes all entries in the posting list.
 func DeleteAll() error {
 	ResetCache()
+	deleteIndexStates(func(string) bool { return true })
 	return pstore.DropAll()
 }
 
@@ -1235,9 +1636,10 @@ func DeletePredicate(ctx context.Context, attr string, ts uint64) error {
 	// TODO: We should only delete cache for certain keys, not all the keys.
 	ResetCache()
 	prefix := x.PredicatePrefix(attr)
-	if err := pstore.DropPrefix(prefix); err != nil {
+	if err := pstore.DropPrefix(prefix, x.IndexStateKey(attr)); err != nil {
 		return err
 	}
+	deleteIndexStates(func(a string) bool { return a == attr })
 	return schema.State().Delete(attr, ts)
 }
 
@@ -1248,9 +1650,10 @@ func DeletePredicateBlocking(ctx context.Context, attr string, ts uint64) error
 	// TODO: We should only delete cache for certain keys, not all the keys.
 	ResetCache()
 	prefix := x.PredicatePrefix(attr)
-	if err := pstore.DropPrefixBlocking(prefix); err != nil {
+	if err := pstore.DropPrefixBlocking(prefix, x.IndexStateKey(attr)); err != nil {
 		return err
 	}
+	deleteIndexStates(func(a string) bool { return a == attr })
 	return schema.State().Delete(attr, ts)
 }
 
@@ -1259,5 +1662,6 @@ func DeleteNamespace(ns uint64) error {
 	// TODO: We should only delete cache for certain keys, not all the keys.
 	ResetCache()
 	schema.State().DeletePredsForNs(ns)
+	deleteIndexStates(func(attr string) bool { return x.ParseNamespace(attr) == ns })
 	return pstore.BanNamespace(ns)
 }
@@ -0,0 +1,170 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package posting
+
+import (
+	"encoding/binary"
+	"math"
+	"sync"
+	"sync/atomic"
+
+	"github.com/dgraph-io/badger/v3"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/golang/glog"
+)
+
+// The states of the indexes of a predicate, see IndexStateOf.
+const (
+	// IndexBuilt means that the indexes of the predicate are fully built.
+	IndexBuilt = "built"
+	// IndexBuilding means that the indexes of the predicate are being rebuilt.
+	IndexBuilding = "building"
+	// IndexStale means that the rebuild of the indexes of the predicate failed, or was interrupted
+	// by a crash. The indexes stay incomplete until they are rebuilt by another schema update.
+	IndexStale = "stale"
+)
+
+// indexBuild tracks the rebuild of the indexes of a predicate. Its record is written under
+// x.IndexStateKey when the rebuild starts, and removed once the rebuild succeeds.
+type indexBuild struct {
+	// progress is the number of posting lists processed by the rebuild so far. It's first in the
+	// struct to be aligned for the atomic operations.
+	progress uint64
+	// running is false if the rebuild failed, or was started before the Alpha restarted.
+	running bool
+}
+
+// indexBuilds holds the rebuilds of the predicates having an index state record.
+var indexBuilds struct {
+	sync.Mutex
+	m map[string]*indexBuild
+}
+
+// loadIndexStates reads the index state records left by the previous runs of the Alpha. Their
+// rebuilds didn't complete, so their indexes are stale.
+func loadIndexStates() error {
+	txn := pstore.NewTransactionAt(math.MaxUint64, false)
+	defer txn.Discard()
+	iopt := badger.DefaultIteratorOptions
+	iopt.PrefetchValues = false
+	iopt.Prefix = x.IndexStatePrefix()
+	itr := txn.NewIterator(iopt)
+	defer itr.Close()
+
+	indexBuilds.Lock()
+	defer indexBuilds.Unlock()
+	indexBuilds.m = make(map[string]*indexBuild)
+	for itr.Rewind(); itr.Valid(); itr.Next() {
+		attr, err := x.ParseIndexStateKey(itr.Item().Key())
+		if err != nil {
+			return err
+		}
+		glog.Warningf("The indexes of predicate %s weren't fully rebuilt and are stale", attr)
+		indexBuilds.m[attr] = &indexBuild{}
+	}
+	return nil
+}
+
+// writeIndexState writes the index state record of the predicate at the given ts, or deletes it.
+func writeIndexState(attr string, ts uint64, del bool) error {
+	txn := pstore.NewTransactionAt(ts, true)
+	defer txn.Discard()
+	var err error
+	if del {
+		err = txn.Delete(x.IndexStateKey(attr))
+	} else {
+		var val [8]byte
+		binary.BigEndian.PutUint64(val[:], ts)
+		err = txn.Set(x.IndexStateKey(attr), val[:])
+	}
+	if err != nil {
+		return err
+	}
+	return txn.CommitAt(ts, nil)
+}
+
+// startIndexBuild records that the indexes of the predicate are being rebuilt at the given ts.
+func startIndexBuild(attr string, ts uint64) error {
+	if err := writeIndexState(attr, ts, false); err != nil {
+		return err
+	}
+	indexBuilds.Lock()
+	defer indexBuilds.Unlock()
+	if indexBuilds.m == nil {
+		indexBuilds.m = make(map[string]*indexBuild)
+	}
+	indexBuilds.m[attr] = &indexBuild{running: true}
+	return nil
+}
+
+// finishIndexBuild removes the index state record of the predicate once its indexes are rebuilt.
+// If the rebuild failed, the record is kept and the indexes are reported as stale.
+func finishIndexBuild(attr string, ts uint64, buildErr error) error {
+	if buildErr != nil {
+		indexBuilds.Lock()
+		if b, ok := indexBuilds.m[attr]; ok {
+			b.running = false
+		}
+		indexBuilds.Unlock()
+		return nil
+	}
+	if err := writeIndexState(attr, ts, true); err != nil {
+		return err
+	}
+	indexBuilds.Lock()
+	delete(indexBuilds.m, attr)
+	indexBuilds.Unlock()
+	return nil
+}
+
+// runningIndexBuild returns the running rebuild of the indexes of the predicate, if any.
+func runningIndexBuild(attr string) *indexBuild {
+	indexBuilds.Lock()
+	defer indexBuilds.Unlock()
+	if b, ok := indexBuilds.m[attr]; ok && b.running {
+		return b
+	}
+	return nil
+}
+
+// deleteIndexStates forgets the rebuilds of the predicates matching the given function. Their
+// records are dropped along with the data of the predicates.
+func deleteIndexStates(match func(attr string) bool) {
+	indexBuilds.Lock()
+	defer indexBuilds.Unlock()
+	for attr := range indexBuilds.m {
+		if match(attr) {
+			delete(indexBuilds.m, attr)
+		}
+	}
+}
+
+// IndexStateOf returns the state of the indexes of the predicate, and the number of posting lists
+// processed so far if they're being rebuilt.
+func IndexStateOf(attr string) (string, uint64) {
+	indexBuilds.Lock()
+	defer indexBuilds.Unlock()
+	b, ok := indexBuilds.m[attr]
+	switch {
+	case !ok:
+		return IndexBuilt, 0
+	case !b.running:
+		return IndexStale, 0
+	default:
+		return IndexBuilding, atomic.LoadUint64(&b.progress)
+	}
+}
@@ -21,16 +21,19 @@ import (
 	"context"
 	"math"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/dgraph-io/badger/v3"
 	"github.com/dgraph-io/badger/v3/y"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 
 	"github.com/dgraph-io/dgraph/protos/pb"
 	"github.com/dgraph-io/dgraph/schema"
 	"github.com/dgraph-io/dgraph/types"
+	"github.com/dgraph-io/dgraph/types/facets"
 	"github.com/dgraph-io/dgraph/x"
 )
 
@@ -551,3 +554,85 @@ func TestNeedsListTypeRebuild(t *testing.T) {
 	require.False(t, rebuild)
 	require.Error(t, err)
 }
+
+func TestIndexState(t *testing.T) {
+	attr := x.GalaxyAttr("index_state")
+	state, _ := IndexStateOf(attr)
+	require.Equal(t, IndexBuilt, state)
+
+	require.NoError(t, startIndexBuild(attr, 5))
+	atomic.AddUint64(&runningIndexBuild(attr).progress, 3)
+	state, progress := IndexStateOf(attr)
+	require.Equal(t, IndexBuilding, state)
+	require.Equal(t, uint64(3), progress)
+
+	// The records left by the failed or interrupted rebuilds are reported as stale.
+	require.NoError(t, finishIndexBuild(attr, 5, errors.New("failed")))
+	state, _ = IndexStateOf(attr)
+	require.Equal(t, IndexStale, state)
+	require.Nil(t, runningIndexBuild(attr))
+	require.NoError(t, loadIndexStates())
+	state, _ = IndexStateOf(attr)
+	require.Equal(t, IndexStale, state)
+
+	require.NoError(t, startIndexBuild(attr, 7))
+	require.NoError(t, finishIndexBuild(attr, 7, nil))
+	state, _ = IndexStateOf(attr)
+	require.Equal(t, IndexBuilt, state)
+	require.NoError(t, loadIndexStates())
+	state, _ = IndexStateOf(attr)
+	require.Equal(t, IndexBuilt, state)
+}
+
+func TestTypeTokens(t *testing.T) {
+	require.NoError(t, schema.ParseBytes([]byte(`dgraph.type: [string] @index(exact) .`), 1))
+	for _, typ := range []pb.TypeUpdate{
+		{TypeName: x.GalaxyAttr("Animal")},
+		{TypeName: x.GalaxyAttr("Pet")},
+		{TypeName: x.GalaxyAttr("Dog"), Extends: []string{x.GalaxyAttr("Animal"),
+			x.GalaxyAttr("Pet")}},
+	} {
+		schema.State().SetType(typ.TypeName, typ)
+	}
+
+	info := &indexMutationInfo{
+		tokenizers: schema.State().Tokenizer(context.Background(), x.GalaxyAttr("dgraph.type")),
+		edge:       &pb.DirectedEdge{Attr: x.GalaxyAttr("dgraph.type"), Entity: 1},
+		val:        types.Val{Tid: types.StringID, Value: "Dog"},
+	}
+	tokens, err := typeTokens(context.Background(), info)
+	require.NoError(t, err)
+	require.Equal(t, []string{"\x02Dog", "\x02Animal", "\x02Pet"}, tokens)
+
+	// The types still implied by the other types of the node are left out.
+	info.keepTypes = map[string]bool{"Pet": true}
+	tokens, err = typeTokens(context.Background(), info)
+	require.NoError(t, err)
+	require.Equal(t, []string{"\x02Dog", "\x02Animal"}, tokens)
+}
+
+func TestFacetIndexValidSinceForever(t *testing.T) {
+	attr := x.GalaxyAttr("lived_in")
+	keys := []string{facets.ValidFrom}
+	token := func(subject uint64, at time.Time) string {
+		tok, err := facets.IndexToken(facets.ValidFrom, subject, types.Val{
+			Tid:   types.DateTimeID,
+			Value: at,
+		})
+		require.NoError(t, err)
+		return string(x.IndexKey(attr, tok))
+	}
+
+	// The edges without a validFrom facet are indexed at the zero time.
+	txn := NewTxn(5)
+	require.NoError(t, txn.addFacetIndexMutations(context.Background(), attr, 1,
+		&pb.Posting{Uid: 2}, keys, pb.DirectedEdge_SET))
+	require.Contains(t, txn.cache.plists, token(1, time.Time{}))
+
+	from := time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC)
+	txn = NewTxn(6)
+	require.NoError(t, txn.addFacetIndexMutations(context.Background(), attr, 1,
+		&pb.Posting{Uid: 3, ValidFrom: from.Unix()}, keys, pb.DirectedEdge_SET))
+	require.Contains(t, txn.cache.plists, token(1, from))
+	require.NotContains(t, txn.cache.plists, token(1, time.Time{}))
+}
@@ -23,6 +23,7 @@ import (
 	"log"
 	"math"
 	"sort"
+	"time"
 
 	"github.com/dgryski/go-farm"
 	"github.com/pkg/errors"
@@ -43,7 +44,7 @@ import (
 var (
 	// ErrRetry can be triggered if the posting list got deleted from memory due to a hard commit.
 	// In such a case, retry.
-	ErrRetry = errors.New("Temporary error. Please retry")
+	ErrRetry = x.WithErrorCode(errors.New("Temporary error. Please retry"), x.ErrorCodeRetryable)
 	// ErrNoValue would be returned if no value was found in the posting list.
 	ErrNoValue = errors.New("No value found")
 	// ErrStopIteration is returned when an iteration is terminated early.
@@ -57,6 +58,8 @@ const (
 	Set uint32 = 0x01
 	// Del means delete in mutation layer. It contributes -1 in Length.
 	Del uint32 = 0x02
+	// Inc means increment the value of a counter in mutation layer. It contributes 0 in Length.
+	Inc uint32 = 0x04
 
 	// BitSchemaPosting signals that the value stores a schema or type.
 	BitSchemaPosting byte = 0x01
@@ -66,6 +69,9 @@ const (
 	BitCompletePosting byte = 0x08
 	// BitEmptyPosting signals that the value stores an empty posting list.
 	BitEmptyPosting byte = 0x10
+	// BitChecksum signals that the value ends with the checksum of the rest of the value. It's
+	// set along with one of the bits above, see PostingMeta.
+	BitChecksum byte = 0x20
 )
 
 // List stores the in-memory representation of a posting list.
@@ -246,6 +252,10 @@ func NewPosting(t *pb.DirectedEdge) *pb.Posting {
 		Op:          op,
 		Facets:      t.Facets,
 	}
+	// The valid time is kept out of the facets. It was validated along with the edge.
+	if from, to, fs, err := facets.ValidTime(t.Facets); err == nil {
+		p.ValidFrom, p.ValidTo, p.Facets = from, to, fs
+	}
 	return p
 }
 
@@ -256,7 +266,7 @@ func hasDeleteAll(mpost *pb.Posting) bool {
 // Ensure that you either abort the uncommitted postings or commit them before calling me.
 func (l *List) updateMutationLayer(mpost *pb.Posting, singleUidUpdate bool) error {
 	l.AssertLock()
-	x.AssertTrue(mpost.Op == Set || mpost.Op == Del)
+	x.AssertTrue(mpost.Op == Set || mpost.Op == Del || mpost.Op == Inc)
 
 	// If we have a delete all, then we replace the map entry with just one.
 	if hasDeleteAll(mpost) {
@@ -325,6 +335,10 @@ func (l *List) updateMutationLayer(mpost *pb.Posting, singleUidUpdate bool) erro
 	// the time, because it is O(N^2), where N = number of postings added.
 	for i, prev := range plist.Postings {
 		if prev.Uid == mpost.Uid {
+			if mpost.Op == Inc {
+				// The increments of a counter by the transaction add up.
+				mpost = increment(mpost, prev)
+			}
 			plist.Postings[i] = mpost
 			return nil
 		}
@@ -364,8 +378,10 @@ func fingerprintEdge(t *pb.DirectedEdge) uint64 {
 }
 
 func (l *List) addMutation(ctx context.Context, txn *Txn, t *pb.DirectedEdge) error {
+	t1 := time.Now()
 	l.Lock()
 	defer l.Unlock()
+	recordLockWait(l.key, time.Since(t1))
 	return l.addMutationInternal(ctx, txn, t)
 }
 
@@ -381,6 +397,9 @@ func GetConflictKey(pk x.ParsedKey, key []byte, t *pb.DirectedEdge) uint64 {
 	switch {
 	case schema.State().HasNoConflict(t.Attr):
 		break
+	case pk.IsData() && t.Op == pb.DirectedEdge_SET && schema.State().IsCounter(t.Attr):
+		// The increments of a counter add up in any order, so they don't conflict.
+		break
 	case schema.State().HasUpsert(t.Attr):
 		// Consider checking to see if a email id is unique. A user adds:
 		// <uid> <email> "email@email.org", and there's a string equal tokenizer
@@ -446,6 +465,12 @@ func (l *List) addMutationInternal(ctx context.Context, txn *Txn, t *pb.Directed
 	pred, ok := schema.State().Get(ctx, t.Attr)
 	isSingleUidUpdate := ok && !pred.GetList() && pred.GetValueType() == pb.Posting_UID &&
 		pk.IsData() && mpost.Op == Set && mpost.PostingType == pb.Posting_REF
+	if ok && pred.GetCounter() && pk.IsData() && mpost.Op == Set {
+		mpost.Op = Inc
+	}
+	if ok && pred.GetOrdered() && pk.IsData() && mpost.Op == Set {
+		mpost.Seq = l.nextSeq(txn.StartTs)
+	}
 
 	if err != l.updateMutationLayer(mpost, isSingleUidUpdate) {
 		return errors.Wrapf(err, "cannot update mutation layer of key %s with value %+v",
@@ -552,7 +577,7 @@ func (l *List) bitmap(opt ListOptions) (*sroar.Bitmap, error) {
 		if p.Uid == prev {
 			continue
 		}
-		if p.Op == Set {
+		if p.Op == Set || p.Op == Inc {
 			r.Set(p.Uid)
 		} else if p.Op == Del {
 			r.Remove(p.Uid)
@@ -673,6 +698,7 @@ func (l *List) pickPostings(readTs uint64) (uint64, []*pb.Posting) {
 	// First pick up the postings.
 	var deleteBelowTs uint64
 	var posts []*pb.Posting
+	var hasInc bool
 	for startTs, plist := range l.mutationMap {
 		// Pick up the transactions which are either committed, or the one which is ME.
 		effectiveTs := effective(startTs, plist.CommitTs)
@@ -683,6 +709,7 @@ func (l *List) pickPostings(readTs uint64) (uint64, []*pb.Posting) {
 					deleteBelowTs = effectiveTs
 					continue
 				}
+				hasInc = hasInc || mpost.Op == Inc
 				posts = append(posts, mpost)
 			}
 		}
@@ -712,6 +739,9 @@ func (l *List) pickPostings(readTs uint64) (uint64, []*pb.Posting) {
 		}
 		return pi.Uid < pj.Uid
 	})
+	if hasInc {
+		posts = foldIncrements(posts)
+	}
 	return deleteBelowTs, posts
 }
 
@@ -780,6 +810,10 @@ loop:
 			pitr.pidx++
 		case pp.Uid == 0 || (mp.Uid > 0 && mp.Uid < pp.Uid):
 			// Either pp is empty, or mp is lower than pp.
+			if mp.Op == Inc {
+				// The counter is incremented from 0.
+				mp = increment(mp, nil)
+			}
 			if mp.Op != Del {
 				err = f(mp)
 				if err != nil {
@@ -789,6 +823,9 @@ loop:
 			prevUid = mp.Uid
 			midx++
 		case pp.Uid == mp.Uid:
+			if mp.Op == Inc {
+				mp = increment(mp, pp)
+			}
 			if mp.Op != Del {
 				err = f(mp)
 				if err != nil {
@@ -1022,11 +1059,19 @@ func MarshalPostingList(plist *pb.PostingList, alloc *z.Allocator) *bpb.KV {
 		return kv
 	}
 
-	out := alloc.Allocate(plist.Size())
-	n, err := plist.MarshalToSizedBuffer(out)
+	meta, sz := BitCompletePosting, plist.Size()
+	var extra int
+	if x.Config.PostingChecksums {
+		meta, extra = meta|BitChecksum, checksumLen
+	}
+	out := alloc.Allocate(sz + extra)
+	n, err := plist.MarshalToSizedBuffer(out[:sz])
 	x.Check(err)
-	kv.Value = out[:n]
-	kv.UserMeta = alloc.Copy([]byte{BitCompletePosting})
+	kv.Value = out[:n+extra]
+	if extra > 0 {
+		putChecksum(kv.Value)
+	}
+	kv.UserMeta = alloc.Copy([]byte{meta})
 	return kv
 }
 
@@ -1183,8 +1228,9 @@ func (l *List) encode(out *rollupOutput, readTs uint64, split bool) error {
 			plist = out.parts[startUid]
 		}
 
-		if p.Facets != nil || p.PostingType != pb.Posting_REF {
-			plist.Postings = append(plist.Postings, p)
+		if p.Facets != nil || p.PostingType != pb.Posting_REF || p.ValidFrom != 0 ||
+			p.ValidTo != 0 {
+			plist.Postings = append(plist.Postings, withCommitSeq(p))
 		}
 		return nil
 	})
@@ -1324,16 +1370,16 @@ func (l *List) AllUntaggedValues(readTs uint64) ([]types.Val, error) {
 	l.RLock()
 	defer l.RUnlock()
 
-	var vals []types.Val
-	err := l.iterate(readTs, 0, func(p *pb.Posting) error {
-		if len(p.LangTag) == 0 {
-			vals = append(vals, types.Val{
-				Tid:   types.TypeID(p.ValType),
-				Value: p.Value,
-			})
-		}
-		return nil
+	posts, err := l.valuePostings(readTs, func(p *pb.Posting) bool {
+		return len(p.LangTag) == 0
 	})
+	var vals []types.Val
+	for _, p := range posts {
+		vals = append(vals, types.Val{
+			Tid:   types.TypeID(p.ValType),
+			Value: p.Value,
+		})
+	}
 	return vals, errors.Wrapf(err, "cannot retrieve untagged values from list with key %s",
 		hex.EncodeToString(l.key))
 }
@@ -1342,15 +1388,15 @@ func (l *List) AllUntaggedValues(readTs uint64) ([]types.Val, error) {
 // fetching facets for list predicates as lang tag in not allowed for list predicates.
 func (l *List) allUntaggedFacets(readTs uint64) ([]*pb.Facets, error) {
 	l.AssertRLock()
-	var facets []*pb.Facets
-	err := l.iterate(readTs, 0, func(p *pb.Posting) error {
-		if len(p.LangTag) == 0 {
-			facets = append(facets, &pb.Facets{Facets: p.Facets})
-		}
-		return nil
+	posts, err := l.valuePostings(readTs, func(p *pb.Posting) bool {
+		return len(p.LangTag) == 0
 	})
+	var fcs []*pb.Facets
+	for _, p := range posts {
+		fcs = append(fcs, &pb.Facets{Facets: facets.PostingFacets(p)})
+	}
 
-	return facets, errors.Wrapf(err, "cannot retrieve untagged facets from list with key %s",
+	return fcs, errors.Wrapf(err, "cannot retrieve untagged facets from list with key %s",
 		hex.EncodeToString(l.key))
 }
 
@@ -1359,14 +1405,14 @@ func (l *List) AllValues(readTs uint64) ([]types.Val, error) {
 	l.RLock()
 	defer l.RUnlock()
 
+	posts, err := l.valuePostings(readTs, func(p *pb.Posting) bool { return true })
 	var vals []types.Val
-	err := l.iterate(readTs, 0, func(p *pb.Posting) error {
+	for _, p := range posts {
 		vals = append(vals, types.Val{
 			Tid:   types.TypeID(p.ValType),
 			Value: p.Value,
 		})
-		return nil
-	})
+	}
 	return vals, errors.Wrapf(err, "cannot retrieve all values from list with key %s",
 		hex.EncodeToString(l.key))
 }
@@ -1544,6 +1590,14 @@ func (l *List) findPosting(readTs uint64, uid uint64) (found bool, pos *pb.Posti
 		"cannot retrieve posting for UID %d from list with key %s", uid, hex.EncodeToString(l.key))
 }
 
+// FindPosting returns the posting of the given uid at readTs, if the posting holds a value or
+// facets.
+func (l *List) FindPosting(readTs uint64, uid uint64) (bool, *pb.Posting, error) {
+	l.RLock()
+	defer l.RUnlock()
+	return l.findPosting(readTs, uid)
+}
+
 // Facets gives facets for the posting representing value.
 func (l *List) Facets(readTs uint64, param *pb.FacetParams, langs []string,
 	listType bool) ([]*pb.Facets, error) {
@@ -1569,7 +1623,7 @@ func (l *List) Facets(readTs uint64, param *pb.FacetParams, langs []string,
 	case err != nil:
 		return nil, errors.Wrapf(err, "cannot retrieve facet")
 	}
-	fcs = append(fcs, &pb.Facets{Facets: facets.CopyFacets(p.Facets, param)})
+	fcs = append(fcs, &pb.Facets{Facets: facets.CopyFacets(facets.PostingFacets(p), param)})
 	return fcs, nil
 }
 
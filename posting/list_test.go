@@ -18,6 +18,7 @@ package posting
 
 import (
 	"context"
+	"encoding/binary"
 	"io/ioutil"
 	"math"
 	"math/rand"
@@ -200,6 +201,127 @@ func TestAddMutation_Value(t *testing.T) {
 	checkValue(t, ol, "119", txn.StartTs)
 }
 
+func TestAddMutation_Counter(t *testing.T) {
+	require.NoError(t, schema.ParseBytes([]byte("likes: counter ."), 1))
+	key := x.DataKey(x.GalaxyAttr("likes"), 1)
+	ol, err := getNew(key, ps, math.MaxUint64)
+	require.NoError(t, err)
+
+	incr := func(n int64, op uint32, txn *Txn) {
+		val := make([]byte, 8)
+		binary.LittleEndian.PutUint64(val, uint64(n))
+		edge := &pb.DirectedEdge{Attr: x.GalaxyAttr("likes"), Value: val,
+			ValueType: pb.Posting_INT}
+		addMutationHelper(t, ol, edge, op, txn)
+	}
+	checkCounter := func(want int64, readTs uint64) {
+		val, err := ol.Value(readTs)
+		require.NoError(t, err)
+		require.Equal(t, want, int64(binary.LittleEndian.Uint64(val.Value.([]byte))))
+	}
+
+	// The increments of a transaction add up.
+	txn := &Txn{StartTs: 1}
+	incr(2, Set, txn)
+	incr(3, Set, txn)
+	checkCounter(5, txn.StartTs)
+	require.NoError(t, ol.commitMutation(txn.StartTs, 2))
+	checkCounter(5, 3)
+
+	// Concurrent increments don't conflict, and add up once committed.
+	edge := &pb.DirectedEdge{Attr: x.GalaxyAttr("likes"), Op: pb.DirectedEdge_SET}
+	pk, err := x.Parse(key)
+	require.NoError(t, err)
+	require.Zero(t, GetConflictKey(pk, key, edge))
+	txn1, txn2 := &Txn{StartTs: 3}, &Txn{StartTs: 4}
+	incr(10, Set, txn1)
+	incr(-1, Set, txn2)
+	checkCounter(15, txn1.StartTs)
+	checkCounter(4, txn2.StartTs)
+	require.NoError(t, ol.commitMutation(txn2.StartTs, 5))
+	require.NoError(t, ol.commitMutation(txn1.StartTs, 6))
+	checkCounter(4, 5)
+	checkCounter(14, 7)
+
+	// The rollup holds the value of the counter.
+	ol.RLock()
+	out, err := ol.rollup(math.MaxUint64, false)
+	ol.RUnlock()
+	require.NoError(t, err)
+	require.Len(t, out.plist.Postings, 1)
+	require.Equal(t, Set, out.plist.Postings[0].Op)
+	require.Equal(t, int64(14), counterValue(out.plist.Postings[0]))
+	ol = &List{key: key, plist: out.plist, minTs: out.newMinTs, maxTs: out.newMinTs}
+	checkCounter(14, 7)
+
+	// Increments are added to the immutable layer, and a delete resets the counter.
+	txn = &Txn{StartTs: 7}
+	incr(1, Set, txn)
+	require.NoError(t, ol.commitMutation(txn.StartTs, 8))
+	checkCounter(15, 9)
+	txn = &Txn{StartTs: 9}
+	incr(15, Del, txn)
+	incr(4, Set, txn)
+	require.NoError(t, ol.commitMutation(txn.StartTs, 10))
+	checkCounter(4, 11)
+}
+
+func TestAddMutation_Ordered(t *testing.T) {
+	require.NoError(t, schema.ParseBytes([]byte("tags: [string] @ordered ."), 1))
+	key := x.DataKey(x.GalaxyAttr("tags"), 1)
+	ol, err := getNew(key, ps, math.MaxUint64)
+	require.NoError(t, err)
+
+	add := func(val string, op uint32, txn *Txn) {
+		edge := &pb.DirectedEdge{Attr: x.GalaxyAttr("tags"), Value: []byte(val),
+			ValueType: pb.Posting_STRING}
+		addMutationHelper(t, ol, edge, op, txn)
+	}
+	checkOrder := func(want []string, readTs uint64) {
+		vals, err := ol.AllValues(readTs)
+		require.NoError(t, err)
+		got := make([]string, 0, len(vals))
+		for _, v := range vals {
+			got = append(got, string(v.Value.([]byte)))
+		}
+		require.Equal(t, want, got)
+	}
+
+	// The values come in the order they were set, not by fingerprint.
+	txn := &Txn{StartTs: 1}
+	add("zeta", Set, txn)
+	add("alpha", Set, txn)
+	add("mu", Set, txn)
+	checkOrder([]string{"zeta", "alpha", "mu"}, txn.StartTs)
+	require.NoError(t, ol.commitMutation(txn.StartTs, 2))
+
+	// The values set by a later transaction come after, and setting a value again moves it to
+	// the end.
+	txn = &Txn{StartTs: 3}
+	add("beta", Set, txn)
+	add("zeta", Set, txn)
+	add("alpha", Del, txn)
+	require.NoError(t, ol.commitMutation(txn.StartTs, 4))
+	checkOrder([]string{"mu", "beta", "zeta"}, 5)
+
+	// The values set by concurrent transactions come in the order the transactions committed.
+	txn1, txn2 := &Txn{StartTs: 6}, &Txn{StartTs: 7}
+	add("first", Set, txn1)
+	add("second", Set, txn2)
+	checkOrder([]string{"mu", "beta", "zeta", "first"}, txn1.StartTs)
+	require.NoError(t, ol.commitMutation(txn2.StartTs, 8))
+	require.NoError(t, ol.commitMutation(txn1.StartTs, 9))
+	checkOrder([]string{"mu", "beta", "zeta", "second", "first"}, 10)
+
+	// The order is kept by the rollup.
+	ol.RLock()
+	out, err := ol.rollup(math.MaxUint64, false)
+	ol.RUnlock()
+	require.NoError(t, err)
+	ol = &List{key: key, plist: out.plist, minTs: out.newMinTs, maxTs: out.newMinTs}
+	checkOrder([]string{"mu", "beta", "zeta", "second", "first"}, 10)
+}
+
 func TestAddMutation_jchiu1(t *testing.T) {
 	key := x.DataKey(x.GalaxyAttr(x.GalaxyAttr("value")), 12)
 	ol, err := GetNoStore(key, math.MaxUint64)
@@ -20,12 +20,14 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/dgraph-io/badger/v3"
 	"github.com/dgraph-io/dgo/v210/protos/api"
 	"github.com/dgraph-io/ristretto"
 	"github.com/dgraph-io/ristretto/z"
+	"github.com/golang/glog"
 	ostats "go.opencensus.io/stats"
 
 	"github.com/dgraph-io/dgraph/protos/pb"
@@ -47,6 +49,9 @@ func Init(ps *badger.DB, cacheSize int64) {
 	pstore = ps
 	closer = z.NewCloser(1)
 	go x.MonitorMemoryMetrics(closer)
+	if err := loadIndexStates(); err != nil {
+		glog.Errorf("Unable to read the index states: %v", err)
+	}
 
 	// Initialize cache.
 	if cacheSize == 0 {
@@ -102,6 +107,15 @@ func Init(ps *badger.DB, cacheSize int64) {
 	}()
 }
 
+// CacheStats returns the stats of the posting list cache, or an empty string if the cache is
+// disabled.
+func CacheStats() string {
+	if lCache == nil {
+		return ""
+	}
+	return lCache.Metrics.String()
+}
+
 func UpdateMaxCost(maxCost int64) {
 	lCache.UpdateMaxCost(maxCost)
 }
@@ -135,6 +149,37 @@ type LocalCache struct {
 	maxVersions map[string]uint64
 	// plists are posting lists in memory. They can be discarded to reclaim space.
 	plists map[string]*List
+	// stats, if set, counts the posting lists read from the global cache and from disk.
+	stats *ReadStats
+}
+
+// ReadStats counts the posting list reads served by the global posting list cache, and the ones
+// that had to read from disk.
+type ReadStats struct {
+	hits   uint64
+	misses uint64
+}
+
+// Counts returns the number of reads served by the cache, and the number of reads from disk.
+func (rs *ReadStats) Counts() (hits, misses uint64) {
+	return atomic.LoadUint64(&rs.hits), atomic.LoadUint64(&rs.misses)
+}
+
+func (rs *ReadStats) record(hit bool) {
+	if rs == nil {
+		return
+	}
+	if hit {
+		atomic.AddUint64(&rs.hits, 1)
+	} else {
+		atomic.AddUint64(&rs.misses, 1)
+	}
+}
+
+// SetReadStats makes the cache count its posting list reads in the given stats. It must be called
+// before the cache is used.
+func (lc *LocalCache) SetReadStats(stats *ReadStats) {
+	lc.stats = stats
 }
 
 // NewLocalCache returns a new LocalCache instance.
@@ -182,7 +227,7 @@ func (lc *LocalCache) getInternal(key []byte, readFromDisk bool) (*List, error)
 		lc.RLock()
 		defer lc.RUnlock()
 		if lc.plists == nil {
-			return getNew(key, pstore, lc.startTs)
+			return readNew(key, pstore, lc.startTs, lc.stats)
 		}
 		return nil, nil
 	}
@@ -199,7 +244,7 @@ func (lc *LocalCache) getInternal(key []byte, readFromDisk bool) (*List, error)
 	var pl *List
 	if readFromDisk {
 		var err error
-		pl, err = getNew(key, pstore, lc.startTs)
+		pl, err = readNew(key, pstore, lc.startTs, lc.stats)
 		if err != nil {
 			return nil, err
 		}
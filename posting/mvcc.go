@@ -18,6 +18,7 @@ package posting
 
 import (
 	"bytes"
+	"context"
 	"encoding/hex"
 	"math"
 	"sort"
@@ -35,6 +36,7 @@ import (
 	"github.com/dgraph-io/ristretto/z"
 	"github.com/golang/glog"
 	"github.com/pkg/errors"
+	otrace "go.opencensus.io/trace"
 )
 
 type pooledKeys struct {
@@ -50,11 +52,13 @@ type incrRollupi struct {
 	// while idx 1 represents low priority keys to be rolled up.
 	priorityKeys []*pooledKeys
 	count        uint64
+	// flushCh receives the requests to flush the rollups, see Flush.
+	flushCh chan chan struct{}
 }
 
 var (
 	// ErrTsTooOld is returned when a transaction is too old to be applied.
-	ErrTsTooOld = errors.Errorf("Transaction is too old")
+	ErrTsTooOld = x.WithErrorCode(errors.Errorf("Transaction is too old"), x.ErrorCodeConflict)
 	// ErrInvalidKey is returned when trying to read a posting list using
 	// an invalid key (e.g the key to a single part of a larger multi-part list).
 	ErrInvalidKey = errors.Errorf("cannot read posting list using multi-part list key")
@@ -62,6 +66,7 @@ var (
 	// IncrRollup is used to batch keys for rollup incrementally.
 	IncrRollup = &incrRollupi{
 		priorityKeys: make([]*pooledKeys, 2),
+		flushCh:      make(chan chan struct{}),
 	}
 )
 
@@ -85,11 +90,33 @@ func (ir *incrRollupi) rollupKey(sl *skl.Skiplist, key []byte) error {
 	if err != nil {
 		return err
 	}
+	pk, err := x.Parse(key)
+	if err != nil {
+		return err
+	}
+	switch {
+	case pk.IsCountOrCountRev():
+		if err := repairCountKey(l, pk); err != nil {
+			return errors.Wrapf(err, "while repairing count key")
+		}
+	case isPresenceKey(pk):
+		if err := repairPresenceKey(l, pk); err != nil {
+			return errors.Wrapf(err, "while repairing presence list")
+		}
+	}
 
 	kvs, err := l.Rollup(nil)
 	if err != nil {
 		return err
 	}
+	if hasCountIndex(pk) || hasPresence(pk) {
+		l.RLock()
+		n := l.lengthAt(math.MaxUint64)
+		l.RUnlock()
+		if n >= 0 {
+			queueIndexCheck(indexCheck{key: key, pk: pk, n: n})
+		}
+	}
 
 	// If we do a rollup, we typically won't need to update the key in cache.
 	// The only caveat is that the key written by rollup would be written at +1
@@ -109,7 +136,9 @@ func (ir *incrRollupi) rollupKey(sl *skl.Skiplist, key []byte) error {
 		if len(kv.UserMeta) > 0 {
 			vs.UserMeta = kv.UserMeta[0]
 		}
-		switch vs.UserMeta {
+		// The earlier versions are only discarded once the discard ts moves past this version,
+		// which is held back for the versions retained for time-travel queries.
+		switch PostingMeta(vs.UserMeta) {
 		case BitCompletePosting, BitEmptyPosting:
 			vs.Meta = badger.BitDiscardEarlierVersions
 		default:
@@ -117,6 +146,13 @@ func (ir *incrRollupi) rollupKey(sl *skl.Skiplist, key []byte) error {
 		sl.Put(y.KeyWithTs(kv.Key, kv.Version), vs)
 	}
 
+	if x.Config.PredicateMetrics {
+		var size int
+		for _, kv := range kvs {
+			size += len(kv.Value)
+		}
+		x.RecordPredicate(pk.Attr, x.PredicatePostingListSize.M(int64(size)))
+	}
 	return nil
 }
 
@@ -140,6 +176,32 @@ func (ir *incrRollupi) addKeyToBatch(key []byte, priority int) {
 	}
 }
 
+// Pending returns the number of batches of keys waiting to be rolled up.
+func (ir *incrRollupi) Pending() int {
+	var n int
+	for _, rki := range ir.priorityKeys {
+		n += len(rki.keysCh)
+	}
+	return n
+}
+
+// Flush rolls up the keys waiting to be rolled up, and hands the lists rolled up so far over to
+// Badger. It returns once they are written, or once ctx is done. Process must be running.
+func (ir *incrRollupi) Flush(ctx context.Context) error {
+	done := make(chan struct{})
+	select {
+	case ir.flushCh <- done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // Process will rollup batches of 64 keys in a go routine.
 func (ir *incrRollupi) Process(closer *z.Closer) {
 	defer closer.Done()
@@ -172,7 +234,11 @@ func (ir *incrRollupi) Process(closer *z.Closer) {
 		sl = skl.NewGrowingSkiplist(initSize)
 	}
 	doRollup := func(batch *[][]byte, priority int) {
+		_, span := otrace.StartSpan(context.Background(), "posting.Rollup")
+		defer span.End()
+
 		currTs := time.Now().Unix()
+		var rolledUp int64
 		for _, key := range *batch {
 			hash := z.MemHash(key)
 			if elem := m[hash]; currTs-elem < 10 {
@@ -183,8 +249,15 @@ func (ir *incrRollupi) Process(closer *z.Closer) {
 			m[hash] = currTs
 			if err := ir.rollupKey(sl, key); err != nil {
 				glog.Warningf("Error %v rolling up key %v\n", err, key)
+				continue
 			}
+			rolledUp++
 		}
+		// The count indexes and presence lists of the lists rolled up are checked in a batch.
+		ir.checkIndexes()
+		span.AddAttributes(otrace.Int64Attribute("priority", int64(priority)),
+			otrace.Int64Attribute("keys", int64(len(*batch))),
+			otrace.Int64Attribute("rolled_up", rolledUp))
 		*batch = (*batch)[:0]
 		ir.priorityKeys[priority].keysPool.Put(batch)
 	}
@@ -216,6 +289,25 @@ func (ir *incrRollupi) Process(closer *z.Closer) {
 			if ticks%4 == 0 { // base tick is every 500ms. This is 2s.
 				handover()
 			}
+			if ticks%20 == 0 { // This is 10s.
+				ir.findStaleCounts()
+			}
+		case done := <-ir.flushCh:
+			for priority, rki := range ir.priorityKeys {
+				// Pick up the full batches, and then the incomplete one. Only this goroutine
+				// receives from keysCh, so it can't block.
+				for len(rki.keysCh) > 0 {
+					doRollup(<-rki.keysCh, priority)
+				}
+				batch := rki.keysPool.Get().(*[][]byte)
+				if len(*batch) > 0 {
+					doRollup(batch, priority)
+				} else {
+					rki.keysPool.Put(batch)
+				}
+			}
+			handover()
+			close(done)
 		case batch := <-ir.priorityKeys[0].keysCh:
 			// P0 keys are high priority keys. They have more than a threshold number of deltas.
 			doRollup(batch, 0)
@@ -310,10 +402,11 @@ func (txn *Txn) ToSkiplist() error {
 			glog.Errorf("Invalid Entry. len(key): %d len(val): %d\n", len(k), len(data))
 			continue
 		}
+		data, meta := withChecksum(data, BitDeltaPosting)
 		b.Add(y.KeyWithTs(k, math.MaxUint64),
 			y.ValueStruct{
 				Value:    data,
-				UserMeta: BitDeltaPosting,
+				UserMeta: meta,
 			})
 	}
 	txn.sl = b.Skiplist()
@@ -342,6 +435,10 @@ func unmarshalOrCopy(plist *pb.PostingList, item *badger.Item) error {
 	}
 
 	return item.Value(func(val []byte) error {
+		val, err := VerifyValue(item, val)
+		if err != nil {
+			return err
+		}
 		if len(val) == 0 {
 			// empty pl
 			return nil
@@ -401,7 +498,7 @@ func ReadPostingList(key []byte, it *badger.Iterator) (*List, error) {
 			break
 		}
 
-		switch item.UserMeta() {
+		switch PostingMeta(item.UserMeta()) {
 		case BitEmptyPosting:
 			l.minTs = item.Version()
 			return l, nil
@@ -416,6 +513,10 @@ func ReadPostingList(key []byte, it *badger.Iterator) (*List, error) {
 			return l, nil
 		case BitDeltaPosting:
 			err := item.Value(func(val []byte) error {
+				val, err := VerifyValue(item, val)
+				if err != nil {
+					return err
+				}
 				pl := &pb.PostingList{}
 				if err := pl.Unmarshal(val); err != nil {
 					return err
@@ -452,14 +553,20 @@ func ReadPostingList(key []byte, it *badger.Iterator) (*List, error) {
 }
 
 func getNew(key []byte, pstore *badger.DB, readTs uint64) (*List, error) {
+	return readNew(key, pstore, readTs, nil)
+}
+
+// readNew is getNew which records in stats whether the list was served by the cache.
+func readNew(key []byte, pstore *badger.DB, readTs uint64, stats *ReadStats) (*List, error) {
 	if pstore.IsClosed() {
 		return nil, badger.ErrDBClosed
 	}
 
 	var seenTs uint64
-	// We use badger subscription to invalidate the cache. For every write we make the value
-	// corresponding to the key in the cache to nil. So, if we get some non-nil value from the cache
-	// then it means that no  writes have happened after the last set of this key in the cache.
+	// The cache is invalidated by UpdateCachedKeys, which replaces the cached list of every key
+	// written by a committed txn with its commit ts before the Oracle advances to that commit. So,
+	// if we get a list from the cache, then no writes have happened after the last set of this key
+	// in the cache, and no read can see a commit the cache doesn't know of.
 	if val, ok := lCache.Get(key); ok {
 		switch val := val.(type) {
 		case *List:
@@ -470,6 +577,7 @@ func getNew(key []byte, pstore *badger.DB, readTs uint64) (*List, error) {
 				l.RLock()
 				lCopy := copyList(l)
 				l.RUnlock()
+				stats.record(true)
 				return lCopy, nil
 			}
 
@@ -495,6 +603,7 @@ func getNew(key []byte, pstore *badger.DB, readTs uint64) (*List, error) {
 		lCache.Set(key, uint64(1), 0)
 	}
 
+	stats.record(false)
 	txn := pstore.NewTransactionAt(readTs, false)
 	defer txn.Discard()
 
@@ -17,12 +17,15 @@
 package posting
 
 import (
+	"context"
 	"math"
 	"testing"
+	"time"
 
 	"github.com/dgraph-io/dgraph/codec"
 	"github.com/dgraph-io/dgraph/protos/pb"
 	"github.com/dgraph-io/dgraph/x"
+	"github.com/dgraph-io/ristretto/z"
 	"github.com/stretchr/testify/require"
 )
 
@@ -100,3 +103,106 @@ func TestPostingListRead(t *testing.T) {
 	addEdgeToUID(t, attr, 1, 7, 15, 16)
 	assertLength(17, 3)
 }
+
+func TestTxnRollbackTo(t *testing.T) {
+	ctx := context.Background()
+	attr := x.GalaxyAttr("savepoint")
+	key1, key2 := x.DataKey(attr, 1), x.DataKey(attr, 2)
+	txn := NewTxn(100)
+
+	l, err := txn.Get(key1)
+	require.NoError(t, err)
+	addMutationHelper(t, l, &pb.DirectedEdge{ValueId: 10}, Set, txn)
+	txn.Update(ctx)
+	txn.Savepoint("a")
+
+	l, err = txn.Get(key1)
+	require.NoError(t, err)
+	addMutationHelper(t, l, &pb.DirectedEdge{ValueId: 11}, Set, txn)
+	l, err = txn.Get(key2)
+	require.NoError(t, err)
+	addMutationHelper(t, l, &pb.DirectedEdge{ValueId: 20}, Set, txn)
+	txn.Update(ctx)
+	txn.Savepoint("b")
+	require.Len(t, txn.Deltas(), 2)
+
+	// Only the writes made before the savepoint a are kept.
+	txn.RollbackTo(ctx, "a")
+	require.Len(t, txn.Deltas(), 1)
+	l, err = txn.Get(key1)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{10}, listToArray(t, 0, l, 100))
+	require.NotNil(t, txn.Skiplist())
+
+	// The savepoint b was released by the rollback, so rolling back to it discards everything.
+	txn.RollbackTo(ctx, "b")
+	require.Empty(t, txn.Deltas())
+	txn.Skiplist()
+}
+
+func TestTxnStartedBefore(t *testing.T) {
+	o := &oracle{}
+	o.init()
+	old, _ := o.RegisterStartTs(10)
+	old.startedAt = time.Now().Add(-time.Hour)
+	o.RegisterStartTs(20)
+
+	// The old txn stays as old when it is active again, or when it is run again.
+	o.RegisterStartTs(10)
+	o.ResetTxn(10)
+	require.Equal(t, []uint64{10}, o.TxnStartedBefore(time.Minute))
+	require.Empty(t, o.TxnOlderThan(time.Minute))
+
+	txns := o.PendingTxns()
+	require.Len(t, txns, 2)
+	require.Equal(t, uint64(10), txns[0].StartTs)
+	require.True(t, txns[0].Age(time.Now()) >= time.Hour)
+	started, ok := o.PendingTxnStartedAt(20)
+	require.True(t, ok)
+	require.Equal(t, txns[1].StartedAt, started)
+	_, ok = o.PendingTxnStartedAt(30)
+	require.False(t, ok)
+}
+
+func TestMaxCommitTs(t *testing.T) {
+	o := &oracle{}
+	o.init()
+	o.ObserveCommitTs(10)
+	o.ObserveCommitTs(5)
+	require.Equal(t, uint64(10), o.MaxCommitTs())
+
+	// The aborted txns don't count.
+	o.ProcessDelta(&pb.OracleDelta{MaxAssigned: 20, Txns: []*pb.TxnStatus{
+		{StartTs: 11, CommitTs: 15}, {StartTs: 12}}})
+	require.Equal(t, uint64(15), o.MaxCommitTs())
+	// A stale delta still carries the commits.
+	o.ProcessDelta(&pb.OracleDelta{MaxAssigned: 18, Txns: []*pb.TxnStatus{
+		{StartTs: 13, CommitTs: 17}}})
+	require.Equal(t, uint64(17), o.MaxCommitTs())
+	require.Equal(t, uint64(20), o.MaxAssigned())
+}
+
+func TestIncrRollupFlush(t *testing.T) {
+	attr := x.GalaxyAttr("flush")
+	key := x.DataKey(attr, 1)
+	addEdgeToUID(t, attr, 1, 2, 1, 2)
+	addEdgeToUID(t, attr, 1, 3, 3, 4)
+
+	// Nothing flushes without Process.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	require.Error(t, IncrRollup.Flush(ctx))
+
+	closer := z.NewCloser(1)
+	go IncrRollup.Process(closer)
+	defer closer.SignalAndWait()
+
+	IncrRollup.addKeyToBatch(key, 0)
+	require.NoError(t, IncrRollup.Flush(context.Background()))
+
+	txn := pstore.NewTransactionAt(math.MaxUint64, false)
+	defer txn.Discard()
+	item, err := txn.Get(key)
+	require.NoError(t, err)
+	require.Equal(t, BitCompletePosting, item.UserMeta()&BitCompletePosting)
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"encoding/hex"
 	"math"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -67,20 +68,41 @@ type Txn struct {
 	// Keeps track of last update wall clock. We use this fact later to
 	// determine unhealthy, stale txns.
 	lastUpdate time.Time
+	// startedAt is the wall clock of the first pre-write of the txn seen by this Alpha. It is used
+	// to find the txns which have been open for too long, even if they're still active.
+	startedAt time.Time
 
 	cache *LocalCache // This pointer does not get modified.
 	ErrCh chan error
 
 	slWait sync.WaitGroup
 	sl     *skl.Skiplist
+
+	// savepoints are the savepoints set on the txn, oldest first.
+	savepoints []savepoint
+
+	// numPostings is the number of postings written by the txn, and nodeEdges the number of edges
+	// it added to each node, if they're tracked. They are used to enforce the mutation limits.
+	numPostings int
+	nodeEdges   map[uint64]int
+}
+
+// savepoint holds the deltas of a txn at the time a savepoint was set.
+type savepoint struct {
+	name        string
+	deltas      map[string][]byte
+	numPostings int
+	nodeEdges   map[uint64]int
 }
 
 // NewTxn returns a new Txn instance.
 func NewTxn(startTs uint64) *Txn {
+	now := time.Now()
 	return &Txn{
 		StartTs:    startTs,
 		cache:      NewLocalCache(startTs),
-		lastUpdate: time.Now(),
+		lastUpdate: now,
+		startedAt:  now,
 		ErrCh:      make(chan error, 1),
 	}
 }
@@ -120,6 +142,95 @@ func (txn *Txn) Update(ctx context.Context) {
 	}()
 }
 
+// Savepoint marks the current state of the txn under the given name, replacing the savepoint
+// having the same name, if any. It must be called between mutations, once Update has been called.
+func (txn *Txn) Savepoint(name string) {
+	txn.Lock()
+	defer txn.Unlock()
+	txn.cache.RLock()
+	deltas := make(map[string][]byte, len(txn.cache.deltas))
+	for key, delta := range txn.cache.deltas {
+		// The deltas are replaced, not modified, by the later mutations.
+		deltas[key] = delta
+	}
+	txn.cache.RUnlock()
+
+	for i, sp := range txn.savepoints {
+		if sp.name == name {
+			txn.savepoints = append(txn.savepoints[:i], txn.savepoints[i+1:]...)
+			break
+		}
+	}
+	nodeEdges := make(map[uint64]int, len(txn.nodeEdges))
+	for uid, num := range txn.nodeEdges {
+		nodeEdges[uid] = num
+	}
+	txn.savepoints = append(txn.savepoints, savepoint{
+		name:        name,
+		deltas:      deltas,
+		numPostings: txn.numPostings,
+		nodeEdges:   nodeEdges,
+	})
+}
+
+// RollbackTo discards the deltas of the txn written after the savepoint having the given name was
+// set. The savepoint is kept, so that the txn can roll back to it again, but the savepoints set
+// after it are released. If the txn has no such savepoint, it had no delta when the savepoint was
+// set, so all of its deltas are discarded. The conflict keys of the discarded deltas are kept,
+// which can only cause extra aborts.
+func (txn *Txn) RollbackTo(ctx context.Context, name string) {
+	txn.Lock()
+	deltas := make(map[string][]byte)
+	txn.numPostings, txn.nodeEdges = 0, nil
+	for i := len(txn.savepoints) - 1; i >= 0; i-- {
+		if sp := txn.savepoints[i]; sp.name == name {
+			for key, delta := range sp.deltas {
+				deltas[key] = delta
+			}
+			txn.numPostings = sp.numPostings
+			txn.nodeEdges = make(map[uint64]int, len(sp.nodeEdges))
+			for uid, num := range sp.nodeEdges {
+				txn.nodeEdges[uid] = num
+			}
+			txn.savepoints = txn.savepoints[:i+1]
+			break
+		}
+	}
+	txn.cache.Lock()
+	txn.cache.deltas = deltas
+	txn.cache.plists = make(map[string]*List)
+	txn.cache.Unlock()
+	txn.Unlock()
+
+	// Rebuild the skiplist from the remaining deltas.
+	txn.Update(ctx)
+}
+
+// CountEdges adds the postings written by a mutation, and the edges it adds to each node, to the
+// counts of the txn. The counts are only updated if check, given the counts the txn would reach,
+// returns nil, so that a mutation rejected for going past a limit isn't counted. The edges added
+// to the nodes are only counted if nodeEdges isn't nil.
+func (txn *Txn) CountEdges(numPostings int, nodeEdges map[uint64]int,
+	check func(numPostings int, nodeEdges map[uint64]int) error) error {
+	txn.Lock()
+	defer txn.Unlock()
+	totals := make(map[uint64]int, len(nodeEdges))
+	for uid, num := range nodeEdges {
+		totals[uid] = txn.nodeEdges[uid] + num
+	}
+	if err := check(txn.numPostings+numPostings, totals); err != nil {
+		return err
+	}
+	txn.numPostings += numPostings
+	if len(totals) > 0 && txn.nodeEdges == nil {
+		txn.nodeEdges = make(map[uint64]int, len(totals))
+	}
+	for uid, num := range totals {
+		txn.nodeEdges[uid] = num
+	}
+	return nil
+}
+
 // Store is used by tests.
 func (txn *Txn) Store(pl *List) *List {
 	return txn.cache.SetIfAbsent(string(pl.key), pl)
@@ -131,6 +242,10 @@ type oracle struct {
 	// max start ts given out by Zero. Do not use mutex on this, only use atomics.
 	maxAssigned uint64
 
+	// max commit ts seen by this Alpha, either in the deltas from Zero or in the replies to the
+	// commits it sent. Only use atomics on this.
+	maxCommitTs uint64
+
 	// Keeps track of all the startTs we have seen so far, based on the mutations. Then as
 	// transactions are committed or aborted, we delete entries from the startTs map. When taking a
 	// snapshot, we need to know the minimum start ts present in the map, which represents a
@@ -141,6 +256,9 @@ type oracle struct {
 	// Used for waiting logic for transactions with startTs > maxpending so that we don't read an
 	// uncommitted transaction.
 	waiters map[uint64][]chan struct{}
+
+	// history samples the max assigned ts for time-travel queries.
+	history history
 }
 
 func (o *oracle) init() {
@@ -168,6 +286,10 @@ func (o *oracle) ResetTxn(ts uint64) *Txn {
 	defer o.Unlock()
 
 	txn := NewTxn(ts)
+	if prev, ok := o.pendingTxns[ts]; ok {
+		// The txn is run again, but it's still as old.
+		txn.startedAt = prev.startedAt
+	}
 	o.pendingTxns[ts] = txn
 	return txn
 }
@@ -213,6 +335,81 @@ func (o *oracle) NumPendingTxns() int {
 	return len(o.pendingTxns)
 }
 
+// PendingStartTs returns the sorted start timestamps of the pending transactions.
+func (o *oracle) PendingStartTs() []uint64 {
+	o.RLock()
+	res := make([]uint64, 0, len(o.pendingTxns))
+	for startTs := range o.pendingTxns {
+		res = append(res, startTs)
+	}
+	o.RUnlock()
+	sort.Slice(res, func(i, j int) bool { return res[i] < res[j] })
+	return res
+}
+
+// TxnStartedBefore returns the start ts of the pending txns whose first pre-write was seen more
+// than dur ago, whether or not they have been active since.
+func (o *oracle) TxnStartedBefore(dur time.Duration) (res []uint64) {
+	o.RLock()
+	defer o.RUnlock()
+
+	cutoff := time.Now().Add(-dur)
+	for startTs, txn := range o.pendingTxns {
+		if txn.startedAt.Before(cutoff) {
+			res = append(res, startTs)
+		}
+	}
+	return res
+}
+
+// PendingTxn describes a txn which has done pre-writes, but is yet to be committed or aborted.
+type PendingTxn struct {
+	StartTs uint64
+	// StartedAt is the time of the first pre-write of the txn seen by this Alpha, and LastUpdate
+	// the time of the last one.
+	StartedAt  time.Time
+	LastUpdate time.Time
+	// NumKeys is the number of keys written by the txn.
+	NumKeys int
+}
+
+// Age returns how long the txn has been open at the given time.
+func (p PendingTxn) Age(now time.Time) time.Duration {
+	return now.Sub(p.StartedAt)
+}
+
+// PendingTxns returns the pending txns, the oldest first.
+func (o *oracle) PendingTxns() []PendingTxn {
+	o.RLock()
+	res := make([]PendingTxn, 0, len(o.pendingTxns))
+	for startTs, txn := range o.pendingTxns {
+		txn.cache.RLock()
+		numKeys := len(txn.cache.deltas)
+		txn.cache.RUnlock()
+		res = append(res, PendingTxn{
+			StartTs:    startTs,
+			StartedAt:  txn.startedAt,
+			LastUpdate: txn.lastUpdate,
+			NumKeys:    numKeys,
+		})
+	}
+	o.RUnlock()
+	sort.Slice(res, func(i, j int) bool { return res[i].StartTs < res[j].StartTs })
+	return res
+}
+
+// PendingTxnStartedAt returns the time of the first pre-write of the pending txn having the given
+// start ts seen by this Alpha, or false if there's no such txn.
+func (o *oracle) PendingTxnStartedAt(startTs uint64) (time.Time, bool) {
+	o.RLock()
+	defer o.RUnlock()
+	txn, ok := o.pendingTxns[startTs]
+	if !ok {
+		return time.Time{}, false
+	}
+	return txn.startedAt, true
+}
+
 func (o *oracle) TxnOlderThan(dur time.Duration) (res []uint64) {
 	o.RLock()
 	defer o.RUnlock()
@@ -255,6 +452,21 @@ func (o *oracle) SetMaxAssigned(m uint64) {
 	atomic.StoreUint64(&o.maxAssigned, m)
 }
 
+// MaxCommitTs returns the highest commit timestamp seen by this Alpha.
+func (o *oracle) MaxCommitTs() uint64 {
+	return atomic.LoadUint64(&o.maxCommitTs)
+}
+
+// ObserveCommitTs records that a transaction was committed at the given timestamp.
+func (o *oracle) ObserveCommitTs(ts uint64) {
+	for {
+		cur := atomic.LoadUint64(&o.maxCommitTs)
+		if ts <= cur || atomic.CompareAndSwapUint64(&o.maxCommitTs, cur, ts) {
+			return
+		}
+	}
+}
+
 func (o *oracle) WaitForTs(ctx context.Context, startTs uint64) error {
 	ch, ok := o.addToWaiters(startTs)
 	if !ok {
@@ -297,6 +509,10 @@ func (o *oracle) ProcessDelta(delta *pb.OracleDelta) {
 		}
 	}
 
+	for _, txn := range delta.Txns {
+		o.ObserveCommitTs(txn.CommitTs)
+	}
+
 	o.Lock()
 	defer o.Unlock()
 	curMax := o.MaxAssigned()
@@ -315,6 +531,9 @@ func (o *oracle) ProcessDelta(delta *pb.OracleDelta) {
 		delete(o.waiters, startTs)
 	}
 	x.AssertTrue(atomic.CompareAndSwapUint64(&o.maxAssigned, curMax, delta.MaxAssigned))
+	if x.Config.HistoryRetention > 0 {
+		o.history.record(time.Now(), delta.MaxAssigned, x.Config.HistoryRetention)
+	}
 	ostats.Record(context.Background(),
 		x.MaxAssignedTs.M(int64(delta.MaxAssigned))) // Can't access o.MaxAssigned without atomics.
 }
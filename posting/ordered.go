@@ -0,0 +1,125 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package posting
+
+import (
+	"math"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+)
+
+// The postings of a list are sorted by uid, which for values is their fingerprint. The values of
+// an ordered list predicate carry a sequence number instead, by which they are returned. The
+// transaction setting a value only knows its position among the values it set, so the sequence
+// number is completed with the commit ts of the transaction once it's committed, and the values
+// set by a transaction committed later come after, whichever started first. The rollup writes
+// the complete sequence numbers, as the commit ts of the postings isn't kept with them. Setting a
+// value again moves it to the end of the list. The indexes don't depend on the order of the
+// values, and are maintained as for the other lists.
+
+// seqBits is the number of bits of the sequence number holding the position of the value among
+// the values set by the transaction.
+const seqBits = 20
+
+// nextSeq returns the position of the next value set on the list by the transaction.
+func (l *List) nextSeq(startTs uint64) uint64 {
+	l.AssertLock()
+	seq := uint64(1)
+	if plist, ok := l.mutationMap[startTs]; ok {
+		for _, p := range plist.Postings {
+			if p.Seq >= seq {
+				seq = p.Seq + 1
+			}
+		}
+	}
+	return seq
+}
+
+// seqOf returns the sequence number of the posting, made of the commit ts of its transaction and
+// of its position in it. The postings of the transaction reading the list, not committed yet,
+// come after the committed ones.
+func seqOf(p *pb.Posting) uint64 {
+	if p.Seq == 0 || p.Seq >= 1<<seqBits {
+		return p.Seq
+	}
+	commitTs := p.CommitTs
+	if commitTs == 0 {
+		commitTs = math.MaxUint64 >> seqBits
+	}
+	return commitTs<<seqBits | p.Seq
+}
+
+// withCommitSeq returns the posting with its complete sequence number, for the rollup to write.
+// The posting is copied, as it's still read from the mutable layer of the list.
+func withCommitSeq(p *pb.Posting) *pb.Posting {
+	if p.Seq == 0 || p.Seq >= 1<<seqBits || p.CommitTs == 0 {
+		return p
+	}
+	out := proto.Clone(p).(*pb.Posting)
+	out.Seq = seqOf(p)
+	return out
+}
+
+// sortBySeq sorts the postings of an ordered list by their sequence number. The values set
+// before the predicate was ordered have none, and come first by uid.
+func sortBySeq(posts []*pb.Posting) {
+	for _, p := range posts {
+		if p.Seq != 0 {
+			sort.SliceStable(posts, func(i, j int) bool { return seqOf(posts[i]) < seqOf(posts[j]) })
+			return
+		}
+	}
+}
+
+// valuePostings returns the postings of the list at readTs accepted by keep, in the order of
+// the values of the list.
+func (l *List) valuePostings(readTs uint64, keep func(p *pb.Posting) bool) ([]*pb.Posting,
+	error) {
+	l.AssertRLock()
+	var posts []*pb.Posting
+	err := l.iterate(readTs, 0, func(p *pb.Posting) error {
+		if keep(p) {
+			posts = append(posts, p)
+		}
+		return nil
+	})
+	sortBySeq(posts)
+	return posts, err
+}
+
+// IterateValues calls f with the value postings of the list at readTs, in the order of the values
+// of the list, until f returns an error. Unlike Iterate, it reads all the postings first.
+func (l *List) IterateValues(readTs uint64, f func(p *pb.Posting) error) error {
+	l.RLock()
+	defer l.RUnlock()
+	posts, err := l.valuePostings(readTs, func(p *pb.Posting) bool { return true })
+	if err != nil {
+		return err
+	}
+	for _, p := range posts {
+		if err := f(p); err != nil {
+			if err == ErrStopIteration {
+				return nil
+			}
+			return err
+		}
+	}
+	return nil
+}
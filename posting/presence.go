@@ -0,0 +1,116 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package posting
+
+import (
+	"context"
+
+	"github.com/dgraph-io/sroar"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/schema"
+	"github.com/dgraph-io/dgraph/tok"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// The presence list of a predicate with @presence has the uids of the nodes having a value for
+// it, so that has() reads a single list instead of scanning all the data keys of the predicate.
+// It's kept under an index key whose term is the tok.IdentPresence byte alone, which no tokenizer
+// produces. The mutations add a node to it when its list stops being empty, and remove it when
+// its list gets empty. Like the count index, the list drifts when concurrent transactions change
+// the list of the same node, and the drift is fixed when the lists are rolled up, see checkIndex.
+
+var presenceTerm = string([]byte{tok.IdentPresence})
+
+// PresenceKey returns the key of the presence list of the predicate.
+func PresenceKey(attr string) []byte {
+	return x.IndexKey(attr, presenceTerm)
+}
+
+// isPresenceKey tells whether the key is the key of a presence list.
+func isPresenceKey(pk x.ParsedKey) bool {
+	return pk.IsIndex() && !pk.HasStartUid && pk.Term == presenceTerm
+}
+
+// hasPresence tells whether the key is the key of a list tracked by a presence list.
+func hasPresence(pk x.ParsedKey) bool {
+	return pk.IsData() && !pk.HasStartUid &&
+		schema.State().HasPresence(context.Background(), pk.Attr)
+}
+
+// addPresenceMutation adds the node to the presence list of the predicate, or deletes it.
+func (txn *Txn) addPresenceMutation(ctx context.Context, attr string, uid uint64,
+	op pb.DirectedEdge_Op) error {
+	plist, err := txn.cache.GetFromDelta(PresenceKey(attr))
+	if err != nil {
+		return err
+	}
+	return plist.addMutation(ctx, txn, &pb.DirectedEdge{ValueId: uid, Attr: attr, Op: op})
+}
+
+// updatePresence adds the node of the list to the presence list if the list was empty before the
+// mutation and isn't after it, or deletes it if the list wasn't empty before the mutation and is
+// after it.
+func (txn *Txn) updatePresence(ctx context.Context, params countParams) error {
+	switch {
+	case params.countBefore == 0 && params.countAfter > 0:
+		return txn.addPresenceMutation(ctx, params.attr, params.entity, pb.DirectedEdge_SET)
+	case params.countBefore > 0 && params.countAfter == 0:
+		return txn.addPresenceMutation(ctx, params.attr, params.entity, pb.DirectedEdge_DEL)
+	default:
+		return nil
+	}
+}
+
+// contains tells whether the list has the uid at readTs. Unlike Bitmap, it only reads the part of
+// a split list which can have the uid.
+func (l *List) contains(readTs, uid uint64) (bool, error) {
+	l.RLock()
+	defer l.RUnlock()
+	deleteBelow, posts := l.pickPostings(readTs)
+	// The postings are sorted by uid, the latest first.
+	for _, p := range posts {
+		if p.Uid == uid {
+			return p.Op == Set || p.Op == Inc, nil
+		}
+	}
+	if deleteBelow > 0 {
+		return false, nil
+	}
+	if len(l.plist.Splits) == 0 {
+		return sroar.FromBuffer(l.plist.Bitmap).Contains(uid), nil
+	}
+	si := l.splitIdx(uid)
+	if si < 0 {
+		return false, nil
+	}
+	part, err := l.readListPart(l.plist.Splits[si])
+	if err != nil {
+		return false, err
+	}
+	return sroar.FromBuffer(part.Bitmap).Contains(uid), nil
+}
+
+// repairPresenceKey fixes the entries of the presence list queued by checkIndex, in the list
+// read to roll it up, see repairEntries.
+func repairPresenceKey(l *List, pk x.ParsedKey) error {
+	return repairEntries(l, pk.Attr, "the presence list", func(uid uint64) []byte {
+		return x.DataKey(pk.Attr, uid)
+	}, func(n int) bool {
+		return n > 0
+	})
+}
@@ -0,0 +1,102 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package posting
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/dgraph-io/badger/v3/skl"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/schema"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+const presenceSchemaVal = `
+owner: [uid] @presence .
+holder: [uid] @presence .
+keeper: [uid] @presence .
+`
+
+func presenceUids(t *testing.T, attr string, readTs uint64) []uint64 {
+	l, err := GetNoStore(PresenceKey(attr), readTs)
+	require.NoError(t, err)
+	return uids(l, readTs)
+}
+
+func TestPresenceMutations(t *testing.T) {
+	require.NoError(t, schema.ParseBytes([]byte(presenceSchemaVal), 1))
+	attr := x.GalaxyAttr("owner")
+	mutate := func(dst uint64, op uint32, startTs, commitTs uint64) {
+		l, err := GetNoStore(x.DataKey(attr, 1), startTs)
+		require.NoError(t, err)
+		edge := &pb.DirectedEdge{Entity: 1, ValueId: dst, Attr: attr}
+		addMutation(t, l, edge, op, startTs, commitTs, true)
+	}
+
+	mutate(2, Set, 61, 62)
+	require.Equal(t, []uint64{1}, presenceUids(t, attr, 63))
+	mutate(3, Set, 63, 64)
+	mutate(2, Del, 65, 66)
+	require.Equal(t, []uint64{1}, presenceUids(t, attr, 67))
+	// The node leaves the presence list with its last edge.
+	mutate(3, Del, 67, 68)
+	require.Empty(t, presenceUids(t, attr, 69))
+}
+
+func TestRebuildPresence(t *testing.T) {
+	require.NoError(t, schema.ParseBytes([]byte(presenceSchemaVal), 1))
+	attr := x.GalaxyAttr("holder")
+	addEdgeToUID(t, attr, 7, 8, 71, 72)
+	addEdgeToUID(t, attr, 9, 10, 73, 74)
+
+	currentSchema, _ := schema.State().Get(context.Background(), attr)
+	rb := IndexRebuild{
+		Attr:          attr,
+		StartTs:       75,
+		OldSchema:     &pb.SchemaUpdate{ValueType: pb.Posting_UID, List: true},
+		CurrentSchema: &currentSchema,
+	}
+	require.Equal(t, indexOp(indexRebuild), rb.needsPresenceRebuild())
+	require.False(t, rb.GetQuerySchema().Presence)
+	require.NoError(t, rebuildPresence(context.Background(), &rb))
+	require.Equal(t, []uint64{7, 9}, presenceUids(t, attr, 76))
+
+	rb.OldSchema, rb.CurrentSchema = rb.CurrentSchema, rb.OldSchema
+	require.Equal(t, indexOp(indexDelete), rb.needsPresenceRebuild())
+	require.Len(t, prefixesToDropPresence(context.Background(), &rb), 2)
+}
+
+func TestPresenceRollupRepair(t *testing.T) {
+	require.NoError(t, schema.ParseBytes([]byte(presenceSchemaVal), 1))
+	attr := x.GalaxyAttr("keeper")
+	// The edge is added without maintaining the presence list, as if it had drifted.
+	addEdgeToUID(t, attr, 5, 6, 81, 82)
+	require.Empty(t, presenceUids(t, attr, math.MaxUint64))
+
+	Oracle().SetMaxAssigned(90)
+	sl := skl.NewGrowingSkiplist(1 << 20)
+	require.NoError(t, IncrRollup.rollupKey(sl, x.DataKey(attr, 5)))
+	IncrRollup.checkIndexes()
+	require.NoError(t, IncrRollup.rollupKey(sl, PresenceKey(attr)))
+	handover(sl, 0)
+
+	require.Equal(t, []uint64{5}, presenceUids(t, attr, math.MaxUint64))
+}
@@ -87,7 +87,7 @@ func (w *TxnWriter) update(commitTs uint64, f func(txn *badger.Txn) error) error
 // SetAt writes a key-value pair at the given timestamp.
 func (w *TxnWriter) SetAt(key, val []byte, meta byte, ts uint64) error {
 	return w.update(ts, func(txn *badger.Txn) error {
-		switch meta {
+		switch PostingMeta(meta) {
 		case BitCompletePosting, BitEmptyPosting:
 			err := txn.SetEntry((&badger.Entry{
 				Key:      key,
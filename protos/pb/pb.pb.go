@@ -136,6 +136,10 @@ const (
 	Posting_PASSWORD Posting_ValType = 8
 	Posting_STRING   Posting_ValType = 9
 	Posting_OBJECT   Posting_ValType = 10
+	Posting_BIGINT   Posting_ValType = 11
+	Posting_DECIMAL  Posting_ValType = 12
+	Posting_DURATION Posting_ValType = 13
+	Posting_BYTES    Posting_ValType = 14
 )
 
 var Posting_ValType_name = map[int32]string{
@@ -150,6 +154,10 @@ var Posting_ValType_name = map[int32]string{
 	8:  "PASSWORD",
 	9:  "STRING",
 	10: "OBJECT",
+	11: "BIGINT",
+	12: "DECIMAL",
+	13: "DURATION",
+	14: "BYTES",
 }
 
 var Posting_ValType_value = map[string]int32{
@@ -164,6 +172,10 @@ var Posting_ValType_value = map[string]int32{
 	"PASSWORD": 8,
 	"STRING":   9,
 	"OBJECT":   10,
+	"BIGINT":   11,
+	"DECIMAL":  12,
+	"DURATION": 13,
+	"BYTES":    14,
 }
 
 func (x Posting_ValType) String() string {
@@ -239,18 +251,21 @@ const (
 	Num_NS_ID  NumLeaseType = 0
 	Num_UID    NumLeaseType = 1
 	Num_TXN_TS NumLeaseType = 2
+	Num_SEQ    NumLeaseType = 3
 )
 
 var NumLeaseType_name = map[int32]string{
 	0: "NS_ID",
 	1: "UID",
 	2: "TXN_TS",
+	3: "SEQ",
 }
 
 var NumLeaseType_value = map[string]int32{
 	"NS_ID":  0,
 	"UID":    1,
 	"TXN_TS": 2,
+	"SEQ":    3,
 }
 
 func (x NumLeaseType) String() string {
@@ -469,6 +484,7 @@ type SrcFunction struct {
 	Name    string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	Args    []string `protobuf:"bytes,3,rep,name=args,proto3" json:"args,omitempty"`
 	IsCount bool     `protobuf:"varint,4,opt,name=isCount,proto3" json:"isCount,omitempty"`
+	Coerce  string   `protobuf:"bytes,5,opt,name=coerce,proto3" json:"coerce,omitempty"`
 }
 
 func (m *SrcFunction) Reset()         { *m = SrcFunction{} }
@@ -525,6 +541,13 @@ func (m *SrcFunction) GetIsCount() bool {
 	return false
 }
 
+func (m *SrcFunction) GetCoerce() string {
+	if m != nil {
+		return m.Coerce
+	}
+	return ""
+}
+
 type Query struct {
 	Attr     string   `protobuf:"bytes,1,opt,name=attr,proto3" json:"attr,omitempty"`
 	Langs    []string `protobuf:"bytes,2,rep,name=langs,proto3" json:"langs,omitempty"`
@@ -550,6 +573,18 @@ type Query struct {
 	// Offset helps in fetching lesser results for the has query when there is no
 	// filter and order.
 	Offset int32 `protobuf:"varint,16,opt,name=offset,proto3" json:"offset,omitempty"`
+	// The facet to order the uids by, when it is indexed.
+	FacetOrder     string `protobuf:"bytes,17,opt,name=facet_order,json=facetOrder,proto3" json:"facet_order,omitempty"`
+	FacetOrderDesc bool   `protobuf:"varint,18,opt,name=facet_order_desc,json=facetOrderDesc,proto3" json:"facet_order_desc,omitempty"`
+	// The valid time at which the postings are read, in seconds since the epoch. Zero reads the
+	// postings irrespective of their valid time.
+	ValidAt int64 `protobuf:"varint,19,opt,name=valid_at,json=validAt,proto3" json:"valid_at,omitempty"`
+	// Whether the group leader must confirm, through a ReadIndex request, that the node serving
+	// the query is up to date before it is run.
+	Linearizable bool `protobuf:"varint,20,opt,name=linearizable,proto3" json:"linearizable,omitempty"`
+	// The maximum number of uids an edge is expanded to, the uids being sampled evenly if it has
+	// more. Zero expands the edges fully.
+	ExpandSample uint64 `protobuf:"varint,21,opt,name=expand_sample,json=expandSample,proto3" json:"expand_sample,omitempty"`
 }
 
 func (m *Query) Reset()         { *m = Query{} }
@@ -683,6 +718,41 @@ func (m *Query) GetOffset() int32 {
 	return 0
 }
 
+func (m *Query) GetFacetOrder() string {
+	if m != nil {
+		return m.FacetOrder
+	}
+	return ""
+}
+
+func (m *Query) GetFacetOrderDesc() bool {
+	if m != nil {
+		return m.FacetOrderDesc
+	}
+	return false
+}
+
+func (m *Query) GetValidAt() int64 {
+	if m != nil {
+		return m.ValidAt
+	}
+	return 0
+}
+
+func (m *Query) GetLinearizable() bool {
+	if m != nil {
+		return m.Linearizable
+	}
+	return false
+}
+
+func (m *Query) GetExpandSample() uint64 {
+	if m != nil {
+		return m.ExpandSample
+	}
+	return 0
+}
+
 type ValueList struct {
 	Values []*TaskValue `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
 }
@@ -779,6 +849,13 @@ type Result struct {
 	FacetMatrix   []*FacetsList `protobuf:"bytes,5,rep,name=facet_matrix,json=facetMatrix,proto3" json:"facet_matrix,omitempty"`
 	LangMatrix    []*LangList   `protobuf:"bytes,6,rep,name=lang_matrix,json=langMatrix,proto3" json:"lang_matrix,omitempty"`
 	List          bool          `protobuf:"varint,7,opt,name=list,proto3" json:"list,omitempty"`
+	// The number of uid lists which were sampled because of the expand_sample of the query.
+	NumSampled uint64 `protobuf:"varint,8,opt,name=num_sampled,json=numSampled,proto3" json:"num_sampled,omitempty"`
+	// The group serving the task, and the time spent by the task in reading the index and in
+	// fetching the values and the edges, reported in the latency breakdown of the query.
+	GroupId uint32 `protobuf:"varint,9,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	IndexNs uint64 `protobuf:"varint,10,opt,name=index_ns,json=indexNs,proto3" json:"index_ns,omitempty"`
+	FetchNs uint64 `protobuf:"varint,11,opt,name=fetch_ns,json=fetchNs,proto3" json:"fetch_ns,omitempty"`
 }
 
 func (m *Result) Reset()         { *m = Result{} }
@@ -863,6 +940,34 @@ func (m *Result) GetList() bool {
 	return false
 }
 
+func (m *Result) GetNumSampled() uint64 {
+	if m != nil {
+		return m.NumSampled
+	}
+	return 0
+}
+
+func (m *Result) GetGroupId() uint32 {
+	if m != nil {
+		return m.GroupId
+	}
+	return 0
+}
+
+func (m *Result) GetIndexNs() uint64 {
+	if m != nil {
+		return m.IndexNs
+	}
+	return 0
+}
+
+func (m *Result) GetFetchNs() uint64 {
+	if m != nil {
+		return m.FetchNs
+	}
+	return 0
+}
+
 type Order struct {
 	Attr  string   `protobuf:"bytes,1,opt,name=attr,proto3" json:"attr,omitempty"`
 	Desc  bool     `protobuf:"varint,2,opt,name=desc,proto3" json:"desc,omitempty"`
@@ -1130,6 +1235,7 @@ type Member struct {
 	AmDead          bool   `protobuf:"varint,5,opt,name=am_dead,json=amDead,proto3" json:"amDead,omitempty"`
 	LastUpdate      uint64 `protobuf:"varint,6,opt,name=last_update,json=lastUpdate,proto3" json:"lastUpdate,omitempty"`
 	Learner         bool   `protobuf:"varint,7,opt,name=learner,proto3" json:"learner,omitempty"`
+	Witness         bool   `protobuf:"varint,8,opt,name=witness,proto3" json:"witness,omitempty"`
 	ClusterInfoOnly bool   `protobuf:"varint,13,opt,name=cluster_info_only,json=clusterInfoOnly,proto3" json:"clusterInfoOnly,omitempty"`
 	ForceGroupId    bool   `protobuf:"varint,14,opt,name=force_group_id,json=forceGroupId,proto3" json:"forceGroupId,omitempty"`
 }
@@ -1216,6 +1322,13 @@ func (m *Member) GetLearner() bool {
 	return false
 }
 
+func (m *Member) GetWitness() bool {
+	if m != nil {
+		return m.Witness
+	}
+	return false
+}
+
 func (m *Member) GetClusterInfoOnly() bool {
 	if m != nil {
 		return m.ClusterInfoOnly
@@ -1388,6 +1501,12 @@ type ZeroProposal struct {
 	Snapshot   *ZeroSnapshot     `protobuf:"bytes,11,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
 	// 12 has already been used.
 	DeleteNs *DeleteNsRequest `protobuf:"bytes,13,opt,name=delete_ns,json=deleteNs,proto3" json:"delete_ns,omitempty"`
+	// Records the UID ranges reserved by name. A range with a zero end_id releases the name.
+	ReservedUids map[string]*AssignedIds `protobuf:"bytes,14,rep,name=reserved_uids,json=reservedUids,proto3" json:"reserved_uids,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Shrinks the UID lease down to this UID, which must not have been handed out yet.
+	ShrinkMaxUID uint64 `protobuf:"varint,15,opt,name=shrink_max_uid,json=shrinkMaxUid,proto3" json:"shrink_max_uid,omitempty"`
+	// Leases the values of the sequence predicates up to this value.
+	MaxSeq uint64 `protobuf:"varint,16,opt,name=maxSeq,proto3" json:"maxSeq,omitempty"`
 }
 
 func (m *ZeroProposal) Reset()         { *m = ZeroProposal{} }
@@ -1507,6 +1626,27 @@ func (m *ZeroProposal) GetDeleteNs() *DeleteNsRequest {
 	return nil
 }
 
+func (m *ZeroProposal) GetReservedUids() map[string]*AssignedIds {
+	if m != nil {
+		return m.ReservedUids
+	}
+	return nil
+}
+
+func (m *ZeroProposal) GetShrinkMaxUID() uint64 {
+	if m != nil {
+		return m.ShrinkMaxUID
+	}
+	return 0
+}
+
+func (m *ZeroProposal) GetMaxSeq() uint64 {
+	if m != nil {
+		return m.MaxSeq
+	}
+	return 0
+}
+
 // MembershipState is used to pack together the current membership state of all
 // the nodes in the caller server; and the membership updates recorded by the
 // callee server since the provided lastUpdate.
@@ -1521,6 +1661,10 @@ type MembershipState struct {
 	Removed   []*Member          `protobuf:"bytes,7,rep,name=removed,proto3" json:"removed,omitempty"`
 	Cid       string             `protobuf:"bytes,8,opt,name=cid,proto3" json:"cid,omitempty"`
 	License   *License           `protobuf:"bytes,9,opt,name=license,proto3" json:"license,omitempty"`
+	// The UID ranges reserved for external ID systems, by name.
+	ReservedUids map[string]*AssignedIds `protobuf:"bytes,11,rep,name=reserved_uids,json=reservedUids,proto3" json:"reserved_uids,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// The maximum value of the sequence predicates leased so far.
+	MaxSeq uint64 `protobuf:"varint,12,opt,name=maxSeq,proto3" json:"maxSeq,omitempty"`
 }
 
 func (m *MembershipState) Reset()         { *m = MembershipState{} }
@@ -1626,6 +1770,20 @@ func (m *MembershipState) GetLicense() *License {
 	return nil
 }
 
+func (m *MembershipState) GetReservedUids() map[string]*AssignedIds {
+	if m != nil {
+		return m.ReservedUids
+	}
+	return nil
+}
+
+func (m *MembershipState) GetMaxSeq() uint64 {
+	if m != nil {
+		return m.MaxSeq
+	}
+	return 0
+}
+
 type ConnectionState struct {
 	Member *Member          `protobuf:"bytes,1,opt,name=member,proto3" json:"member,omitempty"`
 	State  *MembershipState `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
@@ -1699,6 +1857,11 @@ type HealthInfo struct {
 	Indexing    []string `protobuf:"bytes,9,rep,name=indexing,proto3" json:"indexing,omitempty"`
 	EeFeatures  []string `protobuf:"bytes,10,rep,name=ee_features,json=eeFeatures,proto3" json:"ee_features,omitempty"`
 	MaxAssigned uint64   `protobuf:"varint,11,opt,name=max_assigned,json=maxAssigned,proto3" json:"max_assigned,omitempty"`
+	// The phase of the startup of an Alpha, and the Raft entries of its WAL replayed out of those
+	// to replay.
+	StartupPhase   string `protobuf:"bytes,12,opt,name=startup_phase,json=startupPhase,proto3" json:"startup_phase,omitempty"`
+	StartupApplied uint64 `protobuf:"varint,13,opt,name=startup_applied,json=startupApplied,proto3" json:"startup_applied,omitempty"`
+	StartupTotal   uint64 `protobuf:"varint,14,opt,name=startup_total,json=startupTotal,proto3" json:"startup_total,omitempty"`
 }
 
 func (m *HealthInfo) Reset()         { *m = HealthInfo{} }
@@ -1811,6 +1974,27 @@ func (m *HealthInfo) GetMaxAssigned() uint64 {
 	return 0
 }
 
+func (m *HealthInfo) GetStartupPhase() string {
+	if m != nil {
+		return m.StartupPhase
+	}
+	return ""
+}
+
+func (m *HealthInfo) GetStartupApplied() uint64 {
+	if m != nil {
+		return m.StartupApplied
+	}
+	return 0
+}
+
+func (m *HealthInfo) GetStartupTotal() uint64 {
+	if m != nil {
+		return m.StartupTotal
+	}
+	return 0
+}
+
 type Tablet struct {
 	// Served by which group.
 	GroupId     uint32 `protobuf:"varint,1,opt,name=group_id,json=groupId,proto3" json:"groupId,omitempty"`
@@ -2031,14 +2215,16 @@ func (m *DirectedEdge) GetNamespace() uint64 {
 }
 
 type Mutations struct {
-	GroupId   uint32           `protobuf:"varint,1,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
-	StartTs   uint64           `protobuf:"varint,2,opt,name=start_ts,json=startTs,proto3" json:"start_ts,omitempty"`
-	Edges     []*DirectedEdge  `protobuf:"bytes,3,rep,name=edges,proto3" json:"edges,omitempty"`
-	Schema    []*SchemaUpdate  `protobuf:"bytes,4,rep,name=schema,proto3" json:"schema,omitempty"`
-	Types     []*TypeUpdate    `protobuf:"bytes,6,rep,name=types,proto3" json:"types,omitempty"`
-	DropOp    Mutations_DropOp `protobuf:"varint,7,opt,name=drop_op,json=dropOp,proto3,enum=pb.Mutations_DropOp" json:"drop_op,omitempty"`
-	DropValue string           `protobuf:"bytes,8,opt,name=drop_value,json=dropValue,proto3" json:"drop_value,omitempty"`
-	Metadata  *Metadata        `protobuf:"bytes,9,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	GroupId    uint32           `protobuf:"varint,1,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	StartTs    uint64           `protobuf:"varint,2,opt,name=start_ts,json=startTs,proto3" json:"start_ts,omitempty"`
+	Edges      []*DirectedEdge  `protobuf:"bytes,3,rep,name=edges,proto3" json:"edges,omitempty"`
+	Schema     []*SchemaUpdate  `protobuf:"bytes,4,rep,name=schema,proto3" json:"schema,omitempty"`
+	Types      []*TypeUpdate    `protobuf:"bytes,6,rep,name=types,proto3" json:"types,omitempty"`
+	DropOp     Mutations_DropOp `protobuf:"varint,7,opt,name=drop_op,json=dropOp,proto3,enum=pb.Mutations_DropOp" json:"drop_op,omitempty"`
+	DropValue  string           `protobuf:"bytes,8,opt,name=drop_value,json=dropValue,proto3" json:"drop_value,omitempty"`
+	Metadata   *Metadata        `protobuf:"bytes,9,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Savepoint  string           `protobuf:"bytes,10,opt,name=savepoint,proto3" json:"savepoint,omitempty"`
+	RollbackTo string           `protobuf:"bytes,11,opt,name=rollback_to,json=rollbackTo,proto3" json:"rollback_to,omitempty"`
 }
 
 func (m *Mutations) Reset()         { *m = Mutations{} }
@@ -2130,6 +2316,20 @@ func (m *Mutations) GetMetadata() *Metadata {
 	return nil
 }
 
+func (m *Mutations) GetSavepoint() string {
+	if m != nil {
+		return m.Savepoint
+	}
+	return ""
+}
+
+func (m *Mutations) GetRollbackTo() string {
+	if m != nil {
+		return m.RollbackTo
+	}
+	return ""
+}
+
 type Metadata struct {
 	// Map of predicates to their hints.
 	PredHints map[string]Metadata_HintType `protobuf:"bytes,1,rep,name=pred_hints,json=predHints,proto3" json:"pred_hints,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3,enum=pb.Metadata_HintType"`
@@ -2185,6 +2385,14 @@ type Snapshot struct {
 	SinceTs uint64 `protobuf:"varint,5,opt,name=since_ts,json=sinceTs,proto3" json:"since_ts,omitempty"`
 	// max_assigned stores the ts as seen as of snapshot read_ts.
 	MaxAssigned uint64 `protobuf:"varint,6,opt,name=max_assigned,json=maxAssigned,proto3" json:"max_assigned,omitempty"`
+	// compress asks for the batches of the snapshot stream to be compressed with zstd.
+	Compress bool `protobuf:"varint,7,opt,name=compress,proto3" json:"compress,omitempty"`
+	// resumable asks for the keys to be streamed in order, so that the transfer can be resumed
+	// after the last key received if the stream breaks.
+	Resumable bool `protobuf:"varint,8,opt,name=resumable,proto3" json:"resumable,omitempty"`
+	// resume_key is the last key received before the stream broke. Only the keys after it are
+	// streamed.
+	ResumeKey []byte `protobuf:"bytes,9,opt,name=resume_key,json=resumeKey,proto3" json:"resume_key,omitempty"`
 }
 
 func (m *Snapshot) Reset()         { *m = Snapshot{} }
@@ -2262,6 +2470,27 @@ func (m *Snapshot) GetMaxAssigned() uint64 {
 	return 0
 }
 
+func (m *Snapshot) GetCompress() bool {
+	if m != nil {
+		return m.Compress
+	}
+	return false
+}
+
+func (m *Snapshot) GetResumable() bool {
+	if m != nil {
+		return m.Resumable
+	}
+	return false
+}
+
+func (m *Snapshot) GetResumeKey() []byte {
+	if m != nil {
+		return m.ResumeKey
+	}
+	return nil
+}
+
 type ZeroSnapshot struct {
 	Index        uint64           `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
 	CheckpointTs uint64           `protobuf:"varint,2,opt,name=checkpoint_ts,json=checkpointTs,proto3" json:"checkpoint_ts,omitempty"`
@@ -2700,6 +2929,10 @@ type KVS struct {
 	Predicates []string `protobuf:"bytes,3,rep,name=predicates,proto3" json:"predicates,omitempty"`
 	// Types is the list of types known by the leader at the time of the snapshot.
 	Types []string `protobuf:"bytes,4,rep,name=types,proto3" json:"types,omitempty"`
+	// Compressed is set if data is compressed with zstd.
+	Compressed bool `protobuf:"varint,6,opt,name=compressed,proto3" json:"compressed,omitempty"`
+	// Ordered is set if the keys are streamed in order, so that the stream can be resumed.
+	Ordered bool `protobuf:"varint,7,opt,name=ordered,proto3" json:"ordered,omitempty"`
 }
 
 func (m *KVS) Reset()         { *m = KVS{} }
@@ -2763,6 +2996,20 @@ func (m *KVS) GetTypes() []string {
 	return nil
 }
 
+func (m *KVS) GetCompressed() bool {
+	if m != nil {
+		return m.Compressed
+	}
+	return false
+}
+
+func (m *KVS) GetOrdered() bool {
+	if m != nil {
+		return m.Ordered
+	}
+	return false
+}
+
 // Posting messages.
 type Posting struct {
 	Uid         uint64              `protobuf:"fixed64,1,opt,name=uid,proto3" json:"uid,omitempty"`
@@ -2775,6 +3022,11 @@ type Posting struct {
 	Op       uint32 `protobuf:"varint,12,opt,name=op,proto3" json:"op,omitempty"`
 	StartTs  uint64 `protobuf:"varint,13,opt,name=start_ts,json=startTs,proto3" json:"start_ts,omitempty"`
 	CommitTs uint64 `protobuf:"varint,14,opt,name=commit_ts,json=commitTs,proto3" json:"commit_ts,omitempty"`
+	// The valid time of the posting, in seconds since the epoch. Zero means unbounded.
+	ValidFrom int64 `protobuf:"varint,15,opt,name=valid_from,json=validFrom,proto3" json:"valid_from,omitempty"`
+	ValidTo   int64 `protobuf:"varint,16,opt,name=valid_to,json=validTo,proto3" json:"valid_to,omitempty"`
+	// The position of the value in an ordered list, assigned when the value is set.
+	Seq uint64 `protobuf:"varint,17,opt,name=seq,proto3" json:"seq,omitempty"`
 }
 
 func (m *Posting) Reset()         { *m = Posting{} }
@@ -2873,6 +3125,27 @@ func (m *Posting) GetCommitTs() uint64 {
 	return 0
 }
 
+func (m *Posting) GetValidFrom() int64 {
+	if m != nil {
+		return m.ValidFrom
+	}
+	return 0
+}
+
+func (m *Posting) GetValidTo() int64 {
+	if m != nil {
+		return m.ValidTo
+	}
+	return 0
+}
+
+func (m *Posting) GetSeq() uint64 {
+	if m != nil {
+		return m.Seq
+	}
+	return 0
+}
+
 type PostingList struct {
 	Postings []*Posting `protobuf:"bytes,2,rep,name=postings,proto3" json:"postings,omitempty"`
 	CommitTs uint64     `protobuf:"varint,3,opt,name=commit_ts,json=commitTs,proto3" json:"commit_ts,omitempty"`
@@ -3335,6 +3608,19 @@ type SchemaNode struct {
 	Upsert     bool     `protobuf:"varint,8,opt,name=upsert,proto3" json:"upsert,omitempty"`
 	Lang       bool     `protobuf:"varint,9,opt,name=lang,proto3" json:"lang,omitempty"`
 	NoConflict bool     `protobuf:"varint,10,opt,name=no_conflict,json=noConflict,proto3" json:"no_conflict,omitempty"`
+	Owned      bool     `protobuf:"varint,11,opt,name=owned,proto3" json:"owned,omitempty"`
+	FacetIndex []string `protobuf:"bytes,12,rep,name=facet_index,json=facetIndex,proto3" json:"facet_index,omitempty"`
+	Facets     []string `protobuf:"bytes,13,rep,name=facets,proto3" json:"facets,omitempty"`
+	View       string   `protobuf:"bytes,14,opt,name=view,proto3" json:"view,omitempty"`
+	Trigger    string   `protobuf:"bytes,15,opt,name=trigger,proto3" json:"trigger,omitempty"`
+	Sequence   bool     `protobuf:"varint,16,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// The state of the indexes of the predicate while they aren't fully built: building or stale.
+	IndexState string `protobuf:"bytes,17,opt,name=index_state,json=indexState,proto3" json:"index_state,omitempty"`
+	// The number of posting lists processed so far by the rebuild of the indexes.
+	IndexProgress uint64   `protobuf:"varint,18,opt,name=index_progress,json=indexProgress,proto3" json:"index_progress,omitempty"`
+	Ordered       bool     `protobuf:"varint,19,opt,name=ordered,proto3" json:"ordered,omitempty"`
+	Validators    []string `protobuf:"bytes,20,rep,name=validators,proto3" json:"validators,omitempty"`
+	Presence      bool     `protobuf:"varint,21,opt,name=presence,proto3" json:"presence,omitempty"`
 }
 
 func (m *SchemaNode) Reset()         { *m = SchemaNode{} }
@@ -3440,6 +3726,83 @@ func (m *SchemaNode) GetNoConflict() bool {
 	return false
 }
 
+func (m *SchemaNode) GetOwned() bool {
+	if m != nil {
+		return m.Owned
+	}
+	return false
+}
+
+func (m *SchemaNode) GetFacetIndex() []string {
+	if m != nil {
+		return m.FacetIndex
+	}
+	return nil
+}
+
+func (m *SchemaNode) GetFacets() []string {
+	if m != nil {
+		return m.Facets
+	}
+	return nil
+}
+
+func (m *SchemaNode) GetView() string {
+	if m != nil {
+		return m.View
+	}
+	return ""
+}
+
+func (m *SchemaNode) GetTrigger() string {
+	if m != nil {
+		return m.Trigger
+	}
+	return ""
+}
+
+func (m *SchemaNode) GetSequence() bool {
+	if m != nil {
+		return m.Sequence
+	}
+	return false
+}
+
+func (m *SchemaNode) GetIndexState() string {
+	if m != nil {
+		return m.IndexState
+	}
+	return ""
+}
+
+func (m *SchemaNode) GetIndexProgress() uint64 {
+	if m != nil {
+		return m.IndexProgress
+	}
+	return 0
+}
+
+func (m *SchemaNode) GetOrdered() bool {
+	if m != nil {
+		return m.Ordered
+	}
+	return false
+}
+
+func (m *SchemaNode) GetValidators() []string {
+	if m != nil {
+		return m.Validators
+	}
+	return nil
+}
+
+func (m *SchemaNode) GetPresence() bool {
+	if m != nil {
+		return m.Presence
+	}
+	return false
+}
+
 type SchemaResult struct {
 	Schema []*SchemaNode `protobuf:"bytes,1,rep,name=schema,proto3" json:"schema,omitempty"` // Deprecated: Do not use.
 }
@@ -3501,6 +3864,25 @@ type SchemaUpdate struct {
 	// name. This field stores said name.
 	ObjectTypeName string `protobuf:"bytes,12,opt,name=object_type_name,json=objectTypeName,proto3" json:"object_type_name,omitempty"`
 	NoConflict     bool   `protobuf:"varint,13,opt,name=no_conflict,json=noConflict,proto3" json:"no_conflict,omitempty"`
+	Owned          bool   `protobuf:"varint,14,opt,name=owned,proto3" json:"owned,omitempty"`
+	// The keys of the facets of the edges that are indexed.
+	FacetIndex []string `protobuf:"bytes,15,rep,name=facet_index,json=facetIndex,proto3" json:"facet_index,omitempty"`
+	// The keys and value types of the facets declared for the edges.
+	Facets []*api.Facet `protobuf:"bytes,16,rep,name=facets,proto3" json:"facets,omitempty"`
+	// The DQL query which maintains the values of a view predicate.
+	View string `protobuf:"bytes,17,opt,name=view,proto3" json:"view,omitempty"`
+	// The target called once the changes to the predicate are committed.
+	Trigger string `protobuf:"bytes,18,opt,name=trigger,proto3" json:"trigger,omitempty"`
+	// The values of sequence predicates are allocated by Zero.
+	Sequence bool `protobuf:"varint,19,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// The mutations of counter predicates add their value to the current one.
+	Counter bool `protobuf:"varint,20,opt,name=counter,proto3" json:"counter,omitempty"`
+	// The values of ordered list predicates are returned in the order they were set.
+	Ordered bool `protobuf:"varint,21,opt,name=ordered,proto3" json:"ordered,omitempty"`
+	// The validators called on the values set by the mutations, in turn.
+	Validators []string `protobuf:"bytes,22,rep,name=validators,proto3" json:"validators,omitempty"`
+	// The uids of the nodes having a value are kept in a presence list, which has() reads.
+	Presence bool `protobuf:"varint,23,opt,name=presence,proto3" json:"presence,omitempty"`
 }
 
 func (m *SchemaUpdate) Reset()         { *m = SchemaUpdate{} }
@@ -3620,9 +4002,83 @@ func (m *SchemaUpdate) GetNoConflict() bool {
 	return false
 }
 
+func (m *SchemaUpdate) GetOwned() bool {
+	if m != nil {
+		return m.Owned
+	}
+	return false
+}
+
+func (m *SchemaUpdate) GetFacetIndex() []string {
+	if m != nil {
+		return m.FacetIndex
+	}
+	return nil
+}
+
+func (m *SchemaUpdate) GetFacets() []*api.Facet {
+	if m != nil {
+		return m.Facets
+	}
+	return nil
+}
+
+func (m *SchemaUpdate) GetView() string {
+	if m != nil {
+		return m.View
+	}
+	return ""
+}
+
+func (m *SchemaUpdate) GetTrigger() string {
+	if m != nil {
+		return m.Trigger
+	}
+	return ""
+}
+
+func (m *SchemaUpdate) GetSequence() bool {
+	if m != nil {
+		return m.Sequence
+	}
+	return false
+}
+
+func (m *SchemaUpdate) GetCounter() bool {
+	if m != nil {
+		return m.Counter
+	}
+	return false
+}
+
+func (m *SchemaUpdate) GetOrdered() bool {
+	if m != nil {
+		return m.Ordered
+	}
+	return false
+}
+
+func (m *SchemaUpdate) GetValidators() []string {
+	if m != nil {
+		return m.Validators
+	}
+	return nil
+}
+
+func (m *SchemaUpdate) GetPresence() bool {
+	if m != nil {
+		return m.Presence
+	}
+	return false
+}
+
 type TypeUpdate struct {
 	TypeName string          `protobuf:"bytes,1,opt,name=type_name,json=typeName,proto3" json:"type_name,omitempty"`
 	Fields   []*SchemaUpdate `protobuf:"bytes,2,rep,name=fields,proto3" json:"fields,omitempty"`
+	// The target called once the changes to the nodes of the type are committed.
+	Trigger string `protobuf:"bytes,3,opt,name=trigger,proto3" json:"trigger,omitempty"`
+	// The types extended by the type. It inherits their fields, and its nodes match their type().
+	Extends []string `protobuf:"bytes,4,rep,name=extends,proto3" json:"extends,omitempty"`
 }
 
 func (m *TypeUpdate) Reset()         { *m = TypeUpdate{} }
@@ -3672,8 +4128,22 @@ func (m *TypeUpdate) GetFields() []*SchemaUpdate {
 	return nil
 }
 
-type MapHeader struct {
-	PartitionKeys [][]byte `protobuf:"bytes,1,rep,name=partition_keys,json=partitionKeys,proto3" json:"partition_keys,omitempty"`
+func (m *TypeUpdate) GetTrigger() string {
+	if m != nil {
+		return m.Trigger
+	}
+	return ""
+}
+
+func (m *TypeUpdate) GetExtends() []string {
+	if m != nil {
+		return m.Extends
+	}
+	return nil
+}
+
+type MapHeader struct {
+	PartitionKeys [][]byte `protobuf:"bytes,1,rep,name=partition_keys,json=partitionKeys,proto3" json:"partition_keys,omitempty"`
 }
 
 func (m *MapHeader) Reset()         { *m = MapHeader{} }
@@ -4790,6 +5260,8 @@ type ExportRequest struct {
 	SessionToken string `protobuf:"bytes,8,opt,name=session_token,json=sessionToken,proto3" json:"session_token,omitempty"`
 	Anonymous    bool   `protobuf:"varint,9,opt,name=anonymous,proto3" json:"anonymous,omitempty"`
 	Namespace    uint64 `protobuf:"varint,10,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// The age X25519 recipient the export is encrypted for, instead of the encryption key.
+	Recipient string `protobuf:"bytes,11,opt,name=recipient,proto3" json:"recipient,omitempty"`
 }
 
 func (m *ExportRequest) Reset()         { *m = ExportRequest{} }
@@ -4895,6 +5367,13 @@ func (m *ExportRequest) GetNamespace() uint64 {
 	return 0
 }
 
+func (m *ExportRequest) GetRecipient() string {
+	if m != nil {
+		return m.Recipient
+	}
+	return ""
+}
+
 type ExportResponse struct {
 	// 0 indicates a success, and a non-zero code indicates failure
 	Code  int32    `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
@@ -5133,6 +5612,8 @@ type UpdateGraphQLSchemaRequest struct {
 	DgraphTypes   []*TypeUpdate                 `protobuf:"bytes,4,rep,name=dgraph_types,json=dgraphTypes,proto3" json:"dgraph_types,omitempty"`
 	LambdaScript  string                        `protobuf:"bytes,5,opt,name=lambda_script,json=lambdaScript,proto3" json:"lambda_script,omitempty"`
 	Op            UpdateGraphQLSchemaRequest_Op `protobuf:"varint,6,opt,name=op,proto3,enum=pb.UpdateGraphQLSchemaRequest_Op" json:"op,omitempty"`
+	// If non-zero, the schema is only updated if its current version is this one.
+	ExpectedVersion uint64 `protobuf:"varint,7,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
 }
 
 func (m *UpdateGraphQLSchemaRequest) Reset()         { *m = UpdateGraphQLSchemaRequest{} }
@@ -5210,6 +5691,13 @@ func (m *UpdateGraphQLSchemaRequest) GetOp() UpdateGraphQLSchemaRequest_Op {
 	return UpdateGraphQLSchemaRequest_SCHEMA
 }
 
+func (m *UpdateGraphQLSchemaRequest) GetExpectedVersion() uint64 {
+	if m != nil {
+		return m.ExpectedVersion
+	}
+	return 0
+}
+
 type UpdateGraphQLSchemaResponse struct {
 	Uid uint64 `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
 }
@@ -5369,6 +5857,8 @@ func (m *DeleteNsRequest) GetNamespace() uint64 {
 
 type TaskStatusRequest struct {
 	TaskId uint64 `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	// Cancel cancels the task if it's queued or running.
+	Cancel bool `protobuf:"varint,2,opt,name=cancel,proto3" json:"cancel,omitempty"`
 }
 
 func (m *TaskStatusRequest) Reset()         { *m = TaskStatusRequest{} }
@@ -5411,8 +5901,22 @@ func (m *TaskStatusRequest) GetTaskId() uint64 {
 	return 0
 }
 
+func (m *TaskStatusRequest) GetCancel() bool {
+	if m != nil {
+		return m.Cancel
+	}
+	return false
+}
+
 type TaskStatusResponse struct {
 	TaskMeta uint64 `protobuf:"varint,1,opt,name=task_meta,json=taskMeta,proto3" json:"task_meta,omitempty"`
+	TaskId   uint64 `protobuf:"varint,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	// The progress of the task, out of total if the total is known.
+	Progress uint64 `protobuf:"varint,3,opt,name=progress,proto3" json:"progress,omitempty"`
+	Total    uint64 `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	// The last log lines of the task.
+	Logs  []string `protobuf:"bytes,5,rep,name=logs,proto3" json:"logs,omitempty"`
+	Error string   `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
 }
 
 func (m *TaskStatusResponse) Reset()         { *m = TaskStatusResponse{} }
@@ -5455,6 +5959,323 @@ func (m *TaskStatusResponse) GetTaskMeta() uint64 {
 	return 0
 }
 
+func (m *TaskStatusResponse) GetTaskId() uint64 {
+	if m != nil {
+		return m.TaskId
+	}
+	return 0
+}
+
+func (m *TaskStatusResponse) GetProgress() uint64 {
+	if m != nil {
+		return m.Progress
+	}
+	return 0
+}
+
+func (m *TaskStatusResponse) GetTotal() uint64 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+func (m *TaskStatusResponse) GetLogs() []string {
+	if m != nil {
+		return m.Logs
+	}
+	return nil
+}
+
+func (m *TaskStatusResponse) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type BatchMutationRequest struct {
+	Requests []*api.Request `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"`
+	// The number of requests applied concurrently, capped by the server.
+	Concurrency uint32 `protobuf:"varint,2,opt,name=concurrency,proto3" json:"concurrency,omitempty"`
+}
+
+func (m *BatchMutationRequest) Reset()         { *m = BatchMutationRequest{} }
+func (m *BatchMutationRequest) String() string { return proto.CompactTextString(m) }
+func (*BatchMutationRequest) ProtoMessage()    {}
+func (*BatchMutationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{70}
+}
+func (m *BatchMutationRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BatchMutationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BatchMutationRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *BatchMutationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchMutationRequest.Merge(m, src)
+}
+func (m *BatchMutationRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *BatchMutationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchMutationRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchMutationRequest proto.InternalMessageInfo
+
+func (m *BatchMutationRequest) GetRequests() []*api.Request {
+	if m != nil {
+		return m.Requests
+	}
+	return nil
+}
+
+func (m *BatchMutationRequest) GetConcurrency() uint32 {
+	if m != nil {
+		return m.Concurrency
+	}
+	return 0
+}
+
+type BatchMutationResult struct {
+	Success  bool          `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Response *api.Response `protobuf:"bytes,2,opt,name=response,proto3" json:"response,omitempty"`
+	Error    string        `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (m *BatchMutationResult) Reset()         { *m = BatchMutationResult{} }
+func (m *BatchMutationResult) String() string { return proto.CompactTextString(m) }
+func (*BatchMutationResult) ProtoMessage()    {}
+func (*BatchMutationResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{71}
+}
+func (m *BatchMutationResult) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BatchMutationResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BatchMutationResult.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *BatchMutationResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchMutationResult.Merge(m, src)
+}
+func (m *BatchMutationResult) XXX_Size() int {
+	return m.Size()
+}
+func (m *BatchMutationResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchMutationResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchMutationResult proto.InternalMessageInfo
+
+func (m *BatchMutationResult) GetSuccess() bool {
+	if m != nil {
+		return m.Success
+	}
+	return false
+}
+
+func (m *BatchMutationResult) GetResponse() *api.Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *BatchMutationResult) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type BatchMutationResponse struct {
+	Results []*BatchMutationResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+}
+
+func (m *BatchMutationResponse) Reset()         { *m = BatchMutationResponse{} }
+func (m *BatchMutationResponse) String() string { return proto.CompactTextString(m) }
+func (*BatchMutationResponse) ProtoMessage()    {}
+func (*BatchMutationResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{72}
+}
+func (m *BatchMutationResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BatchMutationResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BatchMutationResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *BatchMutationResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchMutationResponse.Merge(m, src)
+}
+func (m *BatchMutationResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *BatchMutationResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchMutationResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchMutationResponse proto.InternalMessageInfo
+
+func (m *BatchMutationResponse) GetResults() []*BatchMutationResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+// Exactly one of export and backup is set. The destination of the request is ignored, as the
+// files are streamed to the caller.
+type StreamExportRequest struct {
+	Export *ExportRequest `protobuf:"bytes,1,opt,name=export,proto3" json:"export,omitempty"`
+	Backup *BackupRequest `protobuf:"bytes,2,opt,name=backup,proto3" json:"backup,omitempty"`
+}
+
+func (m *StreamExportRequest) Reset()         { *m = StreamExportRequest{} }
+func (m *StreamExportRequest) String() string { return proto.CompactTextString(m) }
+func (*StreamExportRequest) ProtoMessage()    {}
+func (*StreamExportRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{73}
+}
+func (m *StreamExportRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StreamExportRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StreamExportRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *StreamExportRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamExportRequest.Merge(m, src)
+}
+func (m *StreamExportRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *StreamExportRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamExportRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamExportRequest proto.InternalMessageInfo
+
+func (m *StreamExportRequest) GetExport() *ExportRequest {
+	if m != nil {
+		return m.Export
+	}
+	return nil
+}
+
+func (m *StreamExportRequest) GetBackup() *BackupRequest {
+	if m != nil {
+		return m.Backup
+	}
+	return nil
+}
+
+// The files are sent by chunks, each chunk carrying the path of its file.
+type ExportChunk struct {
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Data []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	// last is set on the last chunk of the file.
+	Last bool `protobuf:"varint,3,opt,name=last,proto3" json:"last,omitempty"`
+	// drop_operations are set on the last message of the backup of a group.
+	DropOperations []*DropOperation `protobuf:"bytes,4,rep,name=drop_operations,json=dropOperations,proto3" json:"drop_operations,omitempty"`
+}
+
+func (m *ExportChunk) Reset()         { *m = ExportChunk{} }
+func (m *ExportChunk) String() string { return proto.CompactTextString(m) }
+func (*ExportChunk) ProtoMessage()    {}
+func (*ExportChunk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{74}
+}
+func (m *ExportChunk) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ExportChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ExportChunk.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ExportChunk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportChunk.Merge(m, src)
+}
+func (m *ExportChunk) XXX_Size() int {
+	return m.Size()
+}
+func (m *ExportChunk) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportChunk.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportChunk proto.InternalMessageInfo
+
+func (m *ExportChunk) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *ExportChunk) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *ExportChunk) GetLast() bool {
+	if m != nil {
+		return m.Last
+	}
+	return false
+}
+
+func (m *ExportChunk) GetDropOperations() []*DropOperation {
+	if m != nil {
+		return m.DropOperations
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterEnum("pb.DirectedEdge_Op", DirectedEdge_Op_name, DirectedEdge_Op_value)
 	proto.RegisterEnum("pb.Mutations_DropOp", Mutations_DropOp_name, Mutations_DropOp_value)
@@ -5484,9 +6305,11 @@ func init() {
 	proto.RegisterType((*License)(nil), "pb.License")
 	proto.RegisterType((*ZeroProposal)(nil), "pb.ZeroProposal")
 	proto.RegisterMapType((map[uint32]uint64)(nil), "pb.ZeroProposal.SnapshotTsEntry")
+	proto.RegisterMapType((map[string]*AssignedIds)(nil), "pb.ZeroProposal.ReservedUidsEntry")
 	proto.RegisterType((*MembershipState)(nil), "pb.MembershipState")
 	proto.RegisterMapType((map[uint32]*Group)(nil), "pb.MembershipState.GroupsEntry")
 	proto.RegisterMapType((map[uint64]*Member)(nil), "pb.MembershipState.ZerosEntry")
+	proto.RegisterMapType((map[string]*AssignedIds)(nil), "pb.MembershipState.ReservedUidsEntry")
 	proto.RegisterType((*ConnectionState)(nil), "pb.ConnectionState")
 	proto.RegisterType((*HealthInfo)(nil), "pb.HealthInfo")
 	proto.RegisterType((*Tablet)(nil), "pb.Tablet")
@@ -5544,349 +6367,356 @@ func init() {
 	proto.RegisterType((*DeleteNsRequest)(nil), "pb.DeleteNsRequest")
 	proto.RegisterType((*TaskStatusRequest)(nil), "pb.TaskStatusRequest")
 	proto.RegisterType((*TaskStatusResponse)(nil), "pb.TaskStatusResponse")
+	proto.RegisterType((*BatchMutationRequest)(nil), "pb.BatchMutationRequest")
+	proto.RegisterType((*BatchMutationResult)(nil), "pb.BatchMutationResult")
+	proto.RegisterType((*BatchMutationResponse)(nil), "pb.BatchMutationResponse")
+	proto.RegisterType((*StreamExportRequest)(nil), "pb.StreamExportRequest")
+	proto.RegisterType((*ExportChunk)(nil), "pb.ExportChunk")
 }
 
 func init() { proto.RegisterFile("pb.proto", fileDescriptor_f80abaa17e25ccc8) }
 
 var fileDescriptor_f80abaa17e25ccc8 = []byte{
-	// 5383 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x7b, 0x4b, 0x6c, 0x1c, 0xe9,
-	0x71, 0x30, 0xe7, 0xdd, 0x5d, 0xf3, 0xe0, 0xf0, 0x93, 0x2c, 0x8f, 0xa9, 0x5d, 0x49, 0xdb, 0xb2,
-	0x76, 0xb9, 0xd2, 0x8a, 0x92, 0x28, 0x1b, 0xbf, 0x77, 0x0d, 0xff, 0x08, 0x1f, 0x43, 0x2d, 0x57,
-	0x14, 0x49, 0x37, 0x47, 0xf2, 0x03, 0x48, 0x06, 0xcd, 0xe9, 0x8f, 0x64, 0x9b, 0x3d, 0xdd, 0xed,
-	0xee, 0x1e, 0x9a, 0xf4, 0xcd, 0x08, 0x60, 0x23, 0x37, 0x1f, 0x73, 0xca, 0x21, 0xd7, 0x9c, 0xf3,
-	0x40, 0x90, 0xdc, 0x72, 0x08, 0x72, 0x89, 0x8f, 0x09, 0x92, 0x2c, 0x82, 0x75, 0x90, 0xc3, 0x1e,
-	0x02, 0x04, 0x39, 0x26, 0x87, 0xa0, 0xaa, 0xbe, 0x7e, 0xcd, 0x0c, 0xa5, 0x5d, 0x07, 0x39, 0xe4,
-	0x34, 0x5f, 0x55, 0x7d, 0xcf, 0xfa, 0xea, 0xab, 0x67, 0x0f, 0x68, 0xc1, 0xd1, 0x6a, 0x10, 0xfa,
-	0xb1, 0x2f, 0xca, 0xc1, 0xd1, 0xb2, 0x6e, 0x05, 0x0e, 0x83, 0xcb, 0xf7, 0x4f, 0x9c, 0xf8, 0x74,
-	0x72, 0xb4, 0x3a, 0xf2, 0xc7, 0x8f, 0xec, 0x93, 0xd0, 0x0a, 0x4e, 0x1f, 0x3a, 0xfe, 0xa3, 0x23,
-	0xcb, 0x3e, 0x91, 0xe1, 0xa3, 0xf3, 0xa7, 0x8f, 0x82, 0xa3, 0x47, 0xc9, 0xd0, 0xe5, 0x87, 0xb9,
-	0xbe, 0x27, 0xfe, 0x89, 0xff, 0x88, 0xd0, 0x47, 0x93, 0x63, 0x82, 0x08, 0xa0, 0x16, 0x77, 0x37,
-	0xfe, 0x3f, 0x54, 0x77, 0x9d, 0x28, 0x16, 0x37, 0xa0, 0x7e, 0xe4, 0xc4, 0x63, 0x2b, 0xe8, 0x95,
-	0xef, 0x94, 0x56, 0x5a, 0xa6, 0x82, 0xc4, 0x2d, 0x80, 0xc8, 0x0f, 0x63, 0x69, 0xbf, 0x74, 0xec,
-	0xa8, 0x57, 0xb9, 0x53, 0x59, 0xa9, 0x9b, 0x39, 0x8c, 0xf1, 0x02, 0xf4, 0x81, 0x15, 0x9d, 0xbd,
-	0xb2, 0xdc, 0x89, 0x14, 0x5d, 0xa8, 0x9c, 0x5b, 0x6e, 0xaf, 0x44, 0x33, 0x60, 0x53, 0xac, 0x82,
-	0x76, 0x6e, 0xb9, 0xc3, 0xf8, 0x32, 0x90, 0x34, 0x71, 0x67, 0xed, 0xda, 0x6a, 0x70, 0xb4, 0x7a,
-	0xe0, 0x47, 0xb1, 0xe3, 0x9d, 0xac, 0xbe, 0xb2, 0xdc, 0xc1, 0x65, 0x20, 0xcd, 0xc6, 0x39, 0x37,
-	0x8c, 0x7d, 0x68, 0x1e, 0x86, 0xa3, 0xed, 0x89, 0x37, 0x8a, 0x1d, 0xdf, 0x13, 0x02, 0xaa, 0x9e,
-	0x35, 0x96, 0x34, 0xa3, 0x6e, 0x52, 0x1b, 0x71, 0x56, 0x78, 0xc2, 0x7b, 0xd1, 0x4d, 0x6a, 0x8b,
-	0x1e, 0x34, 0x9c, 0x68, 0xd3, 0x9f, 0x78, 0x71, 0xaf, 0x7a, 0xa7, 0xb4, 0xa2, 0x99, 0x09, 0x68,
-	0xfc, 0x69, 0x05, 0x6a, 0xdf, 0x9d, 0xc8, 0xf0, 0x92, 0xc6, 0xc5, 0x71, 0x98, 0xcc, 0x85, 0x6d,
-	0x71, 0x1d, 0x6a, 0xae, 0xe5, 0x9d, 0x44, 0xbd, 0x32, 0x4d, 0xc6, 0x80, 0xb8, 0x09, 0xba, 0x75,
-	0x1c, 0xcb, 0x70, 0x38, 0x71, 0xec, 0x5e, 0xe5, 0x4e, 0x69, 0xa5, 0x6e, 0x6a, 0x84, 0x78, 0xe9,
-	0xd8, 0xe2, 0x6b, 0xa0, 0xd9, 0xfe, 0x70, 0x94, 0x5f, 0xcb, 0xf6, 0x69, 0x2d, 0x71, 0x17, 0xb4,
-	0x89, 0x63, 0x0f, 0x5d, 0x27, 0x8a, 0x7b, 0xb5, 0x3b, 0xa5, 0x95, 0xe6, 0x9a, 0x86, 0x87, 0x45,
-	0xfe, 0x9a, 0x8d, 0x89, 0x63, 0x13, 0xa3, 0xef, 0x83, 0x16, 0x85, 0xa3, 0xe1, 0xf1, 0xc4, 0x1b,
-	0xf5, 0xea, 0xd4, 0x69, 0x11, 0x3b, 0xe5, 0x4e, 0x6d, 0x36, 0x22, 0x06, 0xf0, 0x58, 0xa1, 0x3c,
-	0x97, 0x61, 0x24, 0x7b, 0x0d, 0x5e, 0x4a, 0x81, 0xe2, 0x31, 0x34, 0x8f, 0xad, 0x91, 0x8c, 0x87,
-	0x81, 0x15, 0x5a, 0xe3, 0x9e, 0x96, 0x4d, 0xb4, 0x8d, 0xe8, 0x03, 0xc4, 0x46, 0x26, 0x1c, 0xa7,
-	0x80, 0x78, 0x0a, 0x6d, 0x82, 0xa2, 0xe1, 0xb1, 0xe3, 0xc6, 0x32, 0xec, 0xe9, 0x34, 0xa6, 0x43,
-	0x63, 0x08, 0x33, 0x08, 0xa5, 0x34, 0x5b, 0xdc, 0x89, 0x31, 0xe2, 0x6d, 0x00, 0x79, 0x11, 0x58,
-	0x9e, 0x3d, 0xb4, 0x5c, 0xb7, 0x07, 0xb4, 0x07, 0x9d, 0x31, 0xeb, 0xae, 0x2b, 0xbe, 0x8a, 0xfb,
-	0xb3, 0xec, 0x61, 0x1c, 0xf5, 0xda, 0x77, 0x4a, 0x2b, 0x55, 0xb3, 0x8e, 0xe0, 0x20, 0x42, 0xbe,
-	0x8e, 0xac, 0xd1, 0xa9, 0xec, 0x75, 0xee, 0x94, 0x56, 0x6a, 0x26, 0x03, 0x88, 0x3d, 0x76, 0xc2,
-	0x28, 0xee, 0x2d, 0x32, 0x96, 0x00, 0x94, 0x3c, 0xff, 0xf8, 0x38, 0x92, 0x71, 0xaf, 0x4b, 0x68,
-	0x05, 0x19, 0x6b, 0xa0, 0x93, 0x54, 0x11, 0xd7, 0xee, 0x41, 0xfd, 0x1c, 0x81, 0xa8, 0x57, 0xba,
-	0x53, 0x59, 0x69, 0xae, 0xb5, 0x71, 0xdb, 0xa9, 0xe0, 0x99, 0x8a, 0x68, 0xdc, 0x02, 0x6d, 0xd7,
-	0xf2, 0x4e, 0x68, 0x88, 0x80, 0x2a, 0x5e, 0x27, 0x0d, 0xd0, 0x4d, 0x6a, 0x1b, 0xbf, 0x5f, 0x86,
-	0xba, 0x29, 0xa3, 0x89, 0x1b, 0x8b, 0xf7, 0x00, 0xf0, 0xb2, 0xc6, 0x56, 0x1c, 0x3a, 0x17, 0x6a,
-	0xd6, 0xec, 0xba, 0xf4, 0x89, 0x63, 0xbf, 0x20, 0x92, 0x78, 0x0c, 0x2d, 0x9a, 0x3d, 0xe9, 0x5a,
-	0xce, 0x36, 0x90, 0xee, 0xcf, 0x6c, 0x52, 0x17, 0x35, 0xe2, 0x06, 0xd4, 0x49, 0x3e, 0x58, 0x46,
-	0xdb, 0xa6, 0x82, 0xc4, 0x3d, 0xe8, 0x38, 0x5e, 0x8c, 0xf7, 0x37, 0x8a, 0x87, 0xb6, 0x8c, 0x12,
-	0x01, 0x6a, 0xa7, 0xd8, 0x2d, 0x19, 0xc5, 0xe2, 0x09, 0xf0, 0x25, 0x24, 0x0b, 0xd6, 0x68, 0xc1,
-	0x4e, 0x7a, 0xb9, 0x11, 0xaf, 0x48, 0x7d, 0xd4, 0x8a, 0x0f, 0xa1, 0x89, 0xe7, 0x4b, 0x46, 0xd4,
-	0x69, 0x44, 0x8b, 0x4e, 0xa3, 0xd8, 0x61, 0x02, 0x76, 0x50, 0xdd, 0x91, 0x35, 0x28, 0xa4, 0x2c,
-	0x54, 0xd4, 0x36, 0xfa, 0x50, 0xdb, 0x0f, 0x6d, 0x19, 0xce, 0x7d, 0x27, 0x02, 0xaa, 0xb6, 0x8c,
-	0x46, 0xf4, 0x84, 0x35, 0x93, 0xda, 0xd9, 0xdb, 0xa9, 0xe4, 0xde, 0x8e, 0xf1, 0x07, 0x25, 0x68,
-	0x1e, 0xfa, 0x61, 0xfc, 0x42, 0x46, 0x91, 0x75, 0x22, 0xc5, 0x6d, 0xa8, 0xf9, 0x38, 0xad, 0xe2,
-	0xb0, 0x8e, 0x7b, 0xa2, 0x75, 0x4c, 0xc6, 0x4f, 0xdd, 0x43, 0xf9, 0xea, 0x7b, 0x40, 0x99, 0xa2,
-	0x57, 0x57, 0x51, 0x32, 0x45, 0x6f, 0x2e, 0x93, 0x9e, 0x6a, 0x5e, 0x7a, 0xae, 0x14, 0x4d, 0xe3,
-	0x9b, 0x00, 0xb8, 0xbf, 0x2f, 0x29, 0x05, 0xc6, 0x2f, 0x4a, 0xd0, 0x34, 0xad, 0xe3, 0x78, 0xd3,
-	0xf7, 0x62, 0x79, 0x11, 0x8b, 0x0e, 0x94, 0x1d, 0x9b, 0x78, 0x54, 0x37, 0xcb, 0x8e, 0x8d, 0xbb,
-	0x3b, 0x09, 0xfd, 0x09, 0xab, 0xcf, 0xb6, 0xc9, 0x00, 0xf1, 0xd2, 0xb6, 0x43, 0xda, 0x32, 0xf2,
-	0xd2, 0xb6, 0x43, 0x71, 0x1b, 0x9a, 0x91, 0x67, 0x05, 0xd1, 0xa9, 0x1f, 0xe3, 0xee, 0xaa, 0xb4,
-	0x3b, 0x48, 0x50, 0x83, 0x08, 0x1f, 0x9d, 0x13, 0x0d, 0x5d, 0x69, 0x85, 0x9e, 0x0c, 0x49, 0x91,
-	0x68, 0xa6, 0xee, 0x44, 0xbb, 0x8c, 0x30, 0x7e, 0x51, 0x81, 0xfa, 0x0b, 0x39, 0x3e, 0x92, 0xe1,
-	0xcc, 0x26, 0x1e, 0x83, 0x46, 0xeb, 0x0e, 0x1d, 0x9b, 0xf7, 0xb1, 0xf1, 0x95, 0xcf, 0x3f, 0xbd,
-	0xbd, 0x44, 0xb8, 0x1d, 0xfb, 0x03, 0x7f, 0xec, 0xc4, 0x72, 0x1c, 0xc4, 0x97, 0x66, 0x43, 0xa1,
-	0xe6, 0x6e, 0xf0, 0x06, 0xd4, 0x5d, 0x69, 0xe1, 0x9d, 0xb1, 0x78, 0x2a, 0x48, 0x3c, 0x84, 0x86,
-	0x35, 0x1e, 0xda, 0xd2, 0xb2, 0x79, 0x53, 0x1b, 0xd7, 0x3f, 0xff, 0xf4, 0x76, 0xd7, 0x1a, 0x6f,
-	0x49, 0x2b, 0x3f, 0x77, 0x9d, 0x31, 0xe2, 0x43, 0x94, 0xc9, 0x28, 0x1e, 0x4e, 0x02, 0xdb, 0x8a,
-	0x25, 0xe9, 0xba, 0xea, 0x46, 0xef, 0xf3, 0x4f, 0x6f, 0x5f, 0x47, 0xf4, 0x4b, 0xc2, 0xe6, 0x86,
-	0x41, 0x86, 0x45, 0xbd, 0x97, 0x1c, 0x5f, 0xe9, 0x3d, 0x05, 0x8a, 0x1d, 0x58, 0x1a, 0xb9, 0x93,
-	0x08, 0x95, 0xb3, 0xe3, 0x1d, 0xfb, 0x43, 0xdf, 0x73, 0x2f, 0xe9, 0x82, 0xb5, 0x8d, 0xb7, 0x3f,
-	0xff, 0xf4, 0xf6, 0xd7, 0x14, 0x71, 0xc7, 0x3b, 0xf6, 0xf7, 0x3d, 0xf7, 0x32, 0x37, 0xff, 0xe2,
-	0x14, 0x49, 0xfc, 0x16, 0x74, 0x8e, 0xfd, 0x70, 0x24, 0x87, 0x29, 0xcb, 0x3a, 0x34, 0xcf, 0xf2,
-	0xe7, 0x9f, 0xde, 0xbe, 0x41, 0x94, 0x67, 0x33, 0x7c, 0x6b, 0xe5, 0xf1, 0xc6, 0x3f, 0x95, 0xa1,
-	0x46, 0x6d, 0xf1, 0x18, 0x1a, 0x63, 0xba, 0x92, 0x44, 0x3f, 0xdd, 0x40, 0x19, 0x22, 0xda, 0x2a,
-	0xdf, 0x55, 0xd4, 0xf7, 0xe2, 0xf0, 0xd2, 0x4c, 0xba, 0xe1, 0x88, 0xd8, 0x3a, 0x72, 0x65, 0x1c,
-	0x29, 0x99, 0xcf, 0x8d, 0x18, 0x30, 0x41, 0x8d, 0x50, 0xdd, 0xa6, 0xe5, 0xa6, 0x32, 0x23, 0x37,
-	0xcb, 0xa0, 0x8d, 0x4e, 0xe5, 0xe8, 0x2c, 0x9a, 0x8c, 0x95, 0x54, 0xa5, 0xb0, 0xb8, 0x0b, 0x6d,
-	0x6a, 0x07, 0xbe, 0xe3, 0xd1, 0xf0, 0x1a, 0x75, 0x68, 0x65, 0xc8, 0x41, 0xb4, 0xbc, 0x0d, 0xad,
-	0xfc, 0x66, 0xd1, 0x9c, 0x9f, 0xc9, 0x4b, 0x92, 0xaf, 0xaa, 0x89, 0x4d, 0x71, 0x07, 0x6a, 0xa4,
-	0xe8, 0x48, 0xba, 0x9a, 0x6b, 0x80, 0x7b, 0xe6, 0x21, 0x26, 0x13, 0x3e, 0x2a, 0x7f, 0xab, 0x84,
-	0xf3, 0xe4, 0x8f, 0x90, 0x9f, 0x47, 0xbf, 0x7a, 0x1e, 0x1e, 0x92, 0x9b, 0xc7, 0xf0, 0xa1, 0xb1,
-	0xeb, 0x8c, 0xa4, 0x17, 0x91, 0xd1, 0x9f, 0x44, 0x32, 0x55, 0x4a, 0xd8, 0xc6, 0xf3, 0x8e, 0xad,
-	0x8b, 0x3d, 0xdf, 0x96, 0x11, 0xcd, 0x53, 0x35, 0x53, 0x18, 0x69, 0xf2, 0x22, 0x70, 0xc2, 0xcb,
-	0x01, 0x73, 0xaa, 0x62, 0xa6, 0x30, 0x4a, 0x97, 0xf4, 0x70, 0x31, 0x3b, 0x31, 0xe0, 0x0a, 0x34,
-	0x7e, 0x5e, 0x85, 0xd6, 0x0f, 0x65, 0xe8, 0x1f, 0x84, 0x7e, 0xe0, 0x47, 0x96, 0x2b, 0xd6, 0x8b,
-	0x3c, 0xe7, 0xbb, 0xbd, 0x83, 0xbb, 0xcd, 0x77, 0x5b, 0x3d, 0x4c, 0x2f, 0x81, 0xef, 0x2c, 0x7f,
-	0x2b, 0x06, 0xd4, 0xf9, 0xce, 0xe7, 0xf0, 0x4c, 0x51, 0xb0, 0x0f, 0xdf, 0x32, 0xed, 0xb5, 0xc8,
-	0x0f, 0x45, 0xc1, 0x57, 0x39, 0xb6, 0x2e, 0x5e, 0xee, 0x6c, 0xa9, 0xbb, 0x55, 0x90, 0xe2, 0xc2,
-	0xe0, 0xc2, 0x1b, 0x24, 0x97, 0x9a, 0xc2, 0x78, 0x52, 0xe4, 0x48, 0xb4, 0xb3, 0xd5, 0x6b, 0x11,
-	0x29, 0x01, 0xc5, 0x5b, 0xa0, 0x8f, 0xad, 0x0b, 0x54, 0x68, 0x3b, 0x36, 0x3f, 0x4d, 0x33, 0x43,
-	0x88, 0x77, 0xa0, 0x12, 0x5f, 0x78, 0xf4, 0xf6, 0xd0, 0xab, 0x40, 0x47, 0x74, 0x70, 0xe1, 0x29,
-	0xd5, 0x67, 0x22, 0x0d, 0xef, 0x74, 0xe4, 0xd8, 0xe4, 0x44, 0xe8, 0x26, 0x36, 0xc5, 0x3d, 0x68,
-	0xb8, 0x7c, 0x5b, 0xe4, 0x28, 0x34, 0xd7, 0x9a, 0xac, 0x47, 0x09, 0x65, 0x26, 0x34, 0xf1, 0x01,
-	0x68, 0x09, 0x77, 0x7a, 0x4d, 0xea, 0xd7, 0x4d, 0xf8, 0x99, 0xb0, 0xd1, 0x4c, 0x7b, 0x88, 0xc7,
-	0xa0, 0xdb, 0xd2, 0x95, 0xb1, 0x1c, 0x7a, 0xac, 0xc8, 0x9b, 0xec, 0x40, 0x6e, 0x11, 0x72, 0x2f,
-	0x32, 0xe5, 0x8f, 0x27, 0x32, 0x8a, 0x4d, 0xcd, 0x56, 0x88, 0xe5, 0xef, 0xc0, 0xe2, 0xd4, 0x75,
-	0xe4, 0xe5, 0xaf, 0xcd, 0xf2, 0x77, 0x3d, 0x2f, 0x7f, 0xd5, 0x9c, 0xcc, 0x7d, 0x52, 0xd5, 0xb4,
-	0xae, 0x6e, 0xfc, 0x7b, 0x05, 0x16, 0xd5, 0x53, 0x38, 0x75, 0x82, 0xc3, 0x58, 0x29, 0x25, 0x32,
-	0x39, 0x4a, 0x0a, 0xab, 0x66, 0x02, 0x8a, 0xff, 0x07, 0x75, 0xd2, 0x21, 0xc9, 0x53, 0xbe, 0x9d,
-	0x5d, 0x71, 0x3a, 0x9c, 0x9f, 0xb6, 0x92, 0x0f, 0xd5, 0x5d, 0x7c, 0x03, 0x6a, 0x3f, 0x95, 0xa1,
-	0xcf, 0x26, 0xb4, 0xb9, 0x76, 0x6b, 0xde, 0x38, 0x64, 0x8c, 0x1a, 0xc6, 0x9d, 0xff, 0xa7, 0x92,
-	0x00, 0x5f, 0x46, 0x12, 0xbe, 0x8e, 0x66, 0x74, 0xec, 0x9f, 0x4b, 0xbb, 0xd7, 0xa0, 0x3d, 0xe6,
-	0xc5, 0x37, 0x21, 0x25, 0xc2, 0xa0, 0xcd, 0x15, 0x06, 0xfd, 0x6a, 0x61, 0x58, 0xde, 0x82, 0x66,
-	0x8e, 0x2f, 0x73, 0x2e, 0xea, 0x76, 0x51, 0x51, 0xe8, 0xa9, 0x92, 0xcc, 0xeb, 0x9b, 0x2d, 0x80,
-	0x8c, 0x4b, 0xbf, 0xa9, 0xd6, 0x32, 0x7e, 0x56, 0x82, 0xc5, 0x4d, 0xdf, 0xf3, 0x24, 0x39, 0xe1,
-	0x7c, 0xe7, 0xd9, 0xe3, 0x2d, 0x5d, 0xf9, 0x78, 0xdf, 0x87, 0x5a, 0x84, 0x9d, 0xd5, 0xec, 0xd7,
-	0xe6, 0x5c, 0xa2, 0xc9, 0x3d, 0x50, 0x85, 0x8f, 0xad, 0x8b, 0x61, 0x20, 0x3d, 0xdb, 0xf1, 0x4e,
-	0x12, 0x15, 0x3e, 0xb6, 0x2e, 0x0e, 0x18, 0x63, 0xfc, 0x59, 0x19, 0xe0, 0x63, 0x69, 0xb9, 0xf1,
-	0x29, 0x9a, 0x29, 0xbc, 0x51, 0xc7, 0x8b, 0x62, 0xcb, 0x1b, 0x25, 0x21, 0x50, 0x0a, 0xe3, 0x8d,
-	0xa2, 0xb5, 0x96, 0x11, 0x2b, 0x3f, 0xdd, 0x4c, 0x40, 0x94, 0x0f, 0x5c, 0x6e, 0x12, 0x29, 0xab,
-	0xae, 0xa0, 0xcc, 0x45, 0xa9, 0x12, 0x5a, 0xb9, 0x28, 0x3d, 0x68, 0x60, 0x48, 0xe1, 0xf8, 0x1e,
-	0x09, 0x8d, 0x6e, 0x26, 0x20, 0xce, 0x33, 0x09, 0x62, 0x67, 0xcc, 0xb6, 0xbb, 0x62, 0x2a, 0x08,
-	0x77, 0x85, 0xb6, 0xba, 0x3f, 0x3a, 0xf5, 0x49, 0x45, 0x54, 0xcc, 0x14, 0xc6, 0xd9, 0x7c, 0xef,
-	0xc4, 0xc7, 0xd3, 0x69, 0xe4, 0x16, 0x26, 0x20, 0x9f, 0xc5, 0x96, 0x17, 0x48, 0xd2, 0x89, 0x94,
-	0xc2, 0xc8, 0x17, 0x29, 0x87, 0xc7, 0xd2, 0x8a, 0x27, 0xa1, 0x8c, 0x7a, 0x40, 0x64, 0x90, 0x72,
-	0x5b, 0x61, 0xc4, 0x3b, 0xd0, 0x42, 0xc6, 0x59, 0x51, 0xe4, 0x9c, 0x78, 0xd2, 0x26, 0xc5, 0x51,
-	0x35, 0x91, 0x99, 0xeb, 0x0a, 0x65, 0xfc, 0x65, 0x19, 0xea, 0xac, 0x32, 0x0b, 0x6e, 0x50, 0xe9,
-	0x0b, 0xb9, 0x41, 0x6f, 0x81, 0x1e, 0x84, 0xd2, 0x76, 0x46, 0xc9, 0x3d, 0xea, 0x66, 0x86, 0xa0,
-	0xb8, 0x05, 0xed, 0x3e, 0xf1, 0x53, 0x33, 0x19, 0x10, 0x06, 0xb4, 0x7d, 0x6f, 0x68, 0x3b, 0xd1,
-	0xd9, 0xf0, 0xe8, 0x32, 0x96, 0x91, 0xe2, 0x45, 0xd3, 0xf7, 0xb6, 0x9c, 0xe8, 0x6c, 0x03, 0x51,
-	0xc8, 0x42, 0x7e, 0x23, 0xf4, 0x36, 0x34, 0x53, 0x41, 0xe2, 0x29, 0xe8, 0xe4, 0x9d, 0x92, 0xfb,
-	0xa2, 0x93, 0xdb, 0x71, 0xe3, 0xf3, 0x4f, 0x6f, 0x0b, 0x44, 0x4e, 0xf9, 0x2d, 0x5a, 0x82, 0x43,
-	0xff, 0x0b, 0x07, 0xa3, 0x21, 0xa2, 0x37, 0xcc, 0xfe, 0x17, 0xa2, 0x06, 0x51, 0xde, 0xff, 0x62,
-	0x8c, 0x78, 0x08, 0x62, 0xe2, 0x8d, 0xfc, 0x71, 0x80, 0x42, 0x21, 0x6d, 0xb5, 0xc9, 0x26, 0x6d,
-	0x72, 0x29, 0x4f, 0xa1, 0xad, 0x1a, 0xff, 0x58, 0x86, 0xd6, 0x96, 0x13, 0xca, 0x51, 0x2c, 0xed,
-	0xbe, 0x7d, 0x22, 0x71, 0xef, 0xd2, 0x8b, 0x9d, 0xf8, 0x52, 0x39, 0x98, 0x0a, 0x4a, 0xe3, 0x83,
-	0x72, 0x31, 0x8e, 0xe6, 0x17, 0x56, 0xa1, 0xd0, 0x9f, 0x01, 0xb1, 0x06, 0xc0, 0x91, 0x13, 0x85,
-	0xff, 0xd5, 0xab, 0xc3, 0x7f, 0x9d, 0xba, 0x61, 0x13, 0xc3, 0x6b, 0x1e, 0xe3, 0xb0, 0x97, 0x59,
-	0xa7, 0xdc, 0xc0, 0x44, 0xb2, 0xaf, 0x4a, 0x01, 0x5d, 0x83, 0x17, 0xc6, 0xb6, 0xb8, 0x0b, 0x65,
-	0x3f, 0x20, 0xe6, 0xaa, 0xa9, 0xf3, 0x47, 0x58, 0xdd, 0x0f, 0xcc, 0xb2, 0x1f, 0xe0, 0x2b, 0xe6,
-	0xa8, 0x96, 0x04, 0x0f, 0x5f, 0x31, 0x5a, 0x34, 0x8a, 0xa5, 0x4c, 0x45, 0x11, 0x06, 0xb4, 0x2c,
-	0xd7, 0xf5, 0x7f, 0x22, 0xed, 0x83, 0x50, 0xda, 0x89, 0x0c, 0x16, 0x70, 0x28, 0x25, 0x9e, 0x35,
-	0x96, 0x51, 0x60, 0x8d, 0xa4, 0x12, 0xc1, 0x0c, 0x61, 0xdc, 0x80, 0xf2, 0x7e, 0x20, 0x1a, 0x50,
-	0x39, 0xec, 0x0f, 0xba, 0x0b, 0xd8, 0xd8, 0xea, 0xef, 0x76, 0xd1, 0xa2, 0xd4, 0xbb, 0x0d, 0xe3,
-	0xb3, 0x32, 0xe8, 0x2f, 0x26, 0xb1, 0x85, 0xba, 0x25, 0xc2, 0x53, 0x16, 0x25, 0x34, 0x13, 0xc5,
-	0xaf, 0x81, 0x16, 0xc5, 0x56, 0x48, 0xfe, 0x06, 0x5b, 0xa7, 0x06, 0xc1, 0x83, 0x48, 0xbc, 0x0b,
-	0x35, 0x69, 0x9f, 0xc8, 0xc4, 0x5c, 0x74, 0xa7, 0xcf, 0x6b, 0x32, 0x59, 0xac, 0x40, 0x3d, 0x1a,
-	0x9d, 0xca, 0xb1, 0xd5, 0xab, 0x66, 0x1d, 0x0f, 0x09, 0xc3, 0x0e, 0xb6, 0xa9, 0xe8, 0xe2, 0xeb,
-	0x50, 0xc3, 0xbb, 0x89, 0x54, 0xc4, 0x48, 0x31, 0x26, 0x5e, 0x83, 0xea, 0xc6, 0x44, 0x14, 0x3c,
-	0x3b, 0xf4, 0x83, 0xa1, 0x1f, 0x10, 0xef, 0x3b, 0x6b, 0xd7, 0x49, 0xc7, 0x25, 0xa7, 0x59, 0xdd,
-	0x0a, 0xfd, 0x60, 0x3f, 0x30, 0xeb, 0x36, 0xfd, 0x62, 0xfc, 0x42, 0xdd, 0x59, 0x22, 0xd8, 0x28,
-	0xe8, 0x88, 0xe1, 0x24, 0xd1, 0x0a, 0x68, 0x63, 0x19, 0x5b, 0xb6, 0x15, 0x5b, 0xca, 0x36, 0xb4,
-	0x58, 0x65, 0x32, 0xce, 0x4c, 0xa9, 0xc6, 0x23, 0xa8, 0xf3, 0xd4, 0x42, 0x83, 0xea, 0xde, 0xfe,
-	0x5e, 0x9f, 0xd9, 0xba, 0xbe, 0xbb, 0xdb, 0x2d, 0x21, 0x6a, 0x6b, 0x7d, 0xb0, 0xde, 0x2d, 0x63,
-	0x6b, 0xf0, 0x83, 0x83, 0x7e, 0xb7, 0x62, 0xfc, 0x4d, 0x09, 0xb4, 0x64, 0x1e, 0xf1, 0x11, 0x00,
-	0x3e, 0xe1, 0xe1, 0xa9, 0xe3, 0xa5, 0xae, 0xdb, 0xcd, 0xfc, 0x4a, 0xab, 0x78, 0xab, 0x1f, 0x23,
-	0x95, 0xcd, 0x2b, 0xbd, 0x78, 0x82, 0x97, 0x0f, 0xa1, 0x53, 0x24, 0xce, 0xf1, 0x61, 0x1f, 0xe4,
-	0xad, 0x4a, 0x67, 0xed, 0x2b, 0x85, 0xa9, 0x71, 0x24, 0x89, 0x76, 0xce, 0xc0, 0x3c, 0x04, 0x2d,
-	0x41, 0x8b, 0x26, 0x34, 0xb6, 0xfa, 0xdb, 0xeb, 0x2f, 0x77, 0x51, 0x54, 0x00, 0xea, 0x87, 0x3b,
-	0x7b, 0xcf, 0x76, 0xfb, 0x7c, 0xac, 0xdd, 0x9d, 0xc3, 0x41, 0xb7, 0x6c, 0xfc, 0x49, 0x09, 0xb4,
-	0xc4, 0x93, 0x11, 0xef, 0xa3, 0xf3, 0x41, 0xee, 0x97, 0xb2, 0x44, 0x94, 0xeb, 0xc9, 0x05, 0xa4,
-	0x66, 0x42, 0xc7, 0xb7, 0x48, 0x8a, 0x35, 0xf1, 0x6d, 0x08, 0xc8, 0xc7, 0xc3, 0x95, 0x42, 0xaa,
-	0x06, 0x43, 0x7b, 0xdf, 0x93, 0xca, 0x15, 0xa6, 0x36, 0xc9, 0xa0, 0xe3, 0x8d, 0x64, 0x16, 0x28,
-	0x34, 0x08, 0x1e, 0xcc, 0x6a, 0xe2, 0xfa, 0xac, 0x26, 0x8e, 0xd9, 0x89, 0x4e, 0xf7, 0x9e, 0x6e,
-	0xa8, 0x94, 0xdf, 0xd0, 0x4c, 0x44, 0x52, 0x9e, 0x8d, 0x48, 0x32, 0xdb, 0x5a, 0x7b, 0x93, 0x6d,
-	0x35, 0xfe, 0xb3, 0x0a, 0x1d, 0x53, 0x46, 0xb1, 0x1f, 0x4a, 0xe5, 0x14, 0xbe, 0xee, 0x95, 0xbd,
-	0x0d, 0x10, 0x72, 0xe7, 0x6c, 0x69, 0x5d, 0x61, 0x38, 0x94, 0x72, 0xfd, 0x11, 0x89, 0xb7, 0x32,
-	0xa2, 0x29, 0x2c, 0x6e, 0x82, 0x7e, 0x64, 0x8d, 0xce, 0x78, 0x5a, 0x36, 0xa5, 0x1a, 0x23, 0x78,
-	0x5e, 0x6b, 0x34, 0x92, 0x51, 0x34, 0x44, 0x69, 0x61, 0x83, 0xaa, 0x33, 0xe6, 0xb9, 0xbc, 0x44,
-	0x72, 0x24, 0x47, 0xa1, 0x8c, 0x89, 0x5c, 0x67, 0x32, 0x63, 0x90, 0x7c, 0x17, 0xda, 0x91, 0x8c,
-	0xd0, 0xf8, 0x0e, 0x63, 0xff, 0x4c, 0x7a, 0x4a, 0xd5, 0xb5, 0x14, 0x72, 0x80, 0x38, 0xd4, 0x42,
-	0x96, 0xe7, 0x7b, 0x97, 0x63, 0x7f, 0x12, 0x29, 0xb3, 0x92, 0x21, 0xc4, 0x2a, 0x5c, 0x93, 0xde,
-	0x28, 0xbc, 0x0c, 0x70, 0xaf, 0xb8, 0xca, 0xf0, 0xd8, 0x71, 0xa5, 0xf2, 0xd3, 0x97, 0x32, 0xd2,
-	0x73, 0x79, 0xb9, 0xed, 0xb8, 0x12, 0x77, 0x74, 0x6e, 0x4d, 0xdc, 0x78, 0x48, 0x69, 0x00, 0xe0,
-	0x1d, 0x11, 0x66, 0xdd, 0xb6, 0x43, 0x71, 0x1f, 0x96, 0x98, 0x1c, 0xfa, 0xae, 0x74, 0x6c, 0x9e,
-	0xac, 0x49, 0xbd, 0x16, 0x89, 0x60, 0x12, 0x9e, 0xa6, 0x5a, 0x85, 0x6b, 0xdc, 0x97, 0x0f, 0x94,
-	0xf4, 0x6e, 0xf1, 0xd2, 0x44, 0x3a, 0x54, 0x94, 0xe2, 0xd2, 0x81, 0x15, 0x9f, 0x92, 0x73, 0x9f,
-	0x2c, 0x7d, 0x60, 0xc5, 0xa7, 0xe8, 0x14, 0x30, 0xf9, 0xd8, 0x91, 0x2e, 0x07, 0xe7, 0xba, 0xc9,
-	0x23, 0xb6, 0x11, 0x83, 0xa2, 0xa8, 0x3a, 0xf8, 0xe1, 0xd8, 0xe2, 0xac, 0xa2, 0x6e, 0xf2, 0xa0,
-	0x6d, 0x42, 0xe1, 0x12, 0xea, 0xae, 0xbc, 0xc9, 0x98, 0xf2, 0x8b, 0x55, 0x53, 0xdd, 0xde, 0xde,
-	0x64, 0x2c, 0xde, 0x87, 0xae, 0xe3, 0x8d, 0x42, 0x39, 0x96, 0x5e, 0x6c, 0xb9, 0xc3, 0xe3, 0xd0,
-	0x1f, 0xf7, 0x96, 0xa8, 0xd3, 0x62, 0x0e, 0xbf, 0x1d, 0xfa, 0x63, 0x95, 0x94, 0x09, 0xac, 0x30,
-	0x76, 0x2c, 0xb7, 0x27, 0x92, 0xa4, 0xcc, 0x01, 0x23, 0x8c, 0xff, 0xaa, 0x80, 0x96, 0x46, 0x8d,
-	0x0f, 0x40, 0x1f, 0x27, 0xca, 0x51, 0x79, 0x85, 0xed, 0x82, 0xc6, 0x34, 0x33, 0xba, 0x78, 0x1b,
-	0xca, 0x67, 0xe7, 0x4a, 0x51, 0xb7, 0x57, 0x39, 0xa7, 0x1f, 0x1c, 0x3d, 0x5d, 0x7d, 0xfe, 0xca,
-	0x2c, 0x9f, 0x9d, 0x7f, 0x89, 0x17, 0x20, 0xde, 0x83, 0xc5, 0x91, 0x2b, 0x2d, 0x6f, 0x98, 0xb9,
-	0x32, 0x2c, 0x61, 0x1d, 0x42, 0x1f, 0xa4, 0xfe, 0xcc, 0x3d, 0xa8, 0xd9, 0xd2, 0x8d, 0xad, 0x7c,
-	0xda, 0x78, 0x3f, 0xb4, 0x46, 0xae, 0xdc, 0x42, 0xb4, 0xc9, 0x54, 0x54, 0xd4, 0x69, 0xa4, 0x96,
-	0x53, 0xd4, 0x73, 0xa2, 0xb4, 0xf4, 0x85, 0x43, 0xfe, 0x85, 0x3f, 0x80, 0x25, 0x79, 0x11, 0x90,
-	0x75, 0x1a, 0xa6, 0x89, 0x09, 0x36, 0x9b, 0xdd, 0x84, 0xb0, 0x99, 0x24, 0x28, 0x3e, 0x40, 0xfd,
-	0x44, 0xcf, 0x8f, 0x04, 0xa6, 0xb9, 0x26, 0x48, 0xc1, 0x15, 0x1e, 0xb4, 0x99, 0x74, 0x11, 0xef,
-	0x83, 0x3e, 0xb2, 0x47, 0x43, 0xe6, 0x4c, 0x3b, 0xdb, 0xdb, 0xe6, 0xd6, 0x26, 0xb3, 0x44, 0x1b,
-	0xd9, 0x23, 0x76, 0xe1, 0x0b, 0x11, 0x64, 0xe7, 0x0b, 0x44, 0x90, 0x89, 0xaa, 0x5f, 0xcc, 0x02,
-	0x88, 0xbc, 0x4d, 0xee, 0x16, 0x6c, 0xf2, 0x27, 0x55, 0xad, 0xd1, 0xd5, 0x8c, 0xbb, 0xa0, 0x25,
-	0x4b, 0xa3, 0xa6, 0x8d, 0xa4, 0xa7, 0xf2, 0x05, 0xa4, 0x69, 0x11, 0x1c, 0x44, 0xc6, 0x08, 0x2a,
-	0xcf, 0x5f, 0x1d, 0x92, 0xc2, 0x45, 0xdb, 0x57, 0x23, 0x57, 0x89, 0xda, 0xa9, 0x12, 0x2e, 0xe7,
-	0x94, 0xf0, 0x2d, 0xb6, 0x5f, 0x74, 0x65, 0x49, 0x92, 0x35, 0x87, 0x41, 0xa6, 0xb3, 0xed, 0xae,
-	0x72, 0xfe, 0x95, 0x00, 0xe3, 0x5f, 0x2b, 0xd0, 0x50, 0xee, 0x15, 0x1e, 0x64, 0x92, 0xe6, 0x07,
-	0xb1, 0x59, 0x8c, 0x7b, 0x53, 0x3f, 0x2d, 0x5f, 0xa4, 0xa9, 0xbc, 0xb9, 0x48, 0x23, 0x3e, 0x82,
-	0x56, 0xc0, 0xb4, 0xbc, 0x67, 0xf7, 0xd5, 0xfc, 0x18, 0xf5, 0x4b, 0xe3, 0x9a, 0x41, 0x06, 0x20,
-	0x2b, 0x29, 0x53, 0x1d, 0x5b, 0x27, 0x8a, 0x03, 0x0d, 0x84, 0x07, 0xd6, 0xc9, 0x17, 0x72, 0xd3,
-	0x3a, 0xe4, 0xef, 0xb5, 0x48, 0x99, 0xa3, 0x6b, 0x97, 0xbf, 0x99, 0x76, 0xd1, 0x5b, 0xba, 0x09,
-	0xfa, 0xc8, 0x1f, 0x8f, 0x1d, 0xa2, 0x75, 0x54, 0x3e, 0x8c, 0x10, 0x83, 0xc8, 0xf8, 0x79, 0x09,
-	0x1a, 0xea, 0x5c, 0x33, 0xb6, 0x78, 0x63, 0x67, 0x6f, 0xdd, 0xfc, 0x41, 0xb7, 0x84, 0xbe, 0xc6,
-	0xce, 0xde, 0xa0, 0x5b, 0x16, 0x3a, 0xd4, 0xb6, 0x77, 0xf7, 0xd7, 0x07, 0xdd, 0x0a, 0xda, 0xe7,
-	0x8d, 0xfd, 0xfd, 0xdd, 0x6e, 0x55, 0xb4, 0x40, 0xdb, 0x5a, 0x1f, 0xf4, 0x07, 0x3b, 0x2f, 0xfa,
-	0xdd, 0x1a, 0xf6, 0x7d, 0xd6, 0xdf, 0xef, 0xd6, 0xb1, 0xf1, 0x72, 0x67, 0xab, 0xdb, 0x40, 0xfa,
-	0xc1, 0xfa, 0xe1, 0xe1, 0xf7, 0xf6, 0xcd, 0xad, 0xae, 0x46, 0x36, 0x7e, 0x60, 0xee, 0xec, 0x3d,
-	0xeb, 0xea, 0xd8, 0xde, 0xdf, 0xf8, 0xa4, 0xbf, 0x39, 0xe8, 0x82, 0xf1, 0x04, 0x9a, 0x39, 0x5e,
-	0xe1, 0x68, 0xb3, 0xbf, 0xdd, 0x5d, 0xc0, 0x25, 0x5f, 0xad, 0xef, 0xbe, 0x44, 0x97, 0xa0, 0x03,
-	0x40, 0xcd, 0xe1, 0xee, 0xfa, 0xde, 0xb3, 0x6e, 0x59, 0x39, 0x94, 0xbf, 0x57, 0x4a, 0x47, 0x52,
-	0xb9, 0xe3, 0x3d, 0xd0, 0x14, 0x9f, 0x93, 0x34, 0x44, 0x33, 0x77, 0x21, 0x66, 0x4a, 0x2c, 0xf2,
-	0xa5, 0x52, 0xe4, 0x0b, 0xc5, 0x8e, 0x81, 0xeb, 0xc4, 0x2c, 0x55, 0x28, 0xbb, 0x04, 0xe5, 0xca,
-	0x83, 0xb5, 0x7c, 0x79, 0xf0, 0x93, 0xaa, 0x56, 0xea, 0x96, 0x8d, 0x6f, 0x00, 0x64, 0x65, 0xa7,
-	0x39, 0xae, 0xd2, 0x75, 0xa8, 0x59, 0xae, 0x63, 0x25, 0x91, 0x2a, 0x03, 0xc6, 0x1e, 0x34, 0x73,
-	0xc5, 0x2a, 0xbc, 0x4a, 0xcb, 0x75, 0xd1, 0x64, 0xf1, 0xc3, 0xd1, 0xcc, 0x86, 0xe5, 0xba, 0xcf,
-	0xe5, 0x65, 0x84, 0x6e, 0x2a, 0xd7, 0xb9, 0xca, 0x53, 0xa5, 0x10, 0x1a, 0x6a, 0x32, 0xd1, 0xf8,
-	0x00, 0xea, 0xdb, 0x89, 0x33, 0x9f, 0x48, 0x52, 0xe9, 0x2a, 0x49, 0x32, 0x3e, 0x54, 0x7b, 0xa6,
-	0x6a, 0x8a, 0x78, 0xa0, 0xea, 0x69, 0x11, 0x57, 0xef, 0x4a, 0x59, 0xae, 0x83, 0x3b, 0xa9, 0x52,
-	0x1a, 0x75, 0x36, 0xb6, 0x40, 0x7b, 0x6d, 0x85, 0x52, 0x31, 0xa0, 0x9c, 0x31, 0x60, 0x4e, 0xcd,
-	0xd2, 0xf8, 0x11, 0x40, 0x56, 0x77, 0x53, 0x82, 0xcd, 0xb3, 0xa0, 0x60, 0xdf, 0x07, 0x6d, 0x74,
-	0xea, 0xb8, 0x76, 0x28, 0xbd, 0xc2, 0xa9, 0xb3, 0x4a, 0x5d, 0x4a, 0x17, 0x77, 0xa0, 0x4a, 0xe5,
-	0xc4, 0x4a, 0xa6, 0x08, 0xd3, 0x5a, 0x22, 0x51, 0x8c, 0x0b, 0x68, 0xb3, 0xff, 0xff, 0x05, 0x5c,
-	0xa3, 0xa2, 0xde, 0x29, 0xcf, 0xe8, 0x9d, 0x1b, 0x50, 0x27, 0x8b, 0x9c, 0x9c, 0x46, 0x41, 0x57,
-	0xe8, 0xa3, 0xdf, 0x2d, 0x03, 0xf0, 0xd2, 0x7b, 0xbe, 0x2d, 0x8b, 0x81, 0x76, 0x69, 0x3a, 0xd0,
-	0x16, 0x50, 0x4d, 0x2b, 0xc5, 0xba, 0x49, 0xed, 0xcc, 0xb6, 0xa8, 0xe0, 0x9b, 0x6d, 0xcb, 0x5b,
-	0xa0, 0x93, 0x87, 0xe4, 0xfc, 0x94, 0xca, 0x14, 0xb8, 0x60, 0x86, 0xc8, 0xd7, 0x4d, 0x6b, 0xc5,
-	0xba, 0x69, 0x5a, 0x44, 0xaa, 0xf3, 0x6c, 0x5c, 0x44, 0x9a, 0x53, 0x0f, 0xe3, 0xec, 0x47, 0x24,
-	0xc3, 0x38, 0x09, 0xdd, 0x19, 0x4a, 0xa3, 0x50, 0x5d, 0xf5, 0xb5, 0x38, 0x7f, 0xe1, 0xf9, 0xc3,
-	0x91, 0xef, 0x1d, 0xbb, 0xce, 0x28, 0x56, 0x75, 0x52, 0xf0, 0xfc, 0x4d, 0x85, 0x31, 0x3e, 0x82,
-	0x56, 0xc2, 0x7f, 0x2a, 0x3b, 0xdd, 0x4f, 0x23, 0xb4, 0x52, 0x76, 0xb7, 0x19, 0x9b, 0x36, 0xca,
-	0xbd, 0x52, 0x12, 0xa3, 0x19, 0xff, 0x51, 0x49, 0x06, 0xab, 0xea, 0xc8, 0xeb, 0x79, 0x58, 0x0c,
-	0xba, 0xcb, 0x5f, 0x28, 0xe8, 0xfe, 0x16, 0xe8, 0x36, 0xc5, 0x91, 0xce, 0x79, 0x62, 0x01, 0x96,
-	0xa7, 0x63, 0x46, 0x15, 0x69, 0x3a, 0xe7, 0xd2, 0xcc, 0x3a, 0xbf, 0xe1, 0x1e, 0x52, 0x6e, 0xd7,
-	0xe6, 0x71, 0xbb, 0xfe, 0x1b, 0x72, 0xfb, 0x1d, 0x68, 0x79, 0xbe, 0x37, 0xf4, 0x26, 0xae, 0x6b,
-	0x1d, 0xb9, 0x52, 0xb1, 0xbb, 0xe9, 0xf9, 0xde, 0x9e, 0x42, 0xa1, 0xdb, 0x9a, 0xef, 0xc2, 0x8f,
-	0xba, 0x49, 0xfd, 0x16, 0x73, 0xfd, 0xe8, 0xe9, 0xaf, 0x40, 0xd7, 0x3f, 0xfa, 0x91, 0x1c, 0xc5,
-	0xc4, 0xb1, 0x21, 0xbd, 0x66, 0xf6, 0x59, 0x3b, 0x8c, 0x47, 0x16, 0xed, 0xe1, 0xbb, 0x9e, 0xba,
-	0xe6, 0xf6, 0xcc, 0x35, 0x7f, 0x08, 0x7a, 0xca, 0xa5, 0x5c, 0xcc, 0xaa, 0x43, 0x6d, 0x67, 0x6f,
-	0xab, 0xff, 0xfd, 0x6e, 0x09, 0x6d, 0x8d, 0xd9, 0x7f, 0xd5, 0x37, 0x0f, 0xfb, 0xdd, 0x32, 0xda,
-	0x81, 0xad, 0xfe, 0x6e, 0x7f, 0xd0, 0xef, 0x56, 0xd8, 0x8f, 0xa0, 0x22, 0x85, 0xeb, 0x8c, 0x9c,
-	0xd8, 0x38, 0x04, 0xc8, 0x02, 0x71, 0xd4, 0xd9, 0xd9, 0xe6, 0x54, 0x26, 0x30, 0x4e, 0xb6, 0xb5,
-	0x92, 0x3e, 0xc8, 0xf2, 0x55, 0xe1, 0x3e, 0xd3, 0x8d, 0x35, 0xd0, 0x5f, 0x58, 0xc1, 0xc7, 0x5c,
-	0xce, 0xbb, 0x07, 0x1d, 0x72, 0x67, 0x93, 0x40, 0x81, 0x95, 0x65, 0xcb, 0x6c, 0xa7, 0x58, 0xd4,
-	0xbd, 0xc6, 0xdf, 0x96, 0xe0, 0xfa, 0x0b, 0xff, 0x5c, 0xa6, 0xee, 0xe3, 0x81, 0x75, 0xe9, 0xfa,
-	0x96, 0xfd, 0x06, 0x31, 0xc4, 0x48, 0xc7, 0x9f, 0x50, 0x79, 0x2d, 0x29, 0x46, 0x9a, 0x3a, 0x63,
-	0x9e, 0xa9, 0xaf, 0x28, 0x64, 0x14, 0x13, 0xb1, 0xc2, 0xfa, 0x07, 0x61, 0x24, 0xe5, 0x22, 0xd5,
-	0x6a, 0x21, 0x52, 0x9d, 0xeb, 0x4f, 0xd6, 0xae, 0xf0, 0x27, 0xf3, 0x21, 0x6c, 0xbd, 0x10, 0xc2,
-	0x1a, 0x9b, 0xa0, 0x0f, 0x2e, 0x28, 0xc1, 0x3b, 0x89, 0x0a, 0x0e, 0x44, 0xe9, 0x35, 0x0e, 0x44,
-	0x79, 0xca, 0x81, 0xf8, 0x97, 0x12, 0x34, 0x73, 0x3e, 0xb3, 0x78, 0x07, 0xaa, 0xf1, 0x85, 0x57,
-	0xfc, 0x3c, 0x21, 0x59, 0xc4, 0x24, 0xd2, 0x4c, 0xe8, 0x5c, 0x9e, 0x09, 0x9d, 0xc5, 0x2e, 0x2c,
-	0xb2, 0x5a, 0x4e, 0xce, 0x97, 0xe4, 0x7a, 0xee, 0x4e, 0xf9, 0xe8, 0x9c, 0x04, 0x4f, 0x4e, 0xab,
-	0x12, 0x18, 0x9d, 0x93, 0x02, 0x72, 0x79, 0x1d, 0xae, 0xcd, 0xe9, 0xf6, 0x65, 0xca, 0x21, 0xc6,
-	0x6d, 0x68, 0x0f, 0x2e, 0xbc, 0x81, 0x33, 0x96, 0x51, 0x6c, 0x8d, 0x03, 0x72, 0xc0, 0x94, 0x59,
-	0xad, 0x9a, 0xe5, 0x38, 0x32, 0xde, 0x85, 0xd6, 0x81, 0x94, 0xa1, 0x29, 0xa3, 0xc0, 0xf7, 0x22,
-	0x99, 0x4b, 0x3e, 0xb3, 0x0d, 0x57, 0x90, 0xf1, 0x3b, 0xa0, 0x9b, 0xd6, 0x71, 0xbc, 0x61, 0xc5,
-	0xa3, 0xd3, 0x2f, 0x93, 0xcd, 0x78, 0x17, 0x1a, 0x01, 0x0b, 0x9c, 0x8a, 0xa4, 0x5a, 0x64, 0xcb,
-	0x95, 0x10, 0x9a, 0x09, 0xd1, 0xf8, 0x6d, 0xb8, 0x76, 0x38, 0x39, 0x8a, 0x46, 0xa1, 0x43, 0xe1,
-	0x6d, 0x62, 0xe7, 0x96, 0x41, 0x0b, 0x42, 0x79, 0xec, 0x5c, 0xc8, 0x44, 0xbc, 0x53, 0x58, 0xdc,
-	0x87, 0xc6, 0x18, 0xb7, 0x23, 0xb3, 0x87, 0x93, 0x85, 0x5f, 0x2f, 0x90, 0x62, 0x26, 0x1d, 0x8c,
-	0x6f, 0xc3, 0xf5, 0xe2, 0xf4, 0xea, 0xb8, 0x77, 0xa1, 0x72, 0x76, 0x1e, 0xa9, 0x53, 0x2c, 0x15,
-	0xc2, 0x37, 0xfa, 0x82, 0x00, 0xa9, 0xc6, 0x9f, 0x97, 0xa0, 0x82, 0xe1, 0x66, 0xee, 0xf3, 0xa8,
-	0x2a, 0x7f, 0x1e, 0x75, 0x33, 0x9f, 0x07, 0x66, 0xe7, 0x3f, 0xcb, 0xf7, 0xbe, 0x05, 0xfa, 0xb1,
-	0x1f, 0xfe, 0xc4, 0x0a, 0x6d, 0x69, 0x2b, 0xeb, 0x97, 0x21, 0x50, 0x33, 0x1e, 0x4d, 0xc6, 0x81,
-	0x52, 0xad, 0xd4, 0x16, 0xf7, 0x94, 0xfd, 0x64, 0x87, 0x7c, 0x09, 0x99, 0xba, 0x37, 0x19, 0xaf,
-	0xba, 0xd2, 0x8a, 0x48, 0xd1, 0xb3, 0x49, 0x35, 0x1e, 0x80, 0x9e, 0xa2, 0x50, 0x39, 0xed, 0x1d,
-	0x0e, 0x77, 0xb6, 0x38, 0xb7, 0x86, 0xae, 0x6b, 0x09, 0x15, 0xd3, 0xe0, 0xfb, 0x7b, 0xc3, 0xc1,
-	0x61, 0xb7, 0x6c, 0xfc, 0x10, 0x9a, 0x89, 0x78, 0xee, 0xd8, 0x54, 0x48, 0xa2, 0xf7, 0xb1, 0x63,
-	0x17, 0x9e, 0xcb, 0x0e, 0xc5, 0x16, 0xd2, 0xb3, 0x77, 0x12, 0xb9, 0x66, 0xa0, 0x78, 0x42, 0x55,
-	0x95, 0x4a, 0x4e, 0x68, 0xf4, 0x61, 0xc9, 0xa4, 0x84, 0x38, 0x1a, 0xbd, 0xe4, 0xca, 0x6e, 0x40,
-	0xdd, 0xf3, 0x6d, 0x99, 0x2e, 0xa0, 0x20, 0x5c, 0x59, 0xb9, 0x28, 0x4a, 0x9d, 0x24, 0xa0, 0x21,
-	0x61, 0x09, 0x35, 0x94, 0x2a, 0x98, 0xaa, 0x69, 0x0a, 0xc9, 0xda, 0xd2, 0x54, 0xb2, 0x16, 0x17,
-	0x51, 0x15, 0x57, 0xf6, 0x35, 0x92, 0x2a, 0xeb, 0x32, 0x68, 0x76, 0x14, 0xd3, 0xab, 0x51, 0x7a,
-	0x29, 0x85, 0x8d, 0x47, 0x70, 0x6d, 0x3d, 0x08, 0xdc, 0xcb, 0xa4, 0x8a, 0xa5, 0x16, 0xea, 0x65,
-	0xa5, 0xae, 0x92, 0x0a, 0x68, 0x18, 0x34, 0xb6, 0xa1, 0x95, 0x04, 0xcb, 0x2f, 0x64, 0x6c, 0x91,
-	0x42, 0x71, 0x9d, 0x42, 0x6c, 0xa8, 0x31, 0x62, 0x50, 0x4c, 0x09, 0x4f, 0x9d, 0x6f, 0x15, 0xea,
-	0x4a, 0x5b, 0x09, 0xa8, 0x8e, 0x7c, 0x9b, 0x17, 0xaa, 0x99, 0xd4, 0x46, 0xa9, 0x1a, 0x47, 0x27,
-	0x89, 0xb7, 0x39, 0x8e, 0x4e, 0x8c, 0xbf, 0x2f, 0x43, 0x7b, 0x83, 0x92, 0x1c, 0xc9, 0x1e, 0x73,
-	0x3a, 0xb5, 0x54, 0xd0, 0xa9, 0x79, 0x35, 0x59, 0x2e, 0x66, 0xfa, 0xf2, 0x1b, 0xaa, 0x14, 0x5d,
-	0xc4, 0xaf, 0x42, 0x63, 0xe2, 0x39, 0x17, 0x89, 0x8a, 0xd6, 0xcd, 0x3a, 0x82, 0x83, 0x48, 0xdc,
-	0x81, 0x26, 0xaa, 0x71, 0xc7, 0xe3, 0xd4, 0x19, 0xe7, 0xbf, 0xf2, 0xa8, 0xa9, 0x04, 0x59, 0xfd,
-	0xf5, 0x09, 0xb2, 0xc6, 0x1b, 0x13, 0x64, 0xda, 0x9b, 0x12, 0x64, 0xfa, 0x74, 0x82, 0xac, 0xe8,
-	0xde, 0xc2, 0x8c, 0x7b, 0xfb, 0x36, 0x00, 0x7f, 0x16, 0x72, 0x3c, 0x71, 0x5d, 0xe5, 0x33, 0xe8,
-	0x84, 0xd9, 0x9e, 0xb8, 0xae, 0xb1, 0x0b, 0x9d, 0x84, 0xb5, 0x4a, 0x05, 0x7c, 0x04, 0x8b, 0x2a,
-	0x3b, 0x2e, 0x43, 0x95, 0xf3, 0x61, 0x23, 0x40, 0xef, 0x8f, 0x13, 0xd8, 0x8a, 0x62, 0x76, 0xec,
-	0x3c, 0x18, 0x19, 0xbf, 0x2c, 0x41, 0xbb, 0xd0, 0x43, 0x3c, 0xc9, 0x72, 0xed, 0x25, 0x7a, 0xc5,
-	0xbd, 0x99, 0x59, 0x5e, 0x9f, 0x6f, 0x2f, 0x4f, 0xe5, 0xdb, 0x8d, 0x87, 0x69, 0x16, 0x5d, 0xe5,
-	0xce, 0x17, 0xd2, 0xdc, 0x39, 0xa5, 0x9b, 0xd7, 0x07, 0x03, 0xb3, 0x5b, 0x16, 0x75, 0x28, 0xef,
-	0x1d, 0x76, 0x2b, 0xc6, 0x1f, 0x97, 0xa1, 0xdd, 0xbf, 0x08, 0xe8, 0x13, 0xa9, 0x37, 0xc6, 0x0a,
-	0x39, 0xb9, 0x2a, 0x17, 0xe4, 0x2a, 0x27, 0x21, 0x15, 0x55, 0x3c, 0x64, 0x09, 0xc1, 0xe8, 0x81,
-	0xd3, 0x75, 0x4a, 0x72, 0x18, 0xfa, 0xbf, 0x20, 0x39, 0x05, 0x8d, 0x02, 0xd3, 0xe5, 0x9f, 0x5d,
-	0xe8, 0x24, 0x6c, 0x53, 0x82, 0xf1, 0x85, 0x1e, 0x2b, 0x7f, 0x14, 0xe9, 0xa6, 0x19, 0x1e, 0x06,
-	0x8c, 0x3f, 0x2a, 0x83, 0xce, 0x72, 0x86, 0x9b, 0x7f, 0x5f, 0xe9, 0xf5, 0x52, 0x56, 0x69, 0x48,
-	0x89, 0xab, 0xcf, 0xe5, 0x65, 0xa6, 0xdb, 0xe7, 0x56, 0xe7, 0x54, 0x1e, 0x88, 0x63, 0x7d, 0xca,
-	0x03, 0xdd, 0x04, 0x9d, 0xbd, 0x9e, 0x89, 0xca, 0x61, 0x57, 0x4d, 0x76, 0x83, 0x5e, 0x3a, 0x64,
-	0x59, 0x62, 0x19, 0x8e, 0xd5, 0x1d, 0x50, 0xbb, 0x18, 0x37, 0xb5, 0x13, 0x4f, 0xbe, 0xc0, 0x91,
-	0xc6, 0x34, 0x47, 0x4e, 0xa1, 0xa1, 0xf6, 0x86, 0x6e, 0xef, 0xcb, 0xbd, 0xe7, 0x7b, 0xfb, 0xdf,
-	0xdb, 0x2b, 0x48, 0x5f, 0xea, 0x18, 0x97, 0xf3, 0x8e, 0x71, 0x05, 0xf1, 0x9b, 0xfb, 0x2f, 0xf7,
-	0x06, 0xdd, 0xaa, 0x68, 0x83, 0x4e, 0xcd, 0xa1, 0xd9, 0x7f, 0xd5, 0xad, 0x51, 0x1a, 0x65, 0xf3,
-	0xe3, 0xfe, 0x8b, 0xf5, 0x6e, 0x3d, 0xad, 0xfb, 0x34, 0x8c, 0x3f, 0x2c, 0xc1, 0x12, 0x33, 0x24,
-	0x9f, 0x11, 0x11, 0x50, 0x9d, 0x38, 0x76, 0xe2, 0xac, 0x50, 0xfb, 0x7f, 0x39, 0x4b, 0x72, 0x13,
-	0xf4, 0x89, 0x93, 0x54, 0x5a, 0x39, 0x51, 0xa2, 0x4d, 0x1c, 0x55, 0x60, 0xfd, 0x8b, 0x32, 0x2c,
-	0xb3, 0x3f, 0xfe, 0x2c, 0xb4, 0x82, 0xd3, 0xef, 0xee, 0xce, 0x44, 0xe4, 0x57, 0x39, 0xa2, 0xf7,
-	0xa0, 0x43, 0x1f, 0x7d, 0xff, 0xd8, 0x1d, 0xaa, 0xa8, 0x91, 0x6f, 0xb7, 0xad, 0xb0, 0x3c, 0x91,
-	0x78, 0x0a, 0x2d, 0xfe, 0x38, 0x9c, 0x12, 0xc0, 0x85, 0x2a, 0x61, 0x21, 0x1a, 0x68, 0x72, 0x2f,
-	0xae, 0x69, 0x3e, 0x49, 0x07, 0x65, 0xc1, 0xfb, 0x6c, 0x21, 0x50, 0x0d, 0x19, 0x50, 0x39, 0xf0,
-	0x2e, 0xb4, 0x5d, 0x6b, 0x7c, 0x64, 0x5b, 0x43, 0xf6, 0x87, 0x94, 0xa0, 0xb4, 0x18, 0x79, 0x48,
-	0x38, 0xf1, 0x84, 0xf2, 0x19, 0x75, 0x12, 0xd8, 0x77, 0x70, 0xb6, 0xab, 0x8f, 0xae, 0xca, 0xb4,
-	0xc6, 0x5b, 0x54, 0x40, 0xcd, 0x6e, 0x98, 0x0b, 0x63, 0x9b, 0xe6, 0xce, 0xc1, 0xa0, 0x5b, 0x32,
-	0x1e, 0xc1, 0xcd, 0xb9, 0x53, 0xa8, 0xc7, 0x96, 0xcb, 0x75, 0xb2, 0x8c, 0x1b, 0xff, 0x50, 0x02,
-	0x6d, 0x63, 0xe2, 0x9e, 0x91, 0xe9, 0x7d, 0x1b, 0x40, 0xda, 0x27, 0x52, 0x7d, 0xb7, 0x5d, 0x22,
-	0x95, 0xa4, 0x23, 0x86, 0xbf, 0xdc, 0xfe, 0x08, 0x80, 0x39, 0x3b, 0xe4, 0x2f, 0xe0, 0xd3, 0x5a,
-	0x61, 0x32, 0x81, 0xe2, 0xe0, 0x0b, 0x2b, 0x50, 0xb5, 0xc2, 0x28, 0x81, 0xb3, 0x1a, 0x6a, 0xe5,
-	0x35, 0x35, 0xd4, 0xe5, 0x3d, 0xe8, 0x14, 0xa7, 0x98, 0x93, 0x26, 0x7b, 0xb7, 0xf8, 0x9d, 0xca,
-	0xec, 0xcd, 0xe5, 0x1c, 0xf3, 0x4f, 0x60, 0x71, 0x2a, 0x83, 0xfd, 0x3a, 0x3d, 0x5d, 0x78, 0xa8,
-	0xe5, 0xe9, 0x87, 0xfa, 0x01, 0x2c, 0x0d, 0xac, 0xe8, 0x4c, 0x05, 0x2b, 0x99, 0xcb, 0x10, 0x5b,
-	0xd1, 0xd9, 0x30, 0x65, 0x6a, 0x1d, 0xc1, 0x1d, 0xdb, 0x78, 0x02, 0x22, 0xdf, 0x5b, 0xf1, 0x1f,
-	0x23, 0x54, 0xec, 0x3e, 0x96, 0xb1, 0x95, 0xf8, 0x36, 0x88, 0x40, 0xe6, 0xad, 0xfd, 0x55, 0x09,
-	0xaa, 0xe8, 0xdd, 0x8b, 0x87, 0xa0, 0x7f, 0x2c, 0xad, 0x30, 0x3e, 0x92, 0x56, 0x2c, 0x0a, 0x9e,
-	0xfc, 0x32, 0xf1, 0x2d, 0xfb, 0xf6, 0xc5, 0x58, 0x78, 0x5c, 0x12, 0xab, 0xfc, 0xcd, 0x6d, 0xf2,
-	0x2d, 0x71, 0x3b, 0x89, 0x12, 0x28, 0x8a, 0x58, 0x2e, 0x8c, 0x37, 0x16, 0x56, 0xa8, 0xff, 0x27,
-	0xbe, 0xe3, 0x6d, 0xf2, 0x97, 0x9e, 0x62, 0x3a, 0xaa, 0x98, 0x1e, 0x21, 0x1e, 0x42, 0x7d, 0x27,
-	0xc2, 0xf0, 0x65, 0xb6, 0x2b, 0x31, 0x3f, 0x1f, 0xd9, 0x18, 0x0b, 0x6b, 0x3f, 0xab, 0x41, 0xf5,
-	0x87, 0x32, 0xf4, 0xc5, 0x07, 0xd0, 0x50, 0x5f, 0x0a, 0x89, 0xdc, 0x17, 0x41, 0xcb, 0x94, 0x66,
-	0x99, 0xfa, 0x84, 0x88, 0x56, 0xe9, 0xf2, 0xfd, 0x65, 0x75, 0x1b, 0x91, 0x7d, 0xc8, 0x34, 0xb3,
-	0xa9, 0x0f, 0xa1, 0x7b, 0x18, 0x87, 0xd2, 0x1a, 0xe7, 0xba, 0x17, 0x59, 0x35, 0xaf, 0x08, 0x44,
-	0xfc, 0x7a, 0x00, 0x75, 0x8e, 0x11, 0xa7, 0x06, 0x4c, 0x57, 0x78, 0xa8, 0xf3, 0x7b, 0xd0, 0x3c,
-	0x3c, 0xf5, 0x27, 0xae, 0x7d, 0x28, 0xc3, 0x73, 0x29, 0x72, 0xdf, 0x1c, 0x2e, 0xe7, 0xda, 0xc6,
-	0x82, 0x78, 0x0f, 0x74, 0x8e, 0x00, 0xd0, 0xff, 0x6f, 0xa8, 0xa0, 0x82, 0xe7, 0xcc, 0x45, 0x06,
-	0xc6, 0x82, 0x58, 0x01, 0xc8, 0x45, 0x8a, 0xaf, 0xeb, 0xf9, 0x14, 0xda, 0x9b, 0xa4, 0x4c, 0xf7,
-	0xc3, 0xf5, 0x23, 0x3f, 0x8c, 0xc5, 0xf4, 0x47, 0x86, 0xcb, 0xd3, 0x08, 0x63, 0x41, 0x3c, 0x06,
-	0x6d, 0x10, 0x5e, 0x72, 0xff, 0x25, 0x15, 0x60, 0x67, 0xeb, 0xcd, 0x39, 0xa4, 0xf8, 0x46, 0xfa,
-	0x48, 0x52, 0xc7, 0x7f, 0x5e, 0xed, 0x87, 0xcf, 0xcb, 0x02, 0x6d, 0x2c, 0x88, 0x27, 0x00, 0x59,
-	0x54, 0x22, 0xbe, 0xc2, 0x75, 0xa8, 0xa9, 0x28, 0x65, 0x76, 0x48, 0x16, 0x81, 0xf0, 0x90, 0x99,
-	0x88, 0x64, 0x6a, 0xc8, 0x37, 0xa1, 0x95, 0x8f, 0x26, 0x04, 0x95, 0x4f, 0xe6, 0xc4, 0x17, 0xc5,
-	0x61, 0x6b, 0xff, 0x56, 0x83, 0xfa, 0xf7, 0xfc, 0xf0, 0x4c, 0x86, 0xe2, 0x3e, 0xd4, 0xa9, 0xa2,
-	0xa8, 0x1e, 0x46, 0x5a, 0x5d, 0x9c, 0xc7, 0xbb, 0xaf, 0x83, 0x4e, 0xd7, 0x8c, 0x2f, 0x97, 0x85,
-	0x8f, 0xfe, 0x14, 0xc3, 0x93, 0x73, 0x52, 0x92, 0x24, 0xb5, 0xc3, 0xa2, 0x97, 0xd6, 0xee, 0x0b,
-	0x15, 0xbf, 0x65, 0xba, 0xd2, 0xe7, 0xaf, 0x0e, 0xf1, 0xb1, 0x3d, 0x2e, 0xa1, 0x5b, 0x72, 0xc8,
-	0x97, 0x87, 0x9d, 0xb2, 0x8f, 0xfe, 0xf9, 0x2d, 0x67, 0x5f, 0xd9, 0x1b, 0x0b, 0xe2, 0x11, 0xd4,
-	0x95, 0x95, 0x5a, 0xca, 0xb4, 0x5a, 0x72, 0xc2, 0x6e, 0x1e, 0xa5, 0x06, 0x3c, 0x81, 0x3a, 0x5b,
-	0x74, 0x1e, 0x50, 0x08, 0x67, 0x96, 0x45, 0x1e, 0x95, 0x3c, 0x4f, 0xf1, 0x00, 0x1a, 0xaa, 0x5e,
-	0x28, 0xe6, 0x14, 0x0f, 0x67, 0x6e, 0xac, 0xce, 0xee, 0x1a, 0xcf, 0x5f, 0xf0, 0x78, 0x79, 0xfe,
-	0xa2, 0x37, 0xc7, 0xef, 0xd8, 0x94, 0x23, 0xe9, 0xe4, 0x72, 0x61, 0x22, 0xe1, 0xc8, 0x1c, 0x65,
-	0xf4, 0x21, 0xb4, 0x0b, 0x79, 0x33, 0xd1, 0x4b, 0xc4, 0x62, 0x3a, 0x95, 0x36, 0xa3, 0x02, 0xbe,
-	0x0d, 0xba, 0xca, 0x36, 0x1c, 0x29, 0xc1, 0x98, 0x93, 0xdb, 0x58, 0x9e, 0x4d, 0x37, 0xd0, 0xbb,
-	0xfe, 0x3e, 0x5c, 0x9b, 0x63, 0x28, 0xc5, 0xad, 0xd7, 0x1b, 0xe1, 0xe5, 0xdb, 0x57, 0xd2, 0x53,
-	0x06, 0xfc, 0x66, 0xcf, 0xe9, 0x3b, 0x00, 0x99, 0xbd, 0xe0, 0xb7, 0x31, 0x63, 0x6d, 0x96, 0x6f,
-	0x4c, 0xa3, 0x93, 0x45, 0x37, 0x7a, 0x7f, 0xfd, 0xd9, 0xad, 0xd2, 0xaf, 0x3e, 0xbb, 0x55, 0xfa,
-	0xe7, 0xcf, 0x6e, 0x95, 0x7e, 0xf9, 0xeb, 0x5b, 0x0b, 0xbf, 0xfa, 0xf5, 0xad, 0x85, 0xbf, 0xfb,
-	0xf5, 0xad, 0x85, 0xa3, 0x3a, 0xfd, 0x83, 0xed, 0xe9, 0x7f, 0x07, 0x00, 0x00, 0xff, 0xff, 0x9e,
-	0xab, 0x7a, 0xb4, 0x37, 0x37, 0x00, 0x00,
+	// 5422 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd5, 0x3b, 0x49, 0x6c, 0x24, 0xd7,
+	0x75, 0xd3, 0x7b, 0xf7, 0xeb, 0x85, 0xcd, 0x9a, 0xd1, 0x88, 0x6a, 0xd9, 0x33, 0xe3, 0x92, 0x25,
+	0x8d, 0x96, 0xe1, 0x68, 0x38, 0x32, 0x62, 0xc9, 0x70, 0x10, 0x2e, 0xcd, 0x11, 0x25, 0x0e, 0x49,
+	0x57, 0xf7, 0x8c, 0x64, 0x03, 0x49, 0xa3, 0xd8, 0x5d, 0x24, 0xcb, 0xd3, 0x5d, 0xd5, 0xae, 0xaa,
+	0xa6, 0x49, 0xdf, 0x8c, 0x00, 0x36, 0x72, 0xf3, 0x31, 0xa7, 0x1c, 0x72, 0xcd, 0x39, 0x0b, 0x82,
+	0xe4, 0x96, 0x43, 0x90, 0x4b, 0x7c, 0x4c, 0x90, 0x05, 0x81, 0x13, 0xe4, 0x90, 0x43, 0x80, 0x20,
+	0xc7, 0xe4, 0x90, 0xb7, 0xfc, 0x5f, 0x4b, 0x77, 0x73, 0x66, 0xa4, 0x20, 0x87, 0x1c, 0x1a, 0xfc,
+	0xff, 0xbd, 0xbf, 0xbe, 0xff, 0xf6, 0x57, 0x84, 0xea, 0xf4, 0x78, 0x7d, 0x1a, 0xf8, 0x91, 0x6f,
+	0xe4, 0xa7, 0xc7, 0x9d, 0x9a, 0x3d, 0x75, 0xa5, 0xdb, 0x79, 0xf7, 0xd4, 0x8d, 0xce, 0x66, 0xc7,
+	0xeb, 0x43, 0x7f, 0x72, 0x7f, 0x74, 0x1a, 0xd8, 0xd3, 0xb3, 0x7b, 0xae, 0x7f, 0xff, 0xd8, 0x1e,
+	0x9d, 0x3a, 0xc1, 0xfd, 0xf3, 0x87, 0xf7, 0xa7, 0xc7, 0xf7, 0xf5, 0xd4, 0xce, 0xbd, 0xd4, 0xd8,
+	0x53, 0xff, 0xd4, 0xbf, 0xcf, 0xe0, 0xe3, 0xd9, 0x09, 0xf7, 0xb8, 0xc3, 0x2d, 0x19, 0x6e, 0xfe,
+	0x3a, 0x14, 0xf7, 0xdd, 0x30, 0x32, 0x6e, 0x42, 0xf9, 0xd8, 0x8d, 0x26, 0xf6, 0x74, 0x2d, 0x7f,
+	0x27, 0x77, 0xb7, 0x61, 0xa9, 0x9e, 0x71, 0x0b, 0x20, 0xf4, 0x83, 0xc8, 0x19, 0x3d, 0x71, 0x47,
+	0xe1, 0x5a, 0xe1, 0x4e, 0xe1, 0x6e, 0xd9, 0x4a, 0x41, 0xcc, 0xc7, 0x50, 0xeb, 0xdb, 0xe1, 0xb3,
+	0xa7, 0xf6, 0x78, 0xe6, 0x18, 0x6d, 0x28, 0x9c, 0xdb, 0xe3, 0xb5, 0x1c, 0xaf, 0x40, 0x4d, 0x63,
+	0x1d, 0xaa, 0xf8, 0x67, 0x10, 0x5d, 0x4e, 0x1d, 0x5e, 0xb8, 0xb5, 0x71, 0x7d, 0x1d, 0x8f, 0x7a,
+	0xe4, 0x87, 0x91, 0xeb, 0x9d, 0xae, 0xe3, 0xb4, 0x3e, 0xa2, 0xac, 0xca, 0xb9, 0x34, 0xcc, 0x43,
+	0xa8, 0xf7, 0x82, 0xe1, 0xee, 0xcc, 0x1b, 0x46, 0xae, 0xef, 0x19, 0x06, 0x14, 0x3d, 0x7b, 0xe2,
+	0xf0, 0x8a, 0x35, 0x8b, 0xdb, 0x04, 0xb3, 0x83, 0x53, 0x39, 0x0b, 0xc2, 0xa8, 0x6d, 0xac, 0x41,
+	0xc5, 0x0d, 0xb7, 0xfd, 0x99, 0x17, 0xad, 0x15, 0x71, 0x68, 0xd5, 0xd2, 0x5d, 0xf3, 0x8f, 0x0b,
+	0x50, 0xfa, 0xde, 0xcc, 0x09, 0x2e, 0x79, 0x5e, 0x14, 0x05, 0x7a, 0x2d, 0x6a, 0x1b, 0x37, 0xa0,
+	0x34, 0xb6, 0x3d, 0x5c, 0x2c, 0xcf, 0x8b, 0x49, 0xc7, 0x78, 0x1d, 0x6a, 0xf6, 0x49, 0xe4, 0x04,
+	0x83, 0x99, 0x3b, 0xc2, 0x6d, 0x72, 0x78, 0xe5, 0x2a, 0x03, 0xf0, 0xc6, 0xc6, 0x6b, 0x50, 0x1d,
+	0xf9, 0x83, 0x61, 0x7a, 0xaf, 0x91, 0xcf, 0x7b, 0x19, 0x6f, 0x40, 0x15, 0x67, 0x0c, 0xc6, 0x48,
+	0xcf, 0xb5, 0x12, 0xa2, 0xea, 0x1b, 0x55, 0xba, 0x2c, 0xd1, 0xd7, 0xaa, 0x20, 0x86, 0x09, 0xfd,
+	0x2e, 0x54, 0xc3, 0x60, 0x38, 0x38, 0xc1, 0x2b, 0xae, 0x95, 0x79, 0xd0, 0x0a, 0x0d, 0x4a, 0xdd,
+	0xda, 0xaa, 0x84, 0xd2, 0xa1, 0x6b, 0x05, 0xce, 0xb9, 0x13, 0x84, 0xce, 0x5a, 0x45, 0xb6, 0x52,
+	0x5d, 0xe3, 0x03, 0xa8, 0x9f, 0xd8, 0x43, 0x27, 0x1a, 0x4c, 0xed, 0xc0, 0x9e, 0xac, 0x55, 0x93,
+	0x85, 0x76, 0x09, 0x7c, 0x44, 0xd0, 0xd0, 0x82, 0x93, 0xb8, 0x63, 0x3c, 0x84, 0x26, 0xf7, 0xc2,
+	0xc1, 0x89, 0x3b, 0xc6, 0xbb, 0xac, 0xd5, 0x78, 0x4e, 0x8b, 0xe7, 0x30, 0xa4, 0x1f, 0x38, 0x8e,
+	0xd5, 0x90, 0x41, 0x02, 0x31, 0xbe, 0x0e, 0xe0, 0x5c, 0x4c, 0x6d, 0x6f, 0x34, 0xb0, 0xc7, 0xe3,
+	0x35, 0xe0, 0x33, 0xd4, 0x04, 0xb2, 0x39, 0x1e, 0x1b, 0xaf, 0xd2, 0xf9, 0xec, 0xd1, 0x20, 0x0a,
+	0xd7, 0x9a, 0x88, 0x2b, 0x5a, 0x65, 0xea, 0xf6, 0x43, 0xa2, 0xeb, 0xd0, 0x1e, 0x9e, 0x39, 0x6b,
+	0x2d, 0x04, 0x97, 0x2c, 0xe9, 0x10, 0xf4, 0xc4, 0x0d, 0x90, 0x38, 0x2b, 0x02, 0xe5, 0x0e, 0x71,
+	0x9e, 0x7f, 0x72, 0x12, 0x3a, 0xd1, 0x5a, 0x9b, 0xc1, 0xaa, 0x67, 0x6e, 0x40, 0x8d, 0xb9, 0x8a,
+	0xa9, 0xf6, 0x26, 0x94, 0xcf, 0xa9, 0x13, 0xe2, 0xf3, 0x15, 0xf0, 0xd8, 0x4d, 0x3a, 0x76, 0xcc,
+	0x78, 0x96, 0x42, 0x9a, 0xb7, 0xa0, 0xba, 0x8f, 0x4f, 0xc8, 0x53, 0xf0, 0xbd, 0xe9, 0x39, 0x79,
+	0x02, 0xbe, 0x37, 0xb5, 0xcd, 0xdf, 0xcd, 0x43, 0xd9, 0x72, 0xc2, 0xd9, 0x38, 0x32, 0xde, 0x06,
+	0xa0, 0xc7, 0x9a, 0xd8, 0x51, 0xe0, 0x5e, 0xa8, 0x55, 0x93, 0xe7, 0xaa, 0x21, 0xee, 0x31, 0xa3,
+	0x90, 0xd4, 0x0d, 0x5e, 0x5d, 0x0f, 0xcd, 0x27, 0x07, 0x88, 0xcf, 0x67, 0xd5, 0x79, 0x88, 0x9a,
+	0x81, 0x37, 0x62, 0xfe, 0x10, 0x1e, 0x6d, 0x5a, 0xaa, 0x87, 0x97, 0x68, 0xb9, 0x5e, 0x44, 0xef,
+	0x37, 0x8c, 0x06, 0x23, 0x27, 0xd4, 0x0c, 0xd4, 0x8c, 0xa1, 0x3b, 0x08, 0x34, 0x1e, 0x80, 0x3c,
+	0x82, 0xde, 0xb0, 0xc4, 0x1b, 0xb6, 0xe2, 0xc7, 0x0d, 0x65, 0x47, 0x1e, 0xa3, 0x76, 0xbc, 0x07,
+	0x75, 0xba, 0x9f, 0x9e, 0x51, 0xe6, 0x19, 0x0d, 0xbe, 0x8d, 0x22, 0x87, 0x05, 0x34, 0x40, 0x0d,
+	0x27, 0xd2, 0x10, 0x93, 0x0a, 0x53, 0x71, 0xdb, 0xec, 0x42, 0xe9, 0x30, 0x18, 0xe1, 0x9b, 0x2f,
+	0x93, 0x13, 0x84, 0xe1, 0x79, 0x87, 0x2c, 0xc2, 0x38, 0x81, 0xda, 0x89, 0xec, 0x14, 0x52, 0xb2,
+	0x63, 0xfe, 0x5e, 0x0e, 0x25, 0x18, 0xd5, 0xc3, 0x63, 0x27, 0x0c, 0xed, 0x53, 0xc7, 0xb8, 0x0d,
+	0x25, 0x9f, 0x96, 0x55, 0x14, 0xae, 0xd1, 0x99, 0x78, 0x1f, 0x4b, 0xe0, 0x73, 0xef, 0x90, 0xbf,
+	0xfa, 0x1d, 0x88, 0xa7, 0x58, 0xea, 0x0a, 0x8a, 0xa7, 0x58, 0xe6, 0x12, 0xee, 0x29, 0xa6, 0xb9,
+	0xe7, 0x4a, 0xd6, 0x34, 0xbf, 0x05, 0x40, 0xe7, 0xfb, 0x92, 0x5c, 0x60, 0xfe, 0x1c, 0xef, 0x65,
+	0xa1, 0x12, 0xd8, 0xf6, 0xf1, 0xad, 0x2e, 0x22, 0xa3, 0x05, 0x79, 0x54, 0x0e, 0x39, 0x56, 0x0e,
+	0xd8, 0xa2, 0xd3, 0x9d, 0x06, 0xfe, 0x4c, 0xd4, 0x67, 0xd3, 0x92, 0x0e, 0xd3, 0x72, 0x34, 0x0a,
+	0xf8, 0xc8, 0x44, 0x4b, 0x6c, 0x23, 0x45, 0xea, 0xa1, 0x67, 0x4f, 0xc3, 0x33, 0x3f, 0xa2, 0xd3,
+	0x15, 0xf9, 0x74, 0xa0, 0x41, 0x28, 0x3c, 0x28, 0x74, 0x6e, 0x38, 0x18, 0x3b, 0x76, 0xe0, 0x21,
+	0xdd, 0x4a, 0x22, 0x74, 0x6e, 0xb8, 0x2f, 0x00, 0xf3, 0xe7, 0x05, 0x28, 0x3f, 0x76, 0x26, 0xc7,
+	0x48, 0xbb, 0xf9, 0x43, 0x7c, 0x00, 0x55, 0xde, 0x77, 0x80, 0x50, 0x3e, 0xc7, 0xd6, 0x2b, 0xff,
+	0xf6, 0x8f, 0xb7, 0x57, 0x19, 0xb6, 0x37, 0x7a, 0xdf, 0x9f, 0xb8, 0x91, 0x33, 0x99, 0x46, 0x97,
+	0x56, 0x45, 0x81, 0x96, 0x1e, 0x10, 0x49, 0x8a, 0x9b, 0xd3, 0x9b, 0x09, 0x7b, 0xaa, 0x1e, 0x32,
+	0x59, 0xc5, 0x9e, 0x20, 0xdf, 0xda, 0x23, 0x39, 0xd4, 0xd6, 0x0d, 0x5c, 0xbc, 0x6d, 0x4f, 0x76,
+	0x10, 0x92, 0x5a, 0xbb, 0x2c, 0x10, 0xe3, 0x23, 0xe2, 0xc9, 0x30, 0x1a, 0xcc, 0xa6, 0x23, 0x3b,
+	0x72, 0x58, 0xd7, 0x15, 0xb7, 0xd6, 0x70, 0xca, 0x0d, 0x02, 0x3f, 0x61, 0x68, 0x6a, 0x1a, 0x24,
+	0x50, 0xd2, 0x7b, 0xfa, 0xfa, 0x4a, 0xef, 0xa9, 0xae, 0xb1, 0x07, 0xab, 0xc3, 0xf1, 0x2c, 0x24,
+	0xe5, 0xec, 0x7a, 0x27, 0xfe, 0xc0, 0xf7, 0xc6, 0x97, 0xfc, 0xc0, 0xd5, 0xad, 0xaf, 0xe3, 0xd2,
+	0xaf, 0x29, 0xe4, 0x1e, 0xe2, 0x0e, 0x11, 0x95, 0x5a, 0x7f, 0x65, 0x0e, 0x65, 0xfc, 0x06, 0xb4,
+	0x4e, 0xfc, 0x60, 0xe8, 0x0c, 0x62, 0x92, 0xb5, 0x78, 0x9d, 0x0e, 0xae, 0x73, 0x93, 0x31, 0x8f,
+	0x16, 0xe8, 0xd6, 0x48, 0xc3, 0xcd, 0x7f, 0xc8, 0x43, 0x89, 0xdb, 0x48, 0xf8, 0xca, 0x84, 0x9f,
+	0x44, 0xeb, 0xa7, 0x9b, 0xc4, 0x43, 0x8c, 0x5b, 0x97, 0xb7, 0x0a, 0xbb, 0x5e, 0x14, 0x20, 0xe1,
+	0xd5, 0x30, 0x9a, 0x11, 0xd9, 0xc7, 0x63, 0x94, 0x66, 0xc5, 0xf3, 0xa9, 0x19, 0x7d, 0x41, 0xa8,
+	0x19, 0x6a, 0xd8, 0x3c, 0xdf, 0x14, 0x16, 0xf8, 0xa6, 0x03, 0x55, 0xd4, 0xb2, 0xc3, 0x67, 0xe1,
+	0x6c, 0xa2, 0xb8, 0x2a, 0xee, 0xa3, 0x69, 0x6a, 0x72, 0x7b, 0xea, 0xa3, 0xae, 0xa1, 0xe9, 0x25,
+	0x1e, 0xd0, 0x48, 0x80, 0xfd, 0xb0, 0xb3, 0x0b, 0x8d, 0xf4, 0x61, 0xc9, 0x9c, 0x3f, 0x73, 0x2e,
+	0x99, 0xbf, 0x8a, 0x16, 0x35, 0x8d, 0x3b, 0x50, 0x62, 0x45, 0xc7, 0xdc, 0x55, 0xdf, 0x00, 0x3a,
+	0xb3, 0x4c, 0xb1, 0x04, 0xf1, 0x71, 0xfe, 0xdb, 0x39, 0x5a, 0x27, 0x7d, 0x85, 0xf4, 0x3a, 0xb5,
+	0xab, 0xd7, 0x91, 0x29, 0xa9, 0x75, 0x4c, 0x1f, 0x2a, 0xfb, 0xee, 0xd0, 0xf1, 0x42, 0x36, 0xfa,
+	0xb3, 0xd0, 0x89, 0x95, 0x12, 0xb5, 0xe9, 0xbe, 0x13, 0xfb, 0xe2, 0xc0, 0x47, 0x6d, 0xc4, 0xeb,
+	0xe0, 0x7d, 0x75, 0x9f, 0x70, 0x68, 0xa6, 0xdc, 0xe0, 0xb2, 0x2f, 0x94, 0x2a, 0x58, 0x71, 0x9f,
+	0xb8, 0xcb, 0xf1, 0x68, 0xb3, 0x91, 0x36, 0xe0, 0xaa, 0x6b, 0xfe, 0xac, 0x08, 0x8d, 0x1f, 0x38,
+	0x81, 0x7f, 0x14, 0xf8, 0x53, 0x3f, 0x44, 0xf7, 0x65, 0x33, 0x4b, 0x73, 0x79, 0xdb, 0x3b, 0x74,
+	0xda, 0xf4, 0xb0, 0xf5, 0x5e, 0xfc, 0x08, 0xf2, 0x66, 0xe9, 0x57, 0x31, 0xa1, 0x2c, 0x6f, 0xbe,
+	0x84, 0x66, 0x0a, 0x43, 0x63, 0xe4, 0x95, 0xf9, 0xac, 0x59, 0x7a, 0x28, 0x0c, 0x49, 0x25, 0xde,
+	0xee, 0xc9, 0xde, 0x8e, 0x7a, 0x5b, 0xd5, 0x53, 0x54, 0xe8, 0x5f, 0x78, 0x7d, 0xfd, 0xa8, 0x71,
+	0x9f, 0x6e, 0x4a, 0x14, 0x09, 0x71, 0x52, 0x83, 0x51, 0xba, 0x6b, 0x7c, 0x0d, 0x6a, 0xd8, 0x24,
+	0x85, 0xb6, 0x37, 0x12, 0xd1, 0xb4, 0x12, 0x80, 0xf1, 0x0d, 0x28, 0x44, 0x17, 0x1e, 0xcb, 0x1e,
+	0x79, 0x15, 0xe4, 0x88, 0xe2, 0x82, 0x4a, 0xf5, 0x59, 0x84, 0xa3, 0x37, 0x1d, 0xa2, 0xc8, 0xd4,
+	0xe4, 0x4d, 0xb1, 0x89, 0xd6, 0xad, 0x32, 0x96, 0xd7, 0x62, 0x47, 0xa1, 0xbe, 0x51, 0x17, 0x3d,
+	0xca, 0x20, 0x4b, 0xe3, 0x8c, 0xf7, 0xd1, 0xff, 0x51, 0xd4, 0x59, 0xab, 0xf3, 0xb8, 0xb6, 0xa6,
+	0xa7, 0x26, 0xa3, 0x15, 0x8f, 0x40, 0x31, 0xa9, 0x8d, 0x1c, 0xbc, 0xbe, 0x33, 0xf0, 0x44, 0x91,
+	0xd7, 0xc5, 0x81, 0xdc, 0x61, 0xe0, 0x41, 0x68, 0x39, 0x3f, 0x42, 0xbb, 0x8f, 0x33, 0x46, 0x0a,
+	0xd0, 0xf9, 0x2e, 0xac, 0xcc, 0x3d, 0x47, 0x9a, 0xff, 0x9a, 0xc2, 0x7f, 0x37, 0xd2, 0xfc, 0x57,
+	0x4c, 0xf1, 0xdc, 0xa7, 0xc5, 0x6a, 0xb5, 0x5d, 0x33, 0xff, 0xa3, 0x00, 0x2b, 0x4a, 0x14, 0xce,
+	0xdc, 0x69, 0x2f, 0x52, 0x4a, 0x89, 0x4d, 0x8e, 0xe2, 0x42, 0x24, 0xa6, 0xea, 0x1a, 0xbf, 0x06,
+	0x65, 0xd6, 0x21, 0x5a, 0x94, 0x6f, 0x27, 0x4f, 0x1c, 0x4f, 0x17, 0xd1, 0x56, 0xfc, 0xa1, 0x86,
+	0x1b, 0x1f, 0x42, 0xe9, 0x27, 0x78, 0x6f, 0x31, 0xa1, 0xf5, 0x8d, 0x5b, 0xcb, 0xe6, 0x11, 0x61,
+	0xd4, 0x34, 0x19, 0xfc, 0xbf, 0xe5, 0x04, 0xf8, 0x32, 0x9c, 0xf0, 0x4d, 0x32, 0xa3, 0x13, 0xff,
+	0x1c, 0x65, 0xa5, 0xc2, 0x67, 0x4c, 0xb3, 0xaf, 0x46, 0x69, 0x66, 0xa8, 0x2e, 0x65, 0x86, 0xda,
+	0xd5, 0xcc, 0xd0, 0xd9, 0x81, 0x7a, 0x8a, 0x2e, 0x4b, 0x1e, 0xea, 0x76, 0x56, 0x51, 0xd4, 0x62,
+	0x25, 0x99, 0xd6, 0x37, 0x3b, 0x00, 0x09, 0x95, 0xbe, 0xaa, 0xd6, 0x32, 0x7f, 0x9a, 0x83, 0x15,
+	0x64, 0x71, 0xcf, 0x61, 0x27, 0x5c, 0xde, 0x3c, 0x11, 0xde, 0xdc, 0x95, 0xc2, 0xfb, 0x0e, 0x94,
+	0x42, 0x1a, 0xac, 0x56, 0xbf, 0xbe, 0xe4, 0x11, 0x2d, 0x19, 0x41, 0x2a, 0x1c, 0x49, 0x3b, 0x98,
+	0x3a, 0xde, 0x08, 0xa3, 0x1f, 0xad, 0xc2, 0x11, 0x74, 0x24, 0x10, 0xf3, 0x4f, 0xf2, 0x00, 0x9f,
+	0x38, 0xf6, 0x38, 0x3a, 0x23, 0x33, 0x45, 0x2f, 0xea, 0x7a, 0x38, 0xd5, 0x1b, 0xea, 0x10, 0x28,
+	0xee, 0xd3, 0x8b, 0x92, 0xb5, 0x46, 0x37, 0x8b, 0x37, 0xae, 0x59, 0xba, 0x4b, 0xfc, 0x41, 0xdb,
+	0xcd, 0x42, 0x65, 0xd5, 0x55, 0x2f, 0x71, 0x51, 0x8a, 0x0c, 0x56, 0x2e, 0x0a, 0xae, 0x43, 0x21,
+	0x05, 0x5e, 0x99, 0x99, 0x06, 0xd7, 0x51, 0x5d, 0x5a, 0x67, 0x36, 0x8d, 0xdc, 0x89, 0xd8, 0xee,
+	0x82, 0xa5, 0x7a, 0x74, 0x2a, 0xb2, 0xd5, 0xdd, 0xe1, 0x99, 0xcf, 0x2a, 0x02, 0x75, 0xab, 0xee,
+	0xd3, 0x6a, 0xbe, 0x77, 0xea, 0xd3, 0xed, 0xaa, 0xec, 0x16, 0xea, 0xae, 0xdc, 0x65, 0xe4, 0x5c,
+	0x10, 0xaa, 0xc6, 0xa8, 0xb8, 0x4f, 0x74, 0x71, 0x9c, 0xc1, 0x89, 0x83, 0xc7, 0xc4, 0x1b, 0x20,
+	0x87, 0x12, 0x1a, 0x1c, 0x67, 0x57, 0x41, 0x50, 0x21, 0x35, 0x88, 0x70, 0x76, 0x18, 0xba, 0xa7,
+	0x1e, 0xf2, 0x62, 0x9d, 0x29, 0x47, 0xc4, 0xdc, 0x54, 0x20, 0xf3, 0xcf, 0xd1, 0xb5, 0x17, 0x95,
+	0x99, 0x71, 0x83, 0x72, 0x2f, 0xe5, 0x06, 0xa1, 0x10, 0x4c, 0x03, 0x67, 0xe4, 0x0e, 0xf5, 0x3b,
+	0xd6, 0xac, 0x04, 0xc0, 0x71, 0x0b, 0xd9, 0x7d, 0xa6, 0x67, 0xd5, 0x92, 0x0e, 0xf2, 0x46, 0xd3,
+	0xf7, 0x06, 0x23, 0x37, 0x7c, 0x36, 0x38, 0xbe, 0x8c, 0xf0, 0xd8, 0x42, 0x8b, 0xba, 0xef, 0xed,
+	0x20, 0x6c, 0x8b, 0x40, 0x44, 0x42, 0x91, 0x11, 0x96, 0x8d, 0xaa, 0xa5, 0x7a, 0x18, 0x8c, 0xd5,
+	0xd8, 0x3b, 0x65, 0xf7, 0xa5, 0xc6, 0x6e, 0xc7, 0x4d, 0x3c, 0xa2, 0x41, 0xc0, 0x39, 0xbf, 0xa5,
+	0xaa, 0x61, 0xe4, 0x7f, 0xd1, 0x64, 0x32, 0x44, 0x2c, 0xc3, 0xe2, 0x7f, 0x11, 0xa8, 0x1f, 0xa6,
+	0xfd, 0x2f, 0x81, 0xe0, 0x70, 0x03, 0x63, 0x48, 0x7f, 0x32, 0x25, 0xa6, 0x70, 0x46, 0xea, 0x90,
+	0x75, 0x3e, 0xe4, 0x6a, 0x1a, 0xc3, 0x47, 0x35, 0xff, 0x3e, 0x0f, 0x8d, 0x1d, 0x37, 0x40, 0xee,
+	0x77, 0x46, 0xdd, 0x11, 0x7a, 0xee, 0x78, 0x76, 0xc7, 0x8b, 0xdc, 0xe8, 0x52, 0x39, 0x98, 0xaa,
+	0x17, 0xc7, 0x07, 0xf9, 0x6c, 0x1c, 0x2d, 0x12, 0x56, 0xe0, 0xd0, 0x5f, 0x3a, 0xc6, 0x06, 0x80,
+	0x44, 0x4e, 0x1c, 0xfe, 0x17, 0xaf, 0x0e, 0xff, 0x6b, 0x3c, 0x8c, 0x9a, 0x14, 0x5e, 0xcb, 0x1c,
+	0x57, 0xbc, 0xcc, 0x32, 0xe7, 0x06, 0x66, 0x8e, 0xf8, 0xaa, 0x1c, 0xd0, 0x55, 0x64, 0x63, 0x6a,
+	0xa3, 0x5f, 0x93, 0xf7, 0xa7, 0x4c, 0x5c, 0xb5, 0x74, 0xfa, 0x0a, 0xeb, 0x87, 0x53, 0x0b, 0xd1,
+	0x24, 0xc5, 0x12, 0xd5, 0x32, 0xe3, 0x91, 0x14, 0x93, 0x45, 0xe3, 0x58, 0xca, 0x52, 0x18, 0x1c,
+	0xd3, 0xc0, 0x10, 0xd7, 0xff, 0xb1, 0x33, 0x3a, 0xc2, 0x77, 0xd7, 0x3c, 0x98, 0x81, 0x11, 0x97,
+	0x50, 0x06, 0x22, 0x9c, 0xe2, 0x14, 0xc5, 0x82, 0x09, 0xc0, 0xbc, 0x09, 0xf9, 0xc3, 0xa9, 0x51,
+	0x81, 0x42, 0xaf, 0xdb, 0x6f, 0x5f, 0xa3, 0xc6, 0x4e, 0x77, 0xbf, 0x4d, 0x16, 0xa5, 0xdc, 0xae,
+	0x98, 0xbf, 0xca, 0x43, 0xed, 0xf1, 0x0c, 0x05, 0x11, 0x25, 0x2b, 0xa4, 0x5b, 0x66, 0x39, 0x34,
+	0x61, 0x45, 0x44, 0xa1, 0xbc, 0x06, 0xec, 0x6f, 0x88, 0x75, 0xaa, 0x70, 0x1f, 0x5f, 0xf4, 0x2d,
+	0x28, 0x39, 0x78, 0x2d, 0x6d, 0x2e, 0xda, 0xf3, 0xf7, 0xb5, 0x04, 0x6d, 0xdc, 0x45, 0x05, 0x80,
+	0x8e, 0xdd, 0xc4, 0x46, 0x9a, 0xc7, 0x03, 0x7b, 0x0c, 0x11, 0x07, 0xdb, 0x52, 0x78, 0x54, 0xef,
+	0x25, 0x7a, 0x9b, 0x50, 0x45, 0x8c, 0x1c, 0x63, 0xd2, 0x33, 0xa8, 0x61, 0x82, 0x24, 0xc6, 0x1b,
+	0xa1, 0xab, 0x33, 0x40, 0x4a, 0x57, 0x98, 0xd2, 0x37, 0x58, 0xc7, 0xe9, 0xdb, 0xac, 0xef, 0x20,
+	0x12, 0x49, 0x5d, 0x1e, 0xf1, 0x5f, 0x8a, 0x5f, 0x78, 0xb8, 0x70, 0x84, 0x18, 0x85, 0x1a, 0x41,
+	0x24, 0x49, 0x74, 0x17, 0xcd, 0x94, 0x13, 0xd9, 0xb8, 0x81, 0xad, 0x6c, 0x43, 0x43, 0x54, 0xa6,
+	0xc0, 0xac, 0x18, 0x6b, 0xde, 0x87, 0xb2, 0x2c, 0x6d, 0x54, 0xa1, 0x78, 0x70, 0x78, 0xd0, 0x15,
+	0xb2, 0x6e, 0xee, 0x23, 0x59, 0x09, 0xb4, 0xb3, 0xd9, 0xdf, 0x6c, 0xe7, 0xa9, 0xd5, 0xff, 0xfe,
+	0x51, 0xb7, 0x5d, 0x30, 0xff, 0x2a, 0x07, 0x55, 0xbd, 0x8e, 0xf1, 0x31, 0x00, 0x89, 0xf0, 0xe0,
+	0xcc, 0xf5, 0x62, 0xd7, 0xed, 0xf5, 0xf4, 0x4e, 0xeb, 0xf4, 0xaa, 0x9f, 0x10, 0x56, 0xcc, 0x2b,
+	0x4b, 0x3c, 0xf7, 0x3b, 0x3d, 0x68, 0x65, 0x91, 0x4b, 0x7c, 0xd8, 0xf7, 0xd2, 0x56, 0xa5, 0xb5,
+	0xf1, 0x4a, 0x66, 0x69, 0x9a, 0xc9, 0xac, 0x9d, 0x32, 0x30, 0xf7, 0xa0, 0xaa, 0xc1, 0x46, 0x1d,
+	0x2a, 0x3b, 0xdd, 0xdd, 0xcd, 0x27, 0xfb, 0xc4, 0x2a, 0x00, 0xe5, 0xde, 0xde, 0xc1, 0xa3, 0xfd,
+	0xae, 0x5c, 0x6b, 0x7f, 0xaf, 0xd7, 0x6f, 0xe7, 0xcd, 0x3f, 0xc2, 0xcb, 0x68, 0x4f, 0x06, 0x8d,
+	0x0c, 0x7a, 0x1b, 0xec, 0x7e, 0x29, 0x4b, 0xc4, 0xb9, 0x9e, 0x54, 0x40, 0x6a, 0x69, 0x3c, 0xc9,
+	0x22, 0x2b, 0x56, 0xed, 0xdb, 0x70, 0x27, 0x1d, 0x0f, 0x17, 0x32, 0xa9, 0x1a, 0x0a, 0xed, 0x7d,
+	0xcf, 0x51, 0xae, 0x30, 0xb7, 0x99, 0x07, 0x5d, 0x34, 0x32, 0x49, 0xa0, 0x50, 0xe1, 0x7e, 0x7f,
+	0x51, 0x13, 0x97, 0x17, 0x35, 0x71, 0x24, 0x4e, 0x74, 0x7c, 0xf6, 0xf8, 0x40, 0xb9, 0xf4, 0x81,
+	0x16, 0x22, 0x92, 0xfc, 0x62, 0x44, 0x92, 0xd8, 0xd6, 0xd2, 0x8b, 0x6c, 0xab, 0xf9, 0x5f, 0x45,
+	0x68, 0x61, 0x50, 0x1f, 0xf9, 0x81, 0xa3, 0x9c, 0xc2, 0xe7, 0x49, 0x19, 0xf2, 0x68, 0x20, 0x83,
+	0x93, 0xad, 0x6b, 0x0a, 0x22, 0xa1, 0xd4, 0xd8, 0x1f, 0x32, 0x7b, 0x2b, 0x23, 0x1a, 0xf7, 0x29,
+	0x3b, 0x78, 0x6c, 0x0f, 0x9f, 0xc9, 0xb2, 0x62, 0x4a, 0xab, 0x02, 0x90, 0x75, 0xed, 0xe1, 0x10,
+	0xd5, 0xea, 0x80, 0xb8, 0x45, 0x0c, 0x6a, 0x4d, 0x20, 0x9f, 0x21, 0xcf, 0x20, 0x3a, 0x74, 0x86,
+	0x81, 0x13, 0x31, 0xba, 0x2c, 0x68, 0x81, 0x10, 0x1a, 0x69, 0x12, 0xe2, 0x48, 0xdc, 0x65, 0x10,
+	0xf9, 0xcf, 0x1c, 0x4f, 0xa9, 0xba, 0x86, 0x02, 0xf6, 0x09, 0x46, 0x5a, 0xc8, 0xf6, 0x7c, 0xef,
+	0x72, 0xe2, 0xa3, 0x85, 0x17, 0xb3, 0x92, 0x00, 0x8c, 0x75, 0xb8, 0xee, 0x78, 0xc3, 0xe0, 0x72,
+	0x4a, 0x67, 0xa5, 0x5d, 0x28, 0xdd, 0xe7, 0x28, 0x3f, 0x7d, 0x35, 0x41, 0xe1, 0x76, 0xbb, 0x88,
+	0xa0, 0x13, 0x9d, 0xdb, 0xb3, 0x71, 0x34, 0xe0, 0x34, 0x00, 0xc8, 0x89, 0x18, 0xb2, 0x49, 0xb9,
+	0x80, 0x77, 0x61, 0x55, 0xd0, 0x81, 0x3f, 0x76, 0xdc, 0x91, 0x2c, 0x56, 0xe7, 0x51, 0x2b, 0x8c,
+	0xb0, 0x18, 0xce, 0x4b, 0xe1, 0xd6, 0x32, 0x56, 0x2e, 0xa4, 0x47, 0x37, 0x64, 0x6b, 0x46, 0xf5,
+	0x14, 0x26, 0xbb, 0xf5, 0xd4, 0x8e, 0xce, 0xd8, 0xb9, 0xd7, 0x5b, 0x1f, 0x21, 0x80, 0x9c, 0x02,
+	0x41, 0x9f, 0xb8, 0xce, 0x58, 0x82, 0x73, 0x74, 0x0a, 0x18, 0xb4, 0x4b, 0x10, 0x62, 0x45, 0x35,
+	0xc0, 0x0f, 0x26, 0xb6, 0x64, 0x15, 0x6b, 0x96, 0x4c, 0xda, 0x65, 0x10, 0x6d, 0xa1, 0xde, 0xca,
+	0xc3, 0xa0, 0xb8, 0x2d, 0xcf, 0x2c, 0x90, 0x03, 0x8c, 0x8a, 0xdf, 0x81, 0x36, 0xb2, 0x35, 0xda,
+	0x64, 0x34, 0x6d, 0xf6, 0x78, 0x70, 0x12, 0xf8, 0x93, 0xb5, 0x55, 0x1e, 0xb4, 0x92, 0x82, 0xef,
+	0x22, 0x58, 0x25, 0x65, 0xa6, 0xa8, 0x88, 0x5d, 0x7b, 0xbc, 0x66, 0xe8, 0xa4, 0xcc, 0x91, 0x00,
+	0xcc, 0xff, 0x2e, 0x40, 0x35, 0x8e, 0x1a, 0xdf, 0x43, 0x97, 0x5a, 0x2b, 0x47, 0xe5, 0x15, 0x36,
+	0x33, 0x1a, 0xd3, 0x4a, 0xf0, 0xb8, 0x70, 0xfe, 0xd9, 0xb9, 0x52, 0xd4, 0xcd, 0x75, 0xc9, 0xe9,
+	0x4f, 0x8f, 0x1f, 0xae, 0x7f, 0xf6, 0xd4, 0x42, 0xc4, 0x97, 0x90, 0x00, 0xe3, 0x6d, 0x58, 0x19,
+	0x8e, 0x1d, 0xdb, 0x1b, 0x24, 0xae, 0x8c, 0x70, 0x58, 0x8b, 0xc1, 0x47, 0xb1, 0x3f, 0xf3, 0x26,
+	0x94, 0x30, 0x5c, 0x42, 0xf5, 0x9b, 0x4a, 0x1b, 0x1f, 0x06, 0x36, 0x8e, 0xda, 0x21, 0xb0, 0x25,
+	0x58, 0x52, 0xd4, 0x71, 0xa4, 0x96, 0x52, 0xd4, 0x4b, 0xa2, 0xb4, 0x58, 0xc2, 0x21, 0x2d, 0xe1,
+	0xef, 0xc1, 0x2a, 0xc6, 0xdc, 0x6c, 0x9d, 0x06, 0x71, 0x62, 0x42, 0xcc, 0x66, 0x5b, 0x23, 0xb6,
+	0x75, 0x82, 0xe2, 0x7d, 0xd2, 0x4f, 0x2c, 0x7e, 0xcc, 0x30, 0xf5, 0x0d, 0x83, 0x15, 0x5c, 0x46,
+	0xa0, 0x2d, 0x3d, 0x04, 0xa9, 0x52, 0x1b, 0x8e, 0x86, 0x03, 0xa1, 0x4c, 0x33, 0x39, 0xdb, 0xf6,
+	0xce, 0xb6, 0x90, 0xa4, 0x8a, 0x68, 0x71, 0xe1, 0x33, 0x11, 0x64, 0xeb, 0x25, 0x22, 0x48, 0xad,
+	0xea, 0x57, 0x92, 0x00, 0x22, 0x6d, 0x93, 0xdb, 0x19, 0x9b, 0x8c, 0xd6, 0xbd, 0xd2, 0xae, 0x9a,
+	0x6f, 0x40, 0x55, 0x6f, 0x4d, 0x9a, 0x36, 0x74, 0x3c, 0x95, 0x2f, 0x60, 0x4d, 0x4b, 0xdd, 0x7e,
+	0x68, 0x0e, 0xa1, 0xf0, 0xd9, 0xd3, 0x1e, 0x2b, 0x5c, 0xb2, 0x7d, 0x25, 0x76, 0x95, 0xb8, 0x1d,
+	0x2b, 0xe1, 0x7c, 0x4a, 0x09, 0xdf, 0x12, 0xfb, 0xc5, 0x4f, 0xa6, 0x93, 0xac, 0x29, 0x08, 0x11,
+	0x5d, 0x6c, 0x77, 0x51, 0xf2, 0xaf, 0xdc, 0x31, 0xff, 0xb5, 0x00, 0x15, 0xe5, 0x5e, 0xd1, 0x45,
+	0x66, 0x71, 0x7e, 0x90, 0x9a, 0xd9, 0xb8, 0x37, 0xf6, 0xd3, 0xd2, 0x45, 0x9a, 0xc2, 0x8b, 0x8b,
+	0x34, 0x68, 0x59, 0x1b, 0x53, 0xc1, 0xa5, 0x3d, 0xbb, 0x57, 0xd3, 0x73, 0xd4, 0x5f, 0x9e, 0x57,
+	0x9f, 0x26, 0x1d, 0x22, 0x25, 0x67, 0xaa, 0x23, 0xfb, 0x54, 0x51, 0xa0, 0x42, 0xfd, 0xbe, 0x7d,
+	0xfa, 0x52, 0x6e, 0x5a, 0x8b, 0xfd, 0xbd, 0x06, 0x2b, 0x73, 0x72, 0xed, 0xd2, 0x2f, 0xd3, 0xcc,
+	0x7a, 0x4b, 0xa8, 0xa7, 0xd1, 0xc7, 0x45, 0xb7, 0x98, 0x70, 0x2d, 0x95, 0x0f, 0x63, 0x00, 0xbe,
+	0xc5, 0xcf, 0x72, 0x50, 0x51, 0xf7, 0x5a, 0xb0, 0xc5, 0x5b, 0x7b, 0x07, 0x9b, 0xd6, 0xf7, 0xd1,
+	0x16, 0xa3, 0xaf, 0xb1, 0x77, 0x80, 0xa6, 0xd8, 0xa8, 0x41, 0x69, 0x77, 0xff, 0x70, 0xb3, 0xdf,
+	0x2e, 0x90, 0x7d, 0xde, 0x3a, 0x3c, 0xdc, 0x6f, 0x17, 0x8d, 0x06, 0x54, 0xd1, 0x01, 0xe9, 0xf6,
+	0xf7, 0x1e, 0x77, 0xdb, 0x25, 0x1a, 0xfb, 0xa8, 0x7b, 0xd8, 0x2e, 0x53, 0x03, 0x83, 0xf1, 0x76,
+	0x85, 0xf0, 0x47, 0x9b, 0xbd, 0xde, 0xe7, 0x87, 0xd6, 0x4e, 0xbb, 0xca, 0x36, 0xbe, 0x6f, 0xa1,
+	0x95, 0x6f, 0xd7, 0xa8, 0x7d, 0xb8, 0xf5, 0x69, 0x77, 0xbb, 0xdf, 0x06, 0xf3, 0x01, 0xd4, 0x53,
+	0xb4, 0xa2, 0xd9, 0x56, 0x77, 0x17, 0xcf, 0x81, 0x5b, 0x3e, 0xdd, 0xdc, 0x7f, 0x42, 0x2e, 0x41,
+	0x0b, 0x80, 0x9b, 0x83, 0xfd, 0x4d, 0x9c, 0x9e, 0x57, 0x0e, 0xe5, 0xef, 0xe4, 0xe2, 0x99, 0x5c,
+	0xee, 0x78, 0x1b, 0xaa, 0x8a, 0xce, 0x3a, 0x0d, 0x51, 0x4f, 0x3d, 0x88, 0x15, 0x23, 0xb3, 0x74,
+	0x29, 0x64, 0xe9, 0xc2, 0xb1, 0xe3, 0x74, 0xec, 0x46, 0xc2, 0x55, 0xc4, 0xbb, 0xdc, 0x4b, 0x95,
+	0x07, 0x4b, 0xe9, 0xf2, 0x20, 0x9e, 0x25, 0x87, 0xae, 0xca, 0x87, 0x00, 0x49, 0xd9, 0x69, 0x89,
+	0xab, 0x84, 0x6c, 0x67, 0x8f, 0x5d, 0x5b, 0x47, 0xaa, 0xd2, 0x31, 0x0f, 0xa0, 0x9e, 0x2a, 0x56,
+	0xd1, 0x53, 0xa2, 0xb7, 0x4d, 0x26, 0x4b, 0x04, 0xa7, 0x8a, 0x11, 0xed, 0x78, 0x8c, 0x76, 0x2a,
+	0x24, 0x37, 0x55, 0xea, 0x5c, 0xf9, 0xb9, 0x52, 0x08, 0x4f, 0xb5, 0x04, 0x69, 0xbe, 0x0f, 0xe5,
+	0x5d, 0xed, 0xcc, 0x6b, 0x4e, 0xca, 0x5d, 0xc5, 0x49, 0xe6, 0x47, 0xea, 0xcc, 0x5c, 0x4d, 0x41,
+	0x5d, 0x55, 0x57, 0xd5, 0x31, 0x2e, 0x8c, 0xe4, 0x92, 0x5c, 0x87, 0x0c, 0x52, 0xa5, 0x34, 0x1e,
+	0x6c, 0xee, 0x40, 0xf5, 0xb9, 0x15, 0x4a, 0x45, 0x80, 0x7c, 0x42, 0x80, 0x25, 0x35, 0x4b, 0xf3,
+	0x87, 0x78, 0x80, 0xb8, 0xee, 0xa6, 0x18, 0x5b, 0x56, 0x21, 0xc6, 0x7e, 0x97, 0x92, 0xb9, 0xee,
+	0x18, 0x23, 0x7a, 0x2f, 0x73, 0xeb, 0xa4, 0x52, 0x17, 0xe3, 0x8d, 0x3b, 0x50, 0xe4, 0x72, 0x62,
+	0x21, 0x51, 0x84, 0x71, 0x2d, 0x91, 0x31, 0xe6, 0x05, 0x34, 0xc5, 0xff, 0x7f, 0x09, 0xd7, 0x28,
+	0xab, 0x77, 0xf2, 0x0b, 0x7a, 0x07, 0x59, 0x81, 0x2d, 0xb2, 0xbe, 0x8d, 0xea, 0x5d, 0xa1, 0x8f,
+	0x7e, 0x3b, 0x0f, 0x20, 0x5b, 0x53, 0x62, 0x36, 0x1b, 0x68, 0xe7, 0xe6, 0x03, 0x6d, 0x24, 0x53,
+	0x5c, 0x29, 0x46, 0x32, 0x51, 0x3b, 0xb1, 0x2d, 0x2a, 0xf8, 0x16, 0xdb, 0x82, 0xeb, 0xb0, 0x87,
+	0xe4, 0xfe, 0x84, 0xcb, 0x14, 0xb4, 0x61, 0x02, 0x48, 0xd7, 0x4d, 0x4b, 0xd9, 0xba, 0x69, 0x5c,
+	0x44, 0x2a, 0xcb, 0x6a, 0x52, 0x44, 0x5a, 0x52, 0x0f, 0x93, 0xec, 0x47, 0xe8, 0x04, 0x91, 0x0e,
+	0xdd, 0xa5, 0x17, 0x47, 0xa1, 0x35, 0x35, 0xd6, 0x96, 0xfc, 0x85, 0x47, 0x35, 0x61, 0xef, 0x64,
+	0xec, 0x0e, 0x23, 0x55, 0x27, 0x05, 0xcf, 0xdf, 0x56, 0x10, 0x13, 0x35, 0xa6, 0xa6, 0x3f, 0x97,
+	0x9d, 0xde, 0x8d, 0x23, 0xb4, 0x5c, 0xf2, 0xb6, 0x09, 0x99, 0xb6, 0xf2, 0x6b, 0x39, 0x1d, 0xa3,
+	0x99, 0xff, 0x59, 0xd0, 0x93, 0x55, 0x75, 0xe4, 0xf9, 0x34, 0xcc, 0x06, 0xdd, 0xf9, 0x97, 0x0a,
+	0xba, 0xbf, 0x8d, 0x36, 0x92, 0xe3, 0x48, 0xf7, 0x5c, 0x5b, 0x80, 0xce, 0x7c, 0xcc, 0xa8, 0x22,
+	0x4d, 0x1c, 0x61, 0x25, 0x83, 0x5f, 0xf0, 0x0e, 0x31, 0xb5, 0x4b, 0xcb, 0xa8, 0x5d, 0xfe, 0x8a,
+	0xd4, 0x46, 0xbf, 0x0f, 0xdd, 0x5d, 0xf4, 0xe8, 0xc6, 0x63, 0xca, 0xf7, 0x28, 0x72, 0xe3, 0x0b,
+	0x78, 0x07, 0x0a, 0x44, 0x6e, 0x6b, 0x7a, 0x88, 0x08, 0x75, 0x9d, 0xc7, 0xad, 0xa4, 0xc6, 0xb1,
+	0xe8, 0xdf, 0x85, 0xb6, 0x7f, 0xfc, 0x43, 0x2a, 0xc9, 0x12, 0xc5, 0x06, 0x2c, 0xcd, 0xe2, 0xb3,
+	0xb6, 0x04, 0x4e, 0x24, 0x3a, 0x20, 0xb9, 0x9e, 0x7b, 0xe6, 0xe6, 0xc2, 0x33, 0x7f, 0x04, 0xb5,
+	0x98, 0x4a, 0xa9, 0x98, 0x15, 0x75, 0xf9, 0xde, 0xc1, 0x4e, 0xf7, 0x0b, 0xd4, 0xe5, 0x68, 0x6b,
+	0xac, 0xee, 0xd3, 0xae, 0xd5, 0xeb, 0xa2, 0x59, 0x41, 0x3b, 0xb0, 0xd3, 0xdd, 0xef, 0xf6, 0x31,
+	0x74, 0x15, 0x3f, 0x82, 0x8b, 0x14, 0xb8, 0x92, 0x1b, 0x99, 0x3d, 0x80, 0x24, 0x10, 0x27, 0x9d,
+	0x9d, 0x1c, 0x4e, 0x65, 0x02, 0x23, 0x7d, 0xac, 0xbb, 0xb1, 0x40, 0xe6, 0xaf, 0x0a, 0xf7, 0x05,
+	0x4f, 0x25, 0xf5, 0xc7, 0xf6, 0xf4, 0x13, 0x29, 0xe7, 0xbd, 0x09, 0x2d, 0x76, 0x67, 0x75, 0xa0,
+	0x20, 0xca, 0xb2, 0x61, 0x35, 0x63, 0x28, 0xe9, 0x5e, 0xf3, 0xaf, 0x73, 0x70, 0xe3, 0xb1, 0x7f,
+	0xee, 0xc4, 0xee, 0xe3, 0x91, 0x7d, 0x39, 0xf6, 0xed, 0xd1, 0x0b, 0xd8, 0x90, 0x22, 0x1d, 0x7f,
+	0xc6, 0xe5, 0x35, 0x5d, 0x8c, 0xc4, 0x48, 0x87, 0x21, 0x8f, 0xd4, 0x57, 0x14, 0xa8, 0x87, 0x18,
+	0x59, 0x10, 0xfd, 0x43, 0x7d, 0x42, 0xa5, 0x22, 0xd5, 0x62, 0x26, 0x52, 0x5d, 0xea, 0x4f, 0x96,
+	0xae, 0xf0, 0x27, 0xd3, 0x21, 0x6c, 0x39, 0x13, 0xc2, 0x9a, 0xdb, 0x50, 0xeb, 0x5f, 0x70, 0x82,
+	0x77, 0x16, 0x66, 0x1c, 0x88, 0xdc, 0x73, 0x1c, 0x88, 0xfc, 0x9c, 0x03, 0xf1, 0x2f, 0x68, 0x7e,
+	0x53, 0x3e, 0x33, 0x32, 0x65, 0x31, 0xba, 0xf0, 0xb2, 0x9f, 0x27, 0xe8, 0x4d, 0x2c, 0x46, 0x2d,
+	0x84, 0xce, 0xf9, 0x85, 0xd0, 0xd9, 0xd8, 0x87, 0x15, 0x51, 0xcb, 0xfa, 0x7e, 0x3a, 0xd7, 0xf3,
+	0xc6, 0x9c, 0x8f, 0x2e, 0x49, 0x70, 0x7d, 0x5b, 0x95, 0xc0, 0x68, 0x9d, 0x66, 0x80, 0x9d, 0x4d,
+	0xb8, 0xbe, 0x64, 0xd8, 0x97, 0x29, 0x87, 0x98, 0xb7, 0xa1, 0x49, 0x05, 0x04, 0x77, 0x82, 0x8f,
+	0x63, 0x4f, 0xa6, 0xec, 0x80, 0x29, 0xb3, 0x5a, 0xb4, 0xb0, 0x65, 0xbe, 0x05, 0x8d, 0x23, 0xc7,
+	0x09, 0x50, 0xaf, 0x4d, 0x7d, 0x2a, 0xef, 0x24, 0xc9, 0x67, 0xb1, 0xe1, 0xaa, 0x67, 0xfe, 0x16,
+	0xd4, 0x28, 0x5b, 0xb1, 0x65, 0x47, 0xc3, 0xb3, 0x2f, 0x93, 0xcd, 0x78, 0x0b, 0x2a, 0x53, 0x61,
+	0x38, 0x15, 0x49, 0x35, 0xd8, 0x96, 0x2b, 0x26, 0xb4, 0x34, 0xd2, 0xfc, 0x4d, 0xb8, 0xde, 0x9b,
+	0x1d, 0x87, 0xc3, 0xc0, 0xe5, 0xf0, 0x56, 0xdb, 0x39, 0x0c, 0xe4, 0x91, 0x27, 0x4f, 0xdc, 0x0b,
+	0x47, 0xb3, 0x77, 0xdc, 0x47, 0x25, 0x51, 0x99, 0xd0, 0x71, 0x9c, 0x44, 0x70, 0x92, 0xf0, 0xeb,
+	0x31, 0x61, 0x2c, 0x3d, 0xc0, 0xfc, 0x0e, 0xdc, 0xc8, 0x2e, 0xaf, 0xae, 0xfb, 0x06, 0xd2, 0xf2,
+	0x3c, 0x54, 0xb7, 0x58, 0xcd, 0x84, 0x6f, 0xfc, 0x05, 0x01, 0x61, 0xcd, 0x3f, 0xcd, 0x41, 0x81,
+	0xc2, 0xcd, 0xd4, 0xe7, 0x51, 0x45, 0xf9, 0x3c, 0xea, 0xf5, 0x74, 0x1e, 0x58, 0x9c, 0xff, 0x24,
+	0xdf, 0x8b, 0x02, 0x86, 0x91, 0xed, 0x8f, 0xed, 0x60, 0xe4, 0x8c, 0x94, 0xf5, 0x4b, 0x00, 0xa4,
+	0x19, 0x8f, 0x67, 0x93, 0xa9, 0x52, 0xad, 0xdc, 0x46, 0x91, 0x2e, 0xa6, 0x1c, 0xf2, 0x55, 0x22,
+	0x2a, 0xee, 0xbb, 0x8e, 0xd1, 0x5f, 0xc8, 0x8a, 0x5e, 0x4c, 0xaa, 0x89, 0xf1, 0x69, 0x0c, 0x22,
+	0xe5, 0x74, 0xd0, 0x1b, 0xa0, 0xc7, 0x7a, 0x4d, 0xbb, 0xae, 0x39, 0x52, 0x4c, 0xfd, 0x2f, 0x0e,
+	0x06, 0xfd, 0x1e, 0xfa, 0x76, 0x3f, 0x80, 0xba, 0x66, 0xcf, 0xbd, 0x11, 0x17, 0x92, 0x58, 0x3e,
+	0xf6, 0x46, 0x19, 0x71, 0xd9, 0xe3, 0xd8, 0xc2, 0xf1, 0x70, 0x8c, 0x66, 0x22, 0xee, 0x64, 0x6f,
+	0xa8, 0xaa, 0x52, 0xfa, 0x86, 0x66, 0x17, 0x56, 0x2d, 0x4e, 0x88, 0x93, 0xd1, 0xd3, 0x4f, 0x86,
+	0x1c, 0xe4, 0x61, 0x37, 0xde, 0x40, 0xf5, 0x68, 0x67, 0xe5, 0xa2, 0x28, 0x75, 0xa2, 0xbb, 0xa6,
+	0x03, 0xab, 0xa4, 0xa1, 0x54, 0xc1, 0x54, 0x2d, 0x93, 0x49, 0xd6, 0xe6, 0xe6, 0x92, 0xb5, 0xb4,
+	0x89, 0xaa, 0xb8, 0x8a, 0xaf, 0xa1, 0xab, 0xac, 0xc8, 0x2f, 0x23, 0x54, 0x43, 0x5c, 0x26, 0x11,
+	0xbd, 0x14, 0xf7, 0xcd, 0xfb, 0x70, 0x7d, 0x73, 0x3a, 0x1d, 0x5f, 0xea, 0x2a, 0x96, 0xda, 0x68,
+	0x2d, 0x29, 0x75, 0xe5, 0x54, 0x40, 0x23, 0x5d, 0x73, 0x17, 0x0d, 0xb7, 0x0a, 0x91, 0x29, 0x31,
+	0xc8, 0x0a, 0x65, 0xec, 0x66, 0x62, 0xc3, 0xaa, 0x00, 0xfa, 0xd9, 0x94, 0xf0, 0xdc, 0xfd, 0xd6,
+	0x31, 0x76, 0x10, 0x6d, 0x85, 0x8f, 0x3e, 0x44, 0x6a, 0xf0, 0xe4, 0x92, 0xc5, 0x6d, 0xe2, 0xaa,
+	0x49, 0x78, 0xaa, 0xbd, 0x4d, 0x6c, 0x9a, 0x7f, 0x9b, 0x87, 0xe6, 0x16, 0x27, 0x39, 0xf4, 0x19,
+	0x53, 0x3a, 0x35, 0x97, 0xd1, 0xa9, 0x69, 0x35, 0x99, 0xcf, 0x66, 0xfa, 0xd2, 0x07, 0x2a, 0x64,
+	0x5d, 0x44, 0x5c, 0x6e, 0xe6, 0xb9, 0x17, 0x5a, 0x45, 0x23, 0xf9, 0xa8, 0x8b, 0x73, 0xee, 0x40,
+	0x9d, 0xd4, 0xb8, 0xeb, 0x49, 0xea, 0x4c, 0xf2, 0x5f, 0x69, 0xd0, 0x5c, 0x82, 0xac, 0xfc, 0xfc,
+	0x04, 0x59, 0xe5, 0x85, 0x09, 0xb2, 0xea, 0x8b, 0x12, 0x64, 0xb5, 0xf9, 0x04, 0x59, 0xd6, 0xbd,
+	0x85, 0x05, 0xf7, 0x16, 0x4f, 0x20, 0x9f, 0x85, 0x9c, 0xa0, 0x93, 0xa0, 0x7c, 0x86, 0x1a, 0x43,
+	0x76, 0x11, 0x60, 0xee, 0x43, 0x4b, 0x93, 0x56, 0xa9, 0x80, 0x8f, 0x61, 0x45, 0x65, 0xc7, 0x9d,
+	0x40, 0xe5, 0x7c, 0xc4, 0x08, 0xb0, 0xfc, 0x49, 0x02, 0x5b, 0x61, 0xac, 0xd6, 0x28, 0xdd, 0x0d,
+	0xcd, 0x5f, 0xe4, 0xa0, 0x99, 0x19, 0x61, 0x3c, 0x48, 0x72, 0xed, 0x39, 0x96, 0xe2, 0xb5, 0x85,
+	0x55, 0x9e, 0x9f, 0x6f, 0xcf, 0xcf, 0xe5, 0xdb, 0xcd, 0x7b, 0x71, 0x16, 0x5d, 0xe5, 0xce, 0xaf,
+	0xc5, 0xb9, 0x73, 0x4e, 0x37, 0x6f, 0xf6, 0xfb, 0x16, 0x3a, 0x23, 0x65, 0xc8, 0x1f, 0xf4, 0xda,
+	0x05, 0xf3, 0x0f, 0x91, 0x79, 0xba, 0x17, 0x53, 0xfe, 0x44, 0xea, 0x85, 0xb1, 0x42, 0x8a, 0xaf,
+	0xf2, 0x19, 0xbe, 0x4a, 0x71, 0x48, 0x41, 0x15, 0x0f, 0x85, 0x43, 0x28, 0x7a, 0x90, 0x74, 0x9d,
+	0xe2, 0x1c, 0xe9, 0xfd, 0x7f, 0xe0, 0x9c, 0x8c, 0x46, 0x81, 0xf9, 0xf2, 0x0f, 0x32, 0x86, 0x26,
+	0x9b, 0x62, 0x8c, 0x97, 0x12, 0x56, 0xf9, 0x28, 0x72, 0x1c, 0x67, 0x78, 0xa4, 0x63, 0xfe, 0x41,
+	0x1e, 0x6a, 0xc2, 0x67, 0x74, 0xf8, 0x77, 0x94, 0x5e, 0xcf, 0x25, 0x95, 0x86, 0x18, 0xb9, 0x8e,
+	0xbf, 0x44, 0xb7, 0x2f, 0xad, 0xce, 0xa9, 0x3c, 0x90, 0xc4, 0xfa, 0x9c, 0x07, 0x42, 0x4d, 0x24,
+	0x5e, 0xcf, 0x4c, 0xe5, 0xb0, 0x51, 0x13, 0x31, 0x80, 0xbe, 0x70, 0xa5, 0x28, 0xcc, 0x09, 0x26,
+	0xea, 0x0d, 0xb8, 0x9d, 0x8d, 0x9b, 0x9a, 0xda, 0x93, 0xcf, 0x50, 0xa4, 0x32, 0x4f, 0x91, 0x33,
+	0xa8, 0xa8, 0xb3, 0x91, 0xdb, 0xfb, 0xe4, 0xe0, 0xb3, 0x83, 0xc3, 0xcf, 0x0f, 0x32, 0xdc, 0x17,
+	0x3b, 0xc6, 0xf9, 0xb4, 0x63, 0x5c, 0x20, 0xf8, 0xf6, 0xe1, 0x93, 0x83, 0x7e, 0xbb, 0x68, 0x34,
+	0xa1, 0xc6, 0xcd, 0x01, 0x62, 0xdb, 0x25, 0x4e, 0xa3, 0x6c, 0x7f, 0xd2, 0x7d, 0xbc, 0xd9, 0x2e,
+	0xc7, 0x75, 0x9f, 0x8a, 0xf9, 0xfb, 0x39, 0x58, 0x15, 0x82, 0xa4, 0x33, 0x22, 0xf4, 0xcd, 0x10,
+	0x7d, 0xb4, 0x2c, 0xce, 0x0a, 0xb7, 0xff, 0x8f, 0xb3, 0x24, 0x38, 0x89, 0xbe, 0x26, 0x94, 0x4a,
+	0xab, 0x24, 0x4a, 0xe8, 0x8b, 0x60, 0x29, 0xb0, 0xfe, 0x59, 0x1e, 0x3a, 0xe2, 0x8f, 0x3f, 0xa2,
+	0x2f, 0xb8, 0xbf, 0xb7, 0xbf, 0x10, 0x91, 0x5f, 0xe5, 0x88, 0xa2, 0xa7, 0xce, 0x1f, 0x7d, 0xff,
+	0x68, 0x3c, 0x50, 0x51, 0xa3, 0xbc, 0x6e, 0x53, 0x41, 0x65, 0x21, 0xe3, 0x21, 0x34, 0xe4, 0xe3,
+	0x70, 0x4e, 0x00, 0x67, 0xaa, 0x84, 0x99, 0x68, 0xa0, 0x2e, 0xa3, 0xa4, 0xa6, 0xf9, 0x20, 0x9e,
+	0x94, 0x04, 0xef, 0x8b, 0x85, 0x40, 0x35, 0xa5, 0xcf, 0xe5, 0x40, 0x14, 0xa5, 0xb1, 0x3d, 0x39,
+	0x1e, 0xd9, 0x03, 0xf1, 0x87, 0x14, 0xa3, 0x34, 0x04, 0xd8, 0x63, 0x18, 0xae, 0x4b, 0xf9, 0x8c,
+	0x32, 0x33, 0xec, 0x37, 0x68, 0xb5, 0xab, 0xaf, 0xae, 0xca, 0xb4, 0xe6, 0xd7, 0xb8, 0x80, 0x9a,
+	0xbc, 0xb0, 0x14, 0xc6, 0xb6, 0xad, 0xbd, 0xa3, 0x7e, 0x3b, 0x87, 0xd6, 0xf7, 0xf5, 0xa5, 0x4b,
+	0x28, 0x61, 0x4b, 0xe5, 0x3a, 0x85, 0xc7, 0xcd, 0xbf, 0xcb, 0x41, 0x75, 0x6b, 0x36, 0x7e, 0xc6,
+	0xa6, 0x97, 0x3e, 0x64, 0x46, 0xd7, 0x4c, 0x7d, 0xb7, 0x9d, 0x63, 0x95, 0x54, 0x23, 0x88, 0x7c,
+	0xb9, 0xfd, 0x31, 0x2a, 0x0f, 0x5e, 0x6f, 0x20, 0x5f, 0xc0, 0xc7, 0xb5, 0x42, 0xbd, 0x80, 0xa2,
+	0x20, 0x46, 0x4f, 0xaa, 0x56, 0x18, 0xea, 0x7e, 0x52, 0x43, 0x2d, 0x3c, 0xa7, 0x86, 0xda, 0x39,
+	0x80, 0x56, 0x76, 0x89, 0x25, 0x69, 0xb2, 0xb7, 0xb2, 0xdf, 0xa9, 0x2c, 0xbe, 0x5c, 0xca, 0x31,
+	0xff, 0x14, 0x56, 0xe6, 0x32, 0xd8, 0xcf, 0xd3, 0xd3, 0x19, 0x41, 0xcd, 0xcf, 0x0b, 0xea, 0xfb,
+	0xb0, 0x4a, 0x9f, 0x52, 0xab, 0x60, 0x25, 0x71, 0x19, 0x22, 0x04, 0x0e, 0x62, 0xa2, 0x96, 0xa9,
+	0x8b, 0xde, 0xc8, 0x03, 0x30, 0xd2, 0xa3, 0x15, 0xfd, 0x29, 0x42, 0xa5, 0xe1, 0x54, 0xbc, 0xd5,
+	0xbe, 0x0d, 0x01, 0x88, 0x78, 0xe6, 0x31, 0xdc, 0x60, 0xc7, 0x5f, 0x17, 0x40, 0xf4, 0x1e, 0x77,
+	0x01, 0x7d, 0x41, 0x6e, 0x6a, 0x9b, 0x29, 0xde, 0x7d, 0x9c, 0x93, 0xd7, 0x58, 0x32, 0x0f, 0x18,
+	0x11, 0x0c, 0x67, 0x41, 0xe0, 0x78, 0xc3, 0x4b, 0xe5, 0x20, 0xa5, 0x41, 0xe6, 0x14, 0xae, 0xcf,
+	0xed, 0xc1, 0x99, 0x16, 0xf2, 0x57, 0x67, 0x6c, 0x24, 0x74, 0x52, 0x51, 0x75, 0x51, 0xa9, 0xe2,
+	0xf2, 0x72, 0xfa, 0xb8, 0x48, 0x23, 0x9b, 0x0b, 0xd0, 0x8a, 0xd1, 0xec, 0xda, 0x06, 0x81, 0xaf,
+	0x3f, 0x93, 0x95, 0x0e, 0x3e, 0xc1, 0x2b, 0xf3, 0x3b, 0xca, 0xf0, 0x07, 0x5c, 0xcb, 0xc0, 0xdd,
+	0xf5, 0xad, 0x5e, 0x15, 0x8d, 0xbd, 0x70, 0x3a, 0x4b, 0x8f, 0xdb, 0xf8, 0x8b, 0x1c, 0x14, 0x29,
+	0xfe, 0x31, 0xee, 0x41, 0x0d, 0xe3, 0xf3, 0x20, 0x3a, 0x76, 0xd0, 0x28, 0x66, 0x62, 0x9d, 0x0e,
+	0x73, 0x56, 0xf2, 0x75, 0x90, 0x79, 0xed, 0x83, 0x9c, 0xb1, 0x2e, 0x5f, 0x25, 0xeb, 0xaf, 0xad,
+	0x9b, 0x3a, 0x8e, 0xe2, 0xcd, 0x3a, 0x99, 0xf9, 0xe6, 0xb5, 0xbb, 0x3c, 0xfe, 0x53, 0xdf, 0xf5,
+	0xb6, 0xe5, 0x5b, 0x58, 0x63, 0x3e, 0xee, 0x9a, 0x9f, 0x81, 0xc7, 0x29, 0xef, 0x85, 0x14, 0xe0,
+	0x2d, 0x0e, 0x65, 0xf6, 0x4c, 0xc7, 0x7e, 0xe6, 0xb5, 0x8d, 0x9f, 0x96, 0xa0, 0x48, 0xb5, 0x5f,
+	0x2a, 0xe7, 0xa8, 0x6f, 0xa9, 0x8c, 0xd4, 0x37, 0x53, 0x1d, 0x4e, 0x44, 0xcd, 0x7d, 0x64, 0xc5,
+	0xbb, 0xb4, 0x85, 0xc3, 0x93, 0xca, 0x96, 0x91, 0x7c, 0xea, 0xb5, 0x70, 0xa8, 0x8f, 0xa0, 0xdd,
+	0x8b, 0xd0, 0xd1, 0x98, 0xa4, 0x86, 0x67, 0x49, 0xb5, 0xac, 0x4c, 0xc6, 0xf4, 0x7a, 0x0f, 0xca,
+	0x12, 0x45, 0xcf, 0x4d, 0x98, 0xaf, 0x81, 0xf1, 0xe0, 0xb7, 0xa1, 0xde, 0x3b, 0xf3, 0x67, 0xe3,
+	0x51, 0xcf, 0x09, 0xce, 0x1d, 0x23, 0xf5, 0x55, 0x66, 0x27, 0xd5, 0xc6, 0x03, 0xbd, 0x0d, 0x35,
+	0x89, 0x91, 0x28, 0x42, 0xaa, 0xa8, 0xb0, 0x4b, 0xd6, 0x4c, 0xc5, 0x4e, 0x38, 0xf0, 0x2e, 0x40,
+	0x2a, 0x96, 0x7e, 0xde, 0xc8, 0x87, 0xd0, 0xdc, 0x66, 0x73, 0x73, 0x18, 0x6c, 0x1e, 0xa3, 0x57,
+	0x61, 0xcc, 0x7f, 0x86, 0xd9, 0x99, 0x07, 0xe0, 0xa4, 0x0f, 0xa0, 0xda, 0x0f, 0x2e, 0x65, 0xfc,
+	0xaa, 0x4a, 0x41, 0x24, 0xfb, 0x2d, 0xb9, 0xa4, 0xf1, 0x61, 0xac, 0x46, 0xe2, 0xd0, 0x68, 0x59,
+	0x75, 0x4c, 0xee, 0x2b, 0x22, 0x8f, 0xb3, 0x1e, 0x00, 0x24, 0x71, 0x9b, 0xf1, 0x8a, 0x54, 0xea,
+	0xe6, 0xe2, 0xb8, 0xc5, 0x29, 0x49, 0x8c, 0x26, 0x53, 0x16, 0x62, 0xb6, 0xb9, 0x29, 0xdf, 0x82,
+	0x46, 0x3a, 0xde, 0x32, 0x58, 0x8a, 0x96, 0x44, 0x60, 0xd9, 0x69, 0x1b, 0xff, 0x5e, 0x82, 0xf2,
+	0xe7, 0x7e, 0xf0, 0xcc, 0xa1, 0xea, 0x75, 0x99, 0x05, 0x4e, 0x09, 0x46, 0x5c, 0x7f, 0x5d, 0x46,
+	0xbb, 0x6f, 0x42, 0x8d, 0x9f, 0x99, 0x74, 0x9b, 0x30, 0x1f, 0xff, 0xdb, 0x90, 0x2c, 0x2e, 0xe2,
+	0xca, 0x9c, 0xda, 0x12, 0xd6, 0x8b, 0xbf, 0x6e, 0xc8, 0xd4, 0x44, 0x3b, 0xfc, 0xa4, 0x9f, 0x3d,
+	0xed, 0x91, 0xb0, 0x21, 0x07, 0xa1, 0xe3, 0xd6, 0x93, 0xc7, 0xa3, 0x41, 0xc9, 0xbf, 0x45, 0x88,
+	0x2c, 0x27, 0xff, 0x87, 0x80, 0x2b, 0xdf, 0x47, 0x5b, 0x27, 0x76, 0x7c, 0x35, 0xd1, 0xfb, 0xfa,
+	0x86, 0xed, 0x34, 0x48, 0x4d, 0x78, 0x00, 0x65, 0xf1, 0x79, 0x64, 0x42, 0x26, 0xe0, 0xeb, 0x18,
+	0x69, 0x90, 0x16, 0x4f, 0xe4, 0xfe, 0x8a, 0xaa, 0xa8, 0x1a, 0x4b, 0xca, 0xab, 0x0b, 0x2f, 0x56,
+	0x16, 0x87, 0x56, 0xd6, 0xcf, 0xc4, 0x04, 0xb2, 0x7e, 0xd6, 0xdf, 0x15, 0x39, 0xb6, 0x9c, 0xa1,
+	0xe3, 0xa6, 0xb2, 0x85, 0x86, 0xa6, 0xc8, 0x12, 0x65, 0xf4, 0x11, 0x34, 0x33, 0x99, 0x45, 0x63,
+	0x4d, 0xb3, 0xc5, 0x7c, 0xb2, 0x71, 0x41, 0x05, 0x7c, 0x07, 0x5f, 0x4b, 0xf2, 0x31, 0xc7, 0x8a,
+	0x31, 0x96, 0x64, 0x7f, 0x3a, 0x8b, 0x09, 0x19, 0x96, 0xeb, 0x2f, 0xe0, 0xfa, 0x12, 0x57, 0xc2,
+	0xb8, 0xf5, 0x7c, 0x37, 0xa5, 0x73, 0xfb, 0x4a, 0x7c, 0x4c, 0x80, 0xaf, 0x26, 0x4e, 0xdf, 0x45,
+	0xad, 0x10, 0x5b, 0x54, 0x91, 0x8d, 0x05, 0x7b, 0xdc, 0xb9, 0x39, 0x0f, 0x8e, 0x95, 0xee, 0x63,
+	0x28, 0x6d, 0x8e, 0xa7, 0x67, 0xb6, 0xb1, 0x03, 0xf5, 0xc4, 0xc8, 0x28, 0x6a, 0x2e, 0xb3, 0xbb,
+	0x9d, 0xd7, 0x96, 0xd9, 0x23, 0xb5, 0xdc, 0xd6, 0xda, 0x5f, 0xfe, 0xea, 0x56, 0xee, 0x97, 0xf8,
+	0xfb, 0x27, 0xfc, 0xfd, 0xe2, 0x9f, 0x6f, 0x5d, 0xfb, 0x25, 0xfe, 0xfe, 0x06, 0x7f, 0xc7, 0x65,
+	0xfe, 0x97, 0xc1, 0x87, 0xff, 0x03, 0x2c, 0x3d, 0xfd, 0xe0, 0xa8, 0x38, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -6719,6 +7549,7 @@ type WorkerClient interface {
 	UpdateGraphQLSchema(ctx context.Context, in *UpdateGraphQLSchemaRequest, opts ...grpc.CallOption) (*UpdateGraphQLSchemaResponse, error)
 	DeleteNamespace(ctx context.Context, in *DeleteNsRequest, opts ...grpc.CallOption) (*Status, error)
 	TaskStatus(ctx context.Context, in *TaskStatusRequest, opts ...grpc.CallOption) (*TaskStatusResponse, error)
+	StreamExport(ctx context.Context, in *StreamExportRequest, opts ...grpc.CallOption) (Worker_StreamExportClient, error)
 }
 
 type workerClient struct {
@@ -6925,6 +7756,38 @@ func (c *workerClient) TaskStatus(ctx context.Context, in *TaskStatusRequest, op
 	return out, nil
 }
 
+func (c *workerClient) StreamExport(ctx context.Context, in *StreamExportRequest, opts ...grpc.CallOption) (Worker_StreamExportClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Worker_serviceDesc.Streams[3], "/pb.Worker/StreamExport", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &workerStreamExportClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Worker_StreamExportClient interface {
+	Recv() (*ExportChunk, error)
+	grpc.ClientStream
+}
+
+type workerStreamExportClient struct {
+	grpc.ClientStream
+}
+
+func (x *workerStreamExportClient) Recv() (*ExportChunk, error) {
+	m := new(ExportChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // WorkerServer is the server API for Worker service.
 type WorkerServer interface {
 	// Data serving RPCs.
@@ -6942,6 +7805,7 @@ type WorkerServer interface {
 	UpdateGraphQLSchema(context.Context, *UpdateGraphQLSchemaRequest) (*UpdateGraphQLSchemaResponse, error)
 	DeleteNamespace(context.Context, *DeleteNsRequest) (*Status, error)
 	TaskStatus(context.Context, *TaskStatusRequest) (*TaskStatusResponse, error)
+	StreamExport(*StreamExportRequest, Worker_StreamExportServer) error
 }
 
 // UnimplementedWorkerServer can be embedded to have forward compatible implementations.
@@ -6990,6 +7854,9 @@ func (*UnimplementedWorkerServer) DeleteNamespace(ctx context.Context, req *Dele
 func (*UnimplementedWorkerServer) TaskStatus(ctx context.Context, req *TaskStatusRequest) (*TaskStatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method TaskStatus not implemented")
 }
+func (*UnimplementedWorkerServer) StreamExport(req *StreamExportRequest, srv Worker_StreamExportServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamExport not implemented")
+}
 
 func RegisterWorkerServer(s *grpc.Server, srv WorkerServer) {
 	s.RegisterService(&_Worker_serviceDesc, srv)
@@ -7266,17 +8133,38 @@ func _Worker_TaskStatus_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
-var _Worker_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "pb.Worker",
-	HandlerType: (*WorkerServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "Mutate",
-			Handler:    _Worker_Mutate_Handler,
-		},
-		{
-			MethodName: "ServeTask",
-			Handler:    _Worker_ServeTask_Handler,
+func _Worker_StreamExport_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamExportRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(WorkerServer).StreamExport(m, &workerStreamExportServer{stream})
+}
+
+type Worker_StreamExportServer interface {
+	Send(*ExportChunk) error
+	grpc.ServerStream
+}
+
+type workerStreamExportServer struct {
+	grpc.ServerStream
+}
+
+func (x *workerStreamExportServer) Send(m *ExportChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _Worker_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "pb.Worker",
+	HandlerType: (*WorkerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Mutate",
+			Handler:    _Worker_Mutate_Handler,
+		},
+		{
+			MethodName: "ServeTask",
+			Handler:    _Worker_ServeTask_Handler,
 		},
 		{
 			MethodName: "Sort",
@@ -7332,6 +8220,151 @@ var _Worker_serviceDesc = grpc.ServiceDesc{
 			Handler:       _Worker_Subscribe_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "StreamExport",
+			Handler:       _Worker_StreamExport_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "pb.proto",
+}
+
+// AlphaClient is the client API for Alpha service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type AlphaClient interface {
+	// Applies each mutation request in its own transaction.
+	BatchMutate(ctx context.Context, in *BatchMutationRequest, opts ...grpc.CallOption) (*BatchMutationResponse, error)
+	// Streams the files of an export or a backup of the cluster to the caller.
+	StreamExport(ctx context.Context, in *StreamExportRequest, opts ...grpc.CallOption) (Alpha_StreamExportClient, error)
+}
+
+type alphaClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewAlphaClient(cc *grpc.ClientConn) AlphaClient {
+	return &alphaClient{cc}
+}
+
+func (c *alphaClient) BatchMutate(ctx context.Context, in *BatchMutationRequest, opts ...grpc.CallOption) (*BatchMutationResponse, error) {
+	out := new(BatchMutationResponse)
+	err := c.cc.Invoke(ctx, "/pb.Alpha/BatchMutate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *alphaClient) StreamExport(ctx context.Context, in *StreamExportRequest, opts ...grpc.CallOption) (Alpha_StreamExportClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Alpha_serviceDesc.Streams[0], "/pb.Alpha/StreamExport", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &alphaStreamExportClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Alpha_StreamExportClient interface {
+	Recv() (*ExportChunk, error)
+	grpc.ClientStream
+}
+
+type alphaStreamExportClient struct {
+	grpc.ClientStream
+}
+
+func (x *alphaStreamExportClient) Recv() (*ExportChunk, error) {
+	m := new(ExportChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// AlphaServer is the server API for Alpha service.
+type AlphaServer interface {
+	// Applies each mutation request in its own transaction.
+	BatchMutate(context.Context, *BatchMutationRequest) (*BatchMutationResponse, error)
+	// Streams the files of an export or a backup of the cluster to the caller.
+	StreamExport(*StreamExportRequest, Alpha_StreamExportServer) error
+}
+
+// UnimplementedAlphaServer can be embedded to have forward compatible implementations.
+type UnimplementedAlphaServer struct {
+}
+
+func (*UnimplementedAlphaServer) BatchMutate(ctx context.Context, req *BatchMutationRequest) (*BatchMutationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchMutate not implemented")
+}
+func (*UnimplementedAlphaServer) StreamExport(req *StreamExportRequest, srv Alpha_StreamExportServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamExport not implemented")
+}
+
+func RegisterAlphaServer(s *grpc.Server, srv AlphaServer) {
+	s.RegisterService(&_Alpha_serviceDesc, srv)
+}
+
+func _Alpha_BatchMutate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchMutationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AlphaServer).BatchMutate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Alpha/BatchMutate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AlphaServer).BatchMutate(ctx, req.(*BatchMutationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Alpha_StreamExport_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamExportRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AlphaServer).StreamExport(m, &alphaStreamExportServer{stream})
+}
+
+type Alpha_StreamExportServer interface {
+	Send(*ExportChunk) error
+	grpc.ServerStream
+}
+
+type alphaStreamExportServer struct {
+	grpc.ServerStream
+}
+
+func (x *alphaStreamExportServer) Send(m *ExportChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _Alpha_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "pb.Alpha",
+	HandlerType: (*AlphaServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "BatchMutate",
+			Handler:    _Alpha_BatchMutate_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamExport",
+			Handler:       _Alpha_StreamExport_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "pb.proto",
 }
@@ -7430,6 +8463,13 @@ func (m *SrcFunction) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Coerce) > 0 {
+		i -= len(m.Coerce)
+		copy(dAtA[i:], m.Coerce)
+		i = encodeVarintPb(dAtA, i, uint64(len(m.Coerce)))
+		i--
+		dAtA[i] = 0x2a
+	}
 	if m.IsCount {
 		i--
 		if m.IsCount {
@@ -7479,6 +8519,53 @@ func (m *Query) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.ExpandSample != 0 {
+		i = encodeVarintPb(dAtA, i, uint64(m.ExpandSample))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xa8
+	}
+	if m.Linearizable {
+		i--
+		if m.Linearizable {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xa0
+	}
+	if m.ValidAt != 0 {
+		i = encodeVarintPb(dAtA, i, uint64(m.ValidAt))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x98
+	}
+	if m.FacetOrderDesc {
+		i--
+		if m.FacetOrderDesc {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x90
+	}
+	if len(m.FacetOrder) > 0 {
+		i -= len(m.FacetOrder)
+		copy(dAtA[i:], m.FacetOrder)
+		i = encodeVarintPb(dAtA, i, uint64(len(m.FacetOrder)))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x8a
+	}
 	if m.Offset != 0 {
 		i = encodeVarintPb(dAtA, i, uint64(m.Offset))
 		i--
@@ -7693,6 +8780,26 @@ func (m *Result) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.FetchNs != 0 {
+		i = encodeVarintPb(dAtA, i, uint64(m.FetchNs))
+		i--
+		dAtA[i] = 0x58
+	}
+	if m.IndexNs != 0 {
+		i = encodeVarintPb(dAtA, i, uint64(m.IndexNs))
+		i--
+		dAtA[i] = 0x50
+	}
+	if m.GroupId != 0 {
+		i = encodeVarintPb(dAtA, i, uint64(m.GroupId))
+		i--
+		dAtA[i] = 0x48
+	}
+	if m.NumSampled != 0 {
+		i = encodeVarintPb(dAtA, i, uint64(m.NumSampled))
+		i--
+		dAtA[i] = 0x40
+	}
 	if m.List {
 		i--
 		if m.List {
@@ -8038,6 +9145,16 @@ func (m *Member) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x68
 	}
+	if m.Witness {
+		i--
+		if m.Witness {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x40
+	}
 	if m.Learner {
 		i--
 		if m.Learner {
@@ -8252,6 +9369,44 @@ func (m *ZeroProposal) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.MaxSeq != 0 {
+		i = encodeVarintPb(dAtA, i, uint64(m.MaxSeq))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x80
+	}
+	if m.ShrinkMaxUID != 0 {
+		i = encodeVarintPb(dAtA, i, uint64(m.ShrinkMaxUID))
+		i--
+		dAtA[i] = 0x78
+	}
+	if len(m.ReservedUids) > 0 {
+		for k := range m.ReservedUids {
+			v := m.ReservedUids[k]
+			baseI := i
+			if v != nil {
+				{
+					size, err := v.MarshalToSizedBuffer(dAtA[:i])
+					if err != nil {
+						return 0, err
+					}
+					i -= size
+					i = encodeVarintPb(dAtA, i, uint64(size))
+				}
+				i--
+				dAtA[i] = 0x12
+			}
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintPb(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintPb(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x72
+		}
+	}
 	if m.DeleteNs != nil {
 		{
 			size, err := m.DeleteNs.MarshalToSizedBuffer(dAtA[:i])
@@ -8389,6 +9544,37 @@ func (m *MembershipState) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.MaxSeq != 0 {
+		i = encodeVarintPb(dAtA, i, uint64(m.MaxSeq))
+		i--
+		dAtA[i] = 0x60
+	}
+	if len(m.ReservedUids) > 0 {
+		for k := range m.ReservedUids {
+			v := m.ReservedUids[k]
+			baseI := i
+			if v != nil {
+				{
+					size, err := v.MarshalToSizedBuffer(dAtA[:i])
+					if err != nil {
+						return 0, err
+					}
+					i -= size
+					i = encodeVarintPb(dAtA, i, uint64(size))
+				}
+				i--
+				dAtA[i] = 0x12
+			}
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintPb(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintPb(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x5a
+		}
+	}
 	if m.MaxNsID != 0 {
 		i = encodeVarintPb(dAtA, i, uint64(m.MaxNsID))
 		i--
@@ -8570,6 +9756,23 @@ func (m *HealthInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.StartupTotal != 0 {
+		i = encodeVarintPb(dAtA, i, uint64(m.StartupTotal))
+		i--
+		dAtA[i] = 0x70
+	}
+	if m.StartupApplied != 0 {
+		i = encodeVarintPb(dAtA, i, uint64(m.StartupApplied))
+		i--
+		dAtA[i] = 0x68
+	}
+	if len(m.StartupPhase) > 0 {
+		i -= len(m.StartupPhase)
+		copy(dAtA[i:], m.StartupPhase)
+		i = encodeVarintPb(dAtA, i, uint64(len(m.StartupPhase)))
+		i--
+		dAtA[i] = 0x62
+	}
 	if m.MaxAssigned != 0 {
 		i = encodeVarintPb(dAtA, i, uint64(m.MaxAssigned))
 		i--
@@ -8844,6 +10047,20 @@ func (m *Mutations) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.RollbackTo) > 0 {
+		i -= len(m.RollbackTo)
+		copy(dAtA[i:], m.RollbackTo)
+		i = encodeVarintPb(dAtA, i, uint64(len(m.RollbackTo)))
+		i--
+		dAtA[i] = 0x5a
+	}
+	if len(m.Savepoint) > 0 {
+		i -= len(m.Savepoint)
+		copy(dAtA[i:], m.Savepoint)
+		i = encodeVarintPb(dAtA, i, uint64(len(m.Savepoint)))
+		i--
+		dAtA[i] = 0x52
+	}
 	if m.Metadata != nil {
 		{
 			size, err := m.Metadata.MarshalToSizedBuffer(dAtA[:i])
@@ -8983,28 +10200,55 @@ func (m *Snapshot) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
-	if m.MaxAssigned != 0 {
-		i = encodeVarintPb(dAtA, i, uint64(m.MaxAssigned))
-		i--
-		dAtA[i] = 0x30
-	}
-	if m.SinceTs != 0 {
-		i = encodeVarintPb(dAtA, i, uint64(m.SinceTs))
+	if len(m.ResumeKey) > 0 {
+		i -= len(m.ResumeKey)
+		copy(dAtA[i:], m.ResumeKey)
+		i = encodeVarintPb(dAtA, i, uint64(len(m.ResumeKey)))
 		i--
-		dAtA[i] = 0x28
+		dAtA[i] = 0x4a
 	}
-	if m.Done {
+	if m.Resumable {
 		i--
-		if m.Done {
+		if m.Resumable {
 			dAtA[i] = 1
 		} else {
 			dAtA[i] = 0
 		}
 		i--
-		dAtA[i] = 0x20
+		dAtA[i] = 0x40
 	}
-	if m.ReadTs != 0 {
-		i = encodeVarintPb(dAtA, i, uint64(m.ReadTs))
+	if m.Compress {
+		i--
+		if m.Compress {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.MaxAssigned != 0 {
+		i = encodeVarintPb(dAtA, i, uint64(m.MaxAssigned))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.SinceTs != 0 {
+		i = encodeVarintPb(dAtA, i, uint64(m.SinceTs))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Done {
+		i--
+		if m.Done {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.ReadTs != 0 {
+		i = encodeVarintPb(dAtA, i, uint64(m.ReadTs))
 		i--
 		dAtA[i] = 0x18
 	}
@@ -9424,6 +10668,26 @@ func (m *KVS) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Ordered {
+		i--
+		if m.Ordered {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.Compressed {
+		i--
+		if m.Compressed {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x30
+	}
 	if len(m.Data) > 0 {
 		i -= len(m.Data)
 		copy(dAtA[i:], m.Data)
@@ -9482,6 +10746,25 @@ func (m *Posting) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Seq != 0 {
+		i = encodeVarintPb(dAtA, i, uint64(m.Seq))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x88
+	}
+	if m.ValidTo != 0 {
+		i = encodeVarintPb(dAtA, i, uint64(m.ValidTo))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x80
+	}
+	if m.ValidFrom != 0 {
+		i = encodeVarintPb(dAtA, i, uint64(m.ValidFrom))
+		i--
+		dAtA[i] = 0x78
+	}
 	if m.CommitTs != 0 {
 		i = encodeVarintPb(dAtA, i, uint64(m.CommitTs))
 		i--
@@ -9946,6 +11229,111 @@ func (m *SchemaNode) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Presence {
+		i--
+		if m.Presence {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xa8
+	}
+	if len(m.Validators) > 0 {
+		for iNdEx := len(m.Validators) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Validators[iNdEx])
+			copy(dAtA[i:], m.Validators[iNdEx])
+			i = encodeVarintPb(dAtA, i, uint64(len(m.Validators[iNdEx])))
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0xa2
+		}
+	}
+	if m.Ordered {
+		i--
+		if m.Ordered {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x98
+	}
+	if m.IndexProgress != 0 {
+		i = encodeVarintPb(dAtA, i, uint64(m.IndexProgress))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x90
+	}
+	if len(m.IndexState) > 0 {
+		i -= len(m.IndexState)
+		copy(dAtA[i:], m.IndexState)
+		i = encodeVarintPb(dAtA, i, uint64(len(m.IndexState)))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x8a
+	}
+	if m.Sequence {
+		i--
+		if m.Sequence {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x80
+	}
+	if len(m.Trigger) > 0 {
+		i -= len(m.Trigger)
+		copy(dAtA[i:], m.Trigger)
+		i = encodeVarintPb(dAtA, i, uint64(len(m.Trigger)))
+		i--
+		dAtA[i] = 0x7a
+	}
+	if len(m.View) > 0 {
+		i -= len(m.View)
+		copy(dAtA[i:], m.View)
+		i = encodeVarintPb(dAtA, i, uint64(len(m.View)))
+		i--
+		dAtA[i] = 0x72
+	}
+	if len(m.Facets) > 0 {
+		for iNdEx := len(m.Facets) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Facets[iNdEx])
+			copy(dAtA[i:], m.Facets[iNdEx])
+			i = encodeVarintPb(dAtA, i, uint64(len(m.Facets[iNdEx])))
+			i--
+			dAtA[i] = 0x6a
+		}
+	}
+	if len(m.FacetIndex) > 0 {
+		for iNdEx := len(m.FacetIndex) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.FacetIndex[iNdEx])
+			copy(dAtA[i:], m.FacetIndex[iNdEx])
+			i = encodeVarintPb(dAtA, i, uint64(len(m.FacetIndex[iNdEx])))
+			i--
+			dAtA[i] = 0x62
+		}
+	}
+	if m.Owned {
+		i--
+		if m.Owned {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x58
+	}
 	if m.NoConflict {
 		i--
 		if m.NoConflict {
@@ -10099,6 +11487,118 @@ func (m *SchemaUpdate) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Presence {
+		i--
+		if m.Presence {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xb8
+	}
+	if len(m.Validators) > 0 {
+		for iNdEx := len(m.Validators) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Validators[iNdEx])
+			copy(dAtA[i:], m.Validators[iNdEx])
+			i = encodeVarintPb(dAtA, i, uint64(len(m.Validators[iNdEx])))
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0xb2
+		}
+	}
+	if m.Ordered {
+		i--
+		if m.Ordered {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xa8
+	}
+	if m.Counter {
+		i--
+		if m.Counter {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xa0
+	}
+	if m.Sequence {
+		i--
+		if m.Sequence {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x98
+	}
+	if len(m.Trigger) > 0 {
+		i -= len(m.Trigger)
+		copy(dAtA[i:], m.Trigger)
+		i = encodeVarintPb(dAtA, i, uint64(len(m.Trigger)))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x92
+	}
+	if len(m.View) > 0 {
+		i -= len(m.View)
+		copy(dAtA[i:], m.View)
+		i = encodeVarintPb(dAtA, i, uint64(len(m.View)))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x8a
+	}
+	if len(m.Facets) > 0 {
+		for iNdEx := len(m.Facets) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Facets[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintPb(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0x82
+		}
+	}
+	if len(m.FacetIndex) > 0 {
+		for iNdEx := len(m.FacetIndex) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.FacetIndex[iNdEx])
+			copy(dAtA[i:], m.FacetIndex[iNdEx])
+			i = encodeVarintPb(dAtA, i, uint64(len(m.FacetIndex[iNdEx])))
+			i--
+			dAtA[i] = 0x7a
+		}
+	}
+	if m.Owned {
+		i--
+		if m.Owned {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x70
+	}
 	if m.NoConflict {
 		i--
 		if m.NoConflict {
@@ -10225,6 +11725,22 @@ func (m *TypeUpdate) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Extends) > 0 {
+		for iNdEx := len(m.Extends) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Extends[iNdEx])
+			copy(dAtA[i:], m.Extends[iNdEx])
+			i = encodeVarintPb(dAtA, i, uint64(len(m.Extends[iNdEx])))
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.Trigger) > 0 {
+		i -= len(m.Trigger)
+		copy(dAtA[i:], m.Trigger)
+		i = encodeVarintPb(dAtA, i, uint64(len(m.Trigger)))
+		i--
+		dAtA[i] = 0x1a
+	}
 	if len(m.Fields) > 0 {
 		for iNdEx := len(m.Fields) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -11094,6 +12610,13 @@ func (m *ExportRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Recipient) > 0 {
+		i -= len(m.Recipient)
+		copy(dAtA[i:], m.Recipient)
+		i = encodeVarintPb(dAtA, i, uint64(len(m.Recipient)))
+		i--
+		dAtA[i] = 0x5a
+	}
 	if m.Namespace != 0 {
 		i = encodeVarintPb(dAtA, i, uint64(m.Namespace))
 		i--
@@ -11373,6 +12896,11 @@ func (m *UpdateGraphQLSchemaRequest) MarshalToSizedBuffer(dAtA []byte) (int, err
 	_ = i
 	var l int
 	_ = l
+	if m.ExpectedVersion != 0 {
+		i = encodeVarintPb(dAtA, i, uint64(m.ExpectedVersion))
+		i--
+		dAtA[i] = 0x38
+	}
 	if m.Op != 0 {
 		i = encodeVarintPb(dAtA, i, uint64(m.Op))
 		i--
@@ -11577,6 +13105,16 @@ func (m *TaskStatusRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Cancel {
+		i--
+		if m.Cancel {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
 	if m.TaskId != 0 {
 		i = encodeVarintPb(dAtA, i, uint64(m.TaskId))
 		i--
@@ -11605,6 +13143,37 @@ func (m *TaskStatusResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Error) > 0 {
+		i -= len(m.Error)
+		copy(dAtA[i:], m.Error)
+		i = encodeVarintPb(dAtA, i, uint64(len(m.Error)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.Logs) > 0 {
+		for iNdEx := len(m.Logs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Logs[iNdEx])
+			copy(dAtA[i:], m.Logs[iNdEx])
+			i = encodeVarintPb(dAtA, i, uint64(len(m.Logs[iNdEx])))
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if m.Total != 0 {
+		i = encodeVarintPb(dAtA, i, uint64(m.Total))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Progress != 0 {
+		i = encodeVarintPb(dAtA, i, uint64(m.Progress))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.TaskId != 0 {
+		i = encodeVarintPb(dAtA, i, uint64(m.TaskId))
+		i--
+		dAtA[i] = 0x10
+	}
 	if m.TaskMeta != 0 {
 		i = encodeVarintPb(dAtA, i, uint64(m.TaskMeta))
 		i--
@@ -11613,24 +13182,263 @@ func (m *TaskStatusResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func encodeVarintPb(dAtA []byte, offset int, v uint64) int {
-	offset -= sovPb(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *BatchMutationRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return dAtA[:n], nil
 }
-func (m *List) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Bitmap)
+
+func (m *BatchMutationRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BatchMutationRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Concurrency != 0 {
+		i = encodeVarintPb(dAtA, i, uint64(m.Concurrency))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Requests) > 0 {
+		for iNdEx := len(m.Requests) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Requests[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintPb(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *BatchMutationResult) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BatchMutationResult) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BatchMutationResult) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Error) > 0 {
+		i -= len(m.Error)
+		copy(dAtA[i:], m.Error)
+		i = encodeVarintPb(dAtA, i, uint64(len(m.Error)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Response != nil {
+		{
+			size, err := m.Response.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPb(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Success {
+		i--
+		if m.Success {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *BatchMutationResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BatchMutationResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BatchMutationResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Results) > 0 {
+		for iNdEx := len(m.Results) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Results[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintPb(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *StreamExportRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StreamExportRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StreamExportRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Backup != nil {
+		{
+			size, err := m.Backup.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPb(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Export != nil {
+		{
+			size, err := m.Export.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPb(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ExportChunk) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ExportChunk) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ExportChunk) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.DropOperations) > 0 {
+		for iNdEx := len(m.DropOperations) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.DropOperations[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintPb(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if m.Last {
+		i--
+		if m.Last {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Data) > 0 {
+		i -= len(m.Data)
+		copy(dAtA[i:], m.Data)
+		i = encodeVarintPb(dAtA, i, uint64(len(m.Data)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Path) > 0 {
+		i -= len(m.Path)
+		copy(dAtA[i:], m.Path)
+		i = encodeVarintPb(dAtA, i, uint64(len(m.Path)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintPb(dAtA []byte, offset int, v uint64) int {
+	offset -= sovPb(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *List) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Bitmap)
 	if l > 0 {
 		n += 1 + l + sovPb(uint64(l))
 	}
@@ -11675,6 +13483,10 @@ func (m *SrcFunction) Size() (n int) {
 	if m.IsCount {
 		n += 2
 	}
+	l = len(m.Coerce)
+	if l > 0 {
+		n += 1 + l + sovPb(uint64(l))
+	}
 	return n
 }
 
@@ -11734,6 +13546,22 @@ func (m *Query) Size() (n int) {
 	if m.Offset != 0 {
 		n += 2 + sovPb(uint64(m.Offset))
 	}
+	l = len(m.FacetOrder)
+	if l > 0 {
+		n += 2 + l + sovPb(uint64(l))
+	}
+	if m.FacetOrderDesc {
+		n += 3
+	}
+	if m.ValidAt != 0 {
+		n += 2 + sovPb(uint64(m.ValidAt))
+	}
+	if m.Linearizable {
+		n += 3
+	}
+	if m.ExpandSample != 0 {
+		n += 2 + sovPb(uint64(m.ExpandSample))
+	}
 	return n
 }
 
@@ -11810,6 +13638,18 @@ func (m *Result) Size() (n int) {
 	if m.List {
 		n += 2
 	}
+	if m.NumSampled != 0 {
+		n += 1 + sovPb(uint64(m.NumSampled))
+	}
+	if m.GroupId != 0 {
+		n += 1 + sovPb(uint64(m.GroupId))
+	}
+	if m.IndexNs != 0 {
+		n += 1 + sovPb(uint64(m.IndexNs))
+	}
+	if m.FetchNs != 0 {
+		n += 1 + sovPb(uint64(m.FetchNs))
+	}
 	return n
 }
 
@@ -11933,6 +13773,9 @@ func (m *Member) Size() (n int) {
 	if m.Learner {
 		n += 2
 	}
+	if m.Witness {
+		n += 2
+	}
 	if m.ClusterInfoOnly {
 		n += 2
 	}
@@ -12062,6 +13905,25 @@ func (m *ZeroProposal) Size() (n int) {
 		l = m.DeleteNs.Size()
 		n += 1 + l + sovPb(uint64(l))
 	}
+	if len(m.ReservedUids) > 0 {
+		for k, v := range m.ReservedUids {
+			_ = k
+			_ = v
+			l = 0
+			if v != nil {
+				l = v.Size()
+				l += 1 + sovPb(uint64(l))
+			}
+			mapEntrySize := 1 + len(k) + sovPb(uint64(len(k))) + l
+			n += mapEntrySize + 1 + sovPb(uint64(mapEntrySize))
+		}
+	}
+	if m.ShrinkMaxUID != 0 {
+		n += 1 + sovPb(uint64(m.ShrinkMaxUID))
+	}
+	if m.MaxSeq != 0 {
+		n += 2 + sovPb(uint64(m.MaxSeq))
+	}
 	return n
 }
 
@@ -12126,6 +13988,22 @@ func (m *MembershipState) Size() (n int) {
 	if m.MaxNsID != 0 {
 		n += 1 + sovPb(uint64(m.MaxNsID))
 	}
+	if len(m.ReservedUids) > 0 {
+		for k, v := range m.ReservedUids {
+			_ = k
+			_ = v
+			l = 0
+			if v != nil {
+				l = v.Size()
+				l += 1 + sovPb(uint64(l))
+			}
+			mapEntrySize := 1 + len(k) + sovPb(uint64(len(k))) + l
+			n += mapEntrySize + 1 + sovPb(uint64(mapEntrySize))
+		}
+	}
+	if m.MaxSeq != 0 {
+		n += 1 + sovPb(uint64(m.MaxSeq))
+	}
 	return n
 }
 
@@ -12202,10 +14080,20 @@ func (m *HealthInfo) Size() (n int) {
 	if m.MaxAssigned != 0 {
 		n += 1 + sovPb(uint64(m.MaxAssigned))
 	}
-	return n
-}
-
-func (m *Tablet) Size() (n int) {
+	l = len(m.StartupPhase)
+	if l > 0 {
+		n += 1 + l + sovPb(uint64(l))
+	}
+	if m.StartupApplied != 0 {
+		n += 1 + sovPb(uint64(m.StartupApplied))
+	}
+	if m.StartupTotal != 0 {
+		n += 1 + sovPb(uint64(m.StartupTotal))
+	}
+	return n
+}
+
+func (m *Tablet) Size() (n int) {
 	if m == nil {
 		return 0
 	}
@@ -12328,6 +14216,14 @@ func (m *Mutations) Size() (n int) {
 		l = m.Metadata.Size()
 		n += 1 + l + sovPb(uint64(l))
 	}
+	l = len(m.Savepoint)
+	if l > 0 {
+		n += 1 + l + sovPb(uint64(l))
+	}
+	l = len(m.RollbackTo)
+	if l > 0 {
+		n += 1 + l + sovPb(uint64(l))
+	}
 	return n
 }
 
@@ -12373,6 +14269,16 @@ func (m *Snapshot) Size() (n int) {
 	if m.MaxAssigned != 0 {
 		n += 1 + sovPb(uint64(m.MaxAssigned))
 	}
+	if m.Compress {
+		n += 2
+	}
+	if m.Resumable {
+		n += 2
+	}
+	l = len(m.ResumeKey)
+	if l > 0 {
+		n += 1 + l + sovPb(uint64(l))
+	}
 	return n
 }
 
@@ -12566,6 +14472,12 @@ func (m *KVS) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovPb(uint64(l))
 	}
+	if m.Compressed {
+		n += 2
+	}
+	if m.Ordered {
+		n += 2
+	}
 	return n
 }
 
@@ -12607,6 +14519,15 @@ func (m *Posting) Size() (n int) {
 	if m.CommitTs != 0 {
 		n += 1 + sovPb(uint64(m.CommitTs))
 	}
+	if m.ValidFrom != 0 {
+		n += 1 + sovPb(uint64(m.ValidFrom))
+	}
+	if m.ValidTo != 0 {
+		n += 2 + sovPb(uint64(m.ValidTo))
+	}
+	if m.Seq != 0 {
+		n += 2 + sovPb(uint64(m.Seq))
+	}
 	return n
 }
 
@@ -12821,6 +14742,51 @@ func (m *SchemaNode) Size() (n int) {
 	if m.NoConflict {
 		n += 2
 	}
+	if m.Owned {
+		n += 2
+	}
+	if len(m.FacetIndex) > 0 {
+		for _, s := range m.FacetIndex {
+			l = len(s)
+			n += 1 + l + sovPb(uint64(l))
+		}
+	}
+	if len(m.Facets) > 0 {
+		for _, s := range m.Facets {
+			l = len(s)
+			n += 1 + l + sovPb(uint64(l))
+		}
+	}
+	l = len(m.View)
+	if l > 0 {
+		n += 1 + l + sovPb(uint64(l))
+	}
+	l = len(m.Trigger)
+	if l > 0 {
+		n += 1 + l + sovPb(uint64(l))
+	}
+	if m.Sequence {
+		n += 3
+	}
+	l = len(m.IndexState)
+	if l > 0 {
+		n += 2 + l + sovPb(uint64(l))
+	}
+	if m.IndexProgress != 0 {
+		n += 2 + sovPb(uint64(m.IndexProgress))
+	}
+	if m.Ordered {
+		n += 3
+	}
+	if len(m.Validators) > 0 {
+		for _, s := range m.Validators {
+			l = len(s)
+			n += 2 + l + sovPb(uint64(l))
+		}
+	}
+	if m.Presence {
+		n += 3
+	}
 	return n
 }
 
@@ -12886,6 +14852,47 @@ func (m *SchemaUpdate) Size() (n int) {
 	if m.NoConflict {
 		n += 2
 	}
+	if m.Owned {
+		n += 2
+	}
+	if len(m.FacetIndex) > 0 {
+		for _, s := range m.FacetIndex {
+			l = len(s)
+			n += 1 + l + sovPb(uint64(l))
+		}
+	}
+	if len(m.Facets) > 0 {
+		for _, e := range m.Facets {
+			l = e.Size()
+			n += 2 + l + sovPb(uint64(l))
+		}
+	}
+	l = len(m.View)
+	if l > 0 {
+		n += 2 + l + sovPb(uint64(l))
+	}
+	l = len(m.Trigger)
+	if l > 0 {
+		n += 2 + l + sovPb(uint64(l))
+	}
+	if m.Sequence {
+		n += 3
+	}
+	if m.Counter {
+		n += 3
+	}
+	if m.Ordered {
+		n += 3
+	}
+	if len(m.Validators) > 0 {
+		for _, s := range m.Validators {
+			l = len(s)
+			n += 2 + l + sovPb(uint64(l))
+		}
+	}
+	if m.Presence {
+		n += 3
+	}
 	return n
 }
 
@@ -12905,6 +14912,16 @@ func (m *TypeUpdate) Size() (n int) {
 			n += 1 + l + sovPb(uint64(l))
 		}
 	}
+	l = len(m.Trigger)
+	if l > 0 {
+		n += 1 + l + sovPb(uint64(l))
+	}
+	if len(m.Extends) > 0 {
+		for _, s := range m.Extends {
+			l = len(s)
+			n += 1 + l + sovPb(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -13313,6 +15330,10 @@ func (m *ExportRequest) Size() (n int) {
 	if m.Namespace != 0 {
 		n += 1 + sovPb(uint64(m.Namespace))
 	}
+	l = len(m.Recipient)
+	if l > 0 {
+		n += 1 + l + sovPb(uint64(l))
+	}
 	return n
 }
 
@@ -13438,6 +15459,9 @@ func (m *UpdateGraphQLSchemaRequest) Size() (n int) {
 	if m.Op != 0 {
 		n += 1 + sovPb(uint64(m.Op))
 	}
+	if m.ExpectedVersion != 0 {
+		n += 1 + sovPb(uint64(m.ExpectedVersion))
+	}
 	return n
 }
 
@@ -13508,6 +15532,9 @@ func (m *TaskStatusRequest) Size() (n int) {
 	if m.TaskId != 0 {
 		n += 1 + sovPb(uint64(m.TaskId))
 	}
+	if m.Cancel {
+		n += 2
+	}
 	return n
 }
 
@@ -13520,6 +15547,121 @@ func (m *TaskStatusResponse) Size() (n int) {
 	if m.TaskMeta != 0 {
 		n += 1 + sovPb(uint64(m.TaskMeta))
 	}
+	if m.TaskId != 0 {
+		n += 1 + sovPb(uint64(m.TaskId))
+	}
+	if m.Progress != 0 {
+		n += 1 + sovPb(uint64(m.Progress))
+	}
+	if m.Total != 0 {
+		n += 1 + sovPb(uint64(m.Total))
+	}
+	if len(m.Logs) > 0 {
+		for _, s := range m.Logs {
+			l = len(s)
+			n += 1 + l + sovPb(uint64(l))
+		}
+	}
+	l = len(m.Error)
+	if l > 0 {
+		n += 1 + l + sovPb(uint64(l))
+	}
+	return n
+}
+
+func (m *BatchMutationRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Requests) > 0 {
+		for _, e := range m.Requests {
+			l = e.Size()
+			n += 1 + l + sovPb(uint64(l))
+		}
+	}
+	if m.Concurrency != 0 {
+		n += 1 + sovPb(uint64(m.Concurrency))
+	}
+	return n
+}
+
+func (m *BatchMutationResult) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Success {
+		n += 2
+	}
+	if m.Response != nil {
+		l = m.Response.Size()
+		n += 1 + l + sovPb(uint64(l))
+	}
+	l = len(m.Error)
+	if l > 0 {
+		n += 1 + l + sovPb(uint64(l))
+	}
+	return n
+}
+
+func (m *BatchMutationResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Results) > 0 {
+		for _, e := range m.Results {
+			l = e.Size()
+			n += 1 + l + sovPb(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *StreamExportRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Export != nil {
+		l = m.Export.Size()
+		n += 1 + l + sovPb(uint64(l))
+	}
+	if m.Backup != nil {
+		l = m.Backup.Size()
+		n += 1 + l + sovPb(uint64(l))
+	}
+	return n
+}
+
+func (m *ExportChunk) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Path)
+	if l > 0 {
+		n += 1 + l + sovPb(uint64(l))
+	}
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovPb(uint64(l))
+	}
+	if m.Last {
+		n += 2
+	}
+	if len(m.DropOperations) > 0 {
+		for _, e := range m.DropOperations {
+			l = e.Size()
+			n += 1 + l + sovPb(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -13881,6 +16023,38 @@ func (m *SrcFunction) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.IsCount = bool(v != 0)
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Coerce", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Coerce = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPb(dAtA[iNdEx:])
@@ -14285,29 +16459,139 @@ func (m *Query) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipPb(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FacetOrder", wireType)
 			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthPb
 			}
-			if (iNdEx + skippy) > l {
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ValueList) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
+			m.FacetOrder = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 18:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FacetOrderDesc", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.FacetOrderDesc = bool(v != 0)
+		case 19:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValidAt", wireType)
+			}
+			m.ValidAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ValidAt |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 20:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Linearizable", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Linearizable = bool(v != 0)
+		case 21:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpandSample", wireType)
+			}
+			m.ExpandSample = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExpandSample |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ValueList) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
 		preIndex := iNdEx
@@ -14753,6 +17037,82 @@ func (m *Result) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.List = bool(v != 0)
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NumSampled", wireType)
+			}
+			m.NumSampled = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NumSampled |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupId", wireType)
+			}
+			m.GroupId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GroupId |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IndexNs", wireType)
+			}
+			m.IndexNs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.IndexNs |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FetchNs", wireType)
+			}
+			m.FetchNs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FetchNs |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPb(dAtA[iNdEx:])
@@ -15486,6 +17846,26 @@ func (m *Member) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.Learner = bool(v != 0)
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Witness", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Witness = bool(v != 0)
 		case 13:
 			if wireType != 0 {
 				return fmt.Errorf("proto: wrong wireType = %d for field ClusterInfoOnly", wireType)
@@ -16490,78 +18870,9 @@ func (m *ZeroProposal) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipPb(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthPb
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *MembershipState) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowPb
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: MembershipState: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: MembershipState: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Counter", wireType)
-			}
-			m.Counter = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Counter |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 2:
+		case 14:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Groups", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ReservedUids", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -16588,11 +18899,11 @@ func (m *MembershipState) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Groups == nil {
-				m.Groups = make(map[uint32]*Group)
+			if m.ReservedUids == nil {
+				m.ReservedUids = make(map[string]*AssignedIds)
 			}
-			var mapkey uint32
-			var mapvalue *Group
+			var mapkey string
+			var mapvalue *AssignedIds
 			for iNdEx < postIndex {
 				entryPreIndex := iNdEx
 				var wire uint64
@@ -16612,6 +18923,7 @@ func (m *MembershipState) Unmarshal(dAtA []byte) error {
 				}
 				fieldNum := int32(wire >> 3)
 				if fieldNum == 1 {
+					var stringLenmapkey uint64
 					for shift := uint(0); ; shift += 7 {
 						if shift >= 64 {
 							return ErrIntOverflowPb
@@ -16621,13 +18933,248 @@ func (m *MembershipState) Unmarshal(dAtA []byte) error {
 						}
 						b := dAtA[iNdEx]
 						iNdEx++
-						mapkey |= uint32(b&0x7F) << shift
+						stringLenmapkey |= uint64(b&0x7F) << shift
 						if b < 0x80 {
 							break
 						}
 					}
-				} else if fieldNum == 2 {
-					var mapmsglen int
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthPb
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthPb
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var mapmsglen int
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPb
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapmsglen |= int(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					if mapmsglen < 0 {
+						return ErrInvalidLengthPb
+					}
+					postmsgIndex := iNdEx + mapmsglen
+					if postmsgIndex < 0 {
+						return ErrInvalidLengthPb
+					}
+					if postmsgIndex > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = &AssignedIds{}
+					if err := mapvalue.Unmarshal(dAtA[iNdEx:postmsgIndex]); err != nil {
+						return err
+					}
+					iNdEx = postmsgIndex
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipPb(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if (skippy < 0) || (iNdEx+skippy) < 0 {
+						return ErrInvalidLengthPb
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.ReservedUids[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 15:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShrinkMaxUID", wireType)
+			}
+			m.ShrinkMaxUID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ShrinkMaxUID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 16:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxSeq", wireType)
+			}
+			m.MaxSeq = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxSeq |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *MembershipState) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MembershipState: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MembershipState: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Counter", wireType)
+			}
+			m.Counter = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Counter |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Groups", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Groups == nil {
+				m.Groups = make(map[uint32]*Group)
+			}
+			var mapkey uint32
+			var mapvalue *Group
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPb
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapkey |= uint32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+				} else if fieldNum == 2 {
+					var mapmsglen int
 					for shift := uint(0); ; shift += 7 {
 						if shift >= 64 {
 							return ErrIntOverflowPb
@@ -16967,17 +19514,165 @@ func (m *MembershipState) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipPb(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReservedUids", wireType)
 			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthPb
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
+			if msglen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ReservedUids == nil {
+				m.ReservedUids = make(map[string]*AssignedIds)
+			}
+			var mapkey string
+			var mapvalue *AssignedIds
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPb
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthPb
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthPb
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var mapmsglen int
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPb
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapmsglen |= int(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					if mapmsglen < 0 {
+						return ErrInvalidLengthPb
+					}
+					postmsgIndex := iNdEx + mapmsglen
+					if postmsgIndex < 0 {
+						return ErrInvalidLengthPb
+					}
+					if postmsgIndex > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = &AssignedIds{}
+					if err := mapvalue.Unmarshal(dAtA[iNdEx:postmsgIndex]); err != nil {
+						return err
+					}
+					iNdEx = postmsgIndex
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipPb(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if (skippy < 0) || (iNdEx+skippy) < 0 {
+						return ErrInvalidLengthPb
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.ReservedUids[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxSeq", wireType)
+			}
+			m.MaxSeq = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxSeq |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
 			}
 			iNdEx += skippy
 		}
@@ -17471,6 +20166,76 @@ func (m *HealthInfo) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartupPhase", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.StartupPhase = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 13:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartupApplied", wireType)
+			}
+			m.StartupApplied = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StartupApplied |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 14:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartupTotal", wireType)
+			}
+			m.StartupTotal = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StartupTotal |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPb(dAtA[iNdEx:])
@@ -18257,6 +21022,70 @@ func (m *Mutations) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Savepoint", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Savepoint = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RollbackTo", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RollbackTo = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPb(dAtA[iNdEx:])
@@ -18602,10 +21431,84 @@ func (m *Snapshot) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipPb(dAtA[iNdEx:])
-			if err != nil {
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Compress", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Compress = bool(v != 0)
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Resumable", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Resumable = bool(v != 0)
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResumeKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ResumeKey = append(m.ResumeKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.ResumeKey == nil {
+				m.ResumeKey = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPb(dAtA[iNdEx:])
+			if err != nil {
 				return err
 			}
 			if (skippy < 0) || (iNdEx+skippy) < 0 {
@@ -19957,6 +22860,46 @@ func (m *KVS) Unmarshal(dAtA []byte) error {
 				m.Data = []byte{}
 			}
 			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Compressed", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Compressed = bool(v != 0)
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ordered", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Ordered = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPb(dAtA[iNdEx:])
@@ -20214,6 +23157,63 @@ func (m *Posting) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 15:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValidFrom", wireType)
+			}
+			m.ValidFrom = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ValidFrom |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 16:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValidTo", wireType)
+			}
+			m.ValidTo = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ValidTo |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 17:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Seq", wireType)
+			}
+			m.Seq = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Seq |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPb(dAtA[iNdEx:])
@@ -21562,61 +24562,63 @@ func (m *SchemaNode) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.NoConflict = bool(v != 0)
-		default:
-			iNdEx = preIndex
-			skippy, err := skipPb(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Owned", wireType)
 			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthPb
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
+			m.Owned = bool(v != 0)
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FacetIndex", wireType)
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *SchemaResult) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowPb
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPb
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: SchemaResult: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SchemaResult: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.FacetIndex = append(m.FacetIndex, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 13:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Schema", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Facets", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -21626,79 +24628,27 @@ func (m *SchemaResult) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthPb
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthPb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Schema = append(m.Schema, &SchemaNode{})
-			if err := m.Schema[len(m.Schema)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Facets = append(m.Facets, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipPb(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthPb
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *SchemaUpdate) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowPb
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: SchemaUpdate: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SchemaUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 14:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Predicate", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field View", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -21726,49 +24676,11 @@ func (m *SchemaUpdate) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Predicate = string(dAtA[iNdEx:postIndex])
+			m.View = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ValueType", wireType)
-			}
-			m.ValueType = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.ValueType |= Posting_ValType(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Directive", wireType)
-			}
-			m.Directive = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Directive |= SchemaUpdate_Directive(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 4:
+		case 15:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Tokenizer", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Trigger", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -21796,11 +24708,11 @@ func (m *SchemaUpdate) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Tokenizer = append(m.Tokenizer, string(dAtA[iNdEx:postIndex]))
+			m.Trigger = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 5:
+		case 16:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Sequence", wireType)
 			}
 			var v int
 			for shift := uint(0); ; shift += 7 {
@@ -21817,12 +24729,12 @@ func (m *SchemaUpdate) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-			m.Count = bool(v != 0)
-		case 6:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field List", wireType)
+			m.Sequence = bool(v != 0)
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IndexState", wireType)
 			}
-			var v int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -21832,57 +24744,29 @@ func (m *SchemaUpdate) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.List = bool(v != 0)
-		case 8:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Upsert", wireType)
-			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPb
 			}
-			m.Upsert = bool(v != 0)
-		case 9:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Lang", wireType)
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
 			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
 			}
-			m.Lang = bool(v != 0)
-		case 10:
+			m.IndexState = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 18:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NonNullable", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field IndexProgress", wireType)
 			}
-			var v int
+			m.IndexProgress = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -21892,15 +24776,14 @@ func (m *SchemaUpdate) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				m.IndexProgress |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.NonNullable = bool(v != 0)
-		case 11:
+		case 19:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NonNullableList", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Ordered", wireType)
 			}
 			var v int
 			for shift := uint(0); ; shift += 7 {
@@ -21917,10 +24800,10 @@ func (m *SchemaUpdate) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-			m.NonNullableList = bool(v != 0)
-		case 12:
+			m.Ordered = bool(v != 0)
+		case 20:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ObjectTypeName", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Validators", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -21948,11 +24831,11 @@ func (m *SchemaUpdate) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ObjectTypeName = string(dAtA[iNdEx:postIndex])
+			m.Validators = append(m.Validators, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		case 13:
+		case 21:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NoConflict", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Presence", wireType)
 			}
 			var v int
 			for shift := uint(0); ; shift += 7 {
@@ -21969,7 +24852,7 @@ func (m *SchemaUpdate) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-			m.NoConflict = bool(v != 0)
+			m.Presence = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPb(dAtA[iNdEx:])
@@ -21991,7 +24874,7 @@ func (m *SchemaUpdate) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *TypeUpdate) Unmarshal(dAtA []byte) error {
+func (m *SchemaResult) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -22014,47 +24897,15 @@ func (m *TypeUpdate) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: TypeUpdate: wiretype end group for non-group")
+			return fmt.Errorf("proto: SchemaResult: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: TypeUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SchemaResult: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TypeName", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthPb
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthPb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.TypeName = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Fields", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Schema", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -22081,8 +24932,8 @@ func (m *TypeUpdate) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Fields = append(m.Fields, &SchemaUpdate{})
-			if err := m.Fields[len(m.Fields)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Schema = append(m.Schema, &SchemaNode{})
+			if err := m.Schema[len(m.Schema)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -22107,7 +24958,7 @@ func (m *TypeUpdate) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *MapHeader) Unmarshal(dAtA []byte) error {
+func (m *SchemaUpdate) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -22130,17 +24981,17 @@ func (m *MapHeader) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: MapHeader: wiretype end group for non-group")
+			return fmt.Errorf("proto: SchemaUpdate: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: MapHeader: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SchemaUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PartitionKeys", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Predicate", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -22150,77 +25001,65 @@ func (m *MapHeader) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthPb
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthPb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.PartitionKeys = append(m.PartitionKeys, make([]byte, postIndex-iNdEx))
-			copy(m.PartitionKeys[len(m.PartitionKeys)-1], dAtA[iNdEx:postIndex])
+			m.Predicate = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipPb(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthPb
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValueType", wireType)
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *MovePredicatePayload) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowPb
+			m.ValueType = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ValueType |= Posting_ValType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Directive", wireType)
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			m.Directive = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Directive |= SchemaUpdate_Directive(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: MovePredicatePayload: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: MovePredicatePayload: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Predicate", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Tokenizer", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -22248,13 +25087,13 @@ func (m *MovePredicatePayload) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Predicate = string(dAtA[iNdEx:postIndex])
+			m.Tokenizer = append(m.Tokenizer, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		case 2:
+		case 5:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SourceGid", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
 			}
-			m.SourceGid = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -22264,16 +25103,17 @@ func (m *MovePredicatePayload) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.SourceGid |= uint32(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 3:
+			m.Count = bool(v != 0)
+		case 6:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DestGid", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field List", wireType)
 			}
-			m.DestGid = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -22283,16 +25123,17 @@ func (m *MovePredicatePayload) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.DestGid |= uint32(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 4:
+			m.List = bool(v != 0)
+		case 8:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ReadTs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Upsert", wireType)
 			}
-			m.ReadTs = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -22302,16 +25143,17 @@ func (m *MovePredicatePayload) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ReadTs |= uint64(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 5:
+			m.Upsert = bool(v != 0)
+		case 9:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ExpectedChecksum", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Lang", wireType)
 			}
-			m.ExpectedChecksum = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -22321,16 +25163,17 @@ func (m *MovePredicatePayload) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ExpectedChecksum |= uint64(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 6:
+			m.Lang = bool(v != 0)
+		case 10:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SinceTs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NonNullable", wireType)
 			}
-			m.SinceTs = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -22340,66 +25183,17 @@ func (m *MovePredicatePayload) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.SinceTs |= uint64(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipPb(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthPb
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *TxnStatus) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowPb
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: TxnStatus: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: TxnStatus: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			m.NonNullable = bool(v != 0)
+		case 11:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StartTs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NonNullableList", wireType)
 			}
-			m.StartTs = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -22409,16 +25203,17 @@ func (m *TxnStatus) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.StartTs |= uint64(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CommitTs", wireType)
+			m.NonNullableList = bool(v != 0)
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ObjectTypeName", wireType)
 			}
-			m.CommitTs = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -22428,66 +25223,29 @@ func (m *TxnStatus) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.CommitTs |= uint64(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipPb(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthPb
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *OracleDelta) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowPb
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
 			}
-			if iNdEx >= l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: OracleDelta: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: OracleDelta: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Txns", wireType)
+			m.ObjectTypeName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 13:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NoConflict", wireType)
 			}
-			var msglen int
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -22497,31 +25255,1833 @@ func (m *OracleDelta) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthPb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthPb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+			m.NoConflict = bool(v != 0)
+		case 14:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Owned", wireType)
 			}
-			m.Txns = append(m.Txns, &TxnStatus{})
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Owned = bool(v != 0)
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FacetIndex", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.FacetIndex = append(m.FacetIndex, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Facets", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Facets = append(m.Facets, &api.Facet{})
+			if err := m.Facets[len(m.Facets)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field View", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.View = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 18:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Trigger", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Trigger = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 19:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sequence", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Sequence = bool(v != 0)
+		case 20:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Counter", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Counter = bool(v != 0)
+		case 21:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ordered", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Ordered = bool(v != 0)
+		case 22:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Validators", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Validators = append(m.Validators, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 23:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Presence", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Presence = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TypeUpdate) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TypeUpdate: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TypeUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TypeName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TypeName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Fields", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Fields = append(m.Fields, &SchemaUpdate{})
+			if err := m.Fields[len(m.Fields)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Trigger", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Trigger = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Extends", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Extends = append(m.Extends, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *MapHeader) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MapHeader: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MapHeader: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PartitionKeys", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PartitionKeys = append(m.PartitionKeys, make([]byte, postIndex-iNdEx))
+			copy(m.PartitionKeys[len(m.PartitionKeys)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *MovePredicatePayload) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MovePredicatePayload: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MovePredicatePayload: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Predicate", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Predicate = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SourceGid", wireType)
+			}
+			m.SourceGid = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SourceGid |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DestGid", wireType)
+			}
+			m.DestGid = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DestGid |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadTs", wireType)
+			}
+			m.ReadTs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ReadTs |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpectedChecksum", wireType)
+			}
+			m.ExpectedChecksum = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExpectedChecksum |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SinceTs", wireType)
+			}
+			m.SinceTs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SinceTs |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TxnStatus) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TxnStatus: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TxnStatus: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartTs", wireType)
+			}
+			m.StartTs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StartTs |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CommitTs", wireType)
+			}
+			m.CommitTs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CommitTs |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *OracleDelta) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: OracleDelta: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: OracleDelta: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Txns", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Txns = append(m.Txns, &TxnStatus{})
 			if err := m.Txns[len(m.Txns)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			iNdEx = postIndex
-		case 2:
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxAssigned", wireType)
+			}
+			m.MaxAssigned = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxAssigned |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupChecksums", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.GroupChecksums == nil {
+				m.GroupChecksums = make(map[uint32]uint64)
+			}
+			var mapkey uint32
+			var mapvalue uint64
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPb
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapkey |= uint32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+				} else if fieldNum == 2 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPb
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipPb(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if (skippy < 0) || (iNdEx+skippy) < 0 {
+						return ErrInvalidLengthPb
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.GroupChecksums[mapkey] = mapvalue
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TxnTimestamps) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TxnTimestamps: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TxnTimestamps: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Ts = append(m.Ts, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthPb
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthPb
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.Ts) == 0 {
+					m.Ts = make([]uint64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPb
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Ts = append(m.Ts, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ts", wireType)
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PeerResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PeerResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PeerResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Status = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RaftBatch) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RaftBatch: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RaftBatch: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Context", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Context == nil {
+				m.Context = &RaftContext{}
+			}
+			if err := m.Context.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Payload == nil {
+				m.Payload = &api.Payload{}
+			}
+			if err := m.Payload.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SubscriptionRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SubscriptionRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SubscriptionRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Prefixes", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Prefixes = append(m.Prefixes, make([]byte, postIndex-iNdEx))
+			copy(m.Prefixes[len(m.Prefixes)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Matches", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Matches = append(m.Matches, &pb.Match{})
+			if err := m.Matches[len(m.Matches)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SubscriptionResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SubscriptionResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SubscriptionResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Kvs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Kvs == nil {
+				m.Kvs = &pb.KVList{}
+			}
+			if err := m.Kvs.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Num) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Num: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Num: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Val", wireType)
+			}
+			m.Val = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Val |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadOnly", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ReadOnly = bool(v != 0)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Forwarded", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Forwarded = bool(v != 0)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= NumLeaseType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Bump", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Bump = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AssignedIds) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AssignedIds: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AssignedIds: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartId", wireType)
+			}
+			m.StartId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StartId |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EndId", wireType)
+			}
+			m.EndId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EndId |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadOnly", wireType)
+			}
+			m.ReadOnly = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ReadOnly |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RemoveNodeRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RemoveNodeRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RemoveNodeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MaxAssigned", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NodeId", wireType)
 			}
-			m.MaxAssigned = 0
+			m.NodeId = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -22531,16 +27091,16 @@ func (m *OracleDelta) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.MaxAssigned |= uint64(b&0x7F) << shift
+				m.NodeId |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GroupChecksums", wireType)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupId", wireType)
 			}
-			var msglen int
+			m.GroupId = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -22550,91 +27110,11 @@ func (m *OracleDelta) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.GroupId |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthPb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthPb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.GroupChecksums == nil {
-				m.GroupChecksums = make(map[uint32]uint64)
-			}
-			var mapkey uint32
-			var mapvalue uint64
-			for iNdEx < postIndex {
-				entryPreIndex := iNdEx
-				var wire uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowPb
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					wire |= uint64(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				fieldNum := int32(wire >> 3)
-				if fieldNum == 1 {
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowPb
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						mapkey |= uint32(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-				} else if fieldNum == 2 {
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowPb
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						mapvalue |= uint64(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-				} else {
-					iNdEx = entryPreIndex
-					skippy, err := skipPb(dAtA[iNdEx:])
-					if err != nil {
-						return err
-					}
-					if (skippy < 0) || (iNdEx+skippy) < 0 {
-						return ErrInvalidLengthPb
-					}
-					if (iNdEx + skippy) > postIndex {
-						return io.ErrUnexpectedEOF
-					}
-					iNdEx += skippy
-				}
-			}
-			m.GroupChecksums[mapkey] = mapvalue
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPb(dAtA[iNdEx:])
@@ -22656,7 +27136,7 @@ func (m *OracleDelta) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *TxnTimestamps) Unmarshal(dAtA []byte) error {
+func (m *MoveTabletRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -22679,87 +27159,81 @@ func (m *TxnTimestamps) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: TxnTimestamps: wiretype end group for non-group")
+			return fmt.Errorf("proto: MoveTabletRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: TxnTimestamps: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: MoveTabletRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType == 0 {
-				var v uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowPb
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					v |= uint64(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
+			}
+			m.Namespace = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
 				}
-				m.Ts = append(m.Ts, v)
-			} else if wireType == 2 {
-				var packedLen int
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowPb
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					packedLen |= int(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
 				}
-				if packedLen < 0 {
-					return ErrInvalidLengthPb
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Namespace |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
 				}
-				postIndex := iNdEx + packedLen
-				if postIndex < 0 {
-					return ErrInvalidLengthPb
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tablet", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
 				}
-				if postIndex > l {
+				if iNdEx >= l {
 					return io.ErrUnexpectedEOF
 				}
-				var elementCount int
-				var count int
-				for _, integer := range dAtA[iNdEx:postIndex] {
-					if integer < 128 {
-						count++
-					}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
 				}
-				elementCount = count
-				if elementCount != 0 && len(m.Ts) == 0 {
-					m.Ts = make([]uint64, 0, elementCount)
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Tablet = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DstGroup", wireType)
+			}
+			m.DstGroup = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
 				}
-				for iNdEx < postIndex {
-					var v uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowPb
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						v |= uint64(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					m.Ts = append(m.Ts, v)
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DstGroup |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
 				}
-			} else {
-				return fmt.Errorf("proto: wrong wireType = %d for field Ts", wireType)
 			}
 		default:
 			iNdEx = preIndex
@@ -22782,7 +27256,7 @@ func (m *TxnTimestamps) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PeerResponse) Unmarshal(dAtA []byte) error {
+func (m *ApplyLicenseRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -22805,17 +27279,17 @@ func (m *PeerResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PeerResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: ApplyLicenseRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PeerResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ApplyLicenseRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field License", wireType)
 			}
-			var v int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -22825,12 +27299,26 @@ func (m *PeerResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Status = bool(v != 0)
+			if byteLen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.License = append(m.License[:0], dAtA[iNdEx:postIndex]...)
+			if m.License == nil {
+				m.License = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPb(dAtA[iNdEx:])
@@ -22852,7 +27340,7 @@ func (m *PeerResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *RaftBatch) Unmarshal(dAtA []byte) error {
+func (m *SnapshotMeta) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -22875,17 +27363,17 @@ func (m *RaftBatch) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: RaftBatch: wiretype end group for non-group")
+			return fmt.Errorf("proto: SnapshotMeta: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RaftBatch: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SnapshotMeta: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Context", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientTs", wireType)
 			}
-			var msglen int
+			m.ClientTs = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -22895,33 +27383,104 @@ func (m *RaftBatch) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.ClientTs |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthPb
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupId", wireType)
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			m.GroupId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GroupId |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthPb
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Context == nil {
-				m.Context = &RaftContext{}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Status) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPb
 			}
-			if err := m.Context.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Status: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Status: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+			}
+			m.Code = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Code |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Msg", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -22931,27 +27490,23 @@ func (m *RaftBatch) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthPb
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthPb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Payload == nil {
-				m.Payload = &api.Payload{}
-			}
-			if err := m.Payload.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Msg = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -22974,7 +27529,7 @@ func (m *RaftBatch) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SubscriptionRequest) Unmarshal(dAtA []byte) error {
+func (m *BackupRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -22997,17 +27552,106 @@ func (m *SubscriptionRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SubscriptionRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: BackupRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SubscriptionRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: BackupRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadTs", wireType)
+			}
+			m.ReadTs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ReadTs |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SinceTs", wireType)
+			}
+			m.SinceTs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SinceTs |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupId", wireType)
+			}
+			m.GroupId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GroupId |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UnixTs", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.UnixTs = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Prefixes", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Destination", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -23017,29 +27661,29 @@ func (m *SubscriptionRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthPb
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthPb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Prefixes = append(m.Prefixes, make([]byte, postIndex-iNdEx))
-			copy(m.Prefixes[len(m.Prefixes)-1], dAtA[iNdEx:postIndex])
+			m.Destination = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Matches", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AccessKey", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -23049,81 +27693,29 @@ func (m *SubscriptionRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthPb
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthPb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Matches = append(m.Matches, &pb.Match{})
-			if err := m.Matches[len(m.Matches)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.AccessKey = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipPb(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthPb
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *SubscriptionResponse) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowPb
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: SubscriptionResponse: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SubscriptionResponse: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Kvs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SecretKey", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -23133,83 +27725,29 @@ func (m *SubscriptionResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthPb
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthPb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Kvs == nil {
-				m.Kvs = &pb.KVList{}
-			}
-			if err := m.Kvs.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.SecretKey = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipPb(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthPb
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *Num) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowPb
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: Num: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Num: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Val", wireType)
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SessionToken", wireType)
 			}
-			m.Val = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -23219,34 +27757,27 @@ func (m *Num) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Val |= uint64(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ReadOnly", wireType)
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPb
 			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
 			}
-			m.ReadOnly = bool(v != 0)
-		case 3:
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SessionToken = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 9:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Forwarded", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Anonymous", wireType)
 			}
 			var v int
 			for shift := uint(0); ; shift += 7 {
@@ -23263,12 +27794,12 @@ func (m *Num) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-			m.Forwarded = bool(v != 0)
-		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			m.Anonymous = bool(v != 0)
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Predicates", wireType)
 			}
-			m.Type = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -23278,14 +27809,27 @@ func (m *Num) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Type |= NumLeaseType(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 5:
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Predicates = append(m.Predicates, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 11:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Bump", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ForceFull", wireType)
 			}
 			var v int
 			for shift := uint(0); ; shift += 7 {
@@ -23302,7 +27846,7 @@ func (m *Num) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-			m.Bump = bool(v != 0)
+			m.ForceFull = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPb(dAtA[iNdEx:])
@@ -23324,7 +27868,7 @@ func (m *Num) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AssignedIds) Unmarshal(dAtA []byte) error {
+func (m *BackupResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -23347,36 +27891,17 @@ func (m *AssignedIds) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AssignedIds: wiretype end group for non-group")
+			return fmt.Errorf("proto: BackupResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AssignedIds: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: BackupResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StartId", wireType)
-			}
-			m.StartId = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.StartId |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EndId", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DropOperations", wireType)
 			}
-			m.EndId = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -23386,30 +27911,26 @@ func (m *AssignedIds) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.EndId |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 5:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ReadOnly", wireType)
+			if msglen < 0 {
+				return ErrInvalidLengthPb
 			}
-			m.ReadOnly = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.ReadOnly |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DropOperations = append(m.DropOperations, &DropOperation{})
+			if err := m.DropOperations[len(m.DropOperations)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPb(dAtA[iNdEx:])
@@ -23431,7 +27952,7 @@ func (m *AssignedIds) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *RemoveNodeRequest) Unmarshal(dAtA []byte) error {
+func (m *DropOperation) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -23454,17 +27975,17 @@ func (m *RemoveNodeRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: RemoveNodeRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: DropOperation: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RemoveNodeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DropOperation: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NodeId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DropOp", wireType)
 			}
-			m.NodeId = 0
+			m.DropOp = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -23474,16 +27995,16 @@ func (m *RemoveNodeRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.NodeId |= uint64(b&0x7F) << shift
+				m.DropOp |= DropOperation_DropOp(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GroupId", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DropValue", wireType)
 			}
-			m.GroupId = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -23493,11 +28014,24 @@ func (m *RemoveNodeRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.GroupId |= uint32(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DropValue = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPb(dAtA[iNdEx:])
@@ -23519,7 +28053,7 @@ func (m *RemoveNodeRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *MoveTabletRequest) Unmarshal(dAtA []byte) error {
+func (m *ExportRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -23542,17 +28076,17 @@ func (m *MoveTabletRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: MoveTabletRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ExportRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: MoveTabletRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ExportRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupId", wireType)
 			}
-			m.Namespace = 0
+			m.GroupId = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -23562,14 +28096,52 @@ func (m *MoveTabletRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Namespace |= uint64(b&0x7F) << shift
+				m.GroupId |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadTs", wireType)
+			}
+			m.ReadTs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ReadTs |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UnixTs", wireType)
+			}
+			m.UnixTs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.UnixTs |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Tablet", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Format", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -23597,13 +28169,13 @@ func (m *MoveTabletRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Tablet = string(dAtA[iNdEx:postIndex])
+			m.Format = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DstGroup", wireType)
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Destination", wireType)
 			}
-			m.DstGroup = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -23613,66 +28185,29 @@ func (m *MoveTabletRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.DstGroup |= uint32(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipPb(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthPb
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ApplyLicenseRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowPb
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
 			}
-			if iNdEx >= l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ApplyLicenseRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ApplyLicenseRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			m.Destination = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field License", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AccessKey", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -23682,81 +28217,29 @@ func (m *ApplyLicenseRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthPb
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthPb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.License = append(m.License[:0], dAtA[iNdEx:postIndex]...)
-			if m.License == nil {
-				m.License = []byte{}
-			}
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipPb(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthPb
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *SnapshotMeta) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowPb
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: SnapshotMeta: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SnapshotMeta: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ClientTs", wireType)
+			m.AccessKey = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SecretKey", wireType)
 			}
-			m.ClientTs = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -23766,16 +28249,29 @@ func (m *SnapshotMeta) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ClientTs |= uint64(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GroupId", wireType)
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPb
 			}
-			m.GroupId = 0
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SecretKey = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SessionToken", wireType)
+			}
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -23785,66 +28281,49 @@ func (m *SnapshotMeta) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.GroupId |= uint32(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipPb(dAtA[iNdEx:])
-			if err != nil {
-				return err
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPb
 			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
 				return ErrInvalidLengthPb
 			}
-			if (iNdEx + skippy) > l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *Status) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowPb
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			m.SessionToken = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Anonymous", wireType)
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: Status: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Status: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			m.Anonymous = bool(v != 0)
+		case 10:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
 			}
-			m.Code = 0
+			m.Namespace = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -23854,14 +28333,14 @@ func (m *Status) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Code |= int32(b&0x7F) << shift
+				m.Namespace |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
+		case 11:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Msg", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Recipient", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -23889,7 +28368,7 @@ func (m *Status) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Msg = string(dAtA[iNdEx:postIndex])
+			m.Recipient = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -23912,7 +28391,7 @@ func (m *Status) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *BackupRequest) Unmarshal(dAtA []byte) error {
+func (m *ExportResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -23935,17 +28414,17 @@ func (m *BackupRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: BackupRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ExportResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: BackupRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ExportResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ReadTs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
 			}
-			m.ReadTs = 0
+			m.Code = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -23955,52 +28434,14 @@ func (m *BackupRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ReadTs |= uint64(b&0x7F) << shift
+				m.Code |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SinceTs", wireType)
-			}
-			m.SinceTs = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.SinceTs |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GroupId", wireType)
-			}
-			m.GroupId = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.GroupId |= uint32(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UnixTs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Msg", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -24028,11 +28469,11 @@ func (m *BackupRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.UnixTs = string(dAtA[iNdEx:postIndex])
+			m.Msg = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 5:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Destination", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Files", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -24060,13 +28501,63 @@ func (m *BackupRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Destination = string(dAtA[iNdEx:postIndex])
+			m.Files = append(m.Files, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		case 6:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AccessKey", wireType)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPb(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			var stringLen uint64
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BackupKey) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BackupKey: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BackupKey: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -24076,27 +28567,14 @@ func (m *BackupRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.Type |= BackupKey_KeyType(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthPb
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthPb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.AccessKey = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 7:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SecretKey", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Attr", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -24124,13 +28602,13 @@ func (m *BackupRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.SecretKey = string(dAtA[iNdEx:postIndex])
+			m.Attr = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 8:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SessionToken", wireType)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Uid", wireType)
 			}
-			var stringLen uint64
+			m.Uid = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -24140,29 +28618,16 @@ func (m *BackupRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.Uid |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthPb
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthPb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.SessionToken = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 9:
+		case 4:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Anonymous", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field StartUid", wireType)
 			}
-			var v int
+			m.StartUid = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -24172,15 +28637,14 @@ func (m *BackupRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				m.StartUid |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Anonymous = bool(v != 0)
-		case 10:
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Predicates", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Term", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -24208,13 +28672,13 @@ func (m *BackupRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Predicates = append(m.Predicates, string(dAtA[iNdEx:postIndex]))
+			m.Term = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 11:
+		case 6:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ForceFull", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
 			}
-			var v int
+			m.Count = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -24224,12 +28688,30 @@ func (m *BackupRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				m.Count |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
+			}
+			m.Namespace = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Namespace |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.ForceFull = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPb(dAtA[iNdEx:])
@@ -24251,7 +28733,7 @@ func (m *BackupRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *BackupResponse) Unmarshal(dAtA []byte) error {
+func (m *BackupPostingList) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -24274,15 +28756,91 @@ func (m *BackupResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: BackupResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: BackupPostingList: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: BackupResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: BackupPostingList: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Uids = append(m.Uids, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthPb
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthPb
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.Uids) == 0 {
+					m.Uids = make([]uint64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPb
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Uids = append(m.Uids, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Uids", wireType)
+			}
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DropOperations", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Postings", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -24309,85 +28867,111 @@ func (m *BackupResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.DropOperations = append(m.DropOperations, &DropOperation{})
-			if err := m.DropOperations[len(m.DropOperations)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Postings = append(m.Postings, &Posting{})
+			if err := m.Postings[len(m.Postings)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipPb(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthPb
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *DropOperation) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowPb
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: DropOperation: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DropOperation: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DropOp", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field CommitTs", wireType)
 			}
-			m.DropOp = 0
+			m.CommitTs = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
 				}
-				if iNdEx >= l {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CommitTs |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Splits = append(m.Splits, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthPb
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthPb
+				}
+				if postIndex > l {
 					return io.ErrUnexpectedEOF
 				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.DropOp |= DropOperation_DropOp(b&0x7F) << shift
-				if b < 0x80 {
-					break
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.Splits) == 0 {
+					m.Splits = make([]uint64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPb
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Splits = append(m.Splits, v)
 				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Splits", wireType)
 			}
-		case 2:
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DropValue", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field UidBytes", wireType)
 			}
-			var stringLen uint64
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -24397,23 +28981,25 @@ func (m *DropOperation) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthPb
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthPb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.DropValue = string(dAtA[iNdEx:postIndex])
+			m.UidBytes = append(m.UidBytes[:0], dAtA[iNdEx:postIndex]...)
+			if m.UidBytes == nil {
+				m.UidBytes = []byte{}
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -24436,7 +29022,7 @@ func (m *DropOperation) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ExportRequest) Unmarshal(dAtA []byte) error {
+func (m *UpdateGraphQLSchemaRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -24459,17 +29045,17 @@ func (m *ExportRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ExportRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: UpdateGraphQLSchemaRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ExportRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: UpdateGraphQLSchemaRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GroupId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field StartTs", wireType)
 			}
-			m.GroupId = 0
+			m.StartTs = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -24479,52 +29065,14 @@ func (m *ExportRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.GroupId |= uint32(b&0x7F) << shift
+				m.StartTs |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ReadTs", wireType)
-			}
-			m.ReadTs = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.ReadTs |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UnixTs", wireType)
-			}
-			m.UnixTs = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.UnixTs |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Format", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field GraphqlSchema", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -24552,13 +29100,13 @@ func (m *ExportRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Format = string(dAtA[iNdEx:postIndex])
+			m.GraphqlSchema = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 5:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Destination", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DgraphPreds", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -24568,61 +29116,31 @@ func (m *ExportRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthPb
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthPb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Destination = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 6:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AccessKey", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthPb
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthPb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+			m.DgraphPreds = append(m.DgraphPreds, &SchemaUpdate{})
+			if err := m.DgraphPreds[len(m.DgraphPreds)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-			m.AccessKey = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 7:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SecretKey", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DgraphTypes", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -24632,27 +29150,29 @@ func (m *ExportRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthPb
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthPb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.SecretKey = string(dAtA[iNdEx:postIndex])
+			m.DgraphTypes = append(m.DgraphTypes, &TypeUpdate{})
+			if err := m.DgraphTypes[len(m.DgraphTypes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 8:
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SessionToken", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LambdaScript", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -24680,13 +29200,13 @@ func (m *ExportRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.SessionToken = string(dAtA[iNdEx:postIndex])
+			m.LambdaScript = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 9:
+		case 6:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Anonymous", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Op", wireType)
 			}
-			var v int
+			m.Op = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -24696,17 +29216,16 @@ func (m *ExportRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				m.Op |= UpdateGraphQLSchemaRequest_Op(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Anonymous = bool(v != 0)
-		case 10:
+		case 7:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpectedVersion", wireType)
 			}
-			m.Namespace = 0
+			m.ExpectedVersion = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -24716,7 +29235,7 @@ func (m *ExportRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Namespace |= uint64(b&0x7F) << shift
+				m.ExpectedVersion |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -24742,7 +29261,7 @@ func (m *ExportRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ExportResponse) Unmarshal(dAtA []byte) error {
+func (m *UpdateGraphQLSchemaResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -24765,68 +29284,17 @@ func (m *ExportResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ExportResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: UpdateGraphQLSchemaResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ExportResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: UpdateGraphQLSchemaResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
-		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
-			}
-			m.Code = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Code |= int32(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Msg", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthPb
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthPb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Msg = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Files", wireType)
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Uid", wireType)
 			}
-			var stringLen uint64
+			m.Uid = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -24836,24 +29304,11 @@ func (m *ExportResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.Uid |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthPb
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthPb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Files = append(m.Files, string(dAtA[iNdEx:postIndex]))
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPb(dAtA[iNdEx:])
@@ -24875,7 +29330,7 @@ func (m *ExportResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *BackupKey) Unmarshal(dAtA []byte) error {
+func (m *BulkMeta) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -24898,17 +29353,17 @@ func (m *BackupKey) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: BackupKey: wiretype end group for non-group")
+			return fmt.Errorf("proto: BulkMeta: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: BackupKey: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: BulkMeta: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field EdgeCount", wireType)
 			}
-			m.Type = 0
+			m.EdgeCount = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -24918,16 +29373,16 @@ func (m *BackupKey) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Type |= BackupKey_KeyType(b&0x7F) << shift
+				m.EdgeCount |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Attr", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SchemaMap", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -24937,67 +29392,126 @@ func (m *BackupKey) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthPb
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthPb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Attr = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Uid", wireType)
-			}
-			m.Uid = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Uid |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StartUid", wireType)
+			if m.SchemaMap == nil {
+				m.SchemaMap = make(map[string]*SchemaUpdate)
 			}
-			m.StartUid = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
+			var mapkey string
+			var mapvalue *SchemaUpdate
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
 				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.StartUid |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPb
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthPb
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthPb
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var mapmsglen int
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPb
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapmsglen |= int(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					if mapmsglen < 0 {
+						return ErrInvalidLengthPb
+					}
+					postmsgIndex := iNdEx + mapmsglen
+					if postmsgIndex < 0 {
+						return ErrInvalidLengthPb
+					}
+					if postmsgIndex > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = &SchemaUpdate{}
+					if err := mapvalue.Unmarshal(dAtA[iNdEx:postmsgIndex]); err != nil {
+						return err
+					}
+					iNdEx = postmsgIndex
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipPb(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if (skippy < 0) || (iNdEx+skippy) < 0 {
+						return ErrInvalidLengthPb
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
 				}
 			}
-		case 5:
+			m.SchemaMap[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Term", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Types", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -25007,29 +29521,81 @@ func (m *BackupKey) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthPb
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthPb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Term = string(dAtA[iNdEx:postIndex])
+			m.Types = append(m.Types, &TypeUpdate{})
+			if err := m.Types[len(m.Types)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 6:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DeleteNsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DeleteNsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DeleteNsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupId", wireType)
 			}
-			m.Count = 0
+			m.GroupId = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -25039,12 +29605,12 @@ func (m *BackupKey) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Count |= uint32(b&0x7F) << shift
+				m.GroupId |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 7:
+		case 2:
 			if wireType != 0 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
 			}
@@ -25084,7 +29650,7 @@ func (m *BackupKey) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *BackupPostingList) Unmarshal(dAtA []byte) error {
+func (m *TaskStatusRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -25107,127 +29673,17 @@ func (m *BackupPostingList) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: BackupPostingList: wiretype end group for non-group")
+			return fmt.Errorf("proto: TaskStatusRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: BackupPostingList: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TaskStatusRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType == 0 {
-				var v uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowPb
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					v |= uint64(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				m.Uids = append(m.Uids, v)
-			} else if wireType == 2 {
-				var packedLen int
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowPb
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					packedLen |= int(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				if packedLen < 0 {
-					return ErrInvalidLengthPb
-				}
-				postIndex := iNdEx + packedLen
-				if postIndex < 0 {
-					return ErrInvalidLengthPb
-				}
-				if postIndex > l {
-					return io.ErrUnexpectedEOF
-				}
-				var elementCount int
-				var count int
-				for _, integer := range dAtA[iNdEx:postIndex] {
-					if integer < 128 {
-						count++
-					}
-				}
-				elementCount = count
-				if elementCount != 0 && len(m.Uids) == 0 {
-					m.Uids = make([]uint64, 0, elementCount)
-				}
-				for iNdEx < postIndex {
-					var v uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowPb
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						v |= uint64(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					m.Uids = append(m.Uids, v)
-				}
-			} else {
-				return fmt.Errorf("proto: wrong wireType = %d for field Uids", wireType)
-			}
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Postings", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthPb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthPb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Postings = append(m.Postings, &Posting{})
-			if err := m.Postings[len(m.Postings)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CommitTs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field TaskId", wireType)
 			}
-			m.CommitTs = 0
+			m.TaskId = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -25237,92 +29693,16 @@ func (m *BackupPostingList) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.CommitTs |= uint64(b&0x7F) << shift
+				m.TaskId |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 4:
-			if wireType == 0 {
-				var v uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowPb
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					v |= uint64(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				m.Splits = append(m.Splits, v)
-			} else if wireType == 2 {
-				var packedLen int
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowPb
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					packedLen |= int(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				if packedLen < 0 {
-					return ErrInvalidLengthPb
-				}
-				postIndex := iNdEx + packedLen
-				if postIndex < 0 {
-					return ErrInvalidLengthPb
-				}
-				if postIndex > l {
-					return io.ErrUnexpectedEOF
-				}
-				var elementCount int
-				var count int
-				for _, integer := range dAtA[iNdEx:postIndex] {
-					if integer < 128 {
-						count++
-					}
-				}
-				elementCount = count
-				if elementCount != 0 && len(m.Splits) == 0 {
-					m.Splits = make([]uint64, 0, elementCount)
-				}
-				for iNdEx < postIndex {
-					var v uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowPb
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						v |= uint64(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					m.Splits = append(m.Splits, v)
-				}
-			} else {
-				return fmt.Errorf("proto: wrong wireType = %d for field Splits", wireType)
-			}
-		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UidBytes", wireType)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Cancel", wireType)
 			}
-			var byteLen int
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -25332,26 +29712,12 @@ func (m *BackupPostingList) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthPb
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthPb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.UidBytes = append(m.UidBytes[:0], dAtA[iNdEx:postIndex]...)
-			if m.UidBytes == nil {
-				m.UidBytes = []byte{}
-			}
-			iNdEx = postIndex
+			m.Cancel = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPb(dAtA[iNdEx:])
@@ -25373,7 +29739,7 @@ func (m *BackupPostingList) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *UpdateGraphQLSchemaRequest) Unmarshal(dAtA []byte) error {
+func (m *TaskStatusResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -25396,17 +29762,17 @@ func (m *UpdateGraphQLSchemaRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: UpdateGraphQLSchemaRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: TaskStatusResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: UpdateGraphQLSchemaRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TaskStatusResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StartTs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field TaskMeta", wireType)
 			}
-			m.StartTs = 0
+			m.TaskMeta = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -25416,48 +29782,35 @@ func (m *UpdateGraphQLSchemaRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.StartTs |= uint64(b&0x7F) << shift
+				m.TaskMeta |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GraphqlSchema", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TaskId", wireType)
 			}
-			var stringLen uint64
+			m.TaskId = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
 				}
 				if iNdEx >= l {
 					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthPb
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthPb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TaskId |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			m.GraphqlSchema = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DgraphPreds", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Progress", wireType)
 			}
-			var msglen int
+			m.Progress = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -25467,31 +29820,16 @@ func (m *UpdateGraphQLSchemaRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Progress |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthPb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthPb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.DgraphPreds = append(m.DgraphPreds, &SchemaUpdate{})
-			if err := m.DgraphPreds[len(m.DgraphPreds)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DgraphTypes", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Total", wireType)
 			}
-			var msglen int
+			m.Total = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -25501,29 +29839,14 @@ func (m *UpdateGraphQLSchemaRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Total |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthPb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthPb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.DgraphTypes = append(m.DgraphTypes, &TypeUpdate{})
-			if err := m.DgraphTypes[len(m.DgraphTypes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LambdaScript", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Logs", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -25551,13 +29874,13 @@ func (m *UpdateGraphQLSchemaRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.LambdaScript = string(dAtA[iNdEx:postIndex])
+			m.Logs = append(m.Logs, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
 		case 6:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Op", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
 			}
-			m.Op = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -25567,11 +29890,24 @@ func (m *UpdateGraphQLSchemaRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Op |= UpdateGraphQLSchemaRequest_Op(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Error = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPb(dAtA[iNdEx:])
@@ -25593,7 +29929,7 @@ func (m *UpdateGraphQLSchemaRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *UpdateGraphQLSchemaResponse) Unmarshal(dAtA []byte) error {
+func (m *BatchMutationRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -25616,17 +29952,51 @@ func (m *UpdateGraphQLSchemaResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: UpdateGraphQLSchemaResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: BatchMutationRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: UpdateGraphQLSchemaResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: BatchMutationRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Requests", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Requests = append(m.Requests, &api.Request{})
+			if err := m.Requests[len(m.Requests)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Uid", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Concurrency", wireType)
 			}
-			m.Uid = 0
+			m.Concurrency = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -25636,7 +30006,7 @@ func (m *UpdateGraphQLSchemaResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Uid |= uint64(b&0x7F) << shift
+				m.Concurrency |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -25662,7 +30032,7 @@ func (m *UpdateGraphQLSchemaResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *BulkMeta) Unmarshal(dAtA []byte) error {
+func (m *BatchMutationResult) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -25685,163 +30055,35 @@ func (m *BulkMeta) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: BulkMeta: wiretype end group for non-group")
+			return fmt.Errorf("proto: BatchMutationResult: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: BulkMeta: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EdgeCount", wireType)
-			}
-			m.EdgeCount = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.EdgeCount |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SchemaMap", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthPb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthPb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.SchemaMap == nil {
-				m.SchemaMap = make(map[string]*SchemaUpdate)
-			}
-			var mapkey string
-			var mapvalue *SchemaUpdate
-			for iNdEx < postIndex {
-				entryPreIndex := iNdEx
-				var wire uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowPb
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					wire |= uint64(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				fieldNum := int32(wire >> 3)
-				if fieldNum == 1 {
-					var stringLenmapkey uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowPb
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						stringLenmapkey |= uint64(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					intStringLenmapkey := int(stringLenmapkey)
-					if intStringLenmapkey < 0 {
-						return ErrInvalidLengthPb
-					}
-					postStringIndexmapkey := iNdEx + intStringLenmapkey
-					if postStringIndexmapkey < 0 {
-						return ErrInvalidLengthPb
-					}
-					if postStringIndexmapkey > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
-					iNdEx = postStringIndexmapkey
-				} else if fieldNum == 2 {
-					var mapmsglen int
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowPb
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						mapmsglen |= int(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					if mapmsglen < 0 {
-						return ErrInvalidLengthPb
-					}
-					postmsgIndex := iNdEx + mapmsglen
-					if postmsgIndex < 0 {
-						return ErrInvalidLengthPb
-					}
-					if postmsgIndex > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapvalue = &SchemaUpdate{}
-					if err := mapvalue.Unmarshal(dAtA[iNdEx:postmsgIndex]); err != nil {
-						return err
-					}
-					iNdEx = postmsgIndex
-				} else {
-					iNdEx = entryPreIndex
-					skippy, err := skipPb(dAtA[iNdEx:])
-					if err != nil {
-						return err
-					}
-					if (skippy < 0) || (iNdEx+skippy) < 0 {
-						return ErrInvalidLengthPb
-					}
-					if (iNdEx + skippy) > postIndex {
-						return io.ErrUnexpectedEOF
-					}
-					iNdEx += skippy
+			return fmt.Errorf("proto: BatchMutationResult: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Success", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
 				}
 			}
-			m.SchemaMap[mapkey] = mapvalue
-			iNdEx = postIndex
-		case 3:
+			m.Success = bool(v != 0)
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Types", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Response", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -25868,11 +30110,45 @@ func (m *BulkMeta) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Types = append(m.Types, &TypeUpdate{})
-			if err := m.Types[len(m.Types)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Response == nil {
+				m.Response = &api.Response{}
+			}
+			if err := m.Response.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Error = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPb(dAtA[iNdEx:])
@@ -25894,7 +30170,7 @@ func (m *BulkMeta) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *DeleteNsRequest) Unmarshal(dAtA []byte) error {
+func (m *BatchMutationResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -25917,17 +30193,17 @@ func (m *DeleteNsRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DeleteNsRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: BatchMutationResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DeleteNsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: BatchMutationResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GroupId", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Results", wireType)
 			}
-			m.GroupId = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -25937,30 +30213,26 @@ func (m *DeleteNsRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.GroupId |= uint32(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
+			if msglen < 0 {
+				return ErrInvalidLengthPb
 			}
-			m.Namespace = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Namespace |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
 			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Results = append(m.Results, &BatchMutationResult{})
+			if err := m.Results[len(m.Results)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPb(dAtA[iNdEx:])
@@ -25982,7 +30254,7 @@ func (m *DeleteNsRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *TaskStatusRequest) Unmarshal(dAtA []byte) error {
+func (m *StreamExportRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -26005,17 +30277,17 @@ func (m *TaskStatusRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: TaskStatusRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: StreamExportRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: TaskStatusRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: StreamExportRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TaskId", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Export", wireType)
 			}
-			m.TaskId = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -26025,11 +30297,64 @@ func (m *TaskStatusRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.TaskId |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Export == nil {
+				m.Export = &ExportRequest{}
+			}
+			if err := m.Export.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Backup", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Backup == nil {
+				m.Backup = &BackupRequest{}
+			}
+			if err := m.Backup.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPb(dAtA[iNdEx:])
@@ -26051,7 +30376,7 @@ func (m *TaskStatusRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *TaskStatusResponse) Unmarshal(dAtA []byte) error {
+func (m *ExportChunk) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -26074,17 +30399,83 @@ func (m *TaskStatusResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: TaskStatusResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: ExportChunk: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: TaskStatusResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ExportChunk: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Path = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TaskMeta", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Last", wireType)
 			}
-			m.TaskMeta = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -26094,11 +30485,46 @@ func (m *TaskStatusResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.TaskMeta |= uint64(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Last = bool(v != 0)
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DropOperations", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DropOperations = append(m.DropOperations, &DropOperation{})
+			if err := m.DropOperations[len(m.DropOperations)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPb(dAtA[iNdEx:])
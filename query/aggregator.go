@@ -19,11 +19,14 @@ package query
 import (
 	"bytes"
 	"math"
+	"math/big"
 	"time"
 
+	"github.com/dgraph-io/dgraph/algo"
 	"github.com/dgraph-io/dgraph/protos/pb"
 	"github.com/dgraph-io/dgraph/types"
 	"github.com/dgraph-io/dgraph/x"
+	farm "github.com/dgryski/go-farm"
 	"github.com/pkg/errors"
 )
 
@@ -31,11 +34,15 @@ type aggregator struct {
 	name   string
 	result types.Val
 	count  int // used when we need avergae.
+	// distinct is the sketch of the values of countdistinct, which estimates their number
+	// without keeping them.
+	distinct *algo.HLL
 }
 
 func isUnary(f string) bool {
 	return f == "ln" || f == "exp" || f == "u-" || f == "sqrt" ||
-		f == "floor" || f == "ceil" || f == "since"
+		f == "floor" || f == "ceil" || f == "since" || f == "toint" ||
+		f == "tofloat" || f == "todatetime" || f == "tostring"
 }
 
 func isBinaryBoolean(f string) bool {
@@ -332,6 +339,14 @@ func applyExp(a, res *types.Val) error {
 }
 
 func applyNeg(a, res *types.Val) error {
+	if a.Tid == types.DurationID {
+		if a.Value.(time.Duration) == math.MinInt64 {
+			return ErrorIntOverflow
+		}
+		res.Value = -a.Value.(time.Duration)
+		return nil
+	}
+
 	vBase := getValType(a)
 	switch vBase {
 	case INT:
@@ -412,6 +427,112 @@ func applySince(a, res *types.Val) error {
 	return errors.Errorf("Wrong type %v encountered for func since", a.Tid)
 }
 
+// coerceTo returns the function converting a value to the given type, as types.Coerce does.
+func coerceTo(tid types.TypeID) unaryFunc {
+	return func(a, res *types.Val) error {
+		b := types.ValueForType(types.BinaryID)
+		if err := types.Marshal(*a, &b); err != nil {
+			return err
+		}
+		v, err := types.Coerce(types.Val{Tid: a.Tid, Value: b.Value}, tid)
+		if err != nil {
+			return err
+		}
+		*res = v
+		return nil
+	}
+}
+
+// applyDuration applies the binary function on the durations, or on the datetimes and the
+// durations: a duration can be added to or subtracted from a datetime, and multiplied or divided
+// by a number, and subtracting datetimes gives a duration. It returns false if neither argument
+// is a duration, unless both are datetimes being subtracted.
+func applyDuration(fn string, a, b types.Val) (types.Val, bool, error) {
+	res := types.Val{Tid: types.DurationID}
+	aDur, bDur := a.Tid == types.DurationID, b.Tid == types.DurationID
+	switch {
+	case a.Tid == types.DateTimeID && b.Tid == types.DateTimeID && fn == "-":
+		// The difference saturates at the largest duration, of about 292 years.
+		res.Value = a.Value.(time.Time).Sub(b.Value.(time.Time))
+		return res, true, nil
+	case !aDur && !bDur, aDur && bDur && (fn == "min" || fn == "max"):
+		return res, false, nil
+	}
+
+	var err error
+	switch {
+	case aDur && bDur:
+		da, db := a.Value.(time.Duration), b.Value.(time.Duration)
+		switch fn {
+		case "+":
+			res.Value, err = addDurations(da, db)
+		case "-":
+			if db == math.MinInt64 {
+				return res, true, ErrorIntOverflow
+			}
+			res.Value, err = addDurations(da, -db)
+		case "/":
+			if db == 0 {
+				return res, true, ErrorDivisionByZero
+			}
+			res = types.Val{Tid: types.FloatID, Value: float64(da) / float64(db)}
+		default:
+			return res, true, wrongDurationTypes(fn, a, b)
+		}
+	case a.Tid == types.DateTimeID && bDur && (fn == "+" || fn == "-"):
+		d := b.Value.(time.Duration)
+		if fn == "-" {
+			d = -d
+		}
+		res = types.Val{Tid: types.DateTimeID, Value: a.Value.(time.Time).Add(d)}
+	case aDur && b.Tid == types.DateTimeID && fn == "+":
+		t := b.Value.(time.Time).Add(a.Value.(time.Duration))
+		res = types.Val{Tid: types.DateTimeID, Value: t}
+	case aDur && b.Tid.IsNumber() && (fn == "*" || fn == "/"):
+		res.Value, err = scaleDuration(a.Value.(time.Duration), b, fn == "/")
+	case a.Tid.IsNumber() && bDur && fn == "*":
+		res.Value, err = scaleDuration(b.Value.(time.Duration), a, false)
+	default:
+		return res, true, wrongDurationTypes(fn, a, b)
+	}
+	return res, true, err
+}
+
+func addDurations(x, y time.Duration) (time.Duration, error) {
+	if (x > 0 && y > math.MaxInt64-x) || (x < 0 && y < math.MinInt64-x) {
+		return 0, ErrorIntOverflow
+	}
+	return x + y, nil
+}
+
+// scaleDuration multiplies, or divides, the duration by the int or float n.
+func scaleDuration(d time.Duration, n types.Val, div bool) (time.Duration, error) {
+	f := float64(d)
+	var by float64
+	if n.Tid == types.IntID {
+		by = float64(n.Value.(int64))
+	} else {
+		by = n.Value.(float64)
+	}
+	if div {
+		if by == 0 {
+			return 0, ErrorDivisionByZero
+		}
+		f /= by
+	} else {
+		f *= by
+	}
+	if math.IsNaN(f) || f >= math.MaxInt64 || f < math.MinInt64 {
+		return 0, ErrorIntOverflow
+	}
+	return time.Duration(math.Round(f)), nil
+}
+
+func wrongDurationTypes(fn string, a, b types.Val) error {
+	return errors.Errorf("Wrong types %v, %v encountered for func %s", a.Tid.Name(),
+		b.Tid.Name(), fn)
+}
+
 type unaryFunc func(a, res *types.Val) error
 type binaryFunc func(a, b, res *types.Val) error
 
@@ -423,6 +544,11 @@ var unaryFunctions = map[string]unaryFunc{
 	"floor": applyFloor,
 	"ceil":  applyCeil,
 	"since": applySince,
+
+	"toint":      coerceTo(types.IntID),
+	"tofloat":    coerceTo(types.FloatID),
+	"todatetime": coerceTo(types.DateTimeID),
+	"tostring":   coerceTo(types.StringID),
 }
 
 var binaryFunctions = map[string]binaryFunc{
@@ -508,6 +634,13 @@ func (ag *aggregator) ApplyVal(v types.Val) error {
 	}
 
 	va := ag.result
+	if res, ok, err := applyDuration(ag.name, va, v); ok {
+		if err != nil {
+			return err
+		}
+		ag.result = res
+		return nil
+	}
 	if err := ag.matchType(&v, &va); err != nil {
 		return err
 	}
@@ -527,6 +660,10 @@ func (ag *aggregator) ApplyVal(v types.Val) error {
 }
 
 func (ag *aggregator) Apply(val types.Val) {
+	if ag.name == "countdistinct" {
+		ag.applyDistinct(val)
+		return
+	}
 	if ag.result.Value == nil {
 		ag.result = val
 		ag.count++
@@ -557,6 +694,12 @@ func (ag *aggregator) Apply(val types.Val) {
 			va.Value = va.Value.(int64) + vb.Value.(int64)
 		case va.Tid == types.FloatID && vb.Tid == types.FloatID:
 			va.Value = va.Value.(float64) + vb.Value.(float64)
+		case va.Tid == types.BigIntID && vb.Tid == types.BigIntID:
+			va.Value = new(big.Int).Add(va.Value.(*big.Int), vb.Value.(*big.Int))
+		case va.Tid == types.DecimalID && vb.Tid == types.DecimalID:
+			va.Value = new(big.Rat).Add(va.Value.(*big.Rat), vb.Value.(*big.Rat))
+		case va.Tid == types.DurationID && vb.Tid == types.DurationID:
+			va.Value = va.Value.(time.Duration) + vb.Value.(time.Duration)
 		}
 		// Skipping the else case since that means the pair cannot be summed.
 		res = va
@@ -567,6 +710,27 @@ func (ag *aggregator) Apply(val types.Val) {
 	ag.result = res
 }
 
+// applyDistinct adds the hash of the value, and of its type, to the sketch of countdistinct. The
+// values which can't be marshalled are skipped.
+func (ag *aggregator) applyDistinct(val types.Val) {
+	if val.Value == nil {
+		return
+	}
+	if val.Tid == types.DateTimeID {
+		// The same instant in different timezones is the same value.
+		val.Value = val.Value.(time.Time).UTC()
+	}
+	data := types.ValueForType(types.BinaryID)
+	if err := types.Marshal(val, &data); err != nil {
+		return
+	}
+	if ag.distinct == nil {
+		ag.distinct = &algo.HLL{}
+	}
+	ag.distinct.Add(farm.Fingerprint64(append([]byte{byte(val.Tid)}, data.Value.([]byte)...)))
+	ag.count++
+}
+
 func (ag *aggregator) ValueMarshalled() (*pb.TaskValue, error) {
 	data := types.ValueForType(types.BinaryID)
 	ag.divideByCount()
@@ -587,6 +751,24 @@ func (ag *aggregator) divideByCount() {
 	if ag.name != "avg" || ag.count == 0 || ag.result.Value == nil {
 		return
 	}
+	switch ag.result.Tid {
+	case types.BigIntID, types.DecimalID:
+		// The average of exact numbers is a decimal, rounded if it has no finite expansion.
+		var sum *big.Rat
+		if ag.result.Tid == types.BigIntID {
+			sum = new(big.Rat).SetInt(ag.result.Value.(*big.Int))
+		} else {
+			sum = ag.result.Value.(*big.Rat)
+		}
+		avg := new(big.Rat).Quo(sum, new(big.Rat).SetInt64(int64(ag.count)))
+		ag.result.Tid = types.DecimalID
+		ag.result.Value = types.RoundDecimal(avg, types.DecimalDivScale)
+		return
+	case types.DurationID:
+		ag.result.Value = ag.result.Value.(time.Duration) / time.Duration(ag.count)
+		return
+	}
+
 	var v float64
 	switch ag.result.Tid {
 	case types.IntID:
@@ -600,6 +782,13 @@ func (ag *aggregator) divideByCount() {
 }
 
 func (ag *aggregator) Value() (types.Val, error) {
+	if ag.name == "countdistinct" {
+		var n uint64
+		if ag.distinct != nil {
+			n = ag.distinct.Count()
+		}
+		return types.Val{Tid: types.IntID, Value: int64(n)}, nil
+	}
 	if ag.result.Value == nil {
 		return ag.result, ErrEmptyVal
 	}
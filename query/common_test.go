@@ -318,6 +318,8 @@ newname                        : string @index(exact, term) .
 newage                         : int .
 boss                           : uid .
 newfriend                      : [uid] .
+member_of                      : [uid] .
+lived_in                       : [uid] @facetindex(validFrom) .
 owner                          : [uid] .
 noconflict_pred                : string @noconflict .
 noindex_name                   : string .
@@ -0,0 +1,124 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package query
+
+import (
+	"context"
+	"strings"
+
+	"github.com/dgraph-io/dgraph/codec"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/types"
+	"github.com/dgraph-io/dgraph/worker"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/dgraph-io/sroar"
+	"github.com/pkg/errors"
+)
+
+// joinKeys returns the keys compared by join() for each of the nodes: the values of the predicate
+// converted to strings, the objects of the uid predicate, or the node itself for uid.
+func joinKeys(ctx context.Context, sg *SubGraph, attr string, nodes *pb.List) ([][]string,
+	error) {
+	uids := codec.GetUids(nodes)
+	keys := make([][]string, len(uids))
+	if attr == "uid" {
+		for i, uid := range uids {
+			keys[i] = []string{UidToHex(uid)}
+		}
+		return keys, nil
+	}
+	if len(uids) == 0 {
+		return keys, nil
+	}
+
+	namespace, err := x.ExtractNamespace(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while processing join()")
+	}
+	res, err := worker.ProcessTaskOverNetwork(ctx, &pb.Query{
+		ReadTs:  sg.ReadTs,
+		Attr:    x.NamespaceAttr(namespace, attr),
+		UidList: nodes,
+	})
+	switch {
+	case err != nil && strings.Contains(err.Error(), worker.ErrNonExistentTabletMessage):
+		return keys, nil
+	case err != nil:
+		return nil, err
+	}
+
+	for i := range uids {
+		if i < len(res.UidMatrix) {
+			for _, uid := range codec.GetUids(res.UidMatrix[i]) {
+				keys[i] = append(keys[i], UidToHex(uid))
+			}
+		}
+		if i >= len(res.ValueMatrix) {
+			continue
+		}
+		for _, tv := range res.ValueMatrix[i].Values {
+			if len(tv.Val) == 0 {
+				continue
+			}
+			val, err := convertWithBestEffort(tv, attr)
+			if err != nil {
+				return nil, err
+			}
+			str, err := types.Convert(val, types.StringID)
+			if err != nil {
+				return nil, err
+			}
+			keys[i] = append(keys[i], str.Value.(string))
+		}
+	}
+	return keys, nil
+}
+
+// processJoin computes the objects of the virtual edge of join() for each of the SrcUIDs: the
+// nodes of the variable whose value of the VarAttr predicate equals a value of the Attr predicate
+// of the subject. The values are compared as strings, and the uids by uid.
+func (sg *SubGraph) processJoin(ctx context.Context) error {
+	join := sg.Params.Join
+	nodes := codec.ToList(sg.DestMap)
+	nodeKeys, err := joinKeys(ctx, sg, join.VarAttr, nodes)
+	if err != nil {
+		return err
+	}
+	srcKeys, err := joinKeys(ctx, sg, join.Attr, sg.SrcUIDs)
+	if err != nil {
+		return err
+	}
+
+	// Index the nodes of the variable by their keys.
+	byKey := make(map[string][]uint64)
+	for i, uid := range codec.GetUids(nodes) {
+		for _, key := range nodeKeys[i] {
+			byKey[key] = append(byKey[key], uid)
+		}
+	}
+	sg.uidMatrix = make([]*pb.List, len(srcKeys))
+	for i, keys := range srcKeys {
+		objects := sroar.NewBitmap()
+		for _, key := range keys {
+			objects.SetMany(byKey[key])
+		}
+		sg.uidMatrix[i] = codec.ToList(objects)
+	}
+	sg.DestMap = codec.Merge(sg.uidMatrix)
+	sg.List = true
+	return nil
+}
@@ -0,0 +1,104 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package query
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/dgo/v210/protos/api"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// The phases of the processing of a query in its latency breakdown.
+const (
+	PhaseIndexLookup  = "index_lookup"
+	PhaseValueFetch   = "value_fetch"
+	PhaseIntersection = "intersection"
+	PhaseJSONEncoding = "json_encoding"
+)
+
+// ServerLatency is the server_latency of the extensions of a response: the latency of the
+// request, followed by its breakdown if it was traced.
+type ServerLatency struct {
+	*api.Latency
+	*LatencyTrace
+}
+
+// LatencyTrace breaks down the time spent in processing a query, to tell which group, predicate
+// or phase dominates its latency. It's filled when it's set in the context of the query with
+// LatencyTraceKey.
+//
+// The tasks of a query run concurrently, so the time of a group, a predicate or a phase is the
+// sum of the times of its tasks, and the sum of the times may exceed the processing time.
+type LatencyTrace struct {
+	mu sync.Mutex
+	// Groups maps the id of each group to the time spent in the tasks it served, the network
+	// round trips included.
+	Groups map[uint32]uint64 `json:"groups_ns"`
+	// Predicates maps each predicate to the time spent in its tasks.
+	Predicates map[string]uint64 `json:"predicates_ns"`
+	// Phases maps each phase, index_lookup, value_fetch, intersection or json_encoding, to the
+	// time spent in it.
+	Phases map[string]uint64 `json:"phases_ns"`
+}
+
+// NewLatencyTrace returns an empty LatencyTrace.
+func NewLatencyTrace() *LatencyTrace {
+	return &LatencyTrace{
+		Groups:     make(map[uint32]uint64),
+		Predicates: make(map[string]uint64),
+		Phases:     make(map[string]uint64),
+	}
+}
+
+// latencyTrace returns the LatencyTrace of the query, nil if it isn't traced.
+func latencyTrace(ctx context.Context) *LatencyTrace {
+	t, _ := ctx.Value(LatencyTraceKey).(*LatencyTrace)
+	return t
+}
+
+// AddPhase adds the duration to the time spent in the phase.
+func (t *LatencyTrace) AddPhase(phase string, d time.Duration) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.Phases[phase] += uint64(d)
+}
+
+// addTask adds the time taken by the task q, which returned res, to its group, its predicate and
+// its phases.
+func (t *LatencyTrace) addTask(q *pb.Query, res *pb.Result, d time.Duration) {
+	if t == nil || res == nil {
+		return
+	}
+	pred := x.ParseAttr(q.Attr)
+	if q.Reverse {
+		pred = "~" + pred
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.Groups[res.GroupId] += uint64(d)
+	t.Predicates[pred] += uint64(d)
+	t.Phases[PhaseIndexLookup] += res.IndexNs
+	t.Phases[PhaseValueFetch] += res.FetchNs
+}
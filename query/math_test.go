@@ -19,6 +19,7 @@ package query
 import (
 	"math"
 	"testing"
+	"time"
 
 	"github.com/dgraph-io/dgraph/types"
 	"github.com/stretchr/testify/require"
@@ -378,6 +379,50 @@ func TestProcessBinary(t *testing.T) {
 	}
 }
 
+func TestProcessBinaryDuration(t *testing.T) {
+	t0 := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+	dur := func(d time.Duration) types.Val { return types.Val{Tid: types.DurationID, Value: d} }
+	date := func(t time.Time) types.Val { return types.Val{Tid: types.DateTimeID, Value: t} }
+	tests := []struct {
+		fn     string
+		a, b   types.Val
+		out    types.Val
+		errStr string
+	}{
+		{fn: "+", a: date(t0), b: dur(36 * time.Hour), out: date(t0.Add(36 * time.Hour))},
+		{fn: "+", a: dur(time.Hour), b: date(t0), out: date(t0.Add(time.Hour))},
+		{fn: "-", a: date(t0), b: dur(time.Minute), out: date(t0.Add(-time.Minute))},
+		{fn: "-", a: date(t0.Add(90 * time.Minute)), b: date(t0), out: dur(90 * time.Minute)},
+		{fn: "+", a: dur(time.Hour), b: dur(time.Minute), out: dur(61 * time.Minute)},
+		{fn: "-", a: dur(time.Minute), b: dur(time.Hour), out: dur(-59 * time.Minute)},
+		{fn: "*", a: dur(time.Hour), b: types.Val{Tid: types.IntID, Value: int64(3)},
+			out: dur(3 * time.Hour)},
+		{fn: "*", a: types.Val{Tid: types.FloatID, Value: 0.5}, b: dur(time.Hour),
+			out: dur(30 * time.Minute)},
+		{fn: "/", a: dur(time.Hour), b: types.Val{Tid: types.IntID, Value: int64(4)},
+			out: dur(15 * time.Minute)},
+		{fn: "/", a: dur(time.Hour), b: dur(40 * time.Minute),
+			out: types.Val{Tid: types.FloatID, Value: 1.5}},
+		{fn: "max", a: dur(time.Hour), b: dur(time.Minute), out: dur(time.Hour)},
+		{fn: "/", a: dur(time.Hour), b: types.Val{Tid: types.IntID, Value: int64(0)},
+			errStr: ErrorDivisionByZero.Error()},
+		{fn: "+", a: dur(math.MaxInt64), b: dur(1), errStr: ErrorIntOverflow.Error()},
+		{fn: "*", a: dur(time.Hour), b: dur(time.Hour),
+			errStr: "Wrong types duration, duration encountered for func *"},
+	}
+	for _, tc := range tests {
+		in := &mathTree{Fn: tc.fn, Child: []*mathTree{{Const: tc.a}, {Const: tc.b}}}
+		err := processBinary(in)
+		if tc.errStr != "" {
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.errStr)
+			continue
+		}
+		require.NoError(t, err)
+		require.Equal(t, tc.out, in.Const)
+	}
+}
+
 func TestProcessUnary(t *testing.T) {
 	tests := []struct {
 		in  *mathTree
@@ -425,6 +470,20 @@ func TestProcessUnary(t *testing.T) {
 			}},
 			out: types.Val{Tid: types.FloatID, Value: 3.0},
 		},
+		{in: &mathTree{
+			Fn: "toint",
+			Child: []*mathTree{
+				{Const: types.Val{Tid: types.StringID, Value: " 42.9"}},
+			}},
+			out: types.Val{Tid: types.IntID, Value: int64(42)},
+		},
+		{in: &mathTree{
+			Fn: "tostring",
+			Child: []*mathTree{
+				{Const: types.Val{Tid: types.FloatID, Value: 2.5}},
+			}},
+			out: types.Val{Tid: types.StringID, Value: "2.5"},
+		},
 	}
 	for _, tc := range tests {
 		t.Logf("Test %s", tc.in.Fn)
@@ -485,6 +544,13 @@ func TestProcessUnary(t *testing.T) {
 		err := processUnary(tc.in)
 		require.EqualError(t, err, tc.err.Error())
 	}
+
+	err := processUnary(&mathTree{
+		Fn: "todatetime",
+		Child: []*mathTree{
+			{Const: types.Val{Tid: types.StringID, Value: "tomorrow"}},
+		}})
+	require.Error(t, err)
 }
 
 func TestProcessBinaryBoolean(t *testing.T) {
@@ -0,0 +1,302 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package query
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// graphResult is the response of a DQL query in the graph format. Instead of nesting the nodes
+// under the edges leading to them, each node is listed once, along with the edges between the
+// nodes, which is what graph visualization tools expect.
+type graphResult struct {
+	Nodes []*graphNode `json:"nodes"`
+	Edges []*graphEdge `json:"edges"`
+	// Roots lists the ids of the nodes returned by each query block.
+	Roots map[string][]string `json:"roots"`
+	// Values lists the results of the query blocks which aren't nodes, like the aggregations.
+	Values map[string][]json.RawMessage `json:"values,omitempty"`
+}
+
+type graphNode struct {
+	// ID is the uid of the node, which is stable across queries.
+	ID    string                     `json:"id"`
+	Types []string                   `json:"types,omitempty"`
+	Attrs map[string]json.RawMessage `json:"attrs,omitempty"`
+}
+
+type graphEdge struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+	Label  string `json:"label"`
+	// Attrs holds the facets of the edge.
+	Attrs map[string]json.RawMessage `json:"attrs,omitempty"`
+}
+
+// ToGraph converts the list of subgraph into a response listing the nodes and the edges of the
+// result. The uids are always fetched for the queries run in this format, as they identify the
+// nodes.
+func ToGraph(l *Latency, sgl []*SubGraph) ([]byte, error) {
+	sgr := &SubGraph{}
+	for _, sg := range sgl {
+		if sg.Params.Alias == "var" || sg.Params.Alias == "shortest" {
+			continue
+		}
+		sgr.Children = append(sgr.Children, sg)
+	}
+	data, err := sgr.toGraph(l)
+	return data, errors.Wrapf(err, "while running ToGraph")
+}
+
+func (sg *SubGraph) toGraph(l *Latency) ([]byte, error) {
+	encodingStart := time.Now()
+	defer func() {
+		l.Json = time.Since(encodingStart)
+	}()
+
+	enc := newEncoder()
+	defer func() {
+		// Put encoder's arena back to arena pool.
+		arenaPool.Put(enc.arena)
+		enc.alloc.Release()
+	}()
+
+	n := enc.newNode(enc.idForAttr("_root_"))
+	for _, sg := range sg.Children {
+		if err := processNodeUids(n, enc, sg); err != nil {
+			return nil, err
+		}
+	}
+	enc.fixOrder(n)
+	return enc.encodeGraph(n)
+}
+
+// encodeGraph returns the graph format of the response whose root is given.
+func (enc *encoder) encodeGraph(n fastJsonNode) ([]byte, error) {
+	g := &graphBuilder{
+		enc:   enc,
+		nodes: make(map[string]*graphNode),
+		edges: make(map[graphEdgeKey]bool),
+		res: &graphResult{
+			Nodes: []*graphNode{},
+			Edges: []*graphEdge{},
+			Roots: make(map[string][]string),
+		},
+	}
+	for child := enc.children(n); child != nil; child = child.next {
+		if err := g.addRoot(child); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(g.res)
+}
+
+type graphEdgeKey struct {
+	source, target, label string
+}
+
+// graphBuilder walks the fastJsonNode tree of a response to build its graphResult.
+type graphBuilder struct {
+	enc   *encoder
+	nodes map[string]*graphNode
+	edges map[graphEdgeKey]bool
+	res   *graphResult
+}
+
+// addRoot adds a result of a query block.
+func (g *graphBuilder) addRoot(fj fastJsonNode) error {
+	alias := g.enc.attrForID(g.enc.getAttr(fj))
+	if _, ok := g.res.Roots[alias]; !ok {
+		g.res.Roots[alias] = []string{}
+	}
+	if g.enc.children(fj) == nil {
+		// An empty block.
+		return nil
+	}
+	id, _, err := g.addObject(fj, "")
+	if err != nil {
+		return err
+	}
+	if id != "" {
+		g.res.Roots[alias] = append(g.res.Roots[alias], id)
+		return nil
+	}
+	val, err := g.encodeJSON(fj)
+	if err != nil {
+		return err
+	}
+	if g.res.Values == nil {
+		g.res.Values = make(map[string][]json.RawMessage)
+	}
+	g.res.Values[alias] = append(g.res.Values[alias], val)
+	return nil
+}
+
+// addObject adds the node of the object along with the nodes and the edges it leads to, and
+// returns its id. It returns an empty id if the object has no uid, like the result of an
+// aggregation, in which case nothing is added. The facets of the edge with the given label leading
+// to the object are returned along with its id.
+func (g *graphBuilder) addObject(fj fastJsonNode,
+	label string) (string, map[string]json.RawMessage, error) {
+	enc := g.enc
+	var id string
+	for child := enc.children(fj); child != nil; child = child.next {
+		if enc.getAttr(child) != enc.uidAttr {
+			continue
+		}
+		val, err := enc.getScalarVal(child)
+		if err != nil {
+			return "", nil, err
+		}
+		if err := json.Unmarshal(val, &id); err != nil {
+			return "", nil, errors.Wrapf(err, "while reading uid")
+		}
+	}
+	if id == "" {
+		return "", nil, nil
+	}
+
+	node, ok := g.nodes[id]
+	if !ok {
+		node = &graphNode{ID: id}
+		g.nodes[id] = node
+		g.res.Nodes = append(g.res.Nodes, node)
+	}
+
+	var facets map[string]json.RawMessage
+	child := enc.children(fj)
+	for child != nil {
+		attr := enc.getAttr(child)
+		// The children having the same attr are next to each other.
+		var run []fastJsonNode
+		for ; child != nil && enc.getAttr(child) == attr; child = child.next {
+			run = append(run, child)
+		}
+		name := enc.attrForID(attr)
+
+		switch {
+		case attr == enc.uidAttr:
+		case label != "" && strings.HasPrefix(name, label+"|"):
+			val, err := enc.getScalarVal(run[0])
+			if err != nil {
+				return "", nil, err
+			}
+			if facets == nil {
+				facets = make(map[string]json.RawMessage)
+			}
+			facets[strings.TrimPrefix(name, label+"|")] = val
+		case name == "dgraph.type":
+			for _, c := range run {
+				val, err := enc.getScalarVal(c)
+				if err != nil {
+					return "", nil, err
+				}
+				var typ string
+				if err := json.Unmarshal(val, &typ); err != nil {
+					return "", nil, errors.Wrapf(err, "while reading dgraph.type")
+				}
+				node.Types = appendUnique(node.Types, typ)
+			}
+		default:
+			if err := g.addAttr(node, name, run); err != nil {
+				return "", nil, err
+			}
+		}
+	}
+	return id, facets, nil
+}
+
+// addAttr adds the children of the node having the given attr. The objects having a uid become
+// edges, and the other children are kept as the value of the attr.
+func (g *graphBuilder) addAttr(node *graphNode, name string, run []fastJsonNode) error {
+	enc := g.enc
+	var vals []json.RawMessage
+	for _, c := range run {
+		if enc.children(c) != nil {
+			target, facets, err := g.addObject(c, name)
+			if err != nil {
+				return err
+			}
+			if target != "" {
+				key := graphEdgeKey{source: node.ID, target: target, label: name}
+				if !g.edges[key] {
+					g.edges[key] = true
+					g.res.Edges = append(g.res.Edges, &graphEdge{
+						Source: node.ID,
+						Target: target,
+						Label:  name,
+						Attrs:  facets,
+					})
+				}
+				continue
+			}
+		}
+		val, err := g.encodeJSON(c)
+		if err != nil {
+			return err
+		}
+		vals = append(vals, val)
+	}
+
+	switch {
+	case len(vals) == 0:
+		return nil
+	case len(vals) == 1 && !enc.getList(run[0]):
+		g.setAttr(node, name, vals[0])
+	default:
+		b, err := json.Marshal(vals)
+		if err != nil {
+			return err
+		}
+		g.setAttr(node, name, b)
+	}
+	return nil
+}
+
+func (g *graphBuilder) setAttr(node *graphNode, name string, val json.RawMessage) {
+	if node.Attrs == nil {
+		node.Attrs = make(map[string]json.RawMessage)
+	}
+	node.Attrs[name] = val
+}
+
+// encodeJSON returns the JSON encoding of the given node, as it would be in a JSON response.
+func (g *graphBuilder) encodeJSON(fj fastJsonNode) (json.RawMessage, error) {
+	buf := g.enc.buf
+	defer func() {
+		g.enc.buf = buf
+	}()
+	g.enc.buf = &bytes.Buffer{}
+	if err := g.enc.encode(fj); err != nil {
+		return nil, err
+	}
+	return g.enc.buf.Bytes(), nil
+}
+
+func appendUnique(list []string, s string) []string {
+	for _, e := range list {
+		if e == s {
+			return list
+		}
+	}
+	return append(list, s)
+}
@@ -0,0 +1,67 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package query
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/dgraph/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeGraph(t *testing.T) {
+	enc := newEncoder()
+	root := enc.newNode(enc.idForAttr("_root_"))
+	str := func(s string) types.Val { return types.Val{Tid: types.StringID, Value: s} }
+
+	// q returns 0x1, which has a friend 0x2, and 0x2 itself.
+	alice := enc.newNode(enc.idForAttr("q"))
+	require.NoError(t, enc.SetUID(alice, 0x1, enc.uidAttr))
+	require.NoError(t, enc.AddValue(alice, enc.idForAttr("name"), str("Alice")))
+	require.NoError(t, enc.AddValue(alice, enc.idForAttr("dgraph.type"), str("Person")))
+	friend := enc.newNode(enc.idForAttr("friend"))
+	require.NoError(t, enc.SetUID(friend, 0x2, enc.uidAttr))
+	require.NoError(t, enc.AddValue(friend, enc.idForAttr("friend|since"),
+		types.Val{Tid: types.IntID, Value: int64(2020)}))
+	enc.AddListChild(alice, friend)
+	enc.AddListChild(root, alice)
+
+	bob := enc.newNode(enc.idForAttr("q"))
+	require.NoError(t, enc.SetUID(bob, 0x2, enc.uidAttr))
+	require.NoError(t, enc.AddValue(bob, enc.idForAttr("name"), str("Bob")))
+	enc.AddListChild(root, bob)
+
+	count := enc.newNode(enc.idForAttr("total"))
+	require.NoError(t, enc.AddValue(count, enc.idForAttr("count"),
+		types.Val{Tid: types.IntID, Value: int64(2)}))
+	enc.AddListChild(root, count)
+	enc.fixOrder(root)
+
+	b, err := enc.encodeGraph(root)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"nodes": [
+			{"id": "0x1", "types": ["Person"], "attrs": {"name": "Alice"}},
+			{"id": "0x2", "attrs": {"name": "Bob"}}
+		],
+		"edges": [
+			{"source": "0x1", "target": "0x2", "label": "friend", "attrs": {"since": 2020}}
+		],
+		"roots": {"q": ["0x1", "0x2"], "total": []},
+		"values": {"total": [{"count": 2}]}
+	}`, string(b))
+}
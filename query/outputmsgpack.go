@@ -0,0 +1,298 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package query
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ToMsgpack converts the list of subgraph into a MessagePack response. The response has the same
+// structure as the one returned by ToJson for a DQL query, but the numbers are encoded in binary,
+// which makes it smaller and cheaper to decode for the results having lots of numbers.
+func ToMsgpack(l *Latency, sgl []*SubGraph) ([]byte, error) {
+	sgr := &SubGraph{}
+	for _, sg := range sgl {
+		if sg.Params.Alias == "var" || sg.Params.Alias == "shortest" {
+			continue
+		}
+		if sg.Params.GetUid {
+			sgr.Params.GetUid = true
+		}
+		sgr.Children = append(sgr.Children, sg)
+	}
+	data, err := sgr.toMsgpack(l)
+	return data, errors.Wrapf(err, "while running ToMsgpack")
+}
+
+func (sg *SubGraph) toMsgpack(l *Latency) ([]byte, error) {
+	encodingStart := time.Now()
+	defer func() {
+		l.Json = time.Since(encodingStart)
+	}()
+
+	enc := newEncoder()
+	defer func() {
+		// Put encoder's arena back to arena pool.
+		arenaPool.Put(enc.arena)
+		enc.alloc.Release()
+	}()
+
+	n := enc.newNode(enc.idForAttr("_root_"))
+	for _, sg := range sg.Children {
+		if err := processNodeUids(n, enc, sg); err != nil {
+			return nil, err
+		}
+	}
+	enc.fixOrder(n)
+
+	if enc.children(n) == nil {
+		writeMsgpackMapHeader(enc.buf, 0)
+	} else if err := enc.encodeMsgpack(n); err != nil {
+		return nil, err
+	}
+
+	if uint64(enc.buf.Len()) > maxEncodedSize {
+		return nil, fmt.Errorf("while writing to buffer. Encoded response size: %d"+
+			" is bigger than threshold: %d", enc.buf.Len(), maxEncodedSize)
+	}
+	return enc.buf.Bytes(), nil
+}
+
+// encodeMsgpack is the MessagePack counterpart of encode. The children having the same attr are
+// next to each other, and are encoded as a list.
+func (enc *encoder) encodeMsgpack(fj fastJsonNode) error {
+	child := enc.children(fj)
+	// This is a scalar value, which is stored in its JSON form.
+	if child == nil {
+		val, err := enc.getScalarVal(fj)
+		if err != nil {
+			return err
+		}
+		return writeJSONAsMsgpack(enc.buf, val)
+	}
+
+	// MessagePack maps are prefixed with their number of keys.
+	keys := 0
+	for cur := child; cur != nil; cur = cur.next {
+		if cur.next == nil || enc.getAttr(cur) != enc.getAttr(cur.next) {
+			keys++
+		}
+	}
+	writeMsgpackMapHeader(enc.buf, keys)
+
+	for child != nil {
+		attr := enc.getAttr(child)
+		count := 1
+		end := child.next
+		for end != nil && enc.getAttr(end) == attr {
+			count++
+			end = end.next
+		}
+
+		writeMsgpackString(enc.buf, enc.attrForID(attr))
+		if count > 1 || enc.getList(child) {
+			writeMsgpackArrayHeader(enc.buf, count)
+		}
+		for cur := child; cur != end; cur = cur.next {
+			if err := enc.encodeMsgpack(cur); err != nil {
+				return err
+			}
+		}
+		child = end
+	}
+	return nil
+}
+
+// JSONToMsgpack converts a JSON document to MessagePack.
+func JSONToMsgpack(js []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := writeJSONAsMsgpack(&buf, js); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeJSONAsMsgpack writes the given JSON value in MessagePack. The scalar values, which make up
+// most of a response, are converted without decoding them into an interface{}.
+func writeJSONAsMsgpack(buf *bytes.Buffer, js []byte) error {
+	js = bytes.TrimSpace(js)
+	if len(js) == 0 {
+		return errors.New("empty JSON value")
+	}
+	switch js[0] {
+	case '"':
+		if bytes.IndexByte(js, '\\') < 0 && len(js) > 1 && js[len(js)-1] == '"' {
+			writeMsgpackString(buf, string(js[1:len(js)-1]))
+			return nil
+		}
+	case 't', 'f', 'n':
+		switch string(js) {
+		case "true":
+			buf.WriteByte(0xc3)
+			return nil
+		case "false":
+			buf.WriteByte(0xc2)
+			return nil
+		case "null":
+			buf.WriteByte(0xc0)
+			return nil
+		}
+	case '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+		return writeMsgpackNumber(buf, string(js))
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(js))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return errors.Wrapf(err, "while converting JSON to MessagePack")
+	}
+	return writeMsgpackValue(buf, v)
+}
+
+func writeMsgpackValue(buf *bytes.Buffer, v interface{}) error {
+	switch v := v.(type) {
+	case nil:
+		buf.WriteByte(0xc0)
+	case bool:
+		if v {
+			buf.WriteByte(0xc3)
+		} else {
+			buf.WriteByte(0xc2)
+		}
+	case string:
+		writeMsgpackString(buf, v)
+	case json.Number:
+		return writeMsgpackNumber(buf, v.String())
+	case []interface{}:
+		writeMsgpackArrayHeader(buf, len(v))
+		for _, e := range v {
+			if err := writeMsgpackValue(buf, e); err != nil {
+				return err
+			}
+		}
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		writeMsgpackMapHeader(buf, len(keys))
+		for _, k := range keys {
+			writeMsgpackString(buf, k)
+			if err := writeMsgpackValue(buf, v[k]); err != nil {
+				return err
+			}
+		}
+	default:
+		return errors.Errorf("unexpected JSON value of type %T", v)
+	}
+	return nil
+}
+
+// writeMsgpackNumber writes a JSON number as an integer if it is one, and as a float64 otherwise.
+func writeMsgpackNumber(buf *bytes.Buffer, num string) error {
+	if i, err := strconv.ParseInt(num, 10, 64); err == nil {
+		writeMsgpackInt(buf, i)
+		return nil
+	}
+	if u, err := strconv.ParseUint(num, 10, 64); err == nil {
+		var b [9]byte
+		b[0] = 0xcf
+		binary.BigEndian.PutUint64(b[1:], u)
+		buf.Write(b[:])
+		return nil
+	}
+	f, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return errors.Wrapf(err, "while converting JSON to MessagePack")
+	}
+	var b [9]byte
+	b[0] = 0xcb
+	binary.BigEndian.PutUint64(b[1:], math.Float64bits(f))
+	buf.Write(b[:])
+	return nil
+}
+
+func writeMsgpackInt(buf *bytes.Buffer, i int64) {
+	var b [9]byte
+	switch {
+	case i >= 0 && i <= math.MaxInt8:
+		// positive fixint
+		buf.WriteByte(byte(i))
+	case i < 0 && i >= -32:
+		// negative fixint
+		buf.WriteByte(byte(int8(i)))
+	case i >= math.MinInt8 && i <= math.MaxInt8:
+		buf.Write([]byte{0xd0, byte(int8(i))})
+	case i >= math.MinInt16 && i <= math.MaxInt16:
+		b[0] = 0xd1
+		binary.BigEndian.PutUint16(b[1:], uint16(i))
+		buf.Write(b[:3])
+	case i >= math.MinInt32 && i <= math.MaxInt32:
+		b[0] = 0xd2
+		binary.BigEndian.PutUint32(b[1:], uint32(i))
+		buf.Write(b[:5])
+	default:
+		b[0] = 0xd3
+		binary.BigEndian.PutUint64(b[1:], uint64(i))
+		buf.Write(b[:])
+	}
+}
+
+func writeMsgpackString(buf *bytes.Buffer, s string) {
+	writeMsgpackHeader(buf, len(s), 0xa0, 32, 0xd9, 0xda, 0xdb)
+	buf.WriteString(s)
+}
+
+func writeMsgpackArrayHeader(buf *bytes.Buffer, n int) {
+	writeMsgpackHeader(buf, n, 0x90, 16, 0, 0xdc, 0xdd)
+}
+
+func writeMsgpackMapHeader(buf *bytes.Buffer, n int) {
+	writeMsgpackHeader(buf, n, 0x80, 16, 0, 0xde, 0xdf)
+}
+
+// writeMsgpackHeader writes the header of a string, an array or a map of length n. The lengths
+// under fixMax are stored in the header byte, the other ones follow the header byte in 1 (only
+// for strings), 2 or 4 bytes.
+func writeMsgpackHeader(buf *bytes.Buffer, n int, fix byte, fixMax int, h8, h16, h32 byte) {
+	var b [5]byte
+	switch {
+	case n < fixMax:
+		buf.WriteByte(fix | byte(n))
+	case h8 != 0 && n <= math.MaxUint8:
+		buf.Write([]byte{h8, byte(n)})
+	case n <= math.MaxUint16:
+		b[0] = h16
+		binary.BigEndian.PutUint16(b[1:], uint16(n))
+		buf.Write(b[:3])
+	default:
+		b[0] = h32
+		binary.BigEndian.PutUint32(b[1:], uint32(n))
+		buf.Write(b[:])
+	}
+}
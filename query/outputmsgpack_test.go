@@ -0,0 +1,71 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONToMsgpack(t *testing.T) {
+	tests := []struct {
+		js       string
+		expected []byte
+	}{
+		{`1`, []byte{0x01}},
+		{`-3`, []byte{0xfd}},
+		{`-100`, []byte{0xd0, 0x9c}},
+		{`300`, []byte{0xd1, 0x01, 0x2c}},
+		{`70000`, []byte{0xd2, 0x00, 0x01, 0x11, 0x70}},
+		{`1.5`, []byte{0xcb, 0x3f, 0xf8, 0, 0, 0, 0, 0, 0}},
+		{`true`, []byte{0xc3}},
+		{`null`, []byte{0xc0}},
+		{`"abc"`, []byte{0xa3, 'a', 'b', 'c'}},
+		{`"a<b"`, []byte{0xa3, 'a', '<', 'b'}},
+		{`[1, "a"]`, []byte{0x92, 0x01, 0xa1, 'a'}},
+		{`{"b": 2, "a": 1}`, []byte{0x82, 0xa1, 'a', 0x01, 0xa1, 'b', 0x02}},
+	}
+	for _, test := range tests {
+		b, err := JSONToMsgpack([]byte(test.js))
+		require.NoError(t, err, test.js)
+		require.Equal(t, test.expected, b, test.js)
+	}
+
+	_, err := JSONToMsgpack([]byte(`{"a": `))
+	require.Error(t, err)
+}
+
+func TestEncodeMsgpack(t *testing.T) {
+	enc := newEncoder()
+	root := enc.newNode(enc.idForAttr("_root_"))
+	for _, age := range []string{"300", "-40"} {
+		friend := enc.newNode(enc.idForAttr("friend"))
+		ageNode, err := enc.makeScalarNode(enc.idForAttr("age"), []byte(age), false)
+		require.NoError(t, err)
+		enc.appendAttrs(friend, ageNode)
+		enc.appendAttrs(root, friend)
+	}
+
+	require.NoError(t, enc.encode(root))
+	expected, err := JSONToMsgpack(enc.buf.Bytes())
+	require.NoError(t, err)
+
+	enc.buf.Reset()
+	require.NoError(t, enc.encodeMsgpack(root))
+	require.Equal(t, expected, enc.buf.Bytes())
+}
@@ -684,6 +684,11 @@ func valToBytes(v types.Val) ([]byte, error) {
 		return []byte(fmt.Sprintf("\"%#x\"", v.Value)), nil
 	case types.PasswordID:
 		return []byte(fmt.Sprintf("%q", v.Value.(string))), nil
+	case types.BigIntID, types.DecimalID:
+		// Encoded as strings so that the clients don't lose precision by decoding them as floats.
+		return v.MarshalJSON()
+	case types.DurationID, types.BytesID:
+		return v.MarshalJSON()
 	default:
 		return nil, errors.New("Unsupported types.Val.Tid")
 	}
@@ -855,13 +860,14 @@ func (enc *encoder) merge(parent, child []fastJsonNode) ([]fastJsonNode, error)
 	// Here we merge two slices of maps.
 	mergedList := make([]fastJsonNode, 0)
 	cnt := 0
+	limit := x.LimitNormalizeNode()
 	for _, pa := range parent {
 		for _, ca := range child {
 			paCopy, paNodeCount := enc.copyFastJsonList(pa)
 			caCopy, caNodeCount := enc.copyFastJsonList(ca)
 
 			cnt += paNodeCount + caNodeCount
-			if cnt > x.Config.LimitNormalizeNode {
+			if cnt > limit {
 				return nil, errors.Errorf(
 					"Couldn't evaluate @normalize directive - too many results")
 			}
@@ -1138,9 +1144,11 @@ func processNodeUids(fj fastJsonNode, enc *encoder, sg *SubGraph) error {
 
 // Extensions represents the extra information appended to query results.
 type Extensions struct {
-	Latency *api.Latency    `json:"server_latency,omitempty"`
+	Latency *ServerLatency  `json:"server_latency,omitempty"`
 	Txn     *api.TxnContext `json:"txn,omitempty"`
 	Metrics *api.Metrics    `json:"metrics,omitempty"`
+	// Warnings are the warnings about the results, like the truncation of the expanded edges.
+	Warnings []string `json:"warnings,omitempty"`
 }
 
 func (sg *SubGraph) toFastJSON(
@@ -1249,7 +1257,11 @@ func (sg *SubGraph) addCount(enc *encoder, count uint64, dst fastJsonNode) error
 	c.Value = int64(count)
 	fieldName := sg.Params.Alias
 	if fieldName == "" {
-		fieldName = fmt.Sprintf("count(%s)", sg.Attr)
+		attr := sg.Attr
+		if len(sg.Params.Langs) > 0 {
+			attr += "@" + strings.Join(sg.Params.Langs, ":")
+		}
+		fieldName = fmt.Sprintf("count(%s)", attr)
 	}
 	return enc.AddValue(dst, enc.idForAttr(fieldName), c)
 }
@@ -1326,7 +1338,7 @@ func (sg *SubGraph) preTraverse(enc *encoder, uid uint64, dst fastJsonNode) erro
 			continue
 		}
 		if pc.IsInternal() {
-			if pc.Params.Expand != "" {
+			if pc.Params.isExpand() {
 				continue
 			}
 			if pc.Params.Normalize && pc.Params.Alias == "" {
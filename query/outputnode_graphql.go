@@ -88,6 +88,10 @@ func newGraphQLEncoder(ctx context.Context, enc *encoder) *graphQLEncoder {
 
 // encode creates a JSON encoded GraphQL response.
 func (genc *graphQLEncoder) encode(encInp encodeInput) bool {
+	if encInp.parentField.Type().IsEdge() {
+		return genc.encodeEdge(encInp)
+	}
+
 	child := genc.children(encInp.fj)
 	// This is a scalar value for DQL.
 	if child == nil {
@@ -520,6 +524,83 @@ func (genc *graphQLEncoder) encode(encInp encodeInput) bool {
 	return true
 }
 
+// encodeEdge encodes an object of an edge type. The data for the node of the edge is in the
+// fastJson node fj, which also holds the facets of the edge as the attrs TypeEdge.facetKey, after
+// the data for the node.
+func (genc *graphQLEncoder) encodeEdge(encInp encodeInput) bool {
+	x.Check2(genc.buf.WriteRune('{'))
+
+	seenField := make(map[string]bool)
+	written := 0
+	for _, curSelection := range encInp.childSelSet {
+		if curSelection.SkipField(nil, seenField) {
+			continue
+		}
+		if written > 0 {
+			x.Check2(genc.buf.WriteRune(','))
+		}
+		written++
+
+		curSelection.CompleteAlias(genc.buf)
+		keyEndPos := genc.buf.Len()
+		curPath := append(encInp.parentPath, curSelection.ResponseName())
+		switch curSelection.Name() {
+		case gqlSchema.Typename:
+			x.Check2(genc.buf.Write(getTypename(curSelection, nil)))
+			continue
+		case gqlSchema.EdgeNode:
+			if genc.encode(encodeInput{
+				parentField: curSelection,
+				parentPath:  curPath,
+				fj:          encInp.fj,
+				fjIsRoot:    false,
+				childSelSet: curSelection.SelectionSet(),
+			}) {
+				continue
+			}
+		default:
+			if genc.writeEdgeFacet(curSelection, encInp.fj, curPath) {
+				continue
+			}
+		}
+		if !writeGraphQLNull(curSelection, genc.buf, keyEndPos) {
+			genc.errs = append(genc.errs, curSelection.GqlErrorf(curPath,
+				gqlSchema.ErrExpectedNonNull, curSelection.Name(), curSelection.Type()))
+			return false
+		}
+	}
+
+	x.Check2(genc.buf.WriteRune('}'))
+	return true
+}
+
+// writeEdgeFacet writes the value of the facet field of an edge, which is held by one of the
+// children of the fastJson node fj. It returns false if the edge doesn't have the facet, or if
+// its value can't be coerced to the type of the field.
+func (genc *graphQLEncoder) writeEdgeFacet(field gqlSchema.Field, fj fastJsonNode,
+	path []interface{}) bool {
+	attrId := genc.idForAttr(field.GetObjectName() + "." + field.Name())
+	for child := genc.children(fj); child != nil; child = child.next {
+		if genc.getAttr(child) != attrId {
+			continue
+		}
+		val, err := genc.getScalarVal(child)
+		if err != nil {
+			genc.errs = append(genc.errs, field.GqlErrorf(path, err.Error()))
+			return false
+		}
+		if cantCoerceScalar(val, field) {
+			genc.errs = append(genc.errs, field.GqlErrorf(path,
+				"Error coercing value '%s' for field '%s' to type %s.",
+				string(val), field.Name(), field.Type().Name()))
+			return false
+		}
+		x.Check2(genc.buf.Write(val))
+		return true
+	}
+	return false
+}
+
 // extractDgraphTypes extracts the all values for dgraph.type predicate from the given child
 // fastJson node. It returns the next fastJson node which doesn't store value for dgraph.type
 // predicate along with the extracted values for dgraph.type.
@@ -893,8 +974,8 @@ func (genc *graphQLEncoder) resolveCustomField(childField gqlSchema.Field,
 
 				// Step-3 & 4: Make the request to external HTTP endpoint using the URL and
 				// body. Then, Decode the HTTP response.
-				response, errs, hardErrs := fconf.MakeAndDecodeHTTPRequest(nil, url, body,
-					childField)
+				response, errs, hardErrs := fconf.MakeAndDecodeHTTPRequest(genc.ctx, nil, url,
+					body, childField)
 				if hardErrs != nil {
 					genc.errCh <- hardErrs
 					return
@@ -926,51 +1007,49 @@ func (genc *graphQLEncoder) resolveCustomField(childField gqlSchema.Field,
 		// a. Step 2-4
 		// b. Step 5
 		// c. Step 6-7
-		// i.e., step-a has to be executed only once irrespective of the number of parentNodes.
+		// i.e., step-a has to be executed only once per batch of parentNodes.
 		// Then, step-b can be executed in parallel for each parentNode.
 		// step-c can run in parallel to step-b in a separate goroutine to minimize contention.
 
-		// Step-2: Construct correct body for the batch request
-		var body interface{}
-		if isGraphqlReq {
-			body = map[string]interface{}{
-				"query":     fconf.RemoteGqlQuery,
-				"variables": map[string]interface{}{fconf.GraphqlBatchModeArgument: uniqueParents},
-			}
-		} else {
-			for i := range uniqueParents {
-				uniqueParents[i] = gqlSchema.SubstituteVarsInBody(fconf.Template,
-					uniqueParents[i].(map[string]interface{}))
-			}
-			if childField.HasLambdaDirective() {
-				body = gqlSchema.GetBodyForLambda(genc.ctx, childField, uniqueParents, nil)
-			} else {
-				body = uniqueParents
-			}
-		}
-
-		// Step-3 & 4: Make the request to external HTTP endpoint using the URL and
-		// body. Then, Decode the HTTP response.
-		response, errs, hardErrs := fconf.MakeAndDecodeHTTPRequest(nil, fconf.URL, body, childField)
-		if hardErrs != nil {
-			genc.errCh <- hardErrs
-			return
+		// Step-2, 3 & 4: Construct the body for the batch requests, make them and decode the
+		// responses. The lambda fields may be resolved for many parents, so their parents are split
+		// into batches of --lambda "batch-size=<n>;" parents, with at most
+		// --lambda "batch-concurrency=<n>;" requests in flight.
+		batchSize, concurrency := batchLimits(len(uniqueParents), childField.HasLambdaDirective())
+		numBatches := (len(uniqueParents) + batchSize - 1) / batchSize
+		batchedResult := make([]interface{}, 0, len(uniqueParents))
+		batchResults := make([][]interface{}, numBatches)
+		batchErrs := make([]x.GqlErrorList, numBatches)
+		batchHardErrs := make([]x.GqlErrorList, numBatches)
+		limiter := make(chan struct{}, concurrency)
+		batchWg := &sync.WaitGroup{}
+		for i := 0; i < numBatches; i++ {
+			batchWg.Add(1)
+			limiter <- struct{}{}
+			go func(idx int) {
+				defer func() {
+					<-limiter
+					batchWg.Done()
+				}()
+				start := idx * batchSize
+				end := start + batchSize
+				if end > len(uniqueParents) {
+					end = len(uniqueParents)
+				}
+				batchResults[idx], batchErrs[idx], batchHardErrs[idx] = genc.makeBatchRequest(
+					childField, fconf, isGraphqlReq, uniqueParents[start:end])
+			}(i)
 		}
+		batchWg.Wait()
 
-		batchedResult, ok := response.([]interface{})
-		if !ok {
-			genc.errCh <- append(errs, childField.GqlErrorf(nil,
-				"Evaluation of custom field failed because expected result of external"+
-					" BATCH request to be of list type, got: %v for field: %s within type: %s.",
-				response, childField.Name(), childField.GetObjectName()))
-			return
+		var errs, hardErrs x.GqlErrorList
+		for i := range batchResults {
+			errs = append(errs, batchErrs[i]...)
+			hardErrs = append(hardErrs, batchHardErrs[i]...)
+			batchedResult = append(batchedResult, batchResults[i]...)
 		}
-		if len(batchedResult) != len(uniqueParents) {
-			genc.errCh <- append(errs, childField.GqlErrorf(nil,
-				"Evaluation of custom field failed because expected result of "+
-					"external request to be of size %v, got: %v for field: %s within type: %s.",
-				len(uniqueParents), len(batchedResult), childField.Name(),
-				childField.GetObjectName()))
+		if hardErrs != nil {
+			genc.errCh <- append(errs, hardErrs...)
 			return
 		}
 
@@ -1010,6 +1089,73 @@ func (genc *graphQLEncoder) resolveCustomField(childField gqlSchema.Field,
 	}
 }
 
+// batchLimits returns the number of parents sent in each request resolving a BATCH mode custom
+// field for numParents parents, and the max number of such requests made at once. Only the
+// lambda fields are split into batches, the other custom fields are resolved in a single request.
+func batchLimits(numParents int, isLambda bool) (int, int) {
+	batchSize, concurrency := numParents, 1
+	if !isLambda {
+		return batchSize, concurrency
+	}
+	if size := int(x.Config.Lambda.BatchSize); size > 0 && size < batchSize {
+		batchSize = size
+	}
+	if c := int(x.Config.Lambda.BatchConcurrency); c > 1 {
+		concurrency = c
+	}
+	return batchSize, concurrency
+}
+
+// makeBatchRequest makes the external HTTP request of a BATCH mode custom field for the given
+// unique parents, and returns the decoded result for each of them. The second list of errors
+// is non-nil if the result couldn't be obtained.
+func (genc *graphQLEncoder) makeBatchRequest(childField gqlSchema.Field,
+	fconf *gqlSchema.FieldHTTPConfig, isGraphqlReq bool,
+	parents []interface{}) ([]interface{}, x.GqlErrorList, x.GqlErrorList) {
+	// Step-2: Construct correct body for the batch request
+	var body interface{}
+	if isGraphqlReq {
+		body = map[string]interface{}{
+			"query":     fconf.RemoteGqlQuery,
+			"variables": map[string]interface{}{fconf.GraphqlBatchModeArgument: parents},
+		}
+	} else {
+		for i := range parents {
+			parents[i] = gqlSchema.SubstituteVarsInBody(fconf.Template,
+				parents[i].(map[string]interface{}))
+		}
+		if childField.HasLambdaDirective() {
+			body = gqlSchema.GetBodyForLambda(genc.ctx, childField, parents, nil)
+		} else {
+			body = parents
+		}
+	}
+
+	// Step-3 & 4: Make the request to external HTTP endpoint using the URL and
+	// body. Then, Decode the HTTP response.
+	response, errs, hardErrs := fconf.MakeAndDecodeHTTPRequest(genc.ctx, nil, fconf.URL, body,
+		childField)
+	if hardErrs != nil {
+		return nil, errs, hardErrs
+	}
+
+	batchedResult, ok := response.([]interface{})
+	if !ok {
+		return nil, errs, x.GqlErrorList{childField.GqlErrorf(nil,
+			"Evaluation of custom field failed because expected result of external"+
+				" BATCH request to be of list type, got: %v for field: %s within type: %s.",
+			response, childField.Name(), childField.GetObjectName())}
+	}
+	if len(batchedResult) != len(parents) {
+		return nil, errs, x.GqlErrorList{childField.GqlErrorf(nil,
+			"Evaluation of custom field failed because expected result of "+
+				"external request to be of size %v, got: %v for field: %s within type: %s.",
+			len(parents), len(batchedResult), childField.Name(),
+			childField.GetObjectName())}
+	}
+	return batchedResult, errs, nil
+}
+
 // resolveNestedFields resolves fields which themselves don't have the @custom directive but their
 // children might.
 //
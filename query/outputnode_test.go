@@ -18,13 +18,17 @@ package query
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"runtime"
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
+	"github.com/dgraph-io/dgo/v210/protos/api"
+	"github.com/dgraph-io/dgraph/protos/pb"
 	"github.com/dgraph-io/dgraph/types"
 	"github.com/dgraph-io/dgraph/worker"
 	"github.com/dgraph-io/dgraph/x"
@@ -261,3 +265,51 @@ func TestChildrenOrder(t *testing.T) {
 	}
 	require.Nil(t, child)
 }
+
+func TestServerLatencyJSON(t *testing.T) {
+	l := &api.Latency{ParsingNs: 10, ProcessingNs: 200}
+	js, err := json.Marshal(Extensions{Latency: &ServerLatency{Latency: l}})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"server_latency":{"parsing_ns":10,"processing_ns":200}}`, string(js))
+
+	trace := NewLatencyTrace()
+	ctx := context.WithValue(context.Background(), LatencyTraceKey, trace)
+	name := x.NamespaceAttr(x.GalaxyNamespace, "name")
+	latencyTrace(ctx).addTask(&pb.Query{Attr: name},
+		&pb.Result{GroupId: 1, IndexNs: 30, FetchNs: 50}, 100)
+	latencyTrace(ctx).addTask(&pb.Query{Attr: name, Reverse: true},
+		&pb.Result{GroupId: 2, FetchNs: 20}, 40)
+	latencyTrace(ctx).AddPhase(PhaseIntersection, 5)
+	trace.AddPhase(PhaseJSONEncoding, time.Duration(7))
+	// The queries which aren't traced are ignored.
+	latencyTrace(context.Background()).AddPhase(PhaseIntersection, 5)
+
+	js, err = json.Marshal(Extensions{Latency: &ServerLatency{Latency: l, LatencyTrace: trace}})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"server_latency":{"parsing_ns":10,"processing_ns":200,
+		"groups_ns":{"1":100,"2":40},"predicates_ns":{"name":100,"~name":40},
+		"phases_ns":{"index_lookup":30,"value_fetch":70,"intersection":5,"json_encoding":7}}}`,
+		string(js))
+}
+
+func TestBatchLimits(t *testing.T) {
+	defer func(opts x.LambdaOptions) { x.Config.Lambda = opts }(x.Config.Lambda)
+	x.Config.Lambda.BatchSize, x.Config.Lambda.BatchConcurrency = 100, 4
+
+	size, concurrency := batchLimits(250, true)
+	require.Equal(t, 100, size)
+	require.Equal(t, 4, concurrency)
+	size, _ = batchLimits(30, true)
+	require.Equal(t, 30, size)
+
+	// The other custom fields are resolved in a single request.
+	size, concurrency = batchLimits(250, false)
+	require.Equal(t, 250, size)
+	require.Equal(t, 1, concurrency)
+
+	// A zero batch size doesn't limit the lambda requests.
+	x.Config.Lambda.BatchSize, x.Config.Lambda.BatchConcurrency = 0, 0
+	size, concurrency = batchLimits(250, true)
+	require.Equal(t, 250, size)
+	require.Equal(t, 1, concurrency)
+}
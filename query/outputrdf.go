@@ -112,7 +112,7 @@ func (b *rdfBuilder) rdfForSubgraph(sg *SubGraph) {
 			continue
 		}
 		if sg.IsInternal() {
-			if sg.Params.Expand != "" {
+			if sg.Params.isExpand() {
 				continue
 			}
 			// Check if we have val for the given uid. If you got uid then populate the rdf.
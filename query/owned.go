@@ -0,0 +1,251 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package query
+
+import (
+	"context"
+
+	"github.com/dgraph-io/dgraph/codec"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/worker"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/dgraph-io/sroar"
+	"github.com/pkg/errors"
+)
+
+// maxOwnedDepth bounds the nesting of the documents expanded by expand(_owned_), in case the owned
+// predicates form a cycle.
+const maxOwnedDepth = 32
+
+// ownedPredicates returns the predicates among preds having the @owned directive.
+func ownedPredicates(ctx context.Context, preds []string) (map[string]bool, error) {
+	owned := make(map[string]bool)
+	if len(preds) == 0 {
+		return owned, nil
+	}
+	schs, err := worker.GetSchemaOverNetwork(ctx, &pb.SchemaRequest{
+		Predicates: preds,
+		Fields:     []string{"owned"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, sch := range schs {
+		if sch.GetOwned() {
+			owned[sch.GetPredicate()] = true
+		}
+	}
+	return owned, nil
+}
+
+// ownedObjects returns the objects of the predicate for each of the subjects, at readTs.
+func ownedObjects(ctx context.Context, attr string, subjects *sroar.Bitmap,
+	readTs uint64) (map[uint64][]uint64, error) {
+	res, err := worker.ProcessTaskOverNetwork(ctx, &pb.Query{
+		ReadTs:  readTs,
+		Attr:    attr,
+		UidList: codec.ToList(subjects),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "while reading owned predicate %s", x.ParseAttr(attr))
+	}
+	objects := make(map[uint64][]uint64)
+	for i, uid := range subjects.ToArray() {
+		if i < len(res.UidMatrix) {
+			objects[uid] = codec.GetUids(res.UidMatrix[i])
+		}
+	}
+	return objects, nil
+}
+
+// OwnedDeletes returns the edges deleting the nodes that the mutation detaches from their owner.
+//
+// Setting a predicate with the @owned directive replaces all its objects, so that updating a
+// nested JSON document replaces its children. The edges to the children missing from the new
+// document are deleted, along with the children themselves unless they are attached to another
+// owner by the mutation. Deleting an owned edge, or a whole node, deletes the owned children as
+// well. The deleted children are traversed in turn, so that their own children are deleted too.
+// The edges are read at readTs, and ownership isn't enforced for galaxy operations.
+func OwnedDeletes(ctx context.Context, edges []*pb.DirectedEdge, readTs uint64) (
+	[]*pb.DirectedEdge, error) {
+	if x.IsGalaxyOperation(ctx) {
+		return nil, nil
+	}
+	ns, err := x.ExtractNamespace(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while deleting owned nodes")
+	}
+
+	var allowedPreds []string
+	var attrs []string
+	seen := make(map[string]bool)
+	for _, edge := range edges {
+		if allowedPreds == nil {
+			allowedPreds = edge.AllowedPreds
+		}
+		if edge.Attr == x.Star {
+			continue
+		}
+		attr := x.NamespaceAttr(ns, edge.Attr)
+		if !seen[attr] {
+			seen[attr] = true
+			attrs = append(attrs, attr)
+		}
+	}
+	owned, err := ownedPredicates(ctx, attrs)
+	if err != nil {
+		return nil, err
+	}
+
+	// attached holds the objects set for each owned predicate of each subject, and kept holds all
+	// the nodes attached to an owner by the mutation.
+	attached := make(map[string]map[uint64]map[uint64]bool)
+	kept := make(map[uint64]bool)
+	replaced := make(map[string]*sroar.Bitmap)
+	deletedNodes := sroar.NewBitmap()
+	detached := sroar.NewBitmap()
+	for _, edge := range edges {
+		isStar := string(edge.Value) == x.Star
+		if edge.Attr == x.Star {
+			if edge.Op == pb.DirectedEdge_DEL && isStar {
+				deletedNodes.Set(edge.Entity)
+			}
+			continue
+		}
+		attr := x.NamespaceAttr(ns, edge.Attr)
+		if !owned[attr] {
+			continue
+		}
+		if replaced[attr] == nil {
+			replaced[attr] = sroar.NewBitmap()
+		}
+		switch {
+		case edge.Op == pb.DirectedEdge_SET:
+			if attached[attr] == nil {
+				attached[attr] = make(map[uint64]map[uint64]bool)
+			}
+			if attached[attr][edge.Entity] == nil {
+				attached[attr][edge.Entity] = make(map[uint64]bool)
+			}
+			attached[attr][edge.Entity][edge.ValueId] = true
+			kept[edge.ValueId] = true
+			replaced[attr].Set(edge.Entity)
+		case isStar:
+			replaced[attr].Set(edge.Entity)
+		case edge.ValueId != 0:
+			detached.Set(edge.ValueId)
+		}
+	}
+
+	var out []*pb.DirectedEdge
+	for attr, subjects := range replaced {
+		if subjects.IsEmpty() {
+			continue
+		}
+		objects, err := ownedObjects(ctx, attr, subjects, readTs)
+		if err != nil {
+			return nil, err
+		}
+		for subject, objs := range objects {
+			for _, obj := range objs {
+				if attached[attr][subject][obj] {
+					continue
+				}
+				out = append(out, &pb.DirectedEdge{
+					Entity:    subject,
+					Attr:      x.ParseAttr(attr),
+					ValueId:   obj,
+					ValueType: pb.Posting_UID,
+					Op:        pb.DirectedEdge_DEL,
+				})
+				detached.Set(obj)
+			}
+		}
+	}
+
+	// The children of the deleted nodes are detached too.
+	orphans := sroar.NewBitmap()
+	for _, uid := range detached.ToArray() {
+		if !kept[uid] {
+			orphans.Set(uid)
+		}
+	}
+	children, err := ownedChildren(ctx, ns, deletedNodes, readTs)
+	if err != nil {
+		return nil, err
+	}
+	for _, uid := range children {
+		if !kept[uid] {
+			orphans.Set(uid)
+		}
+	}
+
+	deleted := deletedNodes.Clone()
+	for !orphans.IsEmpty() {
+		for _, uid := range orphans.ToArray() {
+			out = append(out, &pb.DirectedEdge{
+				Entity:       uid,
+				Attr:         x.Star,
+				Value:        []byte(x.Star),
+				ValueType:    pb.Posting_DEFAULT,
+				Op:           pb.DirectedEdge_DEL,
+				AllowedPreds: allowedPreds,
+			})
+		}
+		deleted.Or(orphans)
+
+		children, err := ownedChildren(ctx, ns, orphans, readTs)
+		if err != nil {
+			return nil, err
+		}
+		orphans = sroar.NewBitmap()
+		for _, uid := range children {
+			if !kept[uid] && !deleted.Contains(uid) {
+				orphans.Set(uid)
+			}
+		}
+	}
+	return out, nil
+}
+
+// ownedChildren returns the objects of the owned predicates of the nodes, according to their
+// types.
+func ownedChildren(ctx context.Context, ns uint64, nodes *sroar.Bitmap, readTs uint64) (
+	[]uint64, error) {
+	if nodes.IsEmpty() {
+		return nil, nil
+	}
+	types, err := getNodeTypes(ctx, &SubGraph{DestMap: nodes, ReadTs: readTs})
+	if err != nil {
+		return nil, err
+	}
+	owned, err := ownedPredicates(ctx, uniquePreds(getPredicatesFromTypes(ns, types)))
+	if err != nil {
+		return nil, err
+	}
+	var children []uint64
+	for attr := range owned {
+		objects, err := ownedObjects(ctx, attr, nodes, readTs)
+		if err != nil {
+			return nil, err
+		}
+		for _, objs := range objects {
+			children = append(children, objs...)
+		}
+	}
+	return children, nil
+}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"math"
 	"math/rand"
+	"path"
 	"sort"
 	"strconv"
 	"strings"
@@ -117,6 +118,9 @@ type params struct {
 	Offset int
 	// Random is the value of the "random" parameter
 	Random int
+	// Sample is the value of the "sample" parameter, the number of uids picked uniformly at
+	// random in each list of the results.
+	Sample int
 	// AfterUID is the value of the "after" parameter.
 	AfterUID uint64
 	// DoCount is true if the count of the predicate is requested instead of its value.
@@ -167,6 +171,10 @@ type params struct {
 	Cascade *CascadeArgs
 	// IgnoreReflex is true if the @ignorereflex directive is specified.
 	IgnoreReflex bool
+	// ValidAt is the time given by the @validAt directive, in seconds since the epoch. Only the
+	// edges valid at that time are read. It is zero if the edges are read irrespective of their
+	// valid time.
+	ValidAt int64
 
 	// ShortestPathArgs contains the from and to functions to execute a shortest path query.
 	ShortestPathArgs gql.ShortestPathArgs
@@ -191,6 +199,17 @@ type params struct {
 	IgnoreResult bool
 	// Expand holds the argument passed to the expand function.
 	Expand string
+	// ExpandPatterns holds the glob patterns of the predicates to expand, matched against the
+	// schema.
+	ExpandPatterns []string
+	// ExpandDepth is the number of levels of uid predicates left to expand.
+	ExpandDepth int
+	// Join holds the arguments of join(), if the SubGraph is a virtual edge to the nodes of a
+	// variable.
+	Join *gql.JoinArgs
+	// OwnedDepth is the number of owned predicates followed by expand(_owned_) to reach this
+	// SubGraph.
+	OwnedDepth int
 
 	// IsGroupBy is true if @groupby is specified.
 	IsGroupBy bool // True if @groupby is specified.
@@ -211,6 +230,11 @@ type params struct {
 	AllowedPreds []string
 }
 
+// isExpand returns true if the SubGraph is an expand() of predicates.
+func (p *params) isExpand() bool {
+	return p.Expand != "" || len(p.ExpandPatterns) > 0
+}
+
 // CascadeArgs stores the arguments needed to process @cascade directive.
 // It is introduced to ensure correct behaviour for cascade with pagination.
 type CascadeArgs struct {
@@ -230,6 +254,7 @@ type Function struct {
 	IsCount    bool      // gt(count(friends),0)
 	IsValueVar bool      // eq(val(s), 10)
 	IsLenVar   bool      // eq(len(s), 10)
+	Coerce     string    // gt(toInt(age), 18) has the type int.
 }
 
 // SubGraph is the way to represent data. It contains both the request parameters and the response.
@@ -275,6 +300,9 @@ type SubGraph struct {
 	// uidMatrix is a slice of List. There would be one List corresponding to each uid in SrcUIDs.
 	// In graph terms, a list is a slice of outgoing edges from a node.
 	uidMatrix []*pb.List
+	// numSampled is the number of lists of uidMatrix which were sampled down to the expand sample
+	// of the request, because their node had more edges.
+	numSampled uint64
 
 	// facetsMatrix contains the facet values. There would a list corresponding to each uid in
 	// uidMatrix.
@@ -338,6 +366,7 @@ func (sg *SubGraph) createSrcFunction(gf *gql.Function) {
 		IsCount:    gf.IsCount,
 		IsValueVar: gf.IsValueVar,
 		IsLenVar:   gf.IsLenVar,
+		Coerce:     gf.Coerce,
 	}
 
 	// type function is just an alias for eq(type, "dgraph.type").
@@ -351,7 +380,7 @@ func (sg *SubGraph) createSrcFunction(gf *gql.Function) {
 	}
 
 	if gf.Lang != "" {
-		sg.Params.Langs = append(sg.Params.Langs, gf.Lang)
+		sg.Params.Langs = append(sg.Params.Langs, strings.Split(gf.Lang, ":")...)
 	}
 }
 
@@ -544,7 +573,7 @@ func treeCopy(gq *gql.GraphQuery, sg *SubGraph) error {
 	attrsSeen := make(map[string]struct{})
 
 	for _, gchild := range gq.Children {
-		if sg.Params.Alias == "shortest" && gchild.Expand != "" {
+		if sg.Params.Alias == "shortest" && gchild.IsExpand() {
 			return errors.Errorf("expand() not allowed inside shortest")
 		}
 
@@ -561,22 +590,26 @@ func treeCopy(gq *gql.GraphQuery, sg *SubGraph) error {
 		attrsSeen[key] = struct{}{}
 
 		args := params{
-			Alias:        gchild.Alias,
-			Expand:       gchild.Expand,
-			Facet:        gchild.Facets,
-			FacetsOrder:  gchild.FacetsOrder,
-			FacetVar:     gchild.FacetVar,
-			GetUid:       sg.Params.GetUid,
-			IgnoreReflex: sg.Params.IgnoreReflex,
-			Langs:        gchild.Langs,
-			NeedsVar:     append(gchild.NeedsVar[:0:0], gchild.NeedsVar...),
-			Normalize:    gchild.Normalize || sg.Params.Normalize,
-			Order:        gchild.Order,
-			Var:          gchild.Var,
-			GroupbyAttrs: gchild.GroupbyAttrs,
-			IsGroupBy:    gchild.IsGroupby,
-			IsInternal:   gchild.IsInternal,
-			Cascade:      &CascadeArgs{},
+			Alias:          gchild.Alias,
+			Expand:         gchild.Expand,
+			ExpandPatterns: gchild.ExpandPatterns,
+			ExpandDepth:    gchild.ExpandDepth,
+			Join:           gchild.Join,
+			Facet:          gchild.Facets,
+			FacetsOrder:    gchild.FacetsOrder,
+			FacetVar:       gchild.FacetVar,
+			GetUid:         sg.Params.GetUid,
+			IgnoreReflex:   sg.Params.IgnoreReflex,
+			ValidAt:        sg.Params.ValidAt,
+			Langs:          gchild.Langs,
+			NeedsVar:       append(gchild.NeedsVar[:0:0], gchild.NeedsVar...),
+			Normalize:      gchild.Normalize || sg.Params.Normalize,
+			Order:          gchild.Order,
+			Var:            gchild.Var,
+			GroupbyAttrs:   gchild.GroupbyAttrs,
+			IsGroupBy:      gchild.IsGroupby,
+			IsInternal:     gchild.IsInternal,
+			Cascade:        &CascadeArgs{},
 		}
 
 		// Inherit from the parent.
@@ -662,6 +695,14 @@ func treeCopy(gq *gql.GraphQuery, sg *SubGraph) error {
 			dst.facetsFilter = facetsFilter
 		}
 
+		if gchild.ValidAt != "" {
+			validAt, err := parseValidAt(gchild.ValidAt)
+			if err != nil {
+				return err
+			}
+			dst.Params.ValidAt = validAt
+		}
+
 		sg.Children = append(sg.Children, dst)
 		if err := treeCopy(gchild, dst); err != nil {
 			return err
@@ -757,6 +798,17 @@ func (args *params) fill(gq *gql.GraphQuery) error {
 		args.Random = int(random)
 	}
 
+	if v, ok := gq.Args["sample"]; ok {
+		sample, err := strconv.ParseInt(v, 0, 32)
+		if err != nil {
+			return err
+		}
+		if sample <= 0 {
+			return errors.Errorf("The sample size must be positive, got %d", sample)
+		}
+		args.Sample = int(sample)
+	}
+
 	return nil
 }
 
@@ -779,8 +831,24 @@ type ContextKey int
 const (
 	// DebugKey is the key used to toggle debug mode.
 	DebugKey ContextKey = iota
+	// MsgpackKey is the key used to encode the response of a DQL query in MessagePack, instead
+	// of JSON. The response is still returned in api.Response.Json.
+	MsgpackKey
+	// GraphKey is the key used to encode the response of a DQL query in the graph format, which
+	// lists the nodes and the edges of the result. See ToGraph.
+	GraphKey
+	// ValueVarsKey is the key of a *ValueVars, which is set to the value variables of a DQL
+	// query instead of encoding its response.
+	ValueVarsKey
+	// LatencyTraceKey is the key of a *LatencyTrace, which is filled with the breakdown of the
+	// time spent in processing the query.
+	LatencyTraceKey
 )
 
+// ValueVars maps the name of each value variable of a query to the values of the variable. The
+// value of an aggregation at the root of a query block is mapped from math.MaxUint64.
+type ValueVars map[string]map[uint64]types.Val
+
 func isDebug(ctx context.Context) bool {
 	var debug bool
 
@@ -799,6 +867,12 @@ func isDebug(ctx context.Context) bool {
 	return debug || d
 }
 
+// isGraphFormat tells whether the response is encoded in the graph format, which needs the uids.
+func isGraphFormat(ctx context.Context) bool {
+	graph, _ := ctx.Value(GraphKey).(bool)
+	return graph
+}
+
 func (sg *SubGraph) populate(uids []uint64) error {
 	// Put sorted entries in matrix.
 	sort.Slice(uids, func(i, j int) bool { return uids[i] < uids[j] })
@@ -820,7 +894,7 @@ func newGraph(ctx context.Context, gq *gql.GraphQuery) (*SubGraph, error) {
 	args := params{
 		Alias:            gq.Alias,
 		Cascade:          &CascadeArgs{Fields: gq.Cascade},
-		GetUid:           isDebug(ctx),
+		GetUid:           isDebug(ctx) || isGraphFormat(ctx),
 		IgnoreReflex:     gq.IgnoreReflex,
 		IsEmpty:          gq.IsEmpty,
 		Langs:            gq.Langs,
@@ -836,6 +910,11 @@ func newGraph(ctx context.Context, gq *gql.GraphQuery) (*SubGraph, error) {
 		IsGroupBy:        gq.IsGroupby,
 		AllowedPreds:     gq.AllowedPreds,
 	}
+	validAt, err := parseValidAt(gq.ValidAt)
+	if err != nil {
+		return nil, err
+	}
+	args.ValidAt = validAt
 
 	// Remove pagination arguments from the query if @cascade is mentioned since
 	// pagination will be applied post processing the data.
@@ -895,6 +974,18 @@ func newGraph(ctx context.Context, gq *gql.GraphQuery) (*SubGraph, error) {
 	return sg, nil
 }
 
+// parseValidAt returns the time of the @validAt directive in seconds since the epoch.
+func parseValidAt(val string) (int64, error) {
+	if val == "" {
+		return 0, nil
+	}
+	t, err := types.ParseTime(val)
+	if err != nil {
+		return 0, errors.Wrapf(err, "while parsing the time of @validAt")
+	}
+	return t.Unix(), nil
+}
+
 func toFacetsFilter(gft *gql.FilterTree) (*pb.FilterTree, error) {
 	if gft == nil {
 		return nil, nil
@@ -940,6 +1031,7 @@ func createTaskQuery(ctx context.Context, sg *SubGraph) (*pb.Query, error) {
 		srcFunc = &pb.SrcFunction{}
 		srcFunc.Name = sg.SrcFunc.Name
 		srcFunc.IsCount = sg.SrcFunc.IsCount
+		srcFunc.Coerce = sg.SrcFunc.Coerce
 		for _, arg := range sg.SrcFunc.Args {
 			srcFunc.Args = append(srcFunc.Args, arg.Value)
 			if arg.IsValueVar {
@@ -970,6 +1062,13 @@ func createTaskQuery(ctx context.Context, sg *SubGraph) (*pb.Query, error) {
 		ExpandAll:    sg.Params.ExpandAll,
 		First:        first,
 		Offset:       offset,
+		ValidAt:      sg.Params.ValidAt,
+	}
+
+	// A single facet order can be served from the facet index of the predicate.
+	if len(sg.Params.FacetsOrder) == 1 && len(sg.Params.Order) == 0 {
+		out.FacetOrder = sg.Params.FacetsOrder[0].Key
+		out.FacetOrderDesc = sg.Params.FacetsOrder[0].Desc
 	}
 
 	// Use the orderedUIDs if present, it will only be present for the shortest path case.
@@ -1773,7 +1872,9 @@ func (sg *SubGraph) fillVars(mp map[string]varValue) error {
 			// TODO: If we support value vars for list type then this needn't be true
 			sg.ExpandPreds = l.strList
 
-		case (v.Typ == gql.UidVar && sg.SrcFunc != nil && sg.SrcFunc.Name == "uid_in"):
+		case (v.Typ == gql.UidVar && sg.SrcFunc != nil &&
+			(sg.SrcFunc.Name == "uid_in" || sg.SrcFunc.Name == "not_uid_in" ||
+				sg.SrcFunc.Name == "eq")):
 			srcFuncArgs := sg.SrcFunc.Args[:0]
 			itr := l.UidMap.NewIterator()
 			for uid := itr.Next(); uid > 0; uid = itr.Next() {
@@ -1983,7 +2084,7 @@ func expandSubgraph(ctx context.Context, sg *SubGraph) ([]*SubGraph, error) {
 	for i := 0; i < len(sg.Children); i++ {
 		child := sg.Children[i]
 
-		if child.Params.Expand == "" {
+		if !child.Params.isExpand() {
 			out = append(out, child)
 			continue
 		}
@@ -1995,30 +2096,16 @@ func expandSubgraph(ctx context.Context, sg *SubGraph) ([]*SubGraph, error) {
 		}
 
 		switch child.Params.Expand {
-		// It could be expand(_all_) or expand(val(x)).
-		case "_all_":
-			span.Annotate(nil, "expand(_all_)")
+		// It could be expand(_all_), expand(_owned_) or expand(val(x)).
+		case "_all_", "_owned_":
+			span.Annotate(nil, "expand("+child.Params.Expand+")")
 			if len(typeNames) == 0 {
 				break
 			}
+			preds = allowedPredicates(sg, getPredicatesFromTypes(namespace, typeNames))
 
-			preds = getPredicatesFromTypes(namespace, typeNames)
-			// We check if enterprise is enabled and only
-			// restrict preds to allowed preds if ACL is turned on.
-			if worker.EnterpriseEnabled() && sg.Params.AllowedPreds != nil {
-				// Take intersection of both the predicate lists
-				intersectPreds := make([]string, 0)
-				hashMap := make(map[string]bool)
-				for _, allowedPred := range sg.Params.AllowedPreds {
-					hashMap[allowedPred] = true
-				}
-				for _, pred := range preds {
-					if _, found := hashMap[pred]; found {
-						intersectPreds = append(intersectPreds, pred)
-					}
-				}
-				preds = intersectPreds
-			}
+		case "":
+			// Only the predicates matching the patterns are expanded.
 
 		default:
 			if len(child.ExpandPreds) > 0 {
@@ -2033,6 +2120,14 @@ func expandSubgraph(ctx context.Context, sg *SubGraph) ([]*SubGraph, error) {
 				preds = getPredicatesFromTypes(namespace, typeNames)
 			}
 		}
+		if len(child.Params.ExpandPatterns) > 0 {
+			span.Annotate(nil, "expand patterns")
+			matched, err := matchPredicates(ctx, namespace, child.Params.ExpandPatterns)
+			if err != nil {
+				return out, err
+			}
+			preds = append(preds, allowedPredicates(sg, matched)...)
+		}
 		preds = uniquePreds(preds)
 
 		// There's a types filter at this level so filter out any non-uid predicates
@@ -2044,6 +2139,23 @@ func expandSubgraph(ctx context.Context, sg *SubGraph) ([]*SubGraph, error) {
 			}
 		}
 
+		var owned map[string]bool
+		if child.Params.Expand == "_owned_" && child.Params.OwnedDepth < maxOwnedDepth {
+			if owned, err = ownedPredicates(ctx, preds); err != nil {
+				return out, err
+			}
+		}
+		nested := make(map[string]bool)
+		if child.Params.ExpandDepth > 1 && len(preds) > 0 {
+			uidPreds, err := filterUidPredicates(ctx, preds)
+			if err != nil {
+				return out, err
+			}
+			for _, pred := range uidPreds {
+				nested[pred] = true
+			}
+		}
+
 		for _, pred := range preds {
 			// Convert attribute name for the given namespace.
 			temp := &SubGraph{
@@ -2061,6 +2173,8 @@ func expandSubgraph(ctx context.Context, sg *SubGraph) ([]*SubGraph, error) {
 			}
 			temp.Params.IsInternal = false
 			temp.Params.Expand = ""
+			temp.Params.ExpandPatterns = nil
+			temp.Params.ExpandDepth = 0
 			temp.Params.Facet = &pb.FacetParams{AllKeys: true}
 			for _, cf := range child.Filters {
 				s := &SubGraph{}
@@ -2068,10 +2182,27 @@ func expandSubgraph(ctx context.Context, sg *SubGraph) ([]*SubGraph, error) {
 				temp.Filters = append(temp.Filters, s)
 			}
 
-			// Go through each child, create a copy and attach to temp.Children.
-			for _, cc := range child.Children {
+			if nested[pred] {
+				// The objects of the uid predicates are expanded in turn, down to the depth of
+				// the expansion. The children of the expansion are at its last level.
 				s := &SubGraph{}
-				recursiveCopy(s, cc)
+				recursiveCopy(s, child)
+				s.Params.ExpandDepth--
+				temp.Children = append(temp.Children, s)
+			} else {
+				// Go through each child, create a copy and attach to temp.Children.
+				for _, cc := range child.Children {
+					s := &SubGraph{}
+					recursiveCopy(s, cc)
+					temp.Children = append(temp.Children, s)
+				}
+			}
+			// The objects of owned predicates are parts of the same document, so they are
+			// expanded as well.
+			if owned[pred] {
+				s := &SubGraph{}
+				recursiveCopy(s, child)
+				s.Params.OwnedDepth++
 				temp.Children = append(temp.Children, s)
 			}
 
@@ -2157,6 +2288,16 @@ func ProcessGraph(ctx context.Context, sg, parent *SubGraph, rch chan error) {
 		// Each filter use it's own (shallow) copy of SrcUIDs, so there is no race conditions,
 		// when multiple filters replace their sg.DestUIDs
 		sg.DestMap = codec.FromList(sg.SrcUIDs)
+	case sg.Params.Join != nil:
+		// The DestMap is set by fillVars to the nodes of the variable of the join.
+		if err := sg.fillVars(sg.Params.ParentVars); err != nil {
+			rch <- err
+			return
+		}
+		if err := sg.processJoin(ctx); err != nil {
+			rch <- err
+			return
+		}
 	default:
 		isInequalityFn := sg.SrcFunc != nil && isInequalityFn(sg.SrcFunc.Name)
 		switch {
@@ -2191,7 +2332,9 @@ func ProcessGraph(ctx context.Context, sg, parent *SubGraph, rch chan error) {
 				rch <- err
 				return
 			}
+			taskStart := time.Now()
 			result, err := worker.ProcessTaskOverNetwork(ctx, taskQuery)
+			latencyTrace(ctx).addTask(taskQuery, result, time.Since(taskStart))
 			switch {
 			case err != nil && strings.Contains(err.Error(), worker.ErrNonExistentTabletMessage):
 				sg.UnknownAttr = true
@@ -2203,6 +2346,7 @@ func ProcessGraph(ctx context.Context, sg, parent *SubGraph, rch chan error) {
 			}
 
 			sg.uidMatrix = result.UidMatrix
+			sg.numSampled = result.NumSampled
 			sg.valueMatrix = result.ValueMatrix
 			sg.facetsMatrix = result.FacetMatrix
 			sg.counts = result.Counts
@@ -2218,11 +2362,13 @@ func ProcessGraph(ctx context.Context, sg, parent *SubGraph, rch chan error) {
 				sg.counts = make([]uint32, len(sg.uidMatrix))
 			}
 
+			intersectStart := time.Now()
 			if result.IntersectDest {
 				sg.DestMap = codec.Intersect(result.UidMatrix)
 			} else {
 				sg.DestMap = codec.Merge(result.UidMatrix)
 			}
+			latencyTrace(ctx).AddPhase(PhaseIntersection, time.Since(intersectStart))
 
 			if parent == nil {
 				// I'm root. We reach here if root had a function.
@@ -2270,6 +2416,7 @@ func ProcessGraph(ctx context.Context, sg, parent *SubGraph, rch chan error) {
 			return
 		}
 
+		intersectStart := time.Now()
 		hasNils := false
 		// Now apply the results from filter.
 		var bitmaps []*sroar.Bitmap
@@ -2311,6 +2458,7 @@ func ProcessGraph(ctx context.Context, sg, parent *SubGraph, rch chan error) {
 				sg.DestMap.And(r)
 			}
 		}
+		latencyTrace(ctx).AddPhase(PhaseIntersection, time.Since(intersectStart))
 	}
 
 	if len(sg.Params.Order) == 0 && len(sg.Params.FacetsOrder) == 0 {
@@ -2341,6 +2489,13 @@ func ProcessGraph(ctx context.Context, sg, parent *SubGraph, rch chan error) {
 		}
 	}
 
+	if sg.Params.Sample > 0 {
+		if err = sg.applySample(); err != nil {
+			rch <- err
+			return
+		}
+	}
+
 	// Here we consider handling count with filtering. We do this after
 	// pagination because otherwise, we need to do the count with pagination
 	// taken into account. For example, a PL might have only 50 entries but the
@@ -2477,6 +2632,34 @@ func (sg *SubGraph) applyRandom(ctx context.Context) error {
 	return nil
 }
 
+// applySample keeps sg.Params.Sample uids picked uniformly at random in each list of uidMatrix,
+// without materializing the lists which aren't ordered. The uids of the ordered lists keep their
+// order, and the lists having fewer uids are kept as they are.
+func (sg *SubGraph) applySample() error {
+	sg.updateUidMatrix()
+
+	n := uint64(sg.Params.Sample)
+	for i, ul := range sg.uidMatrix {
+		if len(ul.SortedUids) > 0 {
+			ranks := codec.RandomRanks(uint64(len(ul.SortedUids)), n)
+			uids := make([]uint64, 0, len(ranks))
+			for _, r := range ranks {
+				uids = append(uids, ul.SortedUids[r])
+			}
+			ul.SortedUids = uids
+			continue
+		}
+		bm, err := codec.RandomSample(codec.FromListNoCopy(ul), n)
+		if err != nil {
+			return errors.Wrapf(err, "while sampling uids")
+		}
+		sg.uidMatrix[i] = codec.ToList(bm)
+	}
+
+	sg.DestMap = codec.Merge(sg.uidMatrix)
+	return nil
+}
+
 // applyPagination applies count and offset to lists inside uidMatrix.
 func (sg *SubGraph) applyPagination(ctx context.Context) error {
 	if sg.Params.Count == 0 && sg.Params.Offset == 0 { // No pagination.
@@ -2512,13 +2695,12 @@ func (sg *SubGraph) applyOrderAndPagination(ctx context.Context) error {
 		return sg.sortAndPaginateUsingFacet(ctx)
 	}
 
-	for _, it := range sg.Params.NeedsVar {
-		// TODO(pawan) - Return error if user uses var order with predicates.
-		if len(sg.Params.Order) > 0 && it.Name == sg.Params.Order[0].Attr &&
-			(it.Typ == gql.ValueVar) {
-			// If the Order name is same as var name and it's a value variable, we sort using that variable.
+	if idx := sg.orderVarIdx(); idx >= 0 {
+		// One of the orders is by a value variable, e.g. the result of math().
+		if len(sg.Params.Order) == 1 {
 			return sg.sortAndPaginateUsingVar(ctx)
 		}
+		return sg.sortAndPaginateUsingVarAndPreds(ctx, idx)
 	}
 
 	// Todo: fix offset for cascade queries.
@@ -2574,6 +2756,47 @@ func (sg *SubGraph) applyOrderAndPagination(ctx context.Context) error {
 	return nil
 }
 
+// orderVarIdx returns the index of the order by a value variable, or -1 if all the orders are by
+// predicates.
+func (sg *SubGraph) orderVarIdx() int {
+	for i, o := range sg.Params.Order {
+		for _, v := range sg.Params.NeedsVar {
+			if v.Name == o.Attr && v.Typ == gql.ValueVar {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// sortAndPaginateUsingVarAndPreds sorts by the orders mixing predicates and the value variable
+// of the order at varIdx, whose values are in UidToVal.
+func (sg *SubGraph) sortAndPaginateUsingVarAndPreds(ctx context.Context, varIdx int) error {
+	if sg.Params.UidToVal == nil {
+		return errors.Errorf("Variable: [%s] used before definition.",
+			sg.Params.Order[varIdx].Attr)
+	}
+	ns, err := x.ExtractNamespace(ctx)
+	if err != nil {
+		return errors.Wrapf(err, "While ordering and paginating")
+	}
+	sortMsg := &pb.SortMessage{
+		Order:     sg.createOrderForTask(ns),
+		UidMatrix: sg.uidMatrix,
+		Offset:    int32(sg.Params.Offset),
+		Count:     int32(sg.Params.Count),
+		ReadTs:    sg.ReadTs,
+	}
+	result, err := worker.SortWithValues(ctx, sortMsg, varIdx, sg.Params.UidToVal)
+	if err != nil {
+		return err
+	}
+	sg.uidMatrix = result.UidMatrix
+	// Update the destUids as we might have removed some UIDs.
+	sg.updateDestUids()
+	return nil
+}
+
 // createOrderForTask creates namespaced aware order for the task.
 func (sg *SubGraph) createOrderForTask(ns uint64) []*pb.Order {
 	out := []*pb.Order{}
@@ -2730,7 +2953,7 @@ func (sg *SubGraph) sortAndPaginateUsingVar(ctx context.Context) error {
 func isValidArg(a string) bool {
 	switch a {
 	case "numpaths", "from", "to", "orderasc", "orderdesc", "first", "offset", "after", "depth",
-		"minweight", "maxweight", "random":
+		"minweight", "maxweight", "random", "sample":
 		return true
 	}
 	return false
@@ -2740,7 +2963,7 @@ func isValidArg(a string) bool {
 func isValidFuncName(f string) bool {
 	switch f {
 	case "anyofterms", "allofterms", "val", "regexp", "anyoftext", "alloftext",
-		"has", "uid", "uid_in", "anyof", "allof", "type", "match":
+		"has", "uid", "uid_in", "not_uid_in", "anyof", "allof", "type", "match":
 		return true
 	}
 	return isInequalityFn(f) || types.IsGeoFunc(f)
@@ -2756,7 +2979,7 @@ func isInequalityFn(f string) bool {
 
 func isAggregatorFn(f string) bool {
 	switch f {
-	case "min", "max", "sum", "avg":
+	case "min", "max", "sum", "avg", "countdistinct":
 		return true
 	}
 	return false
@@ -2788,13 +3011,17 @@ func getPredicatesFromTypes(namespace uint64, typeNames []string) []string {
 	var preds []string
 
 	for _, typeName := range typeNames {
-		typeDef, ok := schema.State().GetType(x.NamespaceAttr(namespace, typeName))
-		if !ok {
-			continue
-		}
+		name := x.NamespaceAttr(namespace, typeName)
+		// The fields of a type include the ones of the types it extends.
+		for _, name := range append([]string{name}, schema.State().Supertypes(name)...) {
+			typeDef, ok := schema.State().GetType(name)
+			if !ok {
+				continue
+			}
 
-		for _, field := range typeDef.Fields {
-			preds = append(preds, field.Predicate)
+			for _, field := range typeDef.Fields {
+				preds = append(preds, field.Predicate)
+			}
 		}
 	}
 	return preds
@@ -2818,6 +3045,59 @@ func filterUidPredicates(ctx context.Context, preds []string) ([]string, error)
 	return filteredPreds, nil
 }
 
+// allowedPredicates returns the predicates among preds that the user is allowed to read.
+func allowedPredicates(sg *SubGraph, preds []string) []string {
+	// We check if enterprise is enabled and only
+	// restrict preds to allowed preds if ACL is turned on.
+	if !worker.EnterpriseEnabled() || sg.Params.AllowedPreds == nil {
+		return preds
+	}
+	// Take intersection of both the predicate lists
+	intersectPreds := make([]string, 0)
+	hashMap := make(map[string]bool)
+	for _, allowedPred := range sg.Params.AllowedPreds {
+		hashMap[allowedPred] = true
+	}
+	for _, pred := range preds {
+		if _, found := hashMap[pred]; found {
+			intersectPreds = append(intersectPreds, pred)
+		}
+	}
+	return intersectPreds
+}
+
+// matchPattern returns true if the name of the predicate matches one of the glob patterns given
+// to expand(). The reserved predicates are only matched by the patterns starting with "dgraph.".
+func matchPattern(attr string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if x.IsReservedPredicate(attr) && !strings.HasPrefix(pattern, "dgraph.") {
+			continue
+		}
+		if ok, err := path.Match(pattern, attr); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+// matchPredicates returns the predicates of the namespace in the schema matching one of the glob
+// patterns given to expand().
+func matchPredicates(ctx context.Context, namespace uint64, patterns []string) ([]string,
+	error) {
+	schs, err := worker.GetSchemaOverNetwork(ctx, &pb.SchemaRequest{Fields: []string{"type"}})
+	if err != nil {
+		return nil, err
+	}
+	var preds []string
+	for _, sch := range schs {
+		pred := sch.GetPredicate()
+		if x.ParseNamespace(pred) == namespace && matchPattern(x.ParseAttr(pred), patterns) {
+			preds = append(preds, pred)
+		}
+	}
+	return preds, nil
+}
+
 // UidsToHex converts the new UIDs to hex string.
 func UidsToHex(m map[string]uint64) map[string]string {
 	res := make(map[string]string)
@@ -2845,6 +3125,17 @@ type Request struct {
 	Vars map[string]varValue
 }
 
+// ValueVars returns the value variables of the processed request.
+func (req *Request) ValueVars() ValueVars {
+	vars := make(ValueVars)
+	for name, v := range req.Vars {
+		if v.Vals != nil {
+			vars[name] = v.Vals
+		}
+	}
+	return vars
+}
+
 // ProcessQuery processes query part of the request (without mutations).
 // Fills Subgraphs and Vars.
 // It can process multiple query blocks that are part of the query..
@@ -3015,6 +3306,9 @@ type ExecutionResult struct {
 	SchemaNode []*pb.SchemaNode
 	Types      []*pb.TypeUpdate
 	Metrics    map[string]uint64
+	// NumSampled is the number of nodes whose edges were sampled down to the expand sample of the
+	// request.
+	NumSampled uint64
 }
 
 // Process handles a query request.
@@ -3028,6 +3322,9 @@ func (req *Request) Process(ctx context.Context) (er ExecutionResult, err error)
 	metrics := make(map[string]uint64)
 	for _, sg := range er.Subgraphs {
 		calculateMetrics(sg, metrics)
+		sg.recurse(func(sg *SubGraph) {
+			er.NumSampled += sg.numSampled
+		})
 	}
 	er.Metrics = metrics
 	namespace, err := x.ExtractNamespace(ctx)
@@ -3077,6 +3374,9 @@ func filterTypesForNamespace(namespace uint64, types []*pb.TypeUpdate) []*pb.Typ
 			fields = append(fields, field)
 		}
 		update.Fields = fields
+		for i, name := range update.Extends {
+			update.Extends[i] = x.ParseAttr(name)
+		}
 		out = append(out, update)
 	}
 	return out
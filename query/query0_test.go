@@ -18,6 +18,7 @@ package query
 
 import (
 	"context"
+	"encoding/json"
 	"os"
 	"testing"
 
@@ -901,6 +902,43 @@ func TestQueryVarValAggSince(t *testing.T) {
 		js)
 }
 
+func TestQueryVarValOrderWithPredicates(t *testing.T) {
+	query := `
+		{
+			f as var(func: uid(23, 24, 25, 31)) {
+				a as age
+				b as math(a + 1)
+			}
+
+			me(func: uid(f), orderdesc: val(b), orderasc: name) {
+				name
+				val(b)
+			}
+		}
+	`
+	js := processQueryNoErr(t, query)
+	require.JSONEq(t,
+		`{"data": {"me":[{"name":"Andrea","val(b)":20},{"name":"Daryl Dixon","val(b)":18},{"name":"Glenn Rhee","val(b)":16},{"name":"Rick Grimes","val(b)":16}]}}`,
+		js)
+
+	query = `
+		{
+			f as var(func: uid(23, 24, 25, 31)) {
+				d as dob
+				c as math(since(d))
+			}
+
+			me(func: uid(f), orderasc: age, orderdesc: val(c), first: 3) {
+				name
+			}
+		}
+	`
+	js = processQueryNoErr(t, query)
+	require.JSONEq(t,
+		`{"data": {"me":[{"name":"Glenn Rhee"},{"name":"Rick Grimes"},{"name":"Daryl Dixon"}]}}`,
+		js)
+}
+
 func TestQueryVarValAggNestedFuncConst(t *testing.T) {
 	query := `
 		{
@@ -2859,6 +2897,44 @@ func TestDateTimeQuery(t *testing.T) {
 		processQueryNoErr(t, query))
 }
 
+func TestDateTimeBetweenOpenBound(t *testing.T) {
+	query := `
+{
+  q(func: between(created_at, "2019-03-28T14:41:57+30:00", *), orderdesc: created_at) {
+	  uid
+	  created_at
+	}
+}
+`
+	require.JSONEq(t,
+		`{"data":{"q":[{"uid":"0x133","created_at":"2019-05-28T14:41:57+30:00"},{"uid":"0x130","created_at":"2019-03-28T15:41:57+30:00"},{"uid":"0x12d","created_at":"2019-03-28T14:41:57+30:00"},{"uid":"0x12e","created_at":"2019-03-28T13:41:57+29:00"},{"uid":"0x12f","created_at":"2019-03-27T14:41:57+06:00"}]}}`,
+		processQueryNoErr(t, query))
+
+	query = `
+{
+  q(func: has(created_at), orderdesc: created_at) @filter(between(created_at, *, "2019-03-27T07:41:57Z")) {
+	  uid
+	  created_at
+	}
+}
+`
+	require.JSONEq(t,
+		`{"data":{"q":[{"uid":"0x131","created_at":"2019-03-28T13:41:57+30:00"},{"uid":"0x132","created_at":"2019-03-24T14:41:57+05:30"}]}}`,
+		processQueryNoErr(t, query))
+
+	// The bounds are the same instant as the values of 0x12d, 0x12e and 0x12f, in other
+	// timezones.
+	query = `
+{
+  q(func: between(created_at, "2019-03-27T08:41:57Z", "2019-03-27T10:41:57+02:00")) {
+	  uid
+	}
+}
+`
+	require.JSONEq(t, `{"data":{"q":[{"uid":"0x12d"},{"uid":"0x12e"},{"uid":"0x12f"}]}}`,
+		processQueryNoErr(t, query))
+}
+
 func TestCountUidWithAlias(t *testing.T) {
 	query := `
 		{
@@ -3593,6 +3669,65 @@ func TestKRandomNodes(t *testing.T) {
 	require.JSONEq(t, `{"data":{"data":[{"count":3}]}}`, result)
 }
 
+func TestSampleNodes(t *testing.T) {
+	q := `{
+		data(func: uid(61, 62, 63, 64, 65, 66, 67, 68), sample: 2) @filter(has(connects)) {
+			kname
+			connects(sample: 2) {
+				kname
+			}
+		}
+	}`
+	result := processQueryNoErr(t, q)
+	expected := `{"data":{"data":[{
+		"kname":"can_be_picked",
+		"connects":[
+			{"kname":"yes"},
+			{"kname":"yes"}
+			]},
+		{"kname":"can_be_picked",
+		"connects":[
+			{"kname":"yes"},
+			{"kname":"yes"}
+		]}]}}`
+	require.JSONEq(t, expected, result)
+
+	q = `{
+		data(func: uid(61, 62, 63, 64, 65, 66, 67, 68), sample: 10) @filter(has(connects)) {
+			count(uid)
+		}
+	}`
+	result = processQueryNoErr(t, q)
+	require.JSONEq(t, `{"data":{"data":[{"count":3}]}}`, result)
+
+	// The sampled uids of an ordered list keep their order.
+	q = `{
+		data(func: uid(1, 23, 24, 25, 31), orderasc: name, sample: 3) {
+			name
+		}
+	}`
+	var res struct {
+		Data struct {
+			Data []struct {
+				Name string `json:"name"`
+			} `json:"data"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(processQueryNoErr(t, q)), &res))
+	require.Len(t, res.Data.Data, 3)
+	for i := 1; i < len(res.Data.Data); i++ {
+		require.Less(t, res.Data.Data[i-1].Name, res.Data.Data[i].Name)
+	}
+
+	_, err := processQuery(context.Background(), t, `{
+		data(func: uid(1, 23, 24), sample: 0) {
+			name
+		}
+	}`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "The sample size must be positive, got 0")
+}
+
 var client *dgo.Dgraph
 
 func TestMain(m *testing.M) {
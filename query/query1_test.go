@@ -1104,6 +1104,132 @@ func TestUidInFunctionWithError(t *testing.T) {
 
 }
 
+func TestNotUidInFunction(t *testing.T) {
+	tcases := []struct {
+		description string
+		query       string
+		expected    string
+	}{
+		{
+			description: "predicate with a reverse index",
+			query: `{
+				me(func: uid(1, 23, 24)) @filter(not_uid_in(friend, 23)) {
+					name
+				}
+			}`,
+			expected: `{"data": {"me":[{"name":"Rick Grimes"},{"name":"Glenn Rhee"}]}}`,
+		},
+		{
+			description: "predicate without a reverse index",
+			query: `{
+				me(func: uid(1, 23, 24)) @filter(not_uid_in(school, 5000)) {
+					name
+				}
+			}`,
+			expected: `{"data": {"me":[{"name":"Rick Grimes"}]}}`,
+		},
+		{
+			description: "list of uids",
+			query: `{
+				me(func: uid(1, 23, 24)) @filter(not_uid_in(friend, [1, 23])) {
+					name
+				}
+			}`,
+			expected: `{"data": {"me":[{"name":"Glenn Rhee"}]}}`,
+		},
+		{
+			description: "uid variable",
+			query: `{
+				uidVar as var(func: uid(5001, 5000))
+				me(func: uid(1, 23, 24)) @filter(not_uid_in(school, uid(uidVar))) {
+					name
+				}
+			}`,
+			expected: `{"data": {"me":[]}}`,
+		},
+		{
+			description: "nested in a uid predicate",
+			query: `{
+				me(func: uid(1)) {
+					friend @filter(not_uid_in(school, 5001)) {
+						name
+					}
+				}
+			}`,
+			expected: `{"data": {"me":[{"friend":[{"name":"Glenn Rhee"},{"name":"Daryl Dixon"}]}]}}`,
+		},
+	}
+
+	for _, test := range tcases {
+		t.Run(test.description, func(t *testing.T) {
+			js := processQueryNoErr(t, test.query)
+			require.JSONEq(t, test.expected, js)
+		})
+	}
+}
+
+func TestEqUidPredicate(t *testing.T) {
+	tcases := []struct {
+		description string
+		query       string
+		expected    string
+	}{
+		{
+			description: "list of uids at root",
+			query: `{
+				me(func: eq(friend, [24, 1])) {
+					uid
+				}
+			}`,
+			expected: `{"data": {"me":[{"uid":"0x1"},{"uid":"0x17"},{"uid":"0x1f"}]}}`,
+		},
+		{
+			description: "reverse predicate at root",
+			query: `{
+				me(func: eq(~friend, 1)) {
+					uid
+				}
+			}`,
+			expected: `{"data": {"me":[{"uid":"0x17"},{"uid":"0x18"},{"uid":"0x19"},` +
+				`{"uid":"0x1f"},{"uid":"0x65"}]}}`,
+		},
+		{
+			description: "uid variable at root",
+			query: `{
+				uidVar as var(func: uid(24))
+				me(func: eq(friend, uid(uidVar))) {
+					uid
+				}
+			}`,
+			expected: `{"data": {"me":[{"uid":"0x1"},{"uid":"0x1f"}]}}`,
+		},
+		{
+			description: "filter on a predicate without a reverse index",
+			query: `{
+				me(func: uid(1, 23, 24)) @filter(eq(school, [5001, 5002])) {
+					uid
+				}
+			}`,
+			expected: `{"data": {"me":[{"uid":"0x17"}]}}`,
+		},
+	}
+
+	for _, test := range tcases {
+		t.Run(test.description, func(t *testing.T) {
+			js := processQueryNoErr(t, test.query)
+			require.JSONEq(t, test.expected, js)
+		})
+	}
+
+	_, err := processQuery(context.Background(), t, `{
+		me(func: eq(school, 5000)) {
+			uid
+		}
+	}`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "eq function on the uid predicate school needs @reverse at root")
+}
+
 func TestUidInFunction3(t *testing.T) {
 	tcases := []struct {
 		description string
@@ -1416,6 +1542,53 @@ func TestAggregateRoot1(t *testing.T) {
 	require.JSONEq(t, `{"data": {"me":[{"sum(val(a))":72}]}}`, js)
 }
 
+func TestAggregateCountDistinct(t *testing.T) {
+	query := `
+		{
+			var(func: uid(23, 24, 25, 31)) {
+				a as age
+			}
+
+			me() {
+				countDistinct(val(a))
+			}
+		}
+	`
+	js := processQueryNoErr(t, query)
+	require.JSONEq(t, `{"data": {"me":[{"countdistinct(val(a))":3}]}}`, js)
+
+	query = `
+		{
+			var(func: uid(1, 31)) {
+				friend {
+					a as age
+				}
+				n as countDistinct(val(a))
+			}
+
+			me(func: uid(1, 31)) {
+				val(n)
+			}
+		}
+	`
+	js = processQueryNoErr(t, query)
+	require.JSONEq(t, `{"data": {"me":[{"val(n)":3},{"val(n)":1}]}}`, js)
+
+	query = `
+		{
+			me(func: uid(1)) {
+				friend @groupby(school) {
+					countDistinct(alive)
+				}
+			}
+		}
+	`
+	js = processQueryNoErr(t, query)
+	require.JSONEq(t, `{"data": {"me":[{"friend":[{"@groupby":[`+
+		`{"school":"0x1388","countdistinct(alive)":1},`+
+		`{"school":"0x1389","countdistinct(alive)":2}]}]}]}}`, js)
+}
+
 func TestAggregateRoot2(t *testing.T) {
 
 	query := `
@@ -3118,6 +3118,30 @@ func TestLangBug1295(t *testing.T) {
 
 }
 
+func TestLangFallbackInIndexedEq(t *testing.T) {
+	query := `
+		{
+			me(func:eq(name_lang_index@en:sv, "zon")) {
+				name_lang_index@sv
+			}
+			other(func:eq(name_lang_index@en:de, "zumachen", "öffnen"), orderasc: name_lang_index@de) {
+				name_lang_index@de
+			}
+			first(func:eq(name_lang_index@de:sv, "zon")) {
+				name_lang_index@sv
+			}
+		}
+	`
+	js := processQueryNoErr(t, query)
+	// The value in a fallback language only matches when the node has no value in the
+	// languages before it.
+	require.JSONEq(t, `{"data": {
+		"me": [{"name_lang_index@sv": "zon"}],
+		"other": [{"name_lang_index@de": "öffnen"}, {"name_lang_index@de": "zumachen"}],
+		"first": []
+	}}`, js)
+}
+
 func TestLangDotInFunction(t *testing.T) {
 
 	query := `
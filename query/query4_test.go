@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/dgraph-io/dgo/v210"
 	"github.com/dgraph-io/dgo/v210/protos/api"
 	"github.com/dgraph-io/dgraph/x"
 	"github.com/stretchr/testify/assert"
@@ -596,6 +597,72 @@ func TestTypeExpandMultipleExplicitTypes(t *testing.T) {
 			"owner": [{"uid": "0xcb"}]}]}}`, js)
 }
 
+func TestTypeExpandPatterns(t *testing.T) {
+	query := `{
+		q(func: eq(make, "Toyota")) {
+			expand("ma*", "y*")
+		}
+	}`
+	js := processQueryNoErr(t, query)
+	require.JSONEq(t, `{"data": {"q":[{"make":"Toyota", "year":2009}]}}`, js)
+}
+
+func TestTypeExpandPatternsWithType(t *testing.T) {
+	query := `{
+		q(func: eq(make, "Toyota")) {
+			expand(Object, "mo*") {
+				uid
+			}
+		}
+	}`
+	js := processQueryNoErr(t, query)
+	require.JSONEq(t, `{"data": {"q":[
+		{"name": "Car", "model":"Prius", "model@jp":"プリウス", "owner": [{"uid": "0xcb"}]}]}}`,
+		js)
+}
+
+func TestTypeExpandDepth(t *testing.T) {
+	query := `{
+		q(func: eq(make, "Toyota")) {
+			expand("owner*", depth: 2)
+		}
+	}`
+	js := processQueryNoErr(t, query)
+	require.JSONEq(t,
+		`{"data": {"q":[{"owner": [{"owner_name": "Owner of Prius"}]}]}}`, js)
+}
+
+func TestJoinOnUid(t *testing.T) {
+	query := `{
+		cars as var(func: type(CarModel))
+		q(func: uid(0xcb)) {
+			owner_name
+			owns: join(cars, uid, owner) {
+				model
+			}
+		}
+	}`
+	js := processQueryNoErr(t, query)
+	require.JSONEq(t,
+		`{"data": {"q":[{"owner_name": "Owner of Prius", "owns": [{"model": "Prius"}]}]}}`, js)
+}
+
+func TestJoinOnValue(t *testing.T) {
+	query := `{
+		cars as var(func: type(CarModel))
+		q(func: eq(make, "Toyota")) {
+			model
+			same_year: join(cars, year) @filter(NOT eq(make, "Toyota")) {
+				make
+				year
+			}
+		}
+	}`
+	js := processQueryNoErr(t, query)
+	require.JSONEq(t, `{"data": {"q":[{"model": "Prius",
+		"same_year": [{"make": "Ford", "year": 2009}]}]}}`, js)
+}
+
 func TestTypeFilterAtExpand(t *testing.T) {
 	query := `{
 		q(func: eq(make, "Toyota")) {
@@ -1561,3 +1628,30 @@ func TestNumUids(t *testing.T) {
 	require.Equal(t, metrics.NumUids["name"], uint64(16))
 	require.Equal(t, metrics.NumUids["_total"], uint64(26))
 }
+
+func TestOrderedListConcurrentTxns(t *testing.T) {
+	setSchema(testSchema + "\n ordered_tags: [string] @ordered .\n")
+	ctx := context.Background()
+	mutate := func(txn *dgo.Txn, tag string) {
+		_, err := txn.Mutate(ctx, &api.Mutation{
+			SetNquads: []byte(`<0x7001> <ordered_tags> "` + tag + `" .`),
+		})
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, addTriplesToCluster(`<0x7001> <ordered_tags> "zulu" .`))
+	// The transaction started first commits last, so its value comes last.
+	first, second := client.NewTxn(), client.NewTxn()
+	defer first.Discard(ctx)
+	defer second.Discard(ctx)
+	mutate(first, "alpha")
+	mutate(second, "mike")
+	require.NoError(t, second.Commit(ctx))
+	require.NoError(t, first.Commit(ctx))
+
+	js := processQueryNoErr(t, `{ q(func: uid(0x7001)) { ordered_tags } }`)
+	require.JSONEq(t, `{"data": {"q": [{"ordered_tags": ["zulu", "mike", "alpha"]}]}}`, js)
+
+	dropPredicate("ordered_tags")
+	setSchema(testSchema)
+}
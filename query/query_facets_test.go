@@ -2432,3 +2432,41 @@ func TestCountFacetsFilteringScalarListPredicate(t *testing.T) {
 		}
 	}`, js)
 }
+
+func TestValidAt(t *testing.T) {
+	require.NoError(t, addTriplesToCluster(`
+		<9101> <name> "Valid Anne" .
+		<9101> <member_of> <9102> (validFrom = 2010-01-01T00:00:00, validTo = 2015-01-01T00:00:00) .
+		<9101> <member_of> <9103> (validFrom = 2015-01-01T00:00:00) .
+		<9101> <member_of> <9104> .
+		<9101> <lived_in> <9102> (validFrom = 2010-01-01T00:00:00, validTo = 2015-01-01T00:00:00) .
+		<9101> <lived_in> <9103> (validFrom = 2015-01-01T00:00:00) .
+		<9101> <lived_in> <9104> .
+	`))
+	query := func(at string) string {
+		return fmt.Sprintf(`{
+			q(func: uid(9101)) @validAt("%s") {
+				member_of { uid }
+				lived_in { uid }
+			}
+		}`, at)
+	}
+
+	// The edges are valid from their validFrom, included, to their validTo, excluded. The
+	// edges of lived_in are read from the index of their validFrom facet.
+	js := processQueryNoErr(t, query("2014-12-31T23:59:59"))
+	require.JSONEq(t, `{"data": {"q": [{
+		"member_of": [{"uid": "0x238e"}, {"uid": "0x2390"}],
+		"lived_in": [{"uid": "0x238e"}, {"uid": "0x2390"}]
+	}]}}`, js)
+	js = processQueryNoErr(t, query("2015-01-01T00:00:00"))
+	require.JSONEq(t, `{"data": {"q": [{
+		"member_of": [{"uid": "0x238f"}, {"uid": "0x2390"}],
+		"lived_in": [{"uid": "0x238f"}, {"uid": "0x2390"}]
+	}]}}`, js)
+	js = processQueryNoErr(t, query("2009-06-01"))
+	require.JSONEq(t, `{"data": {"q": [{
+		"member_of": [{"uid": "0x2390"}],
+		"lived_in": [{"uid": "0x2390"}]
+	}]}}`, js)
+}
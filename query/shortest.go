@@ -240,10 +240,10 @@ func (sg *SubGraph) expandOut(ctx context.Context,
 			}
 		}
 
-		if numEdges > x.Config.LimitQueryEdge {
+		if limit := x.LimitQueryEdge(); numEdges > limit {
 			// If we've seen too many edges, stop the query.
 			rch <- errors.Errorf("Exceeded query edge limit = %v. Found %v edges.",
-				x.Config.LimitMutationsNquad, numEdges)
+				limit, numEdges)
 			return
 		}
 
@@ -0,0 +1,384 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package query
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/dgraph-io/dgraph/codec"
+	"github.com/dgraph-io/dgraph/gql"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/sparql"
+	"github.com/dgraph-io/dgraph/types"
+	"github.com/dgraph-io/dgraph/worker"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/dgraph-io/sroar"
+	"github.com/pkg/errors"
+)
+
+// sparqlSolution is a solution of a SPARQL group, along with the index of the solution it
+// extends in the input of the group.
+type sparqlSolution struct {
+	binding sparql.Binding
+	src     int
+}
+
+type sparqlExecutor struct {
+	ctx    context.Context
+	ns     uint64
+	readTs uint64
+}
+
+// ProcessSparql runs the SPARQL query at readTs, in the namespace of the context.
+//
+// Each triple pattern scans the posting lists of its predicate, either for the subjects bound by
+// the solutions so far, or for all the subjects having the predicate. The subjects are gathered
+// in a bitmap, whose objects are then joined with the solutions. The predicates are the IRIs of
+// the patterns, rdf:type being dgraph.type, and the nodes are IRIs holding their UIDs, like
+// <0x1>.
+func ProcessSparql(ctx context.Context, q *sparql.Query, readTs uint64) (*sparql.Results, error) {
+	ns, err := x.ExtractNamespace(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while processing SPARQL query")
+	}
+	e := &sparqlExecutor{ctx: ctx, ns: ns, readTs: readTs}
+	sols, err := e.group(q.Where, []sparqlSolution{{binding: sparql.Binding{}}})
+	if err != nil {
+		return nil, err
+	}
+
+	res := &sparql.Results{Vars: q.Vars}
+	if res.Vars == nil {
+		res.Vars = sparqlVars(q.Where, nil, make(map[string]bool))
+	}
+	seen := make(map[string]bool)
+	for _, sol := range sols {
+		b := make(sparql.Binding, len(res.Vars))
+		for _, v := range res.Vars {
+			if t, ok := sol.binding[v]; ok {
+				b[v] = t
+			}
+		}
+		if q.Distinct {
+			key := sparqlKey(res.Vars, b)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+		}
+		res.Bindings = append(res.Bindings, b)
+	}
+
+	if q.Offset > 0 {
+		if q.Offset >= len(res.Bindings) {
+			res.Bindings = nil
+		} else {
+			res.Bindings = res.Bindings[q.Offset:]
+		}
+	}
+	if q.Limit > 0 && q.Limit < len(res.Bindings) {
+		res.Bindings = res.Bindings[:q.Limit]
+	}
+	return res, nil
+}
+
+// sparqlVars returns the variables of the group in the order they appear, without the blank
+// nodes.
+func sparqlVars(g *sparql.Group, vars []string, seen map[string]bool) []string {
+	for _, t := range g.Triples {
+		for _, term := range []*sparql.Term{t.Subject, t.Object} {
+			if term.Kind == sparql.VarTerm && !strings.HasPrefix(term.Value, "_:") &&
+				!seen[term.Value] {
+				seen[term.Value] = true
+				vars = append(vars, term.Value)
+			}
+		}
+	}
+	for _, opt := range g.Optionals {
+		vars = sparqlVars(opt, vars, seen)
+	}
+	return vars
+}
+
+func sparqlKey(vars []string, b sparql.Binding) string {
+	var sb strings.Builder
+	for _, v := range vars {
+		if t, ok := b[v]; ok {
+			fmt.Fprintf(&sb, "%d|%q|%q|%q", t.Kind, t.Value, t.Lang, t.Datatype)
+		}
+		sb.WriteByte(0)
+	}
+	return sb.String()
+}
+
+// group matches the group against each of the input solutions.
+func (e *sparqlExecutor) group(g *sparql.Group, in []sparqlSolution) ([]sparqlSolution, error) {
+	sols := in
+	var err error
+	for _, t := range g.Triples {
+		if len(sols) == 0 {
+			return nil, nil
+		}
+		if sols, err = e.triple(t, sols); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, opt := range g.Optionals {
+		// The solutions matching the optional group replace the solution they extend, and the
+		// other solutions are kept as they are.
+		src := make([]sparqlSolution, len(sols))
+		for i, sol := range sols {
+			src[i] = sparqlSolution{binding: sol.binding, src: i}
+		}
+		matched, err := e.group(opt, src)
+		if err != nil {
+			return nil, err
+		}
+		extended := make([][]sparql.Binding, len(sols))
+		for _, m := range matched {
+			extended[m.src] = append(extended[m.src], m.binding)
+		}
+		var out []sparqlSolution
+		for i, sol := range sols {
+			if len(extended[i]) == 0 {
+				out = append(out, sol)
+				continue
+			}
+			for _, b := range extended[i] {
+				out = append(out, sparqlSolution{binding: b, src: sol.src})
+			}
+		}
+		sols = out
+	}
+
+	if len(g.Filters) == 0 {
+		return sols, nil
+	}
+	out := sols[:0]
+	for _, sol := range sols {
+		keep := true
+		for _, f := range g.Filters {
+			if !sol.binding.Filter(f) {
+				keep = false
+				break
+			}
+		}
+		if keep {
+			out = append(out, sol)
+		}
+	}
+	return out, nil
+}
+
+// subjectUid returns the UID the subject of the pattern is bound to in the binding, if any.
+func subjectUid(subject *sparql.Term, b sparql.Binding) (uint64, bool, error) {
+	term := subject
+	if subject.Kind == sparql.VarTerm {
+		var ok bool
+		if term, ok = b[subject.Value]; !ok {
+			return 0, false, nil
+		}
+		// Literals and types are never subjects.
+		uid, err := gql.ParseUid(term.Value)
+		if term.Kind != sparql.IRITerm || err != nil {
+			return 0, true, nil
+		}
+		return uid, true, nil
+	}
+	uid, err := gql.ParseUid(term.Value)
+	if err != nil {
+		return 0, true, errors.Errorf("subjects must be UIDs like <0x1>, got <%s>", term.Value)
+	}
+	return uid, true, nil
+}
+
+func (e *sparqlExecutor) triple(t *sparql.Triple, sols []sparqlSolution) (
+	[]sparqlSolution, error) {
+	attr := t.Predicate.Value
+	if attr == sparql.RDFType {
+		attr = "dgraph.type"
+	}
+
+	subjects := sroar.NewBitmap()
+	scan := false
+	for _, sol := range sols {
+		uid, bound, err := subjectUid(t.Subject, sol.binding)
+		switch {
+		case err != nil:
+			return nil, err
+		case !bound:
+			scan = true
+		case uid != 0:
+			subjects.Set(uid)
+		}
+	}
+	if scan {
+		all, err := e.scan(attr)
+		if err != nil {
+			return nil, err
+		}
+		subjects.Or(all)
+	}
+	if subjects.IsEmpty() {
+		return nil, nil
+	}
+
+	objects, err := e.objects(attr, subjects)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []sparqlSolution
+	join := func(sol sparqlSolution, uid uint64) {
+		b := sol.binding
+		if t.Subject.Kind == sparql.VarTerm {
+			if _, ok := b[t.Subject.Value]; !ok {
+				b = extendBinding(b, t.Subject.Value, uidTerm(uid))
+			}
+		}
+		for _, obj := range objects[uid] {
+			nb := b
+			switch {
+			case t.Object.Kind != sparql.VarTerm:
+				if !t.Object.Equal(obj) {
+					continue
+				}
+			case b[t.Object.Value] != nil:
+				if !b[t.Object.Value].Equal(obj) {
+					continue
+				}
+			default:
+				nb = extendBinding(b, t.Object.Value, obj)
+			}
+			out = append(out, sparqlSolution{binding: nb, src: sol.src})
+		}
+	}
+
+	var all []uint64
+	for _, sol := range sols {
+		uid, bound, _ := subjectUid(t.Subject, sol.binding)
+		if bound {
+			if uid != 0 {
+				join(sol, uid)
+			}
+			continue
+		}
+		if all == nil {
+			all = subjects.ToArray()
+		}
+		for _, uid := range all {
+			join(sol, uid)
+		}
+	}
+	return out, nil
+}
+
+func extendBinding(b sparql.Binding, v string, t *sparql.Term) sparql.Binding {
+	nb := make(sparql.Binding, len(b)+1)
+	for k, val := range b {
+		nb[k] = val
+	}
+	nb[v] = t
+	return nb
+}
+
+func uidTerm(uid uint64) *sparql.Term {
+	return &sparql.Term{Kind: sparql.IRITerm, Value: fmt.Sprintf("%#x", uid)}
+}
+
+// scan returns the subjects having the predicate.
+func (e *sparqlExecutor) scan(attr string) (*sroar.Bitmap, error) {
+	res, err := worker.ProcessTaskOverNetwork(e.ctx, &pb.Query{
+		ReadTs:  e.readTs,
+		Attr:    x.NamespaceAttr(e.ns, attr),
+		SrcFunc: &pb.SrcFunction{Name: "has"},
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "while scanning predicate %s", attr)
+	}
+	if len(res.UidMatrix) == 0 {
+		return sroar.NewBitmap(), nil
+	}
+	return codec.FromList(res.UidMatrix[0]), nil
+}
+
+var sparqlDatatypes = map[types.TypeID]string{
+	types.IntID:      sparql.XSDInteger,
+	types.FloatID:    sparql.XSDDouble,
+	types.BoolID:     sparql.XSDBoolean,
+	types.DateTimeID: sparql.XSDDateTime,
+}
+
+// objects returns the objects of the predicate for each of the subjects.
+func (e *sparqlExecutor) objects(attr string, subjects *sroar.Bitmap) (
+	map[uint64][]*sparql.Term, error) {
+	res, err := worker.ProcessTaskOverNetwork(e.ctx, &pb.Query{
+		ReadTs:    e.readTs,
+		Attr:      x.NamespaceAttr(e.ns, attr),
+		UidList:   codec.ToList(subjects),
+		ExpandAll: true,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "while reading predicate %s", attr)
+	}
+
+	objects := make(map[uint64][]*sparql.Term)
+	for i, uid := range subjects.ToArray() {
+		if i < len(res.UidMatrix) {
+			for _, obj := range codec.GetUids(res.UidMatrix[i]) {
+				objects[uid] = append(objects[uid], uidTerm(obj))
+			}
+		}
+		if i >= len(res.ValueMatrix) {
+			continue
+		}
+		var langs []string
+		if i < len(res.LangMatrix) {
+			langs = res.LangMatrix[i].Lang
+		}
+		for j, tv := range res.ValueMatrix[i].Values {
+			if len(tv.Val) == 0 || types.TypeID(tv.ValType) == types.PasswordID {
+				continue
+			}
+			val, err := convertWithBestEffort(tv, attr)
+			if err != nil {
+				return nil, err
+			}
+			str, err := types.Convert(val, types.StringID)
+			if err != nil {
+				return nil, err
+			}
+			obj := &sparql.Term{
+				Kind:     sparql.LiteralTerm,
+				Value:    str.Value.(string),
+				Datatype: sparqlDatatypes[val.Tid],
+			}
+			if len(langs) == len(res.ValueMatrix[i].Values) {
+				obj.Lang = langs[j]
+			}
+			if attr == "dgraph.type" {
+				// The types are the objects of rdf:type, which are IRIs.
+				obj = &sparql.Term{Kind: sparql.IRITerm, Value: obj.Value}
+			}
+			objects[uid] = append(objects[uid], obj)
+		}
+	}
+	return objects, nil
+}
@@ -371,6 +371,18 @@ func (w *DiskStorage) NumLogFiles() int {
 	return len(w.wal.files)
 }
 
+// LogSize returns the size of the Raft log files on disk, in bytes.
+func (w *DiskStorage) LogSize() int64 {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	size := int64(len(w.wal.current.Data))
+	for _, lf := range w.wal.files {
+		size += int64(len(lf.Data))
+	}
+	return size
+}
+
 // Sync calls the Sync method in the underlying badger instance to write all the contents to disk.
 func (w *DiskStorage) Sync() error {
 	w.lock.Lock()
@@ -219,6 +219,18 @@ func TestStorageCreateSnapshot(t *testing.T) {
 	}
 }
 
+func TestStorageLogSize(t *testing.T) {
+	dir, err := ioutil.TempDir("", "badger")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ds := Init(dir)
+	// Only the current log file exists, which is allocated upfront.
+	require.Equal(t, int64(logFileSize), ds.LogSize())
+	require.NoError(t, ds.reset([]pb.Entry{{Index: 3, Term: 3}, {Index: 4, Term: 4}}))
+	require.Equal(t, int64(logFileSize), ds.LogSize())
+}
+
 func TestStorageAppend(t *testing.T) {
 	dir, err := ioutil.TempDir("", "badger")
 	require.NoError(t, err)
@@ -18,13 +18,17 @@ package schema
 
 import (
 	"math"
+	"net/url"
+	"sort"
 	"strconv"
 	"strings"
 
+	"github.com/dgraph-io/dgo/v210/protos/api"
 	"github.com/dgraph-io/dgraph/lex"
 	"github.com/dgraph-io/dgraph/protos/pb"
 	"github.com/dgraph-io/dgraph/tok"
 	"github.com/dgraph-io/dgraph/types"
+	"github.com/dgraph-io/dgraph/validate"
 	"github.com/dgraph-io/dgraph/x"
 
 	"github.com/golang/glog"
@@ -50,7 +54,8 @@ func ParseBytes(s []byte, gid uint32) (rerr error) {
 	return nil
 }
 
-func parseDirective(it *lex.ItemIterator, schema *pb.SchemaUpdate, t types.TypeID) error {
+func parseDirective(it *lex.ItemIterator, schema *pb.SchemaUpdate, t types.TypeID,
+	sameAs *string) error {
 	it.Next()
 	next := it.Item()
 	if next.Typ != itemText {
@@ -71,16 +76,77 @@ func parseDirective(it *lex.ItemIterator, schema *pb.SchemaUpdate, t types.TypeI
 		schema.Tokenizer = tokenizer
 	case "count":
 		schema.Count = true
+	case "presence":
+		schema.Presence = true
 	case "upsert":
 		schema.Upsert = true
 	case "noconflict":
 		schema.NoConflict = true
+	case "owned":
+		if t != types.UidID {
+			return next.Errorf("Cannot own nodes with non-UID type")
+		}
+		schema.Owned = true
+	case "facetindex":
+		if t != types.UidID {
+			return next.Errorf("Cannot index facets for non-UID type")
+		}
+		keys, err := parseFacetIndexDirective(it, schema.Predicate)
+		if err != nil {
+			return err
+		}
+		schema.FacetIndex = keys
+	case "facets":
+		fcs, err := parseFacetsDirective(it, schema.Predicate)
+		if err != nil {
+			return err
+		}
+		schema.Facets = fcs
+	case "view":
+		if t == types.UidID || schema.List {
+			return next.Errorf("Views can only be defined for scalar, non-list predicates")
+		}
+		query, err := parseViewDirective(it, schema.Predicate)
+		if err != nil {
+			return err
+		}
+		schema.View = query
+	case "trigger":
+		trigger, err := parseTriggerDirective(it, "pred: "+x.ParseAttr(schema.Predicate))
+		if err != nil {
+			return err
+		}
+		schema.Trigger = trigger
+	case "validate":
+		names, err := parseValidateDirective(it, schema.Predicate, t)
+		if err != nil {
+			return err
+		}
+		schema.Validators = names
+	case "sequence":
+		if t != types.IntID || schema.List {
+			return next.Errorf("@sequence directive can only be specified for int type."+
+				" Got: [%v] for attr: [%v]", t.Name(), schema.Predicate)
+		}
+		schema.Sequence = true
+	case "ordered":
+		if t == types.UidID || !schema.List {
+			return next.Errorf("@ordered directive can only be specified for scalar list types."+
+				" Got: [%v] for attr: [%v]", t.Name(), schema.Predicate)
+		}
+		schema.Ordered = true
 	case "lang":
 		if t != types.StringID || schema.List {
 			return next.Errorf("@lang directive can only be specified for string type."+
 				" Got: [%v] for attr: [%v]", t.Name(), schema.Predicate)
 		}
 		schema.Lang = true
+	case "shard":
+		anchor, err := parseShardDirective(it, schema.Predicate)
+		if err != nil {
+			return err
+		}
+		*sameAs = anchor
 	default:
 		return next.Errorf("Invalid index specification")
 	}
@@ -89,7 +155,14 @@ func parseDirective(it *lex.ItemIterator, schema *pb.SchemaUpdate, t types.TypeI
 	return nil
 }
 
-func parseScalarPair(it *lex.ItemIterator, predicate string, ns uint64) (*pb.SchemaUpdate, error) {
+// CounterType is the type of the counter predicates in the schema. Their values are ints, which
+// the mutations increment instead of replacing.
+const CounterType = "counter"
+
+// parseScalarPair parses the schema of a predicate. It also returns the predicate that it must be
+// co-located with, if any, as given by the @shard directive.
+func parseScalarPair(it *lex.ItemIterator, predicate string,
+	ns uint64) (*pb.SchemaUpdate, string, error) {
 	it.Next()
 	next := it.Item()
 	switch {
@@ -102,11 +175,11 @@ func parseScalarPair(it *lex.ItemIterator, predicate string, ns uint64) (*pb.Sch
 	// '@' in predicate names, so both forms are disallowed. Handling them here avoids
 	// messing with the lexer and IRI values.
 	case next.Typ == itemAt || strings.Contains(predicate, "@"):
-		return nil, next.Errorf("Invalid '@' in name")
+		return nil, "", next.Errorf("Invalid '@' in name")
 	case next.Typ != itemColon:
-		return nil, next.Errorf("Missing colon")
+		return nil, "", next.Errorf("Missing colon")
 	case !it.Next():
-		return nil, next.Errorf("Invalid ending while trying to parse schema.")
+		return nil, "", next.Errorf("Invalid ending while trying to parse schema.")
 	}
 	next = it.Item()
 	schema := &pb.SchemaUpdate{Predicate: x.NamespaceAttr(ns, predicate)}
@@ -114,23 +187,32 @@ func parseScalarPair(it *lex.ItemIterator, predicate string, ns uint64) (*pb.Sch
 	if next.Typ == itemLeftSquare {
 		schema.List = true
 		if !it.Next() {
-			return nil, next.Errorf("Invalid ending while trying to parse schema.")
+			return nil, "", next.Errorf("Invalid ending while trying to parse schema.")
 		}
 		next = it.Item()
 	}
 
 	if next.Typ != itemText {
-		return nil, next.Errorf("Missing Type")
+		return nil, "", next.Errorf("Missing Type")
 	}
 	typ := strings.ToLower(next.Val)
+	// Counters hold an int, to which the mutations add their value instead of replacing it, so
+	// that concurrent increments don't conflict.
+	if typ == CounterType {
+		if schema.List {
+			return nil, "", next.Errorf("Unsupported type for list: [%s].", CounterType)
+		}
+		schema.Counter = true
+		typ = types.IntID.Name()
+	}
 	// We ignore the case for types.
 	t, ok := types.TypeForName(typ)
 	if !ok {
-		return nil, next.Errorf("Undefined Type")
+		return nil, "", next.Errorf("Undefined Type")
 	}
 	if schema.List {
 		if uint32(t) == uint32(types.PasswordID) || uint32(t) == uint32(types.BoolID) {
-			return nil, next.Errorf("Unsupported type for list: [%s].", types.TypeID(t).Name())
+			return nil, "", next.Errorf("Unsupported type for list: [%s].", types.TypeID(t).Name())
 		}
 	}
 	schema.ValueType = t.Enum()
@@ -140,37 +222,354 @@ func parseScalarPair(it *lex.ItemIterator, predicate string, ns uint64) (*pb.Sch
 	next = it.Item()
 	if schema.List {
 		if next.Typ != itemRightSquare {
-			return nil, next.Errorf("Unclosed [ while parsing schema for: %s", predicate)
+			return nil, "", next.Errorf("Unclosed [ while parsing schema for: %s", predicate)
 		}
 		if !it.Next() {
-			return nil, next.Errorf("Invalid ending")
+			return nil, "", next.Errorf("Invalid ending")
 		}
 		next = it.Item()
 	}
 
+	var sameAs string
 	for {
 		if next.Typ != itemAt {
 			break
 		}
-		if err := parseDirective(it, schema, t); err != nil {
-			return nil, err
+		if err := parseDirective(it, schema, t, &sameAs); err != nil {
+			return nil, "", err
 		}
 		next = it.Item()
 	}
 
+	if schema.Counter && (schema.Directive != pb.SchemaUpdate_NONE || schema.Count ||
+		schema.Upsert || schema.Sequence || schema.View != "") {
+		return nil, "", next.Errorf("Counter predicate %s can't be indexed or have @count, "+
+			"@upsert, @sequence or @view directives", predicate)
+	}
+
 	if next.Typ != itemDot {
-		return nil, next.Errorf("Invalid ending")
+		return nil, "", next.Errorf("Invalid ending")
 	}
 	it.Next()
 	next = it.Item()
 	if next.Typ == lex.ItemEOF {
 		it.Prev()
-		return schema, nil
+		return schema, sameAs, nil
 	}
 	if next.Typ != itemNewLine {
-		return nil, next.Errorf("Invalid ending")
+		return nil, "", next.Errorf("Invalid ending")
+	}
+	return schema, sameAs, nil
+}
+
+// parseShardDirective works on @shard(group: "same-as: pred"), which asks for the predicate to be
+// served by the same group as pred. It returns the namespaced name of pred.
+func parseShardDirective(it *lex.ItemIterator, predicate string) (string, error) {
+	ns, attr := x.ParseNamespaceAttr(predicate)
+	expected := []lex.ItemType{itemLeftRound, itemText, itemColon, itemQuotedText, itemRightRound}
+	var value string
+	for _, typ := range expected {
+		if !it.Next() {
+			return "", it.Item().Errorf("Invalid ending while parsing @shard for pred: %s", attr)
+		}
+		next := it.Item()
+		if next.Typ != typ {
+			return "", next.Errorf("Invalid @shard directive for pred: %s, expected "+
+				`@shard(group: "same-as: <pred>")`, attr)
+		}
+		switch typ {
+		case itemText:
+			if next.Val != "group" {
+				return "", next.Errorf("Invalid argument %s in @shard directive for pred: %s",
+					next.Val, attr)
+			}
+		case itemQuotedText:
+			var err error
+			if value, err = strconv.Unquote(next.Val); err != nil {
+				return "", next.Errorf("Invalid value in @shard directive for pred: %s", attr)
+			}
+		}
+	}
+
+	parts := strings.SplitN(value, ":", 2)
+	if len(parts) != 2 || strings.TrimSpace(parts[0]) != "same-as" {
+		return "", it.Item().Errorf("Invalid value %q in @shard directive for pred: %s, "+
+			`expected "same-as: <pred>"`, value, attr)
+	}
+	sameAs := strings.TrimSpace(parts[1])
+	switch {
+	case sameAs == "":
+		return "", it.Item().Errorf("Missing predicate in @shard directive for pred: %s", attr)
+	case sameAs == attr:
+		return "", it.Item().Errorf("Pred: %s can't be co-located with itself", attr)
+	case x.IsReservedPredicate(predicate) || x.IsReservedPredicate(x.NamespaceAttr(ns, sameAs)):
+		// Reserved predicates are always served by group 1.
+		return "", it.Item().Errorf("Reserved predicates can't be used in @shard directive")
+	}
+	return x.NamespaceAttr(ns, sameAs), nil
+}
+
+// parseViewDirective works on @view("<query>"), which makes the predicate a view maintained by the
+// given DQL query. The query is only validated when the schema is altered. It returns the query.
+func parseViewDirective(it *lex.ItemIterator, predicate string) (string, error) {
+	_, attr := x.ParseNamespaceAttr(predicate)
+	expected := []lex.ItemType{itemLeftRound, itemQuotedText, itemRightRound}
+	var query string
+	for _, typ := range expected {
+		if !it.Next() {
+			return "", it.Item().Errorf("Invalid ending while parsing @view for pred: %s", attr)
+		}
+		next := it.Item()
+		if next.Typ != typ {
+			return "", next.Errorf("Invalid @view directive for pred: %s, expected "+
+				`@view("<query>")`, attr)
+		}
+		if typ == itemQuotedText {
+			// The query may span several lines, which a Go string literal can't.
+			var err error
+			val := strings.ReplaceAll(next.Val, "\n", `\n`)
+			if query, err = strconv.Unquote(val); err != nil {
+				return "", next.Errorf("Invalid query in @view directive for pred: %s", attr)
+			}
+		}
+	}
+	if strings.TrimSpace(query) == "" {
+		return "", it.Item().Errorf("Empty query in @view directive for pred: %s", attr)
+	}
+	return query, nil
+}
+
+// Triggers call a webhook or a lambda resolver once the changes they watch are committed. Their
+// target is given as "webhook: <url>" or "lambda: <resolver>" in the @trigger directive.
+const (
+	TriggerWebhook = "webhook"
+	TriggerLambda  = "lambda"
+)
+
+// ParseTrigger returns the kind and the target of the given trigger.
+func ParseTrigger(trigger string) (string, string, error) {
+	parts := strings.SplitN(trigger, ":", 2)
+	if len(parts) != 2 {
+		return "", "", errors.Errorf("Invalid trigger %q, expected %q or %q", trigger,
+			TriggerWebhook+": <url>", TriggerLambda+": <resolver>")
+	}
+	kind, target := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+	switch kind {
+	case TriggerWebhook:
+		if u, err := url.Parse(target); err != nil || !u.IsAbs() {
+			return "", "", errors.Errorf("Invalid webhook URL %q in trigger, it must be absolute",
+				target)
+		}
+	case TriggerLambda:
+		if target == "" {
+			return "", "", errors.Errorf("Missing lambda resolver in trigger %q", trigger)
+		}
+	default:
+		return "", "", errors.Errorf("Invalid kind %q of trigger, expected %s or %s", kind,
+			TriggerWebhook, TriggerLambda)
+	}
+	return kind, target, nil
+}
+
+// parseTriggerDirective works on @trigger("<kind>: <target>"), which calls the given target once
+// the changes to the predicate or to the nodes of the type are committed. It returns the trigger.
+func parseTriggerDirective(it *lex.ItemIterator, name string) (string, error) {
+	expected := []lex.ItemType{itemLeftRound, itemQuotedText, itemRightRound}
+	var trigger string
+	for _, typ := range expected {
+		if !it.Next() {
+			return "", it.Item().Errorf("Invalid ending while parsing @trigger for %s", name)
+		}
+		next := it.Item()
+		if next.Typ != typ {
+			return "", next.Errorf("Invalid @trigger directive for %s, expected "+
+				`@trigger("<kind>: <target>")`, name)
+		}
+		if typ == itemQuotedText {
+			var err error
+			if trigger, err = strconv.Unquote(next.Val); err != nil {
+				return "", next.Errorf("Invalid value in @trigger directive for %s", name)
+			}
+		}
+	}
+	if _, _, err := ParseTrigger(trigger); err != nil {
+		return "", it.Item().Errorf("%v for %s", err, name)
+	}
+	return strings.TrimSpace(trigger), nil
+}
+
+// parseValidateDirective works on @validate(validator1, validator2), which calls the given
+// validators in turn on the values set by the mutations. It returns the validators in order.
+func parseValidateDirective(it *lex.ItemIterator, predicate string,
+	typ types.TypeID) ([]string, error) {
+	_, attr := x.ParseNamespaceAttr(predicate)
+	if !it.Next() {
+		return nil, it.Item().Errorf("Invalid ending while parsing @validate for pred: %s", attr)
+	}
+	if next := it.Item(); next.Typ != itemLeftRound {
+		return nil, next.Errorf("Require validators for pred: %s in @validate", attr)
+	}
+
+	var names []string
+	seen := make(map[string]bool)
+	expectArg := true
+	for {
+		if !it.Next() {
+			return nil, it.Item().Errorf("Invalid ending while parsing @validate for pred: %s",
+				attr)
+		}
+		next := it.Item()
+		switch {
+		case next.Typ == itemRightRound:
+			if expectArg {
+				return nil, next.Errorf("Expected a validator in @validate for pred: %s", attr)
+			}
+			return names, nil
+		case next.Typ == itemComma:
+			if expectArg {
+				return nil, next.Errorf("Expected a validator but got comma")
+			}
+			expectArg = true
+		case next.Typ != itemText:
+			return nil, next.Errorf("Expected a validator but got: %v", next.Val)
+		case !expectArg:
+			return nil, next.Errorf("Expected a comma but got: %v", next.Val)
+		default:
+			v, ok := validate.GetValidator(strings.ToLower(next.Val))
+			if !ok {
+				return nil, next.Errorf("Invalid validator %s", next.Val)
+			}
+			validatorType, ok := types.TypeForName(v.Type())
+			x.AssertTrue(ok) // Type is validated during validator loading.
+			if validatorType != typ {
+				return nil, next.Errorf("Validator: %s isn't valid for predicate: %s of type: %s",
+					v.Name(), attr, typ.Name())
+			}
+			if seen[v.Name()] {
+				return nil, next.Errorf("Duplicate validator %s in @validate for pred: %s",
+					v.Name(), attr)
+			}
+			seen[v.Name()] = true
+			names = append(names, v.Name())
+			expectArg = false
+		}
+	}
+}
+
+// parseFacetIndexDirective works on @facetindex(key1, key2), which indexes the facets of the edges
+// with the given keys. It returns the sorted facet keys.
+func parseFacetIndexDirective(it *lex.ItemIterator, predicate string) ([]string, error) {
+	_, attr := x.ParseNamespaceAttr(predicate)
+	if !it.Next() {
+		return nil, it.Item().Errorf("Invalid ending while parsing @facetindex for pred: %s", attr)
+	}
+	if next := it.Item(); next.Typ != itemLeftRound {
+		return nil, next.Errorf("Require facet keys for pred: %s in @facetindex", attr)
+	}
+
+	var keys []string
+	seen := make(map[string]bool)
+	expectArg := true
+	for {
+		if !it.Next() {
+			return nil, it.Item().Errorf("Invalid ending while parsing @facetindex for pred: %s",
+				attr)
+		}
+		next := it.Item()
+		switch {
+		case next.Typ == itemRightRound:
+			if expectArg {
+				return nil, next.Errorf("Expected a facet key in @facetindex for pred: %s", attr)
+			}
+			sort.Strings(keys)
+			return keys, nil
+		case next.Typ == itemComma:
+			if expectArg {
+				return nil, next.Errorf("Expected a facet key but got comma")
+			}
+			expectArg = true
+		case next.Typ != itemText:
+			return nil, next.Errorf("Expected a facet key but got: %v", next.Val)
+		case !expectArg:
+			return nil, next.Errorf("Expected a comma but got: %v", next.Val)
+		case seen[next.Val]:
+			return nil, next.Errorf("Duplicate facet key %s in @facetindex for pred: %s",
+				next.Val, attr)
+		default:
+			seen[next.Val] = true
+			keys = append(keys, next.Val)
+			expectArg = false
+		}
+	}
+}
+
+// facetValTypes maps the types that facets can be declared with to their facet value type.
+var facetValTypes = map[types.TypeID]api.Facet_ValType{
+	types.IntID:      api.Facet_INT,
+	types.FloatID:    api.Facet_FLOAT,
+	types.BoolID:     api.Facet_BOOL,
+	types.DateTimeID: api.Facet_DATETIME,
+	types.StringID:   api.Facet_STRING,
+}
+
+// parseFacetsDirective works on @facets(key1: type1, key2: type2), which declares the value types
+// of the facets of the edges. It returns the facets sorted by key, holding only their key and
+// value type.
+func parseFacetsDirective(it *lex.ItemIterator, predicate string) ([]*api.Facet, error) {
+	_, attr := x.ParseNamespaceAttr(predicate)
+	if !it.Next() {
+		return nil, it.Item().Errorf("Invalid ending while parsing @facets for pred: %s", attr)
+	}
+	if next := it.Item(); next.Typ != itemLeftRound {
+		return nil, next.Errorf("Require facet types for pred: %s in @facets", attr)
+	}
+
+	var fcs []*api.Facet
+	seen := make(map[string]bool)
+	for {
+		// Each facet is made of its key, a colon and its type, followed by a comma or the
+		// closing bracket.
+		var items []lex.Item
+		for i := 0; i < 4; i++ {
+			if !it.Next() {
+				return nil, it.Item().Errorf("Invalid ending while parsing @facets for pred: %s",
+					attr)
+			}
+			items = append(items, it.Item())
+		}
+		key, colon, typ, end := items[0], items[1], items[2], items[3]
+		if key.Typ != itemText {
+			return nil, key.Errorf("Expected a facet key but got: %v", key.Val)
+		}
+		if colon.Typ != itemColon {
+			return nil, colon.Errorf("Expected a colon after facet key %s", key.Val)
+		}
+		if typ.Typ != itemText {
+			return nil, typ.Errorf("Expected the type of facet %s but got: %v", key.Val, typ.Val)
+		}
+		tid, ok := types.TypeForName(strings.ToLower(typ.Val))
+		valType, valid := facetValTypes[tid]
+		if !ok || !valid {
+			return nil, typ.Errorf("Invalid type %s for facet %s of pred: %s", typ.Val,
+				key.Val, attr)
+		}
+		if seen[key.Val] {
+			return nil, key.Errorf("Duplicate facet %s in @facets for pred: %s", key.Val, attr)
+		}
+		seen[key.Val] = true
+		fcs = append(fcs, &api.Facet{Key: key.Val, ValType: valType})
+
+		switch end.Typ {
+		case itemComma:
+		case itemRightRound:
+			sort.Slice(fcs, func(i, j int) bool {
+				return fcs[i].Key < fcs[j].Key
+			})
+			return fcs, nil
+		default:
+			return nil, end.Errorf("Expected a comma or a closing bracket but got: %v", end.Val)
+		}
 	}
-	return schema, nil
 }
 
 // parseIndexDirective works on "@index" or "@index(customtokenizer)".
@@ -312,6 +711,14 @@ func parseTypeDeclaration(it *lex.ItemIterator, ns uint64) (*pb.TypeUpdate, erro
 	typeUpdate := &pb.TypeUpdate{TypeName: x.NamespaceAttr(ns, it.Item().Val)}
 
 	it.Next()
+	if it.Item().Typ == itemText && it.Item().Val == "extends" {
+		extends, err := parseTypeExtends(it, typeUpdate.TypeName, ns)
+		if err != nil {
+			return nil, err
+		}
+		typeUpdate.Extends = extends
+		it.Next()
+	}
 	if it.Item().Typ != itemLeftCurl {
 		return nil, it.Item().Errorf("Expected {. Got %v", it.Item().Val)
 	}
@@ -323,6 +730,14 @@ func parseTypeDeclaration(it *lex.ItemIterator, ns uint64) (*pb.TypeUpdate, erro
 		switch item.Typ {
 		case itemRightCurl:
 			it.Next()
+			if it.Item().Typ == itemAt {
+				trigger, err := parseTypeDirective(it, x.ParseAttr(typeUpdate.TypeName))
+				if err != nil {
+					return nil, err
+				}
+				typeUpdate.Trigger = trigger
+				it.Next()
+			}
 			if it.Item().Typ != itemNewLine && it.Item().Typ != lex.ItemEOF {
 				return nil, it.Item().Errorf(
 					"Expected new line or EOF after type declaration. Got %v", it.Item())
@@ -356,6 +771,47 @@ func parseTypeDeclaration(it *lex.ItemIterator, ns uint64) (*pb.TypeUpdate, erro
 	return nil, errors.Errorf("Shouldn't reach here.")
 }
 
+// parseTypeExtends works on the types extended by a type declaration, like "extends A, B". It
+// returns their namespaced names, and leaves the iterator on the last of them.
+func parseTypeExtends(it *lex.ItemIterator, typeName string, ns uint64) ([]string, error) {
+	var extends []string
+	for {
+		it.Next()
+		item := it.Item()
+		if item.Typ != itemText {
+			return nil, item.Errorf("Expected the name of a type extended by %s. Got %v",
+				x.ParseAttr(typeName), item.Val)
+		}
+		name := x.NamespaceAttr(ns, item.Val)
+		switch {
+		case name == typeName:
+			return nil, item.Errorf("Type %s can't extend itself", item.Val)
+		case x.HasString(extends, name):
+			return nil, item.Errorf("Type %s is extended multiple times by %s", item.Val,
+				x.ParseAttr(typeName))
+		}
+		extends = append(extends, name)
+
+		next, err := it.Peek(1)
+		if err != nil || len(next) != 1 || next[0].Typ != itemComma {
+			return extends, nil
+		}
+		it.Next()
+	}
+}
+
+// parseTypeDirective works on the directives following a type declaration, of which only @trigger
+// is supported. It returns the trigger.
+func parseTypeDirective(it *lex.ItemIterator, typeName string) (string, error) {
+	it.Next()
+	next := it.Item()
+	if next.Typ != itemText || next.Val != "trigger" {
+		return "", next.Errorf("Invalid directive for type %s, only @trigger is supported",
+			typeName)
+	}
+	return parseTriggerDirective(it, "type: "+typeName)
+}
+
 func parseTypeField(it *lex.ItemIterator, typeName string, ns uint64) (*pb.SchemaUpdate, error) {
 	field := &pb.SchemaUpdate{Predicate: x.NamespaceAttr(ns, it.Item().Val)}
 	var list bool
@@ -435,6 +891,9 @@ func parseNamespace(it *lex.ItemIterator) (uint64, error) {
 type ParsedSchema struct {
 	Preds []*pb.SchemaUpdate
 	Types []*pb.TypeUpdate
+	// Colocations maps the predicates with a @shard directive to the predicate they must be
+	// co-located with. Both are namespaced.
+	Colocations map[string]string
 }
 
 func isTypeDeclaration(item lex.Item, it *lex.ItemIterator) bool {
@@ -450,7 +909,8 @@ func isTypeDeclaration(item lex.Item, it *lex.ItemIterator) bool {
 	case nextItems[0].Typ != itemText:
 		return false
 
-	case nextItems[1].Typ != itemLeftCurl:
+	case nextItems[1].Typ != itemLeftCurl &&
+		!(nextItems[1].Typ == itemText && nextItems[1].Val == "extends"):
 		return false
 	}
 
@@ -487,11 +947,17 @@ func parse(s string, namespace uint64) (*ParsedSchema, error) {
 			return nil
 		}
 
-		schema, err := parseScalarPair(it, item.Val, ns)
+		schema, sameAs, err := parseScalarPair(it, item.Val, ns)
 		if err != nil {
 			return err
 		}
 		result.Preds = append(result.Preds, schema)
+		if sameAs != "" {
+			if result.Colocations == nil {
+				result.Colocations = make(map[string]string)
+			}
+			result.Colocations[schema.Predicate] = sameAs
+		}
 		return nil
 	}
 
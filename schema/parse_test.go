@@ -25,6 +25,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/dgraph-io/badger/v3"
+	"github.com/dgraph-io/dgo/v210/protos/api"
 	"github.com/dgraph-io/dgraph/protos/pb"
 	"github.com/dgraph-io/dgraph/types"
 	"github.com/dgraph-io/dgraph/x"
@@ -267,6 +268,270 @@ func TestParse9_Error(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestParseOwned(t *testing.T) {
+	reset()
+	result, err := Parse("items: [uid] @owned @reverse .")
+	require.NoError(t, err)
+	require.EqualValues(t, &pb.SchemaUpdate{
+		Predicate: x.GalaxyAttr("items"),
+		ValueType: pb.Posting_UID,
+		Directive: pb.SchemaUpdate_REVERSE,
+		List:      true,
+		Owned:     true,
+	}, result.Preds[0])
+
+	_, err = Parse("name: string @owned .")
+	require.Error(t, err)
+}
+
+func TestParseSequence(t *testing.T) {
+	reset()
+	result, err := Parse("order_no: int @sequence @index(int) .")
+	require.NoError(t, err)
+	require.EqualValues(t, &pb.SchemaUpdate{
+		Predicate: x.GalaxyAttr("order_no"),
+		ValueType: pb.Posting_INT,
+		Directive: pb.SchemaUpdate_INDEX,
+		Tokenizer: []string{"int"},
+		Sequence:  true,
+	}, result.Preds[0])
+
+	_, err = Parse("name: string @sequence .")
+	require.Error(t, err)
+	_, err = Parse("order_no: [int] @sequence .")
+	require.Error(t, err)
+}
+
+func TestParseCounter(t *testing.T) {
+	reset()
+	result, err := Parse("likes: counter @noconflict .")
+	require.NoError(t, err)
+	require.EqualValues(t, &pb.SchemaUpdate{
+		Predicate:  x.GalaxyAttr("likes"),
+		ValueType:  pb.Posting_INT,
+		NoConflict: true,
+		Counter:    true,
+	}, result.Preds[0])
+
+	for _, s := range []string{
+		"likes: [counter] .",
+		"likes: counter @index(int) .",
+		"likes: counter @upsert .",
+		"likes: counter @sequence .",
+	} {
+		_, err = Parse(s)
+		require.Error(t, err, s)
+	}
+}
+
+func TestParseOrdered(t *testing.T) {
+	reset()
+	result, err := Parse("tags: [string] @index(exact) @ordered .")
+	require.NoError(t, err)
+	require.EqualValues(t, &pb.SchemaUpdate{
+		Predicate: x.GalaxyAttr("tags"),
+		ValueType: pb.Posting_STRING,
+		List:      true,
+		Directive: pb.SchemaUpdate_INDEX,
+		Tokenizer: []string{"exact"},
+		Ordered:   true,
+	}, result.Preds[0])
+
+	for _, s := range []string{
+		"tags: string @ordered .",
+		"friend: [uid] @ordered .",
+	} {
+		_, err = Parse(s)
+		require.Error(t, err, s)
+	}
+}
+
+func TestParsePresence(t *testing.T) {
+	reset()
+	result, err := Parse("nick: string @index(exact) @presence .")
+	require.NoError(t, err)
+	require.EqualValues(t, &pb.SchemaUpdate{
+		Predicate: x.GalaxyAttr("nick"),
+		ValueType: pb.Posting_STRING,
+		Directive: pb.SchemaUpdate_INDEX,
+		Tokenizer: []string{"exact"},
+		Presence:  true,
+	}, result.Preds[0])
+
+	require.NoError(t, ParseBytes([]byte("nick: string @presence .\nname: string ."), 1))
+	require.True(t, State().HasPresence(context.Background(), x.GalaxyAttr("nick")))
+	require.False(t, State().HasPresence(context.Background(), x.GalaxyAttr("name")))
+}
+
+func TestParseValidate(t *testing.T) {
+	reset()
+	result, err := Parse("email: string @validate(trim, lowercase) @index(exact) .")
+	require.NoError(t, err)
+	require.EqualValues(t, &pb.SchemaUpdate{
+		Predicate:  x.GalaxyAttr("email"),
+		ValueType:  pb.Posting_STRING,
+		Directive:  pb.SchemaUpdate_INDEX,
+		Tokenizer:  []string{"exact"},
+		Validators: []string{"trim", "lowercase"},
+	}, result.Preds[0])
+
+	for _, s := range []string{
+		"email: string @validate .",
+		"email: string @validate() .",
+		"email: string @validate(trim,) .",
+		"email: string @validate(trim, trim) .",
+		"email: string @validate(unknown) .",
+		"age: int @validate(trim) .",
+	} {
+		_, err = Parse(s)
+		require.Error(t, err, s)
+	}
+}
+
+func TestParseFacetIndex(t *testing.T) {
+	reset()
+	result, err := Parse("friend: [uid] @facetindex(since, close) @count .")
+	require.NoError(t, err)
+	require.EqualValues(t, &pb.SchemaUpdate{
+		Predicate:  x.GalaxyAttr("friend"),
+		ValueType:  pb.Posting_UID,
+		List:       true,
+		Count:      true,
+		FacetIndex: []string{"close", "since"},
+	}, result.Preds[0])
+
+	_, err = Parse("name: string @facetindex(since) .")
+	require.Error(t, err)
+	_, err = Parse("friend: [uid] @facetindex .")
+	require.Error(t, err)
+	_, err = Parse("friend: [uid] @facetindex(since, since) .")
+	require.Error(t, err)
+	_, err = Parse("friend: [uid] @facetindex(since,) .")
+	require.Error(t, err)
+}
+
+func TestParseFacets(t *testing.T) {
+	reset()
+	result, err := Parse("friend: [uid] @facets(since: dateTime, weight: float) @reverse .")
+	require.NoError(t, err)
+	require.EqualValues(t, &pb.SchemaUpdate{
+		Predicate: x.GalaxyAttr("friend"),
+		ValueType: pb.Posting_UID,
+		Directive: pb.SchemaUpdate_REVERSE,
+		List:      true,
+		Facets: []*api.Facet{
+			{Key: "since", ValType: api.Facet_DATETIME},
+			{Key: "weight", ValType: api.Facet_FLOAT},
+		},
+	}, result.Preds[0])
+
+	_, err = Parse("friend: [uid] @facets(since: geo) .")
+	require.Error(t, err)
+	_, err = Parse("friend: [uid] @facets(since: int, since: float) .")
+	require.Error(t, err)
+	_, err = Parse("friend: [uid] @facets(since) .")
+	require.Error(t, err)
+}
+
+func TestParseView(t *testing.T) {
+	reset()
+	result, err := Parse(`total: float @view("query v($changed: string) {
+		view(func: uid($changed)) { uid value: sum(val(a)) }
+	}") @index(float) .`)
+	require.NoError(t, err)
+	require.EqualValues(t, &pb.SchemaUpdate{
+		Predicate: x.GalaxyAttr("total"),
+		ValueType: pb.Posting_FLOAT,
+		Directive: pb.SchemaUpdate_INDEX,
+		Tokenizer: []string{"float"},
+		View: "query v($changed: string) {\n\t\tview(func: uid($changed)) " +
+			"{ uid value: sum(val(a)) }\n\t}",
+	}, result.Preds[0])
+
+	_, err = Parse(`friend: uid @view("{ view(func: uid(0x1)) { uid } }") .`)
+	require.Error(t, err)
+	_, err = Parse(`total: [float] @view("{ view(func: uid(0x1)) { uid } }") .`)
+	require.Error(t, err)
+	_, err = Parse(`total: float @view .`)
+	require.Error(t, err)
+	_, err = Parse(`total: float @view("") .`)
+	require.Error(t, err)
+}
+
+func TestParseTrigger(t *testing.T) {
+	reset()
+	result, err := Parse(`
+		amount: float @trigger("webhook: https://example.com/orders") .
+		type Order {
+			amount
+		} @trigger("lambda:orderChanged")
+	`)
+	require.NoError(t, err)
+	require.Equal(t, "webhook: https://example.com/orders", result.Preds[0].Trigger)
+	require.Equal(t, "lambda:orderChanged", result.Types[0].Trigger)
+
+	kind, target, err := ParseTrigger(result.Types[0].Trigger)
+	require.NoError(t, err)
+	require.Equal(t, TriggerLambda, kind)
+	require.Equal(t, "orderChanged", target)
+
+	for _, s := range []string{
+		`amount: float @trigger .`,
+		`amount: float @trigger("https://example.com") .`,
+		`amount: float @trigger("webhook: /orders") .`,
+		`amount: float @trigger("lambda:") .`,
+		`amount: float @trigger("email: a@b.com") .`,
+		"type Order {\n amount\n} @index(exact)",
+	} {
+		_, err := Parse(s)
+		require.Error(t, err, s)
+	}
+}
+
+func TestParseTypeExtends(t *testing.T) {
+	reset()
+	result, err := Parse(`
+		name: string .
+		breed: string .
+		type Animal {
+			name
+		}
+		type Pet {
+		}
+		type Dog extends Animal, <Pet> {
+			breed
+		}
+	`)
+	require.NoError(t, err)
+	require.Len(t, result.Types, 3)
+	require.Equal(t, []string{x.GalaxyAttr("Animal"), x.GalaxyAttr("Pet")}, result.Types[2].Extends)
+	require.NoError(t, ValidateTypeHierarchy(result.Types))
+
+	for _, typ := range result.Types {
+		State().SetType(typ.TypeName, *typ)
+	}
+	require.Equal(t, []string{x.GalaxyAttr("Animal"), x.GalaxyAttr("Pet")},
+		State().Supertypes(x.GalaxyAttr("Dog")))
+
+	// The types extended must be defined, and can't make a cycle.
+	result, err = Parse("type Puppy extends Dog, Cat {\n}")
+	require.NoError(t, err)
+	require.Error(t, ValidateTypeHierarchy(result.Types))
+	result, err = Parse("type Animal extends Dog {\n name\n}")
+	require.NoError(t, err)
+	require.Error(t, ValidateTypeHierarchy(result.Types))
+
+	for _, s := range []string{
+		"type Dog extends Dog {\n}",
+		"type Dog extends Animal, Animal {\n}",
+		"type Dog extends {\n}",
+		"type Dog extends Animal, {\n}",
+	} {
+		_, err := Parse(s)
+		require.Error(t, err, s)
+	}
+}
+
 func TestParseScalarList(t *testing.T) {
 	reset()
 	result, err := Parse(`
@@ -653,6 +918,84 @@ func TestParseWithNamespace(t *testing.T) {
 	}, result.Types[0])
 }
 
+func TestParseShard(t *testing.T) {
+	reset()
+	result, err := Parse(`
+		friend: [uid] @reverse .
+		name: string @index(exact) @shard(group: "same-as: friend") .
+		[0x2] age: int @shard( group : "same-as:friend" ) @index(int) .
+	`)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(result.Preds))
+	require.Equal(t, map[string]string{
+		x.GalaxyAttr("name"):      x.GalaxyAttr("friend"),
+		x.NamespaceAttr(2, "age"): x.NamespaceAttr(2, "friend"),
+	}, result.Colocations)
+	require.Equal(t, []string{"int"}, result.Preds[2].Tokenizer)
+
+	result, err = Parse("name: string .")
+	require.NoError(t, err)
+	require.Nil(t, result.Colocations)
+}
+
+func TestParseShard_Error(t *testing.T) {
+	reset()
+	for _, s := range []string{
+		`name: string @shard .`,
+		`name: string @shard(group: same-as) .`,
+		`name: string @shard(node: "same-as: friend") .`,
+		`name: string @shard(group: "with: friend") .`,
+		`name: string @shard(group: "same-as: ") .`,
+		`name: string @shard(group: "same-as: name") .`,
+		`name: string @shard(group: "same-as: dgraph.type") .`,
+		`name: string @shard(group: "same-as: friend" .`,
+	} {
+		_, err := Parse(s)
+		require.Error(t, err, s)
+	}
+}
+
+func TestLoadFromDbLazy(t *testing.T) {
+	reset()
+	name, age := x.GalaxyAttr("lazy_name"), x.GalaxyAttr("lazy_age")
+	txn := ps.NewTransactionAt(1, true)
+	for _, su := range []*pb.SchemaUpdate{
+		{Predicate: name, ValueType: pb.Posting_STRING, Tokenizer: []string{"exact"}},
+		{Predicate: age, ValueType: pb.Posting_INT, List: true},
+	} {
+		data, err := su.Marshal()
+		require.NoError(t, err)
+		require.NoError(t, txn.Set(x.SchemaKey(su.Predicate), data))
+	}
+	require.NoError(t, txn.CommitAt(1, nil))
+
+	require.NoError(t, LoadFromDb())
+	// The schema is decoded on first use of a predicate.
+	require.Contains(t, State().encoded, name)
+	require.Contains(t, State().encoded, age)
+	require.Contains(t, State().Predicates(), name)
+
+	require.True(t, State().IsIndexed(context.Background(), name))
+	require.NotContains(t, State().encoded, name)
+	require.Contains(t, State().encoded, age)
+
+	typ, err := State().TypeOf(age)
+	require.NoError(t, err)
+	require.Equal(t, types.IntID, typ)
+	require.True(t, State().IsList(age))
+	require.Empty(t, State().encoded)
+	require.Zero(t, State().numEncoded)
+
+	// Setting the schema of a predicate replaces its encoded one.
+	require.NoError(t, LoadFromDb())
+	State().Set(age, &pb.SchemaUpdate{Predicate: age, ValueType: pb.Posting_FLOAT})
+	typ, err = State().TypeOf(age)
+	require.NoError(t, err)
+	require.Equal(t, types.FloatID, typ)
+	State().DeleteAll()
+	require.Zero(t, State().numEncoded)
+}
+
 var ps *badger.DB
 
 func TestMain(m *testing.M) {
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"math"
 	"sync"
+	"sync/atomic"
 
 	"github.com/golang/glog"
 	"github.com/golang/protobuf/proto"
@@ -30,6 +31,7 @@ import (
 
 	"github.com/dgraph-io/badger/v3"
 	badgerpb "github.com/dgraph-io/badger/v3/pb"
+	"github.com/dgraph-io/dgo/v210/protos/api"
 	"github.com/dgraph-io/dgraph/protos/pb"
 	"github.com/dgraph-io/dgraph/tok"
 	"github.com/dgraph-io/dgraph/types"
@@ -62,9 +64,14 @@ func (s *state) init() {
 	s.types = make(map[string]*pb.TypeUpdate)
 	s.elog = trace.NewEventLog("Dgraph", "Schema")
 	s.mutSchema = make(map[string]*pb.SchemaUpdate)
+	s.encoded = make(map[string][]byte)
 }
 
 type state struct {
+	// numEncoded is the number of predicates in encoded, read atomically to skip the lookups in
+	// encoded once they're all decoded.
+	numEncoded int64
+
 	sync.RWMutex
 	// Map containing predicate to type information.
 	predicate map[string]*pb.SchemaUpdate
@@ -72,6 +79,62 @@ type state struct {
 	elog      trace.EventLog
 	// mutSchema holds the schema update that is being applied in the background.
 	mutSchema map[string]*pb.SchemaUpdate
+	// encoded holds the schema of the predicates loaded from the DB and not used yet, as it's
+	// stored. They're decoded on first use, so that an Alpha with many predicates doesn't have to
+	// decode them all at startup.
+	encoded map[string][]byte
+}
+
+// decode decodes the schema of the predicate if it's still encoded.
+func (s *state) decode(pred string) {
+	if atomic.LoadInt64(&s.numEncoded) == 0 {
+		return
+	}
+	s.RLock()
+	_, ok := s.encoded[pred]
+	s.RUnlock()
+	if !ok {
+		return
+	}
+	s.Lock()
+	defer s.Unlock()
+	s.decodeLocked(pred)
+}
+
+// decodeLocked decodes the schema of the predicate if it's still encoded. It must be called with
+// the lock held.
+func (s *state) decodeLocked(pred string) {
+	val, ok := s.encoded[pred]
+	if !ok {
+		return
+	}
+	s.deleteEncoded(pred)
+	su := pb.SchemaUpdate{Predicate: pred, ValueType: pb.Posting_DEFAULT}
+	if len(val) > 0 {
+		su = pb.SchemaUpdate{}
+		x.Checkf(su.Unmarshal(val), "Error while loading schema from db")
+	}
+	s.predicate[pred] = &su
+	s.elog.Printf(logUpdate(&su, pred))
+}
+
+// setEncoded sets the encoded schema of the predicate, as stored in the DB.
+func (s *state) setEncoded(pred string, val []byte) {
+	s.Lock()
+	defer s.Unlock()
+	if _, ok := s.encoded[pred]; !ok {
+		atomic.AddInt64(&s.numEncoded, 1)
+	}
+	s.encoded[pred] = val
+	delete(s.predicate, pred)
+}
+
+// deleteEncoded deletes the encoded schema of the predicate. It must be called with the lock held.
+func (s *state) deleteEncoded(pred string) {
+	if _, ok := s.encoded[pred]; ok {
+		delete(s.encoded, pred)
+		atomic.AddInt64(&s.numEncoded, -1)
+	}
 }
 
 // State returns the struct holding the current schema.
@@ -94,6 +157,10 @@ func (s *state) DeleteAll() {
 	for pred := range s.mutSchema {
 		delete(s.mutSchema, pred)
 	}
+
+	for pred := range s.encoded {
+		s.deleteEncoded(pred)
+	}
 }
 
 // Delete updates the schema in memory and disk
@@ -113,6 +180,7 @@ func (s *state) Delete(attr string, ts uint64) error {
 
 	delete(s.predicate, attr)
 	delete(s.mutSchema, attr)
+	s.deleteEncoded(attr)
 	return nil
 }
 
@@ -169,6 +237,11 @@ func (s *state) DeletePredsForNs(delNs uint64) {
 			delete(s.mutSchema, pred)
 		}
 	}
+	for pred := range s.encoded {
+		if x.ParseNamespace(pred) == delNs {
+			s.deleteEncoded(pred)
+		}
+	}
 	for typ := range s.types {
 		ns := x.ParseNamespace(typ)
 		if ns == delNs {
@@ -203,6 +276,7 @@ func (s *state) Set(pred string, schema *pb.SchemaUpdate) {
 
 	s.Lock()
 	defer s.Unlock()
+	s.deleteEncoded(pred)
 	s.predicate[pred] = schema
 	s.elog.Printf(logUpdate(schema, pred))
 }
@@ -249,6 +323,7 @@ func (s *state) SetType(typeName string, typ pb.TypeUpdate) {
 // Get gets the schema for the given predicate.
 func (s *state) Get(ctx context.Context, pred string) (pb.SchemaUpdate, bool) {
 	isWrite, _ := ctx.Value(isWrite).(bool)
+	s.decode(pred)
 	s.RLock()
 	defer s.RUnlock()
 	// If this is write context, mutSchema will have the updated schema.
@@ -277,8 +352,75 @@ func (s *state) GetType(typeName string) (pb.TypeUpdate, bool) {
 	return *typ, true
 }
 
+// Supertypes returns the types extended by the given type, directly or through other types.
+func (s *state) Supertypes(typeName string) []string {
+	s.RLock()
+	defer s.RUnlock()
+	return Supertypes(typeName, func(name string) []string {
+		if typ, ok := s.types[name]; ok {
+			return typ.Extends
+		}
+		return nil
+	})
+}
+
+// Supertypes returns the types extended by the given type, directly or through other types, from
+// the types directly extended by each type. The type itself is left out, even if it's in a cycle.
+func Supertypes(typeName string, extends func(name string) []string) []string {
+	var res []string
+	seen := map[string]bool{typeName: true}
+	queue := append([]string{}, extends(typeName)...)
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		res = append(res, name)
+		queue = append(queue, extends(name)...)
+	}
+	return res
+}
+
+// ValidateTypeHierarchy checks that the types extended by the given types are defined, either by
+// the updates or by the current schema, and that no type extends itself through other types.
+func ValidateTypeHierarchy(updates []*pb.TypeUpdate) error {
+	defined := make(map[string]*pb.TypeUpdate, len(updates))
+	for _, update := range updates {
+		defined[update.TypeName] = update
+	}
+	lookup := func(name string) (*pb.TypeUpdate, bool) {
+		if update, ok := defined[name]; ok {
+			return update, true
+		}
+		typ, ok := State().GetType(name)
+		return &typ, ok
+	}
+	extends := func(name string) []string {
+		typ, _ := lookup(name)
+		return typ.Extends
+	}
+
+	for _, update := range updates {
+		for _, name := range update.Extends {
+			if _, ok := lookup(name); !ok {
+				return errors.Errorf("Type %s extends the undefined type %s",
+					x.ParseAttr(update.TypeName), x.ParseAttr(name))
+			}
+			if name == update.TypeName ||
+				x.HasString(Supertypes(name, extends), update.TypeName) {
+				return errors.Errorf("Type %s extends itself through type %s",
+					x.ParseAttr(update.TypeName), x.ParseAttr(name))
+			}
+		}
+	}
+	return nil
+}
+
 // TypeOf returns the schema type of predicate
 func (s *state) TypeOf(pred string) (types.TypeID, error) {
+	s.decode(pred)
 	s.RLock()
 	defer s.RUnlock()
 	if schema, ok := s.predicate[pred]; ok {
@@ -290,6 +432,7 @@ func (s *state) TypeOf(pred string) (types.TypeID, error) {
 // IsIndexed returns whether the predicate is indexed or not
 func (s *state) IsIndexed(ctx context.Context, pred string) bool {
 	isWrite, _ := ctx.Value(isWrite).(bool)
+	s.decode(pred)
 	s.RLock()
 	defer s.RUnlock()
 	if isWrite {
@@ -318,6 +461,9 @@ func (s *state) Predicates() []string {
 	for k := range s.predicate {
 		out = append(out, k)
 	}
+	for k := range s.encoded {
+		out = append(out, k)
+	}
 	return out
 }
 
@@ -339,6 +485,7 @@ func (s *state) Types() []string {
 // Tokenizer returns the tokenizer for given predicate
 func (s *state) Tokenizer(ctx context.Context, pred string) []tok.Tokenizer {
 	isWrite, _ := ctx.Value(isWrite).(bool)
+	s.decode(pred)
 	s.RLock()
 	defer s.RUnlock()
 	var su *pb.SchemaUpdate
@@ -391,6 +538,7 @@ func (s *state) HasTokenizer(ctx context.Context, id byte, pred string) bool {
 // IsReversed returns whether the predicate has reverse edge or not
 func (s *state) IsReversed(ctx context.Context, pred string) bool {
 	isWrite, _ := ctx.Value(isWrite).(bool)
+	s.decode(pred)
 	s.RLock()
 	defer s.RUnlock()
 	if isWrite {
@@ -404,9 +552,82 @@ func (s *state) IsReversed(ctx context.Context, pred string) bool {
 	return false
 }
 
+// IsOwned returns whether the uid predicate owns its objects, which are then deleted along with
+// the edges to them.
+func (s *state) IsOwned(ctx context.Context, pred string) bool {
+	isWrite, _ := ctx.Value(isWrite).(bool)
+	s.decode(pred)
+	s.RLock()
+	defer s.RUnlock()
+	if isWrite {
+		if schema, ok := s.mutSchema[pred]; ok {
+			return schema.Owned
+		}
+	}
+	return s.predicate[pred].GetOwned()
+}
+
+// FacetIndexes returns the facet keys indexed for the given predicate.
+func (s *state) FacetIndexes(ctx context.Context, pred string) []string {
+	isWrite, _ := ctx.Value(isWrite).(bool)
+	s.decode(pred)
+	s.RLock()
+	defer s.RUnlock()
+	if isWrite {
+		if schema, ok := s.mutSchema[pred]; ok {
+			return schema.FacetIndex
+		}
+	}
+	return s.predicate[pred].GetFacetIndex()
+}
+
+// FacetTypes returns the facets declared for the given predicate, holding their key and value
+// type.
+func (s *state) FacetTypes(ctx context.Context, pred string) []*api.Facet {
+	isWrite, _ := ctx.Value(isWrite).(bool)
+	s.decode(pred)
+	s.RLock()
+	defer s.RUnlock()
+	if isWrite {
+		if schema, ok := s.mutSchema[pred]; ok {
+			return schema.Facets
+		}
+	}
+	return s.predicate[pred].GetFacets()
+}
+
+// View returns the query which maintains the given predicate, if it is a view.
+func (s *state) View(ctx context.Context, pred string) string {
+	isWrite, _ := ctx.Value(isWrite).(bool)
+	s.decode(pred)
+	s.RLock()
+	defer s.RUnlock()
+	if isWrite {
+		if schema, ok := s.mutSchema[pred]; ok {
+			return schema.View
+		}
+	}
+	return s.predicate[pred].GetView()
+}
+
+// Trigger returns the trigger of the given predicate, if any.
+func (s *state) Trigger(ctx context.Context, pred string) string {
+	isWrite, _ := ctx.Value(isWrite).(bool)
+	s.decode(pred)
+	s.RLock()
+	defer s.RUnlock()
+	if isWrite {
+		if schema, ok := s.mutSchema[pred]; ok {
+			return schema.Trigger
+		}
+	}
+	return s.predicate[pred].GetTrigger()
+}
+
 // HasCount returns whether we want to mantain a count index for the given predicate or not.
 func (s *state) HasCount(ctx context.Context, pred string) bool {
 	isWrite, _ := ctx.Value(isWrite).(bool)
+	s.decode(pred)
 	s.RLock()
 	defer s.RUnlock()
 	if isWrite {
@@ -420,8 +641,24 @@ func (s *state) HasCount(ctx context.Context, pred string) bool {
 	return false
 }
 
+// HasPresence returns whether the uids of the nodes having a value for the predicate are kept in a
+// presence list.
+func (s *state) HasPresence(ctx context.Context, pred string) bool {
+	isWrite, _ := ctx.Value(isWrite).(bool)
+	s.decode(pred)
+	s.RLock()
+	defer s.RUnlock()
+	if isWrite {
+		if schema, ok := s.mutSchema[pred]; ok && schema.Presence {
+			return true
+		}
+	}
+	return s.predicate[pred].GetPresence()
+}
+
 // IsList returns whether the predicate is of list type.
 func (s *state) IsList(pred string) bool {
+	s.decode(pred)
 	s.RLock()
 	defer s.RUnlock()
 	if schema, ok := s.predicate[pred]; ok {
@@ -431,6 +668,7 @@ func (s *state) IsList(pred string) bool {
 }
 
 func (s *state) HasUpsert(pred string) bool {
+	s.decode(pred)
 	s.RLock()
 	defer s.RUnlock()
 	if schema, ok := s.predicate[pred]; ok {
@@ -440,6 +678,7 @@ func (s *state) HasUpsert(pred string) bool {
 }
 
 func (s *state) HasLang(pred string) bool {
+	s.decode(pred)
 	s.RLock()
 	defer s.RUnlock()
 	if schema, ok := s.predicate[pred]; ok {
@@ -449,11 +688,45 @@ func (s *state) HasLang(pred string) bool {
 }
 
 func (s *state) HasNoConflict(pred string) bool {
+	s.decode(pred)
 	s.RLock()
 	defer s.RUnlock()
 	return s.predicate[pred].GetNoConflict()
 }
 
+// IsSequence returns whether the values of the int predicate are allocated by Zero.
+func (s *state) IsSequence(pred string) bool {
+	s.decode(pred)
+	s.RLock()
+	defer s.RUnlock()
+	return s.predicate[pred].GetSequence()
+}
+
+// IsCounter returns whether the predicate is a counter, whose mutations add their value to the
+// current one.
+func (s *state) IsCounter(pred string) bool {
+	s.decode(pred)
+	s.RLock()
+	defer s.RUnlock()
+	return s.predicate[pred].GetCounter()
+}
+
+// IsOrdered returns whether the values of the list predicate are kept in the order they were set.
+func (s *state) IsOrdered(pred string) bool {
+	s.decode(pred)
+	s.RLock()
+	defer s.RUnlock()
+	return s.predicate[pred].GetOrdered()
+}
+
+// Validators returns the validators called on the values set on the predicate, in turn.
+func (s *state) Validators(pred string) []string {
+	s.decode(pred)
+	s.RLock()
+	defer s.RUnlock()
+	return s.predicate[pred].GetValidators()
+}
+
 // IndexingInProgress checks whether indexing is going on for a given predicate.
 func (s *state) IndexingInProgress() bool {
 	s.RLock()
@@ -543,16 +816,13 @@ func loadFromDB(loadType int) error {
 
 		switch loadType {
 		case loadSchema:
-			var s pb.SchemaUpdate
-			err := item.Value(func(val []byte) error {
-				if len(val) == 0 {
-					s = pb.SchemaUpdate{Predicate: pk.Attr, ValueType: pb.Posting_DEFAULT}
-				}
-				x.Checkf(s.Unmarshal(val), "Error while loading schema from db")
-				State().Set(pk.Attr, &s)
-				return nil
-			})
-			return nil, err
+			// The schema is decoded on first use of the predicate.
+			val, err := item.ValueCopy(nil)
+			if err != nil {
+				return nil, err
+			}
+			State().setEncoded(pk.Attr, val)
+			return nil, nil
 		case loadType:
 			var t pb.TypeUpdate
 			err := item.Value(func(val []byte) error {
@@ -632,6 +902,14 @@ func initialTypesInternal(namespace uint64, all bool) []*pb.TypeUpdate {
 					Predicate: "dgraph.user.group",
 					ValueType: pb.Posting_UID,
 				},
+				{
+					Predicate: "dgraph.user.password_updated",
+					ValueType: pb.Posting_DATETIME,
+				},
+				{
+					Predicate: "dgraph.user.password_scheme",
+					ValueType: pb.Posting_STRING,
+				},
 			},
 		},
 			&pb.TypeUpdate{
@@ -659,6 +937,27 @@ func initialTypesInternal(namespace uint64, all bool) []*pb.TypeUpdate {
 						ValueType: pb.Posting_INT,
 					},
 				},
+			},
+			&pb.TypeUpdate{
+				TypeName: "dgraph.type.Session",
+				Fields: []*pb.SchemaUpdate{
+					{
+						Predicate: "dgraph.session.id",
+						ValueType: pb.Posting_STRING,
+					},
+					{
+						Predicate: "dgraph.session.user",
+						ValueType: pb.Posting_STRING,
+					},
+					{
+						Predicate: "dgraph.session.expiry",
+						ValueType: pb.Posting_DATETIME,
+					},
+					{
+						Predicate: "dgraph.session.revoked",
+						ValueType: pb.Posting_BOOL,
+					},
+				},
 			})
 	}
 
@@ -703,6 +1002,32 @@ func initialSchemaInternal(namespace uint64, all bool) []*pb.SchemaUpdate {
 		}, &pb.SchemaUpdate{
 			Predicate: "dgraph.graphql.schema",
 			ValueType: pb.Posting_STRING,
+		}, &pb.SchemaUpdate{
+			Predicate: "dgraph.runtime_config",
+			ValueType: pb.Posting_STRING,
+		}, &pb.SchemaUpdate{
+			Predicate: "dgraph.colocation",
+			ValueType: pb.Posting_STRING,
+		}, &pb.SchemaUpdate{
+			Predicate: "dgraph.external_id",
+			ValueType: pb.Posting_STRING,
+			Directive: pb.SchemaUpdate_INDEX,
+			Tokenizer: []string{"exact"},
+			Upsert:    true,
+		}, &pb.SchemaUpdate{
+			Predicate: "dgraph.idempotency.key",
+			ValueType: pb.Posting_STRING,
+			Directive: pb.SchemaUpdate_INDEX,
+			Tokenizer: []string{"exact"},
+			Upsert:    true,
+		}, &pb.SchemaUpdate{
+			Predicate: "dgraph.idempotency.record",
+			ValueType: pb.Posting_STRING,
+		}, &pb.SchemaUpdate{
+			Predicate: "dgraph.idempotency.expiry",
+			ValueType: pb.Posting_DATETIME,
+			Directive: pb.SchemaUpdate_INDEX,
+			Tokenizer: []string{"hour"},
 		}, &pb.SchemaUpdate{
 			Predicate: "dgraph.graphql.xid",
 			ValueType: pb.Posting_STRING,
@@ -736,6 +1061,14 @@ func initialSchemaInternal(namespace uint64, all bool) []*pb.SchemaUpdate {
 				ValueType: pb.Posting_UID,
 				List:      true,
 			},
+			{
+				Predicate: "dgraph.user.password_updated",
+				ValueType: pb.Posting_DATETIME,
+			},
+			{
+				Predicate: "dgraph.user.password_scheme",
+				ValueType: pb.Posting_STRING,
+			},
 			{
 				Predicate: "dgraph.acl.rule",
 				ValueType: pb.Posting_UID,
@@ -752,6 +1085,26 @@ func initialSchemaInternal(namespace uint64, all bool) []*pb.SchemaUpdate {
 				Predicate: "dgraph.rule.permission",
 				ValueType: pb.Posting_INT,
 			},
+			{
+				Predicate: "dgraph.session.id",
+				ValueType: pb.Posting_STRING,
+				Directive: pb.SchemaUpdate_INDEX,
+				Tokenizer: []string{"exact"},
+			},
+			{
+				Predicate: "dgraph.session.user",
+				ValueType: pb.Posting_STRING,
+				Directive: pb.SchemaUpdate_INDEX,
+				Tokenizer: []string{"exact"},
+			},
+			{
+				Predicate: "dgraph.session.expiry",
+				ValueType: pb.Posting_DATETIME,
+			},
+			{
+				Predicate: "dgraph.session.revoked",
+				ValueType: pb.Posting_BOOL,
+			},
 		}...)
 	}
 	for _, sch := range initialSchema {
@@ -0,0 +1,372 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sparql
+
+import (
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// The XML schema datatypes of the literals.
+const (
+	XSDString   = "http://www.w3.org/2001/XMLSchema#string"
+	XSDInteger  = "http://www.w3.org/2001/XMLSchema#integer"
+	XSDDecimal  = "http://www.w3.org/2001/XMLSchema#decimal"
+	XSDDouble   = "http://www.w3.org/2001/XMLSchema#double"
+	XSDBoolean  = "http://www.w3.org/2001/XMLSchema#boolean"
+	XSDDateTime = "http://www.w3.org/2001/XMLSchema#dateTime"
+)
+
+// TermKind is the kind of a term.
+type TermKind int
+
+const (
+	VarTerm TermKind = iota
+	IRITerm
+	LiteralTerm
+)
+
+// Term is a variable, an IRI or a literal. The nodes of Dgraph are IRIs holding their UIDs,
+// like <0x1>.
+type Term struct {
+	Kind     TermKind
+	Value    string
+	Lang     string
+	Datatype string
+}
+
+func (t *Term) isNumeric() bool {
+	switch t.Datatype {
+	case XSDInteger, XSDDecimal, XSDDouble:
+		return true
+	}
+	return false
+}
+
+// Equal returns true if both terms are the same IRI, or equal literals. Numeric literals are
+// equal if their values are, and the plain literals are equal to the strings.
+func (t *Term) Equal(o *Term) bool {
+	if t.Kind != o.Kind {
+		return false
+	}
+	if t.Kind == LiteralTerm {
+		if t.isNumeric() && o.isNumeric() {
+			a, errA := strconv.ParseFloat(t.Value, 64)
+			b, errB := strconv.ParseFloat(o.Value, 64)
+			if errA == nil && errB == nil {
+				return a == b
+			}
+		}
+		if t.Lang != o.Lang || plainType(t.Datatype) != plainType(o.Datatype) {
+			return false
+		}
+	}
+	return t.Value == o.Value
+}
+
+func plainType(dt string) string {
+	if dt == "" {
+		return XSDString
+	}
+	return dt
+}
+
+// Binding maps the variables of a solution to their values.
+type Binding map[string]*Term
+
+// Filter returns true if the expression is true for the binding. The expressions raising errors,
+// like those comparing unbound variables, are false.
+func (b Binding) Filter(expr Expr) bool {
+	t, err := b.eval(expr)
+	if err != nil {
+		return false
+	}
+	ok, err := boolValue(t)
+	return err == nil && ok
+}
+
+var (
+	trueTerm  = &Term{Kind: LiteralTerm, Value: "true", Datatype: XSDBoolean}
+	falseTerm = &Term{Kind: LiteralTerm, Value: "false", Datatype: XSDBoolean}
+)
+
+func boolTerm(ok bool) *Term {
+	if ok {
+		return trueTerm
+	}
+	return falseTerm
+}
+
+// boolValue returns the effective boolean value of the term.
+func boolValue(t *Term) (bool, error) {
+	if t.Kind != LiteralTerm {
+		return false, errors.Errorf("%q has no boolean value", t.Value)
+	}
+	switch {
+	case t.Datatype == XSDBoolean:
+		return t.Value == "true" || t.Value == "1", nil
+	case t.isNumeric():
+		f, err := strconv.ParseFloat(t.Value, 64)
+		return err == nil && f != 0, nil
+	default:
+		return t.Value != "", nil
+	}
+}
+
+func (b Binding) eval(expr Expr) (*Term, error) {
+	switch e := expr.(type) {
+	case *Term:
+		if e.Kind != VarTerm {
+			return e, nil
+		}
+		if t, ok := b[e.Value]; ok {
+			return t, nil
+		}
+		return nil, errors.Errorf("?%s is not bound", e.Value)
+	case *UnaryExpr:
+		t, err := b.eval(e.Expr)
+		if err != nil {
+			return nil, err
+		}
+		ok, err := boolValue(t)
+		if err != nil {
+			return nil, err
+		}
+		return boolTerm(!ok), nil
+	case *BinaryExpr:
+		return b.evalBinary(e)
+	case *CallExpr:
+		return b.evalCall(e)
+	default:
+		return nil, errors.Errorf("unexpected expression %T", expr)
+	}
+}
+
+func (b Binding) evalBinary(e *BinaryExpr) (*Term, error) {
+	switch e.Op {
+	case "||", "&&":
+		// An error on one side is ignored if the other side decides the result.
+		left, errL := b.evalBool(e.Left)
+		right, errR := b.evalBool(e.Right)
+		if e.Op == "||" {
+			if (errL == nil && left) || (errR == nil && right) {
+				return trueTerm, nil
+			}
+		} else if (errL == nil && !left) || (errR == nil && !right) {
+			return falseTerm, nil
+		}
+		if errL != nil {
+			return nil, errL
+		}
+		if errR != nil {
+			return nil, errR
+		}
+		return boolTerm(e.Op == "&&"), nil
+	}
+
+	left, err := b.eval(e.Left)
+	if err != nil {
+		return nil, err
+	}
+	right, err := b.eval(e.Right)
+	if err != nil {
+		return nil, err
+	}
+	switch e.Op {
+	case "=":
+		return boolTerm(left.Equal(right)), nil
+	case "!=":
+		return boolTerm(!left.Equal(right)), nil
+	}
+	cmp, err := compare(left, right)
+	if err != nil {
+		return nil, err
+	}
+	switch e.Op {
+	case "<":
+		return boolTerm(cmp < 0), nil
+	case "<=":
+		return boolTerm(cmp <= 0), nil
+	case ">":
+		return boolTerm(cmp > 0), nil
+	case ">=":
+		return boolTerm(cmp >= 0), nil
+	default:
+		return nil, errors.Errorf("unexpected operator %s", e.Op)
+	}
+}
+
+func (b Binding) evalBool(expr Expr) (bool, error) {
+	t, err := b.eval(expr)
+	if err != nil {
+		return false, err
+	}
+	return boolValue(t)
+}
+
+// compare orders two literals. The numbers are compared by value, the dates by time, and the
+// other literals as strings.
+func compare(a, b *Term) (int, error) {
+	if a.Kind != LiteralTerm || b.Kind != LiteralTerm {
+		return 0, errors.New("only literals can be ordered")
+	}
+	if a.isNumeric() && b.isNumeric() {
+		x, errA := strconv.ParseFloat(a.Value, 64)
+		y, errB := strconv.ParseFloat(b.Value, 64)
+		if errA != nil || errB != nil {
+			return 0, errors.New("invalid number")
+		}
+		switch {
+		case x < y:
+			return -1, nil
+		case x > y:
+			return 1, nil
+		}
+		return 0, nil
+	}
+	if a.Datatype == XSDDateTime && b.Datatype == XSDDateTime {
+		x, errA := time.Parse(time.RFC3339Nano, a.Value)
+		y, errB := time.Parse(time.RFC3339Nano, b.Value)
+		if errA != nil || errB != nil {
+			return 0, errors.New("invalid date")
+		}
+		switch {
+		case x.Before(y):
+			return -1, nil
+		case x.After(y):
+			return 1, nil
+		}
+		return 0, nil
+	}
+	if plainType(a.Datatype) != plainType(b.Datatype) {
+		return 0, errors.New("literals of different types can't be ordered")
+	}
+	return strings.Compare(a.Value, b.Value), nil
+}
+
+func (b Binding) evalCall(e *CallExpr) (*Term, error) {
+	if e.Func == "BOUND" {
+		_, ok := b[e.Args[0].(*Term).Value]
+		return boolTerm(ok), nil
+	}
+	args := make([]*Term, len(e.Args))
+	for i, arg := range e.Args {
+		t, err := b.eval(arg)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = t
+	}
+
+	switch e.Func {
+	case "STR":
+		return &Term{Kind: LiteralTerm, Value: args[0].Value}, nil
+	case "LANG":
+		if args[0].Kind != LiteralTerm {
+			return nil, errors.New("LANG needs a literal")
+		}
+		return &Term{Kind: LiteralTerm, Value: args[0].Lang}, nil
+	case "ISIRI", "ISURI":
+		return boolTerm(args[0].Kind == IRITerm), nil
+	case "ISLITERAL":
+		return boolTerm(args[0].Kind == LiteralTerm), nil
+	}
+
+	for _, arg := range args {
+		if arg.Kind != LiteralTerm {
+			return nil, errors.Errorf("%s needs literals", e.Func)
+		}
+	}
+	switch e.Func {
+	case "CONTAINS":
+		return boolTerm(strings.Contains(args[0].Value, args[1].Value)), nil
+	case "STRSTARTS":
+		return boolTerm(strings.HasPrefix(args[0].Value, args[1].Value)), nil
+	case "STRENDS":
+		return boolTerm(strings.HasSuffix(args[0].Value, args[1].Value)), nil
+	case "REGEX":
+		pattern := args[1].Value
+		if len(args) == 3 {
+			// Only the flags i, m and s are supported by Go regular expressions.
+			var flags strings.Builder
+			for _, f := range args[2].Value {
+				if !strings.ContainsRune("ims", f) {
+					return nil, errors.Errorf("unsupported REGEX flag %q", f)
+				}
+				flags.WriteRune(f)
+			}
+			if flags.Len() > 0 {
+				pattern = "(?" + flags.String() + ")" + pattern
+			}
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, err
+		}
+		return boolTerm(re.MatchString(args[0].Value)), nil
+	default:
+		return nil, errors.Errorf("unexpected function %s", e.Func)
+	}
+}
+
+// Results are the solutions of a query, which are marshalled in the SPARQL 1.1 query results
+// JSON format.
+type Results struct {
+	Vars     []string
+	Bindings []Binding
+}
+
+type jsonTerm struct {
+	Type     string `json:"type"`
+	Value    string `json:"value"`
+	Lang     string `json:"xml:lang,omitempty"`
+	Datatype string `json:"datatype,omitempty"`
+}
+
+// MarshalJSON marshals the results in the SPARQL 1.1 query results JSON format.
+func (r *Results) MarshalJSON() ([]byte, error) {
+	var out struct {
+		Head struct {
+			Vars []string `json:"vars"`
+		} `json:"head"`
+		Results struct {
+			Bindings []map[string]*jsonTerm `json:"bindings"`
+		} `json:"results"`
+	}
+	out.Head.Vars = r.Vars
+	if out.Head.Vars == nil {
+		out.Head.Vars = []string{}
+	}
+	out.Results.Bindings = make([]map[string]*jsonTerm, 0, len(r.Bindings))
+	for _, b := range r.Bindings {
+		m := make(map[string]*jsonTerm, len(b))
+		for v, t := range b {
+			jt := &jsonTerm{Type: "literal", Value: t.Value, Lang: t.Lang, Datatype: t.Datatype}
+			if t.Kind == IRITerm {
+				jt.Type = "uri"
+			}
+			m[v] = jt
+		}
+		out.Results.Bindings = append(out.Results.Bindings, m)
+	}
+	return json.Marshal(out)
+}
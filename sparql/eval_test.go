@@ -0,0 +1,90 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sparql
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilter(t *testing.T) {
+	b := Binding{
+		"s":    {Kind: IRITerm, Value: "0x1"},
+		"name": {Kind: LiteralTerm, Value: "Alice", Lang: "en"},
+		"age":  {Kind: LiteralTerm, Value: "30", Datatype: XSDInteger},
+		"born": {Kind: LiteralTerm, Value: "1991-01-01T00:00:00Z", Datatype: XSDDateTime},
+	}
+	tests := map[string]bool{
+		`?age = 30`:                                    true,
+		`?age = 30.0`:                                  true,
+		`?age != 30`:                                   false,
+		`?age > 18 && ?age <= 30`:                      true,
+		`?age < 18 || ?name = "Alice"`:                 false,
+		`?name = "Alice"@en`:                           true,
+		`lang(?name) = "en"`:                           true,
+		`str(?name) = "Alice"`:                         true,
+		`contains(?name, "lic")`:                       true,
+		`strstarts(?name, "Al")`:                       true,
+		`strends(?name, "Al")`:                         false,
+		`regex(?name, "^al", "i")`:                     true,
+		`regex(?name, "^al")`:                          false,
+		`isIRI(?s) && isLiteral(?name)`:                true,
+		`bound(?missing)`:                              false,
+		`!bound(?missing)`:                             true,
+		`?missing = 1`:                                 false,
+		`!(?missing = 1)`:                              false,
+		`?missing = 1 || ?age = 30`:                    true,
+		`?born < "2000-01-01T00:00:00Z"^^xsd:dateTime`: true,
+		`?name < 1`:                                    false,
+		`?s = <0x1>`:                                   true,
+	}
+	for filter, ok := range tests {
+		q, err := Parse(`SELECT * { ?s <p> ?o FILTER(` + filter + `) }`)
+		require.NoError(t, err, filter)
+		require.Equal(t, ok, b.Filter(q.Where.Filters[0]), filter)
+	}
+}
+
+func TestResultsJSON(t *testing.T) {
+	res := &Results{
+		Vars: []string{"s", "name", "age"},
+		Bindings: []Binding{
+			{
+				"s":    {Kind: IRITerm, Value: "0x1"},
+				"name": {Kind: LiteralTerm, Value: "Alice", Lang: "en"},
+				"age":  {Kind: LiteralTerm, Value: "30", Datatype: XSDInteger},
+			},
+			{"s": {Kind: IRITerm, Value: "0x2"}},
+		},
+	}
+	js, err := json.Marshal(res)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"head": {"vars": ["s", "name", "age"]},
+		"results": {"bindings": [
+			{
+				"s": {"type": "uri", "value": "0x1"},
+				"name": {"type": "literal", "value": "Alice", "xml:lang": "en"},
+				"age": {"type": "literal", "value": "30",
+					"datatype": "http://www.w3.org/2001/XMLSchema#integer"}
+			},
+			{"s": {"type": "uri", "value": "0x2"}}
+		]}
+	}`, string(js))
+}
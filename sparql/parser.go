@@ -0,0 +1,615 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package sparql parses SPARQL 1.1 SELECT queries, and evaluates their filters. Only a subset of
+// SPARQL is supported: basic graph patterns, along with FILTER, OPTIONAL, DISTINCT, LIMIT and
+// OFFSET. The patterns are matched against the data by the query package.
+package sparql
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+)
+
+type tokenType int
+
+const (
+	tokenEOF tokenType = iota
+	tokenIdent
+	tokenVar
+	tokenIRI
+	tokenPName
+	tokenString
+	tokenNumber
+	tokenLang
+	tokenPunct
+)
+
+type token struct {
+	typ tokenType
+	val string
+	pos int
+}
+
+// is returns true if the token is the given punctuation, or the given keyword.
+func (t token) is(val string) bool {
+	switch t.typ {
+	case tokenPunct:
+		return t.val == val
+	case tokenIdent:
+		return strings.EqualFold(t.val, val)
+	default:
+		return false
+	}
+}
+
+var puncts = map[string]bool{
+	"{": true, "}": true, "(": true, ")": true, ".": true, ";": true, ",": true, "*": true,
+	"=": true, "!=": true, "<": true, "<=": true, ">": true, ">=": true, "!": true, "&&": true,
+	"||": true, "^^": true, "-": true,
+}
+
+var iriRef = regexp.MustCompile(`^<[^<>"{}|^` + "`" + `\\\x00-\x20]*>`)
+
+func isNameChar(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '-' || r == '.'
+}
+
+func lex(query string) ([]token, error) {
+	var tokens []token
+	rs := []rune(query)
+	for i := 0; i < len(rs); {
+		r := rs[i]
+		start := i
+		switch {
+		case unicode.IsSpace(r):
+			i++
+			continue
+		case r == '#':
+			for i < len(rs) && rs[i] != '\n' {
+				i++
+			}
+			continue
+		case r == '<' && iriRef.MatchString(string(rs[i:])):
+			iri := iriRef.FindString(string(rs[i:]))
+			i += len([]rune(iri))
+			tokens = append(tokens, token{typ: tokenIRI, val: iri[1 : len(iri)-1], pos: start})
+		case r == '?' || r == '$':
+			i++
+			for i < len(rs) && (unicode.IsLetter(rs[i]) || unicode.IsDigit(rs[i]) || rs[i] == '_') {
+				i++
+			}
+			if i == start+1 {
+				return nil, errors.Errorf("expected a variable name at %d", start)
+			}
+			tokens = append(tokens, token{typ: tokenVar, val: string(rs[start+1 : i]), pos: start})
+		case r == '@':
+			i++
+			for i < len(rs) && (unicode.IsLetter(rs[i]) || unicode.IsDigit(rs[i]) || rs[i] == '-') {
+				i++
+			}
+			if i == start+1 {
+				return nil, errors.Errorf("expected a language tag at %d", start)
+			}
+			tokens = append(tokens, token{typ: tokenLang, val: string(rs[start+1 : i]), pos: start})
+		case unicode.IsLetter(r) || r == '_' || r == ':':
+			typ := tokenIdent
+			for i < len(rs) && (isNameChar(rs[i]) || rs[i] == ':') {
+				if rs[i] == ':' {
+					typ = tokenPName
+				}
+				i++
+			}
+			// A name doesn't end with a dot, which ends the triple instead.
+			for rs[i-1] == '.' {
+				i--
+			}
+			tokens = append(tokens, token{typ: typ, val: string(rs[start:i]), pos: start})
+		case unicode.IsDigit(r) || (r == '.' && i+1 < len(rs) && unicode.IsDigit(rs[i+1])):
+			for i < len(rs) && (unicode.IsDigit(rs[i]) || rs[i] == '.' || rs[i] == 'e' ||
+				rs[i] == 'E' || ((rs[i] == '+' || rs[i] == '-') && (rs[i-1] == 'e' ||
+				rs[i-1] == 'E'))) {
+				i++
+			}
+			// A number doesn't end with a dot either.
+			for rs[i-1] == '.' {
+				i--
+			}
+			tokens = append(tokens, token{typ: tokenNumber, val: string(rs[start:i]), pos: start})
+		case r == '\'' || r == '"':
+			var sb strings.Builder
+			for i++; i < len(rs) && rs[i] != r; i++ {
+				if rs[i] == '\\' && i+1 < len(rs) {
+					i++
+					switch rs[i] {
+					case 'n':
+						sb.WriteRune('\n')
+					case 't':
+						sb.WriteRune('\t')
+					case 'r':
+						sb.WriteRune('\r')
+					default:
+						sb.WriteRune(rs[i])
+					}
+					continue
+				}
+				sb.WriteRune(rs[i])
+			}
+			if i == len(rs) {
+				return nil, errors.Errorf("unterminated string at %d", start)
+			}
+			i++
+			tokens = append(tokens, token{typ: tokenString, val: sb.String(), pos: start})
+		default:
+			val := string(r)
+			if i+1 < len(rs) {
+				switch two := string(rs[i : i+2]); two {
+				case "!=", "<=", ">=", "&&", "||", "^^":
+					val = two
+				}
+			}
+			if !puncts[val] {
+				return nil, errors.Errorf("unexpected character %q at %d", r, start)
+			}
+			i += len([]rune(val))
+			tokens = append(tokens, token{typ: tokenPunct, val: val, pos: start})
+		}
+	}
+	return append(tokens, token{typ: tokenEOF, pos: len(rs)}), nil
+}
+
+// Query is a parsed SPARQL SELECT query.
+type Query struct {
+	// Vars are the projected variables, or nil for SELECT *.
+	Vars     []string
+	Distinct bool
+	Where    *Group
+	Limit    int
+	Offset   int
+}
+
+// Group is a group graph pattern. Its triples are matched first, then its optional groups, and
+// then its filters are applied to the solutions.
+type Group struct {
+	Triples   []*Triple
+	Optionals []*Group
+	Filters   []Expr
+}
+
+// Triple is a triple pattern.
+type Triple struct {
+	Subject, Predicate, Object *Term
+}
+
+// Expr is an expression of a FILTER. It's either a *Term, a *BinaryExpr, a *UnaryExpr or a
+// *CallExpr.
+type Expr interface{}
+
+// BinaryExpr is a binary expression. Op is one of ||, &&, =, !=, <, <=, > and >=.
+type BinaryExpr struct {
+	Op          string
+	Left, Right Expr
+}
+
+// UnaryExpr is the negation of an expression.
+type UnaryExpr struct {
+	Op   string
+	Expr Expr
+}
+
+// CallExpr is a call of a builtin function. Func is the upper-cased name of the function.
+type CallExpr struct {
+	Func string
+	Args []Expr
+}
+
+// functions maps the supported builtin functions to their number of arguments. Those with -1
+// arguments take two or three arguments.
+var functions = map[string]int{
+	"BOUND": 1, "STR": 1, "LANG": 1, "ISIRI": 1, "ISURI": 1, "ISLITERAL": 1,
+	"CONTAINS": 2, "STRSTARTS": 2, "STRENDS": 2, "REGEX": -1,
+}
+
+// RDFType is the IRI of rdf:type, which is matched against dgraph.type.
+const RDFType = "http://www.w3.org/1999/02/22-rdf-syntax-ns#type"
+
+type parser struct {
+	tokens   []token
+	pos      int
+	prefixes map[string]string
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.typ != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) accept(val string) bool {
+	if p.peek().is(val) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(val string) error {
+	if t := p.next(); !t.is(val) {
+		return errors.Errorf("expected %s at %d, got %q", val, t.pos, t.val)
+	}
+	return nil
+}
+
+// Parse parses a SPARQL SELECT query.
+func Parse(query string) (*Query, error) {
+	tokens, err := lex(query)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens, prefixes: map[string]string{
+		"rdf": "http://www.w3.org/1999/02/22-rdf-syntax-ns#",
+		"xsd": "http://www.w3.org/2001/XMLSchema#",
+	}}
+	for p.accept("PREFIX") {
+		t := p.next()
+		if t.typ != tokenPName || !strings.HasSuffix(t.val, ":") {
+			return nil, errors.Errorf("expected a prefix at %d, got %q", t.pos, t.val)
+		}
+		iri := p.next()
+		if iri.typ != tokenIRI {
+			return nil, errors.Errorf("expected an IRI at %d, got %q", iri.pos, iri.val)
+		}
+		p.prefixes[strings.TrimSuffix(t.val, ":")] = iri.val
+	}
+
+	q := &Query{}
+	if t := p.peek(); !t.is("SELECT") {
+		if t.is("CONSTRUCT") || t.is("ASK") || t.is("DESCRIBE") || t.is("INSERT") ||
+			t.is("DELETE") {
+			return nil, errors.Errorf("%s isn't supported, only SELECT queries are", t.val)
+		}
+		return nil, errors.Errorf("expected SELECT at %d, got %q", t.pos, t.val)
+	}
+	p.next()
+	q.Distinct = p.accept("DISTINCT") || p.accept("REDUCED")
+	if !p.accept("*") {
+		for p.peek().typ == tokenVar {
+			q.Vars = append(q.Vars, p.next().val)
+		}
+		if len(q.Vars) == 0 {
+			t := p.peek()
+			return nil, errors.Errorf("expected variables or * at %d, got %q", t.pos, t.val)
+		}
+	}
+	p.accept("WHERE")
+	if q.Where, err = p.parseGroup(); err != nil {
+		return nil, err
+	}
+
+	for {
+		switch t := p.peek(); {
+		case t.is("LIMIT"):
+			p.next()
+			if q.Limit, err = p.parseInt(); err != nil {
+				return nil, err
+			}
+		case t.is("OFFSET"):
+			p.next()
+			if q.Offset, err = p.parseInt(); err != nil {
+				return nil, err
+			}
+		case t.typ == tokenEOF:
+			return q, nil
+		case t.is("ORDER") || t.is("GROUP") || t.is("HAVING") || t.is("VALUES"):
+			return nil, errors.Errorf("%s isn't supported", strings.ToUpper(t.val))
+		default:
+			return nil, errors.Errorf("unexpected %q at %d", t.val, t.pos)
+		}
+	}
+}
+
+func (p *parser) parseInt() (int, error) {
+	t := p.next()
+	if t.typ != tokenNumber {
+		return 0, errors.Errorf("expected a number at %d, got %q", t.pos, t.val)
+	}
+	var n int
+	for _, r := range t.val {
+		if r < '0' || r > '9' {
+			return 0, errors.Errorf("expected an integer at %d, got %q", t.pos, t.val)
+		}
+		n = n*10 + int(r-'0')
+	}
+	return n, nil
+}
+
+func (p *parser) parseGroup() (*Group, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	g := &Group{}
+	for !p.accept("}") {
+		switch t := p.peek(); {
+		case t.is("."):
+			p.next()
+		case t.is("FILTER"):
+			p.next()
+			f, err := p.parseConstraint()
+			if err != nil {
+				return nil, err
+			}
+			g.Filters = append(g.Filters, f)
+		case t.is("OPTIONAL"):
+			p.next()
+			opt, err := p.parseGroup()
+			if err != nil {
+				return nil, err
+			}
+			g.Optionals = append(g.Optionals, opt)
+		case t.is("{") || t.is("UNION") || t.is("MINUS") || t.is("BIND") || t.is("GRAPH") ||
+			t.is("SERVICE") || t.is("VALUES"):
+			return nil, errors.Errorf("%s isn't supported, at %d", t.val, t.pos)
+		case t.typ == tokenEOF:
+			return nil, errors.New("expected } at the end of the query")
+		default:
+			if len(g.Optionals) > 0 {
+				return nil, errors.Errorf("triples after OPTIONAL aren't supported, at %d", t.pos)
+			}
+			triples, err := p.parseTriples()
+			if err != nil {
+				return nil, err
+			}
+			g.Triples = append(g.Triples, triples...)
+		}
+	}
+	return g, nil
+}
+
+// parseTriples parses the triples sharing a subject, with their predicates separated by ; and
+// their objects separated by ,.
+func (p *parser) parseTriples() ([]*Triple, error) {
+	subject, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+	if subject.Kind == LiteralTerm {
+		return nil, errors.Errorf("literal subjects aren't supported, got %q", subject.Value)
+	}
+	var triples []*Triple
+	for {
+		var pred *Term
+		if p.accept("a") {
+			pred = &Term{Kind: IRITerm, Value: RDFType}
+		} else if pred, err = p.parseTerm(); err != nil {
+			return nil, err
+		}
+		if pred.Kind != IRITerm {
+			return nil, errors.Errorf("predicates must be IRIs, got %q", pred.Value)
+		}
+		for {
+			object, err := p.parseTerm()
+			if err != nil {
+				return nil, err
+			}
+			triples = append(triples, &Triple{Subject: subject, Predicate: pred, Object: object})
+			if !p.accept(",") {
+				break
+			}
+		}
+		if !p.accept(";") {
+			return triples, nil
+		}
+		// A ; may end the triples.
+		if t := p.peek(); t.is(".") || t.is("}") {
+			return triples, nil
+		}
+	}
+}
+
+// parseTerm parses a variable, an IRI or a literal.
+func (p *parser) parseTerm() (*Term, error) {
+	t := p.next()
+	switch {
+	case t.typ == tokenVar:
+		return &Term{Kind: VarTerm, Value: t.val}, nil
+	case t.typ == tokenIRI:
+		return &Term{Kind: IRITerm, Value: t.val}, nil
+	case t.typ == tokenPName && strings.HasPrefix(t.val, "_:"):
+		// Blank nodes are variables which aren't projected.
+		return &Term{Kind: VarTerm, Value: t.val}, nil
+	case t.typ == tokenPName:
+		return p.expandPName(t)
+	case t.typ == tokenString:
+		lit := &Term{Kind: LiteralTerm, Value: t.val}
+		if p.peek().typ == tokenLang {
+			lit.Lang = p.next().val
+		} else if p.accept("^^") {
+			dt, err := p.parseTerm()
+			if err != nil {
+				return nil, err
+			}
+			if dt.Kind != IRITerm {
+				return nil, errors.Errorf("expected a datatype IRI at %d", t.pos)
+			}
+			lit.Datatype = dt.Value
+		}
+		return lit, nil
+	case t.typ == tokenNumber:
+		return numberTerm(t.val), nil
+	case t.is("-"):
+		n := p.next()
+		if n.typ != tokenNumber {
+			return nil, errors.Errorf("expected a number at %d", t.pos)
+		}
+		return numberTerm("-" + n.val), nil
+	case t.is("true") || t.is("false"):
+		return &Term{Kind: LiteralTerm, Value: strings.ToLower(t.val), Datatype: XSDBoolean}, nil
+	default:
+		return nil, errors.Errorf("expected a variable, an IRI or a literal at %d, got %q",
+			t.pos, t.val)
+	}
+}
+
+func (p *parser) expandPName(t token) (*Term, error) {
+	idx := strings.Index(t.val, ":")
+	prefix, ok := p.prefixes[t.val[:idx]]
+	if !ok {
+		return nil, errors.Errorf("prefix %q is not declared, at %d", t.val[:idx], t.pos)
+	}
+	return &Term{Kind: IRITerm, Value: prefix + t.val[idx+1:]}, nil
+}
+
+func numberTerm(val string) *Term {
+	dt := XSDInteger
+	switch {
+	case strings.ContainsAny(val, "eE"):
+		dt = XSDDouble
+	case strings.Contains(val, "."):
+		dt = XSDDecimal
+	}
+	return &Term{Kind: LiteralTerm, Value: val, Datatype: dt}
+}
+
+// parseConstraint parses the constraint of a FILTER, which is either a bracketted expression or
+// a function call.
+func (p *parser) parseConstraint() (Expr, error) {
+	if p.peek().is("(") {
+		return p.parsePrimary()
+	}
+	if t := p.peek(); t.typ != tokenIdent {
+		return nil, errors.Errorf("expected a constraint at %d, got %q", t.pos, t.val)
+	}
+	return p.parseCall()
+}
+
+func (p *parser) parseOr() (Expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("||") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &BinaryExpr{Op: "||", Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Expr, error) {
+	left, err := p.parseRelational()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("&&") {
+		right, err := p.parseRelational()
+		if err != nil {
+			return nil, err
+		}
+		left = &BinaryExpr{Op: "&&", Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseRelational() (Expr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for _, op := range []string{"=", "!=", "<", "<=", ">", ">="} {
+		if p.accept(op) {
+			right, err := p.parseUnary()
+			if err != nil {
+				return nil, err
+			}
+			return &BinaryExpr{Op: op, Left: left, Right: right}, nil
+		}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (Expr, error) {
+	if p.accept("!") {
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &UnaryExpr{Op: "!", Expr: expr}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (Expr, error) {
+	t := p.peek()
+	switch {
+	case t.is("("):
+		p.next()
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		return expr, p.expect(")")
+	case t.typ == tokenIdent && !t.is("true") && !t.is("false"):
+		return p.parseCall()
+	default:
+		return p.parseTerm()
+	}
+}
+
+func (p *parser) parseCall() (Expr, error) {
+	t := p.next()
+	name := strings.ToUpper(t.val)
+	n, ok := functions[name]
+	if !ok {
+		return nil, errors.Errorf("function %s isn't supported, at %d", t.val, t.pos)
+	}
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	call := &CallExpr{Func: name}
+	for !p.accept(")") {
+		if len(call.Args) > 0 {
+			if err := p.expect(","); err != nil {
+				return nil, err
+			}
+		}
+		arg, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		call.Args = append(call.Args, arg)
+	}
+	if (n >= 0 && len(call.Args) != n) || (n < 0 && len(call.Args) != 2 && len(call.Args) != 3) {
+		return nil, errors.Errorf("wrong number of arguments for %s, at %d", name, t.pos)
+	}
+	if name == "BOUND" {
+		if v, ok := call.Args[0].(*Term); !ok || v.Kind != VarTerm {
+			return nil, errors.Errorf("BOUND needs a variable, at %d", t.pos)
+		}
+	}
+	return call, nil
+}
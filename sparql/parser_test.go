@@ -0,0 +1,111 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sparql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	q, err := Parse(`
+		PREFIX foaf: <http://xmlns.com/foaf/0.1/>
+		# Friends of Alice, with their age if they have one.
+		SELECT DISTINCT ?friend ?age WHERE {
+			?p a <Person> ;
+				foaf:name "Alice"@en ;
+				foaf:knows ?friend, <0x2> .
+			OPTIONAL { ?friend <age> ?age . FILTER(?age >= 18.5) }
+			FILTER (!bound(?age) || regex(?age, "^1", "i"))
+		}
+		OFFSET 5 LIMIT 10`)
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"friend", "age"}, q.Vars)
+	require.True(t, q.Distinct)
+	require.Equal(t, 5, q.Offset)
+	require.Equal(t, 10, q.Limit)
+
+	p := &Term{Kind: VarTerm, Value: "p"}
+	friend := &Term{Kind: VarTerm, Value: "friend"}
+	knows := &Term{Kind: IRITerm, Value: "http://xmlns.com/foaf/0.1/knows"}
+	require.Equal(t, []*Triple{
+		{Subject: p, Predicate: &Term{Kind: IRITerm, Value: RDFType},
+			Object: &Term{Kind: IRITerm, Value: "Person"}},
+		{Subject: p, Predicate: &Term{Kind: IRITerm, Value: "http://xmlns.com/foaf/0.1/name"},
+			Object: &Term{Kind: LiteralTerm, Value: "Alice", Lang: "en"}},
+		{Subject: p, Predicate: knows, Object: friend},
+		{Subject: p, Predicate: knows, Object: &Term{Kind: IRITerm, Value: "0x2"}},
+	}, q.Where.Triples)
+
+	age := &Term{Kind: VarTerm, Value: "age"}
+	require.Equal(t, []*Group{{
+		Triples: []*Triple{{Subject: friend, Predicate: &Term{Kind: IRITerm, Value: "age"},
+			Object: age}},
+		Filters: []Expr{&BinaryExpr{Op: ">=", Left: age,
+			Right: &Term{Kind: LiteralTerm, Value: "18.5", Datatype: XSDDecimal}}},
+	}}, q.Where.Optionals)
+	require.Equal(t, []Expr{&BinaryExpr{
+		Op:   "||",
+		Left: &UnaryExpr{Op: "!", Expr: &CallExpr{Func: "BOUND", Args: []Expr{age}}},
+		Right: &CallExpr{Func: "REGEX", Args: []Expr{age,
+			&Term{Kind: LiteralTerm, Value: "^1"}, &Term{Kind: LiteralTerm, Value: "i"}}},
+	}}, q.Where.Filters)
+}
+
+func TestParseLiterals(t *testing.T) {
+	q, err := Parse(`SELECT * { ?s <p> 1, -2.5, 3e2, true, "x"^^xsd:string, _:b . _:b <q> ?o }`)
+	require.NoError(t, err)
+	require.Nil(t, q.Vars)
+	var objects []*Term
+	for _, t := range q.Where.Triples {
+		objects = append(objects, t.Object)
+	}
+	require.Equal(t, []*Term{
+		{Kind: LiteralTerm, Value: "1", Datatype: XSDInteger},
+		{Kind: LiteralTerm, Value: "-2.5", Datatype: XSDDecimal},
+		{Kind: LiteralTerm, Value: "3e2", Datatype: XSDDouble},
+		{Kind: LiteralTerm, Value: "true", Datatype: XSDBoolean},
+		{Kind: LiteralTerm, Value: "x", Datatype: XSDString},
+		{Kind: VarTerm, Value: "_:b"},
+		{Kind: VarTerm, Value: "o"},
+	}, objects)
+	require.Equal(t, &Term{Kind: VarTerm, Value: "_:b"}, q.Where.Triples[6].Subject)
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []string{
+		`CONSTRUCT { ?s <p> ?o } WHERE { ?s <p> ?o }`,
+		`ASK { ?s <p> ?o }`,
+		`SELECT ?s { ?s ?p ?o }`,
+		`SELECT ?s { ?s <p> ?o } ORDER BY ?o`,
+		`SELECT ?s { { ?s <p> ?o } UNION { ?s <q> ?o } }`,
+		`SELECT ?s { ?s foaf:name ?o }`,
+		`SELECT ?s { ?s <p> ?o `,
+		`SELECT { ?s <p> ?o }`,
+		`SELECT ?s { "s" <p> ?o }`,
+		`SELECT ?s { ?s <p> ?o FILTER(strlen(?o) > 1) }`,
+		`SELECT ?s { ?s <p> ?o FILTER(bound("x")) }`,
+		`SELECT ?s { ?s <p> ?o } LIMIT x`,
+		`SELECT ?s { ?s <p> "unterminated }`,
+	}
+	for _, query := range tests {
+		_, err := Parse(query)
+		require.Error(t, err, query)
+	}
+}
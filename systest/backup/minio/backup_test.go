@@ -123,7 +123,9 @@ func TestBackupMinio(t *testing.T) {
 	// Check the predicates and types in the schema are as expected.
 	// TODO: refactor tests so that minio and filesystem tests share most of their logic.
 	preds := []string{"dgraph.graphql.schema", "dgraph.graphql.xid", "dgraph.type", "movie",
-		"dgraph.graphql.p_query", "dgraph.drop.op"}
+		"dgraph.graphql.p_query", "dgraph.drop.op", "dgraph.runtime_config",
+		"dgraph.colocation", "dgraph.external_id", "dgraph.idempotency.key",
+		"dgraph.idempotency.record", "dgraph.idempotency.expiry"}
 	types := []string{"Node", "dgraph.graphql", "dgraph.graphql.persisted_query"}
 	testutil.CheckSchema(t, preds, types)
 
@@ -84,6 +84,12 @@ var expectedSchema = `[0x0] <movie>:string .` + " " + `
 [0x0] <dgraph.graphql.xid>:string @index(exact) @upsert .` + " " + `
 [0x0] <dgraph.graphql.schema>:string .` + " " + `
 [0x0] <dgraph.graphql.p_query>:string @index(sha256) .` + " " + `
+[0x0] <dgraph.runtime_config>:string .` + " " + `
+[0x0] <dgraph.colocation>:string .` + " " + `
+[0x0] <dgraph.external_id>:string @index(exact) @upsert .` + " " + `
+[0x0] <dgraph.idempotency.key>:string @index(exact) @upsert .` + " " + `
+[0x0] <dgraph.idempotency.record>:string .` + " " + `
+[0x0] <dgraph.idempotency.expiry>:datetime @index(hour) .` + " " + `
 [0x0] type <Node> {
 	movie
 }
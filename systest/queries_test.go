@@ -395,15 +395,51 @@ func SchemaQueryTestPredicate1(t *testing.T, c *dgo.Dgraph) {
 	  {
 		  "predicate": "dgraph.rule.permission"
 	  },
+	  {
+		  "predicate": "dgraph.session.id"
+	  },
+	  {
+		  "predicate": "dgraph.session.user"
+	  },
+	  {
+		  "predicate": "dgraph.session.expiry"
+	  },
+	  {
+		  "predicate": "dgraph.session.revoked"
+	  },
 	  {
         "predicate": "dgraph.graphql.schema"
 	  },
 	  {
+        "predicate": "dgraph.runtime_config"
+	  },
+	  {
+        "predicate": "dgraph.colocation"
+	  },
+	  {
+        "predicate": "dgraph.external_id"
+	  },
+	  {
+        "predicate": "dgraph.idempotency.key"
+	  },
+	  {
+        "predicate": "dgraph.idempotency.record"
+	  },
+	  {
+        "predicate": "dgraph.idempotency.expiry"
+	  },
+	  {
         "predicate": "dgraph.graphql.xid"
 	  },
       {
         "predicate": "dgraph.user.group"
       },
+      {
+        "predicate": "dgraph.user.password_updated"
+      },
+      {
+        "predicate": "dgraph.user.password_scheme"
+      },
       {
         "predicate": "friends"
       },
@@ -30,20 +30,33 @@ const (
 {"predicate":"dgraph.xid","type":"string", "index":true, "tokenizer":["exact"], "upsert":true},
 {"predicate":"dgraph.password","type":"password"},
 {"predicate":"dgraph.user.group","list":true, "reverse":true, "type":"uid"},
+{"predicate":"dgraph.user.password_updated","type":"datetime"},
+{"predicate":"dgraph.user.password_scheme","type":"string"},
 {"predicate":"dgraph.acl.rule","type":"uid","list":true},
 {"predicate":"dgraph.rule.predicate","type":"string","index":true,"tokenizer":["exact"],"upsert":true},
-{"predicate":"dgraph.rule.permission","type":"int"}
+{"predicate":"dgraph.rule.permission","type":"int"},
+{"predicate":"dgraph.session.id","type":"string","index":true,"tokenizer":["exact"]},
+{"predicate":"dgraph.session.user","type":"string","index":true,"tokenizer":["exact"]},
+{"predicate":"dgraph.session.expiry","type":"datetime"},
+{"predicate":"dgraph.session.revoked","type":"bool"}
 `
 	otherInternalPreds = `
 {"predicate":"dgraph.type","type":"string","index":true,"tokenizer":["exact"],"list":true},
 {"predicate":"dgraph.drop.op", "type": "string"},
 {"predicate":"dgraph.graphql.p_query","type":"string","index":true,"tokenizer":["sha256"]},
 {"predicate":"dgraph.graphql.schema", "type": "string"},
+{"predicate":"dgraph.runtime_config", "type": "string"},
+{"predicate":"dgraph.colocation", "type": "string"},
+{"predicate":"dgraph.external_id","type":"string","index":true,"tokenizer":["exact"],"upsert":true},
+{"predicate":"dgraph.idempotency.key","type":"string","index":true,"tokenizer":["exact"],"upsert":true},
+{"predicate":"dgraph.idempotency.record","type":"string"},
+{"predicate":"dgraph.idempotency.expiry","type":"datetime","index":true,"tokenizer":["hour"]},
 {"predicate":"dgraph.graphql.xid","type":"string","index":true,"tokenizer":["exact"],"upsert":true}
 `
 	aclTypes = `
 {
-	"fields": [{"name": "dgraph.password"},{"name": "dgraph.xid"},{"name": "dgraph.user.group"}],
+	"fields": [{"name": "dgraph.password"},{"name": "dgraph.xid"},{"name": "dgraph.user.group"},
+		{"name": "dgraph.user.password_scheme"},{"name": "dgraph.user.password_updated"}],
 	"name": "dgraph.type.User"
 },{
 	"fields": [{"name": "dgraph.acl.rule"},{"name": "dgraph.xid"}],
@@ -51,6 +64,10 @@ const (
 },{
 	"fields": [{"name": "dgraph.rule.predicate"},{"name": "dgraph.rule.permission"}],
 	"name": "dgraph.type.Rule"
+},{
+	"fields": [{"name": "dgraph.session.expiry"},{"name": "dgraph.session.id"},
+		{"name": "dgraph.session.revoked"},{"name": "dgraph.session.user"}],
+	"name": "dgraph.type.Session"
 }
 `
 	otherInternalTypes = `
@@ -87,10 +104,11 @@ func GetInternalTypes(excludeAclTypes bool) string {
 // GetFullSchemaJSON returns a string representation of the JSON object returned by the full
 // schema{} query. It uses the user provided predicates and types along with the initial internal
 // schema to generate the string. Example response looks like:
-// 	{
-// 		"schema": [ ... ],
-// 		"types": [ ... ]
-// 	}
+//
+//	{
+//		"schema": [ ... ],
+//		"types": [ ... ]
+//	}
 func GetFullSchemaJSON(opts SchemaOptions) string {
 	expectedPreds := GetInternalPreds(opts.ExcludeAclSchema)
 	if len(opts.UserPreds) > 0 {
@@ -112,12 +130,13 @@ func GetFullSchemaJSON(opts SchemaOptions) string {
 // GetFullSchemaHTTPResponse returns a string representation of the HTTP response returned by the
 // full schema{} query. It uses the user provided predicates and types along with the initial
 // internal schema to generate the string. Example response looks like:
-// 	{
-// 		"data": {
-// 			"schema": [ ... ],
-// 			"types": [ ... ]
-// 		}
-// 	}
+//
+//	{
+//		"data": {
+//			"schema": [ ... ],
+//			"types": [ ... ]
+//		}
+//	}
 func GetFullSchemaHTTPResponse(opts SchemaOptions) string {
 	return `{"data":` + GetFullSchemaJSON(opts) + `}`
 }
@@ -18,6 +18,7 @@ package tok
 
 import (
 	"encoding/binary"
+	"math/big"
 	"plugin"
 	"strings"
 	"time"
@@ -53,6 +54,11 @@ const (
 	IdentTrigram   = 0xA
 	IdentHash      = 0xB
 	IdentSha       = 0xC
+	IdentBigInt    = 0xD
+	IdentDecimal   = 0xE
+	IdentDuration  = 0xF
+	IdentPresence  = 0x7e // Presence lists of the predicates with @presence.
+	IdentFacet     = 0x7f // Facet indexes of uid predicates, see types/facets.
 	IdentCustom    = 0x80
 	IdentDelimiter = 0x1f // ASCII 31 - Unit seperator
 )
@@ -90,6 +96,9 @@ func init() {
 	registerTokenizer(GeoTokenizer{})
 	registerTokenizer(IntTokenizer{})
 	registerTokenizer(FloatTokenizer{})
+	registerTokenizer(BigIntTokenizer{})
+	registerTokenizer(DecimalTokenizer{})
+	registerTokenizer(DurationTokenizer{})
 	registerTokenizer(YearTokenizer{})
 	registerTokenizer(HourTokenizer{})
 	registerTokenizer(MonthTokenizer{})
@@ -211,6 +220,42 @@ func (t FloatTokenizer) Identifier() byte { return IdentFloat }
 func (t FloatTokenizer) IsSortable() bool { return true }
 func (t FloatTokenizer) IsLossy() bool    { return true }
 
+// BigIntTokenizer generates tokens from arbitrary precision integer data.
+type BigIntTokenizer struct{}
+
+func (t BigIntTokenizer) Name() string { return "bigint" }
+func (t BigIntTokenizer) Type() string { return "bigint" }
+func (t BigIntTokenizer) Tokens(v interface{}) ([]string, error) {
+	return []string{encodeDecimal(v.(*big.Int).String())}, nil
+}
+func (t BigIntTokenizer) Identifier() byte { return IdentBigInt }
+func (t BigIntTokenizer) IsSortable() bool { return true }
+func (t BigIntTokenizer) IsLossy() bool    { return false }
+
+// DecimalTokenizer generates tokens from arbitrary precision decimal data.
+type DecimalTokenizer struct{}
+
+func (t DecimalTokenizer) Name() string { return "decimal" }
+func (t DecimalTokenizer) Type() string { return "decimal" }
+func (t DecimalTokenizer) Tokens(v interface{}) ([]string, error) {
+	return []string{encodeDecimal(types.FormatDecimal(v.(*big.Rat)))}, nil
+}
+func (t DecimalTokenizer) Identifier() byte { return IdentDecimal }
+func (t DecimalTokenizer) IsSortable() bool { return true }
+func (t DecimalTokenizer) IsLossy() bool    { return false }
+
+// DurationTokenizer generates tokens from duration data.
+type DurationTokenizer struct{}
+
+func (t DurationTokenizer) Name() string { return "duration" }
+func (t DurationTokenizer) Type() string { return "duration" }
+func (t DurationTokenizer) Tokens(v interface{}) ([]string, error) {
+	return []string{encodeInt(int64(v.(time.Duration)))}, nil
+}
+func (t DurationTokenizer) Identifier() byte { return IdentDuration }
+func (t DurationTokenizer) IsSortable() bool { return true }
+func (t DurationTokenizer) IsLossy() bool    { return false }
+
 // YearTokenizer generates year tokens from datetime data.
 type YearTokenizer struct{}
 
@@ -495,6 +540,44 @@ func encodeInt(val int64) string {
 	return string(buf)
 }
 
+// encodeDecimal encodes the canonical decimal text of a number so that the tokens are in the order
+// of the numbers. The number is written as 0.d1d2...dn x 10^exp with d1 and dn not zero, and
+// encoded as its sign, the exponent and the digits. The exponent and the digits of negative numbers
+// are inverted, and the digits followed by 0xff so that a longer number is smaller.
+func encodeDecimal(val string) string {
+	sign := byte(2)
+	if strings.HasPrefix(val, "-") {
+		sign = 0
+		val = val[1:]
+	}
+	intPart, frac := val, ""
+	if i := strings.IndexByte(val, '.'); i >= 0 {
+		intPart, frac = val[:i], val[i+1:]
+	}
+	digits := intPart + frac
+	exp := len(intPart)
+	for len(digits) > 0 && digits[0] == '0' {
+		digits = digits[1:]
+		exp--
+	}
+	digits = strings.TrimRight(digits, "0")
+	if digits == "" {
+		return string([]byte{1})
+	}
+
+	buf := make([]byte, 5, 6+len(digits))
+	buf[0] = sign
+	binary.BigEndian.PutUint32(buf[1:], uint32(int32(exp))^(1<<31))
+	buf = append(buf, digits...)
+	if sign == 0 {
+		for i := 1; i < len(buf); i++ {
+			buf[i] = ^buf[i]
+		}
+		buf = append(buf, 0xff)
+	}
+	return string(buf)
+}
+
 func encodeToken(tok string, typ byte) string {
 	return string(typ) + tok
 }
@@ -23,6 +23,8 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/types"
 )
 
 type encL struct {
@@ -61,6 +63,53 @@ func TestIntEncoding(t *testing.T) {
 	}
 }
 
+func TestDurationEncoding(t *testing.T) {
+	// Sorted by value.
+	vals := []time.Duration{math.MinInt64, -time.Hour, -1, 0, time.Nanosecond, time.Second,
+		time.Hour, math.MaxInt64}
+	var tokens []string
+	for _, val := range vals {
+		toks, err := BuildTokens(val, DurationTokenizer{})
+		require.NoError(t, err)
+		require.Len(t, toks, 1)
+		tokens = append(tokens, toks[0])
+	}
+	for i := 1; i < len(tokens); i++ {
+		require.True(t, tokens[i-1] < tokens[i], "%v vs %v", vals[i-1], vals[i])
+	}
+}
+
+func TestDecimalEncoding(t *testing.T) {
+	// Sorted by value.
+	vals := []string{"-1e40", "-123456789012345678901234567890", "-100", "-12.5", "-12.25",
+		"-12", "-0.5", "-0.05", "0", "0.001", "0.01", "0.0101", "1", "1.5", "9.99", "10", "100",
+		"101", "123456789012345678901234567890", "1e40"}
+	var tokens []string
+	for _, val := range vals {
+		r, err := types.ParseDecimal(val)
+		require.NoError(t, err)
+		toks, err := BuildTokens(r, DecimalTokenizer{})
+		require.NoError(t, err)
+		require.Len(t, toks, 1)
+		tokens = append(tokens, toks[0])
+	}
+	for i := 1; i < len(tokens); i++ {
+		require.True(t, tokens[i-1] < tokens[i], "%s vs %s", vals[i-1], vals[i])
+	}
+
+	// Equal values have the same token, whatever their type.
+	r, err := types.ParseDecimal("100.00")
+	require.NoError(t, err)
+	dec, err := BuildTokens(r, DecimalTokenizer{})
+	require.NoError(t, err)
+	i, err := types.ParseBigInt("100")
+	require.NoError(t, err)
+	bi, err := BuildTokens(i, BigIntTokenizer{})
+	require.NoError(t, err)
+	require.Equal(t, tokens[16][1:], dec[0][1:])
+	require.Equal(t, dec[0][1:], bi[0][1:])
+}
+
 func TestFullTextTokenizer(t *testing.T) {
 	tokenizer, has := GetTokenizer("fulltext")
 	require.True(t, has)
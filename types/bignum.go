@@ -0,0 +1,122 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+import (
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// The values of the bigint type are *big.Int, and the values of the decimal type are *big.Rat
+// having a finite decimal expansion. Both are stored as their canonical decimal text, and are
+// encoded as strings in JSON so that no precision is lost by the clients.
+
+const (
+	// maxDecimalExp bounds the exponent of the decimals parsed, so that a short value like
+	// 1e999999999 can't take up the memory of the server.
+	maxDecimalExp = 1024
+	// DecimalDivScale is the number of digits after the point kept by the divisions of decimals,
+	// whose result may not have a finite decimal expansion.
+	DecimalDivScale = 18
+)
+
+var decimalRe = regexp.MustCompile(`^[+-]?(\d+\.?\d*|\.\d+)([eE][+-]?\d+)?$`)
+
+// ParseBigInt parses a base 10 integer of arbitrary precision.
+func ParseBigInt(s string) (*big.Int, error) {
+	i, ok := new(big.Int).SetString(strings.TrimSpace(s), 10)
+	if !ok {
+		return nil, errors.Errorf("Invalid bigint %q", s)
+	}
+	return i, nil
+}
+
+// ParseDecimal parses a decimal number of arbitrary precision, optionally followed by an
+// exponent, e.g. -12.50 or 1.25e-3.
+func ParseDecimal(s string) (*big.Rat, error) {
+	s = strings.TrimSpace(s)
+	m := decimalRe.FindStringSubmatch(s)
+	if m == nil {
+		return nil, errors.Errorf("Invalid decimal %q", s)
+	}
+	if len(m[2]) > 0 {
+		exp, err := strconv.Atoi(m[2][1:])
+		if err != nil || exp > maxDecimalExp || exp < -maxDecimalExp {
+			return nil, errors.Errorf("Exponent of decimal %q out of range", s)
+		}
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, errors.Errorf("Invalid decimal %q", s)
+	}
+	return r, nil
+}
+
+// decimalScale returns the number of digits after the point of the decimal expansion of r, and
+// false if the expansion isn't finite.
+func decimalScale(r *big.Rat) (int, bool) {
+	d := new(big.Int).Set(r.Denom())
+	twos := int(d.TrailingZeroBits())
+	d.Rsh(d, uint(twos))
+	var fives int
+	five, mod := big.NewInt(5), new(big.Int)
+	for d.Cmp(big.NewInt(1)) != 0 {
+		d.QuoRem(d, five, mod)
+		if mod.Sign() != 0 {
+			return 0, false
+		}
+		fives++
+	}
+	if twos > fives {
+		return twos, true
+	}
+	return fives, true
+}
+
+// FormatDecimal returns the canonical decimal text of r, with no trailing zeros after the point.
+// The values without a finite decimal expansion are rounded to DecimalDivScale digits.
+func FormatDecimal(r *big.Rat) string {
+	scale, ok := decimalScale(r)
+	if !ok {
+		return strings.TrimRight(strings.TrimRight(r.FloatString(DecimalDivScale), "0"), ".")
+	}
+	return r.FloatString(scale)
+}
+
+// RoundDecimal returns r rounded to the given number of digits after the point, the halves
+// being rounded away from zero.
+func RoundDecimal(r *big.Rat, scale int) *big.Rat {
+	res, _ := new(big.Rat).SetString(r.FloatString(scale))
+	return res
+}
+
+// ratToBigInt returns r as an integer, or an error if it has a fractional part.
+func ratToBigInt(r *big.Rat) (*big.Int, error) {
+	if !r.IsInt() {
+		return nil, errors.Errorf("Decimal %s isn't an integer", FormatDecimal(r))
+	}
+	return new(big.Int).Set(r.Num()), nil
+}
+
+// floatToDecimal returns the shortest decimal which parses back to f.
+func floatToDecimal(f float64) (*big.Rat, error) {
+	return ParseDecimal(strconv.FormatFloat(f, 'f', -1, 64))
+}
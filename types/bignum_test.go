@@ -0,0 +1,108 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		in, out string
+	}{
+		{"12.50", "12.5"},
+		{"-0.010", "-0.01"},
+		{".5", "0.5"},
+		{"1.25e-3", "0.00125"},
+		{"3E2", "300"},
+		{"123456789012345678901234567890.000000000000000001",
+			"123456789012345678901234567890.000000000000000001"},
+	}
+	for _, tc := range tests {
+		r, err := ParseDecimal(tc.in)
+		require.NoError(t, err, tc.in)
+		require.Equal(t, tc.out, FormatDecimal(r), tc.in)
+	}
+
+	for _, in := range []string{"", "1/3", "0x10", "1.2.3", "abc", "1e99999", "NaN"} {
+		_, err := ParseDecimal(in)
+		require.Error(t, err, in)
+	}
+
+	// The values with no finite decimal expansion are rounded.
+	require.Equal(t, "0.333333333333333333", FormatDecimal(big.NewRat(1, 3)))
+	require.Equal(t, "0.67", FormatDecimal(RoundDecimal(big.NewRat(2, 3), 2)))
+}
+
+func TestConvertBigNum(t *testing.T) {
+	big1 := "123456789012345678901234567890"
+	src := Val{Tid: StringID, Value: []byte(big1)}
+	i, err := Convert(src, BigIntID)
+	require.NoError(t, err)
+
+	// The values are stored as their text.
+	b := ValueForType(BinaryID)
+	require.NoError(t, Marshal(i, &b))
+	require.Equal(t, big1, string(b.Value.([]byte)))
+	i2, err := Convert(Val{Tid: BigIntID, Value: b.Value}, BigIntID)
+	require.NoError(t, err)
+	eq, err := Equal(i, i2)
+	require.NoError(t, err)
+	require.True(t, eq)
+
+	_, err = Convert(Val{Tid: BigIntID, Value: b.Value}, IntID)
+	require.Error(t, err)
+	d, err := Convert(Val{Tid: BigIntID, Value: b.Value}, DecimalID)
+	require.NoError(t, err)
+	require.Equal(t, big1, FormatDecimal(d.Value.(*big.Rat)))
+
+	d, err = Convert(Val{Tid: StringID, Value: []byte("0.1")}, DecimalID)
+	require.NoError(t, err)
+	d2, err := Convert(Val{Tid: StringID, Value: []byte("0.2")}, DecimalID)
+	require.NoError(t, err)
+	sum := new(big.Rat).Add(d.Value.(*big.Rat), d2.Value.(*big.Rat))
+	require.Equal(t, "0.3", FormatDecimal(sum))
+	less, err := Less(d, d2)
+	require.NoError(t, err)
+	require.True(t, less)
+
+	_, err = Convert(Val{Tid: StringID, Value: []byte("1.5")}, BigIntID)
+	require.Error(t, err)
+	f := ValueForType(BinaryID)
+	require.NoError(t, Marshal(Val{Tid: FloatID, Value: 0.1}, &f))
+	d, err = Convert(Val{Tid: FloatID, Value: f.Value}, DecimalID)
+	require.NoError(t, err)
+	require.Equal(t, "0.1", FormatDecimal(d.Value.(*big.Rat)))
+}
+
+func TestBigNumJSON(t *testing.T) {
+	i, ok := new(big.Int).SetString("-98765432109876543210", 10)
+	require.True(t, ok)
+	out, err := json.Marshal(Val{Tid: BigIntID, Value: i})
+	require.NoError(t, err)
+	require.Equal(t, `"-98765432109876543210"`, string(out))
+
+	r, err := ParseDecimal("10.10")
+	require.NoError(t, err)
+	out, err = json.Marshal(Val{Tid: DecimalID, Value: r})
+	require.NoError(t, err)
+	require.Equal(t, `"10.1"`, string(out))
+}
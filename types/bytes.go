@@ -0,0 +1,56 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+import (
+	"encoding/base64"
+
+	"github.com/pkg/errors"
+)
+
+// The values of the bytes type are small blobs, like thumbnails or protobuf payloads, stored as
+// they are in the postings. Unlike the binary type, they're written as standard base64 in text:
+// in JSON, in the exports, and in the mutations, which can also set them from the bytes value of
+// an N-Quad.
+
+// MaxBytesSize is the largest size of a value of the bytes type.
+const MaxBytesSize = 1 << 20
+
+// ParseBytes decodes a value of the bytes type from its standard base64 encoding.
+func ParseBytes(s string) ([]byte, error) {
+	if base64.StdEncoding.DecodedLen(len(s)) > MaxBytesSize+2 {
+		return nil, errors.Errorf("Bytes value of %d base64 characters exceeds %d bytes",
+			len(s), MaxBytesSize)
+	}
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Invalid base64 for bytes value")
+	}
+	return b, checkBytesSize(b)
+}
+
+// FormatBytes returns the standard base64 encoding of a value of the bytes type.
+func FormatBytes(b []byte) string {
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+func checkBytesSize(b []byte) error {
+	if len(b) > MaxBytesSize {
+		return errors.Errorf("Bytes value of size %d exceeds %d bytes", len(b), MaxBytesSize)
+	}
+	return nil
+}
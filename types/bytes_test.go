@@ -0,0 +1,57 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertBytes(t *testing.T) {
+	blob := []byte{0, 1, 0xfe, 0xff}
+	b, err := Convert(Val{Tid: StringID, Value: []byte("AAH+/w==")}, BytesID)
+	require.NoError(t, err)
+	require.Equal(t, blob, b.Value)
+
+	// The bytes of an N-Quad are taken as they are.
+	b2, err := Convert(Val{Tid: BinaryID, Value: blob}, BytesID)
+	require.NoError(t, err)
+	eq, err := Equal(b, b2)
+	require.NoError(t, err)
+	require.True(t, eq)
+
+	s, err := Convert(Val{Tid: BytesID, Value: blob}, StringID)
+	require.NoError(t, err)
+	require.Equal(t, "AAH+/w==", s.Value)
+	out, err := json.Marshal(b)
+	require.NoError(t, err)
+	require.Equal(t, `"AAH+/w=="`, string(out))
+
+	_, err = Convert(Val{Tid: StringID, Value: []byte("not base64")}, BytesID)
+	require.Error(t, err)
+	_, err = Convert(Val{Tid: BinaryID, Value: make([]byte, MaxBytesSize+1)}, BytesID)
+	require.Error(t, err)
+	_, err = ParseBytes(FormatBytes(make([]byte, MaxBytesSize+1)))
+	require.Error(t, err)
+	_, err = ParseBytes(strings.Repeat("A", 4*MaxBytesSize))
+	require.Error(t, err)
+	_, err = ParseBytes(FormatBytes(make([]byte, MaxBytesSize)))
+	require.NoError(t, err)
+}
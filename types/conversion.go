@@ -21,6 +21,7 @@ import (
 	"encoding/binary"
 	"encoding/json"
 	"math"
+	"math/big"
 	"strconv"
 	"time"
 	"unsafe"
@@ -91,6 +92,28 @@ func Convert(from Val, toID TypeID) (Val, error) {
 				*res = w
 			case PasswordID:
 				*res = string(data)
+			case BigIntID:
+				i, err := ParseBigInt(string(data))
+				if err != nil {
+					return to, err
+				}
+				*res = i
+			case DecimalID:
+				r, err := ParseDecimal(string(data))
+				if err != nil {
+					return to, err
+				}
+				*res = r
+			case DurationID:
+				if len(data) < 8 {
+					return to, errors.Errorf("Invalid data for duration %v", data)
+				}
+				*res = time.Duration(binary.LittleEndian.Uint64(data))
+			case BytesID:
+				if err := checkBytesSize(data); err != nil {
+					return to, err
+				}
+				*res = data
 			default:
 				return to, cantConvert(fromID, toID)
 			}
@@ -144,6 +167,30 @@ func Convert(from Val, toID TypeID) (Val, error) {
 					return to, err
 				}
 				*res = p
+			case BigIntID:
+				i, err := ParseBigInt(vc)
+				if err != nil {
+					return to, err
+				}
+				*res = i
+			case DecimalID:
+				r, err := ParseDecimal(vc)
+				if err != nil {
+					return to, err
+				}
+				*res = r
+			case DurationID:
+				d, err := ParseDuration(vc)
+				if err != nil {
+					return to, err
+				}
+				*res = d
+			case BytesID:
+				b, err := ParseBytes(vc)
+				if err != nil {
+					return to, err
+				}
+				*res = b
 			default:
 				return to, cantConvert(fromID, toID)
 			}
@@ -169,6 +216,10 @@ func Convert(from Val, toID TypeID) (Val, error) {
 				*res = strconv.FormatInt(vc, 10)
 			case DateTimeID:
 				*res = time.Unix(vc, 0).UTC()
+			case BigIntID:
+				*res = big.NewInt(vc)
+			case DecimalID:
+				*res = new(big.Rat).SetInt64(vc)
 			default:
 				return to, cantConvert(fromID, toID)
 			}
@@ -202,6 +253,22 @@ func Convert(from Val, toID TypeID) (Val, error) {
 				fracSecs := vc - float64(secs)
 				nsecs := int64(fracSecs * nanoSecondsInSec)
 				*res = time.Unix(secs, nsecs).UTC()
+			case DecimalID:
+				r, err := floatToDecimal(vc)
+				if err != nil {
+					return to, err
+				}
+				*res = r
+			case BigIntID:
+				r, err := floatToDecimal(vc)
+				if err != nil {
+					return to, err
+				}
+				i, err := ratToBigInt(r)
+				if err != nil {
+					return to, err
+				}
+				*res = i
 			default:
 				return to, cantConvert(fromID, toID)
 			}
@@ -304,12 +371,137 @@ func Convert(from Val, toID TypeID) (Val, error) {
 				return to, cantConvert(fromID, toID)
 			}
 		}
+	case BigIntID:
+		{
+			vc, err := ParseBigInt(string(data))
+			if err != nil {
+				return to, err
+			}
+			switch toID {
+			case BigIntID:
+				*res = vc
+			case BinaryID:
+				*res = []byte(vc.String())
+			case StringID, DefaultID:
+				*res = vc.String()
+			case IntID:
+				if !vc.IsInt64() {
+					return to, errors.Errorf("Bigint %s out of int64 range", vc)
+				}
+				*res = vc.Int64()
+			case FloatID:
+				f, _ := new(big.Float).SetInt(vc).Float64()
+				*res = f
+			case DecimalID:
+				*res = new(big.Rat).SetInt(vc)
+			case BoolID:
+				*res = vc.Sign() != 0
+			default:
+				return to, cantConvert(fromID, toID)
+			}
+		}
+	case DecimalID:
+		{
+			vc, err := ParseDecimal(string(data))
+			if err != nil {
+				return to, err
+			}
+			switch toID {
+			case DecimalID:
+				*res = vc
+			case BinaryID:
+				*res = []byte(FormatDecimal(vc))
+			case StringID, DefaultID:
+				*res = FormatDecimal(vc)
+			case FloatID:
+				f, _ := vc.Float64()
+				*res = f
+			case BigIntID:
+				i, err := ratToBigInt(vc)
+				if err != nil {
+					return to, err
+				}
+				*res = i
+			case IntID:
+				i, err := ratToBigInt(vc)
+				if err != nil {
+					return to, err
+				}
+				if !i.IsInt64() {
+					return to, errors.Errorf("Decimal %s out of int64 range", i)
+				}
+				*res = i.Int64()
+			case BoolID:
+				*res = vc.Sign() != 0
+			default:
+				return to, cantConvert(fromID, toID)
+			}
+		}
+	case DurationID:
+		{
+			if len(data) < 8 {
+				return to, errors.Errorf("Invalid data for duration %v", data)
+			}
+			vc := time.Duration(binary.LittleEndian.Uint64(data))
+			switch toID {
+			case DurationID:
+				*res = vc
+			case BinaryID:
+				var bs [8]byte
+				binary.LittleEndian.PutUint64(bs[:], uint64(vc))
+				*res = bs[:]
+			case StringID, DefaultID:
+				*res = FormatDuration(vc)
+			case IntID:
+				*res = int64(vc / time.Second)
+			case FloatID:
+				*res = vc.Seconds()
+			default:
+				return to, cantConvert(fromID, toID)
+			}
+		}
+	case BytesID:
+		{
+			switch toID {
+			case BytesID, BinaryID:
+				*res = data
+			case StringID, DefaultID:
+				*res = FormatBytes(data)
+			default:
+				return to, cantConvert(fromID, toID)
+			}
+		}
 	default:
 		return to, cantConvert(fromID, toID)
 	}
 	return to, nil
 }
 
+// Coerce converts the value, given in its binary form like to Convert, to the given type. Unlike
+// Convert, it's lenient with the strings, for the values stored with another type than the one
+// they hold: the surrounding white space is ignored, and the numbers having a fractional part are
+// truncated when converted to int.
+func Coerce(from Val, toID TypeID) (Val, error) {
+	if data, ok := from.Value.([]byte); ok && (from.Tid == StringID || from.Tid == DefaultID) {
+		from.Value = bytes.TrimSpace(data)
+		if toID == IntID {
+			if to, err := Convert(from, IntID); err == nil {
+				return to, nil
+			}
+			f, err := Convert(from, FloatID)
+			if err != nil {
+				return Val{Tid: toID}, err
+			}
+			vc := math.Trunc(f.Value.(float64))
+			if vc >= math.MaxInt64 || vc < math.MinInt64 {
+				return Val{Tid: toID}, errors.Errorf("Float out of int64 range")
+			}
+			return Val{Tid: IntID, Value: int64(vc)}, nil
+		}
+	}
+	return Convert(from, toID)
+}
+
 func Marshal(from Val, to *Val) error {
 	if to == nil {
 		return errors.Errorf("Invalid conversion %s to nil", from.Tid.Name())
@@ -435,6 +627,48 @@ func Marshal(from Val, to *Val) error {
 		default:
 			return cantConvert(fromID, toID)
 		}
+	case BigIntID:
+		vc := val.(*big.Int)
+		switch toID {
+		case StringID, DefaultID:
+			*res = vc.String()
+		case BinaryID:
+			*res = []byte(vc.String())
+		default:
+			return cantConvert(fromID, toID)
+		}
+	case DecimalID:
+		vc := val.(*big.Rat)
+		switch toID {
+		case StringID, DefaultID:
+			*res = FormatDecimal(vc)
+		case BinaryID:
+			*res = []byte(FormatDecimal(vc))
+		default:
+			return cantConvert(fromID, toID)
+		}
+	case DurationID:
+		vc := val.(time.Duration)
+		switch toID {
+		case StringID, DefaultID:
+			*res = FormatDuration(vc)
+		case BinaryID:
+			var bs [8]byte
+			binary.LittleEndian.PutUint64(bs[:], uint64(vc))
+			*res = bs[:]
+		default:
+			return cantConvert(fromID, toID)
+		}
+	case BytesID:
+		vc := val.([]byte)
+		switch toID {
+		case StringID, DefaultID:
+			*res = FormatBytes(vc)
+		case BinaryID:
+			*res = vc
+		default:
+			return cantConvert(fromID, toID)
+		}
 	default:
 		return cantConvert(fromID, toID)
 	}
@@ -477,7 +711,7 @@ func ObjectValue(id TypeID, value interface{}) (*api.Value, error) {
 			return def, errors.Errorf("Expected value of type bool. Got : %v", value)
 		}
 		return &api.Value{Val: &api.Value_BoolVal{BoolVal: v}}, nil
-	case BinaryID:
+	case BinaryID, BytesID:
 		var v []byte
 		if v, ok = value.([]byte); !ok {
 			return def, errors.Errorf("Expected value of type []byte. Got : %v", value)
@@ -503,6 +737,20 @@ func ObjectValue(id TypeID, value interface{}) (*api.Value, error) {
 			return def, errors.Errorf("Expected value of type password. Got : %v", value)
 		}
 		return &api.Value{Val: &api.Value_PasswordVal{PasswordVal: v}}, nil
+	// There is no value type for bigint and decimal in the N-Quad, so they're passed as strings,
+	// converted back to the type of the predicate by the mutation.
+	case BigIntID, DecimalID:
+		b, err := toBinary(id, value)
+		if err != nil {
+			return def, err
+		}
+		return &api.Value{Val: &api.Value_StrVal{StrVal: string(b)}}, nil
+	case DurationID:
+		var v time.Duration
+		if v, ok = value.(time.Duration); !ok {
+			return def, errors.Errorf("Expected value of type duration. Got : %v", value)
+		}
+		return &api.Value{Val: &api.Value_StrVal{StrVal: FormatDuration(v)}}, nil
 	default:
 		return def, errors.Errorf("ObjectValue not available for: %v", id)
 	}
@@ -537,6 +785,16 @@ func (v Val) MarshalJSON() ([]byte, error) {
 		return json.Marshal(v.Safe().(string))
 	case PasswordID:
 		return json.Marshal(v.Value.(string))
+	case BigIntID:
+		// Encoded as a string, as JSON numbers are commonly decoded as float64.
+		return json.Marshal(v.Value.(*big.Int).String())
+	case DecimalID:
+		return json.Marshal(FormatDecimal(v.Value.(*big.Rat)))
+	case DurationID:
+		return json.Marshal(FormatDuration(v.Value.(time.Duration)))
+	case BytesID:
+		// Encoded as base64.
+		return json.Marshal(v.Value.([]byte))
 	}
 	return nil, errors.Errorf("Invalid type for MarshalJSON: %v", v.Tid)
 }
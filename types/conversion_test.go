@@ -849,3 +849,30 @@ func TestConvertToString(t *testing.T) {
 		require.EqualValues(t, Val{Tid: StringID, Value: tc.out}, out)
 	}
 }
+
+func TestCoerce(t *testing.T) {
+	tests := []struct {
+		in  Val
+		tid TypeID
+		out interface{}
+	}{
+		{in: Val{Tid: StringID, Value: []byte(" 42 ")}, tid: IntID, out: int64(42)},
+		{in: Val{Tid: DefaultID, Value: []byte("-3.7")}, tid: IntID, out: int64(-3)},
+		{in: Val{Tid: StringID, Value: []byte("1e3")}, tid: IntID, out: int64(1000)},
+		{in: Val{Tid: StringID, Value: []byte("2.5\n")}, tid: FloatID, out: 2.5},
+		{in: Val{Tid: StringID, Value: []byte(" 2021-03-01 ")}, tid: DateTimeID,
+			out: time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{in: Val{Tid: IntID, Value: bs(int64(7))}, tid: StringID, out: "7"},
+		{in: Val{Tid: FloatID, Value: bs(2.5)}, tid: IntID, out: int64(2)},
+	}
+	for _, tc := range tests {
+		out, err := Coerce(tc.in, tc.tid)
+		require.NoError(t, err, tc.in)
+		require.Equal(t, Val{Tid: tc.tid, Value: tc.out}, out, tc.in)
+	}
+
+	for _, in := range []string{"abc", "", "1e30", "NaN"} {
+		_, err := Coerce(Val{Tid: StringID, Value: []byte(in)}, IntID)
+		require.Error(t, err, in)
+	}
+}
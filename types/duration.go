@@ -0,0 +1,120 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// The values of the duration type are time.Duration, stored as their number of nanoseconds. They
+// are written in the ISO-8601 format, e.g. P1DT2H30M or PT0.5S, a day being 24 hours. Years and
+// months are rejected, as their length depends on the date they're added to.
+
+const day = 24 * time.Hour
+
+var (
+	durationRe = regexp.MustCompile(`^([+-])?P(?:([\d.]+)W)?(?:([\d.]+)D)?` +
+		`(?:T(?:([\d.]+)H)?(?:([\d.]+)M)?(?:([\d.]+)S)?)?$`)
+	durationUnits = []time.Duration{7 * day, day, time.Hour, time.Minute, time.Second}
+	// nominalDurationRe matches the durations having years or months.
+	nominalDurationRe = regexp.MustCompile(`^[+-]?P[^T]*[YM]`)
+)
+
+// ParseDuration parses an ISO-8601 duration, optionally signed, with weeks, days, hours, minutes
+// and seconds. Any of them may have a fractional part.
+func ParseDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if nominalDurationRe.MatchString(s) {
+		return 0, errors.Errorf("Years and months aren't allowed in duration %q", s)
+	}
+	m := durationRe.FindStringSubmatch(s)
+	if m == nil || strings.HasSuffix(s, "P") || strings.HasSuffix(s, "T") {
+		return 0, errors.Errorf("Invalid ISO-8601 duration %q", s)
+	}
+
+	var total time.Duration
+	for i, unit := range durationUnits {
+		field := m[i+2]
+		if field == "" {
+			continue
+		}
+		f, err := strconv.ParseFloat(field, 64)
+		if err != nil || f < 0 {
+			return 0, errors.Errorf("Invalid ISO-8601 duration %q", s)
+		}
+		// The whole part is added exactly, so that only the fraction can be rounded.
+		whole, frac := math.Modf(f)
+		if whole > float64(math.MaxInt64/unit) {
+			return 0, errors.Errorf("Duration %q out of range", s)
+		}
+		d := time.Duration(whole)*unit + time.Duration(math.Round(frac*float64(unit)))
+		if total > math.MaxInt64-d {
+			return 0, errors.Errorf("Duration %q out of range", s)
+		}
+		total += d
+	}
+	if m[1] == "-" {
+		total = -total
+	}
+	return total, nil
+}
+
+// FormatDuration returns the ISO-8601 text of the duration, from days to seconds.
+func FormatDuration(d time.Duration) string {
+	if d == 0 {
+		return "PT0S"
+	}
+	var b strings.Builder
+	// The absolute value of the smallest duration doesn't fit, so it's taken unit by unit.
+	u := uint64(d)
+	if d < 0 {
+		b.WriteByte('-')
+		u = -u
+	}
+	b.WriteByte('P')
+	if days := u / uint64(day); days > 0 {
+		b.WriteString(strconv.FormatUint(days, 10) + "D")
+		u %= uint64(day)
+	}
+	if u == 0 {
+		return b.String()
+	}
+	b.WriteByte('T')
+	if hours := u / uint64(time.Hour); hours > 0 {
+		b.WriteString(strconv.FormatUint(hours, 10) + "H")
+		u %= uint64(time.Hour)
+	}
+	if mins := u / uint64(time.Minute); mins > 0 {
+		b.WriteString(strconv.FormatUint(mins, 10) + "M")
+		u %= uint64(time.Minute)
+	}
+	if u > 0 {
+		secs := strconv.FormatUint(u/uint64(time.Second), 10)
+		if ns := u % uint64(time.Second); ns > 0 {
+			frac := strconv.FormatUint(ns+uint64(time.Second), 10)[1:]
+			secs += "." + strings.TrimRight(frac, "0")
+		}
+		b.WriteString(secs + "S")
+	}
+	return b.String()
+}
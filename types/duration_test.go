@@ -0,0 +1,82 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		in  string
+		d   time.Duration
+		out string
+	}{
+		{"PT1H30M", 90 * time.Minute, "PT1H30M"},
+		{"P2DT3H", 51 * time.Hour, "P2DT3H"},
+		{"P1W", 7 * day, "P7D"},
+		{"PT0.5S", 500 * time.Millisecond, "PT0.5S"},
+		{"PT1.5H", 90 * time.Minute, "PT1H30M"},
+		{"PT90S", 90 * time.Second, "PT1M30S"},
+		{"-PT1M0.000000001S", -time.Minute - 1, "-PT1M0.000000001S"},
+		{"P0D", 0, "PT0S"},
+	}
+	for _, tc := range tests {
+		d, err := ParseDuration(tc.in)
+		require.NoError(t, err, tc.in)
+		require.Equal(t, tc.d, d, tc.in)
+		require.Equal(t, tc.out, FormatDuration(d), tc.in)
+	}
+	require.Equal(t, "-P106751DT23H47M16.854775808S", FormatDuration(math.MinInt64))
+
+	for _, in := range []string{"", "P", "PT", "P1DT", "1H", "PT1H2D", "P1.2.3D", "P-1D",
+		"P1Y", "P1M", "P1Y2DT1H", "P999999999999W"} {
+		_, err := ParseDuration(in)
+		require.Error(t, err, in)
+	}
+}
+
+func TestConvertDuration(t *testing.T) {
+	d, err := Convert(Val{Tid: StringID, Value: []byte("PT2M")}, DurationID)
+	require.NoError(t, err)
+	require.Equal(t, 2*time.Minute, d.Value)
+
+	b := ValueForType(BinaryID)
+	require.NoError(t, Marshal(d, &b))
+	d2, err := Convert(Val{Tid: DurationID, Value: b.Value}, DurationID)
+	require.NoError(t, err)
+	eq, err := Equal(d, d2)
+	require.NoError(t, err)
+	require.True(t, eq)
+
+	f, err := Convert(Val{Tid: DurationID, Value: b.Value}, FloatID)
+	require.NoError(t, err)
+	require.Equal(t, 120.0, f.Value)
+
+	less, err := Less(Val{Tid: DurationID, Value: -time.Hour}, d)
+	require.NoError(t, err)
+	require.True(t, less)
+
+	out, err := json.Marshal(d)
+	require.NoError(t, err)
+	require.Equal(t, `"PT2M"`, string(out))
+}
@@ -0,0 +1,115 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package facets
+
+import (
+	"encoding/binary"
+	"math"
+	"time"
+
+	"github.com/dgraph-io/dgraph/tok"
+	"github.com/dgraph-io/dgraph/types"
+	"github.com/pkg/errors"
+)
+
+// The facet index of a uid predicate maps the facet values of the edges of a subject to the
+// objects of these edges. Its tokens are made of the IdentFacet byte, the facet key followed by
+// a zero byte, the subject as 8 big endian bytes, the value type and the value, so that the edges of a subject are sorted by the value of the facet within each value type.
+// Integers and floats share the same value type so that they are compared with each other.
+const (
+	indexNumber   = 'n'
+	indexString   = 's'
+	indexDateTime = 't'
+	indexBool     = 'b'
+)
+
+// IndexPrefix returns the prefix of the index tokens of the facet key for the edges of subject.
+func IndexPrefix(key string, subject uint64) string {
+	buf := make([]byte, 0, len(key)+10)
+	buf = append(buf, tok.IdentFacet)
+	buf = append(buf, key...)
+	buf = append(buf, 0)
+	buf = append(buf, make([]byte, 8)...)
+	binary.BigEndian.PutUint64(buf[len(buf)-8:], subject)
+	return string(buf)
+}
+
+// KeyPrefix returns the prefix of the index tokens of the facet key for all the subjects.
+func KeyPrefix(key string) string {
+	return string(tok.IdentFacet) + key + "\x00"
+}
+
+// IndexToken returns the index token of the facet value val of the facet key, for an edge of
+// subject. Numbers are converted to floats, so the tokens of close large integers might be equal.
+func IndexToken(key string, subject uint64, val types.Val) (string, error) {
+	typ, enc, err := encodeIndexValue(val)
+	if err != nil {
+		return "", err
+	}
+	return IndexPrefix(key, subject) + string(typ) + string(enc), nil
+}
+
+// IndexTypePrefix returns the prefix of the index tokens of the facet key for the edges of
+// subject having a facet value of the same type as val.
+func IndexTypePrefix(key string, subject uint64, val types.Val) (string, error) {
+	typ, _, err := encodeIndexValue(val)
+	if err != nil {
+		return "", err
+	}
+	return IndexPrefix(key, subject) + string(typ), nil
+}
+
+func encodeIndexValue(val types.Val) (byte, []byte, error) {
+	switch val.Tid {
+	case types.IntID:
+		return indexNumber, encodeFloat(float64(val.Value.(int64))), nil
+	case types.FloatID:
+		return indexNumber, encodeFloat(val.Value.(float64)), nil
+	case types.StringID, types.DefaultID:
+		return indexString, []byte(val.Value.(string)), nil
+	case types.DateTimeID:
+		t := val.Value.(time.Time)
+		buf := make([]byte, 12)
+		binary.BigEndian.PutUint64(buf, uint64(t.Unix())^(1<<63))
+		binary.BigEndian.PutUint32(buf[8:], uint32(t.Nanosecond()))
+		return indexDateTime, buf, nil
+	case types.BoolID:
+		if val.Value.(bool) {
+			return indexBool, []byte{1}, nil
+		}
+		return indexBool, []byte{0}, nil
+	default:
+		return 0, nil, errors.Errorf("Cannot index facet value of type %s", val.Tid.Name())
+	}
+}
+
+// encodeFloat encodes f so that the encodings sort in the same order as the floats.
+func encodeFloat(f float64) []byte {
+	if f == 0 {
+		// Negative zero is encoded as zero.
+		f = 0
+	}
+	bits := math.Float64bits(f)
+	if f >= 0 {
+		bits ^= 1 << 63
+	} else {
+		bits = ^bits
+	}
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, bits)
+	return buf
+}
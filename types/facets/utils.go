@@ -193,3 +193,38 @@ func ValFor(f *api.Facet) (types.Val, error) {
 
 	return types.Convert(val, facetTid)
 }
+
+// ConvertTo converts the facet to the given value type. Converting a float holding a fractional
+// part to an integer is an error.
+func ConvertTo(f *api.Facet, valType api.Facet_ValType) (*api.Facet, error) {
+	if f.ValType == valType {
+		return f, nil
+	}
+	src, err := ValFor(f)
+	if err != nil {
+		return nil, err
+	}
+	tid, err := TypeIDFor(&api.Facet{ValType: valType})
+	if err != nil {
+		return nil, err
+	}
+	if src.Tid == types.FloatID && tid == types.IntID {
+		if v := src.Value.(float64); v != math.Trunc(v) {
+			return nil, errors.Errorf("Cannot convert float %v to int", v)
+		}
+	}
+	dst, err := types.Convert(src, tid)
+	if err != nil {
+		return nil, err
+	}
+	out, err := ToBinary(f.Key, dst.Value, valType)
+	if err != nil {
+		return nil, err
+	}
+	out.Alias = f.Alias
+	if valType == api.Facet_STRING {
+		out.Tokens, err = tok.GetTermTokens([]string{dst.Value.(string)})
+		sort.Strings(out.Tokens)
+	}
+	return out, err
+}
@@ -0,0 +1,135 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package facets
+
+import (
+	"sort"
+	"time"
+
+	"github.com/dgraph-io/dgo/v210/protos/api"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/types"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/pkg/errors"
+)
+
+// The valid time of an edge is the time range [validFrom, validTo) in which the fact it records
+// holds in the modeled world, as opposed to the transaction time at which it was committed. It is
+// set through the validFrom and validTo facets, but stored in the posting of the edge in seconds
+// since the epoch instead of among its facets. A missing bound leaves the range open.
+const (
+	ValidFrom = "validFrom"
+	ValidTo   = "validTo"
+)
+
+// ValidTime extracts the valid time of an edge from its facets. It returns the bounds in seconds
+// since the epoch, zero for the missing ones, and the remaining facets.
+func ValidTime(fs []*api.Facet) (int64, int64, []*api.Facet, error) {
+	var from, to int64
+	var rest []*api.Facet
+	for i, f := range fs {
+		if f.Key != ValidFrom && f.Key != ValidTo {
+			if rest != nil {
+				rest = append(rest, f)
+			}
+			continue
+		}
+		if rest == nil {
+			rest = append(make([]*api.Facet, 0, len(fs)), fs[:i]...)
+		}
+		t, err := validTimeOf(f)
+		if err != nil {
+			return 0, 0, nil, err
+		}
+		if f.Key == ValidFrom {
+			from = t
+		} else {
+			to = t
+		}
+	}
+	if rest == nil {
+		return 0, 0, fs, nil
+	}
+	if from != 0 && to != 0 && to <= from {
+		return 0, 0, nil, errors.Errorf("Facet %s must be after facet %s", ValidTo, ValidFrom)
+	}
+	if len(rest) == 0 {
+		rest = nil
+	}
+	return from, to, rest, nil
+}
+
+func validTimeOf(f *api.Facet) (int64, error) {
+	if f.ValType != api.Facet_DATETIME {
+		return 0, errors.Errorf("Facet %s should be of type datetime", f.Key)
+	}
+	val, err := ValFor(f)
+	if err != nil {
+		return 0, err
+	}
+	t := val.Value.(time.Time).Unix()
+	if t == 0 {
+		return 0, errors.Errorf("Facet %s can't be the epoch, which stands for no bound", f.Key)
+	}
+	return t, nil
+}
+
+// PostingFacets returns the facets of the posting, along with the validFrom and validTo facets
+// if the posting has a valid time. The facets are sorted by key.
+func PostingFacets(p *pb.Posting) []*api.Facet {
+	if p.ValidFrom == 0 && p.ValidTo == 0 {
+		return p.Facets
+	}
+	fs := make([]*api.Facet, 0, len(p.Facets)+2)
+	fs = append(fs, p.Facets...)
+	if p.ValidFrom != 0 {
+		fs = append(fs, ValidTimeFacet(ValidFrom, p.ValidFrom))
+	}
+	if p.ValidTo != 0 {
+		fs = append(fs, ValidTimeFacet(ValidTo, p.ValidTo))
+	}
+	sort.Slice(fs, func(i, j int) bool {
+		return fs[i].Key < fs[j].Key
+	})
+	return fs
+}
+
+// ValidTimeFacet returns the facet key with the time given in seconds since the epoch.
+func ValidTimeFacet(key string, secs int64) *api.Facet {
+	f, err := ToBinary(key, time.Unix(secs, 0).UTC(), api.Facet_DATETIME)
+	x.Check(err)
+	return f
+}
+
+// IsValidAt returns whether the facets have a valid time which contains at, given in seconds
+// since the epoch.
+func IsValidAt(fs []*api.Facet, at int64) bool {
+	for _, f := range fs {
+		if f.Key != ValidFrom && f.Key != ValidTo {
+			continue
+		}
+		val, err := ValFor(f)
+		if err != nil || val.Tid != types.DateTimeID {
+			continue
+		}
+		t := val.Value.(time.Time).Unix()
+		if (f.Key == ValidFrom && at < t) || (f.Key == ValidTo && at >= t) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,90 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package facets
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/dgo/v210/protos/api"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	from2010 = time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC).Unix()
+	to2015   = time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC).Unix()
+)
+
+func TestValidTime(t *testing.T) {
+	since, err := ToBinary("since", "2006", api.Facet_STRING)
+	require.NoError(t, err)
+
+	from, to, rest, err := ValidTime([]*api.Facet{
+		ValidTimeFacet(ValidFrom, from2010), since, ValidTimeFacet(ValidTo, to2015),
+	})
+	require.NoError(t, err)
+	require.Equal(t, from2010, from)
+	require.Equal(t, to2015, to)
+	require.Equal(t, []*api.Facet{since}, rest)
+
+	// The facets are kept as they are without a valid time.
+	fs := []*api.Facet{since}
+	from, to, rest, err = ValidTime(fs)
+	require.NoError(t, err)
+	require.Zero(t, from)
+	require.Zero(t, to)
+	require.Equal(t, fs, rest)
+
+	from, to, rest, err = ValidTime([]*api.Facet{ValidTimeFacet(ValidTo, to2015)})
+	require.NoError(t, err)
+	require.Zero(t, from)
+	require.Equal(t, to2015, to)
+	require.Nil(t, rest)
+
+	notTime, err := ToBinary(ValidFrom, "2010", api.Facet_STRING)
+	require.NoError(t, err)
+	epoch, err := ToBinary(ValidTo, time.Unix(0, 0).UTC(), api.Facet_DATETIME)
+	require.NoError(t, err)
+	for _, fs := range [][]*api.Facet{
+		{ValidTimeFacet(ValidFrom, to2015), ValidTimeFacet(ValidTo, from2010)},
+		{ValidTimeFacet(ValidFrom, from2010), ValidTimeFacet(ValidTo, from2010)},
+		{notTime},
+		{epoch},
+	} {
+		_, _, _, err = ValidTime(fs)
+		require.Error(t, err)
+	}
+}
+
+func TestIsValidAt(t *testing.T) {
+	both := []*api.Facet{ValidTimeFacet(ValidFrom, from2010), ValidTimeFacet(ValidTo, to2015)}
+	// The valid time is the half-open range [validFrom, validTo).
+	require.False(t, IsValidAt(both, from2010-1))
+	require.True(t, IsValidAt(both, from2010))
+	require.True(t, IsValidAt(both, to2015-1))
+	require.False(t, IsValidAt(both, to2015))
+
+	onlyFrom := []*api.Facet{ValidTimeFacet(ValidFrom, from2010)}
+	require.False(t, IsValidAt(onlyFrom, from2010-1))
+	require.True(t, IsValidAt(onlyFrom, to2015*2))
+
+	onlyTo := []*api.Facet{ValidTimeFacet(ValidTo, to2015)}
+	require.True(t, IsValidAt(onlyTo, 1))
+	require.False(t, IsValidAt(onlyTo, to2015))
+
+	require.True(t, IsValidAt(nil, from2010))
+}
@@ -17,6 +17,13 @@
 package types
 
 import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
 	"golang.org/x/crypto/bcrypt"
 
 	"github.com/pkg/errors"
@@ -24,14 +31,50 @@ import (
 
 const (
 	pwdLenLimit = 6
+
+	// BcryptScheme is the default scheme used to hash passwords.
+	BcryptScheme = "bcrypt"
+	// Argon2idScheme hashes passwords with Argon2id. The hash is stored in the PHC string format,
+	// i.e. $argon2id$v=19$m=<memory>,t=<time>,p=<threads>$<salt>$<key>.
+	Argon2idScheme = "argon2id"
+
+	argon2idPrefix  = "$argon2id$"
+	argon2idTime    = 1
+	argon2idMemory  = 64 * 1024
+	argon2idThreads = 4
+	argon2idKeyLen  = 32
+	argon2idSaltLen = 16
 )
 
+// hashScheme is the scheme used by Encrypt to hash new passwords.
+var hashScheme = BcryptScheme
+
+// SetPasswordHashScheme sets the scheme used to hash new passwords. Passwords that are already
+// stored keep their scheme, VerifyPassword detects it from the stored hash.
+func SetPasswordHashScheme(scheme string) error {
+	switch scheme {
+	case BcryptScheme, Argon2idScheme:
+		hashScheme = scheme
+		return nil
+	}
+	return errors.Errorf("Invalid password hash scheme: %q. Valid schemes are %q and %q",
+		scheme, BcryptScheme, Argon2idScheme)
+}
+
+// PasswordHashScheme returns the scheme used to hash new passwords.
+func PasswordHashScheme() string {
+	return hashScheme
+}
+
 // Encrypt encrypts the given plain-text password.
 func Encrypt(plain string) (string, error) {
 	if len(plain) < pwdLenLimit {
 		return "", errors.Errorf("Password too short, i.e. should have at least 6 chars")
 	}
 
+	if hashScheme == Argon2idScheme {
+		return encryptArgon2id(plain)
+	}
 	encrypted, err := bcrypt.GenerateFromPassword([]byte(plain), bcrypt.DefaultCost)
 	if err != nil {
 		return "", err
@@ -46,5 +89,55 @@ func VerifyPassword(plain, encrypted string) error {
 		return errors.Errorf("Invalid password/crypted string")
 	}
 
+	if strings.HasPrefix(encrypted, argon2idPrefix) {
+		return verifyArgon2id(plain, encrypted)
+	}
 	return bcrypt.CompareHashAndPassword([]byte(encrypted), []byte(plain))
 }
+
+func encryptArgon2id(plain string) (string, error) {
+	salt := make([]byte, argon2idSaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key := argon2.IDKey([]byte(plain), salt, argon2idTime, argon2idMemory, argon2idThreads,
+		argon2idKeyLen)
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s", argon2idPrefix, argon2.Version,
+		argon2idMemory, argon2idTime, argon2idThreads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+func verifyArgon2id(plain, encrypted string) error {
+	// The hash carries its own parameters, so hashes created with other parameters still verify.
+	parts := strings.Split(encrypted, "$")
+	if len(parts) != 6 {
+		return errors.Errorf("Invalid argon2id hash")
+	}
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return errors.Wrapf(err, "Invalid argon2id hash version")
+	}
+	if version != argon2.Version {
+		return errors.Errorf("Unsupported argon2id version: %d", version)
+	}
+	var memory, iterations uint32
+	var threads uint8
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &memory, &iterations, &threads); err != nil {
+		return errors.Wrapf(err, "Invalid argon2id hash parameters")
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return errors.Wrapf(err, "Invalid argon2id salt")
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return errors.Wrapf(err, "Invalid argon2id key")
+	}
+
+	other := argon2.IDKey([]byte(plain), salt, iterations, memory, threads, uint32(len(key)))
+	if subtle.ConstantTimeCompare(key, other) != 1 {
+		return errors.Errorf("Password does not match")
+	}
+	return nil
+}
@@ -16,7 +16,14 @@
 
 package types
 
-import "testing"
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/argon2"
+)
 
 func TestEncrypt(t *testing.T) {
 	tests := []struct {
@@ -147,3 +154,28 @@ func TestVerifyPassword(t *testing.T) {
 		})
 	}
 }
+
+func TestArgon2id(t *testing.T) {
+	require.Error(t, SetPasswordHashScheme("md5"))
+	require.NoError(t, SetPasswordHashScheme(Argon2idScheme))
+	defer func() {
+		require.NoError(t, SetPasswordHashScheme(BcryptScheme))
+	}()
+
+	encrypted, err := Encrypt("1234567890")
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(encrypted, "$argon2id$v=19$m=65536,t=1,p=4$"))
+	require.NoError(t, VerifyPassword("1234567890", encrypted))
+	require.Error(t, VerifyPassword("0987654321", encrypted))
+	require.Error(t, VerifyPassword("1234567890", "$argon2id$v=19$m=65536"))
+
+	// Hashes created with other parameters are still verified.
+	salt := []byte("somesalt")
+	key := argon2.IDKey([]byte("password"), salt, 2, 16, 1, 16)
+	require.NoError(t, VerifyPassword("password", "$argon2id$v=19$m=16,t=2,p=1$"+
+		base64.RawStdEncoding.EncodeToString(salt)+"$"+base64.RawStdEncoding.EncodeToString(key)))
+
+	// bcrypt hashes can still be verified after switching the scheme.
+	require.NoError(t, VerifyPassword("123456",
+		"$2a$10$LMGgvb5dOq4/YrWXjAy6W.tBfFQC4QDNFAuOCWGRk3f/Z1TMXswaC"))
+}
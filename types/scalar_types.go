@@ -17,6 +17,7 @@
 package types
 
 import (
+	"math/big"
 	"time"
 
 	"github.com/dgraph-io/dgraph/protos/pb"
@@ -55,6 +56,14 @@ const (
 	PasswordID = TypeID(pb.Posting_PASSWORD)
 	// StringID represents the string type.
 	StringID = TypeID(pb.Posting_STRING)
+	// BigIntID represents the arbitrary precision integer type.
+	BigIntID = TypeID(pb.Posting_BIGINT)
+	// DecimalID represents the arbitrary precision decimal number type.
+	DecimalID = TypeID(pb.Posting_DECIMAL)
+	// DurationID represents the length of time type.
+	DurationID = TypeID(pb.Posting_DURATION)
+	// BytesID represents the type of the small blobs, encoded as base64 in text.
+	BytesID = TypeID(pb.Posting_BYTES)
 	// UndefinedID represents the undefined type.
 	UndefinedID = TypeID(100)
 )
@@ -70,6 +79,10 @@ var typeNameMap = map[string]TypeID{
 	"uid":      UidID,
 	"string":   StringID,
 	"password": PasswordID,
+	"bigint":   BigIntID,
+	"decimal":  DecimalID,
+	"duration": DurationID,
+	"bytes":    BytesID,
 }
 
 // TypeID represents the type of the data.
@@ -103,6 +116,14 @@ func (t TypeID) Name() string {
 		return "string"
 	case PasswordID:
 		return "password"
+	case BigIntID:
+		return "bigint"
+	case DecimalID:
+		return "decimal"
+	case DurationID:
+		return "duration"
+	case BytesID:
+		return "bytes"
 	}
 	return ""
 }
@@ -187,6 +208,20 @@ func ValueForType(id TypeID) Val {
 		var p string
 		return Val{PasswordID, p}
 
+	case BigIntID:
+		return Val{BigIntID, new(big.Int)}
+
+	case DecimalID:
+		return Val{DecimalID, new(big.Rat)}
+
+	case DurationID:
+		var d time.Duration
+		return Val{DurationID, &d}
+
+	case BytesID:
+		var b []byte
+		return Val{BytesID, &b}
+
 	default:
 		return Val{}
 	}
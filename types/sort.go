@@ -17,6 +17,8 @@
 package types
 
 import (
+	"bytes"
+	"math/big"
 	"sort"
 	"time"
 
@@ -91,7 +93,8 @@ func (s byValue) Less(i, j int) bool {
 // IsSortable returns true, if tid is sortable. Otherwise it returns false.
 func IsSortable(tid TypeID) bool {
 	switch tid {
-	case DateTimeID, IntID, FloatID, StringID, DefaultID:
+	case DateTimeID, IntID, FloatID, StringID, DefaultID, BigIntID, DecimalID,
+		DurationID:
 		return true
 	default:
 		return false
@@ -101,6 +104,17 @@ func IsSortable(tid TypeID) bool {
 // SortWithFacet sorts the given array in-place and considers the given facets to calculate
 // the proper ordering.
 func SortWithFacet(v [][]Val, ul *[]uint64, l []*pb.Facets, desc []bool, lang string) error {
+	return sortValues(v, ul, l, desc, lang, false)
+}
+
+// SortStable sorts the given array in-place like Sort, keeping the order of the elements having
+// the same values.
+func SortStable(v [][]Val, ul *[]uint64, desc []bool, lang string) error {
+	return sortValues(v, ul, nil, desc, lang, true)
+}
+
+func sortValues(v [][]Val, ul *[]uint64, l []*pb.Facets, desc []bool, lang string,
+	stable bool) error {
 	if len(v) == 0 || len(v[0]) == 0 {
 		return nil
 	}
@@ -122,7 +136,11 @@ func SortWithFacet(v [][]Val, ul *[]uint64, l []*pb.Facets, desc []bool, lang st
 
 	b := sortBase{v, desc, ul, l, cl}
 	toBeSorted := byValue{b}
-	sort.Sort(toBeSorted)
+	if stable {
+		sort.Stable(toBeSorted)
+	} else {
+		sort.Sort(toBeSorted)
+	}
 	return nil
 }
 
@@ -138,7 +156,8 @@ func Less(a, b Val) (bool, error) {
 	}
 	typ := a.Tid
 	switch typ {
-	case DateTimeID, UidID, IntID, FloatID, StringID, DefaultID:
+	case DateTimeID, UidID, IntID, FloatID, StringID, DefaultID, BigIntID, DecimalID,
+		DurationID:
 		// Don't do anything, we can sort values of this type.
 	default:
 		return false, errors.Errorf("Compare not supported for type: %v", a.Tid)
@@ -157,6 +176,12 @@ func less(a, b Val, cl *collate.Collator) bool {
 		return (a.Value.(int64)) < (b.Value.(int64))
 	case FloatID:
 		return (a.Value.(float64)) < (b.Value.(float64))
+	case BigIntID:
+		return a.Value.(*big.Int).Cmp(b.Value.(*big.Int)) < 0
+	case DecimalID:
+		return a.Value.(*big.Rat).Cmp(b.Value.(*big.Rat)) < 0
+	case DurationID:
+		return a.Value.(time.Duration) < b.Value.(time.Duration)
 	case UidID:
 		return (a.Value.(uint64) < b.Value.(uint64))
 	case StringID, DefaultID:
@@ -194,7 +219,8 @@ func Equal(a, b Val) (bool, error) {
 	}
 	typ := a.Tid
 	switch typ {
-	case DateTimeID, IntID, FloatID, StringID, DefaultID, BoolID:
+	case DateTimeID, IntID, FloatID, StringID, DefaultID, BoolID, BigIntID, DecimalID,
+		DurationID, BytesID:
 		// Don't do anything, we can sort values of this type.
 	default:
 		return false, errors.Errorf("Equal not supported for type: %v", a.Tid)
@@ -227,6 +253,22 @@ func equal(a, b Val) bool {
 		aVal, aOk := a.Value.(bool)
 		bVal, bOk := b.Value.(bool)
 		return aOk && bOk && aVal == bVal
+	case BigIntID:
+		aVal, aOk := a.Value.(*big.Int)
+		bVal, bOk := b.Value.(*big.Int)
+		return aOk && bOk && aVal.Cmp(bVal) == 0
+	case DecimalID:
+		aVal, aOk := a.Value.(*big.Rat)
+		bVal, bOk := b.Value.(*big.Rat)
+		return aOk && bOk && aVal.Cmp(bVal) == 0
+	case DurationID:
+		aVal, aOk := a.Value.(time.Duration)
+		bVal, bOk := b.Value.(time.Duration)
+		return aOk && bOk && aVal == bVal
+	case BytesID:
+		aVal, aOk := a.Value.([]byte)
+		bVal, bOk := b.Value.([]byte)
+		return aOk && bOk && bytes.Equal(aVal, bVal)
 	}
 	return false
 }
@@ -287,7 +287,7 @@ func getData(db *badger.DB, attr string, fn func(item *badger.Item) error) error
 		for itr.Seek(startKey); itr.Valid(); itr.Next() {
 			item := itr.Item()
 			// We expect only complete posting list.
-			x.AssertTrue(item.UserMeta() == posting.BitCompletePosting)
+			x.AssertTrue(posting.PostingMeta(item.UserMeta()) == posting.BitCompletePosting)
 			if err := fn(item); err != nil {
 				return err
 			}
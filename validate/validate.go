@@ -0,0 +1,158 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package validate holds the validators of the values of the predicates. The validators of a
+// predicate are given by its @validate directive, and are called in turn on the values set by the
+// mutations before they're written. A validator rejects a value by returning an error, or
+// returns the value to write in its place.
+package validate
+
+import (
+	"net/url"
+	"plugin"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+
+	"github.com/dgraph-io/dgraph/types"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// Validator defines what a validator must provide.
+//
+// A mutation is validated once before it's proposed, and again by each replica when it's applied,
+// so a validator must be deterministic and must return a value it accepts as it is.
+type Validator interface {
+	// Name is the name of the validator in the @validate directive. This should be unique.
+	Name() string
+
+	// Type returns the string representation of the type of the values validated.
+	Type() string
+
+	// Validate returns the value to write in place of the given one, or an error if the value
+	// must be rejected. The values are of the Go type of the type of the validator, e.g. a string
+	// or an int64.
+	Validate(interface{}) (interface{}, error)
+}
+
+// PluginValidator is implemented by external plugins loaded dynamically via *.so files. It
+// follows the implementation semantics of the Validator interface.
+//
+// Think carefully before modifying this interface, as it would break users' plugins.
+type PluginValidator interface {
+	Name() string
+	Type() string
+	Validate(interface{}) (interface{}, error)
+}
+
+var validators = make(map[string]Validator)
+
+func init() {
+	registerValidator(TrimValidator{})
+	registerValidator(LowercaseValidator{})
+	registerValidator(URLValidator{})
+}
+
+// LoadCustomValidator reads and loads a custom validator from the given file.
+func LoadCustomValidator(soFile string) {
+	glog.Infof("Loading custom validator from %q", soFile)
+	pl, err := plugin.Open(soFile)
+	x.Checkf(err, "could not open custom validator plugin file")
+	symb, err := pl.Lookup("Validator")
+	x.Checkf(err, `could not find symbol "Validator" while loading custom validator: %v`, err)
+
+	// Let any type assertion panics occur, since they will contain a message
+	// telling the user what went wrong.
+	registerValidator(symb.(func() interface{})().(PluginValidator))
+}
+
+// GetValidator returns the validator with the given name.
+func GetValidator(name string) (Validator, bool) {
+	v, found := validators[name]
+	return v, found
+}
+
+func registerValidator(v Validator) {
+	_, ok := validators[v.Name()]
+	x.AssertTruef(!ok, "Duplicate validator: %s", v.Name())
+	_, ok = types.TypeForName(v.Type())
+	x.AssertTruef(ok, "Invalid type %q for validator %s", v.Type(), v.Name())
+	validators[v.Name()] = v
+}
+
+// Apply calls the named validators in turn on the value, each validator getting the value
+// returned by the previous one. It returns the value to write.
+func Apply(names []string, val types.Val) (types.Val, error) {
+	for _, name := range names {
+		v, ok := GetValidator(name)
+		if !ok {
+			return val, errors.Errorf("Validator %s isn't loaded", name)
+		}
+		out, err := v.Validate(val.Value)
+		if err != nil {
+			return val, errors.Wrapf(err, "rejected by validator %s", name)
+		}
+		val.Value = out
+	}
+	return val, nil
+}
+
+// TrimValidator removes the leading and trailing white space of strings.
+type TrimValidator struct{}
+
+func (TrimValidator) Name() string { return "trim" }
+func (TrimValidator) Type() string { return "string" }
+func (TrimValidator) Validate(v interface{}) (interface{}, error) {
+	return strings.TrimSpace(v.(string)), nil
+}
+
+// LowercaseValidator maps strings to lower case.
+type LowercaseValidator struct{}
+
+func (LowercaseValidator) Name() string { return "lowercase" }
+func (LowercaseValidator) Type() string { return "string" }
+func (LowercaseValidator) Validate(v interface{}) (interface{}, error) {
+	return strings.ToLower(v.(string)), nil
+}
+
+// URLValidator rejects the strings which aren't absolute URLs, and canonicalizes the others: the
+// scheme and the host are lower cased, the default port is dropped, and so is the fragment.
+type URLValidator struct{}
+
+func (URLValidator) Name() string { return "url" }
+func (URLValidator) Type() string { return "string" }
+func (URLValidator) Validate(v interface{}) (interface{}, error) {
+	u, err := url.Parse(strings.TrimSpace(v.(string)))
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid URL")
+	}
+	if !u.IsAbs() || u.Host == "" {
+		return nil, errors.Errorf("%q isn't an absolute URL", v)
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = strings.ToLower(u.Host)
+	switch port := u.Port(); {
+	case u.Scheme == "http" && port == "80", u.Scheme == "https" && port == "443":
+		u.Host = strings.TrimSuffix(u.Host, ":"+port)
+	}
+	if u.Path == "" {
+		u.Path = "/"
+	}
+	u.Fragment = ""
+	u.RawFragment = ""
+	return u.String(), nil
+}
@@ -0,0 +1,62 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/types"
+)
+
+func TestURLValidator(t *testing.T) {
+	tests := []struct {
+		in, out string
+	}{
+		{"HTTP://Example.COM", "http://example.com/"},
+		{" https://example.com:443/a/B?q=1#top ", "https://example.com/a/B?q=1"},
+		{"http://example.com:8080/", "http://example.com:8080/"},
+	}
+	for _, tc := range tests {
+		out, err := URLValidator{}.Validate(tc.in)
+		require.NoError(t, err, tc.in)
+		require.Equal(t, tc.out, out, tc.in)
+		// Canonical URLs are accepted as they are.
+		again, err := URLValidator{}.Validate(out)
+		require.NoError(t, err)
+		require.Equal(t, out, again)
+	}
+
+	for _, in := range []string{"example.com", "/a/b", "http://%zz"} {
+		_, err := URLValidator{}.Validate(in)
+		require.Error(t, err, in)
+	}
+}
+
+func TestApply(t *testing.T) {
+	val, err := Apply([]string{"trim", "lowercase"},
+		types.Val{Tid: types.StringID, Value: "  Hello World "})
+	require.NoError(t, err)
+	require.Equal(t, "hello world", val.Value)
+
+	_, err = Apply([]string{"trim", "url"}, types.Val{Tid: types.StringID, Value: " nope "})
+	require.Error(t, err)
+
+	_, err = Apply([]string{"missing"}, types.Val{Tid: types.StringID, Value: "x"})
+	require.Error(t, err)
+}
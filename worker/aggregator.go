@@ -26,12 +26,20 @@ func couldApplyAggregatorOn(agrtr string, typ types.TypeID) bool {
 	case "min", "max":
 		return (typ == types.IntID ||
 			typ == types.FloatID ||
+			typ == types.BigIntID ||
+			typ == types.DecimalID ||
+			typ == types.DurationID ||
 			typ == types.DateTimeID ||
 			typ == types.StringID ||
 			typ == types.DefaultID)
 	case "sum", "avg":
 		return (typ == types.IntID ||
-			typ == types.FloatID)
+			typ == types.FloatID ||
+			typ == types.BigIntID ||
+			typ == types.DecimalID ||
+			typ == types.DurationID)
+	case "countdistinct":
+		return true
 	default:
 		return false
 	}
@@ -116,7 +116,7 @@ func StoreExport(request *pb.ExportRequest, dir string, key x.Sensitive) error {
 		return err
 	}
 
-	_, err = exportInternal(context.Background(), request, db, true)
+	_, err = exportInternal(context.Background(), request, db, nil, true)
 	// It is important to close the db before sending err to ch. Else, we will see a memory
 	// leak.
 	db.Close()
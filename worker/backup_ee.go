@@ -16,6 +16,7 @@ import (
 	"context"
 	"encoding/binary"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/url"
@@ -44,10 +45,13 @@ import (
 // Backup handles a request coming from another node.
 func (w *grpcWorker) Backup(ctx context.Context, req *pb.BackupRequest) (*pb.BackupResponse, error) {
 	glog.V(2).Infof("Received backup request via Grpc: %+v", req)
-	return backupCurrentGroup(ctx, req)
+	return backupCurrentGroup(ctx, req, nil)
 }
 
-func backupCurrentGroup(ctx context.Context, req *pb.BackupRequest) (*pb.BackupResponse, error) {
+// backupCurrentGroup backs up the group of this server, creating the backup file with the handler,
+// or at the destination of the request if it's nil.
+func backupCurrentGroup(ctx context.Context, req *pb.BackupRequest,
+	h x.UriHandler) (*pb.BackupResponse, error) {
 	glog.Infof("Backup request: group %d at %d", req.GroupId, req.ReadTs)
 	if err := ctx.Err(); err != nil {
 		glog.Errorf("Context error during backup: %v\n", err)
@@ -73,6 +77,9 @@ func backupCurrentGroup(ctx context.Context, req *pb.BackupRequest) (*pb.BackupR
 	bp := NewBackupProcessor(pstore, req)
 	defer bp.Close()
 
+	if h != nil {
+		return bp.writeBackup(closer.Ctx(), h)
+	}
 	return bp.WriteBackup(closer.Ctx())
 }
 
@@ -80,7 +87,7 @@ func backupCurrentGroup(ctx context.Context, req *pb.BackupRequest) (*pb.BackupR
 func BackupGroup(ctx context.Context, in *pb.BackupRequest) (*pb.BackupResponse, error) {
 	glog.V(2).Infof("Sending backup request: %+v\n", in)
 	if groups().groupId() == in.GroupId {
-		return backupCurrentGroup(ctx, in)
+		return backupCurrentGroup(ctx, in, nil)
 	}
 
 	// This node is not part of the requested group, send the request over the network.
@@ -183,16 +190,7 @@ func ProcessBackupRequest(ctx context.Context, req *pb.BackupRequest) error {
 	}
 
 	// Get the current membership state and parse it for easier processing.
-	state := GetMembershipState()
-	var groups []uint32
-	predMap := make(map[uint32][]string)
-	for gid, group := range state.Groups {
-		groups = append(groups, gid)
-		predMap[gid] = make([]string, 0)
-		for pred := range group.Tablets {
-			predMap[gid] = append(predMap[gid], pred)
-		}
-	}
+	groups, predMap := groupPredicates(GetMembershipState())
 
 	glog.Infof(
 		"Created backup request: read_ts:%d since_ts:%d unix_ts:%q destination:%q. Groups=%v\n",
@@ -202,7 +200,7 @@ func ProcessBackupRequest(ctx context.Context, req *pb.BackupRequest) error {
 
 	var dropOperations []*pb.DropOperation
 	{ // This is the code which sends out Backup requests and waits for them to finish.
-		resCh := make(chan BackupRes, len(state.Groups))
+		resCh := make(chan BackupRes, len(groups))
 		for _, gid := range groups {
 			br := proto.Clone(req).(*pb.BackupRequest)
 			br.GroupId = gid
@@ -255,6 +253,90 @@ func ProcessBackupRequest(ctx context.Context, req *pb.BackupRequest) error {
 	return nil
 }
 
+// groupPredicates returns the groups of the membership state, and the predicates of each group.
+func groupPredicates(state *pb.MembershipState) ([]uint32, map[uint32][]string) {
+	var groups []uint32
+	predMap := make(map[uint32][]string)
+	for gid, group := range state.Groups {
+		groups = append(groups, gid)
+		predMap[gid] = make([]string, 0)
+		for pred := range group.Tablets {
+			predMap[gid] = append(predMap[gid], pred)
+		}
+	}
+	return groups, predMap
+}
+
+// StreamBackupOverNetwork takes a full backup of the cluster, sending its files and its manifest
+// to send instead of writing them at the destination of the request. The manifest only lists
+// this backup, and the streamed files are laid out as in a backup destination.
+func StreamBackupOverNetwork(ctx context.Context, req *pb.BackupRequest,
+	send func(*pb.ExportChunk) error) error {
+	if err := x.HealthCheck(); err != nil {
+		glog.Errorf("Streamed backup canceled, not ready to accept requests: %s", err)
+		return err
+	}
+
+	backupLock.Lock()
+	defer backupLock.Unlock()
+
+	ts, err := Timestamps(ctx, &pb.Num{ReadOnly: true})
+	if err != nil {
+		glog.Errorf("Unable to retrieve readonly timestamp for backup: %s", err)
+		return err
+	}
+	req.ReadTs = ts.ReadOnly
+	req.UnixTs = time.Now().UTC().Format("20060102.150405.000")
+	// The previous backups are unknown to the Alpha, so the backup is always a full one.
+	req.SinceTs = 0
+	req.Destination = ""
+
+	if err := UpdateMembershipState(ctx); err != nil {
+		return err
+	}
+	groups, predMap := groupPredicates(GetMembershipState())
+	glog.Infof("Created streamed backup request: read_ts:%d unix_ts:%q. Groups=%v\n",
+		req.ReadTs, req.UnixTs, groups)
+
+	h := &streamHandler{send: send}
+	reqOf := func(gid uint32) *pb.StreamExportRequest {
+		br := proto.Clone(req).(*pb.BackupRequest)
+		br.GroupId = gid
+		br.Predicates = predMap[gid]
+		return &pb.StreamExportRequest{Backup: br}
+	}
+	dropOperations, err := streamGroups(ctx, groups, reqOf, h)
+	if err != nil {
+		glog.Errorf("Error received during streamed backup: %v", err)
+		return err
+	}
+
+	m := &Manifest{
+		Type:           "full",
+		BackupId:       x.GetRandomName(1),
+		BackupNum:      1,
+		ReadTs:         req.ReadTs,
+		Groups:         predMap,
+		Version:        x.ManifestVersion,
+		DropOperations: dropOperations,
+		Path:           fmt.Sprintf(backupPathFmt, req.UnixTs),
+		Compression:    "snappy",
+		Encrypted:      x.WorkerConfig.EncryptionKey != nil,
+	}
+	w, err := h.CreateFile(backupManifest)
+	if err != nil {
+		return err
+	}
+	if err := json.NewEncoder(w).Encode(&MasterManifest{Manifests: []*Manifest{m}}); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	glog.Infof("Streamed backup at %d completed OK.", req.ReadTs)
+	return nil
+}
+
 func ProcessListBackups(ctx context.Context, location string, creds *x.MinioCredentials) (
 	[]*Manifest, error) {
 
@@ -345,7 +427,7 @@ type LoadResult struct {
 	Err error
 }
 
-func createBackupFile(h x.UriHandler, uri *url.URL, req *pb.BackupRequest) (io.WriteCloser, error) {
+func createBackupFile(h x.UriHandler, req *pb.BackupRequest) (io.WriteCloser, error) {
 	if !h.DirExists("./") {
 		if err := h.CreateDir("./"); err != nil {
 			return nil, errors.Wrap(err, "while creating backup file")
@@ -377,7 +459,13 @@ func (pr *BackupProcessor) WriteBackup(ctx context.Context) (*pb.BackupResponse,
 	if err != nil {
 		return nil, err
 	}
-	w, err := createBackupFile(handler, uri, pr.Request)
+	return pr.writeBackup(ctx, handler)
+}
+
+// writeBackup writes the backup file with the handler.
+func (pr *BackupProcessor) writeBackup(ctx context.Context,
+	handler x.UriHandler) (*pb.BackupResponse, error) {
+	w, err := createBackupFile(handler, pr.Request)
 	if err != nil {
 		return nil, err
 	}
@@ -606,7 +694,7 @@ func (tl *threadLocal) toBackupList(key []byte, itr *badger.Iterator) (
 		return list, nil, nil
 	}
 
-	switch item.UserMeta() {
+	switch posting.PostingMeta(item.UserMeta()) {
 	case posting.BitEmptyPosting, posting.BitCompletePosting, posting.BitDeltaPosting:
 		l, err := posting.ReadPostingList(key, itr)
 		if err != nil {
@@ -34,11 +34,24 @@ func (w *grpcWorker) Backup(
 	return nil, x.ErrNotSupported
 }
 
+func backupCurrentGroup(ctx context.Context, req *pb.BackupRequest,
+	h x.UriHandler) (*pb.BackupResponse, error) {
+
+	return nil, x.ErrNotSupported
+}
+
 func ProcessBackupRequest(ctx context.Context, req *pb.BackupRequest) error {
 	glog.Warningf("Backup failed: %v", x.ErrNotSupported)
 	return x.ErrNotSupported
 }
 
+func StreamBackupOverNetwork(ctx context.Context, req *pb.BackupRequest,
+	send func(*pb.ExportChunk) error) error {
+
+	glog.Warningf("Backup failed: %v", x.ErrNotSupported)
+	return x.ErrNotSupported
+}
+
 func ProcessListBackups(ctx context.Context, location string, creds *x.MinioCredentials) (
 	[]*Manifest, error) {
 
@@ -20,13 +20,34 @@ package worker
 
 import (
 	"math"
+	"time"
 
 	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/pkg/errors"
 )
 
 type CDC struct {
 }
 
+type CDCStatus struct {
+	GroupId      uint32
+	IsLeader     bool
+	SentTs       uint64
+	SeenIndex    uint64
+	AppliedIndex uint64
+	PendingTxns  int
+	LastSentAt   time.Time
+	LastError    string
+}
+
+func (st *CDCStatus) LagEntries() uint64 {
+	return 0
+}
+
+func GetCDCStatus() (*CDCStatus, error) {
+	return nil, errors.New("CDC is an enterprise feature")
+}
+
 func newCDC() *CDC {
 	return nil
 }
@@ -14,7 +14,9 @@ package worker
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
 	"math"
 	"strconv"
 	"strings"
@@ -30,6 +32,8 @@ import (
 	"github.com/golang/glog"
 	"github.com/pkg/errors"
 	"go.etcd.io/etcd/raft/raftpb"
+	ostats "go.opencensus.io/stats"
+	"go.opencensus.io/tag"
 )
 
 const (
@@ -47,7 +51,9 @@ const (
 // order of their commit timestamp. So, this approach would be tricky to get right.
 type CDC struct {
 	sync.Mutex
-	sink             Sink
+	sink             Sink // nil if the events are only used for the views and the triggers.
+	views            bool
+	triggers         *triggers
 	closer           *z.Closer
 	pendingTxnEvents map[uint64][]CDCEvent
 
@@ -58,6 +64,57 @@ type CDC struct {
 	// sent them yet.
 	seenIndex uint64
 	sentTs    uint64 // max commit ts for which we have send the events.
+
+	lastSentAt int64  // Unix time in nanoseconds of the last successful send to the sink.
+	lastError  string // Error of the last send to the sink, empty if it succeeded.
+}
+
+// CDCStatus is the progress of sending the change events of this Alpha's group to the sink. Only
+// the leader of the group sends the events.
+type CDCStatus struct {
+	GroupId      uint32
+	IsLeader     bool
+	SentTs       uint64
+	SeenIndex    uint64
+	AppliedIndex uint64
+	PendingTxns  int
+	LastSentAt   time.Time
+	LastError    string
+}
+
+// LagEntries returns the number of Raft entries applied by this Alpha whose events are yet to be
+// read for sending.
+func (st *CDCStatus) LagEntries() uint64 {
+	if st.AppliedIndex <= st.SeenIndex {
+		return 0
+	}
+	return st.AppliedIndex - st.SeenIndex
+}
+
+// GetCDCStatus returns the progress of CDC on this Alpha, or an error if CDC isn't enabled.
+func GetCDCStatus() (*CDCStatus, error) {
+	n := groups().Node
+	if n == nil || n.cdcTracker == nil {
+		return nil, errors.New("CDC is not enabled on this node")
+	}
+	cdc := n.cdcTracker
+	cdc.Lock()
+	pending, lastError := len(cdc.pendingTxnEvents), cdc.lastError
+	cdc.Unlock()
+
+	st := &CDCStatus{
+		GroupId:      n.gid,
+		IsLeader:     n.AmLeader(),
+		SentTs:       atomic.LoadUint64(&cdc.sentTs),
+		SeenIndex:    atomic.LoadUint64(&cdc.seenIndex),
+		AppliedIndex: n.Applied.DoneUntil(),
+		PendingTxns:  pending,
+		LastError:    lastError,
+	}
+	if ns := atomic.LoadInt64(&cdc.lastSentAt); ns > 0 {
+		st.LastSentAt = time.Unix(0, ns)
+	}
+	return st, nil
 }
 
 func newCDC() *CDC {
@@ -66,13 +123,24 @@ func newCDC() *CDC {
 	}
 
 	cdcFlag := z.NewSuperFlag(Config.ChangeDataConf).MergeAndCheckDefault(CDCDefaults)
-	sink, err := GetSink(cdcFlag)
-	x.Check(err)
 	cdc := &CDC{
-		sink:             sink,
+		views:            cdcFlag.GetBool("views"),
 		closer:           z.NewCloser(1),
 		pendingTxnEvents: make(map[uint64][]CDCEvent),
 	}
+	if cdcFlag.GetBool("triggers") {
+		var err error
+		cdc.triggers, err = newTriggers(cdcFlag)
+		x.Check(err)
+	}
+	// With views or triggers enabled, the events can be read without being sent anywhere.
+	hasSink := cdcFlag.GetString("kafka") != "" || cdcFlag.GetPath("file") != "" ||
+		cdcFlag.GetString("dgraph") != ""
+	if (!cdc.views && cdc.triggers == nil) || hasSink {
+		sink, err := GetSink(cdcFlag)
+		x.Check(err)
+		cdc.sink = sink
+	}
 	return cdc
 }
 
@@ -183,6 +251,12 @@ func (cdc *CDC) Close() {
 	}
 	glog.Infof("closing CDC events...")
 	cdc.closer.SignalAndWait()
+	if err := cdc.triggers.close(); err != nil {
+		glog.Errorf("error while closing the trigger dead-letter queue %v", err)
+	}
+	if cdc.sink == nil {
+		return
+	}
 	err := cdc.sink.Close()
 	glog.Errorf("error while closing sink %v", err)
 }
@@ -193,6 +267,11 @@ func (cdc *CDC) processCDCEvents() {
 	}
 
 	sendToSink := func(pending []CDCEvent, commitTs uint64) error {
+		if cdc.sink == nil {
+			// Only the views use the events, so there's nothing to send.
+			atomic.StoreUint64(&cdc.sentTs, commitTs)
+			return nil
+		}
 		batch := make([]SinkMessage, len(pending))
 		for i, e := range pending {
 			e.Meta.CommitTs = commitTs
@@ -208,10 +287,17 @@ func (cdc *CDC) processCDCEvents() {
 		}
 		if err := cdc.sink.Send(batch); err != nil {
 			glog.Errorf("error while sending cdc event to sink %+v", err)
+			cdc.Lock()
+			cdc.lastError = err.Error()
+			cdc.Unlock()
 			return err
 		}
 		// We successfully sent messages to sink.
 		atomic.StoreUint64(&cdc.sentTs, commitTs)
+		atomic.StoreInt64(&cdc.lastSentAt, time.Now().UnixNano())
+		cdc.Lock()
+		cdc.lastError = ""
+		cdc.Unlock()
 		return nil
 	}
 
@@ -281,10 +367,20 @@ func (cdc *CDC) processCDCEvents() {
 				// This ensures we dont send events again in case of membership changes.
 				if ts.CommitTs > 0 && atomic.LoadUint64(&cdc.sentTs) < ts.CommitTs {
 					events := cdc.pendingTxnEvents[ts.StartTs]
+					// The triggers are fired before the events are sent, so that they are
+					// fired again if the sending fails.
+					err := cdc.triggers.fire(cdc.closer.Ctx(), events, ts.CommitTs)
+					if err != nil {
+						rerr = errors.Wrapf(err, "unable to fire the triggers")
+						return
+					}
 					if err := sendToSink(events, ts.CommitTs); err != nil {
 						rerr = errors.Wrapf(err, "unable to send messages to sink")
 						return
 					}
+					if cdc.views {
+						refreshViews(events, ts.CommitTs)
+					}
 				}
 				// Delete from pending events once events are sent.
 				cdc.removeFromPending(ts.StartTs)
@@ -340,6 +436,11 @@ func (cdc *CDC) processCDCEvents() {
 				if err := sendEvents(); err != nil {
 					glog.Errorf("unable to send events %+v", err)
 				}
+				if st, err := GetCDCStatus(); err == nil {
+					ctx, _ := tag.New(context.Background(),
+						tag.Upsert(x.KeyGroup, fmt.Sprintf("%d", st.GroupId)))
+					ostats.Record(ctx, x.CDCLagEntries.M(int64(st.LagEntries())))
+				}
 			}
 		case <-proposalTick.C:
 			// The leader would propose the max sentTs over to the group.
@@ -361,6 +462,33 @@ func (cdc *CDC) processCDCEvents() {
 	}
 }
 
+// refreshViews calls the view refresher with the uids touched by the committed events, grouped by
+// namespace. The views are refreshed on a best-effort basis, so that a failing view query doesn't
+// hold back the events.
+func refreshViews(events []CDCEvent, commitTs uint64) {
+	if viewRefresher == nil {
+		return
+	}
+	changed := make(map[uint64]map[string][]uint64)
+	for _, e := range events {
+		me, ok := e.Event.(*MutationEvent)
+		if !ok {
+			continue
+		}
+		ns := e.Meta.Namespace
+		if changed[ns] == nil {
+			changed[ns] = make(map[string][]uint64)
+		}
+		changed[ns][me.Attr] = append(changed[ns][me.Attr], me.Uid)
+		if uid, ok := me.Value.(uint64); ok && me.ValueType == types.UidID.Name() {
+			changed[ns][me.Attr] = append(changed[ns][me.Attr], uid)
+		}
+	}
+	for ns, preds := range changed {
+		viewRefresher(ns, commitTs, preds)
+	}
+}
+
 type CDCEvent struct {
 	Meta  *EventMeta  `json:"meta"`
 	Type  string      `json:"type"`
@@ -379,6 +507,7 @@ type MutationEvent struct {
 	Attr      string      `json:"attr"`
 	Value     interface{} `json:"value"`
 	ValueType string      `json:"value_type"`
+	Lang      string      `json:"lang,omitempty"`
 }
 
 type DropEvent struct {
@@ -387,12 +516,6 @@ type DropEvent struct {
 	Pred      string `json:"pred"`
 }
 
-const (
-	EventTypeDrop     = "drop"
-	EventTypeMutation = "mutation"
-	OpDropPred        = "predicate"
-)
-
 func toCDCEvent(index uint64, mutation *pb.Mutations) []CDCEvent {
 	// todo(Aman): we are skipping schema updates for now. Fix this later.
 	if len(mutation.Schema) > 0 || len(mutation.Types) > 0 {
@@ -487,6 +610,7 @@ func toCDCEvent(index uint64, mutation *pb.Mutations) []CDCEvent {
 				Attr:      attr,
 				Value:     val,
 				ValueType: posting.TypeID(edge).Name(),
+				Lang:      edge.Lang,
 			},
 		})
 	}
@@ -0,0 +1,101 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"encoding/json"
+	"sort"
+	"sync"
+
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/pkg/errors"
+)
+
+// ColocationPred is the predicate which stores the co-location hints of all the namespaces, given
+// via the @shard directive, in the galaxy namespace.
+const ColocationPred = "dgraph.colocation"
+
+// Colocation asks for Predicate to be served by the same group as SameAs.
+type Colocation struct {
+	Namespace uint64 `json:"namespace"`
+	Predicate string `json:"predicate"`
+	SameAs    string `json:"sameAs"`
+}
+
+// ParseColocations converts the stored co-location hints into a map from the namespaced predicate
+// to the namespaced predicate it must be co-located with.
+func ParseColocations(data []byte) (map[string]string, error) {
+	var list []Colocation
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &list); err != nil {
+			return nil, errors.Wrapf(err, "while unmarshalling co-location hints")
+		}
+	}
+	hints := make(map[string]string, len(list))
+	for _, c := range list {
+		hints[x.NamespaceAttr(c.Namespace, c.Predicate)] = x.NamespaceAttr(c.Namespace, c.SameAs)
+	}
+	return hints, nil
+}
+
+// MarshalColocations is the inverse of ParseColocations.
+func MarshalColocations(hints map[string]string) ([]byte, error) {
+	list := make([]Colocation, 0, len(hints))
+	for pred, sameAs := range hints {
+		ns, attr := x.ParseNamespaceAttr(pred)
+		list = append(list, Colocation{Namespace: ns, Predicate: attr, SameAs: x.ParseAttr(sameAs)})
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Namespace != list[j].Namespace {
+			return list[i].Namespace < list[j].Namespace
+		}
+		return list[i].Predicate < list[j].Predicate
+	})
+	return json.Marshal(list)
+}
+
+// colocations holds the co-location hints in use by this Alpha.
+var colocations = struct {
+	sync.RWMutex
+	hints map[string]string
+}{hints: make(map[string]string)}
+
+// SetColocations replaces the co-location hints in use by this Alpha.
+func SetColocations(hints map[string]string) {
+	colocations.Lock()
+	defer colocations.Unlock()
+	colocations.hints = hints
+}
+
+// Colocations returns a copy of the co-location hints in use by this Alpha.
+func Colocations() map[string]string {
+	colocations.RLock()
+	defer colocations.RUnlock()
+	hints := make(map[string]string, len(colocations.hints))
+	for pred, sameAs := range colocations.hints {
+		hints[pred] = sameAs
+	}
+	return hints
+}
+
+// colocatedWith returns the predicate that the given predicate must be co-located with, or an
+// empty string if there's none.
+func colocatedWith(pred string) string {
+	colocations.RLock()
+	defer colocations.RUnlock()
+	return colocations.hints[pred]
+}
@@ -0,0 +1,53 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/stretchr/testify/require"
+)
+
+func TestColocations(t *testing.T) {
+	hints := map[string]string{
+		x.GalaxyAttr("name"):       x.GalaxyAttr("friend"),
+		x.NamespaceAttr(3, "age"):  x.NamespaceAttr(3, "friend"),
+		x.NamespaceAttr(3, "city"): x.NamespaceAttr(3, "age"),
+	}
+	data, err := MarshalColocations(hints)
+	require.NoError(t, err)
+	require.JSONEq(t, `[
+		{"namespace": 0, "predicate": "name", "sameAs": "friend"},
+		{"namespace": 3, "predicate": "age", "sameAs": "friend"},
+		{"namespace": 3, "predicate": "city", "sameAs": "age"}
+	]`, string(data))
+
+	parsed, err := ParseColocations(data)
+	require.NoError(t, err)
+	require.Equal(t, hints, parsed)
+
+	parsed, err = ParseColocations(nil)
+	require.NoError(t, err)
+	require.Empty(t, parsed)
+
+	SetColocations(parsed)
+	require.Equal(t, "", colocatedWith(x.GalaxyAttr("name")))
+	SetColocations(hints)
+	require.Equal(t, x.GalaxyAttr("friend"), colocatedWith(x.GalaxyAttr("name")))
+	SetColocations(map[string]string{})
+}
@@ -21,6 +21,7 @@ import (
 	"time"
 
 	"github.com/dgraph-io/dgraph/x"
+	"github.com/dgraph-io/ristretto/z"
 )
 
 const (
@@ -49,6 +50,24 @@ type Options struct {
 	AccessJwtTtl time.Duration
 	// RefreshJwtTtl is the TTL of the refresh JWT.
 	RefreshJwtTtl time.Duration
+	// Oidc options:
+	//
+	// issuer string - issuer URL of the OpenID Connect provider, empty if OIDC login is disabled
+	// jwks-url string - URL of the provider's JSON Web Key Set, discovered if empty
+	// audience string - expected audience of the ID tokens
+	// user-claim string - claim of the ID token holding the user ID
+	// group-claim string - claim of the ID token holding the IdP groups
+	// group-map string - comma separated idp-group:dgraph-group pairs
+	// pass-through-groups bool - whether the IdP groups not in the group map are used as is
+	Oidc *z.SuperFlag
+	// PasswordPolicy options:
+	//
+	// min-length int - minimum length of the password of an ACL user
+	// require-upper, require-lower, require-digit, require-special bool - character classes
+	//   that the password of an ACL user must contain
+	// max-age duration - duration after which a password expires, 0 if they never expire
+	// hash string - scheme used to hash new passwords, bcrypt or argon2id
+	PasswordPolicy *z.SuperFlag
 
 	// CachePercentage is the comma-separated list of cache percentages
 	// used to split the total cache size among the multiple caches.
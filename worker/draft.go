@@ -79,6 +79,10 @@ type node struct {
 	ctx          context.Context
 	gid          uint32
 	closer       *z.Closer
+	// promoteLearner is set if Zero made this node a learner, to be promoted once caught up.
+	promoteLearner bool
+	// witness is set if this node votes in the elections of its group, but holds no data.
+	witness bool
 
 	checkpointTs uint64 // Timestamp corresponding to checkpoint.
 	streaming    int32  // Used to avoid calculating snapshot
@@ -95,6 +99,8 @@ type node struct {
 }
 
 // keysWritten is always accessed serially via applyCh. So, we don't need to make it thread-safe.
+// The only exception are the runs of mutations replayed concurrently, which call StillValid, so
+// its counters are updated atomically.
 type keysWritten struct {
 	rejectBeforeIndex uint64
 	keyCommitTs       map[uint64]uint64
@@ -125,11 +131,11 @@ func newKeysWritten() *keysWritten {
 //    serially by applyCh. This is to avoid edge cases.
 func (kw *keysWritten) StillValid(txn *posting.Txn) bool {
 	if txn.AppliedIndexSeen < kw.rejectBeforeIndex {
-		kw.invalidTxns++
+		atomic.AddInt64(&kw.invalidTxns, 1)
 		return false
 	}
 	if txn.MaxAssignedSeen >= txn.StartTs {
-		kw.validTxns++
+		atomic.AddInt64(&kw.validTxns, 1)
 		return true
 	}
 	for hash := range txn.ReadKeys() {
@@ -138,11 +144,11 @@ func (kw *keysWritten) StillValid(txn *posting.Txn) bool {
 		// MaxAssignedSeen, that means our reads are valid.
 		commitTs := kw.keyCommitTs[hash]
 		if commitTs > txn.MaxAssignedSeen && commitTs <= txn.StartTs {
-			kw.invalidTxns++
+			atomic.AddInt64(&kw.invalidTxns, 1)
 			return false
 		}
 	}
-	kw.validTxns++
+	atomic.AddInt64(&kw.validTxns, 1)
 	return true
 }
 
@@ -364,10 +370,11 @@ func newNode(store *raftwal.DiskStorage, gid uint32, id uint64, myAddr string) *
 		concApplyCh:  make(chan *pb.Proposal, 100),
 		drainApplyCh: make(chan struct{}),
 		elog:         trace.NewEventLog("Dgraph", "ApplyCh"),
-		closer:       z.NewCloser(4), // Matches CLOSER:1
+		closer:       z.NewCloser(5), // Matches CLOSER:1
 		ops:          make(map[op]operation),
 		cdcTracker:   newCDC(),
 		keysWritten:  newKeysWritten(),
+		witness:      x.WorkerConfig.Raft.GetBool("witness"),
 	}
 	return n
 }
@@ -398,7 +405,8 @@ func (n *node) applyConfChange(e raftpb.Entry) {
 	n.DoneConfChange(cc.ID, nil)
 }
 
-var errHasPendingTxns = errors.New("Pending transactions found. Please retry operation")
+var errHasPendingTxns = x.WithErrorCode(
+	errors.New("Pending transactions found. Please retry operation"), x.ErrorCodeRetryable)
 
 // We must not wait here. Previously, we used to block until we have aborted the
 // transactions. We're now applying all updates serially, so blocking for one
@@ -468,6 +476,9 @@ func (n *node) concMutations(ctx context.Context, m *pb.Mutations, txn *posting.
 		span.Annotatef(nil, "Txn %d should abort.", m.StartTs)
 		return x.ErrConflict
 	}
+	if err := checkMutationLimits(ctx, txn, m.Edges); err != nil {
+		return err
+	}
 	// Discard the posting lists from cache to release memory at the end.
 	defer func() {
 		txn.Update(ctx)
@@ -613,6 +624,22 @@ func (n *node) applyMutations(ctx context.Context, proposal *pb.Proposal) (rerr
 		return errors.New("StartTs must be provided")
 	}
 
+	if m := proposal.Mutations; m.Savepoint != "" || m.RollbackTo != "" {
+		txn := posting.Oracle().GetTxn(m.StartTs)
+		if txn == nil {
+			// The txn hasn't written to this group, there's nothing to mark or to roll back.
+			return nil
+		}
+		span.Annotatef(nil, "Savepoint: %q. Rollback to: %q", m.Savepoint, m.RollbackTo)
+		if m.RollbackTo != "" {
+			txn.RollbackTo(ctx, m.RollbackTo)
+		}
+		if m.Savepoint != "" {
+			txn.Savepoint(m.Savepoint)
+		}
+		return nil
+	}
+
 	if len(proposal.Mutations.Schema) > 0 || len(proposal.Mutations.Types) > 0 {
 		n.keysWritten.rejectBeforeIndex = proposal.Index
 
@@ -686,6 +713,15 @@ func (n *node) applyMutations(ctx context.Context, proposal *pb.Proposal) (rerr
 	defer func() {
 		ostats.Record(ctx, x.ActiveMutations.M(int64(-total)))
 	}()
+	if x.Config.PredicateMetrics {
+		edgesPerAttr := make(map[string]int64)
+		for _, edge := range proposal.Mutations.Edges {
+			edgesPerAttr[edge.Attr]++
+		}
+		for attr, cnt := range edgesPerAttr {
+			x.RecordPredicate(attr, x.PredicateMutations.M(cnt))
+		}
+	}
 
 	// Go through all the predicates and their first observed schema type. If we are unable to find
 	// these predicates in the current schema state, add them to the schema state. Note that the
@@ -727,14 +763,20 @@ func (n *node) applyMutations(ctx context.Context, proposal *pb.Proposal) (rerr
 
 func (n *node) applyCommitted(proposal *pb.Proposal) error {
 	key := proposal.Key
-	ctx := n.Ctx(key)
-	span := otrace.FromContext(ctx)
+	ctx, span := otrace.StartSpan(n.Ctx(key), "node.applyCommitted")
+	defer span.End()
+	span.AddAttributes(otrace.Int64Attribute("group", int64(n.gid)),
+		otrace.Int64Attribute("index", int64(proposal.Index)))
 	span.Annotatef(nil, "node.applyCommitted Node id: %d. Group id: %d. Got proposal key: %d",
 		n.Id, n.gid, key)
 	if x.Debug {
 		glog.Infof("applyCommitted: Proposal: %+v\n", proposal)
 	}
 
+	if n.witness && proposal.Snapshot == nil && proposal.State == nil {
+		return n.applyWitness(proposal)
+	}
+
 	if proposal.Mutations != nil {
 		// syncmarks for this shouldn't be marked done until it's committed.
 		span.Annotate(nil, "Applying mutations")
@@ -815,8 +857,9 @@ func (n *node) applyCommitted(proposal *pb.Proposal) error {
 			glog.Warningf("Error while calling CreateSnapshot: %v. Retrying...", err)
 		}
 		atomic.StoreInt64(&lastSnapshotTime, time.Now().Unix())
-		// We can now discard all invalid versions of keys below this ts.
-		pstore.SetDiscardTs(snap.ReadTs)
+		// We can now discard all invalid versions of keys below this ts, except the ones retained
+		// for time-travel queries.
+		pstore.SetDiscardTs(posting.Oracle().DiscardTs(snap.ReadTs))
 		return nil
 	case proposal.Restore != nil:
 		// Enable draining mode for the duration of the restore processing.
@@ -904,6 +947,42 @@ func (n *node) processApplyCh() {
 	}
 	previous := make(map[uint64]*P)
 
+	// applied records the outcome of applying the proposal.
+	applied := func(prop pb.Proposal, perr error, start time.Time) {
+		if prop.Key != 0 {
+			p := &P{err: perr, seen: time.Now()}
+			previous[prop.Key] = p
+		}
+		if perr != nil {
+			glog.Errorf("Applying proposal. Error: %v. Proposal: %q.", perr,
+				getSanitizedString(&prop))
+		}
+		n.elog.Printf("Applied proposal with key: %d, index: %d. Err: %v",
+			prop.Key, prop.Index, perr)
+
+		var tags []tag.Mutator
+		switch {
+		case prop.Mutations != nil:
+			if len(prop.Mutations.Schema) == 0 {
+				// Don't capture schema updates.
+				tags = append(tags, tag.Upsert(x.KeyMethod, "apply.Mutations"))
+			}
+		case prop.Delta != nil:
+			tags = append(tags, tag.Upsert(x.KeyMethod, "apply.Delta"))
+		}
+		ms := x.SinceMs(start)
+		if err := ostats.RecordWithTags(context.Background(),
+			tags, x.LatencyMs.M(ms)); err != nil {
+			glog.Errorf("Error recording stats: %+v", err)
+		}
+	}
+
+	done := func(prop pb.Proposal, perr error) {
+		n.Proposals.Done(prop.Key, perr)
+		n.Applied.Done(prop.Index)
+		ostats.Record(context.Background(), x.RaftAppliedIndex.M(int64(n.Applied.DoneUntil())))
+	}
+
 	// This function must be run serially.
 	handle := func(prop pb.Proposal) {
 		var perr error
@@ -929,37 +1008,73 @@ func (n *node) processApplyCh() {
 			// if this applyCommited fails, how do we ensure
 			start := time.Now()
 			perr = n.applyCommitted(&prop)
-			if prop.Key != 0 {
-				p := &P{err: perr, seen: time.Now()}
-				previous[prop.Key] = p
+			applied(prop, perr, start)
+		}
+		done(prop, perr)
+	}
+
+	// replayRun returns the longest run of proposals at the head of props which can be applied
+	// concurrently. While the WAL is replayed at startup, the mutations over disjoint predicates
+	// of distinct txns don't depend on each other, they only need their txns to be registered
+	// with the Oracle. The others are applied one by one by handle.
+	replayRun := func(props []pb.Proposal) []pb.Proposal {
+		attrs := make(map[string]struct{})
+		txns := make(map[uint64]struct{})
+		keys := make(map[uint64]struct{})
+		max := posting.Oracle().MaxAssigned()
+		for i, prop := range props {
+			m := prop.Mutations
+			if !replaying(prop.Index) || m == nil || len(m.Edges) == 0 ||
+				len(m.Schema) > 0 || len(m.Types) > 0 || m.DropOp != pb.Mutations_NONE ||
+				m.Savepoint != "" || m.RollbackTo != "" || prop.StartTs > max {
+				return props[:i]
 			}
-			if perr != nil {
-				glog.Errorf("Applying proposal. Error: %v. Proposal: %q.", perr,
-					getSanitizedString(&prop))
+			if prev, ok := previous[prop.Key]; ok && prev.err == nil {
+				return props[:i]
 			}
-			n.elog.Printf("Applied proposal with key: %d, index: %d. Err: %v",
-				prop.Key, prop.Index, perr)
-
-			var tags []tag.Mutator
-			switch {
-			case prop.Mutations != nil:
-				if len(prop.Mutations.Schema) == 0 {
-					// Don't capture schema updates.
-					tags = append(tags, tag.Upsert(x.KeyMethod, "apply.Mutations"))
+			if _, ok := keys[prop.Key]; ok {
+				return props[:i]
+			}
+			if _, ok := txns[prop.StartTs]; ok {
+				return props[:i]
+			}
+			propAttrs := make(map[string]struct{})
+			for _, edge := range m.Edges {
+				if _, ok := attrs[edge.Attr]; ok {
+					return props[:i]
+				}
+				if edge.Entity == 0 && bytes.Equal(edge.Value, []byte(x.Star)) {
+					return props[:i]
 				}
-			case prop.Delta != nil:
-				tags = append(tags, tag.Upsert(x.KeyMethod, "apply.Delta"))
+				propAttrs[edge.Attr] = struct{}{}
 			}
-			ms := x.SinceMs(start)
-			if err := ostats.RecordWithTags(context.Background(),
-				tags, x.LatencyMs.M(ms)); err != nil {
-				glog.Errorf("Error recording stats: %+v", err)
+			for attr := range propAttrs {
+				attrs[attr] = struct{}{}
 			}
+			keys[prop.Key] = struct{}{}
+			txns[prop.StartTs] = struct{}{}
 		}
+		return props
+	}
 
-		n.Proposals.Done(prop.Key, perr)
-		n.Applied.Done(prop.Index)
-		ostats.Record(context.Background(), x.RaftAppliedIndex.M(int64(n.Applied.DoneUntil())))
+	// handleRun applies the proposals of a run returned by replayRun concurrently.
+	handleRun := func(run []pb.Proposal) {
+		errs := make([]error, len(run))
+		starts := make([]time.Time, len(run))
+		var wg sync.WaitGroup
+		for i := range run {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				starts[i] = time.Now()
+				errs[i] = n.applyCommitted(&run[i])
+			}(i)
+		}
+		wg.Wait()
+		for i, prop := range run {
+			applied(prop, errs[i], starts[i])
+			done(prop, errs[i])
+		}
 	}
 
 	loopOverPending := func(maxAssigned uint64) {
@@ -1014,9 +1129,20 @@ func (n *node) processApplyCh() {
 				return
 			}
 			var totalSize int64
+			props := make([]pb.Proposal, 0, len(entries))
 			for _, e := range entries {
 				x.AssertTrue(len(e.Data) > 0)
-				p := getProposal(e)
+				props = append(props, getProposal(e))
+				totalSize += int64(e.Size())
+			}
+			for len(props) > 0 {
+				if run := replayRun(props); len(run) > 1 {
+					handleRun(run)
+					props = props[len(run):]
+					continue
+				}
+				p := props[0]
+				props = props[1:]
 				handle(p)
 
 				if p.Delta != nil && len(n.pendingProposals) > 0 {
@@ -1026,8 +1152,8 @@ func (n *node) processApplyCh() {
 						maxAssigned = max
 					}
 				}
-				totalSize += int64(e.Size())
 			}
+			checkReplayDone(n.Applied.DoneUntil())
 			if sz := atomic.AddInt64(&n.pendingSize, -totalSize); sz < 0 {
 				glog.Warningf("Pending size should remain above zero: %d", sz)
 			}
@@ -1076,11 +1202,28 @@ func (n *node) commitOrAbort(_ uint64, delta *pb.OracleDelta) error {
 	var itrs []y.Iterator
 	var txns []*posting.Txn
 	var sz int64
-	for _, status := range delta.Txns {
+	statuses := delta.Txns
+	var recounter *posting.Recounter
+	if x.Config.StrictCountIndex {
+		// The count indexes are recounted in the order of the commits.
+		recounter = posting.NewRecounter()
+		statuses = append([]*pb.TxnStatus{}, delta.Txns...)
+		sort.Slice(statuses, func(i, j int) bool {
+			return statuses[i].CommitTs < statuses[j].CommitTs
+		})
+	}
+	for _, status := range statuses {
 		txn := posting.Oracle().GetTxn(status.StartTs)
 		if txn == nil || status.CommitTs == 0 {
 			continue
 		}
+		if recounter != nil {
+			if err := recounter.Recount(txn, status.CommitTs); err != nil {
+				// The count indexes of the transaction are queued for repair instead.
+				glog.Errorf("Error while recounting the count indexes of txn %d,"+
+					" queued them for repair: %v", status.StartTs, err)
+			}
+		}
 		for k := range txn.Deltas() {
 			n.keysWritten.keyCommitTs[z.MemHashString(k)] = status.CommitTs
 		}
@@ -1201,6 +1344,12 @@ func (n *node) Snapshot() (*pb.Snapshot, error) {
 }
 
 func (n *node) retrieveSnapshot(snap pb.Snapshot) error {
+	if n.witness {
+		// A witness holds no data, it only needs the timestamps of the snapshot.
+		posting.Oracle().SetMaxAssigned(snap.MaxAssigned)
+		return nil
+	}
+
 	closer, err := n.startTask(opSnapshot)
 	if err != nil {
 		return err
@@ -1211,10 +1360,17 @@ func (n *node) retrieveSnapshot(snap pb.Snapshot) error {
 	// the status of Alpha leader. So, instead of blocking forever on waiting
 	// for Zero to send us the updates info about the leader, we can just use
 	// the Snapshot RaftContext, which contains the address of the leader.
+	// A witness leader holds no data, so the data is then streamed from a replica.
 	var pool *conn.Pool
 	addr := snap.Context.GetAddr()
 	glog.V(2).Infof("Snapshot.RaftContext.Addr: %q", addr)
-	if len(addr) > 0 {
+	if isWitness(snap.Context.GetId()) {
+		glog.V(2).Infof("Snapshot sent by witness %#x. Using a replica.", snap.Context.GetId())
+		pool = n.replicaPool()
+		if pool == nil {
+			return errors.Errorf("No replica of group %d to stream the snapshot from", n.gid)
+		}
+	} else if len(addr) > 0 {
 		p, err := conn.GetPools().Get(addr)
 		if err != nil {
 			glog.V(2).Infof("conn.Get(%q) Error: %v", addr, err)
@@ -1272,7 +1428,7 @@ func (n *node) proposeCDCState(ts uint64) error {
 	return n.Raft().Propose(n.ctx, data)
 }
 
-func (n *node) proposeSnapshot() error {
+func (n *node) proposeSnapshot() (*pb.Snapshot, error) {
 	lastIdx := x.Min(n.Applied.DoneUntil(), n.cdcTracker.getSeenIndex())
 	// We can't rely upon the Raft entries to determine the minPendingStart,
 	// because there are many cases during mutations where we don't commit or
@@ -1287,11 +1443,8 @@ func (n *node) proposeSnapshot() error {
 	// a maxCommitTs, which would become the readTs for the snapshot.
 	minPendingStart := x.Min(posting.Oracle().MinPendingStartTs(), n.cdcTracker.getTs())
 	snap, err := n.calculateSnapshot(0, lastIdx, minPendingStart)
-	if err != nil {
-		return err
-	}
-	if snap == nil {
-		return nil
+	if err != nil || snap == nil {
+		return nil, err
 	}
 	proposal := &pb.Proposal{
 		Snapshot: snap,
@@ -1301,7 +1454,39 @@ func (n *node) proposeSnapshot() error {
 	sz, err := proposal.MarshalToSizedBuffer(data[8:])
 	data = data[:8+sz]
 	x.Check(err)
-	return n.Raft().Propose(n.ctx, data)
+	return snap, n.Raft().Propose(n.ctx, data)
+}
+
+// CompactRaftLog takes a snapshot of the group served by this Alpha, and waits for it to be
+// applied. Applying the snapshot truncates the Raft log of every replica of the group, so this
+// reclaims the space used in the w directory. It returns nil if there was nothing to compact.
+func CompactRaftLog(ctx context.Context) (*pb.Snapshot, error) {
+	n := groups().Node
+	if n == nil || n.Raft() == nil {
+		return nil, conn.ErrNoNode
+	}
+	snap, err := n.proposeSnapshot()
+	if err != nil || snap == nil {
+		return nil, err
+	}
+
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		existing, err := n.Store.Snapshot()
+		if err != nil {
+			return nil, err
+		}
+		if existing.Metadata.Index >= snap.Index {
+			return snap, nil
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil, errors.Wrapf(ctx.Err(),
+				"while waiting for the snapshot at index %d to be applied", snap.Index)
+		}
+	}
 }
 
 const (
@@ -1389,7 +1574,9 @@ func (n *node) checkpointAndClose(done chan struct{}) {
 				glog.Errorf("While updating Raft progress: %v", err)
 			}
 
-			if n.AmLeader() {
+			// A witness leader is about to hand the leadership over, and can't tell which txns
+			// are pending, so it leaves the snapshots to the data replicas.
+			if n.AmLeader() && !n.witness {
 				// If leader doesn't have a snapshot, we should create one immediately. This is very
 				// useful when you bring up the cluster from bulk loader. If you remove an alpha and
 				// add a new alpha, the new follower won't get a snapshot if the leader doesn't have
@@ -1441,7 +1628,7 @@ func (n *node) checkpointAndClose(done chan struct{}) {
 					// We can set discardN argument to zero, because we already know that calculate
 					// would be true if either we absolutely needed to calculate the snapshot,
 					// or our checkpoint already crossed the SnapshotAfter threshold.
-					if err := n.proposeSnapshot(); err != nil {
+					if _, err := n.proposeSnapshot(); err != nil {
 						glog.Errorf("While calculating and proposing snapshot: %v", err)
 					} else {
 						atomic.StoreInt64(&lastSnapshotTime, time.Now().Unix())
@@ -1545,6 +1732,10 @@ func (n *node) Run() {
 				} else {
 					ostats.Record(ctx, x.RaftIsLeader.M(0))
 				}
+				if leader && n.witness {
+					// A witness can't serve the group, see witness.go.
+					go n.handOverLeadership()
+				}
 			}
 			if leader {
 				// Leader can send messages in parallel with writing to disk.
@@ -1788,8 +1979,9 @@ func listWrap(kv *bpb.KV) *bpb.KVList {
 
 // calculateTabletSizes updates the tablet sizes for the keys.
 func (n *node) calculateTabletSizes() {
-	if !n.AmLeader() {
-		// Only leader sends the tablet size updates to Zero. No one else does.
+	if !n.AmLeader() || n.witness {
+		// Only leader sends the tablet size updates to Zero. No one else does. A witness has no
+		// tablets to size.
 		return
 	}
 	var total int64
@@ -1841,6 +2033,9 @@ func (n *node) calculateTabletSizes() {
 		glog.V(2).Infof("No tablets found.")
 		return
 	}
+	for pred, tablet := range tablets {
+		x.RecordPredicate(pred, x.PredicateTabletSize.M(tablet.OnDiskBytes))
+	}
 	// Update Zero with the tablet sizes. If Zero sees a tablet which does not belong to
 	// this group, it would send instruction to delete that tablet. There's an edge case
 	// here if the followers are still running Rollup, and happen to read a key before and
@@ -1901,10 +2096,27 @@ func (n *node) blockingAbort(req *pb.TxnTimestamps) error {
 // while. The time that is used is based on the last pre-write seen, so if a txn is doing a
 // pre-write multiple times, we'll pick the timestamp of the last pre-write. Thus, this function
 // would only act on the txns which have not been active in the last N minutes, and send them for
-// abort. Note that only the leader runs this function.
+// abort. The txns which were started longer than --limit "txn-max-age" ago are aborted as well,
+// whether or not they have been active. Note that only the leader runs this function.
 func (n *node) abortOldTransactions() {
 	// Aborts if not already committed.
 	starts := posting.Oracle().TxnOlderThan(x.WorkerConfig.AbortOlderThan)
+	if maxAge := x.WorkerConfig.TxnMaxAge; maxAge > 0 {
+		tooOld := posting.Oracle().TxnStartedBefore(maxAge)
+		if len(tooOld) > 0 {
+			glog.Warningf("Found %d transactions started more than %s ago: %v. Aborting them.",
+				len(tooOld), maxAge, tooOld)
+		}
+		seen := make(map[uint64]bool, len(starts))
+		for _, ts := range starts {
+			seen[ts] = true
+		}
+		for _, ts := range tooOld {
+			if !seen[ts] {
+				starts = append(starts, ts)
+			}
+		}
+	}
 	if len(starts) == 0 {
 		return
 	}
@@ -2171,6 +2383,10 @@ func (n *node) InitAndStartNode() {
 				}
 			}
 		}
+		hs, err := n.Store.HardState()
+		x.Checkf(err, "Unable to get existing hardstate")
+		// The committed entries past the applied index are replayed from the WAL.
+		setReplayRange(n.Applied.DoneUntil()+1, hs.Commit)
 		n.SetRaft(raft.RestartNode(n.Cfg))
 		glog.V(2).Infoln("Restart node complete")
 
@@ -2193,10 +2409,14 @@ func (n *node) InitAndStartNode() {
 		}
 	}
 	go n.processTabletSizes()
+	go n.processPredicateStats()
 	go n.processApplyCh()
 	go n.BatchAndSendMessages()
 	go n.monitorRaftMetrics()
 	go n.cdcTracker.processCDCEvents()
+	if n.promoteLearner {
+		go n.promoteWhenCaughtUp()
+	}
 	// Ignoring the error since InitAndStartNode does not return an error and using x.Check would
 	// not be the right thing to do.
 	_, _ = n.startTask(opRollup)
@@ -2218,10 +2438,35 @@ func (n *node) AmLeader() bool {
 func (n *node) monitorRaftMetrics() {
 	ticker := time.NewTicker(5 * time.Second)
 	defer ticker.Stop()
+	ctx, _ := tag.New(n.ctx, tag.Upsert(x.KeyGroup, fmt.Sprintf("%d", n.gid)))
 	for range ticker.C {
 		curPendingSize := atomic.LoadInt64(&n.pendingSize)
 		ostats.Record(n.ctx, x.RaftPendingSize.M(curPendingSize))
 		ostats.Record(n.ctx, x.RaftApplyCh.M(int64(len(n.applyCh))))
+
+		first, err := n.Store.FirstIndex()
+		if err != nil {
+			glog.Errorf("While reading first index: %v", err)
+			continue
+		}
+		last, err := n.Store.LastIndex()
+		if err != nil {
+			glog.Errorf("While reading last index: %v", err)
+			continue
+		}
+		var entries, behind int64
+		if last >= first {
+			entries = int64(last - first + 1)
+		}
+		if commit, applied := n.Raft().Status().Commit, n.Applied.DoneUntil(); commit > applied {
+			behind = int64(commit - applied)
+		}
+		snapshotAge := time.Now().Unix() - atomic.LoadInt64(&lastSnapshotTime)
+		ostats.Record(ctx,
+			x.RaftLogEntries.M(entries),
+			x.RaftLogSize.M(n.Store.LogSize()),
+			x.RaftEntriesBehind.M(behind),
+			x.RaftSnapshotAge.M(snapshotAge))
 	}
 }
 
@@ -114,3 +114,22 @@ func TestCalculateSnapshot(t *testing.T) {
 	require.NoError(t, err)
 	require.Nil(t, snap)
 }
+
+func TestStartupReplay(t *testing.T) {
+	setReplayRange(11, 20)
+	require.True(t, replaying(15))
+	require.False(t, replaying(21))
+	p := GetStartupProgress()
+	require.Equal(t, uint64(10), p.ToReplay)
+	require.Contains(t, p.String(), "/10 Raft entries")
+
+	checkReplayDone(19)
+	require.True(t, replaying(20))
+	checkReplayDone(20)
+	require.False(t, replaying(20))
+	require.Zero(t, GetStartupProgress().ToReplay)
+
+	// A new node has nothing to replay.
+	setReplayRange(1, 0)
+	require.False(t, replaying(1))
+}
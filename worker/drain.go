@@ -0,0 +1,111 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/dgraph-io/dgraph/conn"
+	"github.com/dgraph-io/dgraph/posting"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/golang/glog"
+)
+
+// DrainStatus is the progress of draining this Alpha before stopping it.
+type DrainStatus struct {
+	// ReadyToStop is set once all the steps of the drain are done.
+	ReadyToStop bool `json:"readyToStop"`
+	// Step is the step the drain is waiting on, if it isn't ready to stop.
+	Step string `json:"step,omitempty"`
+	// PendingTxns is the number of transactions started on this Alpha and not yet committed or
+	// aborted.
+	PendingTxns int `json:"pendingTxns"`
+	// PendingProposals is the number of Raft proposals of this Alpha not yet applied.
+	PendingProposals int `json:"pendingProposals"`
+	// IsLeader tells whether this Alpha is still the leader of its group.
+	IsLeader bool `json:"isLeader"`
+}
+
+// Drain prepares this Alpha to be stopped, e.g. for a rolling upgrade. It enables the draining
+// mode, so that no new query or mutation is accepted, and then:
+//  1. waits for the pending transactions and Raft proposals to finish,
+//  2. transfers the leadership of the group to another replica, if this Alpha is the leader,
+//  3. writes the posting lists rolled up in memory to the postings store.
+//
+// It returns once all the steps are done, or once ctx is done, in which case the status tells
+// which step didn't finish. The draining mode stays enabled either way, until it is disabled
+// with x.UpdateDrainingMode.
+func Drain(ctx context.Context) (*DrainStatus, error) {
+	n := groups().Node
+	if n == nil || n.Raft() == nil {
+		return nil, conn.ErrNoNode
+	}
+	x.UpdateDrainingMode(true)
+	glog.Infof("Draining the Alpha")
+
+	st := &DrainStatus{}
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	// wait calls done until it returns true, or until ctx is done.
+	wait := func(step string, done func() bool) bool {
+		st.Step = step
+		for !done() {
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				glog.Warningf("Drain stopped while waiting for %s: %v", step, ctx.Err())
+				return false
+			}
+		}
+		return true
+	}
+
+	if !wait("pending transactions", func() bool {
+		st.PendingTxns = posting.Oracle().NumPendingTxns()
+		st.PendingProposals = n.Proposals.Len()
+		return st.PendingTxns == 0 && st.PendingProposals == 0
+	}) {
+		return st, nil
+	}
+
+	if n.AmLeader() {
+		if peerId, has := groups().MyPeer(); has {
+			glog.Infof("Transferring the leadership of group %d to %#x", n.gid, peerId)
+			n.Raft().TransferLeadership(ctx, n.Id, peerId)
+		}
+	}
+	if !wait("leadership transfer", func() bool {
+		// A group with a single replica keeps its leader.
+		_, hasPeer := groups().MyPeer()
+		st.IsLeader = n.AmLeader()
+		return !st.IsLeader || !hasPeer
+	}) {
+		return st, nil
+	}
+
+	st.Step = "rollups"
+	if err := posting.IncrRollup.Flush(ctx); err != nil {
+		glog.Warningf("Drain stopped while flushing the rollups: %v", err)
+		return st, nil
+	}
+
+	st.Step = ""
+	st.ReadyToStop = true
+	glog.Infof("The Alpha is drained and ready to stop")
+	return st, nil
+}
@@ -44,6 +44,7 @@ import (
 	"github.com/dgraph-io/dgraph/ee/enc"
 	"github.com/dgraph-io/dgraph/posting"
 	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/schema"
 	"github.com/dgraph-io/dgraph/types"
 	"github.com/dgraph-io/dgraph/types/facets"
 	"github.com/dgraph-io/dgraph/x"
@@ -69,6 +70,13 @@ var exportFormats = map[string]exportFormat{
 		pre:  "",
 		post: "",
 	},
+	// The graph formats are written by a graphWriter, once the posting lists are grouped by node.
+	"graphml": {
+		ext: ".graphml",
+	},
+	"graphson": {
+		ext: ".graphson",
+	},
 }
 
 type exporter struct {
@@ -77,6 +85,15 @@ type exporter struct {
 	attr      string
 	namespace uint64
 	readTs    uint64
+	ordered   bool
+}
+
+// iterate calls f with the postings of the list, the values of an ordered list in their order.
+func (e *exporter) iterate(f func(p *pb.Posting) error) error {
+	if e.ordered {
+		return e.pl.IterateValues(e.readTs, f)
+	}
+	return e.pl.IterateAll(e.readTs, 0, f)
 }
 
 // Map from our types to RDF type. Useful when writing storage types
@@ -90,7 +107,11 @@ var rdfTypeMap = map[types.TypeID]string{
 	types.BoolID:     "xs:boolean",
 	types.GeoID:      "geo:geojson",
 	types.BinaryID:   "xs:base64Binary",
+	types.BytesID:    "xs:base64Binary",
 	types.PasswordID: "xs:password",
+	types.BigIntID:   "xs:bigint",
+	types.DecimalID:  "xs:decimal",
+	types.DurationID: "xs:duration",
 }
 
 // UIDs like 0x1 look weird but 64-bit ones like 0x0000000000000001 are too long.
@@ -169,7 +190,7 @@ func (e *exporter) toJSON() (*bpb.KVList, error) {
 
 	continuing := false
 	mapStart := fmt.Sprintf("  {\"uid\":"+uidFmtStrJson+`,"namespace":"%#x"`, e.uid, e.namespace)
-	err := e.pl.IterateAll(e.readTs, 0, func(p *pb.Posting) error {
+	err := e.iterate(func(p *pb.Posting) error {
 		if continuing {
 			fmt.Fprint(bp, ",\n")
 		} else {
@@ -180,7 +201,7 @@ func (e *exporter) toJSON() (*bpb.KVList, error) {
 		if p.PostingType == pb.Posting_REF {
 			fmt.Fprintf(bp, `,"%s":[`, e.attr)
 			fmt.Fprintf(bp, "{\"uid\":"+uidFmtStrJson, p.Uid)
-			if err := writeFacets(p.Facets); err != nil {
+			if err := writeFacets(facets.PostingFacets(p)); err != nil {
 				return errors.Wrap(err, "While writing facets for posting_REF")
 			}
 			fmt.Fprint(bp, "}]")
@@ -206,7 +227,7 @@ func (e *exporter) toJSON() (*bpb.KVList, error) {
 			}
 
 			fmt.Fprint(bp, str)
-			if err := writeFacets(p.Facets); err != nil {
+			if err := writeFacets(facets.PostingFacets(p)); err != nil {
 				return errors.Wrap(err, "While writing facets for value postings")
 			}
 		}
@@ -226,12 +247,16 @@ func (e *exporter) toRDF() (*bpb.KVList, error) {
 	bp := new(bytes.Buffer)
 
 	prefix := fmt.Sprintf(uidFmtStrRdf+" <%s> ", e.uid, e.attr)
-	err := e.pl.IterateAll(e.readTs, 0, func(p *pb.Posting) error {
+	err := e.iterate(func(p *pb.Posting) error {
 		fmt.Fprint(bp, prefix)
 		if p.PostingType == pb.Posting_REF {
 			fmt.Fprintf(bp, uidFmtStrRdf, p.Uid)
 		} else {
 			val := types.Val{Tid: types.TypeID(p.ValType), Value: p.Value}
+			if val.Tid == types.BinaryID {
+				// Written as base64 like the bytes, as the loaders decode xs:base64Binary.
+				val.Tid = types.BytesID
+			}
 			str, err := valToStr(val)
 			if err != nil {
 				glog.Errorf("Ignoring error: %+v\n", err)
@@ -252,9 +277,9 @@ func (e *exporter) toRDF() (*bpb.KVList, error) {
 		fmt.Fprintf(bp, " <%#x>", e.namespace)
 
 		// Facets.
-		if len(p.Facets) != 0 {
+		if pfacets := facets.PostingFacets(p); len(pfacets) != 0 {
 			fmt.Fprint(bp, " (")
-			for i, fct := range p.Facets {
+			for i, fct := range pfacets {
 				if i != 0 {
 					fmt.Fprint(bp, ",")
 				}
@@ -304,7 +329,11 @@ func toSchema(attr string, update *pb.SchemaUpdate) *bpb.KV {
 	if update.GetList() {
 		x.Check2(buf.WriteRune('['))
 	}
-	x.Check2(buf.WriteString(types.TypeID(update.GetValueType()).Name()))
+	if update.GetCounter() {
+		x.Check2(buf.WriteString(schema.CounterType))
+	} else {
+		x.Check2(buf.WriteString(types.TypeID(update.GetValueType()).Name()))
+	}
 	if update.GetList() {
 		x.Check2(buf.WriteRune(']'))
 	}
@@ -317,12 +346,43 @@ func toSchema(attr string, update *pb.SchemaUpdate) *bpb.KV {
 	if update.GetCount() {
 		x.Check2(buf.WriteString(" @count"))
 	}
+	if update.GetPresence() {
+		x.Check2(buf.WriteString(" @presence"))
+	}
 	if update.GetLang() {
 		x.Check2(buf.WriteString(" @lang"))
 	}
 	if update.GetUpsert() {
 		x.Check2(buf.WriteString(" @upsert"))
 	}
+	if update.GetOwned() {
+		x.Check2(buf.WriteString(" @owned"))
+	}
+	if update.GetSequence() {
+		x.Check2(buf.WriteString(" @sequence"))
+	}
+	if update.GetOrdered() {
+		x.Check2(buf.WriteString(" @ordered"))
+	}
+	if len(update.GetValidators()) > 0 {
+		x.Check2(fmt.Fprintf(&buf, " @validate(%s)", strings.Join(update.GetValidators(), ",")))
+	}
+	if len(update.GetFacets()) > 0 {
+		fcs := make([]string, 0, len(update.GetFacets()))
+		for _, f := range update.GetFacets() {
+			fcs = append(fcs, facetTypeString(f))
+		}
+		x.Check2(fmt.Fprintf(&buf, " @facets(%s)", strings.Join(fcs, ", ")))
+	}
+	if len(update.GetFacetIndex()) > 0 {
+		x.Check2(fmt.Fprintf(&buf, " @facetindex(%s)", strings.Join(update.GetFacetIndex(), ",")))
+	}
+	if update.GetView() != "" {
+		x.Check2(fmt.Fprintf(&buf, " @view(%q)", update.GetView()))
+	}
+	if update.GetTrigger() != "" {
+		x.Check2(fmt.Fprintf(&buf, " @trigger(%q)", update.GetTrigger()))
+	}
 	x.Check2(buf.WriteString(" . \n"))
 	//TODO(Naman): We don't need the version anymore.
 	return &bpb.KV{
@@ -334,12 +394,24 @@ func toSchema(attr string, update *pb.SchemaUpdate) *bpb.KV {
 func toType(attr string, update pb.TypeUpdate) *bpb.KV {
 	var buf bytes.Buffer
 	ns, attr := x.ParseNamespaceAttr(attr)
-	x.Check2(buf.WriteString(fmt.Sprintf("[%#x] type <%s> {\n", ns, attr)))
+	x.Check2(buf.WriteString(fmt.Sprintf("[%#x] type <%s>", ns, attr)))
+	for i, name := range update.Extends {
+		sep := ","
+		if i == 0 {
+			sep = " extends"
+		}
+		x.Check2(fmt.Fprintf(&buf, "%s <%s>", sep, x.ParseAttr(name)))
+	}
+	x.Check2(buf.WriteString(" {\n"))
 	for _, field := range update.Fields {
 		x.Check2(buf.WriteString(fieldToString(field)))
 	}
 
-	x.Check2(buf.WriteString("}\n"))
+	x.Check2(buf.WriteString("}"))
+	if update.GetTrigger() != "" {
+		x.Check2(fmt.Fprintf(&buf, " @trigger(%q)", update.GetTrigger()))
+	}
+	x.Check2(buf.WriteString("\n"))
 
 	return &bpb.KV{
 		Value:   buf.Bytes(),
@@ -368,12 +440,15 @@ func fieldToString(update *pb.SchemaUpdate) string {
 type ExportWriter struct {
 	w             io.WriteCloser
 	bw            *bufio.Writer
+	aw            io.WriteCloser
 	gw            *gzip.Writer
 	relativePath  string
 	hasDataBefore bool
 }
 
-func newExportWriter(handler x.UriHandler, fileName string) (*ExportWriter, error) {
+// newExportWriter returns the writer of an export file. The file is encrypted for the age
+// recipient if one is given, and otherwise with the encryption key of the cluster if it's set.
+func newExportWriter(handler x.UriHandler, fileName, recipient string) (*ExportWriter, error) {
 	writer := &ExportWriter{relativePath: fileName}
 	var err error
 
@@ -382,7 +457,13 @@ func newExportWriter(handler x.UriHandler, fileName string) (*ExportWriter, erro
 		return nil, err
 	}
 	writer.bw = bufio.NewWriterSize(writer.w, 1e6)
-	ew, err := enc.GetWriter(x.WorkerConfig.EncryptionKey, writer.bw)
+	var ew io.Writer
+	if recipient != "" {
+		writer.aw, err = enc.NewAgeWriter(recipient, writer.bw)
+		ew = writer.aw
+	} else {
+		ew, err = enc.GetWriter(x.WorkerConfig.EncryptionKey, writer.bw)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -397,17 +478,20 @@ func (writer *ExportWriter) Close() error {
 	if writer == nil {
 		return nil
 	}
-	var err1, err2, err3 error
+	var err1, err2, err3, err4 error
 	if writer.gw != nil {
 		err1 = writer.gw.Close()
 	}
+	if writer.aw != nil {
+		err2 = writer.aw.Close()
+	}
 	if writer.bw != nil {
-		err2 = writer.bw.Flush()
+		err3 = writer.bw.Flush()
 	}
 	if writer.w != nil {
-		err3 = writer.w.Close()
+		err4 = writer.w.Close()
 	}
-	return x.MultiError(err1, err2, err3)
+	return x.MultiError(err1, err2, err3, err4)
 }
 
 // ExportedFiles has the relative path of files that were written during export
@@ -415,6 +499,13 @@ type ExportedFiles []string
 
 // export creates a export of data by exporting it as an RDF gzip.
 func export(ctx context.Context, in *pb.ExportRequest) (ExportedFiles, error) {
+	return exportTo(ctx, in, nil)
+}
+
+// exportTo exports the data of the group, creating the files with the handler, or at the
+// destination of the request if it's nil.
+func exportTo(ctx context.Context, in *pb.ExportRequest,
+	handler x.UriHandler) (ExportedFiles, error) {
 	if in.GroupId != groups().groupId() {
 		return nil, errors.Errorf("Export request group mismatch. Mine: %d. Requested: %d",
 			groups().groupId(), in.GroupId)
@@ -427,7 +518,7 @@ func export(ctx context.Context, in *pb.ExportRequest) (ExportedFiles, error) {
 	}
 	glog.Infof("Running export for group %d at timestamp %d.", in.GroupId, in.ReadTs)
 
-	return exportInternal(ctx, in, pstore, false)
+	return exportInternal(ctx, in, pstore, handler, false)
 }
 
 func ToExportKvList(pk x.ParsedKey, pl *posting.List, in *pb.ExportRequest) (*bpb.KVList, error) {
@@ -437,6 +528,7 @@ func ToExportKvList(pk x.ParsedKey, pl *posting.List, in *pb.ExportRequest) (*bp
 		namespace: x.ParseNamespace(pk.Attr),
 		attr:      x.ParseAttr(pk.Attr),
 		pl:        pl,
+		ordered:   schema.State().IsOrdered(pk.Attr),
 	}
 
 	emptyList := &bpb.KVList{}
@@ -445,6 +537,12 @@ func ToExportKvList(pk x.ParsedKey, pl *posting.List, in *pb.ExportRequest) (*bp
 	case e.attr == "dgraph.graphql.xid":
 	case e.attr == "dgraph.drop.op":
 	case e.attr == "dgraph.graphql.p_query":
+	case e.attr == "dgraph.runtime_config":
+	case e.attr == "dgraph.colocation":
+	case e.attr == "dgraph.external_id":
+	case e.attr == "dgraph.idempotency.key":
+	case e.attr == "dgraph.idempotency.record":
+	case e.attr == "dgraph.idempotency.expiry":
 
 	case pk.IsData() && e.attr == "dgraph.graphql.schema":
 		// Export the graphql schema.
@@ -514,6 +612,8 @@ func ToExportKvList(pk x.ParsedKey, pl *posting.List, in *pb.ExportRequest) (*bp
 			return e.toJSON()
 		case "rdf":
 			return e.toRDF()
+		case "graphml", "graphson":
+			return e.toGraph()
 		default:
 			glog.Fatalf("Invalid export format found: %s", in.Format)
 		}
@@ -538,6 +638,8 @@ func WriteExport(writers *Writers, kv *bpb.KV, format string) error {
 	case "rdf":
 		// The separator for RDF should be empty since the toRDF function already
 		// adds newline to each RDF entry.
+	case "graphml", "graphson":
+		// The data of the graph formats is written by a graphWriter instead.
 	default:
 		glog.Fatalf("Invalid export format found: %s", format)
 	}
@@ -599,7 +701,11 @@ func NewWriters(req *pb.ExportRequest) (*Writers, error) {
 	if err != nil {
 		return nil, err
 	}
+	return newWriters(handler, req)
+}
 
+// newWriters returns the writers of the export files of the request, created by the handler.
+func newWriters(handler x.UriHandler, req *pb.ExportRequest) (*Writers, error) {
 	// Create the export directory.
 	if !handler.DirExists(".") {
 		if err := handler.CreateDir("."); err != nil {
@@ -613,10 +719,14 @@ func NewWriters(req *pb.ExportRequest) (*Writers, error) {
 	}
 
 	// Create writers for each export file.
+	var err error
 	writers := &Writers{}
 	newWriter := func(ext string) (*ExportWriter, error) {
 		fileName := filepath.Join(dirName, fmt.Sprintf("g%02d%s", req.GroupId, ext))
-		return newExportWriter(handler, fileName)
+		if req.Recipient != "" {
+			fileName += ".age"
+		}
+		return newExportWriter(handler, fileName, req.Recipient)
 	}
 	if writers.DataWriter, err = newWriter(exportFormats[req.Format].ext + ".gz"); err != nil {
 		return writers, err
@@ -650,10 +760,17 @@ func (w *Writers) Close() error {
 // false, the parts of this method that require to talk to zero will be skipped. This is useful
 // when exporting a p directory directly from disk without a running cluster.
 // It uses stream framework to export the data. While it uses an iterator for exporting the schema
-// and types.
+// and types. The files are created by the handler, or at the destination of the request if it's
+// nil.
 func exportInternal(ctx context.Context, in *pb.ExportRequest, db *badger.DB,
-	skipZero bool) (ExportedFiles, error) {
-	writers, err := NewWriters(in)
+	handler x.UriHandler, skipZero bool) (ExportedFiles, error) {
+	var writers *Writers
+	var err error
+	if handler != nil {
+		writers, err = newWriters(handler, in)
+	} else {
+		writers, err = NewWriters(in)
+	}
 	defer writers.Close()
 	if err != nil {
 		return nil, err
@@ -714,6 +831,16 @@ func exportInternal(ctx context.Context, in *pb.ExportRequest, db *badger.DB,
 		return ToExportKvList(pk, pl, in)
 	}
 
+	// The graph formats write each node along with all its predicates, so the data is grouped by
+	// node before being written.
+	var graph *graphExport
+	if isGraphExportFormat(in.Format) {
+		if graph, err = newGraphExport(); err != nil {
+			return nil, err
+		}
+		defer graph.close()
+	}
+
 	stream.Send = func(buf *z.Buffer) error {
 		kv := &bpb.KV{}
 		return buf.SliceIterate(func(s []byte) error {
@@ -721,6 +848,9 @@ func exportInternal(ctx context.Context, in *pb.ExportRequest, db *badger.DB,
 			if err := kv.Unmarshal(s); err != nil {
 				return err
 			}
+			if graph != nil && kv.Version == 1 {
+				return graph.set(kv)
+			}
 			return WriteExport(writers, kv, in.Format)
 		})
 	}
@@ -794,6 +924,11 @@ func exportInternal(ctx context.Context, in *pb.ExportRequest, db *badger.DB,
 	if err := stream.Orchestrate(ctx); err != nil {
 		return nil, err
 	}
+	if graph != nil {
+		if err := graph.write(writers.DataWriter.gw, in.Format); err != nil {
+			return nil, errors.Wrap(err, "while writing the graph export")
+		}
+	}
 	if _, err = writers.DataWriter.gw.Write([]byte(xfmt.post)); err != nil {
 		return nil, err
 	}
@@ -883,6 +1018,25 @@ func handleExportOverNetwork(ctx context.Context, in *pb.ExportRequest) (Exporte
 	return nil, err
 }
 
+// groupExportRequest returns the request exporting the group at readTs.
+func groupExportRequest(input *pb.ExportRequest, group uint32, readTs uint64) *pb.ExportRequest {
+	return &pb.ExportRequest{
+		GroupId:   group,
+		ReadTs:    readTs,
+		UnixTs:    time.Now().Unix(),
+		Format:    input.Format,
+		Namespace: input.Namespace,
+
+		Destination:  input.Destination,
+		AccessKey:    input.AccessKey,
+		SecretKey:    input.SecretKey,
+		SessionToken: input.SessionToken,
+		Anonymous:    input.Anonymous,
+
+		Recipient: input.Recipient,
+	}
+}
+
 // ExportOverNetwork sends export requests to all the known groups.
 func ExportOverNetwork(ctx context.Context, input *pb.ExportRequest) (ExportedFiles, error) {
 	// If we haven't even had a single membership update, don't run export.
@@ -910,20 +1064,7 @@ func ExportOverNetwork(ctx context.Context, input *pb.ExportRequest) (ExportedFi
 	ch := make(chan filesAndError, len(gids))
 	for _, gid := range gids {
 		go func(group uint32) {
-			req := &pb.ExportRequest{
-				GroupId:   group,
-				ReadTs:    readTs,
-				UnixTs:    time.Now().Unix(),
-				Format:    input.Format,
-				Namespace: input.Namespace,
-
-				Destination:  input.Destination,
-				AccessKey:    input.AccessKey,
-				SecretKey:    input.SecretKey,
-				SessionToken: input.SessionToken,
-				Anonymous:    input.Anonymous,
-			}
-			files, err := handleExportOverNetwork(ctx, req)
+			files, err := handleExportOverNetwork(ctx, groupExportRequest(input, group, readTs))
 			ch <- filesAndError{files, err}
 		}(gid)
 	}
@@ -0,0 +1,473 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+
+	"github.com/dgraph-io/badger/v3"
+	bpb "github.com/dgraph-io/badger/v3/pb"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+
+	"github.com/dgraph-io/dgo/v210/protos/api"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/types"
+	"github.com/dgraph-io/dgraph/types/facets"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// isGraphExportFormat returns true for the formats of property graph databases, which write each
+// node along with all its predicates. As the export streams the posting lists predicate by
+// predicate, they are first grouped by node in a temporary Badger DB.
+func isGraphExportFormat(format string) bool {
+	return format == "graphml" || format == "graphson"
+}
+
+// graphKey returns the key of the posting list of attr for the node uid, which sorts the posting
+// lists by node.
+func graphKey(ns, uid uint64, attr string) []byte {
+	key := make([]byte, 16+len(attr))
+	binary.BigEndian.PutUint64(key, ns)
+	binary.BigEndian.PutUint64(key[8:], uid)
+	copy(key[16:], attr)
+	return key
+}
+
+func graphNodeID(ns, uid uint64) string {
+	if ns == x.GalaxyNamespace {
+		return fmt.Sprintf("%#x", uid)
+	}
+	return fmt.Sprintf("%#x-%#x", ns, uid)
+}
+
+// toGraph returns the postings of the posting list, to be grouped by node.
+func (e *exporter) toGraph() (*bpb.KVList, error) {
+	var pl pb.PostingList
+	err := e.pl.IterateAll(e.readTs, 0, func(p *pb.Posting) error {
+		// The postings without values are reused by the iteration.
+		cp := *p
+		pl.Postings = append(pl.Postings, &cp)
+		return nil
+	})
+	if err != nil || len(pl.Postings) == 0 {
+		return &bpb.KVList{}, err
+	}
+	val, err := pl.Marshal()
+	if err != nil {
+		return &bpb.KVList{}, err
+	}
+	kv := &bpb.KV{
+		Key:     graphKey(e.namespace, e.uid, e.attr),
+		Value:   val,
+		Version: 1,
+	}
+	return listWrap(kv), nil
+}
+
+type graphPred struct {
+	attr     string
+	postings []*pb.Posting
+}
+
+type graphNode struct {
+	ns    uint64
+	id    string
+	preds []*graphPred
+}
+
+// graphWriter writes the nodes in a graph format. scan is called with all the nodes before any
+// of them is written, so that the header can declare what the nodes hold.
+type graphWriter interface {
+	scan(n *graphNode)
+	header(w io.Writer) error
+	node(w io.Writer, n *graphNode) error
+	footer(w io.Writer) error
+}
+
+// graphExport groups the posting lists by node in a temporary Badger DB.
+type graphExport struct {
+	dir string
+	db  *badger.DB
+	wb  *badger.WriteBatch
+}
+
+func newGraphExport() (*graphExport, error) {
+	dir, err := ioutil.TempDir(x.WorkerConfig.TmpDir, "export-graph")
+	if err != nil {
+		return nil, errors.Wrap(err, "while creating directory for the graph export")
+	}
+	db, err := badger.Open(badger.DefaultOptions(dir).
+		WithSyncWrites(false).
+		WithLogger(nil))
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, errors.Wrap(err, "while opening DB for the graph export")
+	}
+	return &graphExport{dir: dir, db: db, wb: db.NewWriteBatch()}, nil
+}
+
+func (g *graphExport) set(kv *bpb.KV) error {
+	return g.wb.Set(append([]byte{}, kv.Key...), append([]byte{}, kv.Value...))
+}
+
+// iterate calls fn with each node, in the order of their uids.
+func (g *graphExport) iterate(fn func(n *graphNode) error) error {
+	txn := g.db.NewTransaction(false)
+	defer txn.Discard()
+	itr := txn.NewIterator(badger.DefaultIteratorOptions)
+	defer itr.Close()
+
+	var prefix []byte
+	var n *graphNode
+	for itr.Rewind(); itr.Valid(); itr.Next() {
+		item := itr.Item()
+		key := item.Key()
+		if n != nil && !bytes.Equal(key[:16], prefix) {
+			if err := fn(n); err != nil {
+				return err
+			}
+			n = nil
+		}
+		if n == nil {
+			prefix = append(prefix[:0], key[:16]...)
+			ns := binary.BigEndian.Uint64(key)
+			n = &graphNode{ns: ns, id: graphNodeID(ns, binary.BigEndian.Uint64(key[8:]))}
+		}
+		var pl pb.PostingList
+		err := item.Value(func(val []byte) error {
+			return pl.Unmarshal(val)
+		})
+		if err != nil {
+			return err
+		}
+		n.preds = append(n.preds, &graphPred{attr: string(key[16:]), postings: pl.Postings})
+	}
+	if n != nil {
+		return fn(n)
+	}
+	return nil
+}
+
+// write writes all the nodes to w in the given format.
+func (g *graphExport) write(w io.Writer, format string) error {
+	if err := g.wb.Flush(); err != nil {
+		return err
+	}
+	var gw graphWriter
+	switch format {
+	case "graphml":
+		gw = newGraphMLWriter()
+	case "graphson":
+		gw = &graphsonWriter{}
+	default:
+		glog.Fatalf("Invalid graph export format found: %s", format)
+	}
+
+	if err := g.iterate(func(n *graphNode) error {
+		gw.scan(n)
+		return nil
+	}); err != nil {
+		return err
+	}
+	if err := gw.header(w); err != nil {
+		return err
+	}
+	if err := g.iterate(func(n *graphNode) error {
+		return gw.node(w, n)
+	}); err != nil {
+		return err
+	}
+	return gw.footer(w)
+}
+
+func (g *graphExport) close() {
+	g.wb.Cancel()
+	if err := g.db.Close(); err != nil {
+		glog.Warningf("Error while closing the DB of the graph export: %v", err)
+	}
+	if err := os.RemoveAll(g.dir); err != nil {
+		glog.Warningf("Error while removing %s: %v", g.dir, err)
+	}
+}
+
+// graphValue returns the name of the property holding the value, and the value as a string.
+func graphValue(attr string, p *pb.Posting) (string, types.TypeID, string, error) {
+	name := attr
+	if p.PostingType == pb.Posting_VALUE_LANG {
+		name += "@" + string(p.LangTag)
+	}
+	tid := types.TypeID(p.ValType)
+	str, err := valToStr(types.Val{Tid: tid, Value: p.Value})
+	return name, tid, str, err
+}
+
+// graphFacet returns the value of the facet as a string, along with its type.
+func graphFacet(fct *api.Facet) (types.TypeID, string, error) {
+	str, err := facetToString(fct)
+	if err != nil {
+		return 0, "", err
+	}
+	tid, err := facets.TypeIDFor(fct)
+	return tid, str, err
+}
+
+// graphLabel returns the dgraph.type of the node, if any.
+func graphLabel(n *graphNode) string {
+	for _, pred := range n.preds {
+		if pred.attr != "dgraph.type" {
+			continue
+		}
+		for _, p := range pred.postings {
+			if _, _, str, err := graphValue(pred.attr, p); err == nil {
+				return str
+			}
+		}
+	}
+	return ""
+}
+
+// graphmlWriter writes GraphML, with the values of the nodes as node data, and the predicate and
+// facets of the edges as edge data. The facets of the values aren't exported.
+type graphmlWriter struct {
+	nodeKeys map[string]string
+	edgeKeys map[string]string
+	// ids maps the names of the node and edge keys to their ids.
+	nodeIds map[string]string
+	edgeIds map[string]string
+}
+
+func newGraphMLWriter() *graphmlWriter {
+	return &graphmlWriter{
+		nodeKeys: make(map[string]string),
+		edgeKeys: map[string]string{"label": "string"},
+		nodeIds:  make(map[string]string),
+		edgeIds:  make(map[string]string),
+	}
+}
+
+func graphmlType(tid types.TypeID) string {
+	switch tid {
+	case types.IntID:
+		return "long"
+	case types.FloatID:
+		return "double"
+	case types.BoolID:
+		return "boolean"
+	default:
+		return "string"
+	}
+}
+
+func xmlEscape(s string) string {
+	var buf bytes.Buffer
+	x.Check(xml.EscapeText(&buf, []byte(s)))
+	return buf.String()
+}
+
+func (gw *graphmlWriter) scan(n *graphNode) {
+	// Keys holding values of different types are declared as strings.
+	addKey := func(keys map[string]string, name, typ string) {
+		if prev, ok := keys[name]; ok && prev != typ {
+			typ = "string"
+		}
+		keys[name] = typ
+	}
+	for _, pred := range n.preds {
+		for _, p := range pred.postings {
+			if p.PostingType != pb.Posting_REF {
+				name, tid, _, _ := graphValue(pred.attr, p)
+				addKey(gw.nodeKeys, name, graphmlType(tid))
+				continue
+			}
+			for _, fct := range p.Facets {
+				tid, err := facets.TypeIDFor(fct)
+				if err != nil {
+					continue
+				}
+				addKey(gw.edgeKeys, fct.Key, graphmlType(tid))
+			}
+		}
+	}
+}
+
+func (gw *graphmlWriter) header(w io.Writer) error {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	buf.WriteString("<graphml xmlns=\"http://graphml.graphdrawing.org/xmlns\">\n")
+	declare := func(keys, ids map[string]string, kind string) {
+		names := make([]string, 0, len(keys))
+		for name := range keys {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			id := fmt.Sprintf("d%d", len(gw.nodeIds)+len(gw.edgeIds))
+			ids[name] = id
+			fmt.Fprintf(&buf, "  <key id=\"%s\" for=\"%s\" attr.name=\"%s\" attr.type=\"%s\"/>\n",
+				id, kind, xmlEscape(name), keys[name])
+		}
+	}
+	declare(gw.nodeKeys, gw.nodeIds, "node")
+	declare(gw.edgeKeys, gw.edgeIds, "edge")
+	buf.WriteString("  <graph id=\"G\" edgedefault=\"directed\">\n")
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+func (gw *graphmlWriter) node(w io.Writer, n *graphNode) error {
+	var nodeBuf, edgeBuf bytes.Buffer
+	fmt.Fprintf(&nodeBuf, "    <node id=\"%s\">\n", n.id)
+	for _, pred := range n.preds {
+		for _, p := range pred.postings {
+			if p.PostingType == pb.Posting_REF {
+				fmt.Fprintf(&edgeBuf, "    <edge source=\"%s\" target=\"%s\">\n", n.id,
+					graphNodeID(n.ns, p.Uid))
+				fmt.Fprintf(&edgeBuf, "      <data key=\"%s\">%s</data>\n", gw.edgeIds["label"],
+					xmlEscape(pred.attr))
+				for _, fct := range p.Facets {
+					_, str, err := graphFacet(fct)
+					if err != nil {
+						glog.Errorf("Ignoring error: %+v", err)
+						continue
+					}
+					fmt.Fprintf(&edgeBuf, "      <data key=\"%s\">%s</data>\n",
+						gw.edgeIds[fct.Key], xmlEscape(str))
+				}
+				edgeBuf.WriteString("    </edge>\n")
+				continue
+			}
+			name, _, str, err := graphValue(pred.attr, p)
+			if err != nil {
+				glog.Errorf("Ignoring error: %+v", err)
+				continue
+			}
+			fmt.Fprintf(&nodeBuf, "      <data key=\"%s\">%s</data>\n", gw.nodeIds[name],
+				xmlEscape(str))
+		}
+	}
+	nodeBuf.WriteString("    </node>\n")
+	if _, err := w.Write(nodeBuf.Bytes()); err != nil {
+		return err
+	}
+	_, err := w.Write(edgeBuf.Bytes())
+	return err
+}
+
+func (gw *graphmlWriter) footer(w io.Writer) error {
+	_, err := io.WriteString(w, "  </graph>\n</graphml>\n")
+	return err
+}
+
+// graphsonWriter writes the GraphSON adjacency list format of TinkerPop, with a vertex per line.
+// The dgraph.type of the nodes is used as their label. The facets of the values aren't exported,
+// and neither are the in edges of the vertices, which TinkerPop doesn't need to read them.
+type graphsonWriter struct{}
+
+type graphsonVertex struct {
+	ID         string                         `json:"id"`
+	Label      string                         `json:"label"`
+	Properties map[string][]*graphsonProperty `json:"properties,omitempty"`
+	OutE       map[string][]*graphsonEdge     `json:"outE,omitempty"`
+}
+
+type graphsonProperty struct {
+	ID    string      `json:"id"`
+	Value interface{} `json:"value"`
+}
+
+type graphsonEdge struct {
+	ID         string                 `json:"id"`
+	InV        string                 `json:"inV"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+}
+
+// graphsonValue returns the JSON value of a value of the given type.
+func graphsonValue(tid types.TypeID, str string) interface{} {
+	if tid.IsNumber() || tid == types.BoolID {
+		return json.RawMessage(str)
+	}
+	return str
+}
+
+func (*graphsonWriter) scan(n *graphNode) {}
+
+func (*graphsonWriter) header(w io.Writer) error {
+	return nil
+}
+
+func (*graphsonWriter) node(w io.Writer, n *graphNode) error {
+	v := &graphsonVertex{
+		ID:         n.id,
+		Label:      graphLabel(n),
+		Properties: make(map[string][]*graphsonProperty),
+		OutE:       make(map[string][]*graphsonEdge),
+	}
+	if v.Label == "" {
+		v.Label = "vertex"
+	}
+	for _, pred := range n.preds {
+		for _, p := range pred.postings {
+			if p.PostingType == pb.Posting_REF {
+				inV := graphNodeID(n.ns, p.Uid)
+				edge := &graphsonEdge{ID: n.id + "|" + pred.attr + "|" + inV, InV: inV}
+				for _, fct := range p.Facets {
+					tid, str, err := graphFacet(fct)
+					if err != nil {
+						glog.Errorf("Ignoring error: %+v", err)
+						continue
+					}
+					if edge.Properties == nil {
+						edge.Properties = make(map[string]interface{})
+					}
+					edge.Properties[fct.Key] = graphsonValue(tid, str)
+				}
+				v.OutE[pred.attr] = append(v.OutE[pred.attr], edge)
+				continue
+			}
+			name, tid, str, err := graphValue(pred.attr, p)
+			if err != nil {
+				glog.Errorf("Ignoring error: %+v", err)
+				continue
+			}
+			prop := &graphsonProperty{
+				ID:    fmt.Sprintf("%s|%s|%d", n.id, name, len(v.Properties[name])),
+				Value: graphsonValue(tid, str),
+			}
+			v.Properties[name] = append(v.Properties[name], prop)
+		}
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(b, '\n'))
+	return err
+}
+
+func (*graphsonWriter) footer(w io.Writer) error {
+	return nil
+}
@@ -0,0 +1,254 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"github.com/dgraph-io/dgraph/conn"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+)
+
+// A streamed export or backup sends its files to the caller of the Alpha.StreamExport rpc instead
+// of writing them at a destination, so that the Alphas don't need to reach an object store. The
+// Alpha serving the rpc asks a server of each group to stream the files of its group with the
+// Worker.StreamExport rpc, and forwards their chunks to the caller.
+
+// streamChunkSize is the maximum size of the data of a chunk of a streamed file.
+const streamChunkSize = 1 << 20
+
+var errStreamHandler = errors.New("the files of a streamed export can't be read back")
+
+// streamHandler is the x.UriHandler of the files of a streamed export or backup. The files are
+// sent by chunks carrying their path, the directories being implied by the paths.
+type streamHandler struct {
+	sync.Mutex
+	send func(*pb.ExportChunk) error
+}
+
+// Send sends the chunk on the stream, which is shared by the files of all the groups.
+func (h *streamHandler) Send(c *pb.ExportChunk) error {
+	h.Lock()
+	defer h.Unlock()
+	return h.send(c)
+}
+
+func (h *streamHandler) CreateDir(path string) error {
+	return nil
+}
+
+func (h *streamHandler) CreateFile(path string) (io.WriteCloser, error) {
+	return &streamFile{h: h, path: path}, nil
+}
+
+func (h *streamHandler) DirExists(path string) bool {
+	return true
+}
+
+func (h *streamHandler) FileExists(path string) bool {
+	return false
+}
+
+func (h *streamHandler) JoinPath(path string) string {
+	return path
+}
+
+func (h *streamHandler) ListPaths(path string) []string {
+	return nil
+}
+
+func (h *streamHandler) Read(path string) ([]byte, error) {
+	return nil, errStreamHandler
+}
+
+func (h *streamHandler) Rename(src, dst string) error {
+	return errStreamHandler
+}
+
+func (h *streamHandler) Stream(path string) (io.ReadCloser, error) {
+	return nil, errStreamHandler
+}
+
+type streamFile struct {
+	h    *streamHandler
+	path string
+}
+
+func (f *streamFile) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		size := len(p)
+		if size > streamChunkSize {
+			size = streamChunkSize
+		}
+		// The data is copied, as the message may still be in use by gRPC once Send returns.
+		c := &pb.ExportChunk{Path: f.path, Data: append([]byte(nil), p[:size]...)}
+		if err := f.h.Send(c); err != nil {
+			return n - len(p), err
+		}
+		p = p[size:]
+	}
+	return n, nil
+}
+
+// Close sends the last chunk of the file, telling the caller that the file is complete.
+func (f *streamFile) Close() error {
+	return f.h.Send(&pb.ExportChunk{Path: f.path, Last: true})
+}
+
+// streamCurrentGroup exports or backs up the data of the group of this server with the stream
+// handler, and returns the drop operations of a backup.
+func streamCurrentGroup(ctx context.Context, req *pb.StreamExportRequest,
+	h *streamHandler) ([]*pb.DropOperation, error) {
+	switch {
+	case req.GetExport() != nil:
+		_, err := exportTo(ctx, req.Export, h)
+		return nil, err
+	case req.GetBackup() != nil:
+		res, err := backupCurrentGroup(ctx, req.Backup, h)
+		return res.GetDropOperations(), err
+	}
+	return nil, errors.New("the stream export request has neither an export nor a backup")
+}
+
+// StreamExport streams the files of an export or a backup of the group of this server.
+func (w *grpcWorker) StreamExport(req *pb.StreamExportRequest,
+	stream pb.Worker_StreamExportServer) error {
+	glog.Infof("Received stream export request via Grpc")
+	h := &streamHandler{send: stream.Send}
+	dropOps, err := streamCurrentGroup(stream.Context(), req, h)
+	if err != nil {
+		glog.Errorf("While streaming the export: %v", err)
+		return err
+	}
+	if len(dropOps) == 0 {
+		return nil
+	}
+	return h.Send(&pb.ExportChunk{DropOperations: dropOps})
+}
+
+// streamGroup streams the files of the export or backup of the group to the handler, and returns
+// the drop operations of a backup. The request is sent to a server of the group if this server
+// isn't part of it.
+func streamGroup(ctx context.Context, gid uint32, req *pb.StreamExportRequest,
+	h *streamHandler) ([]*pb.DropOperation, error) {
+	if gid == groups().groupId() {
+		return streamCurrentGroup(ctx, req, h)
+	}
+
+	var pl *conn.Pool
+	if req.GetExport() != nil {
+		pl = groups().Leader(gid)
+	} else {
+		pl = groups().AnyServer(gid)
+	}
+	if pl == nil {
+		return nil, errors.Errorf("Couldn't find a server in group %d", gid)
+	}
+	glog.Infof("Sending stream export request to group: %d, addr: %s", gid, pl.Addr)
+	s, err := pb.NewWorkerClient(pl.Get()).StreamExport(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	var dropOps []*pb.DropOperation
+	for {
+		c, err := s.Recv()
+		switch {
+		case err == io.EOF:
+			return dropOps, nil
+		case err != nil:
+			return nil, errors.Wrapf(err, "while streaming the export of group %d", gid)
+		case c.Path == "":
+			dropOps = append(dropOps, c.DropOperations...)
+		default:
+			if err := h.Send(c); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// streamGroups streams the files of the export or backup of all the groups in parallel, the
+// request of each group being returned by reqOf. It returns the drop operations of a backup.
+func streamGroups(ctx context.Context, gids []uint32,
+	reqOf func(gid uint32) *pb.StreamExportRequest, h *streamHandler) ([]*pb.DropOperation, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type result struct {
+		dropOps []*pb.DropOperation
+		err     error
+	}
+	ch := make(chan result, len(gids))
+	for _, gid := range gids {
+		go func(gid uint32) {
+			dropOps, err := streamGroup(ctx, gid, reqOf(gid), h)
+			ch <- result{dropOps, err}
+		}(gid)
+	}
+	// All the groups are waited for even if one fails, so that none of them sends to the stream
+	// once the rpc returns.
+	var dropOps []*pb.DropOperation
+	var err error
+	for range gids {
+		res := <-ch
+		if res.err != nil && err == nil {
+			err = res.err
+			// Stop streaming the other groups.
+			cancel()
+		}
+		dropOps = append(dropOps, res.dropOps...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return dropOps, nil
+}
+
+// StreamExportOverNetwork exports the data of all the groups, sending the files to send instead of
+// writing them at the destination of the request.
+func StreamExportOverNetwork(ctx context.Context, input *pb.ExportRequest,
+	send func(*pb.ExportChunk) error) error {
+	if err := x.HealthCheck(); err != nil {
+		glog.Errorf("Rejecting stream export request due to health check error: %v\n", err)
+		return err
+	}
+	ts, err := Timestamps(ctx, &pb.Num{ReadOnly: true})
+	if err != nil {
+		glog.Errorf("Unable to retrieve readonly ts for export: %v\n", err)
+		return err
+	}
+	readTs := ts.ReadOnly
+
+	gids := groups().KnownGroups()
+	glog.Infof("Streaming export at readTs %d for groups: %v\n", readTs, gids)
+	reqOf := func(gid uint32) *pb.StreamExportRequest {
+		return &pb.StreamExportRequest{Export: groupExportRequest(input, gid, readTs)}
+	}
+	if _, err := streamGroups(ctx, gids, reqOf, &streamHandler{send: send}); err != nil {
+		rerr := errors.Wrapf(err, "Stream export failed at readTs %d", readTs)
+		glog.Errorln(rerr)
+		return rerr
+	}
+	glog.Infof("Stream export at readTs %d DONE", readTs)
+	return nil
+}
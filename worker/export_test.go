@@ -23,6 +23,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"math"
 	"net/http"
@@ -400,6 +401,71 @@ func TestExportJson(t *testing.T) {
 	checkExportGqlSchema(t, gqlSchema)
 }
 
+func TestExportGraph(t *testing.T) {
+	initTestExport(t, `name: string @index(exact) .
+				 [0x2] name: string @index(exact) .`)
+	time.Sleep(1 * time.Second)
+
+	for _, format := range []string{"graphml", "graphson"} {
+		t.Run(format, func(t *testing.T) {
+			bdir, err := ioutil.TempDir("", "export")
+			require.NoError(t, err)
+			defer os.RemoveAll(bdir)
+
+			x.WorkerConfig.ExportPath = bdir
+			readTs := timestamp()
+			// Do the following so export won't block forever for readTs.
+			posting.Oracle().ProcessDelta(&pb.OracleDelta{MaxAssigned: readTs})
+			req := pb.ExportRequest{ReadTs: readTs, GroupId: 1, Format: format,
+				Namespace: math.MaxUint64}
+			_, err = export(context.Background(), &req)
+			require.NoError(t, err)
+
+			fileList, _, _ := getExportFileList(t, bdir)
+			require.True(t, strings.HasSuffix(fileList[0], "."+format+".gz"))
+			f, err := os.Open(fileList[0])
+			require.NoError(t, err)
+			defer f.Close()
+			r, err := gzip.NewReader(f)
+			require.NoError(t, err)
+
+			// Load the export back with the chunker of its format.
+			ck := chunker.NewChunker(chunker.DataFormat(fileList[0], ""), -1)
+			br := bufio.NewReader(r)
+			for {
+				buf, err := ck.Chunk(br)
+				if buf != nil {
+					require.NoError(t, ck.Parse(buf))
+				}
+				if err == io.EOF {
+					break
+				}
+				require.NoError(t, err)
+			}
+			ck.NQuads().Flush()
+			nqs := make(map[string]*api.NQuad)
+			for batch := range ck.NQuads().Ch() {
+				for _, nq := range batch {
+					nqs[nq.Subject+" "+nq.Predicate+" "+nq.Lang+" "+nq.ObjectId] = nq
+				}
+			}
+			require.Equal(t, "pho\ton", nqs["_:0x1 name  "].ObjectValue.GetDefaultVal())
+			require.Equal(t, "pho\ton", nqs["_:0x2 name en "].ObjectValue.GetDefaultVal())
+			require.Equal(t, "ns2", nqs["_:0x2-0x9 name  "].ObjectValue.GetDefaultVal())
+			for _, sub := range []string{"_:0x1", "_:0x2", "_:0x3", "_:0x4"} {
+				require.Contains(t, nqs, sub+" friend  _:0x5")
+			}
+			fcts := nqs["_:0x4 friend  _:0x5"].Facets
+			require.Len(t, fcts, 5)
+			require.Equal(t, "age", fcts[0].Key)
+			require.Equal(t, api.Facet_INT, fcts[0].ValType)
+			require.Equal(t, "close", fcts[1].Key)
+			require.Equal(t, api.Facet_BOOL, fcts[1].ValType)
+			require.Equal(t, []byte("roses are red\nviolets are blue"), fcts[3].Value)
+		})
+	}
+}
+
 const exportRequest = `mutation export($format: String!) {
 	export(input: {format: $format}) {
 		response { code }
@@ -577,3 +643,30 @@ func TestToSchema(t *testing.T) {
 		require.Equal(t, testCase.expected, string(kv.Value))
 	}
 }
+
+func TestStreamFile(t *testing.T) {
+	var chunks []*pb.ExportChunk
+	h := &streamHandler{send: func(c *pb.ExportChunk) error {
+		chunks = append(chunks, c)
+		return nil
+	}}
+	w, err := h.CreateFile("dir/g01.rdf.gz")
+	require.NoError(t, err)
+
+	data := bytes.Repeat([]byte("0123456789"), streamChunkSize/5)
+	n, err := w.Write(data)
+	require.NoError(t, err)
+	require.Equal(t, len(data), n)
+	require.NoError(t, w.Close())
+
+	// The data is split in chunks of at most streamChunkSize, followed by the last chunk.
+	require.Len(t, chunks, 3)
+	var got []byte
+	for i, c := range chunks {
+		require.Equal(t, "dir/g01.rdf.gz", c.Path)
+		require.LessOrEqual(t, len(c.Data), streamChunkSize)
+		require.Equal(t, i == len(chunks)-1, c.Last)
+		got = append(got, c.Data...)
+	}
+	require.Equal(t, data, got)
+}
@@ -0,0 +1,340 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/dgraph-io/badger/v3"
+	"github.com/dgraph-io/dgraph/codec"
+	"github.com/dgraph-io/dgraph/posting"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/schema"
+	"github.com/dgraph-io/dgraph/types"
+	"github.com/dgraph-io/dgraph/types/facets"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/dgraph-io/sroar"
+	"github.com/pkg/errors"
+)
+
+// facetOrderBuckets holds a value of each sortable value type of the facet index. Booleans
+// aren't sortable, so their edges are ordered like the edges missing the facet.
+var facetOrderBuckets = []types.Val{
+	{Tid: types.FloatID, Value: float64(0)},
+	{Tid: types.StringID, Value: ""},
+	{Tid: types.DateTimeID, Value: time.Time{}},
+}
+
+// retrieveUidsAndFacetsUsingIndex is retrieveUidsAndFacets for the edges of the subject. It reads
+// the facet index of the predicate instead of iterating over all the edges when the facet order
+// or the facets filter of the query can be served from it.
+func retrieveUidsAndFacetsUsingIndex(ctx context.Context, args funcArgs, subject uint64,
+	pl *posting.List, facetsTree *facetsTree, opts posting.ListOptions) (
+	*pb.List, []*pb.Facets, error) {
+	q := args.q
+	var keys []string
+	if args.srcFn.fnType == notAFunction && !q.Reverse {
+		keys = schema.State().FacetIndexes(ctx, q.Attr)
+	}
+
+	if len(keys) > 0 && x.HasString(keys, q.FacetOrder) && q.FacetParam != nil {
+		postings, ok, err := orderedFacetPostings(ctx, q, subject, pl, facetsTree, opts)
+		if err != nil {
+			return nil, nil, err
+		}
+		if ok {
+			return uidsAndFacets(q, postings)
+		}
+	}
+	if indexedFacetFunc(facetsTree, keys) != nil {
+		postings, ok, err := filteredFacetPostings(ctx, q, subject, pl, facetsTree, opts)
+		if err != nil {
+			return nil, nil, err
+		}
+		if ok {
+			return uidsAndFacets(q, postings)
+		}
+	}
+	if facetsTree != nil && facetsTree.validAt != 0 && x.HasString(keys, facets.ValidFrom) {
+		postings, err := validFacetPostings(ctx, q, subject, pl, facetsTree, opts)
+		if err != nil {
+			return nil, nil, err
+		}
+		return uidsAndFacets(q, postings)
+	}
+	return retrieveUidsAndFacets(args, pl, facetsTree, opts)
+}
+
+// validFacetPostings returns the edges of the subject that are valid at facetsTree.validAt and
+// match the facets filter. Only the edges which became valid before that time are read from the
+// validFrom index, so that reading a past slice of the graph doesn't go over the later edges.
+func validFacetPostings(ctx context.Context, q *pb.Query, subject uint64, pl *posting.List,
+	facetsTree *facetsTree, opts posting.ListOptions) ([]*pb.Posting, error) {
+	at := types.Val{Tid: types.DateTimeID, Value: time.Unix(facetsTree.validAt, 0).UTC()}
+	prefix, err := facets.IndexTypePrefix(facets.ValidFrom, subject, at)
+	if err != nil {
+		return nil, err
+	}
+	token, err := facets.IndexToken(facets.ValidFrom, subject, at)
+	if err != nil {
+		return nil, err
+	}
+
+	var postings []*pb.Posting
+	err = scanFacetIndex(ctx, q.Attr, q.ReadTs, prefix, "", token, false,
+		func(uids []uint64) (bool, error) {
+			for _, uid := range uids {
+				p, err := pickFacetPosting(pl, uid, facetsTree, opts)
+				if err != nil {
+					return false, err
+				}
+				if p != nil {
+					postings = append(postings, p)
+				}
+			}
+			return false, nil
+		})
+	return postings, err
+}
+
+// indexedFacetFunc returns the function of the facets filter if the filter is a single comparison
+// on an indexed facet key.
+func indexedFacetFunc(ftree *facetsTree, keys []string) *facetsFunc {
+	if ftree == nil || ftree.function == nil || ftree.function.fnType != compareAttrFn {
+		return nil
+	}
+	if !x.HasString(keys, ftree.function.key) {
+		return nil
+	}
+	return ftree.function
+}
+
+// orderedFacetPostings returns the first q.First + q.Offset edges of the subject in the order of
+// the facet q.FacetOrder, for each value type. The edges are then sorted and paginated by the
+// caller. It returns false if the query has no limit, or if too few edges have the facet so that
+// the edges missing it would be part of the result.
+func orderedFacetPostings(ctx context.Context, q *pb.Query, subject uint64, pl *posting.List,
+	facetsTree *facetsTree, opts posting.ListOptions) ([]*pb.Posting, bool, error) {
+	if q.First <= 0 || q.First == math.MaxInt32 {
+		return nil, false, nil
+	}
+	need := int(q.First) + int(q.Offset)
+
+	var postings []*pb.Posting
+	seen := make(map[uint64]bool)
+	for _, bucket := range facetOrderBuckets {
+		prefix, err := facets.IndexTypePrefix(q.FacetOrder, subject, bucket)
+		if err != nil {
+			return nil, false, err
+		}
+		var picked int
+		err = scanFacetIndex(ctx, q.Attr, q.ReadTs, prefix, "", "", q.FacetOrderDesc,
+			func(uids []uint64) (bool, error) {
+				for _, uid := range uids {
+					if seen[uid] {
+						continue
+					}
+					p, err := pickFacetPosting(pl, uid, facetsTree, opts)
+					if err != nil {
+						return false, err
+					}
+					if p == nil {
+						continue
+					}
+					seen[uid] = true
+					postings = append(postings, p)
+					picked++
+				}
+				// Edges having the same facet value share an index entry, so that ties are
+				// all picked.
+				return picked >= need, nil
+			})
+		if err != nil {
+			return nil, false, err
+		}
+	}
+	if len(postings) < need {
+		return nil, false, nil
+	}
+	return postings, true, nil
+}
+
+// filteredFacetPostings returns the edges of the subject that match the facets filter, scanning
+// the index range of the compared facet in each value type.
+func filteredFacetPostings(ctx context.Context, q *pb.Query, subject uint64, pl *posting.List,
+	facetsTree *facetsTree, opts posting.ListOptions) ([]*pb.Posting, bool, error) {
+	fn := facetsTree.function
+	switch fn.name {
+	case "eq", "le", "lt", "ge", "gt":
+	default:
+		return nil, false, nil
+	}
+
+	// The argument is converted to each value type of the index. Integers are indexed as floats,
+	// so the float argument is used for both, unless the argument isn't a valid float.
+	var args []types.Val
+	if v, ok := fn.typesToVal[types.FloatID]; ok {
+		args = append(args, v)
+	} else if v, ok := fn.typesToVal[types.IntID]; ok {
+		args = append(args, v)
+	}
+	for _, tid := range []types.TypeID{types.StringID, types.DateTimeID, types.BoolID} {
+		if v, ok := fn.typesToVal[tid]; ok {
+			args = append(args, v)
+		}
+	}
+
+	var postings []*pb.Posting
+	seen := make(map[uint64]bool)
+	for _, arg := range args {
+		prefix, err := facets.IndexTypePrefix(fn.key, subject, arg)
+		if err != nil {
+			return nil, false, err
+		}
+		token, err := facets.IndexToken(fn.key, subject, arg)
+		if err != nil {
+			return nil, false, err
+		}
+		// The range is inclusive, as integers might share the token of the argument while
+		// being different from it. Every edge is checked against the filter anyway.
+		var from, to string
+		switch fn.name {
+		case "eq":
+			from, to = token, token
+		case "le", "lt":
+			to = token
+		case "ge", "gt":
+			from = token
+		}
+		err = scanFacetIndex(ctx, q.Attr, q.ReadTs, prefix, from, to, false,
+			func(uids []uint64) (bool, error) {
+				for _, uid := range uids {
+					if seen[uid] {
+						continue
+					}
+					p, err := pickFacetPosting(pl, uid, facetsTree, opts)
+					if err != nil {
+						return false, err
+					}
+					if p != nil {
+						seen[uid] = true
+						postings = append(postings, p)
+					}
+				}
+				return false, nil
+			})
+		if err != nil {
+			return nil, false, err
+		}
+	}
+	return postings, true, nil
+}
+
+// scanFacetIndex iterates over the facet index entries of the predicate with the given prefix,
+// in the order of their tokens. The scan starts at the token from and ends after the token to,
+// unless they are empty. It stops as soon as fn returns true.
+func scanFacetIndex(ctx context.Context, attr string, readTs uint64, prefix, from, to string,
+	desc bool, fn func(uids []uint64) (bool, error)) error {
+	iterOpt := badger.DefaultIteratorOptions
+	iterOpt.PrefetchValues = false
+	iterOpt.Reverse = desc
+	iterOpt.Prefix = x.IndexKey(attr, prefix)
+	txn := pstore.NewTransactionAt(readTs, false)
+	defer txn.Discard()
+
+	var seekKey []byte
+	switch {
+	case desc:
+		// We need to reach the last key of this value type.
+		last := []byte(prefix)
+		last[len(last)-1]++
+		seekKey = x.IndexKey(attr, string(last))
+	case from != "":
+		seekKey = x.IndexKey(attr, from)
+	}
+	itr := txn.NewIterator(iterOpt)
+	defer itr.Close()
+
+	for itr.Seek(seekKey); itr.Valid(); itr.Next() {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		key := itr.Item().KeyCopy(nil)
+		pk, err := x.Parse(key)
+		if err != nil {
+			return errors.Wrapf(err, "while parsing facet index key")
+		}
+		if to != "" && pk.Term > to {
+			return nil
+		}
+		// Don't put the Index keys in memory.
+		pl, err := posting.GetNoStore(key, readTs)
+		if err != nil {
+			return err
+		}
+		list, err := pl.Uids(posting.ListOptions{ReadTs: readTs})
+		if err != nil {
+			return err
+		}
+		done, err := fn(codec.GetUids(list))
+		if err != nil || done {
+			return err
+		}
+	}
+	return nil
+}
+
+// pickFacetPosting returns the posting of the edge to uid if it matches the facets filter.
+func pickFacetPosting(pl *posting.List, uid uint64, facetsTree *facetsTree,
+	opts posting.ListOptions) (*pb.Posting, error) {
+	if uid <= opts.AfterUid {
+		return nil, nil
+	}
+	found, p, err := pl.FindPosting(opts.ReadTs, uid)
+	if err != nil || !found || p.PostingType != pb.Posting_REF {
+		return nil, err
+	}
+	pick, err := applyFacetsTree(facets.PostingFacets(p), facetsTree)
+	if err != nil || !pick {
+		return nil, err
+	}
+	return p, nil
+}
+
+// uidsAndFacets returns the uids of the postings in ascending order, along with their facets.
+func uidsAndFacets(q *pb.Query, postings []*pb.Posting) (*pb.List, []*pb.Facets, error) {
+	sort.Slice(postings, func(i, j int) bool {
+		return postings[i].Uid < postings[j].Uid
+	})
+	res := sroar.NewBitmap()
+	var fcsList []*pb.Facets
+	for _, p := range postings {
+		res.Set(p.Uid)
+		if q.FacetParam != nil {
+			fcsList = append(fcsList, &pb.Facets{
+				Facets: facets.CopyFacets(facets.PostingFacets(p), q.FacetParam),
+			})
+		}
+	}
+	return codec.ToSortedList(res), fcsList, nil
+}
@@ -0,0 +1,648 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/badger/v3"
+	"github.com/dgraph-io/dgraph/posting"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/schema"
+	"github.com/dgraph-io/dgraph/tok"
+	"github.com/dgraph-io/dgraph/types"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+)
+
+// The kinds of inconsistencies found by fsck.
+const (
+	// FsckMissingIndex is a value of a node whose index entry is missing.
+	FsckMissingIndex = "missingIndex"
+	// FsckStaleIndex is an index entry of a node which has no value with that token.
+	FsckStaleIndex = "staleIndex"
+	// FsckMissingReverse is an edge whose reverse edge is missing.
+	FsckMissingReverse = "missingReverse"
+	// FsckStaleReverse is a reverse edge without the matching forward edge.
+	FsckStaleReverse = "staleReverse"
+	// FsckMissingCount is a node missing from the count index entry of its number of edges.
+	FsckMissingCount = "missingCount"
+	// FsckStaleCount is a node in a count index entry which doesn't match its number of edges.
+	FsckStaleCount = "staleCount"
+	// FsckMissingPart is a part of a multi-part list which is listed by the main key of the list,
+	// but can't be found.
+	FsckMissingPart = "missingPart"
+	// FsckOrphanPart is a part of a multi-part list which isn't listed by the main key.
+	FsckOrphanPart = "orphanPart"
+	// FsckUnreadable is a key which can't be read.
+	FsckUnreadable = "unreadable"
+
+	// DefaultFsckMaxIssues is the default max number of issues kept in a fsck report.
+	DefaultFsckMaxIssues = 1000
+)
+
+// FsckOptions are the options of a fsck run.
+type FsckOptions struct {
+	// Predicates restricts the check to these predicates, given without namespace. All the
+	// predicates of the group are checked if it's empty.
+	Predicates []string
+	// Repair rebuilds the indexes, reverse edges and count indexes of the predicates found
+	// inconsistent.
+	Repair bool
+	// MaxIssues is the max number of issues kept in the report. The check goes on once it's
+	// reached, and the report is marked as truncated.
+	MaxIssues int
+}
+
+// FsckIssue is an inconsistency found by fsck.
+type FsckIssue struct {
+	Kind      string `json:"kind"`
+	Predicate string `json:"predicate"`
+	Namespace uint64 `json:"namespace"`
+	// Key is the hex encoded key holding, or which should hold, the inconsistent entry.
+	Key string `json:"key"`
+	// Uid is the node of the inconsistent entry, if any.
+	Uid    uint64 `json:"uid,omitempty"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// FsckReport is the result of a fsck run on the data of the group of this Alpha.
+type FsckReport struct {
+	StartedAt time.Time `json:"startedAt"`
+	// Duration is empty while the check is running.
+	Duration string `json:"duration,omitempty"`
+	ReadTs   uint64 `json:"readTs"`
+	// Predicates is the number of predicates checked so far.
+	Predicates int          `json:"predicates"`
+	Keys       uint64       `json:"keys"`
+	Issues     []*FsckIssue `json:"issues"`
+	// Truncated tells that more issues were found than kept in Issues.
+	Truncated bool `json:"truncated"`
+	// Repaired are the predicates whose indexes were rebuilt.
+	Repaired []string `json:"repaired,omitempty"`
+	Error    string   `json:"error,omitempty"`
+}
+
+func (r *FsckReport) copy() *FsckReport {
+	if r == nil {
+		return nil
+	}
+	res := *r
+	res.Issues = append([]*FsckIssue{}, r.Issues...)
+	res.Repaired = append([]string{}, r.Repaired...)
+	return &res
+}
+
+// fsck makes sure that a single check runs at a time, and keeps the report of the last one.
+var fsck struct {
+	sync.Mutex
+	running *FsckReport
+	last    *FsckReport
+}
+
+// GetFsckStatus returns the progress of the running check if any, and the report of the last
+// one.
+func GetFsckStatus() (running, last *FsckReport) {
+	fsck.Lock()
+	defer fsck.Unlock()
+	return fsck.running.copy(), fsck.last.copy()
+}
+
+// StartFsck starts checking in the background that the posting lists of the group served by this
+// Alpha are consistent with each other, see GetFsckStatus for its progress:
+//   - the index entries of the predicates match the tokens of their values,
+//   - the reverse edges match the forward edges,
+//   - the count indexes match the number of edges of the nodes,
+//   - the parts of the multi-part lists are the ones listed by their main key.
+//
+// The check reads the data at the max timestamp applied when it starts. With Repair, the indexes
+// found inconsistent are rebuilt afterwards through Raft, on every replica of the group, the same
+// way as dropping and adding them back in the schema. The multi-part lists aren't repaired.
+func StartFsck(opts FsckOptions) error {
+	n := groups().Node
+	if n == nil || n.Raft() == nil {
+		return errors.New("This Alpha isn't serving a group yet")
+	}
+	if n.isRunningTask(opIndexing) {
+		return errors.New("Indexes are being built, the check can't run until they are done")
+	}
+	if opts.MaxIssues <= 0 {
+		opts.MaxIssues = DefaultFsckMaxIssues
+	}
+
+	fsck.Lock()
+	defer fsck.Unlock()
+	if fsck.running != nil {
+		return errors.Errorf("A check is already running since %s",
+			fsck.running.StartedAt.Format(time.RFC3339))
+	}
+	report := &FsckReport{
+		StartedAt: time.Now(),
+		ReadTs:    posting.Oracle().MaxAssigned(),
+	}
+	fsck.running = report
+
+	go func() {
+		glog.Infof("Starting fsck at ts %d", report.ReadTs)
+		err := runFsck(n.ctx, report, opts)
+		fsck.Lock()
+		defer fsck.Unlock()
+		report.Duration = time.Since(report.StartedAt).Round(time.Millisecond).String()
+		if err != nil {
+			report.Error = err.Error()
+			glog.Errorf("Fsck failed after %s: %v", report.Duration, err)
+		} else {
+			glog.Infof("Fsck done in %s, found %d issues", report.Duration, len(report.Issues))
+		}
+		fsck.last = report
+		fsck.running = nil
+	}()
+	return nil
+}
+
+// fsckPredicates returns the predicates of the group to check, sorted.
+func fsckPredicates(names []string) []string {
+	var preds []string
+	for _, attr := range schema.State().Predicates() {
+		if len(names) > 0 && !x.HasString(names, x.ParseAttr(attr)) {
+			continue
+		}
+		preds = append(preds, attr)
+	}
+	sort.Strings(preds)
+	return preds
+}
+
+func runFsck(ctx context.Context, report *FsckReport, opts FsckOptions) error {
+	txn := pstore.NewTransactionAt(report.ReadTs, false)
+	defer txn.Discard()
+	c := newFsckChecker(ctx, txn, report.ReadTs, opts.MaxIssues)
+	for _, attr := range fsckPredicates(opts.Predicates) {
+		if err := c.checkPredicate(attr); err != nil {
+			return errors.Wrapf(err, "while checking predicate %s", x.ParseAttr(attr))
+		}
+		fsck.Lock()
+		report.Predicates++
+		report.Keys = c.keys
+		report.Issues = c.issues
+		report.Truncated = c.truncated
+		fsck.Unlock()
+	}
+	if !opts.Repair {
+		return nil
+	}
+
+	for _, attr := range c.failedPredicates() {
+		if err := repairPredicate(ctx, attr, c.failed[attr]); err != nil {
+			return errors.Wrapf(err, "while repairing predicate %s", x.ParseAttr(attr))
+		}
+		fsck.Lock()
+		report.Repaired = append(report.Repaired, x.ParseAttr(attr))
+		fsck.Unlock()
+	}
+	return nil
+}
+
+// fsckFailures are the indexes of a predicate found inconsistent.
+type fsckFailures struct {
+	index, reverse, count bool
+}
+
+type fsckChecker struct {
+	ctx       context.Context
+	txn       *badger.Txn
+	readTs    uint64
+	maxIssues int
+
+	keys      uint64
+	issues    []*FsckIssue
+	truncated bool
+	failed    map[string]*fsckFailures
+	// splits are the start uids of the parts of the multi-part lists read, by main key.
+	splits map[string][]uint64
+}
+
+func newFsckChecker(ctx context.Context, txn *badger.Txn, readTs uint64,
+	maxIssues int) *fsckChecker {
+	return &fsckChecker{
+		ctx:       ctx,
+		txn:       txn,
+		readTs:    readTs,
+		maxIssues: maxIssues,
+		failed:    make(map[string]*fsckFailures),
+		splits:    make(map[string][]uint64),
+	}
+}
+
+func (c *fsckChecker) addIssue(kind, attr string, key []byte, uid uint64, detail string) {
+	failed, ok := c.failed[attr]
+	if !ok {
+		failed = &fsckFailures{}
+		c.failed[attr] = failed
+	}
+	switch kind {
+	case FsckMissingIndex, FsckStaleIndex:
+		failed.index = true
+	case FsckMissingReverse, FsckStaleReverse:
+		failed.reverse = true
+	case FsckMissingCount, FsckStaleCount:
+		failed.count = true
+	}
+	if len(c.issues) >= c.maxIssues {
+		c.truncated = true
+		return
+	}
+	c.issues = append(c.issues, &FsckIssue{
+		Kind:      kind,
+		Predicate: x.ParseAttr(attr),
+		Namespace: x.ParseNamespace(attr),
+		Key:       hex.EncodeToString(key),
+		Uid:       uid,
+		Detail:    detail,
+	})
+}
+
+// failedPredicates returns the predicates having indexes to repair, sorted.
+func (c *fsckChecker) failedPredicates() []string {
+	var preds []string
+	for attr, failed := range c.failed {
+		if failed.index || failed.reverse || failed.count {
+			preds = append(preds, attr)
+		}
+	}
+	sort.Strings(preds)
+	return preds
+}
+
+// forEachList calls fn with the posting lists of the keys having the prefix, except for the parts
+// of the multi-part lists which are read along with their main key.
+func (c *fsckChecker) forEachList(attr string, prefix []byte,
+	fn func(key []byte, pk x.ParsedKey, l *posting.List) error) error {
+	itr := c.txn.NewIterator(badger.IteratorOptions{Prefix: prefix})
+	defer itr.Close()
+
+	for itr.Rewind(); itr.Valid(); itr.Next() {
+		if err := c.ctx.Err(); err != nil {
+			return err
+		}
+		key := itr.Item().KeyCopy(nil)
+		c.keys++
+		pk, err := x.Parse(key)
+		if err != nil {
+			c.addIssue(FsckUnreadable, attr, key, 0, err.Error())
+			continue
+		}
+		l, err := posting.GetNoStore(key, c.readTs)
+		if err != nil {
+			c.addIssue(FsckUnreadable, attr, key, pk.Uid, err.Error())
+			continue
+		}
+		if !c.checkParts(attr, key, pk, l) {
+			continue
+		}
+		if err := fn(key, pk, l); err != nil {
+			c.addIssue(FsckUnreadable, attr, key, pk.Uid, err.Error())
+		}
+	}
+	return nil
+}
+
+// checkParts checks that the parts listed by the main key of the list exist, and returns whether
+// they all do.
+func (c *fsckChecker) checkParts(attr string, key []byte, pk x.ParsedKey, l *posting.List) bool {
+	splits := l.PartSplits()
+	if len(splits) == 0 {
+		return true
+	}
+	c.splits[string(key)] = splits
+	ok := true
+	for _, startUid := range splits {
+		partKey, err := x.SplitKey(key, startUid)
+		if err != nil {
+			c.addIssue(FsckUnreadable, attr, key, pk.Uid, err.Error())
+			return false
+		}
+		if _, err := c.txn.Get(partKey); err == badger.ErrKeyNotFound {
+			c.addIssue(FsckMissingPart, attr, partKey, pk.Uid,
+				fmt.Sprintf("part starting at uid %#x isn't found", startUid))
+			ok = false
+		} else if err != nil {
+			c.addIssue(FsckUnreadable, attr, partKey, pk.Uid, err.Error())
+			ok = false
+		}
+	}
+	return ok
+}
+
+// checkOrphanParts checks that the parts of the lists of the predicate are listed by their main
+// key. Rollups keep the parts which aren't used anymore, but empty them.
+func (c *fsckChecker) checkOrphanParts(attr string) error {
+	prefix := x.PredicatePrefix(attr)
+	prefix[0] = x.ByteSplit
+	itr := c.txn.NewIterator(badger.IteratorOptions{Prefix: prefix})
+	defer itr.Close()
+
+	for itr.Rewind(); itr.Valid(); itr.Next() {
+		if err := c.ctx.Err(); err != nil {
+			return err
+		}
+		item := itr.Item()
+		c.keys++
+		if item.UserMeta()&posting.BitEmptyPosting > 0 {
+			continue
+		}
+		key := item.KeyCopy(nil)
+		pk, err := x.Parse(key)
+		if err != nil || !pk.HasStartUid {
+			c.addIssue(FsckUnreadable, attr, key, 0, fmt.Sprintf("invalid part key: %v", err))
+			continue
+		}
+		mainKey := append([]byte{}, key[:len(key)-8]...)
+		mainKey[0] = x.DefaultPrefix
+		found := false
+		for _, startUid := range c.splits[string(mainKey)] {
+			if startUid == pk.StartUid {
+				found = true
+				break
+			}
+		}
+		if !found {
+			c.addIssue(FsckOrphanPart, attr, key, pk.Uid,
+				fmt.Sprintf("part starting at uid %#x isn't listed by its main key", pk.StartUid))
+		}
+	}
+	return nil
+}
+
+// appendUid appends uid to uids, which are sorted, unless it's already the last one.
+func appendUid(uids []uint64, uid uint64) []uint64 {
+	if len(uids) > 0 && uids[len(uids)-1] == uid {
+		return uids
+	}
+	return append(uids, uid)
+}
+
+// compareUids reports the uids expected in the list of the key but missing from it, and the uids
+// of the list which aren't expected. Both are sorted.
+func (c *fsckChecker) compareUids(attr string, key []byte, expected, actual []uint64,
+	missingKind, staleKind string) {
+	i, j := 0, 0
+	for i < len(expected) || j < len(actual) {
+		switch {
+		case j == len(actual) || (i < len(expected) && expected[i] < actual[j]):
+			c.addIssue(missingKind, attr, key, expected[i], "")
+			i++
+		case i == len(expected) || actual[j] < expected[i]:
+			c.addIssue(staleKind, attr, key, actual[j], "")
+			j++
+		default:
+			i++
+			j++
+		}
+	}
+}
+
+func (c *fsckChecker) listUids(l *posting.List) ([]uint64, error) {
+	var uids []uint64
+	err := l.Iterate(c.readTs, 0, func(p *pb.Posting) error {
+		uids = append(uids, p.Uid)
+		return nil
+	})
+	return uids, err
+}
+
+// checkPredicate reads the data of the predicate to compute its expected indexes, and then
+// compares them with the indexes stored.
+func (c *fsckChecker) checkPredicate(attr string) error {
+	typ, err := schema.State().TypeOf(attr)
+	if err != nil {
+		return err
+	}
+	indexed := typ != types.UidID && schema.State().IsIndexed(c.ctx, attr)
+	reversed := typ == types.UidID && schema.State().IsReversed(c.ctx, attr)
+	counted := schema.State().HasCount(c.ctx, attr)
+
+	index := make(map[string][]uint64)
+	reverse := make(map[uint64][]uint64)
+	count := make(map[uint32][]uint64)
+	// The data keys are iterated by increasing uid, so the expected lists are built sorted.
+	pk := x.ParsedKey{Attr: attr}
+	if err := c.forEachList(attr, pk.DataPrefix(), func(key []byte, pk x.ParsedKey,
+		l *posting.List) error {
+		if pk.Uid == 0 {
+			return nil
+		}
+		var n uint32
+		err := l.Iterate(c.readTs, 0, func(p *pb.Posting) error {
+			n++
+			switch {
+			case reversed:
+				reverse[p.Uid] = appendUid(reverse[p.Uid], pk.Uid)
+			case indexed:
+				val := types.Val{Tid: types.TypeID(p.ValType), Value: p.Value}
+				// The values which can't be indexed are rejected by the mutations.
+				tokens, err := posting.IndexTokens(c.ctx, attr, string(p.LangTag), val)
+				if err != nil {
+					return nil
+				}
+				for _, token := range tokens {
+					index[token] = appendUid(index[token], pk.Uid)
+				}
+			}
+			return nil
+		})
+		if counted && n > 0 {
+			count[n] = appendUid(count[n], pk.Uid)
+		}
+		return err
+	}); err != nil {
+		return err
+	}
+
+	if indexed {
+		if err := c.forEachList(attr, pk.IndexPrefix(), func(key []byte, pk x.ParsedKey,
+			l *posting.List) error {
+			// The facet indexes and the presence lists aren't checked.
+			if len(pk.Term) > 0 &&
+				(pk.Term[0] == tok.IdentFacet || pk.Term[0] == tok.IdentPresence) {
+				return nil
+			}
+			uids, err := c.listUids(l)
+			if err != nil {
+				return err
+			}
+			c.compareUids(attr, key, index[pk.Term], uids, FsckMissingIndex, FsckStaleIndex)
+			delete(index, pk.Term)
+			return nil
+		}); err != nil {
+			return err
+		}
+		tokens := make([]string, 0, len(index))
+		for token := range index {
+			tokens = append(tokens, token)
+		}
+		sort.Strings(tokens)
+		for _, token := range tokens {
+			c.compareUids(attr, x.IndexKey(attr, token), index[token], nil,
+				FsckMissingIndex, FsckStaleIndex)
+		}
+	}
+
+	countRev := make(map[uint32][]uint64)
+	if reversed {
+		if err := c.forEachList(attr, pk.ReversePrefix(), func(key []byte, pk x.ParsedKey,
+			l *posting.List) error {
+			uids, err := c.listUids(l)
+			if err != nil {
+				return err
+			}
+			c.compareUids(attr, key, reverse[pk.Uid], uids, FsckMissingReverse,
+				FsckStaleReverse)
+			delete(reverse, pk.Uid)
+			if counted && len(uids) > 0 {
+				n := uint32(len(uids))
+				countRev[n] = appendUid(countRev[n], pk.Uid)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		uids := make([]uint64, 0, len(reverse))
+		for uid := range reverse {
+			uids = append(uids, uid)
+		}
+		sort.Slice(uids, func(i, j int) bool { return uids[i] < uids[j] })
+		for _, uid := range uids {
+			c.compareUids(attr, x.ReverseKey(attr, uid), reverse[uid], nil,
+				FsckMissingReverse, FsckStaleReverse)
+		}
+	}
+
+	if counted {
+		// The reverse counts are compared with the reverse lists stored, as the reverse lists
+		// themselves are checked above.
+		for _, rev := range []bool{false, true} {
+			if rev && !reversed {
+				continue
+			}
+			expected := count
+			if rev {
+				expected = countRev
+			}
+			if err := c.forEachList(attr, pk.CountPrefix(rev), func(key []byte, pk x.ParsedKey,
+				l *posting.List) error {
+				uids, err := c.listUids(l)
+				if err != nil {
+					return err
+				}
+				c.compareUids(attr, key, expected[pk.Count], uids, FsckMissingCount,
+					FsckStaleCount)
+				delete(expected, pk.Count)
+				return nil
+			}); err != nil {
+				return err
+			}
+			counts := make([]uint32, 0, len(expected))
+			for n := range expected {
+				counts = append(counts, n)
+			}
+			sort.Slice(counts, func(i, j int) bool { return counts[i] < counts[j] })
+			for _, n := range counts {
+				c.compareUids(attr, x.CountKey(attr, n, rev), expected[n], nil,
+					FsckMissingCount, FsckStaleCount)
+			}
+		}
+	}
+
+	return c.checkOrphanParts(attr)
+}
+
+// RebuildCountIndex rebuilds the count indexes of the given predicates of the group served by this
+// Alpha, or of all its predicates having one if none is given, and returns the predicates. They're
+// rebuilt through Raft like the ones repaired by fsck, the rebuild goes on in the background once
+// it returns.
+func RebuildCountIndex(ctx context.Context, names []string) ([]string, error) {
+	n := groups().Node
+	if n == nil || n.Raft() == nil {
+		return nil, errors.New("This Alpha isn't serving a group yet")
+	}
+	if n.isRunningTask(opIndexing) {
+		return nil, errors.New("Indexes are being built, try again once they are done")
+	}
+
+	var preds []string
+	for _, attr := range fsckPredicates(names) {
+		if schema.State().HasCount(ctx, attr) {
+			preds = append(preds, attr)
+		}
+	}
+	for _, name := range names {
+		found := false
+		for _, attr := range preds {
+			found = found || x.ParseAttr(attr) == name
+		}
+		if !found {
+			return nil, errors.Errorf("Predicate %s has no count index in the group of this Alpha",
+				name)
+		}
+	}
+
+	var rebuilt []string
+	for _, attr := range preds {
+		if err := repairPredicate(ctx, attr, &fsckFailures{count: true}); err != nil {
+			return rebuilt, errors.Wrapf(err, "while rebuilding the count index of predicate %s",
+				x.ParseAttr(attr))
+		}
+		rebuilt = append(rebuilt, x.ParseAttr(attr))
+	}
+	return rebuilt, nil
+}
+
+// repairPredicate rebuilds the failed indexes of the predicate, by proposing its schema without
+// them, and then its schema again.
+func repairPredicate(ctx context.Context, attr string, failed *fsckFailures) error {
+	su, ok := schema.State().Get(ctx, attr)
+	if !ok {
+		return errors.Errorf("The schema of the predicate isn't found")
+	}
+	stripped := su
+	if failed.index || failed.reverse {
+		stripped.Directive = pb.SchemaUpdate_NONE
+		stripped.Tokenizer = nil
+	}
+	if failed.count {
+		stripped.Count = false
+	}
+	glog.Infof("Fsck is rebuilding the indexes of predicate %s", x.ParseAttr(attr))
+	for _, update := range []*pb.SchemaUpdate{&stripped, &su} {
+		m := &pb.Mutations{
+			GroupId: groups().groupId(),
+			StartTs: State.GetTimestamp(false),
+			Schema:  []*pb.SchemaUpdate{update},
+		}
+		if err := groups().Node.proposeAndWait(ctx, &pb.Proposal{Mutations: m}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
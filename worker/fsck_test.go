@@ -0,0 +1,97 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dgraph-io/badger/v3"
+	"github.com/dgraph-io/dgraph/posting"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/schema"
+	"github.com/dgraph-io/dgraph/types"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFsckCheckPredicate(t *testing.T) {
+	ctx := context.Background()
+	require.NoError(t, schema.ParseBytes([]byte(`
+		fsck_name: string @index(exact) .
+		fsck_friend: [uid] @reverse @count .`), 1))
+	name, friend := x.GalaxyAttr("fsck_name"), x.GalaxyAttr("fsck_friend")
+
+	for uid, val := range map[uint64]string{1: "alice", 2: "bob"} {
+		addEdge(t, &pb.DirectedEdge{Entity: uid, Attr: name, Value: []byte(val),
+			ValueType: pb.Posting_STRING}, getOrCreate(x.DataKey(name, uid)))
+	}
+	for _, e := range [][2]uint64{{1, 2}, {1, 3}, {2, 3}} {
+		addEdge(t, &pb.DirectedEdge{Entity: e[0], Attr: friend, ValueId: e[1]},
+			getOrCreate(x.DataKey(friend, e[0])))
+	}
+
+	check := func(attr string) []*FsckIssue {
+		readTs := timestamp()
+		txn := pstore.NewTransactionAt(readTs, false)
+		defer txn.Discard()
+		c := newFsckChecker(ctx, txn, readTs, DefaultFsckMaxIssues)
+		require.NoError(t, c.checkPredicate(attr))
+		return c.issues
+	}
+	kinds := func(issues []*FsckIssue) map[string][]uint64 {
+		res := make(map[string][]uint64)
+		for _, issue := range issues {
+			res[issue.Kind] = append(res[issue.Kind], issue.Uid)
+		}
+		return res
+	}
+	require.Empty(t, check(name))
+	require.Empty(t, check(friend))
+
+	// Write behind the back of the indexes.
+	write := func(key []byte, meta byte) {
+		ts := timestamp()
+		txn := pstore.NewTransactionAt(ts, true)
+		defer txn.Discard()
+		require.NoError(t, txn.SetEntry(badger.NewEntry(key, nil).WithMeta(meta)))
+		require.NoError(t, txn.CommitAt(ts, nil))
+	}
+	tokens, err := posting.IndexTokens(ctx, name, "",
+		types.Val{Tid: types.StringID, Value: []byte("alice")})
+	require.NoError(t, err)
+	require.Len(t, tokens, 1)
+	write(x.IndexKey(name, tokens[0]), posting.BitEmptyPosting)
+	write(x.DataKey(name, 2), posting.BitEmptyPosting)
+	require.Equal(t, map[string][]uint64{
+		FsckMissingIndex: {1},
+		FsckStaleIndex:   {2},
+	}, kinds(check(name)))
+
+	// The node 1 loses its friends.
+	write(x.DataKey(friend, 1), posting.BitEmptyPosting)
+	require.Equal(t, map[string][]uint64{
+		FsckStaleReverse: {1, 1},
+		FsckStaleCount:   {1},
+	}, kinds(check(friend)))
+
+	// A part which isn't listed by its main key.
+	part, err := x.SplitKey(x.DataKey(friend, 2), 10)
+	require.NoError(t, err)
+	write(part, posting.BitCompletePosting)
+	require.Equal(t, []uint64{2}, kinds(check(friend))[FsckOrphanPart])
+}
@@ -122,13 +122,29 @@ func UpdateGQLSchemaOverNetwork(ctx context.Context, req *pb.UpdateGraphQLSchema
 }
 
 func ParseAsSchemaAndScript(b []byte) (string, string) {
+	data := ParseGQL(b)
+	return data.Schema, data.Script
+}
+
+// ParseGQL parses the value of the GraphQL schema node, along with the previous versions of the
+// schema.
+func ParseGQL(b []byte) *x.GQL {
 	var data x.GQL
 	if err := json.Unmarshal(b, &data); err != nil {
 		glog.Warningf("Cannot unmarshal existing GQL schema into new format. Got err: %+v. "+
 			" Assuming old format.", err)
-		return string(b), ""
+		return &x.GQL{Schema: string(b)}
 	}
-	return data.Schema, data.Script
+	return &data
+}
+
+// GQLSchemaVersion returns the version of the current GraphQL schema. A schema stored before the
+// versions were kept is the first version.
+func GQLSchemaVersion(gql *x.GQL) uint64 {
+	if gql.Version == 0 && gql.Schema != "" {
+		return 1
+	}
+	return gql.Version
 }
 
 // UpdateGraphQLSchema updates the GraphQL schema node with the new GraphQL schema,
@@ -212,12 +228,16 @@ func (w *grpcWorker) UpdateGraphQLSchema(ctx context.Context,
 			return nil,
 				errors.Errorf("Schema node was found but the corresponding schema does not exist")
 		}
-		gql.Schema, gql.Script = ParseAsSchemaAndScript(res.ValueMatrix[0].Values[0].Val)
+		gql = *ParseGQL(res.ValueMatrix[0].Values[0].Val)
 	}
 
 	switch req.Op {
 	case pb.UpdateGraphQLSchemaRequest_SCHEMA:
-		gql.Schema = req.GraphqlSchema
+		err := newGQLSchemaVersion(&gql, req.GraphqlSchema, req.ExpectedVersion,
+			int(x.Config.GraphQL.SchemaHistory))
+		if err != nil {
+			return nil, err
+		}
 	case pb.UpdateGraphQLSchemaRequest_SCRIPT:
 		gql.Script = req.LambdaScript
 	default:
@@ -300,6 +320,31 @@ func (w *grpcWorker) UpdateGraphQLSchema(ctx context.Context,
 	return &pb.UpdateGraphQLSchemaResponse{Uid: schemaNodeUid}, nil
 }
 
+// newGQLSchemaVersion replaces the schema with a new version, keeping the replaced schema in the
+// history, which holds the latest keep versions only. If expected is non-zero, it returns an error
+// if the current version isn't the expected one.
+func newGQLSchemaVersion(gql *x.GQL, sch string, expected uint64, keep int) error {
+	version := GQLSchemaVersion(gql)
+	if expected != 0 && expected != version {
+		return errors.Errorf("GraphQL schema was updated concurrently, its version is %d"+
+			" instead of %d, please retry", version, expected)
+	}
+	if gql.Schema != "" {
+		gql.History = append([]x.GQLVersion{{
+			Version:   version,
+			Schema:    gql.Schema,
+			AppliedAt: gql.AppliedAt,
+		}}, gql.History...)
+	}
+	if len(gql.History) > keep {
+		gql.History = gql.History[:keep]
+	}
+	gql.Schema = sch
+	gql.Version = version + 1
+	gql.AppliedAt = time.Now().UTC()
+	return nil
+}
+
 // WaitForIndexing does a busy wait for indexing to finish or the context to error out,
 // if the input flag shouldWait is true. Otherwise, it just returns nil straight away.
 // If the context errors, it returns that error.
@@ -0,0 +1,53 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGQLSchemaVersion(t *testing.T) {
+	// A schema stored before the versions were kept is the first version.
+	gql := ParseGQL([]byte(`{"Schema": "type A { f: String }"}`))
+	require.Equal(t, uint64(1), GQLSchemaVersion(gql))
+
+	require.NoError(t, newGQLSchemaVersion(gql, "type B { f: String }", 0, 2))
+	require.Equal(t, uint64(2), gql.Version)
+	require.Equal(t, "type B { f: String }", gql.Schema)
+	require.Equal(t, []x.GQLVersion{{Version: 1, Schema: "type A { f: String }"}}, gql.History)
+
+	// The update fails if the schema isn't at the expected version.
+	require.Error(t, newGQLSchemaVersion(gql, "type C { f: String }", 1, 2))
+
+	require.NoError(t, newGQLSchemaVersion(gql, "type C { f: String }", 2, 2))
+	require.NoError(t, newGQLSchemaVersion(gql, "type D { f: String }", 0, 2))
+	require.Equal(t, uint64(4), gql.Version)
+	require.Len(t, gql.History, 2)
+	require.Equal(t, uint64(3), gql.History[0].Version)
+	require.Equal(t, "type C { f: String }", gql.History[0].Schema)
+	require.Equal(t, uint64(2), gql.History[1].Version)
+}
+
+func TestParseGQLOldFormat(t *testing.T) {
+	gql := ParseGQL([]byte("type A { f: String }"))
+	require.Equal(t, "type A { f: String }", gql.Schema)
+	require.Equal(t, uint64(1), GQLSchemaVersion(gql))
+	require.Equal(t, uint64(0), GQLSchemaVersion(&x.GQL{}))
+}
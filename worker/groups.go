@@ -109,6 +109,7 @@ func StartRaftNodes(walStore *raftwal.DiskStorage, bindall bool) {
 		GroupId: x.WorkerConfig.ProposedGroupId,
 		Addr:    x.WorkerConfig.MyAddr,
 		Learner: x.WorkerConfig.Raft.GetBool("learner"),
+		Witness: x.WorkerConfig.Raft.GetBool("witness"),
 	}
 	if m.GroupId > 0 {
 		m.ForceGroupId = true
@@ -149,9 +150,17 @@ func StartRaftNodes(walStore *raftwal.DiskStorage, bindall bool) {
 	walStore.SetUint(raftwal.GroupId, uint64(gid))
 
 	gr.Node = newNode(walStore, gid, raftIdx, x.WorkerConfig.MyAddr)
+	if provisionalLearner(connState.GetState(), gid, raftIdx) {
+		// Zero wants us to catch up with the group as a learner, before we become a voter.
+		glog.Infof("Joining group %d as a learner until caught up.", gid)
+		gr.Node.RaftContext.IsLearner = true
+		gr.Node.promoteLearner = true
+	}
 
+	setStartupPhase(StartupLoadingSchema)
 	x.Checkf(schema.LoadFromDb(), "Error while initializing schema")
 	glog.Infof("Load schema from DB: OK")
+	setStartupPhase(StartupStartingRaft)
 	raftServer.UpdateNode(gr.Node.Node)
 	gr.Node.InitAndStartNode()
 	glog.Infof("Init and start Raft node: OK")
@@ -160,6 +169,7 @@ func StartRaftNodes(walStore *raftwal.DiskStorage, bindall bool) {
 	go gr.receiveMembershipUpdates()
 	go gr.processOracleDeltaStream()
 
+	setStartupPhase(StartupInformingZero)
 	gr.informZeroAboutTablets()
 
 	glog.Infof("Informed Zero about tablets I have: OK")
@@ -167,6 +177,7 @@ func StartRaftNodes(walStore *raftwal.DiskStorage, bindall bool) {
 	gr.applyInitialTypes()
 	glog.Infof("Upserted Schema and Types: OK")
 
+	setStartupPhase(StartupReady)
 	x.UpdateHealthStatus(true)
 	glog.Infof("Server is ready: OK")
 }
@@ -397,8 +408,10 @@ func (g *groupi) applyState(myId uint64, state *pb.MembershipState) {
 	}
 }
 
+// ServesGroup returns true if this Alpha serves the data of the group. A witness is a member of
+// its group, but serves none.
 func (g *groupi) ServesGroup(gid uint32) bool {
-	return g.groupId() == gid
+	return g.groupId() == gid && (g.Node == nil || !g.Node.witness)
 }
 
 func (g *groupi) ChecksumsMatch(ctx context.Context) error {
@@ -519,6 +532,15 @@ func (g *groupi) Tablet(key string) (*pb.Tablet, error) {
 	// We don't know about this tablet.
 	// Check with dgraphzero if we can serve it.
 	tablet = &pb.Tablet{GroupId: g.groupId(), Predicate: key}
+	// If the predicate must be co-located with another one, ask for it to be served by the group
+	// serving the other predicate.
+	if sameAs := colocatedWith(key); sameAs != "" {
+		g.RLock()
+		if other, ok := g.tablets[sameAs]; ok && other.GroupId > 0 {
+			tablet.GroupId = other.GroupId
+		}
+		g.RUnlock()
+	}
 	return g.sendTablet(tablet)
 }
 
@@ -541,7 +563,7 @@ func (g *groupi) HasMeInState() bool {
 	return has
 }
 
-// Returns 0, 1, or 2 valid server addrs.
+// Returns 0, 1, or 2 valid server addrs. The witnesses hold no data, so they're skipped.
 func (g *groupi) AnyTwoServers(gid uint32) []string {
 	g.RLock()
 	defer g.RUnlock()
@@ -555,6 +577,9 @@ func (g *groupi) AnyTwoServers(gid uint32) []string {
 	}
 	var res []string
 	for _, m := range group.Members {
+		if m.Witness {
+			continue
+		}
 		// map iteration gives us members in no particular order.
 		res = append(res, m.Addr)
 		if len(res) >= 2 {
@@ -584,6 +609,9 @@ func (g *groupi) members(gid uint32) map[uint64]*pb.Member {
 func (g *groupi) AnyServer(gid uint32) *conn.Pool {
 	members := g.members(gid)
 	for _, m := range members {
+		if m.Witness {
+			continue
+		}
 		pl, err := conn.GetPools().Get(m.Addr)
 		if err == nil {
 			return pl
@@ -595,7 +623,7 @@ func (g *groupi) AnyServer(gid uint32) *conn.Pool {
 func (g *groupi) MyPeer() (uint64, bool) {
 	members := g.members(g.groupId())
 	for _, m := range members {
-		if m.Id != g.Node.Id {
+		if m.Id != g.Node.Id && !m.Witness {
 			return m.Id, true
 		}
 	}
@@ -723,6 +751,8 @@ func (g *groupi) doSendMembership(tablets map[string]*pb.Tablet) error {
 		Addr:       x.WorkerConfig.MyAddr,
 		Leader:     leader,
 		LastUpdate: uint64(time.Now().Unix()),
+		Learner:    g.Node.RaftContext.IsLearner,
+		Witness:    g.Node.witness,
 	}
 	group := &pb.Group{
 		Members: make(map[uint64]*pb.Member),
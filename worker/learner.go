@@ -0,0 +1,96 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+)
+
+// maxLearnerLag is the number of Raft entries a learner can be behind the commit index of its
+// group, and still be considered caught up.
+const maxLearnerLag = 1000
+
+// caughtUp returns true if a node which has applied the given index is close enough to the given
+// commit index of its group to be promoted to a voter.
+func caughtUp(commit, applied uint64) bool {
+	if commit == 0 || applied == 0 {
+		// We haven't heard from the leader, or haven't received the snapshot yet.
+		return false
+	}
+	return applied >= commit || commit-applied <= maxLearnerLag
+}
+
+// provisionalLearner returns true if Zero made this node a learner while it joined the group,
+// and it must be promoted to a voter once it has caught up. Nodes started as learners stay so.
+func provisionalLearner(state *pb.MembershipState, gid uint32, id uint64) bool {
+	m := state.GetGroups()[gid].GetMembers()[id]
+	return m.GetLearner() && !x.WorkerConfig.Raft.GetBool("learner")
+}
+
+// promote asks the leader of the group to make this node a voter. Adding a learner to the group
+// again as a regular node promotes it.
+func (n *node) promote() error {
+	pl, err := n.leaderBlocking()
+	if err != nil {
+		return err
+	}
+	rc := *n.RaftContext
+	rc.IsLearner = false
+
+	ctx, cancel := context.WithTimeout(n.ctx, time.Minute)
+	defer cancel()
+	c := pb.NewRaftClient(pl.Get())
+	if _, err := c.JoinCluster(ctx, &rc); err != nil {
+		return errors.Wrapf(err, "while promoting node %#x to a voter", n.Id)
+	}
+	return nil
+}
+
+// promoteWhenCaughtUp waits for this learner to catch up with its group, and then promotes it to
+// a voter, letting Zero know about it.
+func (n *node) promoteWhenCaughtUp() {
+	ticker := time.NewTicker(5 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-n.closer.HasBeenClosed():
+			return
+		case <-ticker.C:
+		}
+		commit, applied := n.Raft().Status().Commit, n.Applied.DoneUntil()
+		if !caughtUp(commit, applied) {
+			glog.V(2).Infof("Learner %#x has applied %d of %d. Waiting to catch up.",
+				n.Id, applied, commit)
+			continue
+		}
+		if err := n.promote(); err != nil {
+			glog.Errorf("Unable to promote learner: %v", err)
+			continue
+		}
+		n.RaftContext.IsLearner = false
+		glog.Infof("Promoted node %#x to a voter of group %d", n.Id, n.gid)
+		groups().triggerMembershipSync()
+		return
+	}
+}
@@ -20,6 +20,8 @@ import (
 	"bytes"
 	"context"
 	"math"
+	"sort"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -41,6 +43,8 @@ import (
 	"github.com/dgraph-io/dgraph/protos/pb"
 	"github.com/dgraph-io/dgraph/schema"
 	"github.com/dgraph-io/dgraph/types"
+	"github.com/dgraph-io/dgraph/types/facets"
+	"github.com/dgraph-io/dgraph/validate"
 	"github.com/dgraph-io/dgraph/x"
 	"github.com/dgraph-io/ristretto/z"
 )
@@ -49,7 +53,8 @@ var (
 	// ErrNonExistentTabletMessage is the error message sent when no tablet is serving a predicate.
 	ErrNonExistentTabletMessage = "Requested predicate is not being served by any tablet"
 	errNonExistentTablet        = errors.Errorf(ErrNonExistentTabletMessage)
-	errUnservedTablet           = errors.Errorf("Tablet isn't being served by this instance")
+	errUnservedTablet           = x.WithErrorCode(
+		errors.Errorf("Tablet isn't being served by this instance"), x.ErrorCodeRetryable)
 )
 
 // Default limit on number of simultaneous open files on unix systems
@@ -96,8 +101,11 @@ func runMutation(ctx context.Context, edge *pb.DirectedEdge, txn *posting.Txn) e
 	// the rollup operation would consolidate all these deltas into a posting list.
 	var getFn func(key []byte) (*posting.List, error)
 	switch {
-	case len(su.GetTokenizer()) > 0 || su.GetCount():
-		// Any index or count index.
+	case len(su.GetTokenizer()) > 0 || su.GetCount() || su.GetPresence():
+		// Any index, count index or presence list.
+		getFn = txn.Get
+	case len(su.GetFacetIndex()) > 0:
+		// The facet index entries of the replaced postings need to be removed.
 		getFn = txn.Get
 	case su.GetValueType() == pb.Posting_UID && !su.GetList():
 		// Single UID, not a list.
@@ -169,14 +177,24 @@ func runSchemaMutation(ctx context.Context, updates []*pb.SchemaUpdate, startTs
 				if time.Since(start) < 10*time.Second || !gr.Node.AmLeader() {
 					return
 				}
-				if err := gr.Node.proposeSnapshot(); err != nil {
+				if _, err := gr.Node.proposeSnapshot(); err != nil {
 					glog.Errorf("error in proposing snapshot: %v", err)
 				}
 			}
 		}
 	}
 
-	buildIndexesHelper := func(update *pb.SchemaUpdate, rebuild posting.IndexRebuild) error {
+	buildIndexesHelper := func(update *pb.SchemaUpdate, rebuild posting.IndexRebuild) (rerr error) {
+		// The rebuild is reported as a task. It can't be cancelled, as that would leave the
+		// indexes stale.
+		task := Tasks.Start(TaskKindIndexRebuild, nil)
+		defer func() { task.Finish(rerr) }()
+		task.TrackProgress(func() (uint64, uint64) {
+			_, progress := posting.IndexStateOf(rebuild.Attr)
+			return progress, 0
+		})
+		task.Logf("Rebuilding the indexes of predicate %s", x.ParseAttr(rebuild.Attr))
+
 		wrtCtx := schema.GetWriteContext(context.Background())
 		if err := rebuild.BuildIndexes(wrtCtx); err != nil {
 			return err
@@ -459,9 +477,128 @@ func checkSchema(s *pb.SchemaUpdate) error {
 	return nil
 }
 
+// convertFacets converts the facets of the edge to the types declared for them in the schema.
+func convertFacets(edge *pb.DirectedEdge, su *pb.SchemaUpdate) error {
+	declared := su.GetFacets()
+	if len(declared) == 0 {
+		return nil
+	}
+	for i, f := range edge.Facets {
+		idx := sort.Search(len(declared), func(j int) bool {
+			return declared[j].Key >= f.Key
+		})
+		if idx == len(declared) || declared[idx].Key != f.Key {
+			continue
+		}
+		fc, err := facets.ConvertTo(f, declared[idx].ValType)
+		if err != nil {
+			return errors.Wrapf(err, "Facet %q of predicate %q should be of type %s", f.Key,
+				x.ParseAttr(edge.Attr), strings.ToLower(declared[idx].ValType.String()))
+		}
+		edge.Facets[i] = fc
+	}
+	return nil
+}
+
+// checkMutationLimits counts the postings written by the edges of a mutation, and the edges it
+// adds to each node, against the txn-postings and txn-node-edges limits of the txn. The reverse
+// edges are counted as added to their object. The mutation is rejected as a whole if it goes past
+// a limit, with an error naming the limit and, for the edges added to a node, the node and the
+// predicate adding the most edges to it.
+func checkMutationLimits(ctx context.Context, txn *posting.Txn, edges []*pb.DirectedEdge) error {
+	maxPostings, maxNodeEdges := x.Config.LimitTxnPostings, x.Config.LimitTxnNodeEdges
+	if maxPostings <= 0 && maxNodeEdges <= 0 {
+		return nil
+	}
+
+	var nodeEdges map[uint64]int
+	predEdges := make(map[uint64]map[string]int)
+	if maxNodeEdges > 0 {
+		nodeEdges = make(map[uint64]int)
+		addEdge := func(uid uint64, attr string) {
+			nodeEdges[uid]++
+			if predEdges[uid] == nil {
+				predEdges[uid] = make(map[string]int)
+			}
+			predEdges[uid][attr]++
+		}
+		ctx = schema.GetWriteContext(ctx)
+		for _, edge := range edges {
+			if edge.Op != pb.DirectedEdge_SET {
+				continue
+			}
+			attr := x.ParseAttr(edge.Attr)
+			addEdge(edge.Entity, attr)
+			if edge.ValueId != 0 && schema.State().IsReversed(ctx, edge.Attr) {
+				addEdge(edge.ValueId, "~"+attr)
+			}
+		}
+	}
+
+	check := func(numPostings int, totals map[uint64]int) error {
+		if maxPostings > 0 && numPostings > maxPostings {
+			return errors.Errorf("Transaction would write %d postings, which is more than the "+
+				"txn-postings limit: %d", numPostings, maxPostings)
+		}
+		// Report the node with the smallest uid, so that all the replicas return the same error.
+		var node uint64
+		for uid, num := range totals {
+			if num > maxNodeEdges && (node == 0 || uid < node) {
+				node = uid
+			}
+		}
+		if node == 0 {
+			return nil
+		}
+		var pred string
+		for attr, num := range predEdges[node] {
+			if pred == "" || num > predEdges[node][pred] ||
+				(num == predEdges[node][pred] && attr < pred) {
+				pred = attr
+			}
+		}
+		return errors.Errorf("Transaction would add %d edges to node %#x, mostly through "+
+			"predicate %s, which is more than the txn-node-edges limit: %d",
+			totals[node], node, pred, maxNodeEdges)
+	}
+	return txn.CountEdges(len(edges), nodeEdges, check)
+}
+
 // ValidateAndConvert checks compatibility or converts to the schema type if the storage type is
-// specified. If no storage type is specified then it converts to the schema type.
+// specified. If no storage type is specified then it converts to the schema type. The value is
+// then replaced by the one returned by the validators of the predicate, if any.
 func ValidateAndConvert(edge *pb.DirectedEdge, su *pb.SchemaUpdate) error {
+	if err := convertToSchemaType(edge, su); err != nil {
+		return err
+	}
+	return runValidators(edge, su)
+}
+
+// runValidators calls the validators of the predicate on the value set by the edge, which must
+// be of the schema type, and replaces the value by the one they return.
+func runValidators(edge *pb.DirectedEdge, su *pb.SchemaUpdate) error {
+	if len(su.GetValidators()) == 0 || edge.Op != pb.DirectedEdge_SET || edge.ValueId != 0 {
+		return nil
+	}
+	src := types.Val{Tid: types.TypeID(edge.ValueType), Value: edge.Value}
+	val, err := types.Convert(src, src.Tid)
+	if err != nil {
+		return err
+	}
+	if val, err = validate.Apply(su.GetValidators(), val); err != nil {
+		return errors.Wrapf(err, "invalid value for predicate %q", x.ParseAttr(edge.Attr))
+	}
+	b := types.ValueForType(types.BinaryID)
+	if err := types.Marshal(val, &b); err != nil {
+		return errors.Wrapf(err, "invalid value returned by the validators of predicate %q",
+			x.ParseAttr(edge.Attr))
+	}
+	edge.Value = b.Value.([]byte)
+	return nil
+}
+
+// convertToSchemaType converts the value of the edge to the schema type of its predicate.
+func convertToSchemaType(edge *pb.DirectedEdge, su *pb.SchemaUpdate) error {
 	if isDeletePredicateEdge(edge) {
 		return nil
 	}
@@ -469,6 +606,13 @@ func ValidateAndConvert(edge *pb.DirectedEdge, su *pb.SchemaUpdate) error {
 		return nil
 	}
 
+	if err := convertFacets(edge, su); err != nil {
+		return err
+	}
+	if _, _, _, err := facets.ValidTime(edge.Facets); err != nil {
+		return errors.Wrapf(err, "invalid valid time for predicate %q", x.ParseAttr(edge.Attr))
+	}
+
 	storageType := posting.TypeID(edge)
 	schemaType := types.TypeID(su.ValueType)
 
@@ -561,6 +705,19 @@ func AssignUidsOverNetwork(ctx context.Context, num *pb.Num) (*pb.AssignedIds, e
 	return c.AssignIds(ctx, num)
 }
 
+// AssignSequenceOverNetwork sends a request to assign the next num values of the sequence
+// predicates to the current zero leader.
+func AssignSequenceOverNetwork(ctx context.Context, num uint64) (*pb.AssignedIds, error) {
+	pl := groups().Leader(0)
+	if pl == nil {
+		return nil, conn.ErrNoConnection
+	}
+
+	con := pl.Get()
+	c := pb.NewZeroClient(con)
+	return c.AssignIds(ctx, &pb.Num{Val: num, Type: pb.Num_SEQ})
+}
+
 // Timestamps sends a request to assign startTs for a new transaction to the current zero leader.
 func Timestamps(ctx context.Context, num *pb.Num) (*pb.AssignedIds, error) {
 	pl := groups().connToZeroLeader()
@@ -665,6 +822,19 @@ func populateMutationMap(src *pb.Mutations) (map[uint32]*pb.Mutations, error) {
 		}
 	}
 
+	// Savepoints are sent to all groups, as the txn could have written to any of them.
+	if src.Savepoint != "" || src.RollbackTo != "" {
+		for _, gid := range groups().KnownGroups() {
+			mu := mm[gid]
+			if mu == nil {
+				mu = &pb.Mutations{GroupId: gid}
+				mm[gid] = mu
+			}
+			mu.Savepoint = src.Savepoint
+			mu.RollbackTo = src.RollbackTo
+		}
+	}
+
 	// Type definitions are sent to all groups.
 	if len(src.Types) > 0 {
 		for _, gid := range groups().KnownGroups() {
@@ -858,6 +1028,8 @@ func CommitOverNetwork(ctx context.Context, tc *api.TxnContext) (uint64, error)
 		return 0, dgo.ErrAborted
 	}
 	ostats.Record(ctx, x.TxnCommits.M(1))
+	// The start timestamps leased ahead must not go behind this commit.
+	posting.Oracle().ObserveCommitTs(tctx.CommitTs)
 	return tctx.CommitTs, nil
 }
 
@@ -17,15 +17,18 @@
 package worker
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/dgraph-io/dgo/v210/protos/api"
 	"github.com/dgraph-io/dgraph/posting"
 	"github.com/dgraph-io/dgraph/protos/pb"
 	"github.com/dgraph-io/dgraph/schema"
 	"github.com/dgraph-io/dgraph/types"
+	"github.com/dgraph-io/dgraph/types/facets"
 	"github.com/dgraph-io/dgraph/x"
 )
 
@@ -110,6 +113,71 @@ func TestValidateEdgeTypeError(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestValidateEdgeValidators(t *testing.T) {
+	su := &pb.SchemaUpdate{
+		ValueType:  pb.Posting_STRING,
+		Validators: []string{"trim", "lowercase"},
+	}
+	edge := &pb.DirectedEdge{
+		Value: []byte("  Alice@Example.COM "),
+		Attr:  x.GalaxyAttr("email"),
+	}
+	require.NoError(t, ValidateAndConvert(edge, su))
+	require.Equal(t, "alice@example.com", string(edge.Value))
+	require.Equal(t, pb.Posting_STRING, edge.ValueType)
+
+	// The values of the deletions are left as they are.
+	edge = &pb.DirectedEdge{
+		Value: []byte(" Bob "),
+		Attr:  x.GalaxyAttr("email"),
+		Op:    pb.DirectedEdge_DEL,
+	}
+	require.NoError(t, ValidateAndConvert(edge, su))
+	require.Equal(t, " Bob ", string(edge.Value))
+
+	su.Validators = []string{"url"}
+	edge = &pb.DirectedEdge{
+		Value: []byte("not a url"),
+		Attr:  x.GalaxyAttr("homepage"),
+	}
+	require.Error(t, ValidateAndConvert(edge, su))
+}
+
+func TestValidateEdgeFacetTypes(t *testing.T) {
+	su := &pb.SchemaUpdate{
+		ValueType: pb.Posting_UID,
+		Facets: []*api.Facet{
+			{Key: "since", ValType: api.Facet_DATETIME},
+			{Key: "weight", ValType: api.Facet_FLOAT},
+		},
+	}
+	newEdge := func(key, val string) *pb.DirectedEdge {
+		f, err := facets.FacetFor(key, val)
+		require.NoError(t, err)
+		return &pb.DirectedEdge{
+			Attr:      x.GalaxyAttr("friend"),
+			ValueId:   2,
+			ValueType: pb.Posting_UID,
+			Facets:    []*api.Facet{f},
+		}
+	}
+
+	edge := newEdge("weight", "3")
+	require.NoError(t, ValidateAndConvert(edge, su))
+	require.Equal(t, api.Facet_FLOAT, edge.Facets[0].ValType)
+	val, err := facets.ValFor(edge.Facets[0])
+	require.NoError(t, err)
+	require.Equal(t, float64(3), val.Value)
+
+	// Undeclared facets keep the inferred type.
+	edge = newEdge("close", "3")
+	require.NoError(t, ValidateAndConvert(edge, su))
+	require.Equal(t, api.Facet_INT, edge.Facets[0].ValType)
+
+	require.Error(t, ValidateAndConvert(newEdge("since", "true"), su))
+	require.Error(t, ValidateAndConvert(newEdge("weight", `"heavy"`), su))
+}
+
 func TestPopulateMutationMap(t *testing.T) {
 	edges := []*pb.DirectedEdge{{
 		Value: []byte("set edge"),
@@ -261,3 +329,31 @@ func TestTypeSanityCheck(t *testing.T) {
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "Field in type definition cannot have tokenizers")
 }
+
+func TestCheckMutationLimits(t *testing.T) {
+	defer func(postings, nodeEdges int) {
+		x.Config.LimitTxnPostings, x.Config.LimitTxnNodeEdges = postings, nodeEdges
+	}(x.Config.LimitTxnPostings, x.Config.LimitTxnNodeEdges)
+	x.Config.LimitTxnPostings, x.Config.LimitTxnNodeEdges = 5, 2
+
+	ctx := context.Background()
+	attr := x.GalaxyAttr("friend")
+	edge := func(src, dst uint64) *pb.DirectedEdge {
+		return &pb.DirectedEdge{Entity: src, Attr: attr, ValueId: dst, Op: pb.DirectedEdge_SET}
+	}
+	txn := posting.NewTxn(10)
+	require.NoError(t, checkMutationLimits(ctx, txn,
+		[]*pb.DirectedEdge{edge(1, 2), edge(1, 3), edge(2, 3)}))
+
+	// The node 0x1 would get a third edge.
+	err := checkMutationLimits(ctx, txn, []*pb.DirectedEdge{edge(1, 4)})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "node 0x1, mostly through predicate friend")
+	require.Contains(t, err.Error(), "txn-node-edges")
+
+	// The rejected mutation wasn't counted.
+	require.NoError(t, checkMutationLimits(ctx, txn, []*pb.DirectedEdge{edge(2, 4)}))
+	err = checkMutationLimits(ctx, txn, []*pb.DirectedEdge{edge(3, 4), edge(4, 5)})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "txn-postings")
+}
@@ -430,7 +430,7 @@ func handleRestoreProposal(ctx context.Context, req *pb.RestoreRequest, pidx uin
 			glog.Errorf("Error waiting for mark for index %d: %+v", idx, err)
 			return
 		}
-		if err := n.proposeSnapshot(); err != nil {
+		if _, err := n.proposeSnapshot(); err != nil {
 			glog.Errorf("cannot propose snapshot after processing restore proposal %+v", err)
 		}
 	}(pidx)
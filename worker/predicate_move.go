@@ -21,11 +21,14 @@ import (
 	"fmt"
 	"io"
 	"strconv"
+	"sync/atomic"
+	"time"
 
 	"github.com/dustin/go-humanize"
 	"github.com/golang/glog"
 	"github.com/pkg/errors"
 	otrace "go.opencensus.io/trace"
+	"google.golang.org/grpc/metadata"
 
 	"github.com/dgraph-io/badger/v3"
 	bpb "github.com/dgraph-io/badger/v3/pb"
@@ -51,6 +54,66 @@ const (
 	CleanPredicate
 )
 
+// MoveRateLimitKey is the gRPC metadata key through which Zero passes the bandwidth limit of a
+// predicate move, in bytes per second, to the leader of the source group.
+const MoveRateLimitKey = "move-rate-limit"
+
+// moveRateLimit returns the bandwidth limit set by Zero for the predicate move, or 0 if the move
+// isn't throttled.
+func moveRateLimit(ctx context.Context) int64 {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return 0
+	}
+	val := md.Get(MoveRateLimitKey)
+	if len(val) == 0 {
+		return 0
+	}
+	rate, err := strconv.ParseInt(val[0], 10, 64)
+	if err != nil || rate < 0 {
+		glog.Warningf("Ignoring invalid move rate limit: %q", val[0])
+		return 0
+	}
+	return rate
+}
+
+// moveThrottle keeps the average rate at which a predicate is sent under the limit by sleeping
+// whenever the sender gets ahead of it.
+type moveThrottle struct {
+	rate  int64 // bytes per second, 0 means no limit.
+	start time.Time
+	sent  int64
+}
+
+func newMoveThrottle(rate int64) *moveThrottle {
+	return &moveThrottle{rate: rate, start: time.Now()}
+}
+
+// wait accounts for n bytes being sent, and blocks until sending them is within the limit.
+func (t *moveThrottle) wait(ctx context.Context, n int) error {
+	sent := atomic.AddInt64(&t.sent, int64(n))
+	if t.rate <= 0 {
+		return nil
+	}
+	due := t.start.Add(time.Duration(float64(sent) / float64(t.rate) * float64(time.Second)))
+	d := time.Until(due)
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (t *moveThrottle) bytesSent() int64 {
+	return atomic.LoadInt64(&t.sent)
+}
+
 // size of kvs won't be too big, we would take care before proposing.
 func populateKeyValues(ctx context.Context, kvs []*bpb.KV) error {
 	glog.Infof("Writing %d keys\n", len(kvs))
@@ -255,20 +318,31 @@ func (w *grpcWorker) MovePredicate(ctx context.Context,
 	glog.Info(msg)
 	span.Annotate(nil, msg)
 
-	err = movePredicateHelper(ctx, in)
+	// The move is reported as a task. Cancelling it fails the move, which Zero then aborts.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	task := Tasks.Start(TaskKindMoveTablet, cancel)
+	task.Logf("Moving predicate %s from group %d to group %d, since ts %d",
+		x.ParseAttr(in.Predicate), in.SourceGid, in.DestGid, in.SinceTs)
+
+	// The number of bytes sent is returned to Zero, which uses it to report the move progress.
+	sent, err := movePredicateHelper(ctx, in, task)
+	task.Finish(err)
 	if err != nil {
 		span.Annotatef(nil, "Error while movePredicateHelper: %v", err)
+		return &emptyPayload, err
 	}
-	return &emptyPayload, err
+	return &api.Payload{Data: []byte(strconv.FormatInt(sent, 10))}, nil
 }
 
-func movePredicateHelper(ctx context.Context, in *pb.MovePredicatePayload) error {
+func movePredicateHelper(ctx context.Context, in *pb.MovePredicatePayload,
+	task *Task) (int64, error) {
 	// Note: Manish thinks it *should* be OK for a predicate receiver to not have to stop other
 	// operations like snapshots and rollups. Note that this is the sender. This should stop other
 	// operations.
 	closer, err := groups().Node.startTask(opPredMove)
 	if err != nil {
-		return errors.Wrapf(err, "unable to start task opPredMove")
+		return 0, errors.Wrapf(err, "unable to start task opPredMove")
 	}
 	defer closer.Done()
 
@@ -276,12 +350,21 @@ func movePredicateHelper(ctx context.Context, in *pb.MovePredicatePayload) error
 
 	pl := groups().Leader(in.DestGid)
 	if pl == nil {
-		return errors.Errorf("Unable to find a connection for group: %d\n", in.DestGid)
+		return 0, errors.Errorf("Unable to find a connection for group: %d\n", in.DestGid)
 	}
 	c := pb.NewWorkerClient(pl.Get())
 	out, err := c.ReceivePredicate(ctx)
 	if err != nil {
-		return errors.Wrapf(err, "while calling ReceivePredicate")
+		return 0, errors.Wrapf(err, "while calling ReceivePredicate")
+	}
+
+	throttle := newMoveThrottle(moveRateLimit(ctx))
+	task.TrackProgress(func() (uint64, uint64) {
+		return uint64(throttle.bytesSent()), 0
+	})
+	if throttle.rate > 0 {
+		glog.Infof("Sending predicate: [%s] at up to %s/s", in.Predicate,
+			humanize.IBytes(uint64(throttle.rate)))
 	}
 
 	txn := pstore.NewTransactionAt(in.ReadTs, false)
@@ -295,11 +378,11 @@ func movePredicateHelper(ctx context.Context, in *pb.MovePredicatePayload) error
 		// The predicate along with the schema could have been deleted. In that case badger would
 		// return ErrKeyNotFound. We don't want to try and access item.Value() in that case.
 	case err != nil:
-		return err
+		return 0, err
 	default:
 		val, err := item.ValueCopy(nil)
 		if err != nil {
-			return err
+			return 0, err
 		}
 		buf := z.NewBuffer(1024, "PredicateMove.MovePredicateHelper")
 		defer buf.Release()
@@ -318,8 +401,11 @@ func movePredicateHelper(ctx context.Context, in *pb.MovePredicatePayload) error
 		kvs := &pb.KVS{
 			Data: buf.Bytes(),
 		}
+		if err := throttle.wait(ctx, len(kvs.Data)); err != nil {
+			return 0, err
+		}
 		if err := out.Send(kvs); err != nil {
-			return errors.Errorf("while sending: %v", err)
+			return 0, errors.Errorf("while sending: %v", err)
 		}
 	}
 
@@ -366,23 +452,27 @@ func movePredicateHelper(ctx context.Context, in *pb.MovePredicatePayload) error
 		kvs := &pb.KVS{
 			Data: buf.Bytes(),
 		}
+		if err := throttle.wait(out.Context(), len(kvs.Data)); err != nil {
+			return err
+		}
 		return out.Send(kvs)
 	}
 	span.Annotatef(nil, "Starting stream list orchestrate")
 	if err := stream.Orchestrate(out.Context()); err != nil {
-		return err
+		return 0, err
 	}
 
 	payload, err := out.CloseAndRecv()
 	if err != nil {
-		return err
+		return 0, err
 	}
 	recvCount, err := strconv.Atoi(string(payload.Data))
 	if err != nil {
-		return err
+		return 0, err
 	}
-	msg := fmt.Sprintf("Receiver %s says it got %d keys.\n", pl.Addr, recvCount)
+	msg := fmt.Sprintf("Receiver %s says it got %d keys, %s sent.", pl.Addr, recvCount,
+		humanize.IBytes(uint64(throttle.bytesSent())))
 	span.Annotate(nil, msg)
-	glog.Infof(msg)
-	return nil
+	task.Logf("%s", msg)
+	return throttle.bytesSent(), nil
 }
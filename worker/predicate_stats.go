@@ -0,0 +1,284 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/badger/v3"
+	"github.com/dgraph-io/dgraph/ee/enc"
+	"github.com/dgraph-io/dgraph/posting"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+)
+
+const (
+	// predicateStatsFile is the file of the posting directory which keeps the last predicate
+	// stats computed, so that they are available right after a restart.
+	predicateStatsFile = "predicate_stats.json"
+	// predicateStatsInterval is the interval at which the predicate stats are computed. Computing
+	// them reads all the keys of the group, so it shouldn't run often.
+	predicateStatsInterval = 30 * time.Minute
+)
+
+// DiskStats holds the storage stats of a predicate, read from the keys of the predicate in the
+// posting directory.
+type DiskStats struct {
+	Predicate string `json:"predicate"`
+	Namespace uint64 `json:"namespace"`
+	// KeyCount is the number of data keys of the predicate, one per node having the predicate.
+	KeyCount uint64 `json:"keyCount"`
+	// TotalBytes is the estimated size of all the versions of all the keys of the predicate,
+	// including its indexes.
+	TotalBytes int64 `json:"totalBytes"`
+	// SplitLists is the number of posting lists of the predicate split in several parts.
+	SplitLists uint64 `json:"splitLists"`
+	// AvgDeltaChain is the average number of deltas above the latest complete posting list of the
+	// data keys. A long chain means that the reads merge many deltas until the lists are rolled
+	// up.
+	AvgDeltaChain float64 `json:"avgDeltaChain"`
+	// IndexBytes, ReverseBytes and CountBytes are the estimated sizes of the term indexes, the
+	// reverse edges and the count indexes of the predicate.
+	IndexBytes   int64 `json:"indexBytes"`
+	ReverseBytes int64 `json:"reverseBytes"`
+	CountBytes   int64 `json:"countBytes"`
+
+	deltas uint64
+}
+
+// PredicateStatsReport is the result of a pass computing the stats of the predicates served by
+// the group of this Alpha.
+type PredicateStatsReport struct {
+	GroupId    uint32       `json:"groupId"`
+	ReadTs     uint64       `json:"readTs"`
+	ComputedAt time.Time    `json:"computedAt"`
+	Duration   string       `json:"duration"`
+	Predicates []*DiskStats `json:"predicates"`
+}
+
+var predicateStats struct {
+	sync.RWMutex
+	report *PredicateStatsReport
+}
+
+// GetPredicateStats returns the last predicate stats computed by this Alpha, or nil if they
+// haven't been computed yet. The stats are computed in the background, see
+// processPredicateStats, so the call doesn't read the data.
+func GetPredicateStats() *PredicateStatsReport {
+	predicateStats.RLock()
+	defer predicateStats.RUnlock()
+	return predicateStats.report
+}
+
+func predicateStatsPath() string {
+	return filepath.Join(Config.PostingDir, predicateStatsFile)
+}
+
+// loadPredicateStats reads the predicate stats persisted by the previous run, if any.
+func loadPredicateStats() error {
+	f, err := os.Open(predicateStatsPath())
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	r, err := enc.GetReader(x.WorkerConfig.EncryptionKey, f)
+	if err != nil {
+		return err
+	}
+	var report PredicateStatsReport
+	if err := json.NewDecoder(r).Decode(&report); err != nil {
+		return errors.Wrapf(err, "while decoding %s", predicateStatsFile)
+	}
+	predicateStats.Lock()
+	defer predicateStats.Unlock()
+	if predicateStats.report == nil {
+		predicateStats.report = &report
+	}
+	return nil
+}
+
+// savePredicateStats persists the report, encrypted if encryption at rest is enabled. The file is
+// replaced atomically, so that a crash can't leave a partial report behind.
+func savePredicateStats(report *PredicateStatsReport) error {
+	var buf bytes.Buffer
+	w, err := enc.GetWriter(x.WorkerConfig.EncryptionKey, &buf)
+	if err != nil {
+		return err
+	}
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(Config.PostingDir, predicateStatsFile+".*")
+	if err != nil {
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := x.WriteFileSync(tmp.Name(), buf.Bytes(), 0600); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), predicateStatsPath())
+}
+
+// computePredicateStats reads all the versions of the keys of pstore at readTs, and returns the
+// stats of the predicates found, sorted by predicate.
+func computePredicateStats(db *badger.DB, readTs uint64) []*DiskStats {
+	txn := db.NewTransactionAt(readTs, false)
+	defer txn.Discard()
+	iopt := badger.DefaultIteratorOptions
+	iopt.AllVersions = true
+	iopt.PrefetchValues = false
+	itr := txn.NewIterator(iopt)
+	defer itr.Close()
+
+	stats := make(map[string]*DiskStats)
+	// The parts of a split list are stored next to each other, under the same base key followed
+	// by the start uid of the part.
+	var lastSplit []byte
+	for itr.Rewind(); itr.Valid(); {
+		item := itr.Item()
+		key := item.KeyCopy(nil)
+		pk, err := x.Parse(key)
+		if err != nil || pk.Attr == "" || pk.IsSchema() || pk.IsType() {
+			// Skip the keys which don't belong to a predicate, along with all their versions.
+			for ; itr.Valid() && bytes.Equal(itr.Item().Key(), key); itr.Next() {
+			}
+			continue
+		}
+		st, ok := stats[pk.Attr]
+		if !ok {
+			st = &DiskStats{
+				Predicate: x.ParseAttr(pk.Attr),
+				Namespace: x.ParseNamespace(pk.Attr),
+			}
+			stats[pk.Attr] = st
+		}
+		if pk.HasStartUid {
+			base := key[:len(key)-8]
+			if !bytes.Equal(base, lastSplit) {
+				st.SplitLists++
+				lastSplit = append(lastSplit[:0], base...)
+			}
+		} else if pk.IsData() {
+			st.KeyCount++
+		}
+
+		// The versions are iterated from the latest. The chain of deltas stops at the first
+		// complete or empty posting list.
+		chainDone := false
+		for ; itr.Valid() && bytes.Equal(itr.Item().Key(), key); itr.Next() {
+			item := itr.Item()
+			sz := item.EstimatedSize()
+			st.TotalBytes += sz
+			switch {
+			case pk.IsIndex():
+				st.IndexBytes += sz
+			case pk.IsReverse():
+				st.ReverseBytes += sz
+			case pk.IsCountOrCountRev():
+				st.CountBytes += sz
+			}
+			if !pk.IsData() || pk.HasStartUid || chainDone {
+				continue
+			}
+			if item.UserMeta()&posting.BitDeltaPosting > 0 {
+				st.deltas++
+			} else {
+				chainDone = true
+			}
+		}
+	}
+
+	res := make([]*DiskStats, 0, len(stats))
+	for _, st := range stats {
+		if st.KeyCount > 0 {
+			st.AvgDeltaChain = float64(st.deltas) / float64(st.KeyCount)
+		}
+		res = append(res, st)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Namespace != res[j].Namespace {
+			return res[i].Namespace < res[j].Namespace
+		}
+		return res[i].Predicate < res[j].Predicate
+	})
+	return res
+}
+
+// updatePredicateStats computes the stats of the predicates of the group, and persists them.
+func (n *node) updatePredicateStats() error {
+	start := time.Now()
+	readTs := posting.Oracle().MaxAssigned()
+	preds := computePredicateStats(pstore, readTs)
+	report := &PredicateStatsReport{
+		GroupId:    n.gid,
+		ReadTs:     readTs,
+		ComputedAt: start,
+		Duration:   time.Since(start).Round(time.Millisecond).String(),
+		Predicates: preds,
+	}
+	predicateStats.Lock()
+	predicateStats.report = report
+	predicateStats.Unlock()
+	glog.V(2).Infof("Computed the stats of %d predicates in %s", len(preds), report.Duration)
+	return savePredicateStats(report)
+}
+
+func (n *node) processPredicateStats() {
+	defer n.closer.Done() // CLOSER:1
+	if err := loadPredicateStats(); err != nil {
+		glog.Warningf("While loading the predicate stats: %v", err)
+	}
+	// Without stats from the previous run, compute them soon after the start rather than waiting
+	// for the first tick.
+	var first <-chan time.Time
+	if GetPredicateStats() == nil {
+		first = time.After(time.Minute)
+	}
+	tick := time.NewTicker(predicateStatsInterval)
+	defer tick.Stop()
+
+	update := func() {
+		if err := n.updatePredicateStats(); err != nil {
+			glog.Errorf("While computing the predicate stats: %v", err)
+		}
+	}
+	for {
+		select {
+		case <-n.closer.HasBeenClosed():
+			return
+		case <-first:
+			update()
+		case <-tick.C:
+			update()
+		}
+	}
+}
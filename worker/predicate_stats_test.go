@@ -0,0 +1,76 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/dgraph-io/badger/v3"
+	"github.com/dgraph-io/dgraph/posting"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputePredicateStats(t *testing.T) {
+	dir, err := ioutil.TempDir("", "predstats_")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	db, err := badger.OpenManaged(badger.DefaultOptions(dir).WithLogger(nil))
+	require.NoError(t, err)
+	defer db.Close()
+
+	attr := x.GalaxyAttr("name")
+	set := func(key []byte, meta byte, ts uint64) {
+		txn := db.NewTransactionAt(ts, true)
+		defer txn.Discard()
+		require.NoError(t, txn.SetEntry(badger.NewEntry(key, []byte("val")).WithMeta(meta)))
+		require.NoError(t, txn.CommitAt(ts, nil))
+	}
+	// The first node has two deltas above its complete list, the second one only has a delta.
+	set(x.DataKey(attr, 1), posting.BitCompletePosting, 1)
+	set(x.DataKey(attr, 1), posting.BitDeltaPosting, 2)
+	set(x.DataKey(attr, 1), posting.BitDeltaPosting, 3)
+	set(x.DataKey(attr, 2), posting.BitDeltaPosting, 3)
+	set(x.IndexKey(attr, "term"), posting.BitDeltaPosting, 3)
+	// A list of the second node split in two parts.
+	for _, start := range []uint64{1, 100} {
+		key, err := x.SplitKey(x.DataKey(attr, 2), start)
+		require.NoError(t, err)
+		set(key, posting.BitCompletePosting, 3)
+	}
+	set(x.SchemaKey(attr), posting.BitSchemaPosting, 3)
+
+	stats := computePredicateStats(db, 3)
+	require.Len(t, stats, 1)
+	st := stats[0]
+	require.Equal(t, "name", st.Predicate)
+	require.Equal(t, x.GalaxyNamespace, st.Namespace)
+	require.Equal(t, uint64(2), st.KeyCount)
+	require.Equal(t, uint64(1), st.SplitLists)
+	require.Equal(t, 1.5, st.AvgDeltaChain)
+	require.Greater(t, st.IndexBytes, int64(0))
+	require.Greater(t, st.TotalBytes, st.IndexBytes)
+	require.Zero(t, st.ReverseBytes)
+
+	// The stats at an earlier timestamp don't see the later versions.
+	stats = computePredicateStats(db, 1)
+	require.Len(t, stats, 1)
+	require.Equal(t, uint64(1), stats[0].KeyCount)
+	require.Zero(t, stats[0].AvgDeltaChain)
+}
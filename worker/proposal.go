@@ -20,6 +20,7 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/binary"
+	"fmt"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -129,7 +130,9 @@ func uniqueKey() uint64 {
 }
 
 var errInternalRetry = errors.New("Retry Raft proposal internally")
-var errUnableToServe = errors.New("Server overloaded with pending proposals. Please retry later")
+var errUnableToServe = x.WithErrorCode(
+	errors.New("Server overloaded with pending proposals. Please retry later"),
+	x.ErrorCodeResourceExhausted)
 
 // proposeAndWait sends a proposal through RAFT. It waits on a channel for the proposal
 // to be applied(written to WAL) to all the nodes in the group.
@@ -193,7 +196,7 @@ func (n *node) proposeAndWait(ctx context.Context, proposal *pb.Proposal) (perr
 				}
 				continue
 			} else if err := ValidateAndConvert(edge, &su); err != nil {
-				return err
+				return x.WithErrorCode(err, x.ErrorCodeSchema)
 			}
 		}
 
@@ -239,6 +242,7 @@ func (n *node) proposeAndWait(ctx context.Context, proposal *pb.Proposal) (perr
 
 		span.Annotatef(nil, "Proposing with key: %d. Timeout: %v", key, timeout)
 
+		proposedAt := time.Now()
 		if err = n.Raft().Propose(cctx, data); err != nil {
 			return errors.Wrapf(err, "While proposing")
 		}
@@ -250,6 +254,10 @@ func (n *node) proposeAndWait(ctx context.Context, proposal *pb.Proposal) (perr
 			select {
 			case err = <-errCh:
 				// We arrived here by a call to n.Proposals.Done().
+				if err == nil {
+					gctx, _ := tag.New(ctx, tag.Upsert(x.KeyGroup, fmt.Sprintf("%d", n.gid)))
+					ostats.Record(gctx, x.RaftProposalLatencyMs.M(x.SinceMs(proposedAt)))
+				}
 				return err
 			case <-ctx.Done():
 				return ctx.Err()
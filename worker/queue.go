@@ -23,6 +23,7 @@ import (
 	"math/rand"
 	"path/filepath"
 	"reflect"
+	"sort"
 	"sync"
 	"time"
 
@@ -35,6 +36,10 @@ import (
 	"github.com/pkg/errors"
 )
 
+// ErrTaskCancelled is given to Task.Finish when a task stopped because it was cancelled by other
+// means than the task API.
+var ErrTaskCancelled = errors.New("the task was cancelled")
+
 // TaskStatusOverNetwork fetches the status of a task over the network. Alphas only know about the
 // tasks created by them, but this function would fetch the task from the correct Alpha.
 func TaskStatusOverNetwork(ctx context.Context, req *pb.TaskStatusRequest,
@@ -75,17 +80,16 @@ func TaskStatusOverNetwork(ctx context.Context, req *pb.TaskStatusRequest,
 	return client.TaskStatus(ctx, req)
 }
 
-// TaskStatus retrieves metadata for a given task ID.
+// TaskStatus retrieves metadata for a given task ID, and cancels the task if asked to.
 func (*grpcWorker) TaskStatus(ctx context.Context, req *pb.TaskStatusRequest,
 ) (*pb.TaskStatusResponse, error) {
 	taskId := req.GetTaskId()
-	meta, err := Tasks.get(taskId)
-	if err != nil {
-		return nil, err
+	if req.GetCancel() {
+		if err := Tasks.cancel(taskId); err != nil {
+			return nil, err
+		}
 	}
-
-	resp := &pb.TaskStatusResponse{TaskMeta: meta.uint64()}
-	return resp, nil
+	return Tasks.status(taskId)
 }
 
 var (
@@ -105,6 +109,7 @@ func InitTasks() {
 		queue: make(chan taskRequest, 16),
 		log:   log,
 		logMu: new(sync.Mutex),
+		info:  make(map[uint64]*taskInfo),
 		rng:   rand.New(rand.NewSource(time.Now().UnixNano())),
 	}
 
@@ -130,10 +135,27 @@ type tasks struct {
 	// log stores the timestamp, TaskKind, and TaskStatus.
 	log   *z.Tree
 	logMu *sync.Mutex
+	// info holds the details of the tasks started since this Alpha started, which aren't
+	// persisted. It's guarded by logMu.
+	info map[uint64]*taskInfo
 
 	rng *rand.Rand
 }
 
+// maxTaskLogs is the number of log lines kept for each task.
+const maxTaskLogs = 100
+
+// taskInfo holds the details of a task which are only known while this Alpha runs.
+type taskInfo struct {
+	// cancel cancels the task while it's running, it's nil if the task can't be cancelled.
+	cancel    func()
+	cancelled bool
+	// progress returns the progress of the task, and its total if it's known.
+	progress func() (uint64, uint64)
+	logs     []string
+	err      string
+}
+
 // Enqueue adds a new task to the queue, waits for 3 seconds, and returns any errors that
 // may have happened in that span of time. The request must be of type:
 // - *pb.BackupRequest
@@ -195,6 +217,7 @@ func (t *tasks) enqueue(req interface{}) (uint64, error) {
 	// task, and won't be able to find it in t.log.
 	case t.queue <- task:
 		t.log.Set(task.id, newTaskMeta(kind, TaskStatusQueued).uint64())
+		t.info[task.id] = &taskInfo{}
 		return task.id, nil
 	default:
 		return 0, fmt.Errorf("too many pending tasks, please try again later")
@@ -259,26 +282,196 @@ func (t *tasks) run(task taskRequest) error {
 	}
 
 	// Change the task status to Running.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 	t.logMu.Lock()
 	t.log.Set(task.id, newTaskMeta(meta.Kind(), TaskStatusRunning).uint64())
+	t.infoOf(task.id).cancel = cancel
 	t.logMu.Unlock()
 
-	// Run the task.
-	var status TaskStatus
-	err := task.run()
-	if err != nil {
+	// Run the task, and change its status to Success / Failed.
+	err := task.run(ctx, &Task{id: task.id, t: t})
+	t.finish(task.id, err)
+
+	// Return the error from the task.
+	return err
+}
+
+// infoOf returns the details of the task. logMu must be acquired before calling this function.
+func (t *tasks) infoOf(id uint64) *taskInfo {
+	info, ok := t.info[id]
+	if !ok {
+		info = &taskInfo{}
+		t.info[id] = info
+	}
+	return info
+}
+
+// finish sets the final status of the running task, Cancelled if it failed after being
+// cancelled or with ErrTaskCancelled.
+func (t *tasks) finish(id uint64, err error) {
+	t.logMu.Lock()
+	defer t.logMu.Unlock()
+	meta := TaskMeta(t.log.Get(id))
+	if meta == 0 {
+		return
+	}
+	info := t.infoOf(id)
+	info.cancel, info.progress = nil, nil
+	status := TaskStatusSuccess
+	switch {
+	case err != nil && (info.cancelled || errors.Is(err, ErrTaskCancelled)):
+		status = TaskStatusCancelled
+	case err != nil:
 		status = TaskStatusFailed
-	} else {
-		status = TaskStatusSuccess
+		info.err = err.Error()
 	}
+	t.log.Set(id, newTaskMeta(meta.Kind(), status).uint64())
+}
 
-	// Change the task status to Success / Failed.
+// Start registers a task of the given kind which is run by the caller instead of the queue, e.g.
+// an index rebuild, so that it can be followed and cancelled like the queued tasks. The cancel
+// function can be nil if the task can't be cancelled. The caller must call Finish on the returned
+// task once it's done. Start returns nil if the task queue isn't initialized, and the methods of
+// a nil Task do nothing.
+func (t *tasks) Start(kind TaskKind, cancel func()) *Task {
+	if t == nil {
+		return nil
+	}
 	t.logMu.Lock()
-	t.log.Set(task.id, newTaskMeta(meta.Kind(), status).uint64())
+	defer t.logMu.Unlock()
+	id := t.newId()
+	t.log.Set(id, newTaskMeta(kind, TaskStatusRunning).uint64())
+	t.info[id] = &taskInfo{cancel: cancel}
+	return &Task{id: id, t: t}
+}
+
+// cancel cancels the task if it's queued, or asks it to stop if it's running.
+func (t *tasks) cancel(id uint64) error {
+	meta, err := t.get(id)
+	if err != nil {
+		return err
+	}
+	t.logMu.Lock()
+	defer t.logMu.Unlock()
+	switch meta.Status() {
+	case TaskStatusQueued:
+		// The worker skips the tasks which aren't queued anymore.
+		t.log.Set(id, newTaskMeta(meta.Kind(), TaskStatusCancelled).uint64())
+		return nil
+	case TaskStatusRunning:
+		info, ok := t.info[id]
+		if !ok || info.cancel == nil {
+			return errors.Errorf("task %#x can't be cancelled", id)
+		}
+		if !info.cancelled {
+			info.cancelled = true
+			info.cancel()
+		}
+		return nil
+	}
+	return errors.Errorf("task %#x has already finished", id)
+}
+
+// status returns the status and the details of the task.
+func (t *tasks) status(id uint64) (*pb.TaskStatusResponse, error) {
+	meta, err := t.get(id)
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.TaskStatusResponse{TaskMeta: meta.uint64(), TaskId: id}
+	t.logMu.Lock()
+	info, ok := t.info[id]
+	var progress func() (uint64, uint64)
+	if ok {
+		progress = info.progress
+		resp.Logs = append([]string{}, info.logs...)
+		resp.Error = info.err
+	}
 	t.logMu.Unlock()
+	// The progress is read without the lock, as it can take locks of its own.
+	if progress != nil {
+		resp.Progress, resp.Total = progress()
+	}
+	return resp, nil
+}
 
-	// Return the error from the task.
-	return err
+// List returns the status of all the tasks of this Alpha, the most recently updated first.
+func (t *tasks) List() ([]*pb.TaskStatusResponse, error) {
+	if t == nil {
+		return nil, fmt.Errorf("task queue hasn't been initialized yet")
+	}
+	var ids []uint64
+	t.logMu.Lock()
+	t.log.IterateKV(func(id, val uint64) uint64 {
+		ids = append(ids, id)
+		return 0
+	})
+	t.logMu.Unlock()
+
+	res := make([]*pb.TaskStatusResponse, 0, len(ids))
+	for _, id := range ids {
+		// The task could have expired in the meantime.
+		if resp, err := t.status(id); err == nil {
+			res = append(res, resp)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return TaskMeta(res[i].TaskMeta).Timestamp().After(TaskMeta(res[j].TaskMeta).Timestamp())
+	})
+	return res, nil
+}
+
+// Task is a task of this Alpha, whose progress and logs are reported by the task API.
+type Task struct {
+	id uint64
+	t  *tasks
+}
+
+// ID returns the ID of the task, 0 for a nil task.
+func (task *Task) ID() uint64 {
+	if task == nil {
+		return 0
+	}
+	return task.id
+}
+
+// TrackProgress sets the function returning the progress of the task, and its total if it's
+// known.
+func (task *Task) TrackProgress(progress func() (uint64, uint64)) {
+	if task == nil {
+		return
+	}
+	task.t.logMu.Lock()
+	defer task.t.logMu.Unlock()
+	task.t.infoOf(task.id).progress = progress
+}
+
+// Logf adds a line to the logs of the task, and logs it.
+func (task *Task) Logf(format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	if task == nil {
+		glog.Info(msg)
+		return
+	}
+	glog.Infof("task %#x: %s", task.id, msg)
+	line := time.Now().UTC().Format(time.RFC3339) + " " + msg
+
+	task.t.logMu.Lock()
+	defer task.t.logMu.Unlock()
+	info := task.t.infoOf(task.id)
+	if len(info.logs) >= maxTaskLogs {
+		info.logs = info.logs[1:]
+	}
+	info.logs = append(info.logs, line)
+}
+
+// Finish sets the final status of the task started with Start.
+func (task *Task) Finish(err error) {
+	if task == nil {
+		return
+	}
+	task.t.finish(task.id, err)
 }
 
 // cleanup deletes all expired tasks.
@@ -290,6 +483,11 @@ func (t *tasks) cleanup() {
 	t.logMu.Lock()
 	defer t.logMu.Unlock()
 	t.log.DeleteBelow(minMeta)
+	for id := range t.info {
+		if t.log.Get(id) == 0 {
+			delete(t.info, id)
+		}
+	}
 }
 
 // newId generates a random unique task ID. logMu must be acquired before calling this function.
@@ -313,19 +511,21 @@ type taskRequest struct {
 	req interface{} // *pb.BackupRequest, *pb.ExportRequest
 }
 
-// run starts a task and blocks till it completes.
-func (t *taskRequest) run() error {
+// run starts a task and blocks till it completes, or till the context is cancelled.
+func (t *taskRequest) run(ctx context.Context, task *Task) error {
 	switch req := t.req.(type) {
 	case *pb.BackupRequest:
-		if err := ProcessBackupRequest(context.Background(), req); err != nil {
+		task.Logf("Backup started, since ts %d", req.GetSinceTs())
+		if err := ProcessBackupRequest(ctx, req); err != nil {
 			return err
 		}
 	case *pb.ExportRequest:
-		files, err := ExportOverNetwork(context.Background(), req)
+		task.Logf("Export started in %s format", req.GetFormat())
+		files, err := ExportOverNetwork(ctx, req)
 		if err != nil {
 			return err
 		}
-		glog.Infof("task %#x: exported files: %v", t.id, files)
+		task.Logf("Exported files: %v", files)
 	default:
 		glog.Errorf(
 			"task %#x: received request of unknown type (%T)", t.id, reflect.TypeOf(t.req))
@@ -370,6 +570,9 @@ const (
 	// Reserve the zero value for errors.
 	TaskKindBackup TaskKind = iota + 1
 	TaskKindExport
+	TaskKindIndexRebuild
+	TaskKindMoveTablet
+	TaskKindQueryJob
 )
 
 type TaskKind uint64
@@ -380,6 +583,12 @@ func (k TaskKind) String() string {
 		return "Backup"
 	case TaskKindExport:
 		return "Export"
+	case TaskKindIndexRebuild:
+		return "IndexRebuild"
+	case TaskKindMoveTablet:
+		return "MoveTablet"
+	case TaskKindQueryJob:
+		return "QueryJob"
 	default:
 		return "Unknown"
 	}
@@ -391,6 +600,7 @@ const (
 	TaskStatusRunning
 	TaskStatusFailed
 	TaskStatusSuccess
+	TaskStatusCancelled
 )
 
 type TaskStatus uint64
@@ -405,6 +615,8 @@ func (status TaskStatus) String() string {
 		return "Failed"
 	case TaskStatusSuccess:
 		return "Success"
+	case TaskStatusCancelled:
+		return "Cancelled"
 	default:
 		return "Unknown"
 	}
@@ -0,0 +1,139 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"context"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/dgraph-io/ristretto/z"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/raftwal"
+)
+
+// newTestTasks returns a task queue, with a Raft WAL to read the Raft ID of the tasks from, and
+// the function cleaning them up.
+func newTestTasks(t *testing.T) (*tasks, func()) {
+	dir, err := ioutil.TempDir("", "tasks")
+	require.NoError(t, err)
+	ds := raftwal.Init(dir)
+	wal := State.WALstore
+	State.WALstore = ds
+
+	ts := &tasks{
+		queue: make(chan taskRequest, 1),
+		log:   z.NewTree("tasks"),
+		logMu: new(sync.Mutex),
+		info:  make(map[uint64]*taskInfo),
+		rng:   rand.New(rand.NewSource(1)),
+	}
+	return ts, func() {
+		ts.log.Close()
+		State.WALstore = wal
+		ds.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func taskStatus(t *testing.T, ts *tasks, id uint64) *pb.TaskStatusResponse {
+	resp, err := ts.status(id)
+	require.NoError(t, err)
+	return resp
+}
+
+func TestTaskProgressAndLogs(t *testing.T) {
+	ts, cleanup := newTestTasks(t)
+	defer cleanup()
+
+	task := ts.Start(TaskKindIndexRebuild, nil)
+	task.TrackProgress(func() (uint64, uint64) { return 3, 10 })
+	task.Logf("Rebuilding the %s index", "term")
+	resp := taskStatus(t, ts, task.ID())
+	require.Equal(t, TaskStatusRunning, TaskMeta(resp.TaskMeta).Status())
+	require.Equal(t, TaskKindIndexRebuild, TaskMeta(resp.TaskMeta).Kind())
+	require.Equal(t, uint64(3), resp.Progress)
+	require.Equal(t, uint64(10), resp.Total)
+	require.Len(t, resp.Logs, 1)
+	require.Contains(t, resp.Logs[0], "Rebuilding the term index")
+
+	// Only the last logs are kept.
+	for i := 0; i < maxTaskLogs+5; i++ {
+		task.Logf("line %d", i)
+	}
+	resp = taskStatus(t, ts, task.ID())
+	require.Len(t, resp.Logs, maxTaskLogs)
+	require.Contains(t, resp.Logs[maxTaskLogs-1], "line 104")
+
+	// A task started without a cancel function can't be cancelled.
+	require.Error(t, ts.cancel(task.ID()))
+
+	task.Finish(errors.New("out of disk"))
+	resp = taskStatus(t, ts, task.ID())
+	require.Equal(t, TaskStatusFailed, TaskMeta(resp.TaskMeta).Status())
+	require.Equal(t, "out of disk", resp.Error)
+	require.Zero(t, resp.Progress)
+	require.Error(t, ts.cancel(task.ID()))
+
+	done := ts.Start(TaskKindMoveTablet, nil)
+	done.Finish(nil)
+	require.Equal(t, TaskStatusSuccess, TaskMeta(taskStatus(t, ts, done.ID()).TaskMeta).Status())
+
+	list, err := ts.List()
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+
+	// The tasks which aren't tracked don't report anything.
+	var none *Task
+	require.Zero(t, none.ID())
+	none.Logf("not tracked")
+	none.Finish(nil)
+}
+
+func TestTaskCancel(t *testing.T) {
+	ts, cleanup := newTestTasks(t)
+	defer cleanup()
+
+	// A running task is asked to stop once.
+	cancelled := 0
+	task := ts.Start(TaskKindQueryJob, func() { cancelled++ })
+	require.NoError(t, ts.cancel(task.ID()))
+	require.NoError(t, ts.cancel(task.ID()))
+	require.Equal(t, 1, cancelled)
+	task.Finish(context.Canceled)
+	require.Equal(t, TaskStatusCancelled,
+		TaskMeta(taskStatus(t, ts, task.ID()).TaskMeta).Status())
+
+	// A task stopping with ErrTaskCancelled is cancelled too.
+	other := ts.Start(TaskKindQueryJob, nil)
+	other.Finish(errors.Wrap(ErrTaskCancelled, "while running the query"))
+	require.Equal(t, TaskStatusCancelled,
+		TaskMeta(taskStatus(t, ts, other.ID()).TaskMeta).Status())
+
+	// A queued task is cancelled before it runs.
+	id, err := ts.enqueue(&pb.ExportRequest{})
+	require.NoError(t, err)
+	require.NoError(t, ts.cancel(id))
+	require.Error(t, ts.run(<-ts.queue))
+	require.Equal(t, TaskStatusCancelled, TaskMeta(taskStatus(t, ts, id).TaskMeta).Status())
+}
@@ -0,0 +1,202 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/dgraph-io/dgraph/posting"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// ReadinessOptions are the thresholds beyond which an Alpha isn't ready to serve. Zero disables
+// a threshold.
+type ReadinessOptions struct {
+	// MaxApplyLag is the max number of committed Raft entries not yet applied by this Alpha.
+	MaxApplyLag uint64
+	// MaxTsLag is the max difference between the max timestamp assigned by Zero and the max
+	// timestamp applied by this Alpha.
+	MaxTsLag uint64
+}
+
+// GroupLeader is the leader of a group as known from the membership state.
+type GroupLeader struct {
+	GroupId   uint32 `json:"groupId"`
+	LeaderId  uint64 `json:"leaderId,omitempty"`
+	HasLeader bool   `json:"hasLeader"`
+}
+
+// RaftReadiness is the state of the Raft node of this Alpha.
+type RaftReadiness struct {
+	Id           uint64 `json:"id"`
+	GroupId      uint32 `json:"groupId"`
+	LeaderId     uint64 `json:"leaderId"`
+	IsLeader     bool   `json:"isLeader"`
+	Term         uint64 `json:"term"`
+	CommitIndex  uint64 `json:"commitIndex"`
+	AppliedIndex uint64 `json:"appliedIndex"`
+	ApplyLag     uint64 `json:"applyLag"`
+}
+
+// ZeroReadiness is the connectivity of this Alpha to the Zero leader.
+type ZeroReadiness struct {
+	Address   string `json:"address,omitempty"`
+	Connected bool   `json:"connected"`
+	Error     string `json:"error,omitempty"`
+}
+
+// BadgerLevel is the state of a level of the LSM tree of the postings store.
+type BadgerLevel struct {
+	Level      int     `json:"level"`
+	NumTables  int     `json:"numTables"`
+	Size       int64   `json:"size"`
+	TargetSize int64   `json:"targetSize"`
+	Score      float64 `json:"score"`
+}
+
+// BadgerReadiness is the state of the postings store.
+type BadgerReadiness struct {
+	Levels []BadgerLevel `json:"levels"`
+	// CompactionBacklog is the number of levels which are due for a compaction.
+	CompactionBacklog int `json:"compactionBacklog"`
+}
+
+// Readiness is the detailed health of an Alpha, telling whether it can serve requests.
+type Readiness struct {
+	Ready   bool     `json:"ready"`
+	Reasons []string `json:"reasons,omitempty"`
+
+	Raft   RaftReadiness   `json:"raft"`
+	Groups []GroupLeader   `json:"groups"`
+	Zero   ZeroReadiness   `json:"zero"`
+	Badger BadgerReadiness `json:"badger"`
+
+	// PendingRollups is the number of batches of keys waiting to be rolled up.
+	PendingRollups int `json:"pendingRollups"`
+	// MaxApplied is the max timestamp applied by this Alpha.
+	MaxApplied uint64 `json:"maxApplied"`
+	// MaxAssigned is the max timestamp assigned by Zero.
+	MaxAssigned uint64 `json:"maxAssigned"`
+	TsLag       uint64 `json:"tsLag"`
+
+	Startup StartupProgress `json:"startup"`
+	// Snapshot is the progress of the snapshot being received from the leader, if any.
+	Snapshot *SnapshotProgress `json:"snapshot,omitempty"`
+}
+
+// evaluate sets whether the Alpha is ready, and if it isn't, the reasons why.
+func (r *Readiness) evaluate(healthErr error, opts ReadinessOptions) {
+	var reasons []string
+	if healthErr != nil {
+		reasons = append(reasons, healthErr.Error())
+	}
+	if r.Raft.LeaderId == 0 {
+		reasons = append(reasons, fmt.Sprintf("group %d has no leader", r.Raft.GroupId))
+	}
+	if !r.Zero.Connected {
+		reasons = append(reasons, "not connected to the Zero leader")
+	}
+	if opts.MaxApplyLag > 0 && r.Raft.ApplyLag > opts.MaxApplyLag {
+		reasons = append(reasons, fmt.Sprintf("%d Raft entries behind, more than %d",
+			r.Raft.ApplyLag, opts.MaxApplyLag))
+	}
+	if opts.MaxTsLag > 0 && r.Zero.Connected && r.TsLag > opts.MaxTsLag {
+		reasons = append(reasons, fmt.Sprintf("%d timestamps behind Zero, more than %d",
+			r.TsLag, opts.MaxTsLag))
+	}
+	r.Reasons = reasons
+	r.Ready = len(reasons) == 0
+}
+
+// GetReadiness collects the detailed health of this Alpha.
+func GetReadiness(ctx context.Context, opts ReadinessOptions) *Readiness {
+	r := &Readiness{
+		MaxApplied:     posting.Oracle().MaxAssigned(),
+		PendingRollups: posting.IncrRollup.Pending(),
+		Startup:        GetStartupProgress(),
+		Snapshot:       GetSnapshotProgress(),
+	}
+
+	g := groups()
+	if n := g.Node; n != nil && n.Raft() != nil {
+		st := n.Raft().Status()
+		r.Raft = RaftReadiness{
+			Id:           n.Id,
+			GroupId:      n.gid,
+			LeaderId:     st.Lead,
+			IsLeader:     st.Lead == st.ID,
+			Term:         st.Term,
+			CommitIndex:  st.Commit,
+			AppliedIndex: n.Applied.DoneUntil(),
+		}
+		if r.Raft.CommitIndex > r.Raft.AppliedIndex {
+			r.Raft.ApplyLag = r.Raft.CommitIndex - r.Raft.AppliedIndex
+		}
+	}
+
+	g.RLock()
+	for gid, group := range g.state.GetGroups() {
+		gl := GroupLeader{GroupId: gid}
+		for _, m := range group.GetMembers() {
+			if m.Leader {
+				gl.LeaderId, gl.HasLeader = m.Id, true
+			}
+		}
+		r.Groups = append(r.Groups, gl)
+	}
+	g.RUnlock()
+	sort.Slice(r.Groups, func(i, j int) bool { return r.Groups[i].GroupId < r.Groups[j].GroupId })
+
+	// Don't use connToZeroLeader, as it keeps retrying until it finds the leader.
+	if pl := g.Leader(0); pl != nil && pl.IsHealthy() {
+		r.Zero.Address = pl.Addr
+		ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+		ts, err := pb.NewZeroClient(pl.Get()).Timestamps(ctx, &pb.Num{ReadOnly: true})
+		cancel()
+		if err != nil {
+			r.Zero.Error = err.Error()
+		} else {
+			r.Zero.Connected = true
+			r.MaxAssigned = ts.ReadOnly
+			if r.MaxAssigned > r.MaxApplied {
+				r.TsLag = r.MaxAssigned - r.MaxApplied
+			}
+		}
+	}
+
+	if pstore != nil {
+		for _, l := range pstore.Levels() {
+			r.Badger.Levels = append(r.Badger.Levels, BadgerLevel{
+				Level:      l.Level,
+				NumTables:  l.NumTables,
+				Size:       l.Size,
+				TargetSize: l.TargetSize,
+				Score:      l.Score,
+			})
+			if l.Score >= 1 {
+				r.Badger.CompactionBacklog++
+			}
+		}
+	}
+
+	r.evaluate(x.HealthCheck(), opts)
+	return r
+}
@@ -0,0 +1,47 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadinessEvaluate(t *testing.T) {
+	r := &Readiness{
+		Raft:  RaftReadiness{GroupId: 1, LeaderId: 2, ApplyLag: 50},
+		Zero:  ZeroReadiness{Connected: true},
+		TsLag: 20,
+	}
+	r.evaluate(nil, ReadinessOptions{})
+	require.True(t, r.Ready)
+	require.Empty(t, r.Reasons)
+
+	r.evaluate(nil, ReadinessOptions{MaxApplyLag: 100, MaxTsLag: 10})
+	require.False(t, r.Ready)
+	require.Len(t, r.Reasons, 1)
+
+	r.Raft.LeaderId = 0
+	r.Zero.Connected = false
+	r.evaluate(errors.New("draining"), ReadinessOptions{MaxTsLag: 10})
+	require.False(t, r.Ready)
+	// The timestamp lag isn't known without a connection to Zero.
+	require.Equal(t, []string{"draining", "group 1 has no leader",
+		"not connected to the Zero leader"}, r.Reasons)
+}
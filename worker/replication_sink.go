@@ -0,0 +1,393 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dgraph-io/dgo/v210"
+	"github.com/dgraph-io/dgo/v210/protos/api"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/dgraph-io/ristretto/z"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+)
+
+/*
+CROSS-CLUSTER REPLICATION
+
+With --cdc "dgraph=<alphas>; dgraph-zero=<zero>;", the change events of the primary cluster are
+applied to a standby cluster instead of being sent to Kafka. The leader of every group of the
+primary sends the events of each transaction once it commits, in commit order, so the standby
+lags behind the primary but never sees a partial transaction. The nodes keep the uids they have in
+the primary: the sink leases them on the Zero of the standby before using them, so that the
+standby never hands them out to its own writes.
+
+The standby must be put in standby mode through updateRuntimeConfig. It then rejects all the
+writes except the ones coming from the sink, which carry the ReplicationHeader. The failover
+admin mutation takes it out of standby mode. Schema updates aren't part of the change events, so
+the schema has to be applied to both the clusters. With ACL, dgraph-user must exist with the same
+password in every namespace of the standby that's being replicated.
+*/
+
+// ReplicationHeader is the gRPC metadata key set on the requests of the dgraph CDC sink.
+const ReplicationHeader = "dgraph-replication"
+
+const (
+	EventTypeDrop     = "drop"
+	EventTypeMutation = "mutation"
+	OpDropPred        = "predicate"
+)
+
+// replicatedEvent is a CDCEvent as decoded by the dgraph sink.
+type replicatedEvent struct {
+	Meta struct {
+		Namespace uint64 `json:"namespace"`
+		CommitTs  uint64 `json:"commit_ts"`
+	} `json:"meta"`
+	Type  string          `json:"type"`
+	Event json.RawMessage `json:"event"`
+}
+
+type replicatedMutation struct {
+	Operation string      `json:"operation"`
+	Uid       uint64      `json:"uid"`
+	Attr      string      `json:"attr"`
+	Value     interface{} `json:"value"`
+	ValueType string      `json:"value_type"`
+	Lang      string      `json:"lang"`
+}
+
+type replicatedDrop struct {
+	Operation string `json:"operation"`
+	Type      string `json:"type"`
+	Pred      string `json:"pred"`
+}
+
+// dgraphSink applies the change events to another Dgraph cluster. Like the other sinks, it is
+// only used by the leader of the group, one transaction at a time.
+type dgraphSink struct {
+	conns    []*grpc.ClientConn
+	alphas   []api.DgraphClient
+	zero     pb.ZeroClient
+	user     string
+	password string
+
+	// clients holds a client logged into each of the namespaces replicated so far.
+	clients map[uint64]*dgo.Dgraph
+	// maxLeased is the max uid known to be leased by the Zero of the standby.
+	maxLeased uint64
+}
+
+func dialSink(addr string, tlsCfg *tls.Config) (*grpc.ClientConn, error) {
+	opts := []grpc.DialOption{
+		grpc.WithDefaultCallOptions(
+			grpc.MaxCallRecvMsgSize(x.GrpcMaxSize),
+			grpc.MaxCallSendMsgSize(x.GrpcMaxSize)),
+	}
+	if tlsCfg != nil {
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(tlsCfg)))
+	} else {
+		opts = append(opts, grpc.WithInsecure())
+	}
+	// Don't block, so that the primary can start while the standby is unreachable. The events
+	// are retried until they are sent.
+	return grpc.Dial(addr, opts...)
+}
+
+func newDgraphSink(config *z.SuperFlag) (Sink, error) {
+	if config.GetString("dgraph-zero") == "" {
+		return nil, errors.New("dgraph-zero must be provided for the dgraph sink")
+	}
+	tlsCfg, err := sinkTLSConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	sink := &dgraphSink{
+		user:     config.GetString("dgraph-user"),
+		password: config.GetString("dgraph-password"),
+		clients:  make(map[uint64]*dgo.Dgraph),
+	}
+	for _, addr := range strings.Split(config.GetString("dgraph"), ",") {
+		conn, err := dialSink(strings.TrimSpace(addr), tlsCfg)
+		if err != nil {
+			_ = sink.Close()
+			return nil, errors.Wrapf(err, "unable to connect to the standby alpha %s", addr)
+		}
+		sink.conns = append(sink.conns, conn)
+		sink.alphas = append(sink.alphas, api.NewDgraphClient(conn))
+	}
+	conn, err := dialSink(config.GetString("dgraph-zero"), tlsCfg)
+	if err != nil {
+		_ = sink.Close()
+		return nil, errors.Wrapf(err, "unable to connect to the standby zero")
+	}
+	sink.conns = append(sink.conns, conn)
+	sink.zero = pb.NewZeroClient(conn)
+	return sink, nil
+}
+
+// client returns a client for the given namespace of the standby, logging into it if needed.
+func (d *dgraphSink) client(ctx context.Context, ns uint64) (*dgo.Dgraph, error) {
+	if dg, ok := d.clients[ns]; ok {
+		return dg, nil
+	}
+	dg := dgo.NewDgraphClient(d.alphas...)
+	if d.user != "" {
+		if err := dg.LoginIntoNamespace(ctx, d.user, d.password, ns); err != nil {
+			return nil, errors.Wrapf(err, "unable to login into namespace %#x of the standby", ns)
+		}
+	}
+	d.clients[ns] = dg
+	return dg, nil
+}
+
+// leaseUids makes sure that the Zero of the standby has leased the uids up to the given one.
+func (d *dgraphSink) leaseUids(ctx context.Context, uid uint64) error {
+	for d.maxLeased < uid {
+		num := x.Max(uid-d.maxLeased, 1e4)
+		assigned, err := d.zero.AssignIds(ctx, &pb.Num{Val: num, Type: pb.Num_UID})
+		if err != nil {
+			return errors.Wrapf(err, "unable to lease uids up to %#x on the standby", uid)
+		}
+		d.maxLeased = assigned.EndId
+	}
+	return nil
+}
+
+func (d *dgraphSink) Send(messages []SinkMessage) error {
+	if len(messages) == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, ReplicationHeader, "true")
+
+	// The messages are the events of a single transaction, or a single drop.
+	var namespaces []uint64
+	mutations := make(map[uint64][]*api.Mutation)
+	var maxUid uint64
+	for _, m := range messages {
+		var event replicatedEvent
+		if err := json.Unmarshal(m.Value, &event); err != nil {
+			return errors.Wrapf(err, "unable to unmarshal the event")
+		}
+		ns := event.Meta.Namespace
+
+		switch event.Type {
+		case EventTypeDrop:
+			var drop replicatedDrop
+			if err := json.Unmarshal(event.Event, &drop); err != nil {
+				return errors.Wrapf(err, "unable to unmarshal the drop event")
+			}
+			if err := d.drop(ctx, ns, &drop); err != nil {
+				return err
+			}
+		case EventTypeMutation:
+			var mu replicatedMutation
+			dec := json.NewDecoder(bytes.NewReader(event.Event))
+			dec.UseNumber()
+			if err := dec.Decode(&mu); err != nil {
+				return errors.Wrapf(err, "unable to unmarshal the mutation event")
+			}
+			nq, err := toNQuad(&mu)
+			if err != nil {
+				return err
+			}
+			if nq == nil {
+				continue
+			}
+			maxUid = x.Max(maxUid, mu.Uid)
+			if id, err := strconv.ParseUint(nq.ObjectId, 0, 64); err == nil {
+				maxUid = x.Max(maxUid, id)
+			}
+
+			if _, ok := mutations[ns]; !ok {
+				namespaces = append(namespaces, ns)
+			}
+			// The edges are applied in order, so start a new mutation every time the operation
+			// changes.
+			list := mutations[ns]
+			del := mu.Operation == "del"
+			if len(list) == 0 || (len(list[len(list)-1].Del) > 0) != del {
+				list = append(list, &api.Mutation{})
+			}
+			last := list[len(list)-1]
+			if del {
+				last.Del = append(last.Del, nq)
+			} else {
+				last.Set = append(last.Set, nq)
+			}
+			mutations[ns] = list
+		default:
+			glog.Warningf("Dgraph sink: ignoring event of unknown type %q", event.Type)
+		}
+	}
+
+	if err := d.leaseUids(ctx, maxUid); err != nil {
+		return err
+	}
+	for _, ns := range namespaces {
+		dg, err := d.client(ctx, ns)
+		if err != nil {
+			return err
+		}
+		req := &api.Request{Mutations: mutations[ns], CommitNow: true}
+		if _, err := dg.NewTxn().Do(ctx, req); err != nil {
+			return errors.Wrapf(err, "unable to apply the mutations to namespace %#x of the standby",
+				ns)
+		}
+	}
+	return nil
+}
+
+func (d *dgraphSink) drop(ctx context.Context, ns uint64, drop *replicatedDrop) error {
+	op := &api.Operation{}
+	switch drop.Operation {
+	case "all":
+		op.DropAll = true
+	case "data":
+		op.DropOp = api.Operation_DATA
+	case "type":
+		op.DropOp = api.Operation_TYPE
+		op.DropValue = drop.Type
+	case OpDropPred:
+		op.DropOp = api.Operation_ATTR
+		op.DropValue = drop.Pred
+	default:
+		glog.Warningf("Dgraph sink: ignoring drop event of unknown operation %q", drop.Operation)
+		return nil
+	}
+	dg, err := d.client(ctx, ns)
+	if err != nil {
+		return err
+	}
+	if err := dg.Alter(ctx, op); err != nil {
+		return errors.Wrapf(err, "unable to drop %s in namespace %#x of the standby",
+			drop.Operation, ns)
+	}
+	return nil
+}
+
+// toNQuad converts the mutation event into an NQuad. It returns nil for the values that aren't
+// sent in full by CDC, so can't be replicated.
+func toNQuad(mu *replicatedMutation) (*api.NQuad, error) {
+	nq := &api.NQuad{
+		Subject:   fmt.Sprintf("%#x", mu.Uid),
+		Predicate: mu.Attr,
+		Lang:      mu.Lang,
+	}
+	wrap := func(err error) error {
+		return errors.Wrapf(err, "invalid %s value for %s", mu.ValueType, mu.Attr)
+	}
+
+	if s, ok := mu.Value.(string); ok && s == x.Star {
+		nq.ObjectValue = &api.Value{Val: &api.Value_DefaultVal{DefaultVal: x.Star}}
+		return nq, nil
+	}
+	switch mu.ValueType {
+	case "uid":
+		n, ok := mu.Value.(json.Number)
+		if !ok {
+			return nil, wrap(errors.Errorf("expected a number, got %v", mu.Value))
+		}
+		uid, err := strconv.ParseUint(n.String(), 10, 64)
+		if err != nil {
+			return nil, wrap(err)
+		}
+		nq.ObjectId = fmt.Sprintf("%#x", uid)
+	case "int":
+		n, ok := mu.Value.(json.Number)
+		if !ok {
+			return nil, wrap(errors.Errorf("expected a number, got %v", mu.Value))
+		}
+		v, err := n.Int64()
+		if err != nil {
+			return nil, wrap(err)
+		}
+		nq.ObjectValue = &api.Value{Val: &api.Value_IntVal{IntVal: v}}
+	case "float":
+		n, ok := mu.Value.(json.Number)
+		if !ok {
+			return nil, wrap(errors.Errorf("expected a number, got %v", mu.Value))
+		}
+		v, err := n.Float64()
+		if err != nil {
+			return nil, wrap(err)
+		}
+		nq.ObjectValue = &api.Value{Val: &api.Value_DoubleVal{DoubleVal: v}}
+	case "bool":
+		v, ok := mu.Value.(bool)
+		if !ok {
+			return nil, wrap(errors.Errorf("expected a bool, got %v", mu.Value))
+		}
+		nq.ObjectValue = &api.Value{Val: &api.Value_BoolVal{BoolVal: v}}
+	case "datetime":
+		s, _ := mu.Value.(string)
+		t, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return nil, wrap(err)
+		}
+		b, err := t.MarshalBinary()
+		if err != nil {
+			return nil, wrap(err)
+		}
+		nq.ObjectValue = &api.Value{Val: &api.Value_DatetimeVal{DatetimeVal: b}}
+	case "binary":
+		s, _ := mu.Value.(string)
+		b, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return nil, wrap(err)
+		}
+		nq.ObjectValue = &api.Value{Val: &api.Value_BytesVal{BytesVal: b}}
+	case "string":
+		s, _ := mu.Value.(string)
+		nq.ObjectValue = &api.Value{Val: &api.Value_StrVal{StrVal: s}}
+	case "default":
+		s, _ := mu.Value.(string)
+		nq.ObjectValue = &api.Value{Val: &api.Value_DefaultVal{DefaultVal: s}}
+	default:
+		// CDC masks the passwords, and doesn't send the geo values in a portable format.
+		glog.Warningf("Dgraph sink: skipping %s value of %s for uid %#x", mu.ValueType, mu.Attr,
+			mu.Uid)
+		return nil, nil
+	}
+	return nq, nil
+}
+
+func (d *dgraphSink) Close() error {
+	var rerr error
+	for _, conn := range d.conns {
+		if err := conn.Close(); err != nil && rerr == nil {
+			rerr = err
+		}
+	}
+	return rerr
+}
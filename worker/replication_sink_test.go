@@ -0,0 +1,68 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/dgraph-io/dgo/v210/protos/api"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplicatedEventToNQuad(t *testing.T) {
+	decode := func(event string) *replicatedMutation {
+		var mu replicatedMutation
+		dec := json.NewDecoder(bytes.NewReader([]byte(event)))
+		dec.UseNumber()
+		require.NoError(t, dec.Decode(&mu))
+		return &mu
+	}
+
+	nq, err := toNQuad(decode(`{"operation":"set","uid":1,"attr":"friend","value":18446744073709551615,
+		"value_type":"uid"}`))
+	require.NoError(t, err)
+	require.Equal(t, "0x1", nq.Subject)
+	require.Equal(t, "0xffffffffffffffff", nq.ObjectId)
+
+	nq, err = toNQuad(decode(`{"operation":"set","uid":2,"attr":"age","value":42,
+		"value_type":"int"}`))
+	require.NoError(t, err)
+	require.Equal(t, &api.Value{Val: &api.Value_IntVal{IntVal: 42}}, nq.ObjectValue)
+
+	nq, err = toNQuad(decode(`{"operation":"set","uid":2,"attr":"name","value":"Alice",
+		"value_type":"string","lang":"en"}`))
+	require.NoError(t, err)
+	require.Equal(t, "en", nq.Lang)
+	require.Equal(t, &api.Value{Val: &api.Value_StrVal{StrVal: "Alice"}}, nq.ObjectValue)
+
+	nq, err = toNQuad(decode(`{"operation":"del","uid":2,"attr":"name","value":"_STAR_ALL",
+		"value_type":"default"}`))
+	require.NoError(t, err)
+	require.Equal(t, &api.Value{Val: &api.Value_DefaultVal{DefaultVal: "_STAR_ALL"}},
+		nq.ObjectValue)
+
+	nq, err = toNQuad(decode(`{"operation":"set","uid":2,"attr":"pass","value":"****",
+		"value_type":"password"}`))
+	require.NoError(t, err)
+	require.Nil(t, nq)
+
+	_, err = toNQuad(decode(`{"operation":"set","uid":2,"attr":"age","value":"abc",
+		"value_type":"int"}`))
+	require.Error(t, err)
+}
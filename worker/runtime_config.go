@@ -0,0 +1,237 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"flag"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+)
+
+// RuntimeConfigPred is the predicate which stores the runtime config in the galaxy namespace.
+const RuntimeConfigPred = "dgraph.runtime_config"
+
+// RuntimeConfig holds the options that can be changed on all the Alphas of the cluster without a
+// restart. A nil field means that the option is not set, and the value given through the flags
+// is used.
+type RuntimeConfig struct {
+	// CacheMb is the total size of the caches, see UpdateCacheMb.
+	CacheMb *int64 `json:"cacheMb,omitempty"`
+	// LogRequest enables the logging of all the requests coming to the Alphas.
+	LogRequest *bool `json:"logRequest,omitempty"`
+	// LogVerbosity is the glog verbosity level, i.e. the --v flag.
+	LogVerbosity *int `json:"logVerbosity,omitempty"`
+	// QueryEdgeLimit is the query-edge option of the --limit flag.
+	QueryEdgeLimit *uint64 `json:"queryEdgeLimit,omitempty"`
+	// NormalizeNodeLimit is the normalize-node option of the --limit flag.
+	NormalizeNodeLimit *int `json:"normalizeNodeLimit,omitempty"`
+	// MutationsNquadLimit is the mutations-nquad option of the --limit flag.
+	MutationsNquadLimit *int `json:"mutationsNquadLimit,omitempty"`
+	// QueryTimeout is the query-timeout option of the --limit flag, e.g. "30s". 0 disables it.
+	QueryTimeout *string `json:"queryTimeout,omitempty"`
+	// Standby makes the cluster reject the writes that aren't replicated from its primary
+	// cluster. See InStandby.
+	Standby *bool `json:"standby,omitempty"`
+	// NamespaceHosts maps hostnames to the namespaces whose GraphQL API they serve, see
+	// NamespaceOfHost. An update replaces all the hostnames.
+	NamespaceHosts map[string]uint64 `json:"namespaceHosts,omitempty"`
+}
+
+// standby is 1 if the cluster is a standby of another cluster.
+var standby int32
+
+// InStandby returns true if the cluster is a standby, which only accepts the writes replicated
+// from its primary cluster via the dgraph CDC sink. Failing over to the standby turns it off.
+func InStandby() bool {
+	return atomic.LoadInt32(&standby) == 1
+}
+
+// namespaceHosts holds the map[string]uint64 of the NamespaceHosts option.
+var namespaceHosts atomic.Value
+
+// NamespaceOfHost returns the namespace whose GraphQL API is served through the given hostname, if
+// the hostname is mapped to one.
+func NamespaceOfHost(host string) (uint64, bool) {
+	hosts, _ := namespaceHosts.Load().(map[string]uint64)
+	ns, ok := hosts[strings.ToLower(host)]
+	return ns, ok
+}
+
+// runtimeConfigLock serializes the application of runtime config updates, which can come from
+// the admin API and from the subscription at the same time.
+var runtimeConfigLock sync.Mutex
+
+// Validate returns an error if any of the set options has an invalid value.
+func (c *RuntimeConfig) Validate() error {
+	if c.CacheMb != nil && *c.CacheMb < 0 {
+		return errors.Errorf("cacheMb must be non-negative")
+	}
+	if c.LogVerbosity != nil && *c.LogVerbosity < 0 {
+		return errors.Errorf("logVerbosity must be non-negative")
+	}
+	if c.QueryEdgeLimit != nil && *c.QueryEdgeLimit == 0 {
+		return errors.Errorf("queryEdgeLimit must be greater than 0")
+	}
+	if c.NormalizeNodeLimit != nil && *c.NormalizeNodeLimit <= 0 {
+		return errors.Errorf("normalizeNodeLimit must be greater than 0")
+	}
+	if c.MutationsNquadLimit != nil && *c.MutationsNquadLimit <= 0 {
+		return errors.Errorf("mutationsNquadLimit must be greater than 0")
+	}
+	if c.QueryTimeout != nil {
+		d, err := time.ParseDuration(*c.QueryTimeout)
+		if err != nil {
+			return errors.Wrapf(err, "invalid queryTimeout")
+		}
+		if d < 0 {
+			return errors.Errorf("queryTimeout must be non-negative")
+		}
+	}
+	for host := range c.NamespaceHosts {
+		if host == "" || strings.ContainsAny(host, ":/ ") {
+			return errors.Errorf("invalid hostname %q in namespaceHosts", host)
+		}
+	}
+	return nil
+}
+
+// Merge sets the options that are set in the update, and keeps the others.
+func (c *RuntimeConfig) Merge(update *RuntimeConfig) {
+	if update.CacheMb != nil {
+		c.CacheMb = update.CacheMb
+	}
+	if update.LogRequest != nil {
+		c.LogRequest = update.LogRequest
+	}
+	if update.LogVerbosity != nil {
+		c.LogVerbosity = update.LogVerbosity
+	}
+	if update.QueryEdgeLimit != nil {
+		c.QueryEdgeLimit = update.QueryEdgeLimit
+	}
+	if update.NormalizeNodeLimit != nil {
+		c.NormalizeNodeLimit = update.NormalizeNodeLimit
+	}
+	if update.MutationsNquadLimit != nil {
+		c.MutationsNquadLimit = update.MutationsNquadLimit
+	}
+	if update.QueryTimeout != nil {
+		c.QueryTimeout = update.QueryTimeout
+	}
+	if update.Standby != nil {
+		c.Standby = update.Standby
+	}
+	if update.NamespaceHosts != nil {
+		c.NamespaceHosts = update.NamespaceHosts
+	}
+}
+
+// ApplyRuntimeConfig applies the options that are set in the config to this Alpha.
+func ApplyRuntimeConfig(c *RuntimeConfig) error {
+	if err := c.Validate(); err != nil {
+		return err
+	}
+
+	runtimeConfigLock.Lock()
+	defer runtimeConfigLock.Unlock()
+	if c.CacheMb != nil && *c.CacheMb != Config.CacheMb {
+		if err := UpdateCacheMb(*c.CacheMb); err != nil {
+			return err
+		}
+	}
+	if c.LogRequest != nil {
+		UpdateLogRequest(*c.LogRequest)
+	}
+	if c.LogVerbosity != nil {
+		if err := flag.Set("v", strconv.Itoa(*c.LogVerbosity)); err != nil {
+			return errors.Wrapf(err, "while setting the log verbosity")
+		}
+	}
+	x.UpdateLimits(func() {
+		if c.QueryEdgeLimit != nil {
+			x.Config.LimitQueryEdge = *c.QueryEdgeLimit
+		}
+		if c.NormalizeNodeLimit != nil {
+			x.Config.LimitNormalizeNode = *c.NormalizeNodeLimit
+		}
+		if c.MutationsNquadLimit != nil {
+			x.Config.LimitMutationsNquad = *c.MutationsNquadLimit
+		}
+		if c.QueryTimeout != nil {
+			// The duration has already been validated.
+			d, _ := time.ParseDuration(*c.QueryTimeout)
+			x.Config.QueryTimeout = d
+		}
+	})
+	if c.Standby != nil {
+		var v int32
+		if *c.Standby {
+			v = 1
+		}
+		atomic.StoreInt32(&standby, v)
+	}
+	if c.NamespaceHosts != nil {
+		hosts := make(map[string]uint64, len(c.NamespaceHosts))
+		for host, ns := range c.NamespaceHosts {
+			hosts[strings.ToLower(host)] = ns
+		}
+		namespaceHosts.Store(hosts)
+	}
+	glog.Infof("Applied runtime config: %+v", c)
+	return nil
+}
+
+// CurrentRuntimeConfig returns the values of the runtime options currently in use by this Alpha.
+func CurrentRuntimeConfig() *RuntimeConfig {
+	runtimeConfigLock.Lock()
+	defer runtimeConfigLock.Unlock()
+
+	cacheMb := Config.CacheMb
+	logRequest := LogRequestEnabled()
+	var verbosity int
+	if f := flag.Lookup("v"); f != nil {
+		verbosity, _ = strconv.Atoi(f.Value.String())
+	}
+	queryEdge := x.LimitQueryEdge()
+	normalizeNode := x.LimitNormalizeNode()
+	mutationsNquad := x.LimitMutationsNquad()
+	queryTimeout := x.QueryTimeout().String()
+	inStandby := InStandby()
+	hosts, _ := namespaceHosts.Load().(map[string]uint64)
+	hostsCopy := make(map[string]uint64, len(hosts))
+	for host, ns := range hosts {
+		hostsCopy[host] = ns
+	}
+	return &RuntimeConfig{
+		CacheMb:             &cacheMb,
+		LogRequest:          &logRequest,
+		LogVerbosity:        &verbosity,
+		QueryEdgeLimit:      &queryEdge,
+		NormalizeNodeLimit:  &normalizeNode,
+		MutationsNquadLimit: &mutationsNquad,
+		QueryTimeout:        &queryTimeout,
+		Standby:             &inStandby,
+		NamespaceHosts:      hostsCopy,
+	}
+}
@@ -0,0 +1,77 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRuntimeConfig(t *testing.T) {
+	var config RuntimeConfig
+	require.NoError(t, json.Unmarshal([]byte(`{"queryEdgeLimit": 100, "queryTimeout": "1m"}`),
+		&config))
+	require.NoError(t, config.Validate())
+
+	var update RuntimeConfig
+	require.NoError(t, json.Unmarshal([]byte(`{"queryTimeout": "30s", "logRequest": true}`),
+		&update))
+	config.Merge(&update)
+	data, err := json.Marshal(config)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"queryEdgeLimit": 100, "queryTimeout": "30s", "logRequest": true}`,
+		string(data))
+
+	edgeLimit, timeout := x.Config.LimitQueryEdge, x.Config.QueryTimeout
+	defer func() {
+		x.Config.LimitQueryEdge, x.Config.QueryTimeout = edgeLimit, timeout
+		UpdateLogRequest(false)
+	}()
+	require.NoError(t, ApplyRuntimeConfig(&config))
+	require.Equal(t, uint64(100), x.LimitQueryEdge())
+	require.Equal(t, 30*time.Second, x.QueryTimeout())
+	require.True(t, LogRequestEnabled())
+
+	current := CurrentRuntimeConfig()
+	require.Equal(t, uint64(100), *current.QueryEdgeLimit)
+	require.Equal(t, "30s", *current.QueryTimeout)
+
+	require.NoError(t, ApplyRuntimeConfig(&RuntimeConfig{
+		NamespaceHosts: map[string]uint64{"Tenant1.example.com": 1},
+	}))
+	defer namespaceHosts.Store(map[string]uint64{})
+	ns, ok := NamespaceOfHost("tenant1.EXAMPLE.com")
+	require.True(t, ok)
+	require.Equal(t, uint64(1), ns)
+	_, ok = NamespaceOfHost("tenant2.example.com")
+	require.False(t, ok)
+	require.Equal(t, map[string]uint64{"tenant1.example.com": 1},
+		CurrentRuntimeConfig().NamespaceHosts)
+
+	for _, invalid := range []string{`{"cacheMb": -1}`, `{"queryEdgeLimit": 0}`,
+		`{"queryTimeout": "soon"}`, `{"mutationsNquadLimit": 0}`,
+		`{"namespaceHosts": {"example.com:8080": 1}}`} {
+		var c RuntimeConfig
+		require.NoError(t, json.Unmarshal([]byte(invalid), &c))
+		require.Error(t, c.Validate(), invalid)
+		require.Error(t, ApplyRuntimeConfig(&c), invalid)
+	}
+}
@@ -18,12 +18,16 @@ package worker
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/pkg/errors"
 	otrace "go.opencensus.io/trace"
 
+	"github.com/dgraph-io/dgo/v210/protos/api"
 	"github.com/dgraph-io/dgraph/conn"
+	"github.com/dgraph-io/dgraph/posting"
 	"github.com/dgraph-io/dgraph/protos/pb"
 	"github.com/dgraph-io/dgraph/schema"
 	"github.com/dgraph-io/dgraph/types"
@@ -33,6 +37,11 @@ var (
 	emptySchemaResult pb.SchemaResult
 )
 
+// facetTypeString returns the declaration of the facet type in the schema, like "since: datetime".
+func facetTypeString(f *api.Facet) string {
+	return fmt.Sprintf("%s: %s", f.Key, strings.ToLower(f.ValType.String()))
+}
+
 type resultErr struct {
 	result *pb.SchemaResult
 	err    error
@@ -57,7 +66,8 @@ func getSchema(ctx context.Context, s *pb.SchemaRequest) (*pb.SchemaResult, erro
 		fields = s.Fields
 	} else {
 		fields = []string{"type", "index", "tokenizer", "reverse", "count", "list", "upsert",
-			"lang", "noconflict"}
+			"lang", "noconflict", "owned", "facetindex", "facets",
+			"view", "trigger", "sequence", "ordered", "validators", "presence", "indexstate"}
 	}
 
 	myGid := groups().groupId()
@@ -94,6 +104,9 @@ func populateSchema(attr string, fields []string) *pb.SchemaNode {
 		switch field {
 		case "type":
 			schemaNode.Type = typ.Name()
+			if schema.State().IsCounter(attr) {
+				schemaNode.Type = schema.CounterType
+			}
 		case "index":
 			schemaNode.Index = schema.State().IsIndexed(ctx, attr)
 		case "tokenizer":
@@ -112,6 +125,32 @@ func populateSchema(attr string, fields []string) *pb.SchemaNode {
 			schemaNode.Lang = schema.State().HasLang(attr)
 		case "noconflict":
 			schemaNode.NoConflict = schema.State().HasNoConflict(attr)
+		case "owned":
+			schemaNode.Owned = schema.State().IsOwned(ctx, attr)
+		case "facetindex":
+			schemaNode.FacetIndex = schema.State().FacetIndexes(ctx, attr)
+		case "facets":
+			for _, f := range schema.State().FacetTypes(ctx, attr) {
+				schemaNode.Facets = append(schemaNode.Facets, facetTypeString(f))
+			}
+		case "view":
+			schemaNode.View = schema.State().View(ctx, attr)
+		case "trigger":
+			schemaNode.Trigger = schema.State().Trigger(ctx, attr)
+		case "sequence":
+			schemaNode.Sequence = schema.State().IsSequence(attr)
+		case "ordered":
+			schemaNode.Ordered = schema.State().IsOrdered(attr)
+		case "validators":
+			schemaNode.Validators = schema.State().Validators(attr)
+		case "presence":
+			schemaNode.Presence = schema.State().HasPresence(ctx, attr)
+		case "indexstate":
+			// The state is only reported while the indexes aren't fully built.
+			if state, progress := posting.IndexStateOf(attr); state != posting.IndexBuilt {
+				schemaNode.IndexState = state
+				schemaNode.IndexProgress = progress
+			}
 		default:
 			//pass
 		}
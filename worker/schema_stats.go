@@ -0,0 +1,326 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/dgraph-io/dgraph/codec"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/schema"
+	"github.com/dgraph-io/dgraph/types"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// DefaultStatsScanLimit is the default number of nodes read per predicate to estimate its
+	// cardinality.
+	DefaultStatsScanLimit = 1000
+	// DefaultStatsSampleSize is the default number of sample values returned per predicate.
+	DefaultStatsSampleSize = 3
+
+	// statsConcurrency is the number of predicates whose stats are read at the same time.
+	statsConcurrency = 8
+)
+
+// SchemaStatsOptions are the options for GetSchemaStats.
+type SchemaStatsOptions struct {
+	// Predicates restricts the stats to the given predicates. All the predicates of the namespace
+	// are returned if empty.
+	Predicates []string
+	// ScanLimit is the maximum number of nodes read per predicate to estimate its cardinality.
+	ScanLimit int
+	// SampleSize is the number of sample values returned per predicate.
+	SampleSize int
+}
+
+// SchemaStats is the schema of a namespace along with the stats of its predicates and types, as
+// needed by a schema explorer.
+type SchemaStats struct {
+	ReadTs     uint64            `json:"readTs"`
+	Predicates []*PredicateStats `json:"predicates"`
+	Types      []*TypeStats      `json:"types"`
+}
+
+// PredicateStats holds the stats of a predicate.
+type PredicateStats struct {
+	Predicate string `json:"predicate"`
+	Type      string `json:"type"`
+	List      bool   `json:"list,omitempty"`
+	Lang      bool   `json:"lang,omitempty"`
+	Group     uint32 `json:"group"`
+	// Count is the number of nodes having the predicate. It is an estimate unless CountExact is
+	// set, see estimateCount.
+	Count      uint64 `json:"count"`
+	CountExact bool   `json:"countExact"`
+	// Samples are a few values of the predicate. The uids are in hex, and the passwords are never
+	// sampled.
+	Samples           []string     `json:"samples"`
+	Index             *IndexHealth `json:"index"`
+	OnDiskBytes       int64        `json:"onDiskBytes"`
+	UncompressedBytes int64        `json:"uncompressedBytes"`
+}
+
+// IndexHealth describes the indexes of a predicate.
+type IndexHealth struct {
+	Tokenizers []string `json:"tokenizers"`
+	Reverse    bool     `json:"reverse"`
+	Count      bool     `json:"count"`
+	// Indexing is set while the indexes of the predicate are being built, during which the
+	// queries use the previous indexes. It is only known for the predicates served by the group
+	// of this Alpha.
+	Indexing bool `json:"indexing"`
+	// Moving is set while the predicate is moved to another group, during which it can't be
+	// mutated.
+	Moving bool `json:"moving"`
+}
+
+// TypeStats holds the stats of a type.
+type TypeStats struct {
+	Name   string   `json:"name"`
+	Fields []string `json:"fields"`
+	// Count is the number of nodes of the type, read from the dgraph.type index.
+	Count uint64 `json:"count"`
+}
+
+// GetSchemaStats returns the schema of the namespace in the context, along with the cardinality,
+// sample values and index health of its predicates and the number of nodes of its types. It is
+// meant for the UIs exploring the schema: the stats are read from bounded scans and from the
+// dgraph.type index, without running count queries over the whole data.
+func GetSchemaStats(ctx context.Context, opts SchemaStatsOptions) (*SchemaStats, error) {
+	if err := x.HealthCheck(); err != nil {
+		return nil, err
+	}
+	if opts.ScanLimit <= 0 {
+		opts.ScanLimit = DefaultStatsScanLimit
+	}
+	if opts.SampleSize < 0 {
+		opts.SampleSize = 0
+	}
+	if opts.SampleSize > opts.ScanLimit {
+		opts.SampleSize = opts.ScanLimit
+	}
+	namespace, err := x.ExtractNamespace(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while getting schema stats")
+	}
+
+	ts, err := Timestamps(ctx, &pb.Num{ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	stats := &SchemaStats{
+		ReadTs:     ts.ReadOnly,
+		Predicates: []*PredicateStats{},
+		Types:      []*TypeStats{},
+	}
+
+	nodes, err := GetSchemaOverNetwork(ctx, &pb.SchemaRequest{
+		Predicates: x.NamespaceAttrList(namespace, opts.Predicates),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "while fetching schema")
+	}
+	tablets := make(map[string]*pb.Tablet)
+	for _, g := range GetMembershipState().GetGroups() {
+		for pred, tablet := range g.GetTablets() {
+			tablets[pred] = tablet
+		}
+	}
+	indexing := make(map[string]bool)
+	for _, pred := range schema.GetIndexingPredicates() {
+		indexing[pred] = true
+	}
+
+	for _, node := range nodes {
+		if x.ParseNamespace(node.Predicate) != namespace {
+			continue
+		}
+		ps := &PredicateStats{
+			Predicate: x.ParseAttr(node.Predicate),
+			Type:      node.Type,
+			List:      node.List,
+			Lang:      node.Lang,
+			Samples:   []string{},
+			Index: &IndexHealth{
+				Tokenizers: node.Tokenizer,
+				Reverse:    node.Reverse,
+				Count:      node.Count,
+				Indexing:   indexing[node.Predicate],
+			},
+		}
+		if ps.Index.Tokenizers == nil {
+			ps.Index.Tokenizers = []string{}
+		}
+		if tablet, ok := tablets[node.Predicate]; ok {
+			ps.Group = tablet.GroupId
+			ps.OnDiskBytes = tablet.OnDiskBytes
+			ps.UncompressedBytes = tablet.UncompressedBytes
+			ps.Index.Moving = tablet.ReadOnly
+		}
+		stats.Predicates = append(stats.Predicates, ps)
+	}
+	sort.Slice(stats.Predicates, func(i, j int) bool {
+		return stats.Predicates[i].Predicate < stats.Predicates[j].Predicate
+	})
+
+	maxUid := MaxLeaseId()
+	g, gctx := errgroup.WithContext(ctx)
+	sem := make(chan struct{}, statsConcurrency)
+	for _, ps := range stats.Predicates {
+		ps := ps
+		sem <- struct{}{}
+		g.Go(func() error {
+			defer func() { <-sem }()
+			return readPredicateStats(gctx, namespace, ps, stats.ReadTs, maxUid, opts)
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	if len(opts.Predicates) > 0 {
+		return stats, nil
+	}
+	for _, name := range schema.State().Types() {
+		if x.ParseNamespace(name) != namespace {
+			continue
+		}
+		typ, ok := schema.State().GetType(name)
+		if !ok {
+			continue
+		}
+		tstats := &TypeStats{Name: x.ParseAttr(name), Fields: []string{}}
+		for _, field := range typ.Fields {
+			tstats.Fields = append(tstats.Fields, x.ParseAttr(field.Predicate))
+		}
+		res, err := ProcessTaskOverNetwork(ctx, &pb.Query{
+			Attr:    x.NamespaceAttr(namespace, "dgraph.type"),
+			ReadTs:  stats.ReadTs,
+			SrcFunc: &pb.SrcFunction{Name: "eq", Args: []string{tstats.Name}},
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "while counting the nodes of type %s", tstats.Name)
+		}
+		for _, l := range res.UidMatrix {
+			tstats.Count += codec.ListCardinality(l)
+		}
+		stats.Types = append(stats.Types, tstats)
+	}
+	sort.Slice(stats.Types, func(i, j int) bool {
+		return stats.Types[i].Name < stats.Types[j].Name
+	})
+	return stats, nil
+}
+
+// readPredicateStats estimates the cardinality of the predicate from the first nodes having it,
+// and reads the values of a few of them.
+func readPredicateStats(ctx context.Context, namespace uint64, ps *PredicateStats,
+	readTs, maxUid uint64, opts SchemaStatsOptions) error {
+	attr := x.NamespaceAttr(namespace, ps.Predicate)
+	res, err := ProcessTaskOverNetwork(ctx, &pb.Query{
+		Attr:    attr,
+		ReadTs:  readTs,
+		First:   int32(opts.ScanLimit),
+		SrcFunc: &pb.SrcFunction{Name: "has"},
+	})
+	if err != nil {
+		return errors.Wrapf(err, "while scanning predicate %s", ps.Predicate)
+	}
+	var uids []uint64
+	if len(res.UidMatrix) > 0 {
+		uids = codec.GetUids(res.UidMatrix[0])
+	}
+	var last uint64
+	if len(uids) > 0 {
+		last = uids[len(uids)-1]
+	}
+	ps.Count, ps.CountExact = estimateCount(len(uids), opts.ScanLimit, last, maxUid)
+
+	if len(uids) > opts.SampleSize {
+		uids = uids[:opts.SampleSize]
+	}
+	if len(uids) == 0 || ps.Type == types.PasswordID.Name() {
+		return nil
+	}
+	q := &pb.Query{
+		Attr:    attr,
+		ReadTs:  readTs,
+		UidList: &pb.List{SortedUids: uids},
+	}
+	if ps.Lang {
+		q.Langs = []string{"."}
+	}
+	res, err = ProcessTaskOverNetwork(ctx, q)
+	if err != nil {
+		return errors.Wrapf(err, "while sampling predicate %s", ps.Predicate)
+	}
+	for _, l := range res.UidMatrix {
+		for _, uid := range codec.GetUids(l) {
+			ps.Samples = append(ps.Samples, fmt.Sprintf("%#x", uid))
+		}
+	}
+	for _, vals := range res.ValueMatrix {
+		for _, tv := range vals.Values {
+			if s, ok := sampleString(tv); ok {
+				ps.Samples = append(ps.Samples, s)
+			}
+		}
+	}
+	if len(ps.Samples) > opts.SampleSize {
+		ps.Samples = ps.Samples[:opts.SampleSize]
+	}
+	return nil
+}
+
+// estimateCount returns the number of nodes having a predicate, given that a scan limited to
+// limit nodes found the given number of nodes, the last one being last. The count is exact if the
+// scan wasn't cut short. Otherwise, the nodes are assumed to be spread evenly over the leased
+// uids, so the count is extrapolated from the share of the uids covered by the scan.
+func estimateCount(found, limit int, last, maxUid uint64) (uint64, bool) {
+	if found < limit || last == 0 {
+		return uint64(found), true
+	}
+	if maxUid <= last {
+		return uint64(found), false
+	}
+	return uint64(float64(found) * float64(maxUid) / float64(last)), false
+}
+
+// sampleString returns the string form of a value read by a task. The values which can't be
+// converted, like the corrupt ones, are skipped.
+func sampleString(tv *pb.TaskValue) (string, bool) {
+	tid := types.TypeID(tv.ValType)
+	if len(tv.Val) == 0 || !tid.IsScalar() {
+		return "", false
+	}
+	val, err := types.Convert(types.Val{Tid: types.BinaryID, Value: tv.Val}, tid)
+	if err != nil {
+		return "", false
+	}
+	out := types.Val{Tid: types.StringID}
+	if err := types.Marshal(val, &out); err != nil {
+		// Some types, like geo, can't be converted to a string.
+		return fmt.Sprintf("%v", val.Value), true
+	}
+	s, ok := out.Value.(string)
+	return s, ok
+}
@@ -0,0 +1,52 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimateCount(t *testing.T) {
+	// The scan found fewer nodes than the limit, so it read all of them.
+	count, exact := estimateCount(10, 100, 500, 1000)
+	require.Equal(t, uint64(10), count)
+	require.True(t, exact)
+
+	// The 100 nodes found cover the first fourth of the leased uids.
+	count, exact = estimateCount(100, 100, 250, 1000)
+	require.Equal(t, uint64(400), count)
+	require.False(t, exact)
+
+	count, exact = estimateCount(100, 100, 1000, 1000)
+	require.Equal(t, uint64(100), count)
+	require.False(t, exact)
+}
+
+func TestSampleString(t *testing.T) {
+	val := types.ValueForType(types.BinaryID)
+	require.NoError(t, types.Marshal(types.Val{Tid: types.IntID, Value: int64(42)}, &val))
+	s, ok := sampleString(&pb.TaskValue{Val: val.Value.([]byte), ValType: types.IntID.Enum()})
+	require.True(t, ok)
+	require.Equal(t, "42", s)
+
+	_, ok = sampleString(&pb.TaskValue{ValType: types.IntID.Enum()})
+	require.False(t, ok)
+}
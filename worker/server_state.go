@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/dgraph-io/badger/v3"
+	"github.com/dgraph-io/dgraph/posting"
 	"github.com/dgraph-io/dgraph/protos/pb"
 	"github.com/dgraph-io/dgraph/raftwal"
 	"github.com/dgraph-io/dgraph/x"
@@ -42,13 +43,22 @@ const (
 	BadgerDefaults = `compression=snappy; numgoroutines=8;`
 	CacheDefaults  = `size-mb=1024; percentage=50,30,20;`
 	CDCDefaults    = `file=; kafka=; sasl_user=; sasl_password=; ca_cert=; client_cert=; ` +
-		`client_key=; sasl-mechanism=PLAIN;`
-	GraphQLDefaults = `introspection=true; debug=false; extensions=true; poll-interval=1s; `
-	LambdaDefaults  = `url=; num=1; port=20000; restart-after=10s; `
-	LimitDefaults   = `mutations=allow; query-edge=1000000; normalize-node=10000; ` +
+		`client_key=; sasl-mechanism=PLAIN; dgraph=; dgraph-zero=; dgraph-user=; ` +
+		`dgraph-password=; views=false; triggers=false; trigger-retries=3; ` +
+		`trigger-timeout=10s; trigger-dlq=dlq;`
+	GraphQLDefaults = `introspection=true; debug=false; extensions=true; poll-interval=1s; ` +
+		`breaker-failures=5; breaker-cooldown=30s; schema-history=10; `
+	IntegrityDefaults = `checksums=false; count-index=eventual;`
+	LambdaDefaults    = `url=; num=1; port=20000; restart-after=10s; batch-size=1000; ` +
+		`batch-concurrency=4; `
+	LimitDefaults = `mutations=allow; query-edge=1000000; normalize-node=10000; ` +
 		`mutations-nquad=1000000; disallow-drop=false; query-timeout=0ms; txn-abort-after=5m;` +
-		`max-pending-queries=64;  max-retries=-1; shared-instance=false;`
-	RaftDefaults = `learner=false; snapshot-after-entries=10000; ` +
+		`max-pending-queries=64; max-predicate-tasks=0; max-retries=-1; max-prepared-queries=10000; ` +
+		`shared-instance=false; history=0s; txn-max-age=0s; txn-warn-age=1m; ` +
+		`txn-postings=0; txn-node-edges=0; txn-ts-batch=1; txn-ts-max-age=10ms; ` +
+		`idempotency-ttl=24h; max-query-jobs=16; query-job-ttl=1h; query-job-timeout=1h;`
+	MetricsDefaults = `predicates=false;`
+	RaftDefaults    = `learner=false; witness=false; snapshot-after-entries=10000; ` +
 		`snapshot-after-duration=30m; pending-proposals=256; idx=; group=;`
 	SecurityDefaults   = `token=; whitelist=;`
 	ZeroLimitsDefaults = `uid-lease=0; refill-interval=30s; disable-admin-http=false;`
@@ -173,20 +183,15 @@ func (s *ServerState) GetTimestamp(readOnly bool) uint64 {
 }
 
 func (s *ServerState) fillTimestampRequests() {
-	const (
-		initDelay = 10 * time.Millisecond
-		maxDelay  = time.Second
-	)
-
 	defer func() {
 		glog.Infoln("Exiting fillTimestampRequests")
 	}()
 
 	var reqs []tsReq
+	var block tsBlock
 	for {
 		// Reset variables.
 		reqs = reqs[:0]
-		delay := initDelay
 
 		select {
 		case <-s.gcCloser.HasBeenClosed():
@@ -205,45 +210,108 @@ func (s *ServerState) fillTimestampRequests() {
 
 		// Generate the request.
 		num := &pb.Num{}
+		var writes int
 		for _, r := range reqs {
 			if r.readOnly {
 				num.ReadOnly = true
 			} else {
-				num.Val++
+				writes++
 			}
 		}
-
-		// Execute the request with infinite retries.
-	retry:
-		if s.gcCloser.Ctx().Err() != nil {
-			return
+		// Serve what we can from the timestamps leased ahead.
+		startTs := block.take(writes, posting.Oracle().MaxCommitTs(),
+			x.WorkerConfig.TxnTsMaxAge, time.Now())
+		if more := uint64(writes - len(startTs)); more > 0 {
+			num.Val = x.Max(more, x.WorkerConfig.TxnTsBatch)
 		}
-		ctx, cancel := context.WithTimeout(s.gcCloser.Ctx(), 10*time.Second)
-		ts, err := Timestamps(ctx, num)
-		cancel()
-		if err != nil {
-			glog.Warningf("Error while retrieving timestamps: %v with delay: %v."+
-				" Will retry...\n", err, delay)
-			time.Sleep(delay)
-			delay *= 2
-			if delay > maxDelay {
-				delay = maxDelay
+
+		var ts *pb.AssignedIds
+		if num.Val > 0 || num.ReadOnly {
+			leasedAt := time.Now()
+			if ts = s.leaseTimestamps(num); ts == nil {
+				return
+			}
+			if num.Val > 0 {
+				// The new timestamps are above any commit made before the request, so they're
+				// all good for the transactions waiting for it.
+				block = tsBlock{next: ts.StartId, left: ts.EndId - ts.StartId + 1, leasedAt: leasedAt}
+				startTs = append(startTs, block.take(writes-len(startTs), 0, 0, leasedAt)...)
 			}
-			goto retry
 		}
-		var offset uint64
+		x.AssertTrue(len(startTs) == writes)
+
 		for _, req := range reqs {
 			if req.readOnly {
 				req.ch <- ts.ReadOnly
 			} else {
-				req.ch <- ts.StartId + offset
-				offset++
+				req.ch <- startTs[0]
+				startTs = startTs[1:]
 			}
 		}
-		x.AssertTrue(ts.StartId == 0 || ts.StartId+offset-1 == ts.EndId)
 	}
 }
 
+// leaseTimestamps sends the timestamp request to Zero with infinite retries. It returns nil if
+// the server is shutting down.
+func (s *ServerState) leaseTimestamps(num *pb.Num) *pb.AssignedIds {
+	const (
+		initDelay = 10 * time.Millisecond
+		maxDelay  = time.Second
+	)
+
+	delay := initDelay
+	for s.gcCloser.Ctx().Err() == nil {
+		ctx, cancel := context.WithTimeout(s.gcCloser.Ctx(), 10*time.Second)
+		ts, err := Timestamps(ctx, num)
+		cancel()
+		if err == nil {
+			x.AssertTrue(ts.StartId == 0 || ts.EndId-ts.StartId+1 == num.Val)
+			return ts
+		}
+		glog.Warningf("Error while retrieving timestamps: %v with delay: %v."+
+			" Will retry...\n", err, delay)
+		time.Sleep(delay)
+		delay *= 2
+		if delay > maxDelay {
+			delay = maxDelay
+		}
+	}
+	return nil
+}
+
+// tsBlock is a block of start timestamps leased from Zero ahead of the transactions which use
+// them, see --limit "txn-ts-batch".
+type tsBlock struct {
+	next     uint64 // the next timestamp to hand out.
+	left     uint64 // the number of timestamps left, from next on.
+	leasedAt time.Time
+}
+
+// take returns up to n timestamps of the block, in increasing order. The block is only used for
+// maxAge after it was leased, and the timestamps up to minTs, which is the highest commit
+// timestamp seen by the Alpha, are skipped. So a transaction started through this Alpha always
+// reads the commits which completed through it before. A zero maxAge doesn't limit the age.
+func (b *tsBlock) take(n int, minTs uint64, maxAge time.Duration, now time.Time) []uint64 {
+	if maxAge > 0 && now.Sub(b.leasedAt) > maxAge {
+		b.left = 0
+	}
+	if b.left > 0 && b.next <= minTs {
+		skip := minTs - b.next + 1
+		if skip >= b.left {
+			skip = b.left
+		}
+		b.next += skip
+		b.left -= skip
+	}
+	var res []uint64
+	for len(res) < n && b.left > 0 {
+		res = append(res, b.next)
+		b.next++
+		b.left--
+	}
+	return res
+}
+
 type tsReq struct {
 	readOnly bool
 	// A one-shot chan which we can send a txn timestamp upon.
@@ -0,0 +1,48 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTsBlock(t *testing.T) {
+	now := time.Now()
+	var empty tsBlock
+	require.Empty(t, empty.take(1, 0, time.Second, now))
+
+	b := tsBlock{next: 100, left: 10, leasedAt: now}
+	require.Equal(t, []uint64{100, 101}, b.take(2, 50, time.Second, now))
+	// The timestamps up to the highest commit seen are skipped, so the order is kept.
+	require.Equal(t, []uint64{105, 106}, b.take(2, 104, time.Second, now))
+	require.Equal(t, []uint64{107, 108, 109}, b.take(5, 0, time.Second, now))
+	require.Empty(t, b.take(1, 0, time.Second, now))
+
+	// A commit past the block uses it up.
+	b = tsBlock{next: 100, left: 10, leasedAt: now}
+	require.Empty(t, b.take(1, 120, time.Second, now))
+	require.Empty(t, b.take(1, 0, time.Second, now))
+
+	// The block isn't used past its max age, unless there's no limit.
+	b = tsBlock{next: 100, left: 10, leasedAt: now.Add(-time.Minute)}
+	require.Equal(t, []uint64{100}, b.take(1, 0, 0, now))
+	require.Empty(t, b.take(1, 0, time.Second, now))
+	require.Empty(t, b.take(1, 0, 0, now))
+}
@@ -64,6 +64,8 @@ func GetSink(conf *z.SuperFlag) (Sink, error) {
 		return newKafkaSink(conf)
 	case conf.GetPath("file") != "":
 		return newFileSink(conf)
+	case conf.GetString("dgraph") != "":
+		return newDgraphSink(conf)
 	}
 	return nil, errors.New("sink config is not provided")
 }
@@ -86,30 +88,11 @@ func newKafkaSink(config *z.SuperFlag) (Sink, error) {
 	saramaConf.Producer.Return.Successes = true
 	saramaConf.Producer.Return.Errors = true
 
-	if config.GetPath("ca-cert") != "" {
-		tlsCfg := &tls.Config{}
-		var pool *x509.CertPool
-		var err error
-		if pool, err = x509.SystemCertPool(); err != nil {
-			return nil, err
-		}
-		caFile, err := ioutil.ReadFile(config.GetPath("ca-cert"))
-		if err != nil {
-			return nil, errors.Wrap(err, "unable to read ca cert file")
-		}
-		if !pool.AppendCertsFromPEM(caFile) {
-			return nil, errors.New("not able to append certificates")
-		}
-		tlsCfg.RootCAs = pool
-		cert := config.GetPath("client-cert")
-		key := config.GetPath("client-key")
-		if cert != "" && key != "" {
-			cert, err := tls.LoadX509KeyPair(cert, key)
-			if err != nil {
-				return nil, errors.Wrap(err, "unable to load client cert and key")
-			}
-			tlsCfg.Certificates = []tls.Certificate{cert}
-		}
+	tlsCfg, err := sinkTLSConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	if tlsCfg != nil {
 		saramaConf.Net.TLS.Enable = true
 		saramaConf.Net.TLS.Config = tlsCfg
 	}
@@ -155,6 +138,37 @@ func newKafkaSink(config *z.SuperFlag) (Sink, error) {
 	}, nil
 }
 
+// sinkTLSConfig returns the TLS config for connecting to the sink, or nil if no CA cert is given.
+func sinkTLSConfig(config *z.SuperFlag) (*tls.Config, error) {
+	if config.GetPath("ca-cert") == "" {
+		return nil, nil
+	}
+	tlsCfg := &tls.Config{}
+	var pool *x509.CertPool
+	var err error
+	if pool, err = x509.SystemCertPool(); err != nil {
+		return nil, err
+	}
+	caFile, err := ioutil.ReadFile(config.GetPath("ca-cert"))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read ca cert file")
+	}
+	if !pool.AppendCertsFromPEM(caFile) {
+		return nil, errors.New("not able to append certificates")
+	}
+	tlsCfg.RootCAs = pool
+	cert := config.GetPath("client-cert")
+	key := config.GetPath("client-key")
+	if cert != "" && key != "" {
+		cert, err := tls.LoadX509KeyPair(cert, key)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to load client cert and key")
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	}
+	return tlsCfg, nil
+}
+
 func (k *kafkaSinkClient) Send(messages []SinkMessage) error {
 	if len(messages) == 0 {
 		return nil
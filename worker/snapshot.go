@@ -17,7 +17,9 @@
 package worker
 
 import (
+	"bytes"
 	"context"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -28,6 +30,8 @@ import (
 	"go.etcd.io/etcd/raft"
 
 	"github.com/dgraph-io/badger/v3"
+	badgerpb "github.com/dgraph-io/badger/v3/pb"
+	"github.com/dgraph-io/badger/v3/y"
 	"github.com/dgraph-io/dgraph/conn"
 	"github.com/dgraph-io/dgraph/posting"
 	"github.com/dgraph-io/dgraph/protos/pb"
@@ -38,6 +42,16 @@ import (
 const (
 	// MB represents a megabyte.
 	MB = 1 << 20
+
+	// snapshotAttempts is the number of times the stream of a snapshot is attempted before giving
+	// up on it. The attempts after the first one resume after the last key received if they can,
+	// see snapshotReceiver.nextAttempt.
+	snapshotAttempts = 10
+	// snapshotZstdLevel is the zstd level of the batches of a compressed snapshot stream.
+	snapshotZstdLevel = 1
+	// resumedStreamShift shifts the stream ids of the batches of a resumed stream, so that they
+	// don't reuse the ids of the previous attempts, see snapshotReceiver.
+	resumedStreamShift = 24
 )
 
 type badgerWriter interface {
@@ -45,69 +59,262 @@ type badgerWriter interface {
 	Flush() error
 }
 
-// populateSnapshot gets data for a shard from the leader and writes it to BadgerDB on the follower.
-func (n *node) populateSnapshot(snap pb.Snapshot, pl *conn.Pool) error {
-	c := pb.NewWorkerClient(pl.Get())
+// SnapshotProgress is the progress of the transfer of a snapshot to this Alpha.
+type SnapshotProgress struct {
+	Index  uint64 `json:"index"`
+	ReadTs uint64 `json:"readTs"`
+	// Received is the number of bytes received so far, and Size their size once uncompressed.
+	Received int64 `json:"received"`
+	Size     int64 `json:"size"`
+	Batches  int64 `json:"batches"`
+	Attempts int   `json:"attempts"`
+	// Elapsed is the number of seconds since the transfer started.
+	Elapsed int64 `json:"elapsed"`
+}
 
-	// We should absolutely cancel the context when we return from this function, that way, the
-	// leader who is sending the snapshot would stop sending.
-	ctx, cancel := context.WithCancel(n.ctx)
-	defer cancel()
+var snapshotProgress struct {
+	sync.Mutex
+	cur   *SnapshotProgress
+	start time.Time
+}
 
-	// Set my RaftContext on the snapshot, so it's easier to locate me.
-	snap.Context = n.RaftContext
-	stream, err := c.StreamSnapshot(ctx)
-	if err != nil {
-		return err
+// GetSnapshotProgress returns the progress of the snapshot being received, nil if there's none.
+func GetSnapshotProgress() *SnapshotProgress {
+	snapshotProgress.Lock()
+	defer snapshotProgress.Unlock()
+	if snapshotProgress.cur == nil {
+		return nil
 	}
+	p := *snapshotProgress.cur
+	p.Elapsed = int64(time.Since(snapshotProgress.start) / time.Second)
+	return &p
+}
 
-	if err := stream.Send(&snap); err != nil {
-		return err
+// snapshotReceiver writes the batches of the streams of a snapshot. It outlives the streams, so
+// that a broken transfer resumes after the last key written instead of starting over.
+type snapshotReceiver struct {
+	writer badgerWriter
+	// resumable is set once a stream broke. The leader then streams the keys in order, with a
+	// single goroutine, so that the next breaks resume after the last key received. The first
+	// stream is parallel, which is much faster.
+	resumable bool
+	// ordered is set while all the batches received were streamed in the order of their keys,
+	// which is needed to resume the transfer.
+	ordered bool
+	lastKey []byte
+	// A resumed stream starts over from the first stream id. Its key ranges are after lastKey, so
+	// they can't be appended to the ranges of the stream writer already written with the same
+	// ids, which would overlap the others. So streamBase is added to their ids.
+	streamBase uint32
+	// writeErr is set if a batch couldn't be written, which isn't worth a retry.
+	writeErr error
+	lastLog  time.Time
+}
+
+// write writes a batch of the stream.
+func (r *snapshotReceiver) write(kvs *pb.KVS) error {
+	data := kvs.Data
+	if kvs.Compressed {
+		var err error
+		if data, err = y.ZSTDDecompress(nil, kvs.Data); err != nil {
+			return errors.Wrapf(err, "while decompressing snapshot batch")
+		}
 	}
+	buf := z.NewBufferSlice(data)
 
-	var writer badgerWriter
-	if snap.SinceTs == 0 {
-		sw := pstore.NewStreamWriter()
-		defer sw.Cancel()
+	var last []byte
+	if err := buf.SliceIterate(func(s []byte) error {
+		last = s
+		return nil
+	}); err != nil {
+		return err
+	}
+	if r.streamBase > 0 {
+		list, err := badger.BufferToKVList(buf)
+		if err != nil {
+			return err
+		}
+		out := z.NewBuffer(len(data), "Snapshot.Resume")
+		defer out.Release()
+		for _, kv := range list.Kv {
+			kv.StreamId += r.streamBase
+			badger.KVToBuffer(kv, out)
+		}
+		buf = out
+	}
+	if err := r.writer.Write(buf); err != nil {
+		r.writeErr = err
+		return err
+	}
 
-		if err := sw.Prepare(); err != nil {
+	r.ordered = r.ordered && kvs.Ordered
+	if last != nil {
+		var kv badgerpb.KV
+		if err := kv.Unmarshal(last); err != nil {
 			return err
 		}
+		r.lastKey = kv.Key
+	}
 
-		writer = sw
-	} else {
-		writer = pstore.NewManagedWriteBatch()
+	snapshotProgress.Lock()
+	defer snapshotProgress.Unlock()
+	p := snapshotProgress.cur
+	if p == nil {
+		return nil
+	}
+	p.Received += int64(len(kvs.Data))
+	p.Size += int64(len(data))
+	p.Batches++
+	if time.Since(r.lastLog) > 10*time.Second {
+		r.lastLog = time.Now()
+		glog.Infof("Received snapshot at index %d: %s (%s uncompressed) in %d batches, %s.",
+			p.Index, humanize.IBytes(uint64(p.Received)), humanize.IBytes(uint64(p.Size)),
+			p.Batches, time.Since(snapshotProgress.start).Round(time.Second))
+	}
+	return nil
+}
+
+// receive runs a stream of the snapshot, resuming after the last key received if there's one. It
+// returns the stream to acknowledge once the data is received, and its last batch.
+func (r *snapshotReceiver) receive(ctx context.Context, c pb.WorkerClient,
+	snap pb.Snapshot) (pb.Worker_StreamSnapshotClient, *pb.KVS, error) {
+
+	snap.Compress = true
+	snap.Resumable = r.resumable
+	if r.resumable {
+		snap.ResumeKey = r.lastKey
+	}
+	stream, err := c.StreamSnapshot(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := stream.Send(&snap); err != nil {
+		return nil, nil, err
 	}
 
-	// We can use count to check the number of posting lists returned in tests.
-	size := 0
-	var done *pb.KVS
 	for {
 		kvs, err := stream.Recv()
 		if err != nil {
-			return err
+			return nil, nil, err
 		}
 		if kvs.Done {
-			done = kvs
 			glog.V(1).Infoln("All key-values have been received.")
-			break
+			return stream, kvs, nil
 		}
 		select {
 		case <-ctx.Done():
-			return ctx.Err()
+			return nil, nil, ctx.Err()
 		default:
 		}
 
-		size += len(kvs.Data)
-		glog.V(1).Infof("Received batch of size: %s. Total so far: %s\n",
-			humanize.IBytes(uint64(len(kvs.Data))), humanize.IBytes(uint64(size)))
+		glog.V(1).Infof("Received batch of size: %s.", humanize.IBytes(uint64(len(kvs.Data))))
+		if err := r.write(kvs); err != nil {
+			return nil, nil, err
+		}
+	}
+}
+
+// nextAttempt prepares the receiver for the next attempt of a broken transfer. It returns true if
+// the transfer resumes after the last key received, and false if it starts over because the keys
+// weren't received in order, in which case what was written must be dropped.
+func (r *snapshotReceiver) nextAttempt(attempt int, sinceTs uint64) bool {
+	defer func() { r.resumable = true }()
+	switch {
+	case r.lastKey == nil:
+		// Nothing was received, there's nothing to resume.
+		return true
+	case r.resumable && r.ordered:
+		if sinceTs == 0 {
+			r.streamBase = uint32(attempt) << resumedStreamShift
+		}
+		return true
+	default:
+		r.lastKey, r.streamBase, r.ordered = nil, 0, true
+		return false
+	}
+}
+
+// populateSnapshot gets data for a shard from the leader and writes it to BadgerDB on the follower.
+// The data is received compressed. If the stream breaks, the transfer is attempted again with
+// the keys streamed in order, so that it resumes after the last key received if it breaks again.
+func (n *node) populateSnapshot(snap pb.Snapshot, pl *conn.Pool) error {
+	c := pb.NewWorkerClient(pl.Get())
 
-		buf := z.NewBufferSlice(kvs.Data)
-		if err := writer.Write(buf); err != nil {
+	// We should absolutely cancel the context when we return from this function, that way, the
+	// leader who is sending the snapshot would stop sending.
+	ctx, cancel := context.WithCancel(n.ctx)
+	defer cancel()
+
+	// Set my RaftContext on the snapshot, so it's easier to locate me.
+	snap.Context = n.RaftContext
+
+	r := &snapshotReceiver{ordered: true, lastLog: time.Now()}
+	var sw *badger.StreamWriter
+	defer func() {
+		if sw != nil {
+			sw.Cancel()
+		}
+	}()
+	// prepare sets up the writer, dropping what was written by a previous attempt. The keys
+	// written to a managed write batch are simply written again.
+	prepare := func() error {
+		if snap.SinceTs != 0 {
+			if r.writer == nil {
+				r.writer = pstore.NewManagedWriteBatch()
+			}
+			return nil
+		}
+		if sw != nil {
+			sw.Cancel()
+		}
+		sw = pstore.NewStreamWriter()
+		r.writer = sw
+		return sw.Prepare()
+	}
+	if err := prepare(); err != nil {
+		return err
+	}
+
+	snapshotProgress.Lock()
+	snapshotProgress.cur = &SnapshotProgress{Index: snap.Index, ReadTs: snap.ReadTs}
+	snapshotProgress.start = time.Now()
+	snapshotProgress.Unlock()
+	defer func() {
+		snapshotProgress.Lock()
+		snapshotProgress.cur = nil
+		snapshotProgress.Unlock()
+	}()
+
+	var stream pb.Worker_StreamSnapshotClient
+	var done *pb.KVS
+	for attempt := 1; ; attempt++ {
+		snapshotProgress.Lock()
+		snapshotProgress.cur.Attempts = attempt
+		snapshotProgress.Unlock()
+
+		var err error
+		stream, done, err = r.receive(ctx, c, snap)
+		if err == nil {
+			break
+		}
+		if ctx.Err() != nil || r.writeErr != nil || attempt == snapshotAttempts {
 			return err
 		}
+		if r.nextAttempt(attempt, snap.SinceTs) {
+			glog.Warningf("While receiving snapshot at index %d: %v. Resuming after key %x, "+
+				"attempt %d of %d.", snap.Index, err, r.lastKey, attempt+1, snapshotAttempts)
+		} else {
+			// The transfer can only resume after the last key received if the keys were received
+			// in order, otherwise the keys before it aren't all there.
+			glog.Warningf("While receiving snapshot at index %d: %v. Starting over, attempt %d "+
+				"of %d.", snap.Index, err, attempt+1, snapshotAttempts)
+			if err := prepare(); err != nil {
+				return err
+			}
+		}
+		time.Sleep(time.Duration(attempt) * time.Second)
 	}
-	if err := writer.Flush(); err != nil {
+	if err := r.writer.Flush(); err != nil {
 		return err
 	}
 
@@ -124,7 +331,9 @@ func (n *node) populateSnapshot(snap pb.Snapshot, pl *conn.Pool) error {
 	}
 
 	x.VerifySnapshot(pstore, snap.ReadTs)
-	glog.Infof("Populated snapshot with data size: %s\n", humanize.IBytes(uint64(size)))
+	p := GetSnapshotProgress()
+	glog.Infof("Populated snapshot with data size: %s, received %s in %d attempts.\n",
+		humanize.IBytes(uint64(p.Size)), humanize.IBytes(uint64(p.Received)), p.Attempts)
 	return nil
 }
 
@@ -213,12 +422,30 @@ func doStreamSnapshot(snap *pb.Snapshot, out pb.Worker_StreamSnapshotServer) err
 	// Instead, we just stream out all the versions as they are.
 	stream.KeyToList = nil
 	stream.SinceTs = snap.SinceTs
-	if snap.SinceTs == 0 {
+	switch {
+	case snap.Resumable:
+		// Stream the keys in order with a single goroutine, so that the receiver can resume after
+		// the last key it got. Tables can't be copied as they are then.
+		stream.NumGo = 1
+		if len(snap.ResumeKey) > 0 {
+			glog.Infof("Resuming snapshot stream after key %x", snap.ResumeKey)
+			stream.ChooseKey = func(item *badger.Item) bool {
+				return bytes.Compare(item.Key(), snap.ResumeKey) > 0
+			}
+		}
+	case snap.SinceTs == 0:
 		// Do full table copy when streaming the entire data.
 		stream.FullCopy = true
 	}
 	stream.Send = func(buf *z.Buffer) error {
-		kvs := &pb.KVS{Data: buf.Bytes()}
+		kvs := &pb.KVS{Data: buf.Bytes(), Ordered: snap.Resumable}
+		if snap.Compress {
+			data, err := y.ZSTDCompress(nil, kvs.Data, snapshotZstdLevel)
+			if err != nil {
+				return err
+			}
+			kvs.Data, kvs.Compressed = data, true
+		}
 		return out.Send(kvs)
 	}
 
@@ -282,6 +509,16 @@ func (w *grpcWorker) StreamSnapshot(stream pb.Worker_StreamSnapshotServer) error
 		return err
 	}
 	glog.Infof("Got StreamSnapshot request: %+v\n", snap)
+	// A witness holds no data, and a replica which hasn't applied the snapshot yet doesn't hold
+	// all of it. Streaming from them would replace the data of the receiver with an empty or a
+	// partial copy, so the receiver tries another member.
+	if n.witness {
+		return errors.Errorf("Witness %#x holds no data to stream", n.Id)
+	}
+	if applied := n.Applied.DoneUntil(); applied < snap.Index {
+		return errors.Errorf("Node %#x applied index %d, behind snapshot index %d", n.Id, applied,
+			snap.Index)
+	}
 	if err := doStreamSnapshot(snap, stream); err != nil {
 		glog.Errorf("While streaming snapshot: %v. Reporting failure.", err)
 		n.Raft().ReportSnapshot(snap.Context.GetId(), raft.SnapshotFailure)
@@ -28,12 +28,100 @@ import (
 	"testing"
 	"time"
 
+	"github.com/dgraph-io/badger/v3"
+	badgerpb "github.com/dgraph-io/badger/v3/pb"
+	"github.com/dgraph-io/badger/v3/y"
 	"github.com/dgraph-io/dgo/v210"
 	"github.com/dgraph-io/dgo/v210/protos/api"
+	"github.com/dgraph-io/dgraph/protos/pb"
 	"github.com/dgraph-io/dgraph/testutil"
+	"github.com/dgraph-io/ristretto/z"
 	"github.com/stretchr/testify/require"
 )
 
+type kvsWriter struct {
+	kvs []*badgerpb.KV
+}
+
+func (w *kvsWriter) Write(buf *z.Buffer) error {
+	list, err := badger.BufferToKVList(buf)
+	if err != nil {
+		return err
+	}
+	w.kvs = append(w.kvs, list.Kv...)
+	return nil
+}
+
+func (w *kvsWriter) Flush() error { return nil }
+
+func snapshotBatch(streamId uint32, keys ...string) []byte {
+	buf := z.NewBuffer(1<<10, "TestSnapshotReceiver")
+	defer buf.Release()
+	for _, k := range keys {
+		badger.KVToBuffer(&badgerpb.KV{Key: []byte(k), Value: []byte("v"), StreamId: streamId}, buf)
+	}
+	return append([]byte{}, buf.Bytes()...)
+}
+
+func TestSnapshotReceiver(t *testing.T) {
+	w := &kvsWriter{}
+	r := &snapshotReceiver{writer: w, ordered: true}
+	snapshotProgress.cur = &SnapshotProgress{Index: 5}
+	defer func() { snapshotProgress.cur = nil }()
+
+	first := snapshotBatch(1, "a", "b")
+	require.NoError(t, r.write(&pb.KVS{Data: first, Ordered: true}))
+	require.Equal(t, "b", string(r.lastKey))
+
+	// A compressed batch of a resumed stream has its stream ids shifted.
+	r.streamBase = 1 << resumedStreamShift
+	data := snapshotBatch(1, "c")
+	compressed, err := y.ZSTDCompress(nil, data, snapshotZstdLevel)
+	require.NoError(t, err)
+	require.NoError(t, r.write(&pb.KVS{Data: compressed, Compressed: true, Ordered: true}))
+	require.Equal(t, "c", string(r.lastKey))
+	require.True(t, r.ordered)
+
+	require.Len(t, w.kvs, 3)
+	require.Equal(t, uint32(1), w.kvs[1].StreamId)
+	require.Equal(t, uint32(1<<resumedStreamShift+1), w.kvs[2].StreamId)
+
+	p := GetSnapshotProgress()
+	require.Equal(t, int64(2), p.Batches)
+	require.Equal(t, int64(len(first)+len(data)), p.Size)
+	require.Equal(t, int64(len(first)+len(compressed)), p.Received)
+
+	// The keys of a stream from a leader streaming out of order can't resume the transfer.
+	require.NoError(t, r.write(&pb.KVS{Data: snapshotBatch(2, "d")}))
+	require.False(t, r.ordered)
+}
+
+func TestSnapshotReceiverNextAttempt(t *testing.T) {
+	r := &snapshotReceiver{writer: &kvsWriter{}, ordered: true}
+	snapshotProgress.cur = &SnapshotProgress{Index: 5}
+	defer func() { snapshotProgress.cur = nil }()
+
+	// A broken stream which sent nothing is attempted again in order.
+	require.True(t, r.nextAttempt(1, 0))
+	require.True(t, r.resumable)
+
+	// A broken parallel stream starts over, in order.
+	r = &snapshotReceiver{writer: &kvsWriter{}, ordered: true}
+	require.NoError(t, r.write(&pb.KVS{Data: snapshotBatch(1, "b")}))
+	require.NoError(t, r.write(&pb.KVS{Data: snapshotBatch(2, "a")}))
+	require.False(t, r.nextAttempt(1, 0))
+	require.True(t, r.resumable)
+	require.True(t, r.ordered)
+	require.Nil(t, r.lastKey)
+	require.Zero(t, r.streamBase)
+
+	// A broken stream in order resumes after the last key received, with new stream ids.
+	require.NoError(t, r.write(&pb.KVS{Data: snapshotBatch(1, "a", "b"), Ordered: true}))
+	require.True(t, r.nextAttempt(2, 0))
+	require.Equal(t, "b", string(r.lastKey))
+	require.Equal(t, uint32(2<<resumedStreamShift), r.streamBase)
+}
+
 func TestSnapshot(t *testing.T) {
 	snapshotTs := uint64(0)
 
@@ -387,8 +387,43 @@ func multiSort(ctx context.Context, r *sortresult, ts *pb.SortMessage) error {
 	}
 
 	// Execute rest of the sorts concurrently.
-	och := make(chan orderResult, len(ts.Order)-1)
+	orders := make([]int, 0, len(ts.Order)-1)
 	for i := 1; i < len(ts.Order); i++ {
+		orders = append(orders, i)
+	}
+	if err := fetchOrderValues(ctx, ts, dest, orders, sortVals); err != nil {
+		return err
+	}
+
+	desc := make([]bool, 0, len(ts.Order))
+	for _, o := range ts.Order {
+		desc = append(desc, o.Desc)
+	}
+
+	// Values have been accumulated, now we do the multisort for each list.
+	for i, ul := range r.reply.UidMatrix {
+		vals := make([][]types.Val, len(ul.SortedUids))
+		for j, uid := range ul.SortedUids {
+			vals[j] = sortVals[uid]
+		}
+		if err := types.Sort(vals, &ul.SortedUids, desc, ""); err != nil {
+			return err
+		}
+		// Paginate
+		start, end := x.PageRange(int(ts.Count), int(r.multiSortOffsets[i]), len(ul.SortedUids))
+		ul.SortedUids = ul.SortedUids[start:end]
+		r.reply.UidMatrix[i] = ul
+	}
+
+	return nil
+}
+
+// fetchOrderValues fetches the values of the given orders of the sort message for the uids of
+// dest concurrently, and puts them in sortVals at the index of their order.
+func fetchOrderValues(ctx context.Context, ts *pb.SortMessage, dest *sroar.Bitmap, orders []int,
+	sortVals map[uint64][]types.Val) error {
+	och := make(chan orderResult, len(orders))
+	for _, i := range orders {
 		in := &pb.Query{
 			Attr:    ts.Order[i].Attr,
 			UidList: codec.ToSortedList(dest),
@@ -400,7 +435,7 @@ func multiSort(ctx context.Context, r *sortresult, ts *pb.SortMessage) error {
 
 	var oerr error
 	// TODO - Verify behavior with multiple langs.
-	for i := 1; i < len(ts.Order); i++ {
+	for range orders {
 		or := <-och
 		if or.err != nil {
 			if oerr == nil {
@@ -433,32 +468,56 @@ func multiSort(ctx context.Context, r *sortresult, ts *pb.SortMessage) error {
 			uid = itr.Next()
 		}
 	}
+	return oerr
+}
 
-	if oerr != nil {
-		return oerr
+// SortWithValues sorts the lists of the uid matrix of the sort message by all of its orders, like
+// multiSort. The order at varIdx is sorted by the given values, e.g. the values of a math()
+// variable, and the others by the values of their predicates. The uids without a value for the
+// first order are removed, and the ones having the same values for all the orders keep their
+// order in the list.
+func SortWithValues(ctx context.Context, ts *pb.SortMessage, varIdx int,
+	vals map[uint64]types.Val) (*pb.SortResult, error) {
+	dest := destUids(ts.UidMatrix)
+	sortVals := make(map[uint64][]types.Val, dest.GetCardinality())
+	itr := dest.NewIterator()
+	for uid := itr.Next(); uid > 0; uid = itr.Next() {
+		sortVals[uid] = make([]types.Val, len(ts.Order))
+		// The uids without a value get a nil value, which is sorted after all other values.
+		sortVals[uid][varIdx] = vals[uid]
 	}
 
+	orders := make([]int, 0, len(ts.Order)-1)
 	desc := make([]bool, 0, len(ts.Order))
-	for _, o := range ts.Order {
+	for i, o := range ts.Order {
+		if i != varIdx {
+			orders = append(orders, i)
+		}
 		desc = append(desc, o.Desc)
 	}
+	if err := fetchOrderValues(ctx, ts, dest, orders, sortVals); err != nil {
+		return nil, err
+	}
 
-	// Values have been accumulated, now we do the multisort for each list.
-	for i, ul := range r.reply.UidMatrix {
-		vals := make([][]types.Val, len(ul.SortedUids))
-		for j, uid := range ul.SortedUids {
-			vals[j] = sortVals[uid]
+	res := &pb.SortResult{UidMatrix: make([]*pb.List, 0, len(ts.UidMatrix))}
+	for _, ul := range ts.UidMatrix {
+		uids := codec.GetUids(ul)
+		sorted := make([]uint64, 0, len(uids))
+		values := make([][]types.Val, 0, len(uids))
+		for _, uid := range uids {
+			if sortVals[uid][0].Value == nil {
+				continue
+			}
+			sorted = append(sorted, uid)
+			values = append(values, sortVals[uid])
 		}
-		if err := types.Sort(vals, &ul.SortedUids, desc, ""); err != nil {
-			return err
+		if err := types.SortStable(values, &sorted, desc, ""); err != nil {
+			return nil, err
 		}
-		// Paginate
-		start, end := x.PageRange(int(ts.Count), int(r.multiSortOffsets[i]), len(ul.SortedUids))
-		ul.SortedUids = ul.SortedUids[start:end]
-		r.reply.UidMatrix[i] = ul
+		start, end := x.PageRange(int(ts.Count), int(ts.Offset), len(sorted))
+		res.UidMatrix = append(res.UidMatrix, &pb.List{SortedUids: sorted[start:end]})
 	}
-
-	return nil
+	return res, nil
 }
 
 // processSort does sorting with pagination. It works by iterating over index
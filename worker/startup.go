@@ -0,0 +1,142 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang/glog"
+)
+
+// The phases of the startup of an Alpha, in their order.
+const (
+	StartupConnectingZero = "connecting_to_zero"
+	StartupLoadingSchema  = "loading_schema"
+	StartupStartingRaft   = "starting_raft"
+	StartupInformingZero  = "informing_zero"
+	StartupReady          = "ready"
+)
+
+// StartupProgress is the progress of the startup of this Alpha, reported by the health endpoints.
+type StartupProgress struct {
+	Phase string `json:"phase"`
+	// Elapsed is the number of seconds spent in the phase.
+	Elapsed int64 `json:"elapsed"`
+	// The WAL is replayed in the background once the Raft node is started, so the Alpha can be
+	// ready before it's over. Replayed is the number of Raft entries replayed so far, out of
+	// ToReplay. Both are zero once the replay is over.
+	Replayed uint64 `json:"replayed,omitempty"`
+	ToReplay uint64 `json:"toReplay,omitempty"`
+}
+
+func (p StartupProgress) String() string {
+	if p.ToReplay == 0 {
+		return p.Phase
+	}
+	return fmt.Sprintf("%s, replayed %d/%d Raft entries", p.Phase, p.Replayed, p.ToReplay)
+}
+
+// startup tracks the phases of the startup, and the replay of the WAL. The entries to replay are
+// those up to the commit index found at restart.
+var startup struct {
+	sync.Mutex
+	phase string
+	since time.Time
+
+	replayStart time.Time
+	// firstIndex and lastIndex are the range of the entries to replay, read atomically by the
+	// apply loop. They're reset once the replay is over.
+	firstIndex uint64
+	lastIndex  uint64
+}
+
+func init() {
+	setStartupPhase(StartupConnectingZero)
+}
+
+func setStartupPhase(phase string) {
+	startup.Lock()
+	defer startup.Unlock()
+	if startup.phase != "" {
+		glog.Infof("Startup phase %s done in %s", startup.phase,
+			time.Since(startup.since).Round(time.Millisecond))
+	}
+	startup.phase, startup.since = phase, time.Now()
+}
+
+// setReplayRange sets the range of the entries of the WAL to replay. An empty range, as for a new
+// node, means there's nothing to replay.
+func setReplayRange(first, last uint64) {
+	if last < first || last == 0 {
+		return
+	}
+	startup.Lock()
+	startup.replayStart = time.Now()
+	startup.Unlock()
+	glog.Infof("Replaying Raft entries %d to %d of the WAL", first, last)
+	atomic.StoreUint64(&startup.firstIndex, first)
+	atomic.StoreUint64(&startup.lastIndex, last)
+}
+
+// replaying tells whether the entry at the index is replayed from the WAL at startup.
+func replaying(index uint64) bool {
+	return index <= atomic.LoadUint64(&startup.lastIndex)
+}
+
+// checkReplayDone ends the replay once the entries up to the applied index are applied.
+func checkReplayDone(applied uint64) {
+	last := atomic.LoadUint64(&startup.lastIndex)
+	if last == 0 || applied < last {
+		return
+	}
+	if !atomic.CompareAndSwapUint64(&startup.lastIndex, last, 0) {
+		return
+	}
+	first := atomic.SwapUint64(&startup.firstIndex, 0)
+	startup.Lock()
+	took := time.Since(startup.replayStart).Round(time.Millisecond)
+	startup.Unlock()
+	glog.Infof("Replayed %d Raft entries of the WAL in %s", last-first+1, took)
+}
+
+// GetStartupProgress returns the progress of the startup of this Alpha.
+func GetStartupProgress() StartupProgress {
+	startup.Lock()
+	p := StartupProgress{
+		Phase:   startup.phase,
+		Elapsed: int64(time.Since(startup.since) / time.Second),
+	}
+	startup.Unlock()
+
+	first, last := atomic.LoadUint64(&startup.firstIndex), atomic.LoadUint64(&startup.lastIndex)
+	if last == 0 {
+		return p
+	}
+	p.ToReplay = last - first + 1
+	if n := groups().Node; n != nil {
+		if applied := n.Applied.DoneUntil(); applied >= first {
+			p.Replayed = applied - first + 1
+		}
+		if p.Replayed > p.ToReplay {
+			p.Replayed = p.ToReplay
+		}
+	}
+	return p
+}
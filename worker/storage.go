@@ -0,0 +1,204 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/badger/v3"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+)
+
+const (
+	// StorageOpValueLogGC rewrites the value log files of the postings store to reclaim the space
+	// of the values which were deleted or overwritten.
+	StorageOpValueLogGC = "valueLogGC"
+	// StorageOpFlatten compacts all the levels of the LSM tree of the postings store, starting
+	// with L0, into a single level.
+	StorageOpFlatten = "flatten"
+
+	// DefaultDiscardRatio is the default fraction of discardable data a value log file must have
+	// to be rewritten.
+	DefaultDiscardRatio = 0.5
+)
+
+// StorageOpStatus is the status of the last run of a storage operation.
+type StorageOpStatus struct {
+	Op        string    `json:"op"`
+	StartedAt time.Time `json:"startedAt"`
+	// Duration is empty while the operation is running.
+	Duration string `json:"duration,omitempty"`
+	Error    string `json:"error,omitempty"`
+	// Rewritten is the number of value log files rewritten by a value log GC.
+	Rewritten int `json:"rewritten,omitempty"`
+}
+
+// StorageStatus is the state of the postings store of this Alpha, along with the storage
+// operations run through the admin API.
+type StorageStatus struct {
+	LsmBytes  int64         `json:"lsmBytes"`
+	VlogBytes int64         `json:"vlogBytes"`
+	Levels    []BadgerLevel `json:"levels"`
+	// BlockCacheBytes and IndexCacheBytes are the maximum sizes of the caches of the store.
+	BlockCacheBytes int64 `json:"blockCacheBytes"`
+	IndexCacheBytes int64 `json:"indexCacheBytes"`
+	// Running is the operation currently running, if any.
+	Running *StorageOpStatus            `json:"running,omitempty"`
+	Last    map[string]*StorageOpStatus `json:"last"`
+}
+
+// storageOps makes sure that a single storage operation runs at a time, and keeps the status of
+// the last run of each operation.
+var storageOps struct {
+	sync.Mutex
+	running *StorageOpStatus
+	last    map[string]*StorageOpStatus
+}
+
+// startStorageOp runs fn in the background, unless another storage operation is running.
+func startStorageOp(op string, fn func(st *StorageOpStatus) error) error {
+	if pstore == nil {
+		return errors.New("The postings store isn't open")
+	}
+	storageOps.Lock()
+	defer storageOps.Unlock()
+	if storageOps.running != nil {
+		return errors.Errorf("Storage operation %s is already running since %s",
+			storageOps.running.Op, storageOps.running.StartedAt.Format(time.RFC3339))
+	}
+	st := &StorageOpStatus{Op: op, StartedAt: time.Now()}
+	storageOps.running = st
+
+	go func() {
+		glog.Infof("Starting storage operation %s", op)
+		err := fn(st)
+		storageOps.Lock()
+		defer storageOps.Unlock()
+		st.Duration = time.Since(st.StartedAt).Round(time.Millisecond).String()
+		if err != nil {
+			st.Error = err.Error()
+			glog.Errorf("Storage operation %s failed after %s: %v", op, st.Duration, err)
+		} else {
+			glog.Infof("Storage operation %s done in %s", op, st.Duration)
+		}
+		if storageOps.last == nil {
+			storageOps.last = make(map[string]*StorageOpStatus)
+		}
+		storageOps.last[op] = st
+		storageOps.running = nil
+	}()
+	return nil
+}
+
+// StartValueLogGC starts rewriting the value log files of the postings store that have at least
+// the given fraction of discardable data, until no file qualifies. It returns once the GC has
+// started, see GetStorageStatus for its progress.
+func StartValueLogGC(discardRatio float64) error {
+	if discardRatio <= 0 || discardRatio >= 1 {
+		return errors.Errorf("The discard ratio must be between 0 and 1, got %v", discardRatio)
+	}
+	return startStorageOp(StorageOpValueLogGC, func(st *StorageOpStatus) error {
+		for {
+			err := pstore.RunValueLogGC(discardRatio)
+			if err == badger.ErrNoRewrite {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			storageOps.Lock()
+			st.Rewritten++
+			storageOps.Unlock()
+		}
+	})
+}
+
+// StartFlatten starts compacting the LSM tree of the postings store into a single level, using
+// the given number of workers. The compactions in progress are stopped while it runs. It returns
+// once the compaction has started, see GetStorageStatus for its progress.
+func StartFlatten(workers int) error {
+	if workers <= 0 {
+		return errors.Errorf("The number of workers must be positive, got %d", workers)
+	}
+	return startStorageOp(StorageOpFlatten, func(_ *StorageOpStatus) error {
+		return pstore.Flatten(workers)
+	})
+}
+
+// UpdateStorageCache sets the maximum sizes of the block and the index caches of the postings
+// store. Negative sizes leave the corresponding cache unchanged. Unlike UpdateCacheMb, the sizes
+// are given directly rather than as a share of the cache_mb option, and the posting list cache
+// isn't changed.
+func UpdateStorageCache(blockCacheBytes, indexCacheBytes int64) error {
+	if pstore == nil {
+		return errors.New("The postings store isn't open")
+	}
+	if blockCacheBytes >= 0 {
+		glog.Infof("Updating the block cache size to %d bytes", blockCacheBytes)
+		if _, err := pstore.CacheMaxCost(badger.BlockCache, blockCacheBytes); err != nil {
+			return errors.Wrapf(err, "cannot update block cache size")
+		}
+	}
+	if indexCacheBytes >= 0 {
+		glog.Infof("Updating the index cache size to %d bytes", indexCacheBytes)
+		if _, err := pstore.CacheMaxCost(badger.IndexCache, indexCacheBytes); err != nil {
+			return errors.Wrapf(err, "cannot update index cache size")
+		}
+	}
+	return nil
+}
+
+// GetStorageStatus returns the state of the postings store, and of the storage operations.
+func GetStorageStatus() (*StorageStatus, error) {
+	if pstore == nil {
+		return nil, errors.New("The postings store isn't open")
+	}
+	st := &StorageStatus{Levels: []BadgerLevel{}}
+	st.LsmBytes, st.VlogBytes = pstore.Size()
+	for _, l := range pstore.Levels() {
+		st.Levels = append(st.Levels, BadgerLevel{
+			Level:      l.Level,
+			NumTables:  l.NumTables,
+			Size:       l.Size,
+			TargetSize: l.TargetSize,
+			Score:      l.Score,
+		})
+	}
+	// A negative cost reads the size without changing it.
+	var err error
+	if st.BlockCacheBytes, err = pstore.CacheMaxCost(badger.BlockCache, -1); err != nil {
+		return nil, err
+	}
+	if st.IndexCacheBytes, err = pstore.CacheMaxCost(badger.IndexCache, -1); err != nil {
+		return nil, err
+	}
+
+	storageOps.Lock()
+	defer storageOps.Unlock()
+	if storageOps.running != nil {
+		running := *storageOps.running
+		st.Running = &running
+	}
+	st.Last = make(map[string]*StorageOpStatus, len(storageOps.last))
+	for op, last := range storageOps.last {
+		last := *last
+		st.Last[op] = &last
+	}
+	return st, nil
+}
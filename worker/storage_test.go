@@ -0,0 +1,49 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorageOps(t *testing.T) {
+	require.Error(t, StartValueLogGC(0))
+	require.Error(t, StartValueLogGC(1.5))
+	require.Error(t, StartFlatten(0))
+
+	require.NoError(t, StartFlatten(1))
+	require.Eventually(t, func() bool {
+		st, err := GetStorageStatus()
+		require.NoError(t, err)
+		return st.Running == nil && st.Last[StorageOpFlatten] != nil
+	}, 10*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, StartValueLogGC(DefaultDiscardRatio))
+	require.Eventually(t, func() bool {
+		st, err := GetStorageStatus()
+		require.NoError(t, err)
+		return st.Running == nil && st.Last[StorageOpValueLogGC] != nil
+	}, 10*time.Second, 10*time.Millisecond)
+
+	st, err := GetStorageStatus()
+	require.NoError(t, err)
+	require.Empty(t, st.Last[StorageOpFlatten].Error)
+	require.NotEmpty(t, st.Last[StorageOpFlatten].Duration)
+}
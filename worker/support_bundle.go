@@ -0,0 +1,213 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"runtime/pprof"
+	"sort"
+	"time"
+
+	"github.com/dgraph-io/dgraph/posting"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+)
+
+// MaxBundleCPUProfile is the longest CPU profile which can be taken for a support bundle.
+const MaxBundleCPUProfile = time.Minute
+
+// SupportBundleOptions are the options for WriteSupportBundle.
+type SupportBundleOptions struct {
+	// CPUProfile is the duration of the CPU profile. Zero skips the CPU profile.
+	CPUProfile time.Duration
+	// Extra are additional files added to the bundle, keyed by file name.
+	Extra map[string][]byte
+}
+
+type bundleBadger struct {
+	Levels    []BadgerLevel `json:"levels"`
+	NumTables int           `json:"numTables"`
+	LsmSize   int64         `json:"lsmSize"`
+	VlogSize  int64         `json:"vlogSize"`
+}
+
+type bundleTxns struct {
+	MaxAssigned uint64   `json:"maxAssigned"`
+	StartTs     []uint64 `json:"startTs"`
+}
+
+// WriteSupportBundle writes a gzipped tarball with the state of this Alpha needed to debug it:
+// the heap, CPU and goroutine profiles, the state of Badger, the caches and Raft, and the pending
+// transactions. The files which can't be collected are replaced by a .err file holding the error.
+func WriteSupportBundle(ctx context.Context, w io.Writer, opts SupportBundleOptions) error {
+	if opts.CPUProfile > MaxBundleCPUProfile {
+		return errors.Errorf("CPU profile can't be longer than %s", MaxBundleCPUProfile)
+	}
+	files := make(map[string][]byte)
+	add := func(name string, collect func() ([]byte, error)) {
+		data, err := collect()
+		if err != nil {
+			glog.Warningf("Unable to collect %s for the support bundle: %v", name, err)
+			files[name+".err"] = []byte(err.Error())
+			return
+		}
+		files[name] = data
+	}
+	profile := func(name string, debug int) func() ([]byte, error) {
+		return func() ([]byte, error) {
+			var buf bytes.Buffer
+			if err := pprof.Lookup(name).WriteTo(&buf, debug); err != nil {
+				return nil, err
+			}
+			return buf.Bytes(), nil
+		}
+	}
+
+	if opts.CPUProfile > 0 {
+		add("cpu.pprof", func() ([]byte, error) {
+			var buf bytes.Buffer
+			if err := pprof.StartCPUProfile(&buf); err != nil {
+				return nil, err
+			}
+			select {
+			case <-time.After(opts.CPUProfile):
+			case <-ctx.Done():
+			}
+			pprof.StopCPUProfile()
+			return buf.Bytes(), ctx.Err()
+		})
+	}
+	add("heap.pprof", profile("heap", 0))
+	add("goroutine.txt", profile("goroutine", 2))
+	add("readiness.json", func() ([]byte, error) {
+		return json.MarshalIndent(GetReadiness(ctx, ReadinessOptions{}), "", "  ")
+	})
+	add("raft.json", func() ([]byte, error) {
+		n := groups().Node
+		if n == nil || n.Raft() == nil {
+			return nil, errors.New("Raft node isn't initialized")
+		}
+		return json.MarshalIndent(n.Raft().Status(), "", "  ")
+	})
+	add("badger.json", func() ([]byte, error) {
+		if pstore == nil {
+			return nil, errors.New("postings store isn't open")
+		}
+		var b bundleBadger
+		for _, l := range pstore.Levels() {
+			b.Levels = append(b.Levels, BadgerLevel{
+				Level:      l.Level,
+				NumTables:  l.NumTables,
+				Size:       l.Size,
+				TargetSize: l.TargetSize,
+				Score:      l.Score,
+			})
+			b.NumTables += l.NumTables
+		}
+		b.LsmSize, b.VlogSize = pstore.Size()
+		return json.MarshalIndent(b, "", "  ")
+	})
+	add("caches.txt", func() ([]byte, error) {
+		var buf bytes.Buffer
+		fmt.Fprintf(&buf, "Posting list cache: %s\n", posting.CacheStats())
+		if pstore != nil {
+			fmt.Fprintf(&buf, "Block cache: %s\n", pstore.BlockCacheMetrics().String())
+			fmt.Fprintf(&buf, "Index cache: %s\n", pstore.IndexCacheMetrics().String())
+		}
+		return buf.Bytes(), nil
+	})
+	add("txns.json", func() ([]byte, error) {
+		return json.MarshalIndent(bundleTxns{
+			MaxAssigned: posting.Oracle().MaxAssigned(),
+			StartTs:     posting.Oracle().PendingStartTs(),
+		}, "", "  ")
+	})
+	for name, data := range opts.Extra {
+		files[name] = data
+	}
+	return writeTarGz(w, files)
+}
+
+// writeTarGz writes the files to w as a gzipped tarball, sorted by name.
+func writeTarGz(w io.Writer, files map[string][]byte) error {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+	now := time.Now()
+	for _, name := range names {
+		hdr := &tar.Header{
+			Name:    name,
+			Mode:    0644,
+			Size:    int64(len(files[name])),
+			ModTime: now,
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return errors.Wrapf(err, "while writing header of %s", name)
+		}
+		if _, err := tw.Write(files[name]); err != nil {
+			return errors.Wrapf(err, "while writing %s", name)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gw.Close()
+}
+
+// SupportBundleName returns the name of the bundle file of this Alpha taken at the given time.
+func SupportBundleName(t time.Time) string {
+	return fmt.Sprintf("dgraph-alpha-%d-%s.tar.gz", groups().Node.Id,
+		t.UTC().Format("20060102-150405"))
+}
+
+// UploadSupportBundle writes the bundle to the given destination, e.g. s3:///bucket/path, and
+// returns the path of the bundle file.
+func UploadSupportBundle(destination string, name string, bundle []byte) (string, error) {
+	uri, err := url.Parse(destination)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid destination %q", destination)
+	}
+	h, err := x.NewUriHandler(uri, nil)
+	if err != nil {
+		return "", err
+	}
+	f, err := h.CreateFile(name)
+	if err != nil {
+		return "", err
+	}
+	if _, err := f.Write(bundle); err != nil {
+		f.Close()
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		return "", err
+	}
+	return h.JoinPath(name), nil
+}
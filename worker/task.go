@@ -55,11 +55,16 @@ func invokeNetworkRequest(ctx context.Context, addr string,
 		return nil, errors.Wrapf(err, "dispatchTaskOverNetwork: while retrieving connection.")
 	}
 
-	if span := otrace.FromContext(ctx); span != nil {
-		span.Annotatef(nil, "invokeNetworkRequest: Sending request to %v", addr)
-	}
+	ctx, span := otrace.StartSpan(ctx, "worker.invokeNetworkRequest",
+		otrace.WithSpanKind(otrace.SpanKindClient))
+	defer span.End()
+	span.AddAttributes(otrace.StringAttribute("peer.address", addr))
 	c := pb.NewWorkerClient(pl.Get())
-	return f(ctx, c)
+	reply, err := f(ctx, c)
+	if err != nil {
+		span.SetStatus(otrace.Status{Code: otrace.StatusCodeUnknown, Message: err.Error()})
+	}
+	return reply, err
 }
 
 const backupRequestGracePeriod = time.Second
@@ -146,6 +151,15 @@ func ProcessTaskOverNetwork(ctx context.Context, q *pb.Query) (*pb.Result, error
 			attr, gid, q.ReadTs, groups().Node.Id)
 	}
 
+	// The linearizable flag and the expand sample of the query are only in the context of this
+	// Alpha, so they are passed along with the task to the Alpha serving it.
+	if x.IsLinearizable(ctx) {
+		q.Linearizable = true
+	}
+	if q.ExpandSample == 0 {
+		q.ExpandSample = x.ExtractExpandSample(ctx)
+	}
+
 	if groups().ServesGroup(gid) {
 		// No need for a network call, as this should be run from within this instance.
 		return processTask(ctx, q, gid)
@@ -180,6 +194,11 @@ func convertValue(attr, data string) (types.Val, error) {
 	}
 	src := types.Val{Tid: types.StringID, Value: []byte(data)}
 	dst, err := types.Convert(src, t)
+	if err == nil && dst.Tid == types.DateTimeID {
+		// The datetime index tokens are built in UTC, normalize the value so that its tokens and
+		// the comparisons in the first and last buckets agree whatever its timezone.
+		dst.Value = dst.Value.(time.Time).UTC()
+	}
 	return dst, err
 }
 
@@ -222,6 +241,7 @@ const (
 	uidInFn
 	customIndexFn
 	matchFn
+	notUidInFn
 	standardFn = 100
 )
 
@@ -248,7 +268,7 @@ func parseFuncTypeHelper(name string) (FuncType, string) {
 	switch f {
 	case "le", "ge", "lt", "gt", "eq", "between":
 		return compareAttrFn, f
-	case "min", "max", "sum", "avg":
+	case "min", "max", "sum", "avg", "countdistinct":
 		return aggregatorFn, f
 	case "checkpwd":
 		return passwordFn, f
@@ -260,6 +280,8 @@ func parseFuncTypeHelper(name string) (FuncType, string) {
 		return hasFn, f
 	case "uid_in":
 		return uidInFn, f
+	case "not_uid_in":
+		return notUidInFn, f
 	case "anyof", "allof":
 		return customIndexFn, f
 	case "match":
@@ -316,7 +338,7 @@ func (srcFn *functionContext) needsValuePostings(typ types.TypeID) (bool, error)
 	case geoFn, regexFn, fullTextSearchFn, standardFn, hasFn, customIndexFn, matchFn:
 		// All of these require an index, hence would require fetching uid postings.
 		return false, nil
-	case uidInFn, compareScalarFn:
+	case uidInFn, notUidInFn, compareScalarFn:
 		// Operate on uid postings
 		return false, nil
 	case notAFunction:
@@ -325,6 +347,23 @@ func (srcFn *functionContext) needsValuePostings(typ types.TypeID) (bool, error)
 	return false, errors.Errorf("Unhandled case in fetchValuePostings for fn: %s", srcFn.fname)
 }
 
+// compareAttrMatches returns whether the value of the attribute matches the compareAttr function.
+func (srcFn *functionContext) compareAttrMatches(attr string, val types.Val) bool {
+	switch srcFn.fname {
+	case "eq":
+		for _, eqToken := range srcFn.eqTokens {
+			if types.CompareVals(srcFn.fname, val, eqToken) || extendsType(attr, val, eqToken) {
+				return true
+			}
+		}
+		return false
+	case "between":
+		return types.CompareBetween(val, srcFn.eqTokens[0], srcFn.eqTokens[1])
+	default:
+		return types.CompareVals(srcFn.fname, val, srcFn.eqTokens[0])
+	}
+}
+
 // Handles fetching of value posting lists and filtering of uids based on that.
 func (qs *queryState) handleValuePostings(ctx context.Context, args funcArgs) error {
 	srcFn := args.srcFn
@@ -334,6 +373,7 @@ func (qs *queryState) handleValuePostings(ctx context.Context, args funcArgs) er
 	if err != nil {
 		return err
 	}
+	facetsTree = withValidAt(facetsTree, q.ValidAt)
 
 	span := otrace.FromContext(ctx)
 	stop := x.SpanTimer(span, "handleValuePostings")
@@ -437,6 +477,17 @@ func (qs *queryState) handleValuePostings(ctx context.Context, args funcArgs) er
 			res := sroar.NewBitmap()
 			var vl pb.ValueList
 			for _, val := range vals {
+				if srcFn.coerce != types.DefaultID {
+					// The values which can't be converted don't match the function.
+					if val, err = types.Coerce(val, srcFn.coerce); err != nil {
+						continue
+					}
+					if srcFn.compareAttrMatches(q.Attr, val) {
+						res.Set(uid)
+					}
+					continue
+				}
+
 				newValue, err := convertToType(val, srcFn.atype)
 				if err != nil {
 					return err
@@ -449,24 +500,9 @@ func (qs *queryState) handleValuePostings(ctx context.Context, args funcArgs) er
 					if val, err = types.Convert(val, srcFn.atype); err != nil {
 						return err
 					}
-					switch srcFn.fname {
-					case "eq":
-						for _, eqToken := range srcFn.eqTokens {
-							if types.CompareVals(srcFn.fname, val, eqToken) {
-								res.Set(uid)
-								break
-							}
-						}
-					case "between":
-						if types.CompareBetween(val, srcFn.eqTokens[0], srcFn.eqTokens[1]) {
-							res.Set(uid)
-						}
-					default:
-						if types.CompareVals(srcFn.fname, val, srcFn.eqTokens[0]) {
-							res.Set(uid)
-						}
+					if srcFn.compareAttrMatches(q.Attr, val) {
+						res.Set(uid)
 					}
-
 				} else {
 					vl.Values = append(vl.Values, newValue)
 				}
@@ -554,7 +590,7 @@ func facetsFilterValuePostingList(args funcArgs, pl *posting.List, facetsTree *f
 	// TODO(Ashish): This function starts iteration from start(afterUID is always 0). This can be
 	// optimized in come cases. For example when we know lang tag to fetch, we can directly jump
 	// to posting starting with that UID(check list.ValueFor()).
-	return pl.Iterate(q.ReadTs, 0, func(p *pb.Posting) error {
+	pick := func(p *pb.Posting) error {
 		if q.ExpandAll {
 			// If q.ExpandAll is true we need to consider all postings irrespective of langs.
 		} else if listType && len(q.Langs) == 0 {
@@ -574,7 +610,7 @@ func facetsFilterValuePostingList(args funcArgs, pl *posting.List, facetsTree *f
 		}
 
 		// If filterTree is nil, applyFacetsTree returns true and nil error.
-		picked, err := applyFacetsTree(p.Facets, facetsTree)
+		picked, err := applyFacetsTree(facets.PostingFacets(p), facetsTree)
 		if err != nil {
 			return err
 		}
@@ -588,7 +624,13 @@ func facetsFilterValuePostingList(args funcArgs, pl *posting.List, facetsTree *f
 
 		// We have picked the right posting, we can stop iteration now.
 		return posting.ErrStopIteration
-	})
+	}
+	// The values of ordered lists are picked in their order, which requires reading all of them
+	// first, so the other lists are iterated as usual.
+	if schema.State().IsOrdered(q.Attr) {
+		return pl.IterateValues(q.ReadTs, pick)
+	}
+	return pl.Iterate(q.ReadTs, 0, pick)
 }
 
 func countForValuePostings(args funcArgs, pl *posting.List, facetsTree *facetsTree,
@@ -616,7 +658,9 @@ func retrieveValuesAndFacets(args funcArgs, pl *posting.List, facetsTree *facets
 			Value: p.Value,
 		})
 		if q.FacetParam != nil {
-			fcs = append(fcs, &pb.Facets{Facets: facets.CopyFacets(p.Facets, q.FacetParam)})
+			fcs = append(fcs, &pb.Facets{
+				Facets: facets.CopyFacets(facets.PostingFacets(p), q.FacetParam),
+			})
 		}
 	})
 	if err != nil {
@@ -635,7 +679,7 @@ func facetsFilterUidPostingList(pl *posting.List, facetsTree *facetsTree, opts p
 		if p.PostingType != pb.Posting_REF {
 			return nil
 		}
-		pick, err := applyFacetsTree(p.Facets, facetsTree)
+		pick, err := applyFacetsTree(facets.PostingFacets(p), facetsTree)
 		if err != nil {
 			return err
 		}
@@ -677,7 +721,7 @@ func retrieveUidsAndFacets(args funcArgs, pl *posting.List, facetsTree *facetsTr
 		res.Set(p.Uid)
 		if q.FacetParam != nil {
 			fcsList = append(fcsList, &pb.Facets{
-				Facets: facets.CopyFacets(p.Facets, q.FacetParam),
+				Facets: facets.CopyFacets(facets.PostingFacets(p), q.FacetParam),
 			})
 		}
 	})
@@ -700,6 +744,7 @@ func (qs *queryState) handleUidPostings(
 	if err != nil {
 		return err
 	}
+	facetsTree = withValidAt(facetsTree, q.ValidAt)
 
 	span := otrace.FromContext(ctx)
 	stop := x.SpanTimer(span, "handleUidPostings")
@@ -710,13 +755,17 @@ func (qs *queryState) handleUidPostings(
 	if srcFn.n == 0 {
 		return nil
 	}
+	if srcFn.fnType == notUidInFn && !q.Reverse && schema.State().IsReversed(ctx, q.Attr) {
+		span.Annotate(nil, "NotUidInFn using reverse edges")
+		return qs.handleNotUidInUsingReverse(args)
+	}
 
 	// srcFn.n should be equal to len(q.UidList.Uids) for below implementation(DivideAndRule and
 	// calculate) to work correctly. But we have seen some panics while forming DataKey in
 	// calculate(). panic is of the form "index out of range [4] with length 1". Hence return error
 	// from here when srcFn.n != len(q.UidList.Uids).
 	switch srcFn.fnType {
-	case notAFunction, compareScalarFn, hasFn, uidInFn:
+	case notAFunction, compareScalarFn, hasFn, uidInFn, notUidInFn:
 		c := int(codec.ListCardinality(q.UidList))
 		if srcFn.n != c {
 			return errors.Errorf("srcFn.n: %d is not equal to len(q.UidList.Uids): %d, srcFn: %+v in "+
@@ -748,7 +797,7 @@ func (qs *queryState) handleUidPostings(
 			}
 			var key []byte
 			switch srcFn.fnType {
-			case notAFunction, compareScalarFn, hasFn, uidInFn:
+			case notAFunction, compareScalarFn, hasFn, uidInFn, notUidInFn:
 				if q.Reverse {
 					key = x.ReverseKey(q.Attr, uids[i])
 				} else {
@@ -825,11 +874,29 @@ func (qs *queryState) handleUidPostings(
 					tlist := codec.OneUid(uids[i])
 					out.UidMatrix = append(out.UidMatrix, tlist)
 				}
+			case srcFn.fnType == notUidInFn:
+				if i == 0 {
+					span.Annotate(nil, "NotUidInFn")
+				}
+				reqBm := sroar.NewBitmap()
+				reqBm.SetMany(srcFn.uidsPresent)
+				plist, err := pl.Uids(posting.ListOptions{
+					ReadTs:    args.q.ReadTs,
+					Intersect: codec.ToList(reqBm),
+				})
+				if err != nil {
+					return err
+				}
+				if codec.ListCardinality(plist) == 0 {
+					tlist := codec.OneUid(uids[i])
+					out.UidMatrix = append(out.UidMatrix, tlist)
+				}
 			case q.FacetParam != nil || facetsTree != nil:
 				if i == 0 {
 					span.Annotate(nil, "default with facets")
 				}
-				uidList, fcsList, err := retrieveUidsAndFacets(args, pl, facetsTree, opts)
+				uidList, fcsList, err := retrieveUidsAndFacetsUsingIndex(ctx, args, uids[i], pl,
+					facetsTree, opts)
 				if err != nil {
 					return err
 				}
@@ -845,6 +912,15 @@ func (qs *queryState) handleUidPostings(
 				if err != nil {
 					return err
 				}
+				if q.ExpandSample > 0 {
+					var sampled bool
+					if uidList, sampled, err = sampleUids(uidList, q.ExpandSample); err != nil {
+						return err
+					}
+					if sampled {
+						out.NumSampled++
+					}
+				}
 				out.UidMatrix = append(out.UidMatrix, uidList)
 			}
 		}
@@ -872,6 +948,7 @@ func (qs *queryState) handleUidPostings(
 		out.FacetMatrix = append(out.FacetMatrix, chunk.FacetMatrix...)
 		out.Counts = append(out.Counts, chunk.Counts...)
 		out.UidMatrix = append(out.UidMatrix, chunk.UidMatrix...)
+		out.NumSampled += chunk.NumSampled
 	}
 	var total int
 	for _, list := range out.UidMatrix {
@@ -881,6 +958,61 @@ func (qs *queryState) handleUidPostings(
 	return nil
 }
 
+// handleNotUidInUsingReverse handles not_uid_in() on a predicate with a reverse index. The nodes
+// linked to the given uids are read from their reverse posting lists, and removed from the uids
+// of the query with a single bitmap difference, instead of reading the posting list of each uid.
+func (qs *queryState) handleNotUidInUsingReverse(args funcArgs) error {
+	linked := sroar.NewBitmap()
+	for _, uid := range args.srcFn.uidsPresent {
+		pl, err := qs.cache.Get(x.ReverseKey(args.q.Attr, uid))
+		if err != nil {
+			return err
+		}
+		bm, err := pl.Bitmap(posting.ListOptions{ReadTs: args.q.ReadTs})
+		if err != nil {
+			return err
+		}
+		linked.Or(bm)
+	}
+	args.out.UidMatrix = append(args.out.UidMatrix, codec.Difference(args.q.UidList, linked))
+	return nil
+}
+
+// handleUidEqFunction handles eq() on a uid predicate at root. The nodes linked to each of the
+// given uids are read from its reverse posting list, or from its posting list for a reverse
+// predicate, all in this task. Each uid gets a row, and the rows are merged by the query.
+func (qs *queryState) handleUidEqFunction(args funcArgs) error {
+	for _, uid := range args.srcFn.uidsPresent {
+		key := x.ReverseKey(args.q.Attr, uid)
+		if args.q.Reverse {
+			key = x.DataKey(args.q.Attr, uid)
+		}
+		pl, err := qs.cache.Get(key)
+		if err != nil {
+			return err
+		}
+		list, err := pl.Uids(posting.ListOptions{ReadTs: args.q.ReadTs})
+		if err != nil {
+			return err
+		}
+		args.out.UidMatrix = append(args.out.UidMatrix, list)
+	}
+	return nil
+}
+
+// sampleUids returns a sample of n uids of the list, picked evenly across the list, if it has
+// more than n uids. It is used to cap the expansion of the edges of the supernodes.
+func sampleUids(l *pb.List, n uint64) (*pb.List, bool, error) {
+	if codec.ListCardinality(l) <= n {
+		return l, false, nil
+	}
+	bm, err := codec.Sample(codec.FromListNoCopy(l), n)
+	if err != nil {
+		return nil, false, errors.Wrapf(err, "while sampling uids")
+	}
+	return codec.ToList(bm), true, nil
+}
+
 const (
 	// UseTxnCache indicates the transaction cache should be used.
 	UseTxnCache = iota
@@ -896,6 +1028,14 @@ func processTask(ctx context.Context, q *pb.Query, gid uint32) (*pb.Result, erro
 	stop := x.SpanTimer(span, "processTask"+q.Attr)
 	defer stop()
 
+	if q.Linearizable {
+		// The ReadIndex request makes sure that this node isn't lagging behind the leader, e.g.
+		// because it got partitioned away from the group, before its data is read.
+		if err := groups().Node.WaitLinearizableRead(ctx); err != nil {
+			return nil, errors.Wrapf(err, "while waiting for a linearizable read of %s", q.Attr)
+		}
+		span.Annotate(nil, "Done waiting for linearizable read")
+	}
 	span.Annotatef(nil, "Waiting for startTs: %d at node: %d, gid: %d",
 		q.ReadTs, groups().Node.Id, gid)
 	if err := posting.Oracle().WaitForTs(ctx, q.ReadTs); err != nil {
@@ -926,13 +1066,29 @@ func processTask(ctx context.Context, q *pb.Query, gid uint32) (*pb.Result, erro
 	case knownGid != groups().groupId():
 		return nil, errUnservedTablet
 	}
+	x.RecordPredicate(q.Attr, x.PredicateQueries.M(1))
+
+	release, err := acquireTaskSlot(ctx, q.Attr, x.WorkerConfig.MaxPredicateTasks)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	span.Annotatef(nil, "Done waiting for a task slot")
 
 	var qs queryState
 	if q.Cache == UseTxnCache {
 		qs.cache = posting.Oracle().CacheAt(q.ReadTs)
 	}
 	if qs.cache == nil {
+		// A cache of its own, so the reads can be attributed to this task.
 		qs.cache = posting.NoCache(q.ReadTs)
+		var reads posting.ReadStats
+		qs.cache.SetReadStats(&reads)
+		defer func() {
+			hits, misses := reads.Counts()
+			span.AddAttributes(otrace.Int64Attribute("posting.cache_hits", int64(hits)),
+				otrace.Int64Attribute("posting.cache_misses", int64(misses)))
+		}()
 	}
 	// For now, remove the query level cache. It is causing contention for queries with high
 	// fan-out.
@@ -951,7 +1107,8 @@ func (qs *queryState) helpProcessTask(ctx context.Context, q *pb.Query, gid uint
 	*pb.Result, error) {
 
 	span := otrace.FromContext(ctx)
-	out := new(pb.Result)
+	start := time.Now()
+	out := &pb.Result{GroupId: gid}
 	attr := q.Attr
 
 	srcFn, err := parseSrcFn(ctx, q)
@@ -1016,9 +1173,14 @@ func (qs *queryState) helpProcessTask(ctx context.Context, q *pb.Query, gid uint
 		}
 	} else {
 		span.Annotate(nil, "handleUidPostings")
+		indexStart := time.Now()
 		if err = qs.handleUidPostings(ctx, args, opts); err != nil {
 			return nil, err
 		}
+		if len(srcFn.tokens) > 0 {
+			// The uid postings were read from the index keys of the tokens.
+			out.IndexNs = uint64(time.Since(indexStart))
+		}
 	}
 
 	if srcFn.fnType == hasFn && srcFn.isFuncAtRoot {
@@ -1028,6 +1190,13 @@ func (qs *queryState) helpProcessTask(ctx context.Context, q *pb.Query, gid uint
 		}
 	}
 
+	if srcFn.fnType == uidInFn && srcFn.isFuncAtRoot {
+		span.Annotate(nil, "handleUidEqFunction")
+		if err := qs.handleUidEqFunction(args); err != nil {
+			return nil, err
+		}
+	}
+
 	if srcFn.fnType == compareScalarFn && srcFn.isFuncAtRoot {
 		span.Annotate(nil, "handleCompareScalarFunction")
 		if err := qs.handleCompareScalarFunction(ctx, args); err != nil {
@@ -1076,9 +1245,30 @@ func (qs *queryState) helpProcessTask(ctx context.Context, q *pb.Query, gid uint
 	}
 
 	out.IntersectDest = srcFn.intersectDest
+	// The rest of the task fetched the values and the edges of the nodes.
+	out.FetchNs = uint64(time.Since(start)) - out.IndexNs
 	return out, nil
 }
 
+// extendsType returns whether the value of dgraph.type of a node is a type extending the given
+// type, so that the type() filters also match the nodes of its subtypes.
+func extendsType(attr string, val, typ types.Val) bool {
+	if x.ParseAttr(attr) != "dgraph.type" {
+		return false
+	}
+	name, ok := val.Value.(string)
+	if !ok {
+		return false
+	}
+	want, ok := typ.Value.(string)
+	if !ok {
+		return false
+	}
+	ns := x.ParseNamespace(attr)
+	return x.HasString(schema.State().Supertypes(x.NamespaceAttr(ns, name)),
+		x.NamespaceAttr(ns, want))
+}
+
 func needsStringFiltering(srcFn *functionContext, langs []string, attr string) bool {
 	if !srcFn.isStringFn {
 		return false
@@ -1189,7 +1379,7 @@ func (qs *queryState) handleRegexFunction(ctx context.Context, arg funcArgs) err
 		vals := make([]types.Val, 1)
 		switch {
 		case lang != "":
-			vals[0], err = pl.ValueForTag(arg.q.ReadTs, lang)
+			vals[0], err = pl.ValueFor(arg.q.ReadTs, arg.q.Langs)
 
 		case isList:
 			vals, err = pl.AllUntaggedValues(arg.q.ReadTs)
@@ -1357,11 +1547,20 @@ func (qs *queryState) handleCompareFunction(ctx context.Context, arg funcArgs) e
 		compareFunc := func(dst types.Val) bool {
 			return types.CompareBetween(dst, arg.srcFn.eqTokens[0], arg.srcFn.eqTokens[1])
 		}
-		if err := filterRow(0, compareFunc); err != nil {
-			return err
+		// The buckets strictly between the ones of the bounds only hold values within the
+		// range, so only the first and last rows are filtered, and only if they're the buckets
+		// of the bounds.
+		last := len(arg.out.UidMatrix) - 1
+		filterFirst := arg.srcFn.tokens[0] == arg.srcFn.ineqValueToken[0]
+		if filterFirst {
+			if err := filterRow(0, compareFunc); err != nil {
+				return err
+			}
 		}
-		if err := filterRow(len(arg.out.UidMatrix)-1, compareFunc); err != nil {
-			return err
+		if arg.srcFn.tokens[last] == arg.srcFn.ineqValueToken[1] && (last > 0 || !filterFirst) {
+			if err := filterRow(last, compareFunc); err != nil {
+				return err
+			}
 		}
 	case arg.srcFn.tokens[0] == arg.srcFn.ineqValueToken[0]:
 		// If operation is not eq and ineqValueToken equals first token,
@@ -1436,7 +1635,7 @@ func (qs *queryState) handleMatchFunction(ctx context.Context, arg funcArgs) err
 		vals := make([]types.Val, 1)
 		switch {
 		case lang != "":
-			vals[0], err = pl.ValueForTag(arg.q.ReadTs, lang)
+			vals[0], err = pl.ValueFor(arg.q.ReadTs, arg.q.Langs)
 
 		case isList:
 			vals, err = pl.AllUntaggedValues(arg.q.ReadTs)
@@ -1608,7 +1807,7 @@ func (qs *queryState) filterStringFunction(arg funcArgs) error {
 	// the difference.
 	remove := sroar.NewBitmap()
 	for uid := itr.Next(); uid > 0; uid = itr.Next() {
-		vals, err := qs.getValsForUID(attr, lang, uid, arg.q.ReadTs)
+		vals, err := qs.getValsForUID(attr, arg.q.Langs, uid, arg.q.ReadTs)
 		switch {
 		case err == posting.ErrNoValue:
 			continue
@@ -1638,7 +1837,10 @@ func (qs *queryState) filterStringFunction(arg funcArgs) error {
 	return nil
 }
 
-func (qs *queryState) getValsForUID(attr, lang string, uid, ReadTs uint64) ([]types.Val, error) {
+// getValsForUID returns the values of the uid in the first of the languages having one, or the
+// untagged values if no language is given.
+func (qs *queryState) getValsForUID(attr string, langs []string, uid,
+	ReadTs uint64) ([]types.Val, error) {
 	key := x.DataKey(attr, uid)
 	pl, err := qs.cache.Get(key)
 	if err != nil {
@@ -1647,7 +1849,7 @@ func (qs *queryState) getValsForUID(attr, lang string, uid, ReadTs uint64) ([]ty
 
 	var vals []types.Val
 	var val types.Val
-	if lang == "" {
+	if len(langs) == 0 {
 		if schema.State().IsList(attr) {
 			// NOTE: we will never reach here if this function is called from handleHasFunction, as
 			// @lang is not allowed for list predicates.
@@ -1657,7 +1859,7 @@ func (qs *queryState) getValsForUID(attr, lang string, uid, ReadTs uint64) ([]ty
 			vals = append(vals, val)
 		}
 	} else {
-		val, err = pl.ValueForTag(ReadTs, lang)
+		val, err = pl.ValueFor(ReadTs, langs)
 		vals = append(vals, val)
 	}
 
@@ -1688,6 +1890,9 @@ type functionContext struct {
 	isFuncAtRoot   bool
 	isStringFn     bool
 	atype          types.TypeID
+	// coerce is the type the values are converted to by the function, e.g. int for
+	// gt(toInt(age), 18). It's DefaultID when they aren't converted.
+	coerce types.TypeID
 }
 
 const (
@@ -1713,9 +1918,11 @@ func checkRoot(q *pb.Query, fc *functionContext) {
 	}
 }
 
-// We allow atmost one lang in functions. We can inline in 1.9.
+// langForFunc returns the language whose tokenizers are used by the function on the index, the
+// first of the languages of the attribute. Only eq also looks up the tokens of the fallback
+// languages, the values of the others are only looked at when the values of the uids found are
+// checked.
 func langForFunc(langs []string) string {
-	x.AssertTrue(len(langs) <= 1)
 	if len(langs) == 0 {
 		return ""
 	}
@@ -1733,6 +1940,25 @@ func parseSrcFn(ctx context.Context, q *pb.Query) (*functionContext, error) {
 	if err == nil && fnType != notAFunction && t.Name() == types.StringID.Name() {
 		fc.isStringFn = true
 	}
+	if err == nil && fnType == compareAttrFn && f == eq && t == types.UidID {
+		// eq on a uid predicate matches the nodes linked to any of the given uids, like uid_in.
+		fnType = uidInFn
+		fc.fnType = uidInFn
+	}
+	if c := q.SrcFunc.GetCoerce(); c != "" {
+		if fnType != compareAttrFn || q.UidList == nil {
+			return nil, errors.Errorf("Values of %s can only be converted to %s in the "+
+				"inequality functions of filters", x.ParseAttr(attr), c)
+		}
+		ct, ok := types.TypeForName(c)
+		if !ok {
+			return nil, errors.Errorf("Invalid type %q to convert values to", c)
+		}
+		fc.coerce = ct
+		fc.isStringFn = false
+		// The index holds the tokens of the values stored, not of the converted ones.
+		isIndexedAttr = false
+	}
 
 	switch fnType {
 	case notAFunction:
@@ -1765,13 +1991,21 @@ func parseSrcFn(ctx context.Context, q *pb.Query) (*functionContext, error) {
 			}
 		}
 
+		convert := func(arg string) (types.Val, error) {
+			if fc.coerce != types.DefaultID {
+				// The arguments are of the type the values are converted to.
+				return types.Convert(types.Val{Tid: types.StringID, Value: []byte(arg)}, fc.coerce)
+			}
+			return convertValue(attr, arg)
+		}
+
 		var tokens []string
 		var ineqValues []types.Val
 		// eq can have multiple args.
 		for idx := 0; idx < len(args); idx++ {
 			arg := args[idx]
 			ineqValues = ineqValues[:0]
-			ineqValue1, err := convertValue(attr, arg)
+			ineqValue1, err := convert(arg)
 			if err != nil {
 				return nil, errors.Errorf("Got error: %v while running: %v", err, q.SrcFunc)
 			}
@@ -1780,7 +2014,7 @@ func parseSrcFn(ctx context.Context, q *pb.Query) (*functionContext, error) {
 
 			// in case of between also pass other value.
 			if fc.fname == between {
-				ineqValue2, err := convertValue(attr, args[idx+1])
+				ineqValue2, err := convert(args[idx+1])
 				if err != nil {
 					return nil, errors.Errorf("Got error: %v while running: %v", err, q.SrcFunc)
 				}
@@ -1794,21 +2028,40 @@ func parseSrcFn(ctx context.Context, q *pb.Query) (*functionContext, error) {
 				continue
 			}
 
-			var lang string
-			if len(q.Langs) > 0 {
-				// Only one language is allowed.
-				lang = q.Langs[0]
-			}
-
 			// Get tokens ge/le ineqValueToken.
-			if tokens, fc.ineqValueToken, err = getInequalityTokens(ctx, q.ReadTs, attr, f, lang,
-				ineqValues); err != nil {
+			if tokens, fc.ineqValueToken, err = getInequalityTokens(ctx, q.ReadTs, attr, f,
+				langForFunc(q.Langs), ineqValues); err != nil {
 				return nil, err
 			}
 			if len(tokens) == 0 {
 				continue
 			}
 			fc.tokens = append(fc.tokens, tokens...)
+			if fc.fname != eq || len(q.Langs) < 2 {
+				continue
+			}
+
+			// The values in the fallback languages are indexed under tokens of their own, which
+			// are looked up too, each in a row checked against the same argument. The values in
+			// any language (".") can't be looked up in the index.
+			argTokens := len(fc.tokens) - len(tokens)
+			for _, lang := range q.Langs[1:] {
+				if lang == "." {
+					continue
+				}
+				langTokens, _, err := getInequalityTokens(ctx, q.ReadTs, attr, f, lang,
+					ineqValues)
+				if err != nil {
+					return nil, err
+				}
+				for _, token := range langTokens {
+					if x.HasString(fc.tokens[argTokens:], token) {
+						continue
+					}
+					fc.tokens = append(fc.tokens, token)
+					fc.eqTokens = append(fc.eqTokens, ineqValue1)
+				}
+			}
 		}
 
 		// In case of non-indexed predicate, there won't be any tokens. We will fetch value
@@ -1946,7 +2199,7 @@ func parseSrcFn(ctx context.Context, q *pb.Query) (*functionContext, error) {
 			return nil, err
 		}
 		checkRoot(q, fc)
-	case uidInFn:
+	case uidInFn, notUidInFn:
 		for _, arg := range q.SrcFunc.Args {
 			uidParsed, err := strconv.ParseUint(arg, 0, 64)
 			if err != nil {
@@ -1962,8 +2215,13 @@ func parseSrcFn(ctx context.Context, q *pb.Query) (*functionContext, error) {
 			return fc.uidsPresent[i] < fc.uidsPresent[j]
 		})
 		checkRoot(q, fc)
-		if fc.isFuncAtRoot {
-			return nil, errors.Errorf("uid_in function not allowed at root")
+		switch {
+		case !fc.isFuncAtRoot:
+		case f != eq:
+			return nil, errors.Errorf("%s function not allowed at root", q.SrcFunc.Name)
+		case !q.Reverse && !schema.State().IsReversed(ctx, attr):
+			return nil, errors.Errorf("eq function on the uid predicate %s needs @reverse at root",
+				x.ParseAttr(attr))
 		}
 	default:
 		return nil, errors.Errorf("FnType %d not handled in numFnAttrs.", fnType)
@@ -2033,6 +2291,14 @@ func applyFacetsTree(postingFacets []*api.Facet, ftree *facetsTree) (bool, error
 	if ftree == nil {
 		return true, nil
 	}
+	if ftree.validAt != 0 {
+		if !facets.IsValidAt(postingFacets, ftree.validAt) {
+			return false, nil
+		}
+		if ftree.function == nil && len(ftree.children) == 0 {
+			return true, nil
+		}
+	}
 	if ftree.function != nil {
 		var fc *api.Facet
 		for _, fci := range postingFacets {
@@ -2155,6 +2421,21 @@ type facetsTree struct {
 	op       string
 	children []*facetsTree
 	function *facetsFunc
+	// validAt is the valid time of the postings to pick, in seconds since the epoch. It is only
+	// set at the root of the tree.
+	validAt int64
+}
+
+// withValidAt returns the facets tree which also picks only the postings valid at validAt.
+func withValidAt(ftree *facetsTree, validAt int64) *facetsTree {
+	if validAt == 0 {
+		return ftree
+	}
+	if ftree == nil {
+		ftree = &facetsTree{}
+	}
+	ftree.validAt = validAt
+	return ftree
 }
 
 // commonTypeIDs is list of type ids which are more common. In preprocessFilter() we keep converted
@@ -2344,6 +2625,24 @@ func (qs *queryState) handleHasFunction(ctx context.Context, q *pb.Query, out *p
 		glog.Infof("handleHasFunction query: %+v\n", q)
 	}
 
+	needFiltering := needsStringFiltering(srcFn, q.Langs, q.Attr)
+
+	// This function checks if we should include uid in result or not when has is queried with
+	// @lang(eg: has(name@en)). We need to do this inside this function to return correct result
+	// for first.
+	checkInclusion := func(uid uint64) error {
+		if !needFiltering {
+			return nil
+		}
+
+		_, err := qs.getValsForUID(q.Attr, q.Langs, uid, q.ReadTs)
+		return err
+	}
+
+	if !q.Reverse && schema.State().HasPresence(ctx, q.Attr) {
+		return qs.handleHasPresence(ctx, q, out, checkInclusion)
+	}
+
 	txn := pstore.NewTransactionAt(q.ReadTs, false)
 	defer txn.Discard()
 
@@ -2367,21 +2666,6 @@ func (qs *queryState) handleHasFunction(ctx context.Context, q *pb.Query, out *p
 	it := txn.NewIterator(itOpt)
 	defer it.Close()
 
-	lang := langForFunc(q.Langs)
-	needFiltering := needsStringFiltering(srcFn, q.Langs, q.Attr)
-
-	// This function checks if we should include uid in result or not when has is queried with
-	// @lang(eg: has(name@en)). We need to do this inside this function to return correct result
-	// for first.
-	checkInclusion := func(uid uint64) error {
-		if !needFiltering {
-			return nil
-		}
-
-		_, err := qs.getValsForUID(q.Attr, lang, uid, q.ReadTs)
-		return err
-	}
-
 	skipCnt := int32(0)
 	setCnt := 0
 	res := sroar.NewBitmap()
@@ -2487,3 +2771,53 @@ loop:
 	out.UidMatrix = append(out.UidMatrix, result)
 	return nil
 }
+
+// handleHasPresence answers has() from the presence list of the predicate, instead of scanning
+// its data keys.
+func (qs *queryState) handleHasPresence(ctx context.Context, q *pb.Query, out *pb.Result,
+	checkInclusion func(uid uint64) error) error {
+	pl, err := qs.cache.Get(posting.PresenceKey(q.Attr))
+	if err != nil {
+		return err
+	}
+	bm, err := pl.Bitmap(posting.ListOptions{ReadTs: q.ReadTs, AfterUid: q.AfterUid})
+	if err != nil {
+		return err
+	}
+
+	skipCnt := int32(0)
+	setCnt := 0
+	res := sroar.NewBitmap()
+	itr := bm.NewIterator()
+	for i, uid := 0, itr.Next(); uid > 0; i, uid = i+1, itr.Next() {
+		if i%100000 == 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+			}
+		}
+		switch err := checkInclusion(uid); {
+		case err == posting.ErrNoValue:
+			continue
+		case err != nil:
+			return err
+		}
+		// skip entries upto Offset and do not store in the result.
+		if skipCnt < q.Offset {
+			skipCnt++
+			continue
+		}
+		res.Set(uid)
+		setCnt++
+		// We'll stop fetching if we fetch the required count.
+		if setCnt >= int(q.First) {
+			break
+		}
+	}
+	if span := otrace.FromContext(ctx); span != nil {
+		span.Annotatef(nil, "handleHasPresence found %d uids", setCnt)
+	}
+	out.UidMatrix = append(out.UidMatrix, &pb.List{Bitmap: res.ToBuffer()})
+	return nil
+}
@@ -0,0 +1,78 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	ostats "go.opencensus.io/stats"
+
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// taskSlots holds the semaphores bounding the number of tasks reading each predicate
+// concurrently, see the max-predicate-tasks limit. The tasks of a busy predicate wait for a slot,
+// so that a flood of expensive queries against a hot tablet only queues up behind itself instead
+// of taking the CPU and the disk of the group from the other predicates.
+var taskSlots struct {
+	sync.Mutex
+	sems map[string]chan struct{}
+}
+
+// acquireTaskSlot waits for a slot to run a task reading the predicate, and returns the function
+// releasing it. It fails if the context is done first.
+func acquireTaskSlot(ctx context.Context, attr string, limit int) (func(), error) {
+	if limit <= 0 {
+		return func() {}, nil
+	}
+	taskSlots.Lock()
+	if taskSlots.sems == nil {
+		taskSlots.sems = make(map[string]chan struct{})
+	}
+	sem, ok := taskSlots.sems[attr]
+	if !ok || cap(sem) != limit {
+		// The tasks holding a slot of a replaced semaphore release it into the old one.
+		sem = make(chan struct{}, limit)
+		taskSlots.sems[attr] = sem
+	}
+	taskSlots.Unlock()
+
+	release := func() { <-sem }
+	select {
+	case sem <- struct{}{}:
+		return release, nil
+	default:
+	}
+
+	// All the slots of the predicate are taken, the task is queued.
+	start := time.Now()
+	ostats.Record(ctx, x.QueuedTasks.M(1))
+	x.RecordPredicate(attr, x.PredicateQueuedTasks.M(1))
+	defer func() {
+		ostats.Record(ctx, x.QueuedTasks.M(-1), x.TaskQueueLatencyMs.M(x.SinceMs(start)))
+	}()
+	select {
+	case sem <- struct{}{}:
+		return release, nil
+	case <-ctx.Done():
+		return nil, errors.Wrapf(ctx.Err(), "while waiting for a task slot of predicate %s",
+			x.ParseAttr(attr))
+	}
+}
@@ -0,0 +1,92 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/dgraph/codec"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSampleUids(t *testing.T) {
+	uids := make([]uint64, 0, 1000)
+	for uid := uint64(1); uid <= 1000; uid++ {
+		uids = append(uids, uid)
+	}
+	l := &pb.List{}
+	codec.SetUids(l, uids)
+
+	// A list no longer than the sample is kept as is.
+	out, sampled, err := sampleUids(l, 1000)
+	require.NoError(t, err)
+	require.False(t, sampled)
+	require.Equal(t, l, out)
+
+	// The sample is spread evenly across the list.
+	out, sampled, err = sampleUids(l, 4)
+	require.NoError(t, err)
+	require.True(t, sampled)
+	require.Equal(t, []uint64{1, 251, 501, 751}, codec.GetUids(out))
+}
+
+func TestAcquireTaskSlot(t *testing.T) {
+	ctx := context.Background()
+	attr := x.GalaxyAttr("slots")
+
+	// There's no limit.
+	release, err := acquireTaskSlot(ctx, attr, 0)
+	require.NoError(t, err)
+	release()
+
+	r1, err := acquireTaskSlot(ctx, attr, 2)
+	require.NoError(t, err)
+	r2, err := acquireTaskSlot(ctx, attr, 2)
+	require.NoError(t, err)
+	// The slots of the other predicates are free.
+	r3, err := acquireTaskSlot(ctx, x.GalaxyAttr("other"), 2)
+	require.NoError(t, err)
+	r3()
+
+	// The third task waits for a slot until its context is done.
+	tctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	_, err = acquireTaskSlot(tctx, attr, 2)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "task slot of predicate slots")
+
+	// Or until a slot is released.
+	acquired := make(chan struct{})
+	go func() {
+		r, err := acquireTaskSlot(ctx, attr, 2)
+		require.NoError(t, err)
+		close(acquired)
+		r()
+	}()
+	select {
+	case <-acquired:
+		t.Fatal("acquired a slot while all of them are taken")
+	case <-time.After(20 * time.Millisecond):
+	}
+	r1()
+	<-acquired
+	r2()
+}
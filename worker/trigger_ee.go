@@ -0,0 +1,328 @@
+// +build !oss
+
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Dgraph Community License (the "License"); you
+ * may not use this file except in compliance with the License. You
+ * may obtain a copy of the License at
+ *
+ *     https://github.com/dgraph-io/dgraph/blob/master/licenses/DCL.txt
+ */
+
+package worker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/schema"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/dgraph-io/ristretto/z"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultDeadLetterFileName = "trigger_dlq.log"
+)
+
+// triggers call the targets of the @trigger directives with the committed changes. They are fired
+// from the CDC events, before the events are sent to the sink, so a trigger is called at least
+// once for each commit. A call which keeps failing after the retries is written to the dead-letter
+// queue, a file from which it can be replayed. The events are read by the leader of each group, so
+// a type trigger is called once per group serving the fields changed by the commit.
+type triggers struct {
+	client  *http.Client
+	retries int
+	dlq     *x.LogWriter
+}
+
+// triggerPayload is what is posted to the target of a trigger. For lambda triggers, it holds the
+// lambda script of the namespace and the resolver to call, like the payload of the webhooks.
+type triggerPayload struct {
+	Source    string       `json:"source,omitempty"`
+	Namespace uint64       `json:"namespace"`
+	Resolver  string       `json:"resolver,omitempty"`
+	Event     triggerEvent `json:"event"`
+}
+
+// triggerEvent holds the changes committed on the predicate, or on the nodes of the type, which
+// the trigger watches.
+type triggerEvent struct {
+	Predicate string           `json:"predicate,omitempty"`
+	Type      string           `json:"type,omitempty"`
+	CommitTs  uint64           `json:"commitTs"`
+	Mutations []*MutationEvent `json:"mutations"`
+}
+
+type triggerCall struct {
+	trigger string
+	event   *triggerEvent
+}
+
+type deadLetter struct {
+	Trigger string          `json:"trigger"`
+	Error   string          `json:"error"`
+	At      time.Time       `json:"at"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+func newTriggers(conf *z.SuperFlag) (*triggers, error) {
+	dir := conf.GetPath("trigger-dlq")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, errors.Wrap(err, "unable to create directory for the trigger dead-letter queue")
+	}
+	fp, err := filepath.Abs(filepath.Join(dir, defaultDeadLetterFileName))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to find the trigger dead-letter queue path")
+	}
+	w := &x.LogWriter{
+		FilePath: fp,
+		MaxSize:  100,
+		MaxAge:   10,
+	}
+	if w, err = w.Init(); err != nil {
+		return nil, errors.Wrap(err, "unable to init the trigger dead-letter queue")
+	}
+	return &triggers{
+		client:  &http.Client{Timeout: conf.GetDuration("trigger-timeout")},
+		retries: int(conf.GetUint64("trigger-retries")),
+		dlq:     w,
+	}, nil
+}
+
+func (t *triggers) close() error {
+	if t == nil {
+		return nil
+	}
+	return t.dlq.Close()
+}
+
+// fire calls the triggers watching the changes of the given events, committed at commitTs. It
+// only returns an error if a failed call couldn't be written to the dead-letter queue, in which
+// case the events must be fired again.
+func (t *triggers) fire(ctx context.Context, events []CDCEvent, commitTs uint64) error {
+	if t == nil {
+		return nil
+	}
+	byNs := make(map[uint64][]*MutationEvent)
+	var namespaces []uint64
+	for _, e := range events {
+		me, ok := e.Event.(*MutationEvent)
+		if !ok {
+			continue
+		}
+		ns := e.Meta.Namespace
+		if _, ok := byNs[ns]; !ok {
+			namespaces = append(namespaces, ns)
+		}
+		byNs[ns] = append(byNs[ns], me)
+	}
+
+	for _, ns := range namespaces {
+		calls, err := t.calls(ctx, ns, byNs[ns], commitTs)
+		if err != nil {
+			return err
+		}
+		for _, call := range calls {
+			call.event.CommitTs = commitTs
+			if err := t.deliver(ctx, ns, call); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// calls groups the mutation events of a namespace by the triggers watching them. The events on
+// the fields of a type are only passed to the trigger of the type if the node has the type, either
+// before or after the commit.
+func (t *triggers) calls(ctx context.Context, ns uint64, events []*MutationEvent,
+	commitTs uint64) ([]*triggerCall, error) {
+	var calls []*triggerCall
+	predCalls := make(map[string]*triggerCall)
+	for _, me := range events {
+		trigger := schema.State().Trigger(ctx, x.NamespaceAttr(ns, me.Attr))
+		if trigger == "" {
+			continue
+		}
+		call, ok := predCalls[me.Attr]
+		if !ok {
+			call = &triggerCall{trigger: trigger, event: &triggerEvent{Predicate: me.Attr}}
+			predCalls[me.Attr] = call
+			calls = append(calls, call)
+		}
+		call.event.Mutations = append(call.event.Mutations, me)
+	}
+
+	// The types of the namespace with a trigger, by the fields they have.
+	typeTriggers := make(map[string]string)
+	fieldTypes := make(map[string][]string)
+	for _, name := range schema.State().Types() {
+		tns, typeName := x.ParseNamespaceAttr(name)
+		if tns != ns {
+			continue
+		}
+		typ, ok := schema.State().GetType(name)
+		if !ok || typ.Trigger == "" {
+			continue
+		}
+		typeTriggers[typeName] = typ.Trigger
+		for _, field := range typ.Fields {
+			attr := x.ParseAttr(field.Predicate)
+			fieldTypes[attr] = append(fieldTypes[attr], typeName)
+		}
+	}
+	if len(typeTriggers) == 0 {
+		return calls, nil
+	}
+
+	var uids []uint64
+	seen := make(map[uint64]bool)
+	for _, me := range events {
+		if _, ok := fieldTypes[me.Attr]; ok && !seen[me.Uid] {
+			seen[me.Uid] = true
+			uids = append(uids, me.Uid)
+		}
+	}
+	if len(uids) == 0 {
+		return calls, nil
+	}
+	// The nodes deleted by the commit have lost their types, and the nodes created by it didn't
+	// have them yet, so the types are read on both sides of the commit.
+	sort.Slice(uids, func(i, j int) bool { return uids[i] < uids[j] })
+	nodeTypes := make(map[uint64]map[string]bool)
+	for _, readTs := range []uint64{commitTs - 1, commitTs} {
+		if err := readNodeTypes(ctx, ns, uids, readTs, nodeTypes); err != nil {
+			return nil, err
+		}
+	}
+
+	typeCalls := make(map[string]*triggerCall)
+	for _, me := range events {
+		for _, typeName := range fieldTypes[me.Attr] {
+			if !nodeTypes[me.Uid][typeName] {
+				continue
+			}
+			call, ok := typeCalls[typeName]
+			if !ok {
+				call = &triggerCall{
+					trigger: typeTriggers[typeName],
+					event:   &triggerEvent{Type: typeName},
+				}
+				typeCalls[typeName] = call
+				calls = append(calls, call)
+			}
+			call.event.Mutations = append(call.event.Mutations, me)
+		}
+	}
+	return calls, nil
+}
+
+// readNodeTypes adds the types of the given nodes at readTs to nodeTypes. The uids must be sorted.
+func readNodeTypes(ctx context.Context, ns uint64, uids []uint64, readTs uint64,
+	nodeTypes map[uint64]map[string]bool) error {
+	res, err := ProcessTaskOverNetwork(ctx, &pb.Query{
+		Attr:    x.NamespaceAttr(ns, "dgraph.type"),
+		UidList: &pb.List{Uids: uids},
+		ReadTs:  readTs,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "while reading the types of the nodes for the triggers")
+	}
+	for i, vals := range res.ValueMatrix {
+		if i >= len(uids) {
+			break
+		}
+		for _, val := range vals.Values {
+			if nodeTypes[uids[i]] == nil {
+				nodeTypes[uids[i]] = make(map[string]bool)
+			}
+			nodeTypes[uids[i]][string(val.Val)] = true
+		}
+	}
+	return nil
+}
+
+// deliver posts the payload of the call to the target of its trigger, retrying with a backoff. If
+// all the attempts fail, the call is written to the dead-letter queue.
+func (t *triggers) deliver(ctx context.Context, ns uint64, call *triggerCall) error {
+	payload := triggerPayload{Namespace: ns, Event: *call.event}
+	kind, target, err := schema.ParseTrigger(call.trigger)
+	if err == nil && kind == schema.TriggerLambda {
+		payload.Source = GetLambdaScript(ns)
+		payload.Resolver = target
+		if target = x.LambdaUrl(ns); target == "" {
+			err = errors.New("no lambda server is configured")
+		}
+	}
+	b, merr := json.Marshal(payload)
+	if merr != nil {
+		return errors.Wrap(merr, "while marshalling the trigger payload")
+	}
+
+	if err == nil {
+		if err = t.postWithRetries(ctx, target, b); err == nil {
+			return nil
+		}
+	}
+	if ctx.Err() != nil {
+		// CDC is closing, the events are fired again once it's restarted.
+		return err
+	}
+
+	glog.Errorf("Trigger %q failed for commit ts %d, writing it to the dead-letter queue: %v",
+		call.trigger, call.event.CommitTs, err)
+	line, merr := json.Marshal(deadLetter{
+		Trigger: call.trigger,
+		Error:   err.Error(),
+		At:      time.Now(),
+		Payload: b,
+	})
+	if merr != nil {
+		return errors.Wrap(merr, "while marshalling the dead letter")
+	}
+	if _, werr := t.dlq.Write(append(line, '\n')); werr != nil {
+		return errors.Wrap(werr, "unable to write to the trigger dead-letter queue")
+	}
+	return nil
+}
+
+func (t *triggers) postWithRetries(ctx context.Context, url string, body []byte) error {
+	for attempt := 0; ; attempt++ {
+		err := t.post(ctx, url, body)
+		if err == nil || attempt >= t.retries {
+			return err
+		}
+		select {
+		case <-time.After(time.Duration(1<<uint(attempt)) * 100 * time.Millisecond):
+		case <-ctx.Done():
+			return err
+		}
+	}
+}
+
+func (t *triggers) post(ctx context.Context, url string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("got unsuccessful status: %s", resp.Status)
+	}
+	return nil
+}
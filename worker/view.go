@@ -0,0 +1,31 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+// ViewRefresher refreshes the views of a namespace after a transaction was committed at commitTs.
+// The changed map holds the uids touched by the transaction, keyed by predicate. For the edges to
+// other nodes, both the subject and the object are counted as touched.
+type ViewRefresher func(ns, commitTs uint64, changed map[string][]uint64)
+
+// viewRefresher is set by the edgraph package, which runs the view queries. Views are maintained
+// from the CDC events, so it is only called if CDC is running with views enabled.
+var viewRefresher ViewRefresher
+
+// SetViewRefresher sets the function called to refresh the views once a transaction is committed.
+func SetViewRefresher(f ViewRefresher) {
+	viewRefresher = f
+}
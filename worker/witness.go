@@ -0,0 +1,100 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"time"
+
+	"github.com/dgraph-io/dgraph/conn"
+	"github.com/dgraph-io/dgraph/posting"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/golang/glog"
+)
+
+// A witness votes in the elections of its group and keeps the Raft log like any voter, so that
+// two replicas and a witness keep a quorum when one of the replicas is down. It holds no data:
+// it doesn't apply the mutations, doesn't retrieve the snapshots, isn't picked to serve the
+// requests to its group, and hands the leadership over to a replica whenever it wins an election.
+// While it's the leader, the snapshots it sends are streamed from a replica instead, as a witness
+// refuses to stream its empty p directory.
+
+// applyWitness applies the proposal on a witness. It only follows the timestamps of the group, for
+// the proposals waiting on them to keep flowing through the apply loop. The snapshots, which
+// truncate the log, and the membership states are applied as usual by applyCommitted.
+func (n *node) applyWitness(proposal *pb.Proposal) error {
+	if proposal.Delta != nil {
+		posting.Oracle().ProcessDelta(proposal.Delta)
+	}
+	return nil
+}
+
+// replicaToLead returns a replica of the group which the leader heard from recently, to hand the
+// leadership over to, or zero if there's none.
+func (n *node) replicaToLead() uint64 {
+	progress := n.Raft().Status().Progress
+	for id, m := range groups().members(n.gid) {
+		if id == n.Id || m.Witness || m.Learner {
+			continue
+		}
+		if pr, ok := progress[id]; ok && pr.RecentActive {
+			return id
+		}
+	}
+	return 0
+}
+
+// handOverLeadership transfers the leadership of the group from this witness to a replica, until
+// the witness isn't the leader anymore.
+func (n *node) handOverLeadership() {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for n.AmLeader() {
+		if id := n.replicaToLead(); id != 0 {
+			glog.Infof("Witness %#x of group %d is the leader, handing the leadership over to %#x.",
+				n.Id, n.gid, id)
+			n.Raft().TransferLeadership(n.ctx, n.Id, id)
+		}
+		select {
+		case <-ticker.C:
+		case <-n.closer.HasBeenClosed():
+			return
+		}
+	}
+}
+
+// isWitness tells whether the member of this Alpha's group is a witness, as far as the membership
+// state knows.
+func isWitness(id uint64) bool {
+	m, ok := groups().members(groups().groupId())[id]
+	return ok && m.Witness
+}
+
+// replicaPool returns the connection to another member of the group holding data, to stream a
+// snapshot from, or nil if there's none. The members are tried in random order, so that a
+// member which can't stream the snapshot, as it hasn't applied it yet, isn't retried forever.
+func (n *node) replicaPool() *conn.Pool {
+	for id, m := range groups().members(n.gid) {
+		if id == n.Id || m.Witness {
+			continue
+		}
+		if pl, err := conn.GetPools().Get(m.Addr); err == nil {
+			return pl
+		}
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/conn"
+	"github.com/dgraph-io/dgraph/protos/pb"
+)
+
+func TestWitnessIsNotPicked(t *testing.T) {
+	g := &groupi{
+		gid: 1,
+		state: &pb.MembershipState{Groups: map[uint32]*pb.Group{1: {Members: map[uint64]*pb.Member{
+			1: {Id: 1, GroupId: 1, Addr: "alpha1"},
+			2: {Id: 2, GroupId: 1, Addr: "alpha2", Witness: true},
+		}}}},
+	}
+	require.Equal(t, []string{"alpha1"}, g.AnyTwoServers(1))
+
+	// The replica doesn't hand the leadership over to the witness, the witness does to it.
+	g.Node = &node{Node: &conn.Node{Id: 1}}
+	_, ok := g.MyPeer()
+	require.False(t, ok)
+	require.True(t, g.ServesGroup(1))
+
+	g.Node = &node{Node: &conn.Node{Id: 2}, witness: true}
+	peer, ok := g.MyPeer()
+	require.True(t, ok)
+	require.Equal(t, uint64(1), peer)
+	require.False(t, g.ServesGroup(1))
+
+	// The snapshots sent by the witness are streamed from a replica.
+	defer func(old *groupi) { gr = old }(gr)
+	gr = g
+	require.True(t, isWitness(2))
+	require.False(t, isWitness(1))
+	require.False(t, isWitness(3))
+}
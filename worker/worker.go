@@ -67,6 +67,7 @@ func Init(ps *badger.DB) {
 		grpc.MaxSendMsgSize(x.GrpcMaxSize),
 		grpc.MaxConcurrentStreams(math.MaxInt32),
 		grpc.StatsHandler(&ocgrpc.ServerHandler{}),
+		grpc.UnaryInterceptor(x.ErrorCodeInterceptor),
 	}
 
 	if x.WorkerConfig.TLSServerConfig != nil {
@@ -112,7 +113,23 @@ func RunServer(bindall bool) {
 		log.Fatalf("While running server: %v", err)
 	}
 	glog.Infof("Worker listening at address: %v", ln.Addr())
+	serveWorker(ln)
+}
+
+// RunLocalServer serves the requests from the other nodes of this process in memory, see
+// conn.ListenLocal, for an Alpha embedded in another program. It must be called before
+// StartRaftNodes, which registers the Alpha at its address with Zero.
+func RunLocalServer() {
+	addr := x.WorkerConfig.MyAddr
+	if addr == "" {
+		addr = fmt.Sprintf("localhost:%d", workerPort())
+	}
+	ln := conn.ListenLocal(addr)
+	glog.Infof("Worker listening in memory at address: %s", addr)
+	serveWorker(ln)
+}
 
+func serveWorker(ln net.Listener) {
 	pb.RegisterWorkerServer(workerServer, &grpcWorker{})
 	pb.RegisterRaftServer(workerServer, &raftServer)
 	if err := workerServer.Serve(ln); err != nil {
@@ -19,6 +19,7 @@ package x
 import (
 	"crypto/tls"
 	"net"
+	"sync"
 	"time"
 
 	"github.com/dgraph-io/badger/v3"
@@ -36,24 +37,46 @@ type Options struct {
 	// normalize-node int - maximum number of nodes that can be returned in a query that uses the
 	//                      normalize directive
 	// mutations-nquad int - maximum number of nquads that can be inserted in a mutation request
+	// txn-postings int - maximum number of postings a transaction can write in a group
+	// txn-node-edges int - maximum number of edges a transaction can add to a single node
 	// BlockDropAll bool - if set to true, the drop all operation will be rejected by the server.
 	// query-timeout duration - Maximum time after which a query execution will fail.
 	// max-retries int64 - maximum number of retries made by dgraph to commit a transaction to disk.
 	// shared-instance bool - if set to true, ACLs will be disabled for non-galaxy users.
+	// history duration - the duration for which versions are retained for time-travel queries.
+	// idempotency-ttl duration - the duration for which the idempotency keys are recorded.
 	Limit                *z.SuperFlag
 	LimitMutationsNquad  int
+	LimitTxnPostings     int
+	LimitTxnNodeEdges    int
 	LimitQueryEdge       uint64
 	BlockClusterWideDrop bool
 	LimitNormalizeNode   int
 	QueryTimeout         time.Duration
 	MaxRetries           int64
 	SharedInstance       bool
+	HistoryRetention     time.Duration
+	IdempotencyTTL       time.Duration
+
+	// PredicateMetrics is set if the per-predicate metrics are recorded. They are opt-in, as a
+	// schema with many predicates results in many time series.
+	PredicateMetrics bool
+
+	// PostingChecksums is set if a checksum is stored along with the posting lists written, to
+	// detect their corruption when they are read.
+	PostingChecksums bool
+	// StrictCountIndex is set if the entries of the @count indexes are recomputed when the
+	// transactions commit, instead of only being fixed when the posting lists are rolled up.
+	StrictCountIndex bool
 
 	// GraphQL options:
 	//
 	// extensions bool - Will be set to see extensions in GraphQL results
 	// debug bool - Will enable debug mode in GraphQL.
 	// poll-interval duration - The polling interval for graphql subscription.
+	// breaker-failures and breaker-cooldown configure the circuit breaker of the hosts called by
+	// the @custom(http: {...}) fields.
+	// schema-history uint32 - The number of previous GraphQL schemas kept for rollback.
 	GraphQL GraphQLOptions
 
 	// Lambda options:
@@ -70,6 +93,8 @@ type Options struct {
 	// Update(Aug 2021): Now, alpha spins up lambda servers based on cnt and port sub-flags.
 	// Also, no special handling of namespace is needed from lambda as we send the script
 	// along with request body to lambda server. If url is set, these two flags are ignored.
+	// batch-size and batch-concurrency bound the parents sent in a single request to resolve a
+	// lambda field, and the number of such requests made at once.
 	Lambda LambdaOptions
 }
 
@@ -78,6 +103,13 @@ type GraphQLOptions struct {
 	Debug         bool
 	Extensions    bool
 	PollInterval  time.Duration
+	// BreakerFailures is the number of consecutive failed requests to a host after which the
+	// requests of @custom fields to it fail fast. Zero disables the circuit breaker.
+	BreakerFailures uint32
+	// BreakerCooldown is how long the circuit stays open before a request is let through again.
+	BreakerCooldown time.Duration
+	// SchemaHistory is the number of previous GraphQL schemas kept for each namespace.
+	SchemaHistory uint32
 }
 
 type LambdaOptions struct {
@@ -85,11 +117,56 @@ type LambdaOptions struct {
 	Num          uint32
 	Port         uint32
 	RestartAfter time.Duration
+	// BatchSize is the max number of parents in a request to resolve a lambda field. Zero means
+	// no limit.
+	BatchSize uint32
+	// BatchConcurrency is the max number of requests made at once to resolve a lambda field.
+	BatchConcurrency uint32
 }
 
 // Config stores the global instance of this package's options.
 var Config Options
 
+// limitsLock guards the limits of Config which can be changed at runtime. Once the server is
+// running, they must be read through the accessors below and written through UpdateLimits.
+var limitsLock sync.RWMutex
+
+// UpdateLimits calls update, which changes the runtime limits of Config, under the lock of the
+// limits.
+func UpdateLimits(update func()) {
+	limitsLock.Lock()
+	defer limitsLock.Unlock()
+	update()
+}
+
+// LimitQueryEdge returns the query-edge limit.
+func LimitQueryEdge() uint64 {
+	limitsLock.RLock()
+	defer limitsLock.RUnlock()
+	return Config.LimitQueryEdge
+}
+
+// LimitNormalizeNode returns the normalize-node limit.
+func LimitNormalizeNode() int {
+	limitsLock.RLock()
+	defer limitsLock.RUnlock()
+	return Config.LimitNormalizeNode
+}
+
+// LimitMutationsNquad returns the mutations-nquad limit.
+func LimitMutationsNquad() int {
+	limitsLock.RLock()
+	defer limitsLock.RUnlock()
+	return Config.LimitMutationsNquad
+}
+
+// QueryTimeout returns the query-timeout limit, 0 if the queries have no timeout.
+func QueryTimeout() time.Duration {
+	limitsLock.RLock()
+	defer limitsLock.RUnlock()
+	return Config.QueryTimeout
+}
+
 // IPRange represents an IP range.
 type IPRange struct {
 	Lower, Upper net.IP
@@ -132,6 +209,20 @@ type WorkerOptions struct {
 	HmacSecret Sensitive
 	// AbortOlderThan tells Dgraph to discard transactions that are older than this duration.
 	AbortOlderThan time.Duration
+	// TxnMaxAge tells Dgraph to discard transactions which have been open for longer than this
+	// duration, even if they're still active. Zero disables the limit.
+	TxnMaxAge time.Duration
+	// TxnWarnAge is the age of an open transaction from which the responses to its requests
+	// carry a warning. Zero disables the warnings.
+	TxnWarnAge time.Duration
+	// TxnTsBatch is the minimum number of start timestamps an Alpha leases from Zero in one
+	// request. The timestamps left over are handed out to the next transactions.
+	TxnTsBatch uint64
+	// TxnTsMaxAge is the duration for which the start timestamps leased ahead can be handed out.
+	TxnTsMaxAge time.Duration
+	// MaxPredicateTasks is the number of query tasks reading a predicate which run concurrently.
+	// Zero disables the limit.
+	MaxPredicateTasks int
 	// ProposedGroupId will be used if there's a file in the p directory called group_id with the
 	// proposed group ID for this server.
 	ProposedGroupId uint32
@@ -0,0 +1,179 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package x
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/dgraph-io/dgo/v210"
+	"github.com/pkg/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorCode is the structured code of an error returned by the API. The clients can use it to
+// decide whether to retry a request, instead of matching the error message.
+type ErrorCode string
+
+const (
+	// ErrorCodeRetryable is the code of the transient errors, like a server which isn't ready yet
+	// or a tablet being moved. The request can be retried as is.
+	ErrorCodeRetryable ErrorCode = "retryable"
+	// ErrorCodeConflict is the code of the errors of the transactions aborted because of a
+	// conflict. The whole transaction can be retried.
+	ErrorCodeConflict ErrorCode = "conflict"
+	// ErrorCodeSchema is the code of the errors of the schema updates and of the mutations which
+	// don't match the schema. The request must not be retried.
+	ErrorCodeSchema ErrorCode = "schema"
+	// ErrorCodeAuth is the code of the authentication and authorization errors. The request can
+	// only be retried after logging in again, e.g. when the access JWT expired.
+	ErrorCodeAuth ErrorCode = "auth"
+	// ErrorCodeResourceExhausted is the code of the errors of an overloaded server. The request
+	// can be retried with a backoff.
+	ErrorCodeResourceExhausted ErrorCode = "resource-exhausted"
+	// ErrorCodePreconditionFailed is the code of the errors of the mutations whose preconditions
+	// don't hold, see ExtractPreconditions. The request must not be retried as is, but only after
+	// reading the current values again.
+	ErrorCodePreconditionFailed ErrorCode = "precondition-failed"
+
+	// errorCodeDomain is the domain of the ErrorInfo details of the gRPC errors.
+	errorCodeDomain = "dgraph.io"
+)
+
+// Retryable returns whether the clients can retry a request which failed with this code.
+func (c ErrorCode) Retryable() bool {
+	switch c {
+	case ErrorCodeRetryable, ErrorCodeConflict, ErrorCodeResourceExhausted:
+		return true
+	}
+	return false
+}
+
+// grpcCode returns the gRPC status code sent along with the errors of this code which don't
+// already have one.
+func (c ErrorCode) grpcCode() codes.Code {
+	switch c {
+	case ErrorCodeRetryable:
+		return codes.Unavailable
+	case ErrorCodeConflict:
+		return codes.Aborted
+	case ErrorCodeSchema:
+		return codes.InvalidArgument
+	case ErrorCodeAuth:
+		return codes.PermissionDenied
+	case ErrorCodeResourceExhausted:
+		return codes.ResourceExhausted
+	case ErrorCodePreconditionFailed:
+		return codes.FailedPrecondition
+	}
+	return codes.Unknown
+}
+
+type codedError struct {
+	err  error
+	code ErrorCode
+}
+
+// WithErrorCode returns err with the given error code. The error is sent to the gRPC clients
+// along with its code in an ErrorInfo detail, and to the HTTP clients in the extensions of the
+// error. Comparing the returned error with the sentinel errors still works through errors.Is.
+func WithErrorCode(err error, code ErrorCode) error {
+	if err == nil {
+		return nil
+	}
+	return &codedError{err: err, code: code}
+}
+
+func (e *codedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *codedError) Unwrap() error {
+	return e.err
+}
+
+// GRPCStatus is used by gRPC to send the error along with its code.
+func (e *codedError) GRPCStatus() *status.Status {
+	code, msg := e.code.grpcCode(), e.err.Error()
+	if st, ok := status.FromError(e.err); ok {
+		code, msg = st.Code(), st.Message()
+	}
+	st := status.New(code, msg)
+	withInfo, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason:   string(e.code),
+		Domain:   errorCodeDomain,
+		Metadata: map[string]string{"retryable": strconv.FormatBool(e.code.Retryable())},
+	})
+	if err != nil {
+		return st
+	}
+	return withInfo
+}
+
+// ErrorCodeOf returns the error code of err, or an empty string if it has none. The code is read
+// from the error chain, and from the details and the code of the gRPC errors, so that the codes
+// of the errors returned by the other Alphas are kept.
+func ErrorCodeOf(err error) ErrorCode {
+	if err == nil {
+		return ""
+	}
+	var ce *codedError
+	if errors.As(err, &ce) {
+		return ce.code
+	}
+	if errors.Is(err, dgo.ErrAborted) {
+		return ErrorCodeConflict
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		return ""
+	}
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok && info.Domain == errorCodeDomain {
+			return ErrorCode(info.Reason)
+		}
+	}
+	switch st.Code() {
+	case codes.Aborted:
+		return ErrorCodeConflict
+	case codes.Unauthenticated, codes.PermissionDenied:
+		return ErrorCodeAuth
+	case codes.ResourceExhausted:
+		return ErrorCodeResourceExhausted
+	case codes.Unavailable:
+		return ErrorCodeRetryable
+	}
+	return ""
+}
+
+// ErrorCodeInterceptor is a gRPC interceptor which sends the errors along with their error code,
+// even if they have been wrapped since the code was set.
+func ErrorCodeInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	resp, err := handler(ctx, req)
+	if _, ok := err.(*codedError); ok {
+		return resp, err
+	}
+	if code := ErrorCodeOf(err); code != "" {
+		err = WithErrorCode(err, code)
+	}
+	return resp, err
+}
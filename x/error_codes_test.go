@@ -0,0 +1,94 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package x
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dgraph-io/dgo/v210"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestErrorCodeOf(t *testing.T) {
+	require.Equal(t, ErrorCode(""), ErrorCodeOf(nil))
+	require.Equal(t, ErrorCode(""), ErrorCodeOf(errors.New("some error")))
+	require.Equal(t, ErrorCodeConflict, ErrorCodeOf(ErrConflict))
+	require.Equal(t, ErrorCodeConflict, ErrorCodeOf(errors.Wrapf(ErrConflict, "while committing")))
+	require.Equal(t, ErrorCodeConflict, ErrorCodeOf(dgo.ErrAborted))
+	require.Equal(t, ErrorCodeAuth, ErrorCodeOf(status.Error(codes.PermissionDenied, "denied")))
+	require.Equal(t, ErrorCodeRetryable, ErrorCodeOf(HealthCheck()))
+
+	// The sentinel errors can still be compared after being given a code.
+	err := errors.Wrapf(ErrConflict, "while committing")
+	require.True(t, errors.Is(err, ErrConflict))
+	require.Equal(t, ErrConflict, errors.Cause(err))
+}
+
+func TestErrorCodeGRPCStatus(t *testing.T) {
+	// The code is kept by the errors sent over gRPC.
+	st, ok := status.FromError(WithErrorCode(errors.New("overloaded"), ErrorCodeResourceExhausted))
+	require.True(t, ok)
+	require.Equal(t, codes.ResourceExhausted, st.Code())
+	require.Equal(t, ErrorCodeResourceExhausted, ErrorCodeOf(st.Err()))
+
+	st, ok = status.FromError(WithErrorCode(errors.New("changed"), ErrorCodePreconditionFailed))
+	require.True(t, ok)
+	require.Equal(t, codes.FailedPrecondition, st.Code())
+	require.Equal(t, ErrorCodePreconditionFailed, ErrorCodeOf(st.Err()))
+	require.False(t, ErrorCodePreconditionFailed.Retryable())
+
+	// The gRPC code of the status errors is kept.
+	st, ok = status.FromError(WithErrorCode(
+		status.Error(codes.FailedPrecondition, "conflict"), ErrorCodeConflict))
+	require.True(t, ok)
+	require.Equal(t, codes.FailedPrecondition, st.Code())
+	require.Equal(t, "conflict", st.Message())
+	require.Equal(t, ErrorCodeConflict, ErrorCodeOf(st.Err()))
+
+	// The interceptor gives back the code of the wrapped errors.
+	_, err := ErrorCodeInterceptor(context.Background(), nil, &grpc.UnaryServerInfo{},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, errors.Wrapf(ErrHashMismatch, "while querying")
+		})
+	st, ok = status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, codes.PermissionDenied, st.Code())
+	require.Equal(t, ErrorCodeAuth, ErrorCodeOf(st.Err()))
+}
+
+func TestSetErrorStatus(t *testing.T) {
+	w := httptest.NewRecorder()
+	SetErrorStatus(w, ErrorInvalidRequest, ErrConflict)
+	var res struct {
+		Errors []GqlError `json:"errors"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	require.Len(t, res.Errors, 1)
+	require.Equal(t, ErrConflict.Error(), res.Errors[0].Message)
+	require.Equal(t, map[string]interface{}{
+		"code":      ErrorInvalidRequest,
+		"errorCode": "conflict",
+		"retryable": true,
+	}, res.Errors[0].Extensions)
+}
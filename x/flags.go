@@ -22,7 +22,7 @@ import (
 )
 
 const (
-	TraceDefaults     = `ratio=0.01; jaeger=; datadog=;`
+	TraceDefaults     = `ratio=0.01; jaeger=; datadog=; otlp=; otlp-headers=;`
 	TelemetryDefaults = `reports=true; sentry=true;`
 )
 
@@ -44,6 +44,12 @@ func FillCommonFlags(flag *pflag.FlagSet) {
 		Flag("datadog",
 			"URL of Datadog to send OpenCensus traces. As of now, the trace exporter does not "+
 				"support annotation logs and discards them.").
+		Flag("otlp",
+			"URL of an OpenTelemetry collector to send the traces to, using OTLP over HTTP, "+
+				"e.g. http://localhost:4318.").
+		Flag("otlp-headers",
+			"A comma separated list of key=value headers to send to the OpenTelemetry collector, "+
+				"e.g. for authentication.").
 		String())
 
 	flag.String("survive", "process",
@@ -28,11 +28,13 @@ var (
 	// mode is enabled
 	drainingMode uint32
 
-	healthCheck     uint32
-	errHealth       = errors.New("Please retry again, server is not ready to accept requests")
-	errDrainingMode = errors.New("the server is in draining mode " +
-		"and client requests will only be allowed after exiting the mode " +
-		" by sending a GraphQL draining(enable: false) mutation to /admin")
+	healthCheck uint32
+	errHealth   = WithErrorCode(
+		errors.New("Please retry again, server is not ready to accept requests"),
+		ErrorCodeRetryable)
+	errDrainingMode = WithErrorCode(errors.New("the server is in draining mode "+
+		"and client requests will only be allowed after exiting the mode "+
+		" by sending a GraphQL draining(enable: false) mutation to /admin"), ErrorCodeRetryable)
 )
 
 // UpdateHealthStatus updates the server's health status so it can start accepting requests.
@@ -49,6 +49,9 @@ const (
 	ByteType      = byte(0x02)
 	// ByteSplit signals that the key stores an individual part of a multi-part list.
 	ByteSplit = byte(0x04)
+	// ByteIndexState is the prefix of the keys recording the predicates whose indexes are being
+	// rebuilt, see IndexStateKey. These keys don't belong to any predicate.
+	ByteIndexState = byte(0x06)
 	// ByteUnused is a constant to specify keys which need to be discarded.
 	ByteUnused = byte(0xff)
 	// GalaxyNamespace is the default namespace name.
@@ -59,6 +62,15 @@ const (
 	NamespaceOffset = 1
 	// NsSeparator is the separator between between the namespace and attribute.
 	NsSeparator = "-"
+	// ExternalIdPred is the predicate holding the external IDs of the nodes, see
+	// AttachUpsertByXid.
+	ExternalIdPred = "dgraph.external_id"
+	// IdempotencyKeyPred, IdempotencyRecordPred and IdempotencyExpiryPred are the predicates
+	// recording the responses of the requests applied with an idempotency key, see
+	// AttachIdempotencyKey.
+	IdempotencyKeyPred    = "dgraph.idempotency.key"
+	IdempotencyRecordPred = "dgraph.idempotency.record"
+	IdempotencyExpiryPred = "dgraph.idempotency.expiry"
 )
 
 // Invalid bytes are replaced with the Unicode replacement rune.
@@ -508,6 +520,37 @@ func PredicatePrefix(predicate string) []byte {
 	return buf
 }
 
+// IndexStateKey returns the key recording that the indexes of the predicate are being rebuilt.
+// The structure of an index state key is as follows:
+//
+// byte 0: key type prefix (set to ByteIndexState)
+// byte 1-8: namespace
+// byte 9-10: length of attr
+// next len(attr) bytes: value of attr
+func IndexStateKey(attr string) []byte {
+	key, _ := generateKey(ByteIndexState, attr, 0)
+	return key
+}
+
+// IndexStatePrefix returns the prefix of the index state keys.
+func IndexStatePrefix() []byte {
+	var buf [1]byte
+	buf[0] = ByteIndexState
+	return buf[:]
+}
+
+// ParseIndexStateKey returns the predicate of the given index state key.
+func ParseIndexStateKey(key []byte) (string, error) {
+	if len(key) < 11 || key[0] != ByteIndexState {
+		return "", errors.Errorf("Invalid index state key %v", key)
+	}
+	sz := int(binary.BigEndian.Uint16(key[9:11]))
+	if len(key) != 11+sz {
+		return "", errors.Errorf("Invalid size %v for index state key %v", sz, key)
+	}
+	return NamespaceAttr(binary.BigEndian.Uint64(key[1:9]), string(key[11:])), nil
+}
+
 // DataPrefix returns the prefix for all data keys belonging to this namespace.
 func DataPrefix(ns uint64) []byte {
 	buf := make([]byte, 1+8)
@@ -544,7 +587,7 @@ func Parse(key []byte) (ParsedKey, error) {
 	p.bytePrefix = key[0]
 	namespace := key[1:9]
 	key = key[9:]
-	if p.bytePrefix == ByteUnused {
+	if p.bytePrefix == ByteUnused || p.bytePrefix == ByteIndexState {
 		return p, nil
 	}
 
@@ -645,12 +688,18 @@ var starAllPredicateMap = map[string]struct{}{
 }
 
 var aclPredicateMap = map[string]struct{}{
-	"dgraph.xid":             {},
-	"dgraph.password":        {},
-	"dgraph.user.group":      {},
-	"dgraph.rule.predicate":  {},
-	"dgraph.rule.permission": {},
-	"dgraph.acl.rule":        {},
+	"dgraph.xid":                   {},
+	"dgraph.password":              {},
+	"dgraph.user.group":            {},
+	"dgraph.user.password_updated": {},
+	"dgraph.user.password_scheme":  {},
+	"dgraph.rule.predicate":        {},
+	"dgraph.rule.permission":       {},
+	"dgraph.acl.rule":              {},
+	"dgraph.session.id":            {},
+	"dgraph.session.user":          {},
+	"dgraph.session.expiry":        {},
+	"dgraph.session.revoked":       {},
 }
 
 // TODO: rename this map to a better suited name as per its properties. It is not just for GraphQL
@@ -661,6 +710,12 @@ var graphqlReservedPredicate = map[string]struct{}{
 	"dgraph.graphql.schema":  {},
 	"dgraph.drop.op":         {},
 	"dgraph.graphql.p_query": {},
+	"dgraph.runtime_config":  {},
+	"dgraph.colocation":      {},
+	ExternalIdPred:           {},
+	IdempotencyKeyPred:       {},
+	IdempotencyRecordPred:    {},
+	IdempotencyExpiryPred:    {},
 }
 
 // internalPredicateMap stores a set of Dgraph's internal predicate. An internal
@@ -676,6 +731,7 @@ var preDefinedTypeMap = map[string]struct{}{
 	"dgraph.type.User":               {},
 	"dgraph.type.Group":              {},
 	"dgraph.type.Rule":               {},
+	"dgraph.type.Session":            {},
 	"dgraph.graphql.persisted_query": {},
 }
 
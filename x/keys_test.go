@@ -17,6 +17,7 @@
 package x
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"math"
@@ -304,3 +305,20 @@ func TestNsSeparator(t *testing.T) {
 	require.Equal(t, GalaxyNamespace, ns)
 	require.Equal(t, pred, attr)
 }
+
+func TestIndexStateKey(t *testing.T) {
+	attr := NamespaceAttr(2, "name")
+	key := IndexStateKey(attr)
+	require.True(t, bytes.HasPrefix(key, IndexStatePrefix()))
+	got, err := ParseIndexStateKey(key)
+	require.NoError(t, err)
+	require.Equal(t, attr, got)
+
+	_, err = ParseIndexStateKey(SchemaKey(attr))
+	require.Error(t, err)
+
+	// The index state keys don't belong to any predicate.
+	pk, err := Parse(key)
+	require.NoError(t, err)
+	require.Empty(t, pk.Attr)
+}
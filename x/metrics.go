@@ -76,6 +76,10 @@ var (
 	// PendingQueries records the current number of pending queries.
 	PendingQueries = stats.Int64("pending_queries_total",
 		"Number of pending queries", stats.UnitDimensionless)
+	// QueuedTasks records the current number of query tasks waiting for a slot of their
+	// predicate.
+	QueuedTasks = stats.Int64("queued_tasks_total",
+		"Number of query tasks waiting for a slot of their predicate", stats.UnitDimensionless)
 	// PendingProposals records the current number of pending RAFT proposals.
 	PendingProposals = stats.Int64("pending_proposals_total",
 		"Number of pending proposals", stats.UnitDimensionless)
@@ -116,6 +120,21 @@ var (
 		"Number of proposals in Raft apply channel", stats.UnitDimensionless)
 	RaftPendingSize = stats.Int64("pending_proposal_bytes",
 		"Size of Raft pending proposal", stats.UnitBytes)
+	// RaftLogEntries records the number of entries in the Raft log.
+	RaftLogEntries = stats.Int64("raft_log_entries",
+		"Number of entries in the Raft log", stats.UnitDimensionless)
+	// RaftLogSize records the size of the Raft log files on disk.
+	RaftLogSize = stats.Int64("raft_log_size_bytes",
+		"Size of the Raft log files on disk", stats.UnitBytes)
+	// RaftEntriesBehind records the number of committed Raft entries that are yet to be applied.
+	RaftEntriesBehind = stats.Int64("raft_entries_behind",
+		"Number of committed Raft entries yet to be applied", stats.UnitDimensionless)
+	// RaftSnapshotAge records the time since the last Raft snapshot was taken.
+	RaftSnapshotAge = stats.Int64("raft_snapshot_age_seconds",
+		"Time since the last Raft snapshot", stats.UnitSeconds)
+	// RaftProposalLatencyMs records the time taken by proposals to go through Raft and be applied.
+	RaftProposalLatencyMs = stats.Float64("raft_proposal_latency",
+		"Latency of the Raft proposals", stats.UnitMilliseconds)
 	// MaxAssignedTs records the latest max assigned timestamp.
 	MaxAssignedTs = stats.Int64("max_assigned_ts",
 		"Latest max assigned timestamp", stats.UnitDimensionless)
@@ -137,6 +156,44 @@ var (
 	// PLCacheHitRatio records the hit ratio of posting list cache.
 	PLCacheHitRatio = stats.Float64("hit_ratio_posting_cache",
 		"Hit ratio of posting list cache", stats.UnitDimensionless)
+	// CDCLagEntries records the number of applied Raft entries whose change events are yet to be
+	// sent to the CDC sink.
+	CDCLagEntries = stats.Int64("cdc_lag_entries",
+		"Number of applied Raft entries yet to be sent to the CDC sink", stats.UnitDimensionless)
+	// CorruptedPostings records the reads of posting lists which failed on a checksum mismatch.
+	CorruptedPostings = stats.Int64("corrupted_postings_total",
+		"Number of posting list reads which failed on a checksum mismatch", stats.UnitDimensionless)
+	// QuarantinedKeys records the number of keys quarantined for holding a corrupted posting list.
+	QuarantinedKeys = stats.Int64("quarantined_keys",
+		"Number of keys holding a corrupted posting list", stats.UnitDimensionless)
+	// ContendedPostingLocks records the mutations which waited for the lock of a posting list for
+	// at least a millisecond, see the hotKeys admin query.
+	ContendedPostingLocks = stats.Int64("posting_lock_contentions_total",
+		"Number of mutations which waited for the lock of a posting list", stats.UnitDimensionless)
+	// TaskQueueLatencyMs records the time the query tasks waited for a slot of their predicate,
+	// see the max-predicate-tasks limit.
+	TaskQueueLatencyMs = stats.Float64("task_queue_latency",
+		"Latency of the query tasks waiting for a slot of their predicate", stats.UnitMilliseconds)
+	// Per-predicate metrics, only recorded with --metrics "predicates=true;".
+
+	// PredicateQueries records the number of tasks processed for a predicate.
+	PredicateQueries = stats.Int64("predicate_queries_total",
+		"Number of query tasks processed for the predicate", stats.UnitDimensionless)
+	// PredicateQueuedTasks records the number of query tasks of a predicate which waited for a
+	// slot, see the max-predicate-tasks limit.
+	PredicateQueuedTasks = stats.Int64("predicate_queued_tasks_total",
+		"Number of query tasks of the predicate which waited for a slot", stats.UnitDimensionless)
+	// PredicateMutations records the number of edges of a predicate that were mutated.
+	PredicateMutations = stats.Int64("predicate_mutated_edges_total",
+		"Number of edges of the predicate mutated", stats.UnitDimensionless)
+	// PredicatePostingListSize records the size of the posting lists of a predicate when they are
+	// rolled up.
+	PredicatePostingListSize = stats.Int64("predicate_posting_list_size_bytes",
+		"Size of the posting lists of the predicate when rolled up", stats.UnitBytes)
+	// PredicateTabletSize records the on disk size of the tablet of a predicate.
+	PredicateTabletSize = stats.Int64("predicate_tablet_size_bytes",
+		"On disk size of the tablet of the predicate", stats.UnitBytes)
+
 	// RaftHasLeader records whether this instance has a leader
 	RaftHasLeader = stats.Int64("raft_has_leader",
 		"Whether or not a leader exists for the group", stats.UnitDimensionless)
@@ -161,6 +218,11 @@ var (
 	// KeyMethod is the tag key used to record the method (e.g read or mutate).
 	KeyMethod, _ = tag.NewKey("method")
 
+	// KeyNamespace and KeyPredicate are the tag keys used to record the predicate for the
+	// per-predicate metrics.
+	KeyNamespace, _ = tag.NewKey("namespace")
+	KeyPredicate, _ = tag.NewKey("predicate")
+
 	// KeyDirType is the tag key used to record the group for FileSystem metrics
 	KeyDirType, _ = tag.NewKey("dir")
 
@@ -186,6 +248,11 @@ var (
 
 	allFSKeys = []tag.Key{KeyDirType}
 
+	allPredicateKeys = []tag.Key{KeyNamespace, KeyPredicate}
+
+	postingListSizeDistribution = view.Distribution(
+		0, 64, 256, 1<<10, 4<<10, 16<<10, 64<<10, 256<<10, 1<<20, 4<<20, 16<<20, 64<<20)
+
 	allViews = []*view.View{
 		{
 			Name:        LatencyMs.Name(),
@@ -250,6 +317,27 @@ var (
 			Aggregation: view.Count(),
 			TagKeys:     nil,
 		},
+		{
+			Name:        CorruptedPostings.Name(),
+			Measure:     CorruptedPostings,
+			Description: CorruptedPostings.Description(),
+			Aggregation: view.Count(),
+			TagKeys:     nil,
+		},
+		{
+			Name:        ContendedPostingLocks.Name(),
+			Measure:     ContendedPostingLocks,
+			Description: ContendedPostingLocks.Description(),
+			Aggregation: view.Count(),
+			TagKeys:     nil,
+		},
+		{
+			Name:        TaskQueueLatencyMs.Name(),
+			Measure:     TaskQueueLatencyMs,
+			Description: TaskQueueLatencyMs.Description(),
+			Aggregation: defaultLatencyMsDistribution,
+			TagKeys:     nil,
+		},
 		{
 			Name:        ActiveMutations.Name(),
 			Measure:     ActiveMutations,
@@ -266,6 +354,13 @@ var (
 			Aggregation: view.Sum(),
 			TagKeys:     nil,
 		},
+		{
+			Name:        QueuedTasks.Name(),
+			Measure:     QueuedTasks,
+			Description: QueuedTasks.Description(),
+			Aggregation: view.Sum(),
+			TagKeys:     nil,
+		},
 		{
 			Name:        PendingProposals.Name(),
 			Measure:     PendingProposals,
@@ -273,6 +368,13 @@ var (
 			Aggregation: view.LastValue(),
 			TagKeys:     nil,
 		},
+		{
+			Name:        QuarantinedKeys.Name(),
+			Measure:     QuarantinedKeys,
+			Description: QuarantinedKeys.Description(),
+			Aggregation: view.LastValue(),
+			TagKeys:     nil,
+		},
 		{
 			Name:        PendingBackups.Name(),
 			Measure:     PendingBackups,
@@ -386,6 +488,48 @@ var (
 			Aggregation: view.LastValue(),
 			TagKeys:     allRaftKeys,
 		},
+		{
+			Name:        RaftLogEntries.Name(),
+			Measure:     RaftLogEntries,
+			Description: RaftLogEntries.Description(),
+			Aggregation: view.LastValue(),
+			TagKeys:     allRaftKeys,
+		},
+		{
+			Name:        RaftLogSize.Name(),
+			Measure:     RaftLogSize,
+			Description: RaftLogSize.Description(),
+			Aggregation: view.LastValue(),
+			TagKeys:     allRaftKeys,
+		},
+		{
+			Name:        RaftEntriesBehind.Name(),
+			Measure:     RaftEntriesBehind,
+			Description: RaftEntriesBehind.Description(),
+			Aggregation: view.LastValue(),
+			TagKeys:     allRaftKeys,
+		},
+		{
+			Name:        RaftSnapshotAge.Name(),
+			Measure:     RaftSnapshotAge,
+			Description: RaftSnapshotAge.Description(),
+			Aggregation: view.LastValue(),
+			TagKeys:     allRaftKeys,
+		},
+		{
+			Name:        RaftProposalLatencyMs.Name(),
+			Measure:     RaftProposalLatencyMs,
+			Description: RaftProposalLatencyMs.Description(),
+			Aggregation: defaultLatencyMsDistribution,
+			TagKeys:     allRaftKeys,
+		},
+		{
+			Name:        CDCLagEntries.Name(),
+			Measure:     CDCLagEntries,
+			Description: CDCLagEntries.Description(),
+			Aggregation: view.LastValue(),
+			TagKeys:     allRaftKeys,
+		},
 		{
 			Name:        RaftHasLeader.Name(),
 			Measure:     RaftHasLeader,
@@ -407,6 +551,48 @@ var (
 			Aggregation: view.Count(),
 			TagKeys:     allRaftKeys,
 		},
+		{
+			Name:        PredicateQueries.Name(),
+			Measure:     PredicateQueries,
+			Description: PredicateQueries.Description(),
+			Aggregation: view.Sum(),
+			TagKeys:     allPredicateKeys,
+		},
+		{
+			Name:        PredicateQueuedTasks.Name(),
+			Measure:     PredicateQueuedTasks,
+			Description: PredicateQueuedTasks.Description(),
+			Aggregation: view.Sum(),
+			TagKeys:     allPredicateKeys,
+		},
+		{
+			Name:        PredicateMutations.Name(),
+			Measure:     PredicateMutations,
+			Description: PredicateMutations.Description(),
+			Aggregation: view.Sum(),
+			TagKeys:     allPredicateKeys,
+		},
+		{
+			Name:        PredicatePostingListSize.Name(),
+			Measure:     PredicatePostingListSize,
+			Description: PredicatePostingListSize.Description(),
+			Aggregation: postingListSizeDistribution,
+			TagKeys:     allPredicateKeys,
+		},
+		{
+			Name:        "predicate_rollups_total",
+			Measure:     PredicatePostingListSize,
+			Description: "Number of posting lists of the predicate rolled up",
+			Aggregation: view.Count(),
+			TagKeys:     allPredicateKeys,
+		},
+		{
+			Name:        PredicateTabletSize.Name(),
+			Measure:     PredicateTabletSize,
+			Description: PredicateTabletSize.Description(),
+			Aggregation: view.LastValue(),
+			TagKeys:     allPredicateKeys,
+		},
 	}
 )
 
@@ -527,6 +713,23 @@ func WithMethod(parent context.Context, method string) context.Context {
 	return ctx
 }
 
+// RecordPredicate records the measurement for the given namespaced predicate, if the
+// per-predicate metrics are enabled.
+func RecordPredicate(attr string, m stats.Measurement) {
+	if !Config.PredicateMetrics {
+		return
+	}
+	ns, pred := ParseNamespaceAttr(attr)
+	ctx, err := tag.New(context.Background(),
+		tag.Upsert(KeyNamespace, strconv.FormatUint(ns, 10)),
+		tag.Upsert(KeyPredicate, pred))
+	if err != nil {
+		// The predicate isn't a valid tag value, e.g. it's too long or has non-ASCII characters.
+		return
+	}
+	ostats.Record(ctx, m)
+}
+
 // SinceMs returns the time since startTime in milliseconds (as a float).
 func SinceMs(startTime time.Time) float64 {
 	return float64(time.Since(startTime)) / 1e6
@@ -549,6 +752,13 @@ func RegisterExporters(conf *viper.Viper, service string) {
 			// And now finally register it as a Trace Exporter
 			trace.RegisterExporter(je)
 		}
+		if collector := t.GetString("otlp"); len(collector) > 0 {
+			exporter, err := newOTLPExporter(collector, t.GetString("otlp-headers"), service)
+			if err != nil {
+				log.Fatalf("Failed to create the OTLP exporter: %v", err)
+			}
+			trace.RegisterExporter(exporter)
+		}
 		if collector := t.GetString("datadog"); len(collector) > 0 {
 			exporter, err := datadog.NewExporter(datadog.Options{
 				Service:   service,
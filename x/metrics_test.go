@@ -0,0 +1,44 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package x
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+func TestRecordPredicate(t *testing.T) {
+	attr := NamespaceAttr(2, "name")
+	RecordPredicate(attr, PredicateQueries.M(1))
+	rows, err := view.RetrieveData(PredicateQueries.Name())
+	require.NoError(t, err)
+	require.Empty(t, rows)
+
+	Config.PredicateMetrics = true
+	defer func() { Config.PredicateMetrics = false }()
+	RecordPredicate(attr, PredicateQueries.M(1))
+	RecordPredicate(attr, PredicateQueries.M(1))
+	rows, err = view.RetrieveData(PredicateQueries.Name())
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	require.ElementsMatch(t, []tag.Tag{{Key: KeyNamespace, Value: "2"},
+		{Key: KeyPredicate, Value: "name"}}, rows[0].Tags)
+	require.Equal(t, float64(2), rows[0].Data.(*view.SumData).Value)
+}
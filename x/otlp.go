@@ -0,0 +1,299 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package x
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"go.opencensus.io/trace"
+)
+
+const (
+	// otlpBatchSize is the number of spans sent to the collector in one request.
+	otlpBatchSize = 512
+	// otlpMaxQueued is the number of spans kept while the collector is slow or unreachable. The
+	// spans beyond it are dropped.
+	otlpMaxQueued  = 8192
+	otlpFlushEvery = 5 * time.Second
+)
+
+// otlpExporter sends the OpenCensus spans to an OpenTelemetry collector, using the JSON encoding
+// of OTLP over HTTP.
+type otlpExporter struct {
+	url     string
+	headers map[string]string
+	service string
+	client  *http.Client
+
+	sync.Mutex
+	spans   []*trace.SpanData
+	dropped int
+	sendCh  chan []*trace.SpanData
+}
+
+// newOTLPExporter returns an exporter sending to the given collector endpoint, e.g.
+// http://localhost:4318. The headers are a comma separated list of key=value pairs, added to
+// every request.
+func newOTLPExporter(endpoint, headers, service string) (*otlpExporter, error) {
+	if !strings.HasPrefix(endpoint, "http://") && !strings.HasPrefix(endpoint, "https://") {
+		return nil, errors.Errorf("OTLP endpoint %q must start with http:// or https://", endpoint)
+	}
+	url := strings.TrimSuffix(endpoint, "/")
+	if !strings.HasSuffix(url, "/v1/traces") {
+		url += "/v1/traces"
+	}
+	e := &otlpExporter{
+		url:     url,
+		headers: make(map[string]string),
+		service: service,
+		client:  &http.Client{Timeout: 10 * time.Second},
+		sendCh:  make(chan []*trace.SpanData, 4),
+	}
+	for _, kv := range strings.Split(headers, ",") {
+		if strings.TrimSpace(kv) == "" {
+			continue
+		}
+		parts := strings.SplitN(kv, "=", 2)
+		if len(parts) != 2 {
+			return nil, errors.Errorf("invalid OTLP header %q, must be key=value", kv)
+		}
+		e.headers[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+	go e.flushPeriodically()
+	go e.sendBatches()
+	return e, nil
+}
+
+// ExportSpan implements trace.Exporter. It queues the span, to be sent in a batch.
+func (e *otlpExporter) ExportSpan(sd *trace.SpanData) {
+	e.Lock()
+	defer e.Unlock()
+	if len(e.spans) >= otlpMaxQueued {
+		e.dropped++
+		return
+	}
+	e.spans = append(e.spans, sd)
+	if len(e.spans) >= otlpBatchSize {
+		e.flushLocked()
+	}
+}
+
+func (e *otlpExporter) flushLocked() {
+	if len(e.spans) == 0 {
+		return
+	}
+	select {
+	case e.sendCh <- e.spans:
+		e.spans = nil
+	default:
+		// The previous batches are still being sent. Keep queueing.
+	}
+}
+
+func (e *otlpExporter) flushPeriodically() {
+	ticker := time.NewTicker(otlpFlushEvery)
+	defer ticker.Stop()
+	for range ticker.C {
+		e.Lock()
+		e.flushLocked()
+		if e.dropped > 0 {
+			glog.Warningf("Dropped %d spans as the OTLP collector at %s can't keep up",
+				e.dropped, e.url)
+			e.dropped = 0
+		}
+		e.Unlock()
+	}
+}
+
+func (e *otlpExporter) sendBatches() {
+	for spans := range e.sendCh {
+		if err := e.send(spans); err != nil {
+			glog.Warningf("Unable to send %d spans to the OTLP collector: %v", len(spans), err)
+		}
+	}
+}
+
+func (e *otlpExporter) send(spans []*trace.SpanData) error {
+	body, err := json.Marshal(toOTLP(e.service, spans))
+	if err != nil {
+		return errors.Wrapf(err, "while marshalling spans")
+	}
+	req, err := http.NewRequest(http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range e.headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1<<10))
+		return errors.Errorf("collector responded with %s: %s", resp.Status, msg)
+	}
+	return nil
+}
+
+// The types below follow the JSON encoding of the OTLP protobufs, see
+// https://opentelemetry.io/docs/specs/otlp/#json-protobuf-encoding.
+
+type otlpRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceId           string         `json:"traceId"`
+	SpanId            string         `json:"spanId"`
+	ParentSpanId      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              int            `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Events            []otlpEvent    `json:"events,omitempty"`
+	Status            otlpStatus     `json:"status"`
+}
+
+type otlpEvent struct {
+	TimeUnixNano string         `json:"timeUnixNano"`
+	Name         string         `json:"name"`
+	Attributes   []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string                 `json:"key"`
+	Value map[string]interface{} `json:"value"`
+}
+
+const (
+	otlpKindInternal    = 1
+	otlpKindServer      = 2
+	otlpKindClient      = 3
+	otlpStatusCodeError = 2
+)
+
+func otlpTime(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
+
+func otlpAttributes(attrs map[string]interface{}) []otlpKeyValue {
+	res := make([]otlpKeyValue, 0, len(attrs))
+	for k, v := range attrs {
+		var val map[string]interface{}
+		switch v := v.(type) {
+		case bool:
+			val = map[string]interface{}{"boolValue": v}
+		case int64:
+			// 64 bit integers are encoded as strings in JSON.
+			val = map[string]interface{}{"intValue": strconv.FormatInt(v, 10)}
+		case float64:
+			val = map[string]interface{}{"doubleValue": v}
+		case string:
+			val = map[string]interface{}{"stringValue": v}
+		default:
+			continue
+		}
+		res = append(res, otlpKeyValue{Key: k, Value: val})
+	}
+	return res
+}
+
+// toOTLP converts the OpenCensus spans into an OTLP export request.
+func toOTLP(service string, spans []*trace.SpanData) *otlpRequest {
+	out := make([]otlpSpan, 0, len(spans))
+	for _, sd := range spans {
+		s := otlpSpan{
+			TraceId:           hex.EncodeToString(sd.TraceID[:]),
+			SpanId:            hex.EncodeToString(sd.SpanID[:]),
+			Name:              sd.Name,
+			Kind:              otlpKindInternal,
+			StartTimeUnixNano: otlpTime(sd.StartTime),
+			EndTimeUnixNano:   otlpTime(sd.EndTime),
+			Attributes:        otlpAttributes(sd.Attributes),
+		}
+		if sd.ParentSpanID != (trace.SpanID{}) {
+			s.ParentSpanId = hex.EncodeToString(sd.ParentSpanID[:])
+		}
+		switch sd.SpanKind {
+		case trace.SpanKindServer:
+			s.Kind = otlpKindServer
+		case trace.SpanKindClient:
+			s.Kind = otlpKindClient
+		}
+		if sd.Status.Code != trace.StatusCodeOK {
+			s.Status = otlpStatus{Code: otlpStatusCodeError, Message: sd.Status.Message}
+		}
+		for _, a := range sd.Annotations {
+			s.Events = append(s.Events, otlpEvent{
+				TimeUnixNano: otlpTime(a.Time),
+				Name:         a.Message,
+				Attributes:   otlpAttributes(a.Attributes),
+			})
+		}
+		out = append(out, s)
+	}
+
+	return &otlpRequest{ResourceSpans: []otlpResourceSpans{{
+		Resource: otlpResource{Attributes: otlpAttributes(map[string]interface{}{
+			"service.name":    service,
+			"service.version": Version(),
+		})},
+		ScopeSpans: []otlpScopeSpans{{
+			Scope: otlpScope{Name: "dgraph", Version: Version()},
+			Spans: out,
+		}},
+	}}}
+}
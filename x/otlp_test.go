@@ -0,0 +1,85 @@
+/*
+ * Copyright 2021 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package x
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/trace"
+)
+
+func TestToOTLP(t *testing.T) {
+	start := time.Unix(100, 5)
+	sd := &trace.SpanData{
+		SpanContext: trace.SpanContext{
+			TraceID: trace.TraceID{1, 2, 3},
+			SpanID:  trace.SpanID{4, 5, 6},
+		},
+		ParentSpanID: trace.SpanID{7},
+		SpanKind:     trace.SpanKindClient,
+		Name:         "worker.invokeNetworkRequest",
+		StartTime:    start,
+		EndTime:      start.Add(time.Second),
+		Attributes:   map[string]interface{}{"keys": int64(3)},
+		Annotations:  []trace.Annotation{{Time: start, Message: "Sending"}},
+		Status:       trace.Status{Code: trace.StatusCodeUnknown, Message: "failed"},
+	}
+
+	req := toOTLP("dgraph.alpha", []*trace.SpanData{sd})
+	require.Len(t, req.ResourceSpans, 1)
+	spans := req.ResourceSpans[0].ScopeSpans[0].Spans
+	require.Len(t, spans, 1)
+	s := spans[0]
+	require.Equal(t, "01020300000000000000000000000000", s.TraceId)
+	require.Equal(t, "0405060000000000", s.SpanId)
+	require.Equal(t, "0700000000000000", s.ParentSpanId)
+	require.Equal(t, otlpKindClient, s.Kind)
+	require.Equal(t, "100000000005", s.StartTimeUnixNano)
+	require.Equal(t, "101000000005", s.EndTimeUnixNano)
+	require.Equal(t, []otlpKeyValue{{Key: "keys", Value: map[string]interface{}{"intValue": "3"}}},
+		s.Attributes)
+	require.Equal(t, "Sending", s.Events[0].Name)
+	require.Equal(t, otlpStatus{Code: otlpStatusCodeError, Message: "failed"}, s.Status)
+}
+
+func TestOTLPExporterSend(t *testing.T) {
+	var got otlpRequest
+	var auth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v1/traces", r.URL.Path)
+		auth = r.Header.Get("Authorization")
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(body, &got))
+	}))
+	defer srv.Close()
+
+	e, err := newOTLPExporter(srv.URL, "Authorization=Bearer abc", "dgraph.zero")
+	require.NoError(t, err)
+	require.NoError(t, e.send([]*trace.SpanData{{Name: "test"}}))
+	require.Equal(t, "Bearer abc", auth)
+	require.Equal(t, "test", got.ResourceSpans[0].ScopeSpans[0].Spans[0].Name)
+
+	_, err = newOTLPExporter("localhost:4318", "", "dgraph.zero")
+	require.Error(t, err)
+}
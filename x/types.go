@@ -16,6 +16,8 @@
 
 package x
 
+import "time"
+
 type ExportedGQLSchema struct {
 	Namespace uint64
 	Schema    string
@@ -25,6 +27,20 @@ type ExportedGQLSchema struct {
 type GQL struct {
 	Schema string
 	Script string
+	// Version is incremented by each update of the schema. It is zero for a schema stored before
+	// the versions were kept.
+	Version uint64 `json:",omitempty"`
+	// AppliedAt is when the current schema was applied.
+	AppliedAt time.Time `json:",omitempty"`
+	// History holds the previous versions of the schema, the latest first.
+	History []GQLVersion `json:",omitempty"`
+}
+
+// GQLVersion is a previous version of the GraphQL schema.
+type GQLVersion struct {
+	Version   uint64
+	Schema    string
+	AppliedAt time.Time
 }
 
 // Sensitive implements the Stringer interface to redact its contents.
@@ -69,13 +69,14 @@ var (
 	// ErrNotSupported is thrown when an enterprise feature is requested in the open source version.
 	ErrNotSupported = errors.Errorf("Feature available only in Dgraph Enterprise Edition")
 	// ErrNoJwt is returned when JWT is not present in the context.
-	ErrNoJwt = errors.New("no accessJwt available")
+	ErrNoJwt = WithErrorCode(errors.New("no accessJwt available"), ErrorCodeAuth)
 	// ErrorInvalidLogin is returned when username or password is incorrect in login
-	ErrorInvalidLogin = errors.New("invalid username or password")
+	ErrorInvalidLogin = WithErrorCode(errors.New("invalid username or password"), ErrorCodeAuth)
 	// ErrConflict is returned when commit couldn't succeed due to conflicts.
-	ErrConflict = errors.New("Transaction conflict")
+	ErrConflict = WithErrorCode(errors.New("Transaction conflict"), ErrorCodeConflict)
 	// ErrHashMismatch is returned when the hash does not matches the startTs
-	ErrHashMismatch = errors.New("hash mismatch the claimed startTs|namespace")
+	ErrHashMismatch = WithErrorCode(
+		errors.New("hash mismatch the claimed startTs|namespace"), ErrorCodeAuth)
 )
 
 const (
@@ -139,6 +140,14 @@ const (
 		"Content-Type, Content-Length, Accept-Encoding, Cache-Control, " +
 		"X-CSRF-Token, X-Auth-Token, X-Requested-With"
 	DgraphCostHeader = "Dgraph-TouchedUids"
+	// DgraphTxnAgeWarningHeader is set on the responses to the requests of old transactions.
+	DgraphTxnAgeWarningHeader = "Dgraph-Txn-Age-Warning"
+	// DgraphConsistencyTokenHeader holds the consistency token of a response, which can be passed
+	// back with the consistencyToken parameter of a best-effort query to read the data as recent.
+	DgraphConsistencyTokenHeader = "Dgraph-Consistency-Token"
+	// DgraphSampleWarningHeader holds the warning sent when the edges of some nodes were sampled
+	// down to the expand sample of the query.
+	DgraphSampleWarningHeader = "Dgraph-Sample-Warning"
 
 	ManifestVersion = 2105
 )
@@ -415,6 +424,49 @@ func SetStatusWithData(w http.ResponseWriter, code, msg string) {
 	}
 }
 
+// errorExtensions returns the extensions of the error sent for err in an HTTP response. They hold
+// the error code of err, if any, so that the clients can tell whether to retry the request.
+func errorExtensions(code string, err error) map[string]interface{} {
+	ext := map[string]interface{}{"code": code}
+	if errCode := ErrorCodeOf(err); errCode != "" {
+		ext["errorCode"] = string(errCode)
+		ext["retryable"] = errCode.Retryable()
+	}
+	return ext
+}
+
+// SetErrorStatus is like SetStatus, but it also sends the error code of err in the extensions
+// of the error.
+func SetErrorStatus(w http.ResponseWriter, code string, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	var qr queryRes
+	qr.Errors = append(qr.Errors,
+		&GqlError{Message: err.Error(), Extensions: errorExtensions(code, err)})
+	if js, err := json.Marshal(qr); err == nil {
+		if _, err := w.Write(js); err != nil {
+			glog.Errorf("Error while writing: %+v", err)
+		}
+	} else {
+		Panic(errors.Errorf("Unable to marshal: %+v", qr))
+	}
+}
+
+// SetErrorStatusWithData is like SetStatusWithData, but it also sends the error code of err in
+// the extensions of the error.
+func SetErrorStatusWithData(w http.ResponseWriter, code string, err error) {
+	var qr QueryResWithData
+	qr.Errors = append(qr.Errors,
+		&GqlError{Message: err.Error(), Extensions: errorExtensions(code, err)})
+	// This would ensure that data key is present with value null.
+	if js, err := json.Marshal(qr); err == nil {
+		if _, err := w.Write(js); err != nil {
+			glog.Errorf("Error while writing: %+v", err)
+		}
+	} else {
+		Panic(errors.Errorf("Unable to marshal: %+v", qr))
+	}
+}
+
 // Reply sets the body of an HTTP response to the JSON representation of the given reply.
 func Reply(w http.ResponseWriter, rep interface{}) {
 	if js, err := json.Marshal(rep); err == nil {
@@ -528,6 +580,279 @@ func AttachAccessJwt(ctx context.Context, r *http.Request) context.Context {
 	return ctx
 }
 
+// AttachAsOf adds the asOf parameter of the incoming HTTP request, if any, into the grpc context
+// metadata.
+func AttachAsOf(ctx context.Context, r *http.Request) context.Context {
+	if asOf := r.URL.Query().Get("asOf"); asOf != "" {
+		md, ok := metadata.FromIncomingContext(ctx)
+		if !ok {
+			md = metadata.New(nil)
+		}
+
+		md.Append("as-of", asOf)
+		ctx = metadata.NewIncomingContext(ctx, md)
+	}
+	return ctx
+}
+
+// ExtractAsOf returns the as-of value of a time-travel query from the incoming gRPC context. It
+// returns an empty string for the queries which read the latest data.
+func ExtractAsOf(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	asOf := md.Get("as-of")
+	if len(asOf) == 0 {
+		return ""
+	}
+	return asOf[0]
+}
+
+// AttachSavepoint adds the savepoint and rollbackTo parameters of the incoming HTTP request, if
+// any, into the grpc context metadata.
+func AttachSavepoint(ctx context.Context, r *http.Request) context.Context {
+	savepoint, rollbackTo := r.URL.Query().Get("savepoint"), r.URL.Query().Get("rollbackTo")
+	if savepoint == "" && rollbackTo == "" {
+		return ctx
+	}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		md = metadata.New(nil)
+	}
+	if savepoint != "" {
+		md.Append("savepoint", savepoint)
+	}
+	if rollbackTo != "" {
+		md.Append("rollback-to", rollbackTo)
+	}
+	return metadata.NewIncomingContext(ctx, md)
+}
+
+// ExtractSavepoint returns the name of the savepoint to set in the pending transaction, and the
+// name of the savepoint to roll the transaction back to, from the incoming gRPC context. Both are
+// empty for the requests which don't use savepoints.
+func ExtractSavepoint(ctx context.Context) (string, string) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", ""
+	}
+	var savepoint, rollbackTo string
+	if v := md.Get("savepoint"); len(v) > 0 {
+		savepoint = v[0]
+	}
+	if v := md.Get("rollback-to"); len(v) > 0 {
+		rollbackTo = v[0]
+	}
+	return savepoint, rollbackTo
+}
+
+// AttachConsistencyToken adds the consistencyToken parameter of the incoming HTTP request, if any,
+// into the grpc context metadata.
+func AttachConsistencyToken(ctx context.Context, r *http.Request) context.Context {
+	if token := r.URL.Query().Get("consistencyToken"); token != "" {
+		md, ok := metadata.FromIncomingContext(ctx)
+		if !ok {
+			md = metadata.New(nil)
+		}
+
+		md.Append("consistency-token", token)
+		ctx = metadata.NewIncomingContext(ctx, md)
+	}
+	return ctx
+}
+
+// ExtractConsistencyToken returns the consistency token given along with a best-effort query in
+// the incoming gRPC context, or zero if there's none.
+func ExtractConsistencyToken(ctx context.Context) (uint64, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return 0, nil
+	}
+	token := md.Get("consistency-token")
+	if len(token) == 0 {
+		return 0, nil
+	}
+	ts, err := strconv.ParseUint(token[0], 0, 64)
+	return ts, errors.Wrapf(err, "invalid consistency token %q", token[0])
+}
+
+// AttachLinearizable marks the query in the context as linearizable. The leader of each group
+// read by a linearizable query confirms, through a ReadIndex request, that the Alpha serving it
+// has applied all the proposals committed so far before the data is read.
+func AttachLinearizable(ctx context.Context) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		md = metadata.New(nil)
+	}
+
+	md.Set("linearizable", "true")
+	return metadata.NewIncomingContext(ctx, md)
+}
+
+// IsLinearizable returns whether the query in the incoming gRPC context is linearizable, see
+// AttachLinearizable.
+func IsLinearizable(ctx context.Context) bool {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false
+	}
+	val := md.Get("linearizable")
+	if len(val) == 0 {
+		return false
+	}
+	linearizable, err := strconv.ParseBool(val[0])
+	return err == nil && linearizable
+}
+
+// AttachUpsertByXid marks the mutations in the context as upserts by external ID. The blank
+// nodes of such mutations are external IDs: each one names the node having it as the value of
+// ExternalIdPred, and the node is created if there's none.
+func AttachUpsertByXid(ctx context.Context) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		md = metadata.New(nil)
+	}
+
+	md.Set("upsert-by-xid", "true")
+	return metadata.NewIncomingContext(ctx, md)
+}
+
+// IsUpsertByXid returns whether the mutations in the incoming gRPC context are upserts by external
+// ID, see AttachUpsertByXid.
+func IsUpsertByXid(ctx context.Context) bool {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false
+	}
+	val := md.Get("upsert-by-xid")
+	if len(val) == 0 {
+		return false
+	}
+	byXid, err := strconv.ParseBool(val[0])
+	return err == nil && byXid
+}
+
+// AttachPreconditions adds the precondition parameters of the incoming HTTP request, if any, into
+// the grpc context metadata.
+func AttachPreconditions(ctx context.Context, r *http.Request) context.Context {
+	preconditions := r.URL.Query()["precondition"]
+	if len(preconditions) == 0 {
+		return ctx
+	}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		md = metadata.New(nil)
+	}
+
+	md.Append("precondition", preconditions...)
+	return metadata.NewIncomingContext(ctx, md)
+}
+
+// ExtractPreconditions returns the preconditions of the mutations in the incoming gRPC context.
+// Each one is an N-Quad, like <0x1> <balance> "100" . The mutations are only applied if every
+// predicate of the preconditions currently has the given value on the given node.
+func ExtractPreconditions(ctx context.Context) []string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil
+	}
+	return md.Get("precondition")
+}
+
+// AttachIdempotencyKey adds the Idempotency-Key header of the incoming HTTP request, if any, into
+// the grpc context metadata.
+func AttachIdempotencyKey(ctx context.Context, r *http.Request) context.Context {
+	key := r.Header.Get("Idempotency-Key")
+	if key == "" {
+		return ctx
+	}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		md = metadata.New(nil)
+	}
+
+	md.Set("idempotency-key", key)
+	return metadata.NewIncomingContext(ctx, md)
+}
+
+// ExtractIdempotencyKey returns the idempotency key of the mutations in the incoming gRPC context,
+// if any. The mutations applied with an idempotency key aren't applied again when they're retried
+// with the same key.
+func ExtractIdempotencyKey(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if val := md.Get("idempotency-key"); len(val) > 0 {
+		return val[0]
+	}
+	return ""
+}
+
+// AttachExpandSample sets the expand sample of the query in the context. The edges of a node
+// having more uids than the expand sample are only expanded to a sample of that many uids.
+func AttachExpandSample(ctx context.Context, sample uint64) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		md = metadata.New(nil)
+	}
+
+	md.Set("expand-sample", strconv.FormatUint(sample, 10))
+	return metadata.NewIncomingContext(ctx, md)
+}
+
+// ExtractExpandSample returns the expand sample of the query in the incoming gRPC context, see
+// AttachExpandSample. It returns zero if the edges are expanded fully.
+func ExtractExpandSample(ctx context.Context) uint64 {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return 0
+	}
+	val := md.Get("expand-sample")
+	if len(val) == 0 {
+		return 0
+	}
+	sample, err := strconv.ParseUint(val[0], 0, 64)
+	if err != nil {
+		return 0
+	}
+	return sample
+}
+
+// AttachPreparedQuery adds the name and the hash of the prepared query given by the prepared and
+// preparedHash parameters of the incoming HTTP request, if any, into the grpc context metadata.
+func AttachPreparedQuery(ctx context.Context, r *http.Request) context.Context {
+	if name := r.URL.Query().Get("prepared"); name != "" {
+		md, ok := metadata.FromIncomingContext(ctx)
+		if !ok {
+			md = metadata.New(nil)
+		}
+
+		md.Append("prepared-query", name)
+		md.Append("prepared-hash", r.URL.Query().Get("preparedHash"))
+		ctx = metadata.NewIncomingContext(ctx, md)
+	}
+	return ctx
+}
+
+// ExtractPreparedQuery returns the name and the hash of the prepared query to run from the
+// incoming gRPC context. It returns an empty name if the request doesn't run a prepared query.
+func ExtractPreparedQuery(ctx context.Context) (string, string) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", ""
+	}
+	name, hash := md.Get("prepared-query"), md.Get("prepared-hash")
+	if len(name) == 0 {
+		return "", ""
+	}
+	if len(hash) == 0 {
+		return name[0], ""
+	}
+	return name[0], hash[0]
+}
+
 // AttachRemoteIP adds any incoming IP data into the grpc context metadata
 func AttachRemoteIP(ctx context.Context, r *http.Request) context.Context {
 	if ip, port, err := net.SplitHostPort(r.RemoteAddr); err == nil {
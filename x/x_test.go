@@ -17,11 +17,14 @@
 package x
 
 import (
+	"context"
 	"fmt"
 	"math"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
 )
 
 func TestSensitiveByteSlice(t *testing.T) {
@@ -197,3 +200,54 @@ func TestToHex(t *testing.T) {
 	require.Equal(t, []byte(`"0xffffffffffffffff"`), ToHex(math.MaxUint64, false))
 	require.Equal(t, []byte(`<0xffffffffffffffff>`), ToHex(math.MaxUint64, true))
 }
+
+func TestConsistencyToken(t *testing.T) {
+	r := httptest.NewRequest("POST", "/query?be=true&consistencyToken=42", nil)
+	token, err := ExtractConsistencyToken(AttachConsistencyToken(context.Background(), r))
+	require.NoError(t, err)
+	require.Equal(t, uint64(42), token)
+
+	token, err = ExtractConsistencyToken(context.Background())
+	require.NoError(t, err)
+	require.Zero(t, token)
+
+	r = httptest.NewRequest("POST", "/query?consistencyToken=abc", nil)
+	_, err = ExtractConsistencyToken(AttachConsistencyToken(context.Background(), r))
+	require.Error(t, err)
+}
+
+func TestLinearizable(t *testing.T) {
+	require.False(t, IsLinearizable(context.Background()))
+	require.True(t, IsLinearizable(AttachLinearizable(context.Background())))
+	// Attaching it twice keeps a single value.
+	require.True(t, IsLinearizable(AttachLinearizable(AttachLinearizable(context.Background()))))
+}
+
+func TestUpsertByXid(t *testing.T) {
+	require.False(t, IsUpsertByXid(context.Background()))
+	require.True(t, IsUpsertByXid(AttachUpsertByXid(context.Background())))
+	md := metadata.New(map[string]string{"upsert-by-xid": "false"})
+	require.False(t, IsUpsertByXid(metadata.NewIncomingContext(context.Background(), md)))
+}
+
+func TestExpandSample(t *testing.T) {
+	require.Zero(t, ExtractExpandSample(context.Background()))
+	ctx := AttachExpandSample(context.Background(), 100)
+	require.Equal(t, uint64(100), ExtractExpandSample(ctx))
+}
+
+func TestIdempotencyKey(t *testing.T) {
+	require.Empty(t, ExtractIdempotencyKey(context.Background()))
+	r := httptest.NewRequest("POST", "/mutate?commitNow=true", nil)
+	require.Empty(t, ExtractIdempotencyKey(AttachIdempotencyKey(context.Background(), r)))
+	r.Header.Set("Idempotency-Key", "order-42")
+	require.Equal(t, "order-42", ExtractIdempotencyKey(AttachIdempotencyKey(context.Background(), r)))
+}
+
+func TestPreconditions(t *testing.T) {
+	require.Empty(t, ExtractPreconditions(context.Background()))
+	r := httptest.NewRequest("POST", "/mutate?precondition=%3C0x1%3E+%3Cbalance%3E+%22100%22+."+
+		"&precondition=%3C0x1%3E+%3Cowner%3E+%3C0x2%3E+.", nil)
+	require.Equal(t, []string{`<0x1> <balance> "100" .`, `<0x1> <owner> <0x2> .`},
+		ExtractPreconditions(AttachPreconditions(context.Background(), r)))
+}
@@ -60,9 +60,12 @@ type XidMap struct {
 	maxUidSeen uint64
 
 	// Optionally, these can be set to persist the mappings.
-	writer *badger.WriteBatch
-	wg     sync.WaitGroup
+	db      *badger.DB
+	writer  *badger.WriteBatch
+	wg      sync.WaitGroup
+	pending sync.WaitGroup // The buffers sent to kvChan, yet to be written.
 
+	kvMu   sync.Mutex // Protects kvBuf.
 	kvBuf  []kv
 	kvChan chan []kv
 }
@@ -114,6 +117,7 @@ func New(opts XidMapOptions) *XidMap {
 
 	if opts.DB != nil {
 		// If DB is provided, let's load up all the xid -> uid mappings in memory.
+		xm.db = opts.DB
 		xm.writer = opts.DB.NewWriteBatch()
 
 		for i := 0; i < 16; i++ {
@@ -234,6 +238,7 @@ func (m *XidMap) dbWriter() {
 		for _, kv := range buf {
 			x.Panic(m.writer.Set(kv.key, kv.value))
 		}
+		m.pending.Done()
 	}
 }
 
@@ -263,12 +268,14 @@ func (m *XidMap) AssignUid(xid string) (uint64, bool) {
 	if m.writer != nil {
 		var uidBuf [8]byte
 		binary.BigEndian.PutUint64(uidBuf[:], newUid)
+		m.kvMu.Lock()
 		m.kvBuf = append(m.kvBuf, kv{key: []byte(xid), value: uidBuf[:]})
-
 		if len(m.kvBuf) == 64 {
+			m.pending.Add(1)
 			m.kvChan <- m.kvBuf
 			m.kvBuf = make([]kv, 0, 64)
 		}
+		m.kvMu.Unlock()
 	}
 
 	return newUid, true
@@ -348,6 +355,28 @@ func (m *XidMap) AllocateUid() uint64 {
 	return sh.assign(m.newRanges)
 }
 
+// Sync writes all the mappings assigned so far to the DB. The assignments block until it returns.
+// It is a no-op if no DB is provided to XidMap.
+func (m *XidMap) Sync() error {
+	if m.writer == nil {
+		return nil
+	}
+	m.kvMu.Lock()
+	defer m.kvMu.Unlock()
+	if len(m.kvBuf) > 0 {
+		m.pending.Add(1)
+		m.kvChan <- m.kvBuf
+		m.kvBuf = make([]kv, 0, 64)
+	}
+	// No buffer can be sent while kvMu is held, so all the writers are idle once this returns.
+	m.pending.Wait()
+	if err := m.writer.Flush(); err != nil {
+		return err
+	}
+	m.writer = m.db.NewWriteBatch()
+	return nil
+}
+
 // Flush must be called if DB is provided to XidMap.
 func (m *XidMap) Flush() error {
 	// While running bulk loader, this method is called at the completion of map phase. After this
@@ -368,6 +397,7 @@ func (m *XidMap) Flush() error {
 	}()
 
 	if len(m.kvBuf) > 0 {
+		m.pending.Add(1)
 		m.kvChan <- m.kvBuf
 	}
 	close(m.kvChan)